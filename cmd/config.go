@@ -0,0 +1,64 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/configvalidator"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/spf13/cobra"
+)
+
+// configCmd groups the configuration utilities
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration utilities",
+}
+
+// validateConfigCmd performs a dry-run load of the configuration
+var validateConfigCmd = &cobra.Command{
+	Use:          "validate",
+	Short:        "Validates the configuration without starting any services",
+	SilenceUsage: true,
+	Long: `Loads the full configuration and checks it for unknown keys, values of the wrong type,
+plugins missing required settings, and servers configured to listen on the same port.
+All of the errors found are reported at once, and no services are started.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		if err := reloadConfig(); err != nil {
+			return i18n.WrapError(ctx, err, i18n.MsgConfigFailed)
+		}
+		errs := configvalidator.Validate(ctx)
+		out := cmd.OutOrStdout()
+		for _, err := range errs {
+			fmt.Fprintln(out, err)
+		}
+		if len(errs) > 0 {
+			return i18n.NewError(ctx, coremsgs.MsgConfigValidationFailed, len(errs))
+		}
+		fmt.Fprintln(out, "Configuration is valid")
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(validateConfigCmd)
+	rootCmd.AddCommand(configCmd)
+}
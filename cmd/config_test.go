@@ -0,0 +1,65 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeTestConfig(t *testing.T, conf string) string {
+	file := filepath.Join(t.TempDir(), "firefly.core.yaml")
+	err := os.WriteFile(file, []byte(conf), 0600)
+	assert.NoError(t, err)
+	return file
+}
+
+func runConfigValidate(cfg string) error {
+	rootCmd.SetArgs([]string{"config", "validate", "-f", cfg})
+	defer func() {
+		rootCmd.SetArgs([]string{})
+		cfgFile = ""
+	}()
+	return rootCmd.Execute()
+}
+
+func TestConfigValidateOK(t *testing.T) {
+	file := writeTestConfig(t, `
+http:
+  port: 5000
+`)
+	err := runConfigValidate(file)
+	assert.NoError(t, err)
+}
+
+func TestConfigValidateErrors(t *testing.T) {
+	file := writeTestConfig(t, `
+http:
+  port: abc
+  bogus: true
+`)
+	err := runConfigValidate(file)
+	assert.Regexp(t, "FF10523.*2", err)
+}
+
+func TestConfigValidateMissingFile(t *testing.T) {
+	err := runConfigValidate(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Regexp(t, "FF00101", err)
+}
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE verifiers ADD COLUMN revoked BIGINT;
+COMMIT;
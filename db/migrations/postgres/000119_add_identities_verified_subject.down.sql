@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE identities DROP COLUMN verified_subject;
+COMMIT;
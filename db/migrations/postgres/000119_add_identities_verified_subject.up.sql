@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE identities ADD COLUMN verified_subject VARCHAR(1024) DEFAULT '';
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+ALTER TABLE contractapis ADD COLUMN version VARCHAR(64) NOT NULL DEFAULT '';
+DROP INDEX contractapis_namespace_name;
+DROP INDEX contractapis_networkname;
+CREATE UNIQUE INDEX contractapis_namespace_name ON contractapis(namespace,name,version);
+CREATE UNIQUE INDEX contractapis_networkname ON contractapis(namespace,network_name,version);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE contractapis ADD COLUMN query_cache_ttl VARCHAR(64);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE contractapis DROP COLUMN interface_hash;
+COMMIT;
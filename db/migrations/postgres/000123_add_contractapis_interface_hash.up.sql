@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE contractapis ADD COLUMN interface_hash CHAR(64);
+COMMIT;
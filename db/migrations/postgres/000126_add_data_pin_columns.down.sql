@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE data DROP COLUMN pin_status;
+ALTER TABLE data DROP COLUMN pin_request;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE data DROP COLUMN hash_algorithm;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE data ADD COLUMN hash_algorithm VARCHAR(64) DEFAULT '';
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+CREATE TABLE leaderleases (
+  seq               SERIAL          PRIMARY KEY,
+  namespace         VARCHAR(64)     NOT NULL,
+  holder            VARCHAR(256)    NOT NULL,
+  expires           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX leaderleases_namespace ON leaderleases(namespace);
+COMMIT;
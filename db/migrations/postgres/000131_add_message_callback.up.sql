@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE messages ADD COLUMN callback VARCHAR(1024);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE batches DROP COLUMN anchor;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE batches ADD COLUMN anchor TEXT;
+
+COMMIT;
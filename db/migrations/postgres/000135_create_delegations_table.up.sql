@@ -0,0 +1,16 @@
+BEGIN;
+CREATE TABLE delegations (
+  seq               SERIAL          PRIMARY KEY,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  delegator         VARCHAR(256)    NOT NULL,
+  delegate          VARCHAR(256)    NOT NULL,
+  scope             TEXT            NOT NULL,
+  expires           BIGINT,
+  message_id        UUID,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX delegations_id ON delegations(id);
+CREATE INDEX delegations_delegate ON delegations(namespace,delegator,delegate);
+COMMIT;
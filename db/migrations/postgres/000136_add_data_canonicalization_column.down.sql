@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE data DROP COLUMN canonicalization;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE data ADD COLUMN canonicalization VARCHAR(64) DEFAULT '';
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+CREATE TABLE inboundevents (
+  seq               SERIAL          PRIMARY KEY,
+  plugin            VARCHAR(64)     NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  payload           TEXT            NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE INDEX inboundevents_connection ON inboundevents(plugin,namespace,seq);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE datatypes DROP COLUMN migrate_from;
+ALTER TABLE data DROP COLUMN migration_from;
+ALTER TABLE data DROP COLUMN migration_datatype;
+ALTER TABLE data DROP COLUMN migration_time;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE datatypes ADD COLUMN migrate_from VARCHAR(64) DEFAULT '';
+ALTER TABLE data ADD COLUMN migration_from VARCHAR(64) DEFAULT '';
+ALTER TABLE data ADD COLUMN migration_datatype UUID;
+ALTER TABLE data ADD COLUMN migration_time BIGINT;
+COMMIT;
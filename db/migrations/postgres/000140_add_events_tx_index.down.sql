@@ -0,0 +1,3 @@
+BEGIN;
+DROP INDEX events_tx;
+COMMIT;
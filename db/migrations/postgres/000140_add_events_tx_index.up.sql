@@ -0,0 +1,3 @@
+BEGIN;
+CREATE INDEX events_tx ON events(namespace, tx_id);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE subscriptions DROP COLUMN owner;
+COMMIT;
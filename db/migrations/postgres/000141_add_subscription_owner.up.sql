@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE subscriptions ADD COLUMN owner VARCHAR(1024) DEFAULT '';
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE opqueue (
+  seq               SERIAL          PRIMARY KEY,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  optype            VARCHAR(64)     NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX opqueue_id ON opqueue(namespace,id);
+CREATE INDEX opqueue_created ON opqueue(namespace,created);
+COMMIT;
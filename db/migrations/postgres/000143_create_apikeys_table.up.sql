@@ -0,0 +1,15 @@
+BEGIN;
+CREATE TABLE apikeys (
+  seq               SERIAL          PRIMARY KEY,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  name              VARCHAR(64)     NOT NULL,
+  scope             VARCHAR(64)     NOT NULL,
+  hash              CHAR(64)        NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX apikeys_id ON apikeys(id);
+CREATE UNIQUE INDEX apikeys_name ON apikeys(namespace,name);
+CREATE UNIQUE INDEX apikeys_hash ON apikeys(hash);
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+DROP INDEX IF EXISTS operations_tags_tag;
+DROP INDEX IF EXISTS operations_tags_op;
+DROP TABLE IF EXISTS operations_tags;
+ALTER TABLE operations DROP COLUMN tags;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE verifiers DROP COLUMN revoked_pin;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE verifiers ADD COLUMN revoked_pin BIGINT DEFAULT 0;
+COMMIT;
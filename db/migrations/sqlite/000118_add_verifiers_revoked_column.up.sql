@@ -0,0 +1 @@
+ALTER TABLE verifiers ADD COLUMN revoked BIGINT;
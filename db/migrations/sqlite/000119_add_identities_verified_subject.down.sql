@@ -0,0 +1 @@
+ALTER TABLE identities DROP COLUMN verified_subject;
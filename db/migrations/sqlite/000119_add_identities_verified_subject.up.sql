@@ -0,0 +1 @@
+ALTER TABLE identities ADD COLUMN verified_subject VARCHAR(1024) DEFAULT '';
@@ -0,0 +1,5 @@
+DROP INDEX contractapis_namespace_name;
+DROP INDEX contractapis_networkname;
+ALTER TABLE contractapis DROP COLUMN version;
+CREATE UNIQUE INDEX contractapis_namespace_name ON contractapis(namespace,name);
+CREATE UNIQUE INDEX contractapis_networkname ON contractapis(namespace,network_name);
@@ -0,0 +1 @@
+ALTER TABLE contractapis DROP COLUMN query_cache_ttl;
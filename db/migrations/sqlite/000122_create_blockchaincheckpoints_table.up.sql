@@ -0,0 +1,13 @@
+CREATE TABLE blockchaincheckpoints (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace         VARCHAR(64)     NOT NULL,
+  ledger            VARCHAR(1024)   NOT NULL,
+  subscription      VARCHAR(1024)   NOT NULL,
+  block_number      BIGINT          NOT NULL,
+  tx_index          BIGINT          NOT NULL,
+  event_index       BIGINT          NOT NULL,
+  tx_id             VARCHAR(1024)   NOT NULL,
+  updated           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX blockchaincheckpoints_subscription ON blockchaincheckpoints(namespace,ledger,subscription);
@@ -0,0 +1 @@
+ALTER TABLE contractapis DROP COLUMN interface_hash;
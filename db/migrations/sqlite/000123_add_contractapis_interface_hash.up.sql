@@ -0,0 +1 @@
+ALTER TABLE contractapis ADD COLUMN interface_hash CHAR(64);
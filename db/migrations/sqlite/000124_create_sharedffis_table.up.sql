@@ -0,0 +1,13 @@
+CREATE TABLE sharedffis (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  interface_id      UUID            NOT NULL,
+  name              VARCHAR(1024)   NOT NULL,
+  version           VARCHAR(1024)   NOT NULL,
+  hash              CHAR(64)        NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX sharedffis_id ON sharedffis(id);
+CREATE UNIQUE INDEX sharedffis_name ON sharedffis(name,version);
@@ -0,0 +1,15 @@
+CREATE TABLE compensations (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  tx_id             UUID            NOT NULL,
+  operation_id      UUID            NOT NULL,
+  optype            VARCHAR(64)     NOT NULL,
+  status            VARCHAR(64)     NOT NULL,
+  output            TEXT,
+  error             TEXT,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX compensations_id ON compensations(id);
+CREATE INDEX compensations_tx ON compensations(namespace,tx_id);
@@ -0,0 +1,2 @@
+ALTER TABLE data DROP COLUMN pin_status;
+ALTER TABLE data DROP COLUMN pin_request;
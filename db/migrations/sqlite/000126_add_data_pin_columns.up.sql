@@ -0,0 +1,2 @@
+ALTER TABLE data ADD COLUMN pin_status VARCHAR(64) DEFAULT '';
+ALTER TABLE data ADD COLUMN pin_request VARCHAR(1024) DEFAULT '';
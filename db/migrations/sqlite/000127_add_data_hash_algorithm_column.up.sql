@@ -0,0 +1 @@
+ALTER TABLE data ADD COLUMN hash_algorithm VARCHAR(64) DEFAULT '';
@@ -0,0 +1,15 @@
+CREATE TABLE subscriptionslas (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace         VARCHAR(64)     NOT NULL,
+  subscription_id   UUID            NOT NULL,
+  day               VARCHAR(10)     NOT NULL,
+  target            BIGINT          NOT NULL,
+  delivered         BIGINT          NOT NULL,
+  within_target     BIGINT          NOT NULL,
+  latency_total     BIGINT          NOT NULL,
+  latency_min       BIGINT          NOT NULL,
+  latency_max       BIGINT          NOT NULL,
+  updated           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX subscriptionslas_day ON subscriptionslas(namespace,subscription_id,day);
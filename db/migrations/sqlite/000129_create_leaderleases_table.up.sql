@@ -0,0 +1,8 @@
+CREATE TABLE leaderleases (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace         VARCHAR(64)     NOT NULL,
+  holder            VARCHAR(256)    NOT NULL,
+  expires           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX leaderleases_namespace ON leaderleases(namespace);
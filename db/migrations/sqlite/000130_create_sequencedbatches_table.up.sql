@@ -0,0 +1,14 @@
+CREATE TABLE sequencedbatches (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  network_namespace VARCHAR(64)     NOT NULL,
+  tx_id             UUID            NOT NULL,
+  tx_type           VARCHAR(64)     NOT NULL,
+  batch_id          UUID            NOT NULL,
+  batch_hash        CHAR(64)        NOT NULL,
+  payload_ref       VARCHAR(1024),
+  contexts          TEXT            NOT NULL,
+  signer            VARCHAR(1024)   NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE INDEX sequencedbatches_network_namespace ON sequencedbatches(network_namespace,seq);
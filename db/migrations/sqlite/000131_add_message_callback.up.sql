@@ -0,0 +1 @@
+ALTER TABLE messages ADD COLUMN callback VARCHAR(1024);
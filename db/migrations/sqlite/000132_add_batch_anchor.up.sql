@@ -0,0 +1 @@
+ALTER TABLE batches ADD COLUMN anchor TEXT;
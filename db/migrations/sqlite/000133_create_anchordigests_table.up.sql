@@ -0,0 +1,16 @@
+CREATE TABLE anchordigests (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  root              CHAR(64)        NOT NULL,
+  plugin            VARCHAR(64)     NOT NULL,
+  first_pin         BIGINT          NOT NULL,
+  last_pin          BIGINT          NOT NULL,
+  batches           TEXT,
+  tx_id             UUID            NOT NULL,
+  blockchain_id     VARCHAR(1024),
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX anchordigests_id ON anchordigests(id);
+CREATE INDEX anchordigests_pins ON anchordigests(namespace,last_pin);
@@ -0,0 +1,12 @@
+CREATE TABLE addressbook (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  name              VARCHAR(64)     NOT NULL,
+  address           VARCHAR(1024)   NOT NULL,
+  created           BIGINT          NOT NULL,
+  updated           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX addressbook_id ON addressbook(id);
+CREATE UNIQUE INDEX addressbook_name ON addressbook(namespace,name);
@@ -0,0 +1 @@
+ALTER TABLE data ADD COLUMN canonicalization VARCHAR(64) DEFAULT '';
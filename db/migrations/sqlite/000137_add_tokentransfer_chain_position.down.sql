@@ -0,0 +1,4 @@
+DROP INDEX tokentransfer_chain_position;
+ALTER TABLE tokentransfer DROP COLUMN block_number;
+ALTER TABLE tokentransfer DROP COLUMN transaction_index;
+ALTER TABLE tokentransfer DROP COLUMN log_index;
@@ -0,0 +1,4 @@
+ALTER TABLE tokentransfer ADD COLUMN block_number BIGINT;
+ALTER TABLE tokentransfer ADD COLUMN transaction_index BIGINT;
+ALTER TABLE tokentransfer ADD COLUMN log_index BIGINT;
+CREATE INDEX tokentransfer_chain_position ON tokentransfer(namespace, pool_id, block_number, transaction_index, log_index);
@@ -0,0 +1,9 @@
+CREATE TABLE inboundevents (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  plugin            VARCHAR(64)     NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  payload           TEXT            NOT NULL,
+  created           BIGINT          NOT NULL
+);
+
+CREATE INDEX inboundevents_connection ON inboundevents(plugin,namespace,seq);
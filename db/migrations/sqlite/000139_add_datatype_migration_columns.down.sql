@@ -0,0 +1,4 @@
+ALTER TABLE datatypes DROP COLUMN migrate_from;
+ALTER TABLE data DROP COLUMN migration_from;
+ALTER TABLE data DROP COLUMN migration_datatype;
+ALTER TABLE data DROP COLUMN migration_time;
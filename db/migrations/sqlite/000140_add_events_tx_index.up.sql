@@ -0,0 +1 @@
+CREATE INDEX events_tx ON events(namespace, tx_id);
@@ -0,0 +1 @@
+ALTER TABLE subscriptions ADD COLUMN owner VARCHAR(1024) DEFAULT '';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS operations_tags_tag;
+DROP INDEX IF EXISTS operations_tags_op;
+DROP TABLE IF EXISTS operations_tags;
+ALTER TABLE operations DROP COLUMN tags;
@@ -0,0 +1,11 @@
+ALTER TABLE operations ADD COLUMN tags TEXT DEFAULT '';
+
+CREATE TABLE operations_tags (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  namespace         VARCHAR(64)     NOT NULL,
+  operation_id      UUID            NOT NULL,
+  tag               VARCHAR(1024)   NOT NULL
+);
+
+CREATE INDEX operations_tags_tag ON operations_tags(namespace,tag);
+CREATE UNIQUE INDEX operations_tags_op ON operations_tags(operation_id,tag);
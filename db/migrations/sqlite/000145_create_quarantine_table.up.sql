@@ -0,0 +1,16 @@
+CREATE TABLE quarantine (
+  seq               INTEGER         PRIMARY KEY AUTOINCREMENT,
+  id                UUID            NOT NULL,
+  namespace         VARCHAR(64)     NOT NULL,
+  reason            VARCHAR(64)     NOT NULL,
+  detail            TEXT,
+  batch_id          UUID,
+  message_id        UUID,
+  data_id           UUID,
+  payload           TEXT,
+  created           BIGINT          NOT NULL
+);
+
+CREATE UNIQUE INDEX quarantine_id ON quarantine(id);
+CREATE INDEX quarantine_batch ON quarantine(namespace,batch_id);
+CREATE INDEX quarantine_message ON quarantine(namespace,message_id);
@@ -0,0 +1 @@
+ALTER TABLE verifiers DROP COLUMN revoked_pin;
@@ -0,0 +1 @@
+ALTER TABLE verifiers ADD COLUMN revoked_pin BIGINT DEFAULT 0;
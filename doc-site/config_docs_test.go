@@ -53,4 +53,8 @@ func TestConfigDocsUpToDate(t *testing.T) {
 const configDocHeader = `---
 title: Configuration Reference
 ---
+
+A configuration file can be checked against this reference without starting any services by running
+` + "`firefly config validate -f <file>`" + `, which reports every unknown key, invalid value, missing
+plugin setting and port clash that it finds.
 `
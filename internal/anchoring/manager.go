@@ -0,0 +1,265 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchoring
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Manager periodically submits a digest of the batches pinned in a namespace to its anchor chain,
+// and provides the proofs that each batch is included in a digest
+type Manager interface {
+	core.Named
+
+	// Start begins submitting a digest on each interval
+	Start() error
+
+	// WaitStop stops submitting digests
+	WaitStop()
+
+	// GetBatchAnchorProof returns the Merkle inclusion proof of a batch, in the digest that covers its pin
+	GetBatchAnchorProof(ctx context.Context, batchID string) (*core.BatchAnchorProof, error)
+
+	// LookupBatchAnchorProof returns the Merkle inclusion proof of a batch, or nil if it is not yet in a digest
+	LookupBatchAnchorProof(ctx context.Context, batchID *fftypes.UUID) (*core.BatchAnchorProof, error)
+
+	// From operations.OperationHandler
+	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
+	RunOperation(ctx context.Context, op *core.PreparedOperation) (outputs fftypes.JSONObject, phase core.OpPhase, err error)
+}
+
+type anchorManager struct {
+	ctx        context.Context
+	cancelCtx  context.CancelFunc
+	namespace  *core.Namespace
+	config     multiparty.Anchor
+	database   database.Plugin
+	anchor     blockchain.Plugin
+	operations operations.Manager
+	txHelper   txcommon.Helper
+	loopDone   chan struct{}
+}
+
+func NewAnchorManager(ctx context.Context, ns *core.Namespace, config multiparty.Anchor, di database.Plugin, ai blockchain.Plugin, om operations.Manager, th txcommon.Helper) (Manager, error) {
+	if di == nil || ai == nil || om == nil || th == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "AnchorManager")
+	}
+	am := &anchorManager{
+		namespace:  ns,
+		config:     config,
+		database:   di,
+		anchor:     ai,
+		operations: om,
+		txHelper:   th,
+	}
+	am.ctx, am.cancelCtx = context.WithCancel(ctx)
+	om.RegisterHandler(ctx, am, []core.OpType{
+		core.OpTypeBlockchainAnchorDigest,
+	})
+	return am, nil
+}
+
+func (am *anchorManager) Name() string {
+	return "AnchorManager"
+}
+
+func (am *anchorManager) Start() error {
+	am.loopDone = make(chan struct{})
+	go am.digestLoop()
+	return nil
+}
+
+func (am *anchorManager) WaitStop() {
+	am.cancelCtx()
+	if am.loopDone != nil {
+		<-am.loopDone
+	}
+}
+
+func (am *anchorManager) digestLoop() {
+	defer close(am.loopDone)
+	ticker := time.NewTicker(am.config.DigestInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := am.submitDigest(am.ctx); err != nil {
+				// The pins are picked up again on the next interval
+				log.L(am.ctx).Errorf("Failed to submit anchor digest: %s", err)
+			}
+		case <-am.ctx.Done():
+			log.L(am.ctx).Debugf("Anchor digest loop exiting")
+			return
+		}
+	}
+}
+
+// lastAnchoredPin returns the sequence of the last pin covered by a digest, or zero if there are none yet
+func (am *anchorManager) lastAnchoredPin(ctx context.Context) (int64, error) {
+	fb := database.AnchorDigestQueryFactory.NewFilter(ctx)
+	digests, _, err := am.database.GetAnchorDigests(ctx, am.namespace.Name, fb.And().Sort("lastpin").Descending().Limit(1))
+	if err != nil || len(digests) == 0 {
+		return 0, err
+	}
+	return digests[0].LastPin, nil
+}
+
+// buildDigest collects the batches pinned since the last digest, in the order of their first pin
+func (am *anchorManager) buildDigest(ctx context.Context) (*core.AnchorDigest, error) {
+	lastPin, err := am.lastAnchoredPin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := am.database.GetPins(ctx, am.namespace.Name, fb.And(fb.Gt("sequence", lastPin)).Sort("sequence"))
+	if err != nil || len(pins) == 0 {
+		return nil, err
+	}
+
+	digest := &core.AnchorDigest{
+		ID:        fftypes.NewUUID(),
+		Namespace: am.namespace.Name,
+		Plugin:    am.anchor.Name(),
+		FirstPin:  pins[0].Sequence,
+		LastPin:   pins[len(pins)-1].Sequence,
+		Batches:   core.AnchorDigestBatches{},
+	}
+	leaves := []*fftypes.Bytes32{}
+	included := make(map[fftypes.UUID]bool)
+	for _, pin := range pins {
+		// Each message context in a batch has its own pin, but the batch is only a single leaf
+		if pin.Batch == nil || pin.BatchHash == nil || included[*pin.Batch] {
+			continue
+		}
+		included[*pin.Batch] = true
+		digest.Batches = append(digest.Batches, &core.AnchorDigestBatch{ID: pin.Batch, Hash: pin.BatchHash})
+		leaves = append(leaves, pin.BatchHash)
+	}
+	if len(leaves) == 0 {
+		return nil, nil
+	}
+	digest.Root = merkleRoot(leaves)
+	return digest, nil
+}
+
+// submitDigest records a digest of the batches pinned since the last one, and submits its root to the anchor chain
+func (am *anchorManager) submitDigest(ctx context.Context) error {
+	digest, err := am.buildDigest(ctx)
+	if err != nil || digest == nil {
+		return err
+	}
+
+	var op *core.Operation
+	err = am.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		txid, err := am.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeAnchorDigest, "")
+		if err != nil {
+			return err
+		}
+		digest.Transaction = txid
+		digest.Created = fftypes.Now()
+		if err := am.database.InsertAnchorDigest(ctx, digest); err != nil {
+			return err
+		}
+		op = core.NewOperation(
+			am.anchor,
+			am.namespace.Name,
+			txid,
+			core.OpTypeBlockchainAnchorDigest)
+		addAnchorDigestInputs(op, digest.ID)
+		return am.operations.AddOrReuseOperation(ctx, op)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.L(ctx).Infof("Submitting anchor digest %s of %d batches (pins %d-%d) with root %s", digest.ID, len(digest.Batches), digest.FirstPin, digest.LastPin, digest.Root)
+	_, err = am.operations.RunOperation(ctx, opAnchorDigest(op, digest), false)
+	return err
+}
+
+func (am *anchorManager) GetBatchAnchorProof(ctx context.Context, batchID string) (*core.BatchAnchorProof, error) {
+	id, err := fftypes.ParseUUID(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := am.LookupBatchAnchorProof(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if proof == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchNotAnchored, id)
+	}
+	return proof, nil
+}
+
+func (am *anchorManager) LookupBatchAnchorProof(ctx context.Context, id *fftypes.UUID) (*core.BatchAnchorProof, error) {
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := am.database.GetPins(ctx, am.namespace.Name, fb.And(fb.Eq("batch", id)).Sort("sequence").Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return nil, nil
+	}
+
+	dfb := database.AnchorDigestQueryFactory.NewFilter(ctx)
+	digests, _, err := am.database.GetAnchorDigests(ctx, am.namespace.Name, dfb.And(
+		dfb.Lte("firstpin", pins[0].Sequence),
+		dfb.Gte("lastpin", pins[0].Sequence),
+	).Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(digests) == 0 {
+		return nil, nil
+	}
+	digest := digests[0]
+
+	index := -1
+	leaves := make([]*fftypes.Bytes32, len(digest.Batches))
+	for i, batch := range digest.Batches {
+		leaves[i] = batch.Hash
+		if batch.ID.Equals(id) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, nil
+	}
+
+	return &core.BatchAnchorProof{
+		Batch:        id,
+		BatchHash:    leaves[index],
+		Digest:       digest.ID,
+		Root:         digest.Root,
+		Plugin:       digest.Plugin,
+		BlockchainID: digest.BlockchainID,
+		Proof:        merkleProof(leaves, index),
+	}, nil
+}
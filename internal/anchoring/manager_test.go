@@ -0,0 +1,352 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchoring
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/mocks/txcommonmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testAnchorManager struct {
+	*anchorManager
+	mdi *databasemocks.Plugin
+	mai *blockchainmocks.Plugin
+	mom *operationmocks.Manager
+	mth *txcommonmocks.Helper
+}
+
+func (am *testAnchorManager) cleanup(t *testing.T) {
+	am.cancelCtx()
+	am.mdi.AssertExpectations(t)
+	am.mai.AssertExpectations(t)
+	am.mom.AssertExpectations(t)
+	am.mth.AssertExpectations(t)
+}
+
+func newTestAnchorManager(t *testing.T) *testAnchorManager {
+	mdi := &databasemocks.Plugin{}
+	mai := &blockchainmocks.Plugin{}
+	mom := &operationmocks.Manager{}
+	mth := &txcommonmocks.Helper{}
+	mom.On("RegisterHandler", mock.Anything, mock.Anything, []core.OpType{
+		core.OpTypeBlockchainAnchorDigest,
+	}).Return()
+	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything).Maybe()
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	config := multiparty.Anchor{
+		Key:            "0x12345",
+		Location:       fftypes.JSONAnyPtr(`{"address":"0x67890"}`),
+		Mode:           multiparty.AnchorModeDigest,
+		DigestInterval: 1 * time.Minute,
+	}
+	am, err := NewAnchorManager(context.Background(), ns, config, mdi, mai, mom, mth)
+	assert.NoError(t, err)
+	return &testAnchorManager{
+		anchorManager: am.(*anchorManager),
+		mdi:           mdi,
+		mai:           mai,
+		mom:           mom,
+		mth:           mth,
+	}
+}
+
+func testPin(seq int64, batch *fftypes.UUID, hash *fftypes.Bytes32) *core.Pin {
+	return &core.Pin{
+		Sequence:  seq,
+		Namespace: "ns1",
+		Batch:     batch,
+		BatchHash: hash,
+		Hash:      fftypes.NewRandB32(),
+	}
+}
+
+func TestNewAnchorManagerMissingDeps(t *testing.T) {
+	_, err := NewAnchorManager(context.Background(), &core.Namespace{}, multiparty.Anchor{}, nil, nil, nil, nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestName(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+	assert.Equal(t, "AnchorManager", am.Name())
+}
+
+func TestStartWaitStop(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+	am.config.DigestInterval = 1 * time.Millisecond
+
+	submitted := make(chan struct{})
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case <-submitted:
+		default:
+			close(submitted)
+		}
+	})
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	err := am.Start()
+	assert.NoError(t, err)
+	<-submitted
+	am.WaitStop()
+}
+
+func TestWaitStopNotStarted(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+	am.WaitStop()
+}
+
+func TestSubmitDigestOK(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	batch1 := fftypes.NewUUID()
+	batch2 := fftypes.NewUUID()
+	hash1 := fftypes.NewRandB32()
+	hash2 := fftypes.NewRandB32()
+	txid := fftypes.NewUUID()
+
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		info, _ := f.Finalize()
+		return info.String() == " sort=-lastpin limit=1"
+	})).Return([]*core.AnchorDigest{{LastPin: 10}}, nil, nil)
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		info, _ := f.Finalize()
+		return info.String() == "( sequence >> 10 ) sort=sequence"
+	})).Return([]*core.Pin{
+		testPin(11, batch1, hash1),
+		testPin(12, batch1, hash1),
+		testPin(13, nil, nil),
+		testPin(14, batch2, hash2),
+	}, nil, nil)
+	am.mai.On("Name").Return("ethereum")
+	am.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeAnchorDigest, core.IdempotencyKey("")).Return(txid, nil)
+	am.mdi.On("InsertAnchorDigest", mock.Anything, mock.MatchedBy(func(digest *core.AnchorDigest) bool {
+		return digest.FirstPin == 11 && digest.LastPin == 14 &&
+			len(digest.Batches) == 2 &&
+			digest.Batches[0].ID.Equals(batch1) &&
+			digest.Batches[1].ID.Equals(batch2) &&
+			digest.Root.Equals(merkleParent(hash1, hash2)) &&
+			digest.Transaction.Equals(txid) &&
+			digest.Plugin == "ethereum"
+	})).Return(nil)
+	am.mom.On("AddOrReuseOperation", mock.Anything, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainAnchorDigest && op.Transaction.Equals(txid)
+	})).Return(nil)
+	am.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(anchorDigestData)
+		return op.Type == core.OpTypeBlockchainAnchorDigest && data.Digest.Transaction.Equals(txid)
+	}), false).Return(nil, nil)
+
+	err := am.submitDigest(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestSubmitDigestFirst(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil)
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		info, _ := f.Finalize()
+		return info.String() == "( sequence >> 0 ) sort=sequence"
+	})).Return([]*core.Pin{}, nil, nil)
+
+	err := am.submitDigest(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestSubmitDigestNoBatches(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil)
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		testPin(1, nil, nil),
+	}, nil, nil)
+	am.mai.On("Name").Return("ethereum")
+
+	err := am.submitDigest(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestSubmitDigestGetPinsFail(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil)
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := am.submitDigest(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSubmitDigestTransactionFail(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil)
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		testPin(1, fftypes.NewUUID(), fftypes.NewRandB32()),
+	}, nil, nil)
+	am.mai.On("Name").Return("ethereum")
+	am.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeAnchorDigest, core.IdempotencyKey("")).Return(nil, fmt.Errorf("pop"))
+
+	err := am.submitDigest(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestSubmitDigestInsertFail(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil)
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{
+		testPin(1, fftypes.NewUUID(), fftypes.NewRandB32()),
+	}, nil, nil)
+	am.mai.On("Name").Return("ethereum")
+	am.mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeAnchorDigest, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	am.mdi.On("InsertAnchorDigest", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := am.submitDigest(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetBatchAnchorProofOK(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	batches := core.AnchorDigestBatches{}
+	leaves := []*fftypes.Bytes32{}
+	for i := 0; i < 3; i++ {
+		hash := fftypes.NewRandB32()
+		batches = append(batches, &core.AnchorDigestBatch{ID: fftypes.NewUUID(), Hash: hash})
+		leaves = append(leaves, hash)
+	}
+	digest := &core.AnchorDigest{
+		ID:           fftypes.NewUUID(),
+		Root:         merkleRoot(leaves),
+		Plugin:       "ethereum",
+		FirstPin:     5,
+		LastPin:      9,
+		Batches:      batches,
+		BlockchainID: "0xabcd",
+	}
+	batchID := batches[1].ID
+
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		info, _ := f.Finalize()
+		return info.String() == fmt.Sprintf("( batch == '%s' ) sort=sequence limit=1", batchID)
+	})).Return([]*core.Pin{testPin(7, batchID, batches[1].Hash)}, nil, nil)
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		info, _ := f.Finalize()
+		return info.String() == "( firstpin <= 7 ) && ( lastpin >= 7 ) limit=1"
+	})).Return([]*core.AnchorDigest{digest}, nil, nil)
+
+	proof, err := am.GetBatchAnchorProof(context.Background(), batchID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, batchID, proof.Batch)
+	assert.Equal(t, digest.ID, proof.Digest)
+	assert.Equal(t, "0xabcd", proof.BlockchainID)
+	assert.Equal(t, proof.Root, verifyMerkleProof(proof.BatchHash, proof.Proof))
+}
+
+func TestGetBatchAnchorProofBadID(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	_, err := am.GetBatchAnchorProof(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetBatchAnchorProofGetPinsFail(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.GetBatchAnchorProof(context.Background(), fftypes.NewUUID().String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetBatchAnchorProofNoPin(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	_, err := am.GetBatchAnchorProof(context.Background(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10566", err)
+}
+
+func TestGetBatchAnchorProofGetDigestsFail(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{testPin(7, batchID, fftypes.NewRandB32())}, nil, nil)
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.GetBatchAnchorProof(context.Background(), batchID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetBatchAnchorProofNotYetAnchored(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{testPin(7, batchID, fftypes.NewRandB32())}, nil, nil)
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{}, nil, nil)
+
+	_, err := am.GetBatchAnchorProof(context.Background(), batchID.String())
+	assert.Regexp(t, "FF10566", err)
+}
+
+func TestGetBatchAnchorProofNotInDigest(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	am.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{testPin(7, batchID, fftypes.NewRandB32())}, nil, nil)
+	am.mdi.On("GetAnchorDigests", mock.Anything, "ns1", mock.Anything).Return([]*core.AnchorDigest{{
+		ID:      fftypes.NewUUID(),
+		Batches: core.AnchorDigestBatches{{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()}},
+	}}, nil, nil)
+
+	_, err := am.GetBatchAnchorProof(context.Background(), batchID.String())
+	assert.Regexp(t, "FF10566", err)
+}
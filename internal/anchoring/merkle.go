@@ -0,0 +1,72 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchoring
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// merkleParent is the hash of two adjacent nodes of the tree
+func merkleParent(left, right *fftypes.Bytes32) *fftypes.Bytes32 {
+	h := sha256.New()
+	h.Write(left[:])
+	h.Write(right[:])
+	var parent fftypes.Bytes32
+	copy(parent[:], h.Sum(nil))
+	return &parent
+}
+
+// merkleLevel builds the next level up the tree. A node without a sibling is promoted unchanged.
+func merkleLevel(level []*fftypes.Bytes32) []*fftypes.Bytes32 {
+	next := make([]*fftypes.Bytes32, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 < len(level) {
+			next = append(next, merkleParent(level[i], level[i+1]))
+		} else {
+			next = append(next, level[i])
+		}
+	}
+	return next
+}
+
+// merkleRoot returns the root of the tree over the given leaves, which must not be empty
+func merkleRoot(leaves []*fftypes.Bytes32) *fftypes.Bytes32 {
+	level := leaves
+	for len(level) > 1 {
+		level = merkleLevel(level)
+	}
+	return level[0]
+}
+
+// merkleProof returns the sibling hashes on the path from the leaf at the given index up to the root
+func merkleProof(leaves []*fftypes.Bytes32, index int) []*core.MerkleProofStep {
+	proof := []*core.MerkleProofStep{}
+	level := leaves
+	for len(level) > 1 {
+		if index%2 == 1 {
+			proof = append(proof, &core.MerkleProofStep{Hash: level[index-1], Position: core.MerkleSiblingLeft})
+		} else if index+1 < len(level) {
+			proof = append(proof, &core.MerkleProofStep{Hash: level[index+1], Position: core.MerkleSiblingRight})
+		}
+		level = merkleLevel(level)
+		index /= 2
+	}
+	return proof
+}
@@ -0,0 +1,70 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchoring
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// verifyMerkleProof is how a third party checks a proof against the root on the anchor chain
+func verifyMerkleProof(leaf *fftypes.Bytes32, proof []*core.MerkleProofStep) *fftypes.Bytes32 {
+	current := leaf
+	for _, step := range proof {
+		if step.Position == core.MerkleSiblingLeft {
+			current = merkleParent(step.Hash, current)
+		} else {
+			current = merkleParent(current, step.Hash)
+		}
+	}
+	return current
+}
+
+func TestMerkleSingleLeaf(t *testing.T) {
+	leaf := fftypes.NewRandB32()
+	assert.Equal(t, leaf, merkleRoot([]*fftypes.Bytes32{leaf}))
+	assert.Empty(t, merkleProof([]*fftypes.Bytes32{leaf}, 0))
+}
+
+func TestMerkleTwoLeaves(t *testing.T) {
+	leaves := []*fftypes.Bytes32{fftypes.NewRandB32(), fftypes.NewRandB32()}
+	root := merkleRoot(leaves)
+	assert.Equal(t, merkleParent(leaves[0], leaves[1]), root)
+
+	proof := merkleProof(leaves, 1)
+	assert.Len(t, proof, 1)
+	assert.Equal(t, leaves[0], proof[0].Hash)
+	assert.Equal(t, core.MerkleSiblingLeft, proof[0].Position)
+}
+
+func TestMerkleProofsAllSizes(t *testing.T) {
+	for size := 1; size <= 17; size++ {
+		leaves := make([]*fftypes.Bytes32, size)
+		for i := range leaves {
+			leaves[i] = fftypes.NewRandB32()
+		}
+		root := merkleRoot(leaves)
+		for i, leaf := range leaves {
+			assert.Equal(t, root, verifyMerkleProof(leaf, merkleProof(leaves, i)), "size=%d index=%d", size, i)
+		}
+		// A proof does not verify a different leaf
+		assert.NotEqual(t, root, verifyMerkleProof(fftypes.NewRandB32(), merkleProof(leaves, 0)))
+	}
+}
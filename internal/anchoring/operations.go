@@ -0,0 +1,103 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchoring
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type anchorDigestData struct {
+	Digest *core.AnchorDigest `json:"digest"`
+}
+
+func addAnchorDigestInputs(op *core.Operation, digestID *fftypes.UUID) {
+	op.Input = fftypes.JSONObject{
+		"digest": digestID.String(),
+	}
+}
+
+func retrieveAnchorDigestInputs(ctx context.Context, op *core.Operation) (digestID *fftypes.UUID, err error) {
+	return fftypes.ParseUUID(ctx, op.Input.GetString("digest"))
+}
+
+func (am *anchorManager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
+	switch op.Type {
+	case core.OpTypeBlockchainAnchorDigest:
+		digestID, err := retrieveAnchorDigestInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := am.database.GetAnchorDigestByID(ctx, am.namespace.Name, digestID)
+		if err != nil {
+			return nil, err
+		} else if digest == nil {
+			return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+		}
+		return opAnchorDigest(op, digest), nil
+
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
+	}
+}
+
+func (am *anchorManager) RunOperation(ctx context.Context, op *core.PreparedOperation) (outputs fftypes.JSONObject, phase core.OpPhase, err error) {
+	switch data := op.Data.(type) {
+	case anchorDigestData:
+		digest := data.Digest
+		// The digest is pinned in the place of a batch, with no message contexts
+		err = am.anchor.SubmitBatchPin(ctx, op.NamespacedIDString(), am.namespace.NetworkName, am.config.Key, &blockchain.BatchPin{
+			TransactionID: digest.Transaction,
+			BatchID:       digest.ID,
+			BatchHash:     digest.Root,
+			Contexts:      []*fftypes.Bytes32{},
+		}, am.config.Location)
+		return nil, operations.ErrTernary(err, core.OpPhaseInitializing, core.OpPhasePending), err
+
+	default:
+		return nil, core.OpPhaseInitializing, i18n.NewError(ctx, coremsgs.MsgOperationDataIncorrect, op.Data)
+	}
+}
+
+func (am *anchorManager) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
+	if op.Type != core.OpTypeBlockchainAnchorDigest || update.Status != core.OpStatusSucceeded || update.BlockchainTXID == "" {
+		return nil
+	}
+	digestID, err := retrieveAnchorDigestInputs(ctx, op)
+	if err != nil {
+		return err
+	}
+	return am.database.UpdateAnchorDigest(ctx, am.namespace.Name, digestID,
+		database.AnchorDigestQueryFactory.NewUpdate(ctx).Set("blockchainid", update.BlockchainTXID))
+}
+
+func opAnchorDigest(op *core.Operation, digest *core.AnchorDigest) *core.PreparedOperation {
+	return &core.PreparedOperation{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Plugin:    op.Plugin,
+		Type:      op.Type,
+		Data:      anchorDigestData{Digest: digest},
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchoring
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPrepareAndRunAnchorDigest(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	digest := &core.AnchorDigest{
+		ID:          fftypes.NewUUID(),
+		Root:        fftypes.NewRandB32(),
+		Transaction: fftypes.NewUUID(),
+	}
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Type:      core.OpTypeBlockchainAnchorDigest,
+	}
+	addAnchorDigestInputs(op, digest.ID)
+
+	am.mdi.On("GetAnchorDigestByID", context.Background(), "ns1", digest.ID).Return(digest, nil)
+	am.mai.On("SubmitBatchPin", context.Background(), "ns1:"+op.ID.String(), "ns1", "0x12345", mock.MatchedBy(func(pin *blockchain.BatchPin) bool {
+		return pin.BatchID.Equals(digest.ID) &&
+			pin.BatchHash.Equals(digest.Root) &&
+			pin.TransactionID.Equals(digest.Transaction) &&
+			len(pin.Contexts) == 0
+	}), am.config.Location).Return(nil)
+
+	po, err := am.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, digest, po.Data.(anchorDigestData).Digest)
+
+	_, phase, err := am.RunOperation(context.Background(), po)
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhasePending, phase)
+}
+
+func TestPrepareOperationNotSupported(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	_, err := am.PrepareOperation(context.Background(), &core.Operation{Type: core.OpTypeBlockchainPinBatch})
+	assert.Regexp(t, "FF10371", err)
+}
+
+func TestPrepareOperationAnchorDigestBadInput(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	op := &core.Operation{
+		Type:  core.OpTypeBlockchainAnchorDigest,
+		Input: fftypes.JSONObject{"digest": "bad"},
+	}
+	_, err := am.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestPrepareOperationAnchorDigestError(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeBlockchainAnchorDigest}
+	digestID := fftypes.NewUUID()
+	addAnchorDigestInputs(op, digestID)
+
+	am.mdi.On("GetAnchorDigestByID", context.Background(), "ns1", digestID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.PrepareOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPrepareOperationAnchorDigestNotFound(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeBlockchainAnchorDigest}
+	digestID := fftypes.NewUUID()
+	addAnchorDigestInputs(op, digestID)
+
+	am.mdi.On("GetAnchorDigestByID", context.Background(), "ns1", digestID).Return(nil, nil)
+
+	_, err := am.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestRunOperationNotSupported(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	_, phase, err := am.RunOperation(context.Background(), &core.PreparedOperation{})
+	assert.Regexp(t, "FF10378", err)
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+}
+
+func TestOperationUpdate(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeBlockchainAnchorDigest}
+	digestID := fftypes.NewUUID()
+	addAnchorDigestInputs(op, digestID)
+
+	am.mdi.On("UpdateAnchorDigest", context.Background(), "ns1", digestID, mock.MatchedBy(func(u ffapi.Update) bool {
+		info, _ := u.Finalize()
+		return info.String() == "blockchainid='0xabcd'"
+	})).Return(nil)
+
+	err := am.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status:         core.OpStatusSucceeded,
+		BlockchainTXID: "0xabcd",
+	})
+	assert.NoError(t, err)
+}
+
+func TestOperationUpdateIgnored(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeBlockchainAnchorDigest}
+	err := am.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status: core.OpStatusFailed,
+	})
+	assert.NoError(t, err)
+}
+
+func TestOperationUpdateBadInput(t *testing.T) {
+	am := newTestAnchorManager(t)
+	defer am.cleanup(t)
+
+	op := &core.Operation{
+		Type:  core.OpTypeBlockchainAnchorDigest,
+		Input: fftypes.JSONObject{"digest": "bad"},
+	}
+	err := am.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status:         core.OpStatusSucceeded,
+		BlockchainTXID: "0xabcd",
+	})
+	assert.Regexp(t, "FF00138", err)
+}
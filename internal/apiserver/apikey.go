@@ -0,0 +1,81 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func routeAPIKeyScope(method string, ce *coreExtensions) core.APIKeyScope {
+	switch {
+	case ce.APIKeyScope != "":
+		return ce.APIKeyScope
+	case method == http.MethodGet:
+		return core.APIKeyScopeReadOnly
+	default:
+		return core.APIKeyScopeAdmin
+	}
+}
+
+// isBootstrapAPIKey checks the key against the hash of the bootstrap key from the configuration, if there is one
+func (as *apiServer) isBootstrapAPIKey(keyString string) bool {
+	if as.apiKeyBootstrapHash == "" {
+		return false
+	}
+	hash := sha256.Sum256([]byte(keyString))
+	return subtle.ConstantTimeCompare([]byte(hex.EncodeToString(hash[:])), []byte(as.apiKeyBootstrapHash)) == 1
+}
+
+// checkAPIKey enforces the scope of the API key supplied on a request to a namespaced route. Requests that
+// do not supply a key are only rejected if keys are required, and the SPI does not use API keys at all.
+// The bootstrap key from the configuration has admin scope, so the first keys can be created when keys are required.
+func (as *apiServer) checkAPIKey(r *ffapi.APIRequest, ce *coreExtensions, or orchestrator.Orchestrator, spi bool) error {
+	if or == nil || spi {
+		return nil
+	}
+	ctx := r.Req.Context()
+	keyString := r.Req.Header.Get(as.apiKeyHeader)
+	if keyString == "" {
+		if as.apiKeysRequired {
+			return i18n.NewError(ctx, coremsgs.MsgAPIKeyMissing, as.apiKeyHeader)
+		}
+		return nil
+	}
+	if as.isBootstrapAPIKey(keyString) {
+		return nil
+	}
+	key, err := or.Identity().ResolveAPIKey(ctx, keyString)
+	if err != nil {
+		return err
+	}
+	if key == nil {
+		return i18n.NewError(ctx, coremsgs.MsgAPIKeyInvalid)
+	}
+	if required := routeAPIKeyScope(r.Req.Method, ce); !key.Allows(required) {
+		return i18n.NewError(ctx, coremsgs.MsgAPIKeyScopeDenied, key.Name, required)
+	}
+	return nil
+}
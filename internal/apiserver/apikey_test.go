@@ -0,0 +1,180 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/mocks/networkmapmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAPIServerAPIKeys(required bool) (*identitymanagermocks.Manager, *networkmapmocks.Manager, *mux.Router) {
+	mgr, o, as := newTestServer()
+	as.apiKeysRequired = required
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mim := &identitymanagermocks.Manager{}
+	o.On("Identity").Return(mim)
+	mnm := &networkmapmocks.Manager{}
+	o.On("NetworkMap").Return(mnm)
+	o.On("MultiParty").Return(&multipartymocks.Manager{})
+	return mim, mnm, as.createMuxRouter(context.Background(), mgr)
+}
+
+func TestAPIKeyNotSupplied(t *testing.T) {
+	_, mnm, r := newTestAPIServerAPIKeys(false)
+	mnm.On("GetOrganizations", mock.Anything, mock.Anything).Return([]*core.Identity{}, nil, nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/network/organizations", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+}
+
+func TestAPIKeyRequired(t *testing.T) {
+	_, _, r := newTestAPIServerAPIKeys(true)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/network/organizations", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 401, res.Result().StatusCode)
+	assert.Regexp(t, "FF10603", res.Body.String())
+}
+
+func TestAPIKeyReadOnlyAllowsGet(t *testing.T) {
+	mim, mnm, r := newTestAPIServerAPIKeys(true)
+	mim.On("ResolveAPIKey", mock.Anything, "secret").Return(&core.APIKey{Name: "partner1", Scope: core.APIKeyScopeReadOnly}, nil)
+	mnm.On("GetOrganizations", mock.Anything, mock.Anything).Return([]*core.Identity{}, nil, nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/network/organizations", nil)
+	req.Header.Set("X-FireFly-API-Key", "secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+}
+
+func TestAPIKeyReadOnlyDeniesPost(t *testing.T) {
+	mim, _, r := newTestAPIServerAPIKeys(false)
+	mim.On("ResolveAPIKey", mock.Anything, "secret").Return(&core.APIKey{Name: "partner1", Scope: core.APIKeyScopeReadOnly}, nil)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/messages/broadcast", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-FireFly-API-Key", "secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 403, res.Result().StatusCode)
+	assert.Regexp(t, "FF10605.*messaging", res.Body.String())
+}
+
+func TestAPIKeyTokensDeniesAPIKeyManagement(t *testing.T) {
+	mim, _, r := newTestAPIServerAPIKeys(false)
+	mim.On("ResolveAPIKey", mock.Anything, "secret").Return(&core.APIKey{Name: "partner1", Scope: core.APIKeyScopeTokens}, nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/apikeys", nil)
+	req.Header.Set("X-FireFly-API-Key", "secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 403, res.Result().StatusCode)
+	assert.Regexp(t, "FF10605.*admin", res.Body.String())
+}
+
+func TestAPIKeyInvalid(t *testing.T) {
+	mim, _, r := newTestAPIServerAPIKeys(false)
+	mim.On("ResolveAPIKey", mock.Anything, "secret").Return(nil, nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/network/organizations", nil)
+	req.Header.Set("X-FireFly-API-Key", "secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 401, res.Result().StatusCode)
+	assert.Regexp(t, "FF10604", res.Body.String())
+}
+
+func TestAPIKeyResolveFail(t *testing.T) {
+	mim, _, r := newTestAPIServerAPIKeys(false)
+	mim.On("ResolveAPIKey", mock.Anything, "secret").Return(nil, fmt.Errorf("pop"))
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/network/organizations", nil)
+	req.Header.Set("X-FireFly-API-Key", "secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 500, res.Result().StatusCode)
+}
+
+func TestRouteAPIKeyScope(t *testing.T) {
+	assert.Equal(t, core.APIKeyScopeReadOnly, routeAPIKeyScope("GET", &coreExtensions{}))
+	assert.Equal(t, core.APIKeyScopeAdmin, routeAPIKeyScope("POST", &coreExtensions{}))
+	assert.Equal(t, core.APIKeyScopeTokens, routeAPIKeyScope("POST", &coreExtensions{APIKeyScope: core.APIKeyScopeTokens}))
+}
+
+func newTestAPIServerBootstrapKey(key string) (*identitymanagermocks.Manager, *mux.Router) {
+	mgr, o, _ := newTestServer()
+	hash := sha256.Sum256([]byte(key))
+	config.Set(coreconfig.APIKeysRequired, true)
+	config.Set(coreconfig.APIKeysBootstrapKeyHash, strings.ToUpper(hex.EncodeToString(hash[:])))
+	as := NewAPIServer().(*apiServer)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mim := &identitymanagermocks.Manager{}
+	o.On("Identity").Return(mim)
+	return mim, as.createMuxRouter(context.Background(), mgr)
+}
+
+func TestAPIKeyBootstrapCreatesFirstKey(t *testing.T) {
+	// No keys exist yet, so only the bootstrap key from the config can create the first one
+	mim, r := newTestAPIServerBootstrapKey("bootstrap-secret")
+	mim.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(key *core.APIKey) bool {
+		return key.Name == "admin1" && key.Scope == core.APIKeyScopeAdmin
+	})).Return(&core.APIKey{Name: "admin1", Scope: core.APIKeyScopeAdmin, Key: "newkey"}, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/apikeys", strings.NewReader(`{"name":"admin1","scope":"admin"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-FireFly-API-Key", "bootstrap-secret")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Regexp(t, "newkey", res.Body.String())
+	mim.AssertExpectations(t)
+	mim.AssertNotCalled(t, "ResolveAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyBootstrapMismatch(t *testing.T) {
+	mim, r := newTestAPIServerBootstrapKey("bootstrap-secret")
+	mim.On("ResolveAPIKey", mock.Anything, "other").Return(nil, nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/apikeys", strings.NewReader(`{"name":"admin1","scope":"admin"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("X-FireFly-API-Key", "other")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 401, res.Result().StatusCode)
+	mim.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// limitedBody wraps a request body, and fails the read that takes it past the maximum size.
+// The error is returned as-is by the JSON decoder, so the client receives a 413 without the
+// remainder of the body being read into memory.
+type limitedBody struct {
+	io.ReadCloser
+	ctx       context.Context
+	limit     int64
+	remaining int64
+}
+
+func (lb *limitedBody) Read(p []byte) (int, error) {
+	if lb.remaining < 0 {
+		return 0, i18n.NewError(lb.ctx, coremsgs.MsgRequestBodyTooLarge, lb.limit)
+	}
+	// Read one byte more than we allow, so we can tell when the limit is exceeded
+	if int64(len(p)) > lb.remaining+1 {
+		p = p[:lb.remaining+1]
+	}
+	n, err := lb.ReadCloser.Read(p)
+	if int64(n) > lb.remaining {
+		n = int(lb.remaining)
+		lb.remaining = -1
+		return n, i18n.NewError(lb.ctx, coremsgs.MsgRequestBodyTooLarge, lb.limit)
+	}
+	lb.remaining -= int64(n)
+	return n, err
+}
+
+// maxRequestBodySizeForRoute returns the limit configured for a route, which also applies to the
+// copy of the route under /namespaces/{ns}. Config map keys are case-insensitive.
+func (as *apiServer) maxRequestBodySizeForRoute(routeName string) int64 {
+	routeName = strings.ToLower(routeName)
+	if maxSize, ok := as.routeMaxRequestBodySize[routeName]; ok {
+		return maxSize
+	}
+	if maxSize, ok := as.routeMaxRequestBodySize[strings.TrimSuffix(routeName, "namespace")]; ok {
+		return maxSize
+	}
+	return as.maxRequestBodySize
+}
+
+// maxRequestBodySizeHandler limits the size of the request body read by a route. Multi-part
+// uploads to routes that handle them are streamed through to storage, so are not limited.
+func maxRequestBodySizeHandler(maxSize int64, formUpload bool, handler http.HandlerFunc) http.HandlerFunc {
+	if maxSize <= 0 {
+		return handler
+	}
+	return func(res http.ResponseWriter, req *http.Request) {
+		if !formUpload || !strings.HasPrefix(strings.ToLower(req.Header.Get("Content-Type")), "multipart/form-data") {
+			lb := &limitedBody{
+				ReadCloser: req.Body,
+				ctx:        req.Context(),
+				limit:      maxSize,
+				remaining:  maxSize,
+			}
+			if req.ContentLength > maxSize {
+				// Reject on the first read, without reading any of the body
+				lb.remaining = -1
+			}
+			req.Body = lb
+		}
+		handler(res, req)
+	}
+}
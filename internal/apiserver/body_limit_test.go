@@ -0,0 +1,169 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAPIServerBodyLimits(maxSize int64, routeMaxSizes map[string]int64) (*datamocks.Manager, *mux.Router) {
+	mgr, o, as := newTestServer()
+	as.maxRequestBodySize = maxSize
+	as.routeMaxRequestBodySize = routeMaxSizes
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("MultiParty").Return(&multipartymocks.Manager{})
+	o.On("Data").Return(mdm)
+	mdm.On("UploadJSON", mock.Anything, mock.AnythingOfType("*core.DataRefOrValue")).
+		Return(&core.Data{}, nil).Maybe()
+	return mdm, as.createMuxRouter(context.Background(), mgr)
+}
+
+func TestRouteMaxRequestBodySizeConfig(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	config.Set(coreconfig.APIRouteMaxRequestBodySize, map[string]interface{}{
+		"postData":   "1Kb",
+		"postNewTxn": float64(100),
+	})
+	as := NewAPIServer().(*apiServer)
+	assert.Equal(t, int64(10*1024*1024), as.maxRequestBodySizeForRoute("getStatus"))
+	assert.Equal(t, int64(1024), as.maxRequestBodySizeForRoute("postData"))
+	assert.Equal(t, int64(1024), as.maxRequestBodySizeForRoute("postDataNamespace"))
+	assert.Equal(t, int64(100), as.maxRequestBodySizeForRoute("postNewTxn"))
+}
+
+func TestRequestBodyUnderLimit(t *testing.T) {
+	_, r := newTestAPIServerBodyLimits(100, nil)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", strings.NewReader(`{"value":"test"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 201, res.Result().StatusCode)
+}
+
+func TestRequestBodyContentLengthOverLimit(t *testing.T) {
+	_, r := newTestAPIServerBodyLimits(10, nil)
+	body := strings.NewReader(`{"value":"a longer value"}`)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", body)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 413, res.Result().StatusCode)
+	resBody, _ := io.ReadAll(res.Body)
+	assert.Regexp(t, "FF10601", string(resBody))
+	// None of the body was read
+	assert.Equal(t, int64(26), int64(body.Len()))
+}
+
+func TestRequestBodyStreamedOverLimit(t *testing.T) {
+	_, r := newTestAPIServerBodyLimits(10, nil)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", io.NopCloser(strings.NewReader(`{"value":"a longer value"}`)))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 413, res.Result().StatusCode)
+	resBody, _ := io.ReadAll(res.Body)
+	assert.Regexp(t, "FF10601", string(resBody))
+}
+
+func TestRequestBodyRouteOverride(t *testing.T) {
+	_, r := newTestAPIServerBodyLimits(10, map[string]int64{"postdata": 100})
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", strings.NewReader(`{"value":"a longer value"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 201, res.Result().StatusCode)
+}
+
+func TestRequestBodyNoLimit(t *testing.T) {
+	_, r := newTestAPIServerBodyLimits(0, nil)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", strings.NewReader(`{"value":"a longer value"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 201, res.Result().StatusCode)
+}
+
+func TestRequestBodyFormUploadNotLimited(t *testing.T) {
+	mdm, r := newTestAPIServerBodyLimits(10, nil)
+	mdm.On("BlobsEnabled").Return(true)
+	mdm.On("UploadBlob", mock.Anything, mock.AnythingOfType("*core.DataRefOrValue"), mock.AnythingOfType("*ffapi.Multipart"), false).
+		Return(&core.Data{}, nil)
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	writer, err := w.CreateFormFile("file", "filename.ext")
+	assert.NoError(t, err)
+	writer.Write([]byte(`some data that is longer than the limit`))
+	w.Close()
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", &b)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 201, res.Result().StatusCode)
+}
+
+func TestLimitedBodyReadAfterLimit(t *testing.T) {
+	lb := &limitedBody{
+		ReadCloser: io.NopCloser(strings.NewReader("0123456789")),
+		ctx:        context.Background(),
+		limit:      5,
+		remaining:  5,
+	}
+	buf := make([]byte, 20)
+	n, err := lb.Read(buf)
+	assert.Equal(t, 5, n)
+	assert.Regexp(t, "FF10601", err)
+	n, err = lb.Read(buf)
+	assert.Equal(t, 0, n)
+	assert.Regexp(t, "FF10601", err)
+}
+
+func TestLimitedBodyExactLimit(t *testing.T) {
+	lb := &limitedBody{
+		ReadCloser: io.NopCloser(strings.NewReader("01234")),
+		ctx:        context.Background(),
+		limit:      5,
+		remaining:  5,
+	}
+	b, err := io.ReadAll(lb)
+	assert.NoError(t, err)
+	assert.Equal(t, "01234", string(b))
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var embedQueryParam = &ffapi.QueryParam{Name: "embed", IsArray: true, Description: coremsgs.APIEmbedDesc}
+
+// getEmbedTypes parses the embed query parameter, which can be repeated and/or comma separated
+func getEmbedTypes(ctx context.Context, r *ffapi.APIRequest) ([]core.EmbedType, error) {
+	var embed []core.EmbedType
+	for _, param := range r.QAP["embed"] {
+		for _, s := range strings.Split(param, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			t, err := fftypes.FFEnumParseString(ctx, "embedtype", s)
+			if err != nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgInvalidEmbedType, s)
+			}
+			embed = append(embed, t)
+		}
+	}
+	return embed, nil
+}
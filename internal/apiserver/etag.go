@@ -0,0 +1,72 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+)
+
+// conditionalGetOutput adds an ETag to the response for a single record, and replaces the
+// response with an empty 304 if the client already holds that version of the record.
+//
+// The tag is computed from the serialized record, rather than from a hash in the record, as
+// not all records have one and the state of a message changes after its hash is fixed. The
+// serialized record is returned directly, so it is not marshalled a second time by ffapi.
+func conditionalGetOutput(r *ffapi.APIRequest, output interface{}) (interface{}, error) {
+	if output == nil {
+		return output, nil
+	}
+	if v := reflect.ValueOf(output); v.Kind() == reflect.Ptr && v.IsNil() {
+		return output, nil
+	}
+	if _, isReader := output.(io.ReadCloser); isReader {
+		return output, nil
+	}
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(output); err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(b.Bytes())
+	etag := `"` + hex.EncodeToString(hash[:]) + `"`
+	r.ResponseHeaders.Set("ETag", etag)
+	r.ResponseHeaders.Set("Content-Type", "application/json")
+	if etagMatches(r.Req.Header.Get("If-None-Match"), etag) {
+		r.SuccessStatus = http.StatusNotModified
+		return io.NopCloser(&bytes.Buffer{}), nil
+	}
+	return io.NopCloser(&b), nil
+}
+
+// etagMatches performs the weak comparison that applies to If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
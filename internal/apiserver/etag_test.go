@@ -0,0 +1,105 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetMsgByIDETag(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	msgID := fftypes.NewUUID()
+	o.On("GetMessageByID", mock.Anything, msgID.String()).
+		Return(&core.Message{Header: core.MessageHeader{ID: msgID}}, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/"+msgID.String(), nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+	etag := res.Result().Header.Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{64}"$`, etag)
+	var msg core.Message
+	assert.NoError(t, fftypes.JSONAnyPtr(res.Body.String()).Unmarshal(req.Context(), &msg))
+	assert.Equal(t, msgID, msg.Header.ID)
+
+	req = httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/"+msgID.String(), nil)
+	req.Header.Set("If-None-Match", etag)
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusNotModified, res.Result().StatusCode)
+	assert.Equal(t, etag, res.Result().Header.Get("ETag"))
+	assert.Empty(t, res.Body.String())
+
+	req = httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/"+msgID.String(), nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	res = httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.NotEmpty(t, res.Body.String())
+}
+
+func TestGetMsgByIDETagNotFound(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	o.On("GetMessageByID", mock.Anything, "abc").Return((*core.Message)(nil), nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/messages/abc", nil)
+	req.Header.Set("If-None-Match", "*")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	assert.Equal(t, 404, res.Result().StatusCode)
+	assert.Empty(t, res.Result().Header.Get("ETag"))
+}
+
+func TestConditionalGetOutputPassthrough(t *testing.T) {
+	r := &ffapi.APIRequest{ResponseHeaders: http.Header{}}
+	output, err := conditionalGetOutput(r, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, output)
+
+	reader := io.NopCloser(strings.NewReader("data"))
+	output, err = conditionalGetOutput(r, reader)
+	assert.NoError(t, err)
+	assert.Equal(t, reader, output)
+	assert.Empty(t, r.ResponseHeaders.Get("ETag"))
+}
+
+func TestConditionalGetOutputMarshalFail(t *testing.T) {
+	r := &ffapi.APIRequest{ResponseHeaders: http.Header{}}
+	_, err := conditionalGetOutput(r, map[string]interface{}{"bad": map[bool]bool{true: false}})
+	assert.Error(t, err)
+}
+
+func TestETagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"def", "abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+	assert.False(t, etagMatches(`"def"`, `"abc"`))
+}
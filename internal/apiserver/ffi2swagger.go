@@ -54,18 +54,27 @@ type ffiSwaggerGen struct{}
 func (swg *ffiSwaggerGen) Build(ctx context.Context, api *core.ContractAPI, ffi *fftypes.FFI) (*ffapi.SwaggerGenOptions, []*ffapi.Route) {
 	hasLocation := !api.Location.IsNil()
 
+	// Routes that resolve the API by version are pinned to the version being described
+	var versionParams []*ffapi.QueryParam
+	if api.Version != "" {
+		versionParams = []*ffapi.QueryParam{
+			{Name: "version", Description: coremsgs.APIContractAPIVersionQueryParam, Default: api.Version, Example: api.Version},
+		}
+	}
+
 	routes := []*ffapi.Route{
 		{
 			Name:            "interface",
 			Path:            "interface", // must match a route defined in apiserver routes!
 			Method:          http.MethodGet,
+			QueryParams:     versionParams,
 			JSONInputValue:  nil,
 			JSONOutputValue: func() interface{} { return &fftypes.FFI{} },
 			JSONOutputCodes: []int{http.StatusOK},
 		},
 	}
 	for _, method := range ffi.Methods {
-		routes = addFFIMethod(ctx, routes, method, hasLocation)
+		routes = addFFIMethod(ctx, routes, method, hasLocation, versionParams)
 	}
 	for _, event := range ffi.Events {
 		routes = addFFIEvent(ctx, routes, event, hasLocation)
@@ -79,7 +88,7 @@ func (swg *ffiSwaggerGen) Build(ctx context.Context, api *core.ContractAPI, ffi
 	}, routes
 }
 
-func addFFIMethod(ctx context.Context, routes []*ffapi.Route, method *fftypes.FFIMethod, hasLocation bool) []*ffapi.Route {
+func addFFIMethod(ctx context.Context, routes []*ffapi.Route, method *fftypes.FFIMethod, hasLocation bool, versionParams []*ffapi.QueryParam) []*ffapi.Route {
 	description := method.Description
 	if method.Details != nil && len(method.Details) > 0 {
 		additionalDetailsHeader := i18n.Expand(ctx, coremsgs.APISmartContractDetails)
@@ -89,9 +98,9 @@ func addFFIMethod(ctx context.Context, routes []*ffapi.Route, method *fftypes.FF
 		Name:   fmt.Sprintf("invoke_%s", method.Pathname),
 		Path:   fmt.Sprintf("invoke/%s", method.Pathname), // must match a route defined in apiserver routes!
 		Method: http.MethodPost,
-		QueryParams: []*ffapi.QueryParam{
+		QueryParams: append([]*ffapi.QueryParam{
 			{Name: "confirm", Description: coremsgs.APIConfirmInvokeQueryParam, IsBool: true, Example: "true"},
-		},
+		}, versionParams...),
 		JSONInputSchema: func(ctx context.Context, schemaGen ffapi.SchemaGenerator) (*openapi3.SchemaRef, error) {
 			return contractRequestJSONSchema(ctx, &method.Params, hasLocation)
 		},
@@ -100,9 +109,10 @@ func addFFIMethod(ctx context.Context, routes []*ffapi.Route, method *fftypes.FF
 		PreTranslatedDescription: description,
 	})
 	routes = append(routes, &ffapi.Route{
-		Name:   fmt.Sprintf("query_%s", method.Pathname),
-		Path:   fmt.Sprintf("query/%s", method.Pathname), // must match a route defined in apiserver routes!
-		Method: http.MethodPost,
+		Name:        fmt.Sprintf("query_%s", method.Pathname),
+		Path:        fmt.Sprintf("query/%s", method.Pathname), // must match a route defined in apiserver routes!
+		Method:      http.MethodPost,
+		QueryParams: versionParams,
 		JSONInputSchema: func(ctx context.Context, schemaGen ffapi.SchemaGenerator) (*openapi3.SchemaRef, error) {
 			return contractRequestJSONSchema(ctx, &method.Params, hasLocation)
 		},
@@ -188,6 +188,20 @@ func TestGenerateWithLocation(t *testing.T) {
 	assert.ElementsMatch(t, []string{}, paramNames(queryMethod2.Properties["input"].Value.Properties))
 }
 
+func TestGenerateWithVersion(t *testing.T) {
+	api := &core.ContractAPI{Version: "1.0.0"}
+	options, routes := (&ffiSwaggerGen{}).Build(context.Background(), api, testFFI())
+	options.BaseURL = "http://localhost:12345"
+	doc := ffapi.NewSwaggerGen(options).Generate(context.Background(), routes)
+
+	versionParam := doc.Paths.Value("/query/method1").Post.Parameters.GetByInAndName("query", "version")
+	assert.NotNil(t, versionParam)
+	assert.Equal(t, "1.0.0", versionParam.Schema.Value.Default)
+	assert.NotNil(t, doc.Paths.Value("/invoke/method1").Post.Parameters.GetByInAndName("query", "version"))
+	assert.NotNil(t, doc.Paths.Value("/interface").Get.Parameters.GetByInAndName("query", "version"))
+	assert.Nil(t, doc.Paths.Value("/listeners/event1").Post.Parameters.GetByInAndName("query", "version"))
+}
+
 func TestFFIParamBadSchema(t *testing.T) {
 	ctx := context.Background()
 	params := &fftypes.FFIParams{
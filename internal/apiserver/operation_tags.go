@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/operations"
+)
+
+const (
+	operationTagsHeader = "X-FireFly-Operation-Tags"
+	maxOperationTags    = 10
+)
+
+// operationTagsContext propagates the comma-separated tags supplied on a request to every operation
+// created while processing it, so that an application can later find them with "?tags="
+func operationTagsContext(r *ffapi.APIRequest) (context.Context, error) {
+	ctx := r.Req.Context()
+	header := r.Req.Header.Get(operationTagsHeader)
+	if header == "" {
+		return ctx, nil
+	}
+	tags := parseOperationTags(header)
+	if err := tags.Validate(ctx, operationTagsHeader, false, maxOperationTags); err != nil {
+		return nil, err
+	}
+	return operations.WithOperationTags(ctx, tags), nil
+}
+
+func parseOperationTags(s string) fftypes.FFStringArray {
+	tags := fftypes.FFStringArray{}
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,50 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/namespace"
+)
+
+func writeProbeResponse(res http.ResponseWriter, status int, body interface{}) (int, error) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	return status, json.NewEncoder(res).Encode(body)
+}
+
+// liveHandler reports the process is running and serving requests, for use as a Kubernetes liveness probe
+func liveHandler(res http.ResponseWriter, req *http.Request) (status int, err error) {
+	return writeProbeResponse(res, http.StatusOK, fftypes.JSONObject{"live": true})
+}
+
+// readyHandler checks connectivity to each plugin, for use as a Kubernetes readiness probe.
+// A 503 is returned with the per-component status if any plugin cannot be reached.
+func readyHandler(mgr namespace.Manager) ffapi.HandlerFunction {
+	return func(res http.ResponseWriter, req *http.Request) (status int, err error) {
+		readiness := mgr.GetReadiness(req.Context())
+		status = http.StatusOK
+		if !readiness.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		return writeProbeResponse(res, status, readiness)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLiveProbe(t *testing.T) {
+	mgr, _, as := newTestServer()
+	r := as.createMuxRouter(context.Background(), mgr)
+
+	req := httptest.NewRequest("GET", "/live", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.JSONEq(t, `{"live":true}`, res.Body.String())
+}
+
+func TestReadyProbe(t *testing.T) {
+	mgr, _, as := newTestServer()
+	r := as.createMuxRouter(context.Background(), mgr)
+	readiness := &core.NodeReadiness{Ready: true}
+	readiness.AddComponent("database0", "database", "postgres", nil)
+	mgr.On("GetReadiness", mock.Anything).Return(readiness)
+
+	req := httptest.NewRequest("GET", "/ready", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	var result core.NodeReadiness
+	err := json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.Equal(t, *readiness, result)
+}
+
+func TestReadyProbeNotReady(t *testing.T) {
+	mgr, _, as := newTestServer()
+	r := as.createMuxRouter(context.Background(), mgr)
+	readiness := &core.NodeReadiness{Ready: true}
+	readiness.AddComponent("blockchain0", "blockchain", "ethereum", context.DeadlineExceeded)
+	mgr.On("GetReadiness", mock.Anything).Return(readiness)
+
+	req := httptest.NewRequest("GET", "/ready", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 503, res.Result().StatusCode)
+	var result core.NodeReadiness
+	err := json.NewDecoder(res.Body).Decode(&result)
+	assert.NoError(t, err)
+	assert.False(t, result.Ready)
+	assert.Equal(t, "context deadline exceeded", result.Components[0].Error)
+}
@@ -30,14 +30,16 @@ var deleteContractAPI = &ffapi.Route{
 	PathParams: []*ffapi.PathParam{
 		{Name: "apiName", Description: coremsgs.APIParamsContractAPIName},
 	},
-	QueryParams:     nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "version", Description: coremsgs.APIContractAPIVersionQueryParam},
+	},
 	Description:     coremsgs.APIEndpointsDeleteContractAPI,
 	JSONInputValue:  nil,
 	JSONOutputValue: nil,
 	JSONOutputCodes: []int{http.StatusNoContent}, // Sync operation, no output
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			return nil, cr.or.Contracts().DeleteContractAPI(cr.ctx, r.PP["apiName"])
+			return nil, cr.or.Contracts().DeleteContractAPI(cr.ctx, r.PP["apiName"], r.QP["version"])
 		},
 	},
 }
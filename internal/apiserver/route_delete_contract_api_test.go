@@ -34,7 +34,7 @@ func TestDeleteContractAPI(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	res := httptest.NewRecorder()
 
-	mcm.On("DeleteContractAPI", mock.Anything, "banana").Return(nil)
+	mcm.On("DeleteContractAPI", mock.Anything, "banana", "").Return(nil)
 	r.ServeHTTP(res, req)
 
 	assert.Equal(t, 204, res.Result().StatusCode)
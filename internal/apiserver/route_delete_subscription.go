@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
 )
 
 var deleteSubscription = &ffapi.Route{
@@ -37,7 +38,7 @@ var deleteSubscription = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusNoContent}, // Sync operation, no output
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			err = cr.or.DeleteSubscription(cr.ctx, r.PP["subid"])
+			err = cr.or.DeleteSubscription(cr.ctx, r.PP["subid"], core.RequestPrincipal(r.Req))
 			return nil, err
 		},
 	},
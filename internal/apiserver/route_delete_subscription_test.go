@@ -38,9 +38,10 @@ func TestDeleteSubscription(t *testing.T) {
 	u := fftypes.NewUUID()
 	req := httptest.NewRequest("DELETE", fmt.Sprintf("/api/v1/namespaces/ns1/subscriptions/%s", u), &buf)
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.SetBasicAuth("user1", "pass1")
 	res := httptest.NewRecorder()
 
-	o.On("DeleteSubscription", mock.Anything, u.String()).
+	o.On("DeleteSubscription", mock.Anything, u.String(), "basic:user1").
 		Return(nil)
 	r.ServeHTTP(res, req)
 
@@ -0,0 +1,68 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var getChartAggregate = &ffapi.Route{
+	Name:   "getChartAggregate",
+	Path:   "charts/{collection}",
+	Method: http.MethodGet,
+	PathParams: []*ffapi.PathParam{
+		{Name: "collection", Description: coremsgs.APIParamsCollectionID},
+	},
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "interval", Description: coremsgs.APIChartIntervalParam, IsBool: false},
+		{Name: "startTime", Description: coremsgs.APIChartStartTimeParam, IsBool: false},
+		{Name: "endTime", Description: coremsgs.APIChartEndTimeParam, IsBool: false},
+	},
+	Description:     coremsgs.APIEndpointsGetChartAggregate,
+	JSONInputValue:  nil,
+	JSONOutputValue: func() interface{} { return []*core.ChartHistogram{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			interval := core.ChartIntervalMinute
+			if r.QP["interval"] != "" {
+				if interval, err = fftypes.FFEnumParseString(cr.ctx, "chartinterval", r.QP["interval"]); err != nil {
+					return nil, err
+				}
+			}
+			var startTime, endTime *fftypes.FFTime
+			if r.QP["startTime"] != "" {
+				if startTime, err = fftypes.ParseTimeString(r.QP["startTime"]); err != nil {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgInvalidChartNumberParam, "startTime")
+				}
+			}
+			if r.QP["endTime"] != "" {
+				if endTime, err = fftypes.ParseTimeString(r.QP["endTime"]); err != nil {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgInvalidChartNumberParam, "endTime")
+				}
+			}
+			return cr.or.GetChartAggregate(cr.ctx, database.CollectionName(r.PP["collection"]), interval, startTime, endTime)
+		},
+	},
+}
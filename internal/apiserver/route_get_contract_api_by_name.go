@@ -32,7 +32,9 @@ var getContractAPIByName = &ffapi.Route{
 	PathParams: []*ffapi.PathParam{
 		{Name: "apiName", Description: coremsgs.APIParamsContractAPIName},
 	},
-	QueryParams:     nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "version", Description: coremsgs.APIContractAPIVersionQueryParam},
+	},
 	Description:     coremsgs.APIEndpointsGetContractAPIByName,
 	JSONInputValue:  nil,
 	JSONOutputValue: func() interface{} { return &core.ContractAPI{} },
@@ -42,7 +44,7 @@ var getContractAPIByName = &ffapi.Route{
 			return or.Contracts() != nil
 		},
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			return cr.or.Contracts().GetContractAPI(cr.ctx, cr.apiBaseURL, r.PP["apiName"])
+			return cr.or.Contracts().GetContractAPI(cr.ctx, cr.apiBaseURL, r.PP["apiName"], r.QP["version"])
 		},
 	},
 }
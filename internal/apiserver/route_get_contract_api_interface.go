@@ -32,7 +32,9 @@ var getContractAPIInterface = &ffapi.Route{
 	PathParams: []*ffapi.PathParam{
 		{Name: "apiName", Description: coremsgs.APIParamsContractAPIName},
 	},
-	QueryParams:     nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "version", Description: coremsgs.APIContractAPIVersionQueryParam},
+	},
 	Description:     coremsgs.APIEndpointsGetContractAPIInterface,
 	JSONInputValue:  nil,
 	JSONOutputValue: func() interface{} { return &fftypes.FFI{} },
@@ -42,7 +44,7 @@ var getContractAPIInterface = &ffapi.Route{
 			return or.Contracts() != nil
 		},
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			return cr.or.Contracts().GetContractAPIInterface(cr.ctx, r.PP["apiName"])
+			return cr.or.Contracts().GetContractAPIInterface(cr.ctx, r.PP["apiName"], r.QP["version"])
 		},
 	},
 }
@@ -44,6 +44,7 @@ var getContractInterface = &ffapi.Route{
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.Contracts() != nil
 		},
+		ConditionalGet: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			interfaceID, err := fftypes.ParseUUID(cr.ctx, r.PP["interfaceId"])
 			if err != nil {
@@ -22,7 +22,6 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
-	"github.com/hyperledger/firefly/internal/orchestrator"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
@@ -41,9 +40,7 @@ var getDataBlob = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return []byte{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
-		EnabledIf: func(or orchestrator.Orchestrator) bool {
-			return or.Data().BlobsEnabled()
-		},
+		RequiresMultiparty: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			blob, reader, err := cr.or.Data().DownloadBlob(cr.ctx, r.PP["dataid"])
 			if err == nil {
@@ -37,6 +37,7 @@ var getDataByID = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Data{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ConditionalGet: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			output, err = cr.or.GetDataByID(cr.ctx, r.PP["dataid"])
 			return output, err
@@ -17,10 +17,15 @@
 package apiserver
 
 import (
+	"bytes"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
@@ -43,6 +48,17 @@ var getDataValue = &ffapi.Route{
 			if err != nil {
 				return nil, err
 			}
+			if strings.Contains(r.Req.Header.Get("Accept"), core.MIMETypeProtobuf) {
+				if d.Validator != core.ValidatorTypeProtobuf {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgDataValueNotProtobuf, d.ID)
+				}
+				payload, err := core.DecodeBinaryValue(cr.ctx, d.Value)
+				if err != nil {
+					return nil, err
+				}
+				r.ResponseHeaders.Set("Content-Type", core.MIMETypeProtobuf)
+				return io.NopCloser(bytes.NewReader(payload)), nil
+			}
 			return d.Value, err
 		},
 	},
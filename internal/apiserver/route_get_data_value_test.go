@@ -67,3 +67,66 @@ func TestGetDataValueFail(t *testing.T) {
 	assert.Equal(t, 500, res.Result().StatusCode)
 
 }
+
+func TestGetDataValueProtobuf(t *testing.T) {
+	o, r := newTestAPIServer()
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/data/abcd12345/value", nil)
+	req.Header.Set("Accept", core.MIMETypeProtobuf)
+	res := httptest.NewRecorder()
+
+	o.On("GetDataByID", mock.Anything, "abcd12345").
+		Return(&core.Data{
+			Validator: core.ValidatorTypeProtobuf,
+			Value:     core.BinaryValue([]byte{0x0a, 0x01, 0x61}),
+		}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	assert.Equal(t, core.MIMETypeProtobuf, res.Result().Header.Get("Content-Type"))
+
+	resData, err := ioutil.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x01, 0x61}, resData)
+}
+
+func TestGetDataValueProtobufBadValue(t *testing.T) {
+	o, r := newTestAPIServer()
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/data/abcd12345/value", nil)
+	req.Header.Set("Accept", core.MIMETypeProtobuf)
+	res := httptest.NewRecorder()
+
+	o.On("GetDataByID", mock.Anything, "abcd12345").
+		Return(&core.Data{
+			Validator: core.ValidatorTypeProtobuf,
+			Value:     fftypes.JSONAnyPtr(`{"not":"binary"}`),
+		}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+}
+
+func TestGetDataValueProtobufNotAcceptable(t *testing.T) {
+	o, r := newTestAPIServer()
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/mynamespace/data/abcd12345/value", nil)
+	req.Header.Set("Accept", core.MIMETypeProtobuf)
+	res := httptest.NewRecorder()
+
+	o.On("GetDataByID", mock.Anything, "abcd12345").
+		Return(&core.Data{
+			ID:        fftypes.NewUUID(),
+			Validator: core.ValidatorTypeJSON,
+			Value:     fftypes.JSONAnyPtr(`{"some":"data"}`),
+		}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 406, res.Result().StatusCode)
+}
@@ -21,7 +21,6 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
-	"github.com/hyperledger/firefly/internal/orchestrator"
 	"github.com/hyperledger/firefly/pkg/core"
 )
 
@@ -38,9 +37,7 @@ var getGroupByHash = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Group{} },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
-		EnabledIf: func(or orchestrator.Orchestrator) bool {
-			return or.PrivateMessaging() != nil
-		},
+		RequiresMultiparty: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			return cr.or.PrivateMessaging().GetGroupByID(cr.ctx, r.PP["hash"])
 		},
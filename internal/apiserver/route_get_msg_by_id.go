@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -34,14 +35,23 @@ var getMsgByID = &ffapi.Route{
 	},
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "fetchdata", IsBool: true, Description: coremsgs.APIFetchDataDesc},
+		asOfQueryParam,
 	},
 	Description:     coremsgs.APIEndpointsGetMsgByID,
 	JSONInputValue:  nil,
 	JSONOutputValue: func() interface{} { return &core.MessageInOut{} }, // can include full values
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		ConditionalGet: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			if strings.EqualFold(r.QP["data"], "true") || strings.EqualFold(r.QP["fetchdata"], "true") {
+			fetchData := strings.EqualFold(r.QP["data"], "true") || strings.EqualFold(r.QP["fetchdata"], "true")
+			if asOf := r.QP["asof"]; asOf != "" {
+				if fetchData {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgAsOfNotCombinable, "fetchdata")
+				}
+				return cr.or.GetMessageByIDAsOf(cr.ctx, r.PP["msgid"], asOf)
+			}
+			if fetchData {
 				return cr.or.GetMessageByIDWithData(cr.ctx, r.PP["msgid"])
 			}
 			return cr.or.GetMessageByID(cr.ctx, r.PP["msgid"])
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -33,6 +34,8 @@ var getMsgs = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "fetchdata", IsBool: true, Description: coremsgs.APIFetchDataDesc},
+		embedQueryParam,
+		asOfQueryParam,
 	},
 	FilterFactory:   database.MessageQueryFactory,
 	Description:     coremsgs.APIEndpointsGetMsgs,
@@ -41,6 +44,22 @@ var getMsgs = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			embed, err := getEmbedTypes(cr.ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			if asOf := r.QP["asof"]; asOf != "" {
+				if len(embed) > 0 {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgAsOfNotCombinable, "embed")
+				}
+				if strings.EqualFold(r.QP["fetchdata"], "true") {
+					return nil, i18n.NewError(cr.ctx, coremsgs.MsgAsOfNotCombinable, "fetchdata")
+				}
+				return r.FilterResult(cr.or.GetMessagesAsOf(cr.ctx, r.Filter, asOf))
+			}
+			if len(embed) > 0 {
+				return r.FilterResult(cr.or.GetMessagesWithEmbedded(cr.ctx, r.Filter, embed))
+			}
 			if strings.EqualFold(r.QP["fetchdata"], "true") {
 				return r.FilterResult(cr.or.GetMessagesWithData(cr.ctx, r.Filter))
 			}
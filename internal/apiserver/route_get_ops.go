@@ -18,6 +18,7 @@ package apiserver
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
@@ -26,11 +27,14 @@ import (
 )
 
 var getOps = &ffapi.Route{
-	Name:            "getOps",
-	Path:            "operations",
-	Method:          http.MethodGet,
-	PathParams:      nil,
-	QueryParams:     nil,
+	Name:       "getOps",
+	Path:       "operations",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "stalled", IsBool: true, Description: coremsgs.APIStalledOperationsDesc},
+		{Name: "tags", Description: coremsgs.APIOperationTagsDesc},
+	},
 	FilterFactory:   database.OperationQueryFactory,
 	Description:     coremsgs.APIEndpointsGetOps,
 	JSONInputValue:  nil,
@@ -38,6 +42,12 @@ var getOps = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			if strings.EqualFold(r.QP["stalled"], "true") {
+				return r.FilterResult(cr.or.GetStalledOperations(cr.ctx, r.Filter))
+			}
+			if tags := parseOperationTags(r.QP["tags"]); len(tags) > 0 {
+				return r.FilterResult(cr.or.GetOperationsByTags(cr.ctx, tags, r.Filter))
+			}
 			return r.FilterResult(cr.or.GetOperations(cr.ctx, r.Filter))
 		},
 	},
@@ -26,11 +26,13 @@ import (
 )
 
 var getTokenBalances = &ffapi.Route{
-	Name:            "getTokenBalances",
-	Path:            "tokens/balances",
-	Method:          http.MethodGet,
-	PathParams:      nil,
-	QueryParams:     nil,
+	Name:       "getTokenBalances",
+	Path:       "tokens/balances",
+	Method:     http.MethodGet,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		asOfQueryParam,
+	},
 	FilterFactory:   database.TokenBalanceQueryFactory,
 	Description:     coremsgs.APIEndpointsGetTokenBalances,
 	JSONInputValue:  nil,
@@ -38,6 +40,9 @@ var getTokenBalances = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			if asOf := r.QP["asof"]; asOf != "" {
+				return r.FilterResult(cr.or.GetTokenBalancesAsOf(cr.ctx, r.Filter, asOf))
+			}
 			return r.FilterResult(cr.or.Assets().GetTokenBalances(cr.ctx, r.Filter))
 		},
 	},
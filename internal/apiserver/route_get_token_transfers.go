@@ -32,6 +32,7 @@ var getTokenTransfers = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "fromOrTo", Description: coremsgs.APIParamsTokenTransferFromOrTo},
+		embedQueryParam,
 	},
 	FilterFactory:   database.TokenTransferQueryFactory,
 	Description:     coremsgs.APIEndpointsGetTokenTransfers,
@@ -48,6 +49,13 @@ var getTokenTransfers = &ffapi.Route{
 						Condition(fb.Eq("from", fromOrTo)).
 						Condition(fb.Eq("to", fromOrTo)))
 			}
+			embed, err := getEmbedTypes(cr.ctx, r)
+			if err != nil {
+				return nil, err
+			}
+			if len(embed) > 0 {
+				return r.FilterResult(cr.or.GetTokenTransfersWithEmbedded(cr.ctx, filter, embed))
+			}
 			return r.FilterResult(cr.or.Assets().GetTokenTransfers(cr.ctx, filter))
 		},
 	},
@@ -34,6 +34,7 @@ var patchUpdateIdentity = &ffapi.Route{
 	},
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPatchUpdateIdentity,
 	JSONInputValue:  func() interface{} { return &core.IdentityUpdateDTO{} },
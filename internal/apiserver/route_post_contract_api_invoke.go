@@ -36,10 +36,12 @@ var postContractAPIInvoke = &ffapi.Route{
 	},
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
+		{Name: "version", Description: coremsgs.APIContractAPIVersionQueryParam},
 	},
 	Description:     coremsgs.APIEndpointsPostContractAPIInvoke,
 	JSONInputValue:  func() interface{} { return &core.ContractCallRequest{} },
-	JSONOutputValue: func() interface{} { return &core.Operation{} },
+	JSONOutputValue: func() interface{} { return &core.ContractInvokeResult{} },
 	JSONOutputCodes: []int{http.StatusOK, http.StatusAccepted},
 	Extensions: &coreExtensions{
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
@@ -50,7 +52,7 @@ var postContractAPIInvoke = &ffapi.Route{
 			r.SuccessStatus = syncRetcode(waitConfirm)
 			req := r.Input.(*core.ContractCallRequest)
 			req.Type = core.CallTypeInvoke
-			return cr.or.Contracts().InvokeContractAPI(cr.ctx, r.PP["apiName"], r.PP["methodPath"], req, waitConfirm)
+			return cr.or.Contracts().InvokeContractAPI(cr.ctx, r.PP["apiName"], r.QP["version"], r.PP["methodPath"], req, waitConfirm)
 		},
 	},
 }
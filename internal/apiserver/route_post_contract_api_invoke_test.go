@@ -22,6 +22,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/mocks/contractmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
@@ -40,10 +41,37 @@ func TestPostContractAPIInvoke(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	res := httptest.NewRecorder()
 
-	mcm.On("InvokeContractAPI", mock.Anything, "banana", "peel", mock.MatchedBy(func(req *core.ContractCallRequest) bool {
+	mcm.On("InvokeContractAPI", mock.Anything, "banana", "", "peel", mock.MatchedBy(func(req *core.ContractCallRequest) bool {
 		return req.Type == core.CallTypeInvoke
 	}), false).Return("banana", nil)
 	r.ServeHTTP(res, req)
 
 	assert.Equal(t, 202, res.Result().StatusCode)
 }
+
+func TestPostContractAPIInvokeConfirm(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mcm := &contractmocks.Manager{}
+	o.On("Contracts").Return(mcm)
+	input := core.ContractCallRequest{}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/apis/banana/invoke/peel?confirm=true", &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	mcm.On("InvokeContractAPI", mock.Anything, "banana", "", "peel", mock.MatchedBy(func(req *core.ContractCallRequest) bool {
+		return req.Type == core.CallTypeInvoke
+	}), true).Return(&core.ContractInvokeResult{
+		Operation:    &core.Operation{Status: core.OpStatusSucceeded},
+		ReturnValues: fftypes.JSONObject{"peeled": true},
+	}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	var result fftypes.JSONObject
+	json.NewDecoder(res.Body).Decode(&result)
+	assert.Equal(t, "Succeeded", result.GetString("status"))
+	assert.True(t, result.GetObject("returnValues").GetBool("peeled"))
+}
@@ -33,19 +33,22 @@ var postContractAPIQuery = &ffapi.Route{
 		{Name: "apiName", Description: coremsgs.APIParamsContractAPIName},
 		{Name: "methodPath", Description: coremsgs.APIParamsMethodPath},
 	},
-	QueryParams:     []*ffapi.QueryParam{},
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "version", Description: coremsgs.APIContractAPIVersionQueryParam},
+	},
 	Description:     coremsgs.APIEndpointsPostContractAPIQuery,
 	JSONInputValue:  func() interface{} { return &core.ContractCallRequest{} },
 	JSONOutputValue: func() interface{} { return make(map[string]interface{}) },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		APIKeyScope: core.APIKeyScopeReadOnly,
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.Contracts() != nil
 		},
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			req := r.Input.(*core.ContractCallRequest)
 			req.Type = core.CallTypeQuery
-			return cr.or.Contracts().InvokeContractAPI(cr.ctx, r.PP["apiName"], r.PP["methodPath"], req, true)
+			return cr.or.Contracts().InvokeContractAPI(cr.ctx, r.PP["apiName"], r.QP["version"], r.PP["methodPath"], req, true)
 		},
 	},
 }
@@ -40,7 +40,7 @@ func TestPostContractAPIQuery(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	res := httptest.NewRecorder()
 
-	mcm.On("InvokeContractAPI", mock.Anything, "banana", "peel", mock.MatchedBy(func(req *core.ContractCallRequest) bool {
+	mcm.On("InvokeContractAPI", mock.Anything, "banana", "", "peel", mock.MatchedBy(func(req *core.ContractCallRequest) bool {
 		return req.Type == core.CallTypeQuery
 	}), true).Return("banana", nil)
 	r.ServeHTTP(res, req)
@@ -33,6 +33,7 @@ var postContractDeploy = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPostContractDeploy,
 	JSONInputValue:  func() interface{} { return &core.ContractDeployRequest{} },
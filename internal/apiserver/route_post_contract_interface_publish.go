@@ -36,6 +36,7 @@ var postContractInterfacePublish = &ffapi.Route{
 	},
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPostContractInterfacePublish,
 	JSONInputValue:  func() interface{} { return &core.DefinitionPublish{} },
@@ -33,6 +33,7 @@ var postContractInvoke = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmInvokeQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPostContractInvoke,
 	JSONInputValue:  func() interface{} { return &core.ContractCallRequest{} },
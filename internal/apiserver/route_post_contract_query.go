@@ -36,6 +36,7 @@ var postContractQuery = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return make(map[string]interface{}) },
 	JSONOutputCodes: []int{http.StatusOK},
 	Extensions: &coreExtensions{
+		APIKeyScope: core.APIKeyScopeReadOnly,
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.Contracts() != nil
 		},
@@ -48,6 +48,7 @@ var postData = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.Data{} },
 	JSONOutputCodes: []int{http.StatusCreated},
 	Extensions: &coreExtensions{
+		APIKeyScope: core.APIKeyScopeMessaging,
 		EnabledIf: func(or orchestrator.Orchestrator) bool {
 			return or.Data() != nil
 		},
@@ -56,10 +57,6 @@ var postData = &ffapi.Route{
 			return output, err
 		},
 		CoreFormUploadHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			if !cr.or.Data().BlobsEnabled() {
-				return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
-			}
-
 			data := &core.DataRefOrValue{}
 			validator := r.FP["validator"]
 			if len(validator) > 0 {
@@ -71,6 +68,14 @@ var postData = &ffapi.Route{
 					Version: r.FP["datatype.version"],
 				}
 			}
+			// A protobuf upload is the value of the data itself, rather than a blob
+			if data.Validator == core.ValidatorTypeProtobuf {
+				return cr.or.Data().UploadProtobuf(cr.ctx, data, r.Part)
+			}
+
+			if !cr.or.Data().BlobsEnabled() {
+				return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
+			}
 			metadata := r.FP["metadata"]
 			if len(metadata) > 0 {
 				// The metadata might be JSON, or just a simple string. Try to unmarshal and see
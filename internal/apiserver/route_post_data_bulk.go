@@ -0,0 +1,47 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var postDataBulk = &ffapi.Route{
+	Name:            "postDataBulk",
+	Path:            "data/bulk",
+	Method:          http.MethodPost,
+	PathParams:      nil,
+	QueryParams:     nil,
+	Description:     coremsgs.APIEndpointsPostDataBulk,
+	JSONInputValue:  func() interface{} { return &[]*core.DataRefOrValue{} },
+	JSONOutputValue: func() interface{} { return []*core.Data{} },
+	JSONOutputCodes: []int{http.StatusCreated},
+	Extensions: &coreExtensions{
+		APIKeyScope: core.APIKeyScopeMessaging,
+		EnabledIf: func(or orchestrator.Orchestrator) bool {
+			return or.Data() != nil
+		},
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			return cr.or.Data().UploadBulk(cr.ctx, *r.Input.(*[]*core.DataRefOrValue))
+		},
+	},
+}
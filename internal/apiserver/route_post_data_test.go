@@ -295,3 +295,41 @@ func TestPostDataNoBlobs(t *testing.T) {
 
 	assert.Equal(t, 400, res.Result().StatusCode)
 }
+
+func TestPostDataProtobuf(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("MultiParty").Return(&multipartymocks.Manager{})
+	o.On("Data").Return(mdm)
+
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	writer, err := w.CreateFormField("validator")
+	assert.NoError(t, err)
+	writer.Write([]byte(`protobuf`))
+	writer, err = w.CreateFormField("datatype.name")
+	assert.NoError(t, err)
+	writer.Write([]byte(`widget`))
+	writer, err = w.CreateFormField("datatype.version")
+	assert.NoError(t, err)
+	writer.Write([]byte(`1.0`))
+	writer, err = w.CreateFormFile("file", "widget.bin")
+	assert.NoError(t, err)
+	writer.Write([]byte{0x0a, 0x01, 0x61})
+	w.Close()
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/data", &b)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	res := httptest.NewRecorder()
+
+	mdm.On("UploadProtobuf", mock.Anything, mock.MatchedBy(func(d *core.DataRefOrValue) bool {
+		return d.Validator == core.ValidatorTypeProtobuf &&
+			d.Datatype.Name == "widget" && d.Datatype.Version == "1.0"
+	}), mock.AnythingOfType("*ffapi.Multipart")).
+		Return(&core.Data{}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 201, res.Result().StatusCode)
+	mdm.AssertExpectations(t)
+}
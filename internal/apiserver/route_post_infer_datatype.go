@@ -0,0 +1,53 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var postInferDatatype = &ffapi.Route{
+	Name:       "postInferDatatype",
+	Path:       "datatypes/infer",
+	Method:     http.MethodPost,
+	PathParams: nil,
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "publish", Description: coremsgs.APIPublishQueryParam, IsBool: true},
+		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
+	},
+	Description:     coremsgs.APIEndpointsPostInferDatatype,
+	JSONInputValue:  func() interface{} { return &core.DatatypeInferInput{} },
+	JSONOutputValue: func() interface{} { return &core.Datatype{} },
+	JSONOutputCodes: []int{http.StatusOK, http.StatusAccepted},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			datatype, err := cr.or.Data().InferDatatype(cr.ctx, r.Input.(*core.DatatypeInferInput))
+			if err == nil && strings.EqualFold(r.QP["publish"], "true") {
+				waitConfirm := strings.EqualFold(r.QP["confirm"], "true")
+				r.SuccessStatus = syncRetcode(waitConfirm)
+				err = cr.or.DefinitionSender().DefineDatatype(cr.ctx, datatype, waitConfirm)
+			}
+			return datatype, err
+		},
+	},
+}
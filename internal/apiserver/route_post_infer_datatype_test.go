@@ -0,0 +1,116 @@
+// Copyright © 2021 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/definitionsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newInferDatatypeRequest() *bytes.Buffer {
+	input := core.DatatypeInferInput{
+		Name:    "widget",
+		Version: "0.0.1",
+		Sample:  fftypes.JSONAnyPtr(`{"name":"widget"}`),
+	}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	return &buf
+}
+
+func TestPostInferDatatype(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/datatypes/infer", newInferDatatypeRequest())
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	mdm.On("InferDatatype", mock.Anything, mock.MatchedBy(func(input *core.DatatypeInferInput) bool {
+		return input.Name == "widget" && input.Sample.String() == `{"name":"widget"}`
+	})).Return(&core.Datatype{Name: "widget"}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	mdm.AssertExpectations(t)
+}
+
+func TestPostInferDatatypePublish(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	mds := &definitionsmocks.Sender{}
+	o.On("DefinitionSender").Return(mds)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/datatypes/infer?publish", newInferDatatypeRequest())
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	datatype := &core.Datatype{Name: "widget"}
+	mdm.On("InferDatatype", mock.Anything, mock.Anything).Return(datatype, nil)
+	mds.On("DefineDatatype", mock.Anything, datatype, false).Return(nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 202, res.Result().StatusCode)
+	mds.AssertExpectations(t)
+}
+
+func TestPostInferDatatypePublishSync(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	mds := &definitionsmocks.Sender{}
+	o.On("DefinitionSender").Return(mds)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/datatypes/infer?publish&confirm", newInferDatatypeRequest())
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	datatype := &core.Datatype{Name: "widget"}
+	mdm.On("InferDatatype", mock.Anything, mock.Anything).Return(datatype, nil)
+	mds.On("DefineDatatype", mock.Anything, datatype, true).Return(nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	mds.AssertExpectations(t)
+}
+
+func TestPostInferDatatypeFail(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mdm := &datamocks.Manager{}
+	o.On("Data").Return(mdm)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/datatypes/infer?publish", newInferDatatypeRequest())
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	mdm.On("InferDatatype", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 500, res.Result().StatusCode)
+}
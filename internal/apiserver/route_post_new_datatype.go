@@ -32,6 +32,7 @@ var postNewDatatype = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPostNewDatatype,
 	JSONInputValue:  func() interface{} { return &core.Datatype{} },
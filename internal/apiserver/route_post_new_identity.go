@@ -32,6 +32,7 @@ var postNewIdentity = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPostNewIdentity,
 	JSONInputValue:  func() interface{} { return &core.IdentityCreateDTO{} },
@@ -22,7 +22,6 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
-	"github.com/hyperledger/firefly/internal/orchestrator"
 	"github.com/hyperledger/firefly/pkg/core"
 )
 
@@ -33,18 +32,22 @@ var postNewMessageBroadcast = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
+		{Name: "pinonly", Description: coremsgs.APIPinOnlyQueryParam, IsBool: true},
 	},
 	Description:     coremsgs.APIEndpointsPostNewMessageBroadcast,
 	JSONInputValue:  func() interface{} { return &core.MessageInOut{} },
 	JSONOutputValue: func() interface{} { return &core.Message{} },
 	JSONOutputCodes: []int{http.StatusAccepted, http.StatusOK},
 	Extensions: &coreExtensions{
-		EnabledIf: func(or orchestrator.Orchestrator) bool {
-			return or.MultiParty() != nil
-		},
+		APIKeyScope:        core.APIKeyScopeMessaging,
+		RequiresMultiparty: true,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			waitConfirm := strings.EqualFold(r.QP["confirm"], "true")
 			r.SuccessStatus = syncRetcode(waitConfirm)
+			if strings.EqualFold(r.QP["pinonly"], "true") {
+				return cr.or.Broadcast().BroadcastPinOnlyMessage(cr.ctx, r.Input.(*core.MessageInOut), waitConfirm)
+			}
 			output, err = cr.or.Broadcast().BroadcastMessage(cr.ctx, r.Input.(*core.MessageInOut), waitConfirm)
 			return output, err
 		},
@@ -70,3 +70,24 @@ func TestPostNewMessageBroadcastSync(t *testing.T) {
 
 	assert.Equal(t, 200, res.Result().StatusCode)
 }
+
+func TestPostNewMessageBroadcastPinOnly(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mmp := &multipartymocks.Manager{}
+	o.On("MultiParty").Return(mmp)
+	mbm := &broadcastmocks.Manager{}
+	o.On("Broadcast").Return(mbm)
+	input := core.MessageInOut{}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/messages/broadcast?pinonly=true", &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	mbm.On("BroadcastPinOnlyMessage", mock.Anything, mock.AnythingOfType("*core.MessageInOut"), false).
+		Return(&core.Message{}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 202, res.Result().StatusCode)
+}
@@ -32,6 +32,7 @@ var postTokenApproval = &ffapi.Route{
 	PathParams: nil,
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description: coremsgs.APIEndpointsPostTokenApproval,
 	JSONInputValue: func() interface{} {
@@ -44,6 +45,7 @@ var postTokenApproval = &ffapi.Route{
 	JSONOutputValue: func() interface{} { return &core.TokenApproval{} },
 	JSONOutputCodes: []int{http.StatusAccepted, http.StatusOK},
 	Extensions: &coreExtensions{
+		APIKeyScope: core.APIKeyScopeTokens,
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
 			waitConfirm := strings.EqualFold(r.QP["confirm"], "true")
 			r.SuccessStatus = syncRetcode(waitConfirm)
@@ -34,6 +34,7 @@ var postTokenPoolPublish = &ffapi.Route{
 	},
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIEndpointsPostTokenPoolPublish,
 	JSONInputValue:  func() interface{} { return &core.DefinitionPublish{} },
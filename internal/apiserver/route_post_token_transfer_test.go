@@ -18,9 +18,11 @@ package apiserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly/mocks/assetmocks"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -46,3 +48,42 @@ func TestPostTokenTransfer(t *testing.T) {
 
 	assert.Equal(t, 202, res.Result().StatusCode)
 }
+
+func TestPostTokenTransferConfirmTimeout(t *testing.T) {
+	o, r := newTestAPIServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	mam := &assetmocks.Manager{}
+	o.On("Assets").Return(mam)
+	input := core.TokenTransferInput{}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/tokens/transfers?confirm=true&timeout=5s", &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Request-Timeout", "1h")
+	res := httptest.NewRecorder()
+
+	mam.On("TransferTokens", mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+		return ok && time.Until(deadline) <= 5*time.Second
+	}), mock.AnythingOfType("*core.TokenTransferInput"), true).
+		Return(&core.TokenTransfer{}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+	mam.AssertExpectations(t)
+}
+
+func TestPostTokenTransferBadConfirmTimeout(t *testing.T) {
+	_, r := newTestAPIServer()
+	input := core.TokenTransferInput{}
+	var buf bytes.Buffer
+	json.NewEncoder(&buf).Encode(&input)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/tokens/transfers?confirm=true&timeout=soon", &buf)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 400, res.Result().StatusCode)
+	assert.Regexp(t, "FF10528", res.Body.String())
+}
@@ -36,6 +36,7 @@ var putContractAPI = &ffapi.Route{
 	},
 	QueryParams: []*ffapi.QueryParam{
 		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
 	},
 	Description:     coremsgs.APIParamsContractAPIID,
 	JSONInputValue:  func() interface{} { return &core.ContractAPI{} },
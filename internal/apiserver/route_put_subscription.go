@@ -36,7 +36,9 @@ var putSubscription = &ffapi.Route{
 	JSONOutputCodes: []int{http.StatusOK}, // Sync operation
 	Extensions: &coreExtensions{
 		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
-			output, err = cr.or.CreateUpdateSubscription(cr.ctx, r.Input.(*core.Subscription))
+			sub := r.Input.(*core.Subscription)
+			sub.Owner = core.RequestPrincipal(r.Req)
+			output, err = cr.or.CreateUpdateSubscription(cr.ctx, sub)
 			return output, err
 		},
 	},
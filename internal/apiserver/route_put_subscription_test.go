@@ -35,9 +35,12 @@ func TestPutSubscription(t *testing.T) {
 	json.NewEncoder(&buf).Encode(&input)
 	req := httptest.NewRequest("PUT", "/api/v1/namespaces/ns1/subscriptions", &buf)
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.SetBasicAuth("user1", "pass1")
 	res := httptest.NewRecorder()
 
-	o.On("CreateUpdateSubscription", mock.Anything, mock.AnythingOfType("*core.Subscription")).
+	o.On("CreateUpdateSubscription", mock.Anything, mock.MatchedBy(func(sub *core.Subscription) bool {
+		return sub.Owner == "basic:user1"
+	})).
 		Return(&core.Subscription{}, nil)
 	r.ServeHTTP(res, req)
 
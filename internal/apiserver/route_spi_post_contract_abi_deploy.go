@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var spiPostContractABIDeploy = &ffapi.Route{
+	Name:   "spiPostContractABIDeploy",
+	Path:   "blockchain/abis/{abiid}/deploy",
+	Method: http.MethodPost,
+	PathParams: []*ffapi.PathParam{
+		{Name: "abiid", Description: coremsgs.APIParamsContractABIID},
+	},
+	QueryParams: []*ffapi.QueryParam{
+		{Name: "confirm", Description: coremsgs.APIConfirmMsgQueryParam, IsBool: true, Example: "true"},
+		{Name: "timeout", Description: coremsgs.APIConfirmTimeoutQueryParam, Example: "30s"},
+	},
+	Description:     coremsgs.APIEndpointsAdminPostContractABIDeploy,
+	JSONInputValue:  func() interface{} { return &core.ContractABIDeployRequest{} },
+	JSONOutputValue: func() interface{} { return &core.Operation{} },
+	JSONOutputCodes: []int{http.StatusOK, http.StatusAccepted},
+	Extensions: &coreExtensions{
+		EnabledIf: func(or orchestrator.Orchestrator) bool {
+			return or.Contracts() != nil
+		},
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			waitConfirm := strings.EqualFold(r.QP["confirm"], "true")
+			r.SuccessStatus = syncRetcode(waitConfirm)
+			req := r.Input.(*core.ContractABIDeployRequest)
+			req.ABI = r.PP["abiid"]
+			return cr.or.Contracts().DeployContractABI(cr.ctx, req, waitConfirm)
+		},
+	},
+}
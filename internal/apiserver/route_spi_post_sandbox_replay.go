@@ -0,0 +1,48 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var spiPostSandboxReplay = &ffapi.Route{
+	Name:            "spiPostSandboxReplay",
+	Path:            "sandbox/replay",
+	Method:          http.MethodPost,
+	PathParams:      nil,
+	QueryParams:     nil,
+	FilterFactory:   nil,
+	Description:     coremsgs.APIEndpointsAdminPostSandboxReplay,
+	JSONInputValue:  func() interface{} { return &core.SandboxReplayInput{} },
+	JSONOutputValue: func() interface{} { return &core.SandboxReplayResult{} },
+	JSONOutputCodes: []int{http.StatusOK},
+	Extensions: &coreExtensions{
+		CoreJSONHandler: func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error) {
+			input := r.Input.(*core.SandboxReplayInput)
+			source, err := cr.mgr.Orchestrator(cr.ctx, input.Source, false)
+			if err != nil {
+				return nil, err
+			}
+			return cr.or.ReplayToSandbox(cr.ctx, cr.apiBaseURL, source, input)
+		},
+	},
+}
@@ -0,0 +1,64 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/mocks/spieventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSPIPostSandboxReplay(t *testing.T) {
+	or, r := newTestSPIServer()
+	or.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("POST", "/spi/v1/namespaces/ns1/sandbox/replay", bytes.NewReader([]byte(`{"source":"default","definitions":true,"fromSequence":10}`)))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	or.On("ReplayToSandbox", mock.Anything, mock.Anything, or, mock.MatchedBy(func(input *core.SandboxReplayInput) bool {
+		return input.Source == "default" && input.Definitions && input.FromSequence == 10
+	})).Return(&core.SandboxReplayResult{}, nil)
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 200, res.Result().StatusCode)
+}
+
+func TestSPIPostSandboxReplayUnknownSource(t *testing.T) {
+	config.Set(coreconfig.NamespacesDefault, "default")
+	mgr, or, as := newTestServer()
+	mgr.On("SPIEvents").Return(&spieventsmocks.Manager{})
+	mgr.On("Orchestrator", mock.Anything, "unknown", false).Return(nil, i18n.NewError(context.Background(), coremsgs.Msg404NotFound))
+	r := as.createAdminMuxRouter(mgr)
+	or.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	req := httptest.NewRequest("POST", "/spi/v1/namespaces/ns1/sandbox/replay", bytes.NewReader([]byte(`{"source":"unknown"}`)))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 404, res.Result().StatusCode)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/namespace"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/pkg/core"
 )
 
 type coreRequest struct {
@@ -34,7 +35,14 @@ type coreRequest struct {
 }
 
 type coreExtensions struct {
-	EnabledIf             func(or orchestrator.Orchestrator) bool
+	// RequiresMultiparty rejects the route with a 409 in gateway (non-multiparty) namespaces
+	RequiresMultiparty bool
+	EnabledIf          func(or orchestrator.Orchestrator) bool
+	// APIKeyScope is the scope an API key must grant to call the route. When unset, GET routes require
+	// the read-only scope, and all other routes require the admin scope
+	APIKeyScope core.APIKeyScope
+	// ConditionalGet adds an ETag to a single record response, and honors If-None-Match with a 304
+	ConditionalGet        bool
 	CoreJSONHandler       func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)
 	CoreFormUploadHandler func(r *ffapi.APIRequest, cr *coreRequest) (output interface{}, err error)
 }
@@ -53,24 +61,36 @@ var routes = append(
 		getWebSockets,
 	}),
 	namespacedRoutes([]*ffapi.Route{
+		deleteAddressBookEntry,
+		deleteAPIKey,
 		deleteContractAPI,
 		deleteContractInterface,
 		deleteContractListener,
 		deleteData,
+		deleteQuarantineEntry,
 		deleteSubscription,
 		deleteTokenPool,
+		getAddressBook,
+		getAddressBookEntryByName,
+		getAPIKeyByID,
+		getAPIKeys,
+		getBatchAnchorProof,
 		getBatchByID,
 		getBatches,
+		getBatchPayload,
 		getBlockchainEventByID,
 		getBlockchainEvents,
+		getChartAggregate,
 		getChartHistogram,
 		getContractAPIByName,
 		getContractAPIInterface,
+		getContractAPIDiff,
 		getContractAPIs,
 		getContractAPIListeners,
 		getContractInterface,
 		getContractInterfaceNameVersion,
 		getContractInterfaces,
+		getContractSharedInterfaces,
 		getContractListenerByNameOrID,
 		getContractListeners,
 		getData,
@@ -81,9 +101,12 @@ var routes = append(
 		getDataMsgs,
 		getDatatypeByName,
 		getDatatypes,
+		getDelegationByID,
+		getDelegations,
 		getEventByID,
 		getEvents,
 		getGroupByHash,
+		getGroupMembersResolved,
 		getGroups,
 		getIdentities,
 		getIdentityByDID,
@@ -93,12 +116,14 @@ var routes = append(
 		getMsgByID,
 		getMsgData,
 		getMsgEvents,
+		getMsgProof,
 		getMsgs,
 		getMsgTxn,
 		getNetworkDIDDocByDID,
 		getNetworkIdentities,
 		getNetworkIdentityByDID,
 		getNetworkNode,
+		getNetworkNodeStatus,
 		getNetworkNodes,
 		getNetworkOrg,
 		getNetworkOrgs,
@@ -106,30 +131,45 @@ var routes = append(
 		getOpByID,
 		getOps,
 		getPins,
+		getQuarantineEntries,
+		getQuarantineEntryByID,
+		getQuarantinedMsgs,
+		getStats,
 		getStatus,
 		getStatusMultiparty,
 		getStatusBatchManager,
 		getSubscriptionByID,
 		getSubscriptions,
 		getSubscriptionEventsFiltered,
+		getSubscriptionSLA,
+		getTokenAccountByKey,
+		getTokenAccountHistory,
 		getTokenAccountPools,
 		getTokenAccounts,
 		getTokenApprovals,
 		getTokenBalances,
 		getTokenConnectors,
 		getTokenPoolByNameOrID,
+		getTokenPoolTemplates,
 		getTokenPools,
 		getTokenTransferByID,
 		getTokenTransfers,
 		getTxnBlockchainEvents,
 		getTxnByID,
+		getTxnCompensations,
+		getTxnEvents,
 		getTxnOps,
 		getTxns,
 		getTxnStatus,
 		getVerifierByID,
 		getVerifiers,
+		patchNodesSelf,
 		patchUpdateIdentity,
+		postAddressBookEntry,
+		postAPIKey,
 		postBatchCancel,
+		postBatchPayload,
+		postBatchVerify,
 		postContractAPIInvoke,
 		postContractAPIPublish,
 		postContractAPIQuery,
@@ -137,17 +177,23 @@ var routes = append(
 		postContractListenerSignature,
 		postContractInterfaceGenerate,
 		postContractInterfacePublish,
+		postContractInterfaceShare,
 		postContractDeploy,
 		postContractInvoke,
+		postContractInvokeBatch,
 		postContractQuery,
 		postData,
 		postDataBlobPublish,
+		postDataBulk,
 		postDataValuePublish,
+		postInferDatatype,
 		postNetworkAction,
+		postNetworkMigration,
 		postNewContractAPI,
 		postNewContractInterface,
 		postNewContractListener,
 		postNewDatatype,
+		postNewDelegation,
 		postNewIdentity,
 		postNewMessageBroadcast,
 		postNewMessagePrivate,
@@ -156,17 +202,26 @@ var routes = append(
 		postNewOrganization,
 		postNewOrganizationSelf,
 		postNodesSelf,
+		postNodeRevoke,
+		postOpAttachTX,
 		postOpRetry,
 		postPinsRewind,
+		postQuarantineEntryRevalidate,
+		postQuarantinedMsgReview,
+		postSubscriptionTest,
 		postTokenApproval,
 		postTokenBurn,
 		postTokenMint,
+		postTokenMintDistribute,
 		postTokenPool,
 		postTokenPoolPublish,
 		postTokenTransfer,
+		postTxnCompensate,
 		putContractAPI,
 		putSubscription,
 		postVerifiersResolve,
+		postVerifierRevoke,
+		postVerify,
 	})...,
 )
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -55,13 +56,18 @@ type Server interface {
 
 type apiServer struct {
 	// Defaults set with config
-	apiTimeout             time.Duration
-	apiMaxTimeout          time.Duration
-	metricsEnabled         bool
-	ffiSwaggerGen          FFISwaggerGen
-	apiPublicURL           string
-	dynamicPublicURLHeader string
-	defaultNamespace       string
+	apiTimeout              time.Duration
+	apiMaxTimeout           time.Duration
+	metricsEnabled          bool
+	ffiSwaggerGen           FFISwaggerGen
+	apiPublicURL            string
+	dynamicPublicURLHeader  string
+	defaultNamespace        string
+	maxRequestBodySize      int64
+	routeMaxRequestBodySize map[string]int64
+	apiKeyHeader            string
+	apiKeysRequired         bool
+	apiKeyBootstrapHash     string
 }
 
 func InitConfig() {
@@ -74,12 +80,25 @@ func InitConfig() {
 
 func NewAPIServer() Server {
 	as := &apiServer{
-		apiTimeout:             config.GetDuration(coreconfig.APIRequestTimeout),
-		apiMaxTimeout:          config.GetDuration(coreconfig.APIRequestMaxTimeout),
-		dynamicPublicURLHeader: config.GetString(coreconfig.APIDynamicPublicURLHeader),
-		defaultNamespace:       config.GetString(coreconfig.NamespacesDefault),
-		metricsEnabled:         config.GetBool(coreconfig.MetricsEnabled),
-		ffiSwaggerGen:          &ffiSwaggerGen{},
+		apiTimeout:              config.GetDuration(coreconfig.APIRequestTimeout),
+		apiMaxTimeout:           config.GetDuration(coreconfig.APIRequestMaxTimeout),
+		dynamicPublicURLHeader:  config.GetString(coreconfig.APIDynamicPublicURLHeader),
+		defaultNamespace:        config.GetString(coreconfig.NamespacesDefault),
+		metricsEnabled:          config.GetBool(coreconfig.MetricsEnabled),
+		ffiSwaggerGen:           &ffiSwaggerGen{},
+		maxRequestBodySize:      config.GetByteSize(coreconfig.APIMaxRequestBodySize),
+		routeMaxRequestBodySize: make(map[string]int64),
+		apiKeyHeader:            config.GetString(coreconfig.APIKeysHeader),
+		apiKeysRequired:         config.GetBool(coreconfig.APIKeysRequired),
+		apiKeyBootstrapHash:     strings.ToLower(config.GetString(coreconfig.APIKeysBootstrapKeyHash)),
+	}
+	for routeName, maxSize := range config.GetObject(coreconfig.APIRouteMaxRequestBodySize) {
+		switch v := maxSize.(type) {
+		case float64:
+			as.routeMaxRequestBodySize[strings.ToLower(routeName)] = int64(v)
+		default:
+			as.routeMaxRequestBodySize[strings.ToLower(routeName)] = fftypes.ParseToByteSize(fmt.Sprintf("%v", v))
+		}
 	}
 	as.apiPublicURL = as.getPublicURL(apiConfig, "")
 	return as
@@ -152,18 +171,26 @@ func (as *apiServer) getPublicURL(conf config.Section, pathPrefix string) string
 }
 
 func getOrchestrator(ctx context.Context, mgr namespace.Manager, tag string, r *ffapi.APIRequest) (or orchestrator.Orchestrator, err error) {
+	var ns string
 	switch tag {
 	case routeTagDefaultNamespace:
-		return mgr.Orchestrator(ctx, config.GetString(coreconfig.NamespacesDefault), false)
+		ns = config.GetString(coreconfig.NamespacesDefault)
 	case routeTagNonDefaultNamespace:
 		vars := mux.Vars(r.Req)
-		if ns, ok := vars["ns"]; ok {
-			return mgr.Orchestrator(ctx, ns, false)
+		var ok bool
+		if ns, ok = vars["ns"]; !ok {
+			return nil, i18n.NewError(ctx, coremsgs.MsgMissingNamespace)
 		}
 	case routeTagGlobal:
 		return nil, nil
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgMissingNamespace)
 	}
-	return nil, i18n.NewError(ctx, coremsgs.MsgMissingNamespace)
+	// Standby instances in a cluster only serve reads - state changes must go to the leader
+	if r.Req.Method != http.MethodGet && mgr.IsStandby(ns) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceStandby, ns)
+	}
+	return mgr.Orchestrator(ctx, ns, false)
 }
 
 func (as *apiServer) baseSwaggerGenOptions() ffapi.SwaggerGenOptions {
@@ -198,11 +225,26 @@ func (as *apiServer) getBaseURL(req *http.Request) string {
 	return baseURL
 }
 
+func checkRouteEnabled(ctx context.Context, ce *coreExtensions, or orchestrator.Orchestrator) error {
+	if ce.RequiresMultiparty && or.MultiParty() == nil {
+		return i18n.NewError(ctx, coremsgs.MsgMultipartyRequired)
+	}
+	if ce.EnabledIf != nil && !ce.EnabledIf(or) {
+		return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	return nil
+}
+
 func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manager, fixedBaseURL string, route *ffapi.Route) http.HandlerFunc {
 	// We extend the base ffapi functionality, with standardized DB filter support for all core resources.
 	// We also pass the Orchestrator context through
 	ce := route.Extensions.(*coreExtensions)
 	route.JSONHandler = func(r *ffapi.APIRequest) (output interface{}, err error) {
+		if timeout := r.QP["timeout"]; timeout != "" {
+			if _, err := fftypes.ParseDurationString(timeout, time.Second); err != nil {
+				return nil, i18n.WrapError(r.Req.Context(), err, coremsgs.MsgInvalidConfirmTimeout, timeout)
+			}
+		}
 		or, err := getOrchestrator(r.Req.Context(), mgr, route.Tag, r)
 		if err != nil {
 			return nil, err
@@ -219,9 +261,17 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 				return nil, err
 			}
 		}
+		if err := as.checkAPIKey(r, ce, or, fixedBaseURL != ""); err != nil {
+			return nil, err
+		}
 
-		if ce.EnabledIf != nil && !ce.EnabledIf(or) {
-			return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
+		if err := checkRouteEnabled(r.Req.Context(), ce, or); err != nil {
+			return nil, err
+		}
+
+		ctx, err := operationTagsContext(r)
+		if err != nil {
+			return nil, err
 		}
 
 		apiBaseURL := fixedBaseURL // for SPI
@@ -231,10 +281,14 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 		cr := &coreRequest{
 			mgr:        mgr,
 			or:         or,
-			ctx:        r.Req.Context(),
+			ctx:        ctx,
 			apiBaseURL: apiBaseURL,
 		}
-		return ce.CoreJSONHandler(r, cr)
+		output, err = ce.CoreJSONHandler(r, cr)
+		if err == nil && ce.ConditionalGet {
+			return conditionalGetOutput(r, output)
+		}
+		return output, err
 	}
 	if ce.CoreFormUploadHandler != nil {
 		route.FormUploadHandler = func(r *ffapi.APIRequest) (output interface{}, err error) {
@@ -242,8 +296,16 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			if err != nil {
 				return nil, err
 			}
-			if ce.EnabledIf != nil && !ce.EnabledIf(or) {
-				return nil, i18n.NewError(r.Req.Context(), coremsgs.MsgActionNotSupported)
+			if err := as.checkAPIKey(r, ce, or, fixedBaseURL != ""); err != nil {
+				return nil, err
+			}
+			if err := checkRouteEnabled(r.Req.Context(), ce, or); err != nil {
+				return nil, err
+			}
+
+			ctx, err := operationTagsContext(r)
+			if err != nil {
+				return nil, err
 			}
 
 			apiBaseURL := fixedBaseURL // for SPI
@@ -253,13 +315,26 @@ func (as *apiServer) routeHandler(hf *ffapi.HandlerFactory, mgr namespace.Manage
 			cr := &coreRequest{
 				mgr:        mgr,
 				or:         or,
-				ctx:        r.Req.Context(),
+				ctx:        ctx,
 				apiBaseURL: apiBaseURL,
 			}
 			return ce.CoreFormUploadHandler(r, cr)
 		}
 	}
-	return hf.RouteHandler(route)
+	return maxRequestBodySizeHandler(as.maxRequestBodySizeForRoute(route.Name), ce.CoreFormUploadHandler != nil,
+		confirmTimeoutHandler(hf.RouteHandler(route)))
+}
+
+// confirmTimeoutHandler allows a request that waits for confirmation to set its timeout with a
+// "timeout" query parameter, which is applied in the same way as the Request-Timeout header
+func confirmTimeoutHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(res http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if timeout := query.Get("timeout"); timeout != "" && strings.EqualFold(query.Get("confirm"), "true") {
+			req.Header.Set("Request-Timeout", timeout)
+		}
+		handler(res, req)
+	}
 }
 
 func (as *apiServer) handlerFactory() *ffapi.HandlerFactory {
@@ -309,7 +384,7 @@ func (as *apiServer) namespacedContractSwaggerGenerator(hf *ffapi.HandlerFactory
 		}
 		apiBaseURL := as.getBaseURL(req)
 		cm := or.Contracts()
-		api, err := cm.GetContractAPI(req.Context(), apiBaseURL, vars["apiName"])
+		api, err := cm.GetContractAPI(req.Context(), apiBaseURL, vars["apiName"], req.URL.Query().Get("version"))
 		if err != nil {
 			return -1, err
 		} else if api == nil || api.Interface == nil {
@@ -337,7 +412,11 @@ func (as *apiServer) namespacedContractSwaggerUI(hf *ffapi.HandlerFactory, r *mu
 			StaticPublicURL:        publicURL + "/api/v1/namespaces/" + vars["ns"],
 			DynamicPublicURLHeader: as.dynamicPublicURLHeader,
 		}
-		return oaf.SwaggerUIHandler(`/apis/`+vars["apiName"]+`/api/openapi.yaml`)(res, req)
+		openAPIPath := `/apis/` + vars["apiName"] + `/api/openapi.yaml`
+		if version := req.URL.Query().Get("version"); version != "" {
+			openAPIPath += "?version=" + url.QueryEscape(version)
+		}
+		return oaf.SwaggerUIHandler(openAPIPath)(res, req)
 	}))
 }
 
@@ -386,6 +465,10 @@ func (as *apiServer) createMuxRouter(ctx context.Context, mgr namespace.Manager)
 
 	r.HandleFunc(`/favicon{any:.*}.png`, favIcons)
 
+	// Kubernetes liveness and readiness probes
+	r.HandleFunc(`/live`, hf.APIWrapper(liveHandler)).Methods(http.MethodGet)
+	r.HandleFunc(`/ready`, hf.APIWrapper(readyHandler(mgr))).Methods(http.MethodGet)
+
 	ws, _ := eifactory.GetPlugin(ctx, "websockets")
 	ws.(*websockets.WebSockets).SetAuthorizer(mgr)
 	r.HandleFunc(`/ws`, ws.(*websockets.WebSockets).ServeHTTP)
@@ -60,6 +60,7 @@ func newTestServer() (*namespacemocks.Manager, *orchestratormocks.Orchestrator,
 	mgr.On("Orchestrator", mock.Anything, "default", false).Return(o, nil).Maybe()
 	mgr.On("Orchestrator", mock.Anything, "mynamespace", false).Return(o, nil).Maybe()
 	mgr.On("Orchestrator", mock.Anything, "ns1", false).Return(o, nil).Maybe()
+	mgr.On("IsStandby", mock.Anything).Return(false).Maybe()
 	config.Set(coreconfig.APIMaxFilterLimit, 100)
 	as := NewAPIServer().(*apiServer)
 	return mgr, o, as
@@ -337,7 +338,7 @@ func TestContractAPISwaggerJSON(t *testing.T) {
 	}
 	as.dynamicPublicURLHeader = "X-API-BaseURL"
 
-	mcm.On("GetContractAPI", mock.Anything, "http://mydomain.com/path/to/default", "my-api").Return(api, nil)
+	mcm.On("GetContractAPI", mock.Anything, "http://mydomain.com/path/to/default", "my-api", "").Return(api, nil)
 	mcm.On("GetFFIByIDWithChildren", mock.Anything, api.Interface.ID).Return(ffi, nil)
 	mffi.On("Build", mock.Anything, api, ffi).Return(&ffapi.SwaggerGenOptions{}, []*ffapi.Route{})
 
@@ -356,7 +357,7 @@ func TestContractAPISwaggerJSONGetAPIFail(t *testing.T) {
 	s := httptest.NewServer(r)
 	defer s.Close()
 
-	mcm.On("GetContractAPI", mock.Anything, "http://127.0.0.1:5000/api/v1/namespaces/default", "my-api").Return(nil, fmt.Errorf("pop"))
+	mcm.On("GetContractAPI", mock.Anything, "http://127.0.0.1:5000/api/v1/namespaces/default", "my-api", "").Return(nil, fmt.Errorf("pop"))
 
 	res, err := http.Get(fmt.Sprintf("http://%s/api/v1/namespaces/default/apis/my-api/api/swagger.json", s.Listener.Addr()))
 	assert.NoError(t, err)
@@ -371,7 +372,7 @@ func TestContractAPISwaggerJSONGetAPINotFound(t *testing.T) {
 	s := httptest.NewServer(r)
 	defer s.Close()
 
-	mcm.On("GetContractAPI", mock.Anything, "http://127.0.0.1:5000/api/v1/namespaces/default", "my-api").Return(nil, nil)
+	mcm.On("GetContractAPI", mock.Anything, "http://127.0.0.1:5000/api/v1/namespaces/default", "my-api", "").Return(nil, nil)
 
 	res, err := http.Get(fmt.Sprintf("http://%s/api/v1/namespaces/default/apis/my-api/api/swagger.json", s.Listener.Addr()))
 	assert.NoError(t, err)
@@ -392,7 +393,7 @@ func TestContractAPISwaggerJSONGetFFIFail(t *testing.T) {
 		},
 	}
 
-	mcm.On("GetContractAPI", mock.Anything, "http://127.0.0.1:5000/api/v1/namespaces/default", "my-api").Return(api, nil)
+	mcm.On("GetContractAPI", mock.Anything, "http://127.0.0.1:5000/api/v1/namespaces/default", "my-api", "").Return(api, nil)
 	mcm.On("GetFFIByIDWithChildren", mock.Anything, api.Interface.ID).Return(nil, fmt.Errorf("pop"))
 
 	res, err := http.Get(fmt.Sprintf("http://%s/api/v1/namespaces/default/apis/my-api/api/swagger.json", s.Listener.Addr()))
@@ -476,10 +477,10 @@ func TestJSONDisabledRoute(t *testing.T) {
 	s := httptest.NewServer(r)
 	defer s.Close()
 
-	o.On("PrivateMessaging").Return(nil)
+	o.On("Contracts").Return(nil)
 
 	var b bytes.Buffer
-	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/groups", &b)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/contracts/interfaces", &b)
 	req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	res := httptest.NewRecorder()
 
@@ -488,6 +489,26 @@ func TestJSONDisabledRoute(t *testing.T) {
 	assert.Equal(t, 400, res.Result().StatusCode)
 }
 
+func TestJSONMultipartyRequiredRoute(t *testing.T) {
+	mgr, o, as := newTestServer()
+	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
+	r := as.createMuxRouter(context.Background(), mgr)
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	o.On("MultiParty").Return(nil)
+
+	var b bytes.Buffer
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/groups", &b)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, 409, res.Result().StatusCode)
+	assert.Regexp(t, "FF10519", res.Body.String())
+}
+
 func TestFormDataDisabledRoute(t *testing.T) {
 	mgr, o, as := newTestServer()
 	o.On("Authorize", mock.Anything, mock.Anything).Return(nil)
@@ -575,3 +596,31 @@ func TestContractAPIDefaultNS(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 200, res.StatusCode())
 }
+
+func TestGetOrchestratorStandbyRejectsWrites(t *testing.T) {
+	mgr := &namespacemocks.Manager{}
+	mgr.On("IsStandby", "ns1").Return(true)
+	req := httptest.NewRequest("POST", "/api/v1/namespaces/ns1/messages/broadcast", nil)
+	req = mux.SetURLVars(req, map[string]string{"ns": "ns1"})
+	_, err := getOrchestrator(context.Background(), mgr, routeTagNonDefaultNamespace, &ffapi.APIRequest{Req: req})
+	assert.Regexp(t, "FF10525", err)
+	mgr.AssertExpectations(t)
+}
+
+func TestGetOrchestratorStandbyServesReads(t *testing.T) {
+	mgr := &namespacemocks.Manager{}
+	o := &orchestratormocks.Orchestrator{}
+	mgr.On("Orchestrator", mock.Anything, "ns1", false).Return(o, nil)
+	req := httptest.NewRequest("GET", "/api/v1/namespaces/ns1/messages", nil)
+	req = mux.SetURLVars(req, map[string]string{"ns": "ns1"})
+	or, err := getOrchestrator(context.Background(), mgr, routeTagNonDefaultNamespace, &ffapi.APIRequest{Req: req})
+	assert.NoError(t, err)
+	assert.Equal(t, o, or)
+	mgr.AssertExpectations(t)
+}
+
+func TestGetOrchestratorMissingNamespaceVar(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/v1/messages", nil)
+	_, err := getOrchestrator(context.Background(), &namespacemocks.Manager{}, routeTagNonDefaultNamespace, &ffapi.APIRequest{Req: req})
+	assert.Regexp(t, "FF10437", err)
+}
@@ -29,10 +29,22 @@ var spiRoutes = append(globalRoutes([]*ffapi.Route{
 	spiGetNamespaces,
 	spiGetOpByID,
 	spiPatchOpByID,
+	spiPostPluginRestart,
 	spiPostReset,
 }),
 	namespacedSPIRoutes([]*ffapi.Route{
+		spiGetContractABIByID,
+		spiGetContractABIs,
+		spiGetDefinitionsExport,
 		spiGetOps,
+		spiGetTransferLimits,
+		spiPostBlobsCollect,
+		spiPostContractABI,
+		spiPostContractABIDeploy,
+		spiGetRecovery,
+		spiPostDefinitionsImport,
+		spiPostSandboxReplay,
+		spiPutTransferLimits,
 	})...,
 )
 
@@ -0,0 +1,96 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// CompensateOperation reverses the effect of a succeeded token operation, by submitting a new transfer or approval
+// in a separate transaction. The compensating action is signed by the key that now holds the tokens, so it can
+// only succeed if that key is one this node is able to sign with.
+func (am *assetManager) CompensateOperation(ctx context.Context, op *core.Operation) (fftypes.JSONObject, bool, error) {
+	switch op.Type {
+	case core.OpTypeTokenTransfer:
+		transfer, err := txcommon.RetrieveTokenTransferInputs(ctx, op)
+		if err != nil {
+			return nil, false, err
+		}
+		input := &core.TokenTransferInput{
+			TokenTransfer: core.TokenTransfer{
+				TokenIndex: transfer.TokenIndex,
+				Amount:     transfer.Amount,
+				Key:        transfer.To,
+				From:       transfer.To,
+			},
+			Pool: transfer.Pool.String(),
+		}
+		var out *core.TokenTransfer
+		switch transfer.Type {
+		case core.TokenTransferTypeMint:
+			// Burn the tokens that were minted
+			out, err = am.BurnTokens(ctx, input, false)
+		case core.TokenTransferTypeTransfer:
+			// Return the tokens to their original holder
+			input.To = transfer.From
+			out, err = am.TransferTokens(ctx, input, false)
+		default:
+			// Burned tokens cannot be recovered
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return fftypes.JSONObject{
+			"type":    out.Type,
+			"localId": out.LocalID,
+			"tx":      out.TX.ID,
+		}, true, nil
+
+	case core.OpTypeTokenApproval:
+		approval, err := txcommon.RetrieveTokenApprovalInputs(ctx, op)
+		if err != nil {
+			return nil, false, err
+		}
+		if !approval.Approved {
+			// Only the granting of an approval is compensated
+			return nil, false, nil
+		}
+		out, err := am.TokenApproval(ctx, &core.TokenApprovalInput{
+			TokenApproval: core.TokenApproval{
+				Key:      approval.Key,
+				Operator: approval.Operator,
+				Approved: false,
+			},
+			Pool: approval.Pool.String(),
+		}, false)
+		if err != nil {
+			return nil, false, err
+		}
+		return fftypes.JSONObject{
+			"localId": out.LocalID,
+			"tx":      out.TX.ID,
+		}, true, nil
+
+	default:
+		return nil, false, nil
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/mocks/txcommonmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func compensationTestTransferOp(transfer *core.TokenTransfer) *core.Operation {
+	op := &core.Operation{
+		ID:     fftypes.NewUUID(),
+		Type:   core.OpTypeTokenTransfer,
+		Status: core.OpStatusSucceeded,
+	}
+	txcommon.AddTokenTransferInputs(op, transfer)
+	return op
+}
+
+func TestCompensateMintWithBurn(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+	op := compensationTestTransferOp(&core.TokenTransfer{
+		Type:   core.TokenTransferTypeMint,
+		Pool:   pool.ID,
+		Key:    "0xminter",
+		To:     "0xholder",
+		Amount: *fftypes.NewFFBigInt(5),
+	})
+	txID := fftypes.NewUUID()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mim.On("ResolveInputSigningKey", context.Background(), "0xholder", identity.KeyNormalizationBlockchainPlugin).Return("0xholder", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "0xholder").Return("0xholder", nil)
+	mdi.On("GetTokenPoolByID", context.Background(), "ns1", pool.ID).Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(txID, nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(transferData)
+		return data.Transfer.Type == core.TokenTransferTypeBurn &&
+			data.Transfer.From == "0xholder" &&
+			data.Transfer.Amount.Int().Int64() == 5
+	}), false).Return(nil, nil)
+
+	output, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.True(t, compensated)
+	assert.Equal(t, core.TokenTransferTypeBurn, output["type"])
+	assert.Equal(t, txID, output["tx"])
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestCompensateTransferWithReturnTransfer(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+	op := compensationTestTransferOp(&core.TokenTransfer{
+		Type:   core.TokenTransferTypeTransfer,
+		Pool:   pool.ID,
+		Key:    "0xsender",
+		From:   "0xsender",
+		To:     "0xrecipient",
+		Amount: *fftypes.NewFFBigInt(5),
+	})
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mim.On("ResolveInputSigningKey", context.Background(), "0xrecipient", identity.KeyNormalizationBlockchainPlugin).Return("0xrecipient", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "0xrecipient").Return("0xrecipient", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "0xsender").Return("0xsender", nil)
+	mdi.On("GetTokenPoolByID", context.Background(), "ns1", pool.ID).Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(transferData)
+		return data.Transfer.Type == core.TokenTransferTypeTransfer &&
+			data.Transfer.From == "0xrecipient" &&
+			data.Transfer.To == "0xsender"
+	}), false).Return(nil, nil)
+
+	_, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.True(t, compensated)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestCompensateTransferFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+	op := compensationTestTransferOp(&core.TokenTransfer{
+		Type: core.TokenTransferTypeMint,
+		Pool: pool.ID,
+		To:   "0xholder",
+	})
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mim.On("ResolveInputSigningKey", context.Background(), "0xholder", identity.KeyNormalizationBlockchainPlugin).Return("0xholder", nil).Maybe()
+	mdi.On("GetTokenPoolByID", context.Background(), "ns1", pool.ID).Return(pool, nil).Maybe()
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(nil, fmt.Errorf("pop"))
+
+	_, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+	assert.False(t, compensated)
+
+	mth.AssertExpectations(t)
+}
+
+func TestCompensateBurnSkipped(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	op := compensationTestTransferOp(&core.TokenTransfer{
+		Type: core.TokenTransferTypeBurn,
+		Pool: fftypes.NewUUID(),
+	})
+
+	_, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.False(t, compensated)
+}
+
+func TestCompensateTransferBadInput(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	op := &core.Operation{
+		Type:  core.OpTypeTokenTransfer,
+		Input: fftypes.JSONObject{"amount": "bad"},
+	}
+
+	_, _, err := am.CompensateOperation(context.Background(), op)
+	assert.Regexp(t, "FF00127", err)
+}
+
+func TestCompensateApprovalWithRevoke(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Locator:   "F1",
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeTokenApproval,
+	}
+	txcommon.AddTokenApprovalInputs(op, &core.TokenApproval{
+		Pool:     pool.ID,
+		Key:      "0xowner",
+		Operator: "0xoperator",
+		Approved: true,
+	})
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mim.On("ResolveInputSigningKey", context.Background(), "0xowner", identity.KeyNormalizationBlockchainPlugin).Return("0xowner", nil)
+	mdi.On("GetTokenPoolByID", context.Background(), "ns1", pool.ID).Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenApproval, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(approvalData)
+		return !data.Approval.Approved && data.Approval.Operator == "0xoperator"
+	}), false).Return(nil, nil)
+
+	_, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.True(t, compensated)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestCompensateApprovalFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeTokenApproval,
+	}
+	txcommon.AddTokenApprovalInputs(op, &core.TokenApproval{
+		Pool:     pool.ID,
+		Key:      "0xowner",
+		Approved: true,
+	})
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mim.On("ResolveInputSigningKey", context.Background(), "0xowner", identity.KeyNormalizationBlockchainPlugin).Return("0xowner", nil).Maybe()
+	mdi.On("GetTokenPoolByID", context.Background(), "ns1", pool.ID).Return(pool, nil).Maybe()
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenApproval, core.IdempotencyKey("")).Return(nil, fmt.Errorf("pop"))
+
+	_, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+	assert.False(t, compensated)
+
+	mth.AssertExpectations(t)
+}
+
+func TestCompensateRevokedApprovalSkipped(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeTokenApproval,
+	}
+	txcommon.AddTokenApprovalInputs(op, &core.TokenApproval{
+		Pool:     fftypes.NewUUID(),
+		Approved: false,
+	})
+
+	_, compensated, err := am.CompensateOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.False(t, compensated)
+}
+
+func TestCompensateApprovalBadInput(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	op := &core.Operation{
+		Type:  core.OpTypeTokenApproval,
+		Input: fftypes.JSONObject{"approved": "bad"},
+	}
+
+	_, _, err := am.CompensateOperation(context.Background(), op)
+	assert.Regexp(t, "FF00127", err)
+}
+
+func TestCompensateUnknownOperationSkipped(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	_, compensated, err := am.CompensateOperation(context.Background(), &core.Operation{Type: core.OpTypeTokenCreatePool})
+	assert.NoError(t, err)
+	assert.False(t, compensated)
+}
@@ -18,8 +18,10 @@ package assets
 
 import (
 	"context"
+	"io"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/broadcast"
@@ -49,16 +51,22 @@ type Manager interface {
 	GetTokenPoolByID(ctx context.Context, id *fftypes.UUID) (*core.TokenPool, error)
 	ResolvePoolMethods(ctx context.Context, pool *core.TokenPool) error
 	DeleteTokenPool(ctx context.Context, poolNameOrID string) error
+	GetTokenPoolTemplates(ctx context.Context) []*core.TokenPoolTemplate
+	SetTransferPolicy(policy TransferPolicy)
 
 	GetTokenBalances(ctx context.Context, filter ffapi.AndFilter) ([]*core.TokenBalance, *ffapi.FilterResult, error)
+	GetTokenBalancesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf *fftypes.FFTime) ([]*core.TokenBalance, *ffapi.FilterResult, error)
 	GetTokenAccounts(ctx context.Context, filter ffapi.AndFilter) ([]*core.TokenAccount, *ffapi.FilterResult, error)
 	GetTokenAccountPools(ctx context.Context, key string, filter ffapi.AndFilter) ([]*core.TokenAccountPool, *ffapi.FilterResult, error)
+	GetTokenAccountSummary(ctx context.Context, key string) (*core.TokenAccountSummary, error)
+	ExportTokenAccountHistory(ctx context.Context, poolNameOrID, key, format string) (io.ReadCloser, error)
 
 	GetTokenTransfers(ctx context.Context, filter ffapi.AndFilter) ([]*core.TokenTransfer, *ffapi.FilterResult, error)
 	GetTokenTransferByID(ctx context.Context, id string) (*core.TokenTransfer, error)
 
 	NewTransfer(transfer *core.TokenTransferInput) syncasync.Sender
 	MintTokens(ctx context.Context, transfer *core.TokenTransferInput, waitConfirm bool) (*core.TokenTransfer, error)
+	MintTokensDistribution(ctx context.Context, input *core.TokenMintDistributionInput) (*core.TokenMintDistribution, error)
 	BurnTokens(ctx context.Context, transfer *core.TokenTransferInput, waitConfirm bool) (*core.TokenTransfer, error)
 	TransferTokens(ctx context.Context, transfer *core.TokenTransferInput, waitConfirm bool) (*core.TokenTransfer, error)
 
@@ -91,9 +99,12 @@ type assetManager struct {
 	contracts        contracts.Manager
 	cache            cache.CInterface
 	keyNormalization int
+	poolTemplates    map[string]*core.TokenPoolTemplate
+	historyPageSize  int
+	transferPolicy   TransferPolicy
 }
 
-func NewAssetManager(ctx context.Context, ns, keyNormalization string, di database.Plugin, ti map[string]tokens.Plugin, im identity.Manager, sa syncasync.Bridge, bm broadcast.Manager, pm privatemessaging.Manager, mm metrics.Manager, om operations.Manager, cm contracts.Manager, txHelper txcommon.Helper, cacheManager cache.Manager) (Manager, error) {
+func NewAssetManager(ctx context.Context, ns, keyNormalization string, poolTemplates map[string]*core.TokenPoolTemplate, di database.Plugin, ti map[string]tokens.Plugin, im identity.Manager, sa syncasync.Bridge, bm broadcast.Manager, pm privatemessaging.Manager, mm metrics.Manager, om operations.Manager, cm contracts.Manager, txHelper txcommon.Helper, cacheManager cache.Manager) (Manager, error) {
 	if di == nil || im == nil || sa == nil || ti == nil || mm == nil || om == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "AssetManager")
 	}
@@ -109,9 +120,11 @@ func NewAssetManager(ctx context.Context, ns, keyNormalization string, di databa
 		messaging:        pm,
 		tokens:           ti,
 		keyNormalization: identity.ParseKeyNormalizationConfig(keyNormalization),
+		poolTemplates:    poolTemplates,
 		metrics:          mm,
 		operations:       om,
 		contracts:        cm,
+		historyPageSize:  tokenAccountHistoryPageSize,
 	}
 	if cacheManager != nil {
 		am.cache, err = cacheManager.GetCache(
@@ -126,12 +139,21 @@ func NewAssetManager(ctx context.Context, ns, keyNormalization string, di databa
 			return nil, err
 		}
 	}
+	if transferPolicyConfig.GetString(ffresty.HTTPConfigURL) != "" {
+		if am.transferPolicy, err = newHTTPTransferPolicy(ctx); err != nil {
+			return nil, err
+		}
+	}
 	om.RegisterHandler(ctx, am, []core.OpType{
 		core.OpTypeTokenCreatePool,
 		core.OpTypeTokenActivatePool,
 		core.OpTypeTokenTransfer,
 		core.OpTypeTokenApproval,
 	})
+	om.RegisterCompensation(ctx, am, []core.OpType{
+		core.OpTypeTokenTransfer,
+		core.OpTypeTokenApproval,
+	})
 	return am, nil
 }
 
@@ -54,6 +54,7 @@ func newTestAssetsWithMetrics(t *testing.T) (*assetManager, func()) {
 
 func newTestAssetsCommon(t *testing.T, metrics bool) (*assetManager, func()) {
 	coreconfig.Reset()
+	InitConfig()
 	mdi := &databasemocks.Plugin{}
 	mim := &identitymanagermocks.Manager{}
 	mdm := &datamocks.Manager{}
@@ -71,9 +72,10 @@ func newTestAssetsCommon(t *testing.T, metrics bool) (*assetManager, func()) {
 	mm.On("IsMetricsEnabled").Return(metrics)
 	mm.On("TransferSubmitted", mock.Anything)
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
+	mom.On("RegisterCompensation", mock.Anything, mock.Anything, mock.Anything)
 	mti.On("Name").Return("ut").Maybe()
 	ctx, cancel := context.WithCancel(ctx)
-	a, err := NewAssetManager(ctx, "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
+	a, err := NewAssetManager(ctx, "ns1", "blockchain_plugin", nil, mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything).Maybe()
 	rag.RunFn = func(a mock.Arguments) {
 		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
@@ -85,13 +87,14 @@ func newTestAssetsCommon(t *testing.T, metrics bool) (*assetManager, func()) {
 }
 
 func TestInitFail(t *testing.T) {
-	_, err := NewAssetManager(context.Background(), "", "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := NewAssetManager(context.Background(), "", "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
 func TestCacheInitFail(t *testing.T) {
 	cacheInitError := errors.New("Initialization error.")
 	coreconfig.Reset()
+	InitConfig()
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
@@ -106,7 +109,7 @@ func TestCacheInitFail(t *testing.T) {
 	cmi.On("GetCache", mock.Anything).Return(nil, cacheInitError)
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
 
-	_, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
+	_, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", nil, mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 
 	assert.Equal(t, cacheInitError, err)
 }
@@ -164,6 +167,7 @@ func TestGetTokenConnectors(t *testing.T) {
 
 func TestStart(t *testing.T) {
 	coreconfig.Reset()
+	InitConfig()
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
@@ -177,6 +181,7 @@ func TestStart(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(nil, nil)
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
+	mom.On("RegisterCompensation", mock.Anything, mock.Anything, mock.Anything)
 	mdi.On("GetTokenPools", mock.Anything, mock.Anything, mock.Anything).Return([]*core.TokenPool{
 		{
 			Connector: "hot_tokens",
@@ -186,7 +191,7 @@ func TestStart(t *testing.T) {
 	mti.On("StartNamespace", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mti.On("ConnectorName").Return("hot_tokens")
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
-	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
+	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", nil, mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	assert.NoError(t, err)
 	err = am.Start()
 	assert.NoError(t, err)
@@ -194,6 +199,7 @@ func TestStart(t *testing.T) {
 
 func TestStartDBError(t *testing.T) {
 	coreconfig.Reset()
+	InitConfig()
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
@@ -207,9 +213,10 @@ func TestStartDBError(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(nil, nil)
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
+	mom.On("RegisterCompensation", mock.Anything, mock.Anything, mock.Anything)
 	mdi.On("GetTokenPools", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
-	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
+	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", nil, mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	assert.NoError(t, err)
 	err = am.Start()
 	assert.Regexp(t, "pop", err)
@@ -217,6 +224,7 @@ func TestStartDBError(t *testing.T) {
 
 func TestStartError(t *testing.T) {
 	coreconfig.Reset()
+	InitConfig()
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
 	mim := &identitymanagermocks.Manager{}
@@ -230,6 +238,7 @@ func TestStartError(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(nil, nil)
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
+	mom.On("RegisterCompensation", mock.Anything, mock.Anything, mock.Anything)
 	mdi.On("GetTokenPools", mock.Anything, mock.Anything, mock.Anything).Return([]*core.TokenPool{
 		{
 			Connector: "hot_tokens",
@@ -239,7 +248,7 @@ func TestStartError(t *testing.T) {
 	mti.On("StartNamespace", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
 	mti.On("ConnectorName").Return("hot_tokens")
 	txHelper, _ := txcommon.NewTransactionHelper(context.Background(), "ns1", mdi, mdm, cmi)
-	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
+	am, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", nil, mdi, map[string]tokens.Plugin{"magic-tokens": mti}, mim, msa, mbm, mpm, mm, mom, mcm, txHelper, cmi)
 	assert.NoError(t, err)
 	err = am.Start()
 	assert.Regexp(t, "pop", err)
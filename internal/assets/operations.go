@@ -123,6 +123,9 @@ func (am *assetManager) RunOperation(ctx context.Context, op *core.PreparedOpera
 		if err != nil {
 			return nil, core.OpPhaseInitializing, err
 		}
+		if phase, err := am.checkTransferPolicy(ctx, op, data.Pool, data.Transfer); err != nil {
+			return nil, phase, err
+		}
 		switch data.Transfer.Type {
 		case core.TokenTransferTypeMint:
 			err = plugin.MintTokens(ctx, op.NamespacedIDString(), data.Pool.Locator, data.Transfer, data.Pool.Methods)
@@ -0,0 +1,103 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"database/sql/driver"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const tokenAccountRecentTransfers = 10
+
+// GetTokenAccountSummary aggregates the position of an account in every pool it has been active in,
+// across all token connectors in the namespace, along with its most recent transfers
+func (am *assetManager) GetTokenAccountSummary(ctx context.Context, key string) (*core.TokenAccountSummary, error) {
+	balances, err := am.database.GetTokenAccountBalanceSummaries(ctx, am.namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := am.database.GetTokenAccountTransferSummaries(ctx, am.namespace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &core.TokenAccountSummary{
+		Key:   key,
+		Pools: make([]*core.TokenAccountPoolSummary, 0, len(balances)),
+	}
+	byPool := make(map[fftypes.UUID]*core.TokenAccountPoolSummary)
+	for _, balance := range balances {
+		byPool[*balance.Pool] = balance
+		summary.Pools = append(summary.Pools, balance)
+	}
+	for _, transfer := range transfers {
+		existing, ok := byPool[*transfer.Pool]
+		if !ok {
+			byPool[*transfer.Pool] = transfer
+			summary.Pools = append(summary.Pools, transfer)
+			continue
+		}
+		existing.Transfers = transfer.Transfers
+		if isLater(transfer.LastActivity, existing.LastActivity) {
+			existing.LastActivity = transfer.LastActivity
+		}
+	}
+
+	if len(summary.Pools) > 0 {
+		poolIDs := make([]driver.Value, 0, len(summary.Pools))
+		for _, p := range summary.Pools {
+			poolIDs = append(poolIDs, p.Pool)
+		}
+		fb := database.TokenPoolQueryFactory.NewFilter(ctx)
+		pools, _, err := am.database.GetTokenPools(ctx, am.namespace, fb.In("id", poolIDs))
+		if err != nil {
+			return nil, err
+		}
+		for _, pool := range pools {
+			if p, ok := byPool[*pool.ID]; ok {
+				p.Name = pool.Name
+				p.Type = pool.Type
+				p.Decimals = pool.Decimals
+			}
+		}
+	}
+	sort.SliceStable(summary.Pools, func(i, j int) bool {
+		return isLater(summary.Pools[i].LastActivity, summary.Pools[j].LastActivity)
+	})
+
+	fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	summary.Recent, _, err = am.database.GetTokenTransfers(ctx, am.namespace, fb.Sort(tokenTransferChainOrder...).Descending().Limit(tokenAccountRecentTransfers).Or(
+		fb.Eq("from", key),
+		fb.Eq("to", key),
+	))
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func isLater(t1, t2 *fftypes.FFTime) bool {
+	if t1 == nil {
+		return false
+	}
+	return t2 == nil || t1.Time().After(*t2.Time())
+}
@@ -0,0 +1,220 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+
+	tokenAccountHistoryPageSize = 100
+)
+
+var tokenAccountHistoryCSVHeader = []string{
+	"created", "type", "localId", "tokenIndex", "from", "to", "amount", "change", "balance", "protocolId", "tx", "blockchainEvent",
+}
+
+type tokenAccountHistoryWriter interface {
+	start() error
+	writeEntry(entry *core.TokenAccountHistoryEntry) error
+	flush() error
+	finish() error
+}
+
+// ExportTokenAccountHistory streams every transfer into or out of the account within the pool, in the
+// order they were recorded, along with the running balance of the account after each one.
+// The history is read from the database a page at a time as the returned reader is consumed.
+func (am *assetManager) ExportTokenAccountHistory(ctx context.Context, poolNameOrID, key, format string) (io.ReadCloser, error) {
+	if format == "" {
+		format = ExportFormatJSON
+	}
+	if format != ExportFormatJSON && format != ExportFormatCSV {
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnsupportedExportFormat, format, "json, csv")
+	}
+	pool, err := am.GetTokenPoolByNameOrID(ctx, poolNameOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	r, w := io.Pipe()
+	var hw tokenAccountHistoryWriter
+	if format == ExportFormatCSV {
+		hw = &csvHistoryWriter{w: csv.NewWriter(w)}
+	} else {
+		hw = &jsonHistoryWriter{w: w}
+	}
+	go func() {
+		err := am.writeTokenAccountHistory(ctx, pool, key, hw)
+		if err != nil {
+			log.L(ctx).Errorf("Failed to export history of account '%s' in pool '%s': %s", key, pool.ID, err)
+		}
+		_ = w.CloseWithError(err)
+	}()
+	return r, nil
+}
+
+func (am *assetManager) writeTokenAccountHistory(ctx context.Context, pool *core.TokenPool, key string, hw tokenAccountHistoryWriter) error {
+	if err := hw.start(); err != nil {
+		return err
+	}
+	balance := new(big.Int)
+	skip := 0
+	for {
+		fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+		filter := fb.Sort(tokenTransferChainOrder...).Skip(uint64(skip)).Limit(uint64(am.historyPageSize)).And(
+			fb.Eq("pool", pool.ID),
+			fb.Or(
+				fb.Eq("from", key),
+				fb.Eq("to", key),
+			),
+		)
+		transfers, _, err := am.database.GetTokenTransfers(ctx, am.namespace, filter)
+		if err != nil {
+			return err
+		}
+		for _, transfer := range transfers {
+			if err := hw.writeEntry(newTokenAccountHistoryEntry(transfer, key, balance)); err != nil {
+				return err
+			}
+		}
+		if err := hw.flush(); err != nil {
+			return err
+		}
+		if len(transfers) < am.historyPageSize {
+			return hw.finish()
+		}
+		skip += len(transfers)
+	}
+}
+
+// tokenTransferChange is the signed change the transfer makes to the balance of the account.
+// A transfer from the account to itself leaves the balance unchanged.
+func tokenTransferChange(transfer *core.TokenTransfer, key string) *big.Int {
+	change := new(big.Int)
+	if transfer.To == key {
+		change.Add(change, transfer.Amount.Int())
+	}
+	if transfer.From == key {
+		change.Sub(change, transfer.Amount.Int())
+	}
+	return change
+}
+
+// newTokenAccountHistoryEntry applies the transfer to the running balance of the account
+func newTokenAccountHistoryEntry(transfer *core.TokenTransfer, key string, balance *big.Int) *core.TokenAccountHistoryEntry {
+	change := tokenTransferChange(transfer, key)
+	balance.Add(balance, change)
+	entry := &core.TokenAccountHistoryEntry{
+		Created:         transfer.Created,
+		Type:            transfer.Type,
+		LocalID:         transfer.LocalID,
+		TokenIndex:      transfer.TokenIndex,
+		From:            transfer.From,
+		To:              transfer.To,
+		Amount:          transfer.Amount,
+		ProtocolID:      transfer.ProtocolID,
+		TX:              transfer.TX.ID,
+		BlockchainEvent: transfer.BlockchainEvent,
+	}
+	entry.Change.Int().Set(change)
+	entry.Balance.Int().Set(balance)
+	return entry
+}
+
+type jsonHistoryWriter struct {
+	w       io.Writer
+	entries int
+}
+
+func (jw *jsonHistoryWriter) start() error {
+	_, err := jw.w.Write([]byte("["))
+	return err
+}
+
+func (jw *jsonHistoryWriter) writeEntry(entry *core.TokenAccountHistoryEntry) error {
+	b, _ := json.Marshal(entry)
+	if jw.entries > 0 {
+		b = append([]byte(","), b...)
+	}
+	jw.entries++
+	_, err := jw.w.Write(b)
+	return err
+}
+
+func (jw *jsonHistoryWriter) flush() error {
+	return nil
+}
+
+func (jw *jsonHistoryWriter) finish() error {
+	_, err := jw.w.Write([]byte("]"))
+	return err
+}
+
+type csvHistoryWriter struct {
+	w *csv.Writer
+}
+
+func csvString(v fmt.Stringer, isNil bool) string {
+	if isNil {
+		return ""
+	}
+	return v.String()
+}
+
+func (cw *csvHistoryWriter) start() error {
+	return cw.w.Write(tokenAccountHistoryCSVHeader)
+}
+
+func (cw *csvHistoryWriter) writeEntry(entry *core.TokenAccountHistoryEntry) error {
+	return cw.w.Write([]string{
+		csvString(entry.Created, entry.Created == nil),
+		entry.Type.String(),
+		csvString(entry.LocalID, entry.LocalID == nil),
+		entry.TokenIndex,
+		entry.From,
+		entry.To,
+		entry.Amount.String(),
+		entry.Change.String(),
+		entry.Balance.String(),
+		entry.ProtocolID,
+		csvString(entry.TX, entry.TX == nil),
+		csvString(entry.BlockchainEvent, entry.BlockchainEvent == nil),
+	})
+}
+
+func (cw *csvHistoryWriter) flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvHistoryWriter) finish() error {
+	return cw.flush()
+}
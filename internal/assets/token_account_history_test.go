@@ -0,0 +1,238 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type failingWriter struct {
+	writes int
+}
+
+func (fw *failingWriter) Write(b []byte) (int, error) {
+	if fw.writes <= 0 {
+		return 0, fmt.Errorf("pop")
+	}
+	fw.writes--
+	return len(b), nil
+}
+
+func newTestHistoryTransfer(transferType core.TokenTransferType, from, to string, amount int64) *core.TokenTransfer {
+	transfer := &core.TokenTransfer{
+		Type:            transferType,
+		LocalID:         fftypes.NewUUID(),
+		From:            from,
+		To:              to,
+		ProtocolID:      "000000000001/000000/000000",
+		Created:         fftypes.Now(),
+		TX:              core.TransactionRef{ID: fftypes.NewUUID()},
+		BlockchainEvent: fftypes.NewUUID(),
+	}
+	transfer.Amount.Int().SetInt64(amount)
+	return transfer
+}
+
+func matchHistoryPage(skip uint64) interface{} {
+	return mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, err := filter.Finalize()
+		return err == nil && fi.Skip == skip && fi.Limit == 2 &&
+			len(fi.Sort) == 4 && fi.Sort[0].Field == "blocknumber" && fi.Sort[3].Field == "sequence"
+	})
+}
+
+func TestExportTokenAccountHistoryJSON(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.historyPageSize = 2
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenPool", am.ctx, "ns1", "pool1").Return(pool, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", matchHistoryPage(0)).Return([]*core.TokenTransfer{
+		newTestHistoryTransfer(core.TokenTransferTypeMint, "", "0x1", 10),
+		newTestHistoryTransfer(core.TokenTransferTypeTransfer, "0x1", "0x2", 3),
+	}, nil, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", matchHistoryPage(2)).Return([]*core.TokenTransfer{
+		newTestHistoryTransfer(core.TokenTransferTypeTransfer, "0x1", "0x1", 5),
+	}, nil, nil)
+
+	r, err := am.ExportTokenAccountHistory(am.ctx, "pool1", "0x1", "")
+	assert.NoError(t, err)
+	defer r.Close()
+
+	var entries []*core.TokenAccountHistoryEntry
+	err = json.NewDecoder(r).Decode(&entries)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "10", entries[0].Change.String())
+	assert.Equal(t, "10", entries[0].Balance.String())
+	assert.Equal(t, "-3", entries[1].Change.String())
+	assert.Equal(t, "7", entries[1].Balance.String())
+	assert.Equal(t, "0", entries[2].Change.String())
+	assert.Equal(t, "7", entries[2].Balance.String())
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportTokenAccountHistoryJSONEmpty(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenPool", am.ctx, "ns1", "pool1").Return(pool, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+
+	r, err := am.ExportTokenAccountHistory(am.ctx, "pool1", "0x1", ExportFormatJSON)
+	assert.NoError(t, err)
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(b))
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportTokenAccountHistoryCSV(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.historyPageSize = 2
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	burn := newTestHistoryTransfer(core.TokenTransferTypeBurn, "0x1", "", 4)
+	burn.Created = nil
+	burn.LocalID = nil
+	burn.TX.ID = nil
+	burn.BlockchainEvent = nil
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenPool", am.ctx, "ns1", "pool1").Return(pool, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", matchHistoryPage(0)).Return([]*core.TokenTransfer{
+		newTestHistoryTransfer(core.TokenTransferTypeTransfer, "0x2", "0x1", 10),
+		burn,
+	}, nil, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", matchHistoryPage(2)).Return([]*core.TokenTransfer{}, nil, nil)
+
+	r, err := am.ExportTokenAccountHistory(am.ctx, "pool1", "0x1", ExportFormatCSV)
+	assert.NoError(t, err)
+	defer r.Close()
+
+	rows, err := csv.NewReader(r).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, tokenAccountHistoryCSVHeader, rows[0])
+	assert.Equal(t, []string{"transfer", "0x2", "0x1", "10", "10", "10"}, []string{rows[1][1], rows[1][4], rows[1][5], rows[1][6], rows[1][7], rows[1][8]})
+	assert.Equal(t, []string{"", "burn", "", "", "0x1", "", "4", "-4", "6", "000000000001/000000/000000", "", ""}, rows[2])
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportTokenAccountHistoryBadFormat(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	_, err := am.ExportTokenAccountHistory(am.ctx, "pool1", "0x1", "xml")
+	assert.Regexp(t, "FF10549", err)
+}
+
+func TestExportTokenAccountHistoryPoolFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenPool", am.ctx, "ns1", "pool1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.ExportTokenAccountHistory(am.ctx, "pool1", "0x1", ExportFormatCSV)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestExportTokenAccountHistoryQueryFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenPool", am.ctx, "ns1", "pool1").Return(pool, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	r, err := am.ExportTokenAccountHistory(am.ctx, "pool1", "0x1", ExportFormatJSON)
+	assert.NoError(t, err)
+	defer r.Close()
+
+	_, err = io.ReadAll(r)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWriteTokenAccountHistoryWriteFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{
+		newTestHistoryTransfer(core.TokenTransferTypeMint, "", "0x1", 10),
+	}, nil, nil)
+
+	err := am.writeTokenAccountHistory(am.ctx, pool, "0x1", &jsonHistoryWriter{w: &failingWriter{}})
+	assert.EqualError(t, err, "pop")
+
+	err = am.writeTokenAccountHistory(am.ctx, pool, "0x1", &jsonHistoryWriter{w: &failingWriter{writes: 1}})
+	assert.EqualError(t, err, "pop")
+
+	err = am.writeTokenAccountHistory(am.ctx, pool, "0x1", &csvHistoryWriter{w: csv.NewWriter(&failingWriter{})})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestWriteTokenAccountHistoryFinishFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+
+	err := am.writeTokenAccountHistory(am.ctx, pool, "0x1", &jsonHistoryWriter{w: &failingWriter{writes: 1}})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCSVHistoryWriterStartFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPool{ID: fftypes.NewUUID(), Name: "pool1"}
+	cw := csv.NewWriter(&failingWriter{})
+	cw.Comma = '"'
+
+	err := am.writeTokenAccountHistory(am.ctx, pool, "0x1", &csvHistoryWriter{w: cw})
+	assert.Error(t, err)
+}
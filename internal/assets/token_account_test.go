@@ -0,0 +1,146 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTokenAccountSummary(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	poolA := &core.TokenPool{ID: fftypes.NewUUID(), Name: "poolA", Type: core.TokenTypeFungible, Decimals: 18}
+	poolB := &core.TokenPool{ID: fftypes.NewUUID(), Name: "poolB", Type: core.TokenTypeNonFungible}
+	poolC := fftypes.NewUUID()
+	older := fftypes.FFTime(time.Now().Add(-1 * time.Hour))
+	newer := fftypes.Now()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenAccountBalanceSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{
+		{Pool: poolA.ID, Connector: "erc20", Balance: *fftypes.NewFFBigInt(10), Tokens: 1, LastActivity: &older},
+		{Pool: poolB.ID, Connector: "erc721", Balance: *fftypes.NewFFBigInt(2), Tokens: 2, LastActivity: &older},
+	}, nil)
+	mdi.On("GetTokenAccountTransferSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{
+		{Pool: poolB.ID, Connector: "erc721", Transfers: 3, LastActivity: newer},
+		{Pool: poolC, Connector: "erc20", Transfers: 1},
+	}, nil)
+	mdi.On("GetTokenPools", am.ctx, "ns1", mock.Anything).Return([]*core.TokenPool{poolA, poolB}, nil, nil)
+	recent := []*core.TokenTransfer{{LocalID: fftypes.NewUUID()}}
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return(recent, nil, nil)
+
+	summary, err := am.GetTokenAccountSummary(am.ctx, "0x1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1", summary.Key)
+	assert.Len(t, summary.Pools, 3)
+	assert.Equal(t, "poolB", summary.Pools[0].Name)
+	assert.Equal(t, core.TokenTypeNonFungible, summary.Pools[0].Type)
+	assert.Equal(t, int64(3), summary.Pools[0].Transfers)
+	assert.Equal(t, newer, summary.Pools[0].LastActivity)
+	assert.Equal(t, "poolA", summary.Pools[1].Name)
+	assert.Equal(t, 18, summary.Pools[1].Decimals)
+	assert.Equal(t, int64(0), summary.Pools[1].Transfers)
+	assert.Equal(t, poolC, summary.Pools[2].Pool)
+	assert.Empty(t, summary.Pools[2].Name)
+	assert.Equal(t, recent, summary.Recent)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenAccountSummaryEmpty(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenAccountBalanceSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{}, nil)
+	mdi.On("GetTokenAccountTransferSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{}, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return([]*core.TokenTransfer{}, nil, nil)
+
+	summary, err := am.GetTokenAccountSummary(am.ctx, "0x1")
+	assert.NoError(t, err)
+	assert.Empty(t, summary.Pools)
+	assert.Empty(t, summary.Recent)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenAccountSummaryBalancesFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenAccountBalanceSummaries", am.ctx, "ns1", "0x1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.GetTokenAccountSummary(am.ctx, "0x1")
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenAccountSummaryTransfersFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenAccountBalanceSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{}, nil)
+	mdi.On("GetTokenAccountTransferSummaries", am.ctx, "ns1", "0x1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.GetTokenAccountSummary(am.ctx, "0x1")
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenAccountSummaryPoolsFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenAccountBalanceSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{
+		{Pool: fftypes.NewUUID()},
+	}, nil)
+	mdi.On("GetTokenAccountTransferSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{}, nil)
+	mdi.On("GetTokenPools", am.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.GetTokenAccountSummary(am.ctx, "0x1")
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenAccountSummaryRecentFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenAccountBalanceSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{}, nil)
+	mdi.On("GetTokenAccountTransferSummaries", am.ctx, "ns1", "0x1").Return([]*core.TokenAccountPoolSummary{}, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.GetTokenAccountSummary(am.ctx, "0x1")
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// GetTokenBalancesAsOf returns the balances matching the filter as they stood at the given time.
+// The filter selects the accounts from the current balances, and the balance of each is then rebuilt
+// from the transfers recorded in its pool and token index up to (and including) that time.
+func (am *assetManager) GetTokenBalancesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf *fftypes.FFTime) ([]*core.TokenBalance, *ffapi.FilterResult, error) {
+	balances, fr, err := am.database.GetTokenBalances(ctx, am.namespace, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, balance := range balances {
+		if err := am.rebuildTokenBalance(ctx, balance, asOf); err != nil {
+			return nil, nil, err
+		}
+	}
+	return balances, fr, nil
+}
+
+func (am *assetManager) rebuildTokenBalance(ctx context.Context, balance *core.TokenBalance, asOf *fftypes.FFTime) error {
+	balance.Balance = fftypes.FFBigInt{}
+	balance.Updated = nil
+	skip := 0
+	for {
+		fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+		filter := fb.Sort(tokenTransferChainOrder...).Skip(uint64(skip)).Limit(uint64(am.historyPageSize)).And(
+			fb.Eq("pool", balance.Pool),
+			fb.Eq("tokenindex", balance.TokenIndex),
+			fb.Lte("created", asOf),
+			fb.Or(
+				fb.Eq("from", balance.Key),
+				fb.Eq("to", balance.Key),
+			),
+		)
+		transfers, _, err := am.database.GetTokenTransfers(ctx, am.namespace, filter)
+		if err != nil {
+			return err
+		}
+		for _, transfer := range transfers {
+			balance.Balance.Int().Add(balance.Balance.Int(), tokenTransferChange(transfer, balance.Key))
+			balance.Updated = transfer.Created
+		}
+		if len(transfers) < am.historyPageSize {
+			return nil
+		}
+		skip += len(transfers)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetTokenBalancesAsOf(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.historyPageSize = 2
+
+	pool := fftypes.NewUUID()
+	current := &core.TokenBalance{Pool: pool, Key: "0x1", Updated: fftypes.Now()}
+	current.Balance.Int().SetInt64(100)
+	asOf := fftypes.Now()
+	lastTransfer := newTestHistoryTransfer(core.TokenTransferTypeTransfer, "0x1", "0x1", 5)
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenBalances", am.ctx, "ns1", mock.Anything).Return([]*core.TokenBalance{current}, nil, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", matchHistoryPage(0)).Return([]*core.TokenTransfer{
+		newTestHistoryTransfer(core.TokenTransferTypeMint, "", "0x1", 10),
+		newTestHistoryTransfer(core.TokenTransferTypeTransfer, "0x1", "0x2", 3),
+	}, nil, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", matchHistoryPage(2)).Return([]*core.TokenTransfer{
+		lastTransfer,
+	}, nil, nil)
+
+	fb := database.TokenBalanceQueryFactory.NewFilter(am.ctx)
+	balances, _, err := am.GetTokenBalancesAsOf(am.ctx, fb.And(), asOf)
+	assert.NoError(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, int64(7), balances[0].Balance.Int().Int64())
+	assert.Equal(t, lastTransfer.Created, balances[0].Updated)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenBalancesAsOfNoTransfers(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	current := &core.TokenBalance{Pool: fftypes.NewUUID(), Key: "0x1", Updated: fftypes.Now()}
+	current.Balance.Int().SetInt64(100)
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenBalances", am.ctx, "ns1", mock.Anything).Return([]*core.TokenBalance{current}, nil, nil)
+	asOf := fftypes.Now()
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		fi, err := filter.Finalize()
+		return err == nil && strings.Contains(fi.String(), fmt.Sprintf("created <= %d", asOf.UnixNano()))
+	})).Return([]*core.TokenTransfer{}, nil, nil)
+
+	fb := database.TokenBalanceQueryFactory.NewFilter(am.ctx)
+	balances, _, err := am.GetTokenBalancesAsOf(am.ctx, fb.And(), asOf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), balances[0].Balance.Int().Int64())
+	assert.Nil(t, balances[0].Updated)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenBalancesAsOfTransfersFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenBalances", am.ctx, "ns1", mock.Anything).Return([]*core.TokenBalance{{Pool: fftypes.NewUUID(), Key: "0x1"}}, nil, nil)
+	mdi.On("GetTokenTransfers", am.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenBalanceQueryFactory.NewFilter(am.ctx)
+	_, _, err := am.GetTokenBalancesAsOf(am.ctx, fb.And(), fftypes.Now())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenBalancesAsOfBalancesFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenBalances", am.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenBalanceQueryFactory.NewFilter(am.ctx)
+	_, _, err := am.GetTokenBalancesAsOf(am.ctx, fb.And(), fftypes.Now())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
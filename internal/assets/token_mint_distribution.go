@@ -0,0 +1,145 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// MintTokensDistribution mints tokens and distributes them to a list of recipients, with every operation submitted
+// within a single transaction. The tokens of a fungible pool are minted to the signing key in one mint, followed by
+// a transfer to each recipient. Each recipient of a non-fungible pool has its token minted to it directly.
+// The operations are submitted in order, so the connector sequences the transfers after the mint.
+func (am *assetManager) MintTokensDistribution(ctx context.Context, input *core.TokenMintDistributionInput) (*core.TokenMintDistribution, error) {
+	if len(input.Recipients) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMintDistributionNoRecipients)
+	}
+	for i, r := range input.Recipients {
+		if r == nil || r.To == "" || r.Amount.Int().Sign() <= 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgMintDistributionBadRecipient, i)
+		}
+	}
+
+	txid, err := am.txHelper.SubmitNewTransaction(ctx, core.TransactionTypeTokenTransfer, input.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	result := &core.TokenMintDistribution{
+		TX: core.TransactionRef{ID: txid, Type: core.TransactionTypeTokenTransfer},
+	}
+
+	var pool *core.TokenPool
+	var ops []*core.Operation
+	err = am.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		transfers, err := am.expandDistribution(ctx, input)
+		if err != nil {
+			return err
+		}
+		for _, transfer := range transfers {
+			transfer.TX = result.TX
+			if pool, err = am.validateTransfer(ctx, transfer); err != nil {
+				return err
+			}
+			if transfer.Type == core.TokenTransferTypeTransfer && transfer.From == transfer.To {
+				return i18n.NewError(ctx, coremsgs.MsgCannotTransferToSelf)
+			}
+			plugin, err := am.selectTokenPlugin(ctx, transfer.Connector)
+			if err != nil {
+				return err
+			}
+			op := core.NewOperation(plugin, am.namespace, txid, core.OpTypeTokenTransfer)
+			if err = txcommon.AddTokenTransferInputs(op, &transfer.TokenTransfer); err == nil {
+				err = am.operations.AddOrReuseOperation(ctx, op)
+			}
+			if err != nil {
+				return err
+			}
+			ops = append(ops, op)
+			result.Transfers = append(result.Transfers, &transfer.TokenTransfer)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range ops {
+		if am.metrics.IsMetricsEnabled() {
+			am.metrics.TransferSubmitted(result.Transfers[i])
+		}
+		if _, err = am.operations.RunOperation(ctx, opTransfer(op, pool, result.Transfers[i]), input.IdempotencyKey != ""); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// expandDistribution builds the mints and transfers that make up a distribution, for the type of the target pool
+func (am *assetManager) expandDistribution(ctx context.Context, input *core.TokenMintDistributionInput) ([]*core.TokenTransferInput, error) {
+	newTransfer := func(transferType core.TokenTransferType, r *core.TokenMintRecipient) *core.TokenTransferInput {
+		return &core.TokenTransferInput{
+			TokenTransfer: core.TokenTransfer{
+				Type:       transferType,
+				LocalID:    fftypes.NewUUID(),
+				Namespace:  am.namespace,
+				Key:        input.Key,
+				To:         r.To,
+				Amount:     r.Amount,
+				TokenIndex: r.TokenIndex,
+				Config:     input.Config,
+			},
+			Pool: input.Pool,
+		}
+	}
+
+	var pool *core.TokenPool
+	var err error
+	if input.Pool == "" {
+		pool, err = am.getDefaultTokenPool(ctx)
+	} else {
+		pool, err = am.GetTokenPoolByNameOrID(ctx, input.Pool)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*core.TokenTransferInput, 0, len(input.Recipients)+1)
+	if pool.Type == core.TokenTypeNonFungible {
+		for _, r := range input.Recipients {
+			transfers = append(transfers, newTransfer(core.TokenTransferTypeMint, r))
+		}
+		return transfers, nil
+	}
+
+	// The total is minted to the signing key, which then transfers each share
+	total := new(big.Int)
+	for _, r := range input.Recipients {
+		total.Add(total, r.Amount.Int())
+		transfers = append(transfers, newTransfer(core.TokenTransferTypeTransfer, r))
+	}
+	mint := newTransfer(core.TokenTransferTypeMint, &core.TokenMintRecipient{})
+	mint.Amount = *fftypes.NewFFBigInt(0)
+	mint.Amount.Int().Set(total)
+	return append([]*core.TokenTransferInput{mint}, transfers...), nil
+}
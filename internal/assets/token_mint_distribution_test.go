@@ -0,0 +1,277 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/mocks/txcommonmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDistribution() *core.TokenMintDistributionInput {
+	return &core.TokenMintDistributionInput{
+		Pool: "pool1",
+		Recipients: []*core.TokenMintRecipient{
+			{To: "0x1111", Amount: *fftypes.NewFFBigInt(5)},
+			{To: "alice", Amount: *fftypes.NewFFBigInt(7)},
+		},
+		IdempotencyKey: "idem1",
+	}
+}
+
+func mockDistributionResolve(am *assetManager, pool *core.TokenPool) {
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "0x1111").Return("0x1111", nil).Maybe()
+	mim.On("ResolveAddressAlias", context.Background(), "alice").Return("0x2222", nil).Maybe()
+}
+
+func TestMintTokensDistributionFungible(t *testing.T) {
+	am, cancel := newTestAssetsWithMetrics(t)
+	defer cancel()
+
+	input := newTestDistribution()
+	pool := &core.TokenPool{
+		Type:      core.TokenTypeFungible,
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+	txID := fftypes.NewUUID()
+
+	mockDistributionResolve(am, pool)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(txID, nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeTokenTransfer && op.Transaction.Equals(txID)
+	})).Return(nil).Times(3)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		return op.Data.(transferData).Pool == pool
+	}), true).Return(nil, nil).Times(3)
+
+	result, err := am.MintTokensDistribution(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, txID, result.TX.ID)
+	assert.Len(t, result.Transfers, 3)
+
+	mint := result.Transfers[0]
+	assert.Equal(t, core.TokenTransferTypeMint, mint.Type)
+	assert.Equal(t, "0x12345", mint.To)
+	assert.Equal(t, int64(12), mint.Amount.Int().Int64())
+	for i, to := range []string{"0x1111", "0x2222"} {
+		transfer := result.Transfers[i+1]
+		assert.Equal(t, core.TokenTransferTypeTransfer, transfer.Type)
+		assert.Equal(t, "0x12345", transfer.From)
+		assert.Equal(t, to, transfer.To)
+		assert.Equal(t, input.Recipients[i].Amount.Int().Int64(), transfer.Amount.Int().Int64())
+		assert.Equal(t, txID, transfer.TX.ID)
+		assert.NotEqual(t, mint.LocalID, transfer.LocalID)
+	}
+
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionNonFungible(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	input := newTestDistribution()
+	input.Recipients[0].TokenIndex = "1"
+	input.Recipients[1].TokenIndex = "2"
+	pool := &core.TokenPool{
+		Type:      core.TokenTypeNonFungible,
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mockDistributionResolve(am, pool)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil).Times(2)
+	mom.On("RunOperation", context.Background(), mock.Anything, true).Return(nil, nil).Times(2)
+
+	result, err := am.MintTokensDistribution(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Len(t, result.Transfers, 2)
+	assert.Equal(t, core.TokenTransferTypeMint, result.Transfers[0].Type)
+	assert.Equal(t, "0x1111", result.Transfers[0].To)
+	assert.Equal(t, "1", result.Transfers[0].TokenIndex)
+	assert.Equal(t, core.TokenTransferTypeMint, result.Transfers[1].Type)
+	assert.Equal(t, "0x2222", result.Transfers[1].To)
+	assert.Equal(t, "2", result.Transfers[1].TokenIndex)
+
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionNoRecipients(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	_, err := am.MintTokensDistribution(context.Background(), &core.TokenMintDistributionInput{})
+	assert.Regexp(t, "FF10613", err)
+}
+
+func TestMintTokensDistributionBadRecipient(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	input := newTestDistribution()
+	input.Recipients[1].Amount = *fftypes.NewFFBigInt(0)
+
+	_, err := am.MintTokensDistribution(context.Background(), input)
+	assert.Regexp(t, "FF10614.*1", err)
+}
+
+func TestMintTokensDistributionTXFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.MintTokensDistribution(context.Background(), newTestDistribution())
+	assert.EqualError(t, err, "pop")
+
+	mth.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionPoolFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := am.MintTokensDistribution(context.Background(), newTestDistribution())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionDefaultPoolFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	input := newTestDistribution()
+	input.Pool = ""
+	mdi := am.database.(*databasemocks.Plugin)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mdi.On("GetTokenPools", context.Background(), "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := am.MintTokensDistribution(context.Background(), input)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionPoolNotActive(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(&core.TokenPool{Connector: "magic-tokens"}, nil)
+
+	_, err := am.MintTokensDistribution(context.Background(), newTestDistribution())
+	assert.Regexp(t, "FF10293", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionTransferToSelf(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	input := newTestDistribution()
+	input.Recipients[0].To = "0x12345"
+	mockDistributionResolve(am, &core.TokenPool{Connector: "magic-tokens", Active: true})
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mim.On("ResolveAddressAlias", context.Background(), "0x12345").Return("0x12345", nil)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil).Once()
+
+	_, err := am.MintTokensDistribution(context.Background(), input)
+	assert.Regexp(t, "FF10280", err)
+
+	mom.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionBadConnector(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mockDistributionResolve(am, &core.TokenPool{Connector: "bad", Active: true})
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+
+	_, err := am.MintTokensDistribution(context.Background(), newTestDistribution())
+	assert.Regexp(t, "FF10272.*bad", err)
+}
+
+func TestMintTokensDistributionAddOpFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mockDistributionResolve(am, &core.TokenPool{Connector: "magic-tokens", Active: true})
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := am.MintTokensDistribution(context.Background(), newTestDistribution())
+	assert.EqualError(t, err, "pop")
+
+	mom.AssertExpectations(t)
+}
+
+func TestMintTokensDistributionRunOpFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	mockDistributionResolve(am, &core.TokenPool{Connector: "magic-tokens", Active: true})
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.Anything, true).Return(nil, fmt.Errorf("pop")).Once()
+
+	_, err := am.MintTokensDistribution(context.Background(), newTestDistribution())
+	assert.EqualError(t, err, "pop")
+
+	mom.AssertExpectations(t)
+}
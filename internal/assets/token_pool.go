@@ -19,6 +19,7 @@ package assets
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -43,6 +44,12 @@ func (am *assetManager) CreateTokenPool(ctx context.Context, pool *core.TokenPoo
 	pool.ID = fftypes.NewUUID()
 	pool.Namespace = am.namespace
 
+	if pool.Template != "" {
+		if err := am.applyPoolTemplate(ctx, pool); err != nil {
+			return nil, err
+		}
+	}
+
 	if pool.Connector == "" {
 		connector, err := am.getDefaultTokenConnector(ctx)
 		if err != nil {
@@ -65,6 +72,43 @@ func (am *assetManager) CreateTokenPool(ctx context.Context, pool *core.TokenPoo
 	return am.createTokenPoolInternal(ctx, pool, waitConfirm)
 }
 
+// applyPoolTemplate fills in the fields of the pool that were not supplied on the request from the
+// named template. Keys supplied in the config of the request take precedence over those of the template.
+func (am *assetManager) applyPoolTemplate(ctx context.Context, pool *core.TokenPoolInput) error {
+	template := am.poolTemplates[pool.Template]
+	if template == nil {
+		return i18n.NewError(ctx, coremsgs.MsgTokenPoolTemplateNotFound, pool.Template)
+	}
+	if pool.Connector == "" {
+		pool.Connector = template.Connector
+	}
+	if pool.Type == "" {
+		pool.Type = template.Type
+	}
+	if len(template.Config) > 0 {
+		config := make(fftypes.JSONObject, len(template.Config)+len(pool.Config))
+		for k, v := range template.Config {
+			config[k] = v
+		}
+		for k, v := range pool.Config {
+			config[k] = v
+		}
+		pool.Config = config
+	}
+	return nil
+}
+
+func (am *assetManager) GetTokenPoolTemplates(ctx context.Context) []*core.TokenPoolTemplate {
+	templates := make([]*core.TokenPoolTemplate, 0, len(am.poolTemplates))
+	for _, template := range am.poolTemplates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates
+}
+
 func (am *assetManager) createTokenPoolInternal(ctx context.Context, pool *core.TokenPoolInput, waitConfirm bool) (*core.TokenPool, error) {
 	plugin, err := am.selectTokenPlugin(ctx, pool.Connector)
 	if err != nil {
@@ -121,6 +121,88 @@ func TestCreateTokenPoolDefaultConnectorSuccess(t *testing.T) {
 	mom.AssertExpectations(t)
 }
 
+func TestCreateTokenPoolFromTemplate(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.poolTemplates = map[string]*core.TokenPoolTemplate{
+		"stablecoin": {
+			Name:      "stablecoin",
+			Connector: "magic-tokens",
+			Type:      core.TokenTypeFungible,
+			Standard:  "ERC20",
+			Decimals:  6,
+			Config:    fftypes.JSONObject{"factory": "0x1234", "withData": true},
+		},
+	}
+
+	pool := &core.TokenPoolInput{
+		TokenPool: core.TokenPool{
+			Name:   "testpool",
+			Config: fftypes.JSONObject{"withData": false},
+		},
+		Template: "stablecoin",
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "testpool").Return(nil, nil)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("resolved-key", nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenPool, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(createPoolData)
+		return op.Type == core.OpTypeTokenCreatePool && data.Pool == &pool.TokenPool
+	}), false).Return(nil, nil)
+
+	_, err := am.CreateTokenPool(context.Background(), pool, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "magic-tokens", pool.Connector)
+	assert.Equal(t, core.TokenTypeFungible, pool.Type)
+	assert.Equal(t, fftypes.JSONObject{"factory": "0x1234", "withData": false}, pool.Config)
+	assert.Equal(t, fftypes.JSONObject{"factory": "0x1234", "withData": true}, am.poolTemplates["stablecoin"].Config)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestCreateTokenPoolTemplateNotFound(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	pool := &core.TokenPoolInput{
+		TokenPool: core.TokenPool{
+			Name: "testpool",
+		},
+		Template: "stablecoin",
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "testpool").Return(nil, nil)
+
+	_, err := am.CreateTokenPool(context.Background(), pool, false)
+	assert.Regexp(t, "FF10550", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetTokenPoolTemplates(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.poolTemplates = map[string]*core.TokenPoolTemplate{
+		"stablecoin":  {Name: "stablecoin"},
+		"collectible": {Name: "collectible"},
+	}
+
+	templates := am.GetTokenPoolTemplates(context.Background())
+	assert.Len(t, templates, 2)
+	assert.Equal(t, "collectible", templates[0].Name)
+	assert.Equal(t, "stablecoin", templates[1].Name)
+}
+
 func TestCreateTokenPoolIdempotentResubmit(t *testing.T) {
 	am, cancel := newTestAssets(t)
 	defer cancel()
@@ -29,6 +29,10 @@ import (
 	"github.com/hyperledger/firefly/pkg/core"
 )
 
+// tokenTransferChainOrder sorts transfers by their position on the chain, rather than the order they arrived.
+// Sequence breaks ties for transfers whose connector did not report a position.
+var tokenTransferChainOrder = []string{"blocknumber", "transactionindex", "logindex", "sequence"}
+
 func (am *assetManager) GetTokenTransfers(ctx context.Context, filter ffapi.AndFilter) ([]*core.TokenTransfer, *ffapi.FilterResult, error) {
 	return am.database.GetTokenTransfers(ctx, am.namespace, filter)
 }
@@ -109,11 +113,28 @@ func (am *assetManager) validateTransfer(ctx context.Context, transfer *core.Tok
 	if transfer.Key, err = am.identity.ResolveInputSigningKey(ctx, transfer.Key, am.keyNormalization); err != nil {
 		return nil, err
 	}
+	// The from/to accounts default to the signing key, or are resolved through the address book if supplied
 	if transfer.From == "" {
 		transfer.From = transfer.Key
+	} else if transfer.From, err = am.identity.ResolveAddressAlias(ctx, transfer.From); err != nil {
+		return nil, err
 	}
 	if transfer.To == "" {
 		transfer.To = transfer.Key
+	} else if transfer.To, err = am.identity.ResolveAddressAlias(ctx, transfer.To); err != nil {
+		return nil, err
+	}
+	if transfer.OnBehalfOf != "" {
+		// The identity that owns the signing key must hold a delegation from the identity it is acting for,
+		// and any message attached to the transfer is authored by that identity
+		author, err := am.identity.ResolveDelegatedAuthor(ctx, transfer.OnBehalfOf, transfer.Key, core.DelegationScopeTransfers)
+		if err != nil {
+			return nil, err
+		}
+		if transfer.Message != nil {
+			transfer.Message.Header.Author = author
+			transfer.Message.Header.Key = transfer.Key
+		}
 	}
 	return pool, nil
 }
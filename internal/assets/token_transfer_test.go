@@ -727,6 +727,8 @@ func TestTransferTokensSuccess(t *testing.T) {
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mom := am.operations.(*operationmocks.Manager)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
 	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
@@ -744,6 +746,216 @@ func TestTransferTokensSuccess(t *testing.T) {
 	mom.AssertExpectations(t)
 }
 
+func TestTransferTokensAddressBookAliases(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	transfer := &core.TokenTransferInput{
+		TokenTransfer: core.TokenTransfer{
+			From:   "treasury",
+			To:     "supplier",
+			Amount: *fftypes.NewFFBigInt(5),
+		},
+		Pool: "pool1",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "treasury").Return("0x1111", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "supplier").Return("0x2222", nil)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(transferData)
+		return data.Transfer.From == "0x1111" && data.Transfer.To == "0x2222"
+	}), false).Return(nil, nil)
+
+	_, err := am.TransferTokens(context.Background(), transfer, false)
+	assert.NoError(t, err)
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestTransferTokensFromAliasFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	transfer := &core.TokenTransferInput{
+		TokenTransfer: core.TokenTransfer{
+			From:   "treasury",
+			To:     "supplier",
+			Amount: *fftypes.NewFFBigInt(5),
+		},
+		Pool: "pool1",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "treasury").Return("", fmt.Errorf("pop"))
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	_, err := am.TransferTokens(context.Background(), transfer, false)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mth.AssertExpectations(t)
+}
+
+func TestTransferTokensToAliasFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	transfer := &core.TokenTransferInput{
+		TokenTransfer: core.TokenTransfer{
+			From:   "treasury",
+			To:     "supplier",
+			Amount: *fftypes.NewFFBigInt(5),
+		},
+		Pool: "pool1",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "treasury").Return("0x1111", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "supplier").Return("", fmt.Errorf("pop"))
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	_, err := am.TransferTokens(context.Background(), transfer, false)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mth.AssertExpectations(t)
+}
+
+func TestTransferTokensOnBehalfOf(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	transfer := &core.TokenTransferInput{
+		TokenTransfer: core.TokenTransfer{
+			To:     "0x2222",
+			Amount: *fftypes.NewFFBigInt(5),
+		},
+		Pool:       "pool1",
+		OnBehalfOf: "customer",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mom := am.operations.(*operationmocks.Manager)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "0x2222").Return("0x2222", nil)
+	mim.On("ResolveDelegatedAuthor", context.Background(), "customer", "0x12345", core.DelegationScopeTransfers).Return("did:firefly:org/customer", nil)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
+	mom.On("RunOperation", context.Background(), mock.Anything, false).Return(nil, nil)
+
+	_, err := am.TransferTokens(context.Background(), transfer, false)
+	assert.NoError(t, err)
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestTransferTokensOnBehalfOfNotDelegated(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	transfer := &core.TokenTransferInput{
+		TokenTransfer: core.TokenTransfer{
+			Amount: *fftypes.NewFFBigInt(5),
+		},
+		Pool:       "pool1",
+		OnBehalfOf: "customer",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mth := am.txHelper.(*txcommonmocks.Helper)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveDelegatedAuthor", context.Background(), "customer", "0x12345", core.DelegationScopeTransfers).Return("", fmt.Errorf("pop"))
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	_, err := am.TransferTokens(context.Background(), transfer, false)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mth.AssertExpectations(t)
+}
+
+func TestValidateTransferOnBehalfOfWithMessage(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	transfer := &core.TokenTransferInput{
+		TokenTransfer: core.TokenTransfer{
+			Amount: *fftypes.NewFFBigInt(5),
+		},
+		Pool:       "pool1",
+		OnBehalfOf: "customer",
+		Message:    &core.MessageInOut{},
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Active:    true,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mim := am.identity.(*identitymanagermocks.Manager)
+	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveDelegatedAuthor", context.Background(), "customer", "0x12345", core.DelegationScopeTransfers).Return("did:firefly:org/customer", nil)
+	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
+
+	_, err := am.validateTransfer(context.Background(), transfer)
+	assert.NoError(t, err)
+	assert.Equal(t, "did:firefly:org/customer", transfer.Message.Header.Author)
+	assert.Equal(t, "0x12345", transfer.Message.Header.Key)
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
 func TestTransferTokensUnconfirmedPool(t *testing.T) {
 	am, cancel := newTestAssets(t)
 	defer cancel()
@@ -899,6 +1111,8 @@ func TestTransferTokensWithBroadcastMessage(t *testing.T) {
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mom := am.operations.(*operationmocks.Manager)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
 	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
@@ -1002,6 +1216,8 @@ func TestTransferTokensWithBroadcastMessageSendFail(t *testing.T) {
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mom := am.operations.(*operationmocks.Manager)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
 	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
@@ -1099,6 +1315,8 @@ func TestTransferTokensWithPrivateMessage(t *testing.T) {
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mom := am.operations.(*operationmocks.Manager)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
 	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
@@ -1222,6 +1440,8 @@ func TestTransferTokensConfirm(t *testing.T) {
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mom := am.operations.(*operationmocks.Manager)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
 	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
@@ -1287,6 +1507,8 @@ func TestTransferTokensWithBroadcastConfirm(t *testing.T) {
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mom := am.operations.(*operationmocks.Manager)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mom.On("AddOrReuseOperation", context.Background(), mock.Anything).Return(nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
@@ -1374,6 +1596,8 @@ func TestTransferPrepare(t *testing.T) {
 	mim := am.identity.(*identitymanagermocks.Manager)
 	mth := am.txHelper.(*txcommonmocks.Helper)
 	mim.On("ResolveInputSigningKey", context.Background(), "", identity.KeyNormalizationBlockchainPlugin).Return("0x12345", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "A").Return("A", nil)
+	mim.On("ResolveAddressAlias", context.Background(), "B").Return("B", nil)
 	mdi.On("GetTokenPool", context.Background(), "ns1", "pool1").Return(pool, nil)
 	mth.On("SubmitNewTransaction", context.Background(), core.TransactionTypeTokenTransfer, core.IdempotencyKey("idem1")).Return(fftypes.NewUUID(), nil)
 
@@ -0,0 +1,122 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/proxy"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// TransferPolicy is consulted before each mint, burn or transfer is submitted to a token connector,
+// and can deny the request (for example, for compliance checks on the parties or the amount)
+type TransferPolicy interface {
+	CheckTransfer(ctx context.Context, pool *core.TokenPool, transfer *core.TokenTransfer) (*TransferPolicyDecision, error)
+}
+
+// TransferPolicyDecision is the result of a transfer policy check
+type TransferPolicyDecision struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// transferPolicyRequest is the payload sent to an external transfer policy service
+type transferPolicyRequest struct {
+	Namespace  string                 `json:"namespace"`
+	Type       core.TokenTransferType `json:"type"`
+	Pool       *fftypes.UUID          `json:"pool"`
+	PoolName   string                 `json:"poolName"`
+	Connector  string                 `json:"connector"`
+	TokenIndex string                 `json:"tokenIndex,omitempty"`
+	Key        string                 `json:"key"`
+	From       string                 `json:"from,omitempty"`
+	To         string                 `json:"to,omitempty"`
+	Amount     fftypes.FFBigInt       `json:"amount"`
+	LocalID    *fftypes.UUID          `json:"localId"`
+	TX         core.TransactionRef    `json:"tx"`
+}
+
+type httpTransferPolicy struct {
+	client *resty.Client
+}
+
+func newHTTPTransferPolicy(ctx context.Context) (TransferPolicy, error) {
+	client, err := proxy.NewRESTClient(ctx, transferPolicyConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &httpTransferPolicy{client: client}, nil
+}
+
+func (p *httpTransferPolicy) CheckTransfer(ctx context.Context, pool *core.TokenPool, transfer *core.TokenTransfer) (*TransferPolicyDecision, error) {
+	var decision TransferPolicyDecision
+	res, err := p.client.R().
+		SetContext(ctx).
+		SetBody(&transferPolicyRequest{
+			Namespace:  pool.Namespace,
+			Type:       transfer.Type,
+			Pool:       pool.ID,
+			PoolName:   pool.Name,
+			Connector:  pool.Connector,
+			TokenIndex: transfer.TokenIndex,
+			Key:        transfer.Key,
+			From:       transfer.From,
+			To:         transfer.To,
+			Amount:     transfer.Amount,
+			LocalID:    transfer.LocalID,
+			TX:         transfer.TX,
+		}).
+		SetResult(&decision).
+		Post("")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgTransferPolicyRESTErr)
+	}
+	return &decision, nil
+}
+
+func (am *assetManager) SetTransferPolicy(policy TransferPolicy) {
+	am.transferPolicy = policy
+}
+
+// checkTransferPolicy returns an error if the configured policy fails or denies the transfer.
+// A denial is recorded as a transfer_denied event, and returned with a complete phase so the operation is marked failed.
+func (am *assetManager) checkTransferPolicy(ctx context.Context, op *core.PreparedOperation, pool *core.TokenPool, transfer *core.TokenTransfer) (core.OpPhase, error) {
+	if am.transferPolicy == nil {
+		return core.OpPhaseInitializing, nil
+	}
+	decision, err := am.transferPolicy.CheckTransfer(ctx, pool, transfer)
+	if err != nil {
+		return core.OpPhaseInitializing, err
+	}
+	if decision.Approved {
+		return core.OpPhaseInitializing, nil
+	}
+	log.L(ctx).Warnf("Token %s %s denied by transfer policy: %s", transfer.Type, transfer.LocalID, decision.Reason)
+	event := core.NewEvent(core.EventTypeTransferDenied, am.namespace, op.ID, transfer.TX.ID, pool.ID.String())
+	event.Correlator = transfer.LocalID
+	if err := am.database.InsertEvent(ctx, event); err != nil {
+		return core.OpPhaseInitializing, err
+	}
+	return core.OpPhaseComplete, i18n.NewError(ctx, coremsgs.MsgTransferDenied, transfer.Type, decision.Reason)
+}
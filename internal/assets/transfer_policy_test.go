@@ -0,0 +1,293 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/mocks/operationmocks"
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/mocks/tokenmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/tokens"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testTransferPolicy struct {
+	decision *TransferPolicyDecision
+	err      error
+}
+
+func (p *testTransferPolicy) CheckTransfer(ctx context.Context, pool *core.TokenPool, transfer *core.TokenTransfer) (*TransferPolicyDecision, error) {
+	return p.decision, p.err
+}
+
+func newTestTransferPolicyAssets(t *testing.T, url string) (*assetManager, error) {
+	transferPolicyConfig.Set(ffresty.HTTPConfigURL, url)
+	mdi := &databasemocks.Plugin{}
+	mom := &operationmocks.Manager{}
+	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
+	mom.On("RegisterCompensation", mock.Anything, mock.Anything, mock.Anything)
+	a, err := NewAssetManager(context.Background(), "ns1", "blockchain_plugin", nil, mdi, map[string]tokens.Plugin{"magic-tokens": &tokenmocks.Plugin{}}, &identitymanagermocks.Manager{}, &syncasyncmocks.Bridge{}, nil, nil, &metricsmocks.Manager{}, mom, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return a.(*assetManager), nil
+}
+
+func TestHTTPTransferPolicyApproved(t *testing.T) {
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "pool1",
+		Connector: "magic-tokens",
+	}
+	transfer := &core.TokenTransfer{
+		Type:    core.TokenTransferTypeTransfer,
+		LocalID: fftypes.NewUUID(),
+		Key:     "0x01",
+		From:    "0x01",
+		To:      "0x02",
+		Amount:  *fftypes.NewFFBigInt(5),
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req transferPolicyRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "ns1", req.Namespace)
+		assert.Equal(t, core.TokenTransferTypeTransfer, req.Type)
+		assert.Equal(t, pool.ID, req.Pool)
+		assert.Equal(t, "pool1", req.PoolName)
+		assert.Equal(t, "0x01", req.From)
+		assert.Equal(t, "0x02", req.To)
+		assert.Equal(t, int64(5), req.Amount.Int().Int64())
+		assert.Equal(t, transfer.LocalID, req.LocalID)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"approved":true}`))
+	}))
+	defer server.Close()
+
+	coreconfig.Reset()
+	InitConfig()
+	am, err := newTestTransferPolicyAssets(t, server.URL)
+	assert.NoError(t, err)
+	assert.NotNil(t, am.transferPolicy)
+
+	decision, err := am.transferPolicy.CheckTransfer(context.Background(), pool, transfer)
+	assert.NoError(t, err)
+	assert.True(t, decision.Approved)
+}
+
+func TestHTTPTransferPolicyDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"approved":false,"reason":"sanctioned address"}`))
+	}))
+	defer server.Close()
+
+	coreconfig.Reset()
+	InitConfig()
+	am, err := newTestTransferPolicyAssets(t, server.URL)
+	assert.NoError(t, err)
+
+	decision, err := am.transferPolicy.CheckTransfer(context.Background(), &core.TokenPool{}, &core.TokenTransfer{})
+	assert.NoError(t, err)
+	assert.False(t, decision.Approved)
+	assert.Equal(t, "sanctioned address", decision.Reason)
+}
+
+func TestHTTPTransferPolicyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`pop`))
+	}))
+	defer server.Close()
+
+	coreconfig.Reset()
+	InitConfig()
+	am, err := newTestTransferPolicyAssets(t, server.URL)
+	assert.NoError(t, err)
+
+	_, err = am.transferPolicy.CheckTransfer(context.Background(), &core.TokenPool{}, &core.TokenTransfer{})
+	assert.Regexp(t, "FF10553", err)
+}
+
+func TestHTTPTransferPolicyInitFail(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	tlsConfig := transferPolicyConfig.SubSection("tls")
+	tlsConfig.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConfig.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+
+	_, err := newHTTPTransferPolicy(context.Background())
+	assert.Error(t, err)
+}
+
+func TestNewAssetManagerTransferPolicyFail(t *testing.T) {
+	coreconfig.Reset()
+	InitConfig()
+	tlsConfig := transferPolicyConfig.SubSection("tls")
+	tlsConfig.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConfig.Set(fftls.HTTPConfTLSCAFile, "!!!badness")
+
+	_, err := newTestTransferPolicyAssets(t, "http://localhost:12345")
+	assert.Error(t, err)
+}
+
+func TestSetTransferPolicy(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+
+	assert.Nil(t, am.transferPolicy)
+	policy := &testTransferPolicy{}
+	am.SetTransferPolicy(policy)
+	assert.Equal(t, policy, am.transferPolicy)
+}
+
+func TestRunOperationTransferPolicyApproved(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.SetTransferPolicy(&testTransferPolicy{decision: &TransferPolicyDecision{Approved: true}})
+
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Locator:   "F1",
+	}
+	transfer := &core.TokenTransfer{
+		Type: core.TokenTransferTypeMint,
+	}
+
+	mti := am.tokens["magic-tokens"].(*tokenmocks.Plugin)
+	mti.On("MintTokens", context.Background(), "ns1:"+op.ID.String(), "F1", transfer, (*fftypes.JSONAny)(nil)).Return(nil)
+
+	_, phase, err := am.RunOperation(context.Background(), opTransfer(op, pool, transfer))
+
+	assert.Equal(t, core.OpPhasePending, phase)
+	assert.NoError(t, err)
+
+	mti.AssertExpectations(t)
+}
+
+func TestRunOperationTransferPolicyDenied(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.SetTransferPolicy(&testTransferPolicy{decision: &TransferPolicyDecision{Approved: false, Reason: "limit exceeded"}})
+
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Connector: "magic-tokens",
+		Locator:   "F1",
+	}
+	transfer := &core.TokenTransfer{
+		Type:    core.TokenTransferTypeTransfer,
+		LocalID: fftypes.NewUUID(),
+		TX: core.TransactionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeTransferDenied &&
+			*event.Reference == *op.ID &&
+			*event.Correlator == *transfer.LocalID &&
+			*event.Transaction == *transfer.TX.ID &&
+			event.Topic == pool.ID.String()
+	})).Return(nil)
+
+	_, phase, err := am.RunOperation(context.Background(), opTransfer(op, pool, transfer))
+
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.Regexp(t, "FF10552.*limit exceeded", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRunOperationTransferPolicyDeniedEventFail(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.SetTransferPolicy(&testTransferPolicy{decision: &TransferPolicyDecision{Approved: false}})
+
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Connector: "magic-tokens",
+		Locator:   "F1",
+	}
+	transfer := &core.TokenTransfer{
+		Type: core.TokenTransferTypeBurn,
+	}
+
+	mdi := am.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, phase, err := am.RunOperation(context.Background(), opTransfer(op, pool, transfer))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRunOperationTransferPolicyError(t *testing.T) {
+	am, cancel := newTestAssets(t)
+	defer cancel()
+	am.SetTransferPolicy(&testTransferPolicy{err: fmt.Errorf("pop")})
+
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	pool := &core.TokenPool{
+		Connector: "magic-tokens",
+		Locator:   "F1",
+	}
+	transfer := &core.TokenTransfer{
+		Type: core.TokenTransferTypeMint,
+	}
+
+	_, phase, err := am.RunOperation(context.Background(), opTransfer(op, pool, transfer))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.EqualError(t, err, "pop")
+}
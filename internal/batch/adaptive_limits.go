@@ -0,0 +1,124 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"time"
+)
+
+// AdaptiveOptions bounds the tuning of the batch limits of each processor, based on the observed
+// latency of dispatching batches (uploading to shared storage, and submitting the blockchain transaction)
+type AdaptiveOptions struct {
+	Enabled       bool
+	MinSize       uint
+	MinBytes      int64
+	TargetLatency time.Duration
+	GrowthFactor  float64
+}
+
+// adaptiveLimits grows the limits of a processor while full batches dispatch within the target latency,
+// and halves them when the target is exceeded or a dispatch fails.
+// The limits start at the maximums configured on the dispatcher, so behavior is unchanged until
+// a dispatch is slow.
+type adaptiveLimits struct {
+	opts  AdaptiveOptions
+	conf  *DispatcherOptions
+	size  uint  // 0 for the configured maximum
+	bytes int64 // 0 for the configured maximum
+}
+
+func newAdaptiveLimits(opts AdaptiveOptions, conf *DispatcherOptions) *adaptiveLimits {
+	if opts.MinSize < 1 {
+		opts.MinSize = 1
+	}
+	if opts.GrowthFactor < 1 {
+		opts.GrowthFactor = 1
+	}
+	return &adaptiveLimits{
+		opts: opts,
+		conf: conf,
+	}
+}
+
+func (al *adaptiveLimits) batchSize() uint {
+	if al.size == 0 || al.size > al.conf.BatchMaxSize {
+		return al.conf.BatchMaxSize
+	}
+	return al.size
+}
+
+func (al *adaptiveLimits) payloadLimit() int64 {
+	if al.bytes == 0 || al.bytes > al.conf.BatchMaxBytes {
+		return al.conf.BatchMaxBytes
+	}
+	return al.bytes
+}
+
+func (al *adaptiveLimits) set(size uint, bytes int64) bool {
+	changed := size != al.batchSize() || bytes != al.payloadLimit()
+	al.size, al.bytes = size, bytes
+	return changed
+}
+
+// observe adjusts the limits after a batch has been dispatched successfully. The limits only grow
+// when the batch was cut by the limits, as otherwise we have no evidence a larger batch would be used.
+func (al *adaptiveLimits) observe(latency time.Duration, limited bool) bool {
+	if !al.opts.Enabled {
+		return false
+	}
+	if latency > al.opts.TargetLatency {
+		return al.shrink()
+	}
+	if !limited {
+		return false
+	}
+	size := al.batchSize()
+	grownSize := uint(float64(size) * al.opts.GrowthFactor)
+	if grownSize == size {
+		grownSize++
+	}
+	if grownSize > al.conf.BatchMaxSize {
+		grownSize = al.conf.BatchMaxSize
+	}
+	grownBytes := int64(float64(al.payloadLimit()) * al.opts.GrowthFactor)
+	if grownBytes > al.conf.BatchMaxBytes {
+		grownBytes = al.conf.BatchMaxBytes
+	}
+	return al.set(grownSize, grownBytes)
+}
+
+// shrink halves the limits, down to the configured minimums (which are capped to the maximums)
+func (al *adaptiveLimits) shrink() bool {
+	if !al.opts.Enabled {
+		return false
+	}
+	size := al.batchSize() / 2
+	if size < al.opts.MinSize {
+		size = al.opts.MinSize
+	}
+	if size > al.conf.BatchMaxSize {
+		size = al.conf.BatchMaxSize
+	}
+	bytes := al.payloadLimit() / 2
+	if bytes < al.opts.MinBytes {
+		bytes = al.opts.MinBytes
+	}
+	if bytes > al.conf.BatchMaxBytes {
+		bytes = al.conf.BatchMaxBytes
+	}
+	return al.set(size, bytes)
+}
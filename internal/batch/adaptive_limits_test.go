@@ -0,0 +1,97 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveLimitsDisabled(t *testing.T) {
+	al := newAdaptiveLimits(AdaptiveOptions{}, &DispatcherOptions{BatchMaxSize: 100, BatchMaxBytes: 1024})
+	assert.False(t, al.observe(time.Hour, true))
+	assert.False(t, al.shrink())
+	assert.Equal(t, uint(100), al.batchSize())
+	assert.Equal(t, int64(1024), al.payloadLimit())
+	assert.Equal(t, uint(1), al.opts.MinSize)
+	assert.Equal(t, float64(1), al.opts.GrowthFactor)
+}
+
+func TestAdaptiveLimitsMinimumsAboveMaximums(t *testing.T) {
+	al := newAdaptiveLimits(AdaptiveOptions{
+		Enabled:  true,
+		MinSize:  1000,
+		MinBytes: 1000000,
+	}, &DispatcherOptions{BatchMaxSize: 100, BatchMaxBytes: 1024})
+	assert.False(t, al.shrink())
+	assert.Equal(t, uint(100), al.batchSize())
+	assert.Equal(t, int64(1024), al.payloadLimit())
+}
+
+func TestAdaptiveLimitsShrinkAndGrow(t *testing.T) {
+	conf := &DispatcherOptions{BatchMaxSize: 100, BatchMaxBytes: 1000}
+	al := newAdaptiveLimits(AdaptiveOptions{
+		Enabled:       true,
+		MinSize:       10,
+		MinBytes:      100,
+		TargetLatency: 1 * time.Second,
+		GrowthFactor:  1.5,
+	}, conf)
+
+	// Slow dispatches halve the limits down to the minimums
+	assert.True(t, al.observe(2*time.Second, false))
+	assert.Equal(t, uint(50), al.batchSize())
+	assert.Equal(t, int64(500), al.payloadLimit())
+	assert.True(t, al.shrink())
+	assert.True(t, al.shrink())
+	assert.True(t, al.shrink())
+	assert.Equal(t, uint(10), al.batchSize())
+	assert.Equal(t, int64(100), al.payloadLimit())
+	assert.False(t, al.shrink())
+
+	// Fast dispatches only grow the limits when the batch was cut by them
+	assert.False(t, al.observe(1*time.Millisecond, false))
+	assert.True(t, al.observe(1*time.Millisecond, true))
+	assert.Equal(t, uint(15), al.batchSize())
+	assert.Equal(t, int64(150), al.payloadLimit())
+	for i := 0; i < 10; i++ {
+		al.observe(1*time.Millisecond, true)
+	}
+	assert.Equal(t, uint(100), al.batchSize())
+	assert.Equal(t, int64(1000), al.payloadLimit())
+	assert.False(t, al.observe(1*time.Millisecond, true))
+
+	// Lowering the configured maximums caps the tuned limits
+	al.shrink()
+	conf.BatchMaxSize = 20
+	conf.BatchMaxBytes = 200
+	assert.Equal(t, uint(20), al.batchSize())
+	assert.Equal(t, int64(200), al.payloadLimit())
+}
+
+func TestAdaptiveLimitsGrowSmallSize(t *testing.T) {
+	al := newAdaptiveLimits(AdaptiveOptions{
+		Enabled:       true,
+		TargetLatency: 1 * time.Second,
+		GrowthFactor:  1.1,
+	}, &DispatcherOptions{BatchMaxSize: 10, BatchMaxBytes: 1000})
+	al.size = 1
+	assert.True(t, al.observe(1*time.Millisecond, true))
+	assert.Equal(t, uint(2), al.batchSize())
+}
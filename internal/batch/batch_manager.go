@@ -34,15 +34,29 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
-func NewBatchManager(ctx context.Context, ns string, di database.Plugin, dm data.Manager, im identity.Manager, txHelper txcommon.Helper) (Manager, error) {
-	if di == nil || dm == nil || im == nil {
+// PipelineOptions isolates the batch pipeline of a namespace, so that a busy namespace cannot delay
+// the pinning of batches in other namespaces
+type PipelineOptions struct {
+	// Workers is the maximum number of batches flushed concurrently by the namespace - 0 for one per processor
+	Workers int
+	// QueueDepth is the number of messages each processor queues for assembly - 0 for the batch size
+	QueueDepth int
+}
+
+func NewBatchManager(ctx context.Context, ns string, di database.Plugin, dm data.Manager, im identity.Manager, txHelper txcommon.Helper, mm metrics.Manager, pipeline PipelineOptions) (Manager, error) {
+	if di == nil || dm == nil || im == nil || mm == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "BatchManager")
 	}
+	hashAlgorithm, err := core.ParseHashAlgorithm(ctx, config.GetString(coreconfig.HashAlgorithm))
+	if err != nil {
+		return nil, err
+	}
 	pCtx, cancelCtx := context.WithCancel(log.WithLogField(ctx, "role", "batchmgr"))
 	readPageSize := config.GetUint(coreconfig.BatchManagerReadPageSize)
 	bm := &batchManager{
@@ -53,6 +67,9 @@ func NewBatchManager(ctx context.Context, ns string, di database.Plugin, dm data
 		database:                   di,
 		data:                       dm,
 		txHelper:                   txHelper,
+		metrics:                    mm,
+		queueDepth:                 pipeline.QueueDepth,
+		hashAlgorithm:              hashAlgorithm,
 		readOffset:                 -1, // On restart we trawl for all ready messages
 		readPageSize:               uint64(readPageSize),
 		minimumPollDelay:           config.GetDuration(coreconfig.BatchManagerMinimumPollDelay),
@@ -70,6 +87,16 @@ func NewBatchManager(ctx context.Context, ns string, di database.Plugin, dm data
 			MaximumDelay: config.GetDuration(coreconfig.BatchRetryMaxDelay),
 			Factor:       config.GetFloat64(coreconfig.BatchRetryFactor),
 		},
+		adaptive: AdaptiveOptions{
+			Enabled:       config.GetBool(coreconfig.BatchAdaptiveEnabled),
+			MinSize:       config.GetUint(coreconfig.BatchAdaptiveMinSize),
+			MinBytes:      config.GetByteSize(coreconfig.BatchAdaptiveMinPayloadLimit),
+			TargetLatency: config.GetDuration(coreconfig.BatchAdaptiveTargetLatency),
+			GrowthFactor:  config.GetFloat64(coreconfig.BatchAdaptiveGrowthFactor),
+		},
+	}
+	if pipeline.Workers > 0 {
+		bm.workers = make(chan struct{}, pipeline.Workers)
 	}
 	return bm, nil
 }
@@ -103,6 +130,11 @@ type batchManager struct {
 	database                   database.Plugin
 	data                       data.Manager
 	txHelper                   txcommon.Helper
+	metrics                    metrics.Manager
+	workers                    chan struct{}
+	queueDepth                 int
+	adaptive                   AdaptiveOptions
+	hashAlgorithm              core.HashAlgorithm
 	dispatcherMux              sync.Mutex
 	dispatcherMap              map[string]*dispatcher
 	allDispatchers             []*dispatcher
@@ -130,6 +162,11 @@ type DispatcherOptions struct {
 	BatchMaxBytes  int64
 	BatchTimeout   time.Duration
 	DisposeTimeout time.Duration
+	// BatchCompression is the compression applied to the payload of batches when they are sent
+	BatchCompression core.BatchCompression
+	// TopicAffinity assembles a separate batch for each distinct set of message topics, so unrelated
+	// topics do not share a batch (and cannot block each other in the aggregator)
+	TopicAffinity bool
 }
 
 type dispatcher struct {
@@ -139,7 +176,10 @@ type dispatcher struct {
 	options    DispatcherOptions
 }
 
-func (bm *batchManager) getProcessorKey(author string, groupID *fftypes.Bytes32) string {
+func (bm *batchManager) getProcessorKey(author string, groupID *fftypes.Bytes32, topics fftypes.FFStringArray, topicAffinity bool) string {
+	if topicAffinity {
+		return fmt.Sprintf("%s|%v|%s", author, groupID, topics)
+	}
 	return fmt.Sprintf("%s|%v", author, groupID)
 }
 
@@ -178,7 +218,7 @@ func (bm *batchManager) NewMessages() chan<- int64 {
 	return bm.newMessages
 }
 
-func (bm *batchManager) getProcessor(txType core.TransactionType, msgType core.MessageType, group *fftypes.Bytes32, author string, create bool) (*batchProcessor, error) {
+func (bm *batchManager) getProcessor(txType core.TransactionType, msgType core.MessageType, group *fftypes.Bytes32, author string, topics fftypes.FFStringArray, create bool) (*batchProcessor, error) {
 	bm.dispatcherMux.Lock()
 	defer bm.dispatcherMux.Unlock()
 
@@ -188,7 +228,7 @@ func (bm *batchManager) getProcessor(txType core.TransactionType, msgType core.M
 	if !ok {
 		return nil, i18n.NewError(bm.ctx, coremsgs.MsgUnregisteredBatchType, dispatcherKey)
 	}
-	name := bm.getProcessorKey(author, group)
+	name := bm.getProcessorKey(author, group, topics, dispatcher.options.TopicAffinity)
 	processor, ok := dispatcher.processors[name]
 	if !ok && create {
 		processor = newBatchProcessor(
@@ -198,6 +238,7 @@ func (bm *batchManager) getProcessor(txType core.TransactionType, msgType core.M
 				name:              name,
 				pinned:            pinned,
 				dispatcherName:    dispatcher.name,
+				hashAlgorithm:     bm.hashAlgorithm,
 				author:            author,
 				group:             group,
 				dispatch:          dispatcher.handler,
@@ -327,7 +368,7 @@ func (bm *batchManager) messageSequencer() {
 				// the database store. Meaning we cannot rely on the sequence having been set.
 				msg.Sequence = entry.Sequence
 
-				processor, err := bm.getProcessor(msg.Header.TxType, msg.Header.Type, msg.Header.Group, msg.Header.SignerRef.Author, true)
+				processor, err := bm.getProcessor(msg.Header.TxType, msg.Header.Type, msg.Header.Group, msg.Header.SignerRef.Author, msg.Header.Topics, true)
 				if err != nil {
 					l.Errorf("Failed to dispatch message %s: %s", msg.Header.ID, err)
 					continue
@@ -482,6 +523,29 @@ func (bm *batchManager) WaitStop() {
 	}
 }
 
+// acquireWorker blocks until there is a free worker in the pipeline of this namespace
+func (bm *batchManager) acquireWorker(ctx context.Context) error {
+	if bm.workers == nil {
+		return nil
+	}
+	startTime := time.Now()
+	select {
+	case bm.workers <- struct{}{}:
+	case <-ctx.Done():
+		return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+	}
+	if bm.metrics.IsMetricsEnabled() {
+		bm.metrics.BatchWorkerWait(bm.namespace, time.Since(startTime))
+	}
+	return nil
+}
+
+func (bm *batchManager) releaseWorker() {
+	if bm.workers != nil {
+		<-bm.workers
+	}
+}
+
 func (bm *batchManager) getNextNonce(ctx context.Context, state *dispatchState, nonceKeyHash *fftypes.Bytes32, contextHash *fftypes.Bytes32) (int64, error) {
 
 	// See if the nonceKeyHash is in our cached state already
@@ -623,7 +687,7 @@ func (bm *batchManager) CancelBatch(ctx context.Context, batchID string) error {
 		return i18n.NewError(ctx, coremsgs.MsgErrorLoadingBatch)
 	}
 	msg := batch.Payload.Messages[0]
-	processor, err := bm.getProcessor(msg.Header.TxType, msg.Header.Type, msg.Header.Group, msg.Header.SignerRef.Author, false)
+	processor, err := bm.getProcessor(msg.Header.TxType, msg.Header.Type, msg.Header.Group, msg.Header.SignerRef.Author, msg.Header.Topics, false)
 	if err != nil {
 		return err
 	}
@@ -34,6 +34,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -45,6 +46,12 @@ func testConfigReset() {
 	log.SetLevel("debug")
 }
 
+func newTestMetrics() *metricsmocks.Manager {
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+	return mmi
+}
+
 func newTestBatchManager(t *testing.T) (*batchManager, func()) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
@@ -53,7 +60,7 @@ func newTestBatchManager(t *testing.T) (*batchManager, func()) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	bm, err := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, err := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	assert.NoError(t, err)
 	return bm.(*batchManager), bm.(*batchManager).cancelCtx
 }
@@ -98,7 +105,7 @@ func TestE2EDispatchBroadcast(t *testing.T) {
 		return nil
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	bmi, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper)
+	bmi, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm := bmi.(*batchManager)
 	bm.readOffset = 1000
 
@@ -132,6 +139,7 @@ func TestE2EDispatchBroadcast(t *testing.T) {
 	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
 	mdi.On("GetMessageIDs", mock.Anything, "ns1", mock.Anything).Return([]*core.IDAndSequence{{ID: *msg.Header.ID}}, nil).Once()
 	mdi.On("GetMessageIDs", mock.Anything, "ns1", mock.Anything).Return([]*core.IDAndSequence{}, nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	mdi.On("UpdateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mdi.On("UpdateMessage", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil) // pins
@@ -221,7 +229,7 @@ func TestE2EDispatchPrivateUnpinned(t *testing.T) {
 		return nil
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	bmi, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper)
+	bmi, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm := bmi.(*batchManager)
 
 	bm.RegisterDispatcher("utdispatcher", true, []core.MessageType{core.MessageTypePrivate}, handler, DispatcherOptions{
@@ -255,6 +263,7 @@ func TestE2EDispatchPrivateUnpinned(t *testing.T) {
 	mdi.On("GetMessageIDs", mock.Anything, "ns1", mock.Anything).Return([]*core.IDAndSequence{{ID: *msg.Header.ID}}, nil).Once()
 	mdi.On("GetMessageIDs", mock.Anything, "ns1", mock.Anything).Return([]*core.IDAndSequence{}, nil)
 	mdi.On("UpdateMessage", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil) // pins
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", &groupID).Return(nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	mdi.On("UpdateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -304,7 +313,7 @@ func TestDispatchUnknownType(t *testing.T) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	ctx, cancel := context.WithCancel(context.Background())
-	bmi, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper)
+	bmi, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm := bmi.(*batchManager)
 
 	msg := &core.Message{
@@ -323,8 +332,16 @@ func TestDispatchUnknownType(t *testing.T) {
 
 }
 
+func TestInitFailBadHashAlgorithm(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.HashAlgorithm, "md5")
+	defer coreconfig.Reset()
+	_, err := NewBatchManager(context.Background(), "ns1", &databasemocks.Plugin{}, &datamocks.Manager{}, &identitymanagermocks.Manager{}, nil, newTestMetrics(), PipelineOptions{})
+	assert.Regexp(t, "FF00172", err)
+}
+
 func TestInitFailNoPersistence(t *testing.T) {
-	_, err := NewBatchManager(context.Background(), "", nil, nil, nil, nil)
+	_, err := NewBatchManager(context.Background(), "", nil, nil, nil, nil, nil, PipelineOptions{})
 	assert.Error(t, err)
 }
 
@@ -337,9 +354,9 @@ func TestGetInvalidBatchTypeMsg(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	defer bm.Close()
-	_, err := bm.(*batchManager).getProcessor(core.BatchTypeBroadcast, "wrong", nil, "", true)
+	_, err := bm.(*batchManager).getProcessor(core.BatchTypeBroadcast, "wrong", nil, "", nil, true)
 	assert.Regexp(t, "FF10126", err)
 }
 
@@ -352,7 +369,7 @@ func TestMessageSequencerCancelledContext(t *testing.T) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	mdi.On("GetMessageIDs", mock.Anything, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
-	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	defer bm.Close()
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -369,7 +386,7 @@ func TestMessageSequencerMissingMessageData(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm.RegisterDispatcher("utdispatcher", false, []core.MessageType{core.MessageTypeBroadcast},
 		func(c context.Context, state *DispatchPayload) error {
 			return nil
@@ -416,7 +433,7 @@ func TestMessageSequencerUpdateMessagesFail(t *testing.T) {
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	bm, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm.RegisterDispatcher("utdispatcher", true, []core.MessageType{core.MessageTypeBroadcast},
 		func(c context.Context, state *DispatchPayload) error {
 			return nil
@@ -441,6 +458,7 @@ func TestMessageSequencerUpdateMessagesFail(t *testing.T) {
 	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
 	mdi.On("InsertTransaction", mock.Anything, mock.Anything).Return(nil)
 	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil) // transaction submit
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("fizzle"))
 	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything, mock.Anything)
@@ -474,7 +492,7 @@ func TestMessageSequencerDispatchFail(t *testing.T) {
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	bm, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm.RegisterDispatcher("utdispatcher", true, []core.MessageType{core.MessageTypeBroadcast},
 		func(c context.Context, state *DispatchPayload) error {
 			cancelCtx()
@@ -516,7 +534,7 @@ func TestMessageSequencerUpdateBatchFail(t *testing.T) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
-	bm, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(ctx, "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm.RegisterDispatcher("utdispatcher", true, []core.MessageType{core.MessageTypeBroadcast},
 		func(c context.Context, state *DispatchPayload) error {
 			return nil
@@ -538,6 +556,7 @@ func TestMessageSequencerUpdateBatchFail(t *testing.T) {
 	}
 	mdi.On("GetMessageIDs", mock.Anything, "ns1", mock.Anything).Return([]*core.IDAndSequence{{ID: *msg.Header.ID}}, nil)
 	mdm.On("GetMessageWithDataCached", mock.Anything, mock.Anything).Return(msg, core.DataArray{{ID: dataID}}, true, nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("fizzle"))
 	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything, mock.Anything)
 	rag.RunFn = func(a mock.Arguments) {
@@ -602,7 +621,7 @@ func TestAssembleMessageDataNilData(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	bm.Close()
 	mdm.On("GetMessageWithDataCached", mock.Anything, mock.Anything).Return(nil, nil, false, nil)
 	_, _, err := bm.(*batchManager).assembleMessageData(fftypes.NewUUID())
@@ -617,7 +636,7 @@ func TestGetMessageDataFail(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	mdm.On("GetMessageWithDataCached", mock.Anything, mock.Anything).Return(nil, nil, false, fmt.Errorf("pop"))
 	bm.Close()
 	_, _, err := bm.(*batchManager).assembleMessageData(fftypes.NewUUID())
@@ -633,7 +652,7 @@ func TestGetMessageNotFound(t *testing.T) {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
-	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper)
+	bm, _ := NewBatchManager(context.Background(), "ns1", mdi, mdm, mim, txHelper, newTestMetrics(), PipelineOptions{})
 	mdm.On("GetMessageWithDataCached", mock.Anything, mock.Anything).Return(nil, nil, false, nil)
 	bm.Close()
 	_, _, err := bm.(*batchManager).assembleMessageData(fftypes.NewUUID())
@@ -975,7 +994,7 @@ func TestCancelBatch(t *testing.T) {
 		DispatcherOptions{BatchType: core.BatchTypePrivate},
 	)
 	group := fftypes.NewRandB32()
-	_, err := bm.getProcessor(core.TransactionTypeContractInvokePin, core.MessageTypePrivate, group, "did:firefly:org/abcd", true)
+	_, err := bm.getProcessor(core.TransactionTypeContractInvokePin, core.MessageTypePrivate, group, "did:firefly:org/abcd", nil, true)
 	assert.NoError(t, err)
 
 	batchID := fftypes.NewUUID()
@@ -1017,3 +1036,36 @@ func TestCancelBatch(t *testing.T) {
 	mdi.AssertExpectations(t)
 	mdm.AssertExpectations(t)
 }
+
+func TestGetProcessorTopicAffinity(t *testing.T) {
+	bm, cancel := newTestBatchManager(t)
+	defer cancel()
+
+	handler := func(c context.Context, state *DispatchPayload) error {
+		return nil
+	}
+	bm.RegisterDispatcher("utaffinity", true, []core.MessageType{core.MessageTypeBroadcast}, handler,
+		DispatcherOptions{BatchType: core.BatchTypeBroadcast, TopicAffinity: true},
+	)
+	bm.RegisterDispatcher("utmixed", true, []core.MessageType{core.MessageTypePrivate}, handler,
+		DispatcherOptions{BatchType: core.BatchTypePrivate},
+	)
+
+	author := "did:firefly:org/abcd"
+	p1, err := bm.getProcessor(core.TransactionTypeBatchPin, core.MessageTypeBroadcast, nil, author, fftypes.FFStringArray{"topic1"}, true)
+	assert.NoError(t, err)
+	p2, err := bm.getProcessor(core.TransactionTypeBatchPin, core.MessageTypeBroadcast, nil, author, fftypes.FFStringArray{"topic2"}, true)
+	assert.NoError(t, err)
+	p3, err := bm.getProcessor(core.TransactionTypeBatchPin, core.MessageTypeBroadcast, nil, author, fftypes.FFStringArray{"topic1"}, false)
+	assert.NoError(t, err)
+	assert.NotEqual(t, p1, p2)
+	assert.Equal(t, p1, p3)
+	assert.Equal(t, "did:firefly:org/abcd||topic1", p1.conf.name)
+
+	group := fftypes.NewRandB32()
+	p4, err := bm.getProcessor(core.TransactionTypeBatchPin, core.MessageTypePrivate, group, author, fftypes.FFStringArray{"topic1"}, true)
+	assert.NoError(t, err)
+	p5, err := bm.getProcessor(core.TransactionTypeBatchPin, core.MessageTypePrivate, group, author, fftypes.FFStringArray{"topic2"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, p4, p5)
+}
@@ -45,6 +45,7 @@ type batchProcessorConf struct {
 	name           string
 	dispatcherName string
 	pinned         bool
+	hashAlgorithm  core.HashAlgorithm
 	author         string
 	group          *fftypes.Bytes32
 	dispatch       DispatchHandler
@@ -65,6 +66,8 @@ type FlushStatus struct {
 	AverageFlushTimeMS   int64           `ffstruct:"BatchFlushStatus" json:"averageFlushTimeMS"`
 	TotalBatches         int64           `ffstruct:"BatchFlushStatus" json:"totalBatches"`
 	TotalErrors          int64           `ffstruct:"BatchFlushStatus" json:"totalErrors"`
+	CurrentBatchSize     uint            `ffstruct:"BatchFlushStatus" json:"currentBatchSize"`
+	CurrentPayloadLimit  int64           `ffstruct:"BatchFlushStatus" json:"currentPayloadLimit"`
 
 	totalBytesFlushed    int64
 	totalMessagesFlushed int64
@@ -85,6 +88,8 @@ type batchProcessor struct {
 	assemblyID         *fftypes.UUID
 	assemblyQueue      []*batchWork
 	assemblyQueueBytes int64
+	assemblyLimited    bool
+	limits             *adaptiveLimits
 	statusMux          sync.Mutex
 	flushStatus        FlushStatus
 	retry              *retry.Retry
@@ -133,6 +138,10 @@ const batchSizeEstimateBase = int64(512)
 func newBatchProcessor(bm *batchManager, conf *batchProcessorConf, baseRetryConf *retry.Retry, txHelper txcommon.Helper) *batchProcessor {
 	pCtx := log.WithLogField(log.WithLogField(bm.ctx, "d", conf.dispatcherName), "p", conf.name)
 	pCtx, cancelCtx := context.WithCancel(pCtx)
+	queueDepth := int(conf.BatchMaxSize)
+	if bm.queueDepth > 0 {
+		queueDepth = bm.queueDepth
+	}
 	bp := &batchProcessor{
 		ctx:       pCtx,
 		cancelCtx: cancelCtx,
@@ -140,7 +149,7 @@ func newBatchProcessor(bm *batchManager, conf *batchProcessorConf, baseRetryConf
 		database:  bm.database,
 		data:      bm.data,
 		txHelper:  txHelper,
-		newWork:   make(chan *batchWork, conf.BatchMaxSize),
+		newWork:   make(chan *batchWork, queueDepth),
 		quiescing: make(chan bool, 1),
 		done:      make(chan struct{}),
 		retry: &retry.Retry{
@@ -148,9 +157,12 @@ func newBatchProcessor(bm *batchManager, conf *batchProcessorConf, baseRetryConf
 			MaximumDelay: baseRetryConf.MaximumDelay,
 			Factor:       baseRetryConf.Factor,
 		},
-		conf: conf,
+		conf:   conf,
+		limits: newAdaptiveLimits(bm.adaptive, &conf.DispatcherOptions),
 		flushStatus: FlushStatus{
-			LastFlushTime: fftypes.Now(),
+			LastFlushTime:       fftypes.Now(),
+			CurrentBatchSize:    conf.BatchMaxSize,
+			CurrentPayloadLimit: conf.BatchMaxBytes,
 		},
 	}
 	// Capture flush errors for our status
@@ -183,6 +195,7 @@ func (bp *batchProcessor) newAssembly(initialWork ...*batchWork) {
 	bp.assemblyID = fftypes.NewUUID()
 	bp.assemblyQueue = append([]*batchWork{}, initialWork...)
 	bp.assemblyQueueBytes = batchSizeEstimateBase
+	bp.assemblyLimited = false
 }
 
 // addWork adds the work to the assemblyQueue, and calculates if we have overflowed with this work.
@@ -228,15 +241,17 @@ func (bp *batchProcessor) addWork(newWork *batchWork) (full, overflow bool) {
 		bp.assemblyQueueBytes += newWork.estimateSize()
 		bp.assemblyQueue = newQueue
 
-		full = len(bp.assemblyQueue) >= int(bp.conf.BatchMaxSize) || bp.assemblyQueueBytes >= bp.conf.BatchMaxBytes
-		overflow = len(bp.assemblyQueue) > 1 && (batchOfOne || bp.assemblyQueueBytes > bp.conf.BatchMaxBytes)
+		batchSize, payloadLimit := bp.limits.batchSize(), bp.limits.payloadLimit()
+		full = len(bp.assemblyQueue) >= int(batchSize) || bp.assemblyQueueBytes >= payloadLimit
+		overflow = len(bp.assemblyQueue) > 1 && (batchOfOne || bp.assemblyQueueBytes > payloadLimit)
+		bp.assemblyLimited = full
 	}
 
 	log.L(bp.ctx).Debugf("Added message %s sequence=%d to in-flight batch assembly %s", newWork.msg.Header.ID, newWork.msg.Sequence, bp.assemblyID)
 	return full, overflow
 }
 
-func (bp *batchProcessor) startFlush(overflow bool) (id *fftypes.UUID, flushAssembly []*batchWork, byteSize int64) {
+func (bp *batchProcessor) startFlush(overflow bool) (id *fftypes.UUID, flushAssembly []*batchWork, byteSize int64, limited bool) {
 	bp.statusMux.Lock()
 	defer bp.statusMux.Unlock()
 	// Start the clock
@@ -253,9 +268,10 @@ func (bp *batchProcessor) startFlush(overflow bool) (id *fftypes.UUID, flushAsse
 	// Cycle to the next assembly
 	id = bp.assemblyID
 	byteSize = bp.assemblyQueueBytes
+	limited = bp.assemblyLimited
 	bp.flushStatus.Flushing = id
 	bp.newAssembly(overflowWork...)
-	return id, flushAssembly, byteSize
+	return id, flushAssembly, byteSize, limited
 }
 
 func (bp *batchProcessor) notifyFlushComplete(flushWork []*batchWork) {
@@ -300,6 +316,21 @@ func (bp *batchProcessor) captureFlushError(err error) {
 	fs.Blocked = true
 	fs.LastFlushErrorTime = fftypes.Now()
 	fs.LastFlushError = err.Error()
+
+	if bp.bm.metrics.IsMetricsEnabled() {
+		bp.bm.metrics.BatchFlushFailed(bp.bm.namespace, bp.conf.dispatcherName)
+	}
+}
+
+// updateLimits records the batch limits after the adaptive tuning has changed them
+func (bp *batchProcessor) updateLimits() {
+	bp.statusMux.Lock()
+	defer bp.statusMux.Unlock()
+	fs := &bp.flushStatus
+
+	fs.CurrentBatchSize = bp.limits.batchSize()
+	fs.CurrentPayloadLimit = bp.limits.payloadLimit()
+	log.L(bp.ctx).Infof("Batch limits adjusted to size=%d payloadLimit=%d", fs.CurrentBatchSize, fs.CurrentPayloadLimit)
 }
 
 func (bp *batchProcessor) cancelFlush(ctx context.Context, id *fftypes.UUID) error {
@@ -399,7 +430,18 @@ func (bp *batchProcessor) assemblyLoop() {
 }
 
 func (bp *batchProcessor) flush(overflow bool) error {
-	id, flushWork, byteSize := bp.startFlush(overflow)
+	// Wait for a worker in the pipeline of our namespace, before we start the flush
+	if err := bp.bm.acquireWorker(bp.ctx); err != nil {
+		return err
+	}
+	defer bp.bm.releaseWorker()
+
+	startTime := time.Now()
+	id, flushWork, byteSize, limited := bp.startFlush(overflow)
+	if bp.bm.metrics.IsMetricsEnabled() && flushWork[0].msg.Header.Created != nil {
+		// The assembly time is how long the oldest message in the batch has waited for the flush
+		bp.bm.metrics.BatchAssembled(bp.bm.namespace, bp.conf.dispatcherName, time.Since(*flushWork[0].msg.Header.Created.Time()))
+	}
 
 	log.L(bp.ctx).Debugf("Flushing batch %s", id)
 	state := bp.initPayload(id, flushWork)
@@ -414,11 +456,15 @@ func (bp *batchProcessor) flush(overflow bool) error {
 	// Dispatch phase: the heavy lifting work - calling plugins to do the hard work of the batch.
 	//   The dispatcher can update the state, such as appending to the BlobsPublished array,
 	//   to affect DB updates as part of the finalization phase.
+	dispatchStart := time.Now()
 	err = bp.dispatchBatch(state)
 	if err != nil {
 		return err
 	}
 	log.L(bp.ctx).Debugf("Dispatched batch %s", id)
+	if bp.limits.observe(time.Since(dispatchStart), limited) {
+		bp.updateLimits()
+	}
 
 	// Finalization phase: Writes back the changes to the DB, so that these messages
 	//   are all tagged as part of this batch, and won't be included in any future batches.
@@ -433,6 +479,9 @@ func (bp *batchProcessor) flush(overflow bool) error {
 
 	// Update our stats
 	bp.updateFlushStats(state, byteSize)
+	if bp.bm.metrics.IsMetricsEnabled() {
+		bp.bm.metrics.BatchFlushed(bp.bm.namespace, bp.conf.dispatcherName, len(state.Messages), time.Since(startTime))
+	}
 	return nil
 }
 
@@ -587,10 +636,21 @@ func (bp *batchProcessor) sealBatch(payload *DispatchPayload) (err error) {
 
 			// The hash of the batch, is the hash of the manifest to minimize the compute cost.
 			// Note in v0.13 and before, it was the hash of the payload - so the inbound route has a fallback to accepting the full payload hash
-			manifest := payload.Batch.GenManifest(payload.Messages, payload.Data)
-			manifestString := manifest.String()
-			payload.Batch.Manifest = fftypes.JSONAnyPtr(manifestString)
-			payload.Batch.Hash = fftypes.HashString(manifestString)
+			manifest := payload.Batch.GenManifest(payload.Messages, payload.Data).
+				SetCompression(bp.conf.BatchCompression).
+				SetHashAlgorithm(bp.conf.hashAlgorithm)
+			entryDetails, err := bp.recipientsSupportManifest(ctx, core.ManifestVersion3)
+			if err != nil {
+				return err
+			}
+			if entryDetails {
+				manifest.SetEntryDetails(payload.Messages, payload.Data)
+			}
+			payload.Batch.Manifest = fftypes.JSONAnyPtr(manifest.String())
+			payload.Batch.Hash, err = manifest.Hash(ctx)
+			if err != nil {
+				return err
+			}
 			log.L(ctx).Debugf("Batch %s sealed. Hash=%s", payload.Batch.ID, payload.Batch.Hash)
 
 			// At this point the manifest of the batch is finalized. We write it to the database
@@ -616,6 +676,34 @@ func (bp *batchProcessor) sealBatch(payload *DispatchPayload) (err error) {
 	return nil
 }
 
+// recipientsSupportManifest checks every node that receives the batch advertises support for a manifest version
+// in its identity profile. That is the members of the group for a private batch, or every node in the namespace.
+func (bp *batchProcessor) recipientsSupportManifest(ctx context.Context, version uint) (bool, error) {
+	fb := database.IdentityQueryFactory.NewFilter(ctx)
+	filter := fb.Eq("type", core.IdentityTypeNode)
+	if bp.conf.group != nil {
+		group, err := bp.database.GetGroupByHash(ctx, bp.bm.namespace, bp.conf.group)
+		if err != nil || group == nil {
+			return false, err
+		}
+		members := make([]driver.Value, 0, len(group.Members))
+		for _, member := range group.Members {
+			members = append(members, member.Node)
+		}
+		filter = fb.And(filter, fb.In("id", members))
+	}
+	nodes, _, err := bp.database.GetIdentities(ctx, bp.bm.namespace, filter)
+	if err != nil {
+		return false, err
+	}
+	for _, node := range nodes {
+		if core.NodeManifestVersion(node) < version {
+			return false, nil
+		}
+	}
+	return len(nodes) > 0, nil
+}
+
 func (bp *batchProcessor) dispatchBatch(payload *DispatchPayload) error {
 	// Call the dispatcher to do the heavy lifting - will only exit if we're closed
 	return operations.RunWithOperationContext(bp.ctx, func(ctx context.Context) error {
@@ -632,6 +720,8 @@ func (bp *batchProcessor) dispatchBatch(payload *DispatchPayload) error {
 							err = bp.dispatchBatch(gapFillPayload)
 						}
 					}
+				} else if bp.limits.shrink() {
+					bp.updateLimits()
 				}
 			} else {
 				if core.IsPinned(payload.Batch.TX.Type) {
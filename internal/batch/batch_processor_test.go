@@ -34,6 +34,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/txcommonmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
@@ -88,6 +89,7 @@ func TestUnfilledBatch(t *testing.T) {
 
 	mockRunAsGroupPassthrough(mdi)
 	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
 
 	mth := bp.txHelper.(*txcommonmocks.Helper)
@@ -142,6 +144,7 @@ func TestBatchSizeOverflow(t *testing.T) {
 	bp.conf.BatchMaxBytes = batchSizeEstimateBase + (&core.Message{}).EstimateSize(false) + 100
 	mockRunAsGroupPassthrough(mdi)
 	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
 
 	mth := bp.txHelper.(*txcommonmocks.Helper)
@@ -187,6 +190,106 @@ func TestBatchSizeOverflow(t *testing.T) {
 	mim.AssertExpectations(t)
 }
 
+func TestBatchPipelineWorkersAndMetrics(t *testing.T) {
+	log.SetLevel("debug")
+	coreconfig.Reset()
+
+	dispatched := make(chan *DispatchPayload)
+	dispatchAttempts := 0
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		dispatchAttempts++
+		if dispatchAttempts == 1 {
+			return fmt.Errorf("pop")
+		}
+		dispatched <- state
+		return nil
+	})
+	defer cancel()
+	bp.bm.workers = make(chan struct{}, 1)
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("BatchWorkerWait", "ns1", mock.Anything).Return()
+	mmi.On("BatchFlushFailed", "ns1", "").Return()
+	mmi.On("BatchFlushed", "ns1", "", 1, mock.Anything).Return()
+	mmi.On("BatchAssembled", "ns1", "", mock.Anything).Return()
+	bp.bm.metrics = mmi
+
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	mth := bp.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	bp.newWork <- &batchWork{
+		msg: &core.Message{
+			Header: core.MessageHeader{
+				ID:      fftypes.NewUUID(),
+				TxType:  core.TransactionTypeBatchPin,
+				Created: fftypes.Now(),
+			},
+			Sequence: 1000,
+		},
+	}
+
+	batch := <-dispatched
+	assert.Equal(t, 1, len(batch.Messages))
+
+	bp.cancelCtx()
+	<-bp.done
+
+	// The worker is released once the flush completes
+	assert.Empty(t, bp.bm.workers)
+	mmi.AssertExpectations(t)
+}
+
+func TestBatchPipelineWorkerWaitCancelled(t *testing.T) {
+	cancel, _, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	defer cancel()
+	bp.bm.workers = make(chan struct{}, 1)
+	bp.bm.workers <- struct{}{}
+	bp.cancelCtx()
+
+	err := bp.flush(false)
+	assert.Regexp(t, "FF00154", err)
+	<-bp.done
+}
+
+func TestBatchPipelineOptions(t *testing.T) {
+	ctx := context.Background()
+	mdi := &databasemocks.Plugin{}
+	mdm := &datamocks.Manager{}
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
+	bmi, err := NewBatchManager(ctx, "ns1", mdi, mdm, &identitymanagermocks.Manager{}, txHelper, newTestMetrics(), PipelineOptions{
+		Workers:    2,
+		QueueDepth: 3,
+	})
+	assert.NoError(t, err)
+	bm := bmi.(*batchManager)
+	defer bm.cancelCtx()
+	assert.Equal(t, 2, cap(bm.workers))
+
+	bp := newBatchProcessor(bm, &batchProcessorConf{
+		DispatcherOptions: DispatcherOptions{
+			BatchMaxSize:   10,
+			DisposeTimeout: 1 * time.Minute,
+		},
+	}, bm.retry, bm.txHelper)
+	assert.Equal(t, 3, cap(bp.newWork))
+}
+
 func TestCloseToUnblockDispatch(t *testing.T) {
 	cancel, _, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
 		return fmt.Errorf("pop")
@@ -541,6 +644,7 @@ func TestMarkMessageDispatchedUnpinnedOK(t *testing.T) {
 
 	mockRunAsGroupPassthrough(mdi)
 	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
 	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
 	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
@@ -604,6 +708,7 @@ func TestMaskContextsRetryAfterPinsAssigned(t *testing.T) {
 	mdi.On("UpdateNonce", mock.Anything, mock.MatchedBy(func(dbNonce *core.Nonce) bool {
 		return dbNonce.Nonce == 12347 // twice incremented
 	})).Return(nil).Once()
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
 
 	mdm := bp.data.(*datamocks.Manager)
@@ -710,6 +815,7 @@ func TestSealBatchTXAlreadyAssigned(t *testing.T) {
 	mdi.On("GetNonce", mock.Anything, mock.Anything).Return(nil, nil)
 	mdi.On("InsertNonce", mock.Anything, mock.Anything).Return(nil)
 	mdi.On("UpdateMessage", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
 
 	mim := bp.bm.identity.(*identitymanagermocks.Manager)
@@ -744,6 +850,270 @@ func TestSealBatchTXAlreadyAssigned(t *testing.T) {
 	mdm.AssertExpectations(t)
 }
 
+func TestSealBatchHashAlgorithm(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	cancel()
+	bp.conf.hashAlgorithm = core.HashAlgorithmSHA3_256
+
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("GetNonce", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("InsertNonce", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpdateMessage", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypePrivate,
+			Group:  fftypes.NewRandB32(),
+			Topics: fftypes.FFStringArray{"topic1"},
+			TxType: core.TransactionTypeContractInvokePin,
+		},
+		TransactionID: fftypes.NewUUID(),
+	}
+
+	state := bp.initPayload(fftypes.NewUUID(), []*batchWork{{msg: msg}})
+	err := bp.sealBatch(state)
+	assert.NoError(t, err)
+	expectedHash, _ := core.HashBytes(context.Background(), core.HashAlgorithmSHA3_256, []byte(state.Batch.Manifest.String()))
+	assert.Equal(t, expectedHash, state.Batch.Hash)
+	assert.Equal(t, core.HashAlgorithmSHA3_256, state.Batch.GenInflight(state.Messages, state.Data).HashAlgorithm)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mdm.AssertExpectations(t)
+}
+
+func TestSealBatchHashAlgorithmUnsupported(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	cancel()
+	bp.conf.hashAlgorithm = "md5"
+
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("GetNonce", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("InsertNonce", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpdateMessage", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypePrivate,
+			Group:  fftypes.NewRandB32(),
+			Topics: fftypes.FFStringArray{"topic1"},
+			TxType: core.TransactionTypeContractInvokePin,
+		},
+		TransactionID: fftypes.NewUUID(),
+	}
+
+	state := bp.initPayload(fftypes.NewUUID(), []*batchWork{{msg: msg}})
+	err := bp.sealBatch(state)
+	assert.Regexp(t, "FF00154", err)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func testManifestNode(version interface{}) *core.Identity {
+	node := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:   fftypes.NewUUID(),
+			Type: core.IdentityTypeNode,
+		},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{"id": "peer1"},
+		},
+	}
+	if version != nil {
+		node.Profile[core.NodeProfileManifestVersion] = version
+	}
+	return node
+}
+
+func testSealManifest(t *testing.T, bp *batchProcessor, mdi *databasemocks.Plugin) (*DispatchPayload, error) {
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("GetNonce", mock.Anything, mock.Anything).Return(nil, nil)
+	mdi.On("InsertNonce", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("UpdateMessage", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return().Maybe()
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	data := &core.Data{
+		ID:    fftypes.NewUUID(),
+		Hash:  fftypes.NewRandB32(),
+		Value: fftypes.JSONAnyPtr(`{"filename":"report.pdf","mimetype":"application/pdf"}`),
+		Blob: &core.BlobRef{
+			Hash: fftypes.NewRandB32(),
+			Size: 1000,
+		},
+	}
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypePrivate,
+			Group:  fftypes.NewRandB32(),
+			Topics: fftypes.FFStringArray{"topic1"},
+			TxType: core.TransactionTypeContractInvokePin,
+		},
+		Data:          core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+		TransactionID: fftypes.NewUUID(),
+	}
+
+	state := bp.initPayload(fftypes.NewUUID(), []*batchWork{{msg: msg, data: core.DataArray{data}}})
+	err := bp.sealBatch(state)
+	return state, err
+}
+
+func TestSealBatchManifestEntryDetailsGroup(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	defer cancel()
+	bp.conf.group = fftypes.NewRandB32()
+
+	node1 := testManifestNode(float64(3))
+	node2 := testManifestNode(int64(4))
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", bp.conf.group).Return(&core.Group{
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: "did:firefly:org/org1", Node: node1.ID},
+				{Identity: "did:firefly:org/org2", Node: node2.ID},
+			},
+		},
+	}, nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, err := f.Finalize()
+		assert.NoError(t, err)
+		return fi.String() == fmt.Sprintf("( type == 'node' ) && ( id IN ['%s','%s'] )", node1.ID, node2.ID)
+	})).Return([]*core.Identity{node1, node2}, nil, nil)
+
+	state, err := testSealManifest(t, bp, mdi)
+	assert.NoError(t, err)
+
+	var manifest core.BatchManifest
+	err = state.Batch.Manifest.Unmarshal(context.Background(), &manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ManifestVersion3, manifest.Version)
+	assert.Equal(t, "application/pdf", manifest.Data[0].ContentType)
+	assert.Equal(t, int64(1000), manifest.Data[0].Blob.Size)
+	assert.Equal(t, manifest.Data[0].Size+1000, manifest.Messages[0].Size)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestSealBatchManifestNodeNotAdvertising(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	defer cancel()
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{
+		testManifestNode(float64(3)),
+		testManifestNode(nil),
+	}, nil, nil)
+
+	state, err := testSealManifest(t, bp, mdi)
+	assert.NoError(t, err)
+
+	var manifest core.BatchManifest
+	err = state.Batch.Manifest.Unmarshal(context.Background(), &manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, core.ManifestVersion1, manifest.Version)
+	assert.Empty(t, manifest.Data[0].ContentType)
+	assert.Zero(t, manifest.Messages[0].Size)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestSealBatchManifestGroupNotFound(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	defer cancel()
+	bp.conf.group = fftypes.NewRandB32()
+
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", bp.conf.group).Return(nil, nil)
+
+	state, err := testSealManifest(t, bp, mdi)
+	assert.NoError(t, err)
+	assert.Regexp(t, `"version":1`, state.Batch.Manifest.String())
+
+	mdi.AssertExpectations(t)
+}
+
+func TestSealBatchManifestGroupFail(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	cancel()
+	bp.conf.group = fftypes.NewRandB32()
+
+	mdi.On("GetGroupByHash", mock.Anything, "ns1", bp.conf.group).Return(nil, fmt.Errorf("pop"))
+
+	_, err := testSealManifest(t, bp, mdi)
+	assert.Regexp(t, "FF00154", err)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mdi.AssertExpectations(t)
+}
+
+func TestSealBatchManifestGetNodesFail(t *testing.T) {
+	coreconfig.Reset()
+
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		return nil
+	})
+	cancel()
+
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := testSealManifest(t, bp, mdi)
+	assert.Regexp(t, "FF00154", err)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	mdi.AssertExpectations(t)
+}
+
 func TestCalculateContextsLoadPins(t *testing.T) {
 	cancel, _, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
 		return nil
@@ -923,6 +1293,7 @@ func TestCancelBatchPrivate(t *testing.T) {
 		return dbNonce.Nonce == 12346
 	})).Return(nil)
 	mdi.On("UpdateMessage", mock.Anything, "ns1", msg1, mock.Anything).Return(nil).Once()
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil).Times(3)
 
 	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
@@ -1026,6 +1397,7 @@ func TestCancelBatchBroadcast(t *testing.T) {
 	msg2 := fftypes.NewUUID() // dispatched
 
 	mockRunAsGroupPassthrough(mdi)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil).Twice()
 	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
 		info, err := filter.Finalize()
@@ -1123,6 +1495,7 @@ func TestGapFillMessageFail(t *testing.T) {
 	}
 
 	mockRunAsGroupPassthrough(mdi)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
 	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
 
 	mdm := bp.data.(*datamocks.Manager)
@@ -1135,3 +1508,113 @@ func TestGapFillMessageFail(t *testing.T) {
 
 	mdm.AssertExpectations(t)
 }
+
+func TestAdaptiveLimitsShrinkOnDispatchFail(t *testing.T) {
+	coreconfig.Reset()
+
+	dispatched := make(chan *DispatchPayload)
+	attempts := 0
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		attempts++
+		if attempts == 1 {
+			return fmt.Errorf("pop")
+		}
+		dispatched <- state
+		return nil
+	})
+	defer cancel()
+	bp.limits.opts = AdaptiveOptions{
+		Enabled:       true,
+		MinSize:       2,
+		TargetLatency: 1 * time.Hour,
+		GrowthFactor:  2,
+	}
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	mth := bp.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	bp.newWork <- &batchWork{
+		msg: &core.Message{
+			Header: core.MessageHeader{
+				ID:     fftypes.NewUUID(),
+				TxType: core.TransactionTypeBatchPin,
+			},
+			Sequence: 1000,
+		},
+	}
+	batch := <-dispatched
+	assert.Len(t, batch.Messages, 1)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	// The failure halved the limits, and the timed out batch was not cut by them so they did not grow back
+	status := bp.status().Status
+	assert.Equal(t, uint(5), status.CurrentBatchSize)
+	assert.Equal(t, int64(512*1024), status.CurrentPayloadLimit)
+	assert.Equal(t, int64(1), status.TotalErrors)
+}
+
+func TestAdaptiveLimitsGrowOnFullBatch(t *testing.T) {
+	coreconfig.Reset()
+
+	dispatched := make(chan *DispatchPayload)
+	cancel, mdi, bp := newTestBatchProcessor(t, func(c context.Context, state *DispatchPayload) error {
+		dispatched <- state
+		return nil
+	})
+	defer cancel()
+	bp.limits.opts = AdaptiveOptions{
+		Enabled:       true,
+		MinSize:       1,
+		TargetLatency: 1 * time.Hour,
+		GrowthFactor:  2,
+	}
+	bp.limits.size = 2
+	mockRunAsGroupPassthrough(mdi)
+	mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{}, nil, nil)
+	mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+
+	mth := bp.txHelper.(*txcommonmocks.Helper)
+	mth.On("SubmitNewTransaction", mock.Anything, core.TransactionTypeBatchPin, core.IdempotencyKey("")).Return(fftypes.NewUUID(), nil)
+
+	mdm := bp.data.(*datamocks.Manager)
+	mdm.On("UpdateMessageIfCached", mock.Anything, mock.Anything).Return()
+
+	mim := bp.bm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", mock.Anything).Return(&core.Identity{}, nil)
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			bp.newWork <- &batchWork{
+				msg: &core.Message{
+					Header: core.MessageHeader{
+						ID:     fftypes.NewUUID(),
+						TxType: core.TransactionTypeBatchPin,
+					},
+					Sequence: int64(1000 + i),
+				},
+			}
+		}
+	}()
+	batch := <-dispatched
+	assert.Len(t, batch.Messages, 2)
+
+	bp.cancelCtx()
+	<-bp.done
+
+	status := bp.status().Status
+	assert.Equal(t, uint(4), status.CurrentBatchSize)
+	assert.Equal(t, int64(1024*1024), status.CurrentPayloadLimit)
+}
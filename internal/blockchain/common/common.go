@@ -97,6 +97,8 @@ type BlockchainReceiptNotification struct {
 	Message          string                   `json:"errorMessage,omitempty"`
 	ProtocolID       string                   `json:"protocolId,omitempty"`
 	ContractLocation *fftypes.JSONAny         `json:"contractLocation,omitempty"`
+	ContractAddress  string                   `json:"contractAddress,omitempty"`
+	ExtraInfo        *fftypes.JSONAny         `json:"extraInfo,omitempty"`
 }
 
 type BlockchainRESTError struct {
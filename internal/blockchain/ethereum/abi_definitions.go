@@ -155,3 +155,53 @@ var networkVersionMethodABI = &abi.Entry{
 		},
 	},
 }
+
+// multicallAggregate3ABI is the aggregate3 method of the widely deployed Multicall3 contract
+var multicallAggregate3ABI = &abi.Entry{
+	Name:            "aggregate3",
+	Type:            "function",
+	StateMutability: "payable",
+	Inputs: abi.ParameterArray{
+		{
+			InternalType: "struct Multicall3.Call3[]",
+			Name:         "calls",
+			Type:         "tuple[]",
+			Components: abi.ParameterArray{
+				{
+					InternalType: "address",
+					Name:         "target",
+					Type:         "address",
+				},
+				{
+					InternalType: "bool",
+					Name:         "allowFailure",
+					Type:         "bool",
+				},
+				{
+					InternalType: "bytes",
+					Name:         "callData",
+					Type:         "bytes",
+				},
+			},
+		},
+	},
+	Outputs: abi.ParameterArray{
+		{
+			InternalType: "struct Multicall3.Result[]",
+			Name:         "returnData",
+			Type:         "tuple[]",
+			Components: abi.ParameterArray{
+				{
+					InternalType: "bool",
+					Name:         "success",
+					Type:         "bool",
+				},
+				{
+					InternalType: "bytes",
+					Name:         "returnData",
+					Type:         "bytes",
+				},
+			},
+		},
+	},
+}
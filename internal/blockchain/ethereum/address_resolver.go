@@ -23,12 +23,12 @@ import (
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hyperledger/firefly-common/pkg/config"
-	"github.com/hyperledger/firefly-common/pkg/ffresty"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/proxy"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 )
 
@@ -56,7 +56,7 @@ type addressResolverInserts struct {
 
 func newAddressResolver(ctx context.Context, localConfig config.Section, cacheManager cache.Manager, enableCache bool) (ar *addressResolver, err error) {
 
-	client, err := ffresty.New(ctx, localConfig)
+	client, err := proxy.NewRESTClient(ctx, localConfig)
 
 	if err != nil {
 		return nil, err
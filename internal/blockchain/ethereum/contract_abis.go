@@ -0,0 +1,117 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hyperledger/firefly/internal/blockchain/common"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Ethconnect does not support deploying an inline definition and bytecode (see DeployContract).
+// Instead the ABI and bytecode are stored in its ABI store, and new instances are deployed from there.
+
+func (e *Ethereum) UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error) {
+	formData := map[string]string{
+		"abi": upload.ABI.String(),
+	}
+	if upload.Bytecode != "" {
+		formData["bytecode"] = upload.Bytecode
+	}
+	if upload.Name != "" {
+		formData["name"] = upload.Name
+	}
+	if upload.Description != "" {
+		formData["description"] = upload.Description
+	}
+	var abiInfo core.ContractABI
+	var resErr common.BlockchainRESTError
+	res, err := e.client.R().
+		SetContext(ctx).
+		SetMultipartFormData(formData).
+		SetResult(&abiInfo).
+		SetError(&resErr).
+		Post("/abis")
+	if err != nil || !res.IsSuccess() {
+		return nil, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return &abiInfo, nil
+}
+
+func (e *Ethereum) GetContractABIs(ctx context.Context) ([]*core.ContractABI, error) {
+	var abis []*core.ContractABI
+	var resErr common.BlockchainRESTError
+	res, err := e.client.R().
+		SetContext(ctx).
+		SetResult(&abis).
+		SetError(&resErr).
+		Get("/abis")
+	if err != nil || !res.IsSuccess() {
+		return nil, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return abis, nil
+}
+
+func (e *Ethereum) GetContractABI(ctx context.Context, id string) (*core.ContractABI, error) {
+	abiInfo := &core.ContractABI{ID: id}
+	var resErr common.BlockchainRESTError
+	res, err := e.client.R().
+		SetContext(ctx).
+		SetQueryParam("abi", "").
+		SetResult(&abiInfo.ABI).
+		SetError(&resErr).
+		Get("/abis/" + url.PathEscape(id))
+	if err == nil && res.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil || !res.IsSuccess() {
+		return nil, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return abiInfo, nil
+}
+
+func (e *Ethereum) DeployContractABI(ctx context.Context, nsOpID, signingKey, abiID string, input, options map[string]interface{}) (submissionRejected bool, err error) {
+	if e.metrics.IsMetricsEnabled() {
+		e.metrics.BlockchainContractDeployment()
+	}
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+	// The request ID is passed through to the receipt, in the same way as a deployment with an inline definition
+	req := e.client.R().
+		SetContext(ctx).
+		SetQueryParam(e.prefixShort+"-from", signingKey).
+		SetQueryParam(e.prefixShort+"-id", nsOpID).
+		SetBody(input)
+	for k, v := range options {
+		req.SetQueryParam(e.prefixShort+"-"+k, fmt.Sprintf("%v", v))
+	}
+
+	var resErr common.BlockchainRESTError
+	res, err := req.
+		SetError(&resErr).
+		Post("/abis/" + url.PathEscape(abiID))
+	if err != nil || !res.IsSuccess() {
+		return resErr.SubmissionRejected, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return false, nil
+}
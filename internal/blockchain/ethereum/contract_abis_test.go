@@ -0,0 +1,215 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadContractABIOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/abis",
+		func(req *http.Request) (*http.Response, error) {
+			err := req.ParseMultipartForm(1024 * 1024)
+			assert.NoError(t, err)
+			assert.Equal(t, `[{"type":"constructor"}]`, req.FormValue("abi"))
+			assert.Equal(t, "0x123456", req.FormValue("bytecode"))
+			assert.Equal(t, "simplestorage", req.FormValue("name"))
+			assert.Equal(t, "A simple store", req.FormValue("description"))
+			return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"id":         "abi1",
+				"name":       "simplestorage",
+				"deployable": true,
+				"created":    "2024-01-01T00:00:00Z",
+			})(req)
+		})
+
+	abiInfo, err := e.UploadContractABI(context.Background(), &core.ContractABIUpload{
+		Name:        "simplestorage",
+		Description: "A simple store",
+		ABI:         fftypes.JSONAnyPtr(`[{"type":"constructor"}]`),
+		Bytecode:    "0x123456",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "abi1", abiInfo.ID)
+	assert.True(t, abiInfo.Deployable)
+	assert.Equal(t, int64(1704067200), abiInfo.Created.Time().Unix())
+}
+
+func TestUploadContractABIFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/abis",
+		httpmock.NewJsonResponderOrPanic(400, map[string]interface{}{"error": "bad abi"}))
+
+	_, err := e.UploadContractABI(context.Background(), &core.ContractABIUpload{
+		ABI: fftypes.JSONAnyPtr(`[]`),
+	})
+	assert.Regexp(t, "FF10111.*bad abi", err)
+}
+
+func TestGetContractABIsOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/abis",
+		httpmock.NewJsonResponderOrPanic(200, []map[string]interface{}{
+			{"id": "abi1", "name": "simplestorage", "deployable": true},
+			{"id": "abi2", "name": "token"},
+		}))
+
+	abis, err := e.GetContractABIs(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, abis, 2)
+	assert.Equal(t, "abi1", abis[0].ID)
+	assert.False(t, abis[1].Deployable)
+}
+
+func TestGetContractABIsFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/abis",
+		httpmock.NewStringResponder(500, "pop"))
+
+	_, err := e.GetContractABIs(context.Background())
+	assert.Regexp(t, "FF10111", err)
+}
+
+func TestGetContractABIOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/abis/abi1",
+		func(req *http.Request) (*http.Response, error) {
+			assert.True(t, req.URL.Query().Has("abi"))
+			return httpmock.NewJsonResponderOrPanic(200, []map[string]interface{}{
+				{"type": "constructor"},
+			})(req)
+		})
+
+	abiInfo, err := e.GetContractABI(context.Background(), "abi1")
+	assert.NoError(t, err)
+	assert.Equal(t, "abi1", abiInfo.ID)
+	assert.JSONEq(t, `[{"type":"constructor"}]`, abiInfo.ABI.String())
+}
+
+func TestGetContractABINotFound(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/abis/abi1",
+		httpmock.NewJsonResponderOrPanic(404, map[string]interface{}{"error": "not found"}))
+
+	abiInfo, err := e.GetContractABI(context.Background(), "abi1")
+	assert.NoError(t, err)
+	assert.Nil(t, abiInfo)
+}
+
+func TestGetContractABIFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/abis/abi1",
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{"error": "pop"}))
+
+	_, err := e.GetContractABI(context.Background(), "abi1")
+	assert.Regexp(t, "FF10111.*pop", err)
+}
+
+func TestDeployContractABIOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/abis/abi1",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "0x12345", req.URL.Query().Get("fly-from"))
+			assert.Equal(t, "ns1:op1", req.URL.Query().Get("fly-id"))
+			assert.Equal(t, "1000000", req.URL.Query().Get("fly-gas"))
+			var body map[string]interface{}
+			err := json.NewDecoder(req.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, "42", body["x"])
+			return httpmock.NewJsonResponderOrPanic(202, map[string]interface{}{"sent": true})(req)
+		})
+
+	rejected, err := e.DeployContractABI(context.Background(), "ns1:op1", "0x12345", "abi1",
+		map[string]interface{}{"x": "42"},
+		map[string]interface{}{"gas": 1000000})
+	assert.NoError(t, err)
+	assert.False(t, rejected)
+}
+
+func TestDeployContractABINoInput(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/abis/abi1",
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			err := json.NewDecoder(req.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Empty(t, body)
+			return httpmock.NewJsonResponderOrPanic(202, map[string]interface{}{"sent": true})(req)
+		})
+
+	_, err := e.DeployContractABI(context.Background(), "ns1:op1", "0x12345", "abi1", nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestDeployContractABIFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/abis/abi1",
+		httpmock.NewJsonResponderOrPanic(400, map[string]interface{}{"error": "bad input", "submissionRejected": true}))
+
+	rejected, err := e.DeployContractABI(context.Background(), "ns1:op1", "0x12345", "abi1", nil, nil)
+	assert.Regexp(t, "FF10111.*bad input", err)
+	assert.True(t, rejected)
+}
@@ -33,12 +33,14 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
 	"github.com/hyperledger/firefly-signer/pkg/abi"
+	"github.com/hyperledger/firefly-signer/pkg/ethtypes"
 	"github.com/hyperledger/firefly-signer/pkg/ffi2abi"
 	"github.com/hyperledger/firefly/internal/blockchain/common"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/proxy"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/sirupsen/logrus"
@@ -68,8 +70,11 @@ type Ethereum struct {
 	client               *resty.Client
 	streams              *streamManager
 	streamID             map[string]string
+	connections          core.PluginConnectionTracker
+	eventQueue           core.InboundEventQueue
 	wsconn               map[string]wsclient.WSClient
 	wsConfig             *wsclient.WSConfig
+	wsFactory            proxy.WSFactory
 	closed               map[string]chan struct{}
 	addressResolveAlways bool
 	addressResolver      *addressResolver
@@ -144,7 +149,9 @@ func (e *Ethereum) Init(ctx context.Context, cancelCtx context.CancelFunc, conf
 	e.ctx = log.WithLogField(ctx, "proto", "ethereum")
 	e.cancelCtx = cancelCtx
 	e.metrics = metrics
-	e.capabilities = &blockchain.Capabilities{}
+	e.capabilities = &blockchain.Capabilities{
+		Multicall: true,
+	}
 	e.callbacks = common.NewBlockchainCallbacks()
 	e.subs = common.NewFireflySubscriptions()
 
@@ -162,7 +169,10 @@ func (e *Ethereum) Init(ctx context.Context, cancelCtx context.CancelFunc, conf
 
 	e.wsConfig, err = wsclient.GenerateConfig(ctx, ethconnectConf)
 	if err == nil {
-		e.client, err = ffresty.New(e.ctx, ethconnectConf)
+		e.client, err = proxy.NewRESTClient(e.ctx, ethconnectConf)
+	}
+	if err == nil {
+		e.wsFactory, err = proxy.NewWSFactory(ctx, ethconnectConf)
 	}
 
 	if err != nil {
@@ -209,7 +219,11 @@ func (e *Ethereum) StartNamespace(ctx context.Context, namespace string) (err er
 	log.L(e.ctx).Debugf("Starting namespace: %s", namespace)
 	topic := e.getTopic(namespace)
 
-	e.wsconn[namespace], err = wsclient.New(ctx, e.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
+	e.wsconn[namespace], err = e.wsFactory.New(ctx, e.wsConfig, func(ctx context.Context, w wsclient.WSClient) error {
+		// Called before every attempt to (re)connect, so the previous connection has been lost
+		e.connections.Update(namespace, false)
+		return nil
+	}, func(ctx context.Context, w wsclient.WSClient) error {
 		// Send a subscribe to our topic after each connect/reconnect
 		b, _ := json.Marshal(&ethWSCommandPayload{
 			Type:  "listen",
@@ -222,6 +236,7 @@ func (e *Ethereum) StartNamespace(ctx context.Context, namespace string) (err er
 			})
 			err = w.Send(ctx, b)
 		}
+		e.connections.Update(namespace, err == nil)
 		return err
 	})
 	if err != nil {
@@ -253,6 +268,7 @@ func (e *Ethereum) StopNamespace(ctx context.Context, namespace string) (err err
 		wsconn.Close()
 	}
 	delete(e.wsconn, namespace)
+	e.connections.Remove(namespace)
 	delete(e.streamID, namespace)
 	delete(e.closed, namespace)
 
@@ -263,6 +279,14 @@ func (e *Ethereum) SetHandler(namespace string, handler blockchain.Callbacks) {
 	e.callbacks.SetHandler(namespace, handler)
 }
 
+func (e *Ethereum) SetConnectionListener(listener core.PluginConnectionListener) {
+	e.connections.SetListener(listener)
+}
+
+func (e *Ethereum) SetEventQueue(queue core.InboundEventQueue) {
+	e.eventQueue = queue
+}
+
 func (e *Ethereum) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
 	e.callbacks.SetOperationalHandler(namespace, handler)
 }
@@ -271,6 +295,15 @@ func (e *Ethereum) Capabilities() *blockchain.Capabilities {
 	return e.capabilities
 }
 
+// CheckReady only checks the connector is reachable - any HTTP response (including an error status) means it is
+func (e *Ethereum) CheckReady(ctx context.Context) error {
+	res, err := e.client.R().SetContext(ctx).Get("/")
+	if err != nil {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return nil
+}
+
 func (e *Ethereum) AddFireflySubscription(ctx context.Context, namespace *core.Namespace, contract *blockchain.MultipartyContract, lastProtocolID string) (string, error) {
 	ethLocation, err := e.parseContractLocation(ctx, contract.Location)
 	if err != nil {
@@ -303,6 +336,17 @@ func (e *Ethereum) RemoveFireflySubscription(ctx context.Context, subID string)
 	e.subs.RemoveSubscription(ctx, subID)
 }
 
+func (e *Ethereum) RequeryFireflySubscription(ctx context.Context, subID, fromProtocolID string) error {
+	// Always bounded by a known event - re-delivering from the start of the chain is never requested
+	blockNumber, err := strconv.ParseUint(strings.Split(fromProtocolID, "/")[0], 10, 64)
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgInvalidLastEventProtocolID, fromProtocolID)
+	}
+	fromBlock := strconv.FormatUint(blockNumber, 10)
+	log.L(ctx).Infof("Requerying FireFly subscription %s from block %s", subID, fromBlock)
+	return e.streams.resetSubscription(ctx, subID, fromBlock)
+}
+
 func ethHexFormatB32(b *fftypes.Bytes32) string {
 	if b == nil {
 		return "0x0000000000000000000000000000000000000000000000000000000000000000"
@@ -452,6 +496,21 @@ func (e *Ethereum) handleMessageBatch(ctx context.Context, batchID int64, messag
 	return e.callbacks.DispatchBlockchainEvents(ctx, events)
 }
 
+// handleQueuedBatch processes a batch of events that was acknowledged when it was stored in the event queue
+func (e *Ethereum) handleQueuedBatch(ctx context.Context, msgBytes []byte) error {
+	var msgParsed interface{}
+	_ = json.Unmarshal(msgBytes, &msgParsed) // only valid JSON is queued
+	switch msgTyped := msgParsed.(type) {
+	case []interface{}:
+		return e.handleMessageBatch(ctx, 0, msgTyped)
+	case map[string]interface{}:
+		batchNumber, _ := msgTyped["batchNumber"].(float64)
+		events, _ := msgTyped["events"].([]interface{})
+		return e.handleMessageBatch(ctx, (int64)(batchNumber), events)
+	}
+	return nil
+}
+
 func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed chan struct{}) {
 	topic := e.getTopic(namespace)
 	defer wsconn.Close()
@@ -459,6 +518,12 @@ func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed
 	l := log.L(e.ctx).WithField("role", "event-loop").WithField("namespace", namespace)
 	ctx := log.WithLogger(e.ctx, l)
 	log.L(ctx).Debugf("Starting event loop for namespace '%s'", namespace)
+	if e.eventQueue != nil {
+		// Queued batches are processed for as long as the event loop is running
+		consumeCtx, stopConsuming := context.WithCancel(ctx)
+		defer stopConsuming()
+		e.eventQueue.Consume(consumeCtx, namespace, e.handleQueuedBatch)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -479,7 +544,11 @@ func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed
 			}
 			switch msgTyped := msgParsed.(type) {
 			case []interface{}:
-				err = e.handleMessageBatch(ctx, 0, msgTyped)
+				if e.eventQueue != nil {
+					err = e.eventQueue.Enqueue(ctx, namespace, msgBytes)
+				} else {
+					err = e.handleMessageBatch(ctx, 0, msgTyped)
+				}
 				if err == nil {
 					ack, _ := json.Marshal(&ethWSCommandPayload{
 						Type:  "ack",
@@ -493,7 +562,11 @@ func (e *Ethereum) eventLoop(namespace string, wsconn wsclient.WSClient, closed
 					if events, ok := msgTyped["events"].([]interface{}); ok {
 						// FFTM delivery with a batch number to use in the ack
 						isBatch = true
-						err = e.handleMessageBatch(ctx, (int64)(batchNumber), events)
+						if e.eventQueue != nil {
+							err = e.eventQueue.Enqueue(ctx, namespace, msgBytes)
+						} else {
+							err = e.handleMessageBatch(ctx, (int64)(batchNumber), events)
+						}
 						// Errors processing messages are converted into nacks
 						ackOrNack := &ethWSCommandPayload{
 							Topic:       topic,
@@ -777,10 +850,10 @@ func (e *Ethereum) DeployContract(ctx context.Context, nsOpID, signingKey string
 
 // Check if a method supports passing extra data via conformance to ERC5750.
 // That is, check if the last method input is a "bytes" parameter.
-func (e *Ethereum) checkDataSupport(ctx context.Context, method *abi.Entry) error {
-	if len(method.Inputs) > 0 {
-		lastParam := method.Inputs[len(method.Inputs)-1]
-		if lastParam.Type == "bytes" {
+func (e *Ethereum) checkDataSupport(ctx context.Context, methodInfo *parsedFFIMethod) error {
+	if methodInfo.pinIndex >= 0 && methodInfo.pinIndex < len(methodInfo.methodABI.Inputs) {
+		pinParam := methodInfo.methodABI.Inputs[methodInfo.pinIndex]
+		if pinParam.Type == "bytes" {
 			return nil
 		}
 	}
@@ -790,7 +863,7 @@ func (e *Ethereum) checkDataSupport(ctx context.Context, method *abi.Entry) erro
 func (e *Ethereum) ValidateInvokeRequest(ctx context.Context, parsedMethod interface{}, input map[string]interface{}, hasMessage bool) error {
 	methodInfo, _, err := e.prepareRequest(ctx, parsedMethod, input)
 	if err == nil && hasMessage {
-		if err = e.checkDataSupport(ctx, methodInfo.methodABI); err != nil {
+		if err = e.checkDataSupport(ctx, methodInfo); err != nil {
 			return err
 		}
 	}
@@ -807,12 +880,12 @@ func (e *Ethereum) InvokeContract(ctx context.Context, nsOpID string, signingKey
 		return true, err
 	}
 	if batch != nil {
-		err := e.checkDataSupport(ctx, methodInfo.methodABI)
+		err := e.checkDataSupport(ctx, methodInfo)
 		if err == nil {
 			method, batchPin := e.buildBatchPinInput(2, "", batch)
 			encoded, err := method.Inputs.EncodeABIDataValuesCtx(ctx, batchPin)
 			if err == nil {
-				orderedInput[len(orderedInput)-1] = hex.EncodeToString(encoded)
+				orderedInput[methodInfo.pinIndex] = hex.EncodeToString(encoded)
 			}
 		}
 		if err != nil {
@@ -822,6 +895,34 @@ func (e *Ethereum) InvokeContract(ctx context.Context, nsOpID string, signingKey
 	return e.invokeContractMethod(ctx, ethereumLocation.Address, signingKey, methodInfo.methodABI, nsOpID, orderedInput, methodInfo.errorsABI, options)
 }
 
+func (e *Ethereum) InvokeContractBatch(ctx context.Context, nsOpID, signingKey string, aggregator *fftypes.JSONAny, calls []*blockchain.ContractBatchCall, allowFailure bool, options map[string]interface{}) (bool, error) {
+	aggregatorLocation, err := e.parseContractLocation(ctx, aggregator)
+	if err != nil {
+		return true, err
+	}
+	calls3 := make([]interface{}, len(calls))
+	for i, call := range calls {
+		ethereumLocation, err := e.parseContractLocation(ctx, call.Location)
+		if err != nil {
+			return true, err
+		}
+		methodInfo, orderedInput, err := e.prepareRequest(ctx, call.ParsedMethod, call.Input)
+		if err != nil {
+			return true, err
+		}
+		callData, err := methodInfo.methodABI.EncodeCallDataValuesCtx(ctx, orderedInput)
+		if err != nil {
+			return true, err
+		}
+		calls3[i] = map[string]interface{}{
+			"target":       ethereumLocation.Address,
+			"allowFailure": allowFailure,
+			"callData":     hex.EncodeToString(callData),
+		}
+	}
+	return e.invokeContractMethod(ctx, aggregatorLocation.Address, signingKey, multicallAggregate3ABI, nsOpID, []interface{}{calls3}, nil, options)
+}
+
 func (e *Ethereum) QueryContract(ctx context.Context, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}) (interface{}, error) {
 	ethereumLocation, err := e.parseContractLocation(ctx, location)
 	if err != nil {
@@ -843,6 +944,112 @@ func (e *Ethereum) QueryContract(ctx context.Context, signingKey string, locatio
 	return output, nil // note UNLIKE fabric this is just `output`, not `output.Result` - but either way the top level of what we return to the end user, is whatever the Connector sent us
 }
 
+type receiptLog struct {
+	Topics []ethtypes.HexBytes0xPrefix `json:"topics"`
+	Data   ethtypes.HexBytes0xPrefix   `json:"data"`
+}
+
+type receiptExtraInfo struct {
+	ReturnValue ethtypes.HexBytes0xPrefix `json:"returnValue,omitempty"`
+	Logs        []*receiptLog             `json:"logs,omitempty"`
+}
+
+func (e *Ethereum) DecodeInvokeReceipt(ctx context.Context, parsedMethod interface{}, events []*fftypes.FFIEvent, receipt fftypes.JSONObject) (fftypes.JSONObject, []*core.ContractInvokeEvent, error) {
+	methodInfo, ok := parsedMethod.(*parsedFFIMethod)
+	if !ok || methodInfo.methodABI == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.MsgUnexpectedInterfaceType, parsedMethod)
+	}
+	var extraInfo receiptExtraInfo
+	if err := json.Unmarshal([]byte(receipt.GetObject("extraInfo").String()), &extraInfo); err != nil {
+		return nil, nil, i18n.WrapError(ctx, err, coremsgs.MsgInvokeReceiptDecodeFailed)
+	}
+	serializer := abi.NewSerializer().SetByteSerializer(abi.HexByteSerializer0xPrefix)
+
+	var returnValues fftypes.JSONObject
+	if len(extraInfo.ReturnValue) > 0 {
+		cv, err := methodInfo.methodABI.Outputs.DecodeABIDataCtx(ctx, extraInfo.ReturnValue, 0)
+		if err == nil {
+			returnValues, err = serializeABIValues(ctx, serializer, cv)
+		}
+		if err != nil {
+			return nil, nil, i18n.WrapError(ctx, err, coremsgs.MsgInvokeReceiptDecodeFailed)
+		}
+	}
+
+	// Events are matched to the logs of the transaction using the hash of their signature
+	eventABIs := make(map[string]*abi.Entry, len(events))
+	eventsBySig := make(map[string]*fftypes.FFIEvent, len(events))
+	for _, event := range events {
+		eventABI, err := ffi2abi.ConvertFFIEventDefinitionToABI(ctx, &event.FFIEventDefinition)
+		if err != nil {
+			return nil, nil, err
+		}
+		sigHash := eventABI.SignatureHashBytes().String()
+		eventABIs[sigHash] = eventABI
+		eventsBySig[sigHash] = event
+	}
+	var decodedEvents []*core.ContractInvokeEvent
+	for _, l := range extraInfo.Logs {
+		if len(l.Topics) == 0 {
+			continue
+		}
+		eventABI, ok := eventABIs[l.Topics[0].String()]
+		if !ok {
+			continue
+		}
+		event := eventsBySig[l.Topics[0].String()]
+		var output fftypes.JSONObject
+		cv, err := eventABI.DecodeEventDataCtx(ctx, l.Topics, l.Data)
+		if err == nil {
+			output, err = serializeABIValues(ctx, serializer, cv)
+		}
+		if err != nil {
+			return nil, nil, i18n.WrapError(ctx, err, coremsgs.MsgInvokeReceiptDecodeFailed)
+		}
+		signature, _ := e.GenerateEventSignature(ctx, &event.FFIEventDefinition)
+		decodedEvents = append(decodedEvents, &core.ContractInvokeEvent{
+			Name:      event.Name,
+			Signature: signature,
+			Output:    output,
+		})
+	}
+	return returnValues, decodedEvents, nil
+}
+
+func (e *Ethereum) DecodeInvokeBatchReceipt(ctx context.Context, receipt fftypes.JSONObject) ([]*core.ContractCallBatchItemResult, error) {
+	var extraInfo receiptExtraInfo
+	if err := json.Unmarshal([]byte(receipt.GetObject("extraInfo").String()), &extraInfo); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgInvokeReceiptDecodeFailed)
+	}
+	if len(extraInfo.ReturnValue) == 0 {
+		return nil, nil
+	}
+	var output struct {
+		ReturnData []*core.ContractCallBatchItemResult `json:"returnData"`
+	}
+	cv, err := multicallAggregate3ABI.Outputs.DecodeABIDataCtx(ctx, extraInfo.ReturnValue, 0)
+	if err == nil {
+		serializer := abi.NewSerializer().SetByteSerializer(abi.HexByteSerializer0xPrefix)
+		var b []byte
+		if b, err = serializer.SerializeJSONCtx(ctx, cv); err == nil {
+			err = json.Unmarshal(b, &output)
+		}
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgInvokeReceiptDecodeFailed)
+	}
+	return output.ReturnData, nil
+}
+
+// serializeABIValues converts decoded values to JSON, with the serialization errors reported by the caller
+func serializeABIValues(ctx context.Context, serializer *abi.Serializer, cv *abi.ComponentValue) (values fftypes.JSONObject, err error) {
+	b, err := serializer.SerializeJSONCtx(ctx, cv)
+	if err == nil {
+		err = json.Unmarshal(b, &values)
+	}
+	return values, err
+}
+
 func (e *Ethereum) CheckOverlappingLocations(ctx context.Context, left *fftypes.JSONAny, right *fftypes.JSONAny) (bool, error) {
 	if left == nil || right == nil {
 		// No location on either side so overlapping
@@ -1048,6 +1255,7 @@ func (e *Ethereum) GenerateErrorSignature(ctx context.Context, errorDef *fftypes
 type parsedFFIMethod struct {
 	methodABI *abi.Entry
 	errorsABI []*abi.Entry
+	pinIndex  int
 }
 
 func (e *Ethereum) ParseInterface(ctx context.Context, method *fftypes.FFIMethod, errors []*fftypes.FFIError) (interface{}, error) {
@@ -1058,6 +1266,7 @@ func (e *Ethereum) ParseInterface(ctx context.Context, method *fftypes.FFIMethod
 	methodInfo := &parsedFFIMethod{
 		methodABI: methodABI,
 		errorsABI: make([]*abi.Entry, len(errors)),
+		pinIndex:  blockchain.PinParamIndex(method),
 	}
 	for i, ffiError := range errors {
 		errorABI, err := ffi2abi.ConvertFFIErrorDefinitionToABI(ctx, &ffiError.FFIErrorDefinition)
@@ -1223,6 +1432,9 @@ func (e *Ethereum) GetTransactionStatus(ctx context.Context, operation *core.Ope
 				TxHash:     statusResponse.GetString("transactionHash"),
 				Message:    statusResponse.GetString("errorMessage"),
 				ProtocolID: receiptInfo.GetString("protocolId")}
+			if extraInfo := receiptInfo.GetObject("extraInfo"); len(extraInfo) > 0 {
+				receipt.ExtraInfo = fftypes.JSONAnyPtr(extraInfo.String())
+			}
 			err := common.HandleReceipt(ctx, e, receipt, e.callbacks)
 			if err != nil {
 				log.L(ctx).Warnf("Failed to handle receipt")
@@ -1235,3 +1447,42 @@ func (e *Ethereum) GetTransactionStatus(ctx context.Context, operation *core.Ope
 
 	return statusResponse, nil
 }
+
+// GetTransactionReceipt asks the connector for the receipt of a transaction that was submitted outside of FireFly
+func (e *Ethereum) GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error) {
+	body := map[string]interface{}{
+		"headers": EthconnectMessageHeaders{
+			Type: "TransactionReceipt",
+		},
+		"transactionHash": txHash,
+	}
+	var resErr common.BlockchainRESTError
+	var receiptResponse fftypes.JSONObject
+	res, err := e.client.R().
+		SetContext(ctx).
+		SetBody(body).
+		SetError(&resErr).
+		SetResult(&receiptResponse).
+		Post("/")
+	if err != nil || !res.IsSuccess() {
+		if res.StatusCode() == 404 {
+			return nil, nil
+		}
+		return nil, common.WrapRESTError(ctx, &resErr, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	if receiptResponse.GetString("blockHash") == "" {
+		// Not yet mined
+		return nil, nil
+	}
+
+	receipt := &blockchain.TransactionReceipt{
+		TransactionHash: txHash,
+		Success:         receiptResponse.GetBool("success"),
+		ProtocolID:      receiptResponse.GetString("protocolId"),
+		Info:            receiptResponse,
+	}
+	if !receipt.Success {
+		receipt.ErrorMessage = i18n.NewError(ctx, coremsgs.MsgTransactionReverted, txHash).Error()
+	}
+	return receipt, nil
+}
@@ -18,6 +18,7 @@ package ethereum
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -33,6 +34,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly-signer/pkg/ffi2abi"
 	"github.com/hyperledger/firefly/internal/blockchain/common"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
@@ -199,6 +201,31 @@ func TestBadTLSConfig(t *testing.T) {
 	assert.Regexp(t, "FF00153", err)
 }
 
+func TestInitBadProxy(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	resetConf(e)
+	utEthconnectConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utEthconnectConf.Set(ffresty.HTTPConfigProxyURL, "ftp://proxy.example.com")
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(e.ctx, 100, 5*time.Minute), nil)
+	err := e.Init(e.ctx, e.cancelCtx, utConfig, e.metrics, cmi)
+	assert.Regexp(t, "FF10611", err)
+}
+
+func TestInitWebSocketProxy(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	resetConf(e)
+	utEthconnectConf.Set(ffresty.HTTPConfigURL, "http://ethconnect.example.com:12345")
+	utEthconnectConf.Set(ffresty.HTTPConfigProxyURL, "http://proxy.example.com:8080")
+	utEthconnectConf.Set(EthconnectConfigTopic, "topic1")
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(e.ctx, 100, 5*time.Minute), nil)
+	err := e.Init(e.ctx, e.cancelCtx, utConfig, e.metrics, cmi)
+	assert.NoError(t, err)
+}
+
 func TestInitBadAddressResolver(t *testing.T) {
 	e, cancel := newTestEthereum()
 	defer cancel()
@@ -511,6 +538,32 @@ func TestDeleteStreamOKNotFound(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCheckReady(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/",
+		httpmock.NewStringResponder(200, ""))
+
+	err := e.CheckReady(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckReadyFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/",
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	err := e.CheckReady(context.Background())
+	assert.Regexp(t, "FF10111", err)
+}
+
 func TestStartNamespaceWSCreateFail(t *testing.T) {
 	e, cancel := newTestEthereum()
 	defer cancel()
@@ -1789,6 +1842,42 @@ func TestEventLoopSendClosed(t *testing.T) {
 	wsm.AssertExpectations(t)
 }
 
+func TestEventLoopQueued(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	mq := &coremocks.InboundEventQueue{}
+	e.SetEventQueue(mq)
+	r := make(chan []byte, 2)
+	r <- []byte(`[]`)
+	r <- []byte(`{"batchNumber":12345,"events":[]}`)
+	close(r)
+	wsm := &wsmocks.WSClient{}
+	e.wsconn["ns1"] = wsm
+	var handler core.InboundEventHandler
+	mq.On("Consume", mock.Anything, "ns1", mock.Anything).Run(func(args mock.Arguments) {
+		handler = args[2].(core.InboundEventHandler)
+	})
+	mq.On("Enqueue", mock.Anything, "ns1", []byte(`[]`)).Return(nil)
+	mq.On("Enqueue", mock.Anything, "ns1", []byte(`{"batchNumber":12345,"events":[]}`)).Return(nil)
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Send", mock.Anything, []byte(`{"type":"ack","topic":"topic1/ns1"}`)).Return(nil).Once()
+	wsm.On("Send", mock.Anything, []byte(`{"type":"ack","topic":"topic1/ns1","batchNumber":12345}`)).Return(nil).Once()
+	wsm.On("Close").Return()
+	e.closed["ns1"] = make(chan struct{})
+	e.eventLoop("ns1", wsm, e.closed["ns1"])
+
+	// The queued batches are processed by the consumer
+	err := handler(context.Background(), []byte(`[]`))
+	assert.NoError(t, err)
+	err = handler(context.Background(), []byte(`{"batchNumber":12345,"events":[]}`))
+	assert.NoError(t, err)
+	err = handler(context.Background(), []byte(`"unexpected"`))
+	assert.NoError(t, err)
+
+	mq.AssertExpectations(t)
+	wsm.AssertExpectations(t)
+}
+
 func TestHandleReceiptTXSuccess(t *testing.T) {
 	em := &coremocks.OperationCallbacks{}
 	wsm := &wsmocks.WSClient{}
@@ -3048,6 +3137,61 @@ func TestInvokeContractWithBatchOK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestInvokeContractWithBatchPinParam(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	signingKey := ethHexFormatB32(fftypes.NewRandB32())
+	location := &Location{
+		Address: "0x12345",
+	}
+	locationBytes, err := json.Marshal(location)
+	assert.NoError(t, err)
+	method := testFFIPinMethod()
+	method.Params = append(method.Params, &fftypes.FFIParam{
+		Name:   "x",
+		Schema: fftypes.JSONAnyPtr(`{"type":"integer","details":{"type":"uint256"}}`),
+	})
+	method.Details = fftypes.JSONObject{"pinParam": "data"}
+	batch := &blockchain.BatchPin{
+		TransactionID:   fftypes.MustParseUUID("82281f91-6ba8-498a-9e68-c6c6a594b747"),
+		BatchID:         fftypes.MustParseUUID("f3cce875-b979-48be-99a6-1a64c780330d"),
+		BatchHash:       fftypes.MustParseBytes32("4529bffbf77984bfa4b83126f8b963e4da10d194e021f097a60d952d81783649"),
+		BatchPayloadRef: "test-payload",
+		Contexts:        []*fftypes.Bytes32{},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			params := body["params"].([]interface{})
+			assert.Regexp(t, "^82281f916ba8498a9e68c6c6a594b747", params[0])
+			assert.Equal(t, float64(1), params[1])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+	err = e.ValidateInvokeRequest(context.Background(), parsedMethod, map[string]interface{}{"x": float64(1)}, true)
+	assert.NoError(t, err)
+	_, err = e.InvokeContract(context.Background(), "", signingKey, fftypes.JSONAnyPtrBytes(locationBytes), parsedMethod, map[string]interface{}{"x": float64(1)}, nil, batch)
+	assert.NoError(t, err)
+}
+
+func TestInvokeContractWithBatchPinParamNotBytes(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	method := testFFIMethod()
+	method.Details = fftypes.JSONObject{"pinParam": "x"}
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+	err = e.ValidateInvokeRequest(context.Background(), parsedMethod, nil, true)
+	assert.Regexp(t, "FF10443", err)
+}
+
 func TestInvokeContractWithBatchUnsupported(t *testing.T) {
 	e, cancel := newTestEthereum()
 	defer cancel()
@@ -4763,6 +4907,44 @@ func TestGetTransactionStatusSuccess(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetTransactionStatusReceiptExtraInfo(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	em := &coremocks.OperationCallbacks{}
+	e.SetOperationHandler("ns1", em)
+
+	op := &core.Operation{
+		Namespace: "ns1",
+		ID:        fftypes.MustParseUUID("9ffc50ff-6bfe-4502-adc7-93aea54cc059"),
+		Status:    "Pending",
+	}
+
+	httpmock.RegisterResponder("GET", `http://localhost:12345/transactions/ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059`,
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"id":     "ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059",
+			"status": "Succeeded",
+			"receipt": fftypes.JSONObject{
+				"protocolId": "000000000010/000020/000030",
+				"extraInfo": fftypes.JSONObject{
+					"returnValue": "0x01",
+				},
+			},
+		}))
+	em.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdate) bool {
+		return update.NamespacedOpID == "ns1:9ffc50ff-6bfe-4502-adc7-93aea54cc059" &&
+			update.Status == core.OpStatusSucceeded &&
+			update.Output.GetObject("extraInfo").GetString("returnValue") == "0x01"
+	})).Return(nil)
+
+	status, err := e.GetTransactionStatus(context.Background(), op)
+	assert.NotNil(t, status)
+	assert.NoError(t, err)
+
+	em.AssertExpectations(t)
+}
+
 func TestGetTransactionStatusFailed(t *testing.T) {
 	e, cancel := newTestEthereum()
 	defer cancel()
@@ -4851,6 +5033,95 @@ func TestGetTransactionStatusBadResult(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestGetTransactionReceiptSuccess(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "TransactionReceipt", headers["type"])
+			assert.Equal(t, "0x12345", body["transactionHash"])
+			return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"blockHash":  "0xabcde",
+				"success":    true,
+				"protocolId": "000000000010/000000",
+			})(req)
+		})
+
+	receipt, err := e.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.NoError(t, err)
+	assert.True(t, receipt.Success)
+	assert.Equal(t, "0x12345", receipt.TransactionHash)
+	assert.Equal(t, "000000000010/000000", receipt.ProtocolID)
+	assert.Equal(t, "0xabcde", receipt.Info.GetString("blockHash"))
+	assert.Empty(t, receipt.ErrorMessage)
+}
+
+func TestGetTransactionReceiptReverted(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"blockHash": "0xabcde",
+			"success":   false,
+		}))
+
+	receipt, err := e.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.NoError(t, err)
+	assert.False(t, receipt.Success)
+	assert.Regexp(t, "FF10607", receipt.ErrorMessage)
+}
+
+func TestGetTransactionReceiptNotMined(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{}))
+
+	receipt, err := e.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestGetTransactionReceiptNotFound(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(404, map[string]interface{}{}))
+
+	receipt, err := e.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.NoError(t, err)
+	assert.Nil(t, receipt)
+}
+
+func TestGetTransactionReceiptFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{}))
+
+	receipt, err := e.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.Regexp(t, "FF10111", err)
+	assert.Nil(t, receipt)
+}
+
 func TestValidateInvokeRequest(t *testing.T) {
 	e, cancel := newTestEthereum()
 	defer cancel()
@@ -5002,3 +5273,367 @@ func TestCheckOverLappingLocationsSame(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, result)
 }
+
+func testDecodeReceiptInterface(t *testing.T) (*fftypes.FFIMethod, []*fftypes.FFIEvent, string) {
+	method := &fftypes.FFIMethod{
+		Name: "set",
+		Returns: fftypes.FFIParams{
+			{Name: "value", Schema: fftypes.JSONAnyPtr(`{"type":"integer","details":{"type":"uint256"}}`)},
+		},
+	}
+	events := []*fftypes.FFIEvent{{
+		FFIEventDefinition: fftypes.FFIEventDefinition{
+			Name: "Changed",
+			Params: fftypes.FFIParams{
+				{Name: "from", Schema: fftypes.JSONAnyPtr(`{"type":"string","details":{"type":"address","indexed":true}}`)},
+				{Name: "value", Schema: fftypes.JSONAnyPtr(`{"type":"integer","details":{"type":"uint256"}}`)},
+			},
+		},
+	}}
+	eventABI, err := ffi2abi.ConvertFFIEventDefinitionToABI(context.Background(), &events[0].FFIEventDefinition)
+	assert.NoError(t, err)
+	return method, events, eventABI.SignatureHashBytes().String()
+}
+
+func TestDecodeInvokeReceiptOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	method, events, sigHash := testDecodeReceiptInterface(t)
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	var receipt fftypes.JSONObject
+	err = json.Unmarshal([]byte(`{
+		"extraInfo": {
+			"returnValue": "0x000000000000000000000000000000000000000000000000000000000000000c",
+			"logs": [
+				{
+					"topics": [],
+					"data": "0x"
+				},
+				{
+					"topics": ["0x0000000000000000000000000000000000000000000000000000000000000001"],
+					"data": "0x"
+				},
+				{
+					"topics": [
+						"`+sigHash+`",
+						"0x000000000000000000000000081afaa6792a524ff2fb0654e615d19f9a600e57"
+					],
+					"data": "0x000000000000000000000000000000000000000000000000000000000000000c"
+				}
+			]
+		}
+	}`), &receipt)
+	assert.NoError(t, err)
+
+	returnValues, decodedEvents, err := e.DecodeInvokeReceipt(context.Background(), parsedMethod, events, receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, fftypes.JSONObject{"value": "12"}, returnValues)
+	assert.Len(t, decodedEvents, 1)
+	assert.Equal(t, "Changed", decodedEvents[0].Name)
+	assert.Equal(t, "Changed(address,uint256) [i=0]", decodedEvents[0].Signature)
+	assert.Equal(t, fftypes.JSONObject{
+		"from":  "0x081afaa6792a524ff2fb0654e615d19f9a600e57",
+		"value": "12",
+	}, decodedEvents[0].Output)
+}
+
+func TestDecodeInvokeReceiptNoInfo(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	method, events, _ := testDecodeReceiptInterface(t)
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	returnValues, decodedEvents, err := e.DecodeInvokeReceipt(context.Background(), parsedMethod, events, fftypes.JSONObject{})
+	assert.NoError(t, err)
+	assert.Nil(t, returnValues)
+	assert.Empty(t, decodedEvents)
+}
+
+func TestDecodeInvokeReceiptBadMethod(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	_, _, err := e.DecodeInvokeReceipt(context.Background(), "wrong", nil, fftypes.JSONObject{})
+	assert.Regexp(t, "FF10457", err)
+}
+
+func TestDecodeInvokeReceiptBadExtraInfo(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	method, events, _ := testDecodeReceiptInterface(t)
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	_, _, err = e.DecodeInvokeReceipt(context.Background(), parsedMethod, events, fftypes.JSONObject{
+		"extraInfo": fftypes.JSONObject{"returnValue": "!hex"},
+	})
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestDecodeInvokeReceiptBadReturnValue(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	method, events, _ := testDecodeReceiptInterface(t)
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	_, _, err = e.DecodeInvokeReceipt(context.Background(), parsedMethod, events, fftypes.JSONObject{
+		"extraInfo": fftypes.JSONObject{"returnValue": "0x0102"},
+	})
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestDecodeInvokeReceiptBadEventData(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	method, events, sigHash := testDecodeReceiptInterface(t)
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	_, _, err = e.DecodeInvokeReceipt(context.Background(), parsedMethod, events, fftypes.JSONObject{
+		"extraInfo": fftypes.JSONObject{
+			"logs": []interface{}{
+				fftypes.JSONObject{"topics": []string{sigHash}, "data": "0x01"},
+			},
+		},
+	})
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestDecodeInvokeReceiptBadEventDefinition(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	method, _, _ := testDecodeReceiptInterface(t)
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+
+	_, _, err = e.DecodeInvokeReceipt(context.Background(), parsedMethod, []*fftypes.FFIEvent{{
+		FFIEventDefinition: fftypes.FFIEventDefinition{
+			Name: "Bad",
+			Params: fftypes.FFIParams{
+				{Name: "x", Schema: fftypes.JSONAnyPtr(`{"type":"integer"}`)},
+			},
+		},
+	}}, fftypes.JSONObject{})
+	assert.Error(t, err)
+}
+
+func TestInvokeContractBatchOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	signingKey := ethHexFormatB32(fftypes.NewRandB32())
+	parsedMethod, err := e.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+	calls := []*blockchain.ContractBatchCall{
+		{
+			Location:     fftypes.JSONAnyPtr(`{"address":"0x12345"}`),
+			ParsedMethod: parsedMethod,
+			Input:        map[string]interface{}{"x": float64(1), "y": "2"},
+		},
+		{
+			Location:     fftypes.JSONAnyPtr(`{"address":"0x67890"}`),
+			ParsedMethod: parsedMethod,
+			Input:        map[string]interface{}{"x": float64(3), "y": "4"},
+		},
+	}
+	expectedCallData, err := parsedMethod.(*parsedFFIMethod).methodABI.EncodeCallDataValues([]interface{}{float64(1), "2"})
+	assert.NoError(t, err)
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			headers := body["headers"].(map[string]interface{})
+			assert.Equal(t, "SendTransaction", headers["type"])
+			assert.Equal(t, "0x0000000000000000000000000000000000cafe", body["to"])
+			assert.Equal(t, "aggregate3", body["method"].(map[string]interface{})["name"])
+			calls3 := body["params"].([]interface{})[0].([]interface{})
+			assert.Len(t, calls3, 2)
+			call0 := calls3[0].(map[string]interface{})
+			assert.Equal(t, "0x12345", call0["target"])
+			assert.Equal(t, true, call0["allowFailure"])
+			assert.Equal(t, hex.EncodeToString(expectedCallData), call0["callData"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	_, err = e.InvokeContractBatch(context.Background(), "", signingKey, fftypes.JSONAnyPtr(`{"address":"0x0000000000000000000000000000000000cafe"}`), calls, true, nil)
+	assert.NoError(t, err)
+}
+
+func TestInvokeContractBatchBadAggregator(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	rejected, err := e.InvokeContractBatch(context.Background(), "", "0x123", fftypes.JSONAnyPtr(`{}`), nil, false, nil)
+	assert.Regexp(t, "FF10310", err)
+	assert.True(t, rejected)
+}
+
+func TestInvokeContractBatchBadCallLocation(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	rejected, err := e.InvokeContractBatch(context.Background(), "", "0x123", fftypes.JSONAnyPtr(`{"address":"0xcafe"}`), []*blockchain.ContractBatchCall{
+		{Location: fftypes.JSONAnyPtr(`{}`)},
+	}, false, nil)
+	assert.Regexp(t, "FF10310", err)
+	assert.True(t, rejected)
+}
+
+func TestInvokeContractBatchBadMethod(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	rejected, err := e.InvokeContractBatch(context.Background(), "", "0x123", fftypes.JSONAnyPtr(`{"address":"0xcafe"}`), []*blockchain.ContractBatchCall{
+		{Location: fftypes.JSONAnyPtr(`{"address":"0x12345"}`), ParsedMethod: "wrong"},
+	}, false, nil)
+	assert.Regexp(t, "FF10457", err)
+	assert.True(t, rejected)
+}
+
+func TestInvokeContractBatchBadInput(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	parsedMethod, err := e.ParseInterface(context.Background(), testFFIMethod(), nil)
+	assert.NoError(t, err)
+	rejected, err := e.InvokeContractBatch(context.Background(), "", "0x123", fftypes.JSONAnyPtr(`{"address":"0xcafe"}`), []*blockchain.ContractBatchCall{
+		{
+			Location:     fftypes.JSONAnyPtr(`{"address":"0x12345"}`),
+			ParsedMethod: parsedMethod,
+			Input:        map[string]interface{}{"x": "not a number"},
+		},
+	}, false, nil)
+	assert.Regexp(t, "FF22030", err)
+	assert.True(t, rejected)
+}
+
+func TestDecodeInvokeBatchReceiptOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	returnValue, err := multicallAggregate3ABI.Outputs.EncodeABIDataValues(map[string]interface{}{
+		"returnData": []interface{}{
+			map[string]interface{}{"success": true, "returnData": "0x0102"},
+			map[string]interface{}{"success": false, "returnData": "0x"},
+		},
+	})
+	assert.NoError(t, err)
+
+	results, err := e.DecodeInvokeBatchReceipt(context.Background(), fftypes.JSONObject{
+		"extraInfo": fftypes.JSONObject{"returnValue": "0x" + hex.EncodeToString(returnValue)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.ContractCallBatchItemResult{
+		{Success: true, ReturnData: "0x0102"},
+		{Success: false, ReturnData: "0x"},
+	}, results)
+}
+
+func TestDecodeInvokeBatchReceiptNoInfo(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	results, err := e.DecodeInvokeBatchReceipt(context.Background(), fftypes.JSONObject{})
+	assert.NoError(t, err)
+	assert.Nil(t, results)
+}
+
+func TestDecodeInvokeBatchReceiptBadExtraInfo(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	_, err := e.DecodeInvokeBatchReceipt(context.Background(), fftypes.JSONObject{
+		"extraInfo": fftypes.JSONObject{"returnValue": "!hex"},
+	})
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestDecodeInvokeBatchReceiptBadReturnValue(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	_, err := e.DecodeInvokeBatchReceipt(context.Background(), fftypes.JSONObject{
+		"extraInfo": fftypes.JSONObject{"returnValue": "0x0102"},
+	})
+	assert.Regexp(t, "FF10484", err)
+}
+
+func TestRequeryFireflySubscription(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	e.streams = &streamManager{
+		client: e.client,
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/subscriptions/sub1/reset`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]string
+			err := json.NewDecoder(req.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, "12345", body["fromBlock"])
+			return httpmock.NewStringResponder(204, "")(req)
+		})
+
+	err := e.RequeryFireflySubscription(context.Background(), "sub1", "000000012345/000000/000001")
+	assert.NoError(t, err)
+}
+
+func TestRequeryFireflySubscriptionFromStart(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	err := e.RequeryFireflySubscription(context.Background(), "sub1", "")
+	assert.Regexp(t, "FF10472", err)
+}
+
+func TestRequeryFireflySubscriptionBadProtocolID(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	err := e.RequeryFireflySubscription(context.Background(), "sub1", "bad/000000/000001")
+	assert.Regexp(t, "FF10472", err)
+}
+
+func TestRequeryFireflySubscriptionFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	e.streams = &streamManager{
+		client: e.client,
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/subscriptions/sub1/reset`,
+		httpmock.NewStringResponder(500, "pop"))
+
+	err := e.RequeryFireflySubscription(context.Background(), "sub1", "000000012345/000000/000001")
+	assert.Regexp(t, "FF10111", err)
+}
+
+func TestConnectionListener(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	mcl := coremocks.NewPluginConnectionListener(t)
+	mcl.On("PluginConnectionChanged", "ns1", false).Once()
+	e.SetConnectionListener(mcl)
+	e.connections.Update("ns1", true)
+	e.connections.Update("ns1", false)
+}
@@ -290,6 +290,18 @@ func (s *streamManager) deleteSubscription(ctx context.Context, subID string, ok
 	return nil
 }
 
+// resetSubscription rewinds a subscription, so the connector re-delivers its events from the given block
+func (s *streamManager) resetSubscription(ctx context.Context, subID, fromBlock string) error {
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetBody(map[string]string{"fromBlock": fromBlock}).
+		Post(fmt.Sprintf("/subscriptions/%s/reset", subID))
+	if err != nil || !res.IsSuccess() {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgEthConnectorRESTErr)
+	}
+	return nil
+}
+
 func (s *streamManager) ensureFireFlySubscription(ctx context.Context, namespace string, version int, instancePath, firstEvent, stream string, abi *abi.Entry, lastProtocolID string) (sub *subscription, err error) {
 	// Include a hash of the instance path in the subscription, so if we ever point at a different
 	// contract configuration, we re-subscribe from block 0.
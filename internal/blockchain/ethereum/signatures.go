@@ -0,0 +1,52 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// VerifySignature checks a personal_sign (EIP-191) signature, by recovering the address that
+// signed the prefixed payload and comparing it to the signing key.
+func (e *Ethereum) VerifySignature(ctx context.Context, signingKey string, payload []byte, signature *core.KeySignature) error {
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(signature.Value, "0x"))
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, err)
+	}
+	sig, err := secp256k1.DecodeCompactRSV(ctx, sigBytes)
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, err)
+	}
+
+	prefixed := append([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(payload))), payload...)
+	signer, err := sig.Recover(prefixed, 0)
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, err)
+	}
+	if !strings.EqualFold(signer.String(), signingKey) {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, fmt.Sprintf("signed by '%s'", signer))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethereum
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-signer/pkg/secp256k1"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func personalSign(t *testing.T, kp *secp256k1.KeyPair, payload []byte) *core.KeySignature {
+	sig, err := kp.Sign(append([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(payload))), payload...))
+	assert.NoError(t, err)
+	return &core.KeySignature{Value: "0x" + hex.EncodeToString(sig.CompactRSV())}
+}
+
+func TestVerifySignatureOK(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+
+	err = e.VerifySignature(context.Background(), strings.ToUpper(kp.Address.String()), payload, personalSign(t, kp, payload))
+	assert.NoError(t, err)
+}
+
+func TestVerifySignatureWrongKey(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	kp, err := secp256k1.GenerateSecp256k1KeyPair()
+	assert.NoError(t, err)
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+
+	err = e.VerifySignature(context.Background(), "0x1234567890123456789012345678901234567890", payload, personalSign(t, kp, payload))
+	assert.Regexp(t, "FF10595.*signed by", err)
+}
+
+func TestVerifySignatureBadHex(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	err := e.VerifySignature(context.Background(), "0x1234567890123456789012345678901234567890", []byte("{}"), &core.KeySignature{Value: "!hex"})
+	assert.Regexp(t, "FF10595", err)
+}
+
+func TestVerifySignatureBadLength(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	err := e.VerifySignature(context.Background(), "0x1234567890123456789012345678901234567890", []byte("{}"), &core.KeySignature{Value: "0x1234"})
+	assert.Regexp(t, "FF10595", err)
+}
+
+func TestVerifySignatureRecoverFail(t *testing.T) {
+	e, cancel := newTestEthereum()
+	defer cancel()
+
+	sig := make([]byte, 65)
+	sig[64] = 27
+	err := e.VerifySignature(context.Background(), "0x1234567890123456789012345678901234567890", []byte("{}"), &core.KeySignature{Value: hex.EncodeToString(sig)})
+	assert.Regexp(t, "FF10595", err)
+}
@@ -17,10 +17,17 @@
 package fabric
 
 import (
+	"context"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/base64"
 	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 )
 
 func getDNFromCertString(certString string) (string, error) {
@@ -50,3 +57,67 @@ func getCertificateFromBytes(certString string) (*x509.Certificate, error) {
 	}
 	return cert, nil
 }
+
+// loadMSPs reads the root and intermediate CA certificates of each organization in the channel,
+// from a directory with a sub-directory per MSP ID in the same layout as a Fabric MSP
+func loadMSPs(ctx context.Context, mspDir string) (map[string]*x509.VerifyOptions, error) {
+	msps := make(map[string]*x509.VerifyOptions)
+	if mspDir == "" {
+		return msps, nil
+	}
+	entries, err := os.ReadDir(mspDir)
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFabricMSPLoadFailed, mspDir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		mspID := entry.Name()
+		opts := &x509.VerifyOptions{
+			Roots:         x509.NewCertPool(),
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}
+		roots, err := loadMSPCerts(filepath.Join(mspDir, mspID, "cacerts"), opts.Roots)
+		if err == nil && roots == 0 {
+			err = fmt.Errorf("no certificates in cacerts")
+		}
+		if err == nil {
+			_, err = loadMSPCerts(filepath.Join(mspDir, mspID, "intermediatecerts"), opts.Intermediates)
+			if os.IsNotExist(err) {
+				err = nil // intermediate CAs are optional
+			}
+		}
+		if err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgFabricMSPLoadFailed, filepath.Join(mspDir, mspID), err)
+		}
+		msps[mspID] = opts
+	}
+	return msps, nil
+}
+
+func loadMSPCerts(certDir string, pool *x509.CertPool) (count int, err error) {
+	entries, err := os.ReadDir(certDir)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		pemBytes, err := os.ReadFile(filepath.Join(certDir, entry.Name()))
+		if err != nil {
+			return 0, err
+		}
+		for block, rest := pem.Decode(pemBytes); block != nil; block, rest = pem.Decode(rest) {
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return 0, fmt.Errorf("%s: %s", entry.Name(), err)
+			}
+			pool.AddCert(cert)
+			count++
+		}
+	}
+	return count, nil
+}
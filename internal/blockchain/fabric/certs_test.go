@@ -17,7 +17,11 @@
 package fabric
 
 import (
+	"context"
+	"crypto/x509"
 	"encoding/base64"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -82,3 +86,76 @@ func TestClient(t *testing.T) {
 	_, err = getDNFromCertString(certStr)
 	assert.Contains([]string{"x509: malformed certificate", "asn1: syntax error: data truncated"}, err.Error())
 }
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	assert.NoError(t, err)
+	err = os.WriteFile(path, data, 0644)
+	assert.NoError(t, err)
+}
+
+func TestLoadMSPs(t *testing.T) {
+	mspDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mspDir, "README"), []byte("not an MSP"))
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "cacerts", "ca.pem"), []byte(certWithOutAttrs+certWithAttrs))
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "cacerts", "old", "ca.pem"), []byte(badCert))
+	writeTestFile(t, filepath.Join(mspDir, "Org2MSP", "cacerts", "ca.pem"), []byte(certWithAttrs))
+	writeTestFile(t, filepath.Join(mspDir, "Org2MSP", "intermediatecerts", "ica.pem"), []byte(certWithOutAttrs))
+
+	msps, err := loadMSPs(context.Background(), mspDir)
+	assert.NoError(t, err)
+	assert.Len(t, msps, 2)
+	assert.False(t, msps["Org1MSP"].Roots.Equal(msps["Org2MSP"].Roots))
+	assert.True(t, msps["Org1MSP"].Intermediates.Equal(x509.NewCertPool()))
+	assert.False(t, msps["Org2MSP"].Intermediates.Equal(x509.NewCertPool()))
+}
+
+func TestLoadMSPsNotConfigured(t *testing.T) {
+	msps, err := loadMSPs(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Empty(t, msps)
+}
+
+func TestLoadMSPsMissingDir(t *testing.T) {
+	_, err := loadMSPs(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	assert.Regexp(t, "FF10619", err)
+}
+
+func TestLoadMSPsMissingCACerts(t *testing.T) {
+	mspDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "intermediatecerts", "ica.pem"), []byte(certWithOutAttrs))
+	_, err := loadMSPs(context.Background(), mspDir)
+	assert.Regexp(t, "FF10619.*Org1MSP", err)
+}
+
+func TestLoadMSPsNoCACerts(t *testing.T) {
+	mspDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "cacerts", "README"), []byte("no certs here"))
+	_, err := loadMSPs(context.Background(), mspDir)
+	assert.Regexp(t, "FF10619.*no certificates", err)
+}
+
+func TestLoadMSPsBadCert(t *testing.T) {
+	mspDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "cacerts", "ca.pem"), []byte(badCert))
+	_, err := loadMSPs(context.Background(), mspDir)
+	assert.Regexp(t, "FF10619.*ca.pem", err)
+}
+
+func TestLoadMSPsUnreadableCert(t *testing.T) {
+	mspDir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(mspDir, "Org1MSP", "cacerts"), 0755)
+	assert.NoError(t, err)
+	err = os.Symlink(filepath.Join(mspDir, "missing.pem"), filepath.Join(mspDir, "Org1MSP", "cacerts", "ca.pem"))
+	assert.NoError(t, err)
+	_, err = loadMSPs(context.Background(), mspDir)
+	assert.Regexp(t, "FF10619", err)
+}
+
+func TestLoadMSPsBadIntermediateCerts(t *testing.T) {
+	mspDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "cacerts", "ca.pem"), []byte(certWithOutAttrs))
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "intermediatecerts"), []byte("not a directory"))
+	_, err := loadMSPs(context.Background(), mspDir)
+	assert.Regexp(t, "FF10619", err)
+}
@@ -19,6 +19,7 @@ package fabric
 import (
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/proxy"
 )
 
 const (
@@ -53,6 +54,8 @@ const (
 	FabconnectPrefixLong = "prefixLong"
 	// FabconnectConfigChaincodeDeprecated is the Fabric Firefly chaincode deployed to the Firefly channels
 	FabconnectConfigChaincodeDeprecated = "chaincode"
+	// FabconnectConfigMSPDir is a directory with the MSP of each organization in the channel, in a sub-directory named by MSP ID containing cacerts and intermediatecerts
+	FabconnectConfigMSPDir = "mspDir"
 	// FabconnectBackgroundStart is used to not fail the fabric plugin on init and retry to start it in the background
 	FabconnectBackgroundStart = "backgroundStart.enabled"
 	// FabconnectBackgroundStartInitialDelay is delay between restarts in the case where we retry to restart in the fabric plugin
@@ -66,6 +69,7 @@ const (
 func (f *Fabric) InitConfig(config config.Section) {
 	f.fabconnectConf = config.SubSection(FabconnectConfigKey)
 	wsclient.InitConfig(f.fabconnectConf)
+	proxy.InitConfig(f.fabconnectConf)
 	f.fabconnectConf.AddKnownKey(FabconnectConfigDefaultChannel)
 	f.fabconnectConf.AddKnownKey(FabconnectConfigChaincodeDeprecated)
 	f.fabconnectConf.AddKnownKey(FabconnectConfigSigner)
@@ -74,6 +78,7 @@ func (f *Fabric) InitConfig(config config.Section) {
 	f.fabconnectConf.AddKnownKey(FabconnectConfigBatchTimeout, defaultBatchTimeout)
 	f.fabconnectConf.AddKnownKey(FabconnectPrefixShort, defaultPrefixShort)
 	f.fabconnectConf.AddKnownKey(FabconnectPrefixLong, defaultPrefixLong)
+	f.fabconnectConf.AddKnownKey(FabconnectConfigMSPDir)
 	f.fabconnectConf.AddKnownKey(FabconnectBackgroundStart)
 	f.fabconnectConf.AddKnownKey(FabconnectBackgroundStartFactor, defaultBackgroundRetryFactor)
 	f.fabconnectConf.AddKnownKey(FabconnectBackgroundStartInitialDelay, defaultBackgroundInitialDelay)
@@ -170,16 +170,17 @@ func (s *streamManager) getSubscription(ctx context.Context, subID string, okNot
 	return sub, nil
 }
 
-func (s *streamManager) getSubscriptionName(ctx context.Context, subID string, okNotFound bool) (string, error) {
+func (s *streamManager) getSubscriptionInfo(ctx context.Context, subID string) (name, channel string, err error) {
 	if cachedValue := s.cache.GetString("sub:" + subID); cachedValue != "" {
-		return cachedValue, nil
+		return cachedValue, s.cache.GetString("subchannel:" + subID), nil
 	}
-	sub, err := s.getSubscription(ctx, subID, okNotFound)
+	sub, err := s.getSubscription(ctx, subID, false)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	s.cache.SetString("sub:"+subID, sub.Name)
-	return sub.Name, nil
+	s.cache.SetString("subchannel:"+subID, sub.Channel)
+	return sub.Name, sub.Channel, nil
 }
 
 func resolveFromBlock(ctx context.Context, firstEvent, lastProtocolID string) (string, error) {
@@ -18,6 +18,7 @@ package fabric
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
@@ -37,6 +38,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/proxy"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -59,8 +61,12 @@ type Fabric struct {
 	streams        *streamManager
 	streamID       map[string]string
 	idCache        map[string]*fabIdentity
+	msps           map[string]*x509.VerifyOptions
+	connections    core.PluginConnectionTracker
+	eventQueue     core.InboundEventQueue
 	wsconn         map[string]wsclient.WSClient
 	wsConfig       *wsclient.WSConfig
+	wsFactory      proxy.WSFactory
 	closed         map[string]chan struct{}
 	metrics        metrics.Manager
 	fabconnectConf config.Section
@@ -205,7 +211,10 @@ func (f *Fabric) Init(ctx context.Context, cancelCtx context.CancelFunc, conf co
 
 	f.wsConfig, err = wsclient.GenerateConfig(ctx, fabconnectConf)
 	if err == nil {
-		f.client, err = ffresty.New(f.ctx, fabconnectConf)
+		f.client, err = proxy.NewRESTClient(f.ctx, fabconnectConf)
+	}
+	if err == nil {
+		f.wsFactory, err = proxy.NewWSFactory(ctx, fabconnectConf)
 	}
 
 	if err != nil {
@@ -221,6 +230,9 @@ func (f *Fabric) Init(ctx context.Context, cancelCtx context.CancelFunc, conf co
 	}
 	f.prefixShort = fabconnectConf.GetString(FabconnectPrefixShort)
 	f.prefixLong = fabconnectConf.GetString(FabconnectPrefixLong)
+	if f.msps, err = loadMSPs(ctx, fabconnectConf.GetString(FabconnectConfigMSPDir)); err != nil {
+		return err
+	}
 
 	if f.wsConfig.WSKeyPath == "" {
 		f.wsConfig.WSKeyPath = "/ws"
@@ -255,7 +267,11 @@ func (f *Fabric) StartNamespace(ctx context.Context, namespace string) (err erro
 	log.L(f.ctx).Debugf("Starting namespace: %s", namespace)
 	topic := f.getTopic(namespace)
 
-	f.wsconn[namespace], err = wsclient.New(ctx, f.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
+	f.wsconn[namespace], err = f.wsFactory.New(ctx, f.wsConfig, func(ctx context.Context, w wsclient.WSClient) error {
+		// Called before every attempt to (re)connect, so the previous connection has been lost
+		f.connections.Update(namespace, false)
+		return nil
+	}, func(ctx context.Context, w wsclient.WSClient) error {
 		// Send a subscribe to our topic after each connect/reconnect
 		b, _ := json.Marshal(&fabWSCommandPayload{
 			Type:  "listen",
@@ -268,6 +284,7 @@ func (f *Fabric) StartNamespace(ctx context.Context, namespace string) (err erro
 			})
 			err = w.Send(ctx, b)
 		}
+		f.connections.Update(namespace, err == nil)
 		return err
 	})
 	if err != nil {
@@ -299,6 +316,7 @@ func (f *Fabric) StopNamespace(ctx context.Context, namespace string) (err error
 		wsconn.Close()
 	}
 	delete(f.wsconn, namespace)
+	f.connections.Remove(namespace)
 	delete(f.streamID, namespace)
 	delete(f.closed, namespace)
 
@@ -309,6 +327,14 @@ func (f *Fabric) SetHandler(namespace string, handler blockchain.Callbacks) {
 	f.callbacks.SetHandler(namespace, handler)
 }
 
+func (f *Fabric) SetConnectionListener(listener core.PluginConnectionListener) {
+	f.connections.SetListener(listener)
+}
+
+func (f *Fabric) SetEventQueue(queue core.InboundEventQueue) {
+	f.eventQueue = queue
+}
+
 func (f *Fabric) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
 	f.callbacks.SetOperationalHandler(namespace, handler)
 }
@@ -317,6 +343,15 @@ func (f *Fabric) Capabilities() *blockchain.Capabilities {
 	return f.capabilities
 }
 
+// CheckReady only checks the connector is reachable - any HTTP response (including an error status) means it is
+func (f *Fabric) CheckReady(ctx context.Context) error {
+	res, err := f.client.R().SetContext(ctx).Get("/")
+	if err != nil {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgFabconnectRESTErr)
+	}
+	return nil
+}
+
 func decodeJSONPayload(ctx context.Context, payloadString string) *fftypes.JSONObject {
 	bytes, err := base64.StdEncoding.DecodeString(payloadString)
 	if err != nil {
@@ -332,7 +367,7 @@ func decodeJSONPayload(ctx context.Context, payloadString string) *fftypes.JSONO
 	return &payload
 }
 
-func (f *Fabric) parseBlockchainEvent(ctx context.Context, msgJSON fftypes.JSONObject) *blockchain.Event {
+func (f *Fabric) parseBlockchainEvent(ctx context.Context, channel string, msgJSON fftypes.JSONObject) *blockchain.Event {
 	payloadString := msgJSON.GetString("payload")
 	payload := decodeJSONPayload(ctx, payloadString)
 	if payload == nil {
@@ -352,6 +387,17 @@ func (f *Fabric) parseBlockchainEvent(ctx context.Context, msgJSON fftypes.JSONO
 	timestamp := msgJSON.GetInt64("timestamp")
 	chaincode := msgJSON.GetString("chaincodeId")
 
+	// The position of the event is checkpointed per channel, so that events re-delivered by FabConnect after a restart
+	// are discarded. Newer versions of FabConnect also supply the transaction and event indexes within the block.
+	checkpoint := &core.BlockchainCheckpoint{
+		Ledger:           channel,
+		Subscription:     msgJSON.GetString("subId"),
+		BlockNumber:      blockNumber,
+		TransactionIndex: msgJSON.GetInt64("transactionIndex"),
+		EventIndex:       msgJSON.GetInt64("eventIndex"),
+		TransactionID:    sTransactionHash,
+	}
+
 	delete(msgJSON, "payload")
 	return &blockchain.Event{
 		BlockchainTXID: sTransactionHash,
@@ -363,11 +409,12 @@ func (f *Fabric) parseBlockchainEvent(ctx context.Context, msgJSON fftypes.JSONO
 		Timestamp:      fftypes.UnixTime(timestamp),
 		Location:       f.buildEventLocationString(chaincode),
 		Signature:      name,
+		Checkpoint:     checkpoint,
 	}
 }
 
 func (f *Fabric) processBatchPinEvent(ctx context.Context, events common.EventsToDispatch, location *fftypes.JSONAny, subInfo *common.SubscriptionInfo, msgJSON fftypes.JSONObject) {
-	event := f.parseBlockchainEvent(ctx, msgJSON)
+	event := f.parseBlockchainEvent(ctx, subInfo.Extra.(string), msgJSON)
 	if event == nil {
 		return // move on
 	}
@@ -396,12 +443,12 @@ func (f *Fabric) buildEventLocationString(chaincode string) string {
 
 func (f *Fabric) processContractEvent(ctx context.Context, events common.EventsToDispatch, msgJSON fftypes.JSONObject) (err error) {
 	subID := msgJSON.GetString("subId")
-	subName, err := f.streams.getSubscriptionName(ctx, subID, false)
+	subName, channel, err := f.streams.getSubscriptionInfo(ctx, subID)
 	if err != nil {
 		return err // this is a problem - we should be able to find the listener that dispatched this to us
 	}
 	namespace := common.GetNamespaceFromSubName(subName)
-	event := f.parseBlockchainEvent(ctx, msgJSON)
+	event := f.parseBlockchainEvent(ctx, channel, msgJSON)
 	if event != nil {
 		f.callbacks.PrepareBlockchainEvent(ctx, events, namespace, &blockchain.EventForListener{
 			Event:      event,
@@ -453,6 +500,10 @@ func (f *Fabric) RemoveFireflySubscription(ctx context.Context, subID string) {
 	f.subs.RemoveSubscription(ctx, subID)
 }
 
+func (f *Fabric) RequeryFireflySubscription(ctx context.Context, subID, fromProtocolID string) error {
+	return i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
 func (f *Fabric) handleMessageBatch(ctx context.Context, messages []interface{}) error {
 	// Build the set of events that need handling
 	events := make(common.EventsToDispatch)
@@ -500,6 +551,13 @@ func (f *Fabric) handleMessageBatch(ctx context.Context, messages []interface{})
 	return f.callbacks.DispatchBlockchainEvents(ctx, events)
 }
 
+// handleQueuedBatch processes a batch of events that was acknowledged when it was stored in the event queue
+func (f *Fabric) handleQueuedBatch(ctx context.Context, msgBytes []byte) error {
+	var messages []interface{}
+	_ = json.Unmarshal(msgBytes, &messages) // only batches are queued
+	return f.handleMessageBatch(ctx, messages)
+}
+
 func (f *Fabric) eventLoop(namespace string, wsconn wsclient.WSClient, closed chan struct{}) {
 	topic := f.getTopic(namespace)
 	defer wsconn.Close()
@@ -507,6 +565,12 @@ func (f *Fabric) eventLoop(namespace string, wsconn wsclient.WSClient, closed ch
 	l := log.L(f.ctx).WithField("role", "event-loop").WithField("namespace", namespace)
 	ctx := log.WithLogger(f.ctx, l)
 	log.L(ctx).Debugf("Starting event loop for namespace '%s'", namespace)
+	if f.eventQueue != nil {
+		// Queued batches are processed for as long as the event loop is running
+		consumeCtx, stopConsuming := context.WithCancel(ctx)
+		defer stopConsuming()
+		f.eventQueue.Consume(consumeCtx, namespace, f.handleQueuedBatch)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -527,7 +591,11 @@ func (f *Fabric) eventLoop(namespace string, wsconn wsclient.WSClient, closed ch
 			}
 			switch msgTyped := msgParsed.(type) {
 			case []interface{}:
-				err = f.handleMessageBatch(ctx, msgTyped)
+				if f.eventQueue != nil {
+					err = f.eventQueue.Enqueue(ctx, namespace, msgBytes)
+				} else {
+					err = f.handleMessageBatch(ctx, msgTyped)
+				}
 				var ackOrNack []byte
 				if err == nil {
 					ackOrNack, _ = json.Marshal(map[string]string{"type": "ack", "topic": topic})
@@ -773,6 +841,22 @@ func (f *Fabric) DeployContract(ctx context.Context, nsOpID, signingKey string,
 	return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
 }
 
+func (f *Fabric) UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) GetContractABIs(ctx context.Context) ([]*core.ContractABI, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) GetContractABI(ctx context.Context, id string) (*core.ContractABI, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) DeployContractABI(ctx context.Context, nsOpID, signingKey, abiID string, input, options map[string]interface{}) (submissionRejected bool, err error) {
+	return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
 func (f *Fabric) ValidateInvokeRequest(ctx context.Context, parsedMethod interface{}, input map[string]interface{}, hasMessage bool) error {
 	// No additional validation beyond what is enforced by Contract Manager
 	_, _, err := f.recoverFFI(ctx, parsedMethod)
@@ -806,13 +890,16 @@ func (f *Fabric) InvokeContract(ctx context.Context, nsOpID string, signingKey s
 	}
 
 	if batch != nil {
+		pinIndex := blockchain.PinParamIndex(method)
+		if pinIndex < 0 {
+			return true, i18n.NewError(ctx, coremsgs.MsgMethodDoesNotSupportPinning)
+		}
 		_, batchPin := f.buildBatchPinInput(2, "", batch)
 		if input == nil {
 			input = make(map[string]interface{})
 		}
 		batchPinBytes, _ := json.Marshal(batchPin)
-		lastParam := method.Params[len(method.Params)-1]
-		input[lastParam.Name] = string(batchPinBytes)
+		input[method.Params[pinIndex].Name] = string(batchPinBytes)
 	}
 
 	return f.invokeContractMethod(ctx, fabricOnChainLocation.Channel, fabricOnChainLocation.Chaincode, method.Name, signingKey, nsOpID, prefixItems, input, options)
@@ -871,6 +958,19 @@ func (f *Fabric) QueryContract(ctx context.Context, signingKey string, location
 	return output.Result, nil
 }
 
+func (f *Fabric) InvokeContractBatch(ctx context.Context, nsOpID, signingKey string, aggregator *fftypes.JSONAny, calls []*blockchain.ContractBatchCall, allowFailure bool, options map[string]interface{}) (bool, error) {
+	return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) DecodeInvokeBatchReceipt(ctx context.Context, receipt fftypes.JSONObject) ([]*core.ContractCallBatchItemResult, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (f *Fabric) DecodeInvokeReceipt(ctx context.Context, parsedMethod interface{}, events []*fftypes.FFIEvent, receipt fftypes.JSONObject) (fftypes.JSONObject, []*core.ContractInvokeEvent, error) {
+	// Fabconnect receipts do not contain the chaincode response payload, or the chaincode events of the transaction
+	return nil, nil, nil
+}
+
 func jsonEncodeInput(params map[string]interface{}) (output map[string]interface{}, err error) {
 	output = make(map[string]interface{}, len(params))
 	for field, value := range params {
@@ -1012,10 +1112,6 @@ func (f *Fabric) GetFFIParamValidator(ctx context.Context) (fftypes.FFIParamVali
 	return nil, nil
 }
 
-func (f *Fabric) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
-	return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationUnsupported)
-}
-
 func (f *Fabric) GenerateEventSignature(ctx context.Context, event *fftypes.FFIEventDefinition) (string, error) {
 	return event.Name, nil
 }
@@ -1129,3 +1225,7 @@ func (f *Fabric) GetTransactionStatus(ctx context.Context, operation *core.Opera
 
 	return statusResponse, nil
 }
+
+func (f *Fabric) GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -143,6 +144,32 @@ func mockNetworkVersion(version float64) func(req *http.Request) (*http.Response
 	}
 }
 
+func TestCheckReady(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/",
+		httpmock.NewStringResponder(200, ""))
+
+	err := e.CheckReady(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckReadyFail(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/",
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	err := e.CheckReady(context.Background())
+	assert.Regexp(t, "FF10284", err)
+}
+
 func TestStartNamespaceWSConnectFail(t *testing.T) {
 	e, cancel := newTestFabric()
 	defer cancel()
@@ -245,6 +272,33 @@ func TestInitMissingTopic(t *testing.T) {
 	assert.Regexp(t, "FF10138.*topic", err)
 }
 
+func TestInitBadMSPDir(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	resetConf(e)
+	utFabconnectConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utFabconnectConf.Set(FabconnectConfigTopic, "topic1")
+	utFabconnectConf.Set(FabconnectConfigMSPDir, filepath.Join(t.TempDir(), "missing"))
+
+	cmi := &cachemocks.Manager{}
+	err := e.Init(e.ctx, e.cancelCtx, utConfig, &metricsmocks.Manager{}, cmi)
+	assert.Regexp(t, "FF10619", err)
+}
+
+func TestInitWebSocketProxy(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	resetConf(e)
+	utFabconnectConf.Set(ffresty.HTTPConfigURL, "http://fabconnect.example.com:12345")
+	utFabconnectConf.Set(ffresty.HTTPConfigProxyURL, "http://proxy.example.com:8080")
+	utFabconnectConf.Set(FabconnectConfigTopic, "topic1")
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(e.ctx, 100, 5*time.Minute), nil)
+	err := e.Init(e.ctx, e.cancelCtx, utConfig, &metricsmocks.Manager{}, cmi)
+	assert.NoError(t, err)
+}
+
 func TestBadTLS(t *testing.T) {
 	e, cancel := newTestFabric()
 	defer cancel()
@@ -1571,6 +1625,16 @@ func TestHandleMessageBatchPinOK(t *testing.T) {
 	assert.Equal(t, "68e4da79f805bca5b912bcda9c63d03e6e867108dabb9b944109aea541ef522a", b.Batch.Contexts[0].String())
 	assert.Equal(t, "19b82093de5ce92a01e333048e877e2374354bf846dd034864ef6ffbd6438771", b.Batch.Contexts[1].String())
 
+	checkpoint := em.Calls[0].Arguments[0].([]*blockchain.EventToDispatch)[0].BatchPinComplete.Batch.Event.Checkpoint
+	assert.Equal(t, &core.BlockchainCheckpoint{
+		Ledger:           "firefly",
+		Subscription:     "sb-0910f6a8-7bd6-4ced-453e-2db68149ce8e",
+		BlockNumber:      91,
+		TransactionIndex: 2,
+		EventIndex:       50,
+		TransactionID:    "ce79343000e851a0c742f63a733ce19a5f8b9ce1c719b6cecd14f01bcf81fff2",
+	}, checkpoint)
+
 	em.AssertExpectations(t)
 
 }
@@ -1733,6 +1797,35 @@ func TestEventLoopSendClosed(t *testing.T) {
 	wsm.AssertExpectations(t)
 }
 
+func TestEventLoopQueued(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	mq := &coremocks.InboundEventQueue{}
+	e.SetEventQueue(mq)
+	r := make(chan []byte, 1)
+	r <- []byte(`[]`)
+	close(r)
+	wsm := &wsmocks.WSClient{}
+	e.wsconn["ns1"] = wsm
+	var handler core.InboundEventHandler
+	mq.On("Consume", mock.Anything, "ns1", mock.Anything).Run(func(args mock.Arguments) {
+		handler = args[2].(core.InboundEventHandler)
+	})
+	mq.On("Enqueue", mock.Anything, "ns1", []byte(`[]`)).Return(nil)
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Send", mock.Anything, mock.Anything).Return(nil).Once()
+	wsm.On("Close").Return()
+	e.closed["ns1"] = make(chan struct{})
+	e.eventLoop("ns1", wsm, e.closed["ns1"])
+
+	// The queued batch is processed by the consumer
+	err := handler(context.Background(), []byte(`[]`))
+	assert.NoError(t, err)
+
+	mq.AssertExpectations(t)
+	wsm.AssertExpectations(t)
+}
+
 func TestEventLoopUnexpectedMessage(t *testing.T) {
 	e, cancel := newTestFabric()
 	defer cancel()
@@ -2183,7 +2276,7 @@ func TestHandleMessageContractEventOldSubscription(t *testing.T) {
 
 	httpmock.RegisterResponder("GET", "http://localhost:12345/subscriptions/sb-cb37cc07-e873-4f58-44ab-55add6bba320",
 		httpmock.NewJsonResponderOrPanic(200, subscription{
-			ID: "sb-cb37cc07-e873-4f58-44ab-55add6bba320", Stream: "es12345", Name: "old-sub-name",
+			ID: "sb-cb37cc07-e873-4f58-44ab-55add6bba320", Stream: "es12345", Name: "old-sub-name", Channel: "firefly",
 		}))
 
 	e.streams = newTestStreamManager(e.client, e.signer)
@@ -2224,6 +2317,9 @@ func TestHandleMessageContractEventOldSubscription(t *testing.T) {
 		"transactionId": "4763a0c50e3bba7cef1a7ba35dd3f9f3426bb04d0156f326e84ec99387c4746d",
 	}
 	assert.Equal(t, info, ev.Event.Info)
+	assert.Equal(t, "firefly", ev.Event.Checkpoint.Ledger)
+	assert.Equal(t, "sb-cb37cc07-e873-4f58-44ab-55add6bba320", ev.Event.Checkpoint.Subscription)
+	assert.Equal(t, int64(10), ev.Event.Checkpoint.BlockNumber)
 
 	em.AssertExpectations(t)
 }
@@ -2582,6 +2678,66 @@ func TestInvokeContractWithBatchOK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestInvokeContractWithBatchPinParam(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	httpmock.ActivateNonDefault(e.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+	signingKey := fftypes.NewRandB32().String()
+	location := &Location{
+		Channel:   "firefly",
+		Chaincode: "simplestorage",
+	}
+	locationBytes, err := json.Marshal(location)
+	assert.NoError(t, err)
+	method := testFFIPinMethod()
+	method.Params = append(method.Params, &fftypes.FFIParam{
+		Name:   "owner",
+		Schema: fftypes.JSONAnyPtr(`{"type": "string"}`),
+	})
+	method.Details = fftypes.JSONObject{"pinParam": "data"}
+	batch := &blockchain.BatchPin{
+		TransactionID:   fftypes.MustParseUUID("82281f91-6ba8-498a-9e68-c6c6a594b747"),
+		BatchID:         fftypes.MustParseUUID("f3cce875-b979-48be-99a6-1a64c780330d"),
+		BatchHash:       fftypes.MustParseBytes32("4529bffbf77984bfa4b83126f8b963e4da10d194e021f097a60d952d81783649"),
+		BatchPayloadRef: "test-payload",
+		Contexts:        []*fftypes.Bytes32{},
+	}
+
+	httpmock.RegisterResponder("POST", `http://localhost:12345/transactions`,
+		func(req *http.Request) (*http.Response, error) {
+			var body map[string]interface{}
+			json.NewDecoder(req.Body).Decode(&body)
+			args := body["args"].(map[string]interface{})
+			assert.Regexp(t, "test-payload", args["data"])
+			assert.Equal(t, "alice", args["owner"])
+			return httpmock.NewJsonResponderOrPanic(200, "")(req)
+		})
+
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+	_, err = e.InvokeContract(context.Background(), "", signingKey, fftypes.JSONAnyPtrBytes(locationBytes), parsedMethod, map[string]interface{}{"owner": "alice"}, nil, batch)
+	assert.NoError(t, err)
+}
+
+func TestInvokeContractWithBatchNoPinParam(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	location := &Location{
+		Channel:   "firefly",
+		Chaincode: "simplestorage",
+	}
+	locationBytes, err := json.Marshal(location)
+	assert.NoError(t, err)
+	method := testFFIPinMethod()
+	method.Details = fftypes.JSONObject{"pinParam": "missing"}
+
+	parsedMethod, err := e.ParseInterface(context.Background(), method, nil)
+	assert.NoError(t, err)
+	_, err = e.InvokeContract(context.Background(), "", "key", fftypes.JSONAnyPtrBytes(locationBytes), parsedMethod, nil, nil, &blockchain.BatchPin{})
+	assert.Regexp(t, "FF10443", err)
+}
+
 func TestDeployContractOK(t *testing.T) {
 	e, cancel := newTestFabric()
 	defer cancel()
@@ -2942,17 +3098,6 @@ func TestGetFFIParamValidator(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestGenerateFFI(t *testing.T) {
-	e, _ := newTestFabric()
-	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
-		Name:        "Simple",
-		Version:     "v0.0.1",
-		Description: "desc",
-		Input:       fftypes.JSONAnyPtr(`[]`),
-	})
-	assert.Regexp(t, "FF10347", err)
-}
-
 func TestGenerateEventSignature(t *testing.T) {
 	e, _ := newTestFabric()
 	signature, err := e.GenerateEventSignature(context.Background(), &fftypes.FFIEventDefinition{Name: "Changed"})
@@ -3659,3 +3804,67 @@ func TestCheckOverLappingLocationsSameChannelDifferentChaincode(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, result)
 }
+
+func TestInvokeContractBatchNotSupported(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	rejected, err := e.InvokeContractBatch(context.Background(), "", "", nil, nil, false, nil)
+	assert.Regexp(t, "FF10429", err)
+	assert.True(t, rejected)
+}
+
+func TestDecodeInvokeBatchReceiptNotSupported(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	_, err := e.DecodeInvokeBatchReceipt(context.Background(), fftypes.JSONObject{})
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestDecodeInvokeReceipt(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	returnValues, events, err := e.DecodeInvokeReceipt(context.Background(), nil, nil, fftypes.JSONObject{})
+	assert.NoError(t, err)
+	assert.Nil(t, returnValues)
+	assert.Nil(t, events)
+}
+
+func TestRequeryFireflySubscriptionNotSupported(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	err := e.RequeryFireflySubscription(context.Background(), "sub1", "")
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestContractABIsNotSupported(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+	_, err := e.UploadContractABI(context.Background(), &core.ContractABIUpload{})
+	assert.Regexp(t, "FF10429", err)
+	_, err = e.GetContractABIs(context.Background())
+	assert.Regexp(t, "FF10429", err)
+	_, err = e.GetContractABI(context.Background(), "abi1")
+	assert.Regexp(t, "FF10429", err)
+	rejected, err := e.DeployContractABI(context.Background(), "", "", "abi1", nil, nil)
+	assert.Regexp(t, "FF10429", err)
+	assert.True(t, rejected)
+}
+
+func TestConnectionListener(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	mcl := coremocks.NewPluginConnectionListener(t)
+	mcl.On("PluginConnectionChanged", "ns1", false).Once()
+	e.SetConnectionListener(mcl)
+	e.connections.Update("ns1", true)
+	e.connections.Update("ns1", false)
+}
+
+func TestGetTransactionReceiptNotSupported(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	_, err := f.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.Regexp(t, "FF10429", err)
+}
@@ -0,0 +1,200 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const (
+	metadataSystemContract  = "org.hyperledger.fabric"
+	metadataSchemaRefPrefix = "#/components/schemas/"
+	metadataMaxRefDepth     = 32
+)
+
+// FFIGenerationInput is the input for generating an FFI from the metadata of a chaincode built with
+// the Fabric contract API, as returned by the "org.hyperledger.fabric:GetMetadata" transaction
+type FFIGenerationInput struct {
+	Metadata *contractMetadata `json:"metadata,omitempty"`
+	// Contract selects the contract within the chaincode, when it contains more than one
+	Contract string `json:"contract,omitempty"`
+}
+
+type contractMetadata struct {
+	Contracts  map[string]*contractMetadataContract `json:"contracts"`
+	Components struct {
+		Schemas map[string]interface{} `json:"schemas"`
+	} `json:"components"`
+}
+
+type contractMetadataContract struct {
+	Name         string                         `json:"name"`
+	Transactions []*contractMetadataTransaction `json:"transactions"`
+	Info         *contractMetadataInfo          `json:"info,omitempty"`
+}
+
+type contractMetadataInfo struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type contractMetadataTransaction struct {
+	Name       string                   `json:"name"`
+	Tag        []string                 `json:"tag,omitempty"`
+	Parameters []*contractMetadataParam `json:"parameters,omitempty"`
+	Returns    interface{}              `json:"returns,omitempty"`
+}
+
+type contractMetadataParam struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	Schema      interface{} `json:"schema"`
+}
+
+func (f *Fabric) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
+	var input FFIGenerationInput
+	err := json.Unmarshal(generationRequest.Input.Bytes(), &input)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgFFIGenerationFailed, "unable to deserialize JSON as contract metadata")
+	}
+	if input.Metadata == nil || len(input.Metadata.Contracts) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, "contract metadata is empty")
+	}
+
+	var contractNames []string
+	for name := range input.Metadata.Contracts {
+		if name != metadataSystemContract {
+			contractNames = append(contractNames, name)
+		}
+	}
+	sort.Strings(contractNames)
+	contractName := input.Contract
+	if contractName == "" {
+		if len(contractNames) != 1 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, fmt.Sprintf("'contract' must be one of %s", strings.Join(contractNames, ",")))
+		}
+		contractName = contractNames[0]
+	}
+	contract, ok := input.Metadata.Contracts[contractName]
+	if !ok || contractName == metadataSystemContract {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, fmt.Sprintf("contract '%s' not found in metadata", contractName))
+	}
+
+	ffi := &fftypes.FFI{
+		Namespace:   generationRequest.Namespace,
+		Name:        generationRequest.Name,
+		Version:     generationRequest.Version,
+		Description: generationRequest.Description,
+		Methods:     make([]*fftypes.FFIMethod, len(contract.Transactions)),
+		Events:      []*fftypes.FFIEvent{},
+		Errors:      []*fftypes.FFIError{},
+	}
+	if ffi.Description == "" && contract.Info != nil {
+		ffi.Description = contract.Info.Description
+	}
+	for i, tx := range contract.Transactions {
+		// Transactions on any contract other than the default must be qualified with the contract name
+		methodName := tx.Name
+		if len(contractNames) > 1 {
+			methodName = fmt.Sprintf("%s:%s", contractName, tx.Name)
+		}
+		method := &fftypes.FFIMethod{
+			Name:    methodName,
+			Params:  make(fftypes.FFIParams, len(tx.Parameters)),
+			Returns: fftypes.FFIParams{},
+		}
+		if len(tx.Tag) > 0 {
+			method.Details = fftypes.JSONObject{"tag": tx.Tag}
+		}
+		for j, param := range tx.Parameters {
+			if method.Params[j], err = input.Metadata.convertParam(ctx, param.Name, param.Schema); err != nil {
+				return nil, err
+			}
+		}
+		if tx.Returns != nil {
+			returns, err := input.Metadata.convertParam(ctx, "result", tx.Returns)
+			if err != nil {
+				return nil, err
+			}
+			method.Returns = append(method.Returns, returns)
+		}
+		ffi.Methods[i] = method
+	}
+	return ffi, nil
+}
+
+func (m *contractMetadata) convertParam(ctx context.Context, name string, schema interface{}) (*fftypes.FFIParam, error) {
+	resolved, err := m.resolveSchemaRefs(ctx, schema, 0)
+	if err != nil {
+		return nil, err
+	}
+	b, _ := json.Marshal(resolved)
+	return &fftypes.FFIParam{
+		Name:   name,
+		Schema: fftypes.JSONAnyPtrBytes(b),
+	}, nil
+}
+
+// resolveSchemaRefs inlines any references to the shared component schemas of the metadata,
+// as each FFI parameter must carry a self-contained JSON schema
+func (m *contractMetadata) resolveSchemaRefs(ctx context.Context, schema interface{}, depth int) (interface{}, error) {
+	if depth > metadataMaxRefDepth {
+		return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, "schema references are too deeply nested")
+	}
+	switch s := schema.(type) {
+	case map[string]interface{}:
+		if ref, ok := s["$ref"].(string); ok {
+			component, ok := m.Components.Schemas[strings.TrimPrefix(ref, metadataSchemaRefPrefix)]
+			if !ok || !strings.HasPrefix(ref, metadataSchemaRefPrefix) {
+				return nil, i18n.NewError(ctx, coremsgs.MsgFFIGenerationFailed, fmt.Sprintf("unable to resolve schema reference '%s'", ref))
+			}
+			return m.resolveSchemaRefs(ctx, component, depth+1)
+		}
+		resolved := make(map[string]interface{}, len(s))
+		for k, v := range s {
+			if k == "$id" {
+				continue
+			}
+			rv, err := m.resolveSchemaRefs(ctx, v, depth)
+			if err != nil {
+				return nil, err
+			}
+			resolved[k] = rv
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(s))
+		for i, v := range s {
+			rv, err := m.resolveSchemaRefs(ctx, v, depth)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = rv
+		}
+		return resolved, nil
+	default:
+		return schema, nil
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const testContractMetadata = `{
+	"metadata": {
+		"contracts": {
+			"AssetTransfer": {
+				"name": "AssetTransfer",
+				"info": {
+					"title": "Asset transfer",
+					"description": "Transfers assets between owners"
+				},
+				"transactions": [
+					{
+						"name": "CreateAsset",
+						"tag": ["submitTx"],
+						"parameters": [
+							{"name": "id", "schema": {"type": "string"}},
+							{"name": "value", "schema": {"type": "integer"}}
+						]
+					},
+					{
+						"name": "ReadAsset",
+						"tag": ["evaluateTx"],
+						"parameters": [
+							{"name": "id", "schema": {"type": "string"}}
+						],
+						"returns": {"$ref": "#/components/schemas/Asset"}
+					},
+					{
+						"name": "GetAllAssets",
+						"returns": {"type": "array", "items": {"$ref": "#/components/schemas/Asset"}}
+					}
+				]
+			},
+			"org.hyperledger.fabric": {
+				"name": "org.hyperledger.fabric",
+				"transactions": [
+					{"name": "GetMetadata"}
+				]
+			}
+		},
+		"components": {
+			"schemas": {
+				"Asset": {
+					"$id": "Asset",
+					"type": "object",
+					"properties": {
+						"id": {"type": "string"},
+						"value": {"type": "integer"}
+					},
+					"required": ["id", "value"]
+				}
+			}
+		}
+	}
+}`
+
+func TestGenerateFFIFromMetadata(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	ffi, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Namespace: "ns1",
+		Name:      "assets",
+		Version:   "v1.0.0",
+		Input:     fftypes.JSONAnyPtr(testContractMetadata),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "ns1", ffi.Namespace)
+	assert.Equal(t, "assets", ffi.Name)
+	assert.Equal(t, "v1.0.0", ffi.Version)
+	assert.Equal(t, "Transfers assets between owners", ffi.Description)
+	assert.Empty(t, ffi.Events)
+	assert.Len(t, ffi.Methods, 3)
+
+	assert.Equal(t, "CreateAsset", ffi.Methods[0].Name)
+	assert.Equal(t, fftypes.JSONObject{"tag": []string{"submitTx"}}, ffi.Methods[0].Details)
+	assert.Len(t, ffi.Methods[0].Params, 2)
+	assert.Equal(t, "value", ffi.Methods[0].Params[1].Name)
+	assert.JSONEq(t, `{"type":"integer"}`, ffi.Methods[0].Params[1].Schema.String())
+	assert.Empty(t, ffi.Methods[0].Returns)
+
+	assert.Equal(t, "ReadAsset", ffi.Methods[1].Name)
+	assert.Equal(t, "result", ffi.Methods[1].Returns[0].Name)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"id": {"type": "string"},
+			"value": {"type": "integer"}
+		},
+		"required": ["id", "value"]
+	}`, ffi.Methods[1].Returns[0].Schema.String())
+
+	assert.Nil(t, ffi.Methods[2].Details)
+	assert.JSONEq(t, `{
+		"type": "array",
+		"items": {
+			"type": "object",
+			"properties": {
+				"id": {"type": "string"},
+				"value": {"type": "integer"}
+			},
+			"required": ["id", "value"]
+		}
+	}`, ffi.Methods[2].Returns[0].Schema.String())
+}
+
+func TestGenerateFFIFromMetadataMultipleContracts(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	input := `{
+		"contract": "Second",
+		"metadata": {
+			"contracts": {
+				"First": {"name": "First", "transactions": [{"name": "One"}]},
+				"Second": {"name": "Second", "transactions": [{"name": "Two"}]}
+			}
+		}
+	}`
+	ffi, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Description: "desc",
+		Input:       fftypes.JSONAnyPtr(input),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "desc", ffi.Description)
+	assert.Len(t, ffi.Methods, 1)
+	assert.Equal(t, "Second:Two", ffi.Methods[0].Name)
+}
+
+func TestGenerateFFIFromMetadataContractRequired(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	input := `{
+		"metadata": {
+			"contracts": {
+				"First": {"name": "First"},
+				"Second": {"name": "Second"}
+			}
+		}
+	}`
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(input),
+	})
+	assert.Regexp(t, "FF10346.*First,Second", err)
+}
+
+func TestGenerateFFIFromMetadataContractNotFound(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(`{"contract": "org.hyperledger.fabric", "metadata": {"contracts": {"org.hyperledger.fabric": {}}}}`),
+	})
+	assert.Regexp(t, "FF10346.*not found", err)
+}
+
+func TestGenerateFFIFromMetadataBadInput(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(`[]`),
+	})
+	assert.Regexp(t, "FF10346", err)
+}
+
+func TestGenerateFFIFromMetadataEmpty(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(`{}`),
+	})
+	assert.Regexp(t, "FF10346.*empty", err)
+}
+
+func TestGenerateFFIFromMetadataBadParamRef(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	input := `{
+		"metadata": {
+			"contracts": {
+				"Assets": {"name": "Assets", "transactions": [
+					{"name": "Create", "parameters": [{"name": "asset", "schema": {"$ref": "#/components/schemas/Missing"}}]}
+				]}
+			}
+		}
+	}`
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(input),
+	})
+	assert.Regexp(t, "FF10346.*Missing", err)
+}
+
+func TestGenerateFFIFromMetadataBadReturnsRef(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	input := `{
+		"metadata": {
+			"contracts": {
+				"Assets": {"name": "Assets", "transactions": [
+					{"name": "List", "returns": {"type": "array", "items": [{"$ref": "Missing"}]}}
+				]}
+			}
+		}
+	}`
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(input),
+	})
+	assert.Regexp(t, "FF10346.*Missing", err)
+}
+
+func TestGenerateFFIFromMetadataRecursiveRef(t *testing.T) {
+	e, cancel := newTestFabric()
+	defer cancel()
+
+	input := `{
+		"metadata": {
+			"contracts": {
+				"Assets": {"name": "Assets", "transactions": [
+					{"name": "Get", "returns": {"$ref": "#/components/schemas/Node"}}
+				]}
+			},
+			"components": {
+				"schemas": {
+					"Node": {"type": "object", "properties": {"next": {"$ref": "#/components/schemas/Node"}}}
+				}
+			}
+		}
+	}`
+	_, err := e.GenerateFFI(context.Background(), &fftypes.FFIGenerationRequest{
+		Input: fftypes.JSONAnyPtr(input),
+	})
+	assert.Regexp(t, "FF10346.*nested", err)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// VerifySignature checks an ECDSA signature over the SHA-256 hash of the payload, made by the key in the
+// certificate accompanying the signature. The certificate must have the subject and issuer in the MSP
+// identity string of the signing key, and must chain to a CA of the MSP configured for the channel.
+func (f *Fabric) VerifySignature(ctx context.Context, signingKey string, payload []byte, signature *core.KeySignature) error {
+	keyParts := strings.Split(signingKey, "::")
+	if len(keyParts) != 4 || keyParts[1] != "x509" {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, "not a fully qualified MSP identity")
+	}
+	if signature.Certificate == "" {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureCertRequired, signingKey)
+	}
+	block, _ := pem.Decode([]byte(signature.Certificate))
+	if block == nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, "certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, err)
+	}
+	if getDN(&cert.Subject) != keyParts[2] || getDN(&cert.Issuer) != keyParts[3] {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, fmt.Sprintf("certificate issued to '%s' by '%s'", getDN(&cert.Subject), getDN(&cert.Issuer)))
+	}
+	msp := f.msps[keyParts[0]]
+	if msp == nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, fmt.Sprintf("no CA certificates configured for MSP '%s'", keyParts[0]))
+	}
+	if _, err := cert.Verify(*msp); err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, err)
+	}
+
+	pubKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, "certificate does not contain an ECDSA public key")
+	}
+	sigBytes, err := base64.StdEncoding.DecodeString(signature.Value)
+	if err != nil {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, err)
+	}
+	hash := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(pubKey, hash[:], sigBytes) {
+		return i18n.NewError(ctx, coremsgs.MsgKeySignatureInvalid, signingKey, "signature does not match certificate")
+	}
+	return nil
+}
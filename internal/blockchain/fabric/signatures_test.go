@@ -0,0 +1,279 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCA creates a CA for org1, which is self-signed if it has no parent
+func newTestCA(t *testing.T, commonName string, parent *testCA) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"org1.example.com"}},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+func (ca *testCA) msp() *x509.VerifyOptions {
+	opts := &x509.VerifyOptions{Roots: x509.NewCertPool(), KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}}
+	opts.Roots.AddCert(ca.cert)
+	return opts
+}
+
+func (ca *testCA) issue(t *testing.T, key crypto.Signer) (string, string) {
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "user1", OrganizationalUnit: []string{"client"}},
+		NotBefore:    time.Now().Add(-1 * time.Hour),
+		NotAfter:     time.Now().Add(1 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, leaf, ca.cert, key.Public(), ca.key)
+	assert.NoError(t, err)
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	signingKey := fmt.Sprintf("Org1MSP::x509::%s::%s", getDN(&leaf.Subject), getDN(&ca.cert.Subject))
+	return certPEM, signingKey
+}
+
+func newTestSignerCert(t *testing.T, f *Fabric, key crypto.Signer) (string, string) {
+	ca := newTestCA(t, "ca.org1.example.com", nil)
+	f.msps = map[string]*x509.VerifyOptions{"Org1MSP": ca.msp()}
+	return ca.issue(t, key)
+}
+
+func signPayload(t *testing.T, key *ecdsa.PrivateKey, payload []byte) string {
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	assert.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestVerifySignatureOK(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, signingKey := newTestSignerCert(t, f, key)
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+
+	err = f.VerifySignature(context.Background(), signingKey, payload, &core.KeySignature{
+		Value:       signPayload(t, key, payload),
+		Certificate: certPEM,
+	})
+	assert.NoError(t, err)
+}
+
+func TestVerifySignatureIntermediateCA(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	rootCA := newTestCA(t, "ca.org1.example.com", nil)
+	intermediateCA := newTestCA(t, "ica.org1.example.com", rootCA)
+	mspDir := t.TempDir()
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "cacerts", "ca.pem"), rootCA.pem())
+	writeTestFile(t, filepath.Join(mspDir, "Org1MSP", "intermediatecerts", "ica.pem"), intermediateCA.pem())
+	msps, err := loadMSPs(context.Background(), mspDir)
+	assert.NoError(t, err)
+	f.msps = msps
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, signingKey := intermediateCA.issue(t, key)
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+
+	err = f.VerifySignature(context.Background(), signingKey, payload, &core.KeySignature{
+		Value:       signPayload(t, key, payload),
+		Certificate: certPEM,
+	})
+	assert.NoError(t, err)
+}
+
+func TestVerifySignatureSelfSignedCAWithMatchingDNs(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	// A CA outside of the MSP, that claims the same name as the real CA
+	f.msps = map[string]*x509.VerifyOptions{"Org1MSP": newTestCA(t, "ca.org1.example.com", nil).msp()}
+	impostorCA := newTestCA(t, "ca.org1.example.com", nil)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, signingKey := impostorCA.issue(t, key)
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+
+	err = f.VerifySignature(context.Background(), signingKey, payload, &core.KeySignature{
+		Value:       signPayload(t, key, payload),
+		Certificate: certPEM,
+	})
+	assert.Regexp(t, "FF10595.*unknown authority", err)
+}
+
+func TestVerifySignatureUnknownMSP(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, signingKey := newTestSignerCert(t, f, key)
+	f.msps = map[string]*x509.VerifyOptions{}
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+
+	err = f.VerifySignature(context.Background(), signingKey, payload, &core.KeySignature{
+		Value:       signPayload(t, key, payload),
+		Certificate: certPEM,
+	})
+	assert.Regexp(t, "FF10595.*Org1MSP", err)
+}
+
+func TestVerifySignatureMismatch(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, signingKey := newTestSignerCert(t, f, key)
+
+	err = f.VerifySignature(context.Background(), signingKey, []byte(`{"did":"did:firefly:org/org1"}`), &core.KeySignature{
+		Value:       signPayload(t, key, []byte(`{"did":"did:firefly:org/org2"}`)),
+		Certificate: certPEM,
+	})
+	assert.Regexp(t, "FF10595.*does not match", err)
+}
+
+func TestVerifySignatureBadBase64(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, signingKey := newTestSignerCert(t, f, key)
+
+	err = f.VerifySignature(context.Background(), signingKey, []byte("{}"), &core.KeySignature{
+		Value:       "!base64",
+		Certificate: certPEM,
+	})
+	assert.Regexp(t, "FF10595", err)
+}
+
+func TestVerifySignatureNotECDSA(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+	certPEM, signingKey := newTestSignerCert(t, f, key)
+
+	err = f.VerifySignature(context.Background(), signingKey, []byte("{}"), &core.KeySignature{
+		Value:       "",
+		Certificate: certPEM,
+	})
+	assert.Regexp(t, "FF10595.*ECDSA", err)
+}
+
+func TestVerifySignatureWrongSubject(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	certPEM, _ := newTestSignerCert(t, f, key)
+
+	err = f.VerifySignature(context.Background(), "Org1MSP::x509::CN=user2::CN=ca", []byte("{}"), &core.KeySignature{
+		Value:       signPayload(t, key, []byte("{}")),
+		Certificate: certPEM,
+	})
+	assert.Regexp(t, "FF10595.*issued to", err)
+}
+
+func TestVerifySignatureBadCert(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	err := f.VerifySignature(context.Background(), "Org1MSP::x509::CN=user1::CN=ca", []byte("{}"), &core.KeySignature{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("!cert")})),
+	})
+	assert.Regexp(t, "FF10595", err)
+}
+
+func TestVerifySignatureNotPEM(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	err := f.VerifySignature(context.Background(), "Org1MSP::x509::CN=user1::CN=ca", []byte("{}"), &core.KeySignature{
+		Certificate: "!pem",
+	})
+	assert.Regexp(t, "FF10595.*PEM", err)
+}
+
+func TestVerifySignatureNoCert(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	err := f.VerifySignature(context.Background(), "Org1MSP::x509::CN=user1::CN=ca", []byte("{}"), &core.KeySignature{})
+	assert.Regexp(t, "FF10596", err)
+}
+
+func TestVerifySignatureShortKey(t *testing.T) {
+	f, cancel := newTestFabric()
+	defer cancel()
+
+	err := f.VerifySignature(context.Background(), "user1", []byte("{}"), &core.KeySignature{})
+	assert.Regexp(t, "FF10595.*MSP", err)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/ffresty"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/proxy"
 )
 
 const (
@@ -81,6 +82,7 @@ const (
 func (t *Tezos) InitConfig(config config.Section) {
 	t.tezosconnectConf = config.SubSection(TezosconnectConfigKey)
 	wsclient.InitConfig(t.tezosconnectConf)
+	proxy.InitConfig(t.tezosconnectConf)
 	t.tezosconnectConf.AddKnownKey(TezosconnectConfigTopic)
 	t.tezosconnectConf.AddKnownKey(TezosconnectBackgroundStart)
 	t.tezosconnectConf.AddKnownKey(TezosconnectBackgroundStartInitialDelay, defaultBackgroundInitialDelay)
@@ -93,6 +95,7 @@ func (t *Tezos) InitConfig(config config.Section) {
 
 	addressResolverConf := config.SubSection(AddressResolverConfigKey)
 	ffresty.InitConfig(addressResolverConf)
+	proxy.InitConfig(addressResolverConf)
 	addressResolverConf.AddKnownKey(AddressResolverAlwaysResolve)
 	addressResolverConf.AddKnownKey(AddressResolverRetainOriginal)
 	addressResolverConf.AddKnownKey(AddressResolverMethod, defaultAddressResolverMethod)
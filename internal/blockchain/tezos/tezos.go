@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/proxy"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -60,6 +61,8 @@ type Tezos struct {
 	client               *resty.Client
 	streams              *streamManager
 	streamID             string
+	connections          core.PluginConnectionTracker
+	eventQueue           core.InboundEventQueue
 	wsconn               wsclient.WSClient
 	closed               chan struct{}
 	addressResolveAlways bool
@@ -167,9 +170,13 @@ func (t *Tezos) Init(ctx context.Context, cancelCtx context.CancelFunc, conf con
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", tezosconnectConf)
 	}
 
+	var wsFactory proxy.WSFactory
 	wsConfig, err := wsclient.GenerateConfig(ctx, tezosconnectConf)
 	if err == nil {
-		t.client, err = ffresty.New(t.ctx, tezosconnectConf)
+		t.client, err = proxy.NewRESTClient(t.ctx, tezosconnectConf)
+	}
+	if err == nil {
+		wsFactory, err = proxy.NewWSFactory(ctx, tezosconnectConf)
 	}
 
 	if err != nil {
@@ -186,7 +193,7 @@ func (t *Tezos) Init(ctx context.Context, cancelCtx context.CancelFunc, conf con
 	if wsConfig.WSKeyPath == "" {
 		wsConfig.WSKeyPath = "/ws"
 	}
-	t.wsconn, err = wsclient.New(ctx, wsConfig, nil, t.afterConnect)
+	t.wsconn, err = wsFactory.New(ctx, wsConfig, t.beforeConnect, t.afterConnect)
 	if err != nil {
 		return err
 	}
@@ -244,6 +251,14 @@ func (t *Tezos) SetHandler(namespace string, handler blockchain.Callbacks) {
 	t.callbacks.SetHandler(namespace, handler)
 }
 
+func (t *Tezos) SetConnectionListener(listener core.PluginConnectionListener) {
+	t.connections.SetListener(listener)
+}
+
+func (t *Tezos) SetEventQueue(queue core.InboundEventQueue) {
+	t.eventQueue = queue
+}
+
 func (t *Tezos) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
 	t.callbacks.SetOperationalHandler(namespace, handler)
 }
@@ -261,6 +276,15 @@ func (t *Tezos) Capabilities() *blockchain.Capabilities {
 	return t.capabilities
 }
 
+// CheckReady only checks the connector is reachable - any HTTP response (including an error status) means it is
+func (t *Tezos) CheckReady(ctx context.Context) error {
+	res, err := t.client.R().SetContext(ctx).Get("/")
+	if err != nil {
+		return ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgTezosconnectRESTErr)
+	}
+	return nil
+}
+
 func (t *Tezos) AddFireflySubscription(ctx context.Context,
 	namespace *core.Namespace,
 	contract *blockchain.MultipartyContract,
@@ -286,6 +310,14 @@ func (t *Tezos) RemoveFireflySubscription(ctx context.Context, subID string) {
 	t.subs.RemoveSubscription(ctx, subID)
 }
 
+func (t *Tezos) RequeryFireflySubscription(ctx context.Context, subID, fromProtocolID string) error {
+	return i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) VerifySignature(ctx context.Context, signingKey string, payload []byte, signature *core.KeySignature) error {
+	return i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
 func (t *Tezos) ResolveSigningKey(ctx context.Context, key string, intent blockchain.ResolveKeyIntent) (resolved string, err error) {
 	// Key is always required
 	if key == "" {
@@ -395,6 +427,35 @@ func (t *Tezos) QueryContract(ctx context.Context, signingKey string, location *
 	return output, nil
 }
 
+func (t *Tezos) UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) GetContractABIs(ctx context.Context) ([]*core.ContractABI, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) GetContractABI(ctx context.Context, id string) (*core.ContractABI, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) DeployContractABI(ctx context.Context, nsOpID, signingKey, abiID string, input, options map[string]interface{}) (submissionRejected bool, err error) {
+	return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) InvokeContractBatch(ctx context.Context, nsOpID, signingKey string, aggregator *fftypes.JSONAny, calls []*blockchain.ContractBatchCall, allowFailure bool, options map[string]interface{}) (bool, error) {
+	return true, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) DecodeInvokeBatchReceipt(ctx context.Context, receipt fftypes.JSONObject) ([]*core.ContractCallBatchItemResult, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) DecodeInvokeReceipt(ctx context.Context, parsedMethod interface{}, events []*fftypes.FFIEvent, receipt fftypes.JSONObject) (fftypes.JSONObject, []*core.ContractInvokeEvent, error) {
+	// Tezos entrypoints do not return values, and the receipt does not contain the events of the operation
+	return nil, nil, nil
+}
+
 func (t *Tezos) ParseInterface(ctx context.Context, method *fftypes.FFIMethod, errors []*fftypes.FFIError) (interface{}, error) {
 	return &ffiMethodAndErrors{
 		method: method,
@@ -604,6 +665,16 @@ func (t *Tezos) GetTransactionStatus(ctx context.Context, operation *core.Operat
 	return statusResponse, nil
 }
 
+func (t *Tezos) GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+}
+
+func (t *Tezos) beforeConnect(ctx context.Context, w wsclient.WSClient) error {
+	// Called before every attempt to (re)connect, so the previous connection has been lost
+	t.connections.Update("", false)
+	return nil
+}
+
 func (t *Tezos) afterConnect(ctx context.Context, w wsclient.WSClient) error {
 	// Send a subscribe to our topic after each connect/reconnect
 	b, _ := json.Marshal(&tezosWSCommandPayload{
@@ -617,6 +688,7 @@ func (t *Tezos) afterConnect(ctx context.Context, w wsclient.WSClient) error {
 		})
 		err = w.Send(ctx, b)
 	}
+	t.connections.Update("", err == nil)
 	return err
 }
 
@@ -790,6 +862,12 @@ func (t *Tezos) eventLoop() {
 	defer close(t.closed)
 	l := log.L(t.ctx).WithField("role", "event-loop")
 	ctx := log.WithLogger(t.ctx, l)
+	if t.eventQueue != nil {
+		// Queued batches are processed for as long as the event loop is running
+		consumeCtx, stopConsuming := context.WithCancel(ctx)
+		defer stopConsuming()
+		t.eventQueue.Consume(consumeCtx, "", t.handleQueuedBatch)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -810,7 +888,11 @@ func (t *Tezos) eventLoop() {
 			}
 			switch msgTyped := msgParsed.(type) {
 			case []interface{}:
-				err = t.handleMessageBatch(ctx, 0, msgTyped)
+				if t.eventQueue != nil {
+					err = t.eventQueue.Enqueue(ctx, "", msgBytes)
+				} else {
+					err = t.handleMessageBatch(ctx, 0, msgTyped)
+				}
 				if err == nil {
 					ack, _ := json.Marshal(&tezosWSCommandPayload{
 						Type:  "ack",
@@ -840,6 +922,13 @@ func (t *Tezos) eventLoop() {
 	}
 }
 
+// handleQueuedBatch processes a batch of events that was acknowledged when it was stored in the event queue
+func (t *Tezos) handleQueuedBatch(ctx context.Context, msgBytes []byte) error {
+	var messages []interface{}
+	_ = json.Unmarshal(msgBytes, &messages) // only batches are queued
+	return t.handleMessageBatch(ctx, 0, messages)
+}
+
 func (t *Tezos) handleMessageBatch(ctx context.Context, batchID int64, messages []interface{}) error {
 	// TODO:
 	return nil
@@ -156,6 +156,23 @@ func TestBadTLSConfig(t *testing.T) {
 	assert.Regexp(t, "FF00153", err)
 }
 
+func TestInitWebSocketProxy(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	resetConf(tz)
+	utTezosconnectConf.Set(ffresty.HTTPConfigURL, "http://tezosconnect.example.com:12345")
+	utTezosconnectConf.Set(ffresty.HTTPConfigProxyURL, "http://proxy.example.com:8080")
+	utTezosconnectConf.Set(TezosconnectConfigTopic, "topic1")
+	utTezosconnectConf.Set(TezosconnectBackgroundStart, true)
+
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(tz.ctx, 100, 5*time.Minute), nil)
+
+	err := tz.Init(tz.ctx, tz.cancelCtx, utConfig, tz.metrics, cmi)
+	assert.NoError(t, err)
+}
+
 func TestInitBadAddressResolver(t *testing.T) {
 	tz, cancel := newTestTezos()
 	defer cancel()
@@ -615,6 +632,35 @@ func TestEventLoopSendClosed(t *testing.T) {
 	wsm.AssertExpectations(t)
 }
 
+func TestEventLoopQueued(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	mq := &coremocks.InboundEventQueue{}
+	tz.SetEventQueue(mq)
+
+	r := make(chan []byte, 1)
+	r <- []byte(`[]`)
+	close(r)
+	wsm := tz.wsconn.(*wsmocks.WSClient)
+	var handler core.InboundEventHandler
+	mq.On("Consume", mock.Anything, "", mock.Anything).Run(func(args mock.Arguments) {
+		handler = args[2].(core.InboundEventHandler)
+	})
+	mq.On("Enqueue", mock.Anything, "", []byte(`[]`)).Return(nil)
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Send", mock.Anything, mock.Anything).Return(nil).Once()
+	wsm.On("Close").Return()
+	tz.closed = make(chan struct{})
+	tz.eventLoop()
+
+	// The queued batch is processed by the consumer
+	err := handler(context.Background(), []byte(`[]`))
+	assert.NoError(t, err)
+
+	mq.AssertExpectations(t)
+	wsm.AssertExpectations(t)
+}
+
 func TestEventLoopUnexpectedMessage(t *testing.T) {
 	tz, cancel := newTestTezos()
 	defer cancel()
@@ -2109,6 +2155,32 @@ func TestSubmitBatchPin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCheckReady(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	httpmock.ActivateNonDefault(tz.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/",
+		httpmock.NewStringResponder(200, ""))
+
+	err := tz.CheckReady(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestCheckReadyFail(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	httpmock.ActivateNonDefault(tz.client.GetClient())
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "http://localhost:12345/",
+		httpmock.NewErrorResponder(fmt.Errorf("pop")))
+
+	err := tz.CheckReady(context.Background())
+	assert.Regexp(t, "FF10283", err)
+}
+
 func TestStartNamespace(t *testing.T) {
 	tz, cancel := newTestTezos()
 	defer cancel()
@@ -2198,3 +2270,75 @@ func TestCheckOverlappingLocationsSameLocation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, result)
 }
+
+func TestContractABIsNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	_, err := tz.UploadContractABI(context.Background(), &core.ContractABIUpload{})
+	assert.Regexp(t, "FF10429", err)
+	_, err = tz.GetContractABIs(context.Background())
+	assert.Regexp(t, "FF10429", err)
+	_, err = tz.GetContractABI(context.Background(), "abi1")
+	assert.Regexp(t, "FF10429", err)
+	rejected, err := tz.DeployContractABI(context.Background(), "", "", "abi1", nil, nil)
+	assert.Regexp(t, "FF10429", err)
+	assert.True(t, rejected)
+}
+
+func TestInvokeContractBatchNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	rejected, err := tz.InvokeContractBatch(context.Background(), "", "", nil, nil, false, nil)
+	assert.Regexp(t, "FF10429", err)
+	assert.True(t, rejected)
+}
+
+func TestDecodeInvokeBatchReceiptNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	_, err := tz.DecodeInvokeBatchReceipt(context.Background(), fftypes.JSONObject{})
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestDecodeInvokeReceipt(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	returnValues, events, err := tz.DecodeInvokeReceipt(context.Background(), nil, nil, fftypes.JSONObject{})
+	assert.NoError(t, err)
+	assert.Nil(t, returnValues)
+	assert.Nil(t, events)
+}
+
+func TestRequeryFireflySubscriptionNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	err := tz.RequeryFireflySubscription(context.Background(), "sub1", "")
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestVerifySignatureNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+	err := tz.VerifySignature(context.Background(), "tz1", []byte("{}"), &core.KeySignature{})
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestConnectionListener(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	mcl := coremocks.NewPluginConnectionListener(t)
+	mcl.On("PluginConnectionChanged", "", false).Once()
+	tz.SetConnectionListener(mcl)
+	tz.connections.Update("", true)
+	err := tz.beforeConnect(context.Background(), nil)
+	assert.NoError(t, err)
+}
+
+func TestGetTransactionReceiptNotSupported(t *testing.T) {
+	tz, cancel := newTestTezos()
+	defer cancel()
+
+	_, err := tz.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.Regexp(t, "FF10429", err)
+}
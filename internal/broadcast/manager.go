@@ -41,13 +41,17 @@ import (
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 )
 
-const broadcastDispatcherName = "pinned_broadcast"
+const (
+	broadcastDispatcherName        = "pinned_broadcast"
+	broadcastPinOnlyDispatcherName = "pinonly_broadcast"
+)
 
 type Manager interface {
 	core.Named
 
 	NewBroadcast(in *core.MessageInOut) syncasync.Sender
 	BroadcastMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (out *core.Message, err error)
+	BroadcastPinOnlyMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (out *core.Message, err error)
 	PublishDataValue(ctx context.Context, id string, idempotencyKey core.IdempotencyKey) (*core.Data, error)
 	PublishDataBlob(ctx context.Context, id string, idempotencyKey core.IdempotencyKey) (*core.Data, error)
 	Start() error
@@ -97,12 +101,18 @@ func NewBroadcastManager(ctx context.Context, ns *core.Namespace, di database.Pl
 	}
 
 	if ba != nil && mult != nil {
+		compression, err := fftypes.FFEnumParseString(ctx, "batchcompression", config.GetString(coreconfig.BroadcastBatchCompression))
+		if err != nil {
+			return nil, err
+		}
 		bo := batch.DispatcherOptions{
-			BatchType:      core.BatchTypeBroadcast,
-			BatchMaxSize:   config.GetUint(coreconfig.BroadcastBatchSize),
-			BatchMaxBytes:  bm.maxBatchPayloadLength,
-			BatchTimeout:   config.GetDuration(coreconfig.BroadcastBatchTimeout),
-			DisposeTimeout: config.GetDuration(coreconfig.BroadcastBatchAgentTimeout),
+			BatchType:        core.BatchTypeBroadcast,
+			BatchMaxSize:     config.GetUint(coreconfig.BroadcastBatchSize),
+			BatchMaxBytes:    bm.maxBatchPayloadLength,
+			BatchTimeout:     config.GetDuration(coreconfig.BroadcastBatchTimeout),
+			DisposeTimeout:   config.GetDuration(coreconfig.BroadcastBatchAgentTimeout),
+			BatchCompression: compression,
+			TopicAffinity:    config.GetBool(coreconfig.BroadcastBatchTopicAffinity),
 		}
 
 		ba.RegisterDispatcher(broadcastDispatcherName,
@@ -113,6 +123,11 @@ func NewBroadcastManager(ctx context.Context, ns *core.Namespace, di database.Pl
 				core.MessageTypeDeprecatedTransferBroadcast,
 				core.MessageTypeDeprecatedApprovalBroadcast,
 			}, bm.dispatchBatch, bo)
+		ba.RegisterDispatcher(broadcastPinOnlyDispatcherName,
+			true,
+			[]core.MessageType{
+				core.MessageTypeBroadcastPinOnly,
+			}, bm.dispatchPinOnlyBatch, bo)
 	}
 
 	om.RegisterHandler(ctx, bm, []core.OpType{
@@ -159,6 +174,12 @@ func (bm *broadcastManager) dispatchBatch(ctx context.Context, payload *batch.Di
 	return bm.multiparty.SubmitBatchPin(ctx, &payload.Batch, payload.Pins, payloadRef, false /* batch processing does not currently use idempotency keys */)
 }
 
+// dispatchPinOnlyBatch pins the hash of the batch, without uploading the batch to shared storage
+func (bm *broadcastManager) dispatchPinOnlyBatch(ctx context.Context, payload *batch.DispatchPayload) error {
+	log.L(ctx).Infof("Pinning pin-only broadcast batch %s with author=%s key=%s", payload.Batch.ID, payload.Batch.Author, payload.Batch.Key)
+	return bm.multiparty.SubmitBatchPin(ctx, &payload.Batch, payload.Pins, core.BatchPayloadRefPinOnly, false /* batch processing does not currently use idempotency keys */)
+}
+
 func (bm *broadcastManager) uploadBlobs(ctx context.Context, tx *fftypes.UUID, data core.DataArray, idempotentSubmit bool) error {
 	for _, d := range data {
 		// We only need to send a blob if there is one, and it's not been uploaded to the shared storage
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/batch"
@@ -72,6 +73,12 @@ func newTestBroadcastCommon(t *testing.T, metricsEnabled bool) (*broadcastManage
 			core.MessageTypeDeprecatedTransferBroadcast,
 			core.MessageTypeDeprecatedApprovalBroadcast,
 		}, mock.Anything, mock.Anything).Return()
+	mba.On("RegisterDispatcher",
+		broadcastPinOnlyDispatcherName,
+		true,
+		[]core.MessageType{
+			core.MessageTypeBroadcastPinOnly,
+		}, mock.Anything, mock.Anything).Return()
 
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
 
@@ -105,6 +112,13 @@ func TestInitFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitBadCompression(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.BroadcastBatchCompression, "lz4")
+	_, err := NewBroadcastManager(context.Background(), &core.Namespace{}, &databasemocks.Plugin{}, &blockchainmocks.Plugin{}, &dataexchangemocks.Plugin{}, &sharedstoragemocks.Plugin{}, &identitymanagermocks.Manager{}, &datamocks.Manager{}, &batchmocks.Manager{}, &syncasyncmocks.Bridge{}, &multipartymocks.Manager{}, &metricsmocks.Manager{}, &operationmocks.Manager{}, &txcommonmocks.Helper{})
+	assert.Regexp(t, "FF00172", err)
+}
+
 func TestName(t *testing.T) {
 	bm, cancel := newTestBroadcast(t)
 	defer cancel()
@@ -266,7 +280,7 @@ func TestDispatchBatchSubmitBatchPinSucceed(t *testing.T) {
 	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
 		data := op.Data.(uploadBatchData)
 		return op.Type == core.OpTypeSharedStorageUploadBatch && data.Batch.ID.Equals(state.Batch.ID)
-	}), false).Return(getUploadBatchOutputs("payload1"), nil)
+	}), false).Return(getUploadBatchOutputs("payload1", nil), nil)
 
 	err := bm.dispatchBatch(context.Background(), state)
 	assert.NoError(t, err)
@@ -298,7 +312,7 @@ func TestDispatchBatchSubmitBroadcastFail(t *testing.T) {
 	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
 		data := op.Data.(uploadBatchData)
 		return op.Type == core.OpTypeSharedStorageUploadBatch && data.Batch.ID.Equals(state.Batch.ID)
-	}), false).Return(getUploadBatchOutputs("payload1"), nil)
+	}), false).Return(getUploadBatchOutputs("payload1", nil), nil)
 
 	err := bm.dispatchBatch(context.Background(), state)
 	assert.EqualError(t, err, "pop")
@@ -308,6 +322,28 @@ func TestDispatchBatchSubmitBroadcastFail(t *testing.T) {
 	mom.AssertExpectations(t)
 }
 
+func TestDispatchPinOnlyBatch(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+
+	state := &batch.DispatchPayload{
+		Batch: core.BatchPersisted{
+			BatchHeader: core.BatchHeader{
+				ID: fftypes.NewUUID(),
+			},
+		},
+		Pins: []*fftypes.Bytes32{fftypes.NewRandB32()},
+	}
+
+	mmp := bm.multiparty.(*multipartymocks.Manager)
+	mmp.On("SubmitBatchPin", mock.Anything, &state.Batch, state.Pins, core.BatchPayloadRefPinOnly, false).Return(nil)
+
+	err := bm.dispatchPinOnlyBatch(context.Background(), state)
+	assert.NoError(t, err)
+
+	mmp.AssertExpectations(t)
+}
+
 func TestUploadBlobPublishFail(t *testing.T) {
 	bm, cancel := newTestBroadcast(t)
 	defer cancel()
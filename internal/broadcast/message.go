@@ -56,6 +56,20 @@ func (bm *broadcastManager) BroadcastMessage(ctx context.Context, in *core.Messa
 	return &in.Message, err
 }
 
+// BroadcastPinOnlyMessage broadcasts a message where only the hash of the batch is pinned. The payload is not
+// uploaded to shared storage, and must be distributed to the other members out-of-band
+func (bm *broadcastManager) BroadcastPinOnlyMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (out *core.Message, err error) {
+	in.Header.Type = core.MessageTypeBroadcastPinOnly
+	in.Header.TxType = core.TransactionTypeBatchPin
+	broadcast := bm.NewBroadcast(in)
+	if waitConfirm {
+		err = broadcast.SendAndWait(ctx)
+	} else {
+		err = broadcast.Send(ctx)
+	}
+	return &in.Message, err
+}
+
 type broadcastSender struct {
 	mgr      *broadcastManager
 	msg      *data.NewMessage
@@ -132,8 +146,19 @@ func (s *broadcastSender) resolve(ctx context.Context) error {
 	}
 
 	// The data manager is responsible for the heavy lifting of storing/validating all our in-line data elements
-	err := s.mgr.data.ResolveInlineData(ctx, s.msg)
-	return err
+	if err := s.mgr.data.ResolveInlineData(ctx, s.msg); err != nil {
+		return err
+	}
+
+	// Blobs are only ever distributed via shared storage, so cannot be included when the payload is not shared
+	if msg.Header.Type == core.MessageTypeBroadcastPinOnly {
+		for _, d := range s.msg.AllData {
+			if d.Blob != nil {
+				return i18n.NewError(ctx, coremsgs.MsgPinOnlyBlobNotSupported, d.ID)
+			}
+		}
+	}
+	return nil
 }
 
 func (s *broadcastSender) sendInternal(ctx context.Context, method sendMethod) (err error) {
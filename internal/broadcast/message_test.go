@@ -214,6 +214,96 @@ func TestBroadcastMessageBadIdentity(t *testing.T) {
 	mim.AssertExpectations(t)
 }
 
+func TestBroadcastPinOnlyMessageOk(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+	mdm := bm.data.(*datamocks.Manager)
+	mim := bm.identity.(*identitymanagermocks.Manager)
+
+	ctx := context.Background()
+	mdm.On("ResolveInlineData", ctx, mock.Anything).Return(nil)
+	mdm.On("WriteNewMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mim.On("ResolveInputSigningIdentity", ctx, mock.Anything).Return(nil)
+
+	msg, err := bm.BroadcastPinOnlyMessage(ctx, &core.MessageInOut{
+		InlineData: core.InlineData{
+			{Value: fftypes.JSONAnyPtr(`{"hello": "world"}`)},
+		},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageTypeBroadcastPinOnly, msg.Header.Type)
+	assert.Equal(t, core.TransactionTypeBatchPin, msg.Header.TxType)
+
+	mim.AssertExpectations(t)
+	mdm.AssertExpectations(t)
+}
+
+func TestBroadcastPinOnlyMessageWaitConfirmOk(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+	mdm := bm.data.(*datamocks.Manager)
+	msa := bm.syncasync.(*syncasyncmocks.Bridge)
+	mim := bm.identity.(*identitymanagermocks.Manager)
+
+	ctx := context.Background()
+	mdm.On("ResolveInlineData", ctx, mock.Anything).Return(nil)
+	mim.On("ResolveInputSigningIdentity", ctx, mock.Anything).Return(nil)
+
+	replyMsg := &core.Message{
+		Header: core.MessageHeader{
+			Namespace: "ns1",
+			ID:        fftypes.NewUUID(),
+			Type:      core.MessageTypeBroadcastPinOnly,
+		},
+	}
+	msa.On("WaitForMessage", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			send := args[2].(syncasync.SendFunction)
+			send(ctx)
+		}).
+		Return(replyMsg, nil)
+	mdm.On("WriteNewMessage", ctx, mock.Anything, mock.Anything).Return(nil)
+
+	msg, err := bm.BroadcastPinOnlyMessage(ctx, &core.MessageInOut{
+		InlineData: core.InlineData{
+			{Value: fftypes.JSONAnyPtr(`{"hello": "world"}`)},
+		},
+	}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, replyMsg, msg)
+
+	msa.AssertExpectations(t)
+	mdm.AssertExpectations(t)
+}
+
+func TestBroadcastPinOnlyMessageBlobNotSupported(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+	mdm := bm.data.(*datamocks.Manager)
+	mim := bm.identity.(*identitymanagermocks.Manager)
+
+	ctx := context.Background()
+	mdm.On("ResolveInlineData", ctx, mock.Anything).Run(
+		func(args mock.Arguments) {
+			newMsg := args[1].(*data.NewMessage)
+			newMsg.AllData = core.DataArray{
+				{ID: fftypes.NewUUID(), Blob: &core.BlobRef{Hash: fftypes.NewRandB32()}},
+			}
+		}).
+		Return(nil)
+	mim.On("ResolveInputSigningIdentity", ctx, mock.Anything).Return(nil)
+
+	_, err := bm.BroadcastPinOnlyMessage(ctx, &core.MessageInOut{
+		InlineData: core.InlineData{
+			{Value: fftypes.JSONAnyPtr(`{"hello": "world"}`)},
+		},
+	}, false)
+	assert.Regexp(t, "FF10530", err)
+
+	mim.AssertExpectations(t)
+	mdm.AssertExpectations(t)
+}
+
 func TestBroadcastPrepare(t *testing.T) {
 	bm, cancel := newTestBroadcast(t)
 	defer cancel()
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -48,10 +49,8 @@ func addUploadBatchInputs(op *core.Operation, batchID *fftypes.UUID) {
 	}
 }
 
-func getUploadBatchOutputs(payloadRef string) fftypes.JSONObject {
-	return fftypes.JSONObject{
-		"payloadRef": payloadRef,
-	}
+func getUploadBatchOutputs(payloadRef string, pin *core.DataPin) fftypes.JSONObject {
+	return getUploadBlobOutputs(payloadRef, pin)
 }
 
 func addUploadBlobInputs(op *core.Operation, dataID *fftypes.UUID) {
@@ -66,10 +65,14 @@ func addUploadValueInputs(op *core.Operation, dataID *fftypes.UUID) {
 	}
 }
 
-func getUploadBlobOutputs(payloadRef string) fftypes.JSONObject {
-	return fftypes.JSONObject{
+func getUploadBlobOutputs(payloadRef string, pin *core.DataPin) fftypes.JSONObject {
+	outputs := fftypes.JSONObject{
 		"payloadRef": payloadRef,
 	}
+	if pin != nil {
+		outputs["pin"] = pin
+	}
+	return outputs
 }
 
 func retrieveUploadBatchInputs(ctx context.Context, op *core.Operation) (*fftypes.UUID, error) {
@@ -161,6 +164,9 @@ func (bm *broadcastManager) uploadBatch(ctx context.Context, data uploadBatchDat
 	if err != nil {
 		return nil, core.OpPhaseInitializing, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
 	}
+	if payload, err = core.CompressBatchPayload(ctx, data.Batch.Compression, payload); err != nil {
+		return nil, core.OpPhaseInitializing, err
+	}
 
 	// Write it to IPFS to get a payload reference
 	payloadRef, err := bm.sharedstorage.UploadData(ctx, bytes.NewReader(payload))
@@ -168,7 +174,7 @@ func (bm *broadcastManager) uploadBatch(ctx context.Context, data uploadBatchDat
 		return nil, core.OpPhaseInitializing, err
 	}
 	log.L(ctx).Infof("Published batch '%s' to shared storage: '%s'", data.Batch.ID, payloadRef)
-	return getUploadBatchOutputs(payloadRef), core.OpPhaseComplete, nil
+	return getUploadBatchOutputs(payloadRef, bm.pinUpload(ctx, payloadRef)), core.OpPhaseComplete, nil
 }
 
 // uploadBlob streams a blob from the local data exchange, to public storage
@@ -188,13 +194,14 @@ func (bm *broadcastManager) uploadBlob(ctx context.Context, data uploadBlobData)
 	}
 
 	// Update the data in the DB
-	err = bm.database.UpdateData(ctx, bm.namespace.Name, data.Data.ID, database.DataQueryFactory.NewUpdate(ctx).Set("blob.public", data.Data.Blob.Public))
+	pin := bm.pinUpload(ctx, data.Data.Blob.Public)
+	err = bm.database.UpdateData(ctx, bm.namespace.Name, data.Data.ID, setDataPin(database.DataQueryFactory.NewUpdate(ctx).Set("blob.public", data.Data.Blob.Public), pin))
 	if err != nil {
 		return nil, core.OpPhaseInitializing, err
 	}
 
 	log.L(ctx).Infof("Published blob with hash '%s' for data '%s' to shared storage: '%s'", data.Data.Blob.Hash, data.Data.ID, data.Data.Blob.Public)
-	return getUploadBlobOutputs(data.Data.Blob.Public), core.OpPhaseComplete, nil
+	return getUploadBlobOutputs(data.Data.Blob.Public, pin), core.OpPhaseComplete, nil
 }
 
 // uploadValue streams the value JSON from a data record to public storage
@@ -207,13 +214,36 @@ func (bm *broadcastManager) uploadValue(ctx context.Context, data uploadValue) (
 	}
 
 	// Update the public reference for the data in the DB
-	err = bm.database.UpdateData(ctx, bm.namespace.Name, data.Data.ID, database.DataQueryFactory.NewUpdate(ctx).Set("public", data.Data.Public))
+	pin := bm.pinUpload(ctx, data.Data.Public)
+	err = bm.database.UpdateData(ctx, bm.namespace.Name, data.Data.ID, setDataPin(database.DataQueryFactory.NewUpdate(ctx).Set("public", data.Data.Public), pin))
 	if err != nil {
 		return nil, core.OpPhaseInitializing, err
 	}
 
 	log.L(ctx).Infof("Published value for data '%s' to shared storage: '%s'", data.Data.ID, data.Data.Public)
-	return getUploadBlobOutputs(data.Data.Public), core.OpPhaseComplete, nil
+	return getUploadBlobOutputs(data.Data.Public, pin), core.OpPhaseComplete, nil
+}
+
+// pinUpload pins uploaded data with the remote pinning service, if the shared storage plugin has one configured.
+// The plugin retries the request, and a pin that still fails is recorded with a failed status rather than
+// failing the upload - the data is already available from shared storage.
+func (bm *broadcastManager) pinUpload(ctx context.Context, payloadRef string) *core.DataPin {
+	if !bm.sharedstorage.Capabilities().Pinning {
+		return nil
+	}
+	pin, err := bm.sharedstorage.PinData(ctx, payloadRef)
+	if err != nil {
+		log.L(ctx).Errorf("Failed to pin '%s' with the pinning service: %s", payloadRef, err)
+		return &core.DataPin{Status: core.DataPinStatusFailed}
+	}
+	return pin
+}
+
+func setDataPin(update ffapi.Update, pin *core.DataPin) ffapi.Update {
+	if pin != nil {
+		update.Set("pin.status", pin.Status).Set("pin.request", pin.Request)
+	}
+	return update
 }
 
 func (bm *broadcastManager) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
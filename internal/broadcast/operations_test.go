@@ -18,6 +18,7 @@ package broadcast
 import (
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"strings"
 	"testing"
@@ -29,6 +30,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
 	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/sharedstorage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -56,6 +58,7 @@ func TestPrepareAndRunBatchBroadcast(t *testing.T) {
 	mdm.On("HydrateBatch", context.Background(), bp).Return(batch, nil)
 	mdi.On("GetBatchByID", context.Background(), "ns1", bp.ID).Return(bp, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
 
 	po, err := bm.PrepareOperation(context.Background(), op)
 	assert.NoError(t, err)
@@ -206,6 +209,52 @@ func TestRunOperationBatchBroadcastPublishFail(t *testing.T) {
 	mps.AssertExpectations(t)
 }
 
+func TestRunOperationBatchBroadcastCompressed(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+
+	op := &core.Operation{}
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		Compression: core.BatchCompressionGzip,
+	}
+
+	mps := bm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mps.On("UploadData", context.Background(), mock.MatchedBy(func(reader io.Reader) bool {
+		b, _ := io.ReadAll(reader)
+		payload, compression, err := core.DecompressBatchPayload(context.Background(), b, 0)
+		return err == nil && compression == core.BatchCompressionGzip && strings.Contains(string(payload), batch.ID.String())
+	})).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
+
+	_, phase, err := bm.RunOperation(context.Background(), opUploadBatch(op, batch))
+
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.NoError(t, err)
+
+	mps.AssertExpectations(t)
+}
+
+func TestRunOperationBatchBroadcastCompressFail(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+
+	op := &core.Operation{}
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		Compression: "lz4",
+	}
+
+	_, phase, err := bm.RunOperation(context.Background(), opUploadBatch(op, batch))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.Regexp(t, "FF10508", err)
+}
+
 func TestRunOperationBatchBroadcast(t *testing.T) {
 	bm, cancel := newTestBroadcast(t)
 	defer cancel()
@@ -220,6 +269,7 @@ func TestRunOperationBatchBroadcast(t *testing.T) {
 	mps := bm.sharedstorage.(*sharedstoragemocks.Plugin)
 	mdi := bm.database.(*databasemocks.Plugin)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
 
 	outputs, phase, err := bm.RunOperation(context.Background(), opUploadBatch(op, batch))
 	assert.Equal(t, "123", outputs["payloadRef"])
@@ -258,6 +308,7 @@ func TestPrepareAndRunUploadBlob(t *testing.T) {
 	mdi.On("GetDataByID", mock.Anything, "ns1", data.ID, false).Return(data, nil)
 	mdi.On("GetBlobs", mock.Anything, bm.namespace.Name, mock.Anything).Return([]*core.Blob{blob}, nil, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
 	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, nil)
 	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.MatchedBy(func(update ffapi.Update) bool {
 		info, _ := update.Finalize()
@@ -303,6 +354,7 @@ func TestPrepareAndRunValue(t *testing.T) {
 
 	mdi.On("GetDataByID", mock.Anything, "ns1", data.ID, false).Return(data, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
 	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.MatchedBy(func(update ffapi.Update) bool {
 		info, _ := update.Finalize()
 		assert.Equal(t, 1, len(info.SetOperations))
@@ -525,6 +577,7 @@ func TestRunOperationUploadBlobUpdateFail(t *testing.T) {
 	reader := ioutil.NopCloser(strings.NewReader("some data"))
 	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, nil)
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
 	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.Anything).Return(fmt.Errorf("pop"))
 
 	_, phase, err := bm.RunOperation(context.Background(), opUploadBlob(op, data, blob))
@@ -551,6 +604,7 @@ func TestRunOperationUploadValueUpdateFail(t *testing.T) {
 	mdi := bm.database.(*databasemocks.Plugin)
 
 	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
 	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.Anything).Return(fmt.Errorf("pop"))
 
 	_, phase, err := bm.RunOperation(context.Background(), opUploadValue(op, data))
@@ -649,3 +703,103 @@ func TestOperationUpdate(t *testing.T) {
 	defer cancel()
 	assert.NoError(t, bm.OnOperationUpdate(context.Background(), nil, nil))
 }
+
+func TestRunBatchBroadcastPinned(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	pin := &core.DataPin{Status: core.DataPinStatusQueued, Request: "pin1"}
+	mps := bm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("PinData", context.Background(), "123").Return(pin, nil)
+
+	outputs, phase, err := bm.RunOperation(context.Background(), opUploadBatch(&core.Operation{}, batch))
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.Equal(t, "123", outputs["payloadRef"])
+	assert.Equal(t, pin, outputs["pin"])
+
+	mps.AssertExpectations(t)
+}
+
+func TestRunUploadValuePinned(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+
+	data := &core.Data{
+		ID:    fftypes.NewUUID(),
+		Value: fftypes.JSONAnyPtr(`{"some":"data"}`),
+	}
+
+	mps := bm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mdi := bm.database.(*databasemocks.Plugin)
+	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("PinData", context.Background(), "123").Return(&core.DataPin{Status: core.DataPinStatusPinning, Request: "pin1"}, nil)
+	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		assert.Equal(t, 3, len(info.SetOperations))
+		assert.Equal(t, "pin.status", info.SetOperations[1].Field)
+		val, _ := info.SetOperations[1].Value.Value()
+		assert.Equal(t, "pinning", val)
+		assert.Equal(t, "pin.request", info.SetOperations[2].Field)
+		val, _ = info.SetOperations[2].Value.Value()
+		assert.Equal(t, "pin1", val)
+		return true
+	})).Return(nil)
+
+	_, phase, err := bm.RunOperation(context.Background(), opUploadValue(&core.Operation{}, data))
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+
+	mps.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestRunUploadBlobPinFail(t *testing.T) {
+	bm, cancel := newTestBroadcast(t)
+	defer cancel()
+
+	blob := &core.Blob{
+		Hash: fftypes.NewRandB32(),
+	}
+	data := &core.Data{
+		Namespace: "ns1",
+		ID:        fftypes.NewUUID(),
+		Blob: &core.BlobRef{
+			Hash: blob.Hash,
+		},
+	}
+
+	mps := bm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mdx := bm.exchange.(*dataexchangemocks.Plugin)
+	mdi := bm.database.(*databasemocks.Plugin)
+	reader := ioutil.NopCloser(strings.NewReader("some data"))
+	mdx.On("DownloadBlob", context.Background(), mock.Anything).Return(reader, nil)
+	mps.On("UploadData", context.Background(), mock.Anything).Return("123", nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("PinData", context.Background(), "123").Return(nil, fmt.Errorf("pop"))
+	mdi.On("UpdateData", context.Background(), "ns1", data.ID, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		assert.Equal(t, 3, len(info.SetOperations))
+		val, _ := info.SetOperations[1].Value.Value()
+		assert.Equal(t, "failed", val)
+		return true
+	})).Return(nil)
+
+	outputs, phase, err := bm.RunOperation(context.Background(), opUploadBlob(&core.Operation{}, data, blob))
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.Equal(t, core.DataPinStatusFailed, outputs["pin"].(*core.DataPin).Status)
+
+	mps.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type chaosBlockchain struct {
+	blockchain.Plugin
+	ci *injector
+}
+
+// WrapBlockchain returns the plugin wrapped with any configured chaos injection, or the plugin itself
+func WrapBlockchain(ctx context.Context, name string, p blockchain.Plugin) blockchain.Plugin {
+	if ci := newInjector(ctx, pluginTypeBlockchain, name); ci != nil {
+		return &chaosBlockchain{Plugin: p, ci: ci}
+	}
+	return p
+}
+
+func (c *chaosBlockchain) CheckReady(ctx context.Context) error {
+	if err := c.ci.checkReady(ctx); err != nil {
+		return err
+	}
+	return c.Plugin.CheckReady(ctx)
+}
+
+func (c *chaosBlockchain) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *blockchain.BatchPin, location *fftypes.JSONAny) error {
+	if err := c.ci.inject(ctx, "SubmitBatchPin"); err != nil {
+		return err
+	}
+	return c.Plugin.SubmitBatchPin(ctx, nsOpID, networkNamespace, signingKey, batch, location)
+}
+
+func (c *chaosBlockchain) SubmitNetworkAction(ctx context.Context, nsOpID, signingKey string, action core.NetworkActionType, location *fftypes.JSONAny) error {
+	if err := c.ci.inject(ctx, "SubmitNetworkAction"); err != nil {
+		return err
+	}
+	return c.Plugin.SubmitNetworkAction(ctx, nsOpID, signingKey, action, location)
+}
+
+func (c *chaosBlockchain) DeployContract(ctx context.Context, nsOpID, signingKey string, definition, contract *fftypes.JSONAny, input []interface{}, options map[string]interface{}) (bool, error) {
+	if err := c.ci.inject(ctx, "DeployContract"); err != nil {
+		return false, err
+	}
+	return c.Plugin.DeployContract(ctx, nsOpID, signingKey, definition, contract, input, options)
+}
+
+func (c *chaosBlockchain) InvokeContract(ctx context.Context, nsOpID, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}, batch *blockchain.BatchPin) (bool, error) {
+	if err := c.ci.inject(ctx, "InvokeContract"); err != nil {
+		return false, err
+	}
+	return c.Plugin.InvokeContract(ctx, nsOpID, signingKey, location, parsedMethod, input, options, batch)
+}
+
+func (c *chaosBlockchain) InvokeContractBatch(ctx context.Context, nsOpID, signingKey string, aggregator *fftypes.JSONAny, calls []*blockchain.ContractBatchCall, allowFailure bool, options map[string]interface{}) (bool, error) {
+	if err := c.ci.inject(ctx, "InvokeContractBatch"); err != nil {
+		return false, err
+	}
+	return c.Plugin.InvokeContractBatch(ctx, nsOpID, signingKey, aggregator, calls, allowFailure, options)
+}
+
+func (c *chaosBlockchain) QueryContract(ctx context.Context, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}) (interface{}, error) {
+	if err := c.ci.inject(ctx, "QueryContract"); err != nil {
+		return nil, err
+	}
+	return c.Plugin.QueryContract(ctx, signingKey, location, parsedMethod, input, options)
+}
+
+func (c *chaosBlockchain) AddContractListener(ctx context.Context, subscription *core.ContractListener, lastProtocolID string) error {
+	if err := c.ci.inject(ctx, "AddContractListener"); err != nil {
+		return err
+	}
+	return c.Plugin.AddContractListener(ctx, subscription, lastProtocolID)
+}
+
+func (c *chaosBlockchain) DeleteContractListener(ctx context.Context, subscription *core.ContractListener, okNotFound bool) error {
+	if err := c.ci.inject(ctx, "DeleteContractListener"); err != nil {
+		return err
+	}
+	return c.Plugin.DeleteContractListener(ctx, subscription, okNotFound)
+}
+
+func (c *chaosBlockchain) GetTransactionStatus(ctx context.Context, operation *core.Operation) (interface{}, error) {
+	if err := c.ci.inject(ctx, "GetTransactionStatus"); err != nil {
+		return nil, err
+	}
+	return c.Plugin.GetTransactionStatus(ctx, operation)
+}
@@ -0,0 +1,103 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWrapBlockchain(t *testing.T) {
+	coreconfig.Reset()
+	mbi := &blockchainmocks.Plugin{}
+	assert.Equal(t, mbi, WrapBlockchain(context.Background(), "chain1", mbi))
+
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosBlockchainLatency, "1ms")
+	assert.IsType(t, &chaosBlockchain{}, WrapBlockchain(context.Background(), "chain1", mbi))
+}
+
+func TestChaosBlockchain(t *testing.T) {
+	mbi := &blockchainmocks.Plugin{}
+	c := &chaosBlockchain{Plugin: mbi, ci: newTestInjector(pluginTypeBlockchain)}
+	ctx := context.Background()
+	batch := &blockchain.BatchPin{}
+	listener := &core.ContractListener{}
+	op := &core.Operation{}
+
+	mbi.On("CheckReady", ctx).Return(nil)
+	mbi.On("SubmitBatchPin", ctx, "ns1:op1", "ns1", "key1", batch, mock.Anything).Return(nil)
+	mbi.On("SubmitNetworkAction", ctx, "ns1:op1", "key1", core.NetworkActionTerminate, mock.Anything).Return(nil)
+	mbi.On("DeployContract", ctx, "ns1:op1", "key1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	mbi.On("InvokeContract", ctx, "ns1:op1", "key1", mock.Anything, mock.Anything, mock.Anything, mock.Anything, batch).Return(false, nil)
+	mbi.On("InvokeContractBatch", ctx, "ns1:op1", "key1", mock.Anything, mock.Anything, false, mock.Anything).Return(false, nil)
+	mbi.On("QueryContract", ctx, "key1", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("result", nil)
+	mbi.On("AddContractListener", ctx, listener, "").Return(nil)
+	mbi.On("DeleteContractListener", ctx, listener, true).Return(nil)
+	mbi.On("GetTransactionStatus", ctx, op).Return("status", nil)
+
+	assert.NoError(t, c.CheckReady(ctx))
+	assert.NoError(t, c.SubmitBatchPin(ctx, "ns1:op1", "ns1", "key1", batch, nil))
+	assert.NoError(t, c.SubmitNetworkAction(ctx, "ns1:op1", "key1", core.NetworkActionTerminate, nil))
+	_, err := c.DeployContract(ctx, "ns1:op1", "key1", nil, nil, nil, nil)
+	assert.NoError(t, err)
+	_, err = c.InvokeContract(ctx, "ns1:op1", "key1", nil, nil, nil, nil, batch)
+	assert.NoError(t, err)
+	_, err = c.InvokeContractBatch(ctx, "ns1:op1", "key1", nil, nil, false, nil)
+	assert.NoError(t, err)
+	res, err := c.QueryContract(ctx, "key1", nil, nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "result", res)
+	assert.NoError(t, c.AddContractListener(ctx, listener, ""))
+	assert.NoError(t, c.DeleteContractListener(ctx, listener, true))
+	status, err := c.GetTransactionStatus(ctx, op)
+	assert.NoError(t, err)
+	assert.Equal(t, "status", status)
+
+	c.ci.errorRate = 1
+	assert.Regexp(t, "FF10516.*SubmitBatchPin", c.SubmitBatchPin(ctx, "ns1:op1", "ns1", "key1", batch, nil))
+	assert.Regexp(t, "FF10516.*SubmitNetworkAction", c.SubmitNetworkAction(ctx, "ns1:op1", "key1", core.NetworkActionTerminate, nil))
+	rejected, err := c.DeployContract(ctx, "ns1:op1", "key1", nil, nil, nil, nil)
+	assert.Regexp(t, "FF10516.*DeployContract", err)
+	assert.False(t, rejected)
+	rejected, err = c.InvokeContract(ctx, "ns1:op1", "key1", nil, nil, nil, nil, batch)
+	assert.Regexp(t, "FF10516.*InvokeContract", err)
+	assert.False(t, rejected)
+	rejected, err = c.InvokeContractBatch(ctx, "ns1:op1", "key1", nil, nil, false, nil)
+	assert.Regexp(t, "FF10516.*InvokeContractBatch", err)
+	assert.False(t, rejected)
+	_, err = c.QueryContract(ctx, "key1", nil, nil, nil, nil)
+	assert.Regexp(t, "FF10516.*QueryContract", err)
+	assert.Regexp(t, "FF10516.*AddContractListener", c.AddContractListener(ctx, listener, ""))
+	assert.Regexp(t, "FF10516.*DeleteContractListener", c.DeleteContractListener(ctx, listener, true))
+	_, err = c.GetTransactionStatus(ctx, op)
+	assert.Regexp(t, "FF10516.*GetTransactionStatus", err)
+
+	c.ci.disconnectInterval = 1
+	c.ci.disconnectDuration = 1
+	assert.Regexp(t, "FF10517", c.CheckReady(ctx))
+
+	mbi.AssertExpectations(t)
+}
@@ -0,0 +1,143 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos wraps plugins with configurable latency, error and disconnect injection,
+// so the resilience of the orchestrator can be tested without real infrastructure failures.
+// It must never be enabled in production.
+package chaos
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+const (
+	pluginTypeBlockchain   = "blockchain"
+	pluginTypeDatabase     = "database"
+	pluginTypeDataExchange = "dataexchange"
+	pluginTypeTokens       = "tokens"
+)
+
+type injectorKeys struct {
+	latency            config.RootKey
+	errorRate          config.RootKey
+	disconnectInterval config.RootKey
+	disconnectDuration config.RootKey
+}
+
+var configKeys = map[string]injectorKeys{
+	pluginTypeBlockchain: {
+		latency:            coreconfig.ChaosBlockchainLatency,
+		errorRate:          coreconfig.ChaosBlockchainErrorRate,
+		disconnectInterval: coreconfig.ChaosBlockchainDisconnectInterval,
+		disconnectDuration: coreconfig.ChaosBlockchainDisconnectDuration,
+	},
+	pluginTypeDatabase: {
+		latency:            coreconfig.ChaosDatabaseLatency,
+		errorRate:          coreconfig.ChaosDatabaseErrorRate,
+		disconnectInterval: coreconfig.ChaosDatabaseDisconnectInterval,
+		disconnectDuration: coreconfig.ChaosDatabaseDisconnectDuration,
+	},
+	pluginTypeDataExchange: {
+		latency:            coreconfig.ChaosDataExchangeLatency,
+		errorRate:          coreconfig.ChaosDataExchangeErrorRate,
+		disconnectInterval: coreconfig.ChaosDataExchangeDisconnectInterval,
+		disconnectDuration: coreconfig.ChaosDataExchangeDisconnectDuration,
+	},
+	pluginTypeTokens: {
+		latency:            coreconfig.ChaosTokensLatency,
+		errorRate:          coreconfig.ChaosTokensErrorRate,
+		disconnectInterval: coreconfig.ChaosTokensDisconnectInterval,
+		disconnectDuration: coreconfig.ChaosTokensDisconnectDuration,
+	},
+}
+
+type injector struct {
+	pluginType         string
+	name               string
+	latency            time.Duration
+	errorRate          float64
+	disconnectInterval time.Duration
+	disconnectDuration time.Duration
+	startTime          time.Time
+	random             func() float64
+	now                func() time.Time
+}
+
+// newInjector returns nil if chaos is disabled, or nothing is configured for the plugin type
+func newInjector(ctx context.Context, pluginType, name string) *injector {
+	if !config.GetBool(coreconfig.ChaosEnabled) {
+		return nil
+	}
+	keys := configKeys[pluginType]
+	ci := &injector{
+		pluginType:         pluginType,
+		name:               name,
+		latency:            config.GetDuration(keys.latency),
+		errorRate:          config.GetFloat64(keys.errorRate),
+		disconnectInterval: config.GetDuration(keys.disconnectInterval),
+		disconnectDuration: config.GetDuration(keys.disconnectDuration),
+		startTime:          time.Now(),
+		random:             rand.Float64, //nolint:gosec
+		now:                time.Now,
+	}
+	if ci.latency <= 0 && ci.errorRate <= 0 && (ci.disconnectInterval <= 0 || ci.disconnectDuration <= 0) {
+		return nil
+	}
+	log.L(ctx).Warnf("Chaos injection enabled for %s plugin '%s' (latency=%s errorRate=%f disconnectInterval=%s disconnectDuration=%s)",
+		pluginType, name, ci.latency, ci.errorRate, ci.disconnectInterval, ci.disconnectDuration)
+	return ci
+}
+
+// disconnected is true for the last disconnectDuration of every disconnectInterval since the plugin was wrapped
+func (ci *injector) disconnected() bool {
+	if ci.disconnectInterval <= 0 || ci.disconnectDuration <= 0 {
+		return false
+	}
+	elapsed := ci.now().Sub(ci.startTime) % ci.disconnectInterval
+	return elapsed >= ci.disconnectInterval-ci.disconnectDuration
+}
+
+func (ci *injector) checkReady(ctx context.Context) error {
+	if ci.disconnected() {
+		return i18n.NewError(ctx, coremsgs.MsgChaosDisconnected, ci.pluginType, ci.name)
+	}
+	return nil
+}
+
+func (ci *injector) inject(ctx context.Context, operation string) error {
+	if err := ci.checkReady(ctx); err != nil {
+		return err
+	}
+	if ci.latency > 0 {
+		select {
+		case <-time.After(ci.latency):
+		case <-ctx.Done():
+			return i18n.NewError(ctx, i18n.MsgContextCanceled)
+		}
+	}
+	if ci.errorRate > 0 && ci.random() < ci.errorRate {
+		return i18n.NewError(ctx, coremsgs.MsgChaosInjectedError, operation, ci.pluginType, ci.name)
+	}
+	return nil
+}
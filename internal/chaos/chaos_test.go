@@ -0,0 +1,113 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestInjector(pluginType string) *injector {
+	return &injector{
+		pluginType: pluginType,
+		name:       "test1",
+		startTime:  time.Now(),
+		random:     func() float64 { return 0.5 },
+		now:        time.Now,
+	}
+}
+
+func TestNewInjectorDisabled(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.ChaosDatabaseErrorRate, 1.0)
+	assert.Nil(t, newInjector(context.Background(), pluginTypeDatabase, "db1"))
+}
+
+func TestNewInjectorNothingConfigured(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosDatabaseErrorRate, 1.0)
+	config.Set(coreconfig.ChaosTokensDisconnectInterval, "1s")
+	assert.Nil(t, newInjector(context.Background(), pluginTypeTokens, "tokens1"))
+}
+
+func TestNewInjectorEnabled(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosBlockchainLatency, "10ms")
+	config.Set(coreconfig.ChaosBlockchainErrorRate, 0.25)
+	config.Set(coreconfig.ChaosBlockchainDisconnectInterval, "1m")
+	config.Set(coreconfig.ChaosBlockchainDisconnectDuration, "5s")
+	ci := newInjector(context.Background(), pluginTypeBlockchain, "chain1")
+	assert.NotNil(t, ci)
+	assert.Equal(t, "chain1", ci.name)
+	assert.Equal(t, 10*time.Millisecond, ci.latency)
+	assert.Equal(t, 0.25, ci.errorRate)
+	assert.Equal(t, time.Minute, ci.disconnectInterval)
+	assert.Equal(t, 5*time.Second, ci.disconnectDuration)
+}
+
+func TestDisconnected(t *testing.T) {
+	ci := newTestInjector(pluginTypeDataExchange)
+	assert.False(t, ci.disconnected())
+
+	ci.disconnectInterval = time.Minute
+	ci.disconnectDuration = 10 * time.Second
+	ci.now = func() time.Time { return ci.startTime.Add(49 * time.Second) }
+	assert.False(t, ci.disconnected())
+	assert.NoError(t, ci.checkReady(context.Background()))
+
+	ci.now = func() time.Time { return ci.startTime.Add(50 * time.Second) }
+	assert.True(t, ci.disconnected())
+	assert.Regexp(t, "FF10517.*dataexchange.*test1", ci.checkReady(context.Background()))
+
+	ci.now = func() time.Time { return ci.startTime.Add(61 * time.Second) }
+	assert.False(t, ci.disconnected())
+}
+
+func TestInjectOK(t *testing.T) {
+	ci := newTestInjector(pluginTypeDatabase)
+	ci.latency = 1 * time.Millisecond
+	ci.errorRate = 0.4
+	assert.NoError(t, ci.inject(context.Background(), "op1"))
+}
+
+func TestInjectError(t *testing.T) {
+	ci := newTestInjector(pluginTypeDatabase)
+	ci.errorRate = 0.6
+	assert.Regexp(t, "FF10516.*op1.*database.*test1", ci.inject(context.Background(), "op1"))
+}
+
+func TestInjectDisconnected(t *testing.T) {
+	ci := newTestInjector(pluginTypeDatabase)
+	ci.disconnectInterval = time.Minute
+	ci.disconnectDuration = time.Minute
+	assert.Regexp(t, "FF10517", ci.inject(context.Background(), "op1"))
+}
+
+func TestInjectLatencyContextCancelled(t *testing.T) {
+	ci := newTestInjector(pluginTypeDatabase)
+	ci.latency = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Regexp(t, "FF00154", ci.inject(ctx, "op1"))
+}
@@ -0,0 +1,51 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// chaosDatabase injects failures at the group (transaction) boundary, through which all writes pass
+type chaosDatabase struct {
+	database.Plugin
+	ci *injector
+}
+
+// WrapDatabase returns the plugin wrapped with any configured chaos injection, or the plugin itself
+func WrapDatabase(ctx context.Context, name string, p database.Plugin) database.Plugin {
+	if ci := newInjector(ctx, pluginTypeDatabase, name); ci != nil {
+		return &chaosDatabase{Plugin: p, ci: ci}
+	}
+	return p
+}
+
+func (c *chaosDatabase) CheckReady(ctx context.Context) error {
+	if err := c.ci.checkReady(ctx); err != nil {
+		return err
+	}
+	return c.Plugin.CheckReady(ctx)
+}
+
+func (c *chaosDatabase) RunAsGroup(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := c.ci.inject(ctx, "RunAsGroup"); err != nil {
+		return err
+	}
+	return c.Plugin.RunAsGroup(ctx, fn)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWrapDatabase(t *testing.T) {
+	coreconfig.Reset()
+	mdi := &databasemocks.Plugin{}
+	assert.Equal(t, mdi, WrapDatabase(context.Background(), "db1", mdi))
+
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosDatabaseLatency, "1ms")
+	assert.IsType(t, &chaosDatabase{}, WrapDatabase(context.Background(), "db1", mdi))
+}
+
+func TestChaosDatabase(t *testing.T) {
+	mdi := &databasemocks.Plugin{}
+	c := &chaosDatabase{Plugin: mdi, ci: newTestInjector(pluginTypeDatabase)}
+	ctx := context.Background()
+
+	mdi.On("CheckReady", ctx).Return(nil)
+	mdi.On("RunAsGroup", ctx, mock.Anything).Return(nil)
+	assert.NoError(t, c.CheckReady(ctx))
+	assert.NoError(t, c.RunAsGroup(ctx, func(ctx context.Context) error { return nil }))
+
+	c.ci.errorRate = 1
+	c.ci.disconnectInterval = 1
+	c.ci.disconnectDuration = 1
+	assert.Regexp(t, "FF10517", c.CheckReady(ctx))
+	assert.Regexp(t, "FF10517", c.RunAsGroup(ctx, func(ctx context.Context) error { return nil }))
+
+	mdi.AssertExpectations(t)
+}
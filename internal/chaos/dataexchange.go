@@ -0,0 +1,80 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"io"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+)
+
+type chaosDataExchange struct {
+	dataexchange.Plugin
+	ci *injector
+}
+
+// WrapDataExchange returns the plugin wrapped with any configured chaos injection, or the plugin itself
+func WrapDataExchange(ctx context.Context, name string, p dataexchange.Plugin) dataexchange.Plugin {
+	if ci := newInjector(ctx, pluginTypeDataExchange, name); ci != nil {
+		return &chaosDataExchange{Plugin: p, ci: ci}
+	}
+	return p
+}
+
+func (c *chaosDataExchange) CheckReady(ctx context.Context) error {
+	if err := c.ci.checkReady(ctx); err != nil {
+		return err
+	}
+	return c.Plugin.CheckReady(ctx)
+}
+
+func (c *chaosDataExchange) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (string, *fftypes.Bytes32, int64, error) {
+	if err := c.ci.inject(ctx, "UploadBlob"); err != nil {
+		return "", nil, 0, err
+	}
+	return c.Plugin.UploadBlob(ctx, ns, id, content)
+}
+
+func (c *chaosDataExchange) DownloadBlob(ctx context.Context, payloadRef string) (io.ReadCloser, error) {
+	if err := c.ci.inject(ctx, "DownloadBlob"); err != nil {
+		return nil, err
+	}
+	return c.Plugin.DownloadBlob(ctx, payloadRef)
+}
+
+func (c *chaosDataExchange) DeleteBlob(ctx context.Context, payloadRef string) error {
+	if err := c.ci.inject(ctx, "DeleteBlob"); err != nil {
+		return err
+	}
+	return c.Plugin.DeleteBlob(ctx, payloadRef)
+}
+
+func (c *chaosDataExchange) SendMessage(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, data []byte) error {
+	if err := c.ci.inject(ctx, "SendMessage"); err != nil {
+		return err
+	}
+	return c.Plugin.SendMessage(ctx, nsOpID, peer, sender, data)
+}
+
+func (c *chaosDataExchange) TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string, offset int64) error {
+	if err := c.ci.inject(ctx, "TransferBlob"); err != nil {
+		return err
+	}
+	return c.Plugin.TransferBlob(ctx, nsOpID, peer, sender, payloadRef, offset)
+}
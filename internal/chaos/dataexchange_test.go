@@ -0,0 +1,78 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapDataExchange(t *testing.T) {
+	coreconfig.Reset()
+	mdx := &dataexchangemocks.Plugin{}
+	assert.Equal(t, mdx, WrapDataExchange(context.Background(), "dx1", mdx))
+
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosDataExchangeErrorRate, 0.1)
+	assert.IsType(t, &chaosDataExchange{}, WrapDataExchange(context.Background(), "dx1", mdx))
+}
+
+func TestChaosDataExchange(t *testing.T) {
+	mdx := &dataexchangemocks.Plugin{}
+	c := &chaosDataExchange{Plugin: mdx, ci: newTestInjector(pluginTypeDataExchange)}
+	ctx := context.Background()
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	id := fftypes.NewUUID()
+
+	mdx.On("CheckReady", ctx).Return(nil)
+	mdx.On("UploadBlob", ctx, "ns1", *id, nil).Return("ns1/blob1", fftypes.NewRandB32(), int64(10), nil)
+	mdx.On("DownloadBlob", ctx, "ns1/blob1").Return(nil, nil)
+	mdx.On("DeleteBlob", ctx, "ns1/blob1").Return(nil)
+	mdx.On("SendMessage", ctx, "ns1:op1", peer, sender, []byte("hello")).Return(nil)
+	mdx.On("TransferBlob", ctx, "ns1:op1", peer, sender, "ns1/blob1", int64(0)).Return(nil)
+
+	assert.NoError(t, c.CheckReady(ctx))
+	_, _, _, err := c.UploadBlob(ctx, "ns1", *id, nil)
+	assert.NoError(t, err)
+	_, err = c.DownloadBlob(ctx, "ns1/blob1")
+	assert.NoError(t, err)
+	assert.NoError(t, c.DeleteBlob(ctx, "ns1/blob1"))
+	assert.NoError(t, c.SendMessage(ctx, "ns1:op1", peer, sender, []byte("hello")))
+	assert.NoError(t, c.TransferBlob(ctx, "ns1:op1", peer, sender, "ns1/blob1", 0))
+
+	c.ci.errorRate = 1
+	_, _, _, err = c.UploadBlob(ctx, "ns1", *id, nil)
+	assert.Regexp(t, "FF10516.*UploadBlob", err)
+	_, err = c.DownloadBlob(ctx, "ns1/blob1")
+	assert.Regexp(t, "FF10516.*DownloadBlob", err)
+	assert.Regexp(t, "FF10516.*DeleteBlob", c.DeleteBlob(ctx, "ns1/blob1"))
+	assert.Regexp(t, "FF10516.*SendMessage", c.SendMessage(ctx, "ns1:op1", peer, sender, []byte("hello")))
+	assert.Regexp(t, "FF10516.*TransferBlob", c.TransferBlob(ctx, "ns1:op1", peer, sender, "ns1/blob1", 0))
+
+	c.ci.disconnectInterval = 1
+	c.ci.disconnectDuration = 1
+	assert.Regexp(t, "FF10517", c.CheckReady(ctx))
+
+	mdx.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/tokens"
+)
+
+type chaosTokens struct {
+	tokens.Plugin
+	ci *injector
+}
+
+// WrapTokens returns the plugin wrapped with any configured chaos injection, or the plugin itself
+func WrapTokens(ctx context.Context, name string, p tokens.Plugin) tokens.Plugin {
+	if ci := newInjector(ctx, pluginTypeTokens, name); ci != nil {
+		return &chaosTokens{Plugin: p, ci: ci}
+	}
+	return p
+}
+
+func (c *chaosTokens) CheckReady(ctx context.Context) error {
+	if err := c.ci.checkReady(ctx); err != nil {
+		return err
+	}
+	return c.Plugin.CheckReady(ctx)
+}
+
+func (c *chaosTokens) CreateTokenPool(ctx context.Context, nsOpID string, pool *core.TokenPool) (core.OpPhase, error) {
+	if err := c.ci.inject(ctx, "CreateTokenPool"); err != nil {
+		return core.OpPhaseInitializing, err
+	}
+	return c.Plugin.CreateTokenPool(ctx, nsOpID, pool)
+}
+
+func (c *chaosTokens) ActivateTokenPool(ctx context.Context, pool *core.TokenPool) (core.OpPhase, error) {
+	if err := c.ci.inject(ctx, "ActivateTokenPool"); err != nil {
+		return core.OpPhaseInitializing, err
+	}
+	return c.Plugin.ActivateTokenPool(ctx, pool)
+}
+
+func (c *chaosTokens) DeactivateTokenPool(ctx context.Context, pool *core.TokenPool) error {
+	if err := c.ci.inject(ctx, "DeactivateTokenPool"); err != nil {
+		return err
+	}
+	return c.Plugin.DeactivateTokenPool(ctx, pool)
+}
+
+func (c *chaosTokens) MintTokens(ctx context.Context, nsOpID string, poolLocator string, mint *core.TokenTransfer, methods *fftypes.JSONAny) error {
+	if err := c.ci.inject(ctx, "MintTokens"); err != nil {
+		return err
+	}
+	return c.Plugin.MintTokens(ctx, nsOpID, poolLocator, mint, methods)
+}
+
+func (c *chaosTokens) BurnTokens(ctx context.Context, nsOpID string, poolLocator string, burn *core.TokenTransfer, methods *fftypes.JSONAny) error {
+	if err := c.ci.inject(ctx, "BurnTokens"); err != nil {
+		return err
+	}
+	return c.Plugin.BurnTokens(ctx, nsOpID, poolLocator, burn, methods)
+}
+
+func (c *chaosTokens) TransferTokens(ctx context.Context, nsOpID string, poolLocator string, transfer *core.TokenTransfer, methods *fftypes.JSONAny) error {
+	if err := c.ci.inject(ctx, "TransferTokens"); err != nil {
+		return err
+	}
+	return c.Plugin.TransferTokens(ctx, nsOpID, poolLocator, transfer, methods)
+}
+
+func (c *chaosTokens) TokensApproval(ctx context.Context, nsOpID string, poolLocator string, approval *core.TokenApproval, methods *fftypes.JSONAny) error {
+	if err := c.ci.inject(ctx, "TokensApproval"); err != nil {
+		return err
+	}
+	return c.Plugin.TokensApproval(ctx, nsOpID, poolLocator, approval, methods)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/tokenmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWrapTokens(t *testing.T) {
+	coreconfig.Reset()
+	mti := &tokenmocks.Plugin{}
+	assert.Equal(t, mti, WrapTokens(context.Background(), "tokens1", mti))
+
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosTokensDisconnectInterval, "1m")
+	config.Set(coreconfig.ChaosTokensDisconnectDuration, "1s")
+	assert.IsType(t, &chaosTokens{}, WrapTokens(context.Background(), "tokens1", mti))
+}
+
+func TestChaosTokens(t *testing.T) {
+	mti := &tokenmocks.Plugin{}
+	c := &chaosTokens{Plugin: mti, ci: newTestInjector(pluginTypeTokens)}
+	ctx := context.Background()
+	pool := &core.TokenPool{}
+	transfer := &core.TokenTransfer{}
+	approval := &core.TokenApproval{}
+
+	mti.On("CheckReady", ctx).Return(nil)
+	mti.On("CreateTokenPool", ctx, "ns1:op1", pool).Return(core.OpPhaseComplete, nil)
+	mti.On("ActivateTokenPool", ctx, pool).Return(core.OpPhaseComplete, nil)
+	mti.On("DeactivateTokenPool", ctx, pool).Return(nil)
+	mti.On("MintTokens", ctx, "ns1:op1", "F1", transfer, mock.Anything).Return(nil)
+	mti.On("BurnTokens", ctx, "ns1:op1", "F1", transfer, mock.Anything).Return(nil)
+	mti.On("TransferTokens", ctx, "ns1:op1", "F1", transfer, mock.Anything).Return(nil)
+	mti.On("TokensApproval", ctx, "ns1:op1", "F1", approval, mock.Anything).Return(nil)
+
+	assert.NoError(t, c.CheckReady(ctx))
+	phase, err := c.CreateTokenPool(ctx, "ns1:op1", pool)
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	phase, err = c.ActivateTokenPool(ctx, pool)
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.NoError(t, c.DeactivateTokenPool(ctx, pool))
+	assert.NoError(t, c.MintTokens(ctx, "ns1:op1", "F1", transfer, nil))
+	assert.NoError(t, c.BurnTokens(ctx, "ns1:op1", "F1", transfer, nil))
+	assert.NoError(t, c.TransferTokens(ctx, "ns1:op1", "F1", transfer, nil))
+	assert.NoError(t, c.TokensApproval(ctx, "ns1:op1", "F1", approval, nil))
+
+	c.ci.errorRate = 1
+	phase, err = c.CreateTokenPool(ctx, "ns1:op1", pool)
+	assert.Regexp(t, "FF10516.*CreateTokenPool", err)
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	phase, err = c.ActivateTokenPool(ctx, pool)
+	assert.Regexp(t, "FF10516.*ActivateTokenPool", err)
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.Regexp(t, "FF10516.*DeactivateTokenPool", c.DeactivateTokenPool(ctx, pool))
+	assert.Regexp(t, "FF10516.*MintTokens", c.MintTokens(ctx, "ns1:op1", "F1", transfer, nil))
+	assert.Regexp(t, "FF10516.*BurnTokens", c.BurnTokens(ctx, "ns1:op1", "F1", transfer, nil))
+	assert.Regexp(t, "FF10516.*TransferTokens", c.TransferTokens(ctx, "ns1:op1", "F1", transfer, nil))
+	assert.Regexp(t, "FF10516.*TokensApproval", c.TokensApproval(ctx, "ns1:op1", "F1", approval, nil))
+
+	c.ci.disconnectInterval = 1
+	c.ci.disconnectDuration = 1
+	assert.Regexp(t, "FF10517", c.CheckReady(ctx))
+
+	mti.AssertExpectations(t)
+}
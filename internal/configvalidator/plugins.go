@@ -0,0 +1,178 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/firefly-common/pkg/auth/authfactory"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/database/difactory"
+	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
+	"github.com/hyperledger/firefly/internal/identity/iifactory"
+	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
+	"github.com/hyperledger/firefly/internal/tokens/tifactory"
+)
+
+type pluginCategory struct {
+	name string
+	// initConfig registers the known keys of the category on the array section it is read from
+	initConfig func(config config.ArraySection)
+	// checkType returns an error if the plugin type is not one that is built into this node
+	checkType func(ctx context.Context, pluginType string) error
+	// required lists the settings within the type-specific section that a plugin of each type cannot start without
+	required map[string][]string
+}
+
+var pluginCategories = []*pluginCategory{
+	{
+		name:       "auth",
+		initConfig: authfactory.InitConfigArray,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := authfactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+	},
+	{
+		name:       "blockchain",
+		initConfig: bifactory.InitConfig,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := bifactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+		required: map[string][]string{
+			"ethereum": {"ethconnect.url", "ethconnect.topic"},
+			"fabric":   {"fabconnect.url", "fabconnect.topic"},
+			"tezos":    {"tezosconnect.url", "tezosconnect.topic"},
+		},
+	},
+	{
+		name:       "database",
+		initConfig: difactory.InitConfig,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := difactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+		required: map[string][]string{
+			"postgres": {"url"},
+			"sqlite3":  {"url"},
+		},
+	},
+	{
+		name:       "dataexchange",
+		initConfig: dxfactory.InitConfig,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := dxfactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+		required: map[string][]string{
+			"ffdx":   {"url"},
+			"libp2p": {"keyFile", "blobs.path"},
+		},
+	},
+	{
+		name:       "identity",
+		initConfig: iifactory.InitConfig,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := iifactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+	},
+	{
+		name:       "sharedstorage",
+		initConfig: ssfactory.InitConfig,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := ssfactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+		required: map[string][]string{
+			"ipfs": {"api.url", "gateway.url"},
+			"s3":   {"url", "bucket"},
+		},
+	},
+	{
+		name:       "tokens",
+		initConfig: tifactory.InitConfig,
+		checkType: func(ctx context.Context, pluginType string) error {
+			_, err := tifactory.GetPlugin(ctx, pluginType)
+			return err
+		},
+		required: map[string][]string{
+			"fftokens": {"url"},
+		},
+	},
+}
+
+// checkPlugins performs the checks the namespace manager would make when loading the plugins and
+// namespaces, plus the settings each plugin requires on Init, but without initializing anything
+func checkPlugins(ctx context.Context) []error {
+	var errs []error
+	pluginNames := make(map[string]bool)
+	for _, pc := range pluginCategories {
+		arrayConf := config.RootArray(fmt.Sprintf("plugins.%s", pc.name))
+		pc.initConfig(arrayConf)
+		// Reading entries can set defaults that shadow the array, so the size must be read first
+		size := arrayConf.ArraySize()
+		for i := 0; i < size; i++ {
+			path := fmt.Sprintf("plugins.%s[%d]", pc.name, i)
+			conf := arrayConf.ArrayEntry(i)
+			name := conf.GetString(coreconfig.PluginConfigName)
+			pluginType := conf.GetString(coreconfig.PluginConfigType)
+			if name == "" || pluginType == "" {
+				errs = append(errs, i18n.NewError(ctx, coremsgs.MsgInvalidPluginConfiguration, pc.name))
+				continue
+			}
+			if err := fftypes.ValidateFFNameField(ctx, name, fmt.Sprintf("%s.name", path)); err != nil {
+				errs = append(errs, err)
+			}
+			if pluginNames[name] {
+				errs = append(errs, i18n.NewError(ctx, coremsgs.MsgDuplicatePluginName, name))
+			}
+			pluginNames[name] = true
+			if err := pc.checkType(ctx, pluginType); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			typeConf := conf.SubSection(pluginType)
+			for _, key := range pc.required[pluginType] {
+				if typeConf.GetString(key) == "" {
+					errs = append(errs, i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, fmt.Sprintf("%s.%s.%s", path, pluginType, key), pluginType))
+				}
+			}
+		}
+	}
+
+	nsConf := config.RootArray("namespaces.predefined")
+	nsConf.AddKnownKey(coreconfig.NamespaceName)
+	nsConf.AddKnownKey(coreconfig.NamespacePlugins)
+	size := nsConf.ArraySize()
+	for i := 0; i < size; i++ {
+		conf := nsConf.ArrayEntry(i)
+		for _, pluginName := range conf.GetStringSlice(coreconfig.NamespacePlugins) {
+			if !pluginNames[pluginName] {
+				errs = append(errs, i18n.NewError(ctx, coremsgs.MsgNamespaceUnknownPlugin, conf.GetString(coreconfig.NamespaceName), pluginName))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,107 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPluginsMissingRequired(t *testing.T) {
+	newTestConfig(t, `
+plugins:
+  blockchain:
+  - name: ethereum0
+    type: ethereum
+  - name: fabric0
+    type: fabric
+  - name: tezos0
+    type: tezos
+  database:
+  - name: postgres0
+    type: postgres
+  dataexchange:
+  - name: ffdx0
+    type: ffdx
+  - name: libp2p0
+    type: libp2p
+  identity:
+  - name: tbd0
+    type: onchain
+  sharedstorage:
+  - name: ipfs0
+    type: ipfs
+  - name: s3
+    type: s3
+  tokens:
+  - name: fftokens0
+    type: fftokens
+`)
+	errs := checkPlugins(context.Background())
+	assert.Equal(t, []string{
+		"FF10138: Missing configuration 'plugins.blockchain[0].ethereum.ethconnect.url' for ethereum",
+		"FF10138: Missing configuration 'plugins.blockchain[0].ethereum.ethconnect.topic' for ethereum",
+		"FF10138: Missing configuration 'plugins.blockchain[1].fabric.fabconnect.url' for fabric",
+		"FF10138: Missing configuration 'plugins.blockchain[1].fabric.fabconnect.topic' for fabric",
+		"FF10138: Missing configuration 'plugins.blockchain[2].tezos.tezosconnect.url' for tezos",
+		"FF10138: Missing configuration 'plugins.blockchain[2].tezos.tezosconnect.topic' for tezos",
+		"FF10138: Missing configuration 'plugins.database[0].postgres.url' for postgres",
+		"FF10138: Missing configuration 'plugins.dataexchange[0].ffdx.url' for ffdx",
+		"FF10138: Missing configuration 'plugins.dataexchange[1].libp2p.keyFile' for libp2p",
+		"FF10138: Missing configuration 'plugins.dataexchange[1].libp2p.blobs.path' for libp2p",
+		"FF10138: Missing configuration 'plugins.sharedstorage[0].ipfs.api.url' for ipfs",
+		"FF10138: Missing configuration 'plugins.sharedstorage[0].ipfs.gateway.url' for ipfs",
+		"FF10138: Missing configuration 'plugins.sharedstorage[1].s3.url' for s3",
+		"FF10138: Missing configuration 'plugins.sharedstorage[1].s3.bucket' for s3",
+		"FF10138: Missing configuration 'plugins.tokens[0].fftokens.url' for fftokens",
+	}, errorStrings(errs))
+}
+
+func TestCheckPluginsInvalid(t *testing.T) {
+	newTestConfig(t, `
+plugins:
+  auth:
+  - name: auth0
+    type: unknown
+  database:
+  - name: database0
+    type: sqlite3
+    sqlite3:
+      url: /tmp/firefly.db
+  - name: database0
+    type: oracle
+  - type: postgres
+  - name: "bad name!"
+    type: sqlite3
+    sqlite3:
+      url: /tmp/firefly.db
+namespaces:
+  predefined:
+  - name: ns1
+    plugins: [database0, missing]
+`)
+	errs := checkPlugins(context.Background())
+	assert.Len(t, errs, 6)
+	assert.Regexp(t, "FF00168.*unknown", errs[0])
+	assert.Regexp(t, "FF10395.*database0", errs[1])
+	assert.Regexp(t, "FF10122.*oracle", errs[2])
+	assert.Regexp(t, "FF10386.*database", errs[3])
+	assert.Regexp(t, "FF00140.*plugins.database\\[3\\].name", errs[4])
+	assert.Regexp(t, "FF10390.*ns1.*missing", errs[5])
+}
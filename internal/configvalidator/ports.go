@@ -0,0 +1,84 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/httpserver"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+type listener struct {
+	name    string
+	address string
+	port    int
+}
+
+func isWildcardAddress(address string) bool {
+	return address == "" || address == "0.0.0.0" || address == "::"
+}
+
+func (l *listener) clashes(other *listener) bool {
+	return l.port == other.port &&
+		(l.address == other.address || isWildcardAddress(l.address) || isWildcardAddress(other.address))
+}
+
+func httpListener(name string) *listener {
+	conf := config.RootSection(name)
+	return &listener{
+		name:    name,
+		address: conf.GetString(httpserver.HTTPConfAddress),
+		port:    conf.GetInt(httpserver.HTTPConfPort),
+	}
+}
+
+// checkPorts reports any two enabled servers that would fail to bind because they share a port.
+// Port 0 selects a random free port, so can never clash.
+func checkPorts(ctx context.Context) []error {
+	listeners := []*listener{httpListener("http")}
+	if config.GetBool(coreconfig.SPIEnabled) {
+		listeners = append(listeners, httpListener("spi"))
+	}
+	if config.GetBool(coreconfig.MetricsEnabled) {
+		listeners = append(listeners, httpListener("metrics"))
+	}
+	if config.GetInt(coreconfig.DebugPort) > 0 {
+		listeners = append(listeners, &listener{
+			name:    "debug",
+			address: config.GetString(coreconfig.DebugAddress),
+			port:    config.GetInt(coreconfig.DebugPort),
+		})
+	}
+
+	var errs []error
+	for i, l := range listeners {
+		if l.port <= 0 {
+			continue
+		}
+		for _, other := range listeners[i+1:] {
+			if l.clashes(other) {
+				errs = append(errs, i18n.NewError(ctx, coremsgs.MsgConfigPortClash, l.name, other.name, strconv.Itoa(l.port)))
+			}
+		}
+	}
+	return errs
+}
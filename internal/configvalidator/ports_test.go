@@ -0,0 +1,66 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPortsClash(t *testing.T) {
+	newTestConfig(t, `
+http:
+  port: 5000
+spi:
+  enabled: true
+  address: 0.0.0.0
+  port: 5000
+metrics:
+  enabled: true
+  address: 127.0.0.1
+  port: 6000
+debug:
+  address: 127.0.0.1
+  port: 6000
+`)
+	errs := checkPorts(context.Background())
+	assert.Equal(t, []string{
+		"FF10522: The http and spi servers are both configured to listen on port 5000",
+		"FF10522: The metrics and debug servers are both configured to listen on port 6000",
+	}, errorStrings(errs))
+}
+
+func TestCheckPortsNoClash(t *testing.T) {
+	newTestConfig(t, `
+http:
+  address: 127.0.0.1
+  port: 0
+spi:
+  port: 0
+metrics:
+  enabled: true
+  address: 127.0.0.2
+  port: 6000
+debug:
+  address: 127.0.0.1
+  port: 6000
+`)
+	errs := checkPorts(context.Background())
+	assert.Empty(t, errs)
+}
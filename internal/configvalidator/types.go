@@ -0,0 +1,137 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// fieldType finds the documented type of a known key, falling back to the global descriptions
+// in the same way the config reference generation does
+func fieldType(key string) string {
+	if ft, ok := i18n.GetFieldType("config." + key); ok {
+		return ft
+	}
+	splitKey := strings.Split(key, ".")
+	for i := 0; i < len(splitKey); i++ {
+		if ft, ok := i18n.GetFieldType("config.global." + strings.Join(splitKey[i:], ".")); ok {
+			return ft
+		}
+	}
+	return ""
+}
+
+func checkType(ctx context.Context, path, key string, value interface{}) error {
+	var valid bool
+	ft := fieldType(key)
+	switch ft {
+	case i18n.IntType:
+		valid = isInt(value)
+	case i18n.BooleanType:
+		valid = isBool(value)
+	case i18n.FloatType:
+		valid = isFloat(value)
+	case i18n.TimeDurationType:
+		valid = isDuration(value)
+	case i18n.ByteSizeType:
+		valid = isByteSize(value)
+	default:
+		// Strings, lists and objects are not checked
+		return nil
+	}
+	if !valid {
+		return i18n.NewError(ctx, coremsgs.MsgConfigInvalidValue, value, path, ft)
+	}
+	return nil
+}
+
+func isInteger(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isInt(value interface{}) bool {
+	switch v := value.(type) {
+	case float64:
+		return v == float64(int64(v))
+	case string:
+		_, err := strconv.ParseInt(v, 10, 64)
+		return err == nil
+	default:
+		return isInteger(value)
+	}
+}
+
+func isBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return true
+	case string:
+		_, err := strconv.ParseBool(v)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func isFloat(value interface{}) bool {
+	switch v := value.(type) {
+	case float32, float64:
+		return true
+	case string:
+		_, err := strconv.ParseFloat(v, 64)
+		return err == nil
+	default:
+		return isInteger(value)
+	}
+}
+
+func isDuration(value interface{}) bool {
+	switch v := value.(type) {
+	case time.Duration:
+		return true
+	case string:
+		_, err := fftypes.ParseDurationString(v, time.Millisecond)
+		return err == nil
+	default:
+		return isInteger(value)
+	}
+}
+
+func isByteSize(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		_, err := units.RAMInBytes(v)
+		return err == nil
+	default:
+		return isInteger(value)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFieldTypeGlobalFallback(t *testing.T) {
+	assert.Equal(t, i18n.TimeDurationType, fieldType("plugins.blockchain[].ethereum.ethconnect.retry.initWaitTime"))
+	assert.Equal(t, "", fieldType("not.a.key"))
+}
+
+func TestCheckTypeUnchecked(t *testing.T) {
+	assert.NoError(t, checkType(context.Background(), "not.a.key", "not.a.key", 12345))
+}
+
+func TestIsInt(t *testing.T) {
+	assert.True(t, isInt(1))
+	assert.True(t, isInt(uint64(1)))
+	assert.True(t, isInt(float64(1)))
+	assert.True(t, isInt("1"))
+	assert.False(t, isInt(1.5))
+	assert.False(t, isInt("one"))
+	assert.False(t, isInt(true))
+}
+
+func TestIsBool(t *testing.T) {
+	assert.True(t, isBool(true))
+	assert.True(t, isBool("false"))
+	assert.False(t, isBool("maybe"))
+	assert.False(t, isBool(1))
+}
+
+func TestIsFloat(t *testing.T) {
+	assert.True(t, isFloat(0.5))
+	assert.True(t, isFloat(float32(0.5)))
+	assert.True(t, isFloat(1))
+	assert.True(t, isFloat("0.5"))
+	assert.False(t, isFloat("half"))
+	assert.False(t, isFloat(false))
+}
+
+func TestIsDuration(t *testing.T) {
+	assert.True(t, isDuration(5*time.Second))
+	assert.True(t, isDuration("5s"))
+	assert.True(t, isDuration("500"))
+	assert.True(t, isDuration(500))
+	assert.False(t, isDuration("soon"))
+	assert.False(t, isDuration(0.5))
+}
+
+func TestIsByteSize(t *testing.T) {
+	assert.True(t, isByteSize("10Mb"))
+	assert.True(t, isByteSize(1024))
+	assert.False(t, isByteSize("lots"))
+	assert.False(t, isByteSize(true))
+}
+
+func TestCheckTypeFloatAndByteSize(t *testing.T) {
+	newTestConfig(t, "")
+	err := checkType(context.Background(), "chaos.blockchain.errorRate", "chaos.blockchain.errorRate", "high")
+	assert.Regexp(t, "FF10521", err)
+	err = checkType(context.Background(), "cache.message.size", "cache.message.size", "huge")
+	assert.Regexp(t, "FF10521", err)
+}
@@ -0,0 +1,116 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configvalidator checks a loaded configuration without starting any services, so that
+// every problem can be reported at once rather than one failed startup at a time.
+package configvalidator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// Validate checks the configuration that has already been read into the config package,
+// with all known keys initialized. It returns every problem found, in a stable order.
+func Validate(ctx context.Context) []error {
+	var errs []error
+	errs = append(errs, checkKeys(ctx, config.GetConfig(), config.GetKnownKeys())...)
+	errs = append(errs, checkPlugins(ctx)...)
+	errs = append(errs, checkPorts(ctx)...)
+	return errs
+}
+
+func joinKey(prefix, name string) string {
+	return fmt.Sprintf("%s.%s", prefix, name)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keyIndex maps the lower-cased form of every known key (as viper lower-cases the keys it reads)
+// back to the key as registered, and records every parent prefix of a known key.
+type keyIndex struct {
+	known    map[string]string
+	prefixes map[string]bool
+}
+
+func newKeyIndex(knownKeys []string) *keyIndex {
+	ki := &keyIndex{
+		known:    make(map[string]string),
+		prefixes: make(map[string]bool),
+	}
+	for _, k := range knownKeys {
+		lk := strings.ToLower(k)
+		ki.known[lk] = k
+		parts := strings.Split(lk, ".")
+		for i := 1; i < len(parts); i++ {
+			ki.prefixes[strings.Join(parts[:i], ".")] = true
+		}
+	}
+	return ki
+}
+
+func checkKeys(ctx context.Context, conf fftypes.JSONObject, knownKeys []string) []error {
+	ki := newKeyIndex(knownKeys)
+	var errs []error
+	for _, k := range sortedKeys(conf) {
+		errs = append(errs, ki.checkValue(ctx, k, strings.ToLower(k), conf[k])...)
+	}
+	return errs
+}
+
+// checkValue walks a config value, where path is the location for reporting (with array indexes)
+// and key is the lower-cased form used to match against the known keys (with [] for arrays).
+// Objects and arrays are only walked into if there are known keys beneath them.
+func (ki *keyIndex) checkValue(ctx context.Context, path, key string, value interface{}) []error {
+	var errs []error
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ki.prefixes[key] {
+			for _, k := range sortedKeys(v) {
+				errs = append(errs, ki.checkValue(ctx, joinKey(path, k), joinKey(key, strings.ToLower(k)), v[k])...)
+			}
+			return errs
+		}
+	case []interface{}:
+		if ki.prefixes[key+"[]"] {
+			for i, entry := range v {
+				errs = append(errs, ki.checkValue(ctx, fmt.Sprintf("%s[%d]", path, i), key+"[]", entry)...)
+			}
+			return errs
+		}
+	}
+	if knownKey, ok := ki.known[key]; ok {
+		if err := checkType(ctx, path, knownKey, value); err != nil {
+			errs = append(errs, err)
+		}
+		return errs
+	}
+	return []error{i18n.NewError(ctx, coremsgs.MsgConfigUnknownKey, path)}
+}
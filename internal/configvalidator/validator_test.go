@@ -0,0 +1,130 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly/internal/apiserver"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/namespace"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+const validConfig = `
+http:
+  port: 5000
+spi:
+  enabled: true
+  port: 5001
+plugins:
+  database:
+  - name: database0
+    type: sqlite3
+    sqlite3:
+      url: /tmp/firefly.db
+  blockchain:
+  - name: blockchain0
+    type: ethereum
+    ethereum:
+      ethconnect:
+        url: http://localhost:5102
+        topic: topic1
+        headers:
+          x-custom: value
+  auth:
+  - name: basicauth
+    type: basic
+namespaces:
+  default: default
+  predefined:
+  - name: default
+    plugins: [database0, blockchain0, basicauth]
+`
+
+func newTestConfig(t *testing.T, conf string) {
+	coreconfig.Reset()
+	namespace.InitConfig()
+	apiserver.InitConfig()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(conf))
+	assert.NoError(t, err)
+}
+
+func errorStrings(errs []error) []string {
+	strs := make([]string, len(errs))
+	for i, err := range errs {
+		strs[i] = err.Error()
+	}
+	return strs
+}
+
+func TestValidateOK(t *testing.T) {
+	newTestConfig(t, validConfig)
+	errs := Validate(context.Background())
+	assert.Empty(t, errs)
+}
+
+func TestValidateDefaults(t *testing.T) {
+	newTestConfig(t, "")
+	errs := Validate(context.Background())
+	assert.Empty(t, errs)
+}
+
+func TestValidateUnknownKeys(t *testing.T) {
+	newTestConfig(t, `
+http:
+  bogus: true
+unknownsection:
+  a: 1
+unknownlist: [1, 2]
+plugins:
+  blockchain:
+  - name: blockchain0
+    type: ethereum
+    ethereum:
+      ethconnect:
+        url: http://localhost:5102
+        topic: topic1
+        badKey: x
+`)
+	errs := Validate(context.Background())
+	assert.Equal(t, []string{
+		"FF10520: Unknown configuration key 'http.bogus'",
+		"FF10520: Unknown configuration key 'plugins.blockchain[0].ethereum.ethconnect.badkey'",
+		"FF10520: Unknown configuration key 'unknownlist'",
+		"FF10520: Unknown configuration key 'unknownsection'",
+	}, errorStrings(errs))
+}
+
+func TestValidateTypeErrors(t *testing.T) {
+	newTestConfig(t, `
+http:
+  port: abc
+  readTimeout: soon
+metrics:
+  enabled: maybe
+`)
+	errs := Validate(context.Background())
+	assert.Len(t, errs, 3)
+	assert.Regexp(t, "FF10521.*abc.*http.port.*int", errs[0])
+	assert.Regexp(t, "FF10521.*soon.*http.readtimeout", errs[1])
+	assert.Regexp(t, "FF10521.*maybe.*metrics.enabled.*boolean", errs[2])
+}
@@ -0,0 +1,128 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (cm *contractManager) DiffContractAPIVersions(ctx context.Context, apiName, fromVersion, toVersion string) (*core.ContractAPIDiff, error) {
+	if fromVersion == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractAPIDiffFromVersionRequired)
+	}
+	from, fromFFI, err := cm.getContractAPIVersionWithInterface(ctx, apiName, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, toFFI, err := cm.getContractAPIVersionWithInterface(ctx, apiName, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &core.ContractAPIDiff{
+		From: &core.ContractAPIVersionRef{Version: from.Version, Location: from.Location, Interface: ffiReference(fromFFI)},
+		To:   &core.ContractAPIVersionRef{Version: to.Version, Location: to.Location, Interface: ffiReference(toFFI)},
+	}
+	diff.Methods = diffFFIEntries(methodSignatures(fromFFI.Methods), methodSignatures(toFFI.Methods))
+	diff.Events = diffFFIEntries(eventSignatures(fromFFI.Events), eventSignatures(toFFI.Events))
+	diff.Errors = diffFFIEntries(errorSignatures(fromFFI.Errors), errorSignatures(toFFI.Errors))
+	return diff, nil
+}
+
+func (cm *contractManager) getContractAPIVersionWithInterface(ctx context.Context, apiName, version string) (*core.ContractAPI, *fftypes.FFI, error) {
+	api, err := cm.getContractAPIVersion(ctx, apiName, version)
+	if err != nil {
+		return nil, nil, err
+	} else if api == nil || api.Interface == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	ffi, err := cm.GetFFIByIDWithChildren(ctx, api.Interface.ID)
+	if err != nil {
+		return nil, nil, err
+	} else if ffi == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.MsgContractInterfaceNotFound, api.Interface.ID)
+	}
+	return api, ffi, nil
+}
+
+func ffiReference(ffi *fftypes.FFI) *fftypes.FFIReference {
+	return &fftypes.FFIReference{ID: ffi.ID, Name: ffi.Name, Version: ffi.Version}
+}
+
+// The signature of each entry is a serialization of the parts that affect how it is used on the
+// blockchain, so that changes to descriptions alone are not reported as changes
+func entrySignature(parts ...interface{}) string {
+	b, _ := json.Marshal(parts)
+	return string(b)
+}
+
+func methodSignatures(methods []*fftypes.FFIMethod) map[string]string {
+	signatures := make(map[string]string, len(methods))
+	for _, m := range methods {
+		signatures[m.Pathname] = entrySignature(m.Params, m.Returns, m.Details)
+	}
+	return signatures
+}
+
+func eventSignatures(events []*fftypes.FFIEvent) map[string]string {
+	signatures := make(map[string]string, len(events))
+	for _, e := range events {
+		signatures[e.Pathname] = entrySignature(e.Params, e.Details)
+	}
+	return signatures
+}
+
+func errorSignatures(errors []*fftypes.FFIError) map[string]string {
+	signatures := make(map[string]string, len(errors))
+	for _, e := range errors {
+		signatures[e.Pathname] = entrySignature(e.Params)
+	}
+	return signatures
+}
+
+func diffFFIEntries(from, to map[string]string) *core.FFIDiffEntries {
+	entries := &core.FFIDiffEntries{
+		Added:   []string{},
+		Removed: []string{},
+		Changed: []string{},
+	}
+	for pathname, signature := range to {
+		fromSignature, ok := from[pathname]
+		switch {
+		case !ok:
+			entries.Added = append(entries.Added, pathname)
+		case fromSignature != signature:
+			entries.Changed = append(entries.Changed, pathname)
+		}
+	}
+	for pathname := range from {
+		if _, ok := to[pathname]; !ok {
+			entries.Removed = append(entries.Removed, pathname)
+		}
+	}
+	sort.Strings(entries.Added)
+	sort.Strings(entries.Removed)
+	sort.Strings(entries.Changed)
+	return entries
+}
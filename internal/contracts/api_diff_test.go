@@ -0,0 +1,185 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockFFIWithChildren(mdb *databasemocks.Plugin, ffi *fftypes.FFI) {
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(ffi, nil).Once()
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil).Once()
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(ffi.Events, nil, nil).Once()
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(ffi.Errors, nil, nil).Once()
+}
+
+func TestDiffContractAPIVersions(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	uintParam := fftypes.FFIParams{{Name: "x", Schema: fftypes.JSONAnyPtr(`{"type":"integer"}`)}}
+	stringParam := fftypes.FFIParams{{Name: "x", Schema: fftypes.JSONAnyPtr(`{"type":"string"}`)}}
+	ffiV1 := &fftypes.FFI{
+		ID:      fftypes.NewUUID(),
+		Name:    "banana",
+		Version: "1.0.0",
+		Methods: []*fftypes.FFIMethod{
+			{Pathname: "peel", Params: uintParam},
+			{Pathname: "eat", Params: uintParam, Description: "old"},
+			{Pathname: "squash"},
+		},
+		Events: []*fftypes.FFIEvent{
+			{Pathname: "Peeled", FFIEventDefinition: fftypes.FFIEventDefinition{Params: uintParam}},
+		},
+		Errors: []*fftypes.FFIError{
+			{Pathname: "Rotten", FFIErrorDefinition: fftypes.FFIErrorDefinition{Params: uintParam}},
+		},
+	}
+	ffiV2 := &fftypes.FFI{
+		ID:      fftypes.NewUUID(),
+		Name:    "banana",
+		Version: "2.0.0",
+		Methods: []*fftypes.FFIMethod{
+			{Pathname: "peel", Params: stringParam},
+			{Pathname: "eat", Params: uintParam, Description: "new"},
+			{Pathname: "split"},
+		},
+		Events: []*fftypes.FFIEvent{
+			{Pathname: "Peeled", FFIEventDefinition: fftypes.FFIEventDefinition{Params: uintParam}},
+			{Pathname: "Split"},
+		},
+	}
+	location := fftypes.JSONAnyPtr(`{"address":"0x12345"}`)
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "1.0.0").Return(&core.ContractAPI{
+		Version:   "1.0.0",
+		Interface: &fftypes.FFIReference{ID: ffiV1.ID},
+	}, nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(&core.ContractAPI{
+		Version:   "2.0.0",
+		Location:  location,
+		Interface: &fftypes.FFIReference{ID: ffiV2.ID},
+	}, nil)
+	mockFFIWithChildren(mdb, ffiV1)
+	mockFFIWithChildren(mdb, ffiV2)
+	mbi.On("GenerateEventSignature", mock.Anything, mock.Anything).Return("sig", nil)
+	mbi.On("GenerateErrorSignature", mock.Anything, mock.Anything).Return("sig")
+
+	diff, err := cm.DiffContractAPIVersions(context.Background(), "banana", "1.0.0", "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1.0.0", diff.From.Version)
+	assert.Equal(t, ffiV1.ID, diff.From.Interface.ID)
+	assert.Equal(t, "2.0.0", diff.To.Version)
+	assert.Equal(t, "2.0.0", diff.To.Interface.Version)
+	assert.Equal(t, location, diff.To.Location)
+	assert.Equal(t, &core.FFIDiffEntries{
+		Added:   []string{"split"},
+		Removed: []string{"squash"},
+		Changed: []string{"peel"},
+	}, diff.Methods)
+	assert.Equal(t, &core.FFIDiffEntries{
+		Added:   []string{"Split"},
+		Removed: []string{},
+		Changed: []string{},
+	}, diff.Events)
+	assert.Equal(t, &core.FFIDiffEntries{
+		Added:   []string{},
+		Removed: []string{"Rotten"},
+		Changed: []string{},
+	}, diff.Errors)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestDiffContractAPIVersionsFromRequired(t *testing.T) {
+	cm := newTestContractManager()
+
+	_, err := cm.DiffContractAPIVersions(context.Background(), "banana", "", "2.0.0")
+	assert.Regexp(t, "FF10491", err)
+}
+
+func TestDiffContractAPIVersionsFromNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "1.0.0").Return(nil, nil)
+
+	_, err := cm.DiffContractAPIVersions(context.Background(), "banana", "1.0.0", "2.0.0")
+	assert.Regexp(t, "FF10109", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestDiffContractAPIVersionsToLookupFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := &fftypes.FFI{ID: fftypes.NewUUID()}
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "1.0.0").Return(&core.ContractAPI{
+		Interface: &fftypes.FFIReference{ID: ffi.ID},
+	}, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "2.0.0").Return(nil, fmt.Errorf("pop"))
+	mockFFIWithChildren(mdb, ffi)
+
+	_, err := cm.DiffContractAPIVersions(context.Background(), "banana", "1.0.0", "2.0.0")
+	assert.Regexp(t, "pop", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestDiffContractAPIVersionsInterfaceNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ifaceID := fftypes.NewUUID()
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "1.0.0").Return(&core.ContractAPI{
+		Interface: &fftypes.FFIReference{ID: ifaceID},
+	}, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ifaceID).Return(nil, nil)
+
+	_, err := cm.DiffContractAPIVersions(context.Background(), "banana", "1.0.0", "")
+	assert.Regexp(t, "FF10303", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestDiffContractAPIVersionsInterfaceFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ifaceID := fftypes.NewUUID()
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "1.0.0").Return(&core.ContractAPI{
+		Interface: &fftypes.FFIReference{ID: ifaceID},
+	}, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ifaceID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.DiffContractAPIVersions(context.Background(), "banana", "1.0.0", "")
+	assert.Regexp(t, "pop", err)
+
+	mdb.AssertExpectations(t)
+}
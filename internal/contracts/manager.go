@@ -21,8 +21,10 @@ import (
 	"crypto/sha256"
 	"database/sql/driver"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
+	"net/url"
 	"sort"
 	"strings"
 
@@ -38,6 +40,7 @@ import (
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/database/sqlcommon"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/internal/syncasync"
@@ -49,6 +52,11 @@ import (
 	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
+// APIDefiner defines contract APIs on behalf of the contract manager, such as the definition sender
+type APIDefiner interface {
+	DefineContractAPI(ctx context.Context, httpServerURL string, api *core.ContractAPI, waitConfirm bool) error
+}
+
 type Manager interface {
 	core.Named
 
@@ -62,15 +70,25 @@ type Manager interface {
 	ResolveFFI(ctx context.Context, ffi *fftypes.FFI) error
 	ResolveFFIReference(ctx context.Context, ref *fftypes.FFIReference) error
 	DeleteFFI(ctx context.Context, id *fftypes.UUID) error
+	ShareFFI(ctx context.Context, name, version string) (*core.SharedFFI, error)
+	GetSharedFFIs(ctx context.Context, filter ffapi.AndFilter) ([]*core.SharedFFI, *ffapi.FilterResult, error)
+	GetSharedFFIWithChildren(ctx context.Context, name, version string) (*core.SharedFFI, *fftypes.FFI, error)
 
 	DeployContract(ctx context.Context, req *core.ContractDeployRequest, waitConfirm bool) (interface{}, error)
+	UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error)
+	GetContractABIs(ctx context.Context) ([]*core.ContractABI, error)
+	GetContractABI(ctx context.Context, id string) (*core.ContractABI, error)
+	DeployContractABI(ctx context.Context, req *core.ContractABIDeployRequest, waitConfirm bool) (interface{}, error)
 	InvokeContract(ctx context.Context, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error)
-	InvokeContractAPI(ctx context.Context, apiName, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error)
-	GetContractAPI(ctx context.Context, httpServerURL, apiName string) (*core.ContractAPI, error)
-	GetContractAPIInterface(ctx context.Context, apiName string) (*fftypes.FFI, error)
+	InvokeContractBatch(ctx context.Context, req *core.ContractCallBatchRequest) (*core.ContractCallBatchResponse, error)
+	InvokeContractAPI(ctx context.Context, apiName, version, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error)
+	GetContractAPI(ctx context.Context, httpServerURL, apiName, version string) (*core.ContractAPI, error)
+	GetContractAPIInterface(ctx context.Context, apiName, version string) (*fftypes.FFI, error)
 	GetContractAPIs(ctx context.Context, httpServerURL string, filter ffapi.AndFilter) ([]*core.ContractAPI, *ffapi.FilterResult, error)
+	DiffContractAPIVersions(ctx context.Context, apiName, fromVersion, toVersion string) (*core.ContractAPIDiff, error)
 	ResolveContractAPI(ctx context.Context, httpServerURL string, api *core.ContractAPI) error
-	DeleteContractAPI(ctx context.Context, apiName string) error
+	DeleteContractAPI(ctx context.Context, apiName, version string) error
+	SetAPIDefiner(definer APIDefiner)
 
 	ConstructContractListenerSignature(ctx context.Context, listener *core.ContractListenerInput) (output *core.ContractListenerSignatureOutput, err error)
 	AddContractListener(ctx context.Context, listener *core.ContractListenerInput) (output *core.ContractListener, err error)
@@ -97,11 +115,14 @@ type contractManager struct {
 	txHelper          txcommon.Helper
 	txWriter          txwriter.Writer
 	identity          identity.Manager
+	metrics           metrics.Manager
 	blockchain        blockchain.Plugin
 	ffiParamValidator fftypes.FFIParamValidator
 	operations        operations.Manager
 	syncasync         syncasync.Bridge
 	methodCache       cache.CInterface
+	queryCache        cache.CInterface
+	apiDefiner        APIDefiner
 }
 
 type methodCacheEntry struct {
@@ -113,8 +134,8 @@ type schemaValidationEntry struct {
 	schema *jsonschema.Schema
 }
 
-func NewContractManager(ctx context.Context, ns string, di database.Plugin, bi blockchain.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, bp batch.Manager, im identity.Manager, om operations.Manager, txHelper txcommon.Helper, txWriter txwriter.Writer, sa syncasync.Bridge, cacheManager cache.Manager) (Manager, error) {
-	if di == nil || im == nil || bi == nil || dm == nil || om == nil || txHelper == nil || txWriter == nil || sa == nil || cacheManager == nil {
+func NewContractManager(ctx context.Context, ns string, di database.Plugin, bi blockchain.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, bp batch.Manager, im identity.Manager, mm metrics.Manager, om operations.Manager, txHelper txcommon.Helper, txWriter txwriter.Writer, sa syncasync.Bridge, cacheManager cache.Manager) (Manager, error) {
+	if di == nil || im == nil || bi == nil || dm == nil || mm == nil || om == nil || txHelper == nil || txWriter == nil || sa == nil || cacheManager == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "ContractManager")
 	}
 	v, err := bi.GetFFIParamValidator(ctx)
@@ -132,6 +153,7 @@ func NewContractManager(ctx context.Context, ns string, di database.Plugin, bi b
 		txHelper:          txHelper,
 		txWriter:          txWriter,
 		identity:          im,
+		metrics:           mm,
 		blockchain:        bi,
 		ffiParamValidator: v,
 		operations:        om,
@@ -150,9 +172,23 @@ func NewContractManager(ctx context.Context, ns string, di database.Plugin, bi b
 		return nil, err
 	}
 
+	cm.queryCache, err = cacheManager.GetCache(
+		cache.NewCacheConfig(
+			ctx,
+			coreconfig.CacheContractQueryLimit,
+			coreconfig.CacheContractQueryTTL,
+			ns,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	om.RegisterHandler(ctx, cm, []core.OpType{
 		core.OpTypeBlockchainInvoke,
+		core.OpTypeBlockchainInvokeBatch,
 		core.OpTypeBlockchainContractDeploy,
+		core.OpTypeBlockchainContractDeployABI,
 	})
 
 	// Validate all our listeners exist on startup - consistent with the multi-party manager.
@@ -193,7 +229,7 @@ func (cm *contractManager) GetFFI(ctx context.Context, name, version string) (*f
 func (cm *contractManager) GetFFIWithChildren(ctx context.Context, name, version string) (*fftypes.FFI, error) {
 	ffi, err := cm.GetFFI(ctx, name, version)
 	if err == nil {
-		err = cm.getFFIChildren(ctx, ffi)
+		err = cm.getFFIChildren(ctx, cm.namespace, ffi)
 	}
 	return ffi, err
 }
@@ -203,14 +239,22 @@ func (cm *contractManager) GetFFIByID(ctx context.Context, id *fftypes.UUID) (*f
 }
 
 func (cm *contractManager) GetFFIMethods(ctx context.Context, id *fftypes.UUID) ([]*fftypes.FFIMethod, error) {
+	return cm.getFFIMethods(ctx, cm.namespace, id)
+}
+
+func (cm *contractManager) getFFIMethods(ctx context.Context, namespace string, id *fftypes.UUID) ([]*fftypes.FFIMethod, error) {
 	fb := database.FFIMethodQueryFactory.NewFilter(ctx)
-	methods, _, err := cm.database.GetFFIMethods(ctx, cm.namespace, fb.Eq("interface", id))
+	methods, _, err := cm.database.GetFFIMethods(ctx, namespace, fb.Eq("interface", id))
 	return methods, err
 }
 
 func (cm *contractManager) GetFFIEvents(ctx context.Context, id *fftypes.UUID) ([]*fftypes.FFIEvent, error) {
+	return cm.getFFIEvents(ctx, cm.namespace, id)
+}
+
+func (cm *contractManager) getFFIEvents(ctx context.Context, namespace string, id *fftypes.UUID) ([]*fftypes.FFIEvent, error) {
 	fb := database.FFIMethodQueryFactory.NewFilter(ctx)
-	events, _, err := cm.database.GetFFIEvents(ctx, cm.namespace, fb.Eq("interface", id))
+	events, _, err := cm.database.GetFFIEvents(ctx, namespace, fb.Eq("interface", id))
 	if err == nil {
 		for _, event := range events {
 			event.Signature, err = cm.blockchain.GenerateEventSignature(ctx, &event.FFIEventDefinition)
@@ -222,19 +266,19 @@ func (cm *contractManager) GetFFIEvents(ctx context.Context, id *fftypes.UUID) (
 	return events, err
 }
 
-func (cm *contractManager) getFFIChildren(ctx context.Context, ffi *fftypes.FFI) (err error) {
-	ffi.Methods, err = cm.GetFFIMethods(ctx, ffi.ID)
+func (cm *contractManager) getFFIChildren(ctx context.Context, namespace string, ffi *fftypes.FFI) (err error) {
+	ffi.Methods, err = cm.getFFIMethods(ctx, namespace, ffi.ID)
 	if err != nil {
 		return err
 	}
 
-	ffi.Events, err = cm.GetFFIEvents(ctx, ffi.ID)
+	ffi.Events, err = cm.getFFIEvents(ctx, namespace, ffi.ID)
 	if err != nil {
 		return err
 	}
 
 	fb := database.FFIErrorQueryFactory.NewFilter(ctx)
-	ffi.Errors, _, err = cm.database.GetFFIErrors(ctx, cm.namespace, fb.Eq("interface", ffi.ID))
+	ffi.Errors, _, err = cm.database.GetFFIErrors(ctx, namespace, fb.Eq("interface", ffi.ID))
 	if err != nil {
 		return err
 	}
@@ -251,7 +295,7 @@ func (cm *contractManager) GetFFIByIDWithChildren(ctx context.Context, id *fftyp
 		if err != nil || ffi == nil {
 			return err
 		}
-		return cm.getFFIChildren(ctx, ffi)
+		return cm.getFFIChildren(ctx, cm.namespace, ffi)
 	})
 	return ffi, err
 }
@@ -360,17 +404,17 @@ func (cm *contractManager) writeInvokeTransaction(ctx context.Context, req *core
 	return false, op, err
 }
 
-func (cm *contractManager) writeDeployTransaction(ctx context.Context, req *core.ContractDeployRequest) (bool, *core.Operation, error) {
+func (cm *contractManager) writeDeployTransaction(ctx context.Context, opType core.OpType, req interface{}, idempotencyKey core.IdempotencyKey) (bool, *core.Operation, error) {
 
 	op := core.NewOperation(
 		cm.blockchain,
 		cm.namespace,
 		nil, // assigned by txwriter
-		core.OpTypeBlockchainContractDeploy)
+		opType)
 	if err := addBlockchainReqInputs(op, req); err != nil {
 		return false, nil, err
 	}
-	_, err := cm.txWriter.WriteTransactionAndOps(ctx, core.TransactionTypeContractDeploy, req.IdempotencyKey, op)
+	_, err := cm.txWriter.WriteTransactionAndOps(ctx, core.TransactionTypeContractDeploy, idempotencyKey, op)
 	if err != nil {
 		// Check if we've clashed on idempotency key. There might be operations still in "Initialized" state that need
 		// submitting to their handlers
@@ -399,7 +443,21 @@ func (cm *contractManager) DeployContract(ctx context.Context, req *core.Contrac
 		return nil, err
 	}
 
-	resubmit, op, err := cm.writeDeployTransaction(ctx, req)
+	if req.APIName != "" {
+		if req.Interface == nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgContractDeployAPIMissingInterface)
+		}
+		if err := fftypes.ValidateFFNameField(ctx, req.APIName, "apiName"); err != nil {
+			return nil, err
+		}
+	}
+	if req.Interface != nil {
+		if err := cm.ResolveFFIReference(ctx, req.Interface); err != nil {
+			return nil, err
+		}
+	}
+
+	resubmit, op, err := cm.writeDeployTransaction(ctx, core.OpTypeBlockchainContractDeploy, req, req.IdempotencyKey)
 	if err != nil {
 		return nil, err
 	}
@@ -418,6 +476,56 @@ func (cm *contractManager) DeployContract(ctx context.Context, req *core.Contrac
 	return op, err
 }
 
+func (cm *contractManager) SetAPIDefiner(definer APIDefiner) {
+	cm.apiDefiner = definer
+}
+
+func (cm *contractManager) UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error) {
+	if upload.ABI == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractABIMissing)
+	}
+	return cm.blockchain.UploadContractABI(ctx, upload)
+}
+
+func (cm *contractManager) GetContractABIs(ctx context.Context) ([]*core.ContractABI, error) {
+	return cm.blockchain.GetContractABIs(ctx)
+}
+
+func (cm *contractManager) GetContractABI(ctx context.Context, id string) (*core.ContractABI, error) {
+	abiInfo, err := cm.blockchain.GetContractABI(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if abiInfo == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return abiInfo, nil
+}
+
+func (cm *contractManager) DeployContractABI(ctx context.Context, req *core.ContractABIDeployRequest, waitConfirm bool) (res interface{}, err error) {
+	req.Key, err = cm.identity.ResolveInputSigningKey(ctx, req.Key, identity.KeyNormalizationBlockchainPlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	resubmit, op, err := cm.writeDeployTransaction(ctx, core.OpTypeBlockchainContractDeployABI, req, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if resubmit {
+		return op, nil // nothing more to do
+	}
+
+	send := func(ctx context.Context) error {
+		_, err := cm.operations.RunOperation(ctx, opBlockchainContractDeployABI(op, req), req.IdempotencyKey != "")
+		return err
+	}
+	if waitConfirm {
+		return cm.syncasync.WaitForDeployOperation(ctx, op.ID, send)
+	}
+	err = send(ctx)
+	return op, err
+}
+
 func (cm *contractManager) InvokeContract(ctx context.Context, req *core.ContractCallRequest, waitConfirm bool) (res interface{}, err error) {
 	keyResolver := cm.identity.ResolveInputSigningKey
 	if req.Type == core.CallTypeQuery {
@@ -467,7 +575,12 @@ func (cm *contractManager) InvokeContract(ctx context.Context, req *core.Contrac
 	case core.CallTypeInvoke:
 		if msgSender != nil {
 			if waitConfirm {
-				return op, msgSender.SendAndWait(ctx)
+				// The message is only confirmed once its pin has been observed on-chain, which means the
+				// invocation carrying it has been mined - so return the operation as it stands at that point
+				if err := msgSender.SendAndWait(ctx); err != nil {
+					return op, err
+				}
+				return cm.database.GetOperationByID(ctx, cm.namespace, op.ID)
 			}
 			return op, msgSender.Send(ctx)
 		}
@@ -488,8 +601,106 @@ func (cm *contractManager) InvokeContract(ctx context.Context, req *core.Contrac
 	}
 }
 
-func (cm *contractManager) InvokeContractAPI(ctx context.Context, apiName, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error) {
-	api, err := cm.database.GetContractAPIByName(ctx, cm.namespace, apiName)
+func (cm *contractManager) InvokeContractBatch(ctx context.Context, req *core.ContractCallBatchRequest) (res *core.ContractCallBatchResponse, err error) {
+	if len(req.Items) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractBatchEmpty)
+	}
+	if req.Multicall != nil {
+		if !cm.blockchain.Capabilities().Multicall {
+			return nil, i18n.NewError(ctx, coremsgs.MsgNotSupportedByBlockchainPlugin)
+		}
+		if req.Multicall, err = cm.blockchain.NormalizeContractLocation(ctx, blockchain.NormalizeCall, req.Multicall); err != nil {
+			return nil, err
+		}
+	}
+	req.Key, err = cm.identity.ResolveInputSigningKey(ctx, req.Key, identity.KeyNormalizationBlockchainPlugin)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range req.Items {
+		if err := cm.resolveBatchItem(ctx, req, item); err != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgContractBatchItemInvalid, i, err)
+		}
+	}
+
+	resubmit, txID, ops, err := cm.writeInvokeBatchTransaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res = &core.ContractCallBatchResponse{TX: txID, Operations: ops}
+	if resubmit {
+		return res, nil
+	}
+
+	if req.Multicall != nil {
+		_, err = cm.operations.RunOperation(ctx, opBlockchainInvokeBatch(ops[0], req), req.IdempotencyKey != "")
+		return res, err
+	}
+	// Submission stops at the first failure. Any remaining operations are left initialized,
+	// so can be resubmitted by repeating the request with the same idempotency key.
+	for i, op := range ops {
+		if _, err = cm.operations.RunOperation(ctx, txcommon.OpBlockchainInvoke(op, req.Items[i], nil), req.IdempotencyKey != ""); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func (cm *contractManager) resolveBatchItem(ctx context.Context, req *core.ContractCallBatchRequest, item *core.ContractCallRequest) (err error) {
+	if item.Message != nil {
+		return i18n.NewError(ctx, coremsgs.MsgContractBatchMessageNotSupported)
+	}
+	item.Type = core.CallTypeInvoke
+	if req.Multicall != nil || item.Key == "" {
+		// All calls within a multicall are made by the aggregator, in a transaction signed by the batch key
+		item.Key = req.Key
+	} else if item.Key, err = cm.identity.ResolveInputSigningKey(ctx, item.Key, identity.KeyNormalizationBlockchainPlugin); err != nil {
+		return err
+	}
+	if err := cm.resolveInvokeContractRequest(ctx, item); err != nil {
+		return err
+	}
+	_, err = cm.validateInvokeContractRequest(ctx, item, true)
+	return err
+}
+
+func (cm *contractManager) writeInvokeBatchTransaction(ctx context.Context, req *core.ContractCallBatchRequest) (bool, *fftypes.UUID, []*core.Operation, error) {
+	var ops []*core.Operation
+	if req.Multicall != nil {
+		op := core.NewOperation(cm.blockchain, cm.namespace, nil, core.OpTypeBlockchainInvokeBatch)
+		if err := addBlockchainReqInputs(op, req); err != nil {
+			return false, nil, nil, err
+		}
+		ops = append(ops, op)
+	} else {
+		for _, item := range req.Items {
+			op := core.NewOperation(cm.blockchain, cm.namespace, nil, core.OpTypeBlockchainInvoke)
+			if err := addBlockchainReqInputs(op, item); err != nil {
+				return false, nil, nil, err
+			}
+			ops = append(ops, op)
+		}
+	}
+
+	txn, err := cm.txWriter.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, req.IdempotencyKey, ops...)
+	if err != nil {
+		// Check if we've clashed on idempotency key, and resubmit any operations still in "Initialized" state
+		if idemErr, ok := err.(*sqlcommon.IdempotencyError); ok {
+			_, resubmitted, resubmitErr := cm.operations.ResubmitOperations(ctx, idemErr.ExistingTXID)
+			if resubmitErr != nil {
+				err = resubmitErr
+			} else if len(resubmitted) > 0 {
+				return true, idemErr.ExistingTXID, resubmitted, nil
+			}
+		}
+		return false, nil, nil, err
+	}
+	return false, txn.ID, ops, nil
+}
+
+func (cm *contractManager) InvokeContractAPI(ctx context.Context, apiName, version, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error) {
+	api, err := cm.getContractAPIVersion(ctx, apiName, version)
 	if err != nil {
 		return nil, err
 	} else if api == nil || api.Interface == nil {
@@ -500,7 +711,35 @@ func (cm *contractManager) InvokeContractAPI(ctx context.Context, apiName, metho
 	if api.Location != nil {
 		req.Location = api.Location
 	}
-	return cm.InvokeContract(ctx, req, waitConfirm)
+	if queryCacheEnabled(api, req) {
+		return cm.queryContractAPICached(ctx, api, methodPath, req)
+	}
+	res, err := cm.InvokeContract(ctx, req, waitConfirm)
+	if err != nil || !waitConfirm {
+		return res, err
+	}
+	if op, ok := res.(*core.Operation); ok && op.Status == core.OpStatusSucceeded {
+		return cm.decodeInvokeResult(ctx, req, op), nil
+	}
+	return res, nil
+}
+
+// decodeInvokeResult uses the interface of the contract to decode the return values, and the events
+// emitted by the transaction, from the receipt of a confirmed invocation
+func (cm *contractManager) decodeInvokeResult(ctx context.Context, req *core.ContractCallRequest, op *core.Operation) *core.ContractInvokeResult {
+	result := &core.ContractInvokeResult{Operation: op}
+	parsedMethod, err := cm.blockchain.ParseInterface(ctx, req.Method, req.Errors)
+	if err == nil {
+		var events []*fftypes.FFIEvent
+		if events, err = cm.GetFFIEvents(ctx, req.Interface); err == nil {
+			result.ReturnValues, result.Events, err = cm.blockchain.DecodeInvokeReceipt(ctx, parsedMethod, events, op.Output)
+		}
+	}
+	if err != nil {
+		// The transaction is confirmed, so we still return success with the operation
+		log.L(ctx).Warnf("Unable to decode receipt of operation %s: %s", op.ID, err)
+	}
+	return result
 }
 
 func (cm *contractManager) resolveInvokeContractRequest(ctx context.Context, req *core.ContractCallRequest) (err error) {
@@ -538,20 +777,32 @@ func (cm *contractManager) addContractURLs(httpServerURL string, api *core.Contr
 		// These URLs must match the actual routes in apiserver.createMuxRouter()!
 		// Note the httpServerURL includes the namespace
 		baseURL := fmt.Sprintf("%s/apis/%s", httpServerURL, api.Name)
-		api.URLs.OpenAPI = baseURL + "/api/swagger.json"
-		api.URLs.UI = baseURL + "/api"
+		versionQuery := ""
+		if api.Version != "" {
+			versionQuery = "?version=" + url.QueryEscape(api.Version)
+		}
+		api.URLs.OpenAPI = baseURL + "/api/swagger.json" + versionQuery
+		api.URLs.UI = baseURL + "/api" + versionQuery
 		api.URLs.API = baseURL
 	}
 }
 
-func (cm *contractManager) GetContractAPI(ctx context.Context, httpServerURL, apiName string) (*core.ContractAPI, error) {
-	api, err := cm.database.GetContractAPIByName(ctx, cm.namespace, apiName)
+// getContractAPIVersion returns the requested version of an API, or the latest version if none is specified
+func (cm *contractManager) getContractAPIVersion(ctx context.Context, apiName, version string) (*core.ContractAPI, error) {
+	if version == "" {
+		return cm.database.GetContractAPIByName(ctx, cm.namespace, apiName)
+	}
+	return cm.database.GetContractAPIByNameAndVersion(ctx, cm.namespace, apiName, version)
+}
+
+func (cm *contractManager) GetContractAPI(ctx context.Context, httpServerURL, apiName, version string) (*core.ContractAPI, error) {
+	api, err := cm.getContractAPIVersion(ctx, apiName, version)
 	cm.addContractURLs(httpServerURL, api)
 	return api, err
 }
 
-func (cm *contractManager) GetContractAPIInterface(ctx context.Context, apiName string) (*fftypes.FFI, error) {
-	api, err := cm.GetContractAPI(ctx, "", apiName)
+func (cm *contractManager) GetContractAPIInterface(ctx context.Context, apiName, version string) (*fftypes.FFI, error) {
+	api, err := cm.GetContractAPI(ctx, "", apiName, version)
 	if err != nil || api == nil {
 		return nil, err
 	}
@@ -578,7 +829,7 @@ func (cm *contractManager) ResolveContractAPI(ctx context.Context, httpServerURL
 	}
 
 	err = cm.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
-		existing, err := cm.database.GetContractAPIByName(ctx, api.Namespace, api.Name)
+		existing, err := cm.database.GetContractAPIByNameAndVersion(ctx, api.Namespace, api.Name, api.Version)
 		if existing != nil && err == nil {
 			if !api.LocationAndLedgerEquals(existing) {
 				return i18n.NewError(ctx, coremsgs.MsgContractLocationExists)
@@ -588,7 +839,7 @@ func (cm *contractManager) ResolveContractAPI(ctx context.Context, httpServerURL
 		if err := cm.ResolveFFIReference(ctx, api.Interface); err != nil {
 			return err
 		}
-		return nil
+		return cm.verifyInterfaceHash(ctx, api)
 	})
 	if err != nil {
 		return err
@@ -702,6 +953,13 @@ func (cm *contractManager) validateFFIMethod(ctx context.Context, method *fftype
 		paramUniqueHash.Write([]byte(param.Name))
 		paramUniqueHash.Write([]byte(returnHash))
 	}
+	// The parameter used to carry a message pin affects how the input is built, so it is also part of the hash
+	if pinParam := method.Details.GetString(blockchain.FFIMethodPinParam); pinParam != "" {
+		if blockchain.PinParamIndex(method) < 0 {
+			return nil, nil, i18n.NewError(ctx, coremsgs.MsgPinParamNotFound, pinParam, method.Name)
+		}
+		paramUniqueHash.Write([]byte(pinParam))
+	}
 	return paramUniqueHash, paramSchemas, nil
 }
 
@@ -768,32 +1026,41 @@ func (cm *contractManager) validateInvokeContractRequest(ctx context.Context, re
 	}
 
 	// Validate that all parameters are specified and are of reasonable JSON types to match the FFI
-	lastIndex := len(req.Method.Params)
+	pinIndex := -1
 	if req.Message != nil {
-		// If a message is included, skip validation of the last parameter
-		// (assume it will be used for sending the batch pin)
-		lastIndex--
-		if lastIndex < 0 {
+		// If a message is included, skip validation of the parameter used for sending the batch pin
+		// (the last parameter, unless another is configured in the details of the method)
+		pinIndex = blockchain.PinParamIndex(req.Method)
+		if pinIndex < 0 {
 			return nil, i18n.NewError(ctx, coremsgs.MsgMethodDoesNotSupportPinning)
 		}
 
-		// Also verify that the user didn't pass in a value for this last parameter
-		lastParam := req.Method.Params[lastIndex]
-		if _, ok := req.Input[lastParam.Name]; ok {
-			return nil, i18n.NewError(ctx, coremsgs.MsgCannotSetParameterWithMessage, lastParam.Name)
+		// Also verify that the user didn't pass in a value for this parameter
+		pinParam := req.Method.Params[pinIndex]
+		if _, ok := req.Input[pinParam.Name]; ok {
+			return nil, i18n.NewError(ctx, coremsgs.MsgCannotSetParameterWithMessage, pinParam.Name)
 		}
 	}
 
-	for _, param := range req.Method.Params[:lastIndex] {
+	// Check every parameter before failing, so all the problems with the input are reported together
+	var fieldErrors []string
+	for i, param := range req.Method.Params {
+		if i == pinIndex {
+			continue
+		}
 		schema, schemaOk := paramSchemas[param.Name]
 		value, valueOk := req.Input[param.Name]
 		if !valueOk || !schemaOk {
-			return nil, i18n.NewError(ctx, coremsgs.MsgContractMissingInputArgument, param.Name)
+			fieldErrors = append(fieldErrors, i18n.NewError(ctx, coremsgs.MsgContractMissingInputArgument, param.Name).Error())
+			continue
 		}
 		if err := cm.checkParamSchema(ctx, param.Name, value, schema); err != nil {
-			return nil, err
+			fieldErrors = append(fieldErrors, err.Error())
 		}
 	}
+	if len(fieldErrors) > 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgContractInputInvalid, req.Method.Name, strings.Join(fieldErrors, "; "))
+	}
 
 	// Now we need to ask the blockchain connector to do its own validation of the FFI.
 	// This is cached by the aggregate cache key we just built
@@ -1022,6 +1289,9 @@ func (cm *contractManager) verifyContractListener(ctx context.Context, listener
 	} else if listener.Options.FirstEvent == "" {
 		listener.Options.FirstEvent = cm.getDefaultContractListenerOptions().FirstEvent
 	}
+	if err := cm.validateListenerMappings(ctx, listener.Options.Mappings); err != nil {
+		return nil, err
+	}
 
 	_, err = cm.ConstructContractListenerSignature(ctx, listener)
 	if err != nil {
@@ -1240,15 +1510,34 @@ func (cm *contractManager) DeleteContractListenerByNameOrID(ctx context.Context,
 		if err = cm.blockchain.DeleteContractListener(ctx, listener, true /* ok if not found */); err != nil {
 			return err
 		}
+		if err = cm.database.DeleteBlockchainCheckpoints(ctx, cm.namespace, listener.BackendID); err != nil {
+			return err
+		}
 		return cm.database.DeleteContractListenerByID(ctx, cm.namespace, listener.ID)
 	})
 }
 
 func (cm *contractManager) checkParamSchema(ctx context.Context, name string, input interface{}, schema *jsonschema.Schema) error {
-	if err := schema.Validate(input); err != nil {
-		return i18n.WrapError(ctx, err, coremsgs.MsgFFIValidationFail, name)
+	err := schema.Validate(input)
+	if err == nil {
+		return nil
+	}
+	// The input was decoded from JSON, so the only error Validate can return is a ValidationError.
+	// Report each failing field within the parameter, rather than just the first
+	ve := err.(*jsonschema.ValidationError)
+	var reasons []string
+	collectSchemaFailures(name, ve, &reasons)
+	return i18n.WrapError(ctx, errors.New(strings.Join(reasons, ", ")), coremsgs.MsgFFIValidationFail, name)
+}
+
+func collectSchemaFailures(name string, ve *jsonschema.ValidationError, reasons *[]string) {
+	if len(ve.Causes) == 0 {
+		*reasons = append(*reasons, fmt.Sprintf("%s%s: %s", name, ve.InstanceLocation, ve.Message))
+		return
+	}
+	for _, cause := range ve.Causes {
+		collectSchemaFailures(name, cause, reasons)
 	}
-	return nil
 }
 
 func (cm *contractManager) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
@@ -1266,6 +1555,31 @@ func (cm *contractManager) GenerateFFI(ctx context.Context, generationRequest *f
 	return ffi, err
 }
 
+func (cm *contractManager) validateListenerMappings(ctx context.Context, mappings []*core.ContractListenerMapping) error {
+	fields := make(map[string]bool, len(mappings))
+	for i, mapping := range mappings {
+		if mapping == nil || mapping.Field == "" || fields[mapping.Field] {
+			return i18n.NewError(ctx, coremsgs.MsgInvalidListenerMapping, i)
+		}
+		fields[mapping.Field] = true
+		if mapping.Path != "" {
+			for _, segment := range strings.Split(mapping.Path, ".") {
+				if segment == "" {
+					return i18n.NewError(ctx, coremsgs.MsgInvalidListenerMapping, i)
+				}
+			}
+		}
+		if mapping.Type != "" {
+			mappingType, err := fftypes.FFEnumParseString(ctx, "listenermappingtype", string(mapping.Type))
+			if err != nil {
+				return err
+			}
+			mapping.Type = mappingType
+		}
+	}
+	return nil
+}
+
 func (cm *contractManager) getDefaultContractListenerOptions() *core.ContractListenerOptions {
 	return &core.ContractListenerOptions{
 		FirstEvent: string(core.SubOptsFirstEventNewest),
@@ -1312,9 +1626,9 @@ func (cm *contractManager) DeleteFFI(ctx context.Context, id *fftypes.UUID) erro
 	})
 }
 
-func (cm *contractManager) DeleteContractAPI(ctx context.Context, apiName string) error {
+func (cm *contractManager) DeleteContractAPI(ctx context.Context, apiName, version string) error {
 	return cm.database.RunAsGroup(ctx, func(ctx context.Context) error {
-		api, err := cm.GetContractAPI(ctx, "", apiName)
+		api, err := cm.GetContractAPI(ctx, "", apiName, version)
 		if err != nil {
 			return err
 		}
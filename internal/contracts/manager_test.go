@@ -43,6 +43,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
@@ -83,13 +84,13 @@ func newTestContractManager() *contractManager {
 			a[1].(func(context.Context) error)(a[0].(context.Context)),
 		}
 	}
-	cm, _ := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, mom, txHelper, txw, msa, cmi)
+	cm, _ := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, &metricsmocks.Manager{}, mom, txHelper, txw, msa, cmi)
 	cm.(*contractManager).txHelper = &txcommonmocks.Helper{}
 	return cm.(*contractManager)
 }
 
 func TestNewContractManagerFail(t *testing.T) {
-	_, err := NewContractManager(context.Background(), "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := NewContractManager(context.Background(), "", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -120,7 +121,7 @@ func TestNewContractManagerVerifyListenersFails(t *testing.T) {
 	mbi.On("Name").Return("mockblockchain").Maybe()
 	mdi.On("GetContractListeners", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("KABOOM!")).Once()
 
-	cm, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, mom, txHelper, txw, msa, cmi)
+	cm, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, &metricsmocks.Manager{}, mom, txHelper, txw, msa, cmi)
 	assert.Nil(t, cm)
 	assert.NotNil(t, err)
 }
@@ -141,7 +142,7 @@ func TestNewContractManagerFFISchemaLoaderFail(t *testing.T) {
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	msa := &syncasyncmocks.Bridge{}
 	mbi.On("GetFFIParamValidator", mock.Anything).Return(nil, fmt.Errorf("pop"))
-	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, mom, txHelper, txw, msa, cmi)
+	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, &metricsmocks.Manager{}, mom, txHelper, txw, msa, cmi)
 	assert.Regexp(t, "pop", err)
 }
 
@@ -160,7 +161,27 @@ func TestNewContractManagerCacheConfigFail(t *testing.T) {
 	txHelper := &txcommonmocks.Helper{}
 	msa := &syncasyncmocks.Bridge{}
 	mbi.On("GetFFIParamValidator", mock.Anything).Return(nil, nil)
-	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, mom, txHelper, txw, msa, cmi)
+	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, &metricsmocks.Manager{}, mom, txHelper, txw, msa, cmi)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestNewContractManagerQueryCacheConfigFail(t *testing.T) {
+	mdi := &databasemocks.Plugin{}
+	mdm := &datamocks.Manager{}
+	mbm := &broadcastmocks.Manager{}
+	mpm := &privatemessagingmocks.Manager{}
+	mbp := &batchmocks.Manager{}
+	mim := &identitymanagermocks.Manager{}
+	mbi := &blockchainmocks.Plugin{}
+	mom := &operationmocks.Manager{}
+	txw := &txwritermocks.Writer{}
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(context.Background(), 100, 5*time.Minute), nil).Once()
+	cmi.On("GetCache", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	txHelper := &txcommonmocks.Helper{}
+	msa := &syncasyncmocks.Bridge{}
+	mbi.On("GetFFIParamValidator", mock.Anything).Return(nil, nil)
+	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, &metricsmocks.Manager{}, mom, txHelper, txw, msa, cmi)
 	assert.Regexp(t, "pop", err)
 }
 
@@ -182,7 +203,7 @@ func TestNewContractManagerFFISchemaLoader(t *testing.T) {
 	mdi.On("GetContractListeners", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
 	mbi.On("GetFFIParamValidator", mock.Anything).Return(&ffi2abi.ParamValidator{}, nil)
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, mock.Anything)
-	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, mom, txHelper, txw, msa, cmi)
+	_, err := NewContractManager(context.Background(), "ns1", mdi, mbi, mdm, mbm, mpm, mbp, mim, &metricsmocks.Manager{}, mom, txHelper, txw, msa, cmi)
 	assert.NoError(t, err)
 }
 
@@ -666,6 +687,36 @@ func TestValidateFFIBadMethod(t *testing.T) {
 	assert.Regexp(t, "FF10320", err)
 }
 
+func TestValidateFFIBadPinParam(t *testing.T) {
+	cm := newTestContractManager()
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	ffi := &fftypes.FFI{
+		Name:      "math",
+		Version:   "1.0.0",
+		Namespace: "default",
+		Methods: []*fftypes.FFIMethod{
+			{
+				Name: "sum",
+				Params: []*fftypes.FFIParam{
+					{
+						Name:   "x",
+						Schema: fftypes.JSONAnyPtr(`{"type": "integer", "details": {"type": "uint256"}}`),
+					},
+				},
+				Details: fftypes.JSONObject{
+					"pinParam": "data",
+				},
+			},
+		},
+	}
+
+	mdi.On("GetFFI", context.Background(), "ns1", "math", "1.0.0").Return(nil, nil)
+
+	err := cm.ResolveFFI(context.Background(), ffi)
+	assert.Regexp(t, "FF10492", err)
+}
+
 func TestValidateFFIBadEventParam(t *testing.T) {
 	cm := newTestContractManager()
 	mdi := cm.database.(*databasemocks.Plugin)
@@ -1436,6 +1487,62 @@ func TestAddContractListenerMissingTopic(t *testing.T) {
 	assert.Regexp(t, "FF00140.*'topic'", err)
 }
 
+func TestAddContractListenerMappings(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	sub := &core.ContractListenerInput{
+		ContractListener: core.ContractListener{
+			Event: &core.FFISerializedEvent{
+				FFIEventDefinition: fftypes.FFIEventDefinition{
+					Name: "changed",
+				},
+			},
+			Options: &core.ContractListenerOptions{
+				Mappings: []*core.ContractListenerMapping{
+					{Field: "value", Type: "Integer"},
+					{Field: "sender", Path: "args.0.from"},
+				},
+			},
+			Topic: "test-topic",
+		},
+	}
+
+	mbi.On("GenerateEventSignature", context.Background(), mock.Anything).Return("changed", nil)
+	mbi.On("GenerateEventSignatureWithLocation", context.Background(), mock.Anything, mock.Anything).Return("changed", nil)
+	mdi.On("GetContractListeners", context.Background(), "ns1", mock.Anything).Return(nil, nil, nil)
+	mbi.On("AddContractListener", context.Background(), &sub.ContractListener, "").Return(nil)
+	mdi.On("InsertContractListener", context.Background(), &sub.ContractListener).Return(nil)
+
+	result, err := cm.AddContractListener(context.Background(), sub)
+	assert.NoError(t, err)
+	assert.Equal(t, core.MappingTypeInteger, result.Options.Mappings[0].Type)
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestAddContractListenerBadMappings(t *testing.T) {
+	cm := newTestContractManager()
+	addWithMappings := func(mappings ...*core.ContractListenerMapping) error {
+		_, err := cm.AddContractListener(context.Background(), &core.ContractListenerInput{
+			ContractListener: core.ContractListener{
+				Topic: "test-topic",
+				Options: &core.ContractListenerOptions{
+					Mappings: mappings,
+				},
+			},
+		})
+		return err
+	}
+
+	assert.Regexp(t, "FF10486.*0", addWithMappings(&core.ContractListenerMapping{}))
+	assert.Regexp(t, "FF10486.*1", addWithMappings(&core.ContractListenerMapping{Field: "a"}, &core.ContractListenerMapping{Field: "a"}))
+	assert.Regexp(t, "FF10486.*0", addWithMappings(&core.ContractListenerMapping{Field: "a", Path: "args..from"}))
+	assert.Regexp(t, "FF00172", addWithMappings(&core.ContractListenerMapping{Field: "a", Type: "date"}))
+}
+
 func TestAddContractListenerNoInterface(t *testing.T) {
 	cm := newTestContractManager()
 	mbi := cm.blockchain.(*blockchainmocks.Plugin)
@@ -1841,6 +1948,18 @@ func TestGetFFIByID(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetFFIMethods(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	cid := fftypes.NewUUID()
+	methods := []*fftypes.FFIMethod{{Name: "sum"}}
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(methods, nil, nil)
+	res, err := cm.GetFFIMethods(context.Background(), cid)
+	assert.NoError(t, err)
+	assert.Equal(t, methods, res)
+	mdb.AssertExpectations(t)
+}
+
 func TestGetFFIByIDWithChildren(t *testing.T) {
 	cm := newTestContractManager()
 	mdb := cm.database.(*databasemocks.Plugin)
@@ -2002,6 +2121,94 @@ func TestDeployContract(t *testing.T) {
 	mom.AssertExpectations(t)
 }
 
+func TestDeployContractWithAPI(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mom := cm.operations.(*operationmocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+	ffiID := fftypes.NewUUID()
+	req := &core.ContractDeployRequest{
+		Key:        "0x2468",
+		Definition: fftypes.JSONAnyPtr("[]"),
+		Contract:   fftypes.JSONAnyPtr("\"0x123456\""),
+		Interface:  &fftypes.FFIReference{Name: "simplestorage", Version: "v1.0.0"},
+		APIName:    "simple",
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "0x2468", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdi.On("GetFFI", mock.Anything, "ns1", "simplestorage", "v1.0.0").Return(&fftypes.FFI{ID: ffiID}, nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey(""), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Input.GetString("apiName") == "simple"
+	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, nil)
+
+	_, err := cm.DeployContract(context.Background(), req, false)
+	assert.NoError(t, err)
+	assert.Equal(t, ffiID, req.Interface.ID)
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	txw.AssertExpectations(t)
+}
+
+func TestDeployContractAPIMissingInterface(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	req := &core.ContractDeployRequest{
+		APIName: "simple",
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.DeployContract(context.Background(), req, false)
+	assert.Regexp(t, "FF10568", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestDeployContractAPIBadName(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	req := &core.ContractDeployRequest{
+		Interface: &fftypes.FFIReference{Name: "simplestorage", Version: "v1.0.0"},
+		APIName:   "!bad",
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.DeployContract(context.Background(), req, false)
+	assert.Regexp(t, "FF00140.*apiName", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestDeployContractInterfaceNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	req := &core.ContractDeployRequest{
+		Interface: &fftypes.FFIReference{Name: "simplestorage", Version: "v1.0.0"},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdi.On("GetFFI", mock.Anything, "ns1", "simplestorage", "v1.0.0").Return(nil, nil)
+
+	_, err := cm.DeployContract(context.Background(), req, false)
+	assert.Regexp(t, "FF10303", err)
+
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestSetAPIDefiner(t *testing.T) {
+	cm := newTestContractManager()
+	ad := &mockAPIDefiner{}
+	cm.SetAPIDefiner(ad)
+	assert.Equal(t, ad, cm.apiDefiner)
+}
+
 func TestDeployContractIdempotentResubmitOperation(t *testing.T) {
 	cm := newTestContractManager()
 	var id = fftypes.NewUUID()
@@ -2136,114 +2343,306 @@ func TestDeployContractSync(t *testing.T) {
 	mom.AssertExpectations(t)
 }
 
-func TestDeployContractResolveInputSigningKeyFail(t *testing.T) {
+func TestUploadContractABI(t *testing.T) {
 	cm := newTestContractManager()
-	mim := cm.identity.(*identitymanagermocks.Manager)
-	mdi := cm.database.(*databasemocks.Plugin)
-	mth := cm.txHelper.(*txcommonmocks.Helper)
-	mom := cm.operations.(*operationmocks.Manager)
-	signingKey := "0x2468"
-	req := &core.ContractDeployRequest{
-		Key:        signingKey,
-		Definition: fftypes.JSONAnyPtr("[]"),
-		Contract:   fftypes.JSONAnyPtr("\"0x123456\""),
-		Input:      []interface{}{"one", "two", "three"},
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	upload := &core.ContractABIUpload{
+		Name: "simplestorage",
+		ABI:  fftypes.JSONAnyPtr("[]"),
 	}
+	abiInfo := &core.ContractABI{ID: "abi1"}
+	mbi.On("UploadContractABI", context.Background(), upload).Return(abiInfo, nil)
 
-	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("", errors.New("pop"))
-	_, err := cm.DeployContract(context.Background(), req, false)
+	result, err := cm.UploadContractABI(context.Background(), upload)
+	assert.NoError(t, err)
+	assert.Equal(t, abiInfo, result)
 
-	assert.Regexp(t, "pop", err)
+	mbi.AssertExpectations(t)
+}
 
-	mth.AssertExpectations(t)
-	mim.AssertExpectations(t)
-	mdi.AssertExpectations(t)
-	mom.AssertExpectations(t)
+func TestUploadContractABIMissing(t *testing.T) {
+	cm := newTestContractManager()
+
+	_, err := cm.UploadContractABI(context.Background(), &core.ContractABIUpload{})
+	assert.Regexp(t, "FF10567", err)
 }
 
-func TestDeployContractSubmitNewTransactionFail(t *testing.T) {
+func TestGetContractABIs(t *testing.T) {
 	cm := newTestContractManager()
-	mim := cm.identity.(*identitymanagermocks.Manager)
-	mdi := cm.database.(*databasemocks.Plugin)
-	mth := cm.txHelper.(*txcommonmocks.Helper)
-	mom := cm.operations.(*operationmocks.Manager)
-	txw := cm.txWriter.(*txwritermocks.Writer)
-	signingKey := "0x2468"
-	req := &core.ContractDeployRequest{
-		Key:            signingKey,
-		Definition:     fftypes.JSONAnyPtr("[]"),
-		Contract:       fftypes.JSONAnyPtr("\"0x123456\""),
-		Input:          []interface{}{"one", "two", "three"},
-		IdempotencyKey: "idem1",
-	}
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	abis := []*core.ContractABI{{ID: "abi1"}}
+	mbi.On("GetContractABIs", context.Background()).Return(abis, nil)
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
-		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
-	})).Return(nil, errors.New("pop"))
-	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	result, err := cm.GetContractABIs(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, abis, result)
 
-	_, err := cm.DeployContract(context.Background(), req, false)
+	mbi.AssertExpectations(t)
+}
 
-	assert.Regexp(t, "pop", err)
+func TestGetContractABI(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	abiInfo := &core.ContractABI{ID: "abi1"}
+	mbi.On("GetContractABI", context.Background(), "abi1").Return(abiInfo, nil)
 
-	mth.AssertExpectations(t)
-	mim.AssertExpectations(t)
-	mdi.AssertExpectations(t)
-	mom.AssertExpectations(t)
+	result, err := cm.GetContractABI(context.Background(), "abi1")
+	assert.NoError(t, err)
+	assert.Equal(t, abiInfo, result)
+
+	mbi.AssertExpectations(t)
 }
 
-func TestInvokeContract(t *testing.T) {
+func TestGetContractABINotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("GetContractABI", context.Background(), "abi1").Return(nil, nil)
+
+	_, err := cm.GetContractABI(context.Background(), "abi1")
+	assert.Regexp(t, "FF10109", err)
+
+	mbi.AssertExpectations(t)
+}
+
+func TestGetContractABIFail(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("GetContractABI", context.Background(), "abi1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.GetContractABI(context.Background(), "abi1")
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+}
+
+func TestDeployContractABI(t *testing.T) {
 	cm := newTestContractManager()
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	mdi := cm.database.(*databasemocks.Plugin)
-	mth := cm.txHelper.(*txcommonmocks.Helper)
 	mom := cm.operations.(*operationmocks.Manager)
-	mbi := cm.blockchain.(*blockchainmocks.Plugin)
 	txw := cm.txWriter.(*txwritermocks.Writer)
-
-	req := &core.ContractCallRequest{
-		Type:      core.CallTypeInvoke,
-		Interface: fftypes.NewUUID(),
-		Location:  fftypes.JSONAnyPtr(""),
-		Method: &fftypes.FFIMethod{
-			Name:    "doStuff",
-			ID:      fftypes.NewUUID(),
-			Params:  fftypes.FFIParams{},
-			Returns: fftypes.FFIParams{},
-		},
+	req := &core.ContractABIDeployRequest{
+		ABI:            "abi1",
+		Key:            "0x2468",
+		Input:          map[string]interface{}{"x": "42"},
 		IdempotencyKey: "idem1",
 	}
 
-	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
-		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeployABI && op.Input.GetString("abi") == "abi1"
 	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
-	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mim.On("ResolveInputSigningKey", mock.Anything, "0x2468", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
 	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
-		data := op.Data.(txcommon.BlockchainInvokeData)
-		return op.Type == core.OpTypeBlockchainInvoke && data.Request == req
+		data := op.Data.(blockchainContractDeployABIData)
+		return op.Type == core.OpTypeBlockchainContractDeployABI && data.Request == req
 	}), true).Return(nil, nil)
-	opaqueData := "anything"
-	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
-	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
-
-	_, err := cm.InvokeContract(context.Background(), req, false)
 
+	_, err := cm.DeployContractABI(context.Background(), req, false)
 	assert.NoError(t, err)
+	assert.Equal(t, "key-resolved", req.Key)
 
-	mth.AssertExpectations(t)
 	mim.AssertExpectations(t)
-	mdi.AssertExpectations(t)
 	mom.AssertExpectations(t)
-	mbi.AssertExpectations(t)
+	txw.AssertExpectations(t)
 }
 
-func TestInvokeContractViaFFI(t *testing.T) {
+func TestDeployContractABISync(t *testing.T) {
 	cm := newTestContractManager()
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	mdi := cm.database.(*databasemocks.Plugin)
-	mth := cm.txHelper.(*txcommonmocks.Helper)
 	mom := cm.operations.(*operationmocks.Manager)
-	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	sam := cm.syncasync.(*syncasyncmocks.Bridge)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+	req := &core.ContractABIDeployRequest{
+		ABI: "abi1",
+		Key: "0x2468",
+	}
+
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mim.On("ResolveInputSigningKey", mock.Anything, "0x2468", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, nil)
+	sam.On("WaitForDeployOperation", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			send := args[2].(syncasync.SendFunction)
+			send(context.Background())
+		}).
+		Return(&core.Operation{Status: core.OpStatusSucceeded}, nil)
+
+	op, err := cm.DeployContractABI(context.Background(), req, true)
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpStatusSucceeded, op.(*core.Operation).Status)
+
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	sam.AssertExpectations(t)
+}
+
+func TestDeployContractABIIdempotentResubmit(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mom := cm.operations.(*operationmocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+	req := &core.ContractABIDeployRequest{
+		ABI:            "abi1",
+		IdempotencyKey: "idem1",
+	}
+	existingOp := &core.Operation{ID: fftypes.NewUUID()}
+	txid := fftypes.NewUUID()
+
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.Anything).
+		Return(nil, &sqlcommon.IdempotencyError{ExistingTXID: txid, OriginalError: i18n.NewError(context.Background(), coremsgs.MsgIdempotencyKeyDuplicateTransaction, "idem1", txid)})
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mom.On("ResubmitOperations", mock.Anything, txid).Return(1, []*core.Operation{existingOp}, nil)
+
+	op, err := cm.DeployContractABI(context.Background(), req, false)
+	assert.NoError(t, err)
+	assert.Equal(t, existingOp, op)
+
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestDeployContractABIResolveInputSigningKeyFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	req := &core.ContractABIDeployRequest{
+		ABI: "abi1",
+		Key: "0x2468",
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "0x2468", identity.KeyNormalizationBlockchainPlugin).Return("", errors.New("pop"))
+
+	_, err := cm.DeployContractABI(context.Background(), req, false)
+	assert.Regexp(t, "pop", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestDeployContractABIWriteTransactionFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+	req := &core.ContractABIDeployRequest{
+		ABI: "abi1",
+		Key: "0x2468",
+	}
+
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey(""), mock.Anything).Return(nil, errors.New("pop"))
+	mim.On("ResolveInputSigningKey", mock.Anything, "0x2468", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.DeployContractABI(context.Background(), req, false)
+	assert.Regexp(t, "pop", err)
+
+	mim.AssertExpectations(t)
+	txw.AssertExpectations(t)
+}
+
+func TestDeployContractResolveInputSigningKeyFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	signingKey := "0x2468"
+	req := &core.ContractDeployRequest{
+		Key:        signingKey,
+		Definition: fftypes.JSONAnyPtr("[]"),
+		Contract:   fftypes.JSONAnyPtr("\"0x123456\""),
+		Input:      []interface{}{"one", "two", "three"},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("", errors.New("pop"))
+	_, err := cm.DeployContract(context.Background(), req, false)
+
+	assert.Regexp(t, "pop", err)
+
+	mth.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestDeployContractSubmitNewTransactionFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+	signingKey := "0x2468"
+	req := &core.ContractDeployRequest{
+		Key:            signingKey,
+		Definition:     fftypes.JSONAnyPtr("[]"),
+		Contract:       fftypes.JSONAnyPtr("\"0x123456\""),
+		Input:          []interface{}{"one", "two", "three"},
+		IdempotencyKey: "idem1",
+	}
+
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractDeploy, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainContractDeploy && op.Plugin == "mockblockchain"
+	})).Return(nil, errors.New("pop"))
+	mim.On("ResolveInputSigningKey", mock.Anything, signingKey, identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.DeployContract(context.Background(), req, false)
+
+	assert.Regexp(t, "pop", err)
+
+	mth.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+}
+
+func TestInvokeContract(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			Name:    "doStuff",
+			ID:      fftypes.NewUUID(),
+			Params:  fftypes.FFIParams{},
+			Returns: fftypes.FFIParams{},
+		},
+		IdempotencyKey: "idem1",
+	}
+
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
+	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(txcommon.BlockchainInvokeData)
+		return op.Type == core.OpTypeBlockchainInvoke && data.Request == req
+	}), true).Return(nil, nil)
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
+
+	_, err := cm.InvokeContract(context.Background(), req, false)
+
+	assert.NoError(t, err)
+
+	mth.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractViaFFI(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
 	txw := cm.txWriter.(*txwritermocks.Writer)
 
 	req := &core.ContractCallRequest{
@@ -2390,10 +2789,12 @@ func TestInvokeContractWithBroadcastConfirm(t *testing.T) {
 	mbm.On("NewBroadcast", req.Message).Return(sender, nil)
 	sender.On("Prepare", mock.Anything).Return(nil)
 	sender.On("SendAndWait", mock.Anything).Return(nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", mock.Anything).Return(&core.Operation{Status: core.OpStatusSucceeded}, nil)
 
-	_, err := cm.InvokeContract(context.Background(), req, true)
+	res, err := cm.InvokeContract(context.Background(), req, true)
 
 	assert.NoError(t, err)
+	assert.Equal(t, core.OpStatusSucceeded, res.(*core.Operation).Status)
 
 	mth.AssertExpectations(t)
 	mim.AssertExpectations(t)
@@ -2530,10 +2931,12 @@ func TestInvokeContractWithBroadcastBadMethod(t *testing.T) {
 	mbm.AssertExpectations(t)
 }
 
-func TestInvokeContractWithPrivateMessageBadMethod(t *testing.T) {
+func TestInvokeContractWithBroadcastConfirmFail(t *testing.T) {
 	cm := newTestContractManager()
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	mpm := cm.messaging.(*privatemessagingmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbm := cm.broadcast.(*broadcastmocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
 	sender := &syncasyncmocks.Sender{}
 
 	req := &core.ContractCallRequest{
@@ -2541,88 +2944,124 @@ func TestInvokeContractWithPrivateMessageBadMethod(t *testing.T) {
 		Interface: fftypes.NewUUID(),
 		Location:  fftypes.JSONAnyPtr(""),
 		Method: &fftypes.FFIMethod{
-			Name:    "doStuff",
-			ID:      fftypes.NewUUID(),
-			Params:  fftypes.FFIParams{},
+			Name: "doStuff",
+			ID:   fftypes.NewUUID(),
+			Params: fftypes.FFIParams{
+				{
+					Name:   "data",
+					Schema: fftypes.JSONAnyPtr(`{"type":"string"}`),
+				},
+			},
 			Returns: fftypes.FFIParams{},
 		},
-		IdempotencyKey: "idem1",
 		Message: &core.MessageInOut{
-			Message: core.Message{
-				Header: core.MessageHeader{
-					Type: core.MessageTypePrivate,
-				},
-			},
 			InlineData: core.InlineData{
 				&core.DataRefOrValue{Value: fftypes.JSONAnyPtr("\"test-message\"")},
 			},
 		},
 	}
 
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
-	mpm.On("NewMessage", req.Message).Return(sender, nil)
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return("anything", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "anything", req.Input, true).Return(nil)
+	mbm.On("NewBroadcast", req.Message).Return(sender, nil)
+	sender.On("Prepare", mock.Anything).Return(nil)
+	sender.On("SendAndWait", mock.Anything).Return(fmt.Errorf("pop"))
 
-	_, err := cm.InvokeContract(context.Background(), req, false)
+	_, err := cm.InvokeContract(context.Background(), req, true)
 
-	assert.Regexp(t, "FF10443", err)
+	assert.Regexp(t, "pop", err)
 
 	mim.AssertExpectations(t)
-	mpm.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+	mbm.AssertExpectations(t)
+	sender.AssertExpectations(t)
 }
 
-func TestInvokeContractWithBroadcastUnsupported(t *testing.T) {
+func TestInvokeContractWithBroadcastPinParam(t *testing.T) {
 	cm := newTestContractManager()
 	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbm := cm.broadcast.(*broadcastmocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+	sender := &syncasyncmocks.Sender{}
 
 	req := &core.ContractCallRequest{
 		Type:      core.CallTypeInvoke,
 		Interface: fftypes.NewUUID(),
 		Location:  fftypes.JSONAnyPtr(""),
 		Method: &fftypes.FFIMethod{
-			Name:    "doStuff",
-			ID:      fftypes.NewUUID(),
-			Params:  fftypes.FFIParams{},
+			Name: "doStuff",
+			ID:   fftypes.NewUUID(),
+			Params: fftypes.FFIParams{
+				{
+					Name:   "data",
+					Schema: fftypes.JSONAnyPtr(`{"type":"string"}`),
+				},
+				{
+					Name:   "amount",
+					Schema: fftypes.JSONAnyPtr(`{"type":"integer"}`),
+				},
+			},
 			Returns: fftypes.FFIParams{},
+			Details: fftypes.JSONObject{
+				"pinParam": "data",
+			},
 		},
-		IdempotencyKey: "idem1",
 		Message: &core.MessageInOut{
 			InlineData: core.InlineData{
 				&core.DataRefOrValue{Value: fftypes.JSONAnyPtr("\"test-message\"")},
 			},
 		},
+		Input: map[string]interface{}{
+			"amount": 10,
+		},
 	}
 
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvokePin, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return("anything", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "anything", req.Input, true).Return(nil)
+	mbm.On("NewBroadcast", req.Message).Return(sender, nil)
+	sender.On("Prepare", mock.Anything).Return(nil)
+	sender.On("Send", mock.Anything).Return(nil)
 
-	cm.broadcast = nil
 	_, err := cm.InvokeContract(context.Background(), req, false)
 
-	assert.Regexp(t, "FF10415", err)
+	assert.NoError(t, err)
 
 	mim.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+	mbm.AssertExpectations(t)
+	sender.AssertExpectations(t)
 }
 
-func TestInvokeContractWithPrivateMessageUnsupported(t *testing.T) {
+func TestInvokeContractWithBroadcastBadPinParam(t *testing.T) {
 	cm := newTestContractManager()
 	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbm := cm.broadcast.(*broadcastmocks.Manager)
+	sender := &syncasyncmocks.Sender{}
 
 	req := &core.ContractCallRequest{
 		Type:      core.CallTypeInvoke,
 		Interface: fftypes.NewUUID(),
 		Location:  fftypes.JSONAnyPtr(""),
 		Method: &fftypes.FFIMethod{
-			Name:    "doStuff",
-			ID:      fftypes.NewUUID(),
-			Params:  fftypes.FFIParams{},
+			Name: "doStuff",
+			ID:   fftypes.NewUUID(),
+			Params: fftypes.FFIParams{
+				{
+					Name:   "data",
+					Schema: fftypes.JSONAnyPtr(`{"type":"string"}`),
+				},
+			},
 			Returns: fftypes.FFIParams{},
+			Details: fftypes.JSONObject{
+				"pinParam": "missing",
+			},
 		},
-		IdempotencyKey: "idem1",
 		Message: &core.MessageInOut{
-			Message: core.Message{
-				Header: core.MessageHeader{
-					Type: core.MessageTypePrivate,
-				},
-			},
 			InlineData: core.InlineData{
 				&core.DataRefOrValue{Value: fftypes.JSONAnyPtr("\"test-message\"")},
 			},
@@ -2630,24 +3069,134 @@ func TestInvokeContractWithPrivateMessageUnsupported(t *testing.T) {
 	}
 
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbm.On("NewBroadcast", req.Message).Return(sender, nil)
 
-	cm.messaging = nil
 	_, err := cm.InvokeContract(context.Background(), req, false)
 
-	assert.Regexp(t, "FF10415", err)
+	assert.Regexp(t, "FF10492", err)
 
 	mim.AssertExpectations(t)
+	mbm.AssertExpectations(t)
 }
 
-func TestInvokeContractIdempotentResubmitOperation(t *testing.T) {
+func TestInvokeContractWithPrivateMessageBadMethod(t *testing.T) {
 	cm := newTestContractManager()
-	var id = fftypes.NewUUID()
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	mth := cm.txHelper.(*txcommonmocks.Helper)
-	mom := cm.operations.(*operationmocks.Manager)
-	mbm := cm.blockchain.(*blockchainmocks.Plugin)
-	mbrm := cm.broadcast.(*broadcastmocks.Manager)
-	txw := cm.txWriter.(*txwritermocks.Writer)
+	mpm := cm.messaging.(*privatemessagingmocks.Manager)
+	sender := &syncasyncmocks.Sender{}
+
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			Name:    "doStuff",
+			ID:      fftypes.NewUUID(),
+			Params:  fftypes.FFIParams{},
+			Returns: fftypes.FFIParams{},
+		},
+		IdempotencyKey: "idem1",
+		Message: &core.MessageInOut{
+			Message: core.Message{
+				Header: core.MessageHeader{
+					Type: core.MessageTypePrivate,
+				},
+			},
+			InlineData: core.InlineData{
+				&core.DataRefOrValue{Value: fftypes.JSONAnyPtr("\"test-message\"")},
+			},
+		},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mpm.On("NewMessage", req.Message).Return(sender, nil)
+
+	_, err := cm.InvokeContract(context.Background(), req, false)
+
+	assert.Regexp(t, "FF10443", err)
+
+	mim.AssertExpectations(t)
+	mpm.AssertExpectations(t)
+}
+
+func TestInvokeContractWithBroadcastUnsupported(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			Name:    "doStuff",
+			ID:      fftypes.NewUUID(),
+			Params:  fftypes.FFIParams{},
+			Returns: fftypes.FFIParams{},
+		},
+		IdempotencyKey: "idem1",
+		Message: &core.MessageInOut{
+			InlineData: core.InlineData{
+				&core.DataRefOrValue{Value: fftypes.JSONAnyPtr("\"test-message\"")},
+			},
+		},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	cm.broadcast = nil
+	_, err := cm.InvokeContract(context.Background(), req, false)
+
+	assert.Regexp(t, "FF10415", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestInvokeContractWithPrivateMessageUnsupported(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			Name:    "doStuff",
+			ID:      fftypes.NewUUID(),
+			Params:  fftypes.FFIParams{},
+			Returns: fftypes.FFIParams{},
+		},
+		IdempotencyKey: "idem1",
+		Message: &core.MessageInOut{
+			Message: core.Message{
+				Header: core.MessageHeader{
+					Type: core.MessageTypePrivate,
+				},
+			},
+			InlineData: core.InlineData{
+				&core.DataRefOrValue{Value: fftypes.JSONAnyPtr("\"test-message\"")},
+			},
+		},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	cm.messaging = nil
+	_, err := cm.InvokeContract(context.Background(), req, false)
+
+	assert.Regexp(t, "FF10415", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestInvokeContractIdempotentResubmitOperation(t *testing.T) {
+	cm := newTestContractManager()
+	var id = fftypes.NewUUID()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbm := cm.blockchain.(*blockchainmocks.Plugin)
+	mbrm := cm.broadcast.(*broadcastmocks.Manager)
+	txw := cm.txWriter.(*txwritermocks.Writer)
 	sender := &syncasyncmocks.Sender{}
 
 	req := &core.ContractCallRequest{
@@ -2903,7 +3452,7 @@ func TestDeployContractBadInput(t *testing.T) {
 		},
 	}
 
-	_, _, err := cm.writeDeployTransaction(context.Background(), req)
+	_, _, err := cm.writeDeployTransaction(context.Background(), core.OpTypeBlockchainContractDeploy, req, req.IdempotencyKey)
 
 	assert.Regexp(t, "json", err)
 }
@@ -3057,6 +3606,56 @@ func TestInvokeContractMethodBadInput(t *testing.T) {
 	assert.Regexp(t, "FF10304", err)
 }
 
+func TestInvokeContractMethodInputFieldErrors(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			Name: "create",
+			Params: fftypes.FFIParams{
+				{
+					Name:   "id",
+					Schema: fftypes.JSONAnyPtr(`{"type": "string"}`),
+				},
+				{
+					Name: "asset",
+					Schema: fftypes.JSONAnyPtr(`{
+						"type": "object",
+						"properties": {
+							"size": {"type": "integer"},
+							"color": {"type": "string"}
+						},
+						"required": ["size", "color"]
+					}`),
+				},
+				{
+					Name:   "owner",
+					Schema: fftypes.JSONAnyPtr(`{"type": "string"}`),
+				},
+			},
+		},
+		Input: map[string]interface{}{
+			"id": "asset1",
+			"asset": map[string]interface{}{
+				"size":  "large",
+				"color": 12,
+			},
+		},
+	}
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.InvokeContract(context.Background(), req, false)
+	assert.Regexp(t, "FF10485.*'create'", err)
+	assert.Regexp(t, "FF10331.*'asset'.*asset/color: expected string, but got number", err)
+	assert.Regexp(t, "asset/size: expected integer, but got string", err)
+	assert.Regexp(t, "FF10304.*'owner'", err)
+	assert.NotRegexp(t, "'id'", err)
+}
+
 func TestQueryContract(t *testing.T) {
 	cm := newTestContractManager()
 	mbi := cm.blockchain.(*blockchainmocks.Plugin)
@@ -3414,17 +4013,37 @@ func TestDeleteContractListener(t *testing.T) {
 	mdi := cm.database.(*databasemocks.Plugin)
 
 	sub := &core.ContractListener{
-		ID: fftypes.NewUUID(),
+		ID:        fftypes.NewUUID(),
+		BackendID: "sb-1",
 	}
 
 	mdi.On("GetContractListener", context.Background(), "ns1", "sub1").Return(sub, nil)
 	mbi.On("DeleteContractListener", context.Background(), sub, true).Return(nil)
+	mdi.On("DeleteBlockchainCheckpoints", context.Background(), "ns1", "sb-1").Return(nil)
 	mdi.On("DeleteContractListenerByID", context.Background(), "ns1", sub.ID).Return(nil)
 
 	err := cm.DeleteContractListenerByNameOrID(context.Background(), "sub1")
 	assert.NoError(t, err)
 }
 
+func TestDeleteContractListenerCheckpointsFail(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mdi := cm.database.(*databasemocks.Plugin)
+
+	sub := &core.ContractListener{
+		ID:        fftypes.NewUUID(),
+		BackendID: "sb-1",
+	}
+
+	mdi.On("GetContractListener", context.Background(), "ns1", "sub1").Return(sub, nil)
+	mbi.On("DeleteContractListener", context.Background(), sub, true).Return(nil)
+	mdi.On("DeleteBlockchainCheckpoints", context.Background(), "ns1", "sb-1").Return(fmt.Errorf("pop"))
+
+	err := cm.DeleteContractListenerByNameOrID(context.Background(), "sub1")
+	assert.EqualError(t, err, "pop")
+}
+
 func TestDeleteContractListenerBlockchainFail(t *testing.T) {
 	cm := newTestContractManager()
 	mbi := cm.blockchain.(*blockchainmocks.Plugin)
@@ -3452,119 +4071,681 @@ func TestDeleteContractListenerNotFound(t *testing.T) {
 	assert.Regexp(t, "FF10109", err)
 }
 
-func TestInvokeContractAPI(t *testing.T) {
+func testBatchItem(name string) *core.ContractCallRequest {
+	return &core.ContractCallRequest{
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(`{"address":"0x1111"}`),
+		Method: &fftypes.FFIMethod{
+			Name:    name,
+			ID:      fftypes.NewUUID(),
+			Params:  fftypes.FFIParams{},
+			Returns: fftypes.FFIParams{},
+		},
+	}
+}
+
+func TestInvokeContractBatchOperations(t *testing.T) {
 	cm := newTestContractManager()
-	mdb := cm.database.(*databasemocks.Plugin)
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	mdi := cm.database.(*databasemocks.Plugin)
-	mth := cm.txHelper.(*txcommonmocks.Helper)
 	mom := cm.operations.(*operationmocks.Manager)
 	mbi := cm.blockchain.(*blockchainmocks.Plugin)
 	txw := cm.txWriter.(*txwritermocks.Writer)
 
-	req := &core.ContractCallRequest{
-		Type:      core.CallTypeInvoke,
-		Interface: fftypes.NewUUID(),
-		Location:  fftypes.JSONAnyPtr(""),
-		Method: &fftypes.FFIMethod{
-			ID:   fftypes.NewUUID(),
-			Name: "peel",
-		},
+	item1 := testBatchItem("doStuff")
+	item2 := testBatchItem("doMore")
+	item2.Key = "key2"
+	req := &core.ContractCallBatchRequest{
+		Items:          []*core.ContractCallRequest{item1, item2},
 		IdempotencyKey: "idem1",
 	}
-
-	api := &core.ContractAPI{
-		Interface: &fftypes.FFIReference{
-			ID: fftypes.NewUUID(),
-		},
-		Location: fftypes.JSONAnyPtr(""),
-	}
+	txID := fftypes.NewUUID()
 
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
-	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	mim.On("ResolveInputSigningKey", mock.Anything, "key2", identity.KeyNormalizationBlockchainPlugin).Return("key2-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
 	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
-		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
-	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+		return op.Type == core.OpTypeBlockchainInvoke && op.Input.GetString("key") == "key-resolved"
+	}), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainInvoke && op.Input.GetString("key") == "key2-resolved"
+	})).Return(&core.Transaction{ID: txID}, nil)
 	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
-		data := op.Data.(txcommon.BlockchainInvokeData)
-		return op.Type == core.OpTypeBlockchainInvoke && data.Request == req
+		return op.Data.(txcommon.BlockchainInvokeData).Request == item1
+	}), true).Return(nil, nil)
+	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		return op.Data.(txcommon.BlockchainInvokeData).Request == item2
 	}), true).Return(nil, nil)
-	opaqueData := "anything"
-	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
-	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
-
-	_, err := cm.InvokeContractAPI(context.Background(), "banana", "peel", req, false)
 
+	res, err := cm.InvokeContractBatch(context.Background(), req)
 	assert.NoError(t, err)
+	assert.Equal(t, txID, res.TX)
+	assert.Len(t, res.Operations, 2)
+	assert.Equal(t, core.CallTypeInvoke, item1.Type)
 
-	mdb.AssertExpectations(t)
 	mim.AssertExpectations(t)
-	mdi.AssertExpectations(t)
-	mth.AssertExpectations(t)
 	mom.AssertExpectations(t)
 	mbi.AssertExpectations(t)
+	txw.AssertExpectations(t)
 }
 
-func TestInvokeContractAPIFailContractLookup(t *testing.T) {
+func TestInvokeContractBatchStopsOnFailure(t *testing.T) {
 	cm := newTestContractManager()
-	mdb := cm.database.(*databasemocks.Plugin)
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	req := &core.ContractCallRequest{
-		Type:      core.CallTypeInvoke,
-		Interface: fftypes.NewUUID(),
-		Location:  fftypes.JSONAnyPtr(""),
-		Method: &fftypes.FFIMethod{
-			ID: fftypes.NewUUID(),
-		},
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{testBatchItem("doStuff"), testBatchItem("doMore")},
 	}
 
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
-	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(nil, fmt.Errorf("pop"))
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.Anything, mock.Anything).
+		Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, fmt.Errorf("pop")).Once()
 
-	_, err := cm.InvokeContractAPI(context.Background(), "banana", "peel", req, false)
+	res, err := cm.InvokeContractBatch(context.Background(), req)
+	assert.EqualError(t, err, "pop")
+	assert.Len(t, res.Operations, 2)
 
-	assert.Regexp(t, "pop", err)
+	mom.AssertExpectations(t)
 }
 
-func TestInvokeContractAPIContractNotFound(t *testing.T) {
+func TestInvokeContractBatchMulticall(t *testing.T) {
 	cm := newTestContractManager()
-	mdb := cm.database.(*databasemocks.Plugin)
 	mim := cm.identity.(*identitymanagermocks.Manager)
-	req := &core.ContractCallRequest{
-		Type:      core.CallTypeInvoke,
-		Interface: fftypes.NewUUID(),
-		Location:  fftypes.JSONAnyPtr(""),
-		Method: &fftypes.FFIMethod{
-			ID: fftypes.NewUUID(),
-		},
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	item := testBatchItem("doStuff")
+	item.Key = "ignored"
+	aggregator := fftypes.JSONAnyPtr(`{"address":"0xcafe"}`)
+	req := &core.ContractCallBatchRequest{
+		Items:     []*core.ContractCallRequest{item},
+		Multicall: aggregator,
 	}
 
+	mbi.On("Capabilities").Return(&blockchain.Capabilities{Multicall: true})
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, aggregator).Return(aggregator, nil)
 	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
-	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(nil, nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainInvokeBatch
+	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		return op.Data.(blockchainInvokeBatchData).Request == req
+	}), false).Return(nil, nil)
 
-	_, err := cm.InvokeContractAPI(context.Background(), "banana", "peel", req, false)
+	res, err := cm.InvokeContractBatch(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Len(t, res.Operations, 1)
+	assert.Equal(t, "key-resolved", item.Key)
 
-	assert.Regexp(t, "FF10109", err)
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+	txw.AssertExpectations(t)
 }
 
-func TestGetContractAPI(t *testing.T) {
+func TestInvokeContractBatchIdempotentResubmit(t *testing.T) {
 	cm := newTestContractManager()
-	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
 
-	api := &core.ContractAPI{
-		Namespace: "ns1",
-		Name:      "banana",
+	req := &core.ContractCallBatchRequest{
+		Items:          []*core.ContractCallRequest{testBatchItem("doStuff")},
+		IdempotencyKey: "idem1",
 	}
-	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	id := fftypes.NewUUID()
 
-	result, err := cm.GetContractAPI(context.Background(), "http://localhost/api/v1/namespaces/ns1", "banana")
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything).Return(nil, &sqlcommon.IdempotencyError{
+		ExistingTXID:  id,
+		OriginalError: i18n.NewError(context.Background(), coremsgs.MsgIdempotencyKeyDuplicateTransaction, "idem1", id)})
+	mom.On("ResubmitOperations", context.Background(), id).Return(1, []*core.Operation{{}}, nil)
 
+	res, err := cm.InvokeContractBatch(context.Background(), req)
 	assert.NoError(t, err)
-	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana/api/swagger.json", result.URLs.OpenAPI)
+	assert.Equal(t, id, res.TX)
+	assert.Len(t, res.Operations, 1)
+
+	mom.AssertExpectations(t)
+	txw.AssertExpectations(t)
+}
+
+func TestInvokeContractBatchIdempotentResubmitFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallBatchRequest{
+		Items:          []*core.ContractCallRequest{testBatchItem("doStuff")},
+		IdempotencyKey: "idem1",
+	}
+	id := fftypes.NewUUID()
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.Anything).Return(nil, &sqlcommon.IdempotencyError{
+		ExistingTXID:  id,
+		OriginalError: i18n.NewError(context.Background(), coremsgs.MsgIdempotencyKeyDuplicateTransaction, "idem1", id)})
+	mom.On("ResubmitOperations", context.Background(), id).Return(0, nil, fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractBatch(context.Background(), req)
+	assert.EqualError(t, err, "pop")
+
+	mom.AssertExpectations(t)
+	txw.AssertExpectations(t)
+}
+
+func TestInvokeContractBatchEmpty(t *testing.T) {
+	cm := newTestContractManager()
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{})
+	assert.Regexp(t, "FF10488", err)
+}
+
+func TestInvokeContractBatchMulticallNotSupported(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("Capabilities").Return(&blockchain.Capabilities{})
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items:     []*core.ContractCallRequest{testBatchItem("doStuff")},
+		Multicall: fftypes.JSONAnyPtr(`{"address":"0xcafe"}`),
+	})
+	assert.Regexp(t, "FF10429", err)
+}
+
+func TestInvokeContractBatchMulticallBadLocation(t *testing.T) {
+	cm := newTestContractManager()
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("Capabilities").Return(&blockchain.Capabilities{Multicall: true})
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items:     []*core.ContractCallRequest{testBatchItem("doStuff")},
+		Multicall: fftypes.JSONAnyPtr(`{"address":"bad"}`),
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestInvokeContractBatchKeyFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("", fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{testBatchItem("doStuff")},
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestInvokeContractBatchItemKeyFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mim.On("ResolveInputSigningKey", mock.Anything, "bad", identity.KeyNormalizationBlockchainPlugin).Return("", fmt.Errorf("pop"))
+
+	item := testBatchItem("doStuff")
+	item.Key = "bad"
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{item},
+	})
+	assert.Regexp(t, "FF10489.*0.*pop", err)
+}
+
+func TestInvokeContractBatchItemMessage(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+
+	item := testBatchItem("doStuff")
+	item.Message = &core.MessageInOut{}
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{testBatchItem("doStuff"), item},
+	})
+	assert.Regexp(t, "FF10489.*1.*FF10490", err)
+}
+
+func TestInvokeContractBatchItemResolveFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{{}},
+	})
+	assert.Regexp(t, "FF10489.*FF10313", err)
+}
+
+func TestInvokeContractBatchItemValidateFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{testBatchItem("doStuff")},
+	})
+	assert.Regexp(t, "FF10489.*pop", err)
+}
+
+func TestInvokeContractBatchWriteFail(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{testBatchItem("doStuff")},
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestInvokeContractBatchBadInput(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	item := testBatchItem("doStuff")
+	item.Input = map[string]interface{}{
+		"badness": map[bool]bool{false: true}, // cannot be serialized to JSON
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{item},
+	})
+	assert.Regexp(t, "badness", err)
+}
+
+func TestInvokeContractBatchMulticallBadInput(t *testing.T) {
+	cm := newTestContractManager()
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+
+	item := testBatchItem("doStuff")
+	item.Input = map[string]interface{}{
+		"badness": map[bool]bool{false: true}, // cannot be serialized to JSON
+	}
+	aggregator := fftypes.JSONAnyPtr(`{"address":"0xcafe"}`)
+
+	mbi.On("Capabilities").Return(&blockchain.Capabilities{Multicall: true})
+	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, aggregator).Return(aggregator, nil)
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+
+	_, err := cm.InvokeContractBatch(context.Background(), &core.ContractCallBatchRequest{
+		Items:     []*core.ContractCallRequest{item},
+		Multicall: aggregator,
+	})
+	assert.Regexp(t, "badness", err)
+}
+
+func TestInvokeContractAPI(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mth := cm.txHelper.(*txcommonmocks.Helper)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			ID:   fftypes.NewUUID(),
+			Name: "peel",
+		},
+		IdempotencyKey: "idem1",
+	}
+
+	api := &core.ContractAPI{
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+		Location: fftypes.JSONAnyPtr(""),
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey("idem1"), mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Namespace == "ns1" && op.Type == core.OpTypeBlockchainInvoke && op.Plugin == "mockblockchain"
+	})).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data := op.Data.(txcommon.BlockchainInvokeData)
+		return op.Type == core.OpTypeBlockchainInvoke && data.Request == req
+	}), true).Return(nil, nil)
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
+
+	_, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, false)
+
+	assert.NoError(t, err)
+
+	mdb.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+	mth.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIConfirm(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	msa := cm.syncasync.(*syncasyncmocks.Bridge)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallRequest{
+		Type: core.CallTypeInvoke,
+		Method: &fftypes.FFIMethod{
+			ID:   fftypes.NewUUID(),
+			Name: "peel",
+		},
+	}
+	api := &core.ContractAPI{
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+		Location: fftypes.JSONAnyPtr(""),
+	}
+	events := []*fftypes.FFIEvent{{FFIEventDefinition: fftypes.FFIEventDefinition{Name: "Peeled"}}}
+	op := &core.Operation{
+		ID:     fftypes.NewUUID(),
+		Status: core.OpStatusSucceeded,
+		Output: fftypes.JSONObject{"extraInfo": fftypes.JSONObject{}},
+	}
+	decodedEvents := []*core.ContractInvokeEvent{{Name: "Peeled", Output: fftypes.JSONObject{"skins": "1"}}}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(events, nil, nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, nil)
+	msa.On("WaitForInvokeOperation", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			send := args[2].(syncasync.SendFunction)
+			send(context.Background())
+		}).
+		Return(op, nil)
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
+	mbi.On("GenerateEventSignature", mock.Anything, mock.Anything).Return("Peeled()", nil)
+	mbi.On("DecodeInvokeReceipt", context.Background(), opaqueData, events, op.Output).Return(fftypes.JSONObject{"peeled": true}, decodedEvents, nil)
+
+	res, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, true)
+	assert.NoError(t, err)
+	result := res.(*core.ContractInvokeResult)
+	assert.Equal(t, op, result.Operation)
+	assert.Equal(t, fftypes.JSONObject{"peeled": true}, result.ReturnValues)
+	assert.Equal(t, decodedEvents, result.Events)
+
+	mdb.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	msa.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIConfirmDecodeFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mom := cm.operations.(*operationmocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	msa := cm.syncasync.(*syncasyncmocks.Bridge)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallRequest{
+		Type: core.CallTypeInvoke,
+		Method: &fftypes.FFIMethod{
+			ID:   fftypes.NewUUID(),
+			Name: "peel",
+		},
+	}
+	api := &core.ContractAPI{
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	op := &core.Operation{
+		ID:     fftypes.NewUUID(),
+		Status: core.OpStatusSucceeded,
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	msa.On("WaitForInvokeOperation", mock.Anything, mock.Anything, mock.Anything).Return(op, nil)
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
+
+	res, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, true)
+	assert.NoError(t, err)
+	result := res.(*core.ContractInvokeResult)
+	assert.Equal(t, op, result.Operation)
+	assert.Nil(t, result.ReturnValues)
+
+	mdb.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mom.AssertExpectations(t)
+	msa.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIConfirmFailed(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	msa := cm.syncasync.(*syncasyncmocks.Bridge)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallRequest{
+		Type: core.CallTypeInvoke,
+		Method: &fftypes.FFIMethod{
+			ID:   fftypes.NewUUID(),
+			Name: "peel",
+		},
+	}
+	api := &core.ContractAPI{
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	msa.On("WaitForInvokeOperation", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
+
+	_, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, true)
+	assert.Regexp(t, "pop", err)
+
+	mdb.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	msa.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIConfirmNotSucceeded(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	msa := cm.syncasync.(*syncasyncmocks.Bridge)
+	txw := cm.txWriter.(*txwritermocks.Writer)
+
+	req := &core.ContractCallRequest{
+		Type: core.CallTypeInvoke,
+		Method: &fftypes.FFIMethod{
+			ID:   fftypes.NewUUID(),
+			Name: "peel",
+		},
+	}
+	api := &core.ContractAPI{
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	op := &core.Operation{
+		ID:     fftypes.NewUUID(),
+		Status: core.OpStatusPending,
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	txw.On("WriteTransactionAndOps", mock.Anything, core.TransactionTypeContractInvoke, core.IdempotencyKey(""), mock.Anything).Return(&core.Transaction{ID: fftypes.NewUUID()}, nil)
+	msa.On("WaitForInvokeOperation", mock.Anything, mock.Anything, mock.Anything).Return(op, nil)
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), req.Method, req.Errors).Return(opaqueData, nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, opaqueData, req.Input, false).Return(nil)
+
+	// The receipt is only decoded for a succeeded operation
+	res, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, true)
+	assert.NoError(t, err)
+	assert.Equal(t, op, res)
+
+	mdb.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	msa.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIFailContractLookup(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, false)
+
+	assert.Regexp(t, "pop", err)
+}
+
+func TestInvokeContractAPIContractNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	req := &core.ContractCallRequest{
+		Type:      core.CallTypeInvoke,
+		Interface: fftypes.NewUUID(),
+		Location:  fftypes.JSONAnyPtr(""),
+		Method: &fftypes.FFIMethod{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mim.On("ResolveInputSigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(nil, nil)
+
+	_, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, false)
+
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestInvokeContractAPIVersionNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	req := &core.ContractCallRequest{
+		Type: core.CallTypeInvoke,
+	}
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "2.0.0").Return(nil, nil)
+
+	_, err := cm.InvokeContractAPI(context.Background(), "banana", "2.0.0", "peel", req, false)
+
+	assert.Regexp(t, "FF10109", err)
+	mdb.AssertExpectations(t)
+}
+
+func TestGetContractAPI(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	api := &core.ContractAPI{
+		Namespace: "ns1",
+		Name:      "banana",
+	}
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+
+	result, err := cm.GetContractAPI(context.Background(), "http://localhost/api/v1/namespaces/ns1", "banana", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana/api/swagger.json", result.URLs.OpenAPI)
 	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana/api", result.URLs.UI)
 	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana", result.URLs.API)
 }
 
+func TestGetContractAPIVersion(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	api := &core.ContractAPI{
+		Namespace: "ns1",
+		Name:      "banana",
+		Version:   "1.0.0",
+	}
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "banana", "1.0.0").Return(api, nil)
+
+	result, err := cm.GetContractAPI(context.Background(), "http://localhost/api/v1/namespaces/ns1", "banana", "1.0.0")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana/api/swagger.json?version=1.0.0", result.URLs.OpenAPI)
+	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana/api?version=1.0.0", result.URLs.UI)
+	assert.Equal(t, "http://localhost/api/v1/namespaces/ns1/apis/banana", result.URLs.API)
+
+	mdb.AssertExpectations(t)
+}
+
 func TestGetContractAPIs(t *testing.T) {
 	cm := newTestContractManager()
 	mdb := cm.database.(*databasemocks.Plugin)
@@ -3617,7 +4798,7 @@ func TestGetContractAPIInterface(t *testing.T) {
 		return ev.Name == "customError1"
 	})).Return("error1Sig")
 
-	result, err := cm.GetContractAPIInterface(context.Background(), "banana")
+	result, err := cm.GetContractAPIInterface(context.Background(), "banana", "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -3632,7 +4813,7 @@ func TestGetContractAPIInterfaceFail(t *testing.T) {
 
 	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(nil, fmt.Errorf("pop"))
 
-	_, err := cm.GetContractAPIInterface(context.Background(), "banana")
+	_, err := cm.GetContractAPIInterface(context.Background(), "banana", "")
 
 	assert.EqualError(t, err, "pop")
 
@@ -3655,7 +4836,7 @@ func TestResolveContractAPI(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 	mdb.On("GetFFIByID", mock.Anything, "ns1", api.Interface.ID).Return(&fftypes.FFI{}, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
@@ -3729,7 +4910,7 @@ func TestResolveContractAPICannotChangeLocation(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(existing, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(existing, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
 	assert.Regexp(t, "FF10316", err)
@@ -3756,7 +4937,7 @@ func TestResolveContractAPIInterfaceName(t *testing.T) {
 	interfaceID := fftypes.NewUUID()
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 	mdb.On("GetFFI", mock.Anything, "ns1", "my-ffi", "1").Return(&fftypes.FFI{ID: interfaceID}, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
@@ -3779,7 +4960,7 @@ func TestResolveContractAPINoInterface(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
 	assert.Regexp(t, "FF10303", err)
@@ -3804,7 +4985,7 @@ func TestResolveContractAPIInterfaceIDFail(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 	mdb.On("GetFFIByID", mock.Anything, "ns1", api.Interface.ID).Return(nil, fmt.Errorf("pop"))
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
@@ -3830,7 +5011,7 @@ func TestResolveContractAPIInterfaceIDNotFound(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 	mdb.On("GetFFIByID", mock.Anything, "ns1", api.Interface.ID).Return(nil, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
@@ -3857,7 +5038,7 @@ func TestResolveContractAPIInterfaceNameFail(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 	mdb.On("GetFFI", mock.Anything, "ns1", "my-ffi", "1").Return(nil, fmt.Errorf("pop"))
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
@@ -3884,7 +5065,7 @@ func TestResolveContractAPIInterfaceNameNotFound(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 	mdb.On("GetFFI", mock.Anything, "ns1", "my-ffi", "1").Return(nil, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
@@ -3910,7 +5091,7 @@ func TestResolveContractAPIInterfaceNoVersion(t *testing.T) {
 	}
 
 	mbi.On("NormalizeContractLocation", context.Background(), blockchain.NormalizeCall, api.Location).Return(api.Location, nil)
-	mdb.On("GetContractAPIByName", mock.Anything, api.Namespace, api.Name).Return(nil, nil)
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, api.Namespace, api.Name, api.Version).Return(nil, nil)
 
 	err := cm.ResolveContractAPI(context.Background(), "http://localhost/api", api)
 	assert.Regexp(t, "FF10303.*my-ffi", err)
@@ -4063,7 +5244,7 @@ func TestDeleteContractAPI(t *testing.T) {
 	mdi.On("GetContractAPIByName", context.Background(), "ns1", "banana").Return(&core.ContractAPI{ID: id}, nil)
 	mdi.On("DeleteContractAPI", context.Background(), "ns1", id).Return(nil)
 
-	err := cm.DeleteContractAPI(context.Background(), "banana")
+	err := cm.DeleteContractAPI(context.Background(), "banana", "")
 	assert.NoError(t, err)
 
 	mdi.AssertExpectations(t)
@@ -4075,7 +5256,7 @@ func TestDeleteContractAPIFailGet(t *testing.T) {
 	mdi := cm.database.(*databasemocks.Plugin)
 	mdi.On("GetContractAPIByName", context.Background(), "ns1", "banana").Return(nil, fmt.Errorf("pop"))
 
-	err := cm.DeleteContractAPI(context.Background(), "banana")
+	err := cm.DeleteContractAPI(context.Background(), "banana", "")
 	assert.EqualError(t, err, "pop")
 
 	mdi.AssertExpectations(t)
@@ -4087,7 +5268,7 @@ func TestDeleteContractAPINotFound(t *testing.T) {
 	mdi := cm.database.(*databasemocks.Plugin)
 	mdi.On("GetContractAPIByName", context.Background(), "ns1", "banana").Return(nil, nil)
 
-	err := cm.DeleteContractAPI(context.Background(), "banana")
+	err := cm.DeleteContractAPI(context.Background(), "banana", "")
 	assert.Regexp(t, "FF10109", err)
 
 	mdi.AssertExpectations(t)
@@ -4099,7 +5280,7 @@ func TestDeleteContractAPIPublished(t *testing.T) {
 	mdi := cm.database.(*databasemocks.Plugin)
 	mdi.On("GetContractAPIByName", context.Background(), "ns1", "banana").Return(&core.ContractAPI{Published: true}, nil)
 
-	err := cm.DeleteContractAPI(context.Background(), "banana")
+	err := cm.DeleteContractAPI(context.Background(), "banana", "")
 	assert.Regexp(t, "FF10449", err)
 
 	mdi.AssertExpectations(t)
@@ -36,6 +36,14 @@ type blockchainContractDeployData struct {
 	Request *core.ContractDeployRequest `json:"request"`
 }
 
+type blockchainContractDeployABIData struct {
+	Request *core.ContractABIDeployRequest `json:"request"`
+}
+
+type blockchainInvokeBatchData struct {
+	Request *core.ContractCallBatchRequest `json:"request"`
+}
+
 func addBlockchainReqInputs(op *core.Operation, req interface{}) (err error) {
 	var reqJSON []byte
 	if reqJSON, err = json.Marshal(req); err == nil {
@@ -55,6 +63,24 @@ func retrieveBlockchainDeployInputs(ctx context.Context, op *core.Operation) (*c
 	return &req, nil
 }
 
+func retrieveBlockchainDeployABIInputs(ctx context.Context, op *core.Operation) (*core.ContractABIDeployRequest, error) {
+	var req core.ContractABIDeployRequest
+	s := op.Input.String()
+	if err := json.Unmarshal([]byte(s), &req); err != nil {
+		return nil, i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, s)
+	}
+	return &req, nil
+}
+
+func retrieveBlockchainInvokeBatchInputs(ctx context.Context, op *core.Operation) (*core.ContractCallBatchRequest, error) {
+	var req core.ContractCallBatchRequest
+	s := op.Input.String()
+	if err := json.Unmarshal([]byte(s), &req); err != nil {
+		return nil, i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, s)
+	}
+	return &req, nil
+}
+
 func (cm *contractManager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
 	switch op.Type {
 	case core.OpTypeBlockchainInvoke:
@@ -104,6 +130,13 @@ func (cm *contractManager) PrepareOperation(ctx context.Context, op *core.Operat
 
 		return txcommon.OpBlockchainInvoke(op, req, batchPin), nil
 
+	case core.OpTypeBlockchainInvokeBatch:
+		req, err := retrieveBlockchainInvokeBatchInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		return opBlockchainInvokeBatch(op, req), nil
+
 	case core.OpTypeBlockchainContractDeploy:
 		req, err := retrieveBlockchainDeployInputs(ctx, op)
 		if err != nil {
@@ -111,6 +144,13 @@ func (cm *contractManager) PrepareOperation(ctx context.Context, op *core.Operat
 		}
 		return opBlockchainContractDeploy(op, req), nil
 
+	case core.OpTypeBlockchainContractDeployABI:
+		req, err := retrieveBlockchainDeployABIInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		return opBlockchainContractDeployABI(op, req), nil
+
 	default:
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
 	}
@@ -136,10 +176,30 @@ func (cm *contractManager) RunOperation(ctx context.Context, op *core.PreparedOp
 		}
 		submissionRejected, err := cm.blockchain.InvokeContract(ctx, op.NamespacedIDString(), req.Key, req.Location, bcParsedMethod, req.Input, req.Options, batchPin)
 		return nil, submissionPhase(ctx, submissionRejected, err), err
+	case blockchainInvokeBatchData:
+		req := data.Request
+		calls := make([]*blockchain.ContractBatchCall, len(req.Items))
+		for i, item := range req.Items {
+			bcParsedMethod, err := cm.validateInvokeContractRequest(ctx, item, false)
+			if err != nil {
+				return nil, core.OpPhaseInitializing, err
+			}
+			calls[i] = &blockchain.ContractBatchCall{
+				Location:     item.Location,
+				ParsedMethod: bcParsedMethod,
+				Input:        item.Input,
+			}
+		}
+		submissionRejected, err := cm.blockchain.InvokeContractBatch(ctx, op.NamespacedIDString(), req.Key, req.Multicall, calls, req.AllowFailure, req.Options)
+		return nil, submissionPhase(ctx, submissionRejected, err), err
 	case blockchainContractDeployData:
 		req := data.Request
 		submissionRejected, err := cm.blockchain.DeployContract(ctx, op.NamespacedIDString(), req.Key, req.Definition, req.Contract, req.Input, req.Options)
 		return nil, submissionPhase(ctx, submissionRejected, err), err
+	case blockchainContractDeployABIData:
+		req := data.Request
+		submissionRejected, err := cm.blockchain.DeployContractABI(ctx, op.NamespacedIDString(), req.Key, req.ABI, req.Input, req.Options)
+		return nil, submissionPhase(ctx, submissionRejected, err), err
 	default:
 		return nil, core.OpPhaseInitializing, i18n.NewError(ctx, coremsgs.MsgOperationDataIncorrect, op.Data)
 	}
@@ -159,7 +219,16 @@ func submissionPhase(ctx context.Context, submissionRejected bool, err error) co
 func (cm *contractManager) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
 	// Special handling for blockchain operations, which writes an event when it succeeds or fails
 	switch op.Type {
-	case core.OpTypeBlockchainInvoke:
+	case core.OpTypeBlockchainInvoke, core.OpTypeBlockchainInvokeBatch:
+		if op.Type == core.OpTypeBlockchainInvokeBatch && update.Status == core.OpStatusSucceeded && update.Output != nil {
+			// Record the result of each call of the multicall in the output of the operation
+			results, err := cm.blockchain.DecodeInvokeBatchReceipt(ctx, update.Output)
+			if err != nil {
+				log.L(ctx).Warnf("Unable to decode batch results of operation %s: %s", op.ID, err)
+			} else if results != nil {
+				update.Output["items"] = results
+			}
+		}
 		if update.Status == core.OpStatusSucceeded {
 			event := core.NewEvent(core.EventTypeBlockchainInvokeOpSucceeded, op.Namespace, op.ID, op.Transaction, "")
 			if err := cm.database.InsertEvent(ctx, event); err != nil {
@@ -172,8 +241,11 @@ func (cm *contractManager) OnOperationUpdate(ctx context.Context, op *core.Opera
 				return err
 			}
 		}
-	case core.OpTypeBlockchainContractDeploy:
+	case core.OpTypeBlockchainContractDeploy, core.OpTypeBlockchainContractDeployABI:
 		if update.Status == core.OpStatusSucceeded {
+			if op.Type == core.OpTypeBlockchainContractDeploy {
+				cm.defineDeployedContractAPI(ctx, op, update.Output)
+			}
 			event := core.NewEvent(core.EventTypeBlockchainContractDeployOpSucceeded, op.Namespace, op.ID, op.Transaction, "")
 			if err := cm.database.InsertEvent(ctx, event); err != nil {
 				return err
@@ -189,6 +261,50 @@ func (cm *contractManager) OnOperationUpdate(ctx context.Context, op *core.Opera
 	return nil
 }
 
+// defineDeployedContractAPI creates the contract API requested alongside a deployment, bound to the location of the
+// new contract. Failures are logged rather than returned, as the deployment itself has succeeded.
+func (cm *contractManager) defineDeployedContractAPI(ctx context.Context, op *core.Operation, output fftypes.JSONObject) {
+	req, err := retrieveBlockchainDeployInputs(ctx, op)
+	if err != nil || req.APIName == "" {
+		return
+	}
+	location := deployedContractLocation(output)
+	if location == nil || cm.apiDefiner == nil {
+		log.L(ctx).Errorf("Unable to create API '%s' for the contract deployed by operation %s, as the contract location is not known", req.APIName, op.ID)
+		return
+	}
+	api := &core.ContractAPI{
+		Name:      req.APIName,
+		Interface: req.Interface,
+		Location:  location,
+	}
+	if err := cm.apiDefiner.DefineContractAPI(ctx, "", api, false); err != nil {
+		log.L(ctx).Errorf("Failed to create API '%s' for the contract deployed by operation %s: %s", req.APIName, op.ID, err)
+	}
+}
+
+// deployedContractLocation extracts the location of a new contract from the receipt of its deployment.
+// Connectors report either a full contractLocation, or only the contractAddress.
+func deployedContractLocation(output fftypes.JSONObject) *fftypes.JSONAny {
+	if location := output.GetObject("contractLocation"); len(location) > 0 {
+		return fftypes.JSONAnyPtr(location.String())
+	}
+	if address := output.GetString("contractAddress"); address != "" {
+		return fftypes.JSONAnyPtr(fftypes.JSONObject{"address": address}.String())
+	}
+	return nil
+}
+
+func opBlockchainInvokeBatch(op *core.Operation, req *core.ContractCallBatchRequest) *core.PreparedOperation {
+	return &core.PreparedOperation{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Plugin:    op.Plugin,
+		Type:      op.Type,
+		Data:      blockchainInvokeBatchData{Request: req},
+	}
+}
+
 func opBlockchainContractDeploy(op *core.Operation, req *core.ContractDeployRequest) *core.PreparedOperation {
 	return &core.PreparedOperation{
 		ID:        op.ID,
@@ -198,3 +314,13 @@ func opBlockchainContractDeploy(op *core.Operation, req *core.ContractDeployRequ
 		Data:      blockchainContractDeployData{Request: req},
 	}
 }
+
+func opBlockchainContractDeployABI(op *core.Operation, req *core.ContractABIDeployRequest) *core.PreparedOperation {
+	return &core.PreparedOperation{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Plugin:    op.Plugin,
+		Type:      op.Type,
+		Data:      blockchainContractDeployABIData{Request: req},
+	}
+}
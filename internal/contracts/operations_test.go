@@ -350,6 +350,51 @@ func TestPrepareAndRunBlockchainContractDeployRejected(t *testing.T) {
 	mbi.AssertExpectations(t)
 }
 
+func TestPrepareAndRunBlockchainContractDeployABI(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		Type:      core.OpTypeBlockchainContractDeployABI,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	signingKey := "0x2468"
+	req := &core.ContractABIDeployRequest{
+		ABI:     "abi1",
+		Key:     signingKey,
+		Input:   map[string]interface{}{"x": "42"},
+		Options: map[string]interface{}{"gas": "1000000"},
+	}
+	err := addBlockchainReqInputs(op, req)
+	assert.NoError(t, err)
+
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("DeployContractABI", context.Background(), "ns1:"+op.ID.String(), signingKey, "abi1", req.Input, req.Options).Return(false, nil)
+
+	po, err := cm.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, req, po.Data.(blockchainContractDeployABIData).Request)
+
+	_, phase, err := cm.RunOperation(context.Background(), po)
+
+	assert.Equal(t, core.OpPhasePending, phase)
+	assert.NoError(t, err)
+
+	mbi.AssertExpectations(t)
+}
+
+func TestPrepareOperationBlockchainDeployABIBadInput(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		Type:  core.OpTypeBlockchainContractDeployABI,
+		Input: fftypes.JSONObject{"input": "bad"},
+	}
+
+	_, err := cm.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00127", err)
+}
+
 func TestPrepareOperationNotSupported(t *testing.T) {
 	cm := newTestContractManager()
 
@@ -821,6 +866,121 @@ func TestOperationUpdateDeploySucceed(t *testing.T) {
 	mdi.AssertExpectations(t)
 }
 
+type mockAPIDefiner struct {
+	mock.Mock
+}
+
+func (m *mockAPIDefiner) DefineContractAPI(ctx context.Context, httpServerURL string, api *core.ContractAPI, waitConfirm bool) error {
+	return m.Called(ctx, httpServerURL, api, waitConfirm).Error(0)
+}
+
+func newDeployWithAPIOp(t *testing.T) *core.Operation {
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainContractDeploy,
+	}
+	err := addBlockchainReqInputs(op, &core.ContractDeployRequest{
+		Interface: &fftypes.FFIReference{Name: "simplestorage", Version: "v1.0.0"},
+		APIName:   "simple",
+	})
+	assert.NoError(t, err)
+	return op
+}
+
+func TestOperationUpdateDeploySucceedDefineAPI(t *testing.T) {
+	cm := newTestContractManager()
+	ad := &mockAPIDefiner{}
+	cm.SetAPIDefiner(ad)
+
+	op := newDeployWithAPIOp(t)
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+		Output: fftypes.JSONObject{
+			"contractLocation": fftypes.JSONObject{"address": "0x123456"},
+		},
+	}
+
+	ad.On("DefineContractAPI", context.Background(), "", mock.MatchedBy(func(api *core.ContractAPI) bool {
+		return api.Name == "simple" && api.Interface.Name == "simplestorage" && api.Location.JSONObject().GetString("address") == "0x123456"
+	}), false).Return(nil)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.Anything).Return(nil)
+
+	err := cm.OnOperationUpdate(context.Background(), op, update)
+	assert.NoError(t, err)
+
+	ad.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestOperationUpdateDeploySucceedDefineAPIFail(t *testing.T) {
+	cm := newTestContractManager()
+	ad := &mockAPIDefiner{}
+	cm.SetAPIDefiner(ad)
+
+	op := newDeployWithAPIOp(t)
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+		Output: fftypes.JSONObject{
+			"contractAddress": "0x123456",
+		},
+	}
+
+	ad.On("DefineContractAPI", context.Background(), "", mock.MatchedBy(func(api *core.ContractAPI) bool {
+		return api.Location.JSONObject().GetString("address") == "0x123456"
+	}), false).Return(fmt.Errorf("pop"))
+	mdi := cm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.Anything).Return(nil)
+
+	err := cm.OnOperationUpdate(context.Background(), op, update)
+	assert.NoError(t, err)
+
+	ad.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestOperationUpdateDeploySucceedNoLocation(t *testing.T) {
+	cm := newTestContractManager()
+	ad := &mockAPIDefiner{}
+	cm.SetAPIDefiner(ad)
+
+	op := newDeployWithAPIOp(t)
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+	}
+
+	mdi := cm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.Anything).Return(nil)
+
+	err := cm.OnOperationUpdate(context.Background(), op, update)
+	assert.NoError(t, err)
+
+	ad.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestOperationUpdateDeployABISucceed(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainContractDeployABI,
+	}
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+	}
+
+	mdi := cm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeBlockchainContractDeployOpSucceeded && *event.Reference == *op.ID
+	})).Return(nil)
+
+	err := cm.OnOperationUpdate(context.Background(), op, update)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestOperationUpdateDeployFail(t *testing.T) {
 	cm := newTestContractManager()
 
@@ -886,3 +1046,141 @@ func TestOperationUpdateInvokeFail(t *testing.T) {
 
 	mdi.AssertExpectations(t)
 }
+
+func TestPrepareAndRunBlockchainInvokeBatch(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		Type:      core.OpTypeBlockchainInvokeBatch,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	req := &core.ContractCallBatchRequest{
+		Key:          "0x123",
+		Multicall:    fftypes.JSONAnyPtr(`{"address":"0xcafe"}`),
+		AllowFailure: true,
+		Items: []*core.ContractCallRequest{
+			{
+				Key:      "0x123",
+				Location: fftypes.JSONAnyPtr(`{"address":"0x1111"}`),
+				Method:   &fftypes.FFIMethod{Name: "set"},
+				Input:    map[string]interface{}{"value": "1"},
+			},
+		},
+	}
+	err := addBlockchainReqInputs(op, req)
+	assert.NoError(t, err)
+
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	opaqueData := "anything"
+	mbi.On("ParseInterface", context.Background(), mock.MatchedBy(func(method *fftypes.FFIMethod) bool {
+		return method.Name == "set"
+	}), mock.Anything).Return(opaqueData, nil)
+	mbi.On("InvokeContractBatch", context.Background(), "ns1:"+op.ID.String(), "0x123", mock.MatchedBy(func(loc *fftypes.JSONAny) bool {
+		return loc.String() == req.Multicall.String()
+	}), mock.MatchedBy(func(calls []*blockchain.ContractBatchCall) bool {
+		return len(calls) == 1 && calls[0].ParsedMethod == opaqueData && calls[0].Location.String() == `{"address":"0x1111"}`
+	}), true, mock.Anything).Return(false, nil)
+
+	po, err := cm.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, req, po.Data.(blockchainInvokeBatchData).Request)
+
+	_, phase, err := cm.RunOperation(context.Background(), po)
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhasePending, phase)
+
+	mbi.AssertExpectations(t)
+}
+
+func TestRunBlockchainInvokeBatchValidateFail(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		Type:      core.OpTypeBlockchainInvokeBatch,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	req := &core.ContractCallBatchRequest{
+		Items: []*core.ContractCallRequest{
+			{Method: &fftypes.FFIMethod{Name: "set"}},
+		},
+	}
+
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("ParseInterface", context.Background(), mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, phase, err := cm.RunOperation(context.Background(), opBlockchainInvokeBatch(op, req))
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+
+	mbi.AssertExpectations(t)
+}
+
+func TestPrepareOperationBlockchainInvokeBatchBadInput(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		Type:  core.OpTypeBlockchainInvokeBatch,
+		Input: fftypes.JSONObject{"items": "bad"},
+	}
+
+	_, err := cm.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00127", err)
+}
+
+func TestOperationUpdateInvokeBatchSucceed(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainInvokeBatch,
+	}
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+		Output: fftypes.JSONObject{"transactionHash": "0x123"},
+	}
+	results := []*core.ContractCallBatchItemResult{
+		{Success: true, ReturnData: "0x"},
+		{Success: false, ReturnData: "0x08c379a0"},
+	}
+
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("DecodeInvokeBatchReceipt", context.Background(), update.Output).Return(results, nil)
+	mdi := cm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeBlockchainInvokeOpSucceeded && *event.Reference == *op.ID
+	})).Return(nil)
+
+	err := cm.OnOperationUpdate(context.Background(), op, update)
+	assert.NoError(t, err)
+	assert.Equal(t, results, update.Output["items"])
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestOperationUpdateInvokeBatchDecodeFail(t *testing.T) {
+	cm := newTestContractManager()
+
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainInvokeBatch,
+	}
+	update := &core.OperationUpdate{
+		Status: core.OpStatusSucceeded,
+		Output: fftypes.JSONObject{},
+	}
+
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("DecodeInvokeBatchReceipt", context.Background(), update.Output).Return(nil, fmt.Errorf("pop"))
+	mdi := cm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", context.Background(), mock.Anything).Return(nil)
+
+	err := cm.OnOperationUpdate(context.Background(), op, update)
+	assert.NoError(t, err)
+	assert.NotContains(t, update.Output, "items")
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
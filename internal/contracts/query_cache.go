@@ -0,0 +1,70 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type queryCacheEntry struct {
+	result  interface{}
+	expires time.Time
+}
+
+// queryCacheKey identifies a query by everything that can affect its result, including the
+// options that select the block the query is run against
+func queryCacheKey(api *core.ContractAPI, methodPath string, req *core.ContractCallRequest) string {
+	b, _ := json.Marshal([]interface{}{methodPath, req.Key, req.Location, req.Input, req.Options})
+	hash := sha256.Sum256(b)
+	return "query_" + api.ID.String() + "_" + hex.EncodeToString(hash[:])
+}
+
+func (cm *contractManager) queryContractAPICached(ctx context.Context, api *core.ContractAPI, methodPath string, req *core.ContractCallRequest) (interface{}, error) {
+	cacheKey := queryCacheKey(api, methodPath, req)
+	if cached, ok := cm.queryCache.Get(cacheKey).(*queryCacheEntry); ok && time.Now().Before(cached.expires) {
+		log.L(ctx).Debugf("Returning cached result for query '%s' on API '%s'", methodPath, api.Name)
+		if cm.metrics.IsMetricsEnabled() {
+			cm.metrics.ContractQueryCacheHit(api.Name, methodPath)
+		}
+		return cached.result, nil
+	}
+	if cm.metrics.IsMetricsEnabled() {
+		cm.metrics.ContractQueryCacheMiss(api.Name, methodPath)
+	}
+
+	res, err := cm.InvokeContract(ctx, req, true)
+	if err != nil {
+		return nil, err
+	}
+	cm.queryCache.Set(cacheKey, &queryCacheEntry{
+		result:  res,
+		expires: time.Now().Add(time.Duration(*api.QueryCacheTTL)),
+	})
+	return res, nil
+}
+
+func queryCacheEnabled(api *core.ContractAPI, req *core.ContractCallRequest) bool {
+	return req.Type == core.CallTypeQuery && api.QueryCacheTTL != nil && *api.QueryCacheTTL > fftypes.FFDuration(0)
+}
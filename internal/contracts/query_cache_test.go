@@ -0,0 +1,156 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestCachedQueryAPI(ttl time.Duration) *core.ContractAPI {
+	queryCacheTTL := fftypes.FFDuration(ttl)
+	return &core.ContractAPI{
+		ID:            fftypes.NewUUID(),
+		Name:          "banana",
+		Interface:     &fftypes.FFIReference{ID: fftypes.NewUUID()},
+		Location:      fftypes.JSONAnyPtr(`{"address":"0x12345"}`),
+		QueryCacheTTL: &queryCacheTTL,
+	}
+}
+
+func newTestQueryRequest(input map[string]interface{}) *core.ContractCallRequest {
+	return &core.ContractCallRequest{
+		Type: core.CallTypeQuery,
+		Method: &fftypes.FFIMethod{
+			Name: "peel",
+		},
+		Input: input,
+	}
+}
+
+func TestInvokeContractAPIQueryCached(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mmm := &metricsmocks.Manager{}
+	cm.metrics = mmm
+
+	api := newTestCachedQueryAPI(time.Minute)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	mim.On("ResolveQuerySigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mbi.On("QueryContract", mock.Anything, "key-resolved", api.Location, "parsed", map[string]interface{}{"x": float64(1)}, mock.Anything).Return("result1", nil).Once()
+	mbi.On("QueryContract", mock.Anything, "key-resolved", api.Location, "parsed", map[string]interface{}{"x": float64(2)}, mock.Anything).Return("result2", nil).Once()
+	mmm.On("IsMetricsEnabled").Return(true)
+	mmm.On("ContractQueryCacheMiss", "banana", "peel").Twice()
+	mmm.On("ContractQueryCacheHit", "banana", "peel").Once()
+
+	res, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", newTestQueryRequest(map[string]interface{}{"x": float64(1)}), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "result1", res)
+
+	// Served from the cache
+	res, err = cm.InvokeContractAPI(context.Background(), "banana", "", "peel", newTestQueryRequest(map[string]interface{}{"x": float64(1)}), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "result1", res)
+
+	// Different input is a different cache entry
+	res, err = cm.InvokeContractAPI(context.Background(), "banana", "", "peel", newTestQueryRequest(map[string]interface{}{"x": float64(2)}), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "result2", res)
+
+	mbi.AssertExpectations(t)
+	mmm.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIQueryCacheExpired(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mmm := &metricsmocks.Manager{}
+	cm.metrics = mmm
+
+	api := newTestCachedQueryAPI(time.Minute)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	mim.On("ResolveQuerySigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mbi.On("QueryContract", mock.Anything, "key-resolved", api.Location, "parsed", mock.Anything, mock.Anything).Return("result", nil).Once()
+	mmm.On("IsMetricsEnabled").Return(false)
+
+	req := newTestQueryRequest(nil)
+	cm.queryCache.Set(queryCacheKey(api, "peel", req), &queryCacheEntry{
+		result:  "stale",
+		expires: time.Now().Add(-time.Second),
+	})
+
+	res, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", req, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "result", res)
+
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIQueryCacheFailNotCached(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+	mbi := cm.blockchain.(*blockchainmocks.Plugin)
+	mim := cm.identity.(*identitymanagermocks.Manager)
+	mmm := &metricsmocks.Manager{}
+	cm.metrics = mmm
+
+	api := newTestCachedQueryAPI(time.Minute)
+	mdb.On("GetContractAPIByName", mock.Anything, "ns1", "banana").Return(api, nil)
+	mim.On("ResolveQuerySigningKey", mock.Anything, "", identity.KeyNormalizationBlockchainPlugin).Return("key-resolved", nil)
+	mbi.On("ParseInterface", mock.Anything, mock.Anything, mock.Anything).Return("parsed", nil)
+	mbi.On("ValidateInvokeRequest", mock.Anything, "parsed", mock.Anything, false).Return(nil)
+	mbi.On("QueryContract", mock.Anything, "key-resolved", api.Location, "parsed", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop")).Once()
+	mbi.On("QueryContract", mock.Anything, "key-resolved", api.Location, "parsed", mock.Anything, mock.Anything).Return("result", nil).Once()
+	mmm.On("IsMetricsEnabled").Return(false)
+
+	_, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", newTestQueryRequest(nil), true)
+	assert.Regexp(t, "pop", err)
+
+	res, err := cm.InvokeContractAPI(context.Background(), "banana", "", "peel", newTestQueryRequest(nil), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "result", res)
+
+	mbi.AssertExpectations(t)
+}
+
+func TestInvokeContractAPIQueryCacheDisabledForInvoke(t *testing.T) {
+	api := newTestCachedQueryAPI(time.Minute)
+	assert.True(t, queryCacheEnabled(api, &core.ContractCallRequest{Type: core.CallTypeQuery}))
+	assert.False(t, queryCacheEnabled(api, &core.ContractCallRequest{Type: core.CallTypeInvoke}))
+	assert.False(t, queryCacheEnabled(newTestCachedQueryAPI(0), &core.ContractCallRequest{Type: core.CallTypeQuery}))
+	assert.False(t, queryCacheEnabled(&core.ContractAPI{}, &core.ContractCallRequest{Type: core.CallTypeQuery}))
+}
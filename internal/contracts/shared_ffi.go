@@ -0,0 +1,136 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (cm *contractManager) ShareFFI(ctx context.Context, name, version string) (shared *core.SharedFFI, err error) {
+	err = cm.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		ffi, err := cm.GetFFIWithChildren(ctx, name, version)
+		if err != nil {
+			return err
+		}
+
+		existing, err := cm.database.GetSharedFFI(ctx, ffi.Name, ffi.Version)
+		if err != nil {
+			return err
+		} else if existing != nil {
+			if existing.Namespace == cm.namespace && existing.Interface.Equals(ffi.ID) {
+				shared = existing
+				return nil
+			}
+			return i18n.NewError(ctx, coremsgs.MsgContractInterfaceAlreadyShared, ffi.Name, ffi.Version, existing.Namespace)
+		}
+
+		shared = &core.SharedFFI{
+			ID:        fftypes.NewUUID(),
+			Namespace: cm.namespace,
+			Interface: ffi.ID,
+			Name:      ffi.Name,
+			Version:   ffi.Version,
+			Hash:      hashFFI(ffi),
+			Created:   fftypes.Now(),
+		}
+		log.L(ctx).Infof("Sharing interface '%s:%s' (%s) with hash %s", shared.Name, shared.Version, shared.Interface, shared.Hash)
+		return cm.database.InsertSharedFFI(ctx, shared)
+	})
+	return shared, err
+}
+
+func (cm *contractManager) GetSharedFFIs(ctx context.Context, filter ffapi.AndFilter) ([]*core.SharedFFI, *ffapi.FilterResult, error) {
+	return cm.database.GetSharedFFIs(ctx, filter)
+}
+
+// GetSharedFFIWithChildren returns a shared interface, with its methods, events and errors read from
+// the namespace that shared it. Returns nil if no interface is shared with the given name and version.
+func (cm *contractManager) GetSharedFFIWithChildren(ctx context.Context, name, version string) (shared *core.SharedFFI, ffi *fftypes.FFI, err error) {
+	err = cm.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		shared, err = cm.database.GetSharedFFI(ctx, name, version)
+		if err != nil || shared == nil {
+			return err
+		}
+		ffi, err = cm.database.GetFFIByID(ctx, shared.Namespace, shared.Interface)
+		if err != nil {
+			return err
+		} else if ffi == nil {
+			return i18n.NewError(ctx, coremsgs.MsgContractInterfaceNotFound, shared.Interface)
+		}
+		if err = cm.getFFIChildren(ctx, shared.Namespace, ffi); err != nil {
+			return err
+		}
+		if hash := hashFFI(ffi); !hash.Equals(shared.Hash) {
+			return i18n.NewError(ctx, coremsgs.MsgContractInterfaceHashMismatch, shared.Interface, hash, shared.Hash)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return shared, ffi, nil
+}
+
+// verifyInterfaceHash checks that the interface bound to an API matches the hash the API is pinned to, if any
+func (cm *contractManager) verifyInterfaceHash(ctx context.Context, api *core.ContractAPI) error {
+	if api.InterfaceHash == nil {
+		return nil
+	}
+	ffi, err := cm.database.GetFFIByID(ctx, cm.namespace, api.Interface.ID)
+	if err != nil {
+		return err
+	} else if ffi == nil {
+		return i18n.NewError(ctx, coremsgs.MsgContractInterfaceNotFound, api.Interface.ID)
+	}
+	if err := cm.getFFIChildren(ctx, cm.namespace, ffi); err != nil {
+		return err
+	}
+	if hash := hashFFI(ffi); !hash.Equals(api.InterfaceHash) {
+		return i18n.NewError(ctx, coremsgs.MsgContractInterfaceHashMismatch, api.Interface.ID, hash, api.InterfaceHash)
+	}
+	return nil
+}
+
+// hashFFI computes a hash over the content of an interface. Identifiers that are local to a namespace,
+// such as the UUIDs of the FFI and its children, are excluded - so copies of the same interface in
+// different namespaces, or on different members of the network, have the same hash.
+func hashFFI(ffi *fftypes.FFI) *fftypes.Bytes32 {
+	methods := make([]string, 0, len(ffi.Methods))
+	for _, m := range ffi.Methods {
+		methods = append(methods, entrySignature(m.Name, m.Description, m.Params, m.Returns, m.Details))
+	}
+	events := make([]string, 0, len(ffi.Events))
+	for _, e := range ffi.Events {
+		events = append(events, entrySignature(e.Name, e.Description, e.Params, e.Details))
+	}
+	errors := make([]string, 0, len(ffi.Errors))
+	for _, e := range ffi.Errors {
+		errors = append(errors, entrySignature(e.Name, e.Description, e.Params))
+	}
+	sort.Strings(methods)
+	sort.Strings(events)
+	sort.Strings(errors)
+	return fftypes.HashString(entrySignature(ffi.Name, ffi.Version, ffi.Description, methods, events, errors))
+}
@@ -0,0 +1,394 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testSharedFFI() *fftypes.FFI {
+	return &fftypes.FFI{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Name:        "math",
+		Version:     "v1.0.0",
+		Description: "Math functions",
+		Methods: []*fftypes.FFIMethod{
+			{ID: fftypes.NewUUID(), Name: "sum", Pathname: "sum", Params: fftypes.FFIParams{
+				{Name: "x", Schema: fftypes.JSONAnyPtr(`{"type":"integer"}`)},
+			}},
+			{ID: fftypes.NewUUID(), Name: "sub", Pathname: "sub"},
+		},
+	}
+}
+
+func TestHashFFIIgnoresIDsAndOrder(t *testing.T) {
+	ffi1 := testSharedFFI()
+	ffi2 := testSharedFFI()
+	ffi2.Namespace = "ns2"
+	ffi2.Methods[0], ffi2.Methods[1] = ffi2.Methods[1], ffi2.Methods[0]
+	assert.Equal(t, hashFFI(ffi1), hashFFI(ffi2))
+
+	ffi2.Methods[0].Params = fftypes.FFIParams{{Name: "y"}}
+	assert.NotEqual(t, hashFFI(ffi1), hashFFI(ffi2))
+}
+
+func TestHashFFIEventsAndErrors(t *testing.T) {
+	ffi1 := testSharedFFI()
+	ffi1.Events = []*fftypes.FFIEvent{
+		{ID: fftypes.NewUUID(), FFIEventDefinition: fftypes.FFIEventDefinition{Name: "Summed"}},
+	}
+	ffi1.Errors = []*fftypes.FFIError{
+		{ID: fftypes.NewUUID(), FFIErrorDefinition: fftypes.FFIErrorDefinition{Name: "Overflow"}},
+	}
+	ffi2 := testSharedFFI()
+	ffi2.Events = []*fftypes.FFIEvent{
+		{ID: fftypes.NewUUID(), FFIEventDefinition: fftypes.FFIEventDefinition{Name: "Summed"}},
+	}
+	ffi2.Errors = []*fftypes.FFIError{
+		{ID: fftypes.NewUUID(), FFIErrorDefinition: fftypes.FFIErrorDefinition{Name: "Overflow"}},
+	}
+	assert.Equal(t, hashFFI(ffi1), hashFFI(ffi2))
+
+	ffi2.Errors[0].Name = "Underflow"
+	assert.NotEqual(t, hashFFI(ffi1), hashFFI(ffi2))
+	ffi2.Errors = ffi1.Errors
+	ffi2.Events[0].Name = "Subtracted"
+	assert.NotEqual(t, hashFFI(ffi1), hashFFI(ffi2))
+}
+
+func TestShareFFI(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	mdb.On("GetFFI", mock.Anything, "ns1", "math", "v1.0.0").Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(nil, nil)
+	mdb.On("InsertSharedFFI", mock.Anything, mock.MatchedBy(func(shared *core.SharedFFI) bool {
+		return shared.Namespace == "ns1" && shared.Interface.Equals(ffi.ID) && shared.Hash.Equals(hashFFI(ffi))
+	})).Return(nil)
+
+	shared, err := cm.ShareFFI(context.Background(), "math", "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "math", shared.Name)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestShareFFIAlreadySharedIdempotent(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	existing := &core.SharedFFI{Namespace: "ns1", Interface: ffi.ID}
+	mdb.On("GetFFI", mock.Anything, "ns1", "math", "v1.0.0").Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(existing, nil)
+
+	shared, err := cm.ShareFFI(context.Background(), "math", "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, existing, shared)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestShareFFIAlreadySharedOtherNamespace(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	mdb.On("GetFFI", mock.Anything, "ns1", "math", "v1.0.0").Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(&core.SharedFFI{Namespace: "ns2", Interface: fftypes.NewUUID()}, nil)
+
+	_, err := cm.ShareFFI(context.Background(), "math", "v1.0.0")
+	assert.Regexp(t, "FF10493", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestShareFFINotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	mdb.On("GetFFI", mock.Anything, "ns1", "math", "v1.0.0").Return(nil, nil)
+
+	_, err := cm.ShareFFI(context.Background(), "math", "v1.0.0")
+	assert.Regexp(t, "FF10109", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestShareFFIGetSharedFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	mdb.On("GetFFI", mock.Anything, "ns1", "math", "v1.0.0").Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(nil, fmt.Errorf("pop"))
+
+	_, err := cm.ShareFFI(context.Background(), "math", "v1.0.0")
+	assert.EqualError(t, err, "pop")
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIs(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	mdb.On("GetSharedFFIs", mock.Anything, mock.Anything).Return([]*core.SharedFFI{}, nil, nil)
+
+	_, _, err := cm.GetSharedFFIs(context.Background(), nil)
+	assert.NoError(t, err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIWithChildren(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	ffi.Namespace = "ns2"
+	shared := &core.SharedFFI{Namespace: "ns2", Interface: ffi.ID, Hash: hashFFI(ffi)}
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(shared, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns2", ffi.ID).Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns2", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns2", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns2", mock.Anything).Return(nil, nil, nil)
+
+	sharedRead, ffiRead, err := cm.GetSharedFFIWithChildren(context.Background(), "math", "v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, shared, sharedRead)
+	assert.Len(t, ffiRead.Methods, 2)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIWithChildrenNotShared(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(nil, nil)
+
+	shared, ffi, err := cm.GetSharedFFIWithChildren(context.Background(), "math", "v1.0.0")
+	assert.NoError(t, err)
+	assert.Nil(t, shared)
+	assert.Nil(t, ffi)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIWithChildrenInterfaceNotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	shared := &core.SharedFFI{Namespace: "ns2", Interface: fftypes.NewUUID()}
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(shared, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns2", shared.Interface).Return(nil, nil)
+
+	_, _, err := cm.GetSharedFFIWithChildren(context.Background(), "math", "v1.0.0")
+	assert.Regexp(t, "FF10303", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIWithChildrenGetFFIFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	shared := &core.SharedFFI{Namespace: "ns2", Interface: fftypes.NewUUID()}
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(shared, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns2", shared.Interface).Return(nil, fmt.Errorf("pop"))
+
+	_, _, err := cm.GetSharedFFIWithChildren(context.Background(), "math", "v1.0.0")
+	assert.EqualError(t, err, "pop")
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIWithChildrenChildrenFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	shared := &core.SharedFFI{Namespace: "ns2", Interface: ffi.ID}
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(shared, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns2", ffi.ID).Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns2", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, _, err := cm.GetSharedFFIWithChildren(context.Background(), "math", "v1.0.0")
+	assert.EqualError(t, err, "pop")
+
+	mdb.AssertExpectations(t)
+}
+
+func TestGetSharedFFIWithChildrenHashMismatch(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	shared := &core.SharedFFI{Namespace: "ns2", Interface: ffi.ID, Hash: fftypes.NewRandB32()}
+	mdb.On("GetSharedFFI", mock.Anything, "math", "v1.0.0").Return(shared, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns2", ffi.ID).Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns2", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns2", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns2", mock.Anything).Return(nil, nil, nil)
+
+	_, _, err := cm.GetSharedFFIWithChildren(context.Background(), "math", "v1.0.0")
+	assert.Regexp(t, "FF10494", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIInterfaceHash(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	api := &core.ContractAPI{
+		ID:            fftypes.NewUUID(),
+		Namespace:     "ns1",
+		Name:          "math",
+		Interface:     &fftypes.FFIReference{ID: ffi.ID},
+		InterfaceHash: hashFFI(ffi),
+	}
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "math", "").Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+
+	err := cm.ResolveContractAPI(context.Background(), "", api)
+	assert.NoError(t, err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIInterfaceHashMismatch(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	api := &core.ContractAPI{
+		ID:            fftypes.NewUUID(),
+		Namespace:     "ns1",
+		Name:          "math",
+		Interface:     &fftypes.FFIReference{ID: ffi.ID},
+		InterfaceHash: fftypes.NewRandB32(),
+	}
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "math", "").Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(ffi.Methods, nil, nil)
+	mdb.On("GetFFIEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mdb.On("GetFFIErrors", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+
+	err := cm.ResolveContractAPI(context.Background(), "", api)
+	assert.Regexp(t, "FF10494", err)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIInterfaceHashChildrenFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	api := &core.ContractAPI{
+		ID:            fftypes.NewUUID(),
+		Namespace:     "ns1",
+		Name:          "math",
+		Interface:     &fftypes.FFIReference{ID: ffi.ID},
+		InterfaceHash: fftypes.NewRandB32(),
+	}
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "math", "").Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(ffi, nil)
+	mdb.On("GetFFIMethods", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := cm.ResolveContractAPI(context.Background(), "", api)
+	assert.EqualError(t, err, "pop")
+
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIInterfaceHashGetFFIFail(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	api := &core.ContractAPI{
+		ID:            fftypes.NewUUID(),
+		Namespace:     "ns1",
+		Name:          "math",
+		Interface:     &fftypes.FFIReference{ID: ffi.ID},
+		InterfaceHash: fftypes.NewRandB32(),
+	}
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "math", "").Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(ffi, nil).Once()
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(nil, fmt.Errorf("pop")).Once()
+
+	err := cm.ResolveContractAPI(context.Background(), "", api)
+	assert.EqualError(t, err, "pop")
+
+	mdb.AssertExpectations(t)
+}
+
+func TestResolveContractAPIInterfaceHashFFINotFound(t *testing.T) {
+	cm := newTestContractManager()
+	mdb := cm.database.(*databasemocks.Plugin)
+
+	ffi := testSharedFFI()
+	api := &core.ContractAPI{
+		ID:            fftypes.NewUUID(),
+		Namespace:     "ns1",
+		Name:          "math",
+		Interface:     &fftypes.FFIReference{ID: ffi.ID},
+		InterfaceHash: fftypes.NewRandB32(),
+	}
+
+	mdb.On("GetContractAPIByNameAndVersion", mock.Anything, "ns1", "math", "").Return(nil, nil)
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(ffi, nil).Once()
+	mdb.On("GetFFIByID", mock.Anything, "ns1", ffi.ID).Return(nil, nil).Once()
+
+	err := cm.ResolveContractAPI(context.Background(), "", api)
+	assert.Regexp(t, "FF10303", err)
+
+	mdb.AssertExpectations(t)
+}
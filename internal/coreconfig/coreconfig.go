@@ -37,6 +37,10 @@ const (
 	NamespaceDescription = "description"
 	// NamespacePlugins is the list of namespace plugins
 	NamespacePlugins = "plugins"
+	// NamespaceIsolated requires the stateful plugins of a namespace to not be bound to any other namespace
+	NamespaceIsolated = "isolated"
+	// NamespaceSandbox marks a namespace as a sandbox, into which the definitions and events of other namespaces can be replayed
+	NamespaceSandbox = "sandbox"
 	// NamespaceTLSConfigName is the user-supplied name for the TLS Config
 	NamespaceTLSConfigName = "name"
 	// NamespaceTLSConfigs is the list of tls configs
@@ -45,8 +49,36 @@ const (
 	NamespaceTLSConfigTLSSection = "tls"
 	// NamespaceDefaultKey is the default signing key for blockchain transactions within this namespace
 	NamespaceDefaultKey = "defaultKey"
+	// NamespaceDownloadPriorityBatch overrides the priority of batch downloads for this namespace
+	NamespaceDownloadPriorityBatch = "download.priority.batch"
+	// NamespaceDownloadPriorityBlob overrides the priority of blob downloads for this namespace
+	NamespaceDownloadPriorityBlob = "download.priority.blob"
+	// NamespaceBatchWorkers overrides the maximum number of batches flushed concurrently by this namespace
+	NamespaceBatchWorkers = "batch.workers"
+	// NamespaceBatchQueueDepth overrides the number of messages each batch processor of this namespace queues for assembly
+	NamespaceBatchQueueDepth = "batch.queueDepth"
 	// NamespaceAssetKeyNormalization mechanism to normalize keys before using them. Valid options: "blockchain_plugin" - use blockchain plugin (default), "none" - do not attempt normalization
 	NamespaceAssetKeyNormalization = "asset.manager.keyNormalization"
+	// NamespaceAssetPoolTemplates is the list of named templates for creating token pools in this namespace
+	NamespaceAssetPoolTemplates = "asset.manager.poolTemplates"
+	// NamespaceAssetPoolTemplateName is the name a pool template is referenced by on pool creation
+	NamespaceAssetPoolTemplateName = "name"
+	// NamespaceAssetPoolTemplateConnector is the token connector used by pools created from the template
+	NamespaceAssetPoolTemplateConnector = "connector"
+	// NamespaceAssetPoolTemplateType is the type of token (fungible/nonfungible) of pools created from the template
+	NamespaceAssetPoolTemplateType = "type"
+	// NamespaceAssetPoolTemplateStandard is the token standard that pools created from the template conform to
+	NamespaceAssetPoolTemplateStandard = "standard"
+	// NamespaceAssetPoolTemplateDecimals is the number of decimals of pools created from the template
+	NamespaceAssetPoolTemplateDecimals = "decimals"
+	// NamespaceAssetPoolTemplateConfig is the connector specific config passed when creating pools from the template
+	NamespaceAssetPoolTemplateConfig = "config"
+	// NamespaceValidationPolicy overrides the validation policy for this namespace
+	NamespaceValidationPolicy = "validation.policy"
+	// NamespaceValidationDatatypes is a map of datatype names to the validation policy for data of that datatype
+	NamespaceValidationDatatypes = "validation.datatypes"
+	// NamespaceHashCanonicalization overrides the canonicalization of JSON data values before hashing for this namespace
+	NamespaceHashCanonicalization = "hash.canonicalization"
 	// NamespaceMultiparty contains the multiparty configuration for a namespace
 	NamespaceMultiparty = "multiparty"
 	// NamespaceMultipartyEnabled specifies if multi-party mode is enabled for a namespace
@@ -59,10 +91,34 @@ const (
 	NamespaceMultipartyOrgDescription = "org.description"
 	// NamespaceMultipartyOrgKey is the signing key allocated to the local root org within a namespace
 	NamespaceMultipartyOrgKey = "org.key"
+	// NamespaceMultipartyOrgCertificate is a PEM encoded X.509 certificate chain for the local root org within a namespace
+	NamespaceMultipartyOrgCertificate = "org.certificate"
+	// NamespaceMultipartyOrgCertificateSignature is a signature over the claim of the local root org, made with the key of its certificate
+	NamespaceMultipartyOrgCertificateSignature = "org.certificateSignature"
+	// NamespaceMultipartyOrgVerificationTrustRoots is a PEM encoded bundle of CA certificates that org identities must chain to
+	NamespaceMultipartyOrgVerificationTrustRoots = "orgVerification.trustRoots"
+	// NamespaceMultipartyIdentityClaimsRequireSignature requires identity claims to be signed by the submitting key
+	NamespaceMultipartyIdentityClaimsRequireSignature = "identityClaims.requireSignature"
 	// NamespaceMultipartyNodeName is the name for the local node within a namespace
 	NamespaceMultipartyNodeName = "node.name"
 	// NamespaceMultipartyNodeName is a description for the local node within a namespace
 	NamespaceMultipartyNodeDescription = "node.description"
+	// NamespaceMultipartySequencerType selects the plugin used to order batches for this namespace
+	NamespaceMultipartySequencerType = "sequencer.type"
+	// NamespaceMultipartySequencerPollInterval is how often the database sequencer checks for new batches
+	NamespaceMultipartySequencerPollInterval = "sequencer.pollInterval"
+	// NamespaceMultipartySequencerBatchSize is the number of sequenced batches the database sequencer delivers at a time
+	NamespaceMultipartySequencerBatchSize = "sequencer.batchSize"
+	// NamespaceMultipartyAnchorPlugin is the name of a second blockchain plugin, to which every batch is also pinned
+	NamespaceMultipartyAnchorPlugin = "anchor.plugin"
+	// NamespaceMultipartyAnchorKey is the signing key used for pins on the anchor chain
+	NamespaceMultipartyAnchorKey = "anchor.key"
+	// NamespaceMultipartyAnchorLocation is an object specifying the blockchain-specific location of the contract on the anchor chain
+	NamespaceMultipartyAnchorLocation = "anchor.location"
+	// NamespaceMultipartyAnchorMode selects whether every batch is pinned to the anchor chain, or a periodic digest of them
+	NamespaceMultipartyAnchorMode = "anchor.mode"
+	// NamespaceMultipartyAnchorDigestInterval is how often a digest is submitted to the anchor chain, in digest mode
+	NamespaceMultipartyAnchorDigestInterval = "anchor.digest.interval"
 	// NamespaceMultipartyContract is a list of firefly contract configurations for this namespace
 	NamespaceMultipartyContract = "contract"
 	// NamespaceMultipartyContractFirstEvent is the first event to process for this contract
@@ -92,18 +148,56 @@ var (
 	APIOASPanicOnMissingDescription = ffc("api.oas.panicOnMissingDescription")
 	// APIPassThroughHeaders is a list of HTTP request headers to pass through to requests made to dependency microservices
 	APIPassthroughHeaders = ffc("api.passthroughHeaders")
+	// APIMaxRequestBodySize is the maximum size of a request body that will be read on the API
+	APIMaxRequestBodySize = ffc("api.maxRequestBodySize")
+	// APIRouteMaxRequestBodySize is a map of route names to the maximum request body size for that route
+	APIRouteMaxRequestBodySize = ffc("api.routeMaxRequestBodySize")
+	// APIKeysHeader is the HTTP request header in which an application supplies a namespace API key
+	APIKeysHeader = ffc("api.keys.header")
+	// APIKeysRequired rejects requests to namespaced routes that do not supply an API key
+	APIKeysRequired = ffc("api.keys.required")
+	// APIKeysBootstrapKeyHash the SHA-256 hash of a configured API key with admin scope, used to create the first keys
+	APIKeysBootstrapKeyHash = ffc("api.keys.bootstrapKeyHash")
+	// BatchAdaptiveEnabled tunes the size and payload limit of each batch processor based on the observed dispatch latency
+	BatchAdaptiveEnabled = ffc("batch.adaptive.enabled")
+	// BatchAdaptiveMinSize is the lowest number of messages the adaptive tuning will reduce a batch to
+	BatchAdaptiveMinSize = ffc("batch.adaptive.minSize")
+	// BatchAdaptiveMinPayloadLimit is the lowest payload limit the adaptive tuning will reduce a batch to
+	BatchAdaptiveMinPayloadLimit = ffc("batch.adaptive.minPayloadLimit")
+	// BatchAdaptiveTargetLatency is the dispatch latency above which the adaptive tuning shrinks batches
+	BatchAdaptiveTargetLatency = ffc("batch.adaptive.targetLatency")
+	// BatchAdaptiveGrowthFactor is the factor the adaptive tuning grows full batches by, while dispatch latency is under target
+	BatchAdaptiveGrowthFactor = ffc("batch.adaptive.growthFactor")
 	// BatchManagerReadPageSize is the size of each page of messages read from the database into memory when assembling batches
 	BatchManagerReadPageSize = ffc("batch.manager.readPageSize")
 	// BatchManagerReadPollTimeout is how long without any notifications of new messages to wait, before doing a page query
 	BatchManagerReadPollTimeout = ffc("batch.manager.pollTimeout")
 	// BatchManagerMinimumPollDelay is the minimum time the batch manager waits between polls on the DB - to prevent thrashing
 	BatchManagerMinimumPollDelay = ffc("batch.manager.minimumPollDelay")
+	// BatchManagerWorkers is the maximum number of batches each namespace flushes concurrently - 0 for one per batch processor
+	BatchManagerWorkers = ffc("batch.manager.workers")
+	// BatchManagerQueueDepth is the number of messages each batch processor queues for assembly - 0 for the batch size
+	BatchManagerQueueDepth = ffc("batch.manager.queueDepth")
 	// BatchRetryFactor is the retry backoff factor for database operations performed by the batch manager
 	BatchRetryFactor = ffc("batch.retry.factor")
 	// BatchRetryInitDelay is the retry initial delay for database operations
 	BatchRetryInitDelay = ffc("batch.retry.initDelay")
 	// BatchRetryMaxDelay is the maximum delay between retry attempts
 	BatchRetryMaxDelay = ffc("batch.retry.maxDelay")
+	// BlobGCRetention is how long after confirmation a message holds a reference to its blobs - zero retains them indefinitely
+	BlobGCRetention = ffc("blobgc.retention")
+	// BlobGCGracePeriod is the minimum age of a blob before it can be collected, so blobs that arrive ahead of their message are kept
+	BlobGCGracePeriod = ffc("blobgc.gracePeriod")
+	// BlobGCPageSize is the number of blobs read from the database in each page of a garbage collection run
+	BlobGCPageSize = ffc("blobgc.pageSize")
+	// DatatypeMigrationPageSize is the number of data items read from the database in each page of a datatype migration
+	DatatypeMigrationPageSize = ffc("datatype.migration.pageSize")
+	// DatatypeMigrationRetryInitDelay is the initial retry delay for database operations during datatype migrations
+	DatatypeMigrationRetryInitDelay = ffc("datatype.migration.retry.initialDelay")
+	// DatatypeMigrationRetryMaxDelay is the maximum retry delay
+	DatatypeMigrationRetryMaxDelay = ffc("datatype.migration.retry.maxDelay")
+	// DatatypeMigrationRetryFactor is the backoff factor to use for retries
+	DatatypeMigrationRetryFactor = ffc("datatype.migration.retry.factor")
 	// BlobReceiverRetryInitDelay is the initial retry delay
 	BlobReceiverRetryInitDelay = ffc("blobreceiver.retry.initialDelay")
 	// BlobReceiverRetryMaxDelay is the maximum retry delay
@@ -123,12 +217,52 @@ var (
 	BroadcastBatchSize = ffc("broadcast.batch.size")
 	// BroadcastBatchPayloadLimit is the maximum payload size of a batch for broadcast messages
 	BroadcastBatchPayloadLimit = ffc("broadcast.batch.payloadLimit")
+	// BroadcastBatchCompression is the compression applied to broadcast batch payloads uploaded to shared storage
+	BroadcastBatchCompression = ffc("broadcast.batch.compression")
+	// BroadcastBatchTopicAffinity assembles separate broadcast batches for each set of message topics
+	BroadcastBatchTopicAffinity = ffc("broadcast.batch.topicAffinity")
 	// BroadcastBatchTimeout is the timeout to wait for a batch to fill, before sending
 	BroadcastBatchTimeout = ffc("broadcast.batch.timeout")
 
 	// ConfigAutoReload starts a filesystem listener against the config file, and if it changes analyzes the config file for changes that require individual namespaces to restart
 	ConfigAutoReload = ffc("config.autoReload")
 
+	// ChaosEnabled wraps plugins with latency, error and disconnect injection - for resilience testing only
+	ChaosEnabled = ffc("chaos.enabled")
+
+	// Blockchain plugin chaos config
+	ChaosBlockchainLatency            = ffc("chaos.blockchain.latency")
+	ChaosBlockchainErrorRate          = ffc("chaos.blockchain.errorRate")
+	ChaosBlockchainDisconnectInterval = ffc("chaos.blockchain.disconnectInterval")
+	ChaosBlockchainDisconnectDuration = ffc("chaos.blockchain.disconnectDuration")
+
+	// Database plugin chaos config
+	ChaosDatabaseLatency            = ffc("chaos.database.latency")
+	ChaosDatabaseErrorRate          = ffc("chaos.database.errorRate")
+	ChaosDatabaseDisconnectInterval = ffc("chaos.database.disconnectInterval")
+	ChaosDatabaseDisconnectDuration = ffc("chaos.database.disconnectDuration")
+
+	// Data exchange plugin chaos config
+	ChaosDataExchangeLatency            = ffc("chaos.dataexchange.latency")
+	ChaosDataExchangeErrorRate          = ffc("chaos.dataexchange.errorRate")
+	ChaosDataExchangeDisconnectInterval = ffc("chaos.dataexchange.disconnectInterval")
+	ChaosDataExchangeDisconnectDuration = ffc("chaos.dataexchange.disconnectDuration")
+
+	// Tokens plugin chaos config
+	ChaosTokensLatency            = ffc("chaos.tokens.latency")
+	ChaosTokensErrorRate          = ffc("chaos.tokens.errorRate")
+	ChaosTokensDisconnectInterval = ffc("chaos.tokens.disconnectInterval")
+	ChaosTokensDisconnectDuration = ffc("chaos.tokens.disconnectDuration")
+
+	// ClusterEnabled makes instances sharing a database elect a single leader per namespace, which runs the event processing, while the others serve reads
+	ClusterEnabled = ffc("cluster.enabled")
+	// ClusterInstanceID uniquely identifies this instance within the cluster - defaults to the hostname with a random suffix
+	ClusterInstanceID = ffc("cluster.instanceID")
+	// ClusterLeaseTTL is how long a leader holds leadership of a namespace without renewing it
+	ClusterLeaseTTL = ffc("cluster.leaseTTL")
+	// ClusterLeaseRenewInterval is how often the leader renews, and standby instances attempt to take, leadership
+	ClusterLeaseRenewInterval = ffc("cluster.leaseRenewInterval")
+
 	// CacheEnabled determines whether cache will be enabled or not, default to true
 	CacheEnabled = ffc("cache.enabled")
 
@@ -151,6 +285,10 @@ var (
 	CacheEventListenerTopicLimit = ffc("cache.eventlistenertopic.limit")
 	CacheEventListenerTopicTTL   = ffc("cache.eventlistenertopic.ttl")
 
+	// EventDecoder cache config
+	CacheEventDecoderLimit = ffc("cache.eventdecoder.limit")
+	CacheEventDecoderTTL   = ffc("cache.eventdecoder.ttl")
+
 	// Group cache config
 	CacheGroupLimit = ffc("cache.group.limit")
 	CacheGroupTTL   = ffc("cache.group.ttl")
@@ -183,10 +321,18 @@ var (
 	CacheMethodsLimit = ffc("cache.methods.limit")
 	CacheMethodsTTL   = ffc("cache.methods.ttl")
 
+	// Contract API query results cache config
+	CacheContractQueryLimit = ffc("cache.contractquery.limit")
+	CacheContractQueryTTL   = ffc("cache.contractquery.ttl")
+
 	// DownloadWorkerCount is the number of download workers created to pull data from shared storage to the local DX
 	DownloadWorkerCount = ffc("download.worker.count")
-	// DownloadWorkerQueueLength is the length of the work queue in the channel to the workers - defaults to 2x the worker count
+	// DownloadWorkerQueueLength is the maximum number of downloads queued for the workers - defaults to 2x the worker count
 	DownloadWorkerQueueLength = ffc("download.worker.queueLength")
+	// DownloadPriorityBatch is the priority of batch downloads in the work queue - higher priority downloads are dispatched first
+	DownloadPriorityBatch = ffc("download.priority.batch")
+	// DownloadPriorityBlob is the priority of blob downloads in the work queue - higher priority downloads are dispatched first
+	DownloadPriorityBlob = ffc("download.priority.blob")
 	// DownloadRetryMaxAttempts is the maximum number of automatic attempts to make for each shared storage download before failing the operation
 	DownloadRetryMaxAttempts = ffc("download.retry.maxAttempts")
 	// DownloadRetryInitDelay is the initial retry delay
@@ -201,6 +347,10 @@ var (
 	PrivateMessagingBatchSize = ffc("privatemessaging.batch.size")
 	// PrivateMessagingBatchPayloadLimit is the maximum payload size of a private message data exchange payload
 	PrivateMessagingBatchPayloadLimit = ffc("privatemessaging.batch.payloadLimit")
+	// PrivateMessagingBatchCompression is the compression applied to private batch payloads sent over data exchange
+	PrivateMessagingBatchCompression = ffc("privatemessaging.batch.compression")
+	// PrivateMessagingBatchTopicAffinity assembles separate private batches for each set of message topics
+	PrivateMessagingBatchTopicAffinity = ffc("privatemessaging.batch.topicAffinity")
 	// PrivateMessagingBatchTimeout is the timeout to wait for a batch to fill, before sending
 	PrivateMessagingBatchTimeout = ffc("privatemessaging.batch.timeout")
 	// PrivateMessagingRetryFactor the backoff factor to use for retry of database operations
@@ -209,6 +359,22 @@ var (
 	PrivateMessagingRetryInitDelay = ffc("privatemessaging.retry.initDelay")
 	// PrivateMessagingRetryMaxDelay the maximum delay to use for retry of data base operations
 	PrivateMessagingRetryMaxDelay = ffc("privatemessaging.retry.maxDelay")
+	// PrivateMessagingTransfersMaxParallel the maximum number of blob transfers in flight to all peers
+	PrivateMessagingTransfersMaxParallel = ffc("privatemessaging.transfers.maxParallel")
+	// PrivateMessagingTransfersMaxParallelPerPeer the maximum number of blob transfers in flight to a single peer
+	PrivateMessagingTransfersMaxParallelPerPeer = ffc("privatemessaging.transfers.maxParallelPerPeer")
+	// PrivateMessagingTransfersBytesPerSecond the maximum rate at which blob transfers to all peers are started
+	PrivateMessagingTransfersBytesPerSecond = ffc("privatemessaging.transfers.bytesPerSecond")
+	// PrivateMessagingTransfersBytesPerSecondPerPeer the maximum rate at which blob transfers to a single peer are started
+	PrivateMessagingTransfersBytesPerSecondPerPeer = ffc("privatemessaging.transfers.bytesPerSecondPerPeer")
+	// ProxyURL is the proxy server that the REST requests of plugins are routed through
+	ProxyURL = ffc("proxy.url")
+	// ProxyNoProxy is a list of hosts, domains and CIDR ranges that are connected to directly rather than through the proxy
+	ProxyNoProxy = ffc("proxy.noProxy")
+	// HashAlgorithm is the algorithm used to hash new data and batches
+	HashAlgorithm = ffc("hash.algorithm")
+	// HashCanonicalization is how the JSON value of new data is serialized before it is hashed
+	HashCanonicalization = ffc("hash.canonicalization")
 	// DatabaseType the type of the database interface plugin to use
 	HistogramsMaxChartRows = ffc("histograms.maxChartRows")
 	// TokensList is the root key containing a list of supported token connectors
@@ -227,6 +393,8 @@ var (
 	PluginsDataExchangeList = ffc("plugins.dataexchange")
 	// PluginsIdentityList is the key containing a list of configured identity plugins
 	PluginsIdentityList = ffc("plugins.identity")
+	// PluginsKeyManagerList is the key containing a list of configured key manager plugins
+	PluginsKeyManagerList = ffc("plugins.keymanager")
 	// DebugPort a HTTP port on which to enable the go debugger
 	DebugPort = ffc("debug.port")
 	// DebugAddress the HTTP interface for the debugger to listen on
@@ -245,6 +413,8 @@ var (
 	EventAggregatorPollTimeout = ffc("event.aggregator.pollTimeout")
 	// EventAggregatorRewindTimeout the minimum time to wait for rewinds to accumulate before resolving them
 	EventAggregatorRewindTimeout = ffc("event.aggregator.rewindTimeout")
+	// EventAggregatorGapReportDelay how long a gap in pins must persist after a re-query, before it is reported
+	EventAggregatorGapReportDelay = ffc("event.aggregator.gapReportDelay")
 	// EventAggregatorRewindQueueLength the size of the queue into the rewind dispatcher
 	EventAggregatorRewindQueueLength = ffc("event.aggregator.rewindQueueLength")
 	// EventAggregatorRewindQueryLimit safety limit on the maximum number of records to search when performing queries to search for rewinds
@@ -255,18 +425,40 @@ var (
 	EventAggregatorRetryInitDelay = ffc("event.aggregator.retry.initDelay")
 	// EventAggregatorRetryMaxDelay the maximum delay to use for retry of data base operations
 	EventAggregatorRetryMaxDelay = ffc("event.aggregator.retry.maxDelay")
+	// EventAggregatorWorkers the number of worker shards used to aggregate independent ordering contexts in parallel
+	EventAggregatorWorkers = ffc("event.aggregator.workers")
 	// EventDispatcherPollTimeout the time to wait without a notification of new events, before trying a select on the table
 	EventDispatcherPollTimeout = ffc("event.dispatcher.pollTimeout")
+	// EventDXReorderTimeout how long to hold messages received from a data exchange peer out of sequence, waiting for the missing messages
+	EventDXReorderTimeout = ffc("event.dx.reorderTimeout")
 	// EventDispatcherBufferLength the number of events + attachments an individual dispatcher should hold in memory ready for delivery to the subscription
 	EventDispatcherBufferLength = ffc("event.dispatcher.bufferLength")
 	// EventDispatcherBatchTimeout a short time to wait for new events to arrive before re-polling for new events
 	EventDispatcherBatchTimeout = ffc("event.dispatcher.batchTimeout")
+	// EventDispatcherPriorityStarvationLimit the maximum number of times the oldest waiting event can be overtaken by higher priority events
+	EventDispatcherPriorityStarvationLimit = ffc("event.dispatcher.priorityStarvationLimit")
 	// EventDispatcherRetryFactor the backoff factor to use for retry of database operations
 	EventDispatcherRetryFactor = ffc("event.dispatcher.retry.factor")
 	// EventDispatcherRetryInitDelay he initial delay to use for retry of data base operations
 	EventDispatcherRetryInitDelay = ffc("event.dispatcher.retry.initDelay")
 	// EventDispatcherRetryMaxDelay he maximum delay to use for retry of data base operations
 	EventDispatcherRetryMaxDelay = ffc("event.dispatcher.retry.maxDelay")
+	// EventInboundQueueEnabled whether events from plugin connectors are stored in a persistent queue, and acknowledged, before they are processed
+	EventInboundQueueEnabled = ffc("event.inboundQueue.enabled")
+	// EventInboundQueueDatabase the name of the database plugin that stores the inbound event queue
+	EventInboundQueueDatabase = ffc("event.inboundQueue.database")
+	// EventInboundQueueMaxLength the maximum number of events queued for each plugin connection, before reading from the connection is paused
+	EventInboundQueueMaxLength = ffc("event.inboundQueue.maxLength")
+	// EventInboundQueueBatchSize the maximum number of queued events to read from the database at a time
+	EventInboundQueueBatchSize = ffc("event.inboundQueue.batchSize")
+	// EventInboundQueuePollTimeout the time to wait without a notification of new events, before checking the queue in the database
+	EventInboundQueuePollTimeout = ffc("event.inboundQueue.pollTimeout")
+	// EventInboundQueueRetryFactor the backoff factor to use for retry of processing queued events
+	EventInboundQueueRetryFactor = ffc("event.inboundQueue.retry.factor")
+	// EventInboundQueueRetryInitDelay the initial delay to use for retry of processing queued events
+	EventInboundQueueRetryInitDelay = ffc("event.inboundQueue.retry.initDelay")
+	// EventInboundQueueRetryMaxDelay the maximum delay to use for retry of processing queued events
+	EventInboundQueueRetryMaxDelay = ffc("event.inboundQueue.retry.maxDelay")
 	// EventDBEventsBufferSize the size of the buffer of change events
 	EventDBEventsBufferSize = ffc("event.dbevents.bufferSize")
 	// LegacyAdminEnabled is the deprecated key that pre-dates spi.enabled
@@ -305,6 +497,8 @@ var (
 	NodeName = ffc("node.name")
 	// NodeDescription is a description for the node
 	NodeDescription = ffc("node.description")
+	// PeerMonitorInterval is how often the data exchange connectivity of the nodes in each namespace is checked
+	PeerMonitorInterval = ffc("peermonitor.interval")
 	// OpUpdateRetryInitDelay is the initial retry delay
 	OpUpdateRetryInitDelay = ffc("opupdate.retry.initialDelay")
 	// OpUpdatedRetryMaxDelay is the maximum retry delay
@@ -341,6 +535,14 @@ var (
 	SubscriptionsRetryFactor = ffc("subscription.retry.factor")
 	// SubscriptionMaxHistoricalEventScanLength the maximum amount of historical events we scan for in the DB when indexing through old events against a subscription
 	SubscriptionMaxHistoricalEventScanLength = ffc("subscription.events.maxScanLength")
+	// SubscriptionSLAEnabled records daily aggregates of the time taken for subscriptions to acknowledge events
+	SubscriptionSLAEnabled = ffc("subscription.sla.enabled")
+	// SubscriptionSLATarget is the acknowledgement latency that the SLA aggregates count deliveries within
+	SubscriptionSLATarget = ffc("subscription.sla.target")
+	// SubscriptionSLAFlushInterval is how often the in-memory SLA aggregates are written to the database
+	SubscriptionSLAFlushInterval = ffc("subscription.sla.flushInterval")
+	// StatsResyncInterval how often the incrementally maintained namespace statistics are resynchronized with the database
+	StatsResyncInterval = ffc("stats.resyncInterval")
 	// TransactionWriterCount
 	TransactionWriterCount = ffc("transaction.writer.count")
 	// TransactionWriterBatchTimeout
@@ -354,6 +556,10 @@ var (
 	UIEnabled = ffc("ui.enabled")
 	// UIPath the path on which to serve the UI
 	UIPath = ffc("ui.path")
+	// ValidationPolicy determines what happens to received messages whose data fails validation - enforce, warn or quarantine
+	ValidationPolicy = ffc("validation.policy")
+	// ValidationProtobufMaxUploadSize is the largest protobuf payload that can be uploaded as the value of a piece of data
+	ValidationProtobufMaxUploadSize = ffc("validation.protobuf.maxUploadSize")
 )
 
 func setDefaults() {
@@ -365,21 +571,38 @@ func setDefaults() {
 	viper.SetDefault(string(APIMaxFilterSkip), 1000) // protects database (skip+limit pagination is not for bulk operations)
 	viper.SetDefault(string(APIRequestTimeout), "120s")
 	viper.SetDefault(string(APIPassthroughHeaders), []string{})
+	viper.SetDefault(string(APIMaxRequestBodySize), "10Mb")
+	viper.SetDefault(string(APIKeysHeader), "X-FireFly-API-Key")
+	viper.SetDefault(string(APIKeysRequired), false)
 	viper.SetDefault(string(AssetManagerKeyNormalization), "blockchain_plugin")
 	viper.SetDefault(string(CacheBatchLimit), 100)
 	viper.SetDefault(string(CacheBatchTTL), "5m")
+	viper.SetDefault(string(BatchAdaptiveEnabled), false)
+	viper.SetDefault(string(BatchAdaptiveMinSize), 10)
+	viper.SetDefault(string(BatchAdaptiveMinPayloadLimit), "64Kb")
+	viper.SetDefault(string(BatchAdaptiveTargetLatency), "5s")
+	viper.SetDefault(string(BatchAdaptiveGrowthFactor), 1.25)
 	viper.SetDefault(string(BatchManagerReadPageSize), 100)
 	viper.SetDefault(string(BatchManagerReadPollTimeout), "30s")
 	viper.SetDefault(string(BatchManagerMinimumPollDelay), "100ms")
+	viper.SetDefault(string(BatchManagerWorkers), 0)
+	viper.SetDefault(string(BatchManagerQueueDepth), 0)
 	viper.SetDefault(string(BatchRetryFactor), 2.0)
 	viper.SetDefault(string(BatchRetryFactor), 2.0)
 	viper.SetDefault(string(BatchRetryInitDelay), "250ms")
 	viper.SetDefault(string(BatchRetryInitDelay), "250ms")
 	viper.SetDefault(string(BatchRetryMaxDelay), "30s")
 	viper.SetDefault(string(BatchRetryMaxDelay), "30s")
+	viper.SetDefault(string(BlobGCGracePeriod), "1h")
+	viper.SetDefault(string(BlobGCPageSize), 100)
+	viper.SetDefault(string(BlobGCRetention), 0)
 	viper.SetDefault(string(BlobReceiverRetryInitDelay), "250ms")
 	viper.SetDefault(string(BlobReceiverRetryMaxDelay), "1m")
 	viper.SetDefault(string(BlobReceiverRetryFactor), 2.0)
+	viper.SetDefault(string(DatatypeMigrationPageSize), 100)
+	viper.SetDefault(string(DatatypeMigrationRetryInitDelay), "250ms")
+	viper.SetDefault(string(DatatypeMigrationRetryMaxDelay), "1m")
+	viper.SetDefault(string(DatatypeMigrationRetryFactor), 2.0)
 	viper.SetDefault(string(BlobReceiverWorkerBatchTimeout), "50ms")
 	viper.SetDefault(string(BlobReceiverWorkerCount), 5)
 	viper.SetDefault(string(BlobReceiverWorkerBatchMaxInserts), 200)
@@ -388,6 +611,8 @@ func setDefaults() {
 	viper.SetDefault(string(BroadcastBatchAgentTimeout), "2m")
 	viper.SetDefault(string(BroadcastBatchSize), 200)
 	viper.SetDefault(string(BroadcastBatchPayloadLimit), "800Kb")
+	viper.SetDefault(string(BroadcastBatchCompression), "none")
+	viper.SetDefault(string(BroadcastBatchTopicAffinity), false)
 	viper.SetDefault(string(BroadcastBatchTimeout), "1s")
 	viper.SetDefault(string(CacheBlockchainLimit), 100)
 	viper.SetDefault(string(CacheBlockchainTTL), "5m")
@@ -398,9 +623,19 @@ func setDefaults() {
 	viper.SetDefault(string(CacheOperationsTTL), "5m")
 	viper.SetDefault(string(CacheMethodsLimit), 200)
 	viper.SetDefault(string(CacheMethodsTTL), "5m")
+	viper.SetDefault(string(CacheContractQueryLimit), 1000)
+	viper.SetDefault(string(CacheContractQueryTTL), "5m")
+	viper.SetDefault(string(ChaosEnabled), false)
+	viper.SetDefault(string(ClusterEnabled), false)
+	viper.SetDefault(string(ClusterLeaseTTL), "15s")
+	viper.SetDefault(string(ClusterLeaseRenewInterval), "5s")
+	viper.SetDefault(string(HashAlgorithm), "sha256")
+	viper.SetDefault(string(HashCanonicalization), "none")
 	viper.SetDefault(string(HistogramsMaxChartRows), 100)
 	viper.SetDefault(string(DebugPort), -1)
 	viper.SetDefault(string(DebugAddress), "localhost")
+	viper.SetDefault(string(DownloadPriorityBatch), 10)
+	viper.SetDefault(string(DownloadPriorityBlob), 0)
 	viper.SetDefault(string(DownloadWorkerCount), 10)
 	viper.SetDefault(string(DownloadRetryMaxAttempts), 100)
 	viper.SetDefault(string(DownloadRetryInitDelay), "100ms")
@@ -411,19 +646,32 @@ func setDefaults() {
 	viper.SetDefault(string(EventAggregatorBatchTimeout), "0ms")
 	viper.SetDefault(string(EventAggregatorPollTimeout), "30s")
 	viper.SetDefault(string(EventAggregatorRewindTimeout), "50ms")
+	viper.SetDefault(string(EventAggregatorGapReportDelay), "1m")
 	viper.SetDefault(string(EventAggregatorRewindQueueLength), 10)
 	viper.SetDefault(string(EventAggregatorRewindQueryLimit), 1000)
 	viper.SetDefault(string(EventAggregatorRetryFactor), 2.0)
 	viper.SetDefault(string(EventAggregatorRetryInitDelay), "100ms")
 	viper.SetDefault(string(EventAggregatorRetryMaxDelay), "30s")
+	viper.SetDefault(string(EventAggregatorWorkers), 1)
 	viper.SetDefault(string(EventDBEventsBufferSize), 100)
 	viper.SetDefault(string(EventDispatcherBufferLength), 5)
 	viper.SetDefault(string(EventDispatcherBatchTimeout), "0ms")
+	viper.SetDefault(string(EventDispatcherPriorityStarvationLimit), 10)
 	viper.SetDefault(string(EventDispatcherPollTimeout), "30s")
+	viper.SetDefault(string(EventDXReorderTimeout), "30s")
+	viper.SetDefault(string(EventInboundQueueEnabled), false)
+	viper.SetDefault(string(EventInboundQueueMaxLength), 1000)
+	viper.SetDefault(string(EventInboundQueueBatchSize), 50)
+	viper.SetDefault(string(EventInboundQueuePollTimeout), "30s")
+	viper.SetDefault(string(EventInboundQueueRetryFactor), 2.0)
+	viper.SetDefault(string(EventInboundQueueRetryInitDelay), "100ms")
+	viper.SetDefault(string(EventInboundQueueRetryMaxDelay), "30s")
 	viper.SetDefault(string(EventTransportsEnabled), []string{"websockets", "webhooks"})
 	viper.SetDefault(string(EventTransportsDefault), "websockets")
 	viper.SetDefault(string(CacheEventListenerTopicLimit), 100)
 	viper.SetDefault(string(CacheEventListenerTopicTTL), "5m")
+	viper.SetDefault(string(CacheEventDecoderLimit), 1000)
+	viper.SetDefault(string(CacheEventDecoderTTL), "1h")
 	viper.SetDefault(string(CacheGroupLimit), 50)
 	viper.SetDefault(string(CacheGroupTTL), "1h")
 	viper.SetDefault(string(SPIEnabled), false)
@@ -441,6 +689,7 @@ func setDefaults() {
 	viper.SetDefault(string(NamespacesRetryMaxDelay), "1m")
 	viper.SetDefault(string(NamespacesRetryInitDelay), "5s")
 	viper.SetDefault(string(OrchestratorStartupAttempts), 5)
+	viper.SetDefault(string(PeerMonitorInterval), "30s")
 	viper.SetDefault(string(OpUpdateRetryInitDelay), "250ms")
 	viper.SetDefault(string(OpUpdateRetryMaxDelay), "1m")
 	viper.SetDefault(string(OpUpdateRetryFactor), 2.0)
@@ -454,7 +703,13 @@ func setDefaults() {
 	viper.SetDefault(string(PrivateMessagingBatchAgentTimeout), "2m")
 	viper.SetDefault(string(PrivateMessagingBatchSize), 200)
 	viper.SetDefault(string(PrivateMessagingBatchTimeout), "1s")
+	viper.SetDefault(string(PrivateMessagingTransfersMaxParallel), 0)
+	viper.SetDefault(string(PrivateMessagingTransfersMaxParallelPerPeer), 0)
+	viper.SetDefault(string(PrivateMessagingTransfersBytesPerSecond), "0")
+	viper.SetDefault(string(PrivateMessagingTransfersBytesPerSecondPerPeer), "0")
 	viper.SetDefault(string(PrivateMessagingBatchPayloadLimit), "800Kb")
+	viper.SetDefault(string(PrivateMessagingBatchCompression), "none")
+	viper.SetDefault(string(PrivateMessagingBatchTopicAffinity), false)
 	viper.SetDefault(string(SubscriptionDefaultsBatchSize), 50)
 	viper.SetDefault(string(SubscriptionDefaultsBatchTimeout), "50ms")
 	viper.SetDefault(string(SubscriptionMax), 500)
@@ -462,12 +717,18 @@ func setDefaults() {
 	viper.SetDefault(string(SubscriptionsRetryMaxDelay), "30s")
 	viper.SetDefault(string(SubscriptionsRetryFactor), 2.0)
 	viper.SetDefault(string(SubscriptionMaxHistoricalEventScanLength), 1000)
+	viper.SetDefault(string(SubscriptionSLAEnabled), true)
+	viper.SetDefault(string(SubscriptionSLATarget), "1m")
+	viper.SetDefault(string(SubscriptionSLAFlushInterval), "10s")
+	viper.SetDefault(string(StatsResyncInterval), "1m")
 	viper.SetDefault(string(TransactionWriterBatchMaxTransactions), 100)
 	viper.SetDefault(string(TransactionWriterBatchTimeout), "10ms")
 	viper.SetDefault(string(TransactionWriterCount), 5)
 	viper.SetDefault(string(CacheTransactionSize), "1Mb")
 	viper.SetDefault(string(CacheTransactionTTL), "5m")
 	viper.SetDefault(string(UIEnabled), true)
+	viper.SetDefault(string(ValidationPolicy), "enforce")
+	viper.SetDefault(string(ValidationProtobufMaxUploadSize), "1Mb")
 	viper.SetDefault(string(CacheValidatorSize), "1Mb")
 	viper.SetDefault(string(CacheValidatorTTL), "1h")
 	viper.SetDefault(string(CacheIdentityLimit), 100)
@@ -29,6 +29,7 @@ var (
 	APIParamsContractListenerID             = ffm("api.params.contractListenerID", "The contract listener ID")
 	APIParamsSubscriptionID                 = ffm("api.params.subscriptionID", "The subscription ID")
 	APIParamsBatchID                        = ffm("api.params.batchId", "The batch ID")
+	APIParamsDelegationID                   = ffm("api.params.delegationId", "The delegation ID")
 	APIParamsBlockchainEventID              = ffm("api.params.blockchainEventID", "The blockchain event ID")
 	APIParamsCollectionID                   = ffm("api.params.collectionID", "The collection ID")
 	APIParamsContractAPIName                = ffm("api.params.contractAPIName", "The name of the contract API")
@@ -37,7 +38,11 @@ var (
 	APIParamsContractInterfaceID            = ffm("api.params.contractInterfaceID", "The ID of the contract interface")
 	APIParamsContractInterfaceFetchChildren = ffm("api.params.contractInterfaceFetchChildren", "When set, the API will return the full FireFly Interface document including all methods, events, and parameters")
 	APIParamsNSIncludeInitializing          = ffm("api.params.nsIncludeInitializing", "When set, the API will return namespaces even if they are not yet initialized, including in error cases where an initializationError is included")
+	APIParamsContractABIID                  = ffm("api.params.contractABIID", "The ID of the ABI in the blockchain connector's ABI store")
 	APIParamsBlobID                         = ffm("api.params.blobID", "The blob ID")
+	APIParamsBlobCollectDryRun              = ffm("api.params.blobCollectDryRun", "When set, the blobs that are eligible for collection are reported but not deleted")
+	APIParamsAddressBookEntryName           = ffm("api.params.addressBookEntryName", "The name of the address book entry")
+	APIParamsAPIKeyID                       = ffm("api.params.apiKeyID", "The API key ID")
 	APIParamsDataID                         = ffm("api.params.dataID", "The data item ID")
 	APIParamsDatatypeName                   = ffm("api.params.datatypeName", "The name of the datatype")
 	APIParamsDatatypeVersion                = ffm("api.params.datatypeVersion", "The version of the datatype")
@@ -49,6 +54,7 @@ var (
 	APIParamsFetchVerifiers                 = ffm("api.params.fetchVerifiers", "When set, the API will return the verifier for this identity")
 	APIParamsIdentityID                     = ffm("api.params.identityID", "The identity ID, which is a UUID generated by FireFly")
 	APIParamsMessageID                      = ffm("api.params.messageID", "The message ID")
+	APIParamsQuarantineEntryID              = ffm("api.params.quarantineEntryID", "The quarantine entry ID")
 	APIParamsDID                            = ffm("api.params.DID", "The identity DID")
 	APIParamsNodeNameOrID                   = ffm("api.params.nodeNameOrID", "The name or ID of the node")
 	APIParamsOrgNameOrID                    = ffm("api.params.orgNameOrID", "The name or ID of the org")
@@ -61,40 +67,65 @@ var (
 	APIParamsMethodPath                     = ffm("api.params.methodPath", "The name or uniquely generated path name of a method on a smart contract")
 	APIParamsEventPath                      = ffm("api.params.eventPath", "The name or uniquely generated path name of a event on a smart contract")
 	APIParamsInterfaceID                    = ffm("api.params.interfaceID", "The contract interface ID")
-	APIParamsValidator                      = ffm("api.params.validator", "The validator type for this data item. Options are: \"json\", \"none\", or \"definition\"")
+	APIParamsValidator                      = ffm("api.params.validator", "The validator type for this data item. Options are: \"json\", \"none\", \"definition\", or \"protobuf\". With \"protobuf\", the uploaded file is the encoded protobuf message, which is stored as the value of the data rather than as a blob")
 	APIParamsMetadata                       = ffm("api.params.metadata", "Metadata associated with this data item")
 	APIParamsAutometa                       = ffm("api.params.autometa", "When set, FireFly will automatically generate JSON metadata with the upload details")
 	APIParamsContractAPIID                  = ffm("api.params.contractAPIID", "The ID of the contract API")
 	APIParamsFetchStatus                    = ffm("api.params.fetchStatus", "When set, the API will return additional status information if available")
+	APIParamsPluginType                     = ffm("api.params.pluginType", "The type of the plugin - blockchain, database, dataexchange, sharedstorage, tokens, identity, events or auth")
+	APIParamsPluginName                     = ffm("api.params.pluginName", "The name of the plugin, as configured")
 
-	APIEndpointsAdminGetNamespaceByName = ffm("api.endpoints.adminGetNamespaceByName", "Gets a namespace by name")
-	APIEndpointsAdminGetNamespaces      = ffm("api.endpoints.adminGetNamespaces", "List namespaces")
-	APIEndpointsAdminGetOpByID          = ffm("api.endpoints.adminGetOpByID", "Gets an operation by ID")
-	APIEndpointsAdminGetOps             = ffm("api.endpoints.adminGetOps", "Lists operations")
-	APIEndpointsAdminPostReset          = ffm("api.endpoints.adminPostResetConfig", "Restarts FireFly Core HTTP servers and apply all configuration updates")
-	APIEndpointsAdminPatchOpByID        = ffm("api.endpoints.adminPatchOpByID", "Updates an operation by ID")
-	APIEndpointsAdminGetListenerByID    = ffm("api.endpoints.adminGetListenerByID", "Gets a contract listener by ID")
-	APIEndpointsAdminGetListeners       = ffm("api.endpoints.adminGetListeners", "Lists contract listeners")
+	APIEndpointsAdminGetNamespaceByName    = ffm("api.endpoints.adminGetNamespaceByName", "Gets a namespace by name")
+	APIEndpointsAdminGetNamespaces         = ffm("api.endpoints.adminGetNamespaces", "List namespaces")
+	APIEndpointsAdminGetOpByID             = ffm("api.endpoints.adminGetOpByID", "Gets an operation by ID")
+	APIEndpointsAdminGetOps                = ffm("api.endpoints.adminGetOps", "Lists operations")
+	APIEndpointsAdminGetRecovery           = ffm("api.endpoints.adminGetRecovery", "Gets the report of the operations and batches that were recovered when the namespace started")
+	APIEndpointsAdminPostReset             = ffm("api.endpoints.adminPostResetConfig", "Restarts FireFly Core HTTP servers and apply all configuration updates")
+	APIEndpointsAdminPostRestartPlugin     = ffm("api.endpoints.adminPostRestartPlugin", "Re-reads the configuration and restarts a single plugin, along with the namespaces that use it")
+	APIEndpointsAdminPatchOpByID           = ffm("api.endpoints.adminPatchOpByID", "Updates an operation by ID")
+	APIEndpointsAdminGetListenerByID       = ffm("api.endpoints.adminGetListenerByID", "Gets a contract listener by ID")
+	APIEndpointsAdminGetListeners          = ffm("api.endpoints.adminGetListeners", "Lists contract listeners")
+	APIEndpointsAdminGetDefinitions        = ffm("api.endpoints.adminGetDefinitionsExport", "Exports the definitions of the namespace as a portable bundle")
+	APIEndpointsAdminPostDefinitions       = ffm("api.endpoints.adminPostDefinitionsImport", "Imports a bundle of definitions exported from another namespace, defining any that do not already exist")
+	APIEndpointsAdminPostSandboxReplay     = ffm("api.endpoints.adminPostSandboxReplay", "Clones the definitions of another namespace into this sandbox namespace, and replays a range of its events through it")
+	APIEndpointsAdminGetTransferLimits     = ffm("api.endpoints.adminGetTransferLimits", "Gets the limits currently applied to blob transfers to other nodes")
+	APIEndpointsAdminPutTransferLimits     = ffm("api.endpoints.adminPutTransferLimits", "Updates the limits applied to blob transfers to other nodes, taking effect immediately")
+	APIEndpointsAdminPostBlobsCollect      = ffm("api.endpoints.adminPostBlobsCollect", "Deletes the blobs that are no longer referenced by any message within the retention period")
+	APIEndpointsAdminGetContractABIs       = ffm("api.endpoints.adminGetContractABIs", "Lists the ABIs stored in the blockchain connector")
+	APIEndpointsAdminGetContractABI        = ffm("api.endpoints.adminGetContractABI", "Gets an ABI stored in the blockchain connector by ID")
+	APIEndpointsAdminPostContractABI       = ffm("api.endpoints.adminPostContractABI", "Uploads an ABI, and optionally its compiled bytecode, to the blockchain connector")
+	APIEndpointsAdminPostContractABIDeploy = ffm("api.endpoints.adminPostContractABIDeploy", "Deploys a contract from an ABI stored in the blockchain connector, tracking the deployment as a FireFly operation")
 
+	APIEndpointsDeleteAddressBookEntry          = ffm("api.endpoints.deleteAddressBookEntry", "Deletes an entry from the address book of the namespace")
+	APIEndpointsDeleteAPIKey                    = ffm("api.endpoints.deleteAPIKey", "Revokes an API key of the namespace. Requests using the key are rejected immediately")
+	APIEndpointsDeleteQuarantineEntry           = ffm("api.endpoints.deleteQuarantineEntry", "Purges a quarantined item, discarding it without processing")
 	APIEndpointsDeleteContractAPI               = ffm("api.endpoints.deleteContractAPI", "Delete a contract API")
 	APIEndpointsDeleteContractInterface         = ffm("api.endpoints.deleteContractInterface", "Delete a contract interface")
 	APIEndpointsDeleteContractListener          = ffm("api.endpoints.deleteContractListener", "Deletes a contract listener referenced by its name or its ID")
 	APIEndpointsDeleteSubscription              = ffm("api.endpoints.deleteSubscription", "Deletes a subscription")
 	APIEndpointsDeleteTokenPool                 = ffm("api.endpoints.deleteTokenPool", "Delete a token pool")
+	APIEndpointsGetBatchAnchorProof             = ffm("api.endpoints.getBatchAnchorProof", "Gets the Merkle inclusion proof of a batch in the digest that was pinned to the anchor chain")
 	APIEndpointsGetBatchBbyID                   = ffm("api.endpoints.getBatchByID", "Gets a message batch")
+	APIEndpointsGetAddressBook                  = ffm("api.endpoints.getAddressBook", "Gets a list of entries in the address book of the namespace")
+	APIEndpointsGetAddressBookEntryByName       = ffm("api.endpoints.getAddressBookEntryByName", "Gets an entry in the address book of the namespace by name")
+	APIEndpointsGetAPIKeyByID                   = ffm("api.endpoints.getAPIKeyByID", "Gets an API key of the namespace. The key itself is not returned")
+	APIEndpointsGetAPIKeys                      = ffm("api.endpoints.getAPIKeys", "Gets a list of the API keys of the namespace. The keys themselves are not returned")
 	APIEndpointsGetBatches                      = ffm("api.endpoints.getBatches", "Gets a list of message batches")
+	APIEndpointsGetBatchPayload                 = ffm("api.endpoints.getBatchPayload", "Gets the full payload of a batch, as it would be written to shared storage. Used to distribute the payload of a pin-only broadcast to other members")
 	APIEndpointsGetBlockchainEventByID          = ffm("api.endpoints.getBlockchainEventByID", "Gets a blockchain event")
 	APIEndpointsListBlockchainEvents            = ffm("api.endpoints.getBlockchainEvents", "Gets a list of blockchain events")
+	APIEndpointsGetChartAggregate               = ffm("api.endpoints.getChartAggregate", "Gets the counts of a database collection in time buckets of a fixed interval, aggregated by the database. Buckets are aligned to the interval, and broken down by type where the collection has one")
 	APIEndpointsGetChartHistogram               = ffm("api.endpoints.getChartHistogram", "Gets a JSON object containing statistics data that can be used to build a graphical representation of recent activity in a given database collection")
 	APIEndpointsGetContractAPIByName            = ffm("api.endpoints.getContractAPIByName", "Gets information about a contract API, including the URLs for the OpenAPI Spec and Swagger UI for the API")
 	APIEndpointsGetContractAPIs                 = ffm("api.endpoints.getContractAPIs", "Gets a list of contract APIs that have been published")
 	APIEndpointsGetContractInterfaceNameVersion = ffm("api.endpoints.getContractInterfaceNameVersion", "Gets a contract interface by its name and version")
 	APIEndpointsGetContractInterface            = ffm("api.endpoints.getContractInterface", "Gets a contract interface by its ID")
 	APIEndpointsGetContractInterfaces           = ffm("api.endpoints.getContractInterfaces", "Gets a list of contract interfaces that have been published")
+	APIEndpointsGetContractSharedInterfaces     = ffm("api.endpoints.getContractSharedInterfaces", "Gets the registry of contract interfaces that have been shared across namespaces")
 	APIEndpointsGetContractListenerByNameOrID   = ffm("api.endpoints.getContractListenerByNameOrID", "Gets a contract listener by its name or ID")
 	APIEndpointsGetContractListeners            = ffm("api.endpoints.getContractListeners", "Gets a list of contract listeners")
 	APIEndpointsGetDataBlob                     = ffm("api.endpoints.getDataBlob", "Downloads the original file that was previously uploaded or received")
-	APIEndpointsGetDataValue                    = ffm("api.endpoints.getDataValue", "Downloads the JSON value of the data resource, without the associated metadata")
+	APIEndpointsGetDataValue                    = ffm("api.endpoints.getDataValue", "Downloads the JSON value of the data resource, without the associated metadata. For protobuf data, send an Accept header of application/x-protobuf to download the encoded message")
 	APIEndpointsGetDataByID                     = ffm("api.endpoints.getDataByID", "Gets a data item by its ID, including metadata about this item")
 	APIEndpointsDeleteData                      = ffm("api.endpoints.deleteData", "Deletes a data item by its ID, including metadata about this item")
 	APIEndpointsGetDataMsgs                     = ffm("api.endpoints.getDataMsgs", "Gets a list of the messages associated with a data item")
@@ -102,9 +133,12 @@ var (
 	APIEndpointsGetDataSubPaths                 = ffm("api.endpoints.getDataSubPaths", "Gets a list of path names of named blob data, underneath a given parent path ('/' path prefixes are automatically pre-prepended)")
 	APIEndpointsGetDatatypeByName               = ffm("api.endpoints.getDatatypeByName", "Gets a datatype by its name and version")
 	APIEndpointsGetDatatypes                    = ffm("api.endpoints.getDatatypes", "Gets a list of datatypes that have been published")
+	APIEndpointsGetDelegationByID               = ffm("api.endpoints.getDelegationByID", "Gets a confirmed delegation by its ID")
+	APIEndpointsGetDelegations                  = ffm("api.endpoints.getDelegations", "Gets a list of confirmed delegations, that authorize one identity to submit messages or transfers on behalf of another")
 	APIEndpointsGetEventByID                    = ffm("api.endpoints.eventID", "Gets an event by its ID")
 	APIEndpointsGetEvents                       = ffm("api.endpoints.getEvents", "Gets a list of events")
 	APIEndpointsGetGroupByHash                  = ffm("api.endpoints.getGroupByHash", "Gets a group by its ID (hash)")
+	APIEndpointsGetGroupMembersResolved         = ffm("api.endpoints.getGroupMembersResolved", "Gets the members of a group resolved to their orgs and nodes, with the data exchange endpoint and peer status of each node")
 	APIEndpointsGetGroups                       = ffm("api.endpoints.getGroups", "Gets a list of groups")
 	APIEndpointsGetIdentities                   = ffm("api.endpoints.getIdentities", "Gets a list of all identities that have been registered in the namespace")
 	APIEndpointsGetIdentityByID                 = ffm("api.endpoints.getIdentityByID", "Gets an identity by its ID")
@@ -113,8 +147,12 @@ var (
 	APIEndpointsGetMsgByID                      = ffm("api.endpoints.getMsgByID", "Gets a message by its ID")
 	APIEndpointsGetMsgData                      = ffm("api.endpoints.getMsgData", "Gets the list of data items that are attached to a message")
 	APIEndpointsGetMsgEvents                    = ffm("api.endpoints.getMsgEvents", "Gets the list of events for a message")
+	APIEndpointsGetMsgProof                     = ffm("api.endpoints.getMsgProof", "Gets a self-contained proof that a message was pinned to the blockchain, which can be verified offline")
 	APIEndpointsGetMsgTxn                       = ffm("api.endpoints.getMsgTxn", "Gets the transaction for a message")
 	APIEndpointsGetMsgs                         = ffm("api.endpoints.getMsgs", "Gets a list of messages")
+	APIEndpointsGetQuarantinedMsgs              = ffm("api.endpoints.getQuarantinedMsgs", "Gets the list of received messages held for review, because their data failed validation")
+	APIEndpointsGetQuarantineEntries            = ffm("api.endpoints.getQuarantineEntries", "Gets the list of received items quarantined because they failed hash checks or data validation, with the reason for each")
+	APIEndpointsGetQuarantineEntryByID          = ffm("api.endpoints.getQuarantineEntryByID", "Gets a quarantine entry by its ID")
 	APIEndpointsGetNamespace                    = ffm("api.endpoints.getNamespace", "Gets a namespace")
 	APIEndpointsGetNamespaces                   = ffm("api.endpoints.getNamespaces", "Gets a list of namespaces")
 	APIEndpointsGetNetworkIdentityByDID         = ffm("api.endpoints.getNetworkIdentityByDID", "Gets an identity by its DID (deprecated - use /identities/{did} instead of /network/identities/{did})")
@@ -122,6 +160,7 @@ var (
 	APIEndpointsGetDIDDocByDID                  = ffm("api.endpoints.getDIDDocByDID", "Gets a DID document by its DID")
 	APIEndpointsGetNetworkIdentities            = ffm("api.endpoints.getNetworkIdentities", "Gets the list of identities in the network (deprecated - use /identities instead of /network/identities")
 	APIEndpointsGetNetworkNode                  = ffm("api.endpoints.getNetworkNode", "Gets information about a specific node in the network")
+	APIEndpointsGetNetworkNodeStatus            = ffm("api.endpoints.getNetworkNodeStatus", "Gets the data exchange connectivity of a specific node in the network, as seen from the local node")
 	APIEndpointsGetNetworkNodes                 = ffm("api.endpoints.getNetworkNodes", "Gets a list of nodes in the network")
 	APIEndpointsGetNetworkOrg                   = ffm("api.endpoints.getNetworkOrg", "Gets information about a specific org in the network")
 	APIEndpointsGetNetworkOrgs                  = ffm("api.endpoints.APIEndpointsGetNetworkOrgs", "Gets a list of orgs in the network")
@@ -132,83 +171,124 @@ var (
 	APIEndpointsGetNextPins                     = ffm("api.endpoints.getNextPins", "Queries the list of next-pins that determine the next masked message sequence for each member of a privacy group, on each context/topic")
 	APIEndpointsGetWebSockets                   = ffm("api.endpoints.getStatusWebSockets", "Gets a list of the current WebSocket connections to this node")
 	APIEndpointsGetStatus                       = ffm("api.endpoints.getStatus", "Gets the status of this namespace")
+	APIEndpointsGetStats                        = ffm("api.endpoints.getStats", "Gets counts and rates of activity in this namespace, maintained incrementally by the node")
 	APIEndpointsGetMultipartyStatus             = ffm("api.endpoints.getMultipartyStatus", "Gets the registration status of this organization and node on the configured multiparty network")
 	APIEndpointsGetSubscriptionByID             = ffm("api.endpoints.getSubscriptionByID", "Gets a subscription by its ID")
 	APIEndpointsGetSubscriptionEventsFiltered   = ffm("api.endpoints.getSubscriptionEventsFiltered", "Gets a collection of events filtered by the subscription for further filtering")
+	APIEndpointsGetSubscriptionSLA              = ffm("api.endpoints.getSubscriptionSLA", "Gets the daily event delivery SLA statistics for a subscription")
 	APIEndpointsGetSubscriptions                = ffm("api.endpoints.getSubscriptions", "Gets a list of subscriptions")
+	APIEndpointsGetTokenAccountByKey            = ffm("api.endpoints.getTokenAccountByKey", "Gets the balances and recent activity of a token account, aggregated across all token pools and connectors in the namespace")
+	APIEndpointsGetTokenAccountHistory          = ffm("api.endpoints.getTokenAccountHistory", "Exports the full transfer history of a token account within a pool, in order, with the running balance of the account after each transfer. Intended for reconciliation with off-chain ledgers")
 	APIEndpointsGetTokenAccountPools            = ffm("api.endpoints.getTokenAccountPools", "Gets a list of token pools that contain a given token account key")
 	APIEndpointsGetTokenAccounts                = ffm("api.endpoints.getTokenAccounts", "Gets a list of token accounts")
 	APIEndpointsGetTokenApprovals               = ffm("api.endpoints.getTokenApprovals", "Gets a list of token approvals")
 	APIEndpointsGetTokenBalances                = ffm("api.endpoints.getTokenBalances", "Gets a list of token balances")
 	APIEndpointsGetTokenConnectors              = ffm("api.endpoints.getTokenConnectors", "Gets the list of token connectors currently in use")
 	APIEndpointsGetTokenPoolByNameOrID          = ffm("api.endpoints.getTokenPoolByNameOrID", "Gets a token pool by its name or its ID")
+	APIEndpointsGetTokenPoolTemplates           = ffm("api.endpoints.getTokenPoolTemplates", "Gets the templates configured in the namespace for creating token pools")
 	APIEndpointsGetTokenPools                   = ffm("api.endpoints.getTokenPools", "Gets a list of token pools")
 	APIEndpointsGetTokenTransferByID            = ffm("api.endpoints.getTokenTransferByID", "Gets a token transfer by its ID")
 	APIEndpointsGetTokenTransfers               = ffm("api.endpoints.getTokenTransfers", "Gets a list of token transfers")
 	APIEndpointsGetTxnBlockchainEvents          = ffm("api.endpoints.getTxnBlockchainEvents", "Gets a list blockchain events for a specific transaction")
 	APIEndpointsGetTxnByID                      = ffm("api.endpoints.getTxnByID", "Gets a transaction by its ID")
-	APIEndpointsGetTxnOps                       = ffm("api.endpoints.getTxnOps", "Gets a list of operations in a specific transaction")
+	APIEndpointsGetTxnCompensations             = ffm("api.endpoints.getTxnCompensations", "Gets the records of attempts to compensate the operations of a specific transaction")
+	APIEndpointsGetTxnEvents                    = ffm("api.endpoints.getTxnEvents", "Gets the FireFly events emitted for a specific transaction, in the order they were emitted")
+	APIEndpointsGetTxnOps                       = ffm("api.endpoints.getTxnOps", "Gets a list of operations in a specific transaction, in the order they were submitted")
 	APIEndpointsGetTxnStatus                    = ffm("api.endpoints.getTxnStatus", "Gets the status of a transaction")
 	APIEndpointsGetTxns                         = ffm("api.endpoints.getTxns", "Gets a list of transactions")
 	APIEndpointsGetVerifierByHash               = ffm("api.endpoints.getVerifierByHash", "Gets a verifier by its hash")
 	APIEndpointsGetVerifiers                    = ffm("api.endpoints.getVerifiers", "Gets a list of verifiers")
 	APIEndpointsPatchUpdateIdentity             = ffm("api.endpoints.patchUpdateIdentity", "Updates an identity")
+	APIEndpointsPostAddressBookEntry            = ffm("api.endpoints.postAddressBookEntry", "Creates an entry in the address book of the namespace, or updates the address of an existing entry with the same name. The name can then be used in place of the address in any key, to or from field of an API input")
+	APIEndpointsPostAPIKey                      = ffm("api.endpoints.postAPIKey", "Creates an API key granting access to the routes of the namespace allowed by its scope. The key is only returned in the response to this request - only a hash of it is stored")
 	APIEndpointsPostBatchCancel                 = ffm("api.endpoints.postBatchCancel", "Cancel a batch that has failed to dispatch")
+	APIEndpointsPostBatchPayload                = ffm("api.endpoints.postBatchPayload", "Attaches the payload of a batch that was pinned without being shared, such as a pin-only broadcast. The payload is verified against the hash pinned on-chain")
+	APIEndpointsPostBatchVerify                 = ffm("api.endpoints.postBatchVerify", "Re-computes the hash of a batch from the stored messages and data, and compares it to the hash pinned on-chain, returning a report of any mismatches")
 	APIEndpointsPostContractDeploy              = ffm("api.endpoints.postContractDeploy", "Deploy a new smart contract")
-	APIEndpointsPostContractAPIInvoke           = ffm("api.endpoints.postContractAPIInvoke", "Invokes a method on a smart contract API. Performs a blockchain transaction.")
+	APIEndpointsPostContractAPIInvoke           = ffm("api.endpoints.postContractAPIInvoke", "Invokes a method on a smart contract API. Performs a blockchain transaction. With confirm=true, waits for the transaction receipt and returns the return values and events of the transaction, decoded using the interface of the API")
 	APIEndpointsPostContractAPIPublish          = ffm("api.endpoints.postContractAPIPublish", "Publish a contract API to all other members of the multiparty network")
 	APIEndpointsPostContractAPIQuery            = ffm("api.endpoints.postContractAPIQuery", "Queries a method on a smart contract API. Performs a read-only query.")
 	APIEndpointsPostContractInterfaceGenerate   = ffm("api.endpoints.postContractInterfaceGenerate", "A convenience method to convert a blockchain specific smart contract format into a FireFly Interface format. The specific blockchain plugin in use must support this functionality.")
 	APIEndpointsPostContractInterfaceInvoke     = ffm("api.endpoints.postContractInterfaceInvoke", "Invokes a method on a smart contract that matches a given contract interface. Performs a blockchain transaction.")
 	APIEndpointsPostContractInterfaceQuery      = ffm("api.endpoints.postContractInterfaceQuery", "Queries a method on a smart contract that matches a given contract interface. Performs a read-only query.")
 	APIEndpointsPostContractInterfacePublish    = ffm("api.endpoints.postContractInterfacePublish", "Publish a contract interface to all other members of the multiparty network")
+	APIEndpointsPostContractInterfaceShare      = ffm("api.endpoints.postContractInterfaceShare", "Share a contract interface, so that contract APIs in any namespace can bind to it by name and version")
 	APIEndpointsPostContractInvoke              = ffm("api.endpoints.postContractInvoke", "Invokes a method on a smart contract. Performs a blockchain transaction.")
+	APIEndpointsPostContractInvokeBatch         = ffm("api.endpoints.postContractInvokeBatch", "Invokes a set of methods on smart contracts as the operations of a single transaction, optionally via an on-chain multicall aggregator")
 	APIEndpointsPostContractQuery               = ffm("api.endpoints.postContractQuery", "Queries a method on a smart contract. Performs a read-only query.")
 	APIEndpointsPostData                        = ffm("api.endpoints.postData", "Creates a new data item in this FireFly node")
+	APIEndpointsPostDataBulk                    = ffm("api.endpoints.postDataBulk", "Creates a set of new data items in this FireFly node in a single atomic operation, returning all of the stored data items")
 	APIEndpointsPostDataValuePublish            = ffm("api.endpoints.postDataValuePublish", "Publishes the JSON value from the specified data resource, to shared storage")
 	APIEndpointsPostDataBlobPublish             = ffm("api.endpoints.postDataBlobPublish", "Publishes the binary blob attachment stored in your local data exchange, to shared storage")
 	APIEndpointsPostNewContractAPI              = ffm("api.endpoints.postNewContractAPI", "Creates and broadcasts a new custom smart contract API")
 	APIEndpointsPostNewContractInterface        = ffm("api.endpoints.postNewContractInterface", "Creates and broadcasts a new custom smart contract interface")
 	APIEndpointsPostNewContractListener         = ffm("api.endpoints.postNewContractListener", "Creates a new blockchain listener for events emitted by custom smart contracts")
 	APIEndpointsPostContractListenerHash        = ffm("api.endpoints.postContractListenerHash", "Calculates the hash of a blockchain listener filters and events")
+	APIEndpointsPostInferDatatype               = ffm("api.endpoints.postInferDatatype", "Generates a JSON Schema datatype from a sample JSON payload, and optionally publishes it as a new datatype")
 	APIEndpointsPostNewDatatype                 = ffm("api.endpoints.postNewDatatype", "Creates and broadcasts a new datatype")
+	APIEndpointsPostNewDelegation               = ffm("api.endpoints.postNewDelegation", "Creates and broadcasts a delegation, signed by the delegating identity, that authorizes another identity to submit messages or transfers on its behalf")
 	APIEndpointsPostNewIdentity                 = ffm("api.endpoints.postNewIdentity", "Registers a new identity in the network")
+	APIEndpointsPostQuarantinedMsgReview        = ffm("api.endpoints.postQuarantinedMsgReview", "Accepts or rejects a message held for review, emitting a message_confirmed or message_rejected event")
+	APIEndpointsPostQuarantineEntryRevalidate   = ffm("api.endpoints.postQuarantineEntryRevalidate", "Re-validates a quarantined item. If it is now valid it is processed, and removed from the quarantine")
 	APIEndpointsPostNewMessageBroadcast         = ffm("api.endpoints.postNewMessageBroadcast", "Broadcasts a message to all members in the network")
 	APIEndpointsPostNewMessagePrivate           = ffm("api.endpoints.postNewMessagePrivate", "Privately sends a message to one or more members in the network")
 	APIEndpointsPostNewMessageRequestReply      = ffm("api.endpoints.postNewMessageRequestReply", "Sends a message with a blocking HTTP request, waits for a reply to that message, then sends the reply as the HTTP response.")
 	APIEndpointsPostNewNamespace                = ffm("api.endpoints.postNewNamespace", "Creates and broadcasts a new namespace")
+	APIEndpointsPatchNodesSelf                  = ffm("api.endpoints.patchNodesSelf", "Instructs this FireFly node to broadcast an update to its registered identity, advertising its current data exchange endpoint and the latest batch manifest version it supports")
 	APIEndpointsPostNodesSelf                   = ffm("api.endpoints.postNodesSelf", "Instructs this FireFly node to register itself on the network")
 	APIEndpointsPostNewOrganizationSelf         = ffm("api.endpoints.postNewOrganizationSelf", "Instructs this FireFly node to register its org on the network")
 	APIEndpointsPostNewOrganization             = ffm("api.endpoints.postNewOrganization", "Registers a new org in the network")
 	APIEndpointsPostNewSubscription             = ffm("api.endpoints.postNewSubscription", "Creates a new subscription for an application to receive events from FireFly")
+	APIEndpointsPostSubscriptionTest            = ffm("api.endpoints.postSubscriptionTest", "Evaluates the filters of a subscription against a sample event, or an existing event, and reports whether each filter matches")
+	APIEndpointsPostOpAttachTX                  = ffm("api.endpoints.postOpAttachTX", "Attaches a transaction that was submitted outside of FireFly to an operation, and tracks its receipt to complete the operation")
 	APIEndpointsPostOpRetry                     = ffm("api.endpoints.postOpRetry", "Retries a failed operation")
+	APIEndpointsPostTxnCompensate               = ffm("api.endpoints.postTxnCompensate", "Reverses the effect of the succeeded operations of a transaction, by submitting compensating actions in reverse order")
 	APIEndpointsPostPinsRewind                  = ffm("api.endpoints.postPinsRewind", "Force a rewind of the event aggregator to a previous position, to re-evaluate (and possibly dispatch) that pin and others after it. Only accepts a sequence or batch ID for a currently undispatched pin")
 	APIEndpointsPostTokenApproval               = ffm("api.endpoints.postTokenApproval", "Creates a token approval")
 	APIEndpointsPostTokenBurn                   = ffm("api.endpoints.postTokenBurn", "Burns some tokens")
 	APIEndpointsPostTokenMint                   = ffm("api.endpoints.postTokenMint", "Mints some tokens")
+	APIEndpointsPostTokenMintDistribute         = ffm("api.endpoints.postTokenMintDistribute", "Mints tokens and distributes them to a list of recipients, with the mint and all of the transfers submitted in a single transaction")
 	APIEndpointsPostTokenPool                   = ffm("api.endpoints.postTokenPool", "Creates a new token pool")
 	APIEndpointsPostTokenPoolPublish            = ffm("api.endpoints.postTokenPoolPublish", "Publish a token pool to all other members of the multiparty network")
 	APIEndpointsPostTokenTransfer               = ffm("api.endpoints.postTokenTransfer", "Transfers some tokens")
 	APIEndpointsPutContractAPI                  = ffm("api.endpoints.putContractAPI", "Updates an existing contract API")
 	APIEndpointsPutSubscription                 = ffm("api.endpoints.putSubscription", "Update an existing subscription")
 	APIEndpointsGetContractAPIInterface         = ffm("api.endpoints.getContractAPIInterface", "Gets a contract interface for a contract API")
+	APIEndpointsGetContractAPIDiff              = ffm("api.endpoints.getContractAPIDiff", "Compares the contract interfaces bound to two versions of a contract API")
 	APIEndpointsPostNetworkAction               = ffm("api.endpoints.postNetworkAction", "Notify all nodes in the network of a new governance action")
+	APIEndpointsPostNetworkMigration            = ffm("api.endpoints.postNetworkMigration", "Broadcast a migration of all nodes in the network to the next configured FireFly contract, at an agreed block number")
 	APIEndpointsPostVerifiersResolve            = ffm("api.endpoints.postVerifiersResolve", "Resolves an input key to a signing key")
+	APIEndpointsPostVerifierRevoke              = ffm("api.endpoints.postVerifierRevoke", "Revokes a verifier, such as a blockchain signing key, across the network. Messages and batches subsequently signed by the verifier are rejected")
+	APIEndpointsPostVerify                      = ffm("api.endpoints.postVerify", "Verifies a stored message, or a raw batch, for external audit. Reports the validity of each message signature against the registered verifiers, the integrity of the hash chain up to the on-chain pin, and the pinning blockchain transaction")
+	APIEndpointsPostNodeRevoke                  = ffm("api.endpoints.postNodeRevoke", "Revokes all verifiers of a node across the network, such that data exchange transfers from that node are rejected")
 
-	APIFilterParamDesc         = ffm("api.filterParam", "Data filter field. Prefixes supported: > >= < <= @ ^ ! !@ !^")
-	APIFilterSortDesc          = ffm("api.filterSort", "Sort field. For multi-field sort use comma separated values (or multiple query values) with '-' prefix for descending")
-	APIFilterAscendingDesc     = ffm("api.filterAscending", "Ascending sort order (overrides all fields in a multi-field sort)")
-	APIFilterDescendingDesc    = ffm("api.filterDescending", "Descending sort order (overrides all fields in a multi-field sort)")
-	APIFilterSkipDesc          = ffm("api.filterSkip", "The number of records to skip (max: %d). Unsuitable for bulk operations")
-	APIFilterLimitDesc         = ffm("api.filterLimit", "The maximum number of records to return (max: %d)")
-	APIFilterCountDesc         = ffm("api.filterCount", "Return a total count as well as items (adds extra database processing)")
-	APIFetchDataDesc           = ffm("api.fetchData", "Fetch the data and include it in the messages returned")
-	APIConfirmMsgQueryParam    = ffm("api.confirmMsgQueryParam", "When true the HTTP request blocks until the message is confirmed")
-	APIConfirmInvokeQueryParam = ffm("api.confirmInvokeQueryParam", "When true the HTTP request blocks until the blockchain transaction is confirmed")
-	APIPublishQueryParam       = ffm("api.publishQueryParam", "When true the definition will be published to all other members of the multiparty network")
-	APIHistogramStartTimeParam = ffm("api.histogramStartTime", "Start time of the data to be fetched")
-	APIHistogramEndTimeParam   = ffm("api.histogramEndTime", "End time of the data to be fetched")
-	APIHistogramBucketsParam   = ffm("api.histogramBuckets", "Number of buckets between start time and end time")
+	APIFilterParamDesc              = ffm("api.filterParam", "Data filter field. Prefixes supported: > >= < <= @ ^ ! !@ !^")
+	APIFilterSortDesc               = ffm("api.filterSort", "Sort field. For multi-field sort use comma separated values (or multiple query values) with '-' prefix for descending")
+	APIFilterAscendingDesc          = ffm("api.filterAscending", "Ascending sort order (overrides all fields in a multi-field sort)")
+	APIFilterDescendingDesc         = ffm("api.filterDescending", "Descending sort order (overrides all fields in a multi-field sort)")
+	APIFilterSkipDesc               = ffm("api.filterSkip", "The number of records to skip (max: %d). Unsuitable for bulk operations")
+	APIFilterLimitDesc              = ffm("api.filterLimit", "The maximum number of records to return (max: %d)")
+	APIFilterCountDesc              = ffm("api.filterCount", "Return a total count as well as items (adds extra database processing)")
+	APIFetchDataDesc                = ffm("api.fetchData", "Fetch the data and include it in the messages returned")
+	APIEmbedDesc                    = ffm("api.embed", "Referenced records to fetch and embed in each item returned - data, transaction or events. Can be repeated, or comma separated")
+	APIAsOfDesc                     = ffm("api.asOf", "Return the state as it stood at a point in time - either an event sequence number, or an RFC3339 timestamp")
+	APIStalledOperationsDesc        = ffm("api.stalledOperations", "Only return pending operations that have exceeded the stalled threshold configured for their type")
+	APIOperationTagsDesc            = ffm("api.operationTags", "Only return operations carrying one of these comma-separated tags, as supplied in the X-FireFly-Operation-Tags header of the API call that created them")
+	APIConfirmMsgQueryParam         = ffm("api.confirmMsgQueryParam", "When true the HTTP request blocks until the message is confirmed")
+	APIConfirmInvokeQueryParam      = ffm("api.confirmInvokeQueryParam", "When true the HTTP request blocks until the blockchain transaction is confirmed")
+	APIConfirmTimeoutQueryParam     = ffm("api.confirmTimeoutQueryParam", "How long to wait for confirmation when confirm is true, up to the maximum request timeout. Overrides the Request-Timeout header")
+	APIPinOnlyQueryParam            = ffm("api.pinOnlyQueryParam", "When true only the hash of the batch is pinned on-chain, and the payload is not uploaded to shared storage. The payload must be distributed to other members out-of-band")
+	APIPublishQueryParam            = ffm("api.publishQueryParam", "When true the definition will be published to all other members of the multiparty network")
+	APIContractAPIVersionQueryParam = ffm("api.contractAPIVersionQueryParam", "The version of the contract API. Defaults to the latest version")
+	APIContractAPIDiffFromParam     = ffm("api.contractAPIDiffFromParam", "The version of the contract API to compare from")
+	APIContractAPIDiffToParam       = ffm("api.contractAPIDiffToParam", "The version of the contract API to compare to. Defaults to the latest version")
+	APIHistogramStartTimeParam      = ffm("api.histogramStartTime", "Start time of the data to be fetched")
+	APIHistogramEndTimeParam        = ffm("api.histogramEndTime", "End time of the data to be fetched")
+	APIChartIntervalParam           = ffm("api.chartInterval", "The width of each time bucket - minute (default), hour or day")
+	APIChartStartTimeParam          = ffm("api.chartStartTime", "Start time of the data to be fetched. Defaults to 60 intervals before the end time")
+	APIChartEndTimeParam            = ffm("api.chartEndTime", "End time of the data to be fetched. Defaults to now")
+	APIHistogramBucketsParam        = ffm("api.histogramBuckets", "Number of buckets between start time and end time")
+	APIExportFormatParam            = ffm("api.exportFormat", "The format of the export - json (default) or csv")
 
 	APISmartContractDetails      = ffm("api.smartContractDetails", "Additional smart contract details")
 	APISmartContractDetailsKey   = ffm("api.smartContractDetailsKey", "Key")
@@ -48,16 +48,32 @@ var (
 	ConfigSPIReadTimeout  = ffc("config.spi.readTimeout", "The maximum time to wait when reading from an HTTP connection", i18n.TimeDurationType)
 	ConfigSPIWriteTimeout = ffc("config.spi.writeTimeout", "The maximum time to wait when writing to an HTTP connection", i18n.TimeDurationType)
 
-	ConfigAPIDefaultFilterLimit = ffc("config.api.defaultFilterLimit", "The maximum number of rows to return if no limit is specified on an API request", i18n.IntType)
-	ConfigAPIMaxFilterLimit     = ffc("config.api.maxFilterLimit", "The largest value of `limit` that an HTTP client can specify in a request", i18n.IntType)
-	ConfigAPIRequestMaxTimeout  = ffc("config.api.requestMaxTimeout", "The maximum amount of time that an HTTP client can specify in a `Request-Timeout` header to keep a specific request open", i18n.TimeDurationType)
-	ConfigAPIPassthroughHeaders = ffc("config.api.passthroughHeaders", "A list of HTTP request headers to pass through to dependency microservices", i18n.ArrayStringType)
+	ConfigAPIDefaultFilterLimit      = ffc("config.api.defaultFilterLimit", "The maximum number of rows to return if no limit is specified on an API request", i18n.IntType)
+	ConfigAPIMaxFilterLimit          = ffc("config.api.maxFilterLimit", "The largest value of `limit` that an HTTP client can specify in a request", i18n.IntType)
+	ConfigAPIRequestMaxTimeout       = ffc("config.api.requestMaxTimeout", "The maximum amount of time that an HTTP client can specify in a `Request-Timeout` header to keep a specific request open", i18n.TimeDurationType)
+	ConfigAPIPassthroughHeaders      = ffc("config.api.passthroughHeaders", "A list of HTTP request headers to pass through to dependency microservices", i18n.ArrayStringType)
+	ConfigAPIMaxRequestBodySize      = ffc("config.api.maxRequestBodySize", "The maximum size of a request body, which is rejected with a 413 before it is decoded. Multi-part file uploads are streamed, and are not limited. Set to 0 for no limit", i18n.ByteSizeType)
+	ConfigAPIKeysHeader              = ffc("config.api.keys.header", "The HTTP request header in which an application supplies an API key created with the /apikeys route of a namespace", i18n.StringType)
+	ConfigAPIKeysBootstrapKeyHash    = ffc("config.api.keys.bootstrapKeyHash", "The hex encoded SHA-256 hash of a bootstrap API key, which has admin scope on every namespace. Use it to create the first API keys with the /apikeys route when keys are required, then remove it from the configuration. Only the hash is configured, so the key itself is not stored", i18n.StringType)
+	ConfigAPIKeysRequired            = ffc("config.api.keys.required", "When true, requests to the routes of a namespace are rejected with a 401 unless they supply a valid API key. Routes of the SPI are not affected", i18n.BooleanType)
+	ConfigAPIRouteMaxRequestBodySize = ffc("config.api.routeMaxRequestBodySize", "A map of API route names (the operationId in the OpenAPI Spec, such as postData) to the maximum size of a request body for that route, overriding api.maxRequestBodySize. The limit also applies to the namespaced version of the route", i18n.MapStringStringType)
 
 	ConfigAssetManagerKeyNormalization = ffc("config.asset.manager.keyNormalization", "Mechanism to normalize keys before using them. Valid options are `blockchain_plugin` - use blockchain plugin (default) or `none` - do not attempt normalization (deprecated - use namespaces.predefined[].asset.manager.keyNormalization)", i18n.StringType)
 
+	ConfigBatchAdaptiveEnabled         = ffc("config.batch.adaptive.enabled", "Whether to tune the size and payload limit of each batch processor based on the latency observed uploading to shared storage and submitting to the blockchain. The configured batch size and payload limit are the upper bounds", i18n.BooleanType)
+	ConfigBatchAdaptiveMinSize         = ffc("config.batch.adaptive.minSize", "The lowest number of messages the adaptive tuning reduces the batch size to", i18n.IntType)
+	ConfigBatchAdaptiveMinPayloadLimit = ffc("config.batch.adaptive.minPayloadLimit", "The lowest payload limit the adaptive tuning reduces the batch payload limit to", i18n.ByteSizeType)
+	ConfigBatchAdaptiveTargetLatency   = ffc("config.batch.adaptive.targetLatency", "The dispatch latency above which the adaptive tuning halves the batch limits. Full batches dispatched within this latency grow the limits", i18n.TimeDurationType)
+	ConfigBatchAdaptiveGrowthFactor    = ffc("config.batch.adaptive.growthFactor", "The factor the adaptive tuning grows the batch limits by, after a full batch is dispatched within the target latency", i18n.FloatType)
 	ConfigBatchManagerMinimumPollDelay = ffc("config.batch.manager.minimumPollDelay", "The minimum time the batch manager waits between polls on the DB - to prevent thrashing", i18n.TimeDurationType)
 	ConfigBatchManagerPollTimeout      = ffc("config.batch.manager.pollTimeout", "How long to wait without any notifications of new messages before doing a page query", i18n.TimeDurationType)
 	ConfigBatchManagerReadPageSize     = ffc("config.batch.manager.readPageSize", "The size of each page of messages read from the database into memory when assembling batches", i18n.IntType)
+	ConfigBatchManagerWorkers          = ffc("config.batch.manager.workers", "The maximum number of batches each namespace seals and dispatches concurrently. Each namespace has its own pool of workers. 0 for no limit", i18n.IntType)
+	ConfigBatchManagerQueueDepth       = ffc("config.batch.manager.queueDepth", "The number of messages each batch processor queues for assembly while a batch is flushing. 0 to use the batch size", i18n.IntType)
+
+	ConfigBlobgcGracePeriod = ffc("config.blobgc.gracePeriod", "The minimum age of a blob before it can be garbage collected, so blobs received ahead of the message that references them are not deleted", i18n.TimeDurationType)
+	ConfigBlobgcPageSize    = ffc("config.blobgc.pageSize", "The number of blobs read from the database in each page of a garbage collection run", i18n.IntType)
+	ConfigBlobgcRetention   = ffc("config.blobgc.retention", "How long after confirmation a message holds a reference to the blobs of its data. Zero retains the blobs for as long as any message references them", i18n.TimeDurationType)
 
 	ConfigBlobreceiverWorkerBatchMaxInserts = ffc("config.blobreceiver.worker.batchMaxInserts", "The maximum number of items the blob receiver worker will insert in a batch", i18n.IntType)
 	ConfigBlobreceiverWorkerBatchTimeout    = ffc("config.blobreceiver.worker.batchTimeout", "The maximum amount of the the blob receiver worker will wait", i18n.TimeDurationType)
@@ -97,6 +113,7 @@ var (
 	ConfigBlockchainFabricFabconnectBatchTimeout = ffc("config.blockchain.fabric.fabconnect.batchTimeout", "The maximum amount of time to wait for a batch to complete", i18n.TimeDurationType)
 	ConfigBlockchainFabricFabconnectChaincode    = ffc("config.blockchain.fabric.fabconnect.chaincode", "The name of the Fabric chaincode that FireFly will use for BatchPin transactions (deprecated - use namespaces.predefined[].multiparty.contract[].location.chaincode)", i18n.StringType)
 	ConfigBlockchainFabricFabconnectChannel      = ffc("config.blockchain.fabric.fabconnect.channel", "The Fabric channel that FireFly will use for BatchPin transactions (deprecated - use namespaces.predefined[].multiparty.contract[].location.channel)", i18n.StringType)
+	ConfigBlockchainFabricFabconnectMSPDir       = ffc("config.blockchain.fabric.fabconnect.mspDir", "A directory containing the MSP of each organization in the channel, in a sub-directory named by MSP ID with cacerts and optional intermediatecerts. Certificates accompanying Fabric signatures on identity claims must chain to these CAs", i18n.StringType)
 	ConfigBlockchainFabricFabconnectPrefixLong   = ffc("config.blockchain.fabric.fabconnect.prefixLong", "The prefix that will be used for Fabconnect specific HTTP headers when FireFly makes requests to Fabconnect", i18n.StringType)
 	ConfigBlockchainFabricFabconnectPrefixShort  = ffc("config.blockchain.fabric.fabconnect.prefixShort", "The prefix that will be used for Fabconnect specific query parameters when FireFly makes requests to Fabconnect", i18n.StringType)
 	ConfigBlockchainFabricFabconnectSigner       = ffc("config.blockchain.fabric.fabconnect.signer", "The Fabric signing key to use when submitting transactions to Fabconnect", i18n.StringType)
@@ -104,6 +121,30 @@ var (
 	ConfigBlockchainFabricFabconnectURL          = ffc("config.blockchain.fabric.fabconnect.url", "The URL of the Fabconnect instance", urlStringType)
 	ConfigBlockchainFabricFabconnectProxyURL     = ffc("config.blockchain.fabric.fabconnect.proxy.url", "Optional HTTP proxy server to use when connecting to Fabconnect", urlStringType)
 
+	ConfigChaosEnabled = ffc("config.chaos.enabled", "Wraps plugins with the configured latency, error and disconnect injection. For resilience testing only - never enable in production", i18n.BooleanType)
+
+	ConfigChaosBlockchainLatency              = ffc("config.chaos.blockchain.latency", "Delay added before each call to blockchain plugins", i18n.TimeDurationType)
+	ConfigChaosBlockchainErrorRate            = ffc("config.chaos.blockchain.errorRate", "The fraction of calls to blockchain plugins that fail with an injected error, between 0 and 1", i18n.FloatType)
+	ConfigChaosBlockchainDisconnectInterval   = ffc("config.chaos.blockchain.disconnectInterval", "How often blockchain plugins simulate a disconnection. Zero disables disconnect injection", i18n.TimeDurationType)
+	ConfigChaosBlockchainDisconnectDuration   = ffc("config.chaos.blockchain.disconnectDuration", "How long each simulated disconnection of blockchain plugins lasts, during which every call fails and the plugin reports not ready", i18n.TimeDurationType)
+	ConfigChaosDatabaseLatency                = ffc("config.chaos.database.latency", "Delay added before each call to database plugins", i18n.TimeDurationType)
+	ConfigChaosDatabaseErrorRate              = ffc("config.chaos.database.errorRate", "The fraction of calls to database plugins that fail with an injected error, between 0 and 1", i18n.FloatType)
+	ConfigChaosDatabaseDisconnectInterval     = ffc("config.chaos.database.disconnectInterval", "How often database plugins simulate a disconnection. Zero disables disconnect injection", i18n.TimeDurationType)
+	ConfigChaosDatabaseDisconnectDuration     = ffc("config.chaos.database.disconnectDuration", "How long each simulated disconnection of database plugins lasts, during which every call fails and the plugin reports not ready", i18n.TimeDurationType)
+	ConfigChaosDataExchangeLatency            = ffc("config.chaos.dataexchange.latency", "Delay added before each call to data exchange plugins", i18n.TimeDurationType)
+	ConfigChaosDataExchangeErrorRate          = ffc("config.chaos.dataexchange.errorRate", "The fraction of calls to data exchange plugins that fail with an injected error, between 0 and 1", i18n.FloatType)
+	ConfigChaosDataExchangeDisconnectInterval = ffc("config.chaos.dataexchange.disconnectInterval", "How often data exchange plugins simulate a disconnection. Zero disables disconnect injection", i18n.TimeDurationType)
+	ConfigChaosDataExchangeDisconnectDuration = ffc("config.chaos.dataexchange.disconnectDuration", "How long each simulated disconnection of data exchange plugins lasts, during which every call fails and the plugin reports not ready", i18n.TimeDurationType)
+	ConfigChaosTokensLatency                  = ffc("config.chaos.tokens.latency", "Delay added before each call to tokens plugins", i18n.TimeDurationType)
+	ConfigChaosTokensErrorRate                = ffc("config.chaos.tokens.errorRate", "The fraction of calls to tokens plugins that fail with an injected error, between 0 and 1", i18n.FloatType)
+	ConfigChaosTokensDisconnectInterval       = ffc("config.chaos.tokens.disconnectInterval", "How often tokens plugins simulate a disconnection. Zero disables disconnect injection", i18n.TimeDurationType)
+	ConfigChaosTokensDisconnectDuration       = ffc("config.chaos.tokens.disconnectDuration", "How long each simulated disconnection of tokens plugins lasts, during which every call fails and the plugin reports not ready", i18n.TimeDurationType)
+
+	ConfigClusterEnabled            = ffc("config.cluster.enabled", "Enables active/standby clustering of instances that share a database. A single leader per namespace runs the batch assembler, aggregator and plugin event loops, while standby instances only serve read requests", i18n.BooleanType)
+	ConfigClusterInstanceID         = ffc("config.cluster.instanceID", "A unique identifier for this instance within the cluster. Defaults to the hostname with a random suffix", i18n.StringType)
+	ConfigClusterLeaseTTL           = ffc("config.cluster.leaseTTL", "How long leadership of a namespace is held without being renewed, before a standby instance can take over", i18n.TimeDurationType)
+	ConfigClusterLeaseRenewInterval = ffc("config.cluster.leaseRenewInterval", "How often the leader renews its leadership, and standby instances attempt to take leadership. Must be shorter than the lease TTL", i18n.TimeDurationType)
+
 	ConfigCacheEnabled = ffc("config.cache.enabled", "Enables caching, defaults to true", i18n.BooleanType)
 
 	ConfigCacheAddressResolverLimit    = ffc("config.cache.addressresolver.limit", "Max number of cached items for address resolver", i18n.IntType)
@@ -116,6 +157,8 @@ var (
 	ConfigCacheTransactionTTL          = ffc("config.cache.transaction.ttl", "Time to live of cached transactions", i18n.StringType)
 	ConfigCacheEventListenerTopicLimit = ffc("config.cache.eventlistenertopic.limit", "Max number of cached items for blockchain listener topics", i18n.IntType)
 	ConfigCacheEventListenerTopicTTL   = ffc("config.cache.eventlistenertopic.ttl", "Time to live of cached items for blockchain listener topics", i18n.StringType)
+	ConfigCacheEventDecoderLimit       = ffc("config.cache.eventdecoder.limit", "Max number of cached decoders for blockchain listener events, keyed by event signature and ABI", i18n.IntType)
+	ConfigCacheEventDecoderTTL         = ffc("config.cache.eventdecoder.ttl", "Time to live of cached decoders for blockchain listener events", i18n.StringType)
 	ConfigCacheGroupLimit              = ffc("config.cache.group.limit", "Max number of cached items for groups", i18n.IntType)
 	ConfigCacheGroupTTL                = ffc("config.cache.group.ttl", "Time to live of cached items for groups", i18n.StringType)
 	ConfigCacheIdentityLimit           = ffc("config.cache.identity.limit", "Max number of cached identities for identity manager", i18n.IntType)
@@ -134,6 +177,8 @@ var (
 	ConfigCacheTokenPoolTTL            = ffc("config.cache.tokenpool.ttl", "Time to live of cached items for token pool", i18n.StringType)
 	ConfigCacheMethodsLimit            = ffc("config.cache.methods.limit", "Max number of cached items for schema validations on blockchain methods", i18n.IntType)
 	ConfigCacheMethodsTTL              = ffc("config.cache.methods.ttl", "Time to live of cached items for schema validations on blockchain methods", i18n.StringType)
+	ConfigCacheContractQueryLimit      = ffc("config.cache.contractquery.limit", "Max number of cached query results for contract APIs that have a query cache TTL set", i18n.IntType)
+	ConfigCacheContractQueryTTL        = ffc("config.cache.contractquery.ttl", "Maximum time to live of cached query results for contract APIs. The TTL set on each API is capped at this value", i18n.StringType)
 
 	ConfigPluginDatabase     = ffc("config.plugins.database", "The list of configured Database plugins", i18n.StringType)
 	ConfigPluginDatabaseName = ffc("config.plugins.database[].name", "The name of the Database plugin", i18n.StringType)
@@ -169,7 +214,8 @@ var (
 	ConfigPluginBlockchainEthereumAddressResolverURL            = ffc("config.plugins.blockchain[].ethereum.addressResolver.url", "The URL of the Address Resolver", i18n.StringType)
 	ConfigPluginBlockchainEthereumAddressResolverURLTemplate    = ffc("config.plugins.blockchain[].ethereum.addressResolver.urlTemplate", "The URL Go template string to use when calling the Address Resolver. The template input contains '.Key' and '.Intent' string variables.", i18n.GoTemplateType)
 
-	ConfigPluginBlockchainEthereumAddressResolverProxyURL = ffc("config.plugins.blockchain[].ethereum.addressResolver.proxy.url", "Optional HTTP proxy server to use when connecting to the Address Resolver", urlStringType)
+	ConfigPluginBlockchainEthereumAddressResolverProxyURL     = ffc("config.plugins.blockchain[].ethereum.addressResolver.proxy.url", "Optional HTTP proxy server to use when connecting to the Address Resolver", urlStringType)
+	ConfigPluginBlockchainEthereumAddressResolverProxyNoProxy = ffc("config.plugins.blockchain[].ethereum.addressResolver.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
 
 	ConfigPluginBlockchainEthereumEthconnectBackgroundStart             = ffc("config.plugins.blockchain[].ethereum.ethconnect.backgroundStart.enabled", "Start the Ethconnect plugin in the background and enter retry loop if failed to start", i18n.BooleanType)
 	ConfigPluginBlockchainEthereumEthconnectBackgroundStartInitialDelay = ffc("config.plugins.blockchain[].ethereum.ethconnect.backgroundStart.initialDelay", "Delay between restarts in the case where we retry to restart the ethereum plugin", i18n.TimeDurationType)
@@ -184,6 +230,7 @@ var (
 	ConfigPluginBlockchainEthereumEthconnectTopic                       = ffc("config.plugins.blockchain[].ethereum.ethconnect.topic", "The websocket listen topic that the node should register on, which is important if there are multiple nodes using a single ethconnect", i18n.StringType)
 	ConfigPluginBlockchainEthereumEthconnectURL                         = ffc("config.plugins.blockchain[].ethereum.ethconnect.url", "The URL of the Ethconnect instance", urlStringType)
 	ConfigPluginBlockchainEthereumEthconnectProxyURL                    = ffc("config.plugins.blockchain[].ethereum.ethconnect.proxy.url", "Optional HTTP proxy server to use when connecting to Ethconnect", urlStringType)
+	ConfigPluginBlockchainEthereumEthconnectProxyNoProxy                = ffc("config.plugins.blockchain[].ethereum.ethconnect.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
 
 	ConfigPluginBlockchainEthereumFFTMURL      = ffc("config.plugins.blockchain[].ethereum.fftm.url", "The URL of the FireFly Transaction Manager runtime, if enabled", i18n.StringType)
 	ConfigPluginBlockchainEthereumFFTMProxyURL = ffc("config.plugins.blockchain[].ethereum.fftm.proxy.url", "Optional HTTP proxy server to use when connecting to the Transaction Manager", i18n.StringType)
@@ -194,6 +241,7 @@ var (
 	ConfigPluginBlockchainTezosAddressResolverRetainOriginal = ffc("config.plugins.blockchain[].tezos.addressResolver.retainOriginal", "When true the original pre-resolved string is retained after the lookup, and passed down to Tezosconnect as the from address", i18n.BooleanType)
 	ConfigPluginBlockchainTezosAddressResolverURL            = ffc("config.plugins.blockchain[].tezos.addressResolver.url", "The URL of the Address Resolver", i18n.StringType)
 	ConfigPluginBlockchainTezosAddressResolverURLTemplate    = ffc("config.plugins.blockchain[].tezos.addressResolver.urlTemplate", "The URL Go template string to use when calling the Address Resolver. The template input contains '.Key' and '.Intent' string variables.", i18n.GoTemplateType)
+	ConfigPluginBlockchainTezosAddressResolverProxyNoProxy   = ffc("config.plugins.blockchain[].tezos.addressResolver.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
 
 	ConfigPluginBlockchainTezosTezosconnectBackgroundStart             = ffc("config.plugins.blockchain[].tezos.tezosconnect.backgroundStart.enabled", "Start the Tezosconnect plugin in the background and enter retry loop if failed to start", i18n.BooleanType)
 	ConfigPluginBlockchainTezosTezosconnectBackgroundStartInitialDelay = ffc("config.plugins.blockchain[].tezos.tezosconnect.backgroundStart.initialDelay", "Delay between restarts in the case where we retry to restart the tezos plugin", i18n.TimeDurationType)
@@ -208,6 +256,7 @@ var (
 	ConfigPluginBlockchainTezosTezosconnectTopic                       = ffc("config.plugins.blockchain[].tezos.tezosconnect.topic", "The websocket listen topic that the node should register on, which is important if there are multiple nodes using a single tezosconnect", i18n.StringType)
 	ConfigPluginBlockchainTezosTezosconnectURL                         = ffc("config.plugins.blockchain[].tezos.tezosconnect.url", "The URL of the Tezosconnect instance", urlStringType)
 	ConfigPluginBlockchainTezosTezosconnectProxyURL                    = ffc("config.plugins.blockchain[].tezos.tezosconnect.proxy.url", "Optional HTTP proxy server to use when connecting to Tezosconnect", urlStringType)
+	ConfigPluginBlockchainTezosTezosconnectProxyNoProxy                = ffc("config.plugins.blockchain[].tezos.tezosconnect.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
 
 	ConfigPluginBlockchainFabricFabconnectBackgroundStart             = ffc("config.plugins.blockchain[].fabric.fabconnect.backgroundStart.enabled", "Start the fabric plugin in the background and enter retry loop if failed to start", i18n.BooleanType)
 	ConfigPluginBlockchainFabricFabconnectBackgroundStartInitialDelay = ffc("config.plugins.blockchain[].fabric.fabconnect.backgroundStart.initialDelay", "Delay between restarts in the case where we retry to restart the fabric plugin", i18n.TimeDurationType)
@@ -215,19 +264,23 @@ var (
 	ConfigPluginBlockchainFabricFabconnectBackgroundStartFactor       = ffc("config.plugins.blockchain[].fabric.fabconnect.backgroundStart.factor", "Set the factor by which the delay increases when retrying", i18n.FloatType)
 	ConfigPluginBlockchainFabricFabconnectBatchSize                   = ffc("config.plugins.blockchain[].fabric.fabconnect.batchSize", "The number of events Fabconnect should batch together for delivery to FireFly core. Only applies when automatically creating a new event stream", i18n.IntType)
 	ConfigPluginBlockchainFabricFabconnectBatchTimeout                = ffc("config.plugins.blockchain[].fabric.fabconnect.batchTimeout", "The maximum amount of time to wait for a batch to complete", i18n.TimeDurationType)
+	ConfigPluginBlockchainFabricFabconnectMSPDir                      = ffc("config.plugins.blockchain[].fabric.fabconnect.mspDir", "A directory containing the MSP of each organization in the channel, in a sub-directory named by MSP ID with cacerts and optional intermediatecerts. Certificates accompanying Fabric signatures on identity claims must chain to these CAs", i18n.StringType)
 	ConfigPluginBlockchainFabricFabconnectPrefixLong                  = ffc("config.plugins.blockchain[].fabric.fabconnect.prefixLong", "The prefix that will be used for Fabconnect specific HTTP headers when FireFly makes requests to Fabconnect", i18n.StringType)
 	ConfigPluginBlockchainFabricFabconnectPrefixShort                 = ffc("config.plugins.blockchain[].fabric.fabconnect.prefixShort", "The prefix that will be used for Fabconnect specific query parameters when FireFly makes requests to Fabconnect", i18n.StringType)
 	ConfigPluginBlockchainFabricFabconnectSigner                      = ffc("config.plugins.blockchain[].fabric.fabconnect.signer", "The Fabric signing key to use when submitting transactions to Fabconnect", i18n.StringType)
 	ConfigPluginBlockchainFabricFabconnectTopic                       = ffc("config.plugins.blockchain[].fabric.fabconnect.topic", "The websocket listen topic that the node should register on, which is important if there are multiple nodes using a single Fabconnect", i18n.StringType)
 	ConfigPluginBlockchainFabricFabconnectURL                         = ffc("config.plugins.blockchain[].fabric.fabconnect.url", "The URL of the Fabconnect instance", urlStringType)
 	ConfigPluginBlockchainFabricFabconnectProxyURL                    = ffc("config.plugins.blockchain[].fabric.fabconnect.proxy.url", "Optional HTTP proxy server to use when connecting to Fabconnect", urlStringType)
+	ConfigPluginBlockchainFabricFabconnectProxyNoProxy                = ffc("config.plugins.blockchain[].fabric.fabconnect.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
 	ConfigPluginBlockchainFabricFabconnectChaincode                   = ffc("config.plugins.blockchain[].fabric.fabconnect.chaincode", "The name of the Fabric chaincode that FireFly will use for BatchPin transactions (deprecated - use fireflyContract[].chaincode)", i18n.StringType)
 	ConfigPluginBlockchainFabricFabconnectChannel                     = ffc("config.plugins.blockchain[].fabric.fabconnect.channel", "The Fabric channel that FireFly will use for BatchPin transactions", i18n.StringType)
 
-	ConfigBroadcastBatchAgentTimeout = ffc("config.broadcast.batch.agentTimeout", "How long to keep around a batching agent for a sending identity before disposal", i18n.StringType)
-	ConfigBroadcastBatchPayloadLimit = ffc("config.broadcast.batch.payloadLimit", "The maximum payload size of a batch for broadcast messages", i18n.ByteSizeType)
-	ConfigBroadcastBatchSize         = ffc("config.broadcast.batch.size", "The maximum number of messages that can be packed into a batch", i18n.IntType)
-	ConfigBroadcastBatchTimeout      = ffc("config.broadcast.batch.timeout", "The timeout to wait for a batch to fill, before sending", i18n.TimeDurationType)
+	ConfigBroadcastBatchAgentTimeout  = ffc("config.broadcast.batch.agentTimeout", "How long to keep around a batching agent for a sending identity before disposal", i18n.StringType)
+	ConfigBroadcastBatchCompression   = ffc("config.broadcast.batch.compression", "The compression applied to the payload of broadcast batches uploaded to shared storage - none, gzip or zstd. Receiving nodes must support compression", i18n.StringType)
+	ConfigBroadcastBatchPayloadLimit  = ffc("config.broadcast.batch.payloadLimit", "The maximum payload size of a batch for broadcast messages", i18n.ByteSizeType)
+	ConfigBroadcastBatchSize          = ffc("config.broadcast.batch.size", "The maximum number of messages that can be packed into a batch", i18n.IntType)
+	ConfigBroadcastBatchTopicAffinity = ffc("config.broadcast.batch.topicAffinity", "Assemble a separate batch for each set of message topics, so messages on unrelated topics do not block each other in the same batch", i18n.BooleanType)
+	ConfigBroadcastBatchTimeout       = ffc("config.broadcast.batch.timeout", "The timeout to wait for a batch to fill, before sending", i18n.TimeDurationType)
 
 	ConfigDatabaseType = ffc("config.database.type", "The type of the database interface plugin to use", i18n.IntType)
 
@@ -251,6 +304,11 @@ var (
 
 	ConfigDataexchangeFfdxProxyURL = ffc("config.dataexchange.ffdx.proxy.url", "Optional HTTP proxy server to use when connecting to the Data Exchange", urlStringType)
 
+	ConfigDatatypeMigrationPageSize          = ffc("config.datatype.migration.pageSize", "The number of data items read from the database in each page, when migrating existing data to a new version of a datatype", i18n.IntType)
+	ConfigDatatypeMigrationRetryFactor       = ffc("config.datatype.migration.retry.factor", "The retry backoff factor for database operations during a datatype migration", i18n.FloatType)
+	ConfigDatatypeMigrationRetryInitialDelay = ffc("config.datatype.migration.retry.initialDelay", "The initial retry delay for database operations during a datatype migration", i18n.TimeDurationType)
+	ConfigDatatypeMigrationRetryMaxDelay     = ffc("config.datatype.migration.retry.maxDelay", "The maximum retry delay for database operations during a datatype migration", i18n.TimeDurationType)
+
 	ConfigPluginDataexchange     = ffc("config.plugins.dataexchange", "The array of configured Data Exchange plugins ", i18n.StringType)
 	ConfigPluginDataexchangeType = ffc("config.plugins.dataexchange[].type", "The Data Exchange plugin to use", i18n.StringType)
 	ConfigPluginDataexchangeName = ffc("config.plugins.dataexchange[].name", "The name of the configured Data Exchange plugin", i18n.StringType)
@@ -263,29 +321,62 @@ var (
 	ConfigPluginDataexchangeFfdxBackgroundStartMaxDelay     = ffc("config.plugins.dataexchange[].ffdx.backgroundStart.maxDelay", "Max delay between restarts in the case where we retry to restart the data exchange plugin", i18n.TimeDurationType)
 	ConfigPluginDataexchangeFfdxBackgroundStartFactor       = ffc("config.plugins.dataexchange[].ffdx.backgroundStart.factor", "Set the factor by which the delay increases when retrying", i18n.FloatType)
 
-	ConfigPluginDataexchangeFfdxProxyURL = ffc("config.plugins.dataexchange[].ffdx.proxy.url", "Optional HTTP proxy server to use when connecting to the Data Exchange", urlStringType)
+	ConfigPluginDataexchangeFfdxProxyURL     = ffc("config.plugins.dataexchange[].ffdx.proxy.url", "Optional HTTP proxy server to use when connecting to the Data Exchange", urlStringType)
+	ConfigPluginDataexchangeFfdxProxyNoProxy = ffc("config.plugins.dataexchange[].ffdx.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+
+	ConfigPluginDataexchangeLibp2pListenAddresses   = ffc("config.plugins.dataexchange[].libp2p.listenAddresses", "The libp2p multiaddrs to listen on for streams from other nodes, such as /ip4/0.0.0.0/tcp/5100", i18n.ArrayStringType)
+	ConfigPluginDataexchangeLibp2pAnnounceAddresses = ffc("config.plugins.dataexchange[].libp2p.announceAddresses", "The multiaddrs other nodes should use to reach this node, published in place of the listen addresses. Set this to the public address when the node is behind a NAT with a forwarded port", i18n.ArrayStringType)
+	ConfigPluginDataexchangeLibp2pKeyFile           = ffc("config.plugins.dataexchange[].libp2p.keyFile", "The file holding the private key that determines the libp2p peer ID of the node. A new key is generated in this file if it does not exist", i18n.StringType)
+	ConfigPluginDataexchangeLibp2pBlobsPath         = ffc("config.plugins.dataexchange[].libp2p.blobs.path", "The directory that uploaded and received blobs are stored in", i18n.StringType)
+	ConfigPluginDataexchangeLibp2pManifestEnabled   = ffc("config.plugins.dataexchange[].libp2p.manifestEnabled", "Determines whether to require+validate a manifest from the FireFly of the receiving node", i18n.BooleanType)
+	ConfigPluginDataexchangeLibp2pStreamTimeout     = ffc("config.plugins.dataexchange[].libp2p.streamTimeout", "The time allowed to open a stream to a peer and write a message or blob to it", i18n.TimeDurationType)
+	ConfigPluginDataexchangeLibp2pNATPortMapping    = ffc("config.plugins.dataexchange[].libp2p.nat.portMapping", "Attempt to open a port on the NAT gateway of the network with UPnP or NAT-PMP", i18n.BooleanType)
+	ConfigPluginDataexchangeLibp2pNATHolePunching   = ffc("config.plugins.dataexchange[].libp2p.nat.holePunching", "Attempt to connect directly to peers behind a NAT by hole punching, coordinated through a relay", i18n.BooleanType)
+	ConfigPluginDataexchangeLibp2pNATRelays         = ffc("config.plugins.dataexchange[].libp2p.nat.relays", "The multiaddrs, including the /p2p/ peer ID, of circuit relays this node can be reached through when it is not publicly reachable", i18n.ArrayStringType)
+	ConfigPluginDataexchangeLibp2pEventRetryFactor  = ffc("config.plugins.dataexchange[].libp2p.eventRetry.factor", "The retry backoff factor, for delivering received messages and blobs to FireFly", i18n.FloatType)
+	ConfigPluginDataexchangeLibp2pEventRetryInitial = ffc("config.plugins.dataexchange[].libp2p.eventRetry.initialDelay", "The initial retry delay, for delivering received messages and blobs to FireFly", i18n.TimeDurationType)
+	ConfigPluginDataexchangeLibp2pEventRetryMax     = ffc("config.plugins.dataexchange[].libp2p.eventRetry.maxDelay", "The maximum retry delay, for delivering received messages and blobs to FireFly", i18n.TimeDurationType)
 
 	ConfigDebugPort    = ffc("config.debug.port", "An HTTP port on which to enable the go debugger", i18n.IntType)
 	ConfigDebugAddress = ffc("config.debug.address", "The HTTP interface the go debugger binds to", i18n.StringType)
 
+	ConfigDownloadPriorityBatch     = ffc("config.download.priority.batch", "The priority of batch downloads in the work queue. Downloads with a higher priority are dispatched to the workers first", i18n.IntType)
+	ConfigDownloadPriorityBlob      = ffc("config.download.priority.blob", "The priority of blob downloads in the work queue. Downloads with a higher priority are dispatched to the workers first", i18n.IntType)
 	ConfigDownloadWorkerCount       = ffc("config.download.worker.count", "The number of download workers", i18n.IntType)
-	ConfigDownloadWorkerQueueLength = ffc("config.download.worker.queueLength", "The length of the work queue in the channel to the workers - defaults to 2x the worker count", i18n.IntType)
+	ConfigDownloadWorkerQueueLength = ffc("config.download.worker.queueLength", "The maximum number of downloads queued for the workers, in priority order - defaults to 2x the worker count", i18n.IntType)
 
 	ConfigEventAggregatorBatchSize         = ffc("config.event.aggregator.batchSize", "The maximum number of records to read from the DB before performing an aggregation run", i18n.ByteSizeType)
 	ConfigEventAggregatorBatchTimeout      = ffc("config.event.aggregator.batchTimeout", "How long to wait for new events to arrive before performing aggregation on a page of events", i18n.TimeDurationType)
 	ConfigEventAggregatorFirstEvent        = ffc("config.event.aggregator.firstEvent", "The first event the aggregator should process, if no previous offest is stored in the DB. Valid options are `oldest` or `newest`", i18n.StringType)
+	ConfigEventAggregatorGapReportDelay    = ffc("config.event.aggregator.gapReportDelay", "How long a gap in the pins of a member on a private context must persist after the blockchain connector has been asked to re-deliver the missing events, before an aggregation_gap_detected event is emitted", i18n.TimeDurationType)
 	ConfigEventAggregatorPollTimeout       = ffc("config.event.aggregator.pollTimeout", "The time to wait without a notification of new events, before trying a select on the table", i18n.TimeDurationType)
 	ConfigEventAggregatorRewindQueueLength = ffc("config.event.aggregator.rewindQueueLength", "The size of the queue into the rewind dispatcher", i18n.IntType)
 	ConfigEventAggregatorRewindTimout      = ffc("config.event.aggregator.rewindTimeout", "The minimum time to wait for rewinds to accumulate before resolving them", i18n.TimeDurationType)
 	ConfigEventAggregatorRewindQueryLimit  = ffc("config.event.aggregator.rewindQueryLimit", "Safety limit on the maximum number of records to search when performing queries to search for rewinds", i18n.IntType)
+	ConfigEventAggregatorWorkers           = ffc("config.event.aggregator.workers", "The number of worker shards that aggregate independent ordering contexts (topic and group) in parallel. Pins within a single context are always processed in order. A value of 1 processes all pins serially", i18n.IntType)
 	ConfigEventDbeventsBufferSize          = ffc("config.event.dbevents.bufferSize", "The size of the buffer of change events", i18n.ByteSizeType)
 
-	ConfigEventDispatcherBatchTimeout = ffc("config.event.dispatcher.batchTimeout", "A short time to wait for new events to arrive before re-polling for new events", i18n.TimeDurationType)
-	ConfigEventDispatcherBufferLength = ffc("config.event.dispatcher.bufferLength", "The number of events + attachments an individual dispatcher should hold in memory ready for delivery to the subscription", i18n.IntType)
-	ConfigEventDispatcherPollTimeout  = ffc("config.event.dispatcher.pollTimeout", "The time to wait without a notification of new events, before trying a select on the table", i18n.TimeDurationType)
+	ConfigEventDispatcherBatchTimeout            = ffc("config.event.dispatcher.batchTimeout", "A short time to wait for new events to arrive before re-polling for new events", i18n.TimeDurationType)
+	ConfigEventDispatcherPriorityStarvationLimit = ffc("config.event.dispatcher.priorityStarvationLimit", "The maximum number of consecutive higher priority events that can be dispatched ahead of the oldest waiting event, on subscriptions that configure event type priorities", i18n.IntType)
+	ConfigEventDispatcherBufferLength            = ffc("config.event.dispatcher.bufferLength", "The number of events + attachments an individual dispatcher should hold in memory ready for delivery to the subscription", i18n.IntType)
+	ConfigEventDispatcherPollTimeout             = ffc("config.event.dispatcher.pollTimeout", "The time to wait without a notification of new events, before trying a select on the table", i18n.TimeDurationType)
+
+	ConfigEventDXReorderTimeout = ffc("config.event.dx.reorderTimeout", "How long to hold messages that a data exchange connector delivers out of sequence from a peer, waiting for the missing messages, before skipping ahead", i18n.TimeDurationType)
+
+	ConfigEventInboundQueueBatchSize      = ffc("config.event.inboundQueue.batchSize", "The maximum number of queued events to read from the database at a time", i18n.IntType)
+	ConfigEventInboundQueueDatabase       = ffc("config.event.inboundQueue.database", "The name of the database plugin that stores the inbound event queue. Can be omitted when only one database plugin is configured", i18n.StringType)
+	ConfigEventInboundQueueEnabled        = ffc("config.event.inboundQueue.enabled", "Whether events from blockchain, data exchange and tokens connectors are stored in a persistent queue, and acknowledged to the connector, before they are processed. This stops bursts of events from blocking the connection to the connector", i18n.BooleanType)
+	ConfigEventInboundQueueMaxLength      = ffc("config.event.inboundQueue.maxLength", "The maximum number of events queued for each connection to a connector. Reading from the connection is paused while the queue is full", i18n.IntType)
+	ConfigEventInboundQueuePollTimeout    = ffc("config.event.inboundQueue.pollTimeout", "The time to wait without a notification of new events, before checking the queue in the database", i18n.TimeDurationType)
+	ConfigEventInboundQueueRetryFactor    = ffc("config.event.inboundQueue.retry.factor", "The retry backoff factor for processing queued events", i18n.FloatType)
+	ConfigEventInboundQueueRetryInitDelay = ffc("config.event.inboundQueue.retry.initDelay", "The initial retry delay for processing queued events", i18n.TimeDurationType)
+	ConfigEventInboundQueueRetryMaxDelay  = ffc("config.event.inboundQueue.retry.maxDelay", "The maximum retry delay for processing queued events", i18n.TimeDurationType)
 
 	ConfigEventTransportsDefault = ffc("config.event.transports.default", "The default event transport for new subscriptions", i18n.StringType)
-	ConfigEventTransportsEnabled = ffc("config.event.transports.enabled", "Which event interface plugins are enabled", i18n.BooleanType)
+	ConfigEventTransportsEnabled = ffc("config.event.transports.enabled", "Which event interface plugins are enabled", i18n.ArrayStringType)
+
+	ConfigHashCanonicalization = ffc("config.hash.canonicalization", "How the JSON value of new data is serialized before it is hashed - none hashes the value exactly as supplied, and jcs hashes the JSON Canonicalization Scheme (RFC 8785) form so that logically equal values produce the same hash. The canonicalization is recorded on each data record", i18n.StringType)
+	ConfigHashAlgorithm        = ffc("config.hash.algorithm", "The algorithm used to hash new data and batches - sha256, sha3-256 or blake2b-256. The algorithm is recorded alongside each hash, and receiving nodes must support it. Blob hashes are always sha256", i18n.StringType)
 
 	ConfigHistogramsMaxChartRows = ffc("config.histograms.maxChartRows", "The maximum rows to fetch for each histogram bucket", i18n.IntType)
 
@@ -299,6 +390,15 @@ var (
 	ConfigPluginIdentityType = ffc("config.plugins.identity[].type", "The type of a configured Identity plugin", i18n.StringType)
 	ConfigPluginIdentityName = ffc("config.plugins.identity[].name", "The name of a configured Identity plugin", i18n.StringType)
 
+	ConfigPluginKeyManager     = ffc("config.plugins.keymanager", "The list of available key manager plugins, used to map key references to signing keys before they are passed to the blockchain connector", i18n.StringType)
+	ConfigPluginKeyManagerType = ffc("config.plugins.keymanager[].type", "The type of a configured key manager plugin", i18n.StringType)
+	ConfigPluginKeyManagerName = ffc("config.plugins.keymanager[].name", "The name of a configured key manager plugin", i18n.StringType)
+
+	ConfigPluginKeyManagerEthSignerAliases          = ffc("config.plugins.keymanager[].ethsigner.aliases", "A map of alias names to the Ethereum addresses they refer to", i18n.MapStringStringType)
+	ConfigPluginKeyManagerEthSignerAccountsCacheTTL = ffc("config.plugins.keymanager[].ethsigner.accountsCacheTTL", "How long the list of accounts held by the remote signer is cached before being refreshed", i18n.TimeDurationType)
+	ConfigPluginKeyManagerEthSignerURL              = ffc("config.plugins.keymanager[].ethsigner.url", "The URL of the remote Ethereum signer, which must support the eth_accounts JSON/RPC method", urlStringType)
+	ConfigPluginKeyManagerEthSignerProxyNoProxy     = ffc("config.plugins.keymanager[].ethsigner.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+
 	ConfigIdentityManagerLegacySystemIdentitites = ffc("config.identity.manager.legacySystemIdentities", "Whether the identity manager should resolve legacy identities registered on the ff_system namespace", i18n.BooleanType)
 
 	ConfigLogCompress   = ffc("config.log.compress", "Determines if the rotated log files should be compressed using gzip", i18n.BooleanType)
@@ -320,7 +420,7 @@ var (
 	ConfigTransactionWriterBatchTimeout         = ffc("config.transaction.writer.batchTimeout", "How long to wait for more transactions to arrive before flushing the batch", i18n.TimeDurationType)
 	ConfigTransactionWriterCount                = ffc("config.transaction.writer.count", "The number of message writer workers", i18n.IntType)
 
-	ConfigMetricsAddress      = ffc("config.metrics.address", "The IP address on which the metrics HTTP API should listen", i18n.IntType)
+	ConfigMetricsAddress      = ffc("config.metrics.address", "The IP address on which the metrics HTTP API should listen", i18n.StringType)
 	ConfigMetricsEnabled      = ffc("config.metrics.enabled", "Enables the metrics API", i18n.BooleanType)
 	ConfigMetricsPath         = ffc("config.metrics.path", "The path from which to serve the Prometheus metrics", i18n.StringType)
 	ConfigMetricsPort         = ffc("config.metrics.port", "The port on which the metrics HTTP API should listen", i18n.IntType)
@@ -328,27 +428,55 @@ var (
 	ConfigMetricsReadTimeout  = ffc("config.metrics.readTimeout", "The maximum time to wait when reading from an HTTP connection", i18n.TimeDurationType)
 	ConfigMetricsWriteTimeout = ffc("config.metrics.writeTimeout", "The maximum time to wait when writing to an HTTP connection", i18n.TimeDurationType)
 
-	ConfigNamespacesDefault                    = ffc("config.namespaces.default", "The default namespace - must be in the predefined list", i18n.StringType)
-	ConfigNamespacesPredefined                 = ffc("config.namespaces.predefined", "A list of namespaces to ensure exists, without requiring a broadcast from the network", "List "+i18n.StringType)
-	ConfigNamespacesPredefinedName             = ffc("config.namespaces.predefined[].name", "The name of the namespace (must be unique)", i18n.StringType)
-	ConfigNamespacesPredefinedDescription      = ffc("config.namespaces.predefined[].description", "A description for the namespace", i18n.StringType)
-	ConfigNamespacesPredefinedPlugins          = ffc("config.namespaces.predefined[].plugins", "The list of plugins for this namespace", i18n.StringType)
-	ConfigNamespacesPredefinedDefaultKey       = ffc("config.namespaces.predefined[].defaultKey", "A default signing key for blockchain transactions within this namespace", i18n.StringType)
-	ConfigNamespacesPredefinedKeyNormalization = ffc("config.namespaces.predefined[].asset.manager.keyNormalization", "Mechanism to normalize keys before using them. Valid options are `blockchain_plugin` - use blockchain plugin (default) or `none` - do not attempt normalization", i18n.StringType)
-	ConfigNamespacesPredefinedTLSConfigs       = ffc("config.namespaces.predefined[].tlsConfigs", "Supply a set of tls certificates to be used by subscriptions for this namespace", "List "+i18n.StringType)
-	ConfigNamespacesPredefinedTLSConfigsName   = ffc("config.namespaces.predefined[].tlsConfigs[].name", "Name of the TLS Config", i18n.StringType)
+	ConfigNamespacesDefault                          = ffc("config.namespaces.default", "The default namespace - must be in the predefined list", i18n.StringType)
+	ConfigNamespacesPredefined                       = ffc("config.namespaces.predefined", "A list of namespaces to ensure exists, without requiring a broadcast from the network", "List "+i18n.StringType)
+	ConfigNamespacesPredefinedName                   = ffc("config.namespaces.predefined[].name", "The name of the namespace (must be unique)", i18n.StringType)
+	ConfigNamespacesPredefinedDescription            = ffc("config.namespaces.predefined[].description", "A description for the namespace", i18n.StringType)
+	ConfigNamespacesPredefinedPlugins                = ffc("config.namespaces.predefined[].plugins", "The list of plugins for this namespace", i18n.StringType)
+	ConfigNamespacesPredefinedSandbox                = ffc("config.namespaces.predefined[].sandbox", "Mark this namespace as a sandbox, into which the definitions and events of other namespaces on this node can be replayed to test changes against their history. A sandbox cannot be a multi-party namespace", i18n.BooleanType)
+	ConfigNamespacesPredefinedIsolated               = ffc("config.namespaces.predefined[].isolated", "Require the blockchain, database, dataexchange, sharedstorage and tokens plugins of this namespace to be bound exclusively to it, so it cannot share a chain or storage with any other namespace on this node", i18n.BooleanType)
+	ConfigNamespacesPredefinedDefaultKey             = ffc("config.namespaces.predefined[].defaultKey", "A default signing key for blockchain transactions within this namespace", i18n.StringType)
+	ConfigNamespacesPredefinedKeyNormalization       = ffc("config.namespaces.predefined[].asset.manager.keyNormalization", "Mechanism to normalize keys before using them. Valid options are `blockchain_plugin` - use blockchain plugin (default) or `none` - do not attempt normalization", i18n.StringType)
+	ConfigNamespacesPredefinedPoolTemplates          = ffc("config.namespaces.predefined[].asset.manager.poolTemplates", "A list of named templates that can be referenced when creating token pools in this namespace, so pools can be created without knowing the connector specific configuration", "List "+i18n.StringType)
+	ConfigNamespacesPredefinedPoolTemplatesName      = ffc("config.namespaces.predefined[].asset.manager.poolTemplates[].name", "The name of the template, which is supplied in the template field when creating a pool", i18n.StringType)
+	ConfigNamespacesPredefinedPoolTemplatesConnector = ffc("config.namespaces.predefined[].asset.manager.poolTemplates[].connector", "The token connector used by pools created from the template, if not specified on creation", i18n.StringType)
+	ConfigNamespacesPredefinedPoolTemplatesType      = ffc("config.namespaces.predefined[].asset.manager.poolTemplates[].type", "The type of token of pools created from the template, if not specified on creation. Valid options are `fungible` or `nonfungible`", i18n.StringType)
+	ConfigNamespacesPredefinedPoolTemplatesStandard  = ffc("config.namespaces.predefined[].asset.manager.poolTemplates[].standard", "The token standard that pools created from the template conform to, such as ERC20. Informational, for users choosing a template", i18n.StringType)
+	ConfigNamespacesPredefinedPoolTemplatesDecimals  = ffc("config.namespaces.predefined[].asset.manager.poolTemplates[].decimals", "The number of decimals of pools created from the template. Informational, for users choosing a template", i18n.IntType)
+	ConfigNamespacesPredefinedPoolTemplatesConfig    = ffc("config.namespaces.predefined[].asset.manager.poolTemplates[].config", "The connector specific config passed when creating pools from the template. Keys supplied in the config on creation override those of the template", i18n.MapStringStringType)
+	ConfigNamespacesPredefinedDownloadBatch          = ffc("config.namespaces.predefined[].download.priority.batch", "The priority of batch downloads in the work queue of this namespace (defaults to download.priority.batch)", i18n.IntType)
+	ConfigNamespacesPredefinedBatchWorkers           = ffc("config.namespaces.predefined[].batch.workers", "The maximum number of batches this namespace seals and dispatches concurrently (defaults to batch.manager.workers)", i18n.IntType)
+	ConfigNamespacesPredefinedBatchQueueDepth        = ffc("config.namespaces.predefined[].batch.queueDepth", "The number of messages each batch processor of this namespace queues for assembly (defaults to batch.manager.queueDepth)", i18n.IntType)
+	ConfigNamespacesPredefinedHashCanonicalization   = ffc("config.namespaces.predefined[].hash.canonicalization", "How the JSON value of new data in this namespace is serialized before it is hashed - none or jcs (defaults to hash.canonicalization)", i18n.StringType)
+	ConfigNamespacesPredefinedValidationPolicy       = ffc("config.namespaces.predefined[].validation.policy", "What happens to received messages in this namespace whose data fails validation against its datatype (defaults to validation.policy)", i18n.StringType)
+	ConfigNamespacesPredefinedValidationTypes        = ffc("config.namespaces.predefined[].validation.datatypes", "A map of datatype names to the validation policy for data of that datatype, overriding the policy of the namespace", i18n.MapStringStringType)
+	ConfigNamespacesPredefinedDownloadBlob           = ffc("config.namespaces.predefined[].download.priority.blob", "The priority of blob downloads in the work queue of this namespace (defaults to download.priority.blob)", i18n.IntType)
+	ConfigNamespacesPredefinedTLSConfigs             = ffc("config.namespaces.predefined[].tlsConfigs", "Supply a set of tls certificates to be used by subscriptions for this namespace", "List "+i18n.StringType)
+	ConfigNamespacesPredefinedTLSConfigsName         = ffc("config.namespaces.predefined[].tlsConfigs[].name", "Name of the TLS Config", i18n.StringType)
 	// ConfigNamespacesPredefinedTLSConfigsTLS      = ffc("config.namespaces.predefined[].tlsConfigs[].tls", "Specify the path to a CA, Cert and Key for TLS communication", i18n.StringType)
-	ConfigNamespacesMultipartyEnabled            = ffc("config.namespaces.predefined[].multiparty.enabled", "Enables multi-party mode for this namespace (defaults to true if an org name or key is configured, either here or at the root level)", i18n.BooleanType)
-	ConfigNamespacesMultipartyNetworkNamespace   = ffc("config.namespaces.predefined[].multiparty.networknamespace", "The shared namespace name to be sent in multiparty messages, if it differs from the local namespace name", i18n.StringType)
-	ConfigNamespacesMultipartyOrgName            = ffc("config.namespaces.predefined[].multiparty.org.name", "A short name for the local root organization within this namespace", i18n.StringType)
-	ConfigNamespacesMultipartyOrgDesc            = ffc("config.namespaces.predefined[].multiparty.org.description", "A description for the local root organization within this namespace", i18n.StringType)
-	ConfigNamespacesMultipartyOrgKey             = ffc("config.namespaces.predefined[].multiparty.org.key", "The signing key allocated to the root organization within this namespace", i18n.StringType)
-	ConfigNamespacesMultipartyNodeName           = ffc("config.namespaces.predefined[].multiparty.node.name", "The node name for this namespace", i18n.StringType)
-	ConfigNamespacesMultipartyNodeDescription    = ffc("config.namespaces.predefined[].multiparty.node.description", "A description for the node in this namespace", i18n.StringType)
-	ConfigNamespacesMultipartyContract           = ffc("config.namespaces.predefined[].contract", "A list containing configuration for the multi-party blockchain contract", i18n.StringType)
-	ConfigNamespacesMultipartyContractFirstEvent = ffc("config.namespaces.predefined[].multiparty.contract[].firstEvent", "The first event the contract should process. Valid options are `oldest` or `newest`", i18n.StringType)
-	ConfigNamespacesMultipartyContractLocation   = ffc("config.namespaces.predefined[].multiparty.contract[].location", "A blockchain-specific contract location. For example, an Ethereum contract address, or a Fabric chaincode name and channel", i18n.StringType)
-	ConfigNamespacesMultipartyContractOptions    = ffc("config.namespaces.predefined[].multiparty.contract[].options", "Blockchain-specific contract options", i18n.StringType)
+	ConfigNamespacesMultipartyEnabled                        = ffc("config.namespaces.predefined[].multiparty.enabled", "Enables multi-party mode for this namespace (defaults to true if an org name or key is configured, either here or at the root level)", i18n.BooleanType)
+	ConfigNamespacesMultipartyNetworkNamespace               = ffc("config.namespaces.predefined[].multiparty.networknamespace", "The shared namespace name to be sent in multiparty messages, if it differs from the local namespace name", i18n.StringType)
+	ConfigNamespacesMultipartyOrgName                        = ffc("config.namespaces.predefined[].multiparty.org.name", "A short name for the local root organization within this namespace", i18n.StringType)
+	ConfigNamespacesMultipartyOrgDesc                        = ffc("config.namespaces.predefined[].multiparty.org.description", "A description for the local root organization within this namespace", i18n.StringType)
+	ConfigNamespacesMultipartyOrgKey                         = ffc("config.namespaces.predefined[].multiparty.org.key", "The signing key allocated to the root organization within this namespace", i18n.StringType)
+	ConfigNamespacesMultipartyOrgCertificate                 = ffc("config.namespaces.predefined[].multiparty.org.certificate", "A PEM encoded X.509 certificate chain issued to the root organization, presented when registering the organization", i18n.StringType)
+	ConfigNamespacesMultipartyOrgCertificateSignature        = ffc("config.namespaces.predefined[].multiparty.org.certificateSignature", "A base64 encoded signature over the compact JSON {\"did\":\"did:firefly:org/<name>\"} of the root organization, made with the private key of its certificate, proving the organization holds the certificate", i18n.StringType)
+	ConfigNamespacesMultipartyOrgVerificationTrustRoots      = ffc("config.namespaces.predefined[].multiparty.orgVerification.trustRoots", "A PEM encoded bundle of CA certificates. When set, organizations registering in this namespace must present a certificate chaining to one of these roots", i18n.StringType)
+	ConfigNamespacesMultipartyIdentityClaimsRequireSignature = ffc("config.namespaces.predefined[].multiparty.identityClaims.requireSignature", "When true, identity claims in this namespace must include a signature made with the submitting blockchain key, which is verified by the blockchain plugin", i18n.BooleanType)
+	ConfigNamespacesMultipartyNodeName                       = ffc("config.namespaces.predefined[].multiparty.node.name", "The node name for this namespace", i18n.StringType)
+	ConfigNamespacesMultipartyNodeDescription                = ffc("config.namespaces.predefined[].multiparty.node.description", "A description for the node in this namespace", i18n.StringType)
+	ConfigNamespacesMultipartySequencerType                  = ffc("config.namespaces.predefined[].multiparty.sequencer.type", "The plugin used to order batches in this namespace. Valid options are `blockchain`, which pins each batch to the multi-party contract, or `database`, which orders batches using a sequence allocated by the shared database", i18n.StringType)
+	ConfigNamespacesMultipartySequencerPollInterval          = ffc("config.namespaces.predefined[].multiparty.sequencer.pollInterval", "How often the database sequencer checks for newly sequenced batches", i18n.TimeDurationType)
+	ConfigNamespacesMultipartySequencerBatchSize             = ffc("config.namespaces.predefined[].multiparty.sequencer.batchSize", "The maximum number of sequenced batches the database sequencer delivers in each page", i18n.IntType)
+	ConfigNamespacesMultipartyAnchorPlugin                   = ffc("config.namespaces.predefined[].multiparty.anchor.plugin", "The name of a second blockchain plugin, to which every batch in this namespace is also pinned. Must differ from the blockchain plugin of the namespace", i18n.StringType)
+	ConfigNamespacesMultipartyAnchorKey                      = ffc("config.namespaces.predefined[].multiparty.anchor.key", "The signing key used to submit batch pins to the anchor chain", i18n.StringType)
+	ConfigNamespacesMultipartyAnchorLocation                 = ffc("config.namespaces.predefined[].multiparty.anchor.location", "A blockchain-specific contract location on the anchor chain. For example, an Ethereum contract address, or a Fabric chaincode name and channel", i18n.StringType)
+	ConfigNamespacesMultipartyAnchorMode                     = ffc("config.namespaces.predefined[].multiparty.anchor.mode", "How batches are anchored. Valid options are `batch`, which pins every batch to the anchor chain, or `digest`, which periodically pins a single Merkle root of all the batches pinned since the last digest", i18n.StringType)
+	ConfigNamespacesMultipartyAnchorDigestInterval           = ffc("config.namespaces.predefined[].multiparty.anchor.digest.interval", "How often a digest is submitted to the anchor chain, when the anchor mode is `digest`", i18n.TimeDurationType)
+	ConfigNamespacesMultipartyContract                       = ffc("config.namespaces.predefined[].contract", "A list containing configuration for the multi-party blockchain contract", i18n.StringType)
+	ConfigNamespacesMultipartyContractFirstEvent             = ffc("config.namespaces.predefined[].multiparty.contract[].firstEvent", "The first event the contract should process. Valid options are `oldest` or `newest`", i18n.StringType)
+	ConfigNamespacesMultipartyContractLocation               = ffc("config.namespaces.predefined[].multiparty.contract[].location", "A blockchain-specific contract location. For example, an Ethereum contract address, or a Fabric chaincode name and channel", i18n.StringType)
+	ConfigNamespacesMultipartyContractOptions                = ffc("config.namespaces.predefined[].multiparty.contract[].options", "Blockchain-specific contract options", i18n.StringType)
 
 	ConfigNodeDescription = ffc("config.node.description", "The description of this FireFly node", i18n.StringType)
 	ConfigNodeName        = ffc("config.node.name", "The name of this FireFly node", i18n.StringType)
@@ -358,55 +486,124 @@ var (
 	ConfigOpupdateWorkerCount           = ffc("config.opupdate.worker.count", "The number of operation update works", i18n.IntType)
 	ConfigOpupdateWorkerQueueLength     = ffc("config.opupdate.worker.queueLength", "The size of the queue for the Operation Update worker", i18n.IntType)
 
+	ConfigOpretryPoliciesType         = ffc("config.opretry.policies[].type", "The type of operation the policy applies to. Supported types are blockchain_pin_batch, dataexchange_send_batch, dataexchange_send_blob, token_create_pool, token_activate_pool, token_transfer and token_approval", i18n.StringType)
+	ConfigOpretryPoliciesMaxAttempts  = ffc("config.opretry.policies[].maxAttempts", "The maximum number of attempts of a failed operation, including the original attempt", i18n.IntType)
+	ConfigOpretryPoliciesInitialDelay = ffc("config.opretry.policies[].initialDelay", "The delay before the first automatic retry of a failed operation", i18n.TimeDurationType)
+	ConfigOpretryPoliciesMaxDelay     = ffc("config.opretry.policies[].maxDelay", "The maximum delay between automatic retries of a failed operation", i18n.TimeDurationType)
+	ConfigOpretryPoliciesFactor       = ffc("config.opretry.policies[].factor", "The backoff factor applied to the delay after each automatic retry", i18n.FloatType)
+
+	ConfigOpwatchdogInterval          = ffc("config.opwatchdog.interval", "How often the operation watchdog checks for operations that have stalled in the pending state", i18n.TimeDurationType)
+	ConfigOpwatchdogThresholdsType    = ffc("config.opwatchdog.thresholds[].type", "The type of operation the threshold applies to", i18n.StringType)
+	ConfigOpwatchdogThresholdsTimeout = ffc("config.opwatchdog.thresholds[].timeout", "How long an operation of this type can remain pending before an operation_stalled event is emitted for it", i18n.TimeDurationType)
+
+	ConfigPeermonitorInterval = ffc("config.peermonitor.interval", "How often to check whether the data exchange can reach each node in the network, emitting node_connectivity_changed events when this changes. Only applies to data exchange plugins that track the connectivity of their peers. Set to 0 to disable", i18n.TimeDurationType)
+
+	ConfigOplimitsMaxInputSize  = ffc("config.oplimits.maxInputSize", "The maximum size of the input JSON stored on an operation. Larger inputs are stored as a data record, which the operation references. Set to 0 for no limit", i18n.ByteSizeType)
+	ConfigOplimitsMaxOutputSize = ffc("config.oplimits.maxOutputSize", "The maximum size of the output JSON stored on an operation. Larger outputs are stored as a data record, which the operation references. Set to 0 for no limit", i18n.ByteSizeType)
+
+	ConfigOpqueueEnabled      = ffc("config.opqueue.enabled", "Record each operation in a durable queue, in the same database transaction as the operation, until its plugin has been called. Operations that were never submitted because the node stopped are submitted automatically", i18n.BooleanType)
+	ConfigOpqueueWorkers      = ffc("config.opqueue.workers", "The maximum number of plugin calls for operations that are made concurrently, when the queue is enabled", i18n.IntType)
+	ConfigOpqueuePollInterval = ffc("config.opqueue.pollInterval", "How often the queue is checked for operations that were never submitted. Entries newer than this interval are left to the request that created them", i18n.TimeDurationType)
+	ConfigOpqueueBatchSize    = ffc("config.opqueue.batchSize", "The maximum number of queued operations that are submitted on each poll", i18n.IntType)
+
+	ConfigOpreceiptsPollInterval = ffc("config.opreceipts.pollInterval", "How often the blockchain connector is asked for the receipts of transactions that were submitted outside of FireFly and attached to an operation", i18n.TimeDurationType)
+
+	ConfigOprecoveryEnabled = ffc("config.oprecovery.enabled", "On startup, reconcile the operations that were in-flight when the node stopped with the connectors, resume those that were never submitted, and report on them along with any unconfirmed batches", i18n.BooleanType)
+	ConfigOprecoveryLimit   = ffc("config.oprecovery.limit", "The maximum number of in-flight operations, and of unconfirmed batches, to recover on startup", i18n.IntType)
+
 	ConfigOrchestratorStartupAttempts = ffc("config.orchestrator.startupAttempts", "The number of times to attempt to connect to core infrastructure on startup", i18n.StringType)
 
 	ConfigOrgDescription = ffc("config.org.description", "A description of the organization to which this FireFly node belongs (deprecated - should be set on each multi-party namespace instead)", i18n.StringType)
 	ConfigOrgKey         = ffc("config.org.key", "The signing key allocated to the organization (deprecated - should be set on each multi-party namespace instead)", i18n.StringType)
 	ConfigOrgName        = ffc("config.org.name", "The name of the organization to which this FireFly node belongs (deprecated - should be set on each multi-party namespace instead)", i18n.StringType)
 
-	ConfigPrivatemessagingBatchAgentTimeout = ffc("config.privatemessaging.batch.agentTimeout", "How long to keep around a batching agent for a sending identity before disposal", i18n.TimeDurationType)
-	ConfigPrivatemessagingBatchPayloadLimit = ffc("config.privatemessaging.batch.payloadLimit", "The maximum payload size of a private message Data Exchange payload", i18n.ByteSizeType)
-	ConfigPrivatemessagingBatchSize         = ffc("config.privatemessaging.batch.size", "The maximum number of messages in a batch for private messages", i18n.IntType)
-	ConfigPrivatemessagingBatchTimeout      = ffc("config.privatemessaging.batch.timeout", "The timeout to wait for a batch to fill, before sending", i18n.TimeDurationType)
+	ConfigPrivatemessagingBatchAgentTimeout  = ffc("config.privatemessaging.batch.agentTimeout", "How long to keep around a batching agent for a sending identity before disposal", i18n.TimeDurationType)
+	ConfigPrivatemessagingBatchCompression   = ffc("config.privatemessaging.batch.compression", "The compression applied to the payload of private batches sent over Data Exchange - none, gzip or zstd. Receiving nodes must support compression", i18n.StringType)
+	ConfigPrivatemessagingBatchPayloadLimit  = ffc("config.privatemessaging.batch.payloadLimit", "The maximum payload size of a private message Data Exchange payload", i18n.ByteSizeType)
+	ConfigPrivatemessagingBatchSize          = ffc("config.privatemessaging.batch.size", "The maximum number of messages in a batch for private messages", i18n.IntType)
+	ConfigPrivatemessagingBatchTopicAffinity = ffc("config.privatemessaging.batch.topicAffinity", "Assemble a separate batch for each set of message topics, so messages on unrelated topics do not block each other in the same batch", i18n.BooleanType)
+	ConfigPrivatemessagingBatchTimeout       = ffc("config.privatemessaging.batch.timeout", "The timeout to wait for a batch to fill, before sending", i18n.TimeDurationType)
+
+	ConfigPrivatemessagingTransfersMaxParallel           = ffc("config.privatemessaging.transfers.maxParallel", "The maximum number of blob transfers in flight to all peers at any one time. Zero means no limit. Can be changed at runtime through the admin API", i18n.IntType)
+	ConfigPrivatemessagingTransfersMaxParallelPerPeer    = ffc("config.privatemessaging.transfers.maxParallelPerPeer", "The maximum number of blob transfers in flight to a single peer at any one time. Zero means no limit", i18n.IntType)
+	ConfigPrivatemessagingTransfersBytesPerSecond        = ffc("config.privatemessaging.transfers.bytesPerSecond", "The maximum rate at which blob transfers to all peers are started, in bytes per second. Zero means no limit", i18n.ByteSizeType)
+	ConfigPrivatemessagingTransfersBytesPerSecondPerPeer = ffc("config.privatemessaging.transfers.bytesPerSecondPerPeer", "The maximum rate at which blob transfers to a single peer are started, in bytes per second. Zero means no limit", i18n.ByteSizeType)
+
+	ConfigProxyURL     = ffc("config.proxy.url", "Optional HTTP, HTTPS or SOCKS5 proxy server that the REST requests and websockets of plugins are routed through, including the blockchain, token and data exchange connectors, address resolvers, the remote signer, IPFS, S3 and the transfer policy service. Can be overridden by the proxy.url of each plugin", urlStringType)
+	ConfigProxyNoProxy = ffc("config.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, such as .internal.example.com or 10.0.0.0/8. Can be overridden by the proxy.noProxy of each plugin", i18n.ArrayStringType)
 
 	ConfigSharedstorageType                = ffc("config.sharedstorage.type", "The Shared Storage plugin to use", i18n.StringType)
 	ConfigSharedstorageIpfsAPIURL          = ffc("config.sharedstorage.ipfs.api.url", "The URL for the IPFS API", urlStringType)
 	ConfigSharedstorageIpfsAPIProxyURL     = ffc("config.sharedstorage.ipfs.api.proxy.url", "Optional HTTP proxy server to use when connecting to the IPFS API", urlStringType)
 	ConfigSharedstorageIpfsGatewayURL      = ffc("config.sharedstorage.ipfs.gateway.url", "The URL for the IPFS Gateway", urlStringType)
 	ConfigSharedstorageIpfsGatewayProxyURL = ffc("config.sharedstorage.ipfs.gateway.proxy.url", "Optional HTTP proxy server to use when connecting to the IPFS Gateway", urlStringType)
-
-	ConfigPluginSharedstorage                    = ffc("config.plugins.sharedstorage", "The list of configured Shared Storage plugins", i18n.StringType)
-	ConfigPluginSharedstorageName                = ffc("config.plugins.sharedstorage[].name", "The name of the Shared Storage plugin to use", i18n.StringType)
-	ConfigPluginSharedstorageType                = ffc("config.plugins.sharedstorage[].type", "The Shared Storage plugin to use", i18n.StringType)
-	ConfigPluginSharedstorageIpfsAPIURL          = ffc("config.plugins.sharedstorage[].ipfs.api.url", "The URL for the IPFS API", urlStringType)
-	ConfigPluginSharedstorageIpfsAPIProxyURL     = ffc("config.plugins.sharedstorage[].ipfs.api.proxy.url", "Optional HTTP proxy server to use when connecting to the IPFS API", urlStringType)
-	ConfigPluginSharedstorageIpfsGatewayURL      = ffc("config.plugins.sharedstorage[].ipfs.gateway.url", "The URL for the IPFS Gateway", urlStringType)
-	ConfigPluginSharedstorageIpfsGatewayProxyURL = ffc("config.plugins.sharedstorage[].ipfs.gateway.proxy.url", "Optional HTTP proxy server to use when connecting to the IPFS Gateway", urlStringType)
+	ConfigSharedstorageIpfsPinningURL      = ffc("config.sharedstorage.ipfs.pinning.url", "The URL of a remote pinning service implementing the IPFS Pinning Service API, such as Pinata or web3.storage. When set, uploaded data is pinned with the service", urlStringType)
+	ConfigSharedstorageIpfsPinningProxyURL = ffc("config.sharedstorage.ipfs.pinning.proxy.url", "Optional HTTP proxy server to use when connecting to the pinning service", urlStringType)
+
+	ConfigPluginSharedstorage                        = ffc("config.plugins.sharedstorage", "The list of configured Shared Storage plugins", i18n.StringType)
+	ConfigPluginSharedstorageName                    = ffc("config.plugins.sharedstorage[].name", "The name of the Shared Storage plugin to use", i18n.StringType)
+	ConfigPluginSharedstorageType                    = ffc("config.plugins.sharedstorage[].type", "The Shared Storage plugin to use", i18n.StringType)
+	ConfigPluginSharedstorageIpfsAPIURL              = ffc("config.plugins.sharedstorage[].ipfs.api.url", "The URL for the IPFS API", urlStringType)
+	ConfigPluginSharedstorageIpfsAPIProxyURL         = ffc("config.plugins.sharedstorage[].ipfs.api.proxy.url", "Optional HTTP proxy server to use when connecting to the IPFS API", urlStringType)
+	ConfigPluginSharedstorageIpfsAPIProxyNoProxy     = ffc("config.plugins.sharedstorage[].ipfs.api.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+	ConfigPluginSharedstorageIpfsGatewayURL          = ffc("config.plugins.sharedstorage[].ipfs.gateway.url", "The URL for the IPFS Gateway", urlStringType)
+	ConfigPluginSharedstorageIpfsGatewayProxyURL     = ffc("config.plugins.sharedstorage[].ipfs.gateway.proxy.url", "Optional HTTP proxy server to use when connecting to the IPFS Gateway", urlStringType)
+	ConfigPluginSharedstorageIpfsGatewayProxyNoProxy = ffc("config.plugins.sharedstorage[].ipfs.gateway.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+	ConfigPluginSharedstorageIpfsPinningURL          = ffc("config.plugins.sharedstorage[].ipfs.pinning.url", "The URL of a remote pinning service implementing the IPFS Pinning Service API, such as Pinata or web3.storage. When set, uploaded data is pinned with the service", urlStringType)
+	ConfigPluginSharedstorageIpfsPinningProxyURL     = ffc("config.plugins.sharedstorage[].ipfs.pinning.proxy.url", "Optional HTTP proxy server to use when connecting to the pinning service", urlStringType)
+	ConfigPluginSharedstorageIpfsPinningProxyNoProxy = ffc("config.plugins.sharedstorage[].ipfs.pinning.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+	ConfigPluginSharedstorageS3URL                   = ffc("config.plugins.sharedstorage[].s3.url", "The URL of the S3 compatible object store, such as https://s3.us-east-1.amazonaws.com or the URL of a MinIO server. Objects are addressed path-style, under this URL", urlStringType)
+	ConfigPluginSharedstorageS3ProxyURL              = ffc("config.plugins.sharedstorage[].s3.proxy.url", "Optional HTTP proxy server to use when connecting to the object store", urlStringType)
+	ConfigPluginSharedstorageS3ProxyNoProxy          = ffc("config.plugins.sharedstorage[].s3.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+	ConfigPluginSharedstorageS3Bucket                = ffc("config.plugins.sharedstorage[].s3.bucket", "The bucket that payloads are stored in, keyed by the SHA256 hash of their content", i18n.StringType)
+	ConfigPluginSharedstorageS3Region                = ffc("config.plugins.sharedstorage[].s3.region", "The region of the bucket, used to sign requests", i18n.StringType)
+	ConfigPluginSharedstorageS3AccessKeyID           = ffc("config.plugins.sharedstorage[].s3.accessKeyId", "The access key ID used to sign requests", i18n.StringType)
+	ConfigPluginSharedstorageS3SecretAccessKey       = ffc("config.plugins.sharedstorage[].s3.secretAccessKey", "The secret access key used to sign requests", i18n.StringType)
+	ConfigPluginSharedstorageS3PresignedRefsEnabled  = ffc("config.plugins.sharedstorage[].s3.presignedRefs.enabled", "When true, the payload reference of uploaded data is a pre-signed download URL that other members can use without credentials of their own. When false, it is the object key", i18n.BooleanType)
+	ConfigPluginSharedstorageS3PresignedRefsExpiry   = ffc("config.plugins.sharedstorage[].s3.presignedRefs.expiry", "How long pre-signed payload references remain valid. The maximum supported by S3 is 7 days", i18n.TimeDurationType)
 
 	ConfigSubscriptionMax                          = ffc("config.subscription.max", "The maximum number of pre-defined subscriptions that can exist (note for high fan-out consider connecting a dedicated pub/sub broker to the dispatcher)", i18n.IntType)
 	ConfigSubscriptionDefaultsBatchSize            = ffc("config.subscription.defaults.batchSize", "Default read ahead to enable for subscriptions that do not explicitly configure readahead", i18n.IntType)
-	ConfigSubscriptionDefaultsBatchTimeout         = ffc("config.subscription.defaults.batchTimeout", "Default batch timeout", i18n.IntType)
+	ConfigSubscriptionDefaultsBatchTimeout         = ffc("config.subscription.defaults.batchTimeout", "Default batch timeout", i18n.TimeDurationType)
 	ConfigSubscriptionMaxHistoricalEventScanLength = ffc("config.subscription.events.maxScanLength", "The maximum number of events a search for historical events matching a subscription will index from the database", i18n.IntType)
+	ConfigSubscriptionSLAEnabled                   = ffc("config.subscription.sla.enabled", "Records daily aggregates of the time taken for durable subscriptions to acknowledge events, from the creation of each event", i18n.BooleanType)
+	ConfigSubscriptionSLATarget                    = ffc("config.subscription.sla.target", "The acknowledgement time that the daily SLA aggregates count deliveries as within", i18n.TimeDurationType)
+	ConfigStatsResyncInterval                      = ffc("config.stats.resyncInterval", "How often the namespace statistics, which are maintained incrementally between resynchronizations, are recalculated from the database", i18n.TimeDurationType)
+	ConfigSubscriptionSLAFlushInterval             = ffc("config.subscription.sla.flushInterval", "How often the SLA aggregates accumulated in memory are written to the database", i18n.TimeDurationType)
 
 	ConfigTokensName     = ffc("config.tokens[].name", "A name to identify this token plugin", i18n.StringType)
 	ConfigTokensPlugin   = ffc("config.tokens[].plugin", "The type of the token plugin to use", i18n.StringType)
 	ConfigTokensURL      = ffc("config.tokens[].url", "The URL of the token connector", urlStringType)
 	ConfigTokensProxyURL = ffc("config.tokens[].proxy.url", "Optional HTTP proxy server to use when connecting to the token connector", urlStringType)
 
-	ConfigPluginTokens                            = ffc("config.plugins.tokens", "The token plugin configurations", i18n.StringType)
-	ConfigPluginTokensName                        = ffc("config.plugins.tokens[].name", "A name to identify this token plugin", i18n.StringType)
-	ConfigPluginTokensBroadcastName               = ffc("config.plugins.tokens[].broadcastName", "The name to be used in broadcast messages related to this token plugin, if it differs from the local plugin name", i18n.StringType)
-	ConfigPluginTokensType                        = ffc("config.plugins.tokens[].type", "The type of the token plugin to use", i18n.StringType)
-	ConfigPluginTokensURL                         = ffc("config.plugins.tokens[].fftokens.url", "The URL of the token connector", urlStringType)
-	ConfigPluginTokensProxyURL                    = ffc("config.plugins.tokens[].fftokens.proxy.url", "Optional HTTP proxy server to use when connecting to the token connector", urlStringType)
-	ConfigPluginTokensBackgroundStart             = ffc("config.plugins.tokens[].fftokens.backgroundStart.enabled", "Start the tokens plugin in the background and enter retry loop if failed to start", i18n.BooleanType)
-	ConfigPluginTokensBackgroundStartInitialDelay = ffc("config.plugins.tokens[].fftokens.backgroundStart.initialDelay", "Delay between restarts in the case where we retry to restart the token plugin", i18n.TimeDurationType)
-	ConfigPluginTokensBackgroundStartMaxDelay     = ffc("config.plugins.tokens[].fftokens.backgroundStart.maxDelay", "Max delay between restarts in the case where we retry to restart the token plugin", i18n.TimeDurationType)
-	ConfigPluginTokensBackgroundStartFactor       = ffc("config.plugins.tokens[].fftokens.backgroundStart.factor", "Set the factor by which the delay increases when retrying", i18n.FloatType)
+	ConfigPluginTokens                             = ffc("config.plugins.tokens", "The token plugin configurations", i18n.StringType)
+	ConfigPluginTokensName                         = ffc("config.plugins.tokens[].name", "A name to identify this token plugin", i18n.StringType)
+	ConfigPluginTokensBroadcastName                = ffc("config.plugins.tokens[].broadcastName", "The name to be used in broadcast messages related to this token plugin, if it differs from the local plugin name", i18n.StringType)
+	ConfigPluginTokensType                         = ffc("config.plugins.tokens[].type", "The type of the token plugin to use", i18n.StringType)
+	ConfigPluginTokensURL                          = ffc("config.plugins.tokens[].fftokens.url", "The URL of the token connector", urlStringType)
+	ConfigPluginTokensProxyURL                     = ffc("config.plugins.tokens[].fftokens.proxy.url", "Optional HTTP proxy server to use when connecting to the token connector", urlStringType)
+	ConfigPluginTokensProxyNoProxy                 = ffc("config.plugins.tokens[].fftokens.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
+	ConfigPluginTokensBackgroundStart              = ffc("config.plugins.tokens[].fftokens.backgroundStart.enabled", "Start the tokens plugin in the background and enter retry loop if failed to start", i18n.BooleanType)
+	ConfigPluginTokensBackgroundStartInitialDelay  = ffc("config.plugins.tokens[].fftokens.backgroundStart.initialDelay", "Delay between restarts in the case where we retry to restart the token plugin", i18n.TimeDurationType)
+	ConfigPluginTokensBackgroundStartMaxDelay      = ffc("config.plugins.tokens[].fftokens.backgroundStart.maxDelay", "Max delay between restarts in the case where we retry to restart the token plugin", i18n.TimeDurationType)
+	ConfigPluginTokensBackgroundStartFactor        = ffc("config.plugins.tokens[].fftokens.backgroundStart.factor", "Set the factor by which the delay increases when retrying", i18n.FloatType)
+	ConfigPluginTokensCircuitBreakerEnabled        = ffc("config.plugins.tokens[].fftokens.circuitBreaker.enabled", "Reject requests to the token connector immediately while it is failing, rather than waiting for each request to time out", i18n.BooleanType)
+	ConfigPluginTokensCircuitBreakerFailureRatio   = ffc("config.plugins.tokens[].fftokens.circuitBreaker.failureRatio", "The proportion of requests in a window that must fail (with a connection error or 5xx response) to open the circuit", i18n.FloatType)
+	ConfigPluginTokensCircuitBreakerMinRequests    = ffc("config.plugins.tokens[].fftokens.circuitBreaker.minimumRequests", "The number of requests that must be made in a window before the circuit can open", i18n.IntType)
+	ConfigPluginTokensCircuitBreakerWindow         = ffc("config.plugins.tokens[].fftokens.circuitBreaker.window", "The window over which the proportion of failed requests is measured", i18n.TimeDurationType)
+	ConfigPluginTokensCircuitBreakerOpenDuration   = ffc("config.plugins.tokens[].fftokens.circuitBreaker.openDuration", "How long requests are rejected once the circuit opens, before probe requests are sent to check if the connector has recovered", i18n.TimeDurationType)
+	ConfigPluginTokensCircuitBreakerHalfOpenProbes = ffc("config.plugins.tokens[].fftokens.circuitBreaker.halfOpenProbes", "The number of probe requests allowed while checking if the connector has recovered", i18n.IntType)
+
+	ConfigTransferPolicyURL          = ffc("config.transferpolicy.url", "Optional URL of an external service that approves or denies each mint, burn or transfer before it is submitted to the token connector", urlStringType)
+	ConfigTransferPolicyProxyURL     = ffc("config.transferpolicy.proxy.url", "Optional HTTP proxy server to use when connecting to the transfer policy service", urlStringType)
+	ConfigTransferPolicyProxyNoProxy = ffc("config.transferpolicy.proxy.noProxy", "A list of hosts, domains and CIDR ranges to connect to directly rather than through the proxy, overriding the global proxy.noProxy", i18n.ArrayStringType)
 
 	ConfigUIEnabled = ffc("config.ui.enabled", "Enables the web user interface", i18n.BooleanType)
 	ConfigUIPath    = ffc("config.ui.path", "The file system path which contains the static HTML, CSS, and JavaScript files for the user interface", i18n.StringType)
 
+	ConfigValidationPolicy                = ffc("config.validation.policy", "What happens to received messages whose data fails validation against its datatype - `enforce` rejects the message, `warn` confirms it with a message_validation_warning event, and `quarantine` holds it for review", i18n.StringType)
+	ConfigValidationProtobufMaxUploadSize = ffc("config.validation.protobuf.maxUploadSize", "The largest protobuf payload that can be uploaded to the data API, to be stored as the value of a piece of data", i18n.ByteSizeType)
+
 	ConfigAPIOASPanicOnMissingDescription = ffc("config.api.oas.panicOnMissingDescription", "Used for testing purposes only", i18n.IgnoredType)
 
 	ConfigSPIWebSocketBlockedWarnInternal = ffc("config.spi.ws.blockedWarnInterval", "How often to log warnings in core, when an admin change event listener falls behind the stream they requested and misses events", i18n.TimeDurationType)
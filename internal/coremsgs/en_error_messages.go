@@ -316,4 +316,146 @@ var (
 	MsgFiltersEmpty                            = ffe("FF10475", "No filters specified in contract listener: %s.", 500)
 	MsgContractListenerBlockchainFilterLimit   = ffe("FF10476", "Blockchain plugin only supports one filter for contract listener: %s.", 500)
 	MsgDuplicateContractListenerFilterLocation = ffe("FF10477", "Duplicate filter provided for contract listener for location", 400)
+	MsgRevokedMessageSigner                    = ffe("FF10478", "Invalid message '%s'. Signing key '%s' has been revoked")
+	MsgDefRejectedVerifierNotOwned             = ffe("FF10479", "Rejected %s '%s' - verifier '%s' is not owned by the identity")
+	MsgVerifierAlreadyRevoked                  = ffe("FF10480", "Verifier '%s' has already been revoked", 409)
+	MsgDXBadCertificate                        = ffe("FF10481", "Invalid certificate from data exchange: %s")
+	MsgInvalidOrgTrustRoots                    = ffe("FF10482", "No valid PEM encoded certificates found in org verification trust roots for namespace '%s'")
+	MsgOrgCertificateInvalid                   = ffe("FF10483", "Certificate for organization '%s' failed verification: %s", 400)
+	MsgInvokeReceiptDecodeFailed               = ffe("FF10484", "Failed to decode transaction receipt using the contract interface")
+	MsgContractInputInvalid                    = ffe("FF10485", "Invalid input for method '%s': %s", 400)
+	MsgInvalidListenerMapping                  = ffe("FF10486", "Invalid mapping rule %d on contract listener - a unique field name and a valid path are required", 400)
+	MsgListenerMappingFailed                   = ffe("FF10487", "Unable to coerce value of field '%s' to type '%s': %v")
+	MsgContractBatchEmpty                      = ffe("FF10488", "No items provided for batch invocation", 400)
+	MsgContractBatchItemInvalid                = ffe("FF10489", "Invalid item %d in batch invocation: %s", 400)
+	MsgContractBatchMessageNotSupported        = ffe("FF10490", "Pinned messages cannot be included in a batch invocation", 400)
+	MsgContractAPIDiffFromVersionRequired      = ffe("FF10491", "The version of the API to compare from must be specified", 400)
+	MsgPinParamNotFound                        = ffe("FF10492", "Parameter '%s' configured for pinning messages was not found in method '%s'", 400)
+	MsgContractInterfaceAlreadyShared          = ffe("FF10493", "An interface named '%s' with version '%s' has already been shared from namespace '%s'", 409)
+	MsgContractInterfaceHashMismatch           = ffe("FF10494", "Interface '%s' has hash '%s', which does not match the pinned hash '%s'", 409)
+	MsgOperationRetryPolicyNotSupported        = ffe("FF10495", "Automatic retry policies are not supported for operations of type '%s'")
+	MsgOperationNotCompensable                 = ffe("FF10496", "Operation '%s' of type '%s' has no effect that can be compensated")
+	MsgIPFSPinningRESTErr                      = ffe("FF10497", "Error from IPFS pinning service: %s")
+	MsgS3RESTErr                               = ffe("FF10498", "Error from S3: %s")
+	MsgS3InvalidPayloadRef                     = ffe("FF10499", "Invalid S3 payload reference '%s'", 400)
+	MsgDownloadHashMismatch                    = ffe("FF10500", "Content downloaded from shared storage '%s' has hash '%s', which does not match the pinned hash '%s'")
+	MsgInvalidTransferLimits                   = ffe("FF10501", "Transfer limits must not be negative", 400)
+	MsgLibp2pInvalidPayloadRef                 = ffe("FF10502", "Invalid blob payload reference '%s'", 400)
+	MsgLibp2pBlobStoreErr                      = ffe("FF10503", "Failed to access blob '%s' in the data exchange store")
+	MsgLibp2pInvalidPeer                       = ffe("FF10504", "Invalid libp2p peer info: %s")
+	MsgLibp2pTransferFailed                    = ffe("FF10505", "Transfer to peer '%s' failed: %s")
+	MsgLibp2pKeyFileErr                        = ffe("FF10506", "Failed to load the libp2p private key from '%s'")
+	MsgLibp2pHostErr                           = ffe("FF10507", "Failed to start the libp2p host")
+	MsgBatchCompressionUnsupported             = ffe("FF10508", "Unsupported batch compression '%s'")
+	MsgBatchDecompressFailed                   = ffe("FF10509", "Failed to decompress batch payload with '%s'")
+	MsgBatchDecompressedTooLarge               = ffe("FF10510", "Decompressed batch payload exceeds the limit of %d bytes")
+	MsgHashAlgorithmUnsupported                = ffe("FF10511", "Unsupported hash algorithm '%s'")
+	MsgBatchVerifyNoPins                       = ffe("FF10512", "No on-chain pins found for batch %s")
+	MsgBatchVerifyNotFound                     = ffe("FF10513", "The %s %s in the batch manifest was not found")
+	MsgWebSocketNotConnected                   = ffe("FF10514", "The websocket to %s is not connected")
+	MsgDXNotListening                          = ffe("FF10515", "Data exchange is not listening on any address")
+	MsgChaosInjectedError                      = ffe("FF10516", "Injected failure of %s on %s plugin '%s'")
+	MsgChaosDisconnected                       = ffe("FF10517", "The %s plugin '%s' is in an injected disconnection")
+	MsgNamespacePluginNotIsolated              = ffe("FF10518", "Namespace '%s' is isolated, but its %s plugin '%s' is also bound to namespace '%s'")
+	MsgMultipartyRequired                      = ffe("FF10519", "This action requires multi-party mode, which is not enabled for this namespace (it is running as a gateway)", 409)
+	MsgConfigUnknownKey                        = ffe("FF10520", "Unknown configuration key '%s'")
+	MsgConfigInvalidValue                      = ffe("FF10521", "Invalid value '%v' for configuration key '%s' - expected %s")
+	MsgConfigPortClash                         = ffe("FF10522", "The %s and %s servers are both configured to listen on port %s")
+	MsgConfigValidationFailed                  = ffe("FF10523", "Configuration validation failed with %d error(s)")
+	MsgPluginNotFound                          = ffe("FF10524", "No %s plugin named '%s' is configured", 404)
+	MsgNamespaceStandby                        = ffe("FF10525", "Namespace '%s' is in standby on this instance, which only serves read requests. Send requests that modify state to the leader", 503)
+	MsgOperationOutcomeUnknown                 = ffe("FF10526", "The operation was submitted before the node stopped, and its outcome could not be confirmed with the connector. Retry it if required")
+	MsgUnknownSequencer                        = ffe("FF10527", "Unknown sequencer type '%s'")
+	MsgInvalidConfirmTimeout                   = ffe("FF10528", "Invalid confirmation timeout '%s'", 400)
+	MsgOperationOverflowNotFound               = ffe("FF10529", "The input of operation '%s' was stored as data '%s', which could not be found")
+	MsgPinOnlyBlobNotSupported                 = ffe("FF10530", "Data '%s' has a blob attached. Pin-only broadcasts cannot include blobs, as the payload is not uploaded to shared storage", 400)
+	MsgBatchPayloadIDMismatch                  = ffe("FF10531", "The ID of the batch payload '%s' does not match the batch '%s'", 400)
+	MsgBatchPayloadNotPinned                   = ffe("FF10532", "No pins have been received for batch '%s'", 404)
+	MsgBatchPayloadHashMismatch                = ffe("FF10533", "The hash of the batch payload '%s' does not match the pinned hash '%s'", 400)
+	MsgBatchPayloadInvalid                     = ffe("FF10534", "The payload of batch '%s' is invalid, and was not stored", 400)
+	MsgInvalidMessageCallback                  = ffe("FF10535", "Invalid message callback '%s' - must be an absolute http or https URL", 400)
+	MsgCallbackFailed                          = ffe("FF10536", "Callback to '%s' for event '%s' failed with status %d")
+	MsgMessageDataInvalid                      = ffe("FF10537", "Message data failed validation: %s", 400)
+	MsgMessageNotQuarantined                   = ffe("FF10538", "Message '%s' is not quarantined", 409)
+	MsgDataValueNotBinary                      = ffe("FF10539", "Data value must be a base64 encoded string: %s", 400)
+	MsgProtobufMessageNotFound                 = ffe("FF10540", "Message type '%s' not found in the protobuf descriptors of datatype '%s'", 400)
+	MsgProtobufDataInvalid                     = ffe("FF10541", "Data does not conform to protobuf message type '%s' of datatype '%s': %s", 400)
+	MsgProtobufDataUnknownFields               = ffe("FF10542", "Data contains fields that are not defined in protobuf message type '%s' of datatype '%s'", 400)
+	MsgDatatypeValidatorMismatch               = ffe("FF10543", "Validator '%s' does not match validator '%s' of datatype '%s'", 400)
+	MsgProtobufUploadTooLarge                  = ffe("FF10544", "Protobuf payload exceeds the maximum upload size of %d bytes", 413)
+	MsgDataValueNotProtobuf                    = ffe("FF10545", "Data '%s' does not have a protobuf value", 406)
+	MsgVerifyInputInvalid                      = ffe("FF10546", "Exactly one of 'message' or 'batch' must be supplied for verification", 400)
+	MsgVerifyMessageNotBatched                 = ffe("FF10547", "Message '%s' has not been sent in a batch, so cannot be verified", 409)
+	MsgVerifyKeyNotRegistered                  = ffe("FF10548", "Key '%s' is not registered as a verifier of an org or custom identity")
+	MsgUnsupportedExportFormat                 = ffe("FF10549", "Unsupported export format '%s' - must be one of: %s", 400)
+	MsgTokenPoolTemplateNotFound               = ffe("FF10550", "Token pool template '%s' is not configured in this namespace", 400)
+	MsgDuplicateTokenPoolTemplate              = ffe("FF10551", "Duplicate token pool template '%s'")
+	MsgTransferDenied                          = ffe("FF10552", "Token %s denied by transfer policy: %s", 403)
+	MsgTransferPolicyRESTErr                   = ffe("FF10553", "Error from transfer policy check: %s")
+	MsgSandboxNamespaceMultiparty              = ffe("FF10554", "Namespace '%s' cannot be a sandbox as it has multi-party mode enabled")
+	MsgNotSandboxNamespace                     = ffe("FF10555", "Namespace '%s' is not configured as a sandbox", 409)
+	MsgSandboxReplaySameNamespace              = ffe("FF10556", "Cannot replay namespace '%s' into itself", 400)
+	MsgInvalidEmbedType                        = ffe("FF10557", "Invalid embed type '%s' - must be one of: data, transaction, events", 400)
+	MsgNoFireflySubscription                   = ffe("FF10558", "No subscription to the FireFly contract is active for namespace '%s'")
+	MsgContractMigrationInvalidIndex           = ffe("FF10559", "Contract migration to index %d is invalid - the next FireFly contract after the active one is at index %d", 400)
+	MsgContractMigrationPending                = ffe("FF10560", "A migration to FireFly contract index %d is already pending at block %d", 409)
+	MsgContractMigrationInvalidBlock           = ffe("FF10561", "Contract migration block number must be greater than zero", 400)
+	MsgNoAnchorChain                           = ffe("FF10562", "No anchor chain is configured for namespace '%s'")
+	MsgInvalidAnchorPlugin                     = ffe("FF10563", "Anchor plugin '%s' for namespace '%s' must be a blockchain plugin, other than the one used by the namespace")
+	MsgInvalidAnchorMode                       = ffe("FF10564", "Invalid anchor mode '%s' for namespace '%s' - must be 'batch' or 'digest'")
+	MsgAnchorDigestNotEnabled                  = ffe("FF10565", "Anchor digests are not enabled for namespace '%s'", 400)
+	MsgBatchNotAnchored                        = ffe("FF10566", "Batch '%s' has not been included in an anchor digest", 404)
+	MsgContractABIMissing                      = ffe("FF10567", "An ABI must be supplied", 400)
+	MsgContractDeployAPIMissingInterface       = ffe("FF10568", "An interface must be supplied to create an API for the deployed contract", 400)
+	MsgUnknownKeyManagerPlugin                 = ffe("FF10569", "Unknown key manager plugin '%s'")
+	MsgKeyManagerKeyNotPermitted               = ffe("FF10570", "Signing with key '%s' is not permitted by key manager '%s'", 403)
+	MsgKeyManagerRESTErr                       = ffe("FF10571", "Error from remote signer")
+	MsgKeyManagerRPCErr                        = ffe("FF10572", "Error from remote signer [%d]: %s")
+	MsgKeyManagerInvalidAlias                  = ffe("FF10573", "Key alias '%s' must map to a key string")
+	MsgAddressBookAddressMissing               = ffe("FF10574", "An address must be supplied for the address book entry", 400)
+	MsgDelegationScopeMissing                  = ffe("FF10575", "A delegation must include at least one scope", 400)
+	MsgDelegationToSelf                        = ffe("FF10576", "Identity '%s' cannot delegate to itself", 400)
+	MsgNotDelegated                            = ffe("FF10577", "Identity '%s' does not hold a current delegation from '%s' with scope '%s'", 403)
+	MsgMessageNotPinned                        = ffe("FF10578", "Message '%s' has not been pinned to the blockchain, so no proof is available", 409)
+	MsgProofFormatUnsupported                  = ffe("FF10579", "Unsupported message proof format '%s'", 400)
+	MsgProofBatchHashMismatch                  = ffe("FF10580", "Hash '%s' of the batch manifest does not match the batch hash '%s'", 400)
+	MsgProofMessageNotInBatch                  = ffe("FF10581", "Message '%s' with hash '%s' is not in the batch manifest", 400)
+	MsgProofPinMismatch                        = ffe("FF10582", "Pin %d records batch hash '%s' signed by '%s', which does not match the batch hash or message key", 400)
+	MsgProofAnchorMismatch                     = ffe("FF10583", "Merkle proof from batch hash '%s' results in '%s', which does not match the anchored root '%s'", 400)
+	MsgSubscriptionTestEventRequired           = ffe("FF10584", "Exactly one of 'event' or 'eventId' must be supplied to test a subscription", 400)
+	MsgInvalidAsOf                             = ffe("FF10585", "Invalid 'asof' value '%s' - must be an event sequence number, or an RFC3339 timestamp", 400)
+	MsgAsOfEventNotFound                       = ffe("FF10586", "No event found with sequence %d", 404)
+	MsgAsOfNotCombinable                       = ffe("FF10587", "The 'asof' query parameter cannot be combined with '%s'", 400)
+	MsgJSONCanonicalDuplicateKey               = ffe("FF10588", "Duplicate key '%s' in JSON object cannot be canonicalized", 400)
+	MsgJSONCanonicalNumber                     = ffe("FF10589", "JSON number '%s' cannot be canonicalized, as it is outside the range of an IEEE 754 double", 400)
+	MsgTokensFeatureNotSupported               = ffe("FF10590", "Tokens connector '%s' does not support '%s' (negotiated protocol version %d)", 400)
+	MsgInboundQueueDatabaseNotFound            = ffe("FF10591", "Database plugin '%s' for the inbound event queue not found - set 'event.inboundQueue.database' to the name of a database plugin")
+	MsgBulkDataEmpty                           = ffe("FF10592", "Bulk data upload must contain at least one data item", 400)
+	MsgBulkDataItemInvalid                     = ffe("FF10593", "Data item %d in the bulk upload is invalid", 400)
+	MsgDatatypeMigrateSameVersion              = ffe("FF10594", "A datatype cannot migrate data from its own version '%s'", 400)
+	MsgKeySignatureInvalid                     = ffe("FF10595", "Signature is not valid for signing key '%s': %s", 400)
+	MsgKeySignatureCertRequired                = ffe("FF10596", "A certificate must accompany the signature to verify it against signing key '%s'", 400)
+	MsgDefRejectedClaimUnsigned                = ffe("FF10597", "Identity claim '%s' rejected - this namespace requires identity claims to be signed by the submitting key")
+	MsgSubscriptionOwnerMismatch               = ffe("FF10598", "Subscription '%s' is owned by a different principal", 403)
+	MsgStatsNotAvailable                       = ffe("FF10599", "Statistics for namespace '%s' are not yet available", 503)
+	MsgInvalidChartInterval                    = ffe("FF10600", "Invalid chart interval '%s'", 400)
+	MsgRequestBodyTooLarge                     = ffe("FF10601", "Request body exceeds the maximum size of %d bytes", 413)
+	MsgTokensConnectorUnavailable              = ffe("FF10602", "Token connector '%s' is unavailable - requests are rejected until it recovers", 503)
+	MsgAPIKeyMissing                           = ffe("FF10603", "An API key must be supplied in the '%s' header", 401)
+	MsgAPIKeyInvalid                           = ffe("FF10604", "Invalid API key", 401)
+	MsgAPIKeyScopeDenied                       = ffe("FF10605", "API key '%s' does not grant the '%s' scope required by this route", 403)
+	MsgAPIKeyNameExists                        = ffe("FF10606", "An API key named '%s' already exists", 409)
+	MsgTransactionReverted                     = ffe("FF10607", "Transaction '%s' reverted")
+	MsgAttachTxNotSupported                    = ffe("FF10608", "A transaction cannot be attached to an operation of type '%s'", 400)
+	MsgAttachTxOpComplete                      = ffe("FF10609", "Operation '%s' has already completed with status '%s'", 409)
+	MsgAttachTxHashMissing                     = ffe("FF10610", "A transaction hash must be supplied", 400)
+	MsgInvalidProxyURL                         = ffe("FF10611", "Invalid proxy URL '%s' - the scheme must be http, https or socks5")
+	MsgProxyCustomTransport                    = ffe("FF10612", "A proxy cannot be configured for a client with a custom transport")
+	MsgMintDistributionNoRecipients            = ffe("FF10613", "At least one recipient must be supplied for a mint distribution", 400)
+	MsgMintDistributionBadRecipient            = ffe("FF10614", "Recipient %d of the mint distribution must have an account and an amount greater than zero", 400)
+	MsgQuarantineNoBatchPayload                = ffe("FF10615", "Quarantine entry '%s' does not contain a batch that can be re-validated", 400)
+	MsgTokensInvalidEvent                      = ffe("FF10616", "Invalid '%s' event from token connector '%s': %s")
+	MsgNoPinGapRequeryPosition                 = ffe("FF10617", "No blockchain event is known on context '%s' to re-query the gap in pins of author '%s' from")
+	MsgFabricMSPLoadFailed                     = ffe("FF10619", "Failed to load channel MSP certificates from '%s': %s")
+	MsgProxyWebSocketClient                    = ffe("FF10620", "A proxy cannot be configured for websocket client %T")
 )
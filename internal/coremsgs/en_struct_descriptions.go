@@ -72,6 +72,7 @@ var (
 	MessagePins           = ffm("Message.pins", "For private messages, a unique pin hash:nonce is assigned for each topic")
 	MessageTransactionID  = ffm("Message.txid", "The ID of the transaction used to order/deliver this message")
 	MessageIdempotencyKey = ffm("Message.idempotencyKey", "An optional unique identifier for a message. Cannot be duplicated within a namespace, thus allowing idempotent submission of messages to the API. Local only - not transferred when the message is sent to other members of the network")
+	MessageCallback       = ffm("Message.callback", "An optional http or https URL, to which the final state of the message is POSTed when it is confirmed or rejected. Local only - not transferred when the message is sent to other members of the network")
 
 	// MessageInOut field descriptions
 	MessageInOutData  = ffm("MessageInOut.data", "For input allows you to specify data in-line in the message, that will be turned into data attachments. For output when fetchdata is used on API calls, includes the in-line data payloads of all data attachments")
@@ -108,6 +109,24 @@ var (
 	MemberIdentity = ffm("Member.identity", "The DID of the group member")
 	MemberNode     = ffm("Member.node", "The UUID of the node that receives a copy of the off-chain message for the identity")
 
+	// NodeStatus field descriptions
+	NodeStatusID         = ffm("NodeStatus.id", "The UUID of the node identity")
+	NodeStatusName       = ffm("NodeStatus.name", "The name of the node")
+	NodeStatusLocal      = ffm("NodeStatus.local", "True if the node is the local node")
+	NodeStatusPeerID     = ffm("NodeStatus.peerId", "The data exchange peer ID of the node")
+	NodeStatusEndpoint   = ffm("NodeStatus.endpoint", "The data exchange endpoint information the node published in its profile")
+	NodeStatusPeerStatus = ffm("NodeStatus.peerStatus", "Whether the local data exchange can currently reach the node. Not set for the local node")
+
+	// ResolvedGroupMember field descriptions
+	ResolvedGroupMemberIdentity   = ffm("ResolvedGroupMember.identity", "The DID of the group member")
+	ResolvedGroupMemberOrg        = ffm("ResolvedGroupMember.org", "The identity the DID of the member resolves to")
+	ResolvedGroupMemberNode       = ffm("ResolvedGroupMember.node", "The node identity that receives a copy of the off-chain message for the member")
+	ResolvedGroupMemberLocal      = ffm("ResolvedGroupMember.local", "True if the node is the local node, in which case messages are not sent via data exchange")
+	ResolvedGroupMemberPeerID     = ffm("ResolvedGroupMember.peerId", "The data exchange peer ID of the node")
+	ResolvedGroupMemberEndpoint   = ffm("ResolvedGroupMember.endpoint", "The data exchange endpoint information the node published in its profile")
+	ResolvedGroupMemberPeerStatus = ffm("ResolvedGroupMember.peerStatus", "Whether the local data exchange can currently reach the node. Not set for the local node")
+	ResolvedGroupMemberError      = ffm("ResolvedGroupMember.error", "The reason the member could not be fully resolved, or its peer status could not be queried")
+
 	// DataRef field descriptions
 	DataRefID   = ffm("DataRef.id", "The UUID of the referenced data resource")
 	DataRefHash = ffm("DataRef.hash", "The hash of the referenced data")
@@ -120,20 +139,58 @@ var (
 	BlobRefPublic = ffm("BlobRef.public", "If the blob data has been published to shared storage, this field is the id of the data in the shared storage plugin (IPFS hash etc.)")
 
 	// Data field descriptions
-	DataID        = ffm("Data.id", "The UUID of the data resource")
-	DataValidator = ffm("Data.validator", "The data validator type")
-	DataNamespace = ffm("Data.namespace", "The namespace of the data resource")
-	DataHash      = ffm("Data.hash", "The hash of the data resource. Derived from the value and the hash of any binary blob attachment")
-	DataCreated   = ffm("Data.created", "The creation time of the data resource")
-	DataDatatype  = ffm("Data.datatype", "The optional datatype to use of validation of this data")
-	DataValue     = ffm("Data.value", "The value for the data, stored in the FireFly core database. Can be any JSON type - object, array, string, number or boolean. Can be combined with a binary blob attachment")
-	DataBlob      = ffm("Data.blob", "An optional hash reference to a binary blob attachment")
-	DataPublic    = ffm("Data.public", "If the JSON value has been published to shared storage, this field is the id of the data in the shared storage plugin (IPFS hash etc.)")
+	DataID               = ffm("Data.id", "The UUID of the data resource")
+	DataValidator        = ffm("Data.validator", "The data validator type")
+	DataNamespace        = ffm("Data.namespace", "The namespace of the data resource")
+	DataHash             = ffm("Data.hash", "The hash of the data resource. Derived from the value and the hash of any binary blob attachment")
+	DataHashAlgorithm    = ffm("Data.hashAlgorithm", "The algorithm used to calculate the hash of the data resource. Empty for the default of sha256")
+	DataCanonicalization = ffm("Data.canonicalization", "How the JSON value was serialized before hashing. Empty if the value was hashed exactly as stored, or 'jcs' for the JSON Canonicalization Scheme (RFC 8785)")
+	DataCreated          = ffm("Data.created", "The creation time of the data resource")
+	DataDatatype         = ffm("Data.datatype", "The optional datatype to use of validation of this data")
+	DataValue            = ffm("Data.value", "The value for the data, stored in the FireFly core database. Can be any JSON type - object, array, string, number or boolean. Can be combined with a binary blob attachment. For the protobuf validator, this is the encoded message as a base64 string")
+	DataBlob             = ffm("Data.blob", "An optional hash reference to a binary blob attachment")
+	DataPublic           = ffm("Data.public", "If the JSON value has been published to shared storage, this field is the id of the data in the shared storage plugin (IPFS hash etc.)")
+	DataPin              = ffm("Data.pin", "If the shared storage plugin is configured with a remote pinning service, the status of the pin of the published copy of this data")
+
+	DataMigration = ffm("Data.migration", "If this data was moved to a new version of its datatype by a datatype migration, the version it was moved from and when")
+
+	// DataMigration field descriptions
+	DataMigrationFromVersion = ffm("DataMigration.fromVersion", "The version of the datatype this data referenced before it was migrated")
+	DataMigrationDatatype    = ffm("DataMigration.datatype", "The UUID of the datatype version that migrated this data")
+	DataMigrationMigrated    = ffm("DataMigration.migrated", "The time this data was migrated")
+
+	// DataPin field descriptions
+	DataPinStatus  = ffm("DataPin.status", "The status of the pin, as last reported by the pinning service")
+	DataPinRequest = ffm("DataPin.request", "The id of the pin request on the pinning service, used to remove the pin when the data is deleted")
 
 	// DatatypeRef field descriptions
 	DatatypeRefName    = ffm("DatatypeRef.name", "The name of the datatype")
 	DatatypeRefVersion = ffm("DatatypeRef.version", "The version of the datatype. Semantic versioning is encouraged, such as v1.0.1")
 
+	// DatatypeInferInput field descriptions
+	DatatypeInferInputName    = ffm("DatatypeInferInput.name", "The name of the generated datatype. Required to publish the datatype")
+	DatatypeInferInputVersion = ffm("DatatypeInferInput.version", "The version of the generated datatype. Required to publish the datatype")
+	DatatypeInferInputSample  = ffm("DatatypeInferInput.sample", "A sample JSON payload. Every property in the sample is required by the generated JSON Schema")
+
+	// QuarantineReview field descriptions
+	QuarantineReviewAction = ffm("QuarantineReview.action", "Whether to accept the message, confirming it despite its invalid data, or to reject it")
+
+	// QuarantineEntry field descriptions
+	QuarantineEntryID        = ffm("QuarantineEntry.id", "The UUID of the quarantine entry")
+	QuarantineEntryNamespace = ffm("QuarantineEntry.namespace", "The namespace of the quarantine entry")
+	QuarantineEntryReason    = ffm("QuarantineEntry.reason", "The reason code for the item being quarantined")
+	QuarantineEntryDetail    = ffm("QuarantineEntry.detail", "A description of the validation failure")
+	QuarantineEntryBatch     = ffm("QuarantineEntry.batch", "The UUID of the batch the item was received in")
+	QuarantineEntryMessage   = ffm("QuarantineEntry.message", "The UUID of the message that failed validation, if the failure relates to a single message")
+	QuarantineEntryData      = ffm("QuarantineEntry.data", "The UUID of the data that failed validation, if the failure relates to a single data item")
+	QuarantineEntryPayload   = ffm("QuarantineEntry.payload", "The batch as it was received, for entries where the batch could not be stored")
+	QuarantineEntryCreated   = ffm("QuarantineEntry.created", "The time the item was quarantined")
+
+	// QuarantineRevalidation field descriptions
+	QuarantineRevalidationEntry  = ffm("QuarantineRevalidation.entry", "The quarantine entry that was re-validated")
+	QuarantineRevalidationValid  = ffm("QuarantineRevalidation.valid", "True if the item is now valid, and has been processed and removed from the quarantine")
+	QuarantineRevalidationDetail = ffm("QuarantineRevalidation.detail", "A description of why the item is still invalid")
+
 	// Datatype field descriptions
 	DatatypeID        = ffm("Datatype.id", "The UUID of the datatype")
 	DatatypeMessage   = ffm("Datatype.message", "The UUID of the broadcast message that was used to publish this datatype to the network")
@@ -143,7 +200,11 @@ var (
 	DatatypeVersion   = ffm("Datatype.version", "The version of the datatype. Multiple versions can exist with the same name. Use of semantic versioning is encourages, such as v1.0.1")
 	DatatypeHash      = ffm("Datatype.hash", "The hash of the value, such as the JSON schema. Allows all parties to be confident they have the exact same rules for verifying data created against a datatype")
 	DatatypeCreated   = ffm("Datatype.created", "The time the datatype was created")
-	DatatypeValue     = ffm("Datatype.value", "The definition of the datatype, in the syntax supported by the validator (such as a JSON Schema definition)")
+	DatatypeValue     = ffm("Datatype.value", "The definition of the datatype, in the syntax supported by the validator (such as a JSON Schema definition). For the protobuf validator, an object with a base64 encoded 'descriptorSet' and the full name of the 'message' type")
+	DatatypeMigration = ffm("Datatype.migration", "Optionally registers a migration of existing data from an earlier version of this datatype. Once this version is confirmed, each node moves its stored data that is valid against this version to reference it")
+
+	// DatatypeMigration field descriptions
+	DatatypeMigrationFromVersion = ffm("DatatypeMigration.fromVersion", "The earlier version of the datatype, with the same name, that existing data is migrated from")
 
 	// SignerRef field descriptions
 	SignerRefAuthor = ffm("SignerRef.author", "The DID of identity of the submitter")
@@ -161,18 +222,133 @@ var (
 	BatchHeaderCreated   = ffm("BatchHeader.created", "The time the batch was sealed")
 
 	// BatchManifest field descriptions
-	BatchManifestVersion  = ffm("BatchManifest.version", "The version of the manifest generated")
-	BatchManifestID       = ffm("BatchManifest.id", "The UUID of the batch")
-	BatchManifestTX       = ffm("BatchManifest.tx", "The FireFly transaction associated with this batch")
-	BatchManifestMessages = ffm("BatchManifest.messages", "Array of manifest entries, succinctly summarizing the messages in the batch")
-	BatchManifestData     = ffm("BatchManifest.data", "Array of manifest entries, succinctly summarizing the data in the batch")
+	BatchManifestVersion     = ffm("BatchManifest.version", "The version of the manifest generated")
+	BatchManifestID          = ffm("BatchManifest.id", "The UUID of the batch")
+	BatchManifestTX          = ffm("BatchManifest.tx", "The FireFly transaction associated with this batch")
+	BatchManifestMessages    = ffm("BatchManifest.messages", "Array of manifest entries, succinctly summarizing the messages in the batch")
+	BatchManifestData        = ffm("BatchManifest.data", "Array of manifest entries, succinctly summarizing the data in the batch")
+	BatchManifestCompression = ffm("BatchManifest.compression", "The compression applied to the payload of the batch when it was sent")
 
 	// BatchPersisted field descriptions
-	BatchPersistedHash       = ffm("Batch.hash", "The hash of the manifest of the batch")
-	BatchPersistedManifest   = ffm("Batch.manifest", "The manifest of the batch")
-	BatchPersistedTX         = ffm("Batch.tx", "The FireFly transaction associated with this batch")
-	BatchPersistedPayloadRef = ffm("Batch.payloadRef", "For broadcast batches, this is the reference to the binary batch in shared storage")
-	BatchPersistedConfirmed  = ffm("Batch.confirmed", "The time when the batch was confirmed")
+	BatchPersistedHash           = ffm("Batch.hash", "The hash of the manifest of the batch")
+	BatchPersistedManifest       = ffm("Batch.manifest", "The manifest of the batch")
+	BatchPersistedTX             = ffm("Batch.tx", "The FireFly transaction associated with this batch")
+	BatchPersistedPayloadRef     = ffm("Batch.payloadRef", "For broadcast batches, this is the reference to the binary batch in shared storage")
+	BatchCompression             = ffm("Batch.compression", "The compression applied to the payload of the batch when it was sent")
+	BatchManifestVersionInflight = ffm("Batch.manifestVersion", "The version of the manifest sealed into the batch, when it records the details of each entry. Receivers regenerate the manifest at this version to verify the batch hash")
+	BatchHashAlgorithm           = ffm("Batch.hashAlgorithm", "The algorithm used to calculate the hash of the batch manifest. Empty for the default of sha256")
+	BatchPersistedConfirmed      = ffm("Batch.confirmed", "The time when the batch was confirmed")
+	BatchPayload                 = ffm("Batch.payload", "The full payload of the batch, containing the messages and data")
+	BatchAnchor                  = ffm("Batch.anchor", "For namespaces with an anchor chain, the record of the second pin of this batch. Only set on the node that submitted the batch")
+
+	// BatchAnchor field descriptions
+	BatchAnchorPlugin       = ffm("BatchAnchor.plugin", "The name of the blockchain plugin of the anchor chain")
+	BatchAnchorBlockchainID = ffm("BatchAnchor.blockchainId", "The blockchain transaction ID of the anchor pin on the anchor chain")
+	BatchAnchorOperation    = ffm("BatchAnchor.operation", "The UUID of the operation that submitted the anchor pin")
+
+	// AnchorDigest field descriptions
+	AnchorDigestID           = ffm("AnchorDigest.id", "The UUID of the anchor digest")
+	AnchorDigestNamespace    = ffm("AnchorDigest.namespace", "The namespace of the anchor digest")
+	AnchorDigestRoot         = ffm("AnchorDigest.root", "The Merkle root of the hashes of the batches in the digest, which is submitted to the anchor chain")
+	AnchorDigestPlugin       = ffm("AnchorDigest.plugin", "The name of the blockchain plugin of the anchor chain")
+	AnchorDigestFirstPin     = ffm("AnchorDigest.firstPin", "The local sequence of the first pin in the window of the digest")
+	AnchorDigestLastPin      = ffm("AnchorDigest.lastPin", "The local sequence of the last pin in the window of the digest")
+	AnchorDigestBatches      = ffm("AnchorDigest.batches", "The batches in the digest, in the order of the leaves of the Merkle tree")
+	AnchorDigestTransaction  = ffm("AnchorDigest.tx", "The UUID of the FireFly transaction that submitted the digest")
+	AnchorDigestBlockchainID = ffm("AnchorDigest.blockchainId", "The blockchain transaction ID of the digest on the anchor chain, once it has been accepted")
+	AnchorDigestCreated      = ffm("AnchorDigest.created", "The time the digest was created")
+
+	// AnchorDigestBatch field descriptions
+	AnchorDigestBatchID   = ffm("AnchorDigestBatch.id", "The UUID of the batch")
+	AnchorDigestBatchHash = ffm("AnchorDigestBatch.hash", "The hash of the batch, which is the leaf of the Merkle tree")
+
+	// MerkleProofStep field descriptions
+	MerkleProofStepHash     = ffm("MerkleProofStep.hash", "The hash of the sibling node at this level of the Merkle tree")
+	MerkleProofStepPosition = ffm("MerkleProofStep.position", "Whether the sibling is to the left or the right of the hash being proved")
+
+	// BatchAnchorProof field descriptions
+	BatchAnchorProofBatch        = ffm("BatchAnchorProof.batch", "The UUID of the batch")
+	BatchAnchorProofBatchHash    = ffm("BatchAnchorProof.batchHash", "The hash of the batch, which is the leaf the proof starts from")
+	BatchAnchorProofDigest       = ffm("BatchAnchorProof.digest", "The UUID of the anchor digest that includes the batch")
+	BatchAnchorProofRoot         = ffm("BatchAnchorProof.root", "The Merkle root submitted to the anchor chain")
+	BatchAnchorProofPlugin       = ffm("BatchAnchorProof.plugin", "The name of the blockchain plugin of the anchor chain")
+	BatchAnchorProofBlockchainID = ffm("BatchAnchorProof.blockchainId", "The blockchain transaction ID of the digest on the anchor chain, once it has been accepted")
+	BatchAnchorProofProof        = ffm("BatchAnchorProof.proof", "The sibling hashes from the leaf to the root. An empty proof means the batch hash is the root")
+
+	// MessageProof field descriptions
+	MessageProofFormat      = ffm("MessageProof.format", "The format of the proof bundle, which verifiers check before interpreting the rest of the bundle")
+	MessageProofNamespace   = ffm("MessageProof.namespace", "The namespace of the message")
+	MessageProofMessage     = ffm("MessageProof.message", "The message, including the data hashes that its hash covers")
+	MessageProofBatch       = ffm("MessageProof.batch", "The batch that contained the message")
+	MessageProofPins        = ffm("MessageProof.pins", "The pins of the message in the batch, one per topic, which record the batch hash written to the blockchain")
+	MessageProofTransaction = ffm("MessageProof.tx", "The FireFly transaction that pinned the batch, with the blockchain transaction IDs")
+	MessageProofAnchor      = ffm("MessageProof.anchor", "The Merkle path from the batch hash to the root of an anchor digest, if the batch was also anchored")
+
+	// MessageProofBatch field descriptions
+	MessageProofBatchID       = ffm("MessageProofBatch.id", "The UUID of the batch")
+	MessageProofBatchHash     = ffm("MessageProofBatch.hash", "The hash of the batch manifest, which is written to the blockchain")
+	MessageProofBatchManifest = ffm("MessageProofBatch.manifest", "The exact JSON string of the batch manifest that was hashed to produce the batch hash")
+
+	// BatchPayload field descriptions
+	BatchPayloadTX       = ffm("BatchPayload.tx", "The FireFly transaction associated with this batch")
+	BatchPayloadMessages = ffm("BatchPayload.messages", "The messages in the batch")
+	BatchPayloadData     = ffm("BatchPayload.data", "The data in the batch")
+
+	// NodeReadiness field descriptions
+	NodeReadinessReady      = ffm("NodeReadiness.ready", "True if every plugin the node depends on is currently reachable")
+	NodeReadinessComponents = ffm("NodeReadiness.components", "The readiness of each plugin the node depends on")
+
+	// ComponentReadiness field descriptions
+	ComponentReadinessName     = ffm("ComponentReadiness.name", "The configured name of the plugin")
+	ComponentReadinessCategory = ffm("ComponentReadiness.category", "The category of the plugin, such as database or blockchain")
+	ComponentReadinessType     = ffm("ComponentReadiness.type", "The type of the plugin, such as postgres or ethereum")
+	ComponentReadinessReady    = ffm("ComponentReadiness.ready", "True if the plugin is currently reachable")
+	ComponentReadinessError    = ffm("ComponentReadiness.error", "The error encountered checking the plugin, if it is not ready")
+
+	// BatchVerification field descriptions
+	BatchVerificationBatch          = ffm("BatchVerification.batch", "The UUID of the verified batch")
+	BatchVerificationValid          = ffm("BatchVerification.valid", "True if the hash replayed from the stored messages and data matches the hash of the batch, and the hash pinned on-chain")
+	BatchVerificationHashAlgorithm  = ffm("BatchVerification.hashAlgorithm", "The algorithm recorded in the manifest, used to replay the hash of the batch. Empty for the default of sha256")
+	BatchVerificationHash           = ffm("BatchVerification.hash", "The hash of the batch, as stored locally")
+	BatchVerificationCalculatedHash = ffm("BatchVerification.calculatedHash", "The hash of the batch, re-computed from the stored messages and data")
+	BatchVerificationPinnedHash     = ffm("BatchVerification.pinnedHash", "The hash of the batch recorded by the on-chain pins of the batch")
+	BatchVerificationPins           = ffm("BatchVerification.pins", "The number of on-chain pins found for the batch")
+	BatchVerificationMismatches     = ffm("BatchVerification.mismatches", "The list of discrepancies found while verifying the batch")
+
+	// BatchMismatch field descriptions
+	BatchMismatchType     = ffm("BatchMismatch.type", "The part of the batch that failed verification")
+	BatchMismatchID       = ffm("BatchMismatch.id", "The UUID of the message or data that failed verification")
+	BatchMismatchExpected = ffm("BatchMismatch.expected", "The value recorded in the batch manifest, or on-chain pin")
+	BatchMismatchFound    = ffm("BatchMismatch.found", "The value re-computed from the stored records")
+	BatchMismatchError    = ffm("BatchMismatch.error", "Details of the discrepancy, when the value could not be re-computed or the record was not found")
+
+	// VerifyInput field descriptions
+	VerifyInputMessage = ffm("VerifyInput.message", "The UUID of a message stored on this node to verify, along with the batch it was sent in")
+	VerifyInputBatch   = ffm("VerifyInput.batch", "A raw batch to verify, including the full payload of messages and data, such as one obtained from shared storage or another node")
+
+	// SignatureVerification field descriptions
+	SignatureVerificationMessage   = ffm("SignatureVerification.message", "The UUID of the message")
+	SignatureVerificationAuthor    = ffm("SignatureVerification.author", "The DID of the identity that the message claims as its author")
+	SignatureVerificationKey       = ffm("SignatureVerification.key", "The signing key of the message")
+	SignatureVerificationSigner    = ffm("SignatureVerification.signer", "The key that signed the blockchain transaction that pinned the batch, if an on-chain pin was found")
+	SignatureVerificationIdentity  = ffm("SignatureVerification.identity", "The UUID of the identity the signing key is registered to as a verifier")
+	SignatureVerificationDelegated = ffm("SignatureVerification.delegated", "True if the signing identity is not the author, but held a delegation from the author to send messages when the message was created")
+	SignatureVerificationValid     = ffm("SignatureVerification.valid", "True if the key signed the on-chain pin, and is a current verifier of the author of the message or of an identity the author delegated to")
+	SignatureVerificationError     = ffm("SignatureVerification.error", "Details of why the signature is not valid")
+
+	// PinTransaction field descriptions
+	PinTransactionID            = ffm("PinTransaction.id", "The UUID of the FireFly transaction that pinned the batch")
+	PinTransactionType          = ffm("PinTransaction.type", "The type of the FireFly transaction that pinned the batch")
+	PinTransactionBlockchainIDs = ffm("PinTransaction.blockchainIds", "The blockchain transaction IDs of the pin, as recorded by this node")
+	PinTransactionSigner        = ffm("PinTransaction.signer", "The key that signed the blockchain transaction")
+
+	// VerificationReport field descriptions
+	VerificationReportValid      = ffm("VerificationReport.valid", "True if all signatures are valid, and the chain of hashes from the data to the on-chain pin is intact")
+	VerificationReportMessage    = ffm("VerificationReport.message", "The UUID of the verified message, when a message was requested")
+	VerificationReportBatch      = ffm("VerificationReport.batch", "The UUID of the verified batch")
+	VerificationReportSignatures = ffm("VerificationReport.signatures", "The verification of the signing key of each verified message")
+	VerificationReportHashChain  = ffm("VerificationReport.hashChain", "The verification of the hashes of the messages and data, the batch, and the on-chain pin")
+	VerificationReportPin        = ffm("VerificationReport.pin", "The transaction that pinned the batch on-chain")
 
 	// Transaction field descriptions
 	TransactionID             = ffm("Transaction.id", "The UUID of the FireFly transaction")
@@ -196,6 +372,117 @@ var (
 	OperationCreated     = ffm("Operation.created", "The time the operation was created")
 	OperationUpdated     = ffm("Operation.updated", "The last update time of the operation")
 	OperationRetry       = ffm("Operation.retry", "If this operation was initiated as a retry to a previous operation, this field points to the UUID of the operation being retried")
+	OperationTags        = ffm("Operation.tags", "Application-defined tags propagated from the API call that created the operation, which can be used to query for all operations related to a business object")
+
+	// OperationAttachTX field descriptions
+	OperationAttachTXTransactionHash = ffm("OperationAttachTX.transactionHash", "The hash of a transaction that was submitted to the blockchain outside of FireFly. The operation completes when the receipt of the transaction is available")
+
+	// AddressBookEntry field descriptions
+	AddressBookEntryID        = ffm("AddressBookEntry.id", "The UUID of the address book entry")
+	AddressBookEntryNamespace = ffm("AddressBookEntry.namespace", "The namespace of the address book entry")
+	AddressBookEntryName      = ffm("AddressBookEntry.name", "The friendly name that can be used in place of the address in any key, to or from field of an API input")
+	AddressBookEntryAddress   = ffm("AddressBookEntry.address", "The signing key or token account address the name refers to")
+	AddressBookEntryCreated   = ffm("AddressBookEntry.created", "The time the address book entry was created")
+	AddressBookEntryUpdated   = ffm("AddressBookEntry.updated", "The time the address of the entry was last updated")
+
+	// APIKey field descriptions
+	APIKeyID        = ffm("APIKey.id", "The UUID of the API key")
+	APIKeyNamespace = ffm("APIKey.namespace", "The namespace the API key grants access to")
+	APIKeyName      = ffm("APIKey.name", "A unique name for the API key, such as the name of the application it is issued to")
+	APIKeyScope     = ffm("APIKey.scope", "The routes the API key grants access to - 'readonly', 'messaging', 'tokens' or 'admin'")
+	APIKeyKey       = ffm("APIKey.key", "The API key, to be supplied in the configured header of each request. Only returned when the API key is created")
+	APIKeyCreated   = ffm("APIKey.created", "The time the API key was created")
+
+	// Compensation field descriptions
+	CompensationID          = ffm("Compensation.id", "The UUID of the compensation record")
+	CompensationNamespace   = ffm("Compensation.namespace", "The namespace of the compensation record")
+	CompensationTransaction = ffm("Compensation.tx", "The UUID of the FireFly transaction that was compensated")
+	CompensationOperation   = ffm("Compensation.operation", "The UUID of the operation whose effect the compensating action reverses")
+	CompensationType        = ffm("Compensation.type", "The type of the compensated operation")
+	CompensationStatus      = ffm("Compensation.status", "The outcome of the attempt to compensate the operation")
+	CompensationOutput      = ffm("Compensation.output", "Details of the compensating action that was submitted, such as the UUID of the new transaction")
+	CompensationError       = ffm("Compensation.error", "The reason the operation was skipped, or the error that caused the compensating action to fail")
+	CompensationCreated     = ffm("Compensation.created", "The time the operation was compensated")
+
+	// RecoveryReport field descriptions
+	RecoveryReportNamespace  = ffm("RecoveryReport.namespace", "The namespace the recovery report is for")
+	RecoveryReportStarted    = ffm("RecoveryReport.started", "The time startup recovery began scanning for in-flight work")
+	RecoveryReportCompleted  = ffm("RecoveryReport.completed", "The time startup recovery finished. Not set while recovery is still in progress")
+	RecoveryReportError      = ffm("RecoveryReport.error", "The error that prevented startup recovery from completing the scan, if any")
+	RecoveryReportOperations = ffm("RecoveryReport.operations", "The operations that were in-flight when the node stopped, and the action taken for each")
+	RecoveryReportBatches    = ffm("RecoveryReport.batches", "The batches that had not been confirmed when the node stopped")
+
+	// RecoveredOperation field descriptions
+	RecoveredOperationID          = ffm("RecoveredOperation.id", "The UUID of the operation")
+	RecoveredOperationType        = ffm("RecoveredOperation.type", "The type of the operation")
+	RecoveredOperationTransaction = ffm("RecoveredOperation.tx", "The UUID of the FireFly transaction the operation is part of")
+	RecoveredOperationStatus      = ffm("RecoveredOperation.status", "The status of the operation when the node stopped")
+	RecoveredOperationAction      = ffm("RecoveredOperation.action", "The action taken on startup - reconciled with the connector, resumed by submitting it again, or unresolved")
+	RecoveredOperationError       = ffm("RecoveredOperation.error", "The reason the operation could not be recovered automatically")
+
+	// RecoveredBatch field descriptions
+	RecoveredBatchID          = ffm("RecoveredBatch.id", "The UUID of the batch")
+	RecoveredBatchType        = ffm("RecoveredBatch.type", "The type of the batch")
+	RecoveredBatchTransaction = ffm("RecoveredBatch.tx", "The UUID of the FireFly transaction that pins the batch")
+	RecoveredBatchCreated     = ffm("RecoveredBatch.created", "The time the batch was sealed")
+
+	// BlobCollection field descriptions
+	BlobCollectionNamespace = ffm("BlobCollection.namespace", "The namespace the blobs were collected from")
+	BlobCollectionDryRun    = ffm("BlobCollection.dryRun", "When true, the eligible blobs were reported but not deleted")
+	BlobCollectionCutoff    = ffm("BlobCollection.cutoff", "Messages confirmed before this time are past retention, and no longer hold a reference to their blobs")
+	BlobCollectionScanned   = ffm("BlobCollection.scanned", "The number of blobs that were checked for references")
+	BlobCollectionBlobs     = ffm("BlobCollection.blobs", "The blobs that were deleted, or would be deleted on a dry run")
+
+	// CollectedBlob field descriptions
+	CollectedBlobHash       = ffm("CollectedBlob.hash", "The hash of the binary blob data")
+	CollectedBlobDataID     = ffm("CollectedBlob.dataId", "The ID of the data item the blob is attached to")
+	CollectedBlobPayloadRef = ffm("CollectedBlob.payloadRef", "The reference to the blob in the data exchange plugin")
+	CollectedBlobSize       = ffm("CollectedBlob.size", "The size of the binary data")
+	CollectedBlobReason     = ffm("CollectedBlob.reason", "Why the blob is no longer referenced by any message")
+
+	// DefinitionBundle field descriptions
+	DefinitionBundleNamespace    = ffm("DefinitionBundle.namespace", "The namespace the definitions were exported from")
+	DefinitionBundleExported     = ffm("DefinitionBundle.exported", "The time the definitions were exported")
+	DefinitionBundleDatatypes    = ffm("DefinitionBundle.datatypes", "The datatypes defined in the namespace")
+	DefinitionBundleFFIs         = ffm("DefinitionBundle.interfaces", "The contract interfaces defined in the namespace, including their methods, events and errors")
+	DefinitionBundleContractAPIs = ffm("DefinitionBundle.apis", "The contract APIs defined in the namespace")
+	DefinitionBundleTokenPools   = ffm("DefinitionBundle.tokenPools", "The token pools defined in the namespace")
+	DefinitionBundleGroups       = ffm("DefinitionBundle.groups", "The privacy groups known to the namespace")
+
+	// DefinitionImportResult field descriptions
+	DefinitionImportResultNamespace   = ffm("DefinitionImportResult.namespace", "The namespace the definitions were imported into")
+	DefinitionImportResultDefinitions = ffm("DefinitionImportResult.definitions", "The outcome of importing each definition in the bundle")
+
+	// TransferLimits field descriptions
+	TransferLimitsMaxParallel           = ffm("TransferLimits.maxParallel", "The maximum number of blob transfers in flight to all peers at any one time")
+	TransferLimitsMaxParallelPerPeer    = ffm("TransferLimits.maxParallelPerPeer", "The maximum number of blob transfers in flight to a single peer at any one time")
+	TransferLimitsBytesPerSecond        = ffm("TransferLimits.bytesPerSecond", "The maximum rate, in bytes per second, at which blob transfers to all peers are started")
+	TransferLimitsBytesPerSecondPerPeer = ffm("TransferLimits.bytesPerSecondPerPeer", "The maximum rate, in bytes per second, at which blob transfers to a single peer are started")
+
+	// DefinitionImportItem field descriptions
+	DefinitionImportItemType    = ffm("DefinitionImportItem.type", "The type of the definition")
+	DefinitionImportItemName    = ffm("DefinitionImportItem.name", "The name of the definition")
+	DefinitionImportItemVersion = ffm("DefinitionImportItem.version", "The version of the definition, if it is versioned")
+	DefinitionImportItemHash    = ffm("DefinitionImportItem.hash", "The hash of the definition, for privacy groups")
+	DefinitionImportItemID      = ffm("DefinitionImportItem.id", "The UUID of the definition in this namespace, once imported")
+	DefinitionImportItemStatus  = ffm("DefinitionImportItem.status", "Whether the definition was imported, skipped because it already exists, or failed to import")
+	DefinitionImportItemError   = ffm("DefinitionImportItem.error", "The error that caused the definition to fail to import")
+
+	// SandboxReplayInput field descriptions
+	SandboxReplayInputSource       = ffm("SandboxReplayInput.source", "The namespace to replay into the sandbox")
+	SandboxReplayInputDefinitions  = ffm("SandboxReplayInput.definitions", "Clone the definitions of the source namespace into the sandbox before replaying its events")
+	SandboxReplayInputFromSequence = ffm("SandboxReplayInput.fromSequence", "The sequence of the first event of the source namespace to replay")
+	SandboxReplayInputToSequence   = ffm("SandboxReplayInput.toSequence", "The sequence of the last event of the source namespace to replay. Replays to the latest event if not set")
+	SandboxReplayInputTypes        = ffm("SandboxReplayInput.types", "The types of event to replay. All types are replayed if not set")
+
+	// SandboxReplayResult field descriptions
+	SandboxReplayResultSource       = ffm("SandboxReplayResult.source", "The namespace that was replayed")
+	SandboxReplayResultSandbox      = ffm("SandboxReplayResult.sandbox", "The sandbox namespace the source was replayed into")
+	SandboxReplayResultDefinitions  = ffm("SandboxReplayResult.definitions", "The outcome of cloning each definition of the source namespace, if requested")
+	SandboxReplayResultEvents       = ffm("SandboxReplayResult.events", "The number of events replayed into the sandbox")
+	SandboxReplayResultMessages     = ffm("SandboxReplayResult.messages", "The number of messages, with their data, copied into the sandbox for the replayed events")
+	SandboxReplayResultTransactions = ffm("SandboxReplayResult.transactions", "The number of transactions copied into the sandbox for the replayed events")
+	SandboxReplayResultLastSequence = ffm("SandboxReplayResult.lastSequence", "The sequence of the last event of the source namespace that was replayed, from which a further replay can continue")
 
 	// OperationWithDetail field description
 	OperationWithDetail = ffm("OperationWithDetail.detail", "Additional detailed information about an operation provided by the connector")
@@ -223,15 +510,69 @@ var (
 	ChartHistogramTypeType  = ffm("ChartHistogramType.type", "Name of the type")
 
 	// ContractAPI field descriptions
-	ContractAPIID          = ffm("ContractAPI.id", "The UUID of the contract API")
-	ContractAPINamespace   = ffm("ContractAPI.namespace", "The namespace of the contract API")
-	ContractAPIInterface   = ffm("ContractAPI.interface", "Reference to the FireFly Interface definition associated with the contract API")
-	ContractAPILocation    = ffm("ContractAPI.location", "If this API is tied to an individual instance of a smart contract, this field can include a blockchain specific contract identifier. For example an Ethereum contract address, or a Fabric chaincode name and channel")
-	ContractAPIName        = ffm("ContractAPI.name", "The name that is used in the URL to access the API")
-	ContractAPINetworkName = ffm("ContractAPI.networkName", "The published name of the API within the multiparty network")
-	ContractAPIMessage     = ffm("ContractAPI.message", "The UUID of the broadcast message that was used to publish this API to the network")
-	ContractAPIURLs        = ffm("ContractAPI.urls", "The URLs to use to access the API")
-	ContractAPIPublished   = ffm("ContractAPI.published", "Indicates if the API is published to other members of the multiparty network")
+	ContractAPIID            = ffm("ContractAPI.id", "The UUID of the contract API")
+	ContractAPINamespace     = ffm("ContractAPI.namespace", "The namespace of the contract API")
+	ContractAPIInterface     = ffm("ContractAPI.interface", "Reference to the FireFly Interface definition associated with the contract API")
+	ContractAPILocation      = ffm("ContractAPI.location", "If this API is tied to an individual instance of a smart contract, this field can include a blockchain specific contract identifier. For example an Ethereum contract address, or a Fabric chaincode name and channel")
+	ContractAPIName          = ffm("ContractAPI.name", "The name that is used in the URL to access the API")
+	ContractAPINetworkName   = ffm("ContractAPI.networkName", "The published name of the API within the multiparty network")
+	ContractAPIVersion       = ffm("ContractAPI.version", "The version of the API. Multiple versions of an API can share the same name, and requests that do not specify a version are routed to the latest")
+	ContractAPIQueryCacheTTL = ffm("ContractAPI.queryCacheTTL", "If set, the results of queries to the API are cached for this duration, keyed on the method, input, key, location and options (such as the block number) of the query")
+	ContractAPIInterfaceHash = ffm("ContractAPI.interfaceHash", "The hash of the interface the API is bound to. If set when the API is defined, the interface must match this hash")
+	ContractAPIMessage       = ffm("ContractAPI.message", "The UUID of the broadcast message that was used to publish this API to the network")
+	ContractAPIURLs          = ffm("ContractAPI.urls", "The URLs to use to access the API")
+	ContractAPIPublished     = ffm("ContractAPI.published", "Indicates if the API is published to other members of the multiparty network")
+
+	// SharedFFI field descriptions
+	SharedFFIID        = ffm("SharedFFI.id", "The UUID of the shared interface registry entry")
+	SharedFFINamespace = ffm("SharedFFI.namespace", "The namespace that owns the shared interface")
+	SharedFFIInterface = ffm("SharedFFI.interface", "The UUID of the FireFly Interface (FFI) within the owning namespace")
+	SharedFFIName      = ffm("SharedFFI.name", "The name of the shared interface")
+	SharedFFIVersion   = ffm("SharedFFI.version", "The version of the shared interface")
+	SharedFFIHash      = ffm("SharedFFI.hash", "The hash of the shared interface, which contract APIs that bind to it are pinned to")
+	SharedFFICreated   = ffm("SharedFFI.created", "The time the interface was shared")
+
+	// ContractAPIDiff field descriptions
+	ContractAPIDiffFrom    = ffm("ContractAPIDiff.from", "The version of the API that is compared from, and the interface it is bound to")
+	ContractAPIDiffTo      = ffm("ContractAPIDiff.to", "The version of the API that is compared to, and the interface it is bound to")
+	ContractAPIDiffMethods = ffm("ContractAPIDiff.methods", "The methods that were added, removed or changed between the two versions, by pathname")
+	ContractAPIDiffEvents  = ffm("ContractAPIDiff.events", "The events that were added, removed or changed between the two versions, by pathname")
+	ContractAPIDiffErrors  = ffm("ContractAPIDiff.errors", "The errors that were added, removed or changed between the two versions, by pathname")
+
+	// ContractAPIVersionRef field descriptions
+	ContractAPIVersionRefVersion   = ffm("ContractAPIVersionRef.version", "The version of the API")
+	ContractAPIVersionRefLocation  = ffm("ContractAPIVersionRef.location", "The blockchain specific contract identifier the version of the API is bound to")
+	ContractAPIVersionRefInterface = ffm("ContractAPIVersionRef.interface", "Reference to the FireFly Interface definition the version of the API is bound to")
+
+	// FFIDiffEntries field descriptions
+	FFIDiffEntriesAdded   = ffm("FFIDiffEntries.added", "The pathnames of the entries that only exist in the newer interface")
+	FFIDiffEntriesRemoved = ffm("FFIDiffEntries.removed", "The pathnames of the entries that only exist in the older interface")
+	FFIDiffEntriesChanged = ffm("FFIDiffEntries.changed", "The pathnames of the entries that exist in both interfaces, with different parameters or return values")
+
+	// ContractInvokeResult field descriptions
+	ContractInvokeResultReturnValues = ffm("ContractInvokeResult.returnValues", "The return values of the method, decoded from the transaction receipt using the interface of the contract")
+	ContractInvokeResultEvents       = ffm("ContractInvokeResult.events", "The events emitted by the transaction that match the interface of the contract, decoded from the transaction receipt")
+
+	// ContractCallBatchRequest field descriptions
+	ContractCallBatchRequestItems          = ffm("ContractCallBatchRequest.items", "The invocations to submit. Each item is a contract invoke request, and pinned messages are not supported")
+	ContractCallBatchRequestKey            = ffm("ContractCallBatchRequest.key", "The blockchain signing key for the multicall transaction, and the default for items that do not specify a key. Defaults to the first signing key of the organization that operates the node")
+	ContractCallBatchRequestMulticall      = ffm("ContractCallBatchRequest.multicall", "The blockchain specific location of an on-chain multicall aggregator contract. When set, all items are submitted in a single transaction, and the contracts see the aggregator as the caller")
+	ContractCallBatchRequestAllowFailure   = ffm("ContractCallBatchRequest.allowFailure", "When submitting via a multicall aggregator, allow individual calls to fail without reverting the whole transaction")
+	ContractCallBatchRequestOptions        = ffm("ContractCallBatchRequest.options", "A map of named inputs that will be passed through to the blockchain connector for the multicall transaction")
+	ContractCallBatchRequestIdempotencyKey = ffm("ContractCallBatchRequest.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+
+	// ContractCallBatchResponse field descriptions
+	ContractCallBatchResponseTX         = ffm("ContractCallBatchResponse.tx", "The FireFly transaction containing the operations of the batch")
+	ContractCallBatchResponseOperations = ffm("ContractCallBatchResponse.operations", "The operations submitted for the batch. One per item, or a single operation when using a multicall aggregator")
+
+	// ContractCallBatchItemResult field descriptions
+	ContractCallBatchItemResultSuccess    = ffm("ContractCallBatchItemResult.success", "Whether the call succeeded within the multicall transaction")
+	ContractCallBatchItemResultReturnData = ffm("ContractCallBatchItemResult.returnData", "The raw data returned by the call")
+
+	// ContractInvokeEvent field descriptions
+	ContractInvokeEventName      = ffm("ContractInvokeEvent.name", "The name of the event")
+	ContractInvokeEventSignature = ffm("ContractInvokeEvent.signature", "The stringified signature of the event")
+	ContractInvokeEventOutput    = ffm("ContractInvokeEvent.output", "The data fields of the event, decoded using the interface of the contract")
 
 	// ContractURLs field descriptions
 	ContractURLsAPI     = ffm("ContractURLs.api", "The URL to use to invoke the API")
@@ -318,6 +659,12 @@ var (
 
 	// ContractListenerOptions field descriptions
 	ContractListenerOptionsFirstEvent = ffm("ContractListenerOptions.firstEvent", "A blockchain specific string, such as a block number, to start listening from. The special strings 'oldest' and 'newest' are supported by all blockchain connectors. Default is 'newest'")
+	ContractListenerOptionsMappings   = ffm("ContractListenerOptions.mappings", "Rules that transform the output of each matched blockchain event into a structured record. When set, the output of the blockchain event contains only the mapped fields")
+
+	// ContractListenerMapping field descriptions
+	ContractListenerMappingField = ffm("ContractListenerMapping.field", "The name of the field in the mapped record")
+	ContractListenerMappingPath  = ffm("ContractListenerMapping.path", "A dot separated path to the value in the raw event output, with numeric segments indexing into arrays. Defaults to the field name")
+	ContractListenerMappingType  = ffm("ContractListenerMapping.type", "The type to coerce the extracted value to. If omitted the value is copied unchanged")
 
 	ListenerFilterInterface = ffm("ListenerFilter.interface", "A reference to an existing FFI, containing pre-registered type information for the event")
 	ListenerFilterEvent     = ffm("ListenerFilter.event", "The definition of the event, either provided in-line when creating the listener, or extracted from the referenced FFI")
@@ -355,6 +702,7 @@ var (
 	EnrichedEventContractAPI       = ffm("EnrichedEvent.contractAPI", "A Contract API if referenced by the FireFly event")
 	EnrichedEventContractInterface = ffm("EnrichedEvent.contractInterface", "A Contract Interface (FFI) if referenced by the FireFly event")
 	EnrichedEventDatatype          = ffm("EnrichedEvent.datatype", "A Datatype if referenced by the FireFly event")
+	EnrichedEventDelegation        = ffm("EnrichedEvent.delegation", "A delegation if referenced by the FireFly event")
 	EnrichedEventIdentity          = ffm("EnrichedEvent.identity", "An Identity if referenced by the FireFly event")
 	EnrichedEventMessage           = ffm("EnrichedEvent.message", "A Message if  referenced by the FireFly event")
 	EnrichedEventNamespaceDetails  = ffm("EnrichedEvent.namespaceDetails", "Full resource detail of a Namespace if referenced by the FireFly event")
@@ -362,6 +710,7 @@ var (
 	EnrichedEventTokenPool         = ffm("EnrichedEvent.tokenPool", "A Token Pool if referenced by the FireFly event")
 	EnrichedEventTokenTransfer     = ffm("EnrichedEvent.tokenTransfer", "A Token Transfer if referenced by the FireFly event")
 	EnrichedEventTransaction       = ffm("EnrichedEvent.transaction", "A Transaction if associated with the FireFly event")
+	EnrichedEventOperation         = ffm("EnrichedEvent.operation", "An Operation if referenced by the FireFly event")
 
 	// IdentityMessages field descriptions
 	IdentityMessagesClaim        = ffm("IdentityMessages.claim", "The UUID of claim message")
@@ -369,15 +718,16 @@ var (
 	IdentityMessagesUpdate       = ffm("IdentityMessages.update", "The UUID of the most recently applied update message. Unset if no updates have been confirmed")
 
 	// Identity field descriptions
-	IdentityID        = ffm("Identity.id", "The UUID of the identity")
-	IdentityDID       = ffm("Identity.did", "The DID of the identity. Unique across namespaces within a FireFly network")
-	IdentityType      = ffm("Identity.type", "The type of the identity")
-	IdentityParent    = ffm("Identity.parent", "The UUID of the parent identity. Unset for root organization identities")
-	IdentityNamespace = ffm("Identity.namespace", "The namespace of the identity. Organization and node identities are always defined in the ff_system namespace")
-	IdentityName      = ffm("Identity.name", "The name of the identity. The name must be unique within the type and namespace")
-	IdentityMessages  = ffm("Identity.messages", "References to the broadcast messages that established this identity and proved ownership of the associated verifiers (keys)")
-	IdentityCreated   = ffm("Identity.created", "The creation time of the identity")
-	IdentityUpdated   = ffm("Identity.updated", "The last update time of the identity profile")
+	IdentityID              = ffm("Identity.id", "The UUID of the identity")
+	IdentityDID             = ffm("Identity.did", "The DID of the identity. Unique across namespaces within a FireFly network")
+	IdentityType            = ffm("Identity.type", "The type of the identity")
+	IdentityParent          = ffm("Identity.parent", "The UUID of the parent identity. Unset for root organization identities")
+	IdentityNamespace       = ffm("Identity.namespace", "The namespace of the identity. Organization and node identities are always defined in the ff_system namespace")
+	IdentityName            = ffm("Identity.name", "The name of the identity. The name must be unique within the type and namespace")
+	IdentityMessages        = ffm("Identity.messages", "References to the broadcast messages that established this identity and proved ownership of the associated verifiers (keys)")
+	IdentityVerifiedSubject = ffm("Identity.verifiedSubject", "The subject DN of the X.509 certificate presented by an organization, when verified against the trust roots configured for the namespace")
+	IdentityCreated         = ffm("Identity.created", "The creation time of the identity")
+	IdentityUpdated         = ffm("Identity.updated", "The last update time of the identity profile")
 
 	// IdentityProfile field descriptions
 	IdentityProfileProfile     = ffm("IdentityProfile.profile", "A set of metadata for the identity. Part of the updatable profile information of an identity")
@@ -387,11 +737,21 @@ var (
 	IdentityWithVerifiersVerifiers = ffm("IdentityWithVerifiers.verifiers", "The verifiers, such as blockchain signing keys, that have been bound to this identity and can be used to prove data orignates from that identity")
 
 	// IdentityCreateDTO field descriptions
-	IdentityCreateDTOParent = ffm("IdentityCreateDTO.parent", "On input the parent can be specified directly as the UUID of and existing identity, or as a DID to resolve to that identity, or an organization name. The parent must already have been registered, and its blockchain signing key must be available to the local node to sign the verification")
-	IdentityCreateDTOKey    = ffm("IdentityCreateDTO.key", "The blockchain signing key to use to make the claim to the identity. Must be available to the local node to sign the identity claim. Will become a verifier on the established identity")
+	IdentityCreateDTOParent               = ffm("IdentityCreateDTO.parent", "On input the parent can be specified directly as the UUID of and existing identity, or as a DID to resolve to that identity, or an organization name. The parent must already have been registered, and its blockchain signing key must be available to the local node to sign the verification")
+	IdentityCreateDTOKey                  = ffm("IdentityCreateDTO.key", "The blockchain signing key to use to make the claim to the identity. Must be available to the local node to sign the identity claim. Will become a verifier on the established identity")
+	IdentityCreateDTOCertificate          = ffm("IdentityCreateDTO.certificate", "For organizations, a PEM encoded X.509 certificate chain (leaf first) issued to the organization name, for verification by members that require organizations to chain to a trusted PKI")
+	IdentityCreateDTOCertificateSignature = ffm("IdentityCreateDTO.certificateSignature", "For organizations presenting a certificate, a base64 encoded signature made with the private key of the certificate over the same payload as the claim signature, using SHA-256 (ASN.1 for ECDSA, PKCS #1 v1.5 for RSA, or Ed25519)")
+	IdentityCreateDTOSignature            = ffm("IdentityCreateDTO.signature", "A signature made with the claim signing key, over the compact JSON {\"did\":\"<did>\",\"parent\":\"<uuid>\"} of the identity being claimed (parent omitted for root identities). Required if the namespace is configured to require signed identity claims")
+
+	// KeySignature field descriptions
+	KeySignatureValue       = ffm("KeySignature.value", "The signature, encoded as the blockchain plugin expects - a hex encoded personal_sign signature for Ethereum, or a base64 encoded ASN.1 ECDSA signature over the SHA-256 hash of the payload for Fabric")
+	KeySignatureCertificate = ffm("KeySignature.certificate", "For Fabric, the PEM encoded X.509 certificate of the signing identity")
 
 	// IdentityClaim field descriptions
-	IdentityClaimIdentity = ffm("IdentityClaim.identity", "The identity being claimed")
+	IdentityClaimIdentity             = ffm("IdentityClaim.identity", "The identity being claimed")
+	IdentityClaimCertificate          = ffm("IdentityClaim.certificate", "A PEM encoded X.509 certificate chain presented by an organization being claimed")
+	IdentityClaimCertificateSignature = ffm("IdentityClaim.certificateSignature", "A signature over the claimed DID and parent, made with the private key of the certificate presented by the organization")
+	IdentityClaimSignature            = ffm("IdentityClaim.signature", "A signature over the claimed DID and parent, made with the blockchain key that submitted the claim")
 
 	// IdentityVerification field descriptions
 	IdentityVerificationClaim    = ffm("IdentityVerification.claim", "The UUID of the message containing the identity claim being verified")
@@ -402,12 +762,29 @@ var (
 	IdentityUpdateProfile  = ffm("IdentityUpdate.profile", "The new profile, which is replaced in its entirety when the update is confirmed")
 
 	// Verifier field descriptions
-	VerifierHash      = ffm("Verifier.hash", "Hash used as a globally consistent identifier for this namespace + type + value combination on every node in the network")
-	VerifierIdentity  = ffm("Verifier.identity", "The UUID of the parent identity that has claimed this verifier")
-	VerifierType      = ffm("Verifier.type", "The type of the verifier")
-	VerifierValue     = ffm("Verifier.value", "The verifier string, such as an Ethereum address, or Fabric MSP identifier")
-	VerifierNamespace = ffm("Verifier.namespace", "The namespace of the verifier")
-	VerifierCreated   = ffm("Verifier.created", "The time this verifier was created on this node")
+	VerifierHash       = ffm("Verifier.hash", "Hash used as a globally consistent identifier for this namespace + type + value combination on every node in the network")
+	VerifierIdentity   = ffm("Verifier.identity", "The UUID of the parent identity that has claimed this verifier")
+	VerifierType       = ffm("Verifier.type", "The type of the verifier")
+	VerifierValue      = ffm("Verifier.value", "The verifier string, such as an Ethereum address, or Fabric MSP identifier")
+	VerifierNamespace  = ffm("Verifier.namespace", "The namespace of the verifier")
+	VerifierCreated    = ffm("Verifier.created", "The time this verifier was created on this node")
+	VerifierRevoked    = ffm("Verifier.revoked", "The time this verifier was revoked on this node. Messages pinned after the revocation, and batches received after it, that are signed by a revoked verifier are rejected")
+	VerifierRevokedPin = ffm("Verifier.revokedPin", "The local sequence of the last pin of the batch that revoked this verifier. Messages signed by the verifier are only rejected if they are pinned after this, so every node reaches the same result regardless of when it processes them")
+
+	// Delegation field descriptions
+	DelegationID        = ffm("Delegation.id", "The UUID of the delegation")
+	DelegationNamespace = ffm("Delegation.namespace", "The namespace of the delegation")
+	DelegationDelegator = ffm("Delegation.delegator", "The DID of the identity authorizing submission on its behalf. The delegation is signed by this identity")
+	DelegationDelegate  = ffm("Delegation.delegate", "The DID of the identity permitted to submit on behalf of the delegator, using its own signing key")
+	DelegationScope     = ffm("Delegation.scope", "The types of submission the delegation authorizes - 'messages' and/or 'transfers'")
+	DelegationExpires   = ffm("Delegation.expires", "The time after which the delegation no longer authorizes submission. When omitted the delegation does not expire")
+	DelegationMessage   = ffm("Delegation.message", "The UUID of the definition message that broadcast the delegation")
+	DelegationCreated   = ffm("Delegation.created", "The time the delegation was confirmed")
+
+	// VerifierRevocation field descriptions
+	VerifierRevocationIdentity = ffm("VerifierRevocation.identity", "The identity that owns the verifier(s) being revoked")
+	VerifierRevocationVerifier = ffm("VerifierRevocation.verifier", "The verifier being revoked. When omitted all verifiers of the identity are revoked")
+	VerifierRevocationReason   = ffm("VerifierRevocation.reason", "A description of the reason for the revocation")
 
 	// Namespace field descriptions
 	NamespaceName                  = ffm("Namespace.name", "The local namespace name")
@@ -415,6 +792,7 @@ var (
 	NamespaceDescription           = ffm("Namespace.description", "A description of the namespace")
 	NamespaceCreated               = ffm("Namespace.created", "The time the namespace was created")
 	MultipartyContractsActive      = ffm("MultipartyContracts.active", "The currently active FireFly smart contract")
+	MultipartyContractsPending     = ffm("MultipartyContracts.pending", "The next FireFly smart contract, when a migration to it has been scheduled")
 	MultipartyContractsTerminated  = ffm("MultipartyContracts.terminated", "Previously-terminated FireFly smart contracts")
 	MultipartyContractIndex        = ffm("MultipartyContract.index", "The index of this contract in the config file")
 	MultipartyContractVersion      = ffm("MultipartyContract.version", "The version of this multiparty contract")
@@ -424,8 +802,15 @@ var (
 	MultipartyContractSubscription = ffm("MultipartyContract.subscription", "The backend identifier of the subscription for the FireFly BatchPin contract")
 	MultipartyContractStatus       = ffm("MultipartyContract.status", "The status of the contract listener. One of 'syncing', 'synced', or 'unknown'")
 	MultipartyContractInfo         = ffm("MultipartyContract.info", "Additional info about the current status of the multi-party contract")
+	MultipartyContractActivation   = ffm("MultipartyContract.activationBlock", "For a contract switched to by a contract migration, the block number from which events switch over from the previously active contract to this one")
 	NetworkActionType              = ffm("NetworkAction.type", "The action to be performed")
 
+	// ContractMigration field descriptions
+	ContractMigrationID          = ffm("ContractMigration.id", "The UUID of the contract migration")
+	ContractMigrationIndex       = ffm("ContractMigration.index", "The index in the config file of the FireFly contract being migrated to, which must be the one after the active contract")
+	ContractMigrationBlockNumber = ffm("ContractMigration.blockNumber", "The block number at which all members of the network switch to the new contract. Must be far enough in the future for the definition to be confirmed by all members")
+	ContractMigrationMessage     = ffm("ContractMigration.message", "The UUID of the broadcast message that was used to publish this migration to the network")
+
 	// NamespaceWithInitStatus field descriptions
 	NamespaceWithInitStatusInitializing        = ffm("NamespaceWithInitStatus.initializing", "Set to true if the namespace is still initializing")
 	NamespaceWithInitStatusInitializationError = ffm("NamespaceWithInitStatus.initializationError", "Set to a non-empty string in the case that the namespace is currently failing to initialize")
@@ -453,6 +838,23 @@ var (
 	NamespaceStatusOrgID                    = ffm("NamespaceStatusOrg.id", "The UUID of the organization, if registered")
 	NamespaceStatusOrgVerifiers             = ffm("NamespaceStatusOrg.verifiers", "Array of verifiers (blockchain keys) owned by this identity")
 
+	// NamespaceStats field descriptions
+	NamespaceStatsResynced          = ffm("NamespaceStats.resynced", "The time the statistics were last resynchronized with the database. In between, they are maintained incrementally")
+	NamespaceStatsMessages          = ffm("NamespaceStats.messages", "Counts of messages by state")
+	NamespaceStatsEvents            = ffm("NamespaceStats.events", "The number of events")
+	NamespaceStatsTransfers         = ffm("NamespaceStats.transfers", "The number of confirmed token transfers")
+	NamespaceStatsSubscriptions     = ffm("NamespaceStats.subscriptions", "The number of durable subscriptions")
+	NamespaceStatsPendingOperations = ffm("NamespaceStats.pendingOperations", "The number of operations that are initialized or pending. Operations that complete are only reflected after the next resynchronization")
+
+	// MessageStats field descriptions
+	MessageStatsPending   = ffm("MessageStats.pending", "The number of messages that are not yet confirmed or rejected - in the staged, ready, sent or pending states")
+	MessageStatsConfirmed = ffm("MessageStats.confirmed", "The number of confirmed messages")
+	MessageStatsRejected  = ffm("MessageStats.rejected", "The number of rejected messages")
+
+	// StatsCounter field descriptions
+	StatsCounterTotal         = ffm("StatsCounter.total", "The total count")
+	StatsCounterRatePerMinute = ffm("StatsCounter.ratePerMinute", "The count in the last minute")
+
 	// NamespaceStatusDefaults field descriptions
 	NamespaceStatusDefaultsNamespace = ffm("NamespaceStatusDefaults.namespace", "The default namespace on this node")
 
@@ -500,6 +902,8 @@ var (
 	BatchFlushStatusAverageFlushTimeMS   = ffm("BatchFlushStatus.averageFlushTimeMS", "The average amount of time spent flushing each batch")
 	BatchFlushStatusTotalBatches         = ffm("BatchFlushStatus.totalBatches", "The total count of batches flushed by this processor since it started")
 	BatchFlushStatusTotalErrors          = ffm("BatchFlushStatus.totalErrors", "The total count of error flushed encountered by this processor since it started")
+	BatchFlushStatusCurrentBatchSize     = ffm("BatchFlushStatus.currentBatchSize", "The maximum number of messages this processor currently assembles into a batch. Equal to the configured batch size, unless adaptive tuning has reduced it")
+	BatchFlushStatusCurrentPayloadLimit  = ffm("BatchFlushStatus.currentPayloadLimit", "The maximum payload size this processor currently assembles into a batch. Equal to the configured payload limit, unless adaptive tuning has reduced it")
 
 	// Pin field descriptions
 	PinSequence       = ffm("Pin.sequence", "The order of the pin in the local FireFly database, which matches the order in which pins were delivered to FireFly by the blockchain connector event stream")
@@ -530,9 +934,23 @@ var (
 	SubscriptionFilter    = ffm("Subscription.filter", "Server-side filter to apply to events")
 	SubscriptionOptions   = ffm("Subscription.options", "Subscription options")
 	SubscriptionEphemeral = ffm("Subscription.ephemeral", "Ephemeral subscriptions only exist as long as the application is connected, and as such will miss events that occur while the application is disconnected, and cannot be created administratively. You can create one over over a connected WebSocket connection")
+	SubscriptionOwner     = ffm("Subscription.owner", "The authenticated principal that created the subscription. Only connections authenticated as this principal can attach to the subscription")
 	SubscriptionCreated   = ffm("Subscription.created", "Creation time of the subscription")
 	SubscriptionUpdated   = ffm("Subscription.updated", "Last time the subscription was updated")
 
+	// SubscriptionSLA field descriptions
+	SubscriptionSLANamespace      = ffm("SubscriptionSLA.namespace", "The namespace of the subscription")
+	SubscriptionSLASubscription   = ffm("SubscriptionSLA.subscription", "The UUID of the subscription")
+	SubscriptionSLADay            = ffm("SubscriptionSLA.day", "The UTC day (YYYY-MM-DD) these delivery statistics were aggregated over")
+	SubscriptionSLATarget         = ffm("SubscriptionSLA.target", "The target latency from pin confirmation to subscription acknowledgement")
+	SubscriptionSLADelivered      = ffm("SubscriptionSLA.delivered", "The number of events acknowledged by the subscription on this day")
+	SubscriptionSLAWithinTarget   = ffm("SubscriptionSLA.withinTarget", "The number of events acknowledged within the target latency")
+	SubscriptionSLATotalLatency   = ffm("SubscriptionSLA.totalLatency", "The sum of the delivery latencies of all acknowledged events")
+	SubscriptionSLAAverageLatency = ffm("SubscriptionSLA.averageLatency", "The average delivery latency of acknowledged events")
+	SubscriptionSLAMinLatency     = ffm("SubscriptionSLA.minLatency", "The lowest delivery latency of an acknowledged event")
+	SubscriptionSLAMaxLatency     = ffm("SubscriptionSLA.maxLatency", "The highest delivery latency of an acknowledged event")
+	SubscriptionSLAUpdated        = ffm("SubscriptionSLA.updated", "The last time these statistics were updated")
+
 	// SubscriptionFilter field descriptions
 	SubscriptionFilterEvents           = ffm("SubscriptionFilter.events", "Regular expression to apply to the event type, to subscribe to a subset of event types")
 	SubscriptionFilterTopic            = ffm("SubscriptionFilter.topic", "Regular expression to apply to the topic of the event, to subscribe to a subset of topics. Note for messages sent with multiple topics, a separate event is emitted for each topic")
@@ -556,12 +974,28 @@ var (
 	SubscriptionBlockchainEventFilterName     = ffm("SubscriptionBlockchainEventFilter.name", "Regular expression to apply to the blockchain event 'name' field, which is the name of the event in the underlying blockchain smart contract")
 	SubscriptionBlockchainEventFilterListener = ffm("SubscriptionBlockchainEventFilter.listener", "Regular expression to apply to the blockchain event 'listener' field, which is the UUID of the event listener. So you can restrict your subscription to certain blockchain listeners. Alternatively to avoid your application need to know listener UUIDs you can set the 'topic' field of blockchain event listeners, and use a topic filter on your subscriptions")
 
+	// SubscriptionTestInput field descriptions
+	SubscriptionTestInputEvent   = ffm("SubscriptionTestInput.event", "A sample event to evaluate the filters against, including the referenced objects (such as the message) that the filters apply to")
+	SubscriptionTestInputEventID = ffm("SubscriptionTestInput.eventId", "The UUID of an event already recorded in the namespace, to evaluate the filters against instead of a sample event")
+
+	// SubscriptionTestResult field descriptions
+	SubscriptionTestResultMatched = ffm("SubscriptionTestResult.matched", "True if the event matches every filter of the subscription, and as such would be delivered to it")
+	SubscriptionTestResultEvent   = ffm("SubscriptionTestResult.event", "The event the filters were evaluated against")
+	SubscriptionTestResultFilters = ffm("SubscriptionTestResult.filters", "The result of each filter set on the subscription, in the order they are evaluated during delivery")
+
+	// SubscriptionFilterResult field descriptions
+	SubscriptionFilterResultFilter  = ffm("SubscriptionFilterResult.filter", "The filter, such as 'events' or 'message.tag'")
+	SubscriptionFilterResultPattern = ffm("SubscriptionFilterResult.pattern", "The regular expression of the filter")
+	SubscriptionFilterResultValue   = ffm("SubscriptionFilterResult.value", "The value of the event field the regular expression was applied to. Empty if the event does not have the field")
+	SubscriptionFilterResultMatched = ffm("SubscriptionFilterResult.matched", "True if the regular expression matches the value")
+
 	// SubscriptionCoreOptions field descriptions
 	SubscriptionCoreOptionsFirstEvent   = ffm("SubscriptionCoreOptions.firstEvent", "Whether your application would like to receive events from the 'oldest' event emitted by your FireFly node (from the beginning of time), or the 'newest' event (from now), or a specific event sequence. Default is 'newest'")
 	SubscriptionCoreOptionsReadAhead    = ffm("SubscriptionCoreOptions.readAhead", "The number of events to stream ahead to your application, while waiting for confirmation of consumption of those events. At least once delivery semantics are used in FireFly, so if your application crashes/reconnects this is the maximum number of events you would expect to be redelivered after it restarts")
 	SubscriptionCoreOptionsWithData     = ffm("SubscriptionCoreOptions.withData", "Whether message events delivered over the subscription, should be packaged with the full data of those messages in-line as part of the event JSON payload. Or if the application should make separate REST calls to download that data. May not be supported on some transports.")
 	SubscriptionCoreOptionsBatch        = ffm("SubscriptionCoreOptions.batch", "Events are delivered in batches in an ordered array. The batch size is capped to the readAhead limit. The event payload is always an array even if there is a single event in the batch, allowing client-side optimizations when processing the events in a group. Available for both Webhooks and WebSockets.")
 	SubscriptionCoreOptionsBatchTimeout = ffm("SubscriptionCoreOptions.batchTimeout", "When batching is enabled, the optional timeout to send events even when the batch hasn't filled.")
+	SubscriptionCoreOptionsPriority     = ffm("SubscriptionCoreOptions.priority", "A map of event type to dispatch priority. Matched events waiting for dispatch are delivered highest priority first, with unlisted event types at priority 0. Events are reordered only within the buffered page of events, and an event is never overtaken more than the configured starvation limit")
 
 	// TokenApproval field descriptions
 	TokenApprovalLocalID         = ffm("TokenApproval.localId", "The UUID of this token approval, in the local FireFly node")
@@ -624,32 +1058,91 @@ var (
 	TokenPoolPublished       = ffm("TokenPool.published", "Indicates if the token pool is published to other members of the multiparty network")
 
 	// TokenPoolInput field descriptions
+	TokenPoolInputTemplate       = ffm("TokenPoolInput.template", "The name of a pool template configured in the namespace, which supplies the connector, type and connector specific config for any of those fields not set on the request")
 	TokenPoolInputIdempotencyKey = ffm("TokenPoolInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
 
 	// TokenTransfer field descriptions
-	TokenTransferType            = ffm("TokenTransfer.type", "The type of transfer such as mint/burn/transfer")
-	TokenTransferLocalID         = ffm("TokenTransfer.localId", "The UUID of this token transfer, in the local FireFly node")
-	TokenTransferPool            = ffm("TokenTransfer.pool", "The UUID the token pool this transfer applies to")
-	TokenTransferTokenIndex      = ffm("TokenTransfer.tokenIndex", "The index of the token within the pool that this transfer applies to")
-	TokenTransferURI             = ffm("TokenTransfer.uri", "The URI of the token this transfer applies to")
-	TokenTransferConnector       = ffm("TokenTransfer.connector", "The name of the token connector, as specified in the FireFly core configuration file. Required on input when there are more than one token connectors configured")
-	TokenTransferNamespace       = ffm("TokenTransfer.namespace", "The namespace for the transfer, which must match the namespace of the token pool")
-	TokenTransferKey             = ffm("TokenTransfer.key", "The blockchain signing key for the transfer. On input defaults to the first signing key of the organization that operates the node")
-	TokenTransferFrom            = ffm("TokenTransfer.from", "The source account for the transfer. On input defaults to the value of 'key'")
-	TokenTransferTo              = ffm("TokenTransfer.to", "The target account for the transfer. On input defaults to the value of 'key'")
-	TokenTransferAmount          = ffm("TokenTransfer.amount", "The amount for the transfer. For non-fungible tokens will always be 1. For fungible tokens, the number of decimals for the token pool should be considered when inputting the amount. For example, with 18 decimals a fractional balance of 10.234 will be specified as 10,234,000,000,000,000,000")
-	TokenTransferProtocolID      = ffm("TokenTransfer.protocolId", "An alphanumerically sortable string that represents this event uniquely with respect to the blockchain")
-	TokenTransferMessage         = ffm("TokenTransfer.message", "The UUID of a message that has been correlated with this transfer using the data field of the transfer in a compatible token connector")
-	TokenTransferMessageHash     = ffm("TokenTransfer.messageHash", "The hash of a message that has been correlated with this transfer using the data field of the transfer in a compatible token connector")
-	TokenTransferCreated         = ffm("TokenTransfer.created", "The creation time of the transfer")
-	TokenTransferTX              = ffm("TokenTransfer.tx", "If submitted via FireFly, this will reference the UUID of the FireFly transaction (if the token connector in use supports attaching data)")
-	TokenTransferBlockchainEvent = ffm("TokenTransfer.blockchainEvent", "The UUID of the blockchain event")
-	TokenTransferConfig          = ffm("TokenTransfer.config", "Input only field, with token connector specific configuration of the transfer. See your chosen token connector documentation for details")
+	TokenTransferType             = ffm("TokenTransfer.type", "The type of transfer such as mint/burn/transfer")
+	TokenTransferLocalID          = ffm("TokenTransfer.localId", "The UUID of this token transfer, in the local FireFly node")
+	TokenTransferPool             = ffm("TokenTransfer.pool", "The UUID the token pool this transfer applies to")
+	TokenTransferTokenIndex       = ffm("TokenTransfer.tokenIndex", "The index of the token within the pool that this transfer applies to")
+	TokenTransferURI              = ffm("TokenTransfer.uri", "The URI of the token this transfer applies to")
+	TokenTransferConnector        = ffm("TokenTransfer.connector", "The name of the token connector, as specified in the FireFly core configuration file. Required on input when there are more than one token connectors configured")
+	TokenTransferNamespace        = ffm("TokenTransfer.namespace", "The namespace for the transfer, which must match the namespace of the token pool")
+	TokenTransferKey              = ffm("TokenTransfer.key", "The blockchain signing key for the transfer. On input defaults to the first signing key of the organization that operates the node")
+	TokenTransferFrom             = ffm("TokenTransfer.from", "The source account for the transfer. On input defaults to the value of 'key'")
+	TokenTransferTo               = ffm("TokenTransfer.to", "The target account for the transfer. On input defaults to the value of 'key'")
+	TokenTransferAmount           = ffm("TokenTransfer.amount", "The amount for the transfer. For non-fungible tokens will always be 1. For fungible tokens, the number of decimals for the token pool should be considered when inputting the amount. For example, with 18 decimals a fractional balance of 10.234 will be specified as 10,234,000,000,000,000,000")
+	TokenTransferProtocolID       = ffm("TokenTransfer.protocolId", "An alphanumerically sortable string that represents this event uniquely with respect to the blockchain")
+	TokenTransferMessage          = ffm("TokenTransfer.message", "The UUID of a message that has been correlated with this transfer using the data field of the transfer in a compatible token connector")
+	TokenTransferMessageHash      = ffm("TokenTransfer.messageHash", "The hash of a message that has been correlated with this transfer using the data field of the transfer in a compatible token connector")
+	TokenTransferCreated          = ffm("TokenTransfer.created", "The creation time of the transfer")
+	TokenTransferTX               = ffm("TokenTransfer.tx", "If submitted via FireFly, this will reference the UUID of the FireFly transaction (if the token connector in use supports attaching data)")
+	TokenTransferBlockchainEvent  = ffm("TokenTransfer.blockchainEvent", "The UUID of the blockchain event")
+	TokenTransferBlockNumber      = ffm("TokenTransfer.blockNumber", "The number of the block containing the transfer, if reported by the connector")
+	TokenTransferTransactionIndex = ffm("TokenTransfer.transactionIndex", "The index of the transaction containing the transfer within its block, if reported by the connector")
+	TokenTransferLogIndex         = ffm("TokenTransfer.logIndex", "The index of the log event for the transfer within its block, if reported by the connector")
+	TokenTransferConfig           = ffm("TokenTransfer.config", "Input only field, with token connector specific configuration of the transfer. See your chosen token connector documentation for details")
+
+	// TokenAccountPoolSummary field descriptions
+	TokenAccountPoolSummaryPool         = ffm("TokenAccountPoolSummary.pool", "The UUID of the token pool")
+	TokenAccountPoolSummaryName         = ffm("TokenAccountPoolSummary.name", "The name of the token pool")
+	TokenAccountPoolSummaryConnector    = ffm("TokenAccountPoolSummary.connector", "The name of the token connector responsible for the token pool")
+	TokenAccountPoolSummaryType         = ffm("TokenAccountPoolSummary.type", "The type of token the pool contains, such as fungible/non-fungible")
+	TokenAccountPoolSummaryDecimals     = ffm("TokenAccountPoolSummary.decimals", "Number of decimal places that the tokens of the pool have")
+	TokenAccountPoolSummaryBalance      = ffm("TokenAccountPoolSummary.balance", "The total balance of the account across all tokens in the pool")
+	TokenAccountPoolSummaryTokens       = ffm("TokenAccountPoolSummary.tokens", "The number of distinct tokens in the pool for which the account holds a non-zero balance")
+	TokenAccountPoolSummaryTransfers    = ffm("TokenAccountPoolSummary.transfers", "The number of transfers into or out of the account in the pool")
+	TokenAccountPoolSummaryLastActivity = ffm("TokenAccountPoolSummary.lastActivity", "The time of the most recent transfer or balance update of the account in the pool")
+
+	// TokenAccountSummary field descriptions
+	TokenAccountSummaryKey    = ffm("TokenAccountSummary.key", "The blockchain signing key of the account")
+	TokenAccountSummaryPools  = ffm("TokenAccountSummary.pools", "The position of the account in each token pool it has been active in, across all token connectors in the namespace")
+	TokenAccountSummaryRecent = ffm("TokenAccountSummary.recent", "The most recent transfers into or out of the account, across all token pools")
+
+	// TokenAccountHistoryEntry field descriptions
+	TokenAccountHistoryEntryCreated         = ffm("TokenAccountHistoryEntry.created", "The creation time of the transfer")
+	TokenAccountHistoryEntryType            = ffm("TokenAccountHistoryEntry.type", "The type of transfer such as mint/burn/transfer")
+	TokenAccountHistoryEntryLocalID         = ffm("TokenAccountHistoryEntry.localId", "The UUID of the token transfer, in the local FireFly node")
+	TokenAccountHistoryEntryTokenIndex      = ffm("TokenAccountHistoryEntry.tokenIndex", "The index of the token within the pool that the transfer applies to")
+	TokenAccountHistoryEntryFrom            = ffm("TokenAccountHistoryEntry.from", "The source account for the transfer")
+	TokenAccountHistoryEntryTo              = ffm("TokenAccountHistoryEntry.to", "The target account for the transfer")
+	TokenAccountHistoryEntryAmount          = ffm("TokenAccountHistoryEntry.amount", "The amount of the transfer")
+	TokenAccountHistoryEntryChange          = ffm("TokenAccountHistoryEntry.change", "The signed change the transfer made to the balance of the account - positive when received and negative when sent")
+	TokenAccountHistoryEntryBalance         = ffm("TokenAccountHistoryEntry.balance", "The running balance of the account in the pool after the transfer was applied")
+	TokenAccountHistoryEntryProtocolID      = ffm("TokenAccountHistoryEntry.protocolId", "An alphanumerically sortable string that represents the transfer uniquely with respect to the blockchain")
+	TokenAccountHistoryEntryTX              = ffm("TokenAccountHistoryEntry.tx", "The UUID of the FireFly transaction, if the transfer was submitted via FireFly")
+	TokenAccountHistoryEntryBlockchainEvent = ffm("TokenAccountHistoryEntry.blockchainEvent", "The UUID of the blockchain event")
+
+	// TokenPoolTemplate field descriptions
+	TokenPoolTemplateName      = ffm("TokenPoolTemplate.name", "The name of the template, which is supplied in the template field when creating a pool")
+	TokenPoolTemplateConnector = ffm("TokenPoolTemplate.connector", "The token connector used by pools created from the template, if not specified on creation")
+	TokenPoolTemplateType      = ffm("TokenPoolTemplate.type", "The type of token of pools created from the template, if not specified on creation")
+	TokenPoolTemplateStandard  = ffm("TokenPoolTemplate.standard", "The token standard that pools created from the template conform to")
+	TokenPoolTemplateDecimals  = ffm("TokenPoolTemplate.decimals", "The number of decimals of pools created from the template")
+	TokenPoolTemplateConfig    = ffm("TokenPoolTemplate.config", "The connector specific config passed when creating pools from the template. Keys supplied in the config on creation override those of the template")
 
 	// TokenTransferInput field descriptions
 	TokenTransferInputMessage        = ffm("TokenTransferInput.message", "You can specify a message to correlate with the transfer, which can be of type broadcast or private. Your chosen token connector and on-chain smart contract must support on-chain/off-chain correlation by taking a `data` input on the transfer")
 	TokenTransferInputPool           = ffm("TokenTransferInput.pool", "The name or UUID of a token pool")
 	TokenTransferInputIdempotencyKey = ffm("TokenTransferInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+	TokenTransferInputOnBehalfOf     = ffm("TokenTransferInput.onBehalfOf", "The identity this transfer is submitted on behalf of. The identity that owns the signing key must hold a current delegation from it with the 'transfers' scope, and any attached message is authored by it")
+
+	// TokenMintRecipient field descriptions
+	TokenMintRecipientTo         = ffm("TokenMintRecipient.to", "The account to receive the tokens - a signing key or an address book alias")
+	TokenMintRecipientAmount     = ffm("TokenMintRecipient.amount", "The amount of tokens to distribute to the account")
+	TokenMintRecipientTokenIndex = ffm("TokenMintRecipient.tokenIndex", "The index of the token to mint to the account, for non-fungible pools")
+
+	// TokenMintDistributionInput field descriptions
+	TokenMintDistributionInputPool           = ffm("TokenMintDistributionInput.pool", "The name or UUID of a token pool")
+	TokenMintDistributionInputKey            = ffm("TokenMintDistributionInput.key", "The blockchain signing key for the mint and the transfers. The tokens of fungible pools are minted to this key before being transferred to each recipient")
+	TokenMintDistributionInputRecipients     = ffm("TokenMintDistributionInput.recipients", "The accounts to distribute the minted tokens to")
+	TokenMintDistributionInputConfig         = ffm("TokenMintDistributionInput.config", "Input parameters passed to the token connector with each mint and transfer")
+	TokenMintDistributionInputIdempotencyKey = ffm("TokenMintDistributionInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+
+	// TokenMintDistribution field descriptions
+	TokenMintDistributionTX        = ffm("TokenMintDistribution.tx", "The FireFly transaction that the mint and all of the transfers are submitted within")
+	TokenMintDistributionTransfers = ffm("TokenMintDistribution.transfers", "The mints and transfers submitted for the distribution, in the order they are submitted to the token connector")
 
 	// TransactionStatus field descriptions
 	TransactionStatusStatus  = ffm("TransactionStatus.status", "The overall computed status of the transaction, after analyzing the details during the API call")
@@ -671,8 +1164,31 @@ var (
 	ContractDeployRequestContract       = ffm("ContractDeployRequest.contract", "The smart contract to deploy. This should be pre-compiled if required by the blockchain connector")
 	ContractDeployRequestErrors         = ffm("ContractDeployRequest.errors", "An in-line FFI errors definition for the constructor")
 	ContractDeployRequestOptions        = ffm("ContractDeployRequest.options", "A map of named inputs that will be passed through to the blockchain connector")
+	ContractDeployRequestInterface      = ffm("ContractDeployRequest.interface", "An optional reference to the FireFly Interface (FFI) of the contract being deployed")
+	ContractDeployRequestAPIName        = ffm("ContractDeployRequest.apiName", "When set, a contract API with this name is created for the interface, bound to the address of the contract once it is deployed")
 	ContractDeployRequestIdempotencyKey = ffm("ContractDeployRequest.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
 
+	// ContractABI field descriptions
+	ContractABIID          = ffm("ContractABI.id", "The ID assigned to the ABI by the blockchain connector")
+	ContractABIName        = ffm("ContractABI.name", "The name of the contract")
+	ContractABIDescription = ffm("ContractABI.description", "A description of the contract")
+	ContractABIDeployable  = ffm("ContractABI.deployable", "True if bytecode was stored with the ABI, so new instances of the contract can be deployed from it")
+	ContractABICreated     = ffm("ContractABI.created", "The time the ABI was stored in the blockchain connector")
+	ContractABIABI         = ffm("ContractABI.abi", "The ABI definition. Only returned when querying a single ABI")
+
+	// ContractABIUpload field descriptions
+	ContractABIUploadName        = ffm("ContractABIUpload.name", "The name of the contract")
+	ContractABIUploadDescription = ffm("ContractABIUpload.description", "A description of the contract")
+	ContractABIUploadABI         = ffm("ContractABIUpload.abi", "The ABI definition of the contract")
+	ContractABIUploadBytecode    = ffm("ContractABIUpload.bytecode", "The compiled bytecode of the contract, as a hex string. Required to deploy new instances of the contract")
+
+	// ContractABIDeployRequest field descriptions
+	ContractABIDeployRequestABI            = ffm("ContractABIDeployRequest.abi", "The ID of the ABI stored in the blockchain connector")
+	ContractABIDeployRequestKey            = ffm("ContractABIDeployRequest.key", "The blockchain signing key that will be used to deploy the contract. Defaults to the first signing key of the organization that operates the node")
+	ContractABIDeployRequestInput          = ffm("ContractABIDeployRequest.input", "A map of named inputs passed to the smart contract's constructor, if applicable")
+	ContractABIDeployRequestOptions        = ffm("ContractABIDeployRequest.options", "A map of named options that will be passed through to the blockchain connector")
+	ContractABIDeployRequestIdempotencyKey = ffm("ContractABIDeployRequest.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
+
 	// ContractCallRequest field descriptions
 	ContractCallRequestType       = ffm("ContractCallRequest.type", "Invocations cause transactions on the blockchain. Whereas queries simply execute logic in your local node to query data at a given current/historical block")
 	ContractCallRequestInterface  = ffm("ContractCallRequest.interface", "The UUID of a method within a pre-configured FireFly interface (FFI) definition for a smart contract. Required if the 'method' is omitted. Also see Contract APIs as a way to configure a dedicated API for your FFI, including all methods and an OpenAPI/Swagger interface")
@@ -737,6 +1253,17 @@ var (
 	// PublishInput field descriptions
 	PublishInputIdempotencyKey = ffm("PublishInput.idempotencyKey", "An optional identifier to allow idempotent submission of requests. Stored on the transaction uniquely within a namespace")
 
+	// Embedded field descriptions
+	EmbeddedData        = ffm("Embedded.data", "The data attached to the message, or to the message sent with the token transfer, in the order it is referenced")
+	EmbeddedTransaction = ffm("Embedded.transaction", "The transaction the item was submitted in")
+	EmbeddedEvents      = ffm("Embedded.events", "The events that reference the item, or are correlated to it, in the order they were emitted")
+
+	// MessageWithEmbedded field descriptions
+	MessageWithEmbeddedEmbedded = ffm("MessageWithEmbedded.embedded", "The referenced records requested with the embed parameter")
+
+	// TokenTransferWithEmbedded field descriptions
+	TokenTransferWithEmbeddedEmbedded = ffm("TokenTransferWithEmbedded.embedded", "The referenced records requested with the embed parameter")
+
 	// DefinitionPublish field descriptions
 	DefinitionPublishNetworkName = ffm("DefinitionPublish.networkName", "An optional name to be used for publishing this definition to the multiparty network, which may differ from the local name")
 )
@@ -0,0 +1,143 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type blobGCConf struct {
+	retention   time.Duration
+	gracePeriod time.Duration
+	pageSize    int
+}
+
+// CollectBlobs deletes the blobs that are no longer referenced by any message within retention.
+// A blob is referenced by the messages that include the data item it is attached to - once the
+// data item is deleted, no message references it, or every message that does was confirmed
+// before the retention period, the blob is removed from the data exchange and the local database.
+// With dryRun set, the eligible blobs are reported but nothing is deleted.
+func (dm *dataManager) CollectBlobs(ctx context.Context, dryRun bool) (*core.BlobCollection, error) {
+	if dm.exchange == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+
+	now := time.Now()
+	result := &core.BlobCollection{
+		Namespace: dm.namespace.Name,
+		DryRun:    dryRun,
+		Blobs:     []*core.CollectedBlob{},
+	}
+	if dm.blobGC.retention > 0 {
+		cutoff := fftypes.FFTime(now.Add(-dm.blobGC.retention))
+		result.Cutoff = &cutoff
+	}
+	// Blobs created within the grace period are never collected, as the message that references
+	// them might not have arrived yet
+	graceCutoff := fftypes.FFTime(now.Add(-dm.blobGC.gracePeriod))
+
+	skip := 0
+	for {
+		fb := database.BlobQueryFactory.NewFilter(ctx)
+		filter := fb.Lt("created", &graceCutoff).Sort("created").Skip(uint64(skip)).Limit(uint64(dm.blobGC.pageSize))
+		blobs, _, err := dm.database.GetBlobs(ctx, dm.namespace.Name, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, blob := range blobs {
+			result.Scanned++
+			reason, data, err := dm.blobCollectReason(ctx, blob, result.Cutoff)
+			if err != nil {
+				return nil, err
+			}
+			if reason == "" {
+				skip++
+				continue
+			}
+			log.L(ctx).Infof("Collecting blob %s for data %s (reason=%s dryRun=%t)", blob.Hash, blob.DataID, reason, dryRun)
+			if dryRun {
+				skip++
+			} else if err := dm.collectBlob(ctx, blob, data); err != nil {
+				return nil, err
+			}
+			result.Blobs = append(result.Blobs, &core.CollectedBlob{
+				Hash:       blob.Hash,
+				DataID:     blob.DataID,
+				PayloadRef: blob.PayloadRef,
+				Size:       blob.Size,
+				Reason:     reason,
+			})
+		}
+		if len(blobs) < dm.blobGC.pageSize {
+			break
+		}
+	}
+	return result, nil
+}
+
+// blobCollectReason returns why the blob can be collected, or an empty reason if it is still referenced.
+// The data item the blob is attached to is also returned, if it still exists.
+func (dm *dataManager) blobCollectReason(ctx context.Context, blob *core.Blob, cutoff *fftypes.FFTime) (core.BlobCollectReason, *core.Data, error) {
+	if blob.DataID == nil {
+		return core.BlobCollectReasonDataDeleted, nil, nil
+	}
+	data, err := dm.database.GetDataByID(ctx, dm.namespace.Name, blob.DataID, false)
+	if err != nil {
+		return "", nil, err
+	}
+	if data == nil {
+		return core.BlobCollectReasonDataDeleted, nil, nil
+	}
+
+	msgs, _, err := dm.database.GetMessagesForData(ctx, dm.namespace.Name, blob.DataID, database.MessageQueryFactory.NewFilter(ctx).And())
+	if err != nil {
+		return "", nil, err
+	}
+	if len(msgs) == 0 {
+		return core.BlobCollectReasonUnreferenced, data, nil
+	}
+	if cutoff == nil {
+		return "", data, nil
+	}
+	for _, msg := range msgs {
+		// Messages that are not yet confirmed always hold a reference
+		if msg.Confirmed == nil || !msg.Confirmed.Time().Before(*cutoff.Time()) {
+			return "", data, nil
+		}
+	}
+	return core.BlobCollectReasonExpired, data, nil
+}
+
+// collectBlob releases any pin of the shared storage copy of the blob, then deletes it from the
+// data exchange and the local database
+func (dm *dataManager) collectBlob(ctx context.Context, blob *core.Blob, data *core.Data) error {
+	if data != nil && data.Blob != nil && data.Blob.Public != "" && data.Pin != nil && data.Pin.Request != "" &&
+		dm.sharedstorage != nil && dm.sharedstorage.Capabilities().Pinning {
+		if err := dm.sharedstorage.UnpinData(ctx, data.Pin); err != nil {
+			return err
+		}
+	}
+	return dm.DeleteBlob(ctx, blob)
+}
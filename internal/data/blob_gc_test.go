@@ -0,0 +1,287 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/sharedstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestGCBlob() *core.Blob {
+	return &core.Blob{
+		Sequence:   12345,
+		Namespace:  "ns1",
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "ns1/blob1",
+		Size:       100,
+		DataID:     fftypes.NewUUID(),
+	}
+}
+
+func TestCollectBlobsDataDeleted(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+
+	blob := newTestGCBlob()
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil).Once()
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(nil, nil)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil).Once()
+	mdx.On("DeleteBlob", ctx, "ns1/blob1").Return(nil)
+	mdb.On("DeleteBlob", ctx, int64(12345)).Return(nil)
+
+	result, err := dm.CollectBlobs(ctx, false)
+	assert.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Nil(t, result.Cutoff)
+	assert.Equal(t, 1, result.Scanned)
+	assert.Len(t, result.Blobs, 1)
+	assert.Equal(t, core.BlobCollectReasonDataDeleted, result.Blobs[0].Reason)
+	assert.Equal(t, blob.Hash, result.Blobs[0].Hash)
+
+	mdb.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
+
+func TestCollectBlobsNoDataID(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	blob := newTestGCBlob()
+	blob.DataID = nil
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+
+	result, err := dm.CollectBlobs(ctx, true)
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Len(t, result.Blobs, 1)
+	assert.Equal(t, core.BlobCollectReasonDataDeleted, result.Blobs[0].Reason)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestCollectBlobsUnreferencedUnpin(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+	mps := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+
+	blob := newTestGCBlob()
+	data := &core.Data{
+		ID:   blob.DataID,
+		Blob: &core.BlobRef{Hash: blob.Hash, Public: "public-ref"},
+		Pin:  &core.DataPin{Status: core.DataPinStatusPinned, Request: "pin1"},
+	}
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil).Once()
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(data, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", blob.DataID, mock.Anything).Return([]*core.Message{}, nil, nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("UnpinData", ctx, data.Pin).Return(nil)
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil).Once()
+	mdx.On("DeleteBlob", ctx, "ns1/blob1").Return(nil)
+	mdb.On("DeleteBlob", ctx, int64(12345)).Return(nil)
+
+	result, err := dm.CollectBlobs(ctx, false)
+	assert.NoError(t, err)
+	assert.Len(t, result.Blobs, 1)
+	assert.Equal(t, core.BlobCollectReasonUnreferenced, result.Blobs[0].Reason)
+
+	mdb.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mps.AssertExpectations(t)
+}
+
+func TestCollectBlobsUnpinFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mps := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+
+	blob := newTestGCBlob()
+	data := &core.Data{
+		ID:   blob.DataID,
+		Blob: &core.BlobRef{Hash: blob.Hash, Public: "public-ref"},
+		Pin:  &core.DataPin{Status: core.DataPinStatusPinned, Request: "pin1"},
+	}
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(data, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", blob.DataID, mock.Anything).Return([]*core.Message{}, nil, nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("UnpinData", ctx, data.Pin).Return(fmt.Errorf("pop"))
+
+	_, err := dm.CollectBlobs(ctx, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCollectBlobsExpired(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.blobGC.retention = 24 * time.Hour
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	expired := newTestGCBlob()
+	retained := newTestGCBlob()
+	unconfirmed := newTestGCBlob()
+	old := fftypes.FFTime(time.Now().Add(-48 * time.Hour))
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{expired, retained, unconfirmed}, nil, nil)
+	for _, blob := range []*core.Blob{expired, retained, unconfirmed} {
+		mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(&core.Data{ID: blob.DataID}, nil)
+	}
+	mdb.On("GetMessagesForData", ctx, "ns1", expired.DataID, mock.Anything).Return([]*core.Message{
+		{Confirmed: &old},
+		{Confirmed: &old},
+	}, nil, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", retained.DataID, mock.Anything).Return([]*core.Message{
+		{Confirmed: &old},
+		{Confirmed: fftypes.Now()},
+	}, nil, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", unconfirmed.DataID, mock.Anything).Return([]*core.Message{
+		{},
+	}, nil, nil)
+
+	result, err := dm.CollectBlobs(ctx, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Cutoff)
+	assert.Equal(t, 3, result.Scanned)
+	assert.Len(t, result.Blobs, 1)
+	assert.Equal(t, expired.Hash, result.Blobs[0].Hash)
+	assert.Equal(t, core.BlobCollectReasonExpired, result.Blobs[0].Reason)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestCollectBlobsReferencedNoRetention(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	blob := newTestGCBlob()
+	old := fftypes.FFTime(time.Now().Add(-48 * time.Hour))
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(&core.Data{ID: blob.DataID}, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", blob.DataID, mock.Anything).Return([]*core.Message{
+		{Confirmed: &old},
+	}, nil, nil)
+
+	result, err := dm.CollectBlobs(ctx, false)
+	assert.NoError(t, err)
+	assert.Empty(t, result.Blobs)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestCollectBlobsPaging(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.blobGC.pageSize = 1
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	blob := newTestGCBlob()
+	mdb.On("GetBlobs", ctx, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return fi.Skip == 0
+	})).Return([]*core.Blob{blob}, nil, nil)
+	mdb.On("GetBlobs", ctx, "ns1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return fi.Skip == 1
+	})).Return([]*core.Blob{}, nil, nil)
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(&core.Data{ID: blob.DataID}, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", blob.DataID, mock.Anything).Return([]*core.Message{{}}, nil, nil)
+
+	result, err := dm.CollectBlobs(ctx, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Scanned)
+	assert.Empty(t, result.Blobs)
+
+	mdb.AssertExpectations(t)
+}
+
+func TestCollectBlobsGetBlobsFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := dm.CollectBlobs(ctx, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCollectBlobsGetDataFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	blob := newTestGCBlob()
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(nil, fmt.Errorf("pop"))
+
+	_, err := dm.CollectBlobs(ctx, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCollectBlobsGetMessagesFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+
+	blob := newTestGCBlob()
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdb.On("GetDataByID", ctx, "ns1", blob.DataID, false).Return(&core.Data{ID: blob.DataID}, nil)
+	mdb.On("GetMessagesForData", ctx, "ns1", blob.DataID, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := dm.CollectBlobs(ctx, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCollectBlobsDeleteFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mdx := dm.exchange.(*dataexchangemocks.Plugin)
+
+	blob := newTestGCBlob()
+	blob.DataID = nil
+	mdb.On("GetBlobs", ctx, "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdx.On("DeleteBlob", ctx, "ns1/blob1").Return(fmt.Errorf("pop"))
+
+	_, err := dm.CollectBlobs(ctx, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestCollectBlobsNoDX(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.exchange = nil
+
+	_, err := dm.CollectBlobs(ctx, false)
+	assert.Regexp(t, "FF10414", err)
+}
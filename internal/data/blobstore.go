@@ -84,12 +84,14 @@ func (bs *blobStore) UploadBlob(ctx context.Context, inData *core.DataRefOrValue
 	}
 
 	data := &core.Data{
-		ID:        fftypes.NewUUID(),
-		Namespace: bs.dm.namespace.Name,
-		Created:   fftypes.Now(),
-		Validator: inData.Validator,
-		Datatype:  inData.Datatype,
-		Value:     inData.Value,
+		ID:               fftypes.NewUUID(),
+		Namespace:        bs.dm.namespace.Name,
+		Created:          fftypes.Now(),
+		Validator:        inData.Validator,
+		Datatype:         inData.Datatype,
+		Value:            inData.Value,
+		HashAlgorithm:    bs.dm.hashAlgorithm,
+		Canonicalization: bs.dm.canonicalization,
 	}
 
 	hash, blobSize, payloadRef, err := bs.uploadVerifyBlob(ctx, data.ID, mpart.Data)
@@ -20,23 +20,28 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/hyperledger/firefly/pkg/sharedstorage"
 )
 
 type Manager interface {
 	CheckDatatype(ctx context.Context, datatype *core.Datatype) error
-	ValidateAll(ctx context.Context, data core.DataArray) (valid bool, err error)
+	MigrateDatatype(ctx context.Context, datatype *core.Datatype)
+	InferDatatype(ctx context.Context, input *core.DatatypeInferInput) (*core.Datatype, error)
+	ValidateAll(ctx context.Context, data core.DataArray) (*DataValidation, error)
 	GetMessageWithDataCached(ctx context.Context, msgID *fftypes.UUID, options ...CacheReadOption) (msg *core.Message, data core.DataArray, foundAllData bool, err error)
 	GetMessageDataCached(ctx context.Context, msg *core.Message, options ...CacheReadOption) (data core.DataArray, foundAll bool, err error)
 	PeekMessageCache(ctx context.Context, id *fftypes.UUID, options ...CacheReadOption) (msg *core.Message, data core.DataArray)
@@ -48,9 +53,12 @@ type Manager interface {
 	BlobsEnabled() bool
 
 	UploadJSON(ctx context.Context, inData *core.DataRefOrValue) (*core.Data, error)
+	UploadBulk(ctx context.Context, inData []*core.DataRefOrValue) (core.DataArray, error)
 	UploadBlob(ctx context.Context, inData *core.DataRefOrValue, blob *ffapi.Multipart, autoMeta bool) (*core.Data, error)
+	UploadProtobuf(ctx context.Context, inData *core.DataRefOrValue, payload *ffapi.Multipart) (*core.Data, error)
 	DownloadBlob(ctx context.Context, dataID string) (*core.Blob, io.ReadCloser, error)
 	DeleteData(ctx context.Context, dataID string) error
+	CollectBlobs(ctx context.Context, dryRun bool) (*core.BlobCollection, error)
 	HydrateBatch(ctx context.Context, persistedBatch *core.BatchPersisted) (*core.Batch, error)
 	Start()
 	WaitStop()
@@ -58,11 +66,18 @@ type Manager interface {
 
 type dataManager struct {
 	blobStore
-	namespace      *core.Namespace
-	database       database.Plugin
-	validatorCache cache.CInterface
-	messageCache   cache.CInterface
-	messageWriter  *messageWriter
+	namespace        *core.Namespace
+	database         database.Plugin
+	sharedstorage    sharedstorage.Plugin // optional
+	validatorCache   cache.CInterface
+	messageCache     cache.CInterface
+	messageWriter    *messageWriter
+	migrator         *datatypeMigrator
+	blobGC           blobGCConf
+	hashAlgorithm    core.HashAlgorithm
+	canonicalization core.DataCanonicalization
+	validation       ValidationPolicies
+	protobufLimit    int64
 }
 
 type messageCacheEntry struct {
@@ -93,13 +108,27 @@ const (
 	CRORequireBatchID
 )
 
-func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin, dx dataexchange.Plugin, cacheManager cache.Manager) (Manager, error) {
+func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin, dx dataexchange.Plugin, ss sharedstorage.Plugin, cacheManager cache.Manager, validation ValidationPolicies, canonicalization core.DataCanonicalization) (Manager, error) {
 	if di == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DataManager")
 	}
+	hashAlgorithm, err := core.ParseHashAlgorithm(ctx, config.GetString(coreconfig.HashAlgorithm))
+	if err != nil {
+		return nil, err
+	}
 	dm := &dataManager{
-		namespace: ns,
-		database:  di,
+		namespace:     ns,
+		database:      di,
+		sharedstorage: ss,
+		blobGC: blobGCConf{
+			retention:   config.GetDuration(coreconfig.BlobGCRetention),
+			gracePeriod: config.GetDuration(coreconfig.BlobGCGracePeriod),
+			pageSize:    config.GetInt(coreconfig.BlobGCPageSize),
+		},
+		hashAlgorithm:    hashAlgorithm,
+		canonicalization: canonicalization,
+		validation:       validation,
+		protobufLimit:    config.GetByteSize(coreconfig.ValidationProtobufMaxUploadSize),
 	}
 	dm.blobStore = blobStore{
 		dm:       dm,
@@ -137,22 +166,59 @@ func NewDataManager(ctx context.Context, ns *core.Namespace, di database.Plugin,
 		batchTimeout: config.GetDuration(coreconfig.MessageWriterBatchTimeout),
 		maxInserts:   config.GetInt(coreconfig.MessageWriterBatchMaxInserts),
 	})
+	dm.migrator = newDatatypeMigrator(ctx, ns.Name, di, &datatypeMigratorConf{
+		pageSize: config.GetInt(coreconfig.DatatypeMigrationPageSize),
+		retry: &retry.Retry{
+			InitialDelay: config.GetDuration(coreconfig.DatatypeMigrationRetryInitDelay),
+			MaximumDelay: config.GetDuration(coreconfig.DatatypeMigrationRetryMaxDelay),
+			Factor:       config.GetFloat64(coreconfig.DatatypeMigrationRetryFactor),
+		},
+	})
 	return dm, nil
 }
 
 func (dm *dataManager) Start() {
 	dm.messageWriter.start()
+	dm.migrator.start()
 }
 
 func (dm *dataManager) BlobsEnabled() bool {
 	return dm.blobStore.exchange != nil
 }
 
+// MigrateDatatype queues a background migration of existing data to a newly confirmed datatype version,
+// if the datatype registers a migration from an earlier version
+func (dm *dataManager) MigrateDatatype(ctx context.Context, datatype *core.Datatype) {
+	if datatype.Migration != nil {
+		log.L(ctx).Infof("Queuing migration of data from %s:%s to %s:%s", datatype.Name, datatype.Migration.FromVersion, datatype.Name, datatype.Version)
+		dm.migrator.queue(datatype)
+	}
+}
+
 func (dm *dataManager) CheckDatatype(ctx context.Context, datatype *core.Datatype) error {
-	_, err := newJSONValidator(ctx, dm.namespace.Name, datatype)
+	_, err := newValidator(ctx, dm.namespace.Name, datatype.Validator, datatype)
 	return err
 }
 
+func newValidator(ctx context.Context, ns string, validator core.ValidatorType, datatype *core.Datatype) (Validator, error) {
+	if validator == "" {
+		validator = core.ValidatorTypeJSON
+	}
+	datatypeValidator := datatype.Validator
+	if datatypeValidator == "" {
+		datatypeValidator = core.ValidatorTypeJSON
+	}
+	if validator != datatypeValidator {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDatatypeValidatorMismatch, validator, datatypeValidator, &core.DatatypeRef{Name: datatype.Name, Version: datatype.Version})
+	}
+	switch validator {
+	case core.ValidatorTypeProtobuf:
+		return newProtobufValidator(ctx, ns, datatype)
+	default:
+		return newJSONValidator(ctx, ns, datatype)
+	}
+}
+
 // getValidatorForDatatype only returns database errors - not found (of all kinds) is a nil
 func (dm *dataManager) getValidatorForDatatype(ctx context.Context, validator core.ValidatorType, datatypeRef *core.DatatypeRef) (Validator, error) {
 	if validator == "" {
@@ -176,7 +242,7 @@ func (dm *dataManager) getValidatorForDatatype(ctx context.Context, validator co
 	if datatype == nil {
 		return nil, nil
 	}
-	v, err := newJSONValidator(ctx, dm.namespace.Name, datatype)
+	v, err := newValidator(ctx, dm.namespace.Name, validator, datatype)
 	if err != nil {
 		log.L(ctx).Errorf("Invalid validator stored for '%s:%s:%s': %s", validator, dm.namespace.Name, datatypeRef, err)
 		return nil, nil
@@ -315,24 +381,28 @@ func (dm *dataManager) getMessageData(ctx context.Context, msg *core.Message) (d
 	return data, foundAll, nil
 }
 
-func (dm *dataManager) ValidateAll(ctx context.Context, data core.DataArray) (valid bool, err error) {
+// ValidateAll checks the data against the datatypes it references. Data that fails validation is
+// reported with the validation policy of its datatype, rather than as an error - errors are only
+// returned for failures to look up the datatypes.
+func (dm *dataManager) ValidateAll(ctx context.Context, data core.DataArray) (*DataValidation, error) {
+	result := &DataValidation{Valid: true}
 	for _, d := range data {
 		if d.Datatype != nil && d.Validator != core.ValidatorTypeNone {
 			v, err := dm.getValidatorForDatatype(ctx, d.Validator, d.Datatype)
 			if err != nil {
-				return false, err
+				return nil, err
 			}
 			if v == nil {
 				log.L(ctx).Errorf("Datatype %s:%s:%s not found", d.Validator, d.Namespace, d.Datatype)
-				return false, err
+				result.addFailure(dm.validation.forDatatype(d.Datatype), d.Datatype, i18n.NewError(ctx, coremsgs.MsgDatatypeNotFound, d.Datatype).Error())
+				continue
 			}
-			err = v.ValidateValue(ctx, d.Value, d.Hash)
-			if err != nil {
-				return false, err
+			if err = v.ValidateValue(ctx, d.Value, d.Hash); err != nil {
+				result.addFailure(dm.validation.forDatatype(d.Datatype), d.Datatype, err.Error())
 			}
 		}
 	}
-	return true, nil
+	return result, nil
 }
 
 func (dm *dataManager) resolveRef(ctx context.Context, dataRef *core.DataRef) (*core.Data, error) {
@@ -393,6 +463,11 @@ func (dm *dataManager) checkValidation(ctx context.Context, validator core.Valid
 			}
 			err = v.ValidateValue(ctx, value, nil)
 			if err != nil {
+				// The policy of the receiving nodes determines what happens to invalid data, unless it is enforced
+				if policy := dm.validation.forDatatype(datatype); policy != core.ValidationPolicyEnforce {
+					log.L(ctx).Warnf("Sending data that is invalid for datatype %s, as the validation policy is '%s': %s", datatype, policy, err)
+					return nil
+				}
 				return err
 			}
 		}
@@ -418,11 +493,13 @@ func (dm *dataManager) validateInputData(ctx context.Context, inData *core.DataR
 
 	// Ok, we're good to generate the full data payload and save it
 	data = &core.Data{
-		Validator: validator,
-		Datatype:  datatype,
-		Namespace: dm.namespace.Name,
-		Value:     value,
-		Blob:      blobRef,
+		Validator:        validator,
+		Datatype:         datatype,
+		Namespace:        dm.namespace.Name,
+		Value:            value,
+		Blob:             blobRef,
+		HashAlgorithm:    dm.hashAlgorithm,
+		Canonicalization: dm.canonicalization,
 	}
 	err = data.Seal(ctx, blob)
 	if err != nil {
@@ -442,6 +519,44 @@ func (dm *dataManager) UploadJSON(ctx context.Context, inData *core.DataRefOrVal
 	return data, err
 }
 
+// UploadBulk validates and stores a set of data items in one call. The items are stored atomically,
+// so if any of them is invalid, or fails to be written, none of them are stored.
+func (dm *dataManager) UploadBulk(ctx context.Context, inData []*core.DataRefOrValue) (core.DataArray, error) {
+	if len(inData) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgBulkDataEmpty)
+	}
+	data := make(core.DataArray, len(inData))
+	for i, dataOrValue := range inData {
+		if dataOrValue == nil || (dataOrValue.Value == nil && dataOrValue.Blob == nil) {
+			return nil, i18n.NewError(ctx, coremsgs.MsgDataMissing, i)
+		}
+		d, err := dm.validateInputData(ctx, dataOrValue)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgBulkDataItemInvalid, i)
+		}
+		data[i] = d
+	}
+	if err := dm.messageWriter.WriteDataArray(ctx, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UploadProtobuf stores an uploaded protobuf message as the value of a new piece of data,
+// validated against the descriptors of its datatype
+func (dm *dataManager) UploadProtobuf(ctx context.Context, inData *core.DataRefOrValue, payload *ffapi.Multipart) (*core.Data, error) {
+	b, err := io.ReadAll(io.LimitReader(payload.Data, dm.protobufLimit+1))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgBlobStreamingFailed)
+	}
+	if int64(len(b)) > dm.protobufLimit {
+		return nil, i18n.NewError(ctx, coremsgs.MsgProtobufUploadTooLarge, dm.protobufLimit)
+	}
+	inData.Validator = core.ValidatorTypeProtobuf
+	inData.Value = core.BinaryValue(b)
+	return dm.UploadJSON(ctx, inData)
+}
+
 // ResolveInlineData processes an input message that is going to be stored, to see which of the data
 // elements are new, and which are existing. It verifies everything that points to an existing
 // reference, and returns a list of what data is new separately - so that it can be stored by the
@@ -452,6 +567,13 @@ func (dm *dataManager) ResolveInlineData(ctx context.Context, newMessage *NewMes
 		return i18n.NewError(ctx, i18n.MsgNilOrNullObject)
 	}
 
+	if callback := newMessage.Message.Callback; callback != "" {
+		u, err := url.Parse(callback)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return i18n.NewError(ctx, coremsgs.MsgInvalidMessageCallback, callback)
+		}
+	}
+
 	inData := newMessage.Message.InlineData
 	newMessage.AllData = make(core.DataArray, len(newMessage.Message.InlineData))
 	for i, dataOrValue := range inData {
@@ -541,6 +663,7 @@ func (dm *dataManager) WriteNewMessage(ctx context.Context, newMsg *NewMessage)
 
 func (dm *dataManager) WaitStop() {
 	dm.messageWriter.close()
+	dm.migrator.close()
 }
 
 func (dm *dataManager) DeleteData(ctx context.Context, dataID string) error {
@@ -556,6 +679,14 @@ func (dm *dataManager) DeleteData(ctx context.Context, dataID string) error {
 	if data == nil {
 		return i18n.NewError(ctx, coremsgs.Msg404NoResult)
 	}
+
+	// Release any pin of the shared storage copy, so the pinning service no longer retains it
+	if data.Pin != nil && data.Pin.Request != "" && dm.sharedstorage != nil && dm.sharedstorage.Capabilities().Pinning {
+		if err := dm.sharedstorage.UnpinData(ctx, data.Pin); err != nil {
+			return err
+		}
+	}
+
 	if data.Blob != nil && data.Blob.Hash != nil {
 		fb := database.BlobQueryFactory.NewFilter(ctx)
 		blobs, _, err := dm.database.GetBlobs(ctx, dm.namespace.Name, fb.And(fb.Eq("data_id", data.ID), fb.Eq("hash", data.Blob.Hash)))
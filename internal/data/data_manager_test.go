@@ -17,10 +17,12 @@
 package data
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
@@ -31,8 +33,10 @@ import (
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/sharedstorage"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -48,6 +52,7 @@ func TestCacheInitFail(t *testing.T) {
 		Concurrency: true,
 	})
 	mdx := &dataexchangemocks.Plugin{}
+	mps := &sharedstoragemocks.Plugin{}
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
 
 	vErrcmi := &cachemocks.Manager{}
@@ -58,7 +63,7 @@ func TestCacheInitFail(t *testing.T) {
 		ns.Name,
 	)).Return(nil, cacheInitError).Once()
 	defer vErrcmi.AssertExpectations(t)
-	_, err := NewDataManager(ctx, ns, mdi, mdx, vErrcmi)
+	_, err := NewDataManager(ctx, ns, mdi, mdx, mps, vErrcmi, ValidationPolicies{}, "")
 	assert.Equal(t, cacheInitError, err)
 
 	mErrcmi := &cachemocks.Manager{}
@@ -75,7 +80,7 @@ func TestCacheInitFail(t *testing.T) {
 		ns.Name,
 	)).Return(nil, cacheInitError).Once()
 	defer mErrcmi.AssertExpectations(t)
-	_, err = NewDataManager(ctx, ns, mdi, mdx, mErrcmi)
+	_, err = NewDataManager(ctx, ns, mdi, mdx, mps, mErrcmi, ValidationPolicies{}, "")
 	assert.Equal(t, cacheInitError, err)
 }
 
@@ -87,12 +92,14 @@ func newTestDataManager(t *testing.T) (*dataManager, context.Context, func()) {
 	mdi.On("Capabilities").Return(&database.Capabilities{
 		Concurrency: true,
 	})
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil).Maybe()
 	mdx := &dataexchangemocks.Plugin{}
+	mps := &sharedstoragemocks.Plugin{}
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
 
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 10000, 5*time.Minute), nil)
-	dm, err := NewDataManager(ctx, ns, mdi, mdx, cmi)
+	dm, err := NewDataManager(ctx, ns, mdi, mdx, mps, cmi, ValidationPolicies{}, "")
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
 		coreconfig.CacheMessageSize,
@@ -164,9 +171,12 @@ func TestValidateE2E(t *testing.T) {
 		Version:   "0.0.1",
 	}
 	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "customer", "0.0.1").Return(dt, nil)
-	isValid, err := dm.ValidateAll(ctx, core.DataArray{data})
-	assert.Regexp(t, "FF10198", err)
-	assert.False(t, isValid)
+	validation, err := dm.ValidateAll(ctx, core.DataArray{data})
+	assert.NoError(t, err)
+	assert.False(t, validation.Valid)
+	assert.Equal(t, core.ValidationPolicyEnforce, validation.Policy)
+	assert.Equal(t, data.Datatype, validation.Datatype)
+	assert.Regexp(t, "FF10198", validation.Reason)
 
 	v, err := dm.getValidatorForDatatype(ctx, data.Validator, data.Datatype)
 	err = v.Validate(ctx, data)
@@ -177,9 +187,9 @@ func TestValidateE2E(t *testing.T) {
 	err = v.Validate(ctx, data)
 	assert.NoError(t, err)
 
-	isValid, err = dm.ValidateAll(ctx, core.DataArray{data})
+	validation, err = dm.ValidateAll(ctx, core.DataArray{data})
 	assert.NoError(t, err)
-	assert.True(t, isValid)
+	assert.True(t, validation.Valid)
 
 }
 
@@ -276,10 +286,18 @@ func TestWriteNewMessageE2E(t *testing.T) {
 }
 
 func TestInitBadDeps(t *testing.T) {
-	_, err := NewDataManager(context.Background(), &core.Namespace{}, nil, nil, nil)
+	_, err := NewDataManager(context.Background(), &core.Namespace{}, nil, nil, nil, nil, ValidationPolicies{}, "")
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitBadHashAlgorithm(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.HashAlgorithm, "md5")
+	defer coreconfig.Reset()
+	_, err := NewDataManager(context.Background(), &core.Namespace{}, &databasemocks.Plugin{}, nil, nil, nil, ValidationPolicies{}, "")
+	assert.Regexp(t, "FF00172", err)
+}
+
 func TestValidatorLookupCached(t *testing.T) {
 	coreconfig.Reset()
 	dm, ctx, cancel := newTestDataManager(t)
@@ -331,8 +349,9 @@ func TestValidateBadHash(t *testing.T) {
 		Namespace: "0.0.1",
 	}
 	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "customer", "0.0.1").Return(dt, nil).Once()
-	_, err := dm.ValidateAll(ctx, core.DataArray{data})
-	assert.Regexp(t, "FF10201", err)
+	validation, err := dm.ValidateAll(ctx, core.DataArray{data})
+	assert.NoError(t, err)
+	assert.Regexp(t, "FF10201", validation.Reason)
 
 }
 
@@ -444,6 +463,16 @@ func TestCheckDatatypeVerifiesTheSchema(t *testing.T) {
 	assert.Regexp(t, "FF10196", err)
 }
 
+func TestCheckDatatypeProtobuf(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	err := dm.CheckDatatype(ctx, testProtobufDatatype(t, testProtobufFile(), "example.Widget"))
+	assert.NoError(t, err)
+	err = dm.CheckDatatype(ctx, testProtobufDatatype(t, testProtobufFile(), "example.Gadget"))
+	assert.Regexp(t, "FF10540", err)
+}
+
 func TestResolveInlineDataEmpty(t *testing.T) {
 
 	dm, ctx, cancel := newTestDataManager(t)
@@ -468,6 +497,54 @@ func TestResolveInlineDataEmpty(t *testing.T) {
 
 }
 
+func TestResolveInlineDataCallbackOK(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	newMsg := &NewMessage{
+		Message: &core.MessageInOut{
+			Message: core.Message{
+				Header: core.MessageHeader{
+					ID:        fftypes.NewUUID(),
+					Namespace: "ns1",
+				},
+				Callback: "https://example.com/callbacks?app=1",
+			},
+			InlineData: core.InlineData{},
+		},
+	}
+
+	err := dm.ResolveInlineData(ctx, newMsg)
+	assert.NoError(t, err)
+
+}
+
+func TestResolveInlineDataCallbackInvalid(t *testing.T) {
+
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	for _, callback := range []string{"ftp://example.com", "/relative/path", "https://", "::bad"} {
+		newMsg := &NewMessage{
+			Message: &core.MessageInOut{
+				Message: core.Message{
+					Header: core.MessageHeader{
+						ID:        fftypes.NewUUID(),
+						Namespace: "ns1",
+					},
+					Callback: callback,
+				},
+				InlineData: core.InlineData{},
+			},
+		}
+
+		err := dm.ResolveInlineData(ctx, newMsg)
+		assert.Regexp(t, "FF10535", err)
+	}
+
+}
+
 func TestResolveInlineDataRefIDOnlyOK(t *testing.T) {
 	dm, ctx, cancel := newTestDataManager(t)
 	defer cancel()
@@ -601,6 +678,60 @@ func TestResolveInlineDataValueNoValidatorOK(t *testing.T) {
 	assert.NotNil(t, newMsg.AllData[0].Hash)
 }
 
+func TestResolveInlineDataValueHashAlgorithm(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.hashAlgorithm = core.HashAlgorithmSHA3_256
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("UpsertData", ctx, mock.Anything, database.UpsertOptimizationNew).Return(nil)
+
+	_, _, newMsg := testNewMessage()
+	newMsg.Message.InlineData = core.InlineData{
+		{Value: fftypes.JSONAnyPtr(`{"some":"json"}`)},
+	}
+
+	err := dm.ResolveInlineData(ctx, newMsg)
+	assert.NoError(t, err)
+	assert.Equal(t, core.HashAlgorithmSHA3_256, newMsg.AllData[0].HashAlgorithm)
+	expectedHash, _ := core.HashBytes(ctx, core.HashAlgorithmSHA3_256, []byte(`{"some":"json"}`))
+	assert.Equal(t, expectedHash, newMsg.AllData[0].Hash)
+}
+
+func TestResolveInlineDataValueCanonicalization(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.canonicalization = core.DataCanonicalizationJCS
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("UpsertData", ctx, mock.Anything, database.UpsertOptimizationNew).Return(nil)
+
+	_, _, newMsg := testNewMessage()
+	newMsg.Message.InlineData = core.InlineData{
+		{Value: fftypes.JSONAnyPtr(`{"some": "json", "amount": 10.0}`)},
+	}
+
+	err := dm.ResolveInlineData(ctx, newMsg)
+	assert.NoError(t, err)
+	assert.Equal(t, core.DataCanonicalizationJCS, newMsg.AllData[0].Canonicalization)
+	expectedHash, _ := core.HashBytes(ctx, "", []byte(`{"amount":10,"some":"json"}`))
+	assert.Equal(t, expectedHash, newMsg.AllData[0].Hash)
+}
+
+func TestResolveInlineDataValueCanonicalizationFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.canonicalization = core.DataCanonicalizationJCS
+
+	_, _, newMsg := testNewMessage()
+	newMsg.Message.InlineData = core.InlineData{
+		{Value: fftypes.JSONAnyPtr(`{"some":"json","some":"more"}`)},
+	}
+
+	err := dm.ResolveInlineData(ctx, newMsg)
+	assert.Regexp(t, "FF10588", err)
+}
+
 func TestResolveInlineDataValueWithValidation(t *testing.T) {
 	dm, ctx, cancel := newTestDataManager(t)
 	defer cancel()
@@ -692,6 +823,163 @@ func TestUploadJSONLoadInsertDataFail(t *testing.T) {
 	assert.Regexp(t, "FF00154", err)
 }
 
+func TestUploadBulkOk(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("RunAsGroup", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		err := args[1].(func(context.Context) error)(ctx)
+		assert.NoError(t, err)
+	}).Return(nil)
+	mdi.On("InsertDataArray", mock.Anything, mock.MatchedBy(func(data core.DataArray) bool {
+		return len(data) == 2
+	})).Return(nil)
+
+	data, err := dm.UploadBulk(ctx, []*core.DataRefOrValue{
+		{Value: fftypes.JSONAnyPtr(`{"doc":1}`)},
+		{Value: fftypes.JSONAnyPtr(`{"doc":2}`)},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+	assert.NotNil(t, data[0].ID)
+	assert.NotNil(t, data[1].ID)
+	assert.NotEqual(t, *data[0].ID, *data[1].ID)
+	assert.Equal(t, `{"doc":2}`, data[1].Value.String())
+
+	mdi.AssertExpectations(t)
+}
+
+func TestUploadBulkEmpty(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	_, err := dm.UploadBulk(ctx, []*core.DataRefOrValue{})
+	assert.Regexp(t, "FF10592", err)
+}
+
+func TestUploadBulkMissingValue(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	_, err := dm.UploadBulk(ctx, []*core.DataRefOrValue{
+		{Value: fftypes.JSONAnyPtr(`{}`)},
+		{ /* missing */ },
+	})
+	assert.Regexp(t, "FF10205.*1", err)
+}
+
+func TestUploadBulkInvalidItem(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetDatatypeByName", ctx, "ns1", "customer", "0.0.1").Return(nil, fmt.Errorf("pop"))
+	_, err := dm.UploadBulk(ctx, []*core.DataRefOrValue{
+		{
+			Value: fftypes.JSONAnyPtr(`{}`),
+			Datatype: &core.DatatypeRef{
+				Name:    "customer",
+				Version: "0.0.1",
+			},
+		},
+	})
+	assert.Regexp(t, "FF10593.*0.*pop", err)
+}
+
+func TestUploadBulkWriteFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.messageWriter.close()
+	_, err := dm.UploadBulk(ctx, []*core.DataRefOrValue{
+		{Value: fftypes.JSONAnyPtr(`{}`)},
+	})
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestUploadProtobufOk(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "widget", "0.0.1").Return(testProtobufDatatype(t, testProtobufFile(), "example.Widget"), nil)
+	mdi.On("RunAsGroup", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		err := args[1].(func(context.Context) error)(ctx)
+		assert.NoError(t, err)
+	}).Return(nil)
+	mdi.On("InsertDataArray", mock.Anything, mock.Anything).Return(nil)
+
+	payload := []byte{0x0a, 0x07, 'w', 'i', 'd', 'g', 'e', 't', '1'}
+	data, err := dm.UploadProtobuf(ctx, &core.DataRefOrValue{
+		Datatype: &core.DatatypeRef{
+			Name:    "widget",
+			Version: "0.0.1",
+		},
+	}, &ffapi.Multipart{Data: bytes.NewReader(payload)})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ValidatorTypeProtobuf, data.Validator)
+	decoded, err := core.DecodeBinaryValue(ctx, data.Value)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+	mdi.AssertExpectations(t)
+}
+
+func TestUploadProtobufInvalid(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "widget", "0.0.1").Return(testProtobufDatatype(t, testProtobufFile(), "example.Widget"), nil)
+
+	_, err := dm.UploadProtobuf(ctx, &core.DataRefOrValue{
+		Datatype: &core.DatatypeRef{
+			Name:    "widget",
+			Version: "0.0.1",
+		},
+	}, &ffapi.Multipart{Data: bytes.NewReader([]byte{0xff})})
+	assert.Regexp(t, "FF10541", err)
+}
+
+func TestUploadProtobufTooLarge(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.protobufLimit = 2
+
+	_, err := dm.UploadProtobuf(ctx, &core.DataRefOrValue{}, &ffapi.Multipart{Data: bytes.NewReader([]byte{0x01, 0x02, 0x03})})
+	assert.Regexp(t, "FF10544", err)
+}
+
+func TestUploadProtobufReadFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.UploadProtobuf(ctx, &core.DataRefOrValue{}, &ffapi.Multipart{Data: iotest.ErrReader(fmt.Errorf("pop"))})
+	assert.Regexp(t, "FF10217.*pop", err)
+}
+
+func TestValidateAllDatatypeValidatorMismatch(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdi := dm.database.(*databasemocks.Plugin)
+
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "widget", "0.0.1").Return(testProtobufDatatype(t, testProtobufFile(), "example.Widget"), nil)
+
+	validation, err := dm.ValidateAll(ctx, core.DataArray{{
+		Validator: core.ValidatorTypeJSON,
+		Datatype: &core.DatatypeRef{
+			Name:    "widget",
+			Version: "0.0.1",
+		},
+		Value: fftypes.JSONAnyPtr(`{}`),
+	}})
+	assert.NoError(t, err)
+	assert.False(t, validation.Valid)
+	assert.Regexp(t, "FF10195", validation.Reason)
+}
+
+func TestNewValidatorMismatch(t *testing.T) {
+	_, err := newValidator(context.Background(), "ns1", core.ValidatorTypeJSON, testProtobufDatatype(t, testProtobufFile(), "example.Widget"))
+	assert.Regexp(t, "FF10543.*json.*protobuf.*widget", err)
+}
+
 func TestValidateAndStoreLoadNilRef(t *testing.T) {
 	dm, ctx, cancel := newTestDataManager(t)
 	defer cancel()
@@ -825,8 +1113,9 @@ func TestValidateAllStoredValidatorInvalid(t *testing.T) {
 			Version: "0.0.1",
 		},
 	}
-	isValid, err := dm.ValidateAll(ctx, core.DataArray{data})
-	assert.False(t, isValid)
+	validation, err := dm.ValidateAll(ctx, core.DataArray{data})
+	assert.False(t, validation.Valid)
+	assert.Regexp(t, "FF10195", validation.Reason)
 	assert.NoError(t, err)
 	mdi.AssertExpectations(t)
 }
@@ -1379,3 +1668,95 @@ func TestDeleteDataFailGetMessages(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 	mdb.AssertExpectations(t)
 }
+
+func TestDeleteDataUnpin(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mps := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+
+	dataID := fftypes.NewUUID()
+	pin := &core.DataPin{Status: core.DataPinStatusPinned, Request: "pin1"}
+	data := &core.Data{
+		ID:        dataID,
+		Namespace: dm.namespace.Name,
+		Public:    "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
+		Pin:       pin,
+	}
+
+	mdb.On("GetDataByID", ctx, dm.namespace.Name, dataID, false).Return(data, nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("UnpinData", ctx, pin).Return(nil)
+	mdb.On("GetMessagesForData", ctx, dm.namespace.Name, dataID, mock.Anything).Return([]*core.Message{}, &ffapi.FilterResult{}, nil)
+	mdb.On("DeleteData", ctx, dm.namespace.Name, dataID).Return(nil)
+
+	err := dm.DeleteData(ctx, dataID.String())
+	assert.NoError(t, err)
+
+	mdb.AssertExpectations(t)
+	mps.AssertExpectations(t)
+}
+
+func TestDeleteDataPinningDisabled(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mps := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+
+	dataID := fftypes.NewUUID()
+	data := &core.Data{
+		ID:        dataID,
+		Namespace: dm.namespace.Name,
+		Pin:       &core.DataPin{Status: core.DataPinStatusPinned, Request: "pin1"},
+	}
+
+	mdb.On("GetDataByID", ctx, dm.namespace.Name, dataID, false).Return(data, nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{})
+	mdb.On("GetMessagesForData", ctx, dm.namespace.Name, dataID, mock.Anything).Return([]*core.Message{}, &ffapi.FilterResult{}, nil)
+	mdb.On("DeleteData", ctx, dm.namespace.Name, dataID).Return(nil)
+
+	err := dm.DeleteData(ctx, dataID.String())
+	assert.NoError(t, err)
+
+	mdb.AssertExpectations(t)
+	mps.AssertExpectations(t)
+}
+
+func TestDeleteDataUnpinFail(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	mdb := dm.database.(*databasemocks.Plugin)
+	mps := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+
+	dataID := fftypes.NewUUID()
+	pin := &core.DataPin{Status: core.DataPinStatusQueued, Request: "pin1"}
+	data := &core.Data{
+		ID:        dataID,
+		Namespace: dm.namespace.Name,
+		Pin:       pin,
+	}
+
+	mdb.On("GetDataByID", ctx, dm.namespace.Name, dataID, false).Return(data, nil)
+	mps.On("Capabilities").Return(&sharedstorage.Capabilities{Pinning: true})
+	mps.On("UnpinData", ctx, pin).Return(fmt.Errorf("pop"))
+
+	err := dm.DeleteData(ctx, dataID.String())
+	assert.Regexp(t, "pop", err)
+
+	mdb.AssertExpectations(t)
+	mps.AssertExpectations(t)
+}
+
+func TestMigrateDatatypeQueued(t *testing.T) {
+	ctx := context.Background()
+	dm := &dataManager{
+		migrator: newDatatypeMigrator(ctx, "ns1", &databasemocks.Plugin{}, &datatypeMigratorConf{}),
+	}
+
+	dm.MigrateDatatype(ctx, &core.Datatype{Name: "customer", Version: "1.0.0"})
+	assert.Empty(t, dm.migrator.popPending())
+
+	dt := &core.Datatype{Name: "customer", Version: "2.0.0", Migration: &core.DatatypeMigration{FromVersion: "1.0.0"}}
+	dm.MigrateDatatype(ctx, dt)
+	assert.Equal(t, []*core.Datatype{dt}, dm.migrator.popPending())
+}
@@ -0,0 +1,171 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const inferredSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// InferDatatype generates a JSON Schema datatype that the sample payload conforms to.
+// Every property in the sample is required, and array items must match the combined schema of
+// the items in the sample - so the result is a starting point to refine, rather than a final schema.
+func (dm *dataManager) InferDatatype(ctx context.Context, input *core.DatatypeInferInput) (*core.Datatype, error) {
+	if input.Sample == nil || len(*input.Sample) == 0 {
+		return nil, i18n.NewError(ctx, i18n.MsgMissingRequiredField, "sample")
+	}
+
+	// Decode numbers as strings, so we can tell integers and decimals apart
+	var sample interface{}
+	decoder := json.NewDecoder(strings.NewReader(input.Sample.String()))
+	decoder.UseNumber()
+	if err := decoder.Decode(&sample); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgJSONDecodeFailed)
+	}
+
+	schema := fftypes.JSONObject{"$schema": inferredSchemaDraft}
+	for k, v := range inferSchema(sample) {
+		schema[k] = v
+	}
+	return &core.Datatype{
+		Validator: core.ValidatorTypeJSON,
+		Namespace: dm.namespace.Name,
+		Name:      input.Name,
+		Version:   input.Version,
+		Value:     fftypes.JSONAnyPtr(schema.String()),
+	}, nil
+}
+
+func inferSchema(value interface{}) fftypes.JSONObject {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		properties := fftypes.JSONObject{}
+		required := make([]string, 0, len(v))
+		for name, propValue := range v {
+			properties[name] = inferSchema(propValue)
+			required = append(required, name)
+		}
+		sort.Strings(required)
+		return objectSchema(properties, required)
+	case []interface{}:
+		schema := fftypes.JSONObject{"type": "array"}
+		var items fftypes.JSONObject
+		for i, item := range v {
+			if i == 0 {
+				items = inferSchema(item)
+			} else {
+				items = mergeSchemas(items, inferSchema(item))
+			}
+		}
+		if items != nil {
+			schema["items"] = items
+		}
+		return schema
+	case string:
+		return fftypes.JSONObject{"type": "string"}
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return fftypes.JSONObject{"type": "number"}
+		}
+		return fftypes.JSONObject{"type": "integer"}
+	case bool:
+		return fftypes.JSONObject{"type": "boolean"}
+	default:
+		return fftypes.JSONObject{"type": "null"}
+	}
+}
+
+func objectSchema(properties fftypes.JSONObject, required []string) fftypes.JSONObject {
+	schema := fftypes.JSONObject{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// mergeSchemas combines the schemas of two values found in the same place in the sample,
+// such as two items in an array. Properties are only required if both objects have them,
+// and values of different types are allowed to be anything.
+func mergeSchemas(a, b fftypes.JSONObject) fftypes.JSONObject {
+	typeA, _ := a["type"].(string)
+	typeB, _ := b["type"].(string)
+	switch {
+	case typeA == "object" && typeB == "object":
+		return mergeObjectSchemas(a, b)
+	case typeA == "array" && typeB == "array":
+		itemsA, okA := a["items"].(fftypes.JSONObject)
+		itemsB, okB := b["items"].(fftypes.JSONObject)
+		switch {
+		case okA && okB:
+			return fftypes.JSONObject{"type": "array", "items": mergeSchemas(itemsA, itemsB)}
+		case okB:
+			return b
+		default:
+			return a
+		}
+	case typeA == typeB:
+		return a
+	case (typeA == "integer" && typeB == "number") || (typeA == "number" && typeB == "integer"):
+		return fftypes.JSONObject{"type": "number"}
+	default:
+		return fftypes.JSONObject{}
+	}
+}
+
+func mergeObjectSchemas(a, b fftypes.JSONObject) fftypes.JSONObject {
+	propsA, _ := a["properties"].(fftypes.JSONObject)
+	propsB, _ := b["properties"].(fftypes.JSONObject)
+	properties := fftypes.JSONObject{}
+	for name, propA := range propsA {
+		if propB, ok := propsB[name]; ok {
+			properties[name] = mergeSchemas(propA.(fftypes.JSONObject), propB.(fftypes.JSONObject))
+		} else {
+			properties[name] = propA
+		}
+	}
+	for name, propB := range propsB {
+		if _, ok := propsA[name]; !ok {
+			properties[name] = propB
+		}
+	}
+
+	requiredA, _ := a["required"].([]string)
+	requiredB, _ := b["required"].([]string)
+	inB := make(map[string]bool, len(requiredB))
+	for _, name := range requiredB {
+		inB[name] = true
+	}
+	required := make([]string, 0, len(requiredA))
+	for _, name := range requiredA {
+		if inB[name] {
+			required = append(required, name)
+		}
+	}
+	return objectSchema(properties, required)
+}
@@ -0,0 +1,125 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferDatatype(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	sample := fftypes.JSONAnyPtr(`{
+		"name": "widget",
+		"count": 12,
+		"price": 1.5,
+		"big": 123456789012345678901234567890,
+		"active": true,
+		"notes": null,
+		"tags": ["a", "b"],
+		"empty": [],
+		"lines": [
+			{"sku": "A1", "qty": 1, "discount": 0},
+			{"sku": "B2", "qty": 2.5, "gift": true}
+		],
+		"mixed": [1, "two"],
+		"nested": [[], [1], [2.5]],
+		"nestedReverse": [[1], []]
+	}`)
+	dt, err := dm.InferDatatype(ctx, &core.DatatypeInferInput{
+		Name:    "widget",
+		Version: "0.0.1",
+		Sample:  sample,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ValidatorTypeJSON, dt.Validator)
+	assert.Equal(t, "ns1", dt.Namespace)
+	assert.Equal(t, "widget", dt.Name)
+	assert.Equal(t, "0.0.1", dt.Version)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"count": {"type": "integer"},
+			"price": {"type": "number"},
+			"big": {"type": "integer"},
+			"active": {"type": "boolean"},
+			"notes": {"type": "null"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"empty": {"type": "array"},
+			"lines": {"type": "array", "items": {
+				"type": "object",
+				"properties": {
+					"sku": {"type": "string"},
+					"qty": {"type": "number"},
+					"discount": {"type": "integer"},
+					"gift": {"type": "boolean"}
+				},
+				"required": ["qty", "sku"]
+			}},
+			"mixed": {"type": "array", "items": {}},
+			"nested": {"type": "array", "items": {"type": "array", "items": {"type": "number"}}},
+			"nestedReverse": {"type": "array", "items": {"type": "array", "items": {"type": "integer"}}}
+		},
+		"required": ["active", "big", "count", "empty", "lines", "mixed", "name", "nested", "nestedReverse", "notes", "price", "tags"]
+	}`, dt.Value.String())
+
+	// The sample must conform to the schema we generated
+	jv, err := newJSONValidator(ctx, "ns1", dt)
+	assert.NoError(t, err)
+	err = jv.ValidateValue(ctx, sample, nil)
+	assert.NoError(t, err)
+}
+
+func TestInferDatatypeEmptyObject(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	dt, err := dm.InferDatatype(ctx, &core.DatatypeInferInput{
+		Sample: fftypes.JSONAnyPtr(`[{}, {"a": "b"}]`),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "array",
+		"items": {"type": "object", "properties": {"a": {"type": "string"}}}
+	}`, dt.Value.String())
+}
+
+func TestInferDatatypeMissingSample(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.InferDatatype(ctx, &core.DatatypeInferInput{})
+	assert.Regexp(t, "FF00112.*sample", err)
+}
+
+func TestInferDatatypeBadSample(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+
+	_, err := dm.InferDatatype(ctx, &core.DatatypeInferInput{
+		Sample: fftypes.JSONAnyPtr(`{!`),
+	})
+	assert.Regexp(t, "FF10103", err)
+}
@@ -0,0 +1,204 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// datatypeMigrator moves existing data to reference a new version of a datatype, when that version
+// is confirmed with a migration registered from an earlier version.
+//
+// Migrations run eagerly on a single background routine. Only data that is valid against the new
+// version is moved, and each migrated data item records the version it was moved from.
+// Any migrations registered on existing datatypes are resumed each time the migrator starts,
+// so a restart part way through a migration does not leave data behind.
+type datatypeMigrator struct {
+	ctx        context.Context
+	cancelFunc func()
+	namespace  string
+	database   database.Plugin
+	pageSize   int
+	retry      *retry.Retry
+	mux        sync.Mutex
+	pending    []*core.Datatype
+	kick       chan bool
+	done       chan struct{}
+	started    bool
+}
+
+type datatypeMigratorConf struct {
+	pageSize int
+	retry    *retry.Retry
+}
+
+func newDatatypeMigrator(ctx context.Context, ns string, di database.Plugin, conf *datatypeMigratorConf) *datatypeMigrator {
+	dtm := &datatypeMigrator{
+		namespace: ns,
+		database:  di,
+		pageSize:  conf.pageSize,
+		retry:     conf.retry,
+		kick:      make(chan bool, 1),
+		done:      make(chan struct{}),
+	}
+	dtm.ctx, dtm.cancelFunc = context.WithCancel(ctx)
+	return dtm
+}
+
+func (dtm *datatypeMigrator) start() {
+	dtm.started = true
+	go dtm.migrationLoop()
+}
+
+func (dtm *datatypeMigrator) close() {
+	dtm.cancelFunc()
+	if dtm.started {
+		<-dtm.done
+	}
+}
+
+// queue adds a migration to be run in the background, without blocking the caller
+func (dtm *datatypeMigrator) queue(datatype *core.Datatype) {
+	dtm.mux.Lock()
+	dtm.pending = append(dtm.pending, datatype)
+	dtm.mux.Unlock()
+	select {
+	case dtm.kick <- true:
+	default:
+	}
+}
+
+func (dtm *datatypeMigrator) popPending() []*core.Datatype {
+	dtm.mux.Lock()
+	defer dtm.mux.Unlock()
+	pending := dtm.pending
+	dtm.pending = nil
+	return pending
+}
+
+func (dtm *datatypeMigrator) migrationLoop() {
+	defer close(dtm.done)
+
+	err := dtm.retry.Do(dtm.ctx, "resume datatype migrations", func(attempt int) (retry bool, err error) {
+		fb := database.DatatypeQueryFactory.NewFilter(dtm.ctx)
+		datatypes, _, err := dtm.database.GetDatatypes(dtm.ctx, dtm.namespace, fb.Neq("migration.fromversion", ""))
+		if err != nil {
+			return true, err
+		}
+		for _, datatype := range datatypes {
+			dtm.queue(datatype)
+		}
+		return false, nil
+	})
+	if err != nil {
+		log.L(dtm.ctx).Debugf("Datatype migrator exiting: %s", err)
+		return
+	}
+
+	for {
+		select {
+		case <-dtm.kick:
+			for _, datatype := range dtm.popPending() {
+				if err := dtm.migrateDatatype(datatype); err != nil {
+					log.L(dtm.ctx).Debugf("Datatype migrator exiting: %s", err)
+					return
+				}
+			}
+		case <-dtm.ctx.Done():
+			log.L(dtm.ctx).Debugf("Datatype migrator exiting")
+			return
+		}
+	}
+}
+
+// migrateDatatype only returns an error if the context is closed - a datatype that cannot
+// be used for validation is logged and skipped
+func (dtm *datatypeMigrator) migrateDatatype(datatype *core.Datatype) error {
+	ctx := dtm.ctx
+	from := datatype.Migration.FromVersion
+	v, err := newValidator(ctx, dtm.namespace, datatype.Validator, datatype)
+	if err != nil {
+		log.L(ctx).Errorf("Unable to migrate data from %s:%s to %s:%s: %s", datatype.Name, from, datatype.Name, datatype.Version, err)
+		return nil
+	}
+
+	migrated := 0
+	invalid := 0
+	for {
+		var pageMigrated, pageInvalid int
+		var more bool
+		err := dtm.retry.Do(ctx, "datatype migration", func(attempt int) (retry bool, err error) {
+			pageMigrated, pageInvalid, more, err = dtm.migratePage(ctx, datatype, v, invalid)
+			return true, err
+		})
+		if err != nil {
+			return err
+		}
+		migrated += pageMigrated
+		invalid += pageInvalid
+		if !more {
+			break
+		}
+	}
+	log.L(ctx).Infof("Migrated %d data items from %s:%s to %s:%s (%d not valid against the new version)", migrated, datatype.Name, from, datatype.Name, datatype.Version, invalid)
+	return nil
+}
+
+// migratePage moves a page of data that is still on the old version. Data that is migrated drops out of the
+// query, so the page only needs to skip past data that was found to be invalid against the new version.
+func (dtm *datatypeMigrator) migratePage(ctx context.Context, datatype *core.Datatype, v Validator, skip int) (migrated, invalid int, more bool, err error) {
+	fb := database.DataQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("datatype.name", datatype.Name),
+		fb.Eq("datatype.version", datatype.Migration.FromVersion),
+	).Sort("created").Skip(uint64(skip)).Limit(uint64(dtm.pageSize))
+	page, _, err := dtm.database.GetData(ctx, dtm.namespace, filter)
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	for _, d := range page {
+		validator := d.Validator
+		if validator != core.ValidatorTypeNone {
+			// The hash of data with a blob attachment covers the blob too, so only the value is checked here
+			if err := v.ValidateValue(ctx, d.Value, nil); err != nil {
+				log.L(ctx).Warnf("Data %s is not valid against datatype %s:%s and has not been migrated: %s", d.ID, datatype.Name, datatype.Version, err)
+				invalid++
+				continue
+			}
+			validator = datatype.Validator
+		}
+		update := database.DataQueryFactory.NewUpdate(ctx).
+			Set("validator", validator).
+			Set("datatype.version", datatype.Version).
+			Set("migration.fromversion", datatype.Migration.FromVersion).
+			Set("migration.datatype", datatype.ID).
+			Set("migration.migrated", fftypes.Now())
+		if err := dtm.database.UpdateData(ctx, dtm.namespace, d.ID, update); err != nil {
+			return 0, 0, false, err
+		}
+		migrated++
+	}
+	return migrated, invalid, len(page) == dtm.pageSize, nil
+}
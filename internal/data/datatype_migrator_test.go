@@ -0,0 +1,200 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestDatatypeMigrator(t *testing.T) (*datatypeMigrator, *databasemocks.Plugin) {
+	mdi := &databasemocks.Plugin{}
+	dtm := newDatatypeMigrator(context.Background(), "ns1", mdi, &datatypeMigratorConf{
+		pageSize: 2,
+		retry: &retry.Retry{
+			InitialDelay: 1 * time.Microsecond,
+			MaximumDelay: 1 * time.Microsecond,
+		},
+	})
+	return dtm, mdi
+}
+
+func testMigrationDatatype() *core.Datatype {
+	return &core.Datatype{
+		ID:        fftypes.NewUUID(),
+		Validator: core.ValidatorTypeJSON,
+		Namespace: "ns1",
+		Name:      "customer",
+		Version:   "2.0.0",
+		Value: fftypes.JSONAnyPtr(`{
+			"type": "object",
+			"properties": {
+				"id": { "type": "number" }
+			},
+			"required": ["id"]
+		}`),
+		Migration: &core.DatatypeMigration{
+			FromVersion: "1.0.0",
+		},
+	}
+}
+
+func TestDatatypeMigrationResumeOk(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+	dt := testMigrationDatatype()
+
+	valid1 := &core.Data{ID: fftypes.NewUUID(), Validator: core.ValidatorTypeJSON, Value: fftypes.JSONAnyPtr(`{"id":1}`)}
+	invalid := &core.Data{ID: fftypes.NewUUID(), Validator: core.ValidatorTypeJSON, Value: fftypes.JSONAnyPtr(`{"id":"wrong"}`)}
+	unvalidated := &core.Data{ID: fftypes.NewUUID(), Validator: core.ValidatorTypeNone, Value: fftypes.JSONAnyPtr(`"anything"`)}
+
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{dt}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi, _ := filter.Finalize()
+		return fi.Skip == 0
+	})).Return(core.DataArray{valid1, invalid}, nil, nil).Once()
+	mdi.On("GetData", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi, _ := filter.Finalize()
+		return fi.Skip == 1 && fi.Limit == 2
+	})).Return(core.DataArray{unvalidated}, nil, nil).Once()
+	mdi.On("UpdateData", mock.Anything, "ns1", valid1.ID, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		validator, _ := info.SetOperations[0].Value.Value()
+		return len(info.SetOperations) == 5 && validator == "json"
+	})).Return(nil).Once()
+	updated := make(chan struct{})
+	mdi.On("UpdateData", mock.Anything, "ns1", unvalidated.ID, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		validator, _ := info.SetOperations[0].Value.Value()
+		version, _ := info.SetOperations[1].Value.Value()
+		return validator == "none" && version == "2.0.0"
+	})).Run(func(args mock.Arguments) {
+		close(updated)
+	}).Return(nil).Once()
+
+	dtm.start()
+	<-updated
+	dtm.close()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDatatypeMigrationQueueOk(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+	dt := testMigrationDatatype()
+
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	migrated := make(chan bool, 2)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Run(func(args mock.Arguments) {
+		migrated <- true
+	}).Return(core.DataArray{}, nil, nil).Twice()
+
+	dtm.queue(dt)
+	dtm.queue(dt) // second kick does not block
+	dtm.start()
+	<-migrated
+	<-migrated
+	dtm.close()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDatatypeMigrationResumeFailClose(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		dtm.cancelFunc()
+	})
+
+	dtm.start()
+	dtm.close()
+}
+
+func TestDatatypeMigrationFailClose(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+	dt := testMigrationDatatype()
+
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{dt}, nil, nil)
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		dtm.cancelFunc()
+	})
+
+	dtm.start()
+	dtm.close()
+}
+
+func TestDatatypeMigrationCloseNotStarted(t *testing.T) {
+	dtm, _ := newTestDatatypeMigrator(t)
+	dtm.close()
+}
+
+func TestMigrateDatatypeBadValidator(t *testing.T) {
+	dtm, _ := newTestDatatypeMigrator(t)
+	dt := testMigrationDatatype()
+	dt.Value = fftypes.JSONAnyPtr(`!json`)
+
+	err := dtm.migrateDatatype(dt)
+	assert.NoError(t, err)
+}
+
+func TestMigratePageUpdateFail(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+	dt := testMigrationDatatype()
+	v, err := newValidator(dtm.ctx, "ns1", dt.Validator, dt)
+	assert.NoError(t, err)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Validator: core.ValidatorTypeJSON, Value: fftypes.JSONAnyPtr(`{"id":1}`)}
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(core.DataArray{data}, nil, nil)
+	mdi.On("UpdateData", mock.Anything, "ns1", data.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, _, _, err = dtm.migratePage(dtm.ctx, dt, v, 0)
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDatatypeMigrationCloseIdle(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+
+	resumed := make(chan struct{})
+	mdi.On("GetDatatypes", mock.Anything, "ns1", mock.Anything).Return([]*core.Datatype{}, nil, nil).Run(func(args mock.Arguments) {
+		close(resumed)
+	})
+
+	dtm.start()
+	<-resumed
+	dtm.close()
+}
+
+func TestMigrateDatatypeQueryFailClosed(t *testing.T) {
+	dtm, mdi := newTestDatatypeMigrator(t)
+	dtm.cancelFunc()
+
+	mdi.On("GetData", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := dtm.migrateDatatype(testMigrationDatatype())
+	assert.Regexp(t, "FF00154", err)
+}
@@ -64,7 +64,7 @@ func newJSONValidator(ctx context.Context, ns string, datatype *core.Datatype) (
 	jv.schema = schema
 	jv.size = int64(len(schemaBytes))
 
-	log.L(ctx).Debugf("Found JSON schema validator for json:%s:%s: %v", jv.ns, datatype, jv.id)
+	log.L(ctx).Debugf("Found JSON schema validator for json:%s:%s: %v", jv.ns, jv.datatype, jv.id)
 	return jv, nil
 }
 
@@ -139,6 +139,28 @@ func (mw *messageWriter) WriteData(ctx context.Context, data *core.Data) error {
 	return mw.database.UpsertData(ctx, data, database.UpsertOptimizationNew)
 }
 
+// WriteDataArray writes a set of new data items atomically - either all of them are stored, or none are
+func (mw *messageWriter) WriteDataArray(ctx context.Context, data core.DataArray) error {
+	if mw.conf.workerCount > 0 {
+		// Dispatch to background worker, which writes the whole request in a single database group
+		nmi := &writeRequest{
+			id:      data[0].ID,
+			newData: data,
+			result:  make(chan error),
+		}
+		select {
+		case mw.workQueue <- nmi:
+		case <-mw.ctx.Done():
+			return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+		return <-nmi.result
+	}
+	// Otherwise do it in-line on this context
+	return mw.database.RunAsGroup(ctx, func(ctx context.Context) error {
+		return mw.database.InsertDataArray(ctx, data)
+	})
+}
+
 func (mw *messageWriter) start() {
 	if mw.conf.workerCount > 0 {
 		mw.workQueue = make(chan *writeRequest)
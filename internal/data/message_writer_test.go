@@ -71,6 +71,13 @@ func TestWriteDataClosed(t *testing.T) {
 	assert.Regexp(t, "FF00154", err)
 }
 
+func TestWriteDataArrayClosed(t *testing.T) {
+	mw := newTestMessageWriter(t)
+	mw.close()
+	err := mw.WriteDataArray(mw.ctx, core.DataArray{{ID: fftypes.NewUUID()}})
+	assert.Regexp(t, "FF00154", err)
+}
+
 func TestWriteNewMessageSyncFallback(t *testing.T) {
 	mw := newTestMessageWriterNoConcurrency(t)
 	customCtx := context.WithValue(context.Background(), "dbtx", "on this context")
@@ -114,6 +121,28 @@ func TestWriteDataSyncFallback(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestWriteDataArraySyncFallback(t *testing.T) {
+	mw := newTestMessageWriterNoConcurrency(t)
+	customCtx := context.WithValue(context.Background(), "dbtx", "on this context")
+
+	data := core.DataArray{
+		{ID: fftypes.NewUUID()},
+		{ID: fftypes.NewUUID()},
+	}
+
+	mdi := mw.database.(*databasemocks.Plugin)
+	mdi.On("RunAsGroup", customCtx, mock.Anything).Run(func(args mock.Arguments) {
+		err := args[1].(func(context.Context) error)(customCtx)
+		assert.NoError(t, err)
+	}).Return(nil)
+	mdi.On("InsertDataArray", customCtx, data).Return(nil)
+
+	err := mw.WriteDataArray(customCtx, data)
+
+	assert.NoError(t, err)
+	mdi.AssertExpectations(t)
+}
+
 func TestWriteMessagesInsertMessagesFail(t *testing.T) {
 	mw := newTestMessageWriterNoConcurrency(t)
 
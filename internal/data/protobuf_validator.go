@@ -0,0 +1,148 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// protobufSchema is the value of a protobuf datatype - a serialized google.protobuf.FileDescriptorSet
+// (as produced by "protoc --include_imports --descriptor_set_out"), and the full name of the message
+// type within it that the data must conform to
+type protobufSchema struct {
+	DescriptorSet []byte `json:"descriptorSet"`
+	Message       string `json:"message"`
+}
+
+type protobufValidator struct {
+	id       *fftypes.UUID
+	size     int64
+	ns       string
+	datatype *core.DatatypeRef
+	message  protoreflect.MessageDescriptor
+}
+
+func newProtobufValidator(ctx context.Context, ns string, datatype *core.Datatype) (*protobufValidator, error) {
+	pv := &protobufValidator{
+		id: datatype.ID,
+		ns: ns,
+		datatype: &core.DatatypeRef{
+			Name:    datatype.Name,
+			Version: datatype.Version,
+		},
+	}
+
+	var schema protobufSchema
+	if err := datatype.Value.Unmarshal(ctx, &schema); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSchemaLoadFailed, pv.datatype)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(schema.DescriptorSet, &fds); err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSchemaLoadFailed, pv.datatype)
+	}
+	files, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSchemaLoadFailed, pv.datatype)
+	}
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(schema.Message))
+	message, isMessage := desc.(protoreflect.MessageDescriptor)
+	if err != nil || !isMessage {
+		return nil, i18n.NewError(ctx, coremsgs.MsgProtobufMessageNotFound, schema.Message, pv.datatype)
+	}
+	pv.message = message
+	pv.size = datatype.Value.Length()
+
+	log.L(ctx).Debugf("Found protobuf validator for protobuf:%s:%s: %v (%s)", pv.ns, pv.datatype, pv.id, message.FullName())
+	return pv, nil
+}
+
+func (pv *protobufValidator) Validate(ctx context.Context, data *core.Data) error {
+	return pv.ValidateValue(ctx, data.Value, data.Hash)
+}
+
+func (pv *protobufValidator) ValidateValue(ctx context.Context, value *fftypes.JSONAny, expectedHash *fftypes.Bytes32) error {
+	if value == nil {
+		return i18n.NewError(ctx, coremsgs.MsgDataValueIsNull)
+	}
+
+	if expectedHash != nil {
+		hash := value.Hash()
+		if *hash != *expectedHash {
+			return i18n.NewError(ctx, coremsgs.MsgDataInvalidHash, hash, expectedHash)
+		}
+	}
+
+	payload, err := core.DecodeBinaryValue(ctx, value)
+	if err != nil {
+		return err
+	}
+	msg := dynamicpb.NewMessage(pv.message)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		log.L(ctx).Warnf("Protobuf %s [%v] validation failed: %s", pv.datatype, pv.id, err)
+		return i18n.NewError(ctx, coremsgs.MsgProtobufDataInvalid, pv.message.FullName(), pv.datatype, err)
+	}
+	// Fields that are not in the descriptor are retained as unknown fields by the decoder,
+	// rather than causing a failure, so we check for them explicitly
+	if hasUnknownFields(msg) {
+		log.L(ctx).Warnf("Protobuf %s [%v] validation failed: unknown fields", pv.datatype, pv.id)
+		return i18n.NewError(ctx, coremsgs.MsgProtobufDataUnknownFields, pv.message.FullName(), pv.datatype)
+	}
+	return nil
+}
+
+func (pv *protobufValidator) Size() int64 {
+	return pv.size
+}
+
+func hasUnknownFields(m protoreflect.Message) bool {
+	if len(m.GetUnknown()) > 0 {
+		return true
+	}
+	found := false
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+					found = hasUnknownFields(mv.Message())
+					return !found
+				})
+			}
+		case fd.IsList():
+			if fd.Message() != nil {
+				for i := 0; i < v.List().Len() && !found; i++ {
+					found = hasUnknownFields(v.List().Get(i).Message())
+				}
+			}
+		case fd.Message() != nil:
+			found = hasUnknownFields(v.Message())
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,234 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+func testProtobufFile() *descriptorpb.FileDescriptorProto {
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum()
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	stringType := descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum()
+	int64Type := descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()
+	messageType := descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("widget.proto"),
+		Package: proto.String("example"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Part"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("id"), Number: proto.Int32(1), Label: optional, Type: stringType},
+				},
+			},
+			{
+				Name: proto.String("Widget"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					{Name: proto.String("name"), Number: proto.Int32(1), Label: optional, Type: stringType},
+					{Name: proto.String("count"), Number: proto.Int32(2), Label: optional, Type: int64Type},
+					{Name: proto.String("parts"), Number: proto.Int32(3), Label: repeated, Type: messageType, TypeName: proto.String(".example.Part")},
+					{Name: proto.String("main"), Number: proto.Int32(4), Label: optional, Type: messageType, TypeName: proto.String(".example.Part")},
+					{Name: proto.String("labels"), Number: proto.Int32(5), Label: repeated, Type: messageType, TypeName: proto.String(".example.Widget.LabelsEntry")},
+					{Name: proto.String("tags"), Number: proto.Int32(6), Label: repeated, Type: stringType},
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("LabelsEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							{Name: proto.String("key"), Number: proto.Int32(1), Label: optional, Type: stringType},
+							{Name: proto.String("value"), Number: proto.Int32(2), Label: optional, Type: messageType, TypeName: proto.String(".example.Part")},
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testProtobufDatatype(t *testing.T, file *descriptorpb.FileDescriptorProto, message string) *core.Datatype {
+	fds, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{file}})
+	assert.NoError(t, err)
+	schema, err := json.Marshal(&protobufSchema{DescriptorSet: fds, Message: message})
+	assert.NoError(t, err)
+	return &core.Datatype{
+		ID:        fftypes.NewUUID(),
+		Validator: core.ValidatorTypeProtobuf,
+		Name:      "widget",
+		Version:   "0.0.1",
+		Value:     fftypes.JSONAnyPtrBytes(schema),
+	}
+}
+
+func testProtobufPart(t *testing.T, pv *protobufValidator, id string, unknown bool) []byte {
+	md := pv.message.Fields().ByName("main").Message()
+	part := dynamicpb.NewMessage(md)
+	part.Set(md.Fields().ByName("id"), protoreflect.ValueOfString(id))
+	b, err := proto.Marshal(part)
+	assert.NoError(t, err)
+	if unknown {
+		b = protowire.AppendTag(b, 99, protowire.VarintType)
+		b = protowire.AppendVarint(b, 1)
+	}
+	return b
+}
+
+func TestProtobufValidator(t *testing.T) {
+	dt := testProtobufDatatype(t, testProtobufFile(), "example.Widget")
+	pv, err := newProtobufValidator(context.Background(), "ns1", dt)
+	assert.NoError(t, err)
+	assert.Equal(t, dt.Value.Length(), pv.Size())
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, "widget1")
+	b = protowire.AppendTag(b, 2, protowire.VarintType)
+	b = protowire.AppendVarint(b, 10)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	b = protowire.AppendBytes(b, testProtobufPart(t, pv, "part1", false))
+	b = protowire.AppendTag(b, 4, protowire.BytesType)
+	b = protowire.AppendBytes(b, testProtobufPart(t, pv, "part2", false))
+	var entry []byte
+	entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+	entry = protowire.AppendString(entry, "label1")
+	entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, testProtobufPart(t, pv, "part3", false))
+	b = protowire.AppendTag(b, 5, protowire.BytesType)
+	b = protowire.AppendBytes(b, entry)
+	b = protowire.AppendTag(b, 6, protowire.BytesType)
+	b = protowire.AppendString(b, "tag1")
+
+	value := core.BinaryValue(b)
+	err = pv.Validate(context.Background(), &core.Data{Value: value, Hash: value.Hash()})
+	assert.NoError(t, err)
+}
+
+func TestProtobufValidatorUnknownFields(t *testing.T) {
+	dt := testProtobufDatatype(t, testProtobufFile(), "example.Widget")
+	pv, err := newProtobufValidator(context.Background(), "ns1", dt)
+	assert.NoError(t, err)
+
+	var topLevel []byte
+	topLevel = protowire.AppendTag(topLevel, 99, protowire.VarintType)
+	topLevel = protowire.AppendVarint(topLevel, 1)
+
+	var inMessage []byte
+	inMessage = protowire.AppendTag(inMessage, 4, protowire.BytesType)
+	inMessage = protowire.AppendBytes(inMessage, testProtobufPart(t, pv, "part1", true))
+
+	var inList []byte
+	inList = protowire.AppendTag(inList, 3, protowire.BytesType)
+	inList = protowire.AppendBytes(inList, testProtobufPart(t, pv, "part1", false))
+	inList = protowire.AppendTag(inList, 3, protowire.BytesType)
+	inList = protowire.AppendBytes(inList, testProtobufPart(t, pv, "part2", true))
+
+	var entry []byte
+	entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+	entry = protowire.AppendString(entry, "label1")
+	entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+	entry = protowire.AppendBytes(entry, testProtobufPart(t, pv, "part3", true))
+	var inMap []byte
+	inMap = protowire.AppendTag(inMap, 5, protowire.BytesType)
+	inMap = protowire.AppendBytes(inMap, entry)
+
+	for _, b := range [][]byte{topLevel, inMessage, inList, inMap} {
+		err = pv.ValidateValue(context.Background(), core.BinaryValue(b), nil)
+		assert.Regexp(t, "FF10542.*example.Widget", err)
+	}
+}
+
+func TestProtobufValidatorBadPayload(t *testing.T) {
+	dt := testProtobufDatatype(t, testProtobufFile(), "example.Widget")
+	pv, err := newProtobufValidator(context.Background(), "ns1", dt)
+	assert.NoError(t, err)
+
+	err = pv.ValidateValue(context.Background(), core.BinaryValue([]byte{0xff}), nil)
+	assert.Regexp(t, "FF10541.*example.Widget", err)
+}
+
+func TestProtobufValidatorNotBinary(t *testing.T) {
+	dt := testProtobufDatatype(t, testProtobufFile(), "example.Widget")
+	pv, err := newProtobufValidator(context.Background(), "ns1", dt)
+	assert.NoError(t, err)
+
+	err = pv.ValidateValue(context.Background(), fftypes.JSONAnyPtr(`{"some":"json"}`), nil)
+	assert.Regexp(t, "FF10539", err)
+}
+
+func TestProtobufValidatorBadHash(t *testing.T) {
+	v := &protobufValidator{}
+	err := v.ValidateValue(context.Background(), core.BinaryValue([]byte{}), fftypes.NewRandB32())
+	assert.Regexp(t, "FF10201", err)
+}
+
+func TestProtobufValidatorNilData(t *testing.T) {
+	v := &protobufValidator{}
+	err := v.Validate(context.Background(), &core.Data{})
+	assert.Regexp(t, "FF10199", err)
+}
+
+func TestProtobufValidatorBadSchemaJSON(t *testing.T) {
+	dt := &core.Datatype{
+		Validator: core.ValidatorTypeProtobuf,
+		Name:      "widget",
+		Version:   "0.0.1",
+		Value:     fftypes.JSONAnyPtr(`"not an object"`),
+	}
+	_, err := newProtobufValidator(context.Background(), "ns1", dt)
+	assert.Regexp(t, "FF10196", err)
+}
+
+func TestProtobufValidatorBadDescriptorSet(t *testing.T) {
+	dt := &core.Datatype{
+		Validator: core.ValidatorTypeProtobuf,
+		Name:      "widget",
+		Version:   "0.0.1",
+		Value:     fftypes.JSONAnyPtr(`{"descriptorSet":"/w==","message":"example.Widget"}`),
+	}
+	_, err := newProtobufValidator(context.Background(), "ns1", dt)
+	assert.Regexp(t, "FF10196", err)
+}
+
+func TestProtobufValidatorUnresolvedDescriptors(t *testing.T) {
+	file := testProtobufFile()
+	file.MessageType = file.MessageType[1:]
+	_, err := newProtobufValidator(context.Background(), "ns1", testProtobufDatatype(t, file, "example.Widget"))
+	assert.Regexp(t, "FF10196", err)
+}
+
+func TestProtobufValidatorMessageNotFound(t *testing.T) {
+	_, err := newProtobufValidator(context.Background(), "ns1", testProtobufDatatype(t, testProtobufFile(), "example.Gadget"))
+	assert.Regexp(t, "FF10540.*example.Gadget", err)
+}
+
+func TestProtobufValidatorNotAMessage(t *testing.T) {
+	_, err := newProtobufValidator(context.Background(), "ns1", testProtobufDatatype(t, testProtobufFile(), "example.Widget.name"))
+	assert.Regexp(t, "FF10540.*example.Widget.name", err)
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// ValidationPolicies determine what happens to received messages whose data fails validation
+type ValidationPolicies struct {
+	Default   core.ValidationPolicy
+	Datatypes map[string]core.ValidationPolicy
+}
+
+// DataValidation is the result of validating the data of a message against its datatypes
+type DataValidation struct {
+	Valid    bool
+	Policy   core.ValidationPolicy
+	Datatype *core.DatatypeRef
+	Reason   string
+}
+
+// The policy applied when data fails against several datatypes, is the strictest of them
+var validationPolicyStrictness = map[core.ValidationPolicy]int{
+	core.ValidationPolicyWarn:       1,
+	core.ValidationPolicyQuarantine: 2,
+	core.ValidationPolicyEnforce:    3,
+}
+
+func (vp *ValidationPolicies) forDatatype(datatype *core.DatatypeRef) core.ValidationPolicy {
+	if policy, ok := vp.Datatypes[datatype.Name]; ok {
+		return policy
+	}
+	if vp.Default == "" {
+		return core.ValidationPolicyEnforce
+	}
+	return vp.Default
+}
+
+func (v *DataValidation) addFailure(policy core.ValidationPolicy, datatype *core.DatatypeRef, reason string) {
+	if v.Valid || validationPolicyStrictness[policy] > validationPolicyStrictness[v.Policy] {
+		v.Valid = false
+		v.Policy = policy
+		v.Datatype = datatype
+		v.Reason = reason
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPolicyDatatype(name string) *core.Datatype {
+	return &core.Datatype{
+		ID:        fftypes.NewUUID(),
+		Validator: core.ValidatorTypeJSON,
+		Value: fftypes.JSONAnyPtr(`{
+			"properties": {
+				"field1": {
+					"type": "string"
+				}
+			},
+			"additionalProperties": false
+		}`),
+		Namespace: "ns1",
+		Name:      name,
+		Version:   "0.0.1",
+	}
+}
+
+func newTestPolicyData(name, value string) *core.Data {
+	return &core.Data{
+		Namespace: "ns1",
+		Validator: core.ValidatorTypeJSON,
+		Datatype: &core.DatatypeRef{
+			Name:    name,
+			Version: "0.0.1",
+		},
+		Value: fftypes.JSONAnyPtr(value),
+	}
+}
+
+func TestValidateAllPolicies(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.validation = ValidationPolicies{
+		Default: core.ValidationPolicyWarn,
+		Datatypes: map[string]core.ValidationPolicy{
+			"orders": core.ValidationPolicyQuarantine,
+		},
+	}
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "customer", "0.0.1").Return(newTestPolicyDatatype("customer"), nil)
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "orders", "0.0.1").Return(newTestPolicyDatatype("orders"), nil)
+
+	customerInvalid := newTestPolicyData("customer", `{"bad":"value"}`)
+	ordersValid := newTestPolicyData("orders", `{"field1":"value"}`)
+	ordersInvalid := newTestPolicyData("orders", `{"bad":"value"}`)
+
+	// Only the default policy applies
+	validation, err := dm.ValidateAll(ctx, core.DataArray{customerInvalid, ordersValid})
+	assert.NoError(t, err)
+	assert.False(t, validation.Valid)
+	assert.Equal(t, core.ValidationPolicyWarn, validation.Policy)
+	assert.Equal(t, "customer", validation.Datatype.Name)
+
+	// The strictest policy of the failures applies, whichever order they are in
+	validation, err = dm.ValidateAll(ctx, core.DataArray{customerInvalid, ordersInvalid})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ValidationPolicyQuarantine, validation.Policy)
+	assert.Equal(t, "orders", validation.Datatype.Name)
+	assert.Regexp(t, "FF10198", validation.Reason)
+
+	validation, err = dm.ValidateAll(ctx, core.DataArray{ordersInvalid, customerInvalid})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ValidationPolicyQuarantine, validation.Policy)
+	assert.Equal(t, "orders", validation.Datatype.Name)
+}
+
+func TestValidationPolicyDefault(t *testing.T) {
+	vp := &ValidationPolicies{}
+	assert.Equal(t, core.ValidationPolicyEnforce, vp.forDatatype(&core.DatatypeRef{Name: "customer"}))
+}
+
+func TestResolveInlineDataInvalidNotEnforced(t *testing.T) {
+	dm, ctx, cancel := newTestDataManager(t)
+	defer cancel()
+	dm.validation = ValidationPolicies{Default: core.ValidationPolicyQuarantine}
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("GetDatatypeByName", mock.Anything, "ns1", "customer", "0.0.1").Return(newTestPolicyDatatype("customer"), nil)
+
+	_, _, newMsg := testNewMessage()
+	newMsg.Message.InlineData = core.InlineData{
+		{
+			Datatype: &core.DatatypeRef{
+				Name:    "customer",
+				Version: "0.0.1",
+			},
+			Value: fftypes.JSONAnyPtr(`{"not_allowed":"value"}`),
+		},
+	}
+	err := dm.ResolveInlineData(ctx, newMsg)
+	assert.NoError(t, err)
+	assert.Len(t, newMsg.NewData, 1)
+}
@@ -0,0 +1,181 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	addressBookColumns = []string{
+		"id",
+		"namespace",
+		"name",
+		"address",
+		"created",
+		"updated",
+	}
+	addressBookFilterFieldMap = map[string]string{}
+)
+
+const addressbookTable = "addressbook"
+
+func (s *SQLCommon) UpsertAddressBookEntry(ctx context.Context, entry *core.AddressBookEntry) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	// Do a select within the transaction to determine if the name is already in use
+	rows, _, err := s.QueryTx(ctx, addressbookTable, tx,
+		sq.Select("id", "created").
+			From(addressbookTable).
+			Where(sq.Eq{
+				"namespace": entry.Namespace,
+				"name":      entry.Name,
+			}),
+	)
+	if err != nil {
+		return err
+	}
+	existing := rows.Next()
+	if existing {
+		// Retain the identity and creation time of the entry, and only update the address
+		err = rows.Scan(&entry.ID, &entry.Created)
+	}
+	rows.Close()
+	if err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, addressbookTable)
+	}
+
+	if existing {
+		if _, err = s.UpdateTx(ctx, addressbookTable, tx,
+			sq.Update(addressbookTable).
+				Set("address", entry.Address).
+				Set("updated", entry.Updated).
+				Where(sq.Eq{"id": entry.ID}),
+			nil, // address book entries are local to the node, so there are no change events
+		); err != nil {
+			return err
+		}
+	} else {
+		if _, err = s.InsertTx(ctx, addressbookTable, tx,
+			sq.Insert(addressbookTable).
+				Columns(addressBookColumns...).
+				Values(
+					entry.ID,
+					entry.Namespace,
+					entry.Name,
+					entry.Address,
+					entry.Created,
+					entry.Updated,
+				),
+			nil,
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) addressBookEntryResult(ctx context.Context, row *sql.Rows) (*core.AddressBookEntry, error) {
+	entry := core.AddressBookEntry{}
+	err := row.Scan(
+		&entry.ID,
+		&entry.Namespace,
+		&entry.Name,
+		&entry.Address,
+		&entry.Created,
+		&entry.Updated,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, addressbookTable)
+	}
+	return &entry, nil
+}
+
+func (s *SQLCommon) GetAddressBookEntryByName(ctx context.Context, namespace, name string) (*core.AddressBookEntry, error) {
+	rows, _, err := s.Query(ctx, addressbookTable,
+		sq.Select(addressBookColumns...).
+			From(addressbookTable).
+			Where(sq.Eq{"namespace": namespace, "name": name}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Address book entry '%s' not found", name)
+		return nil, nil
+	}
+
+	return s.addressBookEntryResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetAddressBookEntries(ctx context.Context, namespace string, filter ffapi.Filter) (entries []*core.AddressBookEntry, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(addressBookColumns...).From(addressbookTable),
+		filter, addressBookFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, addressbookTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	entries = []*core.AddressBookEntry{}
+	for rows.Next() {
+		entry, err := s.addressBookEntryResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, s.QueryRes(ctx, addressbookTable, tx, fop, nil, fi), err
+}
+
+func (s *SQLCommon) DeleteAddressBookEntry(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, addressbookTable, tx, sq.Delete(addressbookTable).Where(sq.Eq{
+		"id": id, "namespace": namespace,
+	}), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
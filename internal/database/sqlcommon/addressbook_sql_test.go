@@ -0,0 +1,202 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressBookE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Create a new entry
+	entry := &core.AddressBookEntry{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "treasury",
+		Address:   "0x1111",
+		Created:   fftypes.Now(),
+		Updated:   fftypes.Now(),
+	}
+	err := s.UpsertAddressBookEntry(ctx, entry)
+	assert.NoError(t, err)
+
+	// Query back the entry by name
+	entryRead, err := s.GetAddressBookEntryByName(ctx, "ns1", "treasury")
+	assert.NoError(t, err)
+	entryJson, _ := json.Marshal(entry)
+	readJson, _ := json.Marshal(entryRead)
+	assert.Equal(t, string(entryJson), string(readJson))
+
+	// Update the address, which retains the original ID and creation time
+	originalID := entry.ID
+	originalCreated := entry.Created
+	entryUpdate := &core.AddressBookEntry{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "treasury",
+		Address:   "0x2222",
+		Created:   fftypes.Now(),
+		Updated:   fftypes.Now(),
+	}
+	err = s.UpsertAddressBookEntry(ctx, entryUpdate)
+	assert.NoError(t, err)
+	assert.Equal(t, originalID, entryUpdate.ID)
+	assert.Equal(t, originalCreated.String(), entryUpdate.Created.String())
+
+	// Query back the entries
+	fb := database.AddressBookQueryFactory.NewFilter(ctx)
+	entries, res, err := s.GetAddressBookEntries(ctx, "ns1", fb.And(fb.Eq("address", "0x2222")).Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	entryJson, _ = json.Marshal(entryUpdate)
+	readJson, _ = json.Marshal(entries[0])
+	assert.Equal(t, string(entryJson), string(readJson))
+
+	// Other namespaces do not see the entry
+	entryRead, err = s.GetAddressBookEntryByName(ctx, "ns2", "treasury")
+	assert.NoError(t, err)
+	assert.Nil(t, entryRead)
+
+	// Delete the entry
+	err = s.DeleteAddressBookEntry(ctx, "ns1", originalID)
+	assert.NoError(t, err)
+	entries, _, err = s.GetAddressBookEntries(ctx, "ns1", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestUpsertAddressBookEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertAddressBookEntry(context.Background(), &core.AddressBookEntry{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertAddressBookEntryFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertAddressBookEntry(context.Background(), &core.AddressBookEntry{})
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertAddressBookEntryFailScan(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	mock.ExpectRollback()
+	err := s.UpsertAddressBookEntry(context.Background(), &core.AddressBookEntry{})
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertAddressBookEntryFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertAddressBookEntry(context.Background(), &core.AddressBookEntry{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertAddressBookEntryFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id", "created"}).AddRow(fftypes.NewUUID().String(), fftypes.Now().UnixNano()))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertAddressBookEntry(context.Background(), &core.AddressBookEntry{})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAddressBookEntryByNameSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetAddressBookEntryByName(context.Background(), "ns1", "treasury")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAddressBookEntryByNameReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetAddressBookEntryByName(context.Background(), "ns1", "treasury")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAddressBookEntriesFilterSelectFail(t *testing.T) {
+	fb := database.AddressBookQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetAddressBookEntries(context.Background(), "ns1", fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetAddressBookEntriesQueryFail(t *testing.T) {
+	fb := database.AddressBookQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetAddressBookEntries(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAddressBookEntriesReadFail(t *testing.T) {
+	fb := database.AddressBookQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetAddressBookEntries(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAddressBookEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteAddressBookEntry(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAddressBookEntryFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteAddressBookEntry(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
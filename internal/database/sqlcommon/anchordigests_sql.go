@@ -0,0 +1,167 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	anchorDigestColumns = []string{
+		"id",
+		"namespace",
+		"root",
+		"plugin",
+		"first_pin",
+		"last_pin",
+		"batches",
+		"tx_id",
+		"blockchain_id",
+		"created",
+	}
+	anchorDigestFilterFieldMap = map[string]string{
+		"firstpin":     "first_pin",
+		"lastpin":      "last_pin",
+		"tx":           "tx_id",
+		"blockchainid": "blockchain_id",
+	}
+)
+
+const anchorDigestsTable = "anchordigests"
+
+func (s *SQLCommon) InsertAnchorDigest(ctx context.Context, digest *core.AnchorDigest) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if _, err = s.InsertTx(ctx, anchorDigestsTable, tx,
+		sq.Insert(anchorDigestsTable).
+			Columns(anchorDigestColumns...).
+			Values(
+				digest.ID,
+				digest.Namespace,
+				digest.Root,
+				digest.Plugin,
+				digest.FirstPin,
+				digest.LastPin,
+				digest.Batches,
+				digest.Transaction,
+				digest.BlockchainID,
+				digest.Created,
+			),
+		nil, // no change events for anchor digests
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) UpdateAnchorDigest(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	query, err := s.BuildUpdate(sq.Update(anchorDigestsTable), update, anchorDigestFilterFieldMap)
+	if err != nil {
+		return err
+	}
+	query = query.Where(sq.Eq{"id": id, "namespace": namespace})
+
+	_, err = s.UpdateTx(ctx, anchorDigestsTable, tx, query, nil /* no change events for anchor digests */)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) anchorDigestResult(ctx context.Context, row *sql.Rows) (*core.AnchorDigest, error) {
+	digest := core.AnchorDigest{}
+	err := row.Scan(
+		&digest.ID,
+		&digest.Namespace,
+		&digest.Root,
+		&digest.Plugin,
+		&digest.FirstPin,
+		&digest.LastPin,
+		&digest.Batches,
+		&digest.Transaction,
+		&digest.BlockchainID,
+		&digest.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, anchorDigestsTable)
+	}
+	return &digest, nil
+}
+
+func (s *SQLCommon) GetAnchorDigestByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.AnchorDigest, error) {
+	rows, _, err := s.Query(ctx, anchorDigestsTable,
+		sq.Select(anchorDigestColumns...).
+			From(anchorDigestsTable).
+			Where(sq.Eq{"id": id, "namespace": namespace}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Anchor digest '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.anchorDigestResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetAnchorDigests(ctx context.Context, namespace string, filter ffapi.Filter) (digests []*core.AnchorDigest, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(anchorDigestColumns...).From(anchorDigestsTable), filter, anchorDigestFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, anchorDigestsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	digests = []*core.AnchorDigest{}
+	for rows.Next() {
+		digest, err := s.anchorDigestResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		digests = append(digests, digest)
+	}
+
+	return digests, s.QueryRes(ctx, anchorDigestsTable, tx, fop, nil, fi), err
+}
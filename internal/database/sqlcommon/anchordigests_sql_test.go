@@ -0,0 +1,171 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchorDigestsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	digest := &core.AnchorDigest{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Root:      fftypes.NewRandB32(),
+		Plugin:    "anchorchain",
+		FirstPin:  10,
+		LastPin:   20,
+		Batches: core.AnchorDigestBatches{
+			{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()},
+			{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()},
+		},
+		Transaction: fftypes.NewUUID(),
+		Created:     fftypes.Now(),
+	}
+	err := s.InsertAnchorDigest(ctx, digest)
+	assert.NoError(t, err)
+
+	// Query back by ID
+	read, err := s.GetAnchorDigestByID(ctx, "ns1", digest.ID)
+	assert.NoError(t, err)
+	digestJson, _ := json.Marshal(digest)
+	readJson, _ := json.Marshal(read)
+	assert.Equal(t, string(digestJson), string(readJson))
+
+	// Record the blockchain transaction
+	up := database.AnchorDigestQueryFactory.NewUpdate(ctx).Set("blockchainid", "0x12345")
+	err = s.UpdateAnchorDigest(ctx, "ns1", digest.ID, up)
+	assert.NoError(t, err)
+
+	// Query back by the pin sequence in the window
+	fb := database.AnchorDigestQueryFactory.NewFilter(ctx)
+	digests, res, err := s.GetAnchorDigests(ctx, "ns1", fb.And(
+		fb.Lte("firstpin", 15),
+		fb.Gte("lastpin", 15),
+	).Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, digests, 1)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Equal(t, "0x12345", digests[0].BlockchainID)
+
+	// Other namespaces do not see the digest
+	read, err = s.GetAnchorDigestByID(ctx, "ns2", digest.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, read)
+	digests, _, err = s.GetAnchorDigests(ctx, "ns2", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, digests)
+}
+
+func TestInsertAnchorDigestFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertAnchorDigest(context.Background(), &core.AnchorDigest{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertAnchorDigestFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertAnchorDigest(context.Background(), &core.AnchorDigest{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateAnchorDigestFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	u := database.AnchorDigestQueryFactory.NewUpdate(context.Background()).Set("blockchainid", "0x12345")
+	err := s.UpdateAnchorDigest(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateAnchorDigestBuildQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	u := database.AnchorDigestQueryFactory.NewUpdate(context.Background()).Set("blockchainid", map[bool]bool{true: false})
+	err := s.UpdateAnchorDigest(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00143", err)
+}
+
+func TestUpdateAnchorDigestFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	u := database.AnchorDigestQueryFactory.NewUpdate(context.Background()).Set("blockchainid", "0x12345")
+	err := s.UpdateAnchorDigest(context.Background(), "ns1", fftypes.NewUUID(), u)
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAnchorDigestByIDQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetAnchorDigestByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAnchorDigestByIDReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetAnchorDigestByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAnchorDigestsFilterSelectFail(t *testing.T) {
+	fb := database.AnchorDigestQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetAnchorDigests(context.Background(), "ns1", fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetAnchorDigestsQueryFail(t *testing.T) {
+	fb := database.AnchorDigestQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetAnchorDigests(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAnchorDigestsReadFail(t *testing.T) {
+	fb := database.AnchorDigestQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetAnchorDigests(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
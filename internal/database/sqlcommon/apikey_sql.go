@@ -0,0 +1,155 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	apiKeyColumns = []string{
+		"id",
+		"namespace",
+		"name",
+		"scope",
+		"hash",
+		"created",
+	}
+	apiKeyFilterFieldMap = map[string]string{}
+)
+
+const apikeysTable = "apikeys"
+
+func (s *SQLCommon) InsertAPIKey(ctx context.Context, key *core.APIKey) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if _, err = s.InsertTx(ctx, apikeysTable, tx,
+		sq.Insert(apikeysTable).
+			Columns(apiKeyColumns...).
+			Values(
+				key.ID,
+				key.Namespace,
+				key.Name,
+				key.Scope,
+				key.Hash,
+				key.Created,
+			),
+		nil, // API keys are local to the node, so there are no change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) apiKeyResult(ctx context.Context, row *sql.Rows) (*core.APIKey, error) {
+	key := core.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.Namespace,
+		&key.Name,
+		&key.Scope,
+		&key.Hash,
+		&key.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, apikeysTable)
+	}
+	return &key, nil
+}
+
+func (s *SQLCommon) getAPIKeyEq(ctx context.Context, eq sq.Eq, textName string) (*core.APIKey, error) {
+	rows, _, err := s.Query(ctx, apikeysTable,
+		sq.Select(apiKeyColumns...).
+			From(apikeysTable).
+			Where(eq),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("API key '%s' not found", textName)
+		return nil, nil
+	}
+
+	return s.apiKeyResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetAPIKeyByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.APIKey, error) {
+	return s.getAPIKeyEq(ctx, sq.Eq{"namespace": namespace, "id": id}, id.String())
+}
+
+func (s *SQLCommon) GetAPIKeyByHash(ctx context.Context, namespace string, hash *fftypes.Bytes32) (*core.APIKey, error) {
+	return s.getAPIKeyEq(ctx, sq.Eq{"namespace": namespace, "hash": hash}, hash.String())
+}
+
+func (s *SQLCommon) GetAPIKeys(ctx context.Context, namespace string, filter ffapi.Filter) (keys []*core.APIKey, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(apiKeyColumns...).From(apikeysTable),
+		filter, apiKeyFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, apikeysTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	keys = []*core.APIKey{}
+	for rows.Next() {
+		key, err := s.apiKeyResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, s.QueryRes(ctx, apikeysTable, tx, fop, nil, fi), err
+}
+
+func (s *SQLCommon) DeleteAPIKey(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, apikeysTable, tx, sq.Delete(apikeysTable).Where(sq.Eq{
+		"id": id, "namespace": namespace,
+	}), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeysE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Create a new key
+	key := &core.APIKey{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "partner1",
+		Scope:     core.APIKeyScopeMessaging,
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+	}
+	err := s.InsertAPIKey(ctx, key)
+	assert.NoError(t, err)
+
+	// Query back the key by ID and by hash
+	keyJson, _ := json.Marshal(key)
+	keyRead, err := s.GetAPIKeyByID(ctx, "ns1", key.ID)
+	assert.NoError(t, err)
+	readJson, _ := json.Marshal(keyRead)
+	assert.Equal(t, string(keyJson), string(readJson))
+	assert.Equal(t, *key.Hash, *keyRead.Hash)
+	keyRead, err = s.GetAPIKeyByHash(ctx, "ns1", key.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, *key.ID, *keyRead.ID)
+
+	// The key is not found in another namespace
+	keyRead, err = s.GetAPIKeyByHash(ctx, "ns2", key.Hash)
+	assert.NoError(t, err)
+	assert.Nil(t, keyRead)
+
+	// Names are unique within a namespace
+	err = s.InsertAPIKey(ctx, &core.APIKey{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "partner1",
+		Scope:     core.APIKeyScopeReadOnly,
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+	})
+	assert.Regexp(t, "FF00177", err)
+
+	// Query back the key with a filter
+	fb := database.APIKeyQueryFactory.NewFilter(ctx)
+	keys, res, err := s.GetAPIKeys(ctx, "ns1", fb.And(fb.Eq("scope", core.APIKeyScopeMessaging)).Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, int64(1), *res.TotalCount)
+
+	// Delete the key
+	err = s.DeleteAPIKey(ctx, "ns1", key.ID)
+	assert.NoError(t, err)
+	keyRead, err = s.GetAPIKeyByID(ctx, "ns1", key.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, keyRead)
+}
+
+func TestInsertAPIKeyFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertAPIKey(context.Background(), &core.APIKey{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertAPIKeyFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertAPIKey(context.Background(), &core.APIKey{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeyByIDSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetAPIKeyByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeyByHashReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetAPIKeyByHash(context.Background(), "ns1", fftypes.NewRandB32())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeysFilterSelectFail(t *testing.T) {
+	fb := database.APIKeyQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetAPIKeys(context.Background(), "ns1", fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetAPIKeysQueryFail(t *testing.T) {
+	fb := database.APIKeyQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetAPIKeys(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAPIKeysReadFail(t *testing.T) {
+	fb := database.APIKeyQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetAPIKeys(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAPIKeyFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteAPIKey(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteAPIKeyFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteAPIKey(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -45,6 +45,7 @@ var (
 		"tx_type",
 		"tx_id",
 		"node_id",
+		"anchor",
 	}
 	batchFilterFieldMap = map[string]string{
 		"type":    "btype",
@@ -82,6 +83,7 @@ func (s *SQLCommon) InsertOrGetBatch(ctx context.Context, batch *core.BatchPersi
 				batch.TX.Type,
 				batch.TX.ID,
 				batch.Node,
+				batch.Anchor,
 			),
 		func() {
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionBatches, core.ChangeEventTypeCreated, batch.Namespace, batch.ID)
@@ -118,6 +120,7 @@ func (s *SQLCommon) batchResult(ctx context.Context, row *sql.Rows) (*core.Batch
 		&batch.TX.Type,
 		&batch.TX.ID,
 		&batch.Node,
+		&batch.Anchor,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, batchesTable)
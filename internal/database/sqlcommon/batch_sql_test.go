@@ -119,6 +119,20 @@ func TestBatch2EWithDB(t *testing.T) {
 	assert.Equal(t, 1, len(batches))
 	assert.Equal(t, int64(1), *res.TotalCount)
 
+	// Record an anchor
+	anchor := &core.BatchAnchor{
+		Plugin:       "anchorchain",
+		BlockchainID: "0x333333",
+		Operation:    fftypes.NewUUID(),
+	}
+	anchorJSON, _ := json.Marshal(anchor)
+	up = database.BatchQueryFactory.NewUpdate(ctx).Set("anchor", fftypes.JSONAnyPtrBytes(anchorJSON))
+	err = s.UpdateBatch(ctx, "ns1", batchID, up)
+	assert.NoError(t, err)
+	batchRead, err = s.GetBatchByID(ctx, "ns1", batchID)
+	assert.NoError(t, err)
+	assert.Equal(t, anchor, batchRead.Anchor)
+
 	s.callbacks.AssertExpectations(t)
 }
 
@@ -0,0 +1,153 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+var (
+	blockchainCheckpointColumns = []string{
+		"namespace",
+		"ledger",
+		"subscription",
+		"block_number",
+		"tx_index",
+		"event_index",
+		"tx_id",
+		"updated",
+	}
+)
+
+const blockchainCheckpointsTable = "blockchaincheckpoints"
+
+func (s *SQLCommon) UpsertBlockchainCheckpoint(ctx context.Context, checkpoint *core.BlockchainCheckpoint) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	checkpoint.Updated = fftypes.Now()
+	updated, err := s.UpdateTx(ctx, blockchainCheckpointsTable, tx,
+		sq.Update(blockchainCheckpointsTable).
+			Set("block_number", checkpoint.BlockNumber).
+			Set("tx_index", checkpoint.TransactionIndex).
+			Set("event_index", checkpoint.EventIndex).
+			Set("tx_id", checkpoint.TransactionID).
+			Set("updated", checkpoint.Updated).
+			Where(sq.Eq{
+				"namespace":    checkpoint.Namespace,
+				"ledger":       checkpoint.Ledger,
+				"subscription": checkpoint.Subscription,
+			}),
+		nil, // no change events for checkpoints
+	)
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		if _, err = s.InsertTx(ctx, blockchainCheckpointsTable, tx,
+			sq.Insert(blockchainCheckpointsTable).
+				Columns(blockchainCheckpointColumns...).
+				Values(
+					checkpoint.Namespace,
+					checkpoint.Ledger,
+					checkpoint.Subscription,
+					checkpoint.BlockNumber,
+					checkpoint.TransactionIndex,
+					checkpoint.EventIndex,
+					checkpoint.TransactionID,
+					checkpoint.Updated,
+				),
+			nil, // no change events for checkpoints
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) blockchainCheckpointResult(ctx context.Context, row *sql.Rows) (*core.BlockchainCheckpoint, error) {
+	checkpoint := core.BlockchainCheckpoint{}
+	err := row.Scan(
+		&checkpoint.Namespace,
+		&checkpoint.Ledger,
+		&checkpoint.Subscription,
+		&checkpoint.BlockNumber,
+		&checkpoint.TransactionIndex,
+		&checkpoint.EventIndex,
+		&checkpoint.TransactionID,
+		&checkpoint.Updated,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, blockchainCheckpointsTable)
+	}
+	return &checkpoint, nil
+}
+
+func (s *SQLCommon) GetBlockchainCheckpoint(ctx context.Context, namespace, ledger, subscription string) (*core.BlockchainCheckpoint, error) {
+	rows, _, err := s.Query(ctx, blockchainCheckpointsTable,
+		sq.Select(blockchainCheckpointColumns...).
+			From(blockchainCheckpointsTable).
+			Where(sq.Eq{
+				"namespace":    namespace,
+				"ledger":       ledger,
+				"subscription": subscription,
+			}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Checkpoint for subscription '%s' on '%s' not found", subscription, ledger)
+		return nil, nil
+	}
+
+	return s.blockchainCheckpointResult(ctx, rows)
+}
+
+func (s *SQLCommon) DeleteBlockchainCheckpoints(ctx context.Context, namespace, subscription string) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, blockchainCheckpointsTable, tx, sq.Delete(blockchainCheckpointsTable).Where(sq.Eq{
+		"namespace":    namespace,
+		"subscription": subscription,
+	}), nil /* no change events for checkpoints */)
+	if err != nil && err != database.DeleteRecordNotFound {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockchainCheckpointsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Nothing recorded initially
+	checkpoint, err := s.GetBlockchainCheckpoint(ctx, "ns1", "firefly", "sb-1")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	// Record a checkpoint, then move it on
+	checkpoint = &core.BlockchainCheckpoint{
+		Namespace:     "ns1",
+		Ledger:        "firefly",
+		Subscription:  "sb-1",
+		BlockNumber:   10,
+		TransactionID: "tx1",
+	}
+	err = s.UpsertBlockchainCheckpoint(ctx, checkpoint)
+	assert.NoError(t, err)
+	checkpoint.BlockNumber = 11
+	checkpoint.TransactionIndex = 2
+	checkpoint.EventIndex = 1
+	checkpoint.TransactionID = "tx2"
+	err = s.UpsertBlockchainCheckpoint(ctx, checkpoint)
+	assert.NoError(t, err)
+
+	// Check we get the latest checkpoint back
+	checkpointRead, err := s.GetBlockchainCheckpoint(ctx, "ns1", "firefly", "sb-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), checkpointRead.BlockNumber)
+	assert.Equal(t, int64(2), checkpointRead.TransactionIndex)
+	assert.Equal(t, int64(1), checkpointRead.EventIndex)
+	assert.Equal(t, "tx2", checkpointRead.TransactionID)
+	assert.Equal(t, checkpoint.Updated.String(), checkpointRead.Updated.String())
+
+	// Checkpoints are separate for each ledger
+	checkpointRead, err = s.GetBlockchainCheckpoint(ctx, "ns1", "other", "sb-1")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpointRead)
+
+	// Delete the checkpoints of the subscription
+	err = s.DeleteBlockchainCheckpoints(ctx, "ns1", "sb-1")
+	assert.NoError(t, err)
+	checkpointRead, err = s.GetBlockchainCheckpoint(ctx, "ns1", "firefly", "sb-1")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpointRead)
+}
+
+func TestUpsertBlockchainCheckpointFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.UpsertBlockchainCheckpoint(context.Background(), &core.BlockchainCheckpoint{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBlockchainCheckpointFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertBlockchainCheckpoint(context.Background(), &core.BlockchainCheckpoint{})
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpsertBlockchainCheckpointFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.UpsertBlockchainCheckpoint(context.Background(), &core.BlockchainCheckpoint{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockchainCheckpointSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetBlockchainCheckpoint(context.Background(), "ns1", "firefly", "sb-1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockchainCheckpointScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	_, err := s.GetBlockchainCheckpoint(context.Background(), "ns1", "firefly", "sb-1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteBlockchainCheckpointsBeginFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteBlockchainCheckpoints(context.Background(), "ns1", "sb-1")
+	assert.Regexp(t, "FF00175", err)
+}
+
+func TestDeleteBlockchainCheckpointsFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteBlockchainCheckpoints(context.Background(), "ns1", "sb-1")
+	assert.Regexp(t, "FF00179", err)
+}
@@ -19,10 +19,13 @@ package sqlcommon
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strconv"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
@@ -49,6 +52,14 @@ func (s *SQLCommon) getTableNameFromCollection(ctx context.Context, collection d
 	}
 }
 
+func chartTimestampColumn(tableName string) string {
+	if tableName == "blockchainevents" {
+		// Blockchain Events have a `timestamp` column name
+		return "timestamp"
+	}
+	return "created"
+}
+
 func (s *SQLCommon) getSelectStatements(ns string, tableName string, intervals []core.ChartHistogramInterval, timestampKey string, sql sq.SelectBuilder) (queries []sq.SelectBuilder) {
 	for _, interval := range intervals {
 		queries = append(queries, sql.
@@ -108,12 +119,7 @@ func (s *SQLCommon) GetChartHistogram(ctx context.Context, ns string, intervals
 		return nil, err
 	}
 
-	// Timestamp column name
-	timestampKey := "created"
-	if tableName == "blockchainevents" {
-		// Blockchain Events have a `timestamp` column name
-		timestampKey = "timestamp"
-	}
+	timestampKey := chartTimestampColumn(tableName)
 
 	// Number of columns to read.
 	// Some tables don't have a `type` field and therefore
@@ -164,3 +170,78 @@ func (s *SQLCommon) GetChartHistogram(ctx context.Context, ns string, intervals
 
 	return histogramList, nil
 }
+
+// GetChartAggregate counts the rows of a collection in fixed width time buckets, with a GROUP BY in the database
+// rather than reading the rows. The timestamps are stored as integer nanoseconds, so the bucket of each row is
+// calculated with integer division - which behaves the same in all the supported database dialects.
+// Buckets without any rows are returned with a zero count.
+func (s *SQLCommon) GetChartAggregate(ctx context.Context, ns string, collection database.CollectionName, startTime *fftypes.FFTime, bucketWidth time.Duration, buckets int) ([]*core.ChartHistogram, error) {
+	tableName, fieldMap, err := s.getTableNameFromCollection(ctx, collection)
+	if err != nil {
+		return nil, err
+	}
+	timestampKey := chartTimestampColumn(tableName)
+	start := startTime.UnixNano()
+	width := bucketWidth.Nanoseconds()
+	end := start + width*int64(buckets)
+
+	query := sq.Select().
+		Column(sq.Expr(fmt.Sprintf("(%s - ?) / ? AS bucket", timestampKey), start, width)).
+		Column("COUNT(*)")
+	groupBy := []string{"bucket"}
+	typeColumn, hasType := fieldMap["type"]
+	if hasType {
+		query = query.Column(typeColumn)
+		groupBy = append(groupBy, typeColumn)
+	}
+	query = query.
+		From(tableName).
+		Where(sq.And{
+			sq.GtOrEq{timestampKey: start},
+			sq.Lt{timestampKey: end},
+			sq.Eq{"namespace": ns},
+		}).
+		GroupBy(groupBy...).
+		OrderBy(groupBy...)
+
+	rows, _, err := s.Query(ctx, tableName, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]int64, buckets)
+	histogram := make([]*core.ChartHistogram, buckets)
+	for i := range histogram {
+		bucketStart := fftypes.FFTime(time.Unix(0, start+int64(i)*width))
+		histogram[i] = &core.ChartHistogram{
+			Timestamp: &bucketStart,
+			Types:     make([]*core.ChartHistogramType, 0),
+		}
+	}
+	for rows.Next() {
+		var bucket, count int64
+		var typeStr string
+		dest := []interface{}{&bucket, &count}
+		if hasType {
+			dest = append(dest, &typeStr)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, tableName)
+		}
+		if bucket < 0 || bucket >= int64(buckets) {
+			continue
+		}
+		totals[bucket] += count
+		if hasType {
+			histogram[bucket].Types = append(histogram[bucket].Types, &core.ChartHistogramType{
+				Count: strconv.FormatInt(count, 10),
+				Type:  typeStr,
+			})
+		}
+	}
+	for i, total := range totals {
+		histogram[i].Count = strconv.FormatInt(total, 10)
+	}
+	return histogram, nil
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/hyperledger/firefly-common/pkg/config"
@@ -28,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -252,3 +254,86 @@ func TestGetChartHistogramSuccessNoRowsOnlyTimestamp(t *testing.T) {
 	assert.Equal(t, emptyHistogramResult, histogram)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetChartAggregateE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("OrderedUUIDCollectionNSEvent", database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+	start := time.Unix(1000, 0)
+	insert := func(offset time.Duration, eventType core.EventType) {
+		created := fftypes.FFTime(start.Add(offset))
+		err := s.InsertEvent(ctx, &core.Event{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Type:      eventType,
+			Created:   &created,
+		})
+		assert.NoError(t, err)
+	}
+	insert(0, core.EventTypeMessageConfirmed)
+	insert(30*time.Second, core.EventTypeMessageConfirmed)
+	insert(59*time.Second, core.EventTypeTransferConfirmed)
+	insert(2*time.Minute+time.Second, core.EventTypeMessageConfirmed)
+	insert(-time.Second, core.EventTypeMessageConfirmed)  // before the start
+	insert(3*time.Minute, core.EventTypeMessageConfirmed) // after the end
+
+	startTime := fftypes.FFTime(start)
+	histogram, err := s.GetChartAggregate(ctx, "ns1", database.CollectionName(database.CollectionEvents), &startTime, time.Minute, 3)
+	assert.NoError(t, err)
+	assert.Len(t, histogram, 3)
+
+	assert.Equal(t, "3", histogram[0].Count)
+	assert.Equal(t, start.UnixNano(), histogram[0].Timestamp.UnixNano())
+	assert.Equal(t, []*core.ChartHistogramType{
+		{Count: "2", Type: core.EventTypeMessageConfirmed.String()},
+		{Count: "1", Type: core.EventTypeTransferConfirmed.String()},
+	}, histogram[0].Types)
+
+	assert.Equal(t, "0", histogram[1].Count)
+	assert.Equal(t, start.Add(time.Minute).UnixNano(), histogram[1].Timestamp.UnixNano())
+	assert.Empty(t, histogram[1].Types)
+
+	assert.Equal(t, "1", histogram[2].Count)
+	assert.Equal(t, start.Add(2*time.Minute).UnixNano(), histogram[2].Timestamp.UnixNano())
+}
+
+func TestGetChartAggregateInvalidCollectionName(t *testing.T) {
+	s, _ := newMockProvider().init()
+	_, err := s.GetChartAggregate(context.Background(), "ns1", database.CollectionName("abc"), fftypes.UnixTime(1000), time.Minute, 1)
+	assert.Regexp(t, "FF10301", err)
+}
+
+func TestGetChartAggregateNoTypes(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*GROUP BY bucket ORDER BY bucket").WillReturnRows(sqlmock.NewRows([]string{"bucket", "count"}).
+		AddRow(0, 5).
+		AddRow(1, 2).
+		AddRow(2, 1)) // out of range
+
+	histogram, err := s.GetChartAggregate(context.Background(), "ns1", database.CollectionName(database.CollectionBlockchainEvents), fftypes.UnixTime(1000), time.Hour, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", histogram[0].Count)
+	assert.Equal(t, "2", histogram[1].Count)
+	assert.Empty(t, histogram[1].Types)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChartAggregateQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+
+	_, err := s.GetChartAggregate(context.Background(), "ns1", database.CollectionName(database.CollectionMessages), fftypes.UnixTime(1000), time.Minute, 1)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetChartAggregateScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"bucket"}).AddRow("bad"))
+
+	_, err := s.GetChartAggregate(context.Background(), "ns1", database.CollectionName(database.CollectionMessages), fftypes.UnixTime(1000), time.Minute, 1)
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
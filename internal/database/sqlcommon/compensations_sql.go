@@ -0,0 +1,121 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	compensationColumns = []string{
+		"id",
+		"namespace",
+		"tx_id",
+		"operation_id",
+		"optype",
+		"status",
+		"output",
+		"error",
+		"created",
+	}
+	compensationFilterFieldMap = map[string]string{
+		"tx":        "tx_id",
+		"operation": "operation_id",
+		"type":      "optype",
+	}
+)
+
+const compensationsTable = "compensations"
+
+func (s *SQLCommon) InsertCompensation(ctx context.Context, compensation *core.Compensation) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if _, err = s.InsertTx(ctx, compensationsTable, tx,
+		sq.Insert(compensationsTable).
+			Columns(compensationColumns...).
+			Values(
+				compensation.ID,
+				compensation.Namespace,
+				compensation.Transaction,
+				compensation.Operation,
+				compensation.Type,
+				compensation.Status,
+				compensation.Output,
+				compensation.Error,
+				compensation.Created,
+			),
+		nil, // compensations are audit records, so there are no change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) compensationResult(ctx context.Context, row *sql.Rows) (*core.Compensation, error) {
+	compensation := core.Compensation{}
+	err := row.Scan(
+		&compensation.ID,
+		&compensation.Namespace,
+		&compensation.Transaction,
+		&compensation.Operation,
+		&compensation.Type,
+		&compensation.Status,
+		&compensation.Output,
+		&compensation.Error,
+		&compensation.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, compensationsTable)
+	}
+	return &compensation, nil
+}
+
+func (s *SQLCommon) GetCompensations(ctx context.Context, namespace string, filter ffapi.Filter) (compensations []*core.Compensation, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(compensationColumns...).From(compensationsTable), filter, compensationFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, compensationsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	compensations = []*core.Compensation{}
+	for rows.Next() {
+		compensation, err := s.compensationResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		compensations = append(compensations, compensation)
+	}
+
+	return compensations, s.QueryRes(ctx, compensationsTable, tx, fop, nil, fi), err
+}
@@ -0,0 +1,129 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompensationsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	txID := fftypes.NewUUID()
+	submitted := &core.Compensation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: txID,
+		Operation:   fftypes.NewUUID(),
+		Type:        core.OpTypeTokenTransfer,
+		Status:      core.CompensationStatusSubmitted,
+		Output:      fftypes.JSONObject{"tx": fftypes.NewUUID().String()},
+		Created:     fftypes.Now(),
+	}
+	err := s.InsertCompensation(ctx, submitted)
+	assert.NoError(t, err)
+
+	skipped := &core.Compensation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: txID,
+		Operation:   fftypes.NewUUID(),
+		Type:        core.OpTypeBlockchainPinBatch,
+		Status:      core.CompensationStatusSkipped,
+		Error:       "no compensating action",
+		Created:     fftypes.Now(),
+	}
+	err = s.InsertCompensation(ctx, skipped)
+	assert.NoError(t, err)
+
+	// Query back the entries for the transaction
+	fb := database.CompensationQueryFactory.NewFilter(ctx)
+	compensations, res, err := s.GetCompensations(ctx, "ns1", fb.And(fb.Eq("tx", txID)).Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, compensations, 2)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	submittedJson, _ := json.Marshal(submitted)
+	readJson, _ := json.Marshal(compensations[1])
+	assert.Equal(t, string(submittedJson), string(readJson))
+
+	// Filter by status
+	compensations, _, err = s.GetCompensations(ctx, "ns1", fb.And(fb.Eq("status", core.CompensationStatusSkipped)))
+	assert.NoError(t, err)
+	assert.Len(t, compensations, 1)
+	skippedJson, _ := json.Marshal(skipped)
+	readJson, _ = json.Marshal(compensations[0])
+	assert.Equal(t, string(skippedJson), string(readJson))
+
+	// Other namespaces do not see the entries
+	compensations, _, err = s.GetCompensations(ctx, "ns2", fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, compensations)
+}
+
+func TestInsertCompensationFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertCompensation(context.Background(), &core.Compensation{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertCompensationFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertCompensation(context.Background(), &core.Compensation{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCompensationsFilterSelectFail(t *testing.T) {
+	fb := database.CompensationQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetCompensations(context.Background(), "ns1", fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetCompensationsQueryFail(t *testing.T) {
+	fb := database.CompensationQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetCompensations(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCompensationsReadFail(t *testing.T) {
+	fb := database.CompensationQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetCompensations(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
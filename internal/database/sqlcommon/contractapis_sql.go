@@ -19,6 +19,7 @@ package sqlcommon
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/hyperledger/firefly-common/pkg/dbsql"
@@ -41,11 +42,15 @@ var (
 		"namespace",
 		"message_id",
 		"published",
+		"version",
+		"query_cache_ttl",
+		"interface_hash",
 	}
 	contractAPIsFilterFieldMap = map[string]string{
-		"interface":   "interface_id",
-		"message":     "message_id",
-		"networkname": "network_name",
+		"interface":     "interface_id",
+		"message":       "message_id",
+		"networkname":   "network_name",
+		"interfacehash": "interface_hash",
 	}
 )
 
@@ -68,6 +73,9 @@ func (s *SQLCommon) attemptContractAPIUpdate(ctx context.Context, tx *dbsql.TXWr
 			Set("network_name", networkName).
 			Set("message_id", api.Message).
 			Set("published", api.Published).
+			Set("version", api.Version).
+			Set("query_cache_ttl", queryCacheTTLString(api)).
+			Set("interface_hash", api.InterfaceHash).
 			Where(sq.Eq{"id": api.ID}),
 		func() {
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionContractAPIs, core.ChangeEventTypeUpdated, api.Namespace, api.ID)
@@ -93,9 +101,20 @@ func (s *SQLCommon) setContractAPIInsertValues(query sq.InsertBuilder, api *core
 		api.Namespace,
 		api.Message,
 		api.Published,
+		api.Version,
+		queryCacheTTLString(api),
+		api.InterfaceHash,
 	)
 }
 
+func queryCacheTTLString(api *core.ContractAPI) *string {
+	if api.QueryCacheTTL == nil {
+		return nil
+	}
+	ttl := api.QueryCacheTTL.String()
+	return &ttl
+}
+
 func (s *SQLCommon) attemptContractAPIInsert(ctx context.Context, tx *dbsql.TXWrapper, api *core.ContractAPI, requestConflictEmptyResult bool) error {
 	_, err := s.InsertTxExt(ctx, contractapisTable, tx,
 		s.setContractAPIInsertValues(sq.Insert(contractapisTable).Columns(contractAPIsColumns...), api),
@@ -112,8 +131,8 @@ func (s *SQLCommon) contractAPIExists(ctx context.Context, tx *dbsql.TXWrapper,
 				"namespace": api.Namespace,
 			},
 			sq.Or{
-				sq.Eq{"name": api.Name},
-				sq.Eq{"network_name": api.NetworkName},
+				sq.Eq{"name": api.Name, "version": api.Version},
+				sq.Eq{"network_name": api.NetworkName, "version": api.Version},
 			},
 		}),
 	)
@@ -142,8 +161,8 @@ func (s *SQLCommon) InsertOrGetContractAPI(ctx context.Context, api *core.Contra
 		sq.Eq{"namespace": api.Namespace},
 		sq.Or{
 			sq.Eq{"id": api.ID},
-			sq.Eq{"name": api.Name},
-			sq.Eq{"network_name": api.NetworkName},
+			sq.Eq{"name": api.Name, "version": api.Version},
+			sq.Eq{"network_name": api.NetworkName, "version": api.Version},
 		},
 	})
 	if queryErr != nil || existing != nil {
@@ -192,7 +211,7 @@ func (s *SQLCommon) contractAPIResult(ctx context.Context, row *sql.Rows) (*core
 	api := core.ContractAPI{
 		Interface: &fftypes.FFIReference{},
 	}
-	var networkName *string
+	var networkName, queryCacheTTL *string
 	err := row.Scan(
 		&api.ID,
 		&api.Interface.ID,
@@ -202,10 +221,19 @@ func (s *SQLCommon) contractAPIResult(ctx context.Context, row *sql.Rows) (*core
 		&api.Namespace,
 		&api.Message,
 		&api.Published,
+		&api.Version,
+		&queryCacheTTL,
+		&api.InterfaceHash,
 	)
 	if networkName != nil {
 		api.NetworkName = *networkName
 	}
+	if err == nil && queryCacheTTL != nil {
+		var ttl fftypes.FFDuration
+		if ttl, err = fftypes.ParseDurationString(*queryCacheTTL, time.Millisecond); err == nil {
+			api.QueryCacheTTL = &ttl
+		}
+	}
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, "contract")
 	}
@@ -216,7 +244,8 @@ func (s *SQLCommon) getContractAPIPred(ctx context.Context, desc string, pred in
 	rows, _, err := s.Query(ctx, contractapisTable,
 		sq.Select(contractAPIsColumns...).
 			From(contractapisTable).
-			Where(pred),
+			Where(pred).
+			OrderBy("seq DESC"),
 	)
 	if err != nil {
 		return nil, err
@@ -271,8 +300,12 @@ func (s *SQLCommon) GetContractAPIByName(ctx context.Context, namespace, name st
 	return s.getContractAPIPred(ctx, namespace+":"+name, sq.Eq{"namespace": namespace, "name": name})
 }
 
-func (s *SQLCommon) GetContractAPIByNetworkName(ctx context.Context, namespace, networkName string) (*core.ContractAPI, error) {
-	return s.getContractAPIPred(ctx, namespace+":"+networkName, sq.Eq{"namespace": namespace, "network_name": networkName})
+func (s *SQLCommon) GetContractAPIByNameAndVersion(ctx context.Context, namespace, name, version string) (*core.ContractAPI, error) {
+	return s.getContractAPIPred(ctx, namespace+":"+name+":"+version, sq.Eq{"namespace": namespace, "name": name, "version": version})
+}
+
+func (s *SQLCommon) GetContractAPIByNetworkName(ctx context.Context, namespace, networkName, version string) (*core.ContractAPI, error) {
+	return s.getContractAPIPred(ctx, namespace+":"+networkName+":"+version, sq.Eq{"namespace": namespace, "network_name": networkName, "version": version})
 }
 
 func (s *SQLCommon) DeleteContractAPI(ctx context.Context, namespace string, id *fftypes.UUID) error {
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -39,6 +40,7 @@ func TestContractAPIE2EWithDB(t *testing.T) {
 
 	// Create a new contract API
 	apiID := fftypes.NewUUID()
+	queryCacheTTL := fftypes.FFDuration(30 * time.Second)
 	interfaceID := fftypes.NewUUID()
 
 	contractAPI := &core.ContractAPI{
@@ -51,7 +53,9 @@ func TestContractAPIE2EWithDB(t *testing.T) {
 			Name:    "banana",
 			Version: "v1.0.0",
 		},
-		Message: fftypes.NewUUID(),
+		Message:       fftypes.NewUUID(),
+		QueryCacheTTL: &queryCacheTTL,
+		InterfaceHash: fftypes.NewRandB32(),
 	}
 
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionContractAPIs, core.ChangeEventTypeCreated, "ns1", apiID, mock.Anything).Return()
@@ -83,8 +87,10 @@ func TestContractAPIE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, dataRead)
 	assert.Equal(t, *apiID, *dataRead.ID)
+	assert.Equal(t, queryCacheTTL, *dataRead.QueryCacheTTL)
+	assert.Equal(t, contractAPI.InterfaceHash, dataRead.InterfaceHash)
 
-	dataRead, err = s.GetContractAPIByNetworkName(ctx, "ns1", "banana-net")
+	dataRead, err = s.GetContractAPIByNetworkName(ctx, "ns1", "banana-net", "")
 	assert.NoError(t, err)
 	assert.NotNil(t, dataRead)
 	assert.Equal(t, *apiID, *dataRead.ID)
@@ -111,7 +117,41 @@ func TestContractAPIE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, contractAPI.ID, existing.ID)
 
-	// Delete the API
+	// Insert a new version with the same name and network name
+	apiV2 := &core.ContractAPI{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Name:        "banana",
+		NetworkName: "banana-net",
+		Version:     "2.0.0",
+		Interface: &fftypes.FFIReference{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionContractAPIs, core.ChangeEventTypeCreated, "ns1", apiV2.ID, mock.Anything).Return()
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionContractAPIs, core.ChangeEventTypeDeleted, "ns1", apiV2.ID, mock.Anything).Return()
+	existing, err = s.InsertOrGetContractAPI(ctx, apiV2)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// The latest version is returned by name, and each version can be retrieved explicitly
+	dataRead, err = s.GetContractAPIByName(ctx, "ns1", "banana")
+	assert.NoError(t, err)
+	assert.Equal(t, *apiV2.ID, *dataRead.ID)
+	assert.Equal(t, "2.0.0", dataRead.Version)
+	dataRead, err = s.GetContractAPIByNameAndVersion(ctx, "ns1", "banana", "")
+	assert.NoError(t, err)
+	assert.Equal(t, *apiID, *dataRead.ID)
+	dataRead, err = s.GetContractAPIByNameAndVersion(ctx, "ns1", "banana", "2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, *apiV2.ID, *dataRead.ID)
+	dataRead, err = s.GetContractAPIByNetworkName(ctx, "ns1", "banana-net", "2.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, *apiV2.ID, *dataRead.ID)
+
+	// Delete the APIs
+	err = s.DeleteContractAPI(ctx, "ns1", apiV2.ID)
+	assert.NoError(t, err)
 	err = s.DeleteContractAPI(ctx, "ns1", contractAPI.ID)
 	assert.NoError(t, err)
 }
@@ -213,8 +253,8 @@ func TestContractAPIDBNoRows(t *testing.T) {
 func TestGetContractAPIs(t *testing.T) {
 	fb := database.ContractAPIQueryFactory.NewFilter(context.Background())
 	s, mock := newMockProvider().init()
-	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published"}).
-		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "banana", "banana", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", true)
+	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published", "version", "query_cache_ttl", "interface_hash"}).
+		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "banana", "banana", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", true, "", nil, nil)
 	mock.ExpectQuery("SELECT .*").WillReturnRows(rows)
 	_, _, err := s.GetContractAPIs(context.Background(), "ns1", fb.And())
 	assert.NoError(t, err)
@@ -240,9 +280,9 @@ func TestGetContractAPIsQueryFail(t *testing.T) {
 func TestGetContractAPIsQueryResultFail(t *testing.T) {
 	fb := database.ContractAPIQueryFactory.NewFilter(context.Background())
 	s, mock := newMockProvider().init()
-	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published"}).
-		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "apple", "apple", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", false).
-		AddRow("69851ca3-e9f9-489b-8731-dc6a7d990291", "4db4952e-4669-4243-a387-8f0f609e92bd", nil, "orange", "orange", nil, "acfe07a2-117f-46b7-8d47-e3beb7cc382f", false)
+	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published", "version", "query_cache_ttl", "interface_hash"}).
+		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "apple", "apple", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", false, "", nil, nil).
+		AddRow("69851ca3-e9f9-489b-8731-dc6a7d990291", "4db4952e-4669-4243-a387-8f0f609e92bd", nil, "orange", "orange", nil, "acfe07a2-117f-46b7-8d47-e3beb7cc382f", false, "", nil, nil)
 	mock.ExpectQuery("SELECT .*").WillReturnRows(rows)
 	_, _, err := s.GetContractAPIs(context.Background(), "ns1", fb.And())
 	assert.Regexp(t, "FF10121", err)
@@ -251,8 +291,8 @@ func TestGetContractAPIsQueryResultFail(t *testing.T) {
 
 func TestGetContractAPIByName(t *testing.T) {
 	s, mock := newMockProvider().init()
-	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published"}).
-		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "banana", "banana", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", true)
+	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published", "version", "query_cache_ttl", "interface_hash"}).
+		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "banana", "banana", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", true, "", nil, nil)
 	mock.ExpectQuery("SELECT .*").WillReturnRows(rows)
 	api, err := s.GetContractAPIByName(context.Background(), "ns1", "banana")
 	assert.NotNil(t, api)
@@ -260,6 +300,16 @@ func TestGetContractAPIByName(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetContractAPIByNameBadQueryCacheTTL(t *testing.T) {
+	s, mock := newMockProvider().init()
+	rows := sqlmock.NewRows([]string{"id", "interface_id", "location", "name", "network_name", "namespace", "message_id", "published", "version", "query_cache_ttl", "interface_hash"}).
+		AddRow("7e2c001c-e270-4fd7-9e82-9dacee843dc2", "8fcc4938-7d8b-4c00-a71b-1b46837c8ab1", nil, "banana", "banana", "ns1", "acfe07a2-117f-46b7-8d47-e3beb7cc382f", true, "", "bad", nil)
+	mock.ExpectQuery("SELECT .*").WillReturnRows(rows)
+	_, err := s.GetContractAPIByName(context.Background(), "ns1", "banana")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteContractFailBegin(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
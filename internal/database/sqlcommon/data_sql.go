@@ -48,17 +48,29 @@ var (
 		"blob_size",
 		"public",
 		"value_size",
+		"pin_status",
+		"pin_request",
+		"hash_algorithm",
+		"canonicalization",
+		"migration_from",
+		"migration_datatype",
+		"migration_time",
 	}
 	dataColumnsWithValue = append(append([]string{}, dataColumnsNoValue...), "value")
 	dataFilterFieldMap   = map[string]string{
-		"validator":        "validator",
-		"datatype.name":    "datatype_name",
-		"datatype.version": "datatype_version",
-		"blob.hash":        "blob_hash",
-		"blob.public":      "blob_public",
-		"blob.name":        "blob_name",
-		"blob.path":        "blob_path",
-		"blob.size":        "blob_size",
+		"validator":             "validator",
+		"datatype.name":         "datatype_name",
+		"datatype.version":      "datatype_version",
+		"blob.hash":             "blob_hash",
+		"blob.public":           "blob_public",
+		"blob.name":             "blob_name",
+		"blob.path":             "blob_path",
+		"blob.size":             "blob_size",
+		"pin.status":            "pin_status",
+		"pin.request":           "pin_request",
+		"migration.fromversion": "migration_from",
+		"migration.datatype":    "migration_datatype",
+		"migration.migrated":    "migration_time",
 	}
 )
 
@@ -73,6 +85,14 @@ func (s *SQLCommon) attemptDataUpdate(ctx context.Context, tx *dbsql.TXWrapper,
 	if blob == nil {
 		blob = &core.BlobRef{}
 	}
+	pin := data.Pin
+	if pin == nil {
+		pin = &core.DataPin{}
+	}
+	migration := data.Migration
+	if migration == nil {
+		migration = &core.DataMigration{}
+	}
 	data.CalcPath()
 	return s.UpdateTx(ctx, dataTable, tx,
 		sq.Update(dataTable).
@@ -88,6 +108,13 @@ func (s *SQLCommon) attemptDataUpdate(ctx context.Context, tx *dbsql.TXWrapper,
 			Set("blob_size", blob.Size).
 			Set("public", data.Public).
 			Set("value_size", data.ValueSize).
+			Set("pin_status", pin.Status).
+			Set("pin_request", pin.Request).
+			Set("hash_algorithm", data.HashAlgorithm).
+			Set("canonicalization", data.Canonicalization).
+			Set("migration_from", migration.FromVersion).
+			Set("migration_datatype", migration.Datatype).
+			Set("migration_time", migration.Migrated).
 			Set("value", data.Value).
 			Where(sq.Eq{
 				"id":        data.ID,
@@ -108,6 +135,14 @@ func (s *SQLCommon) setDataInsertValues(query sq.InsertBuilder, data *core.Data)
 	if blob == nil {
 		blob = &core.BlobRef{}
 	}
+	pin := data.Pin
+	if pin == nil {
+		pin = &core.DataPin{}
+	}
+	migration := data.Migration
+	if migration == nil {
+		migration = &core.DataMigration{}
+	}
 	data.CalcPath()
 	return query.Values(
 		data.ID,
@@ -124,6 +159,13 @@ func (s *SQLCommon) setDataInsertValues(query sq.InsertBuilder, data *core.Data)
 		blob.Size,
 		data.Public,
 		data.ValueSize,
+		pin.Status,
+		pin.Request,
+		data.HashAlgorithm,
+		data.Canonicalization,
+		migration.FromVersion,
+		migration.Datatype,
+		migration.Migrated,
 		data.Value,
 	)
 }
@@ -232,8 +274,10 @@ func (s *SQLCommon) InsertDataArray(ctx context.Context, dataArray core.DataArra
 
 func (s *SQLCommon) dataResult(ctx context.Context, row *sql.Rows, withValue bool) (*core.Data, error) {
 	data := core.Data{
-		Datatype: &core.DatatypeRef{},
-		Blob:     &core.BlobRef{},
+		Datatype:  &core.DatatypeRef{},
+		Blob:      &core.BlobRef{},
+		Pin:       &core.DataPin{},
+		Migration: &core.DataMigration{},
 	}
 	results := []interface{}{
 		&data.ID,
@@ -250,6 +294,13 @@ func (s *SQLCommon) dataResult(ctx context.Context, row *sql.Rows, withValue boo
 		&data.Blob.Size,
 		&data.Public,
 		&data.ValueSize,
+		&data.Pin.Status,
+		&data.Pin.Request,
+		&data.HashAlgorithm,
+		&data.Canonicalization,
+		&data.Migration.FromVersion,
+		&data.Migration.Datatype,
+		&data.Migration.Migrated,
 	}
 	if withValue {
 		results = append(results, &data.Value)
@@ -261,6 +312,12 @@ func (s *SQLCommon) dataResult(ctx context.Context, row *sql.Rows, withValue boo
 	if data.Datatype.Name == "" && data.Datatype.Version == "" {
 		data.Datatype = nil
 	}
+	if data.Pin.Status == "" {
+		data.Pin = nil
+	}
+	if data.Migration.FromVersion == "" {
+		data.Migration = nil
+	}
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, dataTable)
 	}
@@ -94,15 +94,21 @@ func TestDataE2EWithDB(t *testing.T) {
 			Name:    "customer",
 			Version: "0.0.1",
 		},
-		Hash:    fftypes.NewRandB32(),
-		Created: fftypes.Now(),
-		Value:   fftypes.JSONAnyPtr(val2.String()),
+		Hash:             fftypes.NewRandB32(),
+		HashAlgorithm:    core.HashAlgorithmSHA3_256,
+		Canonicalization: core.DataCanonicalizationJCS,
+		Created:          fftypes.Now(),
+		Value:            fftypes.JSONAnyPtr(val2.String()),
 		Blob: &core.BlobRef{
 			Hash:   fftypes.NewRandB32(),
 			Public: "Qmf412jQZiuVUtdgnB36FXFX7xg5V6KEbSJ4dpQuhkLyfD",
 			Name:   "path/to/myfile.ext",
 			Size:   12345,
 		},
+		Pin: &core.DataPin{
+			Status:  core.DataPinStatusQueued,
+			Request: "pin-request-1",
+		},
 	}
 
 	// Check disallows hash update, regardless of optimization
@@ -155,15 +161,34 @@ func TestDataE2EWithDB(t *testing.T) {
 	err = s.UpdateData(ctx, "ns1", dataID, up)
 	assert.NoError(t, err)
 
+	up = database.DataQueryFactory.NewUpdate(ctx).Set("pin.status", core.DataPinStatusPinned)
+	err = s.UpdateData(ctx, "ns1", dataID, up)
+	assert.NoError(t, err)
+
+	migrationDatatype := fftypes.NewUUID()
+	up = database.DataQueryFactory.NewUpdate(ctx).
+		Set("migration.fromversion", "1.0.0").
+		Set("migration.datatype", migrationDatatype).
+		Set("migration.migrated", fftypes.Now())
+	err = s.UpdateData(ctx, "ns1", dataID, up)
+	assert.NoError(t, err)
+
 	// Test find updated value
 	filter = fb.And(
 		fb.Eq("id", dataUpdated.ID.String()),
 		fb.Eq("datatype.version", v2),
+		fb.Eq("pin.status", core.DataPinStatusPinned),
+		fb.Eq("pin.request", "pin-request-1"),
+		fb.Eq("migration.fromversion", "1.0.0"),
+		fb.Eq("migration.datatype", migrationDatatype),
 	)
 	dataRes, res, err := s.GetData(ctx, "ns1", filter.Count(true))
 	assert.NoError(t, err)
 	assert.Equal(t, 1, len(dataRes))
 	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Equal(t, "1.0.0", dataRes[0].Migration.FromVersion)
+	assert.Equal(t, *migrationDatatype, *dataRes[0].Migration.Datatype)
+	assert.NotNil(t, dataRes[0].Migration.Migrated)
 
 	s.callbacks.AssertExpectations(t)
 
@@ -42,9 +42,11 @@ var (
 		"hash",
 		"created",
 		"value",
+		"migrate_from",
 	}
 	datatypeFilterFieldMap = map[string]string{
-		"message": "message_id",
+		"message":               "message_id",
+		"migration.fromversion": "migrate_from",
 	}
 )
 
@@ -57,6 +59,11 @@ func (s *SQLCommon) UpsertDatatype(ctx context.Context, datatype *core.Datatype,
 	}
 	defer s.RollbackTx(ctx, tx, autoCommit)
 
+	migrateFrom := ""
+	if datatype.Migration != nil {
+		migrateFrom = datatype.Migration.FromVersion
+	}
+
 	existing := false
 	if allowExisting {
 		// Do a select within the transaction to detemine if the UUID already exists
@@ -87,6 +94,7 @@ func (s *SQLCommon) UpsertDatatype(ctx context.Context, datatype *core.Datatype,
 				Set("hash", datatype.Hash).
 				Set("created", datatype.Created).
 				Set("value", datatype.Value).
+				Set("migrate_from", migrateFrom).
 				Where(sq.Eq{"id": datatype.ID}),
 			func() {
 				s.callbacks.UUIDCollectionNSEvent(database.CollectionDataTypes, core.ChangeEventTypeUpdated, datatype.Namespace, datatype.ID)
@@ -108,6 +116,7 @@ func (s *SQLCommon) UpsertDatatype(ctx context.Context, datatype *core.Datatype,
 					datatype.Hash,
 					datatype.Created,
 					datatype.Value,
+					migrateFrom,
 				),
 			func() {
 				s.callbacks.UUIDCollectionNSEvent(database.CollectionDataTypes, core.ChangeEventTypeCreated, datatype.Namespace, datatype.ID)
@@ -122,6 +131,7 @@ func (s *SQLCommon) UpsertDatatype(ctx context.Context, datatype *core.Datatype,
 
 func (s *SQLCommon) datatypeResult(ctx context.Context, row *sql.Rows) (*core.Datatype, error) {
 	var datatype core.Datatype
+	var migrateFrom string
 	err := row.Scan(
 		&datatype.ID,
 		&datatype.Message,
@@ -132,10 +142,14 @@ func (s *SQLCommon) datatypeResult(ctx context.Context, row *sql.Rows) (*core.Da
 		&datatype.Hash,
 		&datatype.Created,
 		&datatype.Value,
+		&migrateFrom,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, datatypesTable)
 	}
+	if migrateFrom != "" {
+		datatype.Migration = &core.DatatypeMigration{FromVersion: migrateFrom}
+	}
 	return &datatype, nil
 }
 
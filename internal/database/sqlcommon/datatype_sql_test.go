@@ -89,6 +89,9 @@ func TestDatatypeE2EWithDB(t *testing.T) {
 		Hash:      randB32,
 		Created:   fftypes.Now(),
 		Value:     fftypes.JSONAnyPtr(val2.String()),
+		Migration: &core.DatatypeMigration{
+			FromVersion: "0.0.0",
+		},
 	}
 	err = s.UpsertDatatype(context.Background(), datatypeUpdated, true)
 	assert.NoError(t, err)
@@ -107,6 +110,7 @@ func TestDatatypeE2EWithDB(t *testing.T) {
 		fb.Eq("validator", string(datatypeUpdated.Validator)),
 		fb.Eq("name", datatypeUpdated.Name),
 		fb.Eq("version", datatypeUpdated.Version),
+		fb.Eq("migration.fromversion", "0.0.0"),
 		fb.Gt("created", "0"),
 	)
 	datatypes, res, err := s.GetDatatypes(ctx, "ns1", filter.Count(true))
@@ -0,0 +1,138 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	delegationColumns = []string{
+		"id",
+		"namespace",
+		"delegator",
+		"delegate",
+		"scope",
+		"expires",
+		"message_id",
+		"created",
+	}
+	delegationFilterFieldMap = map[string]string{
+		"message": "message_id",
+	}
+)
+
+const delegationsTable = "delegations"
+
+func (s *SQLCommon) InsertDelegation(ctx context.Context, delegation *core.Delegation) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if _, err = s.InsertTx(ctx, delegationsTable, tx,
+		sq.Insert(delegationsTable).
+			Columns(delegationColumns...).
+			Values(
+				delegation.ID,
+				delegation.Namespace,
+				delegation.Delegator,
+				delegation.Delegate,
+				delegation.Scope,
+				delegation.Expires,
+				delegation.Message,
+				delegation.Created,
+			),
+		nil, // the confirmation of a delegation is reported via a delegation_confirmed event
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) delegationResult(ctx context.Context, row *sql.Rows) (*core.Delegation, error) {
+	delegation := core.Delegation{}
+	err := row.Scan(
+		&delegation.ID,
+		&delegation.Namespace,
+		&delegation.Delegator,
+		&delegation.Delegate,
+		&delegation.Scope,
+		&delegation.Expires,
+		&delegation.Message,
+		&delegation.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, delegationsTable)
+	}
+	return &delegation, nil
+}
+
+func (s *SQLCommon) GetDelegationByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Delegation, error) {
+	rows, _, err := s.Query(ctx, delegationsTable,
+		sq.Select(delegationColumns...).
+			From(delegationsTable).
+			Where(sq.Eq{"namespace": namespace, "id": id}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Delegation '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.delegationResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetDelegations(ctx context.Context, namespace string, filter ffapi.Filter) (delegations []*core.Delegation, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(delegationColumns...).From(delegationsTable),
+		filter, delegationFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, delegationsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	delegations = []*core.Delegation{}
+	for rows.Next() {
+		delegation, err := s.delegationResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		delegations = append(delegations, delegation)
+	}
+
+	return delegations, s.QueryRes(ctx, delegationsTable, tx, fop, nil, fi), err
+}
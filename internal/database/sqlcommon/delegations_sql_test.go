@@ -0,0 +1,132 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegationsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	delegation := &core.Delegation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Delegator: "did:firefly:org/org1",
+		Delegate:  "did:firefly:org/org2",
+		Scope:     fftypes.NewFFStringArray("messages", "transfers"),
+		Expires:   fftypes.Now(),
+		Message:   fftypes.NewUUID(),
+		Created:   fftypes.Now(),
+	}
+	err := s.InsertDelegation(ctx, delegation)
+	assert.NoError(t, err)
+
+	// Query back the delegation by ID
+	delegationRead, err := s.GetDelegationByID(ctx, "ns1", delegation.ID)
+	assert.NoError(t, err)
+	delegationJson, _ := json.Marshal(delegation)
+	readJson, _ := json.Marshal(delegationRead)
+	assert.Equal(t, string(delegationJson), string(readJson))
+
+	// Query back the delegations between the identities
+	fb := database.DelegationQueryFactory.NewFilter(ctx)
+	delegations, res, err := s.GetDelegations(ctx, "ns1", fb.And(
+		fb.Eq("delegator", "did:firefly:org/org1"),
+		fb.Eq("delegate", "did:firefly:org/org2"),
+	).Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, delegations, 1)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	readJson, _ = json.Marshal(delegations[0])
+	assert.Equal(t, string(delegationJson), string(readJson))
+
+	// Other namespaces do not see the delegation
+	delegationRead, err = s.GetDelegationByID(ctx, "ns2", delegation.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, delegationRead)
+}
+
+func TestInsertDelegationFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertDelegation(context.Background(), &core.Delegation{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertDelegationFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertDelegation(context.Background(), &core.Delegation{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDelegationByIDSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetDelegationByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDelegationByIDReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetDelegationByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDelegationsFilterSelectFail(t *testing.T) {
+	fb := database.DelegationQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetDelegations(context.Background(), "ns1", fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetDelegationsQueryFail(t *testing.T) {
+	fb := database.DelegationQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetDelegations(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDelegationsReadFail(t *testing.T) {
+	fb := database.DelegationQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetDelegations(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
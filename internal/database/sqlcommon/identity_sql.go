@@ -44,6 +44,7 @@ var (
 		"messages_claim",
 		"messages_verification",
 		"messages_update",
+		"verified_subject",
 		"created",
 		"updated",
 	}
@@ -53,6 +54,7 @@ var (
 		"messages.claim":        "messages_claim",
 		"messages.verification": "messages_verification",
 		"messages.update":       "messages_update",
+		"verifiedsubject":       "verified_subject",
 	}
 )
 
@@ -71,6 +73,7 @@ func (s *SQLCommon) attemptIdentityUpdate(ctx context.Context, tx *dbsql.TXWrapp
 			Set("messages_claim", identity.Messages.Claim).
 			Set("messages_verification", identity.Messages.Verification).
 			Set("messages_update", identity.Messages.Update).
+			Set("verified_subject", identity.VerifiedSubject).
 			Set("updated", identity.Updated).
 			Where(sq.Eq{
 				"id":        identity.ID,
@@ -99,6 +102,7 @@ func (s *SQLCommon) attemptIdentityInsert(ctx context.Context, tx *dbsql.TXWrapp
 				identity.Messages.Claim,
 				identity.Messages.Verification,
 				identity.Messages.Update,
+				identity.VerifiedSubject,
 				identity.Created,
 				identity.Updated,
 			),
@@ -166,6 +170,7 @@ func (s *SQLCommon) identityResult(ctx context.Context, row *sql.Rows) (*core.Id
 		&identity.Messages.Claim,
 		&identity.Messages.Verification,
 		&identity.Messages.Update,
+		&identity.VerifiedSubject,
 		&identity.Created,
 		&identity.Updated,
 	)
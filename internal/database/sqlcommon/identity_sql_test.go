@@ -90,7 +90,8 @@ func TestIdentitiesE2EWithDB(t *testing.T) {
 			Verification: fftypes.NewUUID(),
 			Update:       fftypes.NewUUID(),
 		},
-		Created: identity.Created,
+		VerifiedSubject: "CN=identity2",
+		Created:         identity.Created,
 	}
 	err = s.UpsertIdentity(context.Background(), identityUpdated, database.UpsertOptimizationExisting)
 	assert.NoError(t, err)
@@ -107,6 +108,7 @@ func TestIdentitiesE2EWithDB(t *testing.T) {
 	filter := fb.And(
 		fb.Eq("description", string(identityUpdated.Description)),
 		fb.Eq("did", identityUpdated.DID),
+		fb.Eq("verifiedsubject", identityUpdated.VerifiedSubject),
 	)
 	identityRes, res, err := s.GetIdentities(ctx, "ns1", filter.Count(true))
 	assert.NoError(t, err)
@@ -0,0 +1,122 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	inboundEventColumns = []string{
+		"plugin",
+		"namespace",
+		"payload",
+		"created",
+	}
+	inboundEventFilterFieldMap = map[string]string{}
+)
+
+const inboundEventsTable = "inboundevents"
+
+func (s *SQLCommon) InsertInboundEvent(ctx context.Context, event *core.InboundEvent) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if event.Sequence, err = s.InsertTx(ctx, inboundEventsTable, tx,
+		sq.Insert(inboundEventsTable).
+			Columns(inboundEventColumns...).
+			Values(
+				event.Plugin,
+				event.Namespace,
+				event.Payload,
+				event.Created,
+			),
+		nil, // the queue consumer polls for new entries, so there are no change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) inboundEventResult(ctx context.Context, row *sql.Rows) (*core.InboundEvent, error) {
+	event := core.InboundEvent{}
+	err := row.Scan(
+		&event.Plugin,
+		&event.Namespace,
+		&event.Payload,
+		&event.Created,
+		&event.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, inboundEventsTable)
+	}
+	return &event, nil
+}
+
+func (s *SQLCommon) GetInboundEvents(ctx context.Context, plugin, namespace string, filter ffapi.Filter) (events []*core.InboundEvent, res *ffapi.FilterResult, err error) {
+	cols := append([]string{}, inboundEventColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(inboundEventsTable), filter, inboundEventFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"plugin": plugin, "namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, inboundEventsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	events = []*core.InboundEvent{}
+	for rows.Next() {
+		event, err := s.inboundEventResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, s.QueryRes(ctx, inboundEventsTable, tx, fop, nil, fi), err
+}
+
+func (s *SQLCommon) DeleteInboundEvent(ctx context.Context, sequence int64) error {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, inboundEventsTable, tx, sq.Delete(inboundEventsTable).Where(sq.Eq{
+		s.SequenceColumn(): sequence,
+	}), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,138 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboundEventsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newEvent := func(plugin, namespace string) *core.InboundEvent {
+		return &core.InboundEvent{
+			Plugin:    plugin,
+			Namespace: namespace,
+			Payload:   fmt.Sprintf(`{"id":"%s"}`, fftypes.NewUUID()),
+			Created:   fftypes.Now(),
+		}
+	}
+
+	event1 := newEvent("erc20", "ns1")
+	err := s.InsertInboundEvent(ctx, event1)
+	assert.NoError(t, err)
+	event2 := newEvent("erc20", "ns2")
+	err = s.InsertInboundEvent(ctx, event2)
+	assert.NoError(t, err)
+	event3 := newEvent("erc20", "ns1")
+	err = s.InsertInboundEvent(ctx, event3)
+	assert.NoError(t, err)
+	assert.Greater(t, event3.Sequence, event1.Sequence)
+
+	// Each connection has its own queue
+	fb := database.InboundEventQueryFactory.NewFilter(ctx)
+	events, res, err := s.GetInboundEvents(ctx, "erc20", "ns1", fb.And().Sort("sequence").Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	event1Json, _ := json.Marshal(event1)
+	readJson, _ := json.Marshal(events[0])
+	assert.Equal(t, string(event1Json), string(readJson))
+
+	// Remove the head of the queue
+	err = s.DeleteInboundEvent(ctx, event1.Sequence)
+	assert.NoError(t, err)
+	events, _, err = s.GetInboundEvents(ctx, "erc20", "ns1", fb.And().Sort("sequence"))
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, event3.Sequence, events[0].Sequence)
+
+	err = s.DeleteInboundEvent(ctx, event1.Sequence)
+	assert.Equal(t, fftypes.DeleteRecordNotFound, err)
+}
+
+func TestInsertInboundEventFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertInboundEvent(context.Background(), &core.InboundEvent{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertInboundEventFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertInboundEvent(context.Background(), &core.InboundEvent{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetInboundEventsFilterSelectFail(t *testing.T) {
+	fb := database.InboundEventQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetInboundEvents(context.Background(), "erc20", "ns1", fb.And(fb.Eq("sequence", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetInboundEventsQueryFail(t *testing.T) {
+	fb := database.InboundEventQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetInboundEvents(context.Background(), "erc20", "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetInboundEventsReadFail(t *testing.T) {
+	fb := database.InboundEventQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"plugin"}).AddRow("only one"))
+	_, _, err := s.GetInboundEvents(context.Background(), "erc20", "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteInboundEventFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteInboundEvent(context.Background(), 12345)
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteInboundEventFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteInboundEvent(context.Background(), 12345)
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,133 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+var (
+	leaderLeaseColumns = []string{
+		"namespace",
+		"holder",
+		"expires",
+	}
+)
+
+const leaderLeasesTable = "leaderleases"
+
+func (s *SQLCommon) AcquireLeaderLease(ctx context.Context, namespace, holder string, expiry *fftypes.FFTime) (acquired bool, err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	// Extend our own lease, or take over one that has expired
+	updated, err := s.UpdateTx(ctx, leaderLeasesTable, tx,
+		sq.Update(leaderLeasesTable).
+			Set("holder", holder).
+			Set("expires", expiry).
+			Where(sq.And{
+				sq.Eq{"namespace": namespace},
+				sq.Or{
+					sq.Eq{"holder": holder},
+					sq.LtOrEq{"expires": fftypes.Now()},
+				},
+			}),
+		nil, // no change events for leader leases
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if updated == 0 {
+		// Either another holder has a live lease, or there is no lease yet for this namespace
+		existing, err := s.leaderLeaseExists(ctx, tx, namespace)
+		if err != nil {
+			return false, err
+		}
+		if existing {
+			return false, s.CommitTx(ctx, tx, autoCommit)
+		}
+
+		_, insertErr := s.InsertTxExt(ctx, leaderLeasesTable, tx,
+			sq.Insert(leaderLeasesTable).
+				Columns(leaderLeaseColumns...).
+				Values(
+					namespace,
+					holder,
+					expiry,
+				),
+			nil,  // no change events for leader leases
+			true, /* another instance might insert the lease first */
+		)
+		if insertErr != nil {
+			// If another instance starting at the same time inserted the lease first, then we did not acquire it
+			existing, err = s.leaderLeaseExists(ctx, tx, namespace)
+			if err != nil {
+				return false, err
+			}
+			if !existing {
+				return false, insertErr
+			}
+			return false, s.CommitTx(ctx, tx, autoCommit)
+		}
+	}
+
+	return true, s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) leaderLeaseExists(ctx context.Context, tx *dbsql.TXWrapper, namespace string) (bool, error) {
+	leaseRows, _, err := s.QueryTx(ctx, leaderLeasesTable, tx,
+		sq.Select("seq").
+			From(leaderLeasesTable).
+			Where(sq.Eq{"namespace": namespace}),
+	)
+	if err != nil {
+		return false, err
+	}
+	defer leaseRows.Close()
+	return leaseRows.Next(), nil
+}
+
+func (s *SQLCommon) ReleaseLeaderLease(ctx context.Context, namespace, holder string) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	// Expire the lease immediately, so a standby instance can take over without waiting
+	if _, err = s.UpdateTx(ctx, leaderLeasesTable, tx,
+		sq.Update(leaderLeasesTable).
+			Set("expires", 0).
+			Where(sq.Eq{
+				"namespace": namespace,
+				"holder":    holder,
+			}),
+		nil, // no change events for leader leases
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,163 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeaderLeasesE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	future := fftypes.FFTime(time.Now().Add(time.Hour))
+	past := fftypes.FFTime(time.Now().Add(-time.Hour))
+
+	// First holder takes the lease
+	acquired, err := s.AcquireLeaderLease(ctx, "ns1", "instance1", &future)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Second holder cannot take it while it is live
+	acquired, err = s.AcquireLeaderLease(ctx, "ns1", "instance2", &future)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Leases are per namespace
+	acquired, err = s.AcquireLeaderLease(ctx, "ns2", "instance2", &future)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// First holder can renew, and let it lapse
+	acquired, err = s.AcquireLeaderLease(ctx, "ns1", "instance1", &past)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Second holder can take over the expired lease
+	acquired, err = s.AcquireLeaderLease(ctx, "ns1", "instance2", &future)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Release by a non-holder has no effect
+	err = s.ReleaseLeaderLease(ctx, "ns1", "instance1")
+	assert.NoError(t, err)
+	acquired, err = s.AcquireLeaderLease(ctx, "ns1", "instance1", &future)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Release by the holder allows an immediate take over
+	err = s.ReleaseLeaderLease(ctx, "ns1", "instance2")
+	assert.NoError(t, err)
+	acquired, err = s.AcquireLeaderLease(ctx, "ns1", "instance1", &future)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestAcquireLeaderLeaseFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	_, err := s.AcquireLeaderLease(context.Background(), "ns1", "instance1", fftypes.Now())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLeaderLeaseFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.AcquireLeaderLease(context.Background(), "ns1", "instance1", fftypes.Now())
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLeaderLeaseFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.AcquireLeaderLease(context.Background(), "ns1", "instance1", fftypes.Now())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLeaderLeaseFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	mock.ExpectRollback()
+	_, err := s.AcquireLeaderLease(context.Background(), "ns1", "instance1", fftypes.Now())
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLeaderLeaseInsertConflict(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("UNIQUE constraint failed: leaderleases.namespace"))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(12345))
+	mock.ExpectCommit()
+	acquired, err := s.AcquireLeaderLease(context.Background(), "ns1", "instance1", fftypes.Now())
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAcquireLeaderLeaseInsertConflictFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"seq"}))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	_, err := s.AcquireLeaderLease(context.Background(), "ns1", "instance1", fftypes.Now())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseLeaderLeaseFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.ReleaseLeaderLease(context.Background(), "ns1", "instance1")
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReleaseLeaderLeaseFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.ReleaseLeaderLease(context.Background(), "ns1", "instance1")
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
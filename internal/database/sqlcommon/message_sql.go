@@ -57,6 +57,7 @@ var (
 		"tx_parent_id",
 		"batch_id",
 		"idempotency_key",
+		"callback",
 	}
 	msgFilterFieldMap = map[string]string{
 		"type":           "mtype",
@@ -104,6 +105,7 @@ func (s *SQLCommon) attemptMessageUpdate(ctx context.Context, tx *dbsql.TXWrappe
 			Set("tx_parent_id", txParentID).
 			Set("batch_id", message.BatchID).
 			Set("idempotency_key", message.IdempotencyKey).
+			Set("callback", message.Callback).
 			Where(sq.Eq{
 				"id":              message.Header.ID,
 				"hash":            message.Hash,
@@ -147,6 +149,7 @@ func (s *SQLCommon) setMessageInsertValues(query sq.InsertBuilder, message *core
 		txParentID,
 		message.BatchID,
 		message.IdempotencyKey,
+		message.Callback,
 	)
 }
 
@@ -461,6 +464,7 @@ func (s *SQLCommon) msgResult(ctx context.Context, row *sql.Rows) (*core.Message
 		&txParent.ID,
 		&msg.BatchID,
 		&msg.IdempotencyKey,
+		&msg.Callback,
 		// Must be added to the list of columns in all selects
 		&msg.Sequence,
 	)
@@ -0,0 +1,93 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+)
+
+// The functions in this file wrap the query functions of the embedded dbsql.Database,
+// so that the duration (and any failure) of each database operation is recorded when
+// metrics are enabled.
+
+func (s *SQLCommon) observe(table, operation string, startTime time.Time, err error) {
+	if s.metrics.IsMetricsEnabled() {
+		s.metrics.DatabaseOperation(table, operation, time.Since(startTime), err)
+	}
+}
+
+func (s *SQLCommon) Query(ctx context.Context, table string, q sq.SelectBuilder) (*sql.Rows, *dbsql.TXWrapper, error) {
+	startTime := time.Now()
+	rows, tx, err := s.Database.Query(ctx, table, q)
+	s.observe(table, "query", startTime, err)
+	return rows, tx, err
+}
+
+func (s *SQLCommon) QueryTx(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.SelectBuilder) (*sql.Rows, *dbsql.TXWrapper, error) {
+	startTime := time.Now()
+	rows, tx, err := s.Database.QueryTx(ctx, table, tx, q)
+	s.observe(table, "query", startTime, err)
+	return rows, tx, err
+}
+
+func (s *SQLCommon) QueryRes(ctx context.Context, table string, tx *dbsql.TXWrapper, fop sq.Sqlizer, qm dbsql.QueryModifier, fi *ffapi.FilterInfo) *ffapi.FilterResult {
+	startTime := time.Now()
+	res := s.Database.QueryRes(ctx, table, tx, fop, qm, fi)
+	s.observe(table, "count", startTime, nil)
+	return res
+}
+
+func (s *SQLCommon) InsertTx(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.InsertBuilder, postCommit func()) (int64, error) {
+	startTime := time.Now()
+	sequence, err := s.Database.InsertTx(ctx, table, tx, q, postCommit)
+	s.observe(table, "insert", startTime, err)
+	return sequence, err
+}
+
+func (s *SQLCommon) InsertTxExt(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.InsertBuilder, postCommit func(), requestConflictEmptyResult bool) (int64, error) {
+	startTime := time.Now()
+	sequence, err := s.Database.InsertTxExt(ctx, table, tx, q, postCommit, requestConflictEmptyResult)
+	s.observe(table, "insert", startTime, err)
+	return sequence, err
+}
+
+func (s *SQLCommon) InsertTxRows(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.InsertBuilder, postCommit func(), sequences []int64, requestConflictEmptyResult bool) error {
+	startTime := time.Now()
+	err := s.Database.InsertTxRows(ctx, table, tx, q, postCommit, sequences, requestConflictEmptyResult)
+	s.observe(table, "insert", startTime, err)
+	return err
+}
+
+func (s *SQLCommon) UpdateTx(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.UpdateBuilder, postCommit func()) (int64, error) {
+	startTime := time.Now()
+	updated, err := s.Database.UpdateTx(ctx, table, tx, q, postCommit)
+	s.observe(table, "update", startTime, err)
+	return updated, err
+}
+
+func (s *SQLCommon) DeleteTx(ctx context.Context, table string, tx *dbsql.TXWrapper, q sq.DeleteBuilder, postCommit func()) error {
+	startTime := time.Now()
+	err := s.Database.DeleteTx(ctx, table, tx, q, postCommit)
+	s.observe(table, "delete", startTime, err)
+	return err
+}
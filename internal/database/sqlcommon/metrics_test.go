@@ -0,0 +1,75 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDatabaseOperationMetrics(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	for _, op := range []string{"insert", "query", "count", "update", "delete"} {
+		mmi.On("DatabaseOperation", noncesTable, op, mock.Anything, nil).Return()
+	}
+	s.metrics = mmi
+
+	err := s.RunAsGroup(ctx, func(ctx context.Context) error {
+		ctx, tx, _, err := s.BeginOrUseTx(ctx)
+		assert.NoError(t, err)
+
+		insert := func() sq.InsertBuilder {
+			return sq.Insert(noncesTable).Columns(nonceColumns...).Values(fftypes.NewRandB32(), 1)
+		}
+		_, err = s.InsertTx(ctx, noncesTable, tx, insert(), nil)
+		assert.NoError(t, err)
+		_, err = s.InsertTxExt(ctx, noncesTable, tx, insert(), nil, false)
+		assert.NoError(t, err)
+		err = s.InsertTxRows(ctx, noncesTable, tx, insert(), nil, []int64{-1}, false)
+		assert.NoError(t, err)
+
+		rows, _, err := s.QueryTx(ctx, noncesTable, tx, sq.Select(nonceColumns...).From(noncesTable))
+		assert.NoError(t, err)
+		rows.Close()
+
+		_, err = s.UpdateTx(ctx, noncesTable, tx, sq.Update(noncesTable).Set("nonce", 2), nil)
+		assert.NoError(t, err)
+		return s.DeleteTx(ctx, noncesTable, tx, sq.Delete(noncesTable), nil)
+	})
+	assert.NoError(t, err)
+
+	rows, _, err := s.Query(ctx, noncesTable, sq.Select(nonceColumns...).From(noncesTable))
+	assert.NoError(t, err)
+	rows.Close()
+	res := s.QueryRes(ctx, noncesTable, nil, sq.Eq{}, nil, &ffapi.FilterInfo{})
+	assert.NotNil(t, res)
+
+	mmi.AssertExpectations(t)
+	mmi.AssertNumberOfCalls(t, "DatabaseOperation", 8)
+}
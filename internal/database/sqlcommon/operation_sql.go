@@ -45,6 +45,7 @@ var (
 		"input",
 		"output",
 		"retry_id",
+		"tags",
 	}
 	opFilterFieldMap = map[string]string{
 		"tx":     "tx_id",
@@ -55,6 +56,7 @@ var (
 )
 
 const operationsTable = "operations"
+const operationsTagsJoinTable = "operations_tags"
 
 func (s *SQLCommon) setOperationInsertValues(query sq.InsertBuilder, operation *core.Operation) sq.InsertBuilder {
 	return query.Values(
@@ -70,6 +72,7 @@ func (s *SQLCommon) setOperationInsertValues(query sq.InsertBuilder, operation *
 		operation.Input,
 		operation.Output,
 		operation.Retry,
+		operation.Tags,
 	)
 }
 
@@ -80,7 +83,32 @@ func (s *SQLCommon) attemptOperationInsert(ctx context.Context, tx *dbsql.TXWrap
 			s.callbacks.UUIDCollectionNSEvent(database.CollectionOperations, core.ChangeEventTypeCreated, operation.Namespace, operation.ID)
 		},
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	return s.insertOperationTags(ctx, tx, operation)
+}
+
+func (s *SQLCommon) insertOperationTags(ctx context.Context, tx *dbsql.TXWrapper, operation *core.Operation) error {
+	for _, tag := range operation.Tags {
+		if _, err := s.InsertTx(ctx, operationsTagsJoinTable, tx,
+			sq.Insert(operationsTagsJoinTable).
+				Columns(
+					"namespace",
+					"operation_id",
+					"tag",
+				).
+				Values(
+					operation.Namespace,
+					operation.ID,
+					tag,
+				),
+			nil, // no change event
+		); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *SQLCommon) InsertOperation(ctx context.Context, operation *core.Operation, hooks ...database.PostCompletionHook) (err error) {
@@ -124,6 +152,11 @@ func (s *SQLCommon) InsertOperations(ctx context.Context, operations []*core.Ope
 		if err != nil {
 			return err
 		}
+		for _, operation := range operations {
+			if err := s.insertOperationTags(ctx, tx, operation); err != nil {
+				return err
+			}
+		}
 	} else {
 		// Fall back to individual inserts grouped in a TX
 		for _, operation := range operations {
@@ -156,6 +189,7 @@ func (s *SQLCommon) opResult(ctx context.Context, row *sql.Rows) (*core.Operatio
 		&op.Input,
 		&op.Output,
 		&op.Retry,
+		&op.Tags,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, operationsTable)
@@ -190,7 +224,11 @@ func (s *SQLCommon) GetOperationByID(ctx context.Context, namespace string, id *
 
 func (s *SQLCommon) GetOperations(ctx context.Context, namespace string, filter ffapi.Filter) (operation []*core.Operation, fr *ffapi.FilterResult, err error) {
 
-	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(opColumns...).From(operationsTable), filter, opFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	return s.getOperationsFiltered(ctx, filter, sq.Eq{"namespace": namespace})
+}
+
+func (s *SQLCommon) getOperationsFiltered(ctx context.Context, filter ffapi.Filter, preconditions ...sq.Sqlizer) (operation []*core.Operation, fr *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(opColumns...).From(operationsTable), filter, opFilterFieldMap, []interface{}{"sequence"}, preconditions...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -213,6 +251,13 @@ func (s *SQLCommon) GetOperations(ctx context.Context, namespace string, filter
 	return ops, s.QueryRes(ctx, operationsTable, tx, fop, nil, fi), err
 }
 
+func (s *SQLCommon) GetOperationsByTags(ctx context.Context, namespace string, tags []string, filter ffapi.Filter) (operation []*core.Operation, fr *ffapi.FilterResult, err error) {
+	// The tags are matched in the indexed join table, so that only the operations with one of
+	// the tags are scanned when applying the remainder of the filter
+	taggedOps := sq.Select("operation_id").From(operationsTagsJoinTable).Where(sq.Eq{"namespace": namespace, "tag": tags})
+	return s.getOperationsFiltered(ctx, filter, sq.Eq{"namespace": namespace}, sq.Expr("id IN (?)", taggedOps))
+}
+
 func (s *SQLCommon) UpdateOperation(ctx context.Context, ns string, id *fftypes.UUID, filter ffapi.Filter, update ffapi.Update) (updated bool, err error) {
 
 	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
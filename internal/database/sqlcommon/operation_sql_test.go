@@ -49,6 +49,7 @@ func TestOperationE2EWithDB(t *testing.T) {
 		Output:      fftypes.JSONObject{"some": "output-info"},
 		Created:     fftypes.Now(),
 		Updated:     fftypes.Now(),
+		Tags:        fftypes.FFStringArray{"invoice-123", "customer-456"},
 	}
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", operationID).Return()
 	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeUpdated, "ns1", operationID).Return()
@@ -86,6 +87,29 @@ func TestOperationE2EWithDB(t *testing.T) {
 	operationReadJson, _ = json.Marshal(operations[0])
 	assert.Equal(t, string(operationJson), string(operationReadJson))
 
+	// Query back the operation (by tag)
+	untagged := &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Type:        core.OpTypeBlockchainPinBatch,
+		Transaction: fftypes.NewUUID(),
+		Status:      core.OpStatusPending,
+		Created:     fftypes.Now(),
+		Updated:     fftypes.Now(),
+	}
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", untagged.ID).Return()
+	err = s.InsertOperations(ctx, []*core.Operation{untagged})
+	assert.NoError(t, err)
+	operations, res, err = s.GetOperationsByTags(ctx, "ns1", []string{"invoice-123"}, fb.And(fb.Eq("type", operation.Type)).Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(operations))
+	assert.Equal(t, int64(1), *res.TotalCount)
+	assert.Equal(t, operationID, operations[0].ID)
+	assert.Equal(t, operation.Tags, operations[0].Tags)
+	operations, _, err = s.GetOperationsByTags(ctx, "ns2", []string{"invoice-123"}, fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, operations)
+
 	// Negative test on filter
 	filter = fb.And(
 		fb.Eq("id", operation.ID.String()),
@@ -153,6 +177,17 @@ func TestInsertOperationFailCommit(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestInsertOperationFailInsertTags(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*operations").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT .*operations_tags").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertOperation(context.Background(), &core.Operation{ID: fftypes.NewUUID(), Tags: fftypes.FFStringArray{"invoice-123"}})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetOperationByIDSelectFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	operationID := fftypes.NewUUID()
@@ -197,6 +232,15 @@ func TestGetOperationsBuildQueryFail(t *testing.T) {
 	assert.Regexp(t, "FF00143.*id", err)
 }
 
+func TestGetOperationsByTagsQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .* IN \\(SELECT operation_id FROM operations_tags .*").WillReturnError(fmt.Errorf("pop"))
+	f := database.OperationQueryFactory.NewFilter(context.Background()).Eq("id", "")
+	_, _, err := s.GetOperationsByTags(context.Background(), "ns1", []string{"invoice-123"}, f)
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGettOperationsReadMessageFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
@@ -296,6 +340,21 @@ func TestInsertOperationsMultiRowFail(t *testing.T) {
 	s.callbacks.AssertExpectations(t)
 }
 
+func TestInsertOperationsMultiRowTagsFail(t *testing.T) {
+	s := newMockProvider()
+	s.multiRowInsert = true
+	s.fakePSQLInsert = true
+	s, mock := s.init()
+	op1 := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1", Tags: fftypes.FFStringArray{"invoice-123"}}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT.*operations").WillReturnRows(sqlmock.NewRows([]string{s.SequenceColumn()}).AddRow(int64(1001)))
+	mock.ExpectQuery("INSERT.*operations_tags").WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertOperations(context.Background(), []*core.Operation{op1})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+	s.callbacks.AssertExpectations(t)
+}
+
 func TestInsertOperationsSingleRowFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	op1 := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1"}
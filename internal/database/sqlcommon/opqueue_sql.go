@@ -0,0 +1,126 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	opQueueColumns = []string{
+		"id",
+		"namespace",
+		"optype",
+		"created",
+	}
+	opQueueFilterFieldMap = map[string]string{
+		"type": "optype",
+	}
+)
+
+const opQueueTable = "opqueue"
+
+func (s *SQLCommon) InsertOperationQueueEntry(ctx context.Context, entry *core.OperationQueueEntry) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if entry.Sequence, err = s.InsertTx(ctx, opQueueTable, tx,
+		sq.Insert(opQueueTable).
+			Columns(opQueueColumns...).
+			Values(
+				entry.ID,
+				entry.Namespace,
+				entry.Type,
+				entry.Created,
+			),
+		nil, // the queue dispatcher polls for entries, so there are no change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) opQueueResult(ctx context.Context, row *sql.Rows) (*core.OperationQueueEntry, error) {
+	entry := core.OperationQueueEntry{}
+	err := row.Scan(
+		&entry.ID,
+		&entry.Namespace,
+		&entry.Type,
+		&entry.Created,
+		&entry.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, opQueueTable)
+	}
+	return &entry, nil
+}
+
+func (s *SQLCommon) GetOperationQueueEntries(ctx context.Context, namespace string, filter ffapi.Filter) (entries []*core.OperationQueueEntry, res *ffapi.FilterResult, err error) {
+	cols := append([]string{}, opQueueColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(opQueueTable), filter, opQueueFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, opQueueTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	entries = []*core.OperationQueueEntry{}
+	for rows.Next() {
+		entry, err := s.opQueueResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, s.QueryRes(ctx, opQueueTable, tx, fop, nil, fi), err
+}
+
+func (s *SQLCommon) DeleteOperationQueueEntry(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, opQueueTable, tx, sq.Delete(opQueueTable).Where(sq.Eq{
+		"namespace": namespace,
+		"id":        id,
+	}), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
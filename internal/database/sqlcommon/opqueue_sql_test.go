@@ -0,0 +1,147 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperationQueueE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newEntry := func(namespace string) *core.OperationQueueEntry {
+		return &core.OperationQueueEntry{
+			ID:        fftypes.NewUUID(),
+			Namespace: namespace,
+			Type:      core.OpTypeBlockchainInvoke,
+			Created:   fftypes.Now(),
+		}
+	}
+
+	entry1 := newEntry("ns1")
+	err := s.InsertOperationQueueEntry(ctx, entry1)
+	assert.NoError(t, err)
+	entry2 := newEntry("ns2")
+	err = s.InsertOperationQueueEntry(ctx, entry2)
+	assert.NoError(t, err)
+	entry3 := newEntry("ns1")
+	err = s.InsertOperationQueueEntry(ctx, entry3)
+	assert.NoError(t, err)
+	assert.Greater(t, entry3.Sequence, entry1.Sequence)
+
+	// An operation can only be queued once
+	err = s.InsertOperationQueueEntry(ctx, &core.OperationQueueEntry{
+		ID:        entry1.ID,
+		Namespace: "ns1",
+		Type:      core.OpTypeBlockchainInvoke,
+		Created:   fftypes.Now(),
+	})
+	assert.Regexp(t, "FF00177", err)
+
+	// Each namespace has its own queue
+	fb := database.OperationQueueQueryFactory.NewFilter(ctx)
+	entries, res, err := s.GetOperationQueueEntries(ctx, "ns1", fb.And(fb.Eq("type", core.OpTypeBlockchainInvoke)).Sort("sequence").Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	entry1Json, _ := json.Marshal(entry1)
+	readJson, _ := json.Marshal(entries[0])
+	assert.Equal(t, string(entry1Json), string(readJson))
+
+	// Remove an entry once the operation is submitted
+	err = s.DeleteOperationQueueEntry(ctx, "ns1", entry1.ID)
+	assert.NoError(t, err)
+	entries, _, err = s.GetOperationQueueEntries(ctx, "ns1", fb.And().Sort("sequence"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, *entry3.ID, *entries[0].ID)
+
+	err = s.DeleteOperationQueueEntry(ctx, "ns1", entry1.ID)
+	assert.Equal(t, fftypes.DeleteRecordNotFound, err)
+}
+
+func TestInsertOperationQueueEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertOperationQueueEntry(context.Background(), &core.OperationQueueEntry{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertOperationQueueEntryFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertOperationQueueEntry(context.Background(), &core.OperationQueueEntry{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationQueueEntriesFilterSelectFail(t *testing.T) {
+	fb := database.OperationQueueQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetOperationQueueEntries(context.Background(), "ns1", fb.And(fb.Eq("sequence", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetOperationQueueEntriesQueryFail(t *testing.T) {
+	fb := database.OperationQueueQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetOperationQueueEntries(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetOperationQueueEntriesReadFail(t *testing.T) {
+	fb := database.OperationQueueQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetOperationQueueEntries(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteOperationQueueEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteOperationQueueEntry(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteOperationQueueEntryFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteOperationQueueEntry(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
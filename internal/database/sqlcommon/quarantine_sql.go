@@ -0,0 +1,162 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	quarantineColumns = []string{
+		"id",
+		"namespace",
+		"reason",
+		"detail",
+		"batch_id",
+		"message_id",
+		"data_id",
+		"payload",
+		"created",
+	}
+	quarantineFilterFieldMap = map[string]string{
+		"batch":   "batch_id",
+		"message": "message_id",
+		"data":    "data_id",
+	}
+)
+
+const quarantineTable = "quarantine"
+
+func (s *SQLCommon) InsertQuarantineEntry(ctx context.Context, entry *core.QuarantineEntry) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if _, err = s.InsertTx(ctx, quarantineTable, tx,
+		sq.Insert(quarantineTable).
+			Columns(quarantineColumns...).
+			Values(
+				entry.ID,
+				entry.Namespace,
+				entry.Reason,
+				entry.Detail,
+				entry.Batch,
+				entry.Message,
+				entry.Data,
+				entry.Payload,
+				entry.Created,
+			),
+		nil, // quarantine entries are local to the node, so there are no change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) quarantineResult(ctx context.Context, row *sql.Rows) (*core.QuarantineEntry, error) {
+	entry := core.QuarantineEntry{}
+	var detail sql.NullString
+	err := row.Scan(
+		&entry.ID,
+		&entry.Namespace,
+		&entry.Reason,
+		&detail,
+		&entry.Batch,
+		&entry.Message,
+		&entry.Data,
+		&entry.Payload,
+		&entry.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, quarantineTable)
+	}
+	entry.Detail = detail.String
+	return &entry, nil
+}
+
+func (s *SQLCommon) GetQuarantineEntryByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.QuarantineEntry, error) {
+	rows, _, err := s.Query(ctx, quarantineTable,
+		sq.Select(quarantineColumns...).
+			From(quarantineTable).
+			Where(sq.Eq{"namespace": namespace, "id": id}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Quarantine entry '%s' not found", id)
+		return nil, nil
+	}
+
+	return s.quarantineResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetQuarantineEntries(ctx context.Context, namespace string, filter ffapi.Filter) (entries []*core.QuarantineEntry, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(quarantineColumns...).From(quarantineTable),
+		filter, quarantineFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"namespace": namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, quarantineTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	entries = []*core.QuarantineEntry{}
+	for rows.Next() {
+		entry, err := s.quarantineResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, s.QueryRes(ctx, quarantineTable, tx, fop, nil, fi), err
+}
+
+func (s *SQLCommon) DeleteQuarantineEntry(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	err = s.DeleteTx(ctx, quarantineTable, tx, sq.Delete(quarantineTable).Where(sq.Eq{
+		"id": id, "namespace": namespace,
+	}), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
@@ -0,0 +1,156 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuarantineE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Create a new entry
+	entry := &core.QuarantineEntry{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Reason:    core.QuarantineReasonDataHashMismatch,
+		Detail:    "hash mismatch",
+		Batch:     fftypes.NewUUID(),
+		Data:      fftypes.NewUUID(),
+		Payload:   fftypes.JSONAnyPtr(`{"id":"batch1"}`),
+		Created:   fftypes.Now(),
+	}
+	err := s.InsertQuarantineEntry(ctx, entry)
+	assert.NoError(t, err)
+
+	// Query back the entry by ID
+	entryJson, _ := json.Marshal(entry)
+	entryRead, err := s.GetQuarantineEntryByID(ctx, "ns1", entry.ID)
+	assert.NoError(t, err)
+	readJson, _ := json.Marshal(entryRead)
+	assert.Equal(t, string(entryJson), string(readJson))
+
+	// The entry is not found in another namespace
+	entryRead, err = s.GetQuarantineEntryByID(ctx, "ns2", entry.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, entryRead)
+
+	// Query back the entry with a filter
+	fb := database.QuarantineQueryFactory.NewFilter(ctx)
+	entries, res, err := s.GetQuarantineEntries(ctx, "ns1", fb.And(
+		fb.Eq("batch", entry.Batch),
+		fb.Eq("reason", core.QuarantineReasonDataHashMismatch),
+	).Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, int64(1), *res.TotalCount)
+
+	// Delete the entry
+	err = s.DeleteQuarantineEntry(ctx, "ns1", entry.ID)
+	assert.NoError(t, err)
+	entryRead, err = s.GetQuarantineEntryByID(ctx, "ns1", entry.ID)
+	assert.NoError(t, err)
+	assert.Nil(t, entryRead)
+}
+
+func TestInsertQuarantineEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertQuarantineEntry(context.Background(), &core.QuarantineEntry{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertQuarantineEntryFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertQuarantineEntry(context.Background(), &core.QuarantineEntry{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuarantineEntryByIDSelectFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetQuarantineEntryByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuarantineEntryByIDReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetQuarantineEntryByID(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuarantineEntriesFilterSelectFail(t *testing.T) {
+	fb := database.QuarantineQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetQuarantineEntries(context.Background(), "ns1", fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetQuarantineEntriesQueryFail(t *testing.T) {
+	fb := database.QuarantineQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetQuarantineEntries(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuarantineEntriesReadFail(t *testing.T) {
+	fb := database.QuarantineQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetQuarantineEntries(context.Background(), "ns1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteQuarantineEntryFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.DeleteQuarantineEntry(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteQuarantineEntryFailDelete(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.DeleteQuarantineEntry(context.Background(), "ns1", fftypes.NewUUID())
+	assert.Regexp(t, "FF00179", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
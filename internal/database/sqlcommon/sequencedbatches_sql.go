@@ -0,0 +1,123 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	sequencedBatchColumns = []string{
+		"network_namespace",
+		"tx_id",
+		"tx_type",
+		"batch_id",
+		"batch_hash",
+		"payload_ref",
+		"contexts",
+		"signer",
+		"created",
+	}
+	sequencedBatchFilterFieldMap = map[string]string{
+		"tx":    "tx_id",
+		"batch": "batch_id",
+	}
+)
+
+const sequencedBatchesTable = "sequencedbatches"
+
+func (s *SQLCommon) InsertSequencedBatch(ctx context.Context, batch *core.SequencedBatch) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if batch.Sequence, err = s.InsertTx(ctx, sequencedBatchesTable, tx,
+		sq.Insert(sequencedBatchesTable).
+			Columns(sequencedBatchColumns...).
+			Values(
+				batch.NetworkNamespace,
+				batch.TransactionID,
+				batch.TransactionType,
+				batch.BatchID,
+				batch.BatchHash,
+				batch.PayloadRef,
+				batch.Contexts,
+				batch.Signer,
+				batch.Created,
+			),
+		nil, // the sequencer polls for new entries, so there are no change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) sequencedBatchResult(ctx context.Context, row *sql.Rows) (*core.SequencedBatch, error) {
+	batch := core.SequencedBatch{}
+	err := row.Scan(
+		&batch.NetworkNamespace,
+		&batch.TransactionID,
+		&batch.TransactionType,
+		&batch.BatchID,
+		&batch.BatchHash,
+		&batch.PayloadRef,
+		&batch.Contexts,
+		&batch.Signer,
+		&batch.Created,
+		&batch.Sequence,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, sequencedBatchesTable)
+	}
+	return &batch, nil
+}
+
+func (s *SQLCommon) GetSequencedBatches(ctx context.Context, networkNamespace string, filter ffapi.Filter) (batches []*core.SequencedBatch, res *ffapi.FilterResult, err error) {
+	cols := append([]string{}, sequencedBatchColumns...)
+	cols = append(cols, s.SequenceColumn())
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(cols...).From(sequencedBatchesTable), filter, sequencedBatchFilterFieldMap, []interface{}{"sequence"}, sq.Eq{"network_namespace": networkNamespace})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, sequencedBatchesTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	batches = []*core.SequencedBatch{}
+	for rows.Next() {
+		batch, err := s.sequencedBatchResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, s.QueryRes(ctx, sequencedBatchesTable, tx, fop, nil, fi), err
+}
@@ -0,0 +1,120 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequencedBatchesE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newBatch := func(networkNamespace string) *core.SequencedBatch {
+		return &core.SequencedBatch{
+			NetworkNamespace: networkNamespace,
+			TransactionID:    fftypes.NewUUID(),
+			TransactionType:  core.TransactionTypeBatchPin,
+			BatchID:          fftypes.NewUUID(),
+			BatchHash:        fftypes.NewRandB32(),
+			PayloadRef:       "Qm12345",
+			Contexts:         fftypes.NewFFStringArray(fftypes.NewRandB32().String(), fftypes.NewRandB32().String()),
+			Signer:           "0x12345",
+			Created:          fftypes.Now(),
+		}
+	}
+
+	batch1 := newBatch("net1")
+	err := s.InsertSequencedBatch(ctx, batch1)
+	assert.NoError(t, err)
+	batch2 := newBatch("net2")
+	err = s.InsertSequencedBatch(ctx, batch2)
+	assert.NoError(t, err)
+	batch3 := newBatch("net1")
+	err = s.InsertSequencedBatch(ctx, batch3)
+	assert.NoError(t, err)
+	assert.Greater(t, batch3.Sequence, batch1.Sequence)
+
+	// Each network namespace has its own sequence
+	fb := database.SequencedBatchQueryFactory.NewFilter(ctx)
+	batches, res, err := s.GetSequencedBatches(ctx, "net1", fb.And().Sort("sequence").Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	batch1Json, _ := json.Marshal(batch1)
+	readJson, _ := json.Marshal(batches[0])
+	assert.Equal(t, string(batch1Json), string(readJson))
+
+	// Read on from a position in the sequence
+	batches, _, err = s.GetSequencedBatches(ctx, "net1", fb.And(fb.Gt("sequence", batch1.Sequence)).Sort("sequence"))
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, batch3.BatchID, batches[0].BatchID)
+}
+
+func TestInsertSequencedBatchFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertSequencedBatch(context.Background(), &core.SequencedBatch{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertSequencedBatchFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertSequencedBatch(context.Background(), &core.SequencedBatch{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSequencedBatchesFilterSelectFail(t *testing.T) {
+	fb := database.SequencedBatchQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetSequencedBatches(context.Background(), "net1", fb.And(fb.Eq("batch", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetSequencedBatchesQueryFail(t *testing.T) {
+	fb := database.SequencedBatchQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetSequencedBatches(context.Background(), "net1", fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSequencedBatchesReadFail(t *testing.T) {
+	fb := database.SequencedBatchQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"network_namespace"}).AddRow("only one"))
+	_, _, err := s.GetSequencedBatches(context.Background(), "net1", fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,133 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	sharedFFIColumns = []string{
+		"id",
+		"namespace",
+		"interface_id",
+		"name",
+		"version",
+		"hash",
+		"created",
+	}
+	sharedFFIFilterFieldMap = map[string]string{
+		"interface": "interface_id",
+	}
+)
+
+const sharedffisTable = "sharedffis"
+
+func (s *SQLCommon) InsertSharedFFI(ctx context.Context, shared *core.SharedFFI) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	if _, err = s.InsertTx(ctx, sharedffisTable, tx,
+		sq.Insert(sharedffisTable).
+			Columns(sharedFFIColumns...).
+			Values(
+				shared.ID,
+				shared.Namespace,
+				shared.Interface,
+				shared.Name,
+				shared.Version,
+				shared.Hash,
+				shared.Created,
+			),
+		nil, // the registry is global, so there are no namespaced change events
+	); err != nil {
+		return err
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) sharedFFIResult(ctx context.Context, row *sql.Rows) (*core.SharedFFI, error) {
+	shared := core.SharedFFI{}
+	err := row.Scan(
+		&shared.ID,
+		&shared.Namespace,
+		&shared.Interface,
+		&shared.Name,
+		&shared.Version,
+		&shared.Hash,
+		&shared.Created,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, sharedffisTable)
+	}
+	return &shared, nil
+}
+
+func (s *SQLCommon) GetSharedFFI(ctx context.Context, name, version string) (*core.SharedFFI, error) {
+	rows, _, err := s.Query(ctx, sharedffisTable,
+		sq.Select(sharedFFIColumns...).
+			From(sharedffisTable).
+			Where(sq.Eq{"name": name, "version": version}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		log.L(ctx).Debugf("Shared FFI '%s:%s' not found", name, version)
+		return nil, nil
+	}
+
+	return s.sharedFFIResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetSharedFFIs(ctx context.Context, filter ffapi.Filter) (shared []*core.SharedFFI, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(sharedFFIColumns...).From(sharedffisTable), filter, sharedFFIFilterFieldMap, []interface{}{"sequence"})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, sharedffisTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	shared = []*core.SharedFFI{}
+	for rows.Next() {
+		entry, err := s.sharedFFIResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		shared = append(shared, entry)
+	}
+
+	return shared, s.QueryRes(ctx, sharedffisTable, tx, fop, nil, fi), err
+}
@@ -0,0 +1,141 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharedFFIsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	// Nothing shared initially
+	shared, err := s.GetSharedFFI(ctx, "math", "v1.0.0")
+	assert.NoError(t, err)
+	assert.Nil(t, shared)
+
+	shared = &core.SharedFFI{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Interface: fftypes.NewUUID(),
+		Name:      "math",
+		Version:   "v1.0.0",
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+	}
+	err = s.InsertSharedFFI(ctx, shared)
+	assert.NoError(t, err)
+
+	// Check we get the exact same entry back
+	sharedRead, err := s.GetSharedFFI(ctx, "math", "v1.0.0")
+	assert.NoError(t, err)
+	sharedJson, _ := json.Marshal(&shared)
+	sharedReadJson, _ := json.Marshal(&sharedRead)
+	assert.Equal(t, string(sharedJson), string(sharedReadJson))
+
+	// The same name and version cannot be shared twice
+	err = s.InsertSharedFFI(ctx, &core.SharedFFI{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns2",
+		Interface: fftypes.NewUUID(),
+		Name:      "math",
+		Version:   "v1.0.0",
+		Hash:      fftypes.NewRandB32(),
+		Created:   fftypes.Now(),
+	})
+	assert.Error(t, err)
+
+	// Query back the entry
+	fb := database.SharedFFIQueryFactory.NewFilter(ctx)
+	filter := fb.And(fb.Eq("interface", shared.Interface))
+	entries, res, err := s.GetSharedFFIs(ctx, filter.Count(true))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, int64(1), *res.TotalCount)
+	sharedReadJson, _ = json.Marshal(entries[0])
+	assert.Equal(t, string(sharedJson), string(sharedReadJson))
+}
+
+func TestInsertSharedFFIFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.InsertSharedFFI(context.Background(), &core.SharedFFI{})
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertSharedFFIFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.InsertSharedFFI(context.Background(), &core.SharedFFI{})
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSharedFFIQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetSharedFFI(context.Background(), "math", "v1.0.0")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSharedFFIReadFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, err := s.GetSharedFFI(context.Background(), "math", "v1.0.0")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSharedFFIsFilterSelectFail(t *testing.T) {
+	fb := database.SharedFFIQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetSharedFFIs(context.Background(), fb.And(fb.Eq("id", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetSharedFFIsQueryFail(t *testing.T) {
+	fb := database.SharedFFIQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetSharedFFIs(context.Background(), fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSharedFFIsReadFail(t *testing.T) {
+	fb := database.SharedFFIQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("only one"))
+	_, _, err := s.GetSharedFFIs(context.Background(), fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/dbsql"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 
@@ -34,6 +35,7 @@ type SQLCommon struct {
 	dbsql.Database
 	capabilities *database.Capabilities
 	callbacks    callbacks
+	metrics      metrics.Manager
 }
 
 type callbacks struct {
@@ -79,6 +81,7 @@ func (cb *callbacks) HashCollectionNSEvent(resType database.HashCollectionNS, ev
 
 func (s *SQLCommon) Init(ctx context.Context, provider dbsql.Provider, config config.Section, capabilities *database.Capabilities) (err error) {
 	s.capabilities = capabilities
+	s.metrics = metrics.NewMetricsManager(ctx)
 	return s.Database.Init(ctx, provider, config)
 }
 
@@ -96,3 +99,7 @@ func (s *SQLCommon) SetHandler(namespace string, handler database.Callbacks) {
 }
 
 func (s *SQLCommon) Capabilities() *database.Capabilities { return s.capabilities }
+
+func (s *SQLCommon) CheckReady(ctx context.Context) error {
+	return s.DB().PingContext(ctx)
+}
@@ -46,6 +46,18 @@ func TestMigrationUpDown(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCheckReady(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	assert.NoError(t, s.CheckReady(context.Background()))
+}
+
+func TestCheckReadyClosed(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	cleanup()
+	assert.Error(t, s.CheckReady(context.Background()))
+}
+
 func TestTXConcurrency(t *testing.T) {
 	s, cleanup := newSQLiteTestProvider(t)
 	defer cleanup()
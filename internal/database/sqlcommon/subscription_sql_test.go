@@ -43,6 +43,7 @@ func TestSubscriptionsE2EWithDB(t *testing.T) {
 			Namespace: "ns1",
 			Name:      "subscription1",
 		},
+		Owner:   "basic:user1",
 		Created: fftypes.Now(),
 	}
 
@@ -89,6 +90,7 @@ func TestSubscriptionsE2EWithDB(t *testing.T) {
 			},
 		},
 		Options: subOpts,
+		Owner:   "basic:user1", // the owner is not updated
 		Created: fftypes.Now(),
 		Updated: fftypes.Now(),
 	}
@@ -116,6 +118,7 @@ func TestSubscriptionsE2EWithDB(t *testing.T) {
 	fb := database.SubscriptionQueryFactory.NewFilter(ctx)
 	filter := fb.And(
 		fb.Eq("name", subscriptionUpdated.Name),
+		fb.Eq("owner", "basic:user1"),
 	)
 	subscriptionRes, res, err := s.GetSubscriptions(ctx, "ns1", filter.Count(true))
 	assert.NoError(t, err)
@@ -264,7 +267,7 @@ func TestSubscriptionUpdateBuildQueryFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
-		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, fftypes.Now(), fftypes.Now()),
+		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, "", fftypes.Now(), fftypes.Now()),
 	)
 	u := database.SubscriptionQueryFactory.NewUpdate(context.Background()).Set("name", map[bool]bool{true: false})
 	err := s.UpdateSubscription(context.Background(), "ns1", "name1", u)
@@ -295,7 +298,7 @@ func TestSubscriptionUpdateFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
-		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, fftypes.Now(), fftypes.Now()),
+		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, "", fftypes.Now(), fftypes.Now()),
 	)
 	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
 	mock.ExpectRollback()
@@ -315,7 +318,7 @@ func TestSubscriptionDeleteFail(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
-		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, fftypes.Now(), fftypes.Now()),
+		fftypes.NewUUID(), "ns1", "sub1", "websockets", `{}`, `{}`, "", fftypes.Now(), fftypes.Now()),
 	)
 	mock.ExpectExec("DELETE .*").WillReturnError(fmt.Errorf("pop"))
 	err := s.DeleteSubscriptionByID(context.Background(), "ns1", fftypes.NewUUID())
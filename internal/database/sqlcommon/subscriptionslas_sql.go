@@ -0,0 +1,186 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/hyperledger/firefly-common/pkg/dbsql"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+var (
+	subscriptionSLAColumns = []string{
+		"namespace",
+		"subscription_id",
+		"day",
+		"target",
+		"delivered",
+		"within_target",
+		"latency_total",
+		"latency_min",
+		"latency_max",
+		"updated",
+	}
+	subscriptionSLAFilterFieldMap = map[string]string{
+		"withintarget": "within_target",
+	}
+)
+
+const subscriptionSLAsTable = "subscriptionslas"
+
+// durationMillis converts to the millisecond precision that durations are stored with, and scanned back from
+func durationMillis(d *fftypes.FFDuration) int64 {
+	return int64(time.Duration(*d) / time.Millisecond)
+}
+
+func (s *SQLCommon) AddSubscriptionSLA(ctx context.Context, sla *core.SubscriptionSLA) (err error) {
+	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer s.RollbackTx(ctx, tx, autoCommit)
+
+	existing, err := s.getSubscriptionSLA(ctx, tx, sla.Namespace, sla.Subscription, sla.Day)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		existing.Merge(sla)
+		existing.Updated = fftypes.Now()
+		if _, err = s.UpdateTx(ctx, subscriptionSLAsTable, tx,
+			sq.Update(subscriptionSLAsTable).
+				Set("target", durationMillis(existing.Target)).
+				Set("delivered", existing.Delivered).
+				Set("within_target", existing.WithinTarget).
+				Set("latency_total", durationMillis(existing.TotalLatency)).
+				Set("latency_min", durationMillis(existing.MinLatency)).
+				Set("latency_max", durationMillis(existing.MaxLatency)).
+				Set("updated", existing.Updated).
+				Where(sq.Eq{
+					"namespace":       existing.Namespace,
+					"subscription_id": existing.Subscription,
+					"day":             existing.Day,
+				}),
+			nil, // no change events for SLA aggregates
+		); err != nil {
+			return err
+		}
+	} else {
+		sla.Updated = fftypes.Now()
+		if _, err = s.InsertTx(ctx, subscriptionSLAsTable, tx,
+			sq.Insert(subscriptionSLAsTable).
+				Columns(subscriptionSLAColumns...).
+				Values(
+					sla.Namespace,
+					sla.Subscription,
+					sla.Day,
+					durationMillis(sla.Target),
+					sla.Delivered,
+					sla.WithinTarget,
+					durationMillis(sla.TotalLatency),
+					durationMillis(sla.MinLatency),
+					durationMillis(sla.MaxLatency),
+					sla.Updated,
+				),
+			nil, // no change events for SLA aggregates
+		); err != nil {
+			return err
+		}
+	}
+
+	return s.CommitTx(ctx, tx, autoCommit)
+}
+
+func (s *SQLCommon) subscriptionSLAResult(ctx context.Context, row *sql.Rows) (*core.SubscriptionSLA, error) {
+	sla := core.SubscriptionSLA{}
+	err := row.Scan(
+		&sla.Namespace,
+		&sla.Subscription,
+		&sla.Day,
+		&sla.Target,
+		&sla.Delivered,
+		&sla.WithinTarget,
+		&sla.TotalLatency,
+		&sla.MinLatency,
+		&sla.MaxLatency,
+		&sla.Updated,
+	)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, subscriptionSLAsTable)
+	}
+	average := fftypes.FFDuration(0)
+	if sla.Delivered > 0 {
+		average = *sla.TotalLatency / fftypes.FFDuration(sla.Delivered)
+	}
+	sla.AverageLatency = &average
+	return &sla, nil
+}
+
+func (s *SQLCommon) getSubscriptionSLA(ctx context.Context, tx *dbsql.TXWrapper, namespace string, subscription *fftypes.UUID, day string) (*core.SubscriptionSLA, error) {
+	rows, _, err := s.QueryTx(ctx, subscriptionSLAsTable, tx,
+		sq.Select(subscriptionSLAColumns...).
+			From(subscriptionSLAsTable).
+			Where(sq.Eq{
+				"namespace":       namespace,
+				"subscription_id": subscription,
+				"day":             day,
+			}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+	return s.subscriptionSLAResult(ctx, rows)
+}
+
+func (s *SQLCommon) GetSubscriptionSLAs(ctx context.Context, namespace string, subscription *fftypes.UUID, filter ffapi.Filter) (slas []*core.SubscriptionSLA, res *ffapi.FilterResult, err error) {
+	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(subscriptionSLAColumns...).From(subscriptionSLAsTable), filter, subscriptionSLAFilterFieldMap, []interface{}{"day"},
+		sq.Eq{"namespace": namespace, "subscription_id": subscription})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, tx, err := s.Query(ctx, subscriptionSLAsTable, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	slas = []*core.SubscriptionSLA{}
+	for rows.Next() {
+		sla, err := s.subscriptionSLAResult(ctx, rows)
+		if err != nil {
+			return nil, nil, err
+		}
+		slas = append(slas, sla)
+	}
+
+	return slas, s.QueryRes(ctx, subscriptionSLAsTable, tx, fop, nil, fi), err
+}
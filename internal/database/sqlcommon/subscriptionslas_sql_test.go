@@ -0,0 +1,160 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlcommon
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionSLAsE2EWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	subID := fftypes.NewUUID()
+	day1 := core.NewSubscriptionSLA("ns1", subID, "2024-03-01", time.Second)
+	day1.Record(200 * time.Millisecond)
+	day1.Record(3 * time.Second)
+	err := s.AddSubscriptionSLA(ctx, day1)
+	assert.NoError(t, err)
+
+	day2 := core.NewSubscriptionSLA("ns1", subID, "2024-03-02", time.Second)
+	day2.Record(500 * time.Millisecond)
+	err = s.AddSubscriptionSLA(ctx, day2)
+	assert.NoError(t, err)
+
+	// Merge more deliveries into the first day
+	more := core.NewSubscriptionSLA("ns1", subID, "2024-03-01", time.Second)
+	more.Record(100 * time.Millisecond)
+	err = s.AddSubscriptionSLA(ctx, more)
+	assert.NoError(t, err)
+
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(ctx)
+	slas, res, err := s.GetSubscriptionSLAs(ctx, "ns1", subID, fb.And().Count(true))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *res.TotalCount)
+	assert.Len(t, slas, 2)
+	assert.Equal(t, "2024-03-02", slas[0].Day)
+	assert.Equal(t, int64(1), slas[0].Delivered)
+	assert.Equal(t, "2024-03-01", slas[1].Day)
+	assert.Equal(t, subID, slas[1].Subscription)
+	assert.Equal(t, "1s", slas[1].Target.String())
+	assert.Equal(t, int64(3), slas[1].Delivered)
+	assert.Equal(t, int64(2), slas[1].WithinTarget)
+	assert.Equal(t, "3.3s", slas[1].TotalLatency.String())
+	assert.Equal(t, "1.1s", slas[1].AverageLatency.String())
+	assert.Equal(t, "100ms", slas[1].MinLatency.String())
+	assert.Equal(t, "3s", slas[1].MaxLatency.String())
+	assert.NotNil(t, slas[1].Updated)
+
+	// Filter on the number within the target
+	slas, _, err = s.GetSubscriptionSLAs(ctx, "ns1", subID, fb.And(fb.Lt("withintarget", 2)))
+	assert.NoError(t, err)
+	assert.Len(t, slas, 1)
+	assert.Equal(t, "2024-03-02", slas[0].Day)
+
+	// Other subscriptions do not see the entries
+	slas, _, err = s.GetSubscriptionSLAs(ctx, "ns1", fftypes.NewUUID(), fb.And())
+	assert.NoError(t, err)
+	assert.Empty(t, slas)
+}
+
+func TestSubscriptionSLAResultZeroDelivered(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionSLAColumns).
+		AddRow("ns1", fftypes.NewUUID().String(), "2024-03-01", 1000, 0, 0, 0, 0, 0, 0))
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	slas, _, err := s.GetSubscriptionSLAs(context.Background(), "ns1", fftypes.NewUUID(), fb.And())
+	assert.NoError(t, err)
+	assert.Equal(t, "0s", slas[0].AverageLatency.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddSubscriptionSLAFailBegin(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
+	err := s.AddSubscriptionSLA(context.Background(), core.NewSubscriptionSLA("ns1", fftypes.NewUUID(), "2024-03-01", time.Second))
+	assert.Regexp(t, "FF00175", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddSubscriptionSLAFailSelect(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.AddSubscriptionSLA(context.Background(), core.NewSubscriptionSLA("ns1", fftypes.NewUUID(), "2024-03-01", time.Second))
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddSubscriptionSLAFailUpdate(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionSLAColumns).
+		AddRow("ns1", fftypes.NewUUID().String(), "2024-03-01", 1000, 1, 1, 10, 10, 10, 0))
+	mock.ExpectExec("UPDATE .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.AddSubscriptionSLA(context.Background(), core.NewSubscriptionSLA("ns1", fftypes.NewUUID(), "2024-03-01", time.Second))
+	assert.Regexp(t, "FF00178", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddSubscriptionSLAFailInsert(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows(subscriptionSLAColumns))
+	mock.ExpectExec("INSERT .*").WillReturnError(fmt.Errorf("pop"))
+	mock.ExpectRollback()
+	err := s.AddSubscriptionSLA(context.Background(), core.NewSubscriptionSLA("ns1", fftypes.NewUUID(), "2024-03-01", time.Second))
+	assert.Regexp(t, "FF00177", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubscriptionSLAsFilterSelectFail(t *testing.T) {
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	s, _ := newMockProvider().init()
+	_, _, err := s.GetSubscriptionSLAs(context.Background(), "ns1", fftypes.NewUUID(), fb.And(fb.Eq("day", map[bool]bool{true: false})))
+	assert.Error(t, err)
+}
+
+func TestGetSubscriptionSLAsQueryFail(t *testing.T) {
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, _, err := s.GetSubscriptionSLAs(context.Background(), "ns1", fftypes.NewUUID(), fb.And())
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSubscriptionSLAsReadFail(t *testing.T) {
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"namespace"}).AddRow("only one"))
+	_, _, err := s.GetSubscriptionSLAs(context.Background(), "ns1", fftypes.NewUUID(), fb.And())
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -260,6 +260,49 @@ func (s *SQLCommon) GetTokenAccountPools(ctx context.Context, namespace, key str
 	return pools, s.QueryRes(ctx, tokenbalanceTable, tx, fop, nil, fi), err
 }
 
+func (s *SQLCommon) GetTokenAccountBalanceSummaries(ctx context.Context, namespace, key string) ([]*core.TokenAccountPoolSummary, error) {
+	rows, _, err := s.Query(ctx, tokenbalanceTable,
+		sq.Select("pool_id", "connector", "balance", "updated").
+			From(tokenbalanceTable).
+			Where(sq.Eq{"namespace": namespace, "key": key}).
+			OrderBy("pool_id"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Balances are stored as padded hex strings, so cannot be summed in SQL.
+	// Instead the rows are ordered by pool, and the tokens of each pool summed here.
+	summaries := make([]*core.TokenAccountPoolSummary, 0)
+	var summary *core.TokenAccountPoolSummary
+	for rows.Next() {
+		var poolID fftypes.UUID
+		var connector string
+		var balance fftypes.FFBigInt
+		var updated *fftypes.FFTime
+		if err := rows.Scan(&poolID, &connector, &balance, &updated); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, tokenbalanceTable)
+		}
+		if summary == nil || !summary.Pool.Equals(&poolID) {
+			summary = &core.TokenAccountPoolSummary{
+				Pool:      &poolID,
+				Connector: connector,
+			}
+			summaries = append(summaries, summary)
+		}
+		summary.Balance.Int().Add(summary.Balance.Int(), balance.Int())
+		if balance.Int().Sign() != 0 {
+			summary.Tokens++
+		}
+		if summary.LastActivity == nil || (updated != nil && updated.Time().After(*summary.LastActivity.Time())) {
+			summary.LastActivity = updated
+		}
+	}
+
+	return summaries, nil
+}
+
 func (s *SQLCommon) DeleteTokenBalances(ctx context.Context, namespace string, poolID *fftypes.UUID) error {
 	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
 	if err != nil {
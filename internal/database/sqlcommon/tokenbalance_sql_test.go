@@ -137,6 +137,45 @@ func TestTokenBalanceE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTokenAccountBalanceSummariesWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	poolA := fftypes.NewUUID()
+	poolB := fftypes.NewUUID()
+	for _, transfer := range []*core.TokenTransfer{
+		{Pool: poolA, TokenIndex: "1", Connector: "erc1155", Namespace: "ns1", To: "0x1", Amount: *fftypes.NewFFBigInt(10)},
+		{Pool: poolA, TokenIndex: "2", Connector: "erc1155", Namespace: "ns1", To: "0x1", Amount: *fftypes.NewFFBigInt(5)},
+		{Pool: poolA, TokenIndex: "3", Connector: "erc1155", Namespace: "ns1", To: "0x1", Amount: *fftypes.NewFFBigInt(1)},
+		{Pool: poolA, TokenIndex: "3", Connector: "erc1155", Namespace: "ns1", From: "0x1", To: "0x2", Amount: *fftypes.NewFFBigInt(1)},
+		{Pool: poolB, Connector: "erc20", Namespace: "ns1", To: "0x1", Amount: *fftypes.NewFFBigInt(7)},
+		{Pool: poolB, Connector: "erc20", Namespace: "ns1", To: "0x2", Amount: *fftypes.NewFFBigInt(3)},
+	} {
+		err := s.UpdateTokenBalances(ctx, transfer)
+		assert.NoError(t, err)
+	}
+
+	summaries, err := s.GetTokenAccountBalanceSummaries(ctx, "ns1", "0x1")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 2)
+	byPool := map[fftypes.UUID]*core.TokenAccountPoolSummary{}
+	for _, summary := range summaries {
+		assert.NotNil(t, summary.LastActivity)
+		byPool[*summary.Pool] = summary
+	}
+	assert.Equal(t, "erc1155", byPool[*poolA].Connector)
+	assert.Equal(t, int64(15), byPool[*poolA].Balance.Int().Int64())
+	assert.Equal(t, int64(2), byPool[*poolA].Tokens)
+	assert.Equal(t, "erc20", byPool[*poolB].Connector)
+	assert.Equal(t, int64(7), byPool[*poolB].Balance.Int().Int64())
+	assert.Equal(t, int64(1), byPool[*poolB].Tokens)
+
+	summaries, err = s.GetTokenAccountBalanceSummaries(ctx, "ns1", "0x3")
+	assert.NoError(t, err)
+	assert.Empty(t, summaries)
+}
+
 func TestUpdateTokenBalancesFailBegin(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
@@ -290,6 +329,22 @@ func TestGetTokenAccountPoolsScanFail(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetTokenAccountBalanceSummariesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetTokenAccountBalanceSummaries(context.Background(), "ns1", "0x1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTokenAccountBalanceSummariesScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"pool_id"}).AddRow("only one"))
+	_, err := s.GetTokenAccountBalanceSummaries(context.Background(), "ns1", "0x1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDeleteTokenBalancesFailBegin(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
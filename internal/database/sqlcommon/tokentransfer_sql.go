@@ -51,20 +51,26 @@ var (
 		"tx_id",
 		"blockchain_event",
 		"created",
+		"block_number",
+		"transaction_index",
+		"log_index",
 	}
 	tokenTransferFilterFieldMap = map[string]string{
-		"type":            "type",
-		"localid":         "local_id",
-		"pool":            "pool_id",
-		"tokenindex":      "token_index",
-		"from":            "from_key",
-		"to":              "to_key",
-		"protocolid":      "protocol_id",
-		"message":         "message_id",
-		"messagehash":     "message_hash",
-		"tx.type":         "tx_type",
-		"tx.id":           "tx_id",
-		"blockchainevent": "blockchain_event",
+		"type":             "type",
+		"localid":          "local_id",
+		"pool":             "pool_id",
+		"tokenindex":       "token_index",
+		"from":             "from_key",
+		"to":               "to_key",
+		"protocolid":       "protocol_id",
+		"message":          "message_id",
+		"messagehash":      "message_hash",
+		"tx.type":          "tx_type",
+		"tx.id":            "tx_id",
+		"blockchainevent":  "blockchain_event",
+		"blocknumber":      "block_number",
+		"transactionindex": "transaction_index",
+		"logindex":         "log_index",
 	}
 )
 
@@ -90,6 +96,9 @@ func (s *SQLCommon) setTokenTransferEventInsertValues(query sq.InsertBuilder, tr
 		transfer.TX.ID,
 		transfer.BlockchainEvent,
 		transfer.Created,
+		transfer.BlockNumber,
+		transfer.TransactionIndex,
+		transfer.LogIndex,
 	)
 }
 
@@ -147,6 +156,9 @@ func (s *SQLCommon) tokenTransferResult(ctx context.Context, row *sql.Rows) (*co
 		&transfer.TX.ID,
 		&transfer.BlockchainEvent,
 		&transfer.Created,
+		&transfer.BlockNumber,
+		&transfer.TransactionIndex,
+		&transfer.LogIndex,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, tokentransferTable)
@@ -192,7 +204,13 @@ func (s *SQLCommon) GetTokenTransferByProtocolID(ctx context.Context, namespace
 
 func (s *SQLCommon) GetTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) (message []*core.TokenTransfer, fr *ffapi.FilterResult, err error) {
 	query, fop, fi, err := s.FilterSelect(ctx, "", sq.Select(tokenTransferColumns...).From(tokentransferTable),
-		filter, tokenTransferFilterFieldMap, []interface{}{"seq"}, sq.Eq{"namespace": namespace})
+		filter, tokenTransferFilterFieldMap, []interface{}{
+			// Newest first by position on the chain, with transfers recorded before positions were captured at the end
+			&ffapi.SortField{Field: "blocknumber", Descending: true, Nulls: ffapi.NullsLast},
+			&ffapi.SortField{Field: "transactionindex", Descending: true, Nulls: ffapi.NullsLast},
+			&ffapi.SortField{Field: "logindex", Descending: true, Nulls: ffapi.NullsLast},
+			"seq",
+		}, sq.Eq{"namespace": namespace})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -215,6 +233,36 @@ func (s *SQLCommon) GetTokenTransfers(ctx context.Context, namespace string, fil
 	return transfers, s.QueryRes(ctx, tokentransferTable, tx, fop, nil, fi), err
 }
 
+func (s *SQLCommon) GetTokenAccountTransferSummaries(ctx context.Context, namespace, key string) ([]*core.TokenAccountPoolSummary, error) {
+	rows, _, err := s.Query(ctx, tokentransferTable,
+		sq.Select("pool_id", "connector", "COUNT(*) AS transfers", "MAX(created) AS created").
+			From(tokentransferTable).
+			Where(sq.And{
+				sq.Eq{"namespace": namespace},
+				sq.Or{
+					sq.Eq{"from_key": key},
+					sq.Eq{"to_key": key},
+				},
+			}).
+			GroupBy("pool_id", "connector"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make([]*core.TokenAccountPoolSummary, 0)
+	for rows.Next() {
+		var summary core.TokenAccountPoolSummary
+		if err := rows.Scan(&summary.Pool, &summary.Connector, &summary.Transfers, &summary.LastActivity); err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, tokentransferTable)
+		}
+		summaries = append(summaries, &summary)
+	}
+
+	return summaries, nil
+}
+
 func (s *SQLCommon) DeleteTokenTransfers(ctx context.Context, namespace string, poolID *fftypes.UUID) error {
 	ctx, tx, autoCommit, err := s.BeginOrUseTx(ctx)
 	if err != nil {
@@ -110,6 +110,107 @@ func TestTokenTransferE2EWithDB(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestTokenTransfersChainOrderWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionTokenTransfers, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+
+	position := func(v int64) *int64 { return &v }
+	pool := fftypes.NewUUID()
+	var ids []*fftypes.UUID
+	for _, pos := range [][]*int64{
+		{nil, nil, nil}, // recorded before positions were captured
+		{position(20), position(1), position(5)},
+		{position(10), position(3), position(9)},
+		{position(20), position(0), position(2)},
+		{position(20), position(1), position(4)},
+	} {
+		transfer := newTestTransfer()
+		transfer.Pool = pool
+		transfer.ProtocolID = fftypes.NewUUID().String()
+		transfer.BlockNumber, transfer.TransactionIndex, transfer.LogIndex = pos[0], pos[1], pos[2]
+		_, err := s.InsertOrGetTokenTransfer(ctx, transfer)
+		assert.NoError(t, err)
+		ids = append(ids, transfer.LocalID)
+	}
+
+	// Default order is newest first by position on the chain, regardless of arrival
+	fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	transfers, _, err := s.GetTokenTransfers(ctx, "ns1", fb.Eq("pool", pool))
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 5)
+	for i, expected := range []int{1, 4, 3, 2, 0} {
+		assert.Equal(t, ids[expected], transfers[i].LocalID)
+	}
+	assert.Equal(t, int64(20), *transfers[0].BlockNumber)
+	assert.Nil(t, transfers[4].BlockNumber)
+
+	// Filter on the position fields
+	transfers, _, err = s.GetTokenTransfers(ctx, "ns1", fb.And(
+		fb.Eq("blocknumber", 20),
+		fb.Eq("transactionindex", 1),
+		fb.Gt("logindex", 4),
+	))
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 1)
+	assert.Equal(t, ids[1], transfers[0].LocalID)
+}
+
+func TestTokenAccountTransferSummariesWithDB(t *testing.T) {
+	s, cleanup := newSQLiteTestProvider(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	s.callbacks.On("UUIDCollectionNSEvent", database.CollectionTokenTransfers, core.ChangeEventTypeCreated, "ns1", mock.Anything, mock.Anything).Return()
+
+	poolA := fftypes.NewUUID()
+	poolB := fftypes.NewUUID()
+	for _, tt := range []struct {
+		pool     *fftypes.UUID
+		from, to string
+	}{
+		{poolA, "0x01", "0x02"},
+		{poolA, "0x02", "0x03"},
+		{poolB, "0x02", "0x01"},
+	} {
+		transfer := newTestTransfer()
+		transfer.Pool = tt.pool
+		transfer.From = tt.from
+		transfer.To = tt.to
+		transfer.ProtocolID = fftypes.NewUUID().String()
+		_, err := s.InsertOrGetTokenTransfer(ctx, transfer)
+		assert.NoError(t, err)
+	}
+
+	summaries, err := s.GetTokenAccountTransferSummaries(ctx, "ns1", "0x02")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 2)
+	transfers := map[fftypes.UUID]int64{}
+	for _, summary := range summaries {
+		assert.Equal(t, "erc1155", summary.Connector)
+		assert.NotNil(t, summary.LastActivity)
+		transfers[*summary.Pool] = summary.Transfers
+	}
+	assert.Equal(t, int64(2), transfers[*poolA])
+	assert.Equal(t, int64(1), transfers[*poolB])
+
+	summaries, err = s.GetTokenAccountTransferSummaries(ctx, "ns1", "0x03")
+	assert.NoError(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, poolA, summaries[0].Pool)
+	assert.Equal(t, int64(1), summaries[0].Transfers)
+
+	// Transfers can be paged in the order they were recorded
+	fb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	results, _, err := s.GetTokenTransfers(ctx, "ns1", fb.Sort("sequence").Limit(2).And(fb.Eq("pool", poolA)))
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "0x01", results[0].From)
+	assert.Equal(t, "0x02", results[1].From)
+}
+
 func TestInsertOrGetTokenTransferFailBegin(t *testing.T) {
 	s, mock := newMockProvider().init()
 	mock.ExpectBegin().WillReturnError(fmt.Errorf("pop"))
@@ -220,3 +321,19 @@ func TestDeleteTokenTransfersFailDelete(t *testing.T) {
 	assert.Regexp(t, "FF00179", err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestGetTokenAccountTransferSummariesQueryFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnError(fmt.Errorf("pop"))
+	_, err := s.GetTokenAccountTransferSummaries(context.Background(), "ns1", "0x1")
+	assert.Regexp(t, "FF00176", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetTokenAccountTransferSummariesScanFail(t *testing.T) {
+	s, mock := newMockProvider().init()
+	mock.ExpectQuery("SELECT .*").WillReturnRows(sqlmock.NewRows([]string{"pool_id"}).AddRow("only one"))
+	_, err := s.GetTokenAccountTransferSummaries(context.Background(), "ns1", "0x1")
+	assert.Regexp(t, "FF10121", err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -39,9 +39,12 @@ var (
 		"namespace",
 		"value",
 		"created",
+		"revoked",
+		"revoked_pin",
 	}
 	verifierFilterFieldMap = map[string]string{
-		"type": "vtype",
+		"type":       "vtype",
+		"revokedpin": "revoked_pin",
 	}
 )
 
@@ -53,6 +56,8 @@ func (s *SQLCommon) attemptVerifierUpdate(ctx context.Context, tx *dbsql.TXWrapp
 			Set("identity", verifier.Identity).
 			Set("vtype", verifier.Type).
 			Set("value", verifier.Value).
+			Set("revoked", verifier.Revoked).
+			Set("revoked_pin", verifier.RevokedPin).
 			Where(sq.Eq{
 				"hash": verifier.Hash,
 			}),
@@ -73,6 +78,8 @@ func (s *SQLCommon) attemptVerifierInsert(ctx context.Context, tx *dbsql.TXWrapp
 				verifier.Namespace,
 				verifier.Value,
 				verifier.Created,
+				verifier.Revoked,
+				verifier.RevokedPin,
 			),
 		func() {
 			s.callbacks.HashCollectionNSEvent(database.CollectionVerifiers, core.ChangeEventTypeCreated, verifier.Namespace, verifier.Hash)
@@ -136,6 +143,8 @@ func (s *SQLCommon) verifierResult(ctx context.Context, row *sql.Rows) (*core.Ve
 		&verifier.Namespace,
 		&verifier.Value,
 		&verifier.Created,
+		&verifier.Revoked,
+		&verifier.RevokedPin,
 	)
 	if err != nil {
 		return nil, i18n.WrapError(ctx, err, coremsgs.MsgDBReadErr, verifiersTable)
@@ -65,9 +65,11 @@ func TestVerifiersE2EWithDB(t *testing.T) {
 	// Update the verifier (this is testing what's possible at the database layer,
 	// and does not account for the verification that happens at the higher level)
 	verifierUpdated := &core.Verifier{
-		Identity:  fftypes.NewUUID(),
-		Created:   verifier.Created,
-		Namespace: "ns1",
+		Identity:   fftypes.NewUUID(),
+		Created:    verifier.Created,
+		Revoked:    fftypes.Now(),
+		RevokedPin: 12345,
+		Namespace:  "ns1",
 		VerifierRef: core.VerifierRef{
 			Type:  core.VerifierTypeEthAddress,
 			Value: "0x12345",
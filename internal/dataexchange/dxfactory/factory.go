@@ -24,15 +24,18 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/dataexchange/ffdx"
+	"github.com/hyperledger/firefly/internal/dataexchange/libp2pdx"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 )
 
 var (
-	NewFFDXPluginName = (*ffdx.FFDX)(nil).Name()
+	NewFFDXPluginName   = (*ffdx.FFDX)(nil).Name()
+	NewLibp2pPluginName = (*libp2pdx.Libp2pDX)(nil).Name()
 )
 
 var pluginsByName = map[string]func() dataexchange.Plugin{
-	NewFFDXPluginName: func() dataexchange.Plugin { return &ffdx.FFDX{} },
+	NewFFDXPluginName:   func() dataexchange.Plugin { return &ffdx.FFDX{} },
+	NewLibp2pPluginName: func() dataexchange.Plugin { return &libp2pdx.Libp2pDX{} },
 }
 
 func InitConfig(config config.ArraySection) {
@@ -36,6 +36,9 @@ func TestGetPlugin(t *testing.T) {
 	plugin, err := GetPlugin(ctx, "ffdx")
 	assert.NoError(t, err)
 	assert.NotNil(t, plugin)
+	plugin, err = GetPlugin(ctx, "libp2p")
+	assert.NoError(t, err)
+	assert.NotNil(t, plugin)
 }
 
 var root = config.RootSection("di")
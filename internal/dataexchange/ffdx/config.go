@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/proxy"
 )
 
 const (
@@ -44,6 +45,7 @@ const (
 
 func (h *FFDX) InitConfig(config config.Section) {
 	wsclient.InitConfig(config)
+	proxy.InitConfig(config)
 	config.AddKnownKey(DataExchangeManifestEnabled, false)
 	config.AddKnownKey(DataExchangeInitEnabled, false)
 	config.AddKnownKey(DataExchangeEventRetryInitialDelay, 50*time.Millisecond)
@@ -29,23 +29,35 @@ import (
 )
 
 type wsEvent struct {
-	Type      msgType            `json:"type"`
-	EventID   string             `json:"id"`
-	Sender    string             `json:"sender"`
-	Recipient string             `json:"recipient"`
-	RequestID string             `json:"requestId"`
-	Path      string             `json:"path"`
-	Message   string             `json:"message"`
-	Hash      string             `json:"hash"`
-	Size      int64              `json:"size"`
-	Error     string             `json:"error"`
-	Manifest  string             `json:"manifest"`
-	Info      fftypes.JSONObject `json:"info"`
+	Type       msgType            `json:"type"`
+	EventID    string             `json:"id"`
+	Sender     string             `json:"sender"`
+	SenderCert string             `json:"senderCert"`
+	Recipient  string             `json:"recipient"`
+	RequestID  string             `json:"requestId"`
+	Path       string             `json:"path"`
+	Message    string             `json:"message"`
+	Hash       string             `json:"hash"`
+	Size       int64              `json:"size"`
+	Sequence   int64              `json:"sequence"`
+	Progress   *wsBlobProgress    `json:"progress,omitempty"`
+	Error      string             `json:"error"`
+	Manifest   string             `json:"manifest"`
+	Info       fftypes.JSONObject `json:"info"`
+}
+
+// wsBlobProgress is the progress of a blob transfer, reported on blob-progress events
+// and on blob-failed events for transfers that can be resumed
+type wsBlobProgress struct {
+	BytesSent    int64 `json:"bytesSent"`
+	ChunksAcked  int64 `json:"chunksAcked"`
+	ResumeOffset int64 `json:"resumeOffset"`
 }
 
 type dxEvent struct {
 	ffdx                *FFDX
 	id                  string
+	acked               chan string // set for events that were acknowledged when they were queued
 	dxType              dataexchange.DXEventType
 	messageReceived     *dataexchange.MessageReceived
 	privateBlobReceived *dataexchange.PrivateBlobReceived
@@ -60,6 +72,13 @@ func (e *dxEvent) Type() dataexchange.DXEventType {
 }
 
 func (e *dxEvent) AckWithManifest(manifest string) {
+	if e.acked != nil {
+		select {
+		case e.acked <- manifest:
+		default:
+		}
+		return
+	}
 	select {
 	case e.ffdx.ackChannel <- &ack{
 		eventID:  e.id,
@@ -82,11 +101,11 @@ func (e *dxEvent) PrivateBlobReceived() *dataexchange.PrivateBlobReceived {
 	return e.privateBlobReceived
 }
 
-func (h *FFDX) dispatchEvent(msg *wsEvent) {
+func (h *FFDX) dispatchEvent(msg *wsEvent, acked chan string) {
 	var dataID string
 	var namespace string
 	var err error
-	e := &dxEvent{ffdx: h, id: msg.EventID}
+	e := &dxEvent{ffdx: h, id: msg.EventID, acked: acked}
 
 	switch msg.Type {
 	case messageFailed:
@@ -129,7 +148,16 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 			NamespacedOpID: msg.RequestID,
 			Status:         core.OpStatusFailed,
 			ErrorMessage:   msg.Error,
-			Output:         msg.Info,
+			Output:         withBlobProgress(msg.Info, msg),
+			OnComplete:     e.Ack,
+		})
+		return
+	case blobProgress:
+		h.callbacks.OperationUpdate(h.ctx, &core.OperationUpdate{
+			Plugin:         h.Name(),
+			NamespacedOpID: msg.RequestID,
+			Status:         core.OpStatusPending,
+			Output:         withBlobProgress(fftypes.JSONObject{}, msg),
 			OnComplete:     e.Ack,
 		})
 		return
@@ -161,34 +189,48 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 	case messageReceived:
 		// De-serialize the transport wrapper
 		var wrapper *core.TransportWrapper
+		var fingerprint string
 		err = json.Unmarshal([]byte(msg.Message), &wrapper)
+		if err == nil {
+			err = wrapper.Expand(h.ctx, dataexchange.MaxExpandedTransportSize)
+		}
 		switch {
 		case err != nil:
 			err = fmt.Errorf("invalid transmission from peer '%s': %s", msg.Sender, err)
 		case wrapper.Batch == nil:
 			err = fmt.Errorf("invalid transmission from peer '%s': nil batch", msg.Sender)
 		default:
+			if fingerprint, err = h.senderCertFingerprint(msg); err != nil {
+				break
+			}
 			namespace = wrapper.Batch.Namespace
 			e.dxType = dataexchange.DXEventTypeMessageReceived
 			e.messageReceived = &dataexchange.MessageReceived{
-				PeerID:    msg.Sender,
-				Transport: wrapper,
+				PeerID:          msg.Sender,
+				CertFingerprint: fingerprint,
+				Sequence:        msg.Sequence,
+				Transport:       wrapper,
 			}
 		}
 
 	case blobReceived:
 		var hash *fftypes.Bytes32
+		var fingerprint string
 		hash, err = fftypes.ParseBytes32(h.ctx, msg.Hash)
+		if err == nil {
+			fingerprint, err = h.senderCertFingerprint(msg)
+		}
 		if err == nil {
 			_, namespace, dataID = splitBlobPath(msg.Path)
 			e.dxType = dataexchange.DXEventTypePrivateBlobReceived
 			e.privateBlobReceived = &dataexchange.PrivateBlobReceived{
-				Namespace:  namespace,
-				PeerID:     msg.Sender,
-				Hash:       *hash,
-				Size:       msg.Size,
-				PayloadRef: msg.Path,
-				DataID:     dataID,
+				Namespace:       namespace,
+				PeerID:          msg.Sender,
+				CertFingerprint: fingerprint,
+				Hash:            *hash,
+				Size:            msg.Size,
+				PayloadRef:      msg.Path,
+				DataID:          dataID,
 			}
 		}
 
@@ -209,6 +251,37 @@ func (h *FFDX) dispatchEvent(msg *wsEvent) {
 	}
 }
 
+// withBlobProgress adds the progress reported on a blob transfer event to the output of the operation,
+// so that it can be queried while the transfer is in flight, and used to resume the transfer on retry
+func withBlobProgress(output fftypes.JSONObject, msg *wsEvent) fftypes.JSONObject {
+	if msg.Progress == nil {
+		return output
+	}
+	if output == nil {
+		output = fftypes.JSONObject{}
+	}
+	output[dataexchange.TransferProgressKey] = &dataexchange.TransferProgress{
+		BytesSent:    msg.Progress.BytesSent,
+		ChunksAcked:  msg.Progress.ChunksAcked,
+		TotalBytes:   msg.Size,
+		ResumeOffset: msg.Progress.ResumeOffset,
+	}
+	return output
+}
+
+// senderCertFingerprint returns the fingerprint of the TLS certificate the sending peer connected with,
+// or an empty string if the connector does not report the certificate
+func (h *FFDX) senderCertFingerprint(msg *wsEvent) (string, error) {
+	if msg.SenderCert == "" {
+		return "", nil
+	}
+	fingerprint, err := certFingerprint(h.ctx, msg.SenderCert)
+	if err != nil {
+		return "", fmt.Errorf("invalid transmission from peer '%s': %s", msg.Sender, err)
+	}
+	return fingerprint, nil
+}
+
 func (h *FFDX) callbackWithRetry(namespace, recipient string, e *dxEvent) {
 	_ = h.retry.Do(h.ctx, "dispatch ffdx event", func(attempt int) (retry bool, err error) {
 		// Return until success, or the context closes.
@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/retry"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/proxy"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 )
@@ -48,6 +49,9 @@ type FFDX struct {
 	wsconn          wsclient.WSClient
 	needsInit       bool
 	initialized     bool
+	connected       bool
+	connections     core.PluginConnectionTracker
+	eventQueue      core.InboundEventQueue
 	initMutex       sync.Mutex
 	nodes           map[string]*dxNode
 	ackChannel      chan *ack
@@ -123,6 +127,7 @@ const (
 	blobDelivered       msgType = "blob-delivered"
 	blobAcknowledged    msgType = "blob-acknowledged"
 	blobFailed          msgType = "blob-failed"
+	blobProgress        msgType = "blob-progress"
 )
 
 type responseWithRequestID struct {
@@ -147,6 +152,7 @@ type transferBlob struct {
 	Recipient string `json:"recipient"`
 	RequestID string `json:"requestId"`
 	Sender    string `json:"sender"`
+	Offset    int64  `json:"offset,omitempty"`
 }
 
 type wsAck struct {
@@ -184,11 +190,15 @@ func (h *FFDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config co
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", "dataexchange.ffdx")
 	}
 
+	var wsFactory proxy.WSFactory
 	wsConfig, err := wsclient.GenerateConfig(ctx, config)
 	if err == nil {
-		h.client, err = ffresty.New(h.ctx, config)
+		h.client, err = proxy.NewRESTClient(h.ctx, config)
 	}
 
+	if err == nil {
+		wsFactory, err = proxy.NewWSFactory(ctx, config)
+	}
 	if err != nil {
 		return err
 	}
@@ -206,7 +216,7 @@ func (h *FFDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config co
 		wsConfig.WSKeyPath = "/ws"
 	}
 
-	h.wsconn, err = wsclient.New(ctx, wsConfig, h.beforeConnect, nil)
+	h.wsconn, err = wsFactory.New(ctx, wsConfig, h.beforeConnect, h.afterConnect)
 	if err != nil {
 		return err
 	}
@@ -238,6 +248,14 @@ func (h *FFDX) SetHandler(networkNamespace, nodeName string, handler dataexchang
 	}
 }
 
+func (h *FFDX) SetConnectionListener(listener core.PluginConnectionListener) {
+	h.connections.SetListener(listener)
+}
+
+func (h *FFDX) SetEventQueue(queue core.InboundEventQueue) {
+	h.eventQueue = queue
+}
+
 func (h *FFDX) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
 	h.callbacks.writeLock.Lock()
 	defer h.callbacks.writeLock.Unlock()
@@ -273,10 +291,23 @@ func (h *FFDX) Capabilities() *dataexchange.Capabilities {
 	return h.capabilities
 }
 
+func (h *FFDX) CheckReady(ctx context.Context) error {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+	if !h.connected {
+		return i18n.NewError(ctx, coremsgs.MsgWebSocketNotConnected, h.Name())
+	}
+	return nil
+}
+
 func (h *FFDX) beforeConnect(ctx context.Context, w wsclient.WSClient) error {
 	h.initMutex.Lock()
 	defer h.initMutex.Unlock()
 
+	// Called before every attempt to (re)connect, so the previous connection has been lost
+	h.connected = false
+	h.connections.Update("", false)
+
 	if h.needsInit {
 		h.initialized = false
 		var status dxStatus
@@ -299,6 +330,14 @@ func (h *FFDX) beforeConnect(ctx context.Context, w wsclient.WSClient) error {
 	return nil
 }
 
+func (h *FFDX) afterConnect(ctx context.Context, w wsclient.WSClient) error {
+	h.initMutex.Lock()
+	defer h.initMutex.Unlock()
+	h.connected = true
+	h.connections.Update("", true)
+	return nil
+}
+
 func (h *FFDX) checkInitialized(ctx context.Context) error {
 	h.initMutex.Lock()
 	defer h.initMutex.Unlock()
@@ -313,6 +352,29 @@ func (h *FFDX) GetPeerID(peer fftypes.JSONObject) string {
 	return peer.GetString("id")
 }
 
+// GetPeerStatus checks the peer is registered with the data exchange. The HTTPS data exchange connects to
+// its peers per request, so does not know whether a registered peer is online
+func (h *FFDX) GetPeerStatus(ctx context.Context, peer fftypes.JSONObject) (core.PeerStatus, error) {
+	if err := h.checkInitialized(ctx); err != nil {
+		return "", err
+	}
+
+	var peers []fftypes.JSONObject
+	res, err := h.client.R().SetContext(ctx).
+		SetResult(&peers).
+		Get("/api/v1/peers")
+	if err != nil || !res.IsSuccess() {
+		return "", ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgDXRESTErr)
+	}
+	peerID := h.GetPeerID(peer)
+	for _, p := range peers {
+		if h.GetPeerID(p) == peerID {
+			return core.PeerStatusUnknown, nil
+		}
+	}
+	return core.PeerStatusUnregistered, nil
+}
+
 func (h *FFDX) GetEndpointInfo(ctx context.Context, nodeName string) (peer fftypes.JSONObject, err error) {
 	res, err := h.client.R().SetContext(ctx).
 		SetResult(&peer).
@@ -326,6 +388,15 @@ func (h *FFDX) GetEndpointInfo(ctx context.Context, nodeName string) (peer fftyp
 		return nil, i18n.NewError(ctx, coremsgs.MsgDXInfoMissingID)
 	}
 	peer["id"] = fmt.Sprintf("%s%s%s", id, DXIDSeparator, nodeName)
+	if cert := peer.GetString("cert"); cert != "" {
+		// Pin the fingerprint of our certificate, so other members can verify transfers really came from us
+		fingerprint, err := certFingerprint(ctx, cert)
+		if err != nil {
+			log.L(ctx).Warnf("Unable to pin certificate of data exchange peer '%s': %s", id, err)
+		} else {
+			peer[dataexchange.PeerCertFingerprintKey] = fingerprint
+		}
+	}
 	return peer, nil
 }
 
@@ -427,7 +498,7 @@ func (h *FFDX) SendMessage(ctx context.Context, nsOpID string, peer, sender ffty
 	return nil
 }
 
-func (h *FFDX) TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string) (err error) {
+func (h *FFDX) TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string, offset int64) (err error) {
 	if err := h.checkInitialized(ctx); err != nil {
 		return err
 	}
@@ -439,6 +510,7 @@ func (h *FFDX) TransferBlob(ctx context.Context, nsOpID string, peer, sender fft
 			Recipient: h.GetPeerID(peer),
 			RequestID: nsOpID,
 			Sender:    h.GetPeerID(sender),
+			Offset:    offset,
 		}).
 		SetResult(&responseData).
 		Post("/api/v1/transfers")
@@ -470,10 +542,37 @@ func (h *FFDX) ackLoop() {
 	}
 }
 
+// isQueued returns true if the event is stored in the event queue, and acknowledged before it is processed.
+// Received messages are not queued when manifests are enabled, as the manifest is returned with the ack.
+func (h *FFDX) isQueued(msg *wsEvent) bool {
+	return h.eventQueue != nil && !(msg.Type == messageReceived && h.capabilities.Manifest)
+}
+
+// handleQueuedEvent dispatches an event that was acknowledged when it was stored in the event queue,
+// and waits for it to be processed
+func (h *FFDX) handleQueuedEvent(ctx context.Context, msgBytes []byte) error {
+	var msg wsEvent
+	_ = json.Unmarshal(msgBytes, &msg) // only valid JSON is queued
+	acked := make(chan string, 1)
+	h.dispatchEvent(&msg, acked)
+	select {
+	case <-acked:
+		return nil
+	case <-ctx.Done():
+		return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+	}
+}
+
 func (h *FFDX) eventLoop() {
 	defer h.wsconn.Close()
 	l := log.L(h.ctx).WithField("role", "event-loop")
 	ctx := log.WithLogger(h.ctx, l)
+	if h.eventQueue != nil {
+		// Queued events are processed for as long as the event loop is running
+		consumeCtx, stopConsuming := context.WithCancel(ctx)
+		defer stopConsuming()
+		h.eventQueue.Consume(consumeCtx, "", h.handleQueuedEvent)
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -494,7 +593,15 @@ func (h *FFDX) eventLoop() {
 				continue // Swallow this and move on
 			}
 			l.Debugf("Received %s event from DX sender=%s", msg.Type, msg.Sender)
-			h.dispatchEvent(&msg)
+			if !h.isQueued(&msg) {
+				h.dispatchEvent(&msg, nil)
+				continue
+			}
+			if err := h.eventQueue.Enqueue(ctx, "", msgBytes); err != nil {
+				l.Debugf("Event loop exiting (%s)", err)
+				return
+			}
+			(&dxEvent{ffdx: h, id: msg.EventID}).Ack()
 		}
 	}
 }
@@ -131,6 +131,29 @@ func TestInitBadTLS(t *testing.T) {
 	assert.Regexp(t, "FF00153", err)
 }
 
+func TestInitBadProxy(t *testing.T) {
+	coreconfig.Reset()
+	h := &FFDX{}
+	h.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.Set(ffresty.HTTPConfigProxyURL, "ftp://proxy.example.com")
+	ctx, cancel := context.WithCancel(context.Background())
+	err := h.Init(ctx, cancel, utConfig)
+	assert.Regexp(t, "FF10611", err)
+}
+
+func TestInitWebSocketProxy(t *testing.T) {
+	coreconfig.Reset()
+	h := &FFDX{}
+	h.InitConfig(utConfig)
+	utConfig.Set(ffresty.HTTPConfigURL, "http://dx.example.com:12345")
+	utConfig.Set(ffresty.HTTPConfigProxyURL, "http://proxy.example.com:8080")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := h.Init(ctx, cancel, utConfig)
+	assert.NoError(t, err)
+}
+
 func TestInitMissingURL(t *testing.T) {
 	coreconfig.Reset()
 	h := &FFDX{}
@@ -191,6 +214,28 @@ func TestGetEndpointInfo(t *testing.T) {
 	}, peer)
 }
 
+func TestGetEndpointInfoPinsCert(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	certPEM, fingerprint := newTestCert(t)
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/id", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"id":       "peer1",
+			"endpoint": "https://peer1.example.com",
+			"cert":     certPEM,
+		}))
+
+	peer, err := h.GetEndpointInfo(context.Background(), "node1")
+	assert.NoError(t, err)
+	assert.Equal(t, fftypes.JSONObject{
+		"id":              "peer1/node1",
+		"endpoint":        "https://peer1.example.com",
+		"cert":            certPEM,
+		"certFingerprint": fingerprint,
+	}, peer)
+}
+
 func TestGetEndpointMissingID(t *testing.T) {
 	h, _, _, httpURL, done := newTestFFDX(t, false)
 	defer done()
@@ -372,6 +417,35 @@ func TestSendMessageError(t *testing.T) {
 	assert.Regexp(t, "FF10229", err)
 }
 
+func TestGetPeerStatus(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/peers", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, []fftypes.JSONObject{
+			{"id": "peer1/node1", "endpoint": "https://peer1.example.com"},
+		}))
+
+	status, err := h.GetPeerStatus(context.Background(), fftypes.JSONObject{"id": "peer1/node1"})
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusUnknown, status)
+
+	status, err = h.GetPeerStatus(context.Background(), fftypes.JSONObject{"id": "peer2/node2"})
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusUnregistered, status)
+}
+
+func TestGetPeerStatusError(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/peers", httpURL),
+		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
+
+	_, err := h.GetPeerStatus(context.Background(), fftypes.JSONObject{"id": "peer1/node1"})
+	assert.Regexp(t, "FF10229", err)
+}
+
 func TestTransferBlob(t *testing.T) {
 
 	h, _, _, httpURL, done := newTestFFDX(t, false)
@@ -382,7 +456,7 @@ func TestTransferBlob(t *testing.T) {
 
 	peer := fftypes.JSONObject{"id": "peer1"}
 	sender := fftypes.JSONObject{"id": "sender1"}
-	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1")
+	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1", 0)
 	assert.NoError(t, err)
 }
 
@@ -395,7 +469,7 @@ func TestTransferBlobError(t *testing.T) {
 
 	peer := fftypes.JSONObject{"id": "peer1"}
 	sender := fftypes.JSONObject{"id": "sender1"}
-	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1")
+	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1", 0)
 	assert.Regexp(t, "FF10229", err)
 }
 
@@ -602,6 +676,159 @@ func TestMessageEvents(t *testing.T) {
 	ocb.AssertExpectations(t)
 }
 
+func TestCompressedMessageEvent(t *testing.T) {
+
+	h, toServer, fromServer, _, done := newTestFFDX(t, false)
+	defer done()
+
+	mcb := &dataexchangemocks.Callbacks{}
+	h.SetHandler("ns1", "node1", mcb)
+	h.AddNode(context.Background(), "ns1", "node1", fftypes.JSONObject{"id": "peer1"})
+
+	err := h.Start()
+	assert.NoError(t, err)
+
+	batchID := fftypes.NewUUID()
+	tw := &core.TransportWrapper{
+		Batch: &core.Batch{
+			BatchHeader: core.BatchHeader{ID: batchID, Namespace: "ns1"},
+			Compression: core.BatchCompressionGzip,
+		},
+	}
+	payload, err := tw.Serialize(context.Background())
+	assert.NoError(t, err)
+	message, err := json.Marshal(string(payload))
+	assert.NoError(t, err)
+
+	mcb.On("DXEvent", h, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.EventID() == "1" &&
+			ev.Type() == dataexchange.DXEventTypeMessageReceived &&
+			ev.MessageReceived().Transport.Batch.ID.Equals(batchID)
+	})).Run(manifestAcker("")).Return(nil)
+	fromServer <- `{"id":"1","type":"message-received","sender":"peer2","recipient":"peer1","message":` + string(message) + `}`
+	msg := <-toServer
+	assert.Equal(t, `{"action":"ack","id":"1"}`, string(msg))
+
+	// Compressed payloads that cannot be expanded are acked without dispatch
+	fromServer <- `{"id":"2","type":"message-received","sender":"peer2","recipient":"peer1","message":"{\"compressed\":\"H4s=\"}"}`
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"2"}`, string(msg))
+
+	mcb.AssertExpectations(t)
+}
+
+func TestMessageEventsWithSenderCert(t *testing.T) {
+
+	h, toServer, fromServer, _, done := newTestFFDX(t, false)
+	defer done()
+
+	mcb := &dataexchangemocks.Callbacks{}
+	h.SetHandler("ns1", "node1", mcb)
+	h.AddNode(context.Background(), "ns1", "node1", fftypes.JSONObject{"id": "peer1"})
+
+	err := h.Start()
+	assert.NoError(t, err)
+
+	certPEM, fingerprint := newTestCert(t)
+	senderCert, err := json.Marshal(certPEM)
+	assert.NoError(t, err)
+
+	mcb.On("DXEvent", h, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.EventID() == "1" &&
+			ev.Type() == dataexchange.DXEventTypeMessageReceived &&
+			ev.MessageReceived().CertFingerprint == fingerprint &&
+			ev.MessageReceived().Sequence == 5
+	})).Run(manifestAcker("")).Return(nil)
+	fromServer <- `{"id":"1","type":"message-received","sender":"peer2","senderCert":` + string(senderCert) + `,"recipient":"peer1","sequence":5,"message":"{\"batch\":{\"namespace\":\"ns1\"}}"}`
+	msg := <-toServer
+	assert.Equal(t, `{"action":"ack","id":"1"}`, string(msg))
+
+	hash := fftypes.NewRandB32()
+	mcb.On("DXEvent", h, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.EventID() == "2" &&
+			ev.Type() == dataexchange.DXEventTypePrivateBlobReceived &&
+			ev.PrivateBlobReceived().CertFingerprint == fingerprint
+	})).Run(acker()).Return(nil)
+	fromServer <- fmt.Sprintf(`{"id":"2","type":"blob-received","sender":"peer2","senderCert":%s,"recipient":"peer1","path":"ns1/%s","hash":"%s","size":12345}`, senderCert, fftypes.NewUUID(), hash)
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"2"}`, string(msg))
+
+	// Bad certificates are acked without dispatch
+	fromServer <- `{"id":"3","type":"message-received","sender":"peer2","senderCert":"bad","recipient":"peer1","message":"{\"batch\":{\"namespace\":\"ns1\"}}"}`
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"3"}`, string(msg))
+
+	fromServer <- fmt.Sprintf(`{"id":"4","type":"blob-received","sender":"peer2","senderCert":"bad","recipient":"peer1","path":"ns1/%s","hash":"%s","size":12345}`, fftypes.NewUUID(), hash)
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"4"}`, string(msg))
+
+	mcb.AssertExpectations(t)
+}
+
+func TestBlobProgressEvents(t *testing.T) {
+
+	h, toServer, fromServer, _, done := newTestFFDX(t, false)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	h.SetOperationHandler("ns1", ocb)
+
+	err := h.Start()
+	assert.NoError(t, err)
+
+	namespacedID1 := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
+	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdate) bool {
+		return ev.NamespacedOpID == namespacedID1 &&
+			ev.Status == core.OpStatusPending &&
+			ev.Output.String() == `{"progress":{"bytesSent":3000,"chunksAcked":2,"totalBytes":5000,"resumeOffset":2048}}`
+	})).Run(opAcker()).Return(nil)
+	fromServer <- `{"id":"1","type":"blob-progress","requestID":"` + namespacedID1 + `","size":5000,"progress":{"bytesSent":3000,"chunksAcked":2,"resumeOffset":2048}}`
+	msg := <-toServer
+	assert.Equal(t, `{"action":"ack","id":"1"}`, string(msg))
+
+	namespacedID2 := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
+	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdate) bool {
+		return ev.NamespacedOpID == namespacedID2 &&
+			ev.Status == core.OpStatusFailed &&
+			ev.Output.String() == `{"progress":{"bytesSent":4096,"chunksAcked":4,"totalBytes":5000,"resumeOffset":4096}}`
+	})).Run(opAcker()).Return(nil)
+	fromServer <- `{"id":"2","type":"blob-failed","requestID":"` + namespacedID2 + `","error":"pop","size":5000,"progress":{"bytesSent":4096,"chunksAcked":4,"resumeOffset":4096}}`
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"2"}`, string(msg))
+
+	namespacedID3 := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
+	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdate) bool {
+		return ev.NamespacedOpID == namespacedID3 &&
+			ev.Status == core.OpStatusFailed &&
+			ev.Output.String() == `{"progress":{"bytesSent":10,"chunksAcked":0,"totalBytes":5000,"resumeOffset":0},"some":"details"}`
+	})).Run(opAcker()).Return(nil)
+	fromServer <- `{"id":"3","type":"blob-failed","requestID":"` + namespacedID3 + `","error":"pop","size":5000,"info":{"some":"details"},"progress":{"bytesSent":10}}`
+	msg = <-toServer
+	assert.Equal(t, `{"action":"ack","id":"3"}`, string(msg))
+
+	ocb.AssertExpectations(t)
+}
+
+func TestTransferBlobResume(t *testing.T) {
+
+	h, _, _, httpURL, done := newTestFFDX(t, false)
+	defer done()
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/transfers", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			var body transferBlob
+			err := json.NewDecoder(req.Body).Decode(&body)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(2048), body.Offset)
+			return httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{})(req)
+		})
+
+	peer := fftypes.JSONObject{"id": "peer1"}
+	sender := fftypes.JSONObject{"id": "sender1"}
+	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1", 2048)
+	assert.NoError(t, err)
+}
+
 func TestBlobEvents(t *testing.T) {
 
 	h, toServer, fromServer, _, done := newTestFFDX(t, false)
@@ -706,6 +933,94 @@ func TestEventsWithManifest(t *testing.T) {
 	ocb.AssertExpectations(t)
 }
 
+func TestEventsQueued(t *testing.T) {
+
+	h, toServer, fromServer, _, done := newTestFFDX(t, true)
+	defer done()
+
+	mq := &coremocks.InboundEventQueue{}
+	h.SetEventQueue(mq)
+	handlerReady := make(chan core.InboundEventHandler, 1)
+	mq.On("Consume", mock.Anything, "", mock.Anything).Run(func(args mock.Arguments) {
+		handlerReady <- args[2].(core.InboundEventHandler)
+	})
+
+	err := h.Start()
+	assert.NoError(t, err)
+	handler := <-handlerReady
+
+	ocb := &coremocks.OperationCallbacks{}
+	h.SetOperationHandler("ns1", ocb)
+
+	// The event is acked as soon as it is queued
+	namespacedID1 := fmt.Sprintf("ns1:%s", fftypes.NewUUID())
+	event1 := `{"id":"1","type":"message-delivered","requestID":"` + namespacedID1 + `"}`
+	mq.On("Enqueue", mock.Anything, "", []byte(event1)).Return(nil)
+	fromServer <- event1
+	msg := <-toServer
+	assert.Equal(t, `{"action":"ack","id":"1"}`, string(msg))
+
+	// Processing the queued event completes without another ack
+	ocb.On("OperationUpdate", mock.MatchedBy(func(ev *core.OperationUpdate) bool {
+		return ev.NamespacedOpID == namespacedID1 &&
+			ev.Status == core.OpStatusPending &&
+			ev.Plugin == "ffdx"
+	})).Run(opAcker()).Return(nil)
+	err = handler(context.Background(), []byte(event1))
+	assert.NoError(t, err)
+
+	mq.AssertExpectations(t)
+	ocb.AssertExpectations(t)
+}
+
+func TestEventsQueuedHandlerContextCancelled(t *testing.T) {
+
+	h, _, _, _, done := newTestFFDX(t, false)
+	defer done()
+
+	ocb := &coremocks.OperationCallbacks{}
+	h.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Return(nil) // never completes
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := h.handleQueuedEvent(ctx, []byte(`{"id":"1","type":"message-delivered","requestID":"ns1:`+fftypes.NewUUID().String()+`"}`))
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestIsQueued(t *testing.T) {
+	h := &FFDX{capabilities: &dataexchange.Capabilities{Manifest: true}}
+	assert.False(t, h.isQueued(&wsEvent{Type: messageDelivered}))
+
+	h.SetEventQueue(&coremocks.InboundEventQueue{})
+	assert.True(t, h.isQueued(&wsEvent{Type: messageDelivered}))
+	assert.True(t, h.isQueued(&wsEvent{Type: blobReceived}))
+
+	// The manifest of a received message is returned with the ack, after it is processed
+	assert.False(t, h.isQueued(&wsEvent{Type: messageReceived}))
+	h.capabilities.Manifest = false
+	assert.True(t, h.isQueued(&wsEvent{Type: messageReceived}))
+}
+
+func TestEventLoopEnqueueFail(t *testing.T) {
+	wsm := &wsmocks.WSClient{}
+	mq := &coremocks.InboundEventQueue{}
+	h := &FFDX{
+		ctx:          context.Background(),
+		capabilities: &dataexchange.Capabilities{},
+		wsconn:       wsm,
+		eventQueue:   mq,
+	}
+	r := make(chan []byte, 1)
+	r <- []byte(`{"id":"1","type":"message-delivered"}`)
+	mq.On("Consume", mock.Anything, "", mock.Anything)
+	mq.On("Enqueue", mock.Anything, "", mock.Anything).Return(fmt.Errorf("pop"))
+	wsm.On("Close").Return()
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	h.eventLoop() // we're simply looking for it exiting
+	mq.AssertExpectations(t)
+}
+
 func TestEventLoopReceiveClosed(t *testing.T) {
 	dxc := &dataexchangemocks.Callbacks{}
 	wsm := &wsmocks.WSClient{}
@@ -873,11 +1188,29 @@ func TestDXUninitialized(t *testing.T) {
 
 	peer := fftypes.JSONObject{"id": "peer1"}
 	sender := fftypes.JSONObject{"id": "sender1"}
-	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1")
+	err := h.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, "ns1/id1", 0)
 	assert.Regexp(t, "FF10342", err)
 
 	err = h.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), peer, sender, []byte(`some data`))
 	assert.Regexp(t, "FF10342", err)
+
+	_, err = h.GetPeerStatus(context.Background(), peer)
+	assert.Regexp(t, "FF10342", err)
+}
+
+func TestCheckReady(t *testing.T) {
+	h, _, _, _, done := newTestFFDX(t, false)
+	defer done()
+
+	err := h.beforeConnect(context.Background(), nil)
+	assert.NoError(t, err)
+	err = h.CheckReady(context.Background())
+	assert.Regexp(t, "FF10514.*ffdx", err)
+
+	err = h.afterConnect(context.Background(), nil)
+	assert.NoError(t, err)
+	err = h.CheckReady(context.Background())
+	assert.NoError(t, err)
 }
 
 func TestDeleteBlob(t *testing.T) {
@@ -905,3 +1238,25 @@ func TestDeleteBlobFail(t *testing.T) {
 	err := h.DeleteBlob(context.Background(), fmt.Sprintf("ns1/%s", u))
 	assert.Regexp(t, "FF10229", err)
 }
+
+func TestConnectionListener(t *testing.T) {
+	h, _, _, _, done := newTestFFDX(t, false)
+	defer done()
+
+	mcl := coremocks.NewPluginConnectionListener(t)
+	h.SetConnectionListener(mcl)
+
+	// Initial connection is not reported
+	err := h.beforeConnect(context.Background(), nil)
+	assert.NoError(t, err)
+	err = h.afterConnect(context.Background(), nil)
+	assert.NoError(t, err)
+
+	mcl.On("PluginConnectionChanged", "", false).Once()
+	err = h.beforeConnect(context.Background(), nil)
+	assert.NoError(t, err)
+
+	mcl.On("PluginConnectionChanged", "", true).Once()
+	err = h.afterConnect(context.Background(), nil)
+	assert.NoError(t, err)
+}
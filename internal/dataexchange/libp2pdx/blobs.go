@@ -0,0 +1,125 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libp2pdx
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// blobStore keeps blobs as files under a directory, with the payload reference as the relative path of the file.
+// Blobs uploaded locally are stored under "namespace/id", and blobs received from peers under "peerId/namespace/id".
+type blobStore struct {
+	root string
+}
+
+func splitLast(s string, sep string) (string, string) {
+	split := strings.LastIndex(s, sep)
+	if split == -1 {
+		return "", s
+	}
+	return s[:split], s[split+1:]
+}
+
+func splitBlobPath(path string) (prefix, namespace, id string) {
+	path, id = splitLast(path, "/")
+	path, namespace = splitLast(path, "/")
+	return path, namespace, id
+}
+
+// filePath resolves a payload reference to a file, rejecting any reference that is not a plain relative path
+func (bs *blobStore) filePath(ctx context.Context, payloadRef string) (string, error) {
+	for _, segment := range strings.Split(payloadRef, "/") {
+		if segment == "" || segment == "." || segment == ".." || strings.ContainsAny(segment, `\:`) {
+			return "", i18n.NewError(ctx, coremsgs.MsgLibp2pInvalidPayloadRef, payloadRef)
+		}
+	}
+	return filepath.Join(bs.root, filepath.FromSlash(payloadRef)), nil
+}
+
+// write stores the content of a blob, starting at the given offset of a partially received copy, and returns the hash
+// and size of the whole blob. The blob is only visible under its payload reference once all of it has been written.
+func (bs *blobStore) write(ctx context.Context, payloadRef string, offset int64, content io.Reader) (hash *fftypes.Bytes32, size int64, err error) {
+	path, err := bs.filePath(ctx, payloadRef)
+	if err != nil {
+		return nil, -1, err
+	}
+	partialPath := path + ".partial"
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	f, err := os.OpenFile(partialPath, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	defer f.Close()
+
+	// Anything beyond the offset is discarded, and the partial copy must already hold everything before it
+	hasher := sha256.New()
+	stored, err := io.CopyN(hasher, f, offset)
+	if err == nil {
+		err = f.Truncate(offset)
+	}
+	if err == nil {
+		var written int64
+		written, err = io.Copy(io.MultiWriter(f, hasher), content)
+		size = stored + written
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if err != nil {
+		return nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	if err := os.Rename(partialPath, path); err != nil {
+		return nil, -1, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	var h fftypes.Bytes32
+	copy(h[:], hasher.Sum(nil))
+	return &h, size, nil
+}
+
+func (bs *blobStore) open(ctx context.Context, payloadRef string) (*os.File, error) {
+	path, err := bs.filePath(ctx, payloadRef)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	return f, nil
+}
+
+func (bs *blobStore) delete(ctx context.Context, payloadRef string) error {
+	path, err := bs.filePath(ctx, payloadRef)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libp2pdx
+
+import (
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// Libp2pListenAddresses are the multiaddrs the node listens on for streams from other nodes
+	Libp2pListenAddresses = "listenAddresses"
+	// Libp2pAnnounceAddresses replace the listen addresses in the endpoint info of the node, such as the public address of a NAT
+	Libp2pAnnounceAddresses = "announceAddresses"
+	// Libp2pKeyFile is the file the private key of the node is stored in, which is generated if it does not exist
+	Libp2pKeyFile = "keyFile"
+	// Libp2pBlobsPath is the directory blobs are stored in
+	Libp2pBlobsPath = "blobs.path"
+	// Libp2pManifestEnabled determines whether the manifest generated by the receiving FireFly is returned to the sender
+	Libp2pManifestEnabled = "manifestEnabled"
+	// Libp2pStreamTimeout is the time allowed to write a message or blob to a peer
+	Libp2pStreamTimeout = "streamTimeout"
+
+	Libp2pNATPortMapping  = "nat.portMapping"
+	Libp2pNATHolePunching = "nat.holePunching"
+	Libp2pNATRelays       = "nat.relays"
+
+	Libp2pEventRetryInitialDelay = "eventRetry.initialDelay"
+	Libp2pEventRetryMaxDelay     = "eventRetry.maxDelay"
+	Libp2pEventRetryFactor       = "eventRetry.factor"
+
+	defaultListenAddress = "/ip4/0.0.0.0/tcp/5100"
+)
+
+func (p *Libp2pDX) InitConfig(config config.Section) {
+	config.AddKnownKey(Libp2pListenAddresses, []string{defaultListenAddress})
+	config.AddKnownKey(Libp2pAnnounceAddresses)
+	config.AddKnownKey(Libp2pKeyFile)
+	config.AddKnownKey(Libp2pBlobsPath)
+	config.AddKnownKey(Libp2pManifestEnabled, false)
+	config.AddKnownKey(Libp2pStreamTimeout, "2m")
+	config.AddKnownKey(Libp2pNATPortMapping, false)
+	config.AddKnownKey(Libp2pNATHolePunching, false)
+	config.AddKnownKey(Libp2pNATRelays)
+	config.AddKnownKey(Libp2pEventRetryInitialDelay, 50*time.Millisecond)
+	config.AddKnownKey(Libp2pEventRetryMaxDelay, 30*time.Second)
+	config.AddKnownKey(Libp2pEventRetryFactor, 2.0)
+}
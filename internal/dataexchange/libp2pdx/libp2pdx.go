@@ -0,0 +1,434 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libp2pdx
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/security/noise"
+	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// DXIDSeparator separates the libp2p peer ID of the host from the name of the node, in the peer ID of a node,
+// so that multiple nodes can share a host
+const DXIDSeparator = "/"
+
+const (
+	peerInfoID        = "id"
+	peerInfoPeerID    = "peerId"
+	peerInfoAddresses = "addresses"
+)
+
+// Libp2pDX is a data exchange plugin that transfers messages and blobs over libp2p streams directly between
+// the FireFly nodes of the network, without a separate data exchange connector.
+//
+// Streams are always encrypted and authenticated with the key of each host (Noise, or TLS 1.3), and the sender of each
+// message and blob is verified against the peer ID of the host it arrived from. Transfers are acknowledged by the
+// receiving node once its FireFly has processed the event, so the sender reports the operation as succeeded only after
+// the transfer is safely received.
+type Libp2pDX struct {
+	ctx           context.Context
+	cancelCtx     context.CancelFunc
+	capabilities  *dataexchange.Capabilities
+	callbacks     callbacks
+	host          host.Host
+	blobs         *blobStore
+	streamTimeout time.Duration
+	retry         *retry.Retry
+	nodesMutex    sync.Mutex
+	nodes         map[string]*dxNode
+}
+
+type dxNode struct {
+	Name string
+	Peer fftypes.JSONObject
+}
+
+type callbacks struct {
+	plugin     *Libp2pDX
+	writeLock  sync.Mutex
+	handlers   map[string]dataexchange.Callbacks
+	opHandlers map[string]core.OperationCallbacks
+}
+
+func (cb *callbacks) OperationUpdate(ctx context.Context, update *core.OperationUpdate) {
+	namespace, _, _ := core.ParseNamespacedOpID(ctx, update.NamespacedOpID)
+	cb.writeLock.Lock()
+	handler, ok := cb.opHandlers[namespace]
+	cb.writeLock.Unlock()
+	if ok {
+		handler.OperationUpdate(update)
+	} else {
+		log.L(ctx).Errorf("No handler found for DX operation '%s'", update.NamespacedOpID)
+	}
+}
+
+func (cb *callbacks) DXEvent(ctx context.Context, namespace, recipient string, event dataexchange.DXEvent) error {
+	node := cb.plugin.findNode(namespace, recipient)
+	if node != nil {
+		cb.writeLock.Lock()
+		handler, ok := cb.handlers[namespace+":"+node.Name]
+		cb.writeLock.Unlock()
+		if ok {
+			return handler.DXEvent(cb.plugin, event)
+		}
+		log.L(ctx).Errorf("No handler found for DX event '%s' namespace=%s node=%s", event.EventID(), namespace, node.Name)
+		event.Ack()
+	} else {
+		log.L(ctx).Errorf("Unknown local node for DX event '%s' recipient=%s", event.EventID(), recipient)
+		event.Ack()
+	}
+	return nil
+}
+
+func (p *Libp2pDX) Name() string {
+	return "libp2p"
+}
+
+func (p *Libp2pDX) Init(ctx context.Context, cancelCtx context.CancelFunc, config config.Section) (err error) {
+	p.ctx = log.WithLogField(ctx, "dx", "libp2p")
+	p.cancelCtx = cancelCtx
+	p.callbacks = callbacks{
+		plugin:     p,
+		handlers:   make(map[string]dataexchange.Callbacks),
+		opHandlers: make(map[string]core.OperationCallbacks),
+	}
+	p.nodes = make(map[string]*dxNode)
+
+	blobsPath := config.GetString(Libp2pBlobsPath)
+	if blobsPath == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, Libp2pBlobsPath, "dataexchange.libp2p")
+	}
+	p.blobs = &blobStore{root: blobsPath}
+	keyFile := config.GetString(Libp2pKeyFile)
+	if keyFile == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, Libp2pKeyFile, "dataexchange.libp2p")
+	}
+
+	p.capabilities = &dataexchange.Capabilities{
+		Manifest:         config.GetBool(Libp2pManifestEnabled),
+		PeerConnectivity: true,
+	}
+	p.streamTimeout = config.GetDuration(Libp2pStreamTimeout)
+	p.retry = &retry.Retry{
+		InitialDelay: config.GetDuration(Libp2pEventRetryInitialDelay),
+		MaximumDelay: config.GetDuration(Libp2pEventRetryMaxDelay),
+		Factor:       config.GetFloat64(Libp2pEventRetryFactor),
+	}
+
+	key, err := loadOrGenerateKey(ctx, keyFile)
+	if err != nil {
+		return err
+	}
+	options := []libp2p.Option{
+		libp2p.Identity(key),
+		libp2p.ListenAddrStrings(config.GetStringSlice(Libp2pListenAddresses)...),
+		libp2p.Security(noise.ID, noise.New),
+		libp2p.Security(libp2ptls.ID, libp2ptls.New),
+	}
+	natOptions, err := natOptions(ctx, config)
+	if err != nil {
+		return err
+	}
+	options = append(options, natOptions...)
+	if p.host, err = libp2p.New(options...); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgLibp2pHostErr)
+	}
+	log.L(p.ctx).Infof("libp2p data exchange host %s listening on %s", p.host.ID(), p.host.Addrs())
+
+	go func() {
+		<-p.ctx.Done()
+		_ = p.host.Close()
+	}()
+	return nil
+}
+
+func natOptions(ctx context.Context, config config.Section) ([]libp2p.Option, error) {
+	var options []libp2p.Option
+	if announce := config.GetStringSlice(Libp2pAnnounceAddresses); len(announce) > 0 {
+		addrs, err := parseAddrs(ctx, announce)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, libp2p.AddrsFactory(func([]ma.Multiaddr) []ma.Multiaddr { return addrs }))
+	}
+	if config.GetBool(Libp2pNATPortMapping) {
+		options = append(options, libp2p.NATPortMap())
+	}
+	if config.GetBool(Libp2pNATHolePunching) {
+		options = append(options, libp2p.EnableHolePunching())
+	}
+	if relays := config.GetStringSlice(Libp2pNATRelays); len(relays) > 0 {
+		addrs, err := parseAddrs(ctx, relays)
+		if err != nil {
+			return nil, err
+		}
+		relayInfos, err := peer.AddrInfosFromP2pAddrs(addrs...)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pInvalidPeer, strings.Join(relays, ","))
+		}
+		options = append(options, libp2p.EnableAutoRelayWithStaticRelays(relayInfos))
+	}
+	return options, nil
+}
+
+func parseAddrs(ctx context.Context, addrStrings []string) ([]ma.Multiaddr, error) {
+	addrs := make([]ma.Multiaddr, len(addrStrings))
+	for i, s := range addrStrings {
+		addr, err := ma.NewMultiaddr(s)
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pInvalidPeer, s)
+		}
+		addrs[i] = addr
+	}
+	return addrs, nil
+}
+
+// loadOrGenerateKey loads the private key of the host, which determines its peer ID, generating it on first start
+func loadOrGenerateKey(ctx context.Context, keyFile string) (crypto.PrivKey, error) {
+	keyBytes, err := os.ReadFile(keyFile)
+	if os.IsNotExist(err) {
+		key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		if err == nil {
+			keyBytes, err = crypto.MarshalPrivateKey(key)
+		}
+		if err == nil {
+			err = os.WriteFile(keyFile, keyBytes, 0600)
+		}
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pKeyFileErr, keyFile)
+		}
+		log.L(ctx).Infof("Generated libp2p private key in '%s'", keyFile)
+		return key, nil
+	}
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pKeyFileErr, keyFile)
+	}
+	key, err := crypto.UnmarshalPrivateKey(keyBytes)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pKeyFileErr, keyFile)
+	}
+	return key, nil
+}
+
+func (p *Libp2pDX) SetHandler(networkNamespace, nodeName string, handler dataexchange.Callbacks) {
+	p.callbacks.writeLock.Lock()
+	defer p.callbacks.writeLock.Unlock()
+	key := networkNamespace + ":" + nodeName
+	if handler == nil {
+		delete(p.callbacks.handlers, key)
+	} else {
+		p.callbacks.handlers[key] = handler
+	}
+}
+
+// SetConnectionListener is a no-op, as the libp2p host runs in process rather than connecting to a separate runtime
+func (p *Libp2pDX) SetConnectionListener(listener core.PluginConnectionListener) {}
+
+// SetEventQueue is a no-op, as there is no connector to acknowledge events to - messages from peers are
+// delivered over libp2p streams, which are only answered once the message has been processed
+func (p *Libp2pDX) SetEventQueue(queue core.InboundEventQueue) {}
+
+func (p *Libp2pDX) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
+	p.callbacks.writeLock.Lock()
+	defer p.callbacks.writeLock.Unlock()
+	if handler == nil {
+		delete(p.callbacks.opHandlers, namespace)
+	} else {
+		p.callbacks.opHandlers[namespace] = handler
+	}
+}
+
+func (p *Libp2pDX) Start() error {
+	// Streams from other nodes are refused until we are ready to deliver their events
+	p.host.SetStreamHandler(protocolID, p.handleStream)
+	return nil
+}
+
+func (p *Libp2pDX) Capabilities() *dataexchange.Capabilities {
+	return p.capabilities
+}
+
+func (p *Libp2pDX) CheckReady(ctx context.Context) error {
+	if len(p.host.Network().ListenAddresses()) == 0 {
+		return i18n.NewError(ctx, coremsgs.MsgDXNotListening)
+	}
+	return nil
+}
+
+func (p *Libp2pDX) GetPeerID(peer fftypes.JSONObject) string {
+	return peer.GetString(peerInfoID)
+}
+
+func (p *Libp2pDX) GetEndpointInfo(ctx context.Context, nodeName string) (peer fftypes.JSONObject, err error) {
+	addrs := p.host.Addrs()
+	addrStrings := make([]string, len(addrs))
+	for i, addr := range addrs {
+		addrStrings[i] = addr.String()
+	}
+	return fftypes.JSONObject{
+		peerInfoID:        fmt.Sprintf("%s%s%s", p.host.ID(), DXIDSeparator, nodeName),
+		peerInfoPeerID:    p.host.ID().String(),
+		peerInfoAddresses: addrStrings,
+	}, nil
+}
+
+// GetPeerStatus reports whether the host holds a connection to the host of the peer. Nodes sharing
+// the local host are always online
+func (p *Libp2pDX) GetPeerStatus(ctx context.Context, peerInfo fftypes.JSONObject) (core.PeerStatus, error) {
+	peerID, err := peer.Decode(peerInfo.GetString(peerInfoPeerID))
+	if err != nil {
+		return "", i18n.WrapError(ctx, err, coremsgs.MsgLibp2pInvalidPeer, peerInfo.String())
+	}
+	switch {
+	case peerID == p.host.ID(), p.host.Network().Connectedness(peerID) == network.Connected:
+		return core.PeerStatusOnline, nil
+	case len(p.host.Peerstore().Addrs(peerID)) == 0:
+		return core.PeerStatusUnregistered, nil
+	default:
+		return core.PeerStatusOffline, nil
+	}
+}
+
+// addrInfo extracts the libp2p peer ID and addresses from the peer JSON of a node
+func (p *Libp2pDX) addrInfo(ctx context.Context, peerInfo fftypes.JSONObject) (*peer.AddrInfo, error) {
+	peerID, err := peer.Decode(peerInfo.GetString(peerInfoPeerID))
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgLibp2pInvalidPeer, peerInfo.String())
+	}
+	addrs, err := parseAddrs(ctx, peerInfo.GetStringArray(peerInfoAddresses))
+	if err != nil {
+		return nil, err
+	}
+	return &peer.AddrInfo{ID: peerID, Addrs: addrs}, nil
+}
+
+func (p *Libp2pDX) AddNode(ctx context.Context, networkNamespace, nodeName string, peerInfo fftypes.JSONObject) (err error) {
+	info, err := p.addrInfo(ctx, peerInfo)
+	if err != nil {
+		return err
+	}
+	if info.ID != p.host.ID() {
+		p.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+	}
+
+	p.nodesMutex.Lock()
+	defer p.nodesMutex.Unlock()
+	p.nodes[networkNamespace+":"+p.GetPeerID(peerInfo)] = &dxNode{
+		Peer: peerInfo,
+		Name: nodeName,
+	}
+	return nil
+}
+
+func (p *Libp2pDX) findNode(namespace, recipient string) *dxNode {
+	p.nodesMutex.Lock()
+	defer p.nodesMutex.Unlock()
+	node := p.nodes[namespace+":"+recipient]
+	if node == nil {
+		// Fall back to nodes registered on the legacy system namespace
+		// (further verification of the off-chain identity will be performed by the event handler)
+		node = p.nodes[core.LegacySystemNamespace+":"+recipient]
+	}
+	return node
+}
+
+func (p *Libp2pDX) UploadBlob(ctx context.Context, ns string, id fftypes.UUID, content io.Reader) (payloadRef string, hash *fftypes.Bytes32, size int64, err error) {
+	payloadRef = fmt.Sprintf("%s/%s", ns, id.String())
+	hash, size, err = p.blobs.write(ctx, payloadRef, 0, content)
+	if err != nil {
+		return "", nil, -1, err
+	}
+	return payloadRef, hash, size, nil
+}
+
+func (p *Libp2pDX) DownloadBlob(ctx context.Context, payloadRef string) (content io.ReadCloser, err error) {
+	return p.blobs.open(ctx, payloadRef)
+}
+
+func (p *Libp2pDX) DeleteBlob(ctx context.Context, payloadRef string) (err error) {
+	return p.blobs.delete(ctx, payloadRef)
+}
+
+func (p *Libp2pDX) SendMessage(ctx context.Context, nsOpID string, peerInfo, sender fftypes.JSONObject, data []byte) (err error) {
+	info, err := p.addrInfo(ctx, peerInfo)
+	if err != nil {
+		return err
+	}
+	go p.send(info, &frameHeader{
+		Type:      frameTypeMessage,
+		Sender:    p.GetPeerID(sender),
+		Recipient: p.GetPeerID(peerInfo),
+		RequestID: nsOpID,
+		Message:   string(data),
+	}, nil)
+	return nil
+}
+
+func (p *Libp2pDX) TransferBlob(ctx context.Context, nsOpID string, peerInfo, sender fftypes.JSONObject, payloadRef string, offset int64) (err error) {
+	info, err := p.addrInfo(ctx, peerInfo)
+	if err != nil {
+		return err
+	}
+	f, err := p.blobs.open(ctx, payloadRef)
+	if err != nil {
+		return err
+	}
+	stat, err := f.Stat()
+	if err == nil {
+		_, err = f.Seek(offset, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		return i18n.WrapError(ctx, err, coremsgs.MsgLibp2pBlobStoreErr, payloadRef)
+	}
+	go func() {
+		defer f.Close()
+		p.send(info, &frameHeader{
+			Type:      frameTypeBlob,
+			Sender:    p.GetPeerID(sender),
+			Recipient: p.GetPeerID(peerInfo),
+			RequestID: nsOpID,
+			Path:      payloadRef,
+			Offset:    offset,
+			Size:      stat.Size(),
+		}, f)
+	}()
+	return nil
+}
@@ -0,0 +1,765 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libp2pdx
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var utConfig = config.RootSection("libp2p_unit_tests")
+
+func resetTestConfig(dir string) {
+	coreconfig.Reset()
+	(&Libp2pDX{}).InitConfig(utConfig)
+	utConfig.Set(Libp2pListenAddresses, []string{"/ip4/127.0.0.1/tcp/0"})
+	utConfig.Set(Libp2pKeyFile, filepath.Join(dir, "key"))
+	utConfig.Set(Libp2pBlobsPath, filepath.Join(dir, "blobs"))
+	utConfig.Set(Libp2pStreamTimeout, "5s")
+	utConfig.Set(Libp2pEventRetryInitialDelay, "1ms")
+}
+
+func newTestLibp2pDX(t *testing.T, manifestEnabled bool) (p *Libp2pDX, done func()) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pManifestEnabled, manifestEnabled)
+
+	p = &Libp2pDX{}
+	ctx, cancel := context.WithCancel(context.Background())
+	err := p.Init(ctx, cancel, utConfig)
+	assert.NoError(t, err)
+	assert.Equal(t, "libp2p", p.Name())
+	assert.Equal(t, manifestEnabled, p.Capabilities().Manifest)
+	assert.True(t, p.Capabilities().PeerConnectivity)
+	return p, cancel
+}
+
+// connectTestNodes registers a node on each plugin with the other, and returns the peer info of both nodes
+func connectTestNodes(t *testing.T, sender, recipient *Libp2pDX) (senderPeer, recipientPeer fftypes.JSONObject) {
+	ctx := context.Background()
+	senderPeer, err := sender.GetEndpointInfo(ctx, "node1")
+	assert.NoError(t, err)
+	recipientPeer, err = recipient.GetEndpointInfo(ctx, "node2")
+	assert.NoError(t, err)
+	for _, p := range []*Libp2pDX{sender, recipient} {
+		assert.NoError(t, p.AddNode(ctx, "ns1", "node1", senderPeer))
+		assert.NoError(t, p.AddNode(ctx, "ns1", "node2", recipientPeer))
+		assert.NoError(t, p.Start())
+	}
+	return senderPeer, recipientPeer
+}
+
+func TestCheckReady(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	assert.NoError(t, p.CheckReady(context.Background()))
+
+	_ = p.host.Close()
+	assert.Regexp(t, "FF10515", p.CheckReady(context.Background()))
+}
+
+func TestInitMissingBlobsPath(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pBlobsPath, "")
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10138.*blobs.path", err)
+}
+
+func TestInitMissingKeyFile(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pKeyFile, "")
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10138.*keyFile", err)
+}
+
+func TestInitKeyFileWriteFail(t *testing.T) {
+	dir := t.TempDir()
+	resetTestConfig(dir)
+	utConfig.Set(Libp2pKeyFile, filepath.Join(dir, "missing", "key"))
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10506", err)
+}
+
+func TestInitKeyFileReadFail(t *testing.T) {
+	dir := t.TempDir()
+	resetTestConfig(dir)
+	utConfig.Set(Libp2pKeyFile, dir)
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10506", err)
+}
+
+func TestInitKeyFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+	resetTestConfig(dir)
+	err := os.WriteFile(filepath.Join(dir, "key"), []byte("not a key"), 0600)
+	assert.NoError(t, err)
+	err = (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10506", err)
+}
+
+func TestInitKeyReloaded(t *testing.T) {
+	dir := t.TempDir()
+	resetTestConfig(dir)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p1 := &Libp2pDX{}
+	assert.NoError(t, p1.Init(ctx, cancel, utConfig))
+	p2 := &Libp2pDX{}
+	assert.NoError(t, p2.Init(ctx, cancel, utConfig))
+	assert.Equal(t, p1.host.ID(), p2.host.ID())
+}
+
+func TestInitBadListenAddress(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pListenAddresses, []string{"!bad"})
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10507", err)
+}
+
+func TestInitNATOptions(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pAnnounceAddresses, []string{"/ip4/203.0.113.1/tcp/5100"})
+	utConfig.Set(Libp2pNATPortMapping, true)
+	utConfig.Set(Libp2pNATHolePunching, true)
+	utConfig.Set(Libp2pNATRelays, []string{"/ip4/203.0.113.2/tcp/4001/p2p/12D3KooWDpJ7As7BWAwRMfu1VU2WCqNjvq387JEYKDBj4kx6nXTN"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := &Libp2pDX{}
+	assert.NoError(t, p.Init(ctx, cancel, utConfig))
+	peerInfo, err := p.GetEndpointInfo(ctx, "node1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/ip4/203.0.113.1/tcp/5100"}, peerInfo.GetStringArray("addresses"))
+}
+
+func TestInitBadAnnounceAddress(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pAnnounceAddresses, []string{"!bad"})
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestInitBadRelayAddress(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pNATRelays, []string{"!bad"})
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestInitRelayMissingPeerID(t *testing.T) {
+	resetTestConfig(t.TempDir())
+	utConfig.Set(Libp2pNATRelays, []string{"/ip4/203.0.113.2/tcp/4001"})
+	err := (&Libp2pDX{}).Init(context.Background(), func() {}, utConfig)
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestEndpointInfoAndAddNode(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	peerInfo, err := p.GetEndpointInfo(context.Background(), "node1")
+	assert.NoError(t, err)
+	assert.Equal(t, p.host.ID().String()+"/node1", p.GetPeerID(peerInfo))
+	assert.Equal(t, p.host.ID().String(), peerInfo.GetString("peerId"))
+	assert.NotEmpty(t, peerInfo.GetStringArray("addresses"))
+
+	err = p.AddNode(context.Background(), "ns1", "node1", peerInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, "node1", p.findNode("ns1", p.GetPeerID(peerInfo)).Name)
+	assert.Nil(t, p.findNode("ns2", p.GetPeerID(peerInfo)))
+
+	err = p.AddNode(context.Background(), core.LegacySystemNamespace, "node1", peerInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, "node1", p.findNode("ns2", p.GetPeerID(peerInfo)).Name)
+}
+
+func TestAddNodeBadPeerID(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	err := p.AddNode(context.Background(), "ns1", "node1", fftypes.JSONObject{"id": "bad/node1", "peerId": "bad"})
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestAddNodeBadAddress(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	err := p.AddNode(context.Background(), "ns1", "node1", fftypes.JSONObject{
+		"id":        p.host.ID().String() + "/node1",
+		"peerId":    p.host.ID().String(),
+		"addresses": []string{"!bad"},
+	})
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestGetPeerStatus(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	defer done2()
+	other, done3 := newTestLibp2pDX(t, false)
+	defer done3()
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+	otherPeer, err := other.GetEndpointInfo(context.Background(), "node3")
+	assert.NoError(t, err)
+
+	status, err := sender.GetPeerStatus(context.Background(), senderPeer)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusOnline, status)
+
+	status, err = sender.GetPeerStatus(context.Background(), otherPeer)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusUnregistered, status)
+
+	status, err = sender.GetPeerStatus(context.Background(), recipientPeer)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusOffline, status)
+
+	err = sender.host.Connect(context.Background(), peer.AddrInfo{ID: recipient.host.ID(), Addrs: recipient.host.Addrs()})
+	assert.NoError(t, err)
+	status, err = sender.GetPeerStatus(context.Background(), recipientPeer)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusOnline, status)
+}
+
+func TestGetPeerStatusBadPeerID(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	_, err := p.GetPeerStatus(context.Background(), fftypes.JSONObject{"id": "bad/node1", "peerId": "bad"})
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestSetHandlers(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	p.SetHandler("ns1", "node1", &dataexchangemocks.Callbacks{})
+	assert.Len(t, p.callbacks.handlers, 1)
+	p.SetHandler("ns1", "node1", nil)
+	assert.Empty(t, p.callbacks.handlers)
+
+	p.SetOperationHandler("ns1", &coremocks.OperationCallbacks{})
+	assert.Len(t, p.callbacks.opHandlers, 1)
+	p.SetOperationHandler("ns1", nil)
+	assert.Empty(t, p.callbacks.opHandlers)
+
+	p.SetConnectionListener(&coremocks.PluginConnectionListener{})
+	p.SetEventQueue(&coremocks.InboundEventQueue{})
+}
+
+func TestUploadDownloadDeleteBlob(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	id := fftypes.NewUUID()
+	payloadRef, hash, size, err := p.UploadBlob(context.Background(), "ns1", *id, bytes.NewReader([]byte("some data")))
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1/"+id.String(), payloadRef)
+	assert.Equal(t, fftypes.HashString("some data"), hash)
+	assert.Equal(t, int64(9), size)
+
+	r, err := p.DownloadBlob(context.Background(), payloadRef)
+	assert.NoError(t, err)
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	r.Close()
+	assert.Equal(t, "some data", string(b))
+
+	assert.NoError(t, p.DeleteBlob(context.Background(), payloadRef))
+	assert.NoError(t, p.DeleteBlob(context.Background(), payloadRef))
+	_, err = p.DownloadBlob(context.Background(), payloadRef)
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestBlobInvalidPayloadRef(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	_, err := p.DownloadBlob(context.Background(), "ns1/../../etc/passwd")
+	assert.Regexp(t, "FF10502", err)
+	err = p.DeleteBlob(context.Background(), "/abs")
+	assert.Regexp(t, "FF10502", err)
+	_, _, err = p.blobs.write(context.Background(), `ns1\id`, 0, bytes.NewReader(nil))
+	assert.Regexp(t, "FF10502", err)
+}
+
+func TestUploadBlobStoreFail(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	// A file where the namespace directory should be
+	err := os.MkdirAll(p.blobs.root, 0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(p.blobs.root, "ns1"), []byte{}, 0600)
+	assert.NoError(t, err)
+
+	_, _, _, err = p.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), bytes.NewReader([]byte("some data")))
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestBlobWriteFails(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+
+	// A directory where the partial file should be
+	err := os.MkdirAll(filepath.Join(bs.root, "ns1", "id1.partial"), 0700)
+	assert.NoError(t, err)
+	_, _, err = bs.write(context.Background(), "ns1/id1", 0, bytes.NewReader(nil))
+	assert.Regexp(t, "FF10503", err)
+
+	// A directory where the blob should be
+	err = os.MkdirAll(filepath.Join(bs.root, "ns1", "id2", "child"), 0700)
+	assert.NoError(t, err)
+	_, _, err = bs.write(context.Background(), "ns1/id2", 0, bytes.NewReader(nil))
+	assert.Regexp(t, "FF10503", err)
+
+	// Resuming beyond the end of the partial copy
+	_, _, err = bs.write(context.Background(), "ns1/id3", 10, bytes.NewReader(nil))
+	assert.Regexp(t, "FF10503", err)
+
+	// Deleting a directory that is not empty
+	err = bs.delete(context.Background(), "ns1/id2")
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestBlobWriteResume(t *testing.T) {
+	bs := &blobStore{root: t.TempDir()}
+
+	err := os.MkdirAll(filepath.Join(bs.root, "ns1"), 0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(bs.root, "ns1", "id1.partial"), []byte("some dXXXXXXXX"), 0600)
+	assert.NoError(t, err)
+
+	hash, size, err := bs.write(context.Background(), "ns1/id1", 6, bytes.NewReader([]byte("ata")))
+	assert.NoError(t, err)
+	assert.Equal(t, fftypes.HashString("some data"), hash)
+	assert.Equal(t, int64(9), size)
+}
+
+func TestSendMessageEndToEnd(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, true)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, true)
+	defer done2()
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	recipient.SetHandler("ns1", "node2", mcb)
+	mcb.On("DXEvent", recipient, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		return ev.EventID() != "" &&
+			ev.Type() == dataexchange.DXEventTypeMessageReceived &&
+			ev.MessageReceived().PeerID == sender.GetPeerID(senderPeer) &&
+			ev.MessageReceived().Transport.Batch.Namespace == "ns1" &&
+			ev.PrivateBlobReceived() == nil
+	})).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).AckWithManifest("manifest data")
+	}).Return(nil)
+
+	updated := make(chan *core.OperationUpdate)
+	ocb := &coremocks.OperationCallbacks{}
+	sender.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args[0].(*core.OperationUpdate)
+	})
+
+	transport, _ := json.Marshal(&core.TransportWrapper{
+		Batch: &core.Batch{BatchHeader: core.BatchHeader{Namespace: "ns1"}},
+	})
+	err := sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, transport)
+	assert.NoError(t, err)
+
+	update := <-updated
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.True(t, update.VerifyManifest)
+	assert.Equal(t, "manifest data", update.DXManifest)
+	update.OnComplete()
+
+	mcb.AssertExpectations(t)
+	ocb.AssertExpectations(t)
+}
+
+func TestTransferBlobEndToEndResume(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, true)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, true)
+	defer done2()
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	id := fftypes.NewUUID()
+	payloadRef, hash, _, err := sender.UploadBlob(context.Background(), "ns1", *id, bytes.NewReader([]byte("some data")))
+	assert.NoError(t, err)
+
+	// The recipient already has the start of the blob, from a previous attempt
+	receivedRef := fmt.Sprintf("%s/ns1/%s", sender.host.ID(), id)
+	partialPath, _ := recipient.blobs.filePath(context.Background(), receivedRef)
+	err = os.MkdirAll(filepath.Dir(partialPath), 0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(partialPath+".partial", []byte("some"), 0600)
+	assert.NoError(t, err)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	recipient.SetHandler("ns1", "node2", mcb)
+	mcb.On("DXEvent", recipient, mock.MatchedBy(func(ev dataexchange.DXEvent) bool {
+		br := ev.PrivateBlobReceived()
+		return ev.Type() == dataexchange.DXEventTypePrivateBlobReceived &&
+			br.Namespace == "ns1" &&
+			br.DataID == id.String() &&
+			br.PeerID == sender.GetPeerID(senderPeer) &&
+			br.PayloadRef == receivedRef &&
+			br.Hash.Equals(hash) &&
+			br.Size == 9 &&
+			ev.MessageReceived() == nil
+	})).Run(func(args mock.Arguments) {
+		args[1].(dataexchange.DXEvent).Ack()
+	}).Return(nil)
+
+	updated := make(chan *core.OperationUpdate)
+	ocb := &coremocks.OperationCallbacks{}
+	sender.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args[0].(*core.OperationUpdate)
+	})
+
+	err = sender.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, payloadRef, 4)
+	assert.NoError(t, err)
+
+	update := <-updated
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.True(t, update.VerifyManifest)
+	assert.Equal(t, hash.String(), update.DXHash)
+
+	r, err := recipient.DownloadBlob(context.Background(), receivedRef)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "some data", string(b))
+
+	mcb.AssertExpectations(t)
+	ocb.AssertExpectations(t)
+}
+
+func TestSendMessageNoHandler(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	defer done2()
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	updated := make(chan *core.OperationUpdate)
+	ocb := &coremocks.OperationCallbacks{}
+	sender.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args[0].(*core.OperationUpdate)
+	})
+
+	// Events for nodes without a handler, or for unknown nodes, are acknowledged so they do not block the sender
+	transport, _ := json.Marshal(&core.TransportWrapper{
+		Batch: &core.Batch{BatchHeader: core.BatchHeader{Namespace: "ns1"}},
+	})
+	err := sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, transport)
+	assert.NoError(t, err)
+	update := <-updated
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.False(t, update.VerifyManifest)
+
+	unknownPeer := fftypes.JSONObject{}
+	for k, v := range recipientPeer {
+		unknownPeer[k] = v
+	}
+	unknownPeer["id"] = recipient.host.ID().String() + "/unknown"
+	err = sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), unknownPeer, senderPeer, transport)
+	assert.NoError(t, err)
+	update = <-updated
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+}
+
+func TestSendMessageInvalid(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	defer done2()
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	updated := make(chan *core.OperationUpdate)
+	ocb := &coremocks.OperationCallbacks{}
+	sender.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args[0].(*core.OperationUpdate)
+	})
+
+	err := sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, []byte("!json"))
+	assert.NoError(t, err)
+	update := <-updated
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10505.*invalid transmission", update.ErrorMessage)
+
+	err = sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, []byte("{}"))
+	assert.NoError(t, err)
+	update = <-updated
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10505.*nil batch", update.ErrorMessage)
+
+	err = sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, []byte(`{"compressed":"H4s="}`))
+	assert.NoError(t, err)
+	update = <-updated
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10505.*FF10509", update.ErrorMessage)
+
+	// A sender on a different host is rejected
+	impersonated := fftypes.JSONObject{"id": recipient.host.ID().String() + "/node1"}
+	err = sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, impersonated, []byte("{}"))
+	assert.NoError(t, err)
+	update = <-updated
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10505.*FF10504", update.ErrorMessage)
+}
+
+func TestSendMessageBadPeer(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	err := p.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), fftypes.JSONObject{}, fftypes.JSONObject{}, []byte("{}"))
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestSendMessageUnreachable(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+	done2()
+	<-recipient.ctx.Done()
+	time.Sleep(10 * time.Millisecond)
+
+	updated := make(chan *core.OperationUpdate)
+	ocb := &coremocks.OperationCallbacks{}
+	sender.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args[0].(*core.OperationUpdate)
+	})
+
+	err := sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, []byte("{}"))
+	assert.NoError(t, err)
+	update := <-updated
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Regexp(t, "FF10505", update.ErrorMessage)
+}
+
+func TestTransferBlobBadPeer(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	err := p.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), fftypes.JSONObject{}, fftypes.JSONObject{}, "ns1/id1", 0)
+	assert.Regexp(t, "FF10504", err)
+}
+
+func TestTransferBlobNotFound(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	peerInfo, _ := p.GetEndpointInfo(context.Background(), "node1")
+	err := p.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peerInfo, peerInfo, "ns1/id1", 0)
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestTransferBlobBadOffset(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	payloadRef, _, _, err := p.UploadBlob(context.Background(), "ns1", *fftypes.NewUUID(), bytes.NewReader([]byte("some data")))
+	assert.NoError(t, err)
+	peerInfo, _ := p.GetEndpointInfo(context.Background(), "node1")
+	err = p.TransferBlob(context.Background(), "ns1:"+fftypes.NewUUID().String(), peerInfo, peerInfo, payloadRef, -1)
+	assert.Regexp(t, "FF10503", err)
+}
+
+func TestOperationUpdateNoHandler(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	p.callbacks.OperationUpdate(context.Background(), &core.OperationUpdate{NamespacedOpID: "ns1:" + fftypes.NewUUID().String()})
+}
+
+func testFrame(header *frameHeader, content string) *bufio.Reader {
+	b, _ := json.Marshal(header)
+	return bufio.NewReader(strings.NewReader(string(b) + "\n" + content))
+}
+
+func TestReceiveBadHeader(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	_, _, _, err := p.receive(context.Background(), p.host.ID(), bufio.NewReader(strings.NewReader("{}")))
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReceiveUnknownType(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	_, _, _, err := p.receive(context.Background(), p.host.ID(), testFrame(&frameHeader{
+		Type:   "unknown",
+		Sender: p.host.ID().String() + "/node1",
+	}, ""))
+	assert.Regexp(t, "FF10380", err)
+}
+
+func TestReceiveBlobSizeMismatch(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	_, _, _, err := p.receive(context.Background(), p.host.ID(), testFrame(&frameHeader{
+		Type:   frameTypeBlob,
+		Sender: p.host.ID().String() + "/node1",
+		Path:   "ns1/id1",
+		Size:   100,
+	}, "some data"))
+	assert.Regexp(t, "FF10323", err)
+}
+
+func TestReceiveBlobInvalidPath(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	_, _, _, err := p.receive(context.Background(), p.host.ID(), testFrame(&frameHeader{
+		Type:   frameTypeBlob,
+		Sender: p.host.ID().String() + "/node1",
+		Path:   "../id1",
+	}, "some data"))
+	assert.Regexp(t, "FF10502", err)
+}
+
+func TestExchangeStreamFail(t *testing.T) {
+	p, done := newTestLibp2pDX(t, false)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := p.exchange(ctx, &peer.AddrInfo{ID: "unknown"}, &frameHeader{}, nil)
+	assert.Error(t, err)
+}
+
+func TestExchangeContentFail(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	defer done2()
+	_, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	info, err := sender.addrInfo(context.Background(), recipientPeer)
+	assert.NoError(t, err)
+	_, err = sender.exchange(context.Background(), info, &frameHeader{Type: frameTypeBlob}, iotest.ErrReader(fmt.Errorf("pop")))
+	assert.EqualError(t, err, "pop")
+}
+
+type failingWriteStream struct {
+	network.Stream
+}
+
+func (s *failingWriteStream) Write(b []byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+func TestHandleStreamRespondFail(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	defer done2()
+	_, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	handled := make(chan struct{})
+	recipient.host.SetStreamHandler(protocolID, func(s network.Stream) {
+		recipient.handleStream(&failingWriteStream{Stream: s})
+		close(handled)
+	})
+
+	info, err := sender.addrInfo(context.Background(), recipientPeer)
+	assert.NoError(t, err)
+	_, err = sender.exchange(context.Background(), info, &frameHeader{Type: "unknown"}, nil)
+	assert.Error(t, err)
+	<-handled
+}
+
+func TestSplitBlobPath(t *testing.T) {
+	prefix, namespace, id := splitBlobPath("id1")
+	assert.Equal(t, "", prefix)
+	assert.Equal(t, "", namespace)
+	assert.Equal(t, "id1", id)
+}
+
+func TestEventAckOnce(t *testing.T) {
+	e := &dxEvent{acked: make(chan string, 1)}
+	e.AckWithManifest("manifest data")
+	e.Ack()
+	assert.Equal(t, "manifest data", <-e.acked)
+}
+
+func TestSendMessageRecipientClosedBeforeAck(t *testing.T) {
+	sender, done1 := newTestLibp2pDX(t, false)
+	defer done1()
+	recipient, done2 := newTestLibp2pDX(t, false)
+	defer done2()
+	senderPeer, recipientPeer := connectTestNodes(t, sender, recipient)
+
+	mcb := &dataexchangemocks.Callbacks{}
+	recipient.SetHandler("ns1", "node2", mcb)
+	mcb.On("DXEvent", recipient, mock.Anything).Run(func(args mock.Arguments) {
+		// The recipient shuts down without acknowledging the event
+		done2()
+	}).Return(nil)
+
+	updated := make(chan *core.OperationUpdate)
+	ocb := &coremocks.OperationCallbacks{}
+	sender.SetOperationHandler("ns1", ocb)
+	ocb.On("OperationUpdate", mock.Anything).Run(func(args mock.Arguments) {
+		updated <- args[0].(*core.OperationUpdate)
+	})
+
+	transport, _ := json.Marshal(&core.TransportWrapper{
+		Batch: &core.Batch{BatchHeader: core.BatchHeader{Namespace: "ns1"}},
+	})
+	err := sender.SendMessage(context.Background(), "ns1:"+fftypes.NewUUID().String(), recipientPeer, senderPeer, transport)
+	assert.NoError(t, err)
+	update := <-updated
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+
+	mcb.AssertExpectations(t)
+}
@@ -0,0 +1,246 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package libp2pdx
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// protocolID is the libp2p protocol of the streams between FireFly nodes. Each stream carries a single
+// message or blob: a JSON header line, followed by the content of the blob, then a JSON response line
+// from the receiver once the event has been acknowledged by its FireFly.
+const protocolID = protocol.ID("/firefly/dx/1.0.0")
+
+type frameType string
+
+const (
+	frameTypeMessage frameType = "message"
+	frameTypeBlob    frameType = "blob"
+)
+
+type frameHeader struct {
+	Type      frameType `json:"type"`
+	Sender    string    `json:"sender"`
+	Recipient string    `json:"recipient"`
+	RequestID string    `json:"requestId"`
+	Message   string    `json:"message,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	Offset    int64     `json:"offset,omitempty"`
+	Size      int64     `json:"size,omitempty"`
+}
+
+type frameResponse struct {
+	Error    string `json:"error,omitempty"`
+	Manifest string `json:"manifest,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+func writeJSONLine(w io.Writer, v interface{}) error {
+	b, _ := json.Marshal(v)
+	_, err := w.Write(append(b, '\n'))
+	return err
+}
+
+func readJSONLine(r *bufio.Reader, v interface{}) error {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(line, v)
+}
+
+// send writes a message or blob to a peer, and reports the outcome on the operation
+func (p *Libp2pDX) send(info *peer.AddrInfo, header *frameHeader, content io.Reader) {
+	ctx, cancel := context.WithTimeout(p.ctx, p.streamTimeout)
+	defer cancel()
+	response, err := p.exchange(ctx, info, header, content)
+	if err == nil && response.Error != "" {
+		err = fmt.Errorf("%s", response.Error)
+	}
+
+	update := &core.OperationUpdate{
+		Plugin:         p.Name(),
+		NamespacedOpID: header.RequestID,
+		OnComplete:     func() {},
+	}
+	switch {
+	case err != nil:
+		log.L(ctx).Errorf("Failed to send %s %s to peer '%s': %s", header.Type, header.RequestID, header.Recipient, err)
+		update.Status = core.OpStatusFailed
+		update.ErrorMessage = i18n.NewError(ctx, coremsgs.MsgLibp2pTransferFailed, header.Recipient, err).Error()
+	case header.Type == frameTypeMessage:
+		update.Status = core.OpStatusSucceeded
+		update.VerifyManifest = p.capabilities.Manifest
+		update.DXManifest = response.Manifest
+	default:
+		update.Status = core.OpStatusSucceeded
+		update.VerifyManifest = p.capabilities.Manifest
+		update.DXHash = response.Hash
+	}
+	p.callbacks.OperationUpdate(ctx, update)
+}
+
+func (p *Libp2pDX) exchange(ctx context.Context, info *peer.AddrInfo, header *frameHeader, content io.Reader) (*frameResponse, error) {
+	p.host.Peerstore().AddAddrs(info.ID, info.Addrs, time.Hour)
+	s, err := p.host.NewStream(ctx, info.ID, protocolID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetWriteDeadline(deadline)
+	}
+
+	w := bufio.NewWriter(s)
+	err = writeJSONLine(w, header)
+	if err == nil && content != nil {
+		_, err = io.Copy(w, content)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = s.CloseWrite()
+	}
+	if err != nil {
+		s.Reset()
+		return nil, err
+	}
+
+	// The receiver responds once its FireFly has processed the event, which is not limited by the stream timeout
+	var response frameResponse
+	if err := readJSONLine(bufio.NewReader(s), &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// handleStream receives a message or blob from a peer, dispatches the event, and responds once the event is acknowledged
+func (p *Libp2pDX) handleStream(s network.Stream) {
+	defer s.Close()
+	remote := s.Conn().RemotePeer()
+	l := log.L(p.ctx).WithField("peer", remote.String())
+	ctx := log.WithLogger(p.ctx, l)
+
+	_ = s.SetReadDeadline(time.Now().Add(p.streamTimeout))
+	var response frameResponse
+	e, namespace, recipient, err := p.receive(ctx, remote, bufio.NewReader(s))
+	if err == nil {
+		l.Debugf("Received event %s from peer for %s", e.id, recipient)
+		p.callbackWithRetry(ctx, namespace, recipient, e)
+		select {
+		case response.Manifest = <-e.acked:
+		case <-ctx.Done():
+			s.Reset()
+			return
+		}
+		if e.privateBlobReceived != nil {
+			response.Hash = e.privateBlobReceived.Hash.String()
+		}
+	} else {
+		l.Warnf("Failed to receive from peer: %s", err)
+		response.Error = err.Error()
+	}
+	if err := writeJSONLine(s, &response); err != nil {
+		l.Warnf("Failed to respond to peer: %s", err)
+	}
+}
+
+func (p *Libp2pDX) receive(ctx context.Context, remote peer.ID, r *bufio.Reader) (e *dxEvent, namespace, recipient string, err error) {
+	var header frameHeader
+	if err := readJSONLine(r, &header); err != nil {
+		return nil, "", "", err
+	}
+	// The sender must be a node on the host that authenticated the stream
+	if !strings.HasPrefix(header.Sender, remote.String()+DXIDSeparator) {
+		return nil, "", "", i18n.NewError(ctx, coremsgs.MsgLibp2pInvalidPeer, header.Sender)
+	}
+
+	e = &dxEvent{
+		id:    fftypes.NewUUID().String(),
+		acked: make(chan string, 1),
+	}
+	switch header.Type {
+	case frameTypeMessage:
+		var wrapper *core.TransportWrapper
+		err = json.Unmarshal([]byte(header.Message), &wrapper)
+		if err == nil && wrapper != nil {
+			err = wrapper.Expand(ctx, dataexchange.MaxExpandedTransportSize)
+		}
+		switch {
+		case err != nil:
+			return nil, "", "", fmt.Errorf("invalid transmission from peer '%s': %s", header.Sender, err)
+		case wrapper == nil || wrapper.Batch == nil:
+			return nil, "", "", fmt.Errorf("invalid transmission from peer '%s': nil batch", header.Sender)
+		}
+		namespace = wrapper.Batch.Namespace
+		e.dxType = dataexchange.DXEventTypeMessageReceived
+		e.messageReceived = &dataexchange.MessageReceived{
+			PeerID:    header.Sender,
+			Transport: wrapper,
+		}
+
+	case frameTypeBlob:
+		// Blobs from each peer are stored apart, so that a peer cannot overwrite the blobs of others
+		payloadRef := fmt.Sprintf("%s/%s", remote, header.Path)
+		hash, size, err := p.blobs.write(ctx, payloadRef, header.Offset, r)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if size != header.Size {
+			return nil, "", "", i18n.NewError(ctx, coremsgs.MsgDXBadSize, size, header.Size)
+		}
+		var dataID string
+		_, namespace, dataID = splitBlobPath(payloadRef)
+		e.dxType = dataexchange.DXEventTypePrivateBlobReceived
+		e.privateBlobReceived = &dataexchange.PrivateBlobReceived{
+			Namespace:  namespace,
+			PeerID:     header.Sender,
+			Hash:       *hash,
+			Size:       size,
+			PayloadRef: payloadRef,
+			DataID:     dataID,
+		}
+
+	default:
+		return nil, "", "", i18n.NewError(ctx, coremsgs.MsgUnexpectedDXMessageType, header.Type)
+	}
+	return e, namespace, header.Recipient, nil
+}
+
+func (p *Libp2pDX) callbackWithRetry(ctx context.Context, namespace, recipient string, e *dxEvent) {
+	_ = p.retry.Do(ctx, "dispatch libp2p event", func(attempt int) (retry bool, err error) {
+		// Return until success, or the context closes.
+		return true, p.callbacks.DXEvent(ctx, namespace, recipient, e)
+	})
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -53,11 +54,12 @@ type definitionHandler struct {
 	data       data.Manager
 	identity   identity.Manager
 	assets     assets.Manager
-	contracts  contracts.Manager // optional
-	tokenNames map[string]string // mapping of token connector remote name => name
+	contracts  contracts.Manager  // optional
+	mpManager  multiparty.Manager // optional
+	tokenNames map[string]string  // mapping of token connector remote name => name
 }
 
-func newDefinitionHandler(ctx context.Context, ns *core.Namespace, multiparty bool, di database.Plugin, bi blockchain.Plugin, dx dataexchange.Plugin, dm data.Manager, im identity.Manager, am assets.Manager, cm contracts.Manager, tokenNames map[string]string) (*definitionHandler, error) {
+func newDefinitionHandler(ctx context.Context, ns *core.Namespace, multiparty bool, di database.Plugin, bi blockchain.Plugin, dx dataexchange.Plugin, dm data.Manager, im identity.Manager, am assets.Manager, cm contracts.Manager, mp multiparty.Manager, tokenNames map[string]string) (*definitionHandler, error) {
 	if di == nil || dm == nil || im == nil || am == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DefinitionHandler")
 	}
@@ -71,6 +73,7 @@ func newDefinitionHandler(ctx context.Context, ns *core.Namespace, multiparty bo
 		identity:   im,
 		assets:     am,
 		contracts:  cm,
+		mpManager:  mp,
 		tokenNames: tokenNames,
 	}, nil
 }
@@ -91,12 +94,18 @@ func (dh *definitionHandler) HandleDefinitionBroadcast(ctx context.Context, stat
 		return dh.handleIdentityVerificationBroadcast(ctx, state, msg, data)
 	case core.SystemTagIdentityUpdate:
 		return dh.handleIdentityUpdateBroadcast(ctx, state, msg, data)
+	case core.SystemTagRevokeVerifier:
+		return dh.handleVerifierRevocationBroadcast(ctx, state, msg, data)
+	case core.SystemTagDefineDelegation:
+		return dh.handleDelegationBroadcast(ctx, state, msg, data)
 	case core.SystemTagDefinePool:
 		return dh.handleTokenPoolBroadcast(ctx, state, msg, data)
 	case core.SystemTagDefineFFI:
 		return dh.handleFFIBroadcast(ctx, state, msg, data, tx)
 	case core.SystemTagDefineContractAPI:
 		return dh.handleContractAPIBroadcast(ctx, state, msg, data, tx)
+	case core.SystemTagDefineContractMigration:
+		return dh.handleContractMigrationBroadcast(ctx, state, msg, data)
 	default:
 		return HandlerResult{Action: core.ActionReject}, fmt.Errorf("unknown system tag '%s' for definition ID '%s'", msg.Header.Tag, msg.Header.ID)
 	}
@@ -0,0 +1,58 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (dh *definitionHandler) handleContractMigrationBroadcast(ctx context.Context, state *core.BatchState, msg *core.Message, data core.DataArray) (HandlerResult, error) {
+	var migration core.ContractMigration
+	if valid := dh.getSystemBroadcastPayload(ctx, msg, data, &migration); !valid {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "contract migration", msg.Header.ID)
+	}
+	if dh.mpManager == nil || dh.blockchain == nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+
+	// Only a root org can coordinate a migration of the whole network, in the same way as a network action
+	author, err := dh.identity.FindIdentityForVerifier(ctx, []core.IdentityType{core.IdentityTypeOrg}, &core.VerifierRef{
+		Type:  dh.blockchain.VerifierType(),
+		Value: msg.Header.Key,
+	})
+	if err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	if author == nil || author.Parent != nil || author.DID != msg.Header.Author {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedWrongAuthor, "contract migration", msg.Header.ID, msg.Header.Author)
+	}
+
+	retryable, err := dh.mpManager.ScheduleContractMigration(ctx, &migration)
+	if err != nil {
+		if retryable {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		return HandlerResult{Action: core.ActionReject}, err
+	}
+	log.L(ctx).Infof("Contract migration '%s' to contract #%d scheduled at block %d", migration.ID, migration.Index, migration.BlockNumber)
+	return HandlerResult{Action: core.ActionConfirm}, nil
+}
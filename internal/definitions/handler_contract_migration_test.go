@@ -0,0 +1,173 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testContractMigration(t *testing.T) (*core.Identity, *core.Message, *core.Data, *core.ContractMigration) {
+	org1 := testOrgIdentity(t, "org1")
+
+	migration := &core.ContractMigration{
+		ID:          fftypes.NewUUID(),
+		Index:       1,
+		BlockNumber: 100,
+	}
+	b, err := json.Marshal(&migration)
+	assert.NoError(t, err)
+	migrationData := &core.Data{
+		ID:    fftypes.NewUUID(),
+		Value: fftypes.JSONAnyPtrBytes(b),
+	}
+
+	migrationMsg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypeDefinition,
+			Tag:    core.SystemTagDefineContractMigration,
+			Topics: fftypes.FFStringArray{migration.Topic()},
+			SignerRef: core.SignerRef{
+				Author: org1.DID,
+				Key:    "0x12345",
+			},
+		},
+	}
+
+	return org1, migrationMsg, migrationData, migration
+}
+
+func TestHandleDefinitionContractMigrationOk(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	org1, migrationMsg, migrationData, migration := testContractMigration(t)
+
+	dh.mim.On("FindIdentityForVerifier", ctx, []core.IdentityType{core.IdentityTypeOrg}, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	}).Return(org1, nil)
+	dh.mmp.On("ScheduleContractMigration", ctx, mock.MatchedBy(func(m *core.ContractMigration) bool {
+		return m.ID.Equals(migration.ID) && m.Index == 1 && m.BlockNumber == 100 && m.Message.Equals(migrationMsg.Header.ID)
+	})).Return(true, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{migrationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+	bs.assertNoFinalizers()
+
+	dh.cleanup(t)
+}
+
+func TestHandleDefinitionContractMigrationBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	_, migrationMsg, _, _ := testContractMigration(t)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10400", err)
+
+	dh.cleanup(t)
+}
+
+func TestHandleDefinitionContractMigrationNoMultiparty(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.mpManager = nil
+
+	_, migrationMsg, migrationData, _ := testContractMigration(t)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{migrationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10414", err)
+
+	dh.cleanup(t)
+}
+
+func TestHandleDefinitionContractMigrationLookupFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	_, migrationMsg, migrationData, _ := testContractMigration(t)
+
+	dh.mim.On("FindIdentityForVerifier", ctx, []core.IdentityType{core.IdentityTypeOrg}, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{migrationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+
+	dh.cleanup(t)
+}
+
+func TestHandleDefinitionContractMigrationNotRootOrg(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, migrationMsg, migrationData, _ := testContractMigration(t)
+	org1.Parent = fftypes.NewUUID()
+
+	dh.mim.On("FindIdentityForVerifier", ctx, []core.IdentityType{core.IdentityTypeOrg}, mock.Anything).Return(org1, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{migrationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10409", err)
+
+	dh.cleanup(t)
+}
+
+func TestHandleDefinitionContractMigrationScheduleRetry(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, migrationMsg, migrationData, _ := testContractMigration(t)
+
+	dh.mim.On("FindIdentityForVerifier", ctx, []core.IdentityType{core.IdentityTypeOrg}, mock.Anything).Return(org1, nil)
+	dh.mmp.On("ScheduleContractMigration", ctx, mock.Anything).Return(true, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{migrationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+
+	dh.cleanup(t)
+}
+
+func TestHandleDefinitionContractMigrationScheduleReject(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, migrationMsg, migrationData, _ := testContractMigration(t)
+
+	dh.mim.On("FindIdentityForVerifier", ctx, []core.IdentityType{core.IdentityTypeOrg}, mock.Anything).Return(org1, nil)
+	dh.mmp.On("ScheduleContractMigration", ctx, mock.Anything).Return(false, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, migrationMsg, core.DataArray{migrationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.EqualError(t, err, "pop")
+
+	dh.cleanup(t)
+}
@@ -52,7 +52,11 @@ func (dh *definitionHandler) handleDatatypeBroadcast(ctx context.Context, state
 
 	state.AddFinalize(func(ctx context.Context) error {
 		event := core.NewEvent(core.EventTypeDatatypeConfirmed, dt.Namespace, dt.ID, tx, core.SystemTopicDefinitions)
-		return dh.database.InsertEvent(ctx, event)
+		if err := dh.database.InsertEvent(ctx, event); err != nil {
+			return err
+		}
+		dh.data.MigrateDatatype(ctx, &dt)
+		return nil
 	})
 	return HandlerResult{Action: core.ActionConfirm}, nil
 }
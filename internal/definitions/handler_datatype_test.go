@@ -39,6 +39,9 @@ func TestHandleDefinitionBroadcastDatatypeOk(t *testing.T) {
 		Name:      "name1",
 		Version:   "ver1",
 		Value:     fftypes.JSONAnyPtr(`{}`),
+		Migration: &core.DatatypeMigration{
+			FromVersion: "ver0",
+		},
 	}
 	dt.Hash = dt.Value.Hash()
 	b, err := json.Marshal(&dt)
@@ -51,6 +54,9 @@ func TestHandleDefinitionBroadcastDatatypeOk(t *testing.T) {
 	dh.mdi.On("GetDatatypeByName", mock.Anything, "ns1", "name1", "ver1").Return(nil, nil)
 	dh.mdi.On("UpsertDatatype", mock.Anything, mock.Anything, false).Return(nil)
 	dh.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+	dh.mdm.On("MigrateDatatype", mock.Anything, mock.MatchedBy(func(migrated *core.Datatype) bool {
+		return migrated.Version == "ver1" && migrated.Migration.FromVersion == "ver0"
+	})).Return()
 
 	action, err := dh.HandleDefinitionBroadcast(context.Background(), &bs.BatchState, &core.Message{
 		Header: core.MessageHeader{
@@ -0,0 +1,98 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+type delegationMsgInfo struct {
+	ID     *fftypes.UUID
+	Author string
+}
+
+func (dh *definitionHandler) handleDelegationBroadcast(ctx context.Context, state *core.BatchState, msg *core.Message, data core.DataArray) (HandlerResult, error) {
+	var delegation core.Delegation
+	if valid := dh.getSystemBroadcastPayload(ctx, msg, data, &delegation); !valid {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "delegation", msg.Header.ID)
+	}
+	delegation.Message = msg.Header.ID
+	return dh.handleDelegation(ctx, state, &delegationMsgInfo{
+		ID:     msg.Header.ID,
+		Author: msg.Header.Author,
+	}, &delegation)
+}
+
+func (dh *definitionHandler) handleDelegation(ctx context.Context, state *core.BatchState, msg *delegationMsgInfo, delegation *core.Delegation) (HandlerResult, error) {
+	if delegation.ID == nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedValidateFail, "delegation", msg.ID)
+	}
+	if err := delegation.Validate(ctx); err != nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.WrapError(ctx, err, coremsgs.MsgDefRejectedValidateFail, "delegation", delegation.ID)
+	}
+
+	// Both sides of the delegation must be confirmed identities
+	delegator, retryable, err := dh.identity.CachedIdentityLookupMustExist(ctx, delegation.Delegator)
+	if err != nil && retryable {
+		return HandlerResult{Action: core.ActionRetry}, err
+	} else if err != nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedIdentityNotFound, "delegation", delegation.ID, delegation.Delegator)
+	}
+	delegate, retryable, err := dh.identity.CachedIdentityLookupMustExist(ctx, delegation.Delegate)
+	if err != nil && retryable {
+		return HandlerResult{Action: core.ActionRetry}, err
+	} else if err != nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedIdentityNotFound, "delegation", delegation.ID, delegation.Delegate)
+	}
+	if delegator.ID.Equals(delegate.ID) {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDelegationToSelf, delegator.DID)
+	}
+
+	// Only the delegator can grant a delegation of its own authority
+	if dh.multiparty && msg.Author != delegator.DID {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedWrongAuthor, "delegation", delegation.ID, msg.Author)
+	}
+
+	existing, err := dh.database.GetDelegationByID(ctx, dh.namespace.Name, delegation.ID)
+	if err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	if existing != nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedConflict, "delegation", delegation.ID, existing.ID)
+	}
+
+	delegation.Namespace = dh.namespace.Name
+	delegation.Delegator = delegator.DID
+	delegation.Delegate = delegate.DID
+	delegation.Created = fftypes.Now()
+	if err := dh.database.InsertDelegation(ctx, delegation); err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	log.L(ctx).Infof("Identity '%s' delegated %s to '%s' (%s)", delegator.DID, delegation.Scope, delegate.DID, delegation.ID)
+
+	state.AddFinalize(func(ctx context.Context) error {
+		event := core.NewEvent(core.EventTypeDelegationConfirmed, delegation.Namespace, delegation.ID, nil, core.SystemTopicDefinitions)
+		return dh.database.InsertEvent(ctx, event)
+	})
+	return HandlerResult{Action: core.ActionConfirm}, nil
+}
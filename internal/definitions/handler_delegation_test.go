@@ -0,0 +1,281 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testDelegation(t *testing.T) (*core.Identity, *core.Identity, *core.Message, *core.Data, *core.Delegation) {
+	org1 := testOrgIdentity(t, "org1")
+	org2 := testOrgIdentity(t, "org2")
+
+	delegation := &core.Delegation{
+		ID:        fftypes.NewUUID(),
+		Delegator: "org1",
+		Delegate:  "org2",
+		Scope:     fftypes.FFStringArray{"messages"},
+	}
+	b, err := json.Marshal(&delegation)
+	assert.NoError(t, err)
+	delegationData := &core.Data{
+		ID:    fftypes.NewUUID(),
+		Value: fftypes.JSONAnyPtrBytes(b),
+	}
+
+	delegationMsg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypeDefinition,
+			Tag:    core.SystemTagDefineDelegation,
+			Topics: fftypes.FFStringArray{delegation.Topic()},
+			SignerRef: core.SignerRef{
+				Author: org1.DID,
+				Key:    "0x12345",
+			},
+		},
+	}
+
+	return org1, org2, delegationMsg, delegationData, delegation
+}
+
+func TestHandleDefinitionDelegationOk(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	org1, org2, delegationMsg, delegationData, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(org2, false, nil)
+	dh.mdi.On("GetDelegationByID", ctx, "ns1", delegation.ID).Return(nil, nil)
+	dh.mdi.On("InsertDelegation", ctx, mock.MatchedBy(func(d *core.Delegation) bool {
+		return d.ID.Equals(delegation.ID) &&
+			d.Namespace == "ns1" &&
+			d.Delegator == org1.DID &&
+			d.Delegate == org2.DID &&
+			d.Message.Equals(delegationMsg.Header.ID) &&
+			d.Created != nil
+	})).Return(nil)
+	dh.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeDelegationConfirmed && event.Reference.Equals(delegation.ID)
+	})).Return(nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, delegationMsg, core.DataArray{delegationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	err = bs.RunFinalize(ctx)
+	assert.NoError(t, err)
+
+	dh.mim.AssertExpectations(t)
+	dh.mdi.AssertExpectations(t)
+}
+
+func TestHandleDefinitionDelegationBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	_, _, delegationMsg, _, _ := testDelegation(t)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, delegationMsg, core.DataArray{}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10400", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationMissingID(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, &core.Delegation{})
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10403", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationInvalid(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, &core.Delegation{
+		ID: fftypes.NewUUID(),
+	})
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10403.*FF10575", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationDelegatorLookupFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	_, _, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(nil, true, fmt.Errorf("pop"))
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationDelegatorNotFound(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	_, _, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(nil, false, fmt.Errorf("not found"))
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10408.*org1", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationDelegateLookupFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, _, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(nil, true, fmt.Errorf("pop"))
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationDelegateNotFound(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, _, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(nil, false, fmt.Errorf("not found"))
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10408.*org2", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationToSelf(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, _, _, _, delegation := testDelegation(t)
+	delegation.Delegate = org1.DID
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, org1.DID).Return(org1, false, nil)
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10576", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationWrongAuthor(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	org1, org2, delegationMsg, delegationData, _ := testDelegation(t)
+	delegationMsg.Header.Author = org2.DID
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(org2, false, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, delegationMsg, core.DataArray{delegationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10409", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationGetExistingFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, org2, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(org2, false, nil)
+	dh.mdi.On("GetDelegationByID", ctx, "ns1", delegation.ID).Return(nil, fmt.Errorf("pop"))
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationConflict(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, org2, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(org2, false, nil)
+	dh.mdi.On("GetDelegationByID", ctx, "ns1", delegation.ID).Return(&core.Delegation{ID: delegation.ID}, nil)
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10407", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionDelegationInsertFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, org2, _, _, delegation := testDelegation(t)
+
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org1").Return(org1, false, nil)
+	dh.mim.On("CachedIdentityLookupMustExist", ctx, "org2").Return(org2, false, nil)
+	dh.mdi.On("GetDelegationByID", ctx, "ns1", delegation.ID).Return(nil, nil)
+	dh.mdi.On("InsertDelegation", ctx, delegation).Return(fmt.Errorf("pop"))
+
+	action, err := dh.handleDelegation(ctx, &bs.BatchState, &delegationMsgInfo{}, delegation)
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.EqualError(t, err, "pop")
+
+	bs.assertNoFinalizers()
+}
@@ -31,8 +31,9 @@ import (
 type identityMsgInfo struct {
 	core.SignerRef
 	claimMsg struct {
-		ID   *fftypes.UUID
-		Hash *fftypes.Bytes32
+		ID      *fftypes.UUID
+		Hash    *fftypes.Bytes32
+		Created *fftypes.FFTime
 	}
 	verifyMsg struct {
 		ID *fftypes.UUID
@@ -43,6 +44,7 @@ func buildIdentityMsgInfo(msg *core.Message, verifyMsgID *fftypes.UUID) *identit
 	info := &identityMsgInfo{}
 	info.claimMsg.ID = msg.Header.ID
 	info.claimMsg.Hash = msg.Hash
+	info.claimMsg.Created = msg.Header.Created
 	info.verifyMsg.ID = verifyMsgID
 	info.SignerRef = msg.Header.SignerRef
 	return info
@@ -174,6 +176,20 @@ func (dh *definitionHandler) handleIdentityClaim(ctx context.Context, state *cor
 		if err := dh.verifyClaimSignature(ctx, msg, identity, parent); err != nil {
 			return HandlerResult{Action: core.ActionReject}, err
 		}
+		if err := dh.identity.VerifyClaimSignature(ctx, msg.Key, identityClaim); err != nil {
+			return HandlerResult{Action: core.ActionReject}, err
+		}
+	}
+
+	// Orgs must present a certificate from a trusted PKI, if configured for the namespace - checked at the
+	// time of the claim message, so every node gets the same answer. The verified subject is only ever set
+	// by our own verification, never taken from the claim.
+	identity.VerifiedSubject = ""
+	if identity.Type == core.IdentityTypeOrg {
+		identity.VerifiedSubject, err = dh.identity.VerifyOrgCertificate(ctx, identityClaim, msg.claimMsg.Created)
+		if err != nil {
+			return HandlerResult{Action: core.ActionReject}, err
+		}
 	}
 
 	existingIdentity, err := dh.database.GetIdentityByName(ctx, identity.Type, identity.Namespace, identity.Name)
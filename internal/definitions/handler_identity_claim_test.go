@@ -144,6 +144,7 @@ func TestHandleDefinitionIdentityClaimCustomWithExistingParentVerificationOk(t *
 	custom1, org1, claimMsg, claimData, verifyMsg, verifyData := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -188,6 +189,7 @@ func TestHandleDefinitionIdentityClaimIdempotentReplay(t *testing.T) {
 	custom1, org1, claimMsg, claimData, verifyMsg, verifyData := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(custom1, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(&core.Verifier{
@@ -227,6 +229,7 @@ func TestHandleDefinitionIdentityClaimFailInsertIdentity(t *testing.T) {
 	custom1, org1, claimMsg, claimData, verifyMsg, verifyData := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -254,6 +257,7 @@ func TestHandleDefinitionIdentityClaimVerificationDataFail(t *testing.T) {
 	custom1, org1, claimMsg, claimData, verifyMsg, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -279,6 +283,7 @@ func TestHandleDefinitionIdentityClaimVerificationMissingData(t *testing.T) {
 	custom1, org1, claimMsg, claimData, verifyMsg, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -304,6 +309,7 @@ func TestHandleDefinitionIdentityClaimFailInsertVerifier(t *testing.T) {
 	custom1, org1, claimMsg, claimData, verifyMsg, verifyData := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -330,6 +336,7 @@ func TestHandleDefinitionIdentityClaimCustomMissingParentVerificationOk(t *testi
 	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -352,6 +359,7 @@ func TestHandleDefinitionIdentityClaimCustomParentVerificationFail(t *testing.T)
 	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
@@ -374,6 +382,7 @@ func TestHandleDefinitionIdentityClaimVerifierClash(t *testing.T) {
 	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(&core.Verifier{
@@ -397,6 +406,7 @@ func TestHandleDefinitionIdentityClaimVerifierError(t *testing.T) {
 	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, fmt.Errorf("pop"))
@@ -418,6 +428,7 @@ func TestHandleDefinitionIdentityClaimIdentityClash(t *testing.T) {
 	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(&core.Identity{
 		IdentityBase: core.IdentityBase{
 			ID: fftypes.NewUUID(),
@@ -441,6 +452,7 @@ func TestHandleDefinitionIdentityClaimIdentityError(t *testing.T) {
 	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, fmt.Errorf("pop"))
 
@@ -491,6 +503,27 @@ func TestHandleDefinitionIdentityClaimBadSignature(t *testing.T) {
 	bs.assertNoFinalizers()
 }
 
+func TestHandleDefinitionIdentityClaimKeySignatureRejected(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	ctx := context.Background()
+	custom1, org1, claimMsg, claimData, _, _ := testCustomClaimAndVerification(t)
+
+	dh.mim.On("VerifyIdentityChain", ctx, custom1).Return(org1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, "0x12345", mock.MatchedBy(func(claim *core.IdentityClaim) bool {
+		return claim.Identity.DID == custom1.DID
+	})).Return(fmt.Errorf("pop"))
+
+	dh.multiparty = true
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, claimMsg, core.DataArray{claimData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
+
 func TestHandleDefinitionIdentityVerifyChainFail(t *testing.T) {
 	dh, bs := newTestDefinitionHandler(t)
 	defer dh.cleanup(t)
@@ -539,3 +572,61 @@ func TestHandleDefinitionIdentityClaimBadData(t *testing.T) {
 
 	bs.assertNoFinalizers()
 }
+
+func TestHandleDefinitionIdentityClaimOrgCertificateVerified(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	ctx := context.Background()
+	org1 := testOrgIdentity(t, "org1")
+	org1.VerifiedSubject = "CN=spoofed"
+	msg := &identityMsgInfo{SignerRef: core.SignerRef{Key: "0x12345"}}
+	msg.claimMsg.ID = fftypes.NewUUID()
+	msg.claimMsg.Created = fftypes.Now()
+	claim := &core.IdentityClaim{
+		Identity:             org1,
+		Certificate:          "cert-pem",
+		CertificateSignature: "cert-sig",
+	}
+
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, false, nil)
+	dh.mim.On("VerifyOrgCertificate", ctx, claim, msg.claimMsg.Created).Return("CN=org1,O=Org One", nil)
+	dh.mdi.On("GetIdentityByName", ctx, org1.Type, org1.Namespace, org1.Name).Return(nil, nil)
+	dh.mdi.On("GetIdentityByID", ctx, "ns1", org1.ID).Return(nil, nil)
+	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, nil)
+	dh.mdi.On("UpsertVerifier", ctx, mock.Anything, database.UpsertOptimizationNew).Return(nil)
+	dh.mdi.On("UpsertIdentity", ctx, mock.MatchedBy(func(identity *core.Identity) bool {
+		return identity.VerifiedSubject == "CN=org1,O=Org One"
+	}), database.UpsertOptimizationNew).Return(nil)
+	dh.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeIdentityConfirmed
+	})).Return(nil)
+
+	action, err := dh.handleIdentityClaim(ctx, &bs.BatchState, msg, claim)
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	err = bs.RunFinalize(ctx)
+	assert.NoError(t, err)
+}
+
+func TestHandleDefinitionIdentityClaimOrgCertificateRejected(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	defer dh.cleanup(t)
+
+	ctx := context.Background()
+	org1 := testOrgIdentity(t, "org1")
+	msg := &identityMsgInfo{SignerRef: core.SignerRef{Key: "0x12345"}}
+	msg.claimMsg.ID = fftypes.NewUUID()
+
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, false, nil)
+	dh.mim.On("VerifyOrgCertificate", ctx, mock.Anything, msg.claimMsg.Created).Return("", fmt.Errorf("pop"))
+
+	action, err := dh.handleIdentityClaim(ctx, &bs.BatchState, msg, &core.IdentityClaim{
+		Identity: org1,
+	})
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
@@ -37,6 +37,7 @@ func TestHandleDefinitionIdentityVerificationWithExistingClaimOk(t *testing.T) {
 
 	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
 	dh.mim.On("VerifyIdentityChain", ctx, mock.Anything).Return(custom1, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetMessageByID", ctx, "ns1", claimMsg.Header.ID).Return(nil, nil) // Simulate pending confirm in same pin batch
 	dh.mdi.On("GetIdentityByName", ctx, custom1.Type, custom1.Namespace, custom1.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", custom1.ID).Return(nil, nil)
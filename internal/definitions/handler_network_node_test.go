@@ -104,6 +104,7 @@ func TestHandleDeprecatedNodeDefinitionOK(t *testing.T) {
 		Value: node.Owner,
 	}).Return(parent.Migrated().Identity, nil)
 	dh.mim.On("VerifyIdentityChain", ctx, mock.Anything).Return(parent.Migrated().Identity, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
 	dh.mdi.On("GetIdentityByName", ctx, core.IdentityTypeNode, "ns1", node.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", node.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeFFDXPeerID, "ns1", "member_0").Return(nil, nil)
@@ -88,6 +88,8 @@ func TestHandleDeprecatedOrgDefinitionOK(t *testing.T) {
 	org, msg, data := testDeprecatedRootOrg(t)
 
 	dh.mim.On("VerifyIdentityChain", ctx, mock.Anything).Return(nil, false, nil)
+	dh.mim.On("VerifyClaimSignature", ctx, mock.Anything, mock.Anything).Return(nil)
+	dh.mim.On("VerifyOrgCertificate", ctx, mock.Anything, msg.Header.Created).Return("", nil)
 	dh.mdi.On("GetIdentityByName", ctx, core.IdentityTypeOrg, "ns1", org.Name).Return(nil, nil)
 	dh.mdi.On("GetIdentityByID", ctx, "ns1", org.ID).Return(nil, nil)
 	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", msg.Header.Key).Return(nil, nil)
@@ -29,6 +29,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 )
@@ -43,6 +44,7 @@ type testDefinitionHandler struct {
 	mdm *datamocks.Manager
 	mam *assetmocks.Manager
 	mcm *contractmocks.Manager
+	mmp *multipartymocks.Manager
 }
 
 func (tdh *testDefinitionHandler) cleanup(t *testing.T) {
@@ -53,6 +55,7 @@ func (tdh *testDefinitionHandler) cleanup(t *testing.T) {
 	tdh.mdm.AssertExpectations(t)
 	tdh.mam.AssertExpectations(t)
 	tdh.mcm.AssertExpectations(t)
+	tdh.mmp.AssertExpectations(t)
 }
 
 func newTestDefinitionHandler(t *testing.T) (*testDefinitionHandler, *testDefinitionBatchState) {
@@ -63,11 +66,12 @@ func newTestDefinitionHandler(t *testing.T) (*testDefinitionHandler, *testDefini
 	mim := &identitymanagermocks.Manager{}
 	mam := &assetmocks.Manager{}
 	mcm := &contractmocks.Manager{}
+	mmp := &multipartymocks.Manager{}
 	tokenNames := make(map[string]string)
 	tokenNames["remote1"] = "connector1"
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress).Maybe()
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	dh, _ := newDefinitionHandler(context.Background(), ns, false, mdi, mbi, mdx, mdm, mim, mam, mcm, tokenNames)
+	dh, _ := newDefinitionHandler(context.Background(), ns, false, mdi, mbi, mdx, mdm, mim, mam, mcm, mmp, tokenNames)
 	return &testDefinitionHandler{
 		definitionHandler: *dh,
 		mdi:               mdi,
@@ -77,6 +81,7 @@ func newTestDefinitionHandler(t *testing.T) (*testDefinitionHandler, *testDefini
 		mdm:               mdm,
 		mam:               mam,
 		mcm:               mcm,
+		mmp:               mmp,
 	}, newTestDefinitionBatchState(t)
 }
 
@@ -102,7 +107,7 @@ func (bs *testDefinitionBatchState) assertNoFinalizers() {
 }
 
 func TestInitFail(t *testing.T) {
-	_, err := newDefinitionHandler(context.Background(), &core.Namespace{}, false, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err := newDefinitionHandler(context.Background(), &core.Namespace{}, false, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
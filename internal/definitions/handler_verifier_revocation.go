@@ -0,0 +1,128 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type verifierRevocationMsgInfo struct {
+	ID     *fftypes.UUID
+	Author string
+	Pin    int64 // the last pin of the batch containing the revocation, if it was pinned
+}
+
+func (dh *definitionHandler) handleVerifierRevocationBroadcast(ctx context.Context, state *core.BatchState, msg *core.Message, data core.DataArray) (HandlerResult, error) {
+	var revocation core.VerifierRevocation
+	if valid := dh.getSystemBroadcastPayload(ctx, msg, data, &revocation); !valid {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedBadPayload, "verifier revocation", msg.Header.ID)
+	}
+	msgInfo := &verifierRevocationMsgInfo{
+		ID:     msg.Header.ID,
+		Author: msg.Header.Author,
+	}
+	if dh.multiparty && msg.BatchID != nil {
+		// Messages pinned after the batch containing the revocation are rejected. Pins are sequenced in the order of
+		// the chain on every node, so every node makes the same decision for each message.
+		fb := database.PinQueryFactory.NewFilterLimit(ctx, 1)
+		pins, _, err := dh.database.GetPins(ctx, dh.namespace.Name, fb.Eq("batch", msg.BatchID).Sort("sequence").Descending())
+		if err != nil {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		if len(pins) > 0 {
+			msgInfo.Pin = pins[0].Sequence
+		}
+	}
+	return dh.handleVerifierRevocation(ctx, state, msgInfo, &revocation)
+}
+
+func (dh *definitionHandler) handleVerifierRevocation(ctx context.Context, state *core.BatchState, msg *verifierRevocationMsgInfo, revocation *core.VerifierRevocation) (HandlerResult, error) {
+	if revocation.Identity.ID == nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedValidateFail, "verifier revocation", msg.ID)
+	}
+
+	// The identity that owns the verifier(s) must be a confirmed identity
+	identity, err := dh.identity.CachedIdentityLookupByID(ctx, revocation.Identity.ID)
+	if err != nil {
+		return HandlerResult{Action: core.ActionRetry}, err
+	}
+	if identity == nil {
+		return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedIdentityNotFound, "verifier revocation", msg.ID, revocation.Identity.ID)
+	}
+
+	if dh.multiparty {
+
+		parent, retryable, err := dh.identity.VerifyIdentityChain(ctx, identity)
+		if err != nil && retryable {
+			return HandlerResult{Action: core.ActionRetry}, err
+		} else if err != nil {
+			log.L(ctx).Infof("Unable to process verifier revocation (parked) %s: %s", msg.ID, err)
+			return HandlerResult{Action: core.ActionWait}, nil
+		}
+
+		// The revocation can be issued by the signer of the identity, or by its parent
+		expectedSigner := dh.getExpectedSigner(identity, parent)
+		if expectedSigner.DID != msg.Author && (parent == nil || parent.DID != msg.Author) {
+			return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedWrongAuthor, "verifier revocation", msg.ID, msg.Author)
+		}
+
+	}
+
+	var verifiers []*core.Verifier
+	if revocation.Verifier != nil {
+		verifier, err := dh.database.GetVerifierByValue(ctx, revocation.Verifier.Type, dh.namespace.Name, revocation.Verifier.Value)
+		if err != nil {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		if verifier == nil || !verifier.Identity.Equals(identity.ID) {
+			return HandlerResult{Action: core.ActionReject}, i18n.NewError(ctx, coremsgs.MsgDefRejectedVerifierNotOwned, "verifier revocation", msg.ID, revocation.Verifier.Value)
+		}
+		verifiers = []*core.Verifier{verifier}
+	} else {
+		fb := database.VerifierQueryFactory.NewFilter(ctx)
+		verifiers, _, err = dh.database.GetVerifiers(ctx, dh.namespace.Name, fb.And(fb.Eq("identity", identity.ID)))
+		if err != nil {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+	}
+
+	revoked := fftypes.Now()
+	for _, verifier := range verifiers {
+		if verifier.Revoked != nil {
+			continue
+		}
+		verifier.Revoked = revoked
+		verifier.RevokedPin = msg.Pin
+		if err := dh.database.UpsertVerifier(ctx, verifier, database.UpsertOptimizationExisting); err != nil {
+			return HandlerResult{Action: core.ActionRetry}, err
+		}
+		log.L(ctx).Infof("Revoked verifier %s=%s of identity '%s' (%s): %s", verifier.Type, verifier.Value, identity.DID, identity.ID, revocation.Reason)
+	}
+
+	state.AddFinalize(func(ctx context.Context) error {
+		event := core.NewEvent(core.EventTypeVerifierRevoked, identity.Namespace, identity.ID, nil, core.SystemTopicDefinitions)
+		return dh.database.InsertEvent(ctx, event)
+	})
+	return HandlerResult{Action: core.ActionConfirm}, nil
+}
@@ -0,0 +1,344 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testVerifierRevocation(t *testing.T, verifierRef *core.VerifierRef) (*core.Identity, *core.Message, *core.Data, *core.VerifierRevocation) {
+	org1 := testOrgIdentity(t, "org1")
+
+	vr := &core.VerifierRevocation{
+		Identity: org1.IdentityBase,
+		Verifier: verifierRef,
+		Reason:   "compromised",
+	}
+	b, err := json.Marshal(&vr)
+	assert.NoError(t, err)
+	revocationData := &core.Data{
+		ID:    fftypes.NewUUID(),
+		Value: fftypes.JSONAnyPtrBytes(b),
+	}
+
+	revocationMsg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Type:   core.MessageTypeDefinition,
+			Tag:    core.SystemTagRevokeVerifier,
+			Topics: fftypes.FFStringArray{org1.Topic()},
+			SignerRef: core.SignerRef{
+				Author: org1.DID,
+				Key:    "0x12345",
+			},
+		},
+	}
+
+	return org1, revocationMsg, revocationData, vr
+}
+
+func TestHandleDefinitionVerifierRevocationOk(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	verifierRef := &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, verifierRef)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, false, nil)
+	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(&core.Verifier{
+		Identity:    org1.ID,
+		Namespace:   "ns1",
+		VerifierRef: *verifierRef,
+	}, nil)
+	dh.mdi.On("UpsertVerifier", ctx, mock.MatchedBy(func(v *core.Verifier) bool {
+		return v.Value == "0x12345" && v.Revoked != nil
+	}), database.UpsertOptimizationExisting).Return(nil)
+	dh.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeVerifierRevoked && event.Reference.Equals(org1.ID)
+	})).Return(nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	err = bs.RunFinalize(ctx)
+	assert.NoError(t, err)
+
+	dh.mim.AssertExpectations(t)
+	dh.mdi.AssertExpectations(t)
+}
+
+func TestHandleDefinitionVerifierRevocationAllVerifiers(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mdi.On("GetVerifiers", ctx, "ns1", mock.Anything).Return([]*core.Verifier{
+		{Identity: org1.ID, VerifierRef: core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}},
+		{Identity: org1.ID, VerifierRef: core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x23456"}, Revoked: fftypes.Now()},
+	}, nil, nil)
+	dh.mdi.On("UpsertVerifier", ctx, mock.MatchedBy(func(v *core.Verifier) bool {
+		return v.Value == "0x12345" && v.Revoked != nil
+	}), database.UpsertOptimizationExisting).Return(nil).Once()
+	dh.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	err = bs.RunFinalize(ctx)
+	assert.NoError(t, err)
+
+	dh.mim.AssertExpectations(t)
+	dh.mdi.AssertExpectations(t)
+}
+
+func TestHandleDefinitionVerifierRevocationBadPayload(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	_, revocationMsg, _, _ := testVerifierRevocation(t, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10400", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationMissingID(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	action, err := dh.handleVerifierRevocation(ctx, &bs.BatchState, &verifierRevocationMsgInfo{}, &core.VerifierRevocation{})
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10403", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationIdentityLookupFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationIdentityNotFound(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(nil, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10408", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationVerifyChainFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, true, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationVerifyChainInvalid(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, false, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionWait}, action)
+	assert.NoError(t, err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationWrongAuthor(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+	revocationMsg.Header.Author = "did:firefly:org/org2"
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, false, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10409", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationRecordsPin(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	verifierRef := &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, verifierRef)
+	revocationMsg.BatchID = fftypes.NewUUID()
+
+	dh.mdi.On("GetPins", ctx, "ns1", mock.Anything).Return([]*core.Pin{{Sequence: 12345}}, nil, nil)
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mim.On("VerifyIdentityChain", ctx, org1).Return(nil, false, nil)
+	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(&core.Verifier{
+		Identity:    org1.ID,
+		Namespace:   "ns1",
+		VerifierRef: *verifierRef,
+	}, nil)
+	dh.mdi.On("UpsertVerifier", ctx, mock.MatchedBy(func(v *core.Verifier) bool {
+		return v.Revoked != nil && v.RevokedPin == 12345
+	}), database.UpsertOptimizationExisting).Return(nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionConfirm}, action)
+	assert.NoError(t, err)
+
+	dh.mdi.AssertExpectations(t)
+}
+
+func TestHandleDefinitionVerifierRevocationGetPinsFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+	dh.multiparty = true
+
+	_, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+	revocationMsg.BatchID = fftypes.NewUUID()
+
+	dh.mdi.On("GetPins", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationGetVerifierFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	verifierRef := &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, verifierRef)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationVerifierNotOwned(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	verifierRef := &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, verifierRef)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(&core.Verifier{
+		Identity:    fftypes.NewUUID(),
+		VerifierRef: *verifierRef,
+	}, nil)
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionReject}, action)
+	assert.Regexp(t, "FF10479", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationGetVerifiersFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mdi.On("GetVerifiers", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
+
+func TestHandleDefinitionVerifierRevocationUpsertFail(t *testing.T) {
+	dh, bs := newTestDefinitionHandler(t)
+	ctx := context.Background()
+
+	org1, revocationMsg, revocationData, _ := testVerifierRevocation(t, nil)
+
+	dh.mim.On("CachedIdentityLookupByID", ctx, org1.ID).Return(org1, nil)
+	dh.mdi.On("GetVerifiers", ctx, "ns1", mock.Anything).Return([]*core.Verifier{
+		{Identity: org1.ID, VerifierRef: core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}},
+	}, nil, nil)
+	dh.mdi.On("UpsertVerifier", ctx, mock.Anything, database.UpsertOptimizationExisting).Return(fmt.Errorf("pop"))
+
+	action, err := dh.HandleDefinitionBroadcast(ctx, &bs.BatchState, revocationMsg, core.DataArray{revocationData}, fftypes.NewUUID())
+	assert.Equal(t, HandlerResult{Action: core.ActionRetry}, action)
+	assert.Regexp(t, "pop", err)
+
+	bs.assertNoFinalizers()
+}
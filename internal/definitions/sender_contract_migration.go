@@ -0,0 +1,40 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (ds *definitionSender) DefineContractMigration(ctx context.Context, migration *core.ContractMigration, waitConfirm bool) error {
+	if !ds.multiparty {
+		return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+
+	// The migration must be signed by the root org, as only root orgs can coordinate changes to the whole network
+	migration.ID = fftypes.NewUUID()
+	msg, err := ds.getSenderDefault(ctx, migration, core.SystemTagDefineContractMigration).send(ctx, waitConfirm)
+	if msg != nil {
+		migration.Message = msg.Header.ID
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package definitions
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDefineContractMigrationOk(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = true
+	mms := &syncasyncmocks.Sender{}
+
+	ds.mim.On("GetRootOrg", context.Background()).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{
+			DID: "firefly:org1",
+		},
+	}, nil)
+	ds.mim.On("ResolveInputSigningIdentity", mock.Anything, mock.MatchedBy(func(signer *core.SignerRef) bool {
+		return signer.Author == "firefly:org1"
+	})).Return(nil)
+	ds.mbm.On("NewBroadcast", mock.MatchedBy(func(msg *core.MessageInOut) bool {
+		return msg.Header.Tag == core.SystemTagDefineContractMigration
+	})).Return(mms)
+	mms.On("SendAndWait", context.Background()).Return(nil)
+
+	migration := &core.ContractMigration{Index: 1, BlockNumber: 100}
+	err := ds.DefineContractMigration(context.Background(), migration, true)
+	assert.NoError(t, err)
+	assert.NotNil(t, migration.ID)
+
+	mms.AssertExpectations(t)
+}
+
+func TestDefineContractMigrationRootOrgFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = true
+
+	ds.mim.On("GetRootOrg", context.Background()).Return(nil, fmt.Errorf("pop"))
+
+	err := ds.DefineContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 100}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestDefineContractMigrationNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+	ds.multiparty = false
+
+	err := ds.DefineContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 100}, false)
+	assert.Regexp(t, "FF10414", err)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -126,6 +127,10 @@ func (ds *definitionSender) DefineContractAPI(ctx context.Context, httpServerURL
 	}
 	api.Namespace = ds.namespace
 
+	if err := ds.importSharedFFI(ctx, api); err != nil {
+		return err
+	}
+
 	if api.Published {
 		if !ds.multiparty {
 			return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
@@ -141,6 +146,30 @@ func (ds *definitionSender) DefineContractAPI(ctx context.Context, httpServerURL
 	})
 }
 
+// importSharedFFI defines a local copy of a shared interface, when an API references an interface by name and
+// version that is not defined in this namespace, but has been shared from another namespace. The API is pinned
+// to the hash of the shared interface, unless it already specifies a hash.
+func (ds *definitionSender) importSharedFFI(ctx context.Context, api *core.ContractAPI) error {
+	if api.Interface == nil || api.Interface.ID != nil || api.Interface.Name == "" || api.Interface.Version == "" {
+		return nil
+	}
+	existing, err := ds.database.GetFFI(ctx, ds.namespace, api.Interface.Name, api.Interface.Version)
+	if err != nil || existing != nil {
+		return err
+	}
+	shared, ffi, err := ds.contracts.GetSharedFFIWithChildren(ctx, api.Interface.Name, api.Interface.Version)
+	if err != nil || shared == nil {
+		return err
+	}
+	if api.InterfaceHash == nil {
+		api.InterfaceHash = shared.Hash
+	}
+	ffi.Message = nil
+	ffi.Published = false
+	log.L(ctx).Infof("Importing interface '%s:%s' shared from namespace '%s'", shared.Name, shared.Version, shared.Namespace)
+	return ds.DefineFFI(ctx, ffi, true)
+}
+
 func (ds *definitionSender) getContractAPISender(ctx context.Context, httpServerURL string, api *core.ContractAPI) *sendWrapper {
 	if err := ds.contracts.ResolveContractAPI(ctx, httpServerURL, api); err != nil {
 		return wrapSendError(err)
@@ -150,7 +179,7 @@ func (ds *definitionSender) getContractAPISender(ctx context.Context, httpServer
 		api.NetworkName = api.Name
 	}
 
-	existing, err := ds.database.GetContractAPIByNetworkName(ctx, ds.namespace, api.NetworkName)
+	existing, err := ds.database.GetContractAPIByNetworkName(ctx, ds.namespace, api.NetworkName, api.Version)
 	if err != nil {
 		return wrapSendError(err)
 	} else if existing != nil {
@@ -184,14 +213,14 @@ func (ds *definitionSender) getContractAPISender(ctx context.Context, httpServer
 	return sender
 }
 
-func (ds *definitionSender) PublishContractAPI(ctx context.Context, httpServerURL, name, networkName string, waitConfirm bool) (api *core.ContractAPI, err error) {
+func (ds *definitionSender) PublishContractAPI(ctx context.Context, httpServerURL, name, version, networkName string, waitConfirm bool) (api *core.ContractAPI, err error) {
 	if !ds.multiparty {
 		return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
 	}
 
 	var sender *sendWrapper
 	err = ds.database.RunAsGroup(ctx, func(ctx context.Context) error {
-		if api, err = ds.contracts.GetContractAPI(ctx, httpServerURL, name); err != nil {
+		if api, err = ds.contracts.GetContractAPI(ctx, httpServerURL, name, version); err != nil {
 			return err
 		}
 		if api.Published {
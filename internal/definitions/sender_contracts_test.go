@@ -257,7 +257,7 @@ func TestDefineContractAPIFail(t *testing.T) {
 
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	ds.mim.On("GetRootOrg", context.Background()).Return(nil, fmt.Errorf("pop"))
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "banana").Return(nil, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "banana", "").Return(nil, nil)
 
 	err := ds.DefineContractAPI(context.Background(), url, api, false)
 	assert.EqualError(t, err, "pop")
@@ -281,7 +281,7 @@ func TestDefineContractAPIOk(t *testing.T) {
 		},
 	}, nil)
 	ds.mim.On("ResolveInputSigningIdentity", context.Background(), mock.Anything).Return(nil)
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "banana").Return(nil, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "banana", "").Return(nil, nil)
 
 	mms := &syncasyncmocks.Sender{}
 	ds.mbm.On("NewBroadcast", mock.Anything).Return(mms)
@@ -487,8 +487,8 @@ func TestPublishContractAPI(t *testing.T) {
 		Published: false,
 	}
 
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared").Return(nil, nil)
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared", "").Return(nil, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	ds.mim.On("GetRootOrg", context.Background()).Return(&core.Identity{
 		IdentityBase: core.IdentityBase{
@@ -501,7 +501,7 @@ func TestPublishContractAPI(t *testing.T) {
 	mms.On("Send", context.Background()).Return(nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 
-	result, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	result, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.NoError(t, err)
 	assert.Equal(t, api, result)
 	assert.True(t, api.Published)
@@ -521,10 +521,10 @@ func TestPublishContractAPIAlreadyPublished(t *testing.T) {
 		Published: true,
 	}
 
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.Regexp(t, "FF10450", err)
 }
 
@@ -535,10 +535,10 @@ func TestPublishContractAPIQueryFail(t *testing.T) {
 
 	url := "http://firefly"
 
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(nil, fmt.Errorf("pop"))
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(nil, fmt.Errorf("pop"))
 	mockRunAsGroupPassthrough(ds.mdi)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.EqualError(t, err, "pop")
 }
 
@@ -554,11 +554,11 @@ func TestPublishContractAPIResolveFail(t *testing.T) {
 		Published: false,
 	}
 
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(fmt.Errorf("pop"))
 	mockRunAsGroupPassthrough(ds.mdi)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.EqualError(t, err, "pop")
 }
 
@@ -569,7 +569,7 @@ func TestPublishContractAPINonMultiparty(t *testing.T) {
 
 	url := "http://firefly"
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.Regexp(t, "FF10414", err)
 }
 
@@ -585,12 +585,12 @@ func TestPublishContractAPINetworkNameFail(t *testing.T) {
 		Published: false,
 	}
 
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared").Return(nil, fmt.Errorf("pop"))
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared", "").Return(nil, fmt.Errorf("pop"))
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.EqualError(t, err, "pop")
 }
 
@@ -606,12 +606,12 @@ func TestPublishContractAPINetworkNameConflict(t *testing.T) {
 		Published: false,
 	}
 
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared").Return(&core.ContractAPI{}, nil)
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared", "").Return(&core.ContractAPI{}, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.Regexp(t, "FF10448", err)
 }
 
@@ -630,13 +630,13 @@ func TestPublishContractAPIInterfaceFail(t *testing.T) {
 		},
 	}
 
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared").Return(nil, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared", "").Return(nil, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 	ds.mdi.On("GetFFIByID", context.Background(), "ns1", api.Interface.ID).Return(nil, fmt.Errorf("pop"))
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.EqualError(t, err, "pop")
 }
 
@@ -655,13 +655,13 @@ func TestPublishContractAPIInterfaceNotFound(t *testing.T) {
 		},
 	}
 
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared").Return(nil, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared", "").Return(nil, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 	ds.mdi.On("GetFFIByID", context.Background(), "ns1", api.Interface.ID).Return(nil, nil)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.Regexp(t, "FF10303", err)
 }
 
@@ -680,14 +680,103 @@ func TestPublishContractAPIInterfaceNotPublished(t *testing.T) {
 		},
 	}
 
-	ds.mcm.On("GetContractAPI", context.Background(), url, "api").Return(api, nil)
-	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared").Return(nil, nil)
+	ds.mcm.On("GetContractAPI", context.Background(), url, "api", "").Return(api, nil)
+	ds.mdi.On("GetContractAPIByNetworkName", context.Background(), "ns1", "api-shared", "").Return(nil, nil)
 	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
 	mockRunAsGroupPassthrough(ds.mdi)
 	ds.mdi.On("GetFFIByID", context.Background(), "ns1", api.Interface.ID).Return(&fftypes.FFI{
 		Published: false,
 	}, nil)
 
-	_, err := ds.PublishContractAPI(context.Background(), url, "api", "api-shared", false)
+	_, err := ds.PublishContractAPI(context.Background(), url, "api", "", "api-shared", false)
 	assert.Regexp(t, "FF10451", err)
 }
+
+func TestDefineContractAPIImportSharedFFI(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	url := "http://firefly"
+	api := &core.ContractAPI{
+		Name:      "banana",
+		Interface: &fftypes.FFIReference{Name: "math", Version: "v1.0.0"},
+	}
+	sourceID := fftypes.NewUUID()
+	shared := &core.SharedFFI{Namespace: "ns2", Interface: sourceID, Name: "math", Version: "v1.0.0", Hash: fftypes.NewRandB32()}
+	ffi := &fftypes.FFI{
+		ID:        sourceID,
+		Namespace: "ns2",
+		Name:      "math",
+		Version:   "v1.0.0",
+		Message:   fftypes.NewUUID(),
+		Published: true,
+		Methods:   []*fftypes.FFIMethod{{ID: fftypes.NewUUID(), Name: "sum"}},
+	}
+
+	ds.mdi.On("GetFFI", context.Background(), "ns1", "math", "v1.0.0").Return(nil, nil)
+	ds.mcm.On("GetSharedFFIWithChildren", context.Background(), "math", "v1.0.0").Return(shared, ffi, nil)
+	ds.mcm.On("ResolveFFI", context.Background(), mock.MatchedBy(func(imported *fftypes.FFI) bool {
+		return imported.Namespace == "ns1" && !imported.ID.Equals(sourceID) && !imported.Published && imported.Message == nil
+	})).Return(nil)
+	ds.mdi.On("InsertOrGetFFI", context.Background(), ffi).Return(nil, nil)
+	ds.mdi.On("UpsertFFIMethod", context.Background(), ffi.Methods[0]).Return(nil)
+	ds.mdi.On("InsertEvent", context.Background(), mock.Anything).Return(nil)
+	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(nil)
+	ds.mdi.On("InsertOrGetContractAPI", mock.Anything, mock.Anything).Return(nil, nil)
+
+	err := ds.DefineContractAPI(context.Background(), url, api, false)
+	assert.NoError(t, err)
+	assert.Equal(t, shared.Hash, api.InterfaceHash)
+}
+
+func TestDefineContractAPIImportSharedFFINotShared(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	url := "http://firefly"
+	api := &core.ContractAPI{
+		Name:      "banana",
+		Interface: &fftypes.FFIReference{Name: "math", Version: "v1.0.0"},
+	}
+
+	ds.mdi.On("GetFFI", context.Background(), "ns1", "math", "v1.0.0").Return(nil, nil)
+	ds.mcm.On("GetSharedFFIWithChildren", context.Background(), "math", "v1.0.0").Return(nil, nil, nil)
+	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(fmt.Errorf("pop"))
+
+	err := ds.DefineContractAPI(context.Background(), url, api, false)
+	assert.Regexp(t, "pop", err)
+	assert.Nil(t, api.InterfaceHash)
+}
+
+func TestDefineContractAPIImportSharedFFIExistsLocally(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	url := "http://firefly"
+	api := &core.ContractAPI{
+		Name:      "banana",
+		Interface: &fftypes.FFIReference{Name: "math", Version: "v1.0.0"},
+	}
+
+	ds.mdi.On("GetFFI", context.Background(), "ns1", "math", "v1.0.0").Return(&fftypes.FFI{}, nil)
+	ds.mcm.On("ResolveContractAPI", context.Background(), url, api).Return(fmt.Errorf("pop"))
+
+	err := ds.DefineContractAPI(context.Background(), url, api, false)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestDefineContractAPIImportSharedFFIFail(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	api := &core.ContractAPI{
+		Name:      "banana",
+		Interface: &fftypes.FFIReference{Name: "math", Version: "v1.0.0"},
+	}
+
+	ds.mdi.On("GetFFI", context.Background(), "ns1", "math", "v1.0.0").Return(nil, nil)
+	ds.mcm.On("GetSharedFFIWithChildren", context.Background(), "math", "v1.0.0").Return(nil, nil, fmt.Errorf("pop"))
+
+	err := ds.DefineContractAPI(context.Background(), "http://firefly", api, false)
+	assert.Regexp(t, "pop", err)
+}
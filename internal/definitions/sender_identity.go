@@ -75,3 +75,25 @@ func (ds *definitionSender) UpdateIdentity(ctx context.Context, identity *core.I
 		return ds.handler.handleIdentityUpdate(ctx, state, &identityUpdateMsgInfo{}, def)
 	})
 }
+
+func (ds *definitionSender) RevokeVerifier(ctx context.Context, def *core.VerifierRevocation, signingIdentity *core.SignerRef, waitConfirm bool) error {
+	if ds.multiparty {
+		_, err := ds.getSender(ctx, def, signingIdentity, core.SystemTagRevokeVerifier).send(ctx, waitConfirm)
+		return err
+	}
+
+	return fakeBatch(ctx, func(ctx context.Context, state *core.BatchState) (HandlerResult, error) {
+		return ds.handler.handleVerifierRevocation(ctx, state, &verifierRevocationMsgInfo{}, def)
+	})
+}
+
+func (ds *definitionSender) DefineDelegation(ctx context.Context, def *core.Delegation, signingIdentity *core.SignerRef, waitConfirm bool) error {
+	if ds.multiparty {
+		_, err := ds.getSender(ctx, def, signingIdentity, core.SystemTagDefineDelegation).send(ctx, waitConfirm)
+		return err
+	}
+
+	return fakeBatch(ctx, func(ctx context.Context, state *core.BatchState) (HandlerResult, error) {
+		return ds.handler.handleDelegation(ctx, state, &delegationMsgInfo{}, def)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/identity"
 	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -200,3 +201,77 @@ func TestUpdateIdentityNonMultiparty(t *testing.T) {
 	}, false)
 	assert.Regexp(t, "FF10403", err)
 }
+
+func TestRevokeVerifier(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	mms := &syncasyncmocks.Sender{}
+
+	ds.mbm.On("NewBroadcast", mock.Anything).Return(mms)
+	mms.On("Send", mock.Anything).Return(nil)
+	ds.mim.On("ResolveInputSigningIdentity", mock.Anything, mock.MatchedBy(func(signer *core.SignerRef) bool {
+		return signer.Key == "0x1234"
+	})).Return(nil)
+
+	ds.multiparty = true
+
+	err := ds.RevokeVerifier(ds.ctx, &core.VerifierRevocation{
+		Identity: core.IdentityBase{},
+		Reason:   "compromised",
+	}, &core.SignerRef{
+		Key: "0x1234",
+	}, false)
+	assert.NoError(t, err)
+
+	mms.AssertExpectations(t)
+}
+
+func TestRevokeVerifierNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	ds.multiparty = false
+
+	err := ds.RevokeVerifier(ds.ctx, &core.VerifierRevocation{
+		Identity: core.IdentityBase{},
+	}, nil, false)
+	assert.Regexp(t, "FF10403", err)
+}
+
+func TestDefineDelegation(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	mms := &syncasyncmocks.Sender{}
+
+	ds.mbm.On("NewBroadcast", mock.Anything).Return(mms)
+	mms.On("Send", mock.Anything).Return(nil)
+	ds.mim.On("ResolveInputSigningIdentity", mock.Anything, mock.MatchedBy(func(signer *core.SignerRef) bool {
+		return signer.Key == "0x1234"
+	})).Return(nil)
+
+	ds.multiparty = true
+
+	err := ds.DefineDelegation(ds.ctx, &core.Delegation{
+		ID:        fftypes.NewUUID(),
+		Delegator: "org1",
+		Delegate:  "org2",
+		Scope:     fftypes.FFStringArray{"messages"},
+	}, &core.SignerRef{
+		Key: "0x1234",
+	}, false)
+	assert.NoError(t, err)
+
+	mms.AssertExpectations(t)
+}
+
+func TestDefineDelegationNonMultiparty(t *testing.T) {
+	ds := newTestDefinitionSender(t)
+	defer ds.cleanup(t)
+
+	ds.multiparty = false
+
+	err := ds.DefineDelegation(ds.ctx, &core.Delegation{}, nil, false)
+	assert.Regexp(t, "FF10403", err)
+}
@@ -75,7 +75,7 @@ func newTestDefinitionSender(t *testing.T) *testDefinitionSender {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	ds, _, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, mam, mcm, tokenBroadcastNames)
+	ds, _, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, mam, mcm, nil, tokenBroadcastNames)
 	assert.NoError(t, err)
 
 	return &testDefinitionSender{
@@ -101,7 +101,7 @@ func mockRunAsGroupPassthrough(mdi *databasemocks.Plugin) {
 }
 
 func TestInitSenderFail(t *testing.T) {
-	_, _, err := NewDefinitionSender(context.Background(), &core.Namespace{}, false, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	_, _, err := NewDefinitionSender(context.Background(), &core.Namespace{}, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -119,7 +119,7 @@ func TestNewDefinitionSenderHandlerThrows(t *testing.T) {
 
 	ctx := context.Background()
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	ds, dh, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, nil, mcm, tokenBroadcastNames)
+	ds, dh, err := NewDefinitionSender(ctx, ns, false, mdi, mbi, mdx, mbm, mim, mdm, nil, mcm, nil, tokenBroadcastNames)
 	assert.Nil(t, ds)
 	assert.Nil(t, dh)
 	assert.NotNil(t, err)
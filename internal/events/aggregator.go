@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/firefly/internal/definitions"
 	"github.com/hyperledger/firefly/internal/identity"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -60,6 +61,9 @@ type aggregator struct {
 	metrics      metrics.Manager
 	batchCache   cache.CInterface
 	rewinder     *rewinder
+	gapTracker   *gapTracker
+	multiparty   multiparty.Manager // optional
+	workers      int
 }
 
 type batchCacheEntry struct {
@@ -91,7 +95,7 @@ func privatePinHash(topic string, group *fftypes.Bytes32, identity string, nonce
 	return fftypes.HashResult(h)
 }
 
-func newAggregator(ctx context.Context, ns string, di database.Plugin, bi blockchain.Plugin, pm privatemessaging.Manager, sh definitions.Handler, im identity.Manager, dm data.Manager, en *eventNotifier, mm metrics.Manager, cacheManager cache.Manager) (*aggregator, error) {
+func newAggregator(ctx context.Context, ns string, di database.Plugin, bi blockchain.Plugin, mp multiparty.Manager, pm privatemessaging.Manager, sh definitions.Handler, im identity.Manager, dm data.Manager, en *eventNotifier, mm metrics.Manager, cacheManager cache.Manager) (*aggregator, error) {
 	batchSize := config.GetInt(coreconfig.EventAggregatorBatchSize)
 	ag := &aggregator{
 		ctx:          log.WithLogField(ctx, "role", "aggregator"),
@@ -103,6 +107,9 @@ func newAggregator(ctx context.Context, ns string, di database.Plugin, bi blockc
 		data:         dm,
 		verifierType: bi.VerifierType(),
 		metrics:      mm,
+		gapTracker:   newGapTracker(config.GetDuration(coreconfig.EventAggregatorGapReportDelay)),
+		multiparty:   mp,
+		workers:      config.GetInt(coreconfig.EventAggregatorWorkers),
 	}
 
 	batchCache, err := cacheManager.GetCache(
@@ -243,6 +250,7 @@ func (ag *aggregator) processWithBatchState(callback func(ctx context.Context, s
 		}
 	}
 	state.queueRewinds(ag)
+	ag.recoverPinGaps(state)
 	return nil
 }
 
@@ -252,6 +260,9 @@ func (ag *aggregator) processPinsEventsHandler(items []core.LocallySequenced) (r
 		pins[i] = item.(*core.Pin)
 	}
 
+	if ag.workers > 1 {
+		return false, ag.processPinsSharded(pins)
+	}
 	return false, ag.processWithBatchState(func(ctx context.Context, state *batchState) error {
 		return ag.processPins(ctx, pins, state)
 	})
@@ -308,7 +319,7 @@ func (ag *aggregator) extractManifest(ctx context.Context, batch *core.BatchPers
 	switch manifest.Version {
 	case core.ManifestVersionUnset:
 		return ag.migrateManifest(ctx, batch)
-	case core.ManifestVersion1:
+	case core.ManifestVersion1, core.ManifestVersion2, core.ManifestVersion3:
 		return &manifest
 	default:
 		log.L(ctx).Errorf("Invalid manifest version: %d", manifest.Version)
@@ -358,6 +369,14 @@ func (ag *aggregator) cacheBatch(cacheKey string, batch *core.BatchPersisted, ma
 }
 
 func (ag *aggregator) processPins(ctx context.Context, pins []*core.Pin, state *batchState) (err error) {
+	if err := ag.aggregatePins(ctx, pins, state); err != nil {
+		return err
+	}
+	ag.eventPoller.commitOffset(pins[len(pins)-1].Sequence)
+	return nil
+}
+
+func (ag *aggregator) aggregatePins(ctx context.Context, pins []*core.Pin, state *batchState) (err error) {
 	l := log.L(ctx)
 
 	localCache := make(map[fftypes.UUID]*batchCacheEntry)
@@ -403,8 +422,6 @@ func (ag *aggregator) processPins(ctx context.Context, pins []*core.Pin, state *
 			return err
 		}
 	}
-
-	ag.eventPoller.commitOffset(pins[len(pins)-1].Sequence)
 	return nil
 }
 
@@ -449,18 +466,62 @@ func (ag *aggregator) checkOnchainConsistency(ctx context.Context, msg *core.Mes
 			return core.ActionWait, nil // Wait in case the identity is resolved later
 		}
 	}
-	if msg.Header.Author == "" || resolvedAuthor.DID != msg.Header.Author {
+	if msg.Header.Author == "" {
 		return core.ActionReject, i18n.NewError(ctx, coremsgs.MsgInvalidMessageIdentity, msg.Header.ID, msg.Header.Author, verifierRef.Value, resolvedAuthor.DID, resolvedAuthor.ID)
 	}
+	if resolvedAuthor.DID != msg.Header.Author {
+		return ag.checkDelegatedAuthor(ctx, msg, verifierRef, resolvedAuthor)
+	}
+	return core.ActionConfirm, nil
+}
+
+// checkDelegatedAuthor allows a broadcast or private message to be signed by an identity other than the author, when
+// the signer holds a delegation from the author that was current at the time the message was created.
+// Definitions can never be submitted on behalf of another identity.
+func (ag *aggregator) checkDelegatedAuthor(ctx context.Context, msg *core.Message, verifierRef *core.VerifierRef, resolvedAuthor *core.Identity) (action core.MessageAction, err error) {
+	if msg.Header.Type == core.MessageTypeBroadcast || msg.Header.Type == core.MessageTypePrivate {
+		delegated, err := ag.identity.IsDelegated(ctx, msg.Header.Author, resolvedAuthor.DID, core.DelegationScopeMessages, msg.Header.Created)
+		if err != nil {
+			return core.ActionRetry, err
+		}
+		if delegated {
+			return core.ActionConfirm, nil
+		}
+	}
+	return core.ActionReject, i18n.NewError(ctx, coremsgs.MsgInvalidMessageIdentity, msg.Header.ID, msg.Header.Author, verifierRef.Value, resolvedAuthor.DID, resolvedAuthor.ID)
+}
+
+// checkSignerNotRevoked rejects the message if the signer was revoked by a revocation pinned before the message,
+// so the result is the same on every node regardless of the order in which the node processes the two
+func (ag *aggregator) checkSignerNotRevoked(ctx context.Context, msg *core.Message, pin *core.Pin) (action core.MessageAction, err error) {
+	revoked, err := ag.identity.IsVerifierRevokedAtPin(ctx, &core.VerifierRef{
+		Type:  ag.verifierType,
+		Value: pin.Signer,
+	}, pin.Sequence)
+	if err != nil {
+		return core.ActionRetry, err
+	}
+	if revoked {
+		return core.ActionReject, i18n.NewError(ctx, coremsgs.MsgRevokedMessageSigner, msg.Header.ID, pin.Signer)
+	}
 	return core.ActionConfirm, nil
 }
 
+// recordRevokedSigner generates a security event, in addition to the rejection event for the message
+func (ag *aggregator) recordRevokedSigner(msg *core.Message, tx *fftypes.UUID, state *batchState) {
+	state.AddFinalize(func(ctx context.Context) error {
+		event := core.NewEvent(core.EventTypeRevokedSignerRejected, ag.namespace, msg.Header.ID, tx, core.SystemTopicSecurity)
+		return ag.database.InsertEvent(ctx, event)
+	})
+}
+
 func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchManifest, pin *core.Pin, msgBaseIndex int64, msgEntry *core.MessageManifestEntry, batch *core.BatchPersisted, state *batchState) (err error) {
 	l := log.L(ctx)
 
 	unmaskedContexts := make([]*fftypes.Bytes32, 0)
 	nextPins := make([]*nextPinState, 0)
 	action := core.ActionWait
+	signerRevoked := false
 	var correlator *fftypes.UUID
 
 	var cro data.CacheReadOption
@@ -480,6 +541,11 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 	default:
 		// Check the pin signer is valid for the message
 		action, err = ag.checkOnchainConsistency(ctx, msg, pin)
+		if action == core.ActionConfirm {
+			// Check the signer has not been revoked
+			action, err = ag.checkSignerNotRevoked(ctx, msg, pin)
+			signerRevoked = action == core.ActionReject
+		}
 		if action == core.ActionWait || action == core.ActionRetry {
 			break
 		}
@@ -539,12 +605,15 @@ func (ag *aggregator) processMessage(ctx context.Context, manifest *core.BatchMa
 		return nil
 	}
 
-	if action == core.ActionReject && err != nil {
-		log.L(ctx).Warnf("Message '%s' rejected: %s", msg.Header.ID, err)
+	if (action == core.ActionReject || action == core.ActionQuarantine) && err != nil {
+		log.L(ctx).Warnf("Message '%s' %sd: %s", msg.Header.ID, action, err)
 		msg.RejectReason = err.Error()
 	}
 
 	newState := ag.completeDispatch(action, correlator, msg, manifest.TX.ID, state)
+	if signerRevoked {
+		ag.recordRevokedSigner(msg, manifest.TX.ID, state)
+	}
 
 	// Mark all message pins dispatched, and increment all nextPins
 	for _, np := range nextPins {
@@ -639,13 +708,24 @@ func (ag *aggregator) readyForDispatch(ctx context.Context, msg *core.Message, d
 		action = core.ActionConfirm
 
 	case len(msg.Data) > 0:
-		var valid bool
-		valid, err = ag.data.ValidateAll(ctx, data)
-		action = core.ActionReject
-		if err != nil {
+		validation, validateErr := ag.data.ValidateAll(ctx, data)
+		switch {
+		case validateErr != nil:
 			action = core.ActionRetry
-		} else if valid {
+			err = validateErr
+		case validation.Valid:
 			action = core.ActionConfirm
+		case validation.Policy == core.ValidationPolicyWarn:
+			log.L(ctx).Warnf("Message '%s' confirmed with invalid data: %s", msg.Header.ID, validation.Reason)
+			ag.recordValidationWarning(msg, tx, state)
+			action = core.ActionConfirm
+		case validation.Policy == core.ValidationPolicyQuarantine:
+			action = core.ActionQuarantine
+			ag.recordQuarantine(msg, validation.Reason, state)
+			err = i18n.NewError(ctx, coremsgs.MsgMessageDataInvalid, validation.Reason)
+		default:
+			action = core.ActionReject
+			err = i18n.NewError(ctx, coremsgs.MsgMessageDataInvalid, validation.Reason)
 		}
 
 	default:
@@ -658,9 +738,13 @@ func (ag *aggregator) readyForDispatch(ctx context.Context, msg *core.Message, d
 func (ag *aggregator) completeDispatch(action core.MessageAction, correlator *fftypes.UUID, msg *core.Message, tx *fftypes.UUID, state *batchState) core.MessageState {
 	newState := core.MessageStateConfirmed
 	eventType := core.EventTypeMessageConfirmed
-	if action == core.ActionConfirm {
+	switch action {
+	case core.ActionConfirm:
 		state.AddPendingConfirm(msg.Header.ID, msg)
-	} else {
+	case core.ActionQuarantine:
+		newState = core.MessageStateQuarantined
+		eventType = core.EventTypeMessageQuarantined
+	default:
 		newState = core.MessageStateRejected
 		eventType = core.EventTypeMessageRejected
 	}
@@ -686,6 +770,36 @@ func (ag *aggregator) completeDispatch(action core.MessageAction, correlator *ff
 	return newState
 }
 
+// recordValidationWarning emits a warning event for each topic of a message that is confirmed with invalid data
+func (ag *aggregator) recordValidationWarning(msg *core.Message, tx *fftypes.UUID, state *batchState) {
+	state.AddFinalize(func(ctx context.Context) error {
+		for _, topic := range msg.Header.Topics {
+			event := core.NewEvent(core.EventTypeMessageValidationWarning, ag.namespace, msg.Header.ID, tx, topic)
+			event.Correlator = msg.Header.CID
+			if err := ag.database.InsertEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// recordQuarantine adds an entry to the quarantine for a message held for review because its data is invalid,
+// so that it can be re-validated once the datatype (or the validation rules) are corrected
+func (ag *aggregator) recordQuarantine(msg *core.Message, reason string, state *batchState) {
+	state.AddFinalize(func(ctx context.Context) error {
+		return ag.database.InsertQuarantineEntry(ctx, &core.QuarantineEntry{
+			ID:        fftypes.NewUUID(),
+			Namespace: ag.namespace,
+			Reason:    core.QuarantineReasonDataInvalid,
+			Detail:    reason,
+			Batch:     msg.BatchID,
+			Message:   msg.Header.ID,
+			Created:   fftypes.Now(),
+		})
+	})
+}
+
 // resolveBlobs ensures that the blobs for all the attachments in the data array, have been received into the
 // local data exchange blob store. Either because of a private transfer, or by downloading them from the shared storage
 func (ag *aggregator) resolveBlobs(ctx context.Context, data core.DataArray) (resolved bool, err error) {
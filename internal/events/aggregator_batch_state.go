@@ -19,6 +19,7 @@ package events
 import (
 	"context"
 	"database/sql/driver"
+	"strconv"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -103,6 +104,7 @@ type batchState struct {
 	maskedContexts     map[fftypes.Bytes32]*nextPinGroupState
 	unmaskedContexts   map[fftypes.Bytes32]*contextState
 	dispatchedMessages []*dispatchedMessage
+	pinGaps            []*pinGap
 }
 
 func (bs *batchState) RunPreFinalize(ctx context.Context) error {
@@ -188,7 +190,7 @@ func (bs *batchState) checkMaskedContextReady(ctx context.Context, msg *core.Mes
 			}
 		}
 		l.Warnf("Mismatched nexthash or author msg=%s group=%s topic=%s context=%s pin=%s nonce=%s nextHash=%+v author=%s", msg.Header.ID, msg.Header.Group, topic, contextUnmasked, pin, nonceStr, nextPin, msg.Header.Author)
-		return nil, nil
+		return nil, bs.checkNonceGap(ctx, msg, batch, topic, contextUnmasked, npg, pin, nonceStr)
 	}
 	return &nextPinState{
 		nextPinGroup: npg,
@@ -196,6 +198,40 @@ func (bs *batchState) checkMaskedContextReady(ctx context.Context, msg *core.Mes
 	}, err
 }
 
+// checkNonceGap records a gap if the pin is the author's pin for a nonce beyond the next one expected from
+// them on the context, and the pin for the expected nonce has not been received. If that pin has been received,
+// its message is simply waiting for data (such as a private batch still in flight) and there is no gap.
+func (bs *batchState) checkNonceGap(ctx context.Context, msg *core.Message, batch *core.BatchPersisted, topic string, contextUnmasked *fftypes.Bytes32, npg *nextPinGroupState, pin *fftypes.Bytes32, nonceStr string) error {
+	nonce, err := strconv.ParseInt(nonceStr, 10, 64)
+	if err != nil || !npg.calcPinHash(msg.Header.Author, nonce).Equals(pin) {
+		return nil
+	}
+	for _, np := range npg.nextPins {
+		if np.Identity == msg.Header.Author && np.Nonce < nonce {
+			fb := database.PinQueryFactory.NewFilterLimit(ctx, 1)
+			pins, _, err := bs.database.GetPins(ctx, bs.namespace, fb.Eq("hash", np.Hash))
+			if err != nil {
+				return err
+			}
+			if len(pins) > 0 {
+				log.L(ctx).Debugf("Pin for expected nonce received context=%s author=%s expectedNonce=%d sequence=%d", contextUnmasked, msg.Header.Author, np.Nonce, pins[0].Sequence)
+				continue
+			}
+			log.L(ctx).Warnf("Gap detected in pins context=%s author=%s expectedNonce=%d receivedNonce=%d msg=%s", contextUnmasked, msg.Header.Author, np.Nonce, nonce, msg.Header.ID)
+			bs.pinGaps = append(bs.pinGaps, &pinGap{
+				context:       contextUnmasked,
+				identity:      msg.Header.Author,
+				expectedNonce: np.Nonce,
+				receivedNonce: nonce,
+				lastPins:      npg.lastPinHashes(msg.Header.Author),
+				msgID:         msg.Header.ID,
+				txID:          batch.TX.ID,
+			})
+		}
+	}
+	return nil
+}
+
 func (bs *batchState) markMessageDispatched(batchID *fftypes.UUID, msg *core.Message, msgBaseIndex int64, newState core.MessageState) {
 	bs.dispatchedMessages = append(bs.dispatchedMessages, &dispatchedMessage{
 		batchID:       batchID,
@@ -276,7 +312,7 @@ func (bs *batchState) flushPins(ctx context.Context) error {
 			pinsDispatched[*dm.batchID] = batchDispatched
 		}
 
-		if dm.newState == core.MessageStateRejected {
+		if dm.newState == core.MessageStateRejected || dm.newState == core.MessageStateQuarantined {
 			if err := bs.confirmMessages(ctx, []*fftypes.UUID{dm.msgID}, dm.newState, confirmTime, dm.rejectReason); err != nil {
 				return err
 			}
@@ -338,6 +374,23 @@ func (npg *nextPinGroupState) calcPinHash(identity string, nonce int64) *fftypes
 	return privatePinHash(npg.topic, npg.groupID, identity, nonce)
 }
 
+// lastPinHashes returns the hashes of the last pin received from each member on the context, with the given
+// author's pin (if they have one) first
+func (npg *nextPinGroupState) lastPinHashes(author string) []*fftypes.Bytes32 {
+	hashes := make([]*fftypes.Bytes32, 0, len(npg.nextPins))
+	for _, np := range npg.nextPins {
+		if np.Nonce > 0 {
+			hash := npg.calcPinHash(np.Identity, np.Nonce-1)
+			if np.Identity == author {
+				hashes = append([]*fftypes.Bytes32{hash}, hashes...)
+			} else {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+	return hashes
+}
+
 func (bs *batchState) stateForMaskedContext(ctx context.Context, groupID *fftypes.Bytes32, topic string, contextUnmasked *fftypes.Bytes32) (*nextPinGroupState, error) {
 
 	if npg, exists := bs.maskedContexts[*contextUnmasked]; exists {
@@ -0,0 +1,187 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// pinGap is a gap in the sequence of masked pins from an author on a context, detected when a pin arrives
+// for a nonce beyond the next one expected, and no pin has been received for the expected nonce.
+// The message of the later pin is blocked until the gap is filled.
+type pinGap struct {
+	context       *fftypes.Bytes32
+	identity      string
+	expectedNonce int64
+	receivedNonce int64
+	lastPins      []*fftypes.Bytes32 // last pin of each member on the context, with the author's first (if any)
+	msgID         *fftypes.UUID
+	txID          *fftypes.UUID
+}
+
+func (pg *pinGap) key() string {
+	return fmt.Sprintf("%s/%s/%d", pg.context, pg.identity, pg.expectedNonce)
+}
+
+type trackedGap struct {
+	*pinGap
+	detected  time.Time
+	requeried bool
+	reported  bool
+}
+
+// gapTracker holds the gaps that are being recovered, so the blockchain plugin is only re-queried once for
+// each gap, and a gap is only reported once - if it is still detected after the report delay
+type gapTracker struct {
+	mux         sync.Mutex
+	reportDelay time.Duration
+	gaps        map[string]*trackedGap
+}
+
+func newGapTracker(reportDelay time.Duration) *gapTracker {
+	return &gapTracker{
+		reportDelay: reportDelay,
+		gaps:        make(map[string]*trackedGap),
+	}
+}
+
+// recoverPinGaps is called after each batch of pins is processed.
+//   - Gaps that have been filled by the pins in the batch are dropped, and their blocked message re-evaluated
+//   - New gaps are recovered by re-querying the blockchain plugin from the last pin received on the context before the gap
+//   - Gaps where the re-query failed are reported with an event, as are gaps still detected once the report
+//     delay has passed since the re-query
+func (ag *aggregator) recoverPinGaps(state *batchState) {
+	ag.gapTracker.mux.Lock()
+	defer ag.gapTracker.mux.Unlock()
+	if len(state.pinGaps) == 0 && len(ag.gapTracker.gaps) == 0 {
+		return
+	}
+
+	for key, g := range ag.gapTracker.gaps {
+		if npg := state.maskedContexts[*g.context]; npg != nil && npg.identitiesChanged[g.identity] {
+			for _, np := range npg.nextPins {
+				if np.Identity == g.identity && np.Nonce > g.expectedNonce {
+					log.L(ag.ctx).Infof("Gap in pins recovered context=%s author=%s nonce=%d", g.context, g.identity, g.expectedNonce)
+					delete(ag.gapTracker.gaps, key)
+					ag.queueMessageRewind(g.msgID)
+					break
+				}
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, gap := range state.pinGaps {
+		key := gap.key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		g := ag.gapTracker.gaps[key]
+		switch {
+		case g == nil:
+			g = &trackedGap{pinGap: gap, detected: time.Now()}
+			ag.gapTracker.gaps[key] = g
+			if err := ag.requeryPinGap(ag.ctx, gap); err != nil {
+				log.L(ag.ctx).Errorf("Failed to re-query blockchain for gap in pins context=%s author=%s nonce=%d: %s", gap.context, gap.identity, gap.expectedNonce, err)
+				ag.reportPinGap(g)
+			} else {
+				g.requeried = true
+			}
+		case !g.reported && (!g.requeried || time.Since(g.detected) >= ag.gapTracker.reportDelay):
+			// The missing pins were not recovered by the re-query
+			ag.reportPinGap(g)
+		}
+	}
+}
+
+func (ag *aggregator) requeryPinGap(ctx context.Context, gap *pinGap) error {
+	if ag.multiparty == nil {
+		return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	fromProtocolID, err := ag.lastProtocolIDBeforeGap(ctx, gap)
+	if err != nil {
+		return err
+	}
+	if fromProtocolID == "" {
+		return i18n.NewError(ctx, coremsgs.MsgNoPinGapRequeryPosition, gap.context, gap.identity)
+	}
+	log.L(ctx).Infof("Re-querying blockchain for gap in pins context=%s author=%s nonces=%d-%d from='%s'", gap.context, gap.identity, gap.expectedNonce, gap.receivedNonce-1, fromProtocolID)
+	return ag.multiparty.RequeryPins(ctx, fromProtocolID)
+}
+
+// lastProtocolIDBeforeGap finds the blockchain event to re-query from, which is that of the author's last pin on
+// the context before the gap or, if the author has no earlier pin, the latest pin received from any member on the
+// context. An empty string is returned if no such event is known, as the re-query is never unbounded.
+func (ag *aggregator) lastProtocolIDBeforeGap(ctx context.Context, gap *pinGap) (string, error) {
+	if len(gap.lastPins) == 0 {
+		return "", nil
+	}
+	hashes := make([]driver.Value, len(gap.lastPins))
+	for i, hash := range gap.lastPins {
+		hashes[i] = hash
+	}
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := ag.database.GetPins(ctx, ag.namespace, fb.In("hash", hashes).Sort("sequence").Descending())
+	if err != nil || len(pins) == 0 {
+		return "", err
+	}
+	pin := pins[0]
+	if gap.expectedNonce > 0 {
+		for _, p := range pins {
+			if p.Hash.Equals(gap.lastPins[0]) {
+				pin = p
+				break
+			}
+		}
+	}
+	batch, err := ag.database.GetBatchByID(ctx, ag.namespace, pin.Batch)
+	if err != nil || batch == nil || batch.TX.ID == nil {
+		return "", err
+	}
+	efb := database.BlockchainEventQueryFactory.NewFilterLimit(ctx, 1)
+	events, _, err := ag.database.GetBlockchainEvents(ctx, ag.namespace, efb.And(
+		efb.Eq("tx.id", batch.TX.ID),
+		efb.Eq("listener", nil),
+	))
+	if err != nil || len(events) == 0 {
+		return "", err
+	}
+	return events[0].ProtocolID, nil
+}
+
+func (ag *aggregator) reportPinGap(g *trackedGap) {
+	event := core.NewEvent(core.EventTypeAggregationGapDetected, ag.namespace, g.msgID, g.txID, core.SystemBatchPinTopic)
+	if err := ag.database.InsertEvent(ag.ctx, event); err != nil {
+		// Reported when the gap is next detected
+		log.L(ag.ctx).Errorf("Failed to record gap in pins context=%s author=%s nonce=%d: %s", g.context, g.identity, g.expectedNonce, err)
+		return
+	}
+	g.reported = true
+}
@@ -0,0 +1,344 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestPinGap(expectedNonce int64) *pinGap {
+	group := fftypes.NewRandB32()
+	gap := &pinGap{
+		context:       privateContext("topic1", group),
+		identity:      "author1",
+		expectedNonce: expectedNonce,
+		receivedNonce: expectedNonce + 2,
+		lastPins:      []*fftypes.Bytes32{privatePinHash("topic1", group, "author2", 3)},
+		msgID:         fftypes.NewUUID(),
+		txID:          fftypes.NewUUID(),
+	}
+	if expectedNonce > 0 {
+		gap.lastPins = append([]*fftypes.Bytes32{privatePinHash("topic1", group, "author1", expectedNonce-1)}, gap.lastPins...)
+	}
+	return gap
+}
+
+func TestCheckMaskedContextReadyNonceGap(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	group := fftypes.NewRandB32()
+	contextUnmasked := privateContext("topic1", group)
+	npg := &nextPinGroupState{topic: "topic1", groupID: group}
+	ag.mdi.On("GetNextPinsForContext", ag.ctx, "ns1", contextUnmasked).Return([]*core.NextPin{
+		{Context: contextUnmasked, Identity: "author1", Hash: npg.calcPinHash("author1", 5), Nonce: 5},
+		{Context: contextUnmasked, Identity: "author2", Hash: npg.calcPinHash("author2", 1), Nonce: 1},
+	}, nil)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil).Once()
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Group:  group,
+			Topics: fftypes.FFStringArray{"topic1"},
+			SignerRef: core.SignerRef{
+				Author: "author1",
+				Key:    "0x12345",
+			},
+		},
+	}
+	batch := &core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+
+	bs := newBatchState(&ag.aggregator)
+	np, err := bs.checkMaskedContextReady(ag.ctx, msg, batch, "topic1", 12345, npg.calcPinHash("author1", 7), "7")
+	assert.NoError(t, err)
+	assert.Nil(t, np)
+	assert.Len(t, bs.pinGaps, 1)
+	assert.Equal(t, int64(5), bs.pinGaps[0].expectedNonce)
+	assert.Equal(t, int64(7), bs.pinGaps[0].receivedNonce)
+	assert.Equal(t, []*fftypes.Bytes32{npg.calcPinHash("author1", 4), npg.calcPinHash("author2", 0)}, bs.pinGaps[0].lastPins)
+	assert.Equal(t, msg.Header.ID, bs.pinGaps[0].msgID)
+	assert.Equal(t, batch.TX.ID, bs.pinGaps[0].txID)
+
+	// A nonce that does not match the pin is not a gap
+	_, err = bs.checkMaskedContextReady(ag.ctx, msg, batch, "topic1", 12345, npg.calcPinHash("author1", 7), "8")
+	assert.NoError(t, err)
+	// Nor is a pin without a nonce
+	_, err = bs.checkMaskedContextReady(ag.ctx, msg, batch, "topic1", 12345, npg.calcPinHash("author1", 7), "")
+	assert.NoError(t, err)
+	assert.Len(t, bs.pinGaps, 1)
+}
+
+func TestCheckMaskedContextReadyExpectedPinReceived(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	group := fftypes.NewRandB32()
+	contextUnmasked := privateContext("topic1", group)
+	npg := &nextPinGroupState{topic: "topic1", groupID: group}
+	ag.mdi.On("GetNextPinsForContext", ag.ctx, "ns1", contextUnmasked).Return([]*core.NextPin{
+		{Context: contextUnmasked, Identity: "author1", Hash: npg.calcPinHash("author1", 5), Nonce: 5},
+	}, nil)
+	// The pin for nonce 5 has arrived, but its message is still waiting for data
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{{Sequence: 100}}, nil, nil).Once()
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Group:     group,
+			Topics:    fftypes.FFStringArray{"topic1"},
+			SignerRef: core.SignerRef{Author: "author1"},
+		},
+	}
+	batch := &core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+
+	bs := newBatchState(&ag.aggregator)
+	np, err := bs.checkMaskedContextReady(ag.ctx, msg, batch, "topic1", 12345, npg.calcPinHash("author1", 6), "6")
+	assert.NoError(t, err)
+	assert.Nil(t, np)
+	assert.Empty(t, bs.pinGaps)
+}
+
+func TestCheckMaskedContextReadyNonceGapQueryFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	group := fftypes.NewRandB32()
+	contextUnmasked := privateContext("topic1", group)
+	npg := &nextPinGroupState{topic: "topic1", groupID: group}
+	ag.mdi.On("GetNextPinsForContext", ag.ctx, "ns1", contextUnmasked).Return([]*core.NextPin{
+		{Context: contextUnmasked, Identity: "author1", Hash: npg.calcPinHash("author1", 5), Nonce: 5},
+	}, nil)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Group:     group,
+			Topics:    fftypes.FFStringArray{"topic1"},
+			SignerRef: core.SignerRef{Author: "author1"},
+		},
+	}
+	batch := &core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+
+	bs := newBatchState(&ag.aggregator)
+	_, err := bs.checkMaskedContextReady(ag.ctx, msg, batch, "topic1", 12345, npg.calcPinHash("author1", 6), "6")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRecoverPinGapsRequeryThenReport(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	pin := &core.Pin{Hash: gap.lastPins[0], Batch: fftypes.NewUUID()}
+	batch := &core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{
+		{Hash: gap.lastPins[1], Batch: fftypes.NewUUID()},
+		pin,
+	}, nil, nil).Once()
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", pin.Batch).Return(batch, nil).Once()
+	ag.mdi.On("GetBlockchainEvents", ag.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{
+		{ProtocolID: "000000000010/000000/000000"},
+	}, nil, nil).Once()
+	ag.mmp.On("RequeryPins", ag.ctx, "000000000010/000000/000000").Return(nil).Once()
+
+	bs := newBatchState(&ag.aggregator)
+	bs.pinGaps = []*pinGap{gap, gap}
+	ag.recoverPinGaps(bs)
+	assert.True(t, ag.gapTracker.gaps[gap.key()].requeried)
+	assert.False(t, ag.gapTracker.gaps[gap.key()].reported)
+
+	// Detected again before the re-delivered events have had time to arrive, so neither re-queried nor reported
+	ag.recoverPinGaps(bs)
+	assert.False(t, ag.gapTracker.gaps[gap.key()].reported)
+
+	// Still detected after the report delay, so it is reported
+	ag.gapTracker.gaps[gap.key()].detected = time.Now().Add(-ag.gapTracker.reportDelay)
+	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Type == core.EventTypeAggregationGapDetected && e.Reference.Equals(gap.msgID) && e.Transaction.Equals(gap.txID)
+	})).Return(nil).Once()
+	ag.recoverPinGaps(bs)
+	assert.True(t, ag.gapTracker.gaps[gap.key()].reported)
+
+	// Only reported once
+	ag.recoverPinGaps(bs)
+}
+
+func TestRecoverPinGapsRequeryFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	pin := &core.Pin{Hash: gap.lastPins[0], Batch: fftypes.NewUUID()}
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{pin}, nil, nil).Once()
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", pin.Batch).Return(&core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}, nil).Once()
+	ag.mdi.On("GetBlockchainEvents", ag.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{
+		{ProtocolID: "000000000010/000000/000000"},
+	}, nil, nil).Once()
+	ag.mmp.On("RequeryPins", ag.ctx, "000000000010/000000/000000").Return(fmt.Errorf("pop")).Once()
+	ag.mdi.On("InsertEvent", ag.ctx, mock.Anything).Return(fmt.Errorf("pop")).Once()
+
+	bs := newBatchState(&ag.aggregator)
+	bs.pinGaps = []*pinGap{gap}
+	ag.recoverPinGaps(bs)
+	assert.False(t, ag.gapTracker.gaps[gap.key()].requeried)
+	assert.False(t, ag.gapTracker.gaps[gap.key()].reported)
+
+	// Reported when next detected, as the event could not be recorded
+	ag.mdi.On("InsertEvent", ag.ctx, mock.Anything).Return(nil).Once()
+	ag.recoverPinGaps(bs)
+	assert.True(t, ag.gapTracker.gaps[gap.key()].reported)
+}
+
+func TestRecoverPinGapsNoRequeryPosition(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	// First pin from the author, and no other member has pinned on the context
+	gap := newTestPinGap(0)
+	gap.lastPins = nil
+	ag.mdi.On("InsertEvent", ag.ctx, mock.Anything).Return(nil).Once()
+
+	bs := newBatchState(&ag.aggregator)
+	bs.pinGaps = []*pinGap{gap}
+	ag.recoverPinGaps(bs)
+	assert.False(t, ag.gapTracker.gaps[gap.key()].requeried)
+	assert.True(t, ag.gapTracker.gaps[gap.key()].reported)
+	ag.mmp.AssertNotCalled(t, "RequeryPins", mock.Anything, mock.Anything)
+}
+
+func TestRecoverPinGapsNoMultiparty(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.aggregator.multiparty = nil
+
+	gap := newTestPinGap(5)
+	ag.mdi.On("InsertEvent", ag.ctx, mock.Anything).Return(nil)
+
+	bs := newBatchState(&ag.aggregator)
+	bs.pinGaps = []*pinGap{gap}
+	ag.recoverPinGaps(bs)
+	assert.True(t, ag.gapTracker.gaps[gap.key()].reported)
+}
+
+func TestRecoverPinGapsFilled(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	other := newTestPinGap(5)
+	ag.gapTracker.gaps[gap.key()] = &trackedGap{pinGap: gap, requeried: true}
+	ag.gapTracker.gaps[other.key()] = &trackedGap{pinGap: other, requeried: true}
+
+	bs := newBatchState(&ag.aggregator)
+	bs.maskedContexts[*gap.context] = &nextPinGroupState{
+		identitiesChanged: map[string]bool{"author1": true},
+		nextPins: []*core.NextPin{
+			{Identity: "author2", Nonce: 10},
+			{Identity: "author1", Nonce: 6},
+		},
+	}
+	bs.maskedContexts[*other.context] = &nextPinGroupState{
+		identitiesChanged: map[string]bool{"author1": true},
+		nextPins: []*core.NextPin{
+			{Identity: "author1", Nonce: 5},
+		},
+	}
+	ag.recoverPinGaps(bs)
+
+	assert.Nil(t, ag.gapTracker.gaps[gap.key()])
+	assert.NotNil(t, ag.gapTracker.gaps[other.key()])
+	rw := <-ag.rewinder.rewindRequests
+	assert.Equal(t, rewindMessage, rw.rewindType)
+	assert.Equal(t, *gap.msgID, rw.uuid)
+}
+
+func TestLastProtocolIDBeforeGapFirstAuthorPin(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	// The author has no earlier pin, so the latest pin of another member on the context bounds the re-query
+	gap := newTestPinGap(0)
+	pin := &core.Pin{Hash: gap.lastPins[0], Batch: fftypes.NewUUID()}
+	batch := &core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{pin}, nil, nil)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", pin.Batch).Return(batch, nil)
+	ag.mdi.On("GetBlockchainEvents", ag.ctx, "ns1", mock.Anything).Return([]*core.BlockchainEvent{
+		{ProtocolID: "000000000020/000000/000000"},
+	}, nil, nil)
+
+	protocolID, err := ag.lastProtocolIDBeforeGap(ag.ctx, gap)
+	assert.NoError(t, err)
+	assert.Equal(t, "000000000020/000000/000000", protocolID)
+}
+
+func TestLastProtocolIDBeforeGapNotFound(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	protocolID, err := ag.lastProtocolIDBeforeGap(ag.ctx, gap)
+	assert.NoError(t, err)
+	assert.Empty(t, protocolID)
+}
+
+func TestLastProtocolIDBeforeGapNoBatch(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{{Batch: fftypes.NewUUID()}}, nil, nil)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	protocolID, err := ag.lastProtocolIDBeforeGap(ag.ctx, gap)
+	assert.NoError(t, err)
+	assert.Empty(t, protocolID)
+}
+
+func TestLastProtocolIDBeforeGapEventsFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{{Batch: fftypes.NewUUID()}}, nil, nil)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(&core.BatchPersisted{TX: core.TransactionRef{ID: fftypes.NewUUID()}}, nil)
+	ag.mdi.On("GetBlockchainEvents", ag.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := ag.lastProtocolIDBeforeGap(ag.ctx, gap)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRequeryPinGapLookupFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	gap := newTestPinGap(5)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := ag.requeryPinGap(ag.ctx, gap)
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,149 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// pinShardKeys is a union-find over the ordering keys of a page of pins.
+// Two pins end up in the same shard if they share a context, or belong to the same message.
+type pinShardKeys struct {
+	parent map[string]string
+}
+
+func (sk *pinShardKeys) find(key string) string {
+	parent, ok := sk.parent[key]
+	if !ok {
+		sk.parent[key] = key
+		return key
+	}
+	if parent != key {
+		parent = sk.find(parent)
+		sk.parent[key] = parent
+	}
+	return parent
+}
+
+func (sk *pinShardKeys) union(a, b string) {
+	rootA, rootB := sk.find(a), sk.find(b)
+	if rootA != rootB {
+		sk.parent[rootB] = rootA
+	}
+}
+
+// pinOrderingKeys returns the context key, and the message key, for a pin.
+// Unmasked pins carry the context hash directly. Masked pins have a unique hash per
+// nonce, so all pins for the same private group are kept in the same context.
+func (ag *aggregator) pinOrderingKeys(ctx context.Context, pin *core.Pin) (contextKey, msgKey string, err error) {
+	batch, manifest, err := ag.GetBatchForPin(ctx, pin)
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case !pin.Masked:
+		contextKey = fmt.Sprintf("ctx:%s", pin.Hash)
+	case batch != nil && batch.Group != nil:
+		contextKey = fmt.Sprintf("group:%s", batch.Group)
+	default:
+		contextKey = fmt.Sprintf("batch:%s", pin.Batch)
+	}
+	msgKey = fmt.Sprintf("batch:%s", pin.Batch)
+	if manifest != nil {
+		if _, msgEntry, _ := ag.extractBatchMessagePin(manifest, pin.Index); msgEntry != nil {
+			msgKey = fmt.Sprintf("msg:%s", msgEntry.ID)
+		}
+	}
+	return contextKey, msgKey, nil
+}
+
+// shardPins partitions a page of pins into at most ag.workers shards, such that every
+// ordering context is wholly contained in a single shard, with the pins in sequence order.
+// Definitions can affect the processing of any other context (such as an identity claim
+// that a later message depends on), so a page containing definitions is processed in one shard.
+func (ag *aggregator) shardPins(ctx context.Context, pins []*core.Pin) ([][]*core.Pin, error) {
+	definitionsContext := broadcastContext(core.SystemTopicDefinitions)
+	keys := &pinShardKeys{parent: make(map[string]string)}
+	contextKeys := make([]string, len(pins))
+	for i, pin := range pins {
+		if !pin.Masked && pin.Hash.Equals(definitionsContext) {
+			return [][]*core.Pin{pins}, nil
+		}
+		contextKey, msgKey, err := ag.pinOrderingKeys(ctx, pin)
+		if err != nil {
+			return nil, err
+		}
+		keys.union(contextKey, msgKey)
+		contextKeys[i] = contextKey
+	}
+
+	shardIdx := make(map[string]int)
+	shards := make([][]*core.Pin, 0, ag.workers)
+	for i, pin := range pins {
+		root := keys.find(contextKeys[i])
+		idx, ok := shardIdx[root]
+		if !ok {
+			idx = len(shardIdx) % ag.workers
+			shardIdx[root] = idx
+			if idx == len(shards) {
+				shards = append(shards, nil)
+			}
+		}
+		shards[idx] = append(shards[idx], pin)
+	}
+	return shards, nil
+}
+
+// processPinsSharded aggregates each shard in parallel. Each shard retries independently
+// until it succeeds, so pins already dispatched by one shard are never re-processed
+// due to a failure in another. The offset is only committed once every shard is complete.
+func (ag *aggregator) processPinsSharded(pins []*core.Pin) error {
+	shards, err := ag.shardPins(ag.ctx, pins)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, len(shards))
+	var wg sync.WaitGroup
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(i int, shard []*core.Pin) {
+			defer wg.Done()
+			errs[i] = ag.retry.Do(ag.ctx, fmt.Sprintf("aggregate shard %d", i), func(attempt int) (retry bool, err error) {
+				err = ag.processWithBatchState(func(ctx context.Context, state *batchState) error {
+					return ag.aggregatePins(ctx, shard, state)
+				})
+				return true, err
+			})
+		}(i, shard)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	log.L(ag.ctx).Debugf("Aggregated %d pins in %d shards", len(pins), len(shards))
+	ag.eventPoller.commitOffset(pins[len(pins)-1].Sequence)
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestShardBatch(group *fftypes.Bytes32, topics ...string) *core.BatchPersisted {
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{
+			ID:    fftypes.NewUUID(),
+			Group: group,
+		},
+		Payload: core.BatchPayload{
+			Messages: []*core.Message{
+				{Header: core.MessageHeader{
+					ID:     fftypes.NewUUID(),
+					Topics: topics,
+				}},
+			},
+		},
+	}
+	bp, _ := batch.Confirmed()
+	bp.Hash = fftypes.NewRandB32()
+	return bp
+}
+
+func mockRunAsGroup(ag *testAggregator) {
+	rag := ag.mdi.On("RunAsGroup", ag.ctx, mock.Anything)
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{
+			a[1].(func(context.Context) error)(a[0].(context.Context)),
+		}
+	}
+}
+
+func TestShardPinsByContextAndMessage(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.workers = 2
+
+	group := fftypes.NewRandB32()
+	privateBatch1 := newTestShardBatch(group, "topic1")
+	privateBatch2 := newTestShardBatch(group, "topic1")
+	multiTopicBatch := newTestShardBatch(nil, "topicC", "topicD")
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", privateBatch1.ID).Return(privateBatch1, nil)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", privateBatch2.ID).Return(privateBatch2, nil)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", multiTopicBatch.ID).Return(multiTopicBatch, nil)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	ctxA, ctxB := broadcastContext("topicA"), broadcastContext("topicB")
+	ctxC, ctxD := broadcastContext("topicC"), broadcastContext("topicD")
+	pins := []*core.Pin{
+		{Sequence: 1, Hash: ctxA, Batch: fftypes.NewUUID()},
+		{Sequence: 2, Hash: ctxB, Batch: fftypes.NewUUID()},
+		{Sequence: 3, Hash: ctxA, Batch: fftypes.NewUUID()},
+		{Sequence: 4, Hash: fftypes.NewRandB32(), Masked: true, Batch: privateBatch1.ID, BatchHash: privateBatch1.Hash},
+		{Sequence: 5, Hash: ctxC, Batch: multiTopicBatch.ID, BatchHash: multiTopicBatch.Hash, Index: 0},
+		{Sequence: 6, Hash: fftypes.NewRandB32(), Masked: true, Batch: privateBatch2.ID, BatchHash: privateBatch2.Hash},
+		{Sequence: 7, Hash: ctxD, Batch: multiTopicBatch.ID, BatchHash: multiTopicBatch.Hash, Index: 1},
+		{Sequence: 8, Hash: ctxD, Batch: fftypes.NewUUID()},
+		{Sequence: 9, Hash: fftypes.NewRandB32(), Masked: true, Batch: fftypes.NewUUID()},
+	}
+
+	shards, err := ag.shardPins(ag.ctx, pins)
+	assert.NoError(t, err)
+
+	sequences := make([][]int64, len(shards))
+	for i, shard := range shards {
+		for _, pin := range shard {
+			sequences[i] = append(sequences[i], pin.Sequence)
+		}
+	}
+	// Contexts are assigned round-robin: A=0, B=1, group=0, C+D=1, unknown masked batch=0
+	assert.Equal(t, [][]int64{
+		{1, 3, 4, 6, 9},
+		{2, 5, 7, 8},
+	}, sequences)
+}
+
+func TestShardPinsDefinitionsSerial(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.workers = 2
+
+	pins := []*core.Pin{
+		{Sequence: 1, Hash: broadcastContext("topicA"), Batch: fftypes.NewUUID()},
+		{Sequence: 2, Hash: broadcastContext(core.SystemTopicDefinitions), Batch: fftypes.NewUUID()},
+	}
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, nil).Once()
+
+	shards, err := ag.shardPins(ag.ctx, pins)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]*core.Pin{pins}, shards)
+}
+
+func TestProcessPinsShardedOK(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.workers = 2
+
+	mockRunAsGroup(ag)
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	_, err := ag.processPinsEventsHandler([]core.LocallySequenced{
+		&core.Pin{Sequence: 10, Hash: broadcastContext("topicA"), Batch: fftypes.NewUUID()},
+		&core.Pin{Sequence: 11, Hash: broadcastContext("topicB"), Batch: fftypes.NewUUID()},
+		&core.Pin{Sequence: 12, Hash: broadcastContext("topicC"), Batch: fftypes.NewUUID()},
+	})
+	assert.NoError(t, err)
+
+	// Confirm the offset
+	assert.Equal(t, int64(12), <-ag.eventPoller.offsetCommitted)
+}
+
+func TestProcessPinsShardedGetBatchFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.workers = 2
+
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := ag.processPinsEventsHandler([]core.LocallySequenced{
+		&core.Pin{Sequence: 10, Hash: broadcastContext("topicA"), Batch: fftypes.NewUUID()},
+	})
+	assert.Regexp(t, "pop", err)
+}
+
+func TestProcessPinsShardedRetryCancelled(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	ag.workers = 2
+
+	ag.mdi.On("GetBatchByID", ag.ctx, "ns1", mock.Anything).Return(nil, nil)
+	ag.mdi.On("RunAsGroup", ag.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+	ag.cancel()
+
+	_, err := ag.processPinsEventsHandler([]core.LocallySequenced{
+		&core.Pin{Sequence: 10, Hash: broadcastContext("topicA"), Batch: fftypes.NewUUID()},
+		&core.Pin{Sequence: 11, Hash: broadcastContext("topicB"), Batch: fftypes.NewUUID()},
+	})
+	assert.Regexp(t, "FF00154", err)
+	assert.Empty(t, ag.eventPoller.offsetCommitted)
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/definitionsmocks"
 	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
 	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -55,6 +56,7 @@ type testAggregator struct {
 	mdm    *datamocks.Manager
 	mpm    *privatemessagingmocks.Manager
 	mbi    *blockchainmocks.Plugin
+	mmp    *multipartymocks.Manager
 	mim    *identitymanagermocks.Manager
 	mmi    *metricsmocks.Manager
 	mdh    *definitionsmocks.Handler
@@ -66,6 +68,7 @@ func (tag *testAggregator) cleanup(t *testing.T) {
 	tag.mdm.AssertExpectations(t)
 	tag.mpm.AssertExpectations(t)
 	tag.mbi.AssertExpectations(t)
+	tag.mmp.AssertExpectations(t)
 	tag.mim.AssertExpectations(t)
 	tag.mmi.AssertExpectations(t)
 	tag.mdh.AssertExpectations(t)
@@ -84,12 +87,14 @@ func newTestAggregatorCommon(metrics bool) *testAggregator {
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	mbi := &blockchainmocks.Plugin{}
+	mmp := &multipartymocks.Manager{}
 	if metrics {
 		mmi.On("MessageConfirmed", mock.Anything, core.EventTypeMessageConfirmed).Return()
 	}
 	mmi.On("IsMetricsEnabled").Return(metrics).Maybe()
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
-	ag, _ := newAggregator(ctx, "ns1", mdi, mbi, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
+	mim.On("IsVerifierRevokedAtPin", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	ag, _ := newAggregator(ctx, "ns1", mdi, mbi, mmp, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
 	cancel := func() {
 		ctxCancel()
 		if ag.batchCache != nil {
@@ -106,6 +111,7 @@ func newTestAggregatorCommon(metrics bool) *testAggregator {
 		mim:        mim,
 		mmi:        mmi,
 		mbi:        mbi,
+		mmp:        mmp,
 	}
 }
 
@@ -188,7 +194,7 @@ func TestNewAggregator(t *testing.T) {
 	mbi := &blockchainmocks.Plugin{}
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
 	ns := "ns1"
-	_, err := newAggregator(ctx, ns, mdi, mbi, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
+	_, err := newAggregator(ctx, ns, mdi, mbi, nil, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
 	assert.NoError(t, err)
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
@@ -213,7 +219,7 @@ func TestCacheInitFail(t *testing.T) {
 	mbi := &blockchainmocks.Plugin{}
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
 	ns := "ns1"
-	_, err := newAggregator(ctx, ns, mdi, mbi, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
+	_, err := newAggregator(ctx, ns, mdi, mbi, nil, mpm, mdh, mim, mdm, newEventNotifier(ctx, "ut"), mmi, cmi)
 	assert.Equal(t, cacheInitError, err)
 }
 
@@ -305,7 +311,7 @@ func TestAggregationMaskedZeroNonceMatch(t *testing.T) {
 	})).Return(nil).Once()
 	// Validate the message is ok
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, batch.Payload.Messages[0].Header.ID, data.CRORequirePins).Return(batch.Payload.Messages[0], core.DataArray{}, true, nil)
-	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(true, nil)
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{Valid: true}, nil)
 	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, mock.Anything, core.MessageStateConfirmed, mock.Anything, "").Return()
 	// Insert the confirmed event
 	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(e *core.Event) bool {
@@ -423,7 +429,7 @@ func TestAggregationMaskedNextSequenceMatch(t *testing.T) {
 	}, nil).Once()
 	// Validate the message is ok
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, batch.Payload.Messages[0].Header.ID, data.CRORequirePins).Return(batch.Payload.Messages[0], core.DataArray{}, true, nil)
-	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(true, nil)
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{Valid: true}, nil)
 	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, mock.Anything, core.MessageStateConfirmed, mock.Anything, "").Return()
 	// Insert the confirmed event
 	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(e *core.Event) bool {
@@ -515,7 +521,7 @@ func TestAggregationBroadcast(t *testing.T) {
 	ag.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
 	// Validate the message is ok
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, batch.Payload.Messages[0].Header.ID, data.CRORequirePublicBlobRefs).Return(batch.Payload.Messages[0], core.DataArray{}, true, nil)
-	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(true, nil)
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{Valid: true}, nil)
 	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, mock.Anything, core.MessageStateConfirmed, mock.Anything, "").Return()
 	// Insert the confirmed event
 	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(e *core.Event) bool {
@@ -602,7 +608,7 @@ func TestAggregationMigratedBroadcast(t *testing.T) {
 	ag.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
 	// Validate the message is ok
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, batch.Payload.Messages[0].Header.ID, data.CRORequirePublicBlobRefs).Return(batch.Payload.Messages[0], core.DataArray{}, true, nil)
-	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(true, nil)
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{Valid: true}, nil)
 	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, mock.Anything, core.MessageStateConfirmed, mock.Anything, "").Return()
 	// Insert the confirmed event
 	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(e *core.Event) bool {
@@ -1043,6 +1049,94 @@ func TestProcessMsgWrongSigner(t *testing.T) {
 
 }
 
+func TestProcessMsgRevokedSigner(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	bs := newBatchState(&ag.aggregator)
+	org1 := newTestOrg("org1")
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			Topics:    fftypes.FFStringArray{"topic1"},
+			Namespace: "ns1",
+			SignerRef: core.SignerRef{
+				Author: org1.DID,
+				Key:    "0x12345",
+			},
+		},
+	}
+
+	ag.mim.ExpectedCalls = nil
+	ag.mim.On("FindIdentityForVerifier", ag.ctx, []core.IdentityType{core.IdentityTypeOrg, core.IdentityTypeCustom}, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	}).Return(org1, nil)
+	ag.mim.On("IsVerifierRevokedAtPin", ag.ctx, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	}, int64(12345)).Return(true, nil)
+	ag.mdm.On("GetMessageWithDataCached", ag.ctx, mock.Anything, data.CRORequirePublicBlobRefs).Return(msg, nil, true, nil)
+	ag.mdi.On("GetPins", ag.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, msg.Header.ID, core.MessageStateRejected, mock.Anything, mock.Anything).Return()
+	ag.mdi.On("UpdateMessages", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	ag.mdi.On("UpdatePins", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageRejected
+	})).Return(nil)
+	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeRevokedSignerRejected && event.Topic == core.SystemTopicSecurity && event.Reference.Equals(msg.Header.ID)
+	})).Return(nil)
+
+	err := ag.processMessage(ag.ctx, &core.BatchManifest{
+		ID: fftypes.NewUUID(),
+	}, &core.Pin{Masked: false, Sequence: 12345, Signer: "0x12345"}, 10, &core.MessageManifestEntry{
+		MessageRef: core.MessageRef{
+			ID:   msg.Header.ID,
+			Hash: msg.Hash,
+		},
+		Topics: len(msg.Header.Topics),
+	}, &core.BatchPersisted{}, bs)
+	assert.NoError(t, err)
+
+	err = bs.RunFinalize(ag.ctx)
+	assert.NoError(t, err)
+
+	ag.mim.AssertExpectations(t)
+	ag.mdi.AssertExpectations(t)
+}
+
+func TestProcessMsgRevokedSignerCheckFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	org1 := newTestOrg("org1")
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Topics: fftypes.FFStringArray{"topic1"},
+			SignerRef: core.SignerRef{
+				Author: org1.DID,
+				Key:    "0x12345",
+			},
+		},
+	}
+
+	ag.mim.ExpectedCalls = nil
+	ag.mim.On("FindIdentityForVerifier", ag.ctx, mock.Anything, mock.Anything).Return(org1, nil)
+	ag.mim.On("IsVerifierRevokedAtPin", ag.ctx, mock.Anything, int64(12345)).Return(false, fmt.Errorf("pop"))
+	ag.mdm.On("GetMessageWithDataCached", ag.ctx, mock.Anything, data.CRORequirePublicBlobRefs).Return(msg, nil, true, nil)
+
+	err := ag.processMessage(ag.ctx, &core.BatchManifest{},
+		&core.Pin{Masked: false, Sequence: 12345, Signer: "0x12345"},
+		10, &core.MessageManifestEntry{},
+		&core.BatchPersisted{},
+		newBatchState(&ag.aggregator))
+	assert.EqualError(t, err, "pop")
+
+	ag.mim.AssertExpectations(t)
+}
+
 func TestProcessMsgFailFindIdentity(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
@@ -1565,7 +1659,7 @@ func TestReadyForDispatchFailValidateData(t *testing.T) {
 	defer ag.cleanup(t)
 
 	org1 := newTestOrg("org1")
-	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(false, fmt.Errorf("pop"))
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(nil, fmt.Errorf("pop"))
 
 	_, _, err := ag.readyForDispatch(ag.ctx, &core.Message{
 		Header: core.MessageHeader{ID: fftypes.NewUUID(), SignerRef: core.SignerRef{Key: "0x12345", Author: org1.DID}},
@@ -1577,6 +1671,140 @@ func TestReadyForDispatchFailValidateData(t *testing.T) {
 
 }
 
+func TestReadyForDispatchInvalidDataEnforce(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	org1 := newTestOrg("org1")
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{
+		Policy: core.ValidationPolicyEnforce,
+		Reason: "bad data",
+	}, nil)
+
+	action, _, err := ag.readyForDispatch(ag.ctx, &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID(), SignerRef: core.SignerRef{Key: "0x12345", Author: org1.DID}},
+		Data: core.DataRefs{
+			{ID: fftypes.NewUUID()},
+		},
+	}, core.DataArray{}, nil, &batchState{})
+	assert.Equal(t, core.ActionReject, action)
+	assert.Regexp(t, "FF10537.*bad data", err)
+}
+
+func TestReadyForDispatchInvalidDataQuarantine(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	bs := newBatchState(&ag.aggregator)
+
+	org1 := newTestOrg("org1")
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{
+		Policy: core.ValidationPolicyQuarantine,
+		Reason: "bad data",
+	}, nil)
+	msg := &core.Message{
+		Header:  core.MessageHeader{ID: fftypes.NewUUID(), SignerRef: core.SignerRef{Key: "0x12345", Author: org1.DID}},
+		BatchID: fftypes.NewUUID(),
+		Data: core.DataRefs{
+			{ID: fftypes.NewUUID()},
+		},
+	}
+	ag.mdi.On("InsertQuarantineEntry", ag.ctx, mock.MatchedBy(func(entry *core.QuarantineEntry) bool {
+		return entry.Reason == core.QuarantineReasonDataInvalid && entry.Detail == "bad data" &&
+			entry.Message.Equals(msg.Header.ID) && entry.Batch.Equals(msg.BatchID)
+	})).Return(nil)
+
+	action, _, err := ag.readyForDispatch(ag.ctx, msg, core.DataArray{}, nil, bs)
+	assert.Equal(t, core.ActionQuarantine, action)
+	assert.Regexp(t, "FF10537.*bad data", err)
+
+	err = bs.RunFinalize(ag.ctx)
+	assert.NoError(t, err)
+}
+
+func TestReadyForDispatchInvalidDataWarn(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	bs := newBatchState(&ag.aggregator)
+
+	org1 := newTestOrg("org1")
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{
+		Policy: core.ValidationPolicyWarn,
+		Reason: "bad data",
+	}, nil)
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			CID:       fftypes.NewUUID(),
+			SignerRef: core.SignerRef{Key: "0x12345", Author: org1.DID},
+			Topics:    fftypes.FFStringArray{"topic1", "topic2"},
+		},
+		Data: core.DataRefs{
+			{ID: fftypes.NewUUID()},
+		},
+	}
+	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageValidationWarning && event.Reference.Equals(msg.Header.ID) &&
+			event.Correlator.Equals(msg.Header.CID) && event.Topic == "topic1"
+	})).Return(nil).Once()
+	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageValidationWarning && event.Topic == "topic2"
+	})).Return(nil).Once()
+
+	action, _, err := ag.readyForDispatch(ag.ctx, msg, core.DataArray{}, nil, bs)
+	assert.Equal(t, core.ActionConfirm, action)
+	assert.NoError(t, err)
+
+	err = bs.RunFinalize(ag.ctx)
+	assert.NoError(t, err)
+}
+
+func TestRecordValidationWarningFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	bs := newBatchState(&ag.aggregator)
+
+	ag.mdi.On("InsertEvent", ag.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	ag.recordValidationWarning(&core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			Topics: fftypes.FFStringArray{"topic1"},
+		},
+	}, nil, bs)
+	err := bs.RunFinalize(ag.ctx)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestCompleteDispatchQuarantine(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+	bs := newBatchState(&ag.aggregator)
+	org1 := newTestOrg("org1")
+
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:        fftypes.NewUUID(),
+			SignerRef: core.SignerRef{Key: "0x12345", Author: org1.DID},
+			Topics:    fftypes.FFStringArray{"topic1"},
+		},
+		RejectReason: "bad data",
+	}
+
+	ag.mdi.On("InsertEvent", ag.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageQuarantined && event.Reference.Equals(msg.Header.ID)
+	})).Return(nil)
+	ag.mdm.On("UpdateMessageStateIfCached", ag.ctx, msg.Header.ID, core.MessageStateQuarantined, mock.Anything, "bad data").Return()
+	ag.mdi.On("UpdateMessages", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+	ag.mdi.On("UpdatePins", ag.ctx, "ns1", mock.Anything, mock.Anything).Return(nil)
+
+	newState := ag.completeDispatch(core.ActionQuarantine, nil, msg, nil, bs)
+	assert.Equal(t, core.MessageStateQuarantined, newState)
+
+	bs.markMessageDispatched(fftypes.NewUUID(), msg, 0, newState)
+	err := bs.RunFinalize(ag.ctx)
+	assert.NoError(t, err)
+}
+
 func TestReadyForDispatchMissingBlobs(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
@@ -1857,7 +2085,7 @@ func TestDispatchPrivateQueuesLaterDispatch(t *testing.T) {
 	data1 := core.DataArray{}
 	data2 := core.DataArray{{Namespace: "ns1", Blob: &core.BlobRef{Hash: fftypes.NewRandB32()}}}
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, msg1.Header.ID, data.CRORequirePins).Return(msg1, data1, true, nil).Once()
-	ag.mdm.On("ValidateAll", ag.ctx, data1).Return(true, nil)
+	ag.mdm.On("ValidateAll", ag.ctx, data1).Return(&data.DataValidation{Valid: true}, nil)
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, msg2.Header.ID, data.CRORequirePins).Return(msg2, data2, true, nil).Once()
 
 	initNPG := &nextPinGroupState{topic: "topic1", groupID: groupID}
@@ -1900,7 +2128,7 @@ func TestDispatchPrivateNextPinIncremented(t *testing.T) {
 
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, msg1.Header.ID, data.CRORequirePins).Return(msg1, core.DataArray{}, true, nil).Once()
 	ag.mdm.On("GetMessageWithDataCached", ag.ctx, msg2.Header.ID, data.CRORequirePins).Return(msg2, core.DataArray{}, true, nil).Once()
-	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(true, nil)
+	ag.mdm.On("ValidateAll", ag.ctx, mock.Anything).Return(&data.DataValidation{Valid: true}, nil)
 
 	initNPG := &nextPinGroupState{topic: "topic1", groupID: groupID}
 	member1NonceOne := initNPG.calcPinHash(org1.DID, 1)
@@ -2002,6 +2230,66 @@ func TestDefinitionBroadcastRejectSignerLookupWrongOrg(t *testing.T) {
 
 }
 
+func TestBroadcastDelegatedSigner(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	msg1, _, _, _ := newTestManifest(core.MessageTypeBroadcast, nil)
+
+	ag.mim.On("FindIdentityForVerifier", ag.ctx, mock.Anything, mock.Anything).Return(newTestOrg("org2"), nil)
+	ag.mim.On("IsDelegated", ag.ctx, msg1.Header.Author, "did:firefly:org/org2", core.DelegationScopeMessages, msg1.Header.Created).Return(true, nil)
+
+	action, err := ag.checkOnchainConsistency(ag.ctx, msg1, &core.Pin{Signer: "0x12345"})
+	assert.NoError(t, err)
+	assert.Equal(t, core.ActionConfirm, action)
+
+}
+
+func TestPrivateNotDelegatedSigner(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	msg1, _, _, _ := newTestManifest(core.MessageTypePrivate, nil)
+
+	ag.mim.On("FindIdentityForVerifier", ag.ctx, mock.Anything, mock.Anything).Return(newTestOrg("org2"), nil)
+	ag.mim.On("IsDelegated", ag.ctx, msg1.Header.Author, "did:firefly:org/org2", core.DelegationScopeMessages, msg1.Header.Created).Return(false, nil)
+
+	action, err := ag.checkOnchainConsistency(ag.ctx, msg1, &core.Pin{Signer: "0x12345"})
+	assert.Equal(t, core.ActionReject, action)
+	assert.Regexp(t, "FF10453", err)
+
+}
+
+func TestBroadcastSignerNoAuthor(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	msg1, _, _, _ := newTestManifest(core.MessageTypeBroadcast, nil)
+	msg1.Header.Author = ""
+
+	ag.mim.On("FindIdentityForVerifier", ag.ctx, mock.Anything, mock.Anything).Return(newTestOrg("org2"), nil)
+
+	action, err := ag.checkOnchainConsistency(ag.ctx, msg1, &core.Pin{Signer: "0x12345"})
+	assert.Equal(t, core.ActionReject, action)
+	assert.Regexp(t, "FF10453", err)
+
+}
+
+func TestBroadcastDelegatedSignerLookupFail(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	msg1, _, _, _ := newTestManifest(core.MessageTypeBroadcast, nil)
+
+	ag.mim.On("FindIdentityForVerifier", ag.ctx, mock.Anything, mock.Anything).Return(newTestOrg("org2"), nil)
+	ag.mim.On("IsDelegated", ag.ctx, msg1.Header.Author, "did:firefly:org/org2", core.DelegationScopeMessages, msg1.Header.Created).Return(false, fmt.Errorf("pop"))
+
+	action, err := ag.checkOnchainConsistency(ag.ctx, msg1, &core.Pin{Signer: "0x12345"})
+	assert.Equal(t, core.ActionRetry, action)
+	assert.EqualError(t, err, "pop")
+
+}
+
 func TestDefinitionBroadcastParkUnregisteredSignerIdentity(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
@@ -2351,6 +2639,31 @@ func TestExtractManifestBadVersion(t *testing.T) {
 	assert.Nil(t, manifest)
 }
 
+func TestExtractManifestCompressed(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	manifest := ag.extractManifest(ag.ctx, &core.BatchPersisted{
+		Manifest: fftypes.JSONAnyPtr(`{"version":2,"compression":"gzip"}`),
+	})
+
+	assert.Equal(t, core.BatchCompressionGzip, manifest.Compression)
+}
+
+func TestExtractManifestEntryDetails(t *testing.T) {
+	ag := newTestAggregator()
+	defer ag.cleanup(t)
+
+	manifest := ag.extractManifest(ag.ctx, &core.BatchPersisted{
+		Manifest: fftypes.JSONAnyPtr(`{"version":3,"messages":[{"topics":1,"size":1024}],"data":[{"contentType":"image/png","size":24,"blob":{"size":1000}}]}`),
+	})
+
+	assert.Equal(t, core.ManifestVersion3, manifest.Version)
+	assert.Equal(t, int64(1024), manifest.Messages[0].Size)
+	assert.Equal(t, "image/png", manifest.Data[0].ContentType)
+	assert.Equal(t, int64(1000), manifest.Data[0].Blob.Size)
+}
+
 func TestMigrateManifestFail(t *testing.T) {
 	ag := newTestAggregator()
 	defer ag.cleanup(t)
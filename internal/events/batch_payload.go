@@ -0,0 +1,69 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// AttachBatchPayload stores the payload of a batch that has been pinned, but whose payload was not
+// distributed via shared storage. The payload must match the hash in the pins we have received, and
+// is then processed exactly as if it had been downloaded from shared storage.
+func (em *eventManager) AttachBatchPayload(ctx context.Context, batch *core.Batch) (*core.BatchPersisted, error) {
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := em.database.GetPins(ctx, em.namespace.Name, fb.Eq("batch", batch.ID))
+	if err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchPayloadNotPinned, batch.ID)
+	}
+	for _, pin := range pins {
+		if !pin.BatchHash.Equals(batch.Hash) {
+			return nil, i18n.NewError(ctx, coremsgs.MsgBatchPayloadHashMismatch, batch.Hash, pin.BatchHash)
+		}
+	}
+
+	if batch.Namespace != em.namespace.NetworkName {
+		log.L(ctx).Errorf("Batch payload '%s' is for a different namespace '%s'", batch.ID, batch.Namespace)
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchPayloadInvalid, batch.ID)
+	}
+	batch.Namespace = em.namespace.Name
+
+	var persisted *core.BatchPersisted
+	var valid bool
+	err = em.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		persisted, valid, err = em.persistBatch(ctx, batch)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchPayloadInvalid, batch.ID)
+	}
+
+	// Rewind the aggregator to this batch - after the DB updates are complete
+	em.aggregator.queueBatchRewind(batch.ID)
+	return persisted, nil
+}
@@ -0,0 +1,130 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAttachBatchPayloadOk(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batch.ID, BatchHash: batch.Hash},
+	}, nil, nil)
+	em.mdi.On("InsertOrGetBatch", em.ctx, mock.Anything).Return(nil, nil)
+	em.mdi.On("InsertDataArray", em.ctx, mock.Anything).Return(nil, nil)
+	em.mdi.On("InsertMessages", em.ctx, mock.Anything, mock.AnythingOfType("database.PostCompletionHook")).Return(nil, nil).Run(func(args mock.Arguments) {
+		args[2].(database.PostCompletionHook)()
+	})
+	em.mdm.On("UpdateMessageCache", mock.Anything, mock.Anything).Return()
+	em.mim.On("GetLocalNode", mock.Anything).Return(testNode, nil)
+
+	bp, err := em.AttachBatchPayload(em.ctx, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, batch.ID, bp.ID)
+	assert.Equal(t, "ns1", bp.Namespace)
+
+	brw := <-em.aggregator.rewinder.rewindRequests
+	assert.Equal(t, *batch.ID, brw.uuid)
+}
+
+func TestAttachBatchPayloadGetPinsFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := em.AttachBatchPayload(em.ctx, &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAttachBatchPayloadNotPinned(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	_, err := em.AttachBatchPayload(em.ctx, &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}})
+	assert.Regexp(t, "FF10532", err)
+}
+
+func TestAttachBatchPayloadHashMismatch(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	batch := &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}, Hash: fftypes.NewRandB32()}
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batch.ID, BatchHash: fftypes.NewRandB32()},
+	}, nil, nil)
+
+	_, err := em.AttachBatchPayload(em.ctx, batch)
+	assert.Regexp(t, "FF10533", err)
+}
+
+func TestAttachBatchPayloadWrongNamespace(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	batch := &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Namespace: "ns2"}, Hash: fftypes.NewRandB32()}
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batch.ID, BatchHash: batch.Hash},
+	}, nil, nil)
+
+	_, err := em.AttachBatchPayload(em.ctx, batch)
+	assert.Regexp(t, "FF10534", err)
+}
+
+func TestAttachBatchPayloadInvalid(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
+
+	batch := &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Namespace: "ns1"}, Hash: fftypes.NewRandB32()}
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batch.ID, BatchHash: batch.Hash},
+	}, nil, nil)
+
+	_, err := em.AttachBatchPayload(em.ctx, batch)
+	assert.Regexp(t, "FF10534", err)
+}
+
+func TestAttachBatchPayloadPersistFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	em.mdi.On("GetPins", em.ctx, "ns1", mock.Anything).Return([]*core.Pin{
+		{Batch: batch.ID, BatchHash: batch.Hash},
+	}, nil, nil)
+	em.mdi.On("InsertOrGetBatch", em.ctx, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.AttachBatchPayload(em.ctx, batch)
+	assert.EqualError(t, err, "pop")
+}
@@ -69,6 +69,9 @@ func (em *eventManager) handleBlockchainBatchPinEvent(ctx context.Context, event
 	bc.addEventToInsert(chainEvent, em.getTopicForChainListener(nil))
 	bc.postInsert = append(bc.postInsert, func() error {
 		em.emitBlockchainEventMetric(&batchPin.Event)
+		if em.metrics.IsMetricsEnabled() {
+			em.metrics.BatchPinConfirmed(batchPin.BatchID)
+		}
 		return em.postBlockchainBatchPinEventInsert(ctx, event)
 	})
 	return nil
@@ -88,9 +91,10 @@ func (em *eventManager) postBlockchainBatchPinEventInsert(ctx context.Context, e
 	if err != nil {
 		return err
 	}
-	// Kick off a download for broadcast batches if the batch isn't already persisted
-	if !private && batch == nil {
-		if err := em.sharedDownload.InitiateDownloadBatch(ctx, batchPin.TransactionID, batchPin.BatchPayloadRef, false /* batch processing does not currently use idempotency keys */); err != nil {
+	// Kick off a download for broadcast batches if the batch isn't already persisted.
+	// Pin-only broadcasts have no payload in shared storage, so wait for the payload to be attached
+	if !private && batch == nil && batchPin.BatchPayloadRef != core.BatchPayloadRefPinOnly {
+		if err := em.sharedDownload.InitiateDownloadBatch(ctx, batchPin.TransactionID, batchPin.BatchID, batchPin.BatchHash, batchPin.BatchPayloadRef, false /* batch processing does not currently use idempotency keys */); err != nil {
 			return err
 		}
 	}
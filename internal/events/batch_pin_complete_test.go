@@ -126,7 +126,7 @@ func TestBatchPinCompleteOkBroadcast(t *testing.T) {
 	})).Return(nil).Once()
 	em.mdi.On("InsertPins", mock.Anything, mock.Anything).Return(nil).Once()
 	em.mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
-	em.msd.On("InitiateDownloadBatch", mock.Anything, batchPin.TransactionID, batchPin.BatchPayloadRef, false).Return(nil)
+	em.msd.On("InitiateDownloadBatch", mock.Anything, batchPin.TransactionID, batchPin.BatchID, batchPin.BatchHash, batchPin.BatchPayloadRef, false).Return(nil)
 
 	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
 		{
@@ -145,6 +145,52 @@ func TestBatchPinCompleteOkBroadcast(t *testing.T) {
 
 }
 
+func TestBatchPinCompleteOkBroadcastPinOnly(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batchPin := &blockchain.BatchPin{
+		TransactionID:   batch.Payload.TX.ID,
+		BatchID:         batch.ID,
+		BatchHash:       batch.Hash,
+		BatchPayloadRef: core.BatchPayloadRefPinOnly,
+		Contexts:        []*fftypes.Bytes32{fftypes.NewRandB32()},
+		Event: blockchain.Event{
+			Name:           "BatchPin",
+			BlockchainTXID: "0x12345",
+			ProtocolID:     "10/20/30",
+		},
+	}
+
+	em.mth.On("PersistTransaction", mock.Anything, batchPin.TransactionID, core.TransactionTypeBatchPin, "0x12345").
+		Return(true, nil)
+	em.mth.On("InsertNewBlockchainEvents", mock.Anything, mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil).Once()
+	em.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil).Once()
+	em.mdi.On("InsertPins", mock.Anything, mock.MatchedBy(func(pins []*core.Pin) bool {
+		return len(pins) == 1 && !pins[0].Masked
+	})).Return(nil).Once()
+	em.mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type: blockchain.EventTypeBatchPinComplete,
+			BatchPinComplete: &blockchain.BatchPinCompleteEvent{
+				Namespace: "ns1",
+				Batch:     batchPin,
+				SigningKey: &core.VerifierRef{
+					Type:  core.VerifierTypeEthAddress,
+					Value: "0x12345",
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	em.msd.AssertNotCalled(t, "InitiateDownloadBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestBatchPinCompleteOkBroadcastExistingBatch(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -215,7 +261,7 @@ func TestBatchPinCompleteOkBroadcastExistingBatch(t *testing.T) {
 }
 
 func TestBatchPinCompleteOkPrivate(t *testing.T) {
-	em := newTestEventManager(t)
+	em := newTestEventManagerWithMetrics(t)
 	defer em.cleanup(t)
 
 	batchPin := &blockchain.BatchPin{
@@ -236,6 +282,7 @@ func TestBatchPinCompleteOkPrivate(t *testing.T) {
 	em.mth.On("InsertNewBlockchainEvents", mock.Anything, mock.Anything).Return([]*core.BlockchainEvent{{ID: fftypes.NewUUID()}}, nil)
 	em.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
 	em.mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
+	em.mmi.On("BatchPinConfirmed", batchPin.BatchID).Return()
 
 	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
 		{
@@ -369,7 +416,7 @@ func TestSequencedBroadcastInitiateDownloadFail(t *testing.T) {
 	em.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
 	em.mdi.On("InsertPins", mock.Anything, mock.Anything).Return(nil)
 	em.mdi.On("GetBatchByID", mock.Anything, "ns1", mock.Anything).Return(nil, nil)
-	em.msd.On("InitiateDownloadBatch", mock.Anything, batchPin.TransactionID, batchPin.BatchPayloadRef, false).Return(fmt.Errorf("pop"))
+	em.msd.On("InitiateDownloadBatch", mock.Anything, batchPin.TransactionID, batchPin.BatchID, batchPin.BatchHash, batchPin.BatchPayloadRef, false).Return(fmt.Errorf("pop"))
 
 	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
 		{
@@ -467,6 +514,7 @@ func TestBatchPinCompleteNonMultiparty(t *testing.T) {
 func TestPersistBatchMissingID(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batch, valid, err := em.persistBatch(context.Background(), &core.Batch{})
 	assert.False(t, valid)
 	assert.Nil(t, batch)
@@ -476,6 +524,7 @@ func TestPersistBatchMissingID(t *testing.T) {
 func TestPersistBatchAuthorResolveFail(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batchHash := fftypes.NewRandB32()
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
@@ -502,6 +551,7 @@ func TestPersistBatchAuthorResolveFail(t *testing.T) {
 func TestPersistBatchBadAuthor(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batchHash := fftypes.NewRandB32()
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
@@ -528,6 +578,7 @@ func TestPersistBatchBadAuthor(t *testing.T) {
 func TestPersistBatchMismatchChainHash(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
 			ID: fftypes.NewUUID(),
@@ -553,6 +604,7 @@ func TestPersistBatchMismatchChainHash(t *testing.T) {
 func TestPersistBatchBadHash(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
 	batch.Hash = fftypes.NewRandB32()
@@ -566,6 +618,7 @@ func TestPersistBatchBadHash(t *testing.T) {
 func TestPersistBatchNoData(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
 			ID: fftypes.NewUUID(),
@@ -606,6 +659,7 @@ func TestPersistBatchUpsertBatchFail(t *testing.T) {
 func TestPersistBatchSwallowBadData(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
 			ID: fftypes.NewUUID(),
@@ -674,6 +728,7 @@ func TestPersistBatchGoodDataMessageFail(t *testing.T) {
 func TestPersistBatchGoodMessageAuthorMismatch(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
 	batch.Payload.Messages[0].Header.Key = "0x9999999"
@@ -692,6 +747,7 @@ func TestPersistBatchGoodMessageAuthorMismatch(t *testing.T) {
 func TestPersistBatchDataNilData(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
 			ID: fftypes.NewUUID(),
@@ -700,21 +756,24 @@ func TestPersistBatchDataNilData(t *testing.T) {
 	data := &core.Data{
 		ID: fftypes.NewUUID(),
 	}
-	valid := em.validateBatchData(context.Background(), batch, 0, data)
+	valid, err := em.validateBatchData(context.Background(), batch, 0, data)
 	assert.False(t, valid)
+	assert.NoError(t, err)
 }
 
 func TestPersistBatchDataBadHash(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	data := &core.Data{
 		ID:    fftypes.NewUUID(),
 		Value: fftypes.JSONAnyPtr(`"test"`),
 	}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
 	batch.Payload.Data[0].Hash = fftypes.NewRandB32()
-	valid := em.validateBatchData(context.Background(), batch, 0, data)
+	valid, err := em.validateBatchData(context.Background(), batch, 0, data)
 	assert.False(t, valid)
+	assert.NoError(t, err)
 }
 
 func TestPersistBatchDataOk(t *testing.T) {
@@ -724,8 +783,9 @@ func TestPersistBatchDataOk(t *testing.T) {
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
 
-	valid := em.validateBatchData(context.Background(), batch, 0, data)
+	valid, err := em.validateBatchData(context.Background(), batch, 0, data)
 	assert.True(t, valid)
+	assert.NoError(t, err)
 }
 
 func TestPersistBatchDataWithPublicAlreaydDownloadedOk(t *testing.T) {
@@ -769,7 +829,7 @@ func TestPersistBatchDataWithPublicInitiateDownload(t *testing.T) {
 
 	em.mdi.On("GetBlobs", mock.Anything, mock.Anything, mock.Anything).Return([]*core.Blob{}, nil, nil)
 
-	em.msd.On("InitiateDownloadBlob", mock.Anything, batch.Payload.TX.ID, data.ID, "ref1", false).Return(nil)
+	em.msd.On("InitiateDownloadBlob", mock.Anything, batch.Payload.TX.ID, data.ID, data.Blob.Hash, "ref1", false).Return(nil)
 
 	valid, err := em.checkAndInitiateBlobDownloads(context.Background(), batch, 0, data)
 	assert.Nil(t, err)
@@ -794,7 +854,7 @@ func TestPersistBatchDataWithPublicInitiateDownloadFail(t *testing.T) {
 
 	em.mdi.On("GetBlobs", mock.Anything, mock.Anything, mock.Anything).Return([]*core.Blob{}, nil, nil)
 
-	em.msd.On("InitiateDownloadBlob", mock.Anything, batch.Payload.TX.ID, data.ID, "ref1", false).Return(fmt.Errorf("pop"))
+	em.msd.On("InitiateDownloadBlob", mock.Anything, batch.Payload.TX.ID, data.ID, data.Blob.Hash, "ref1", false).Return(fmt.Errorf("pop"))
 
 	valid, err := em.checkAndInitiateBlobDownloads(context.Background(), batch, 0, data)
 	assert.Regexp(t, "pop", err)
@@ -827,6 +887,7 @@ func TestPersistBatchDataWithBlobGetBlobFail(t *testing.T) {
 func TestPersistBatchMessageNilData(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
 			ID: fftypes.NewUUID(),
@@ -837,8 +898,9 @@ func TestPersistBatchMessageNilData(t *testing.T) {
 			ID: fftypes.NewUUID(),
 		},
 	}
-	valid := em.validateBatchMessage(context.Background(), batch, 0, msg)
+	valid, err := em.validateBatchMessage(context.Background(), batch, 0, msg)
 	assert.False(t, valid)
+	assert.NoError(t, err)
 }
 
 func TestPersistBatchMessageOK(t *testing.T) {
@@ -846,6 +908,7 @@ func TestPersistBatchMessageOK(t *testing.T) {
 	defer em.cleanup(t)
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{})
 
-	valid := em.validateBatchMessage(context.Background(), batch, 0, batch.Payload.Messages[0])
+	valid, err := em.validateBatchMessage(context.Background(), batch, 0, batch.Payload.Messages[0])
 	assert.True(t, valid)
+	assert.NoError(t, err)
 }
@@ -31,6 +31,7 @@ type eventBatchContext struct {
 	topicsByEventID         map[string]string
 	chainEventsToInsert     []*core.BlockchainEvent
 	postInsert              []func() error
+	checkpoints             map[string]*core.BlockchainCheckpoint
 }
 
 func (bc *eventBatchContext) addEventToInsert(event *core.BlockchainEvent, topic string) {
@@ -142,10 +143,24 @@ func (em *eventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch
 		bc := &eventBatchContext{
 			contractListenerResults: make(map[string]*core.ContractListener),
 			topicsByEventID:         make(map[string]string),
+			checkpoints:             make(map[string]*core.BlockchainCheckpoint),
 		}
 		return true, em.database.RunAsGroup(em.ctx, func(ctx context.Context) error {
 			// Process the events, generating the optimized list of event inserts
 			for _, event := range batch {
+				replayed, err := em.checkpointBlockchainEvent(ctx, event, bc)
+				if err != nil {
+					return err
+				}
+				if replayed {
+					continue
+				}
+				// A scheduled switch to the next FireFly contract happens on the first event from its activation block
+				if em.multiparty != nil {
+					if err := em.multiparty.CheckContractMigration(ctx, dispatchedChainEvent(event)); err != nil {
+						return err
+					}
+				}
 				switch event.Type {
 				case blockchain.EventTypeForListener:
 					if err := em.handleBlockchainEventForListener(ctx, event.ForListener, bc); err != nil {
@@ -173,11 +188,58 @@ func (em *eventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch
 					return err
 				}
 			}
+			// Move the checkpoints on, in the same DB transaction as the events they cover
+			for _, checkpoint := range bc.checkpoints {
+				if err := em.database.UpsertBlockchainCheckpoint(ctx, checkpoint); err != nil {
+					return err
+				}
+			}
 			return nil
 		})
 	})
 }
 
+func dispatchedChainEvent(event *blockchain.EventToDispatch) *blockchain.Event {
+	switch {
+	case event.ForListener != nil:
+		return event.ForListener.Event
+	case event.BatchPinComplete != nil && event.BatchPinComplete.Batch != nil:
+		return &event.BatchPinComplete.Batch.Event
+	case event.NetworkAction != nil:
+		return event.NetworkAction.Event
+	default:
+		return nil
+	}
+}
+
+// checkpointBlockchainEvent checks events that carry a checkpoint against the last one recorded for their subscription,
+// returning true for events that have been replayed by the connector
+func (em *eventManager) checkpointBlockchainEvent(ctx context.Context, event *blockchain.EventToDispatch, bc *eventBatchContext) (bool, error) {
+	chainEvent := dispatchedChainEvent(event)
+	if chainEvent == nil || chainEvent.Checkpoint == nil {
+		return false, nil
+	}
+	checkpoint := *chainEvent.Checkpoint
+	checkpoint.Namespace = em.namespace.Name
+	key := checkpoint.Ledger + "/" + checkpoint.Subscription
+	last, ok := bc.checkpoints[key]
+	if !ok {
+		var err error
+		if last, err = em.database.GetBlockchainCheckpoint(ctx, checkpoint.Namespace, checkpoint.Ledger, checkpoint.Subscription); err != nil {
+			return false, err
+		}
+		if last != nil {
+			bc.checkpoints[key] = last
+		}
+	}
+	if checkpoint.IsReplay(last) {
+		log.L(ctx).Infof("Ignoring replayed blockchain event '%s' from subscription '%s' on '%s'", chainEvent.ProtocolID, checkpoint.Subscription, checkpoint.Ledger)
+		return true, nil
+	}
+	bc.checkpoints[key] = &checkpoint
+	return false, nil
+}
+
 func (em *eventManager) handleBlockchainEventForListener(ctx context.Context, event *blockchain.EventForListener, bc *eventBatchContext) error {
 	listener, err := em.getChainListenerByProtocolIDCached(ctx, event.ListenerID, bc)
 	if err != nil {
@@ -196,6 +258,7 @@ func (em *eventManager) handleBlockchainEventForListener(ctx context.Context, ev
 	chainEvent := buildBlockchainEvent(listener.Namespace, listener.ID, event.Event, &core.BlockchainTransactionRef{
 		BlockchainID: event.BlockchainTXID,
 	})
+	chainEvent.Output = em.decodeListenerEventOutput(ctx, listener, event.Event)
 	bc.addEventToInsert(chainEvent, em.getTopicForChainListener(listener))
 	em.emitBlockchainEventMetric(event.Event)
 	return nil
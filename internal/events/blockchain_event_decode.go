@@ -0,0 +1,59 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// eventDecoderCacheKey identifies a decoder by the signature of the event, and a fingerprint of
+// the ABI and mappings of the listener - so listeners with identical definitions share a decoder,
+// and a listener that is recreated with a different definition never picks up a stale one
+func eventDecoderCacheKey(signature string, listener *core.ContractListener) string {
+	b, _ := json.Marshal([]interface{}{listener.Event, listener.Options.Mappings})
+	hash := sha256.Sum256(b)
+	return "decoder_" + signature + "_" + hex.EncodeToString(hash[:])
+}
+
+// decodeListenerEventOutput decodes the output of an event delivered to a listener, reusing the
+// decoder for any previous event with the same signature and ABI
+func (em *eventManager) decodeListenerEventOutput(ctx context.Context, listener *core.ContractListener, event *blockchain.Event) fftypes.JSONObject {
+	if listener.Options == nil || len(listener.Options.Mappings) == 0 {
+		return event.Output
+	}
+	cacheKey := eventDecoderCacheKey(event.Signature, listener)
+	decoder, ok := em.eventDecoderCache.Get(cacheKey).(*eventDecoder)
+	if ok {
+		if em.metrics.IsMetricsEnabled() {
+			em.metrics.BlockchainEventDecodeCacheHit(event.Signature)
+		}
+	} else {
+		if em.metrics.IsMetricsEnabled() {
+			em.metrics.BlockchainEventDecodeCacheMiss(event.Signature)
+		}
+		decoder = newEventDecoder(listener)
+		em.eventDecoderCache.Set(cacheKey, decoder)
+	}
+	return decoder.decode(ctx, listener.ID, event.Output)
+}
@@ -0,0 +1,83 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeListenerEventOutputCached(t *testing.T) {
+	em := newTestEventManagerWithMetrics(t)
+	defer em.cleanup(t)
+
+	signature := "Transfer(address,address,uint256)"
+	em.mmi.On("BlockchainEventDecodeCacheMiss", signature).Once()
+	em.mmi.On("BlockchainEventDecodeCacheHit", signature).Twice()
+
+	listener1 := testMappedListener(&core.ContractListenerMapping{Field: "value", Type: core.MappingTypeInteger})
+	listener2 := testMappedListener(&core.ContractListenerMapping{Field: "value", Type: core.MappingTypeInteger})
+	for _, l := range []*core.ContractListener{listener1, listener1, listener2} {
+		mapped := em.decodeListenerEventOutput(context.Background(), l, &blockchain.Event{
+			Signature: signature,
+			Output:    fftypes.JSONObject{"value": "0x10"},
+		})
+		assert.Equal(t, `{"value":16}`, mapped.String())
+	}
+}
+
+func TestDecodeListenerEventOutputABIChange(t *testing.T) {
+	em := newTestEventManagerWithMetrics(t)
+	defer em.cleanup(t)
+
+	signature := "Transfer(address,address,uint256)"
+	em.mmi.On("BlockchainEventDecodeCacheMiss", signature).Twice()
+
+	listener := testMappedListener(&core.ContractListenerMapping{Field: "value", Type: core.MappingTypeInteger})
+	event := &blockchain.Event{
+		Signature: signature,
+		Output:    fftypes.JSONObject{"value": "0x10"},
+	}
+	assert.Equal(t, `{"value":16}`, em.decodeListenerEventOutput(context.Background(), listener, event).String())
+
+	listener.Options.Mappings[0].Type = core.MappingTypeString
+	assert.Equal(t, `{"value":"0x10"}`, em.decodeListenerEventOutput(context.Background(), listener, event).String())
+}
+
+func TestDecodeListenerEventOutputNoMappings(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	output := fftypes.JSONObject{"a": "b"}
+	assert.Equal(t, output, em.decodeListenerEventOutput(context.Background(), &core.ContractListener{}, &blockchain.Event{Output: output}))
+}
+
+func TestDecodeListenerEventOutputNoMetrics(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	listener := testMappedListener(&core.ContractListenerMapping{Field: "sender", Path: "from"})
+	event := &blockchain.Event{Output: fftypes.JSONObject{"from": "0x1111"}}
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, `{"sender":"0x1111"}`, em.decodeListenerEventOutput(context.Background(), listener, event).String())
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// mapListenerEventOutput applies the mapping rules of a listener to the raw output of an event.
+// If any value cannot be coerced, the raw output is retained so that no data is lost.
+func mapListenerEventOutput(ctx context.Context, listener *core.ContractListener, output fftypes.JSONObject) fftypes.JSONObject {
+	return newEventDecoder(listener).decode(ctx, listener.ID, output)
+}
+
+type compiledMapping struct {
+	mapping  *core.ContractListenerMapping
+	segments []string
+}
+
+// eventDecoder holds everything parsed from a listener definition that is needed to decode
+// the output of each event it delivers, so that it can be reused across events
+type eventDecoder struct {
+	mappings []*compiledMapping
+}
+
+func newEventDecoder(listener *core.ContractListener) *eventDecoder {
+	d := &eventDecoder{}
+	if listener.Options == nil {
+		return d
+	}
+	for _, mapping := range listener.Options.Mappings {
+		path := mapping.Path
+		if path == "" {
+			path = mapping.Field
+		}
+		d.mappings = append(d.mappings, &compiledMapping{
+			mapping:  mapping,
+			segments: strings.Split(path, "."),
+		})
+	}
+	return d
+}
+
+func (d *eventDecoder) decode(ctx context.Context, listenerID *fftypes.UUID, output fftypes.JSONObject) fftypes.JSONObject {
+	if len(d.mappings) == 0 {
+		return output
+	}
+	mapped := fftypes.JSONObject{}
+	for _, cm := range d.mappings {
+		value, found := extractMappingValue(output, cm.segments)
+		if !found {
+			continue
+		}
+		coerced, err := coerceMappingValue(ctx, cm.mapping, value)
+		if err != nil {
+			log.L(ctx).Warnf("Retaining raw output of event for listener %s: %s", listenerID, err)
+			return output
+		}
+		mapped[cm.mapping.Field] = coerced
+	}
+	return mapped
+}
+
+func extractMappingValue(value interface{}, segments []string) (interface{}, bool) {
+	for _, segment := range segments {
+		switch v := value.(type) {
+		case fftypes.JSONObject:
+			value = map[string]interface{}(v)
+		case []interface{}:
+			idx, err := strconv.Atoi(segment)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, false
+			}
+			value = v[idx]
+			continue
+		}
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func coerceMappingValue(ctx context.Context, mapping *core.ContractListenerMapping, value interface{}) (interface{}, error) {
+	var coerced interface{}
+	var err error
+	switch mapping.Type {
+	case core.MappingTypeString:
+		coerced, err = coerceMappingString(value)
+	case core.MappingTypeInteger:
+		coerced, err = coerceMappingInteger(value)
+	case core.MappingTypeNumber:
+		coerced, err = coerceMappingNumber(value)
+	case core.MappingTypeBoolean:
+		coerced, err = coerceMappingBoolean(value)
+	default:
+		return value, nil
+	}
+	if err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgListenerMappingFailed, mapping.Field, mapping.Type, err)
+	}
+	return coerced, nil
+}
+
+func coerceMappingString(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
+
+func coerceMappingInteger(value interface{}) (interface{}, error) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		return json.Number(strconv.FormatInt(int64(v), 10)), nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v", v)
+	}
+	// Hex values are accepted, as emitted by EVM based chains
+	base := 10
+	if strings.HasPrefix(s, "0x") {
+		s = s[2:]
+		base = 16
+	}
+	i, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not an integer", s)
+	}
+	// Represented as a JSON number without loss of precision
+	return json.Number(i.String()), nil
+}
+
+func coerceMappingNumber(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return nil, fmt.Errorf("unsupported value %v", v)
+	}
+}
+
+func coerceMappingBoolean(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	case float64:
+		return v != 0, nil
+	case json.Number:
+		f, err := v.Float64()
+		return f != 0, err
+	default:
+		return nil, fmt.Errorf("unsupported value %v", v)
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testMappedListener(mappings ...*core.ContractListenerMapping) *core.ContractListener {
+	return &core.ContractListener{
+		Namespace: "ns1",
+		ID:        fftypes.NewUUID(),
+		Options: &core.ContractListenerOptions{
+			Mappings: mappings,
+		},
+	}
+}
+
+func TestContractEventMapped(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	ev := &blockchain.EventForListener{
+		ListenerID: "sb-1",
+		Event: &blockchain.Event{
+			BlockchainTXID: "0xabcd1234",
+			ProtocolID:     "10/20/30",
+			Name:           "Transfer",
+			Output: fftypes.JSONObject{
+				"from":  "0x1111",
+				"value": "0x10",
+			},
+		},
+	}
+	sub := testMappedListener(
+		&core.ContractListenerMapping{Field: "sender", Path: "from"},
+		&core.ContractListenerMapping{Field: "value", Type: core.MappingTypeInteger},
+	)
+
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(sub, nil)
+	em.mth.On("InsertNewBlockchainEvents", mock.Anything, mock.MatchedBy(func(events []*core.BlockchainEvent) bool {
+		return len(events) == 1 && events[0].Output.String() == `{"sender":"0x1111","value":16}`
+	})).Return([]*core.BlockchainEvent{}, nil)
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type:        blockchain.EventTypeForListener,
+			ForListener: ev,
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestMapListenerEventOutputNoMappings(t *testing.T) {
+	output := fftypes.JSONObject{"a": "b"}
+	assert.Equal(t, output, mapListenerEventOutput(context.Background(), &core.ContractListener{}, output))
+	assert.Equal(t, output, mapListenerEventOutput(context.Background(), testMappedListener(), output))
+}
+
+func TestMapListenerEventOutputPaths(t *testing.T) {
+	var output fftypes.JSONObject
+	err := json.Unmarshal([]byte(`{
+		"order": {
+			"id": 12,
+			"items": [{"sku": "abc"}, {"sku": "def"}],
+			"total": "1.5",
+			"paid": "true",
+			"flags": 0
+		}
+	}`), &output)
+	assert.NoError(t, err)
+
+	mapped := mapListenerEventOutput(context.Background(), testMappedListener(
+		&core.ContractListenerMapping{Field: "id", Path: "order.id", Type: core.MappingTypeString},
+		&core.ContractListenerMapping{Field: "sku", Path: "order.items.1.sku"},
+		&core.ContractListenerMapping{Field: "total", Path: "order.total", Type: core.MappingTypeNumber},
+		&core.ContractListenerMapping{Field: "paid", Path: "order.paid", Type: core.MappingTypeBoolean},
+		&core.ContractListenerMapping{Field: "flagged", Path: "order.flags", Type: core.MappingTypeBoolean},
+		&core.ContractListenerMapping{Field: "items", Path: "order.items", Type: core.MappingTypeString},
+		&core.ContractListenerMapping{Field: "missing", Path: "order.items.5.sku"},
+		&core.ContractListenerMapping{Field: "notarray", Path: "order.items.x"},
+		&core.ContractListenerMapping{Field: "notobject", Path: "order.id.value"},
+		&core.ContractListenerMapping{Field: "nokey", Path: "order.unknown"},
+	), output)
+	assert.Equal(t, fftypes.JSONObject{
+		"id":      "12",
+		"sku":     "def",
+		"total":   1.5,
+		"paid":    true,
+		"flagged": false,
+		"items":   `[{"sku":"abc"},{"sku":"def"}]`,
+	}, mapped)
+}
+
+func TestMapListenerEventOutputCoerceFailRetainsRaw(t *testing.T) {
+	output := fftypes.JSONObject{"value": "not a number"}
+	mapped := mapListenerEventOutput(context.Background(), testMappedListener(
+		&core.ContractListenerMapping{Field: "value", Type: core.MappingTypeInteger},
+	), output)
+	assert.Equal(t, output, mapped)
+}
+
+func TestCoerceMappingValues(t *testing.T) {
+	ctx := context.Background()
+	coerce := func(mappingType core.ContractListenerMappingType, value interface{}) (interface{}, error) {
+		return coerceMappingValue(ctx, &core.ContractListenerMapping{Field: "f", Type: mappingType}, value)
+	}
+
+	v, err := coerce(core.MappingTypeString, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", v)
+	v, err = coerce(core.MappingTypeString, 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", v)
+	v, err = coerce(core.MappingTypeString, json.Number("10"))
+	assert.NoError(t, err)
+	assert.Equal(t, "10", v)
+	v, err = coerce(core.MappingTypeString, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", v)
+
+	v, err = coerce(core.MappingTypeInteger, "123456789012345678901234567890")
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("123456789012345678901234567890"), v)
+	v, err = coerce(core.MappingTypeInteger, "010")
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("10"), v)
+	v, err = coerce(core.MappingTypeInteger, json.Number("42"))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("42"), v)
+	v, err = coerce(core.MappingTypeInteger, float64(7))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("7"), v)
+	_, err = coerce(core.MappingTypeInteger, 1.5)
+	assert.Regexp(t, "FF10487.*not an integer", err)
+	_, err = coerce(core.MappingTypeInteger, true)
+	assert.Regexp(t, "FF10487.*unsupported", err)
+
+	v, err = coerce(core.MappingTypeNumber, 1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, v)
+	v, err = coerce(core.MappingTypeNumber, json.Number("2.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2.5, v)
+	_, err = coerce(core.MappingTypeNumber, "abc")
+	assert.Regexp(t, "FF10487", err)
+	_, err = coerce(core.MappingTypeNumber, true)
+	assert.Regexp(t, "FF10487.*unsupported", err)
+
+	v, err = coerce(core.MappingTypeBoolean, true)
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+	v, err = coerce(core.MappingTypeBoolean, json.Number("1"))
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+	_, err = coerce(core.MappingTypeBoolean, "maybe")
+	assert.Regexp(t, "FF10487", err)
+	_, err = coerce(core.MappingTypeBoolean, nil)
+	assert.Regexp(t, "FF10487.*unsupported", err)
+
+	v, err = coerce("", fftypes.JSONObject{"a": "b"})
+	assert.NoError(t, err)
+	assert.Equal(t, fftypes.JSONObject{"a": "b"}, v)
+}
@@ -152,6 +152,84 @@ func TestContractEventWrongNS(t *testing.T) {
 }
 
 // TODO: Add test case for event not existing
+func TestContractEventCheckpointReplay(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	newEvent := func(block int64, txID string) *blockchain.EventForListener {
+		return &blockchain.EventForListener{
+			ListenerID: "sb-1",
+			Event: &blockchain.Event{
+				BlockchainTXID: txID,
+				ProtocolID:     fmt.Sprintf("%.12d/%s", block, txID),
+				Name:           "Changed",
+				Checkpoint: &core.BlockchainCheckpoint{
+					Ledger:        "firefly",
+					Subscription:  "sb-1",
+					BlockNumber:   block,
+					TransactionID: txID,
+				},
+			},
+		}
+	}
+	sub := &core.ContractListener{
+		Namespace: "ns1",
+		ID:        fftypes.NewUUID(),
+		Topic:     "topic1",
+	}
+
+	em.mdi.On("GetBlockchainCheckpoint", mock.Anything, "ns1", "firefly", "sb-1").Return(nil, fmt.Errorf("pop")).Once()
+	em.mdi.On("GetBlockchainCheckpoint", mock.Anything, "ns1", "firefly", "sb-1").Return(&core.BlockchainCheckpoint{
+		Namespace:     "ns1",
+		Ledger:        "firefly",
+		Subscription:  "sb-1",
+		BlockNumber:   10,
+		TransactionID: "tx1",
+	}, nil)
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(sub, nil)
+	em.mth.On("InsertNewBlockchainEvents", mock.Anything, mock.MatchedBy(func(events []*core.BlockchainEvent) bool {
+		return len(events) == 1 && events[0].TX.BlockchainID == "tx2"
+	})).Return([]*core.BlockchainEvent{}, nil)
+	em.mdi.On("UpsertBlockchainCheckpoint", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	em.mdi.On("UpsertBlockchainCheckpoint", mock.Anything, mock.MatchedBy(func(cp *core.BlockchainCheckpoint) bool {
+		return cp.Namespace == "ns1" && cp.BlockNumber == 11 && cp.TransactionID == "tx2"
+	})).Return(nil).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type:        blockchain.EventTypeForListener,
+			ForListener: newEvent(9, "tx0"),
+		},
+		{
+			Type:        blockchain.EventTypeForListener,
+			ForListener: newEvent(10, "tx1"),
+		},
+		{
+			Type:        blockchain.EventTypeForListener,
+			ForListener: newEvent(11, "tx2"),
+		},
+		{
+			Type:        blockchain.EventTypeForListener,
+			ForListener: newEvent(11, "tx2"),
+		},
+	})
+	assert.NoError(t, err)
+
+	em.mdi.AssertExpectations(t)
+	em.mth.AssertExpectations(t)
+}
+
+func TestDispatchedChainEvent(t *testing.T) {
+	ev := &blockchain.Event{Name: "BatchPin"}
+	assert.Equal(t, ev, dispatchedChainEvent(&blockchain.EventToDispatch{
+		NetworkAction: &blockchain.NetworkActionEvent{Event: ev},
+	}))
+	assert.Equal(t, "BatchPin", dispatchedChainEvent(&blockchain.EventToDispatch{
+		BatchPinComplete: &blockchain.BatchPinCompleteEvent{Batch: &blockchain.BatchPin{Event: *ev}},
+	}).Name)
+	assert.Nil(t, dispatchedChainEvent(&blockchain.EventToDispatch{}))
+}
+
 func TestPersistBlockchainEventDuplicate(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -215,3 +293,29 @@ func TestBlockchainEventMetric(t *testing.T) {
 
 	em.emitBlockchainEventMetric(&event)
 }
+
+func TestContractEventCheckMigrationFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	ev := &blockchain.EventForListener{
+		ListenerID: "sb-1",
+		Event: &blockchain.Event{
+			ProtocolID: "000000000010/000000/000000",
+			Name:       "Changed",
+		},
+	}
+
+	em.mmp.ExpectedCalls = nil
+	em.mmp.On("CheckContractMigration", mock.Anything, ev.Event).Return(fmt.Errorf("pop")).Once()
+	em.mmp.On("CheckContractMigration", mock.Anything, ev.Event).Return(nil).Once()
+	em.mdi.On("GetContractListenerByBackendID", mock.Anything, "ns1", "sb-1").Return(nil, nil).Once()
+
+	err := em.BlockchainEventBatch([]*blockchain.EventToDispatch{
+		{
+			Type:        blockchain.EventTypeForListener,
+			ForListener: ev,
+		},
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,154 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"container/heap"
+
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// dispatchQueue holds the matched events from a page, that are waiting to be dispatched.
+// Events are dispatched in sequence order, unless the subscription assigns priorities to event types.
+// Then higher priority events are dispatched first - but to prevent starvation, the oldest waiting
+// event is dispatched once it has been overtaken by starvationLimit events in a row.
+type dispatchQueue struct {
+	byPriority      []*queuedEvent
+	bySequence      []*queuedEvent
+	prioritized     bool
+	starvationLimit int
+	overtaken       int
+	remaining       int
+}
+
+type queuedEvent struct {
+	event      *core.EventDelivery
+	priority   int
+	index      int
+	dispatched bool
+}
+
+func newDispatchQueue(events []*core.EventDelivery, priorities map[string]int, starvationLimit int) *dispatchQueue {
+	dq := &dispatchQueue{
+		bySequence:      make([]*queuedEvent, len(events)),
+		prioritized:     len(priorities) > 0,
+		starvationLimit: starvationLimit,
+		remaining:       len(events),
+	}
+	for i, event := range events {
+		dq.bySequence[i] = &queuedEvent{
+			event:    event,
+			priority: priorities[event.Type.String()],
+		}
+	}
+	if dq.prioritized {
+		dq.byPriority = make([]*queuedEvent, 0, len(events))
+		for _, qe := range dq.bySequence {
+			heap.Push(dq, qe)
+		}
+	}
+	return dq
+}
+
+func (dq *dispatchQueue) Len() int { return len(dq.byPriority) }
+
+func (dq *dispatchQueue) Less(i, j int) bool {
+	if dq.byPriority[i].priority != dq.byPriority[j].priority {
+		return dq.byPriority[i].priority > dq.byPriority[j].priority
+	}
+	return dq.byPriority[i].event.Sequence < dq.byPriority[j].event.Sequence
+}
+
+func (dq *dispatchQueue) Swap(i, j int) {
+	dq.byPriority[i], dq.byPriority[j] = dq.byPriority[j], dq.byPriority[i]
+	dq.byPriority[i].index = i
+	dq.byPriority[j].index = j
+}
+
+func (dq *dispatchQueue) Push(x any) {
+	qe := x.(*queuedEvent)
+	qe.index = len(dq.byPriority)
+	dq.byPriority = append(dq.byPriority, qe)
+}
+
+func (dq *dispatchQueue) Pop() any {
+	last := len(dq.byPriority) - 1
+	qe := dq.byPriority[last]
+	dq.byPriority = dq.byPriority[:last]
+	return qe
+}
+
+func (dq *dispatchQueue) len() int {
+	return dq.remaining
+}
+
+// oldest returns the lowest sequence event still waiting
+func (dq *dispatchQueue) oldest() *queuedEvent {
+	for len(dq.bySequence) > 0 && dq.bySequence[0].dispatched {
+		dq.bySequence = dq.bySequence[1:]
+	}
+	if len(dq.bySequence) == 0 {
+		return nil
+	}
+	return dq.bySequence[0]
+}
+
+// lowestSequence returns the sequence of the oldest event still waiting, or -1 if the queue is empty
+func (dq *dispatchQueue) lowestSequence() int64 {
+	if oldest := dq.oldest(); oldest != nil {
+		return oldest.event.Sequence
+	}
+	return -1
+}
+
+func (dq *dispatchQueue) next() *queuedEvent {
+	oldest := dq.oldest()
+	if !dq.prioritized {
+		return oldest
+	}
+	var qe *queuedEvent
+	if dq.overtaken >= dq.starvationLimit {
+		qe = oldest
+		heap.Remove(dq, qe.index)
+	} else {
+		qe = heap.Pop(dq).(*queuedEvent)
+	}
+	if qe == oldest {
+		dq.overtaken = 0
+	} else {
+		dq.overtaken++
+	}
+	return qe
+}
+
+// take removes up to n events from the queue, in the order they should be dispatched
+func (dq *dispatchQueue) take(n int) []*core.EventDelivery {
+	if n > dq.remaining {
+		n = dq.remaining
+	}
+	if n <= 0 {
+		return nil
+	}
+	taken := make([]*core.EventDelivery, n)
+	for i := 0; i < n; i++ {
+		qe := dq.next()
+		qe.dispatched = true
+		taken[i] = qe.event
+	}
+	dq.remaining -= n
+	return taken
+}
@@ -0,0 +1,92 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func testQueuedEvents(types ...core.EventType) []*core.EventDelivery {
+	events := make([]*core.EventDelivery, len(types))
+	for i, t := range types {
+		events[i] = &core.EventDelivery{
+			EnrichedEvent: core.EnrichedEvent{
+				Event: core.Event{ID: fftypes.NewUUID(), Sequence: int64(i + 1), Type: t},
+			},
+		}
+	}
+	return events
+}
+
+func sequences(events []*core.EventDelivery) []int64 {
+	seqs := make([]int64, len(events))
+	for i, e := range events {
+		seqs[i] = e.Sequence
+	}
+	return seqs
+}
+
+func TestDispatchQueueSequenceOrder(t *testing.T) {
+	dq := newDispatchQueue(testQueuedEvents(
+		core.EventTypeTransferConfirmed,
+		core.EventTypeMessageRejected,
+		core.EventTypeTransferConfirmed,
+	), nil, 10)
+	assert.Equal(t, 3, dq.len())
+	assert.Nil(t, dq.take(0))
+	assert.Equal(t, []int64{1, 2}, sequences(dq.take(2)))
+	assert.Equal(t, int64(3), dq.lowestSequence())
+	assert.Equal(t, []int64{3}, sequences(dq.take(5)))
+	assert.Equal(t, int64(-1), dq.lowestSequence())
+	assert.Nil(t, dq.take(1))
+}
+
+func TestDispatchQueuePriority(t *testing.T) {
+	dq := newDispatchQueue(testQueuedEvents(
+		core.EventTypeTransferConfirmed,
+		core.EventTypeTransferConfirmed,
+		core.EventTypeMessageRejected,
+		core.EventTypeMessageConfirmed,
+		core.EventTypeMessageRejected,
+	), map[string]int{
+		core.EventTypeMessageRejected.String():  10,
+		core.EventTypeMessageConfirmed.String(): 5,
+	}, 10)
+	assert.Equal(t, []int64{3, 5}, sequences(dq.take(2)))
+	assert.Equal(t, int64(1), dq.lowestSequence())
+	assert.Equal(t, []int64{4, 1, 2}, sequences(dq.take(3)))
+	assert.Equal(t, 0, dq.len())
+}
+
+func TestDispatchQueueStarvation(t *testing.T) {
+	dq := newDispatchQueue(testQueuedEvents(
+		core.EventTypeTransferConfirmed,
+		core.EventTypeTransferConfirmed,
+		core.EventTypeMessageRejected,
+		core.EventTypeMessageRejected,
+		core.EventTypeMessageRejected,
+		core.EventTypeMessageRejected,
+		core.EventTypeMessageRejected,
+	), map[string]int{
+		core.EventTypeMessageRejected.String(): 10,
+	}, 2)
+	assert.Equal(t, []int64{3, 4, 1, 5, 6, 2, 7}, sequences(dq.take(7)))
+}
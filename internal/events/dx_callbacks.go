@@ -19,6 +19,7 @@ package events
 import (
 	"context"
 	"database/sql/driver"
+	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -29,14 +30,15 @@ import (
 
 // Check that the sending data exchange peer corresponds to the node listed in the batch,
 // and that the node has been registered to the org listed in the batch.
-func (em *eventManager) checkReceivedOffchainIdentity(ctx context.Context, peerID, author string, nodeID *fftypes.UUID) (valid bool, err error) {
+func (em *eventManager) checkReceivedOffchainIdentity(ctx context.Context, peerID, certFingerprint, author string, nodeID *fftypes.UUID) (valid bool, err error) {
 	l := log.L(ctx)
 
 	// Resolve the node for the peer ID and check against the node specified on the batch
-	node, err := em.identity.FindIdentityForVerifier(ctx, []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+	peerVerifier := &core.VerifierRef{
 		Type:  core.VerifierTypeFFDXPeerID,
 		Value: peerID,
-	})
+	}
+	node, err := em.identity.FindIdentityForVerifier(ctx, []core.IdentityType{core.IdentityTypeNode}, peerVerifier)
 	if err != nil {
 		return false, err
 	}
@@ -49,6 +51,22 @@ func (em *eventManager) checkReceivedOffchainIdentity(ctx context.Context, peerI
 		return false, nil
 	}
 
+	// Check the peer has not been revoked
+	revoked, err := em.identity.IsVerifierRevoked(ctx, peerVerifier)
+	if err != nil {
+		return false, err
+	}
+	if revoked {
+		l.Errorf("Peer '%s' of node '%s' has been revoked", peerID, node.ID)
+		event := core.NewEvent(core.EventTypeRevokedSignerRejected, em.namespace.Name, node.ID, nil, core.SystemTopicSecurity)
+		return false, em.database.InsertEvent(ctx, event)
+	}
+
+	// Check the peer connected with the certificate pinned for the node
+	if valid, err := em.checkPeerCertificate(ctx, node, peerID, certFingerprint); err != nil || !valid {
+		return false, err
+	}
+
 	// Look up the identity specified on the batch
 	org, retryable, err := em.identity.CachedIdentityLookupMustExist(ctx, author)
 	if err != nil && retryable {
@@ -64,7 +82,36 @@ func (em *eventManager) checkReceivedOffchainIdentity(ctx context.Context, peerI
 	return em.identity.ValidateNodeOwner(ctx, node, org)
 }
 
-func (em *eventManager) privateBatchReceived(peerID string, batch *core.Batch, wrapperGroup *core.Group) (manifest string, err error) {
+// Check the certificate the data exchange connector reports for the sending peer matches the one
+// pinned on the node identity. Nothing is checked if the node was registered without a pinned certificate,
+// but once a certificate is pinned, transfers from a peer whose connector does not report one are rejected.
+func (em *eventManager) checkPeerCertificate(ctx context.Context, node *core.Identity, peerID, certFingerprint string) (valid bool, err error) {
+	pinned := node.Profile.GetString(dataexchange.PeerCertFingerprintKey)
+	if pinned == "" || strings.EqualFold(certFingerprint, pinned) {
+		return true, nil
+	}
+	if certFingerprint == "" {
+		log.L(ctx).Errorf("Peer '%s' of node '%s' did not report a certificate, but certificate '%s' is pinned", peerID, node.ID, pinned)
+	} else {
+		log.L(ctx).Errorf("Peer '%s' of node '%s' connected with certificate '%s', which does not match pinned certificate '%s'", peerID, node.ID, certFingerprint, pinned)
+	}
+	event := core.NewEvent(core.EventTypePeerIdentityMismatch, em.namespace.Name, node.ID, nil, core.SystemTopicSecurity)
+	return false, em.database.InsertEvent(ctx, event)
+}
+
+// Check the certificate of the peer sending a blob, if the node of that peer is known
+func (em *eventManager) checkReceivedBlobPeer(ctx context.Context, peerID, certFingerprint string) (valid bool, err error) {
+	node, err := em.identity.FindIdentityForVerifier(ctx, []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: peerID,
+	})
+	if err != nil || node == nil {
+		return err == nil, err
+	}
+	return em.checkPeerCertificate(ctx, node, peerID, certFingerprint)
+}
+
+func (em *eventManager) privateBatchReceived(peerID, certFingerprint string, batch *core.Batch, wrapperGroup *core.Group) (manifest string, err error) {
 	if em.multiparty == nil {
 		log.L(em.ctx).Errorf("Ignoring private batch from non-multiparty network!")
 		return "", nil
@@ -96,7 +143,7 @@ func (em *eventManager) privateBatchReceived(peerID string, batch *core.Batch, w
 				}
 			}
 
-			if valid, err := em.checkReceivedOffchainIdentity(ctx, peerID, batch.Author, batch.Node); err != nil {
+			if valid, err := em.checkReceivedOffchainIdentity(ctx, peerID, certFingerprint, batch.Author, batch.Node); err != nil {
 				return err
 			} else if !valid {
 				l.Errorf("Batch '%s' received from invalid author '%s' for peer '%s'", batch.ID, batch.Author, peerID)
@@ -172,8 +219,13 @@ func (em *eventManager) DXEvent(dx dataexchange.Plugin, event dataexchange.DXEve
 	case dataexchange.DXEventTypePrivateBlobReceived:
 		em.privateBlobReceived(dx, event)
 	case dataexchange.DXEventTypeMessageReceived:
-		// Batches are significant items of work in their own right, so get dispatched to their own routines
-		go em.messageReceived(dx, event)
+		if event.MessageReceived().Sequence > 0 {
+			// Messages the connector has numbered are delivered in sequence for each peer
+			em.dxSequencer.messageReceived(dx, event)
+		} else {
+			// Batches are significant items of work in their own right, so get dispatched to their own routines
+			go em.messageReceived(dx, event)
+		}
 	default:
 		log.L(em.ctx).Errorf("Invalid data exchange event type from %s: %d", dx.Name(), event.Type())
 		event.Ack() // still ack
@@ -187,7 +239,7 @@ func (em *eventManager) messageReceived(dx dataexchange.Plugin, event dataexchan
 	mr := event.MessageReceived()
 	l.Infof("Private batch received from %s peer '%s'", dx.Name(), mr.PeerID)
 
-	manifestString, err := em.privateBatchReceived(mr.PeerID, mr.Transport.Batch, mr.Transport.Group)
+	manifestString, err := em.privateBatchReceived(mr.PeerID, mr.CertFingerprint, mr.Transport.Batch, mr.Transport.Group)
 	if err != nil {
 		l.Warnf("Exited while persisting batch: %s", err)
 		// We do NOT ack here as we broke out of the retry
@@ -217,6 +269,22 @@ func (em *eventManager) privateBlobReceived(dx dataexchange.Plugin, event dataex
 		return
 	}
 
+	var valid bool
+	err = em.retry.Do(em.ctx, "private blob received", func(attempt int) (bool, error) {
+		valid, err = em.checkReceivedBlobPeer(em.ctx, br.PeerID, br.CertFingerprint)
+		return true, err
+	})
+	if err != nil {
+		log.L(em.ctx).Warnf("Exited while checking blob sender: %s", err)
+		// We do NOT ack here as we broke out of the retry
+		return
+	}
+	if !valid {
+		log.L(em.ctx).Errorf("Ignoring blob '%s' from peer '%s' with mismatched certificate", br.PayloadRef, br.PeerID)
+		event.Ack() // Still confirm the event
+		return
+	}
+
 	// Dispatch to the blob receiver for efficient batch DB operations
 	em.blobReceiver.blobReceived(em.ctx, &blobNotification{
 		blob: &core.Blob{
@@ -407,6 +407,198 @@ func TestMessageReceiveGetCandidateOrgNotMatch(t *testing.T) {
 	mdx.AssertExpectations(t)
 }
 
+func TestMessageReceiveRevokedPeer(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	b, tw := sampleBatchTransfer(t, core.TransactionTypeUnpinned)
+
+	org1 := newTestOrg("org1")
+	node1 := newTestNode("node1", org1)
+	b.Node = node1.ID
+	creator := &core.Member{
+		Identity: b.Author,
+		Node:     b.Node,
+	}
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	peerVerifier := &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: "peer1",
+	}
+	em.mim.ExpectedCalls = nil
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, peerVerifier).Return(node1, nil)
+	em.mim.On("IsVerifierRevoked", em.ctx, peerVerifier).Return(true, nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeRevokedSignerRejected && event.Reference.Equals(node1.ID)
+	})).Return(nil)
+
+	em.mpm.On("EnsureLocalGroup", em.ctx, mock.Anything, creator).Return(true, nil)
+
+	mde := newMessageReceived("peer1", tw, "")
+	em.messageReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	em.mim.AssertExpectations(t)
+}
+
+func TestMessageReceivePeerCertMismatch(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	b, tw := sampleBatchTransfer(t, core.TransactionTypeUnpinned)
+
+	org1 := newTestOrg("org1")
+	node1 := newTestNode("node1", org1)
+	node1.Profile[dataexchange.PeerCertFingerprintKey] = "aaaa"
+	b.Node = node1.ID
+	creator := &core.Member{
+		Identity: b.Author,
+		Node:     b.Node,
+	}
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: "peer1",
+	}).Return(node1, nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypePeerIdentityMismatch && event.Reference.Equals(node1.ID) && event.Topic == core.SystemTopicSecurity
+	})).Return(nil)
+
+	em.mpm.On("EnsureLocalGroup", em.ctx, mock.Anything, creator).Return(true, nil)
+
+	mde := newMessageReceived("peer1", tw, "")
+	mde.MessageReceived().CertFingerprint = "bbbb"
+	em.messageReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	em.mdi.AssertExpectations(t)
+}
+
+func TestPrivateBlobReceivedPeerCertMismatch(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	node1 := newTestNode("node1", newTestOrg("org1"))
+	node1.Profile[dataexchange.PeerCertFingerprintKey] = "aaaa"
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: "peer1",
+	}).Return(node1, nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypePeerIdentityMismatch && event.Reference.Equals(node1.ID)
+	})).Return(nil)
+
+	mde := newPrivateBlobReceived("peer1", fftypes.NewRandB32(), 12345, "ns1/path1", fftypes.NewUUID())
+	mde.PrivateBlobReceived().CertFingerprint = "bbbb"
+	em.privateBlobReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	em.mdi.AssertExpectations(t)
+}
+
+func TestPrivateBlobReceivedPeerCertNotReported(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	node1 := newTestNode("node1", newTestOrg("org1"))
+	node1.Profile[dataexchange.PeerCertFingerprintKey] = "aaaa"
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, &core.VerifierRef{
+		Type:  core.VerifierTypeFFDXPeerID,
+		Value: "peer1",
+	}).Return(node1, nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypePeerIdentityMismatch && event.Reference.Equals(node1.ID)
+	})).Return(nil)
+
+	mde := newPrivateBlobReceived("peer1", fftypes.NewRandB32(), 12345, "ns1/path1", fftypes.NewUUID())
+	em.privateBlobReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+	em.mdi.AssertExpectations(t)
+}
+
+func TestPrivateBlobReceivedPeerCertMatch(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	hash := fftypes.NewRandB32()
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	node1 := newTestNode("node1", newTestOrg("org1"))
+	node1.Profile[dataexchange.PeerCertFingerprintKey] = "AAAA"
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, mock.Anything).Return(node1, nil)
+	em.mdi.On("GetBlobs", em.ctx, mock.Anything, mock.Anything).Return([]*core.Blob{}, nil, nil)
+	em.mdi.On("InsertBlobs", em.ctx, mock.Anything).Return(nil)
+
+	done := make(chan struct{})
+	mde := newPrivateBlobReceivedNoAck("peer1", hash, 12345, "ns1/path1", fftypes.NewUUID())
+	mde.PrivateBlobReceived().CertFingerprint = "aaaa"
+	mde.On("Ack").Run(func(args mock.Arguments) {
+		close(done)
+	})
+	em.privateBlobReceived(mdx, mde)
+	<-done
+
+	mde.AssertExpectations(t)
+}
+
+func TestPrivateBlobReceivedPeerCertUnknownNode(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	hash := fftypes.NewRandB32()
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, mock.Anything).Return(nil, nil)
+	em.mdi.On("GetBlobs", em.ctx, mock.Anything, mock.Anything).Return([]*core.Blob{}, nil, nil)
+	em.mdi.On("InsertBlobs", em.ctx, mock.Anything).Return(nil)
+
+	done := make(chan struct{})
+	mde := newPrivateBlobReceivedNoAck("peer1", hash, 12345, "ns1/path1", fftypes.NewUUID())
+	mde.PrivateBlobReceived().CertFingerprint = "aaaa"
+	mde.On("Ack").Run(func(args mock.Arguments) {
+		close(done)
+	})
+	em.privateBlobReceived(mdx, mde)
+	<-done
+
+	mde.AssertExpectations(t)
+}
+
+func TestPrivateBlobReceivedPeerCertLookupFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.cancel() // retryable error
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	// no ack as we are simulating termination mid retry
+	mde := newPrivateBlobReceivedNoAck("peer1", fftypes.NewRandB32(), 12345, "ns1/path1", fftypes.NewUUID())
+	mde.PrivateBlobReceived().CertFingerprint = "aaaa"
+	em.privateBlobReceived(mdx, mde)
+
+	mde.AssertExpectations(t)
+}
+
 func TestPrivateBlobReceivedTriggersRewindOk(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -415,6 +607,7 @@ func TestPrivateBlobReceivedTriggersRewindOk(t *testing.T) {
 	mdx := &dataexchangemocks.Plugin{}
 	mdx.On("Name").Return("utdx")
 
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, mock.Anything).Return(nil, nil)
 	em.mdi.On("GetBlobs", em.ctx, mock.Anything, mock.Anything).Return([]*core.Blob{}, nil, nil)
 	em.mdi.On("InsertBlobs", em.ctx, mock.Anything).Return(nil)
 
@@ -466,6 +659,7 @@ func TestPrivateBlobReceivedInsertBlobFails(t *testing.T) {
 	mdx := &dataexchangemocks.Plugin{}
 	mdx.On("Name").Return("utdx")
 
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, mock.Anything).Return(nil, nil)
 	em.mdi.On("GetBlobs", em.ctx, mock.Anything, mock.Anything).Return([]*core.Blob{}, nil, nil)
 	em.mdi.On("InsertBlobs", em.ctx, mock.Anything).Return(fmt.Errorf("pop"))
 
@@ -485,6 +679,7 @@ func TestPrivateBlobReceivedGetBlobsFails(t *testing.T) {
 	mdx := &dataexchangemocks.Plugin{}
 	mdx.On("Name").Return("utdx")
 
+	em.mim.On("FindIdentityForVerifier", em.ctx, []core.IdentityType{core.IdentityTypeNode}, mock.Anything).Return(nil, nil)
 	em.mdi.On("GetBlobs", em.ctx, mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
 
 	// no ack as we are simulating termination mid retry
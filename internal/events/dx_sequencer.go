@@ -0,0 +1,149 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+)
+
+// dxSequencer delivers the messages received from each data exchange peer in the order of the sequence numbers
+// assigned by the connector. Duplicates of messages that have already been delivered are acknowledged without
+// being processed again, and messages that arrive ahead of their turn are held until the messages before them
+// arrive, or until the reorder timeout, after which the sequencer skips over the missing messages.
+//
+// Sequences are tracked in memory, starting from the first message received from each peer since startup,
+// as the connector re-delivers any messages that were not acknowledged before a restart.
+type dxSequencer struct {
+	ctx            context.Context
+	mux            sync.Mutex
+	reorderTimeout time.Duration
+	peers          map[string]*peerSequence
+	dispatch       func(dx dataexchange.Plugin, event dataexchange.DXEvent)
+}
+
+type peerSequence struct {
+	next         int64
+	held         map[int64]*sequencedEvent
+	ready        []*sequencedEvent
+	dispatching  bool
+	reorderTimer *time.Timer
+}
+
+type sequencedEvent struct {
+	dx    dataexchange.Plugin
+	event dataexchange.DXEvent
+}
+
+func newDXSequencer(ctx context.Context, reorderTimeout time.Duration, dispatch func(dx dataexchange.Plugin, event dataexchange.DXEvent)) *dxSequencer {
+	return &dxSequencer{
+		ctx:            ctx,
+		reorderTimeout: reorderTimeout,
+		peers:          make(map[string]*peerSequence),
+		dispatch:       dispatch,
+	}
+}
+
+func (ds *dxSequencer) messageReceived(dx dataexchange.Plugin, event dataexchange.DXEvent) {
+	mr := event.MessageReceived()
+	l := log.L(ds.ctx)
+
+	ds.mux.Lock()
+	defer ds.mux.Unlock()
+	ps := ds.peers[mr.PeerID]
+	if ps == nil {
+		ps = &peerSequence{next: mr.Sequence, held: make(map[int64]*sequencedEvent)}
+		ds.peers[mr.PeerID] = ps
+	}
+
+	switch {
+	case mr.Sequence < ps.next || ps.held[mr.Sequence] != nil:
+		l.Infof("Ignoring duplicate message %d from peer '%s' (event=%s)", mr.Sequence, mr.PeerID, event.EventID())
+		event.Ack()
+		return
+	case mr.Sequence > ps.next:
+		l.Infof("Holding message %d from peer '%s' until message %d arrives (event=%s)", mr.Sequence, mr.PeerID, ps.next, event.EventID())
+		ps.held[mr.Sequence] = &sequencedEvent{dx: dx, event: event}
+		if ps.reorderTimer == nil {
+			ps.reorderTimer = time.AfterFunc(ds.reorderTimeout, func() { ds.skipMissing(mr.PeerID, ps) })
+		}
+		return
+	}
+
+	ps.ready = append(ps.ready, &sequencedEvent{dx: dx, event: event})
+	ps.next++
+	ds.release(mr.PeerID, ps)
+}
+
+// skipMissing gives up waiting for the messages missing from the sequence of a peer, and delivers the held messages after them
+func (ds *dxSequencer) skipMissing(peerID string, ps *peerSequence) {
+	ds.mux.Lock()
+	defer ds.mux.Unlock()
+	ps.reorderTimer = nil
+	if len(ps.held) == 0 {
+		return
+	}
+	first := int64(-1)
+	for seq := range ps.held {
+		if first < 0 || seq < first {
+			first = seq
+		}
+	}
+	log.L(ds.ctx).Warnf("Skipping messages %d-%d from peer '%s', which did not arrive within %s", ps.next, first-1, peerID, ds.reorderTimeout)
+	ps.next = first
+	ds.release(peerID, ps)
+}
+
+// release moves the held messages that are now next in sequence to the ready list, and dispatches the ready list
+// in order on a single routine for the peer - must be called with the lock held
+func (ds *dxSequencer) release(peerID string, ps *peerSequence) {
+	for e := ps.held[ps.next]; e != nil; e = ps.held[ps.next] {
+		delete(ps.held, ps.next)
+		ps.ready = append(ps.ready, e)
+		ps.next++
+	}
+	if len(ps.held) == 0 && ps.reorderTimer != nil {
+		ps.reorderTimer.Stop()
+		ps.reorderTimer = nil
+	} else if len(ps.held) > 0 && ps.reorderTimer == nil {
+		ps.reorderTimer = time.AfterFunc(ds.reorderTimeout, func() { ds.skipMissing(peerID, ps) })
+	}
+	if !ps.dispatching && len(ps.ready) > 0 {
+		ps.dispatching = true
+		go ds.dispatchReady(ps)
+	}
+}
+
+func (ds *dxSequencer) dispatchReady(ps *peerSequence) {
+	for {
+		ds.mux.Lock()
+		if len(ps.ready) == 0 {
+			ps.dispatching = false
+			ds.mux.Unlock()
+			return
+		}
+		e := ps.ready[0]
+		ps.ready = ps.ready[1:]
+		ds.mux.Unlock()
+
+		ds.dispatch(e.dx, e.event)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSequencedMessage(peerID string, seq int64) *dataexchangemocks.DXEvent {
+	mde := &dataexchangemocks.DXEvent{}
+	mde.On("MessageReceived").Return(&dataexchange.MessageReceived{
+		PeerID:   peerID,
+		Sequence: seq,
+	})
+	mde.On("EventID").Return(fftypes.NewUUID().String()).Maybe()
+	mde.On("Type").Return(dataexchange.DXEventTypeMessageReceived).Maybe()
+	return mde
+}
+
+func newTestDXSequencer(reorderTimeout time.Duration) (*dxSequencer, chan int64) {
+	dispatched := make(chan int64, 10)
+	ds := newDXSequencer(context.Background(), reorderTimeout, func(dx dataexchange.Plugin, event dataexchange.DXEvent) {
+		dispatched <- event.MessageReceived().Sequence
+	})
+	return ds, dispatched
+}
+
+func TestDXSequencerReorders(t *testing.T) {
+	ds, dispatched := newTestDXSequencer(time.Minute)
+	mdx := &dataexchangemocks.Plugin{}
+
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 1))
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 3))
+	ds.messageReceived(mdx, newSequencedMessage("peer2", 7))
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 2))
+
+	received := []int64{<-dispatched, <-dispatched, <-dispatched, <-dispatched}
+	assert.ElementsMatch(t, []int64{1, 2, 3, 7}, received)
+	var peer1 []int64
+	for _, seq := range received {
+		if seq != 7 {
+			peer1 = append(peer1, seq)
+		}
+	}
+	assert.Equal(t, []int64{1, 2, 3}, peer1)
+
+	ds.mux.Lock()
+	defer ds.mux.Unlock()
+	assert.Equal(t, int64(4), ds.peers["peer1"].next)
+	assert.Nil(t, ds.peers["peer1"].reorderTimer)
+}
+
+func TestDXSequencerDuplicates(t *testing.T) {
+	ds, dispatched := newTestDXSequencer(time.Minute)
+	mdx := &dataexchangemocks.Plugin{}
+
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 1))
+	assert.Equal(t, int64(1), <-dispatched)
+
+	// A re-delivery of a message already dispatched
+	dup1 := newSequencedMessage("peer1", 1)
+	dup1.On("Ack").Return()
+	ds.messageReceived(mdx, dup1)
+
+	// A re-delivery of a message being held
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 3))
+	dup3 := newSequencedMessage("peer1", 3)
+	dup3.On("Ack").Return()
+	ds.messageReceived(mdx, dup3)
+
+	dup1.AssertExpectations(t)
+	dup3.AssertExpectations(t)
+	assert.Empty(t, dispatched)
+}
+
+func TestDXSequencerSkipsMissing(t *testing.T) {
+	ds, dispatched := newTestDXSequencer(10 * time.Millisecond)
+	mdx := &dataexchangemocks.Plugin{}
+
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 1))
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 3))
+	ds.messageReceived(mdx, newSequencedMessage("peer1", 5))
+
+	// 2 and 4 never arrive, so 3 and 5 are delivered once each gap times out
+	assert.Equal(t, int64(1), <-dispatched)
+	assert.Equal(t, int64(3), <-dispatched)
+	assert.Equal(t, int64(5), <-dispatched)
+
+	// A late arrival of a skipped message is treated as a duplicate
+	late := newSequencedMessage("peer1", 2)
+	late.On("Ack").Return()
+	ds.messageReceived(mdx, late)
+	late.AssertExpectations(t)
+}
+
+func TestDXSequencerSkipMissingNothingHeld(t *testing.T) {
+	ds, _ := newTestDXSequencer(time.Minute)
+	ps := &peerSequence{next: 2, held: map[int64]*sequencedEvent{}}
+	ds.skipMissing("peer1", ps)
+	assert.Equal(t, int64(2), ps.next)
+}
+
+func TestDXEventSequencedMessage(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.namespace.NetworkName = "ns2"
+
+	_, b := sampleBatchTransfer(t, core.TransactionTypeBatchPin)
+
+	mdx := &dataexchangemocks.Plugin{}
+	mdx.On("Name").Return("utdx")
+
+	acked := make(chan struct{})
+	mde := &dataexchangemocks.DXEvent{}
+	mde.On("MessageReceived").Return(&dataexchange.MessageReceived{
+		PeerID:    "peer1",
+		Sequence:  1,
+		Transport: b,
+	})
+	mde.On("Type").Return(dataexchange.DXEventTypeMessageReceived)
+	mde.On("AckWithManifest", "").Run(func(args mock.Arguments) {
+		close(acked)
+	}).Return()
+
+	err := em.DXEvent(mdx, mde)
+	assert.NoError(t, err)
+	<-acked
+
+	mde.AssertExpectations(t)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -59,6 +60,8 @@ type eventDispatcher struct {
 	enricher      *eventEnricher
 	data          data.Manager
 	database      database.Plugin
+	metrics       metrics.Manager
+	sla           *slaReporter
 	transport     events.Plugin
 	broadcast     broadcast.Manager        // optional
 	messaging     privatemessaging.Manager // optional
@@ -70,11 +73,14 @@ type eventDispatcher struct {
 	namespace     string
 	readAhead     int
 	batch         bool
+	priorities    map[string]int
+	starvation    int
+	queuedLowest  int64 // lowest sequence of matched events waiting for dispatch, or -1
 	subscription  *subscription
 	txHelper      txcommon.Helper
 }
 
-func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.Plugin, di database.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, connID string, sub *subscription, en *eventNotifier, txHelper txcommon.Helper) *eventDispatcher {
+func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.Plugin, di database.Plugin, dm data.Manager, bm broadcast.Manager, pm privatemessaging.Manager, connID string, sub *subscription, en *eventNotifier, txHelper txcommon.Helper, mm metrics.Manager, sla *slaReporter) *eventDispatcher {
 	ctx, cancelCtx := context.WithCancel(ctx)
 	readAhead := uint(0)
 	if sub.definition.Options.ReadAhead != nil {
@@ -95,6 +101,8 @@ func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.
 			"sub", fmt.Sprintf("%s/%s:%s", sub.definition.ID, sub.definition.Namespace, sub.definition.Name)),
 		enricher:      enricher,
 		database:      di,
+		metrics:       mm,
+		sla:           sla,
 		transport:     ei,
 		broadcast:     bm,
 		messaging:     pm,
@@ -110,6 +118,9 @@ func newEventDispatcher(ctx context.Context, enricher *eventEnricher, ei events.
 		closed:        make(chan struct{}),
 		txHelper:      txHelper,
 		batch:         batch,
+		priorities:    sub.definition.Options.Priority,
+		starvation:    config.GetInt(coreconfig.EventDispatcherPriorityStarvationLimit),
+		queuedLowest:  -1,
 	}
 
 	pollerConf := &eventPollerConf{
@@ -234,28 +245,22 @@ func (ed *eventDispatcher) bufferedDelivery(events []core.LocallySequenced) (boo
 		return false, err
 	}
 
-	matching := ed.filterEvents(candidates)
-	matchCount := len(matching)
+	queue := newDispatchQueue(ed.filterEvents(candidates), ed.priorities, ed.starvation)
+	matchCount := queue.len()
 	dispatched := 0
 
 	// We stay here blocked until we've consumed all the messages in the buffer,
 	// or a reset event happens
 	for {
 		ed.mux.Lock()
-		var dispatchable []*core.EventDelivery
 		inflightCount := len(ed.inflight)
 		maxDispatch := 1 + ed.readAhead - inflightCount
-		if maxDispatch >= len(matching) {
-			dispatchable = matching
-			matching = nil
-		} else if maxDispatch > 0 {
-			dispatchable = matching[0:maxDispatch]
-			matching = matching[maxDispatch:]
-		}
+		dispatchable := queue.take(maxDispatch)
+		ed.queuedLowest = queue.lowestSequence()
 		ed.mux.Unlock()
 
 		l.Debugf("Dispatcher event state: readahead=%d candidates=%d matched=%d inflight=%d queued=%d dispatched=%d dispatchable=%d lastAck=%d nacks=%d highest=%d",
-			ed.readAhead, len(candidates), matchCount, inflightCount, len(matching), dispatched, len(dispatchable), lastAck, nacks, highestOffset)
+			ed.readAhead, len(candidates), matchCount, inflightCount, queue.len(), dispatched, len(dispatchable), lastAck, nacks, highestOffset)
 
 		for _, event := range dispatchable {
 			ed.mux.Lock()
@@ -307,8 +312,13 @@ func (ed *eventDispatcher) handleNackOffsetUpdate(nack ackNack) {
 	// even if we've delivered messages after that.
 	// That means resetting the polling offest, and clearing out all our state
 	delete(ed.inflight, nack.id)
-	if ed.eventPoller.pollingOffset > nack.offset {
-		ed.eventPoller.rewindPollingOffset(nack.offset - 1)
+	rewindTo := nack.offset
+	if ed.queuedLowest >= 0 && ed.queuedLowest < rewindTo {
+		// Prioritized dispatch means lower sequence events might still be waiting
+		rewindTo = ed.queuedLowest
+	}
+	if ed.eventPoller.pollingOffset > rewindTo {
+		ed.eventPoller.rewindPollingOffset(rewindTo - 1)
 	}
 	ed.inflight = map[fftypes.UUID]*core.Event{}
 }
@@ -317,7 +327,9 @@ func (ed *eventDispatcher) handleAckOffsetUpdate(ack ackNack) {
 	oldOffset := ed.eventPoller.getPollingOffset()
 	ed.mux.Lock()
 	delete(ed.inflight, ack.id)
-	lowestInflight := int64(-1)
+	// Events waiting for dispatch also hold back the offset, as prioritized
+	// dispatch means they might have a lower sequence than the acknowledged event
+	lowestInflight := ed.queuedLowest
 	for _, inflight := range ed.inflight {
 		if lowestInflight < 0 || inflight.Sequence < lowestInflight {
 			lowestInflight = inflight.Sequence
@@ -352,6 +364,9 @@ func (ed *eventDispatcher) deliverEvents() {
 				// The first error we encounter stops us attempting to enrich or dispatch any more events
 				if err == nil {
 					log.L(ed.ctx).Debugf("Dispatching %s event: %.10d/%s [%s]: ref=%s/%s", ed.transport.Name(), e.Event.Sequence, e.Event.ID, e.Event.Type, e.Event.Namespace, e.Event.Reference)
+					if ed.metrics.IsMetricsEnabled() && e.Event.Created != nil {
+						ed.metrics.EventDispatched(ed.namespace, ed.transport.Name(), time.Since(*e.Event.Created.Time()))
+					}
 					if withData && e.Event.Message != nil {
 						e.Data, _, err = ed.data.GetMessageDataCached(ed.ctx, e.Event.Message)
 					}
@@ -415,6 +430,9 @@ func (ed *eventDispatcher) deliveryResponse(response *core.EventDeliveryResponse
 	}
 
 	l.Debugf("Response for %s event: %.10d/%s [%s]: ref=%s/%s rejected=%t info='%s'", ed.transport.Name(), event.Sequence, event.ID, event.Type, event.Namespace, event.Reference, response.Rejected, response.Info)
+	if !response.Rejected && !ed.subscription.definition.Ephemeral {
+		ed.sla.recordAck(ed.subscription.definition.ID, event, time.Now())
+	}
 	// We don't do any meaningful work in this call, we just set things up so the right thing
 	// will happen when the poller wakes up. So we need to pass it over
 	select {
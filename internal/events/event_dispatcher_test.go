@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/mocks/syncasyncmocks"
@@ -52,13 +53,15 @@ func newTestEventDispatcher(sub *subscription) (*eventDispatcher, func()) {
 	mbm := &broadcastmocks.Manager{}
 	mpm := &privatemessagingmocks.Manager{}
 	mom := &operationmocks.Manager{}
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
 	enricher := newEventEnricher("ns1", mdi, mdm, mom, txHelper)
 	ctx, cancel := context.WithCancel(context.Background())
-	return newEventDispatcher(ctx, enricher, mei, mdi, mdm, mbm, mpm, fftypes.NewUUID().String(), sub, newEventNotifier(ctx, "ut"), txHelper), func() {
+	return newEventDispatcher(ctx, enricher, mei, mdi, mdm, mbm, mpm, fftypes.NewUUID().String(), sub, newEventNotifier(ctx, "ut"), txHelper, mmi, newSLAReporter(ctx, "ns1", mdi)), func() {
 		cancel()
 		coreconfig.Reset()
 	}
@@ -1093,6 +1096,91 @@ func TestBufferedDeliveryFailNack(t *testing.T) {
 
 }
 
+func TestNackRewindsToQueuedLowest(t *testing.T) {
+
+	sub := &subscription{
+		definition: &core.Subscription{},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	ed.eventPoller.pollingOffset = 100050
+	ed.queuedLowest = 100003
+	ed.handleNackOffsetUpdate(ackNack{id: *fftypes.NewUUID(), isNack: true, offset: 100010})
+	assert.Equal(t, int64(100002), ed.eventPoller.pollingOffset)
+}
+
+func TestAckHeldBackByQueued(t *testing.T) {
+
+	sub := &subscription{
+		definition: &core.Subscription{},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	ed.eventPoller.pollingOffset = 100000
+	ed.queuedLowest = 100003
+	ed.handleAckOffsetUpdate(ackNack{id: *fftypes.NewUUID(), offset: 100010})
+	assert.Equal(t, int64(100000), ed.eventPoller.pollingOffset)
+}
+
+func TestBufferedDeliveryPriority(t *testing.T) {
+
+	sub := &subscription{
+		definition: &core.Subscription{
+			Options: core.SubscriptionOptions{
+				SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+					Priority: map[string]int{
+						core.EventTypeMessageRejected.String(): 10,
+					},
+				},
+			},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+	go ed.deliverEvents()
+
+	mdi := ed.database.(*databasemocks.Plugin)
+	mdm := ed.data.(*datamocks.Manager)
+	mei := ed.transport.(*eventsmocks.Plugin)
+	mdi.On("GetTokenTransferByID", mock.Anything, "ns1", mock.Anything).Return(&core.TokenTransfer{}, nil)
+	mdm.On("GetMessageWithDataCached", mock.Anything, mock.Anything).Return(&core.Message{}, nil, true, nil)
+	mdi.On("UpdateOffset", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	delivered := make(chan *core.EventDelivery)
+	deliver := mei.On("DeliveryRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	deliver.RunFn = func(a mock.Arguments) {
+		delivered <- a[3].(*core.EventDelivery)
+	}
+
+	bdDone := make(chan struct{})
+	ev1 := fftypes.NewUUID()
+	ev2 := fftypes.NewUUID()
+	ed.eventPoller.pollingOffset = 100000
+	go func() {
+		repoll, err := ed.bufferedDelivery([]core.LocallySequenced{
+			&core.Event{ID: ev1, Sequence: 100001, Type: core.EventTypeTransferConfirmed},
+			&core.Event{ID: ev2, Sequence: 100002, Type: core.EventTypeMessageRejected},
+		})
+		assert.NoError(t, err)
+		assert.True(t, repoll)
+		close(bdDone)
+	}()
+
+	// The rejection jumps the queue, but cannot move the offset past the waiting transfer
+	ed1 := <-delivered
+	assert.Equal(t, ev2, ed1.ID)
+	ed.deliveryResponse(&core.EventDeliveryResponse{ID: ev2})
+
+	ed2 := <-delivered
+	assert.Equal(t, ev1, ed2.ID)
+	ed.deliveryResponse(&core.EventDeliveryResponse{ID: ev1})
+
+	<-bdDone
+	assert.Equal(t, int64(100002), ed.eventPoller.pollingOffset)
+}
+
 func TestAckNotInFlightNoop(t *testing.T) {
 
 	sub := &subscription{
@@ -1200,6 +1288,45 @@ func TestDeliverEventsWithDataFail(t *testing.T) {
 
 }
 
+func TestDeliverEventsMetrics(t *testing.T) {
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+		},
+	}
+
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("EventDispatched", "ns1", "ut", mock.Anything).Return()
+	ed.metrics = mmi
+
+	mei := ed.transport.(*eventsmocks.Plugin)
+	mei.On("DeliveryRequest", mock.Anything, ed.connID, sub.definition, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	id1 := fftypes.NewUUID()
+	ed.eventDelivery <- []*core.EventDelivery{
+		{
+			EnrichedEvent: core.EnrichedEvent{
+				Event: core.Event{
+					ID:      id1,
+					Created: fftypes.Now(),
+				},
+			},
+		},
+	}
+
+	ed.inflight[*id1] = &core.Event{ID: id1}
+	go ed.deliverEvents()
+
+	an := <-ed.acksNacks
+	assert.True(t, an.isNack)
+
+	mmi.AssertExpectations(t)
+}
+
 func TestEventDispatcherWithReply(t *testing.T) {
 	log.SetLevel("debug")
 	var two = uint16(5)
@@ -1303,3 +1430,33 @@ func TestEventDeliveryBatch(t *testing.T) {
 	mbm.AssertExpectations(t)
 	mms.AssertExpectations(t)
 }
+
+func TestDeliveryResponseRecordsSLA(t *testing.T) {
+	coreconfig.Reset()
+	sub := &subscription{
+		definition: &core.Subscription{
+			SubscriptionRef: core.SubscriptionRef{ID: fftypes.NewUUID(), Namespace: "ns1", Name: "sub1"},
+		},
+	}
+	ed, cancel := newTestEventDispatcher(sub)
+	defer cancel()
+
+	event1 := &core.Event{ID: fftypes.NewUUID(), Sequence: 1, Created: fftypes.Now()}
+	event2 := &core.Event{ID: fftypes.NewUUID(), Sequence: 2, Created: fftypes.Now()}
+	ed.inflight[*event1.ID] = event1
+	ed.inflight[*event2.ID] = event2
+
+	go ed.deliveryResponse(&core.EventDeliveryResponse{ID: event1.ID, Rejected: true})
+	an := <-ed.acksNacks
+	assert.True(t, an.isNack)
+	assert.Empty(t, ed.sla.pending)
+
+	go ed.deliveryResponse(&core.EventDeliveryResponse{ID: event2.ID})
+	an = <-ed.acksNacks
+	assert.False(t, an.isNack)
+	assert.Len(t, ed.sla.pending, 1)
+	for _, sla := range ed.sla.pending {
+		assert.Equal(t, sub.definition.ID, sla.Subscription)
+		assert.Equal(t, int64(1), sla.Delivered)
+	}
+}
@@ -68,7 +68,7 @@ func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*c
 			return nil, err
 		}
 		e.Transaction = tx
-	case core.EventTypeMessageConfirmed, core.EventTypeMessageRejected:
+	case core.EventTypeMessageConfirmed, core.EventTypeMessageRejected, core.EventTypeMessageQuarantined, core.EventTypeMessageValidationWarning, core.EventTypeAggregationGapDetected:
 		msg, _, _, err := em.data.GetMessageWithDataCached(ctx, event.Reference)
 		if err != nil {
 			return nil, err
@@ -98,12 +98,18 @@ func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*c
 			return nil, err
 		}
 		e.Datatype = dt
-	case core.EventTypeIdentityConfirmed, core.EventTypeIdentityUpdated:
+	case core.EventTypeIdentityConfirmed, core.EventTypeIdentityUpdated, core.EventTypeVerifierRevoked, core.EventTypeNodeConnectivityChanged:
 		identity, err := em.database.GetIdentityByID(ctx, em.namespace, event.Reference)
 		if err != nil {
 			return nil, err
 		}
 		e.Identity = identity
+	case core.EventTypeDelegationConfirmed:
+		delegation, err := em.database.GetDelegationByID(ctx, em.namespace, event.Reference)
+		if err != nil {
+			return nil, err
+		}
+		e.Delegation = delegation
 	case core.EventTypePoolConfirmed:
 		tokenPool, err := em.database.GetTokenPoolByID(ctx, em.namespace, event.Reference)
 		if err != nil {
@@ -124,11 +130,13 @@ func (em *eventEnricher) enrichEvent(ctx context.Context, event *core.Event) (*c
 		e.TokenTransfer = transfer
 	case core.EventTypeApprovalOpFailed,
 		core.EventTypeTransferOpFailed,
+		core.EventTypeTransferDenied,
 		core.EventTypePoolOpFailed,
 		core.EventTypeBlockchainInvokeOpFailed,
 		core.EventTypeBlockchainInvokeOpSucceeded,
 		core.EventTypeBlockchainContractDeployOpFailed,
-		core.EventTypeBlockchainContractDeployOpSucceeded:
+		core.EventTypeBlockchainContractDeployOpSucceeded,
+		core.EventTypeOperationStalled:
 		operation, err := em.operations.GetOperationByIDCached(ctx, event.Reference)
 		if err != nil {
 			return nil, err
@@ -144,6 +144,31 @@ func TestEnrichMessageRejected(t *testing.T) {
 	assert.Equal(t, ref1, enriched.Message.Header.ID)
 }
 
+func TestEnrichMessageQuarantined(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdm := em.data.(*datamocks.Manager)
+	mdm.On("GetMessageWithDataCached", mock.Anything, ref1).Return(&core.Message{
+		Header: core.MessageHeader{ID: ref1},
+	}, nil, true, nil)
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeMessageQuarantined,
+		Reference: ref1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ref1, enriched.Message.Header.ID)
+}
+
 func TestEnrichTxSubmitted(t *testing.T) {
 	em := newTestEventEnricher()
 	ctx := context.Background()
@@ -406,6 +431,34 @@ func TestEnrichIdentityConfirmed(t *testing.T) {
 	assert.Equal(t, ref1, enriched.Identity.IdentityBase.ID)
 }
 
+func TestEnrichNodeConnectivityChanged(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", mock.Anything, "ns1", ref1).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:   ref1,
+			Type: core.IdentityTypeNode,
+		},
+	}, nil)
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeNodeConnectivityChanged,
+		Reference: ref1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ref1, enriched.Identity.IdentityBase.ID)
+}
+
 func TestEnrichIdentityConfirmedFail(t *testing.T) {
 	em := newTestEventEnricher()
 	ctx := context.Background()
@@ -428,6 +481,80 @@ func TestEnrichIdentityConfirmedFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestEnrichDelegationConfirmed(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegationByID", mock.Anything, "ns1", ref1).Return(&core.Delegation{
+		ID: ref1,
+	}, nil)
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeDelegationConfirmed,
+		Reference: ref1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ref1, enriched.Delegation.ID)
+}
+
+func TestEnrichDelegationConfirmedFail(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegationByID", mock.Anything, "ns1", ref1).Return(nil, fmt.Errorf("pop"))
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeDelegationConfirmed,
+		Reference: ref1,
+	}
+
+	_, err := em.enrichEvent(ctx, event)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestEnrichVerifierRevoked(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mdi := em.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", mock.Anything, "ns1", ref1).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID: ref1,
+		},
+	}, nil)
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeVerifierRevoked,
+		Reference: ref1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ref1, enriched.Identity.IdentityBase.ID)
+}
+
 func TestEnrichTokenPoolConfirmed(t *testing.T) {
 	em := newTestEventEnricher()
 	ctx := context.Background()
@@ -597,6 +724,31 @@ func TestEnrichTokenTransferFailed(t *testing.T) {
 	assert.Equal(t, ref1, enriched.Operation.ID)
 }
 
+func TestEnrichOperationStalled(t *testing.T) {
+	em := newTestEventEnricher()
+	ctx := context.Background()
+
+	// Setup the IDs
+	ref1 := fftypes.NewUUID()
+	ev1 := fftypes.NewUUID()
+
+	// Setup enrichment
+	mom := em.operations.(*operationmocks.Manager)
+	mom.On("GetOperationByIDCached", mock.Anything, ref1).Return(&core.Operation{
+		ID: ref1,
+	}, nil)
+
+	event := &core.Event{
+		ID:        ev1,
+		Type:      core.EventTypeOperationStalled,
+		Reference: ref1,
+	}
+
+	enriched, err := em.enrichEvent(ctx, event)
+	assert.NoError(t, err)
+	assert.Equal(t, ref1, enriched.Operation.ID)
+}
+
 func TestEnrichTokenTransferConfirmedFail(t *testing.T) {
 	em := newTestEventEnricher()
 	ctx := context.Background()
@@ -53,6 +53,7 @@ import (
 )
 
 type EventManager interface {
+	AttachBatchPayload(ctx context.Context, batch *core.Batch) (*core.BatchPersisted, error)
 	NewPins() chan<- int64
 	NewEvents() chan<- int64
 	NewSubscriptions() chan<- *fftypes.UUID
@@ -63,7 +64,10 @@ type EventManager interface {
 	EnrichEvent(ctx context.Context, event *core.Event) (*core.EnrichedEvent, error)
 	EnrichEvents(ctx context.Context, events []*core.Event) ([]*core.EnrichedEvent, error)
 	FilterHistoricalEventsOnSubscription(ctx context.Context, events []*core.EnrichedEvent, sub *core.Subscription) ([]*core.EnrichedEvent, error)
+	TestSubscriptionFilter(ctx context.Context, sub *core.Subscription, event *core.EnrichedEvent) (*core.SubscriptionTestResult, error)
 	QueueBatchRewind(batchID *fftypes.UUID)
+	ReviewQuarantinedMessage(ctx context.Context, msgID string, review *core.QuarantineReview) (*core.Message, error)
+	RevalidateQuarantineEntry(ctx context.Context, entryID string) (*core.QuarantineRevalidation, error)
 	ResolveTransportAndCapabilities(ctx context.Context, transportName string) (string, *events.Capabilities, error)
 	Start() error
 	WaitStop()
@@ -107,13 +111,16 @@ type eventManager struct {
 	assets             assets.Manager
 	sharedDownload     shareddownload.Manager // optional
 	blobReceiver       *blobReceiver          // optional
+	dxSequencer        *dxSequencer
 	newEventNotifier   *eventNotifier
 	newPinNotifier     *eventNotifier
 	defaultTransport   string
 	internalEvents     *system.Events
 	metrics            metrics.Manager
 	chainListenerCache cache.CInterface
+	eventDecoderCache  cache.CInterface
 	multiparty         multiparty.Manager // optional
+	messageCallbacks   *messageCallbacks  // optional
 }
 
 func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin, bi blockchain.Plugin, im identity.Manager, dh definitions.Handler, dm data.Manager, ds definitions.Sender, bm broadcast.Manager, pm privatemessaging.Manager, am assets.Manager, sd shareddownload.Manager, mm metrics.Manager, om operations.Manager, txHelper txcommon.Helper, transports map[string]events.Plugin, mp multiparty.Manager, cacheManager cache.Manager) (EventManager, error) {
@@ -135,6 +142,18 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 		return nil, err
 	}
 
+	eventDecoderCache, err := cacheManager.GetCache(
+		cache.NewCacheConfig(
+			ctx,
+			coreconfig.CacheEventDecoderLimit,
+			coreconfig.CacheEventDecoderTTL,
+			ns.Name,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	em := &eventManager{
 		ctx:            log.WithLogField(ctx, "role", "event-manager"),
 		namespace:      ns,
@@ -159,11 +178,13 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 		newPinNotifier:     newPinNotifier,
 		metrics:            mm,
 		chainListenerCache: eventListenerCache,
+		eventDecoderCache:  eventDecoderCache,
 	}
+	em.dxSequencer = newDXSequencer(em.ctx, config.GetDuration(coreconfig.EventDXReorderTimeout), em.messageReceived)
 	ie, _ := eifactory.GetPlugin(ctx, system.SystemEventsTransport)
 	em.internalEvents = ie.(*system.Events)
 	if bi != nil {
-		aggregator, err := newAggregator(ctx, ns.Name, di, bi, pm, dh, im, dm, newPinNotifier, mm, cacheManager)
+		aggregator, err := newAggregator(ctx, ns.Name, di, bi, mp, pm, dh, im, dm, newPinNotifier, mm, cacheManager)
 		if err != nil {
 			return nil, err
 		}
@@ -172,8 +193,9 @@ func NewEventManager(ctx context.Context, ns *core.Namespace, di database.Plugin
 	}
 
 	em.enricher = newEventEnricher(ns.Name, di, dm, om, txHelper)
+	em.messageCallbacks = newMessageCallbacks(ctx, transports)
 
-	if em.subManager, err = newSubscriptionManager(ctx, ns, em.enricher, di, dm, newEventNotifier, bm, pm, txHelper, transports); err != nil {
+	if em.subManager, err = newSubscriptionManager(ctx, ns, em.enricher, di, dm, newEventNotifier, bm, pm, txHelper, mm, transports); err != nil {
 		return nil, err
 	}
 
@@ -187,6 +209,9 @@ func (em *eventManager) Start() (err error) {
 			em.aggregator.start()
 			em.blobReceiver.start()
 		}
+		if em.messageCallbacks != nil {
+			err = em.AddSystemEventListener(em.namespace.Name, em.messageCallbacks.eventReceived)
+		}
 	}
 	return err
 }
@@ -249,7 +274,12 @@ func (em *eventManager) CreateUpdateDurableSubscription(ctx context.Context, sub
 		if mustNew {
 			return i18n.NewError(ctx, coremsgs.MsgAlreadyExists, "subscription", subDef.Namespace, subDef.Name)
 		}
+		// A subscription stays bound to the principal that created it
+		if existing.Owner != "" && existing.Owner != subDef.Owner {
+			return i18n.NewError(ctx, coremsgs.MsgSubscriptionOwnerMismatch, subDef.Name)
+		}
 		// Copy over the generated fields, so we can do a compare
+		subDef.Owner = existing.Owner
 		subDef.Created = existing.Created
 		subDef.ID = existing.ID
 		subDef.Updated = fftypes.Now()
@@ -327,3 +357,14 @@ func (em *eventManager) FilterHistoricalEventsOnSubscription(ctx context.Context
 
 	return matchingEvents, nil
 }
+
+func (em *eventManager) TestSubscriptionFilter(ctx context.Context, sub *core.Subscription, event *core.EnrichedEvent) (*core.SubscriptionTestResult, error) {
+	// As with historical events, only the filters are needed so validate against a fake transport
+	subCopy := *sub
+	subCopy.Transport = "websockets"
+	subscriptionDef, err := em.subManager.parseSubscriptionDef(ctx, &subCopy)
+	if err != nil {
+		return nil, err
+	}
+	return subscriptionDef.explainMatch(event), nil
+}
@@ -144,7 +144,10 @@ func newTestEventManagerCommon(t *testing.T, metrics, dbconcurrency bool) *testE
 	}
 	met.On("Name").Return("ut").Maybe()
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress).Maybe()
+	mim.On("IsVerifierRevoked", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	mim.On("IsVerifierRevokedAtPin", mock.Anything, mock.Anything, mock.Anything).Return(false, nil).Maybe()
 	mdi.On("Capabilities").Return(&database.Capabilities{Concurrency: dbconcurrency}).Maybe()
+	mmp.On("CheckContractMigration", mock.Anything, mock.Anything).Return(nil).Maybe()
 	mev.On("SetHandler", "ns1", mock.Anything).Return(nil).Maybe()
 	mev.On("ValidateOptions", mock.Anything, mock.Anything).Return(nil).Maybe()
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
@@ -240,6 +243,12 @@ func TestAggregatorCacheInitFail(t *testing.T) {
 		coreconfig.CacheEventListenerTopicTTL,
 		ns.Name,
 	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheEventDecoderLimit,
+		coreconfig.CacheEventDecoderTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	cmi.On("GetCache", cache.NewCacheConfig(
 		ctx,
 		coreconfig.CacheTransactionSize,
@@ -316,6 +325,61 @@ func TestEventCacheInitFail(t *testing.T) {
 	assert.Equal(t, cacheInitError, err)
 }
 
+func TestEventDecoderCacheInitFail(t *testing.T) {
+	cacheInitError := errors.New("Initialization error.")
+	config.Set(coreconfig.EventTransportsEnabled, []string{"wrongun"})
+	defer coreconfig.Reset()
+	mdi := &databasemocks.Plugin{}
+	mbi := &blockchainmocks.Plugin{}
+	mim := &identitymanagermocks.Manager{}
+	mdm := &datamocks.Manager{}
+	msh := &definitionsmocks.Handler{}
+	mds := &definitionsmocks.Sender{}
+	mbm := &broadcastmocks.Manager{}
+	mpm := &privatemessagingmocks.Manager{}
+	mam := &assetmocks.Manager{}
+	msd := &shareddownloadmocks.Manager{}
+	mm := &metricsmocks.Manager{}
+	mom := &operationmocks.Manager{}
+	mev := &eventsmocks.Plugin{}
+	events := map[string]events.Plugin{"websockets": mev}
+	mmp := &multipartymocks.Manager{}
+	ctx := context.Background()
+	cmi := &cachemocks.Manager{}
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheEventListenerTopicLimit,
+		coreconfig.CacheEventListenerTopicTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheEventDecoderLimit,
+		coreconfig.CacheEventDecoderTTL,
+		ns.Name,
+	)).Return(nil, cacheInitError)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheTransactionSize,
+		coreconfig.CacheTransactionTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	cmi.On("GetCache", cache.NewCacheConfig(
+		ctx,
+		coreconfig.CacheBlockchainEventLimit,
+		coreconfig.CacheBlockchainEventTTL,
+		ns.Name,
+	)).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := txcommon.NewTransactionHelper(ctx, ns.Name, mdi, mdm, cmi)
+	mdi.On("Capabilities").Return(&database.Capabilities{Concurrency: false})
+	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	mev.On("SetHandler", "ns1", mock.Anything).Return(nil).Maybe()
+	mev.On("ValidateOptions", mock.Anything).Return(nil).Maybe()
+	_, err := NewEventManager(context.Background(), ns, mdi, mbi, mim, msh, mdm, mds, mbm, mpm, mam, msd, mm, mom, txHelper, events, mmp, cmi)
+	assert.Equal(t, cacheInitError, err)
+}
+
 func TestStartStopEventListenerFail(t *testing.T) {
 	config.Set(coreconfig.EventTransportsEnabled, []string{"wrongun"})
 	defer coreconfig.Reset()
@@ -543,6 +607,29 @@ func TestUpdateDurableSubscriptionOk(t *testing.T) {
 	assert.Equal(t, "12345", string(*sub.Options.FirstEvent))
 }
 
+func TestUpdateDurableSubscriptionOtherOwner(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	sub := &core.Subscription{
+		Transport: "websockets",
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Namespace: "ns1",
+			Name:      "sub1",
+		},
+		Owner: "basic:user2",
+	}
+	em.mdi.On("GetSubscriptionByName", mock.Anything, "ns1", "sub1").Return(&core.Subscription{
+		Transport: "websockets",
+		SubscriptionRef: core.SubscriptionRef{
+			ID: fftypes.NewUUID(),
+		},
+		Owner: "basic:user1",
+	}, nil)
+	err := em.CreateUpdateDurableSubscription(em.ctx, sub, false)
+	assert.Regexp(t, "FF10598", err)
+}
+
 func TestUpdateDurableSubscriptionNoOp(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -844,3 +931,104 @@ func TestEventFilterOnSubscriptionFailsWithBadRegex(t *testing.T) {
 	_, err = em.FilterHistoricalEventsOnSubscription(context.Background(), events, subscription)
 	assert.NotNil(t, err)
 }
+
+func TestTestSubscriptionFilterMatched(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	listenerID := fftypes.NewUUID()
+	group := fftypes.NewRandB32()
+	event := &core.EnrichedEvent{
+		Event: core.Event{
+			Type:  core.EventTypeMessageConfirmed,
+			Topic: "topic1",
+		},
+		Message: &core.Message{
+			Header: core.MessageHeader{
+				Tag:       "tag1",
+				Group:     group,
+				SignerRef: core.SignerRef{Author: "did:firefly:org/org1"},
+			},
+		},
+		Transaction:     &core.Transaction{Type: core.TransactionTypeBatchPin},
+		BlockchainEvent: &core.BlockchainEvent{Name: "Changed", Listener: listenerID},
+	}
+	sub := &core.Subscription{
+		Transport: "webhooks",
+		Filter: core.SubscriptionFilter{
+			Events: "message_.*",
+			Topic:  "^topic1$",
+			Message: core.MessageFilter{
+				Tag:    "tag.*",
+				Author: "org1",
+				Group:  group.String(),
+			},
+			Transaction:     core.TransactionFilter{Type: "batch_pin"},
+			BlockchainEvent: core.BlockchainEventFilter{Name: "Changed", Listener: listenerID.String()},
+		},
+	}
+
+	result, err := em.TestSubscriptionFilter(context.Background(), sub, event)
+	assert.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Equal(t, event, result.Event)
+	assert.Len(t, result.Filters, 8)
+	for _, f := range result.Filters {
+		assert.True(t, f.Matched, f.Filter)
+	}
+	assert.Equal(t, "webhooks", sub.Transport)
+
+	sm, err := em.subManager.parseSubscriptionDef(context.Background(), &core.Subscription{Transport: "websockets", Filter: sub.Filter})
+	assert.NoError(t, err)
+	assert.True(t, sm.MatchesEvent(event))
+}
+
+func TestTestSubscriptionFilterNotMatched(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	event := &core.EnrichedEvent{
+		Event: core.Event{
+			Type:  core.EventTypeMessageConfirmed,
+			Topic: "topic1",
+		},
+	}
+	sub := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Events:        "message_confirmed",
+			DeprecatedTag: "tag1",
+		},
+	}
+
+	result, err := em.TestSubscriptionFilter(context.Background(), sub, event)
+	assert.NoError(t, err)
+	assert.False(t, result.Matched)
+	assert.Equal(t, []*core.SubscriptionFilterResult{
+		{Filter: "events", Pattern: "message_confirmed", Value: "message_confirmed", Matched: true},
+		{Filter: "message.tag", Pattern: "tag1", Value: "", Matched: false},
+	}, result.Filters)
+}
+
+func TestTestSubscriptionFilterNoFilters(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	result, err := em.TestSubscriptionFilter(context.Background(), &core.Subscription{}, &core.EnrichedEvent{})
+	assert.NoError(t, err)
+	assert.True(t, result.Matched)
+	assert.Empty(t, result.Filters)
+}
+
+func TestTestSubscriptionFilterBadRegex(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	sub := &core.Subscription{
+		Filter: core.SubscriptionFilter{
+			Topic: "^(a(b)c$",
+		},
+	}
+
+	_, err := em.TestSubscriptionFilter(context.Background(), sub, &core.EnrichedEvent{})
+	assert.Regexp(t, "FF10171", err)
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+)
+
+// callbackTransport is implemented by event transports that can make a one-off delivery of an event to a URL
+type callbackTransport interface {
+	DeliverCallback(ctx context.Context, callbackURL string, event *core.EventDelivery) error
+}
+
+// messageCallbacks delivers the final state of messages submitted with a callback URL, for applications
+// that cannot hold a subscription open. Deliveries are best-effort, using the webhooks transport.
+type messageCallbacks struct {
+	ctx       context.Context
+	transport callbackTransport
+}
+
+func newMessageCallbacks(ctx context.Context, transports map[string]events.Plugin) *messageCallbacks {
+	transport, ok := transports["webhooks"].(callbackTransport)
+	if !ok {
+		return nil
+	}
+	return &messageCallbacks{
+		ctx:       log.WithLogField(ctx, "role", "message-callbacks"),
+		transport: transport,
+	}
+}
+
+func (mc *messageCallbacks) eventReceived(event *core.EventDelivery) error {
+	switch event.Type {
+	case core.EventTypeMessageConfirmed, core.EventTypeMessageRejected:
+		if event.Message != nil && event.Message.Callback != "" {
+			// Deliver in the background, so a slow callback does not hold up the system listener
+			go mc.deliver(event)
+		}
+	}
+	return nil
+}
+
+func (mc *messageCallbacks) deliver(event *core.EventDelivery) {
+	callbackURL := event.Message.Callback
+	if err := mc.transport.DeliverCallback(mc.ctx, callbackURL, event); err != nil {
+		log.L(mc.ctx).Errorf("Failed to deliver callback for message %s to '%s': %s", event.Message.Header.ID, callbackURL, err)
+		return
+	}
+	log.L(mc.ctx).Infof("Delivered callback for message %s (%s) to '%s'", event.Message.Header.ID, event.Type, callbackURL)
+}
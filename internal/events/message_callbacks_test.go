@@ -0,0 +1,145 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/events/webhooks"
+	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testCallbackTransport struct {
+	delivered chan *core.EventDelivery
+	err       error
+}
+
+func (tct *testCallbackTransport) DeliverCallback(ctx context.Context, callbackURL string, event *core.EventDelivery) error {
+	tct.delivered <- event
+	return tct.err
+}
+
+func newTestMessageCallbacks(err error) (*messageCallbacks, *testCallbackTransport) {
+	tct := &testCallbackTransport{
+		delivered: make(chan *core.EventDelivery, 1),
+		err:       err,
+	}
+	return &messageCallbacks{
+		ctx:       context.Background(),
+		transport: tct,
+	}, tct
+}
+
+func TestNewMessageCallbacks(t *testing.T) {
+	mc := newMessageCallbacks(context.Background(), map[string]events.Plugin{
+		"webhooks": &webhooks.WebHooks{},
+	})
+	assert.NotNil(t, mc)
+
+	mc = newMessageCallbacks(context.Background(), map[string]events.Plugin{
+		"websockets": &eventsmocks.Plugin{},
+	})
+	assert.Nil(t, mc)
+}
+
+func TestMessageCallbacksDelivered(t *testing.T) {
+	mc, tct := newTestMessageCallbacks(nil)
+
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:   fftypes.NewUUID(),
+				Type: core.EventTypeMessageConfirmed,
+			},
+			Message: &core.Message{
+				Header:   core.MessageHeader{ID: fftypes.NewUUID()},
+				Callback: "https://example.com/callback",
+			},
+		},
+	}
+	err := mc.eventReceived(event)
+	assert.NoError(t, err)
+
+	assert.Equal(t, event, <-tct.delivered)
+}
+
+func TestMessageCallbacksDeliveryFailed(t *testing.T) {
+	mc, tct := newTestMessageCallbacks(fmt.Errorf("pop"))
+
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:   fftypes.NewUUID(),
+				Type: core.EventTypeMessageRejected,
+			},
+			Message: &core.Message{
+				Header:   core.MessageHeader{ID: fftypes.NewUUID()},
+				Callback: "https://example.com/callback",
+			},
+		},
+	}
+	mc.deliver(event)
+
+	assert.Equal(t, event, <-tct.delivered)
+}
+
+func TestMessageCallbacksIgnored(t *testing.T) {
+	mc, tct := newTestMessageCallbacks(nil)
+
+	err := mc.eventReceived(&core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{Type: core.EventTypeMessageConfirmed},
+			Message: &core.Message{
+				Header: core.MessageHeader{ID: fftypes.NewUUID()},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = mc.eventReceived(&core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{Type: core.EventTypeTransactionSubmitted},
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Empty(t, tct.delivered)
+}
+
+func TestStartStopMessageCallbacks(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.messageCallbacks, _ = newTestMessageCallbacks(nil)
+	em.mdi.On("GetOffset", mock.Anything, core.OffsetTypeAggregator, aggregatorOffsetName).Return(&core.Offset{
+		Type:    core.OffsetTypeAggregator,
+		Name:    aggregatorOffsetName,
+		Current: 12345,
+		RowID:   333333,
+	}, nil)
+	em.mdi.On("GetPins", mock.Anything, "ns1", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	em.mdi.On("GetSubscriptions", mock.Anything, mock.Anything, mock.Anything).Return([]*core.Subscription{}, nil, nil)
+	assert.NoError(t, em.Start())
+	em.cancel()
+	em.WaitStop()
+}
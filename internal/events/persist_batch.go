@@ -18,6 +18,8 @@ package events
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -31,18 +33,19 @@ type messageAndData struct {
 }
 
 // persistBatch performs very simple validation on each message/data element (hashes) and either persists
-// or discards them. Errors are returned only in the case of database failures, which should be retried.
+// or quarantines them. Errors are returned only in the case of database failures, which should be retried.
 func (em *eventManager) persistBatch(ctx context.Context, batch *core.Batch) (persistedBatch *core.BatchPersisted, valid bool, err error) {
 	l := log.L(ctx)
 
 	if batch.ID == nil || batch.Payload.TX.ID == nil || batch.Hash == nil {
-		l.Errorf("Invalid batch. Missing ID (%v), transaction ID (%s) or hash (%s)", batch.ID, batch.Payload.TX.ID, batch.Hash)
-		return nil, false, nil // This is not retryable. skip this batch
+		err = em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil,
+			"Missing ID (%v), transaction ID (%s) or hash (%s)", batch.ID, batch.Payload.TX.ID, batch.Hash)
+		return nil, false, err // This is not retryable. skip this batch
 	}
 
 	if len(batch.Payload.Messages) == 0 {
-		l.Errorf("Invalid batch '%s'. No messages in batch.", batch.ID)
-		return nil, false, nil // This is not retryable. skip this batch
+		err = em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil, "No messages in batch")
+		return nil, false, err // This is not retryable. skip this batch
 	}
 
 	switch batch.Payload.TX.Type {
@@ -50,13 +53,17 @@ func (em *eventManager) persistBatch(ctx context.Context, batch *core.Batch) (pe
 		core.TransactionTypeUnpinned,
 		core.TransactionTypeContractInvokePin:
 	default:
-		l.Errorf("Invalid batch '%s'. Invalid transaction type: %s", batch.ID, batch.Payload.TX.Type)
-		return nil, false, nil // This is not retryable. skip this batch
+		err = em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil, "Invalid transaction type: %s", batch.Payload.TX.Type)
+		return nil, false, err // This is not retryable. skip this batch
 	}
 
 	// Set confirmed on the batch (the messages should not be confirmed at this point - that's the aggregator's job)
 	persistedBatch, manifest := batch.Confirmed()
-	manifestHash := fftypes.HashString(persistedBatch.Manifest.String())
+	manifestHash, err := manifest.Hash(ctx)
+	if err != nil {
+		err = em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil, "%s", err)
+		return nil, false, err // This is not retryable. skip this batch
+	}
 
 	// Verify the hash calculation.
 	if !manifestHash.Equals(batch.Hash) {
@@ -65,8 +72,9 @@ func (em *eventManager) persistBatch(ctx context.Context, batch *core.Batch) (pe
 		if batch.Payload.Hash().Equals(batch.Hash) {
 			l.Infof("Persisting migrated batch '%s'. Hash is a payload hash: %s", batch.ID, batch.Hash)
 		} else {
-			l.Errorf("Invalid batch '%s'. Hash does not match payload. Found=%s Expected=%s", batch.ID, manifestHash, batch.Hash)
-			return nil, false, nil // This is not retryable. skip this batch
+			err = em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil,
+				"Hash does not match payload. Found=%s Expected=%s", manifestHash, batch.Hash)
+			return nil, false, err // This is not retryable. skip this batch
 		}
 	}
 
@@ -95,8 +103,8 @@ func (em *eventManager) validateAndPersistBatchContent(ctx context.Context, batc
 	// Insert the data entries
 	dataByID := make(map[fftypes.UUID]*core.Data)
 	for i, data := range batch.Payload.Data {
-		if valid = em.validateBatchData(ctx, batch, i, data); !valid {
-			return false, nil
+		if valid, err = em.validateBatchData(ctx, batch, i, data); !valid || err != nil {
+			return false, err
 		}
 		if valid, err = em.checkAndInitiateBlobDownloads(ctx, batch, i, data); !valid || err != nil {
 			return false, err
@@ -107,8 +115,8 @@ func (em *eventManager) validateAndPersistBatchContent(ctx context.Context, batc
 
 	// Insert the message entries
 	for i, msg := range batch.Payload.Messages {
-		if valid = em.validateBatchMessage(ctx, batch, i, msg); !valid {
-			return false, nil
+		if valid, err = em.validateBatchMessage(ctx, batch, i, msg); !valid || err != nil {
+			return false, err
 		}
 	}
 
@@ -122,8 +130,9 @@ func (em *eventManager) validateAndPersistBatchContent(ctx context.Context, batc
 		for di, dataRef := range msg.Data {
 			msgData[di] = dataByID[*dataRef.ID]
 			if msgData[di] == nil || !msgData[di].Hash.Equals(dataRef.Hash) {
-				log.L(ctx).Errorf("Message '%s' in batch '%s' - data not in-line in batch id='%s' hash='%s'", msg.Header.ID, batch.ID, dataRef.ID, dataRef.Hash)
-				return false, nil
+				err = em.quarantineBatch(ctx, batch, core.QuarantineReasonDataHashMismatch, msg.Header.ID, dataRef.ID,
+					"Message '%s' data not in-line in batch id='%s' hash='%s'", msg.Header.ID, dataRef.ID, dataRef.Hash)
+				return false, err
 			}
 			matchedData[*dataRef.ID] = true
 		}
@@ -133,34 +142,33 @@ func (em *eventManager) validateAndPersistBatchContent(ctx context.Context, batc
 		}
 	}
 	if len(matchedData) != len(dataByID) {
-		log.L(ctx).Errorf("Batch '%s' contains %d unique data, but %d are referenced from messages", batch.ID, len(dataByID), len(matchedData))
-		return false, nil
+		err = em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil,
+			"Batch contains %d unique data, but %d are referenced from messages", len(dataByID), len(matchedData))
+		return false, err
 	}
 
 	return em.persistBatchContent(ctx, batch, matchedMsgs)
 }
 
-func (em *eventManager) validateBatchData(ctx context.Context, batch *core.Batch, i int, data *core.Data) bool {
+func (em *eventManager) validateBatchData(ctx context.Context, batch *core.Batch, i int, data *core.Data) (bool, error) {
 
 	l := log.L(ctx)
 	l.Tracef("Batch '%s' data %d: %+v", batch.ID, i, data)
 
 	if data == nil {
-		l.Errorf("null data entry %d in batch '%s'", i, batch.ID)
-		return false
+		return false, em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil, "null data entry %d", i)
 	}
 
 	hash, err := data.CalcHash(ctx)
 	if err != nil {
-		log.L(ctx).Errorf("Invalid data entry %d in batch '%s': %s", i, batch.ID, err)
-		return false
+		return false, em.quarantineBatch(ctx, batch, core.QuarantineReasonDataHashMismatch, nil, data.ID, "Invalid data entry %d: %s", i, err)
 	}
 	if data.Hash == nil || *data.Hash != *hash {
-		log.L(ctx).Errorf("Invalid data entry %d in batch '%s': Hash=%v Expected=%v", i, batch.ID, data.Hash, hash)
-		return false
+		return false, em.quarantineBatch(ctx, batch, core.QuarantineReasonDataHashMismatch, nil, data.ID,
+			"Invalid data entry %d: Hash=%v Expected=%v", i, data.Hash, hash)
 	}
 
-	return true
+	return true, nil
 }
 
 func (em *eventManager) checkAndInitiateBlobDownloads(ctx context.Context, batch *core.Batch, i int, data *core.Data) (bool, error) {
@@ -177,7 +185,7 @@ func (em *eventManager) checkAndInitiateBlobDownloads(ctx context.Context, batch
 				log.L(ctx).Errorf("Invalid data entry %d id=%s in batch '%s' - missing public blob reference", i, data.ID, batch.ID)
 				return false, nil
 			}
-			if err = em.sharedDownload.InitiateDownloadBlob(ctx, batch.Payload.TX.ID, data.ID, data.Blob.Public, false /* batch processing does not currently use idempotency keys */); err != nil {
+			if err = em.sharedDownload.InitiateDownloadBlob(ctx, batch.Payload.TX.ID, data.ID, data.Blob.Hash, data.Blob.Public, false /* batch processing does not currently use idempotency keys */); err != nil {
 				return false, err
 			}
 		}
@@ -187,17 +195,16 @@ func (em *eventManager) checkAndInitiateBlobDownloads(ctx context.Context, batch
 	return true, nil
 }
 
-func (em *eventManager) validateBatchMessage(ctx context.Context, batch *core.Batch, i int, msg *core.Message) bool {
+func (em *eventManager) validateBatchMessage(ctx context.Context, batch *core.Batch, i int, msg *core.Message) (bool, error) {
 
 	l := log.L(ctx)
 	if msg == nil {
-		l.Errorf("null message entry %d in batch '%s'", i, batch.ID)
-		return false
+		return false, em.quarantineBatch(ctx, batch, core.QuarantineReasonBatchInvalid, nil, nil, "null message entry %d", i)
 	}
 
 	if msg.Header.Author != batch.Author || msg.Header.Key != batch.Key {
-		log.L(ctx).Errorf("Mismatched key/author '%s'/'%s' on message entry %d in batch '%s'", msg.Header.Key, msg.Header.Author, i, batch.ID)
-		return false
+		return false, em.quarantineBatch(ctx, batch, core.QuarantineReasonMessageInvalid, msg.Header.ID, nil,
+			"Mismatched key/author '%s'/'%s' on message entry %d", msg.Header.Key, msg.Header.Author, i)
 	}
 	msg.LocalNamespace = em.namespace.Name
 	msg.BatchID = batch.ID
@@ -207,15 +214,14 @@ func (em *eventManager) validateBatchMessage(ctx context.Context, batch *core.Ba
 
 	err := msg.Verify(ctx)
 	if err != nil {
-		l.Errorf("Invalid message entry %d in batch '%s': %s", i, batch.ID, err)
-		return false
+		return false, em.quarantineBatch(ctx, batch, core.QuarantineReasonMessageInvalid, msg.Header.ID, nil, "Invalid message entry %d: %s", i, err)
 	}
 	// Set the state to pending, for the insertion stage
 	msg.State = core.MessageStatePending
 	// Remove any idempotency key
 	msg.IdempotencyKey = ""
 
-	return true
+	return true, nil
 }
 
 func (em *eventManager) sentByUs(ctx context.Context, batch *core.Batch) bool {
@@ -275,8 +281,9 @@ func (em *eventManager) persistBatchContent(ctx context.Context, batch *core.Bat
 		for i, data := range batch.Payload.Data {
 			if err := em.database.UpsertData(ctx, data, database.UpsertOptimizationExisting); err != nil {
 				if err == database.HashMismatch {
-					log.L(ctx).Errorf("Invalid data entry %d in batch '%s'. Hash mismatch with existing record with same UUID '%s' Hash=%s", i, batch.ID, data.ID, data.Hash)
-					return false, nil
+					err = em.quarantineBatch(ctx, batch, core.QuarantineReasonDataHashMismatch, nil, data.ID,
+						"Invalid data entry %d. Hash mismatch with existing record with same UUID '%s' Hash=%s", i, data.ID, data.Hash)
+					return false, err
 				}
 				log.L(ctx).Errorf("Failed to insert data entry %d in batch '%s': %s", i, batch.ID, err)
 				return false, err
@@ -306,8 +313,9 @@ func (em *eventManager) persistBatchContent(ctx context.Context, batch *core.Bat
 			}
 			if err = em.database.UpsertMessage(ctx, msg, database.UpsertOptimizationExisting, postHookUpdateMessageCache); err != nil {
 				if err == database.HashMismatch {
-					log.L(ctx).Errorf("Invalid message entry %d in batch'%s'. Hash mismatch with existing record with same UUID '%s' Hash=%s", i, batch.ID, msg.Header.ID, msg.Hash)
-					return false, nil // This is not retryable. skip this data entry
+					err = em.quarantineBatch(ctx, batch, core.QuarantineReasonMessageInvalid, msg.Header.ID, nil,
+						"Invalid message entry %d. Hash mismatch with existing record with same UUID '%s' Hash=%s", i, msg.Header.ID, msg.Hash)
+					return false, err // This is not retryable. skip this data entry
 				}
 				log.L(ctx).Errorf("Failed to insert message entry %d in batch '%s': %s", i, batch.ID, err)
 				return false, err // a persistence failure here is considered retryable (so returned)
@@ -317,3 +325,28 @@ func (em *eventManager) persistBatchContent(ctx context.Context, batch *core.Bat
 
 	return true, nil
 }
+
+// quarantineBatch records a batch that failed validation, along with the reason, so that it can be inspected,
+// re-validated or purged. The full batch is stored as the payload of the entry, as it is not persisted anywhere else.
+func (em *eventManager) quarantineBatch(ctx context.Context, batch *core.Batch, reason core.QuarantineReason, msgID, dataID *fftypes.UUID, detail string, args ...interface{}) error {
+	entry := &core.QuarantineEntry{
+		ID:        fftypes.NewUUID(),
+		Namespace: em.namespace.Name,
+		Reason:    reason,
+		Detail:    fmt.Sprintf(detail, args...),
+		Batch:     batch.ID,
+		Message:   msgID,
+		Data:      dataID,
+		Created:   fftypes.Now(),
+	}
+	if b, err := json.Marshal(batch); err == nil {
+		entry.Payload = fftypes.JSONAnyPtrBytes(b)
+	}
+	if revalidation, ok := ctx.Value(revalidationKey{}).(*core.QuarantineRevalidation); ok {
+		// We are re-validating an existing entry, so report the failure rather than adding another entry
+		revalidation.Detail = entry.Detail
+		return nil
+	}
+	log.L(ctx).Errorf("Quarantining invalid batch '%s' (%s): %s", batch.ID, reason, entry.Detail)
+	return em.database.InsertQuarantineEntry(ctx, entry)
+}
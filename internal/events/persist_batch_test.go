@@ -109,10 +109,51 @@ func TestPersistBatchAlreadyExisting(t *testing.T) {
 
 }
 
+func TestPersistBatchHashAlgorithm(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	existing := &core.BatchPersisted{}
+	em.mdi.On("InsertOrGetBatch", em.ctx, mock.Anything).Return(existing, nil)
+	em.mim.On("GetLocalNode", mock.Anything).Return(testNode, nil)
+	em.mdi.On("InsertDataArray", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertMessages", em.ctx, mock.Anything, mock.Anything).Return(nil, nil)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`), HashAlgorithm: core.HashAlgorithmBLAKE2b256}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batch.HashAlgorithm = core.HashAlgorithmBLAKE2b256
+	_, manifest := batch.Confirmed()
+	batch.Hash, _ = manifest.Hash(em.ctx)
+
+	result, valid, err := em.persistBatch(em.ctx, batch)
+	assert.True(t, valid)
+	assert.NoError(t, err)
+	assert.Equal(t, existing, result)
+
+}
+
+func TestPersistBatchHashAlgorithmUnsupported(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batch.HashAlgorithm = "md5"
+
+	_, valid, err := em.persistBatch(em.ctx, batch)
+	assert.False(t, valid)
+	assert.NoError(t, err)
+
+}
+
 func TestPersistBatchNoCacheDataNotInBatch(t *testing.T) {
 
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 
 	em.mdi.On("InsertOrGetBatch", em.ctx, mock.Anything).Return(nil, nil)
 
@@ -133,6 +174,7 @@ func TestPersistBatchExtraDataInBatch(t *testing.T) {
 
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 
 	em.mdi.On("InsertOrGetBatch", em.ctx, mock.Anything).Return(nil, nil)
 
@@ -154,9 +196,11 @@ func TestPersistBatchNilMessageEntryop(t *testing.T) {
 
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 
-	valid := em.validateBatchMessage(em.ctx, &core.Batch{}, 0, nil)
+	valid, err := em.validateBatchMessage(em.ctx, &core.Batch{}, 0, nil)
 	assert.False(t, valid)
+	assert.NoError(t, err)
 
 }
 
@@ -229,6 +273,7 @@ func TestPersistBatchContentSentByUsFoundMismatch(t *testing.T) {
 
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
@@ -303,6 +348,7 @@ func TestPersistBatchContentDataHashMismatch(t *testing.T) {
 
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 
 	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
 	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
@@ -343,6 +389,7 @@ func TestPersistBatchInvalidTXType(t *testing.T) {
 
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
+	em.mdi.On("InsertQuarantineEntry", mock.Anything, mock.Anything).Return(nil)
 
 	batch := &core.Batch{
 		BatchHeader: core.BatchHeader{
@@ -364,3 +411,24 @@ func TestPersistBatchInvalidTXType(t *testing.T) {
 	assert.False(t, ok)
 
 }
+
+func TestPersistBatchQuarantineBadHash(t *testing.T) {
+
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batch.Hash = fftypes.NewRandB32()
+	em.mdi.On("InsertQuarantineEntry", em.ctx, mock.MatchedBy(func(entry *core.QuarantineEntry) bool {
+		var quarantined core.Batch
+		err := json.Unmarshal(entry.Payload.Bytes(), &quarantined)
+		return err == nil && quarantined.Hash.Equals(batch.Hash) &&
+			entry.Reason == core.QuarantineReasonBatchInvalid && entry.Batch.Equals(batch.ID) && entry.Namespace == "ns1"
+	})).Return(fmt.Errorf("pop"))
+
+	_, valid, err := em.persistBatch(em.ctx, batch)
+	assert.False(t, valid)
+	assert.EqualError(t, err, "pop") // database errors recording the quarantine are retryable
+
+}
@@ -0,0 +1,185 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// ReviewQuarantinedMessage completes the processing of a message that was held for review, because its
+// data failed validation. Accepting the message confirms it despite the invalid data, and rejecting it
+// marks it rejected - with the same events the aggregator would have emitted for that decision.
+func (em *eventManager) ReviewQuarantinedMessage(ctx context.Context, msgID string, review *core.QuarantineReview) (*core.Message, error) {
+	id, err := fftypes.ParseUUID(ctx, msgID)
+	if err != nil {
+		return nil, err
+	}
+
+	newState := core.MessageStateConfirmed
+	eventType := core.EventTypeMessageConfirmed
+	switch review.Action {
+	case core.ReviewActionAccept:
+	case core.ReviewActionReject:
+		newState = core.MessageStateRejected
+		eventType = core.EventTypeMessageRejected
+	default:
+		return nil, i18n.NewError(ctx, i18n.MsgUnknownFieldValue, "action", review.Action)
+	}
+
+	var msg *core.Message
+	err = em.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		msg, err = em.database.GetMessageByID(ctx, em.namespace.Name, id)
+		if err != nil {
+			return err
+		}
+		if msg == nil {
+			return i18n.NewError(ctx, coremsgs.Msg404NotFound)
+		}
+		if msg.State != core.MessageStateQuarantined {
+			return i18n.NewError(ctx, coremsgs.MsgMessageNotQuarantined, id)
+		}
+
+		msg.State = newState
+		msg.Confirmed = fftypes.Now()
+		if newState == core.MessageStateConfirmed {
+			msg.RejectReason = ""
+		}
+		update := database.MessageQueryFactory.NewUpdate(ctx).
+			Set("state", msg.State).
+			Set("confirmed", msg.Confirmed).
+			Set("rejectreason", msg.RejectReason)
+		if err := em.database.UpdateMessage(ctx, em.namespace.Name, id, update); err != nil {
+			return err
+		}
+
+		// Generate the appropriate event - one per topic (events cover a single topic)
+		for _, topic := range msg.Header.Topics {
+			event := core.NewEvent(eventType, em.namespace.Name, id, msg.TransactionID, topic)
+			event.Correlator = msg.Header.CID
+			if err := em.database.InsertEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.L(ctx).Infof("Quarantined message '%s' reviewed: %s", id, review.Action)
+	em.data.UpdateMessageStateIfCached(ctx, id, msg.State, msg.Confirmed, msg.RejectReason)
+	if em.metrics.IsMetricsEnabled() {
+		em.metrics.MessageConfirmed(msg, eventType)
+	}
+	return msg, nil
+}
+
+type revalidationKey struct{}
+
+// RevalidateQuarantineEntry re-runs the validation that caused an item to be quarantined. If the item is now
+// valid, it is processed as if it had been valid on arrival and the entry is removed from the quarantine.
+// Otherwise the entry is left in place, and the result describes why the item is still invalid.
+func (em *eventManager) RevalidateQuarantineEntry(ctx context.Context, entryID string) (*core.QuarantineRevalidation, error) {
+	id, err := fftypes.ParseUUID(ctx, entryID)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := em.database.GetQuarantineEntryByID(ctx, em.namespace.Name, id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	result := &core.QuarantineRevalidation{Entry: entry}
+	if entry.Reason == core.QuarantineReasonDataInvalid {
+		err = em.revalidateQuarantinedMessage(ctx, result)
+	} else {
+		err = em.revalidateQuarantinedBatch(ctx, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Quarantine entry '%s' re-validated: valid=%t", id, result.Valid)
+	return result, nil
+}
+
+func (em *eventManager) revalidateQuarantinedBatch(ctx context.Context, result *core.QuarantineRevalidation) error {
+	var batch *core.Batch
+	if result.Entry.Payload == nil || json.Unmarshal(result.Entry.Payload.Bytes(), &batch) != nil || batch == nil {
+		return i18n.NewError(ctx, coremsgs.MsgQuarantineNoBatchPayload, result.Entry.ID)
+	}
+
+	err := em.database.RunAsGroup(ctx, func(ctx context.Context) (err error) {
+		_, result.Valid, err = em.persistBatch(context.WithValue(ctx, revalidationKey{}, result), batch)
+		if err != nil || !result.Valid {
+			return err
+		}
+		if !core.IsPinned(batch.Payload.TX.Type) {
+			// Unpinned messages are confirmed as soon as they are received
+			if err := em.markUnpinnedMessagesConfirmed(ctx, batch); err != nil {
+				return err
+			}
+		}
+		return em.database.DeleteQuarantineEntry(ctx, em.namespace.Name, result.Entry.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Rewind the aggregator to this batch - after the DB updates are complete
+	if result.Valid && core.IsPinned(batch.Payload.TX.Type) {
+		em.aggregator.queueBatchRewind(batch.ID)
+	}
+	return nil
+}
+
+func (em *eventManager) revalidateQuarantinedMessage(ctx context.Context, result *core.QuarantineRevalidation) error {
+	msg, data, _, err := em.data.GetMessageWithDataCached(ctx, result.Entry.Message)
+	if err != nil {
+		return err
+	}
+
+	// If the message has already been reviewed, the entry is no longer needed
+	if msg != nil && msg.State == core.MessageStateQuarantined {
+		validation, err := em.data.ValidateAll(ctx, data)
+		if err != nil {
+			return err
+		}
+		if !validation.Valid {
+			result.Detail = validation.Reason
+			return nil
+		}
+		if _, err := em.ReviewQuarantinedMessage(ctx, msg.Header.ID.String(), &core.QuarantineReview{
+			Action: core.ReviewActionAccept,
+		}); err != nil {
+			return err
+		}
+	}
+
+	result.Valid = true
+	return em.database.DeleteQuarantineEntry(ctx, em.namespace.Name, result.Entry.ID)
+}
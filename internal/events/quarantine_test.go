@@ -0,0 +1,439 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newQuarantinedMessage() *core.Message {
+	return &core.Message{
+		Header: core.MessageHeader{
+			ID:     fftypes.NewUUID(),
+			CID:    fftypes.NewUUID(),
+			Topics: fftypes.FFStringArray{"topic1", "topic2"},
+		},
+		TransactionID: fftypes.NewUUID(),
+		State:         core.MessageStateQuarantined,
+		RejectReason:  "bad data",
+	}
+}
+
+func TestReviewQuarantinedMessageAccept(t *testing.T) {
+	em := newTestEventManagerWithMetrics(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", msg.Header.ID, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageConfirmed && event.Reference.Equals(msg.Header.ID) &&
+			event.Correlator.Equals(msg.Header.CID) && event.Transaction.Equals(msg.TransactionID)
+	})).Return(nil).Twice()
+	em.mdm.On("UpdateMessageStateIfCached", em.ctx, msg.Header.ID, core.MessageStateConfirmed, mock.Anything, "").Return()
+	em.mmi.On("MessageConfirmed", msg, core.EventTypeMessageConfirmed).Return()
+
+	result, err := em.ReviewQuarantinedMessage(em.ctx, msg.Header.ID.String(), &core.QuarantineReview{
+		Action: core.ReviewActionAccept,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStateConfirmed, result.State)
+	assert.Empty(t, result.RejectReason)
+	assert.NotNil(t, result.Confirmed)
+
+	em.mmi.AssertExpectations(t)
+}
+
+func TestReviewQuarantinedMessageReject(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", msg.Header.ID, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeMessageRejected
+	})).Return(nil).Twice()
+	em.mdm.On("UpdateMessageStateIfCached", em.ctx, msg.Header.ID, core.MessageStateRejected, mock.Anything, "bad data").Return()
+
+	result, err := em.ReviewQuarantinedMessage(em.ctx, msg.Header.ID.String(), &core.QuarantineReview{
+		Action: core.ReviewActionReject,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStateRejected, result.State)
+	assert.Equal(t, "bad data", result.RejectReason)
+}
+
+func TestReviewQuarantinedMessageBadID(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, "bad", &core.QuarantineReview{
+		Action: core.ReviewActionAccept,
+	})
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestReviewQuarantinedMessageBadAction(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, fftypes.NewUUID().String(), &core.QuarantineReview{
+		Action: "wrong",
+	})
+	assert.Regexp(t, "FF00111.*wrong", err)
+}
+
+func TestReviewQuarantinedMessageGetFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	id := fftypes.NewUUID()
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", id).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, id.String(), &core.QuarantineReview{
+		Action: core.ReviewActionAccept,
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReviewQuarantinedMessageNotFound(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	id := fftypes.NewUUID()
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", id).Return(nil, nil)
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, id.String(), &core.QuarantineReview{
+		Action: core.ReviewActionAccept,
+	})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestReviewQuarantinedMessageNotQuarantined(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	msg.State = core.MessageStateConfirmed
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, msg.Header.ID.String(), &core.QuarantineReview{
+		Action: core.ReviewActionReject,
+	})
+	assert.Regexp(t, "FF10538", err)
+}
+
+func TestReviewQuarantinedMessageUpdateFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", msg.Header.ID, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, msg.Header.ID.String(), &core.QuarantineReview{
+		Action: core.ReviewActionAccept,
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReviewQuarantinedMessageInsertEventFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", msg.Header.ID, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := em.ReviewQuarantinedMessage(em.ctx, msg.Header.ID.String(), &core.QuarantineReview{
+		Action: core.ReviewActionAccept,
+	})
+	assert.EqualError(t, err, "pop")
+}
+
+func newBatchQuarantineEntry(t *testing.T, batch *core.Batch) *core.QuarantineEntry {
+	b, err := json.Marshal(batch)
+	assert.NoError(t, err)
+	return &core.QuarantineEntry{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Reason:    core.QuarantineReasonMessageInvalid,
+		Batch:     batch.ID,
+		Payload:   fftypes.JSONAnyPtrBytes(b),
+	}
+}
+
+func TestRevalidateQuarantineEntryBadID(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestRevalidateQuarantineEntryGetFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	id := fftypes.NewUUID()
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", id).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, id.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRevalidateQuarantineEntryNotFound(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	id := fftypes.NewUUID()
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", id).Return(nil, nil)
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, id.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestRevalidateQuarantineEntryBatchValid(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	entry := newBatchQuarantineEntry(t, batch)
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	em.mim.On("GetLocalNode", mock.Anything).Return(testNode, nil)
+	em.mdi.On("InsertDataArray", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertMessages", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("DeleteQuarantineEntry", em.ctx, "ns1", entry.ID).Return(nil)
+
+	result, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, entry, result.Entry)
+}
+
+func TestRevalidateQuarantineEntryBatchUnpinnedValid(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypePrivate, core.TransactionTypeUnpinned, core.DataArray{data})
+	entry := newBatchQuarantineEntry(t, batch)
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	em.mim.On("GetLocalNode", mock.Anything).Return(testNode, nil)
+	em.mdi.On("InsertDataArray", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertMessages", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("DeleteQuarantineEntry", em.ctx, "ns1", entry.ID).Return(nil)
+
+	result, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+}
+
+func TestRevalidateQuarantineEntryBatchUnpinnedConfirmFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypePrivate, core.TransactionTypeUnpinned, core.DataArray{data})
+	entry := newBatchQuarantineEntry(t, batch)
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, nil)
+	em.mim.On("GetLocalNode", mock.Anything).Return(testNode, nil)
+	em.mdi.On("InsertDataArray", mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("InsertMessages", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	em.mdi.On("UpdateMessages", mock.Anything, "ns1", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRevalidateQuarantineEntryBatchStillInvalid(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	batch.Hash = fftypes.NewRandB32()
+	entry := newBatchQuarantineEntry(t, batch)
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+
+	result, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Regexp(t, "Hash does not match payload", result.Detail)
+}
+
+func TestRevalidateQuarantineEntryBatchPersistFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	data := &core.Data{ID: fftypes.NewUUID(), Value: fftypes.JSONAnyPtr(`"test"`)}
+	batch := sampleBatch(t, core.BatchTypeBroadcast, core.TransactionTypeBatchPin, core.DataArray{data})
+	entry := newBatchQuarantineEntry(t, batch)
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdi.On("InsertOrGetBatch", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRevalidateQuarantineEntryNoPayload(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	entry := &core.QuarantineEntry{
+		ID:     fftypes.NewUUID(),
+		Reason: core.QuarantineReasonBatchInvalid,
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.Regexp(t, "FF10615", err)
+}
+
+func TestRevalidateQuarantineEntryMessageValid(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	entry := &core.QuarantineEntry{
+		ID:      fftypes.NewUUID(),
+		Reason:  core.QuarantineReasonDataInvalid,
+		Message: msg.Header.ID,
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdm.On("GetMessageWithDataCached", em.ctx, msg.Header.ID).Return(msg, core.DataArray{}, true, nil)
+	em.mdm.On("ValidateAll", em.ctx, core.DataArray{}).Return(&data.DataValidation{Valid: true}, nil)
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(msg, nil)
+	em.mdi.On("UpdateMessage", em.ctx, "ns1", msg.Header.ID, mock.Anything).Return(nil)
+	em.mdi.On("InsertEvent", em.ctx, mock.Anything).Return(nil)
+	em.mdm.On("UpdateMessageStateIfCached", em.ctx, msg.Header.ID, core.MessageStateConfirmed, mock.Anything, "").Return()
+	em.mdi.On("DeleteQuarantineEntry", em.ctx, "ns1", entry.ID).Return(nil)
+
+	result, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, core.MessageStateConfirmed, msg.State)
+}
+
+func TestRevalidateQuarantineEntryMessageAlreadyReviewed(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	msg.State = core.MessageStateRejected
+	entry := &core.QuarantineEntry{
+		ID:      fftypes.NewUUID(),
+		Reason:  core.QuarantineReasonDataInvalid,
+		Message: msg.Header.ID,
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdm.On("GetMessageWithDataCached", em.ctx, msg.Header.ID).Return(msg, core.DataArray{}, true, nil)
+	em.mdi.On("DeleteQuarantineEntry", em.ctx, "ns1", entry.ID).Return(nil)
+
+	result, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, result.Valid)
+}
+
+func TestRevalidateQuarantineEntryMessageStillInvalid(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	entry := &core.QuarantineEntry{
+		ID:      fftypes.NewUUID(),
+		Reason:  core.QuarantineReasonDataInvalid,
+		Message: msg.Header.ID,
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdm.On("GetMessageWithDataCached", em.ctx, msg.Header.ID).Return(msg, core.DataArray{}, true, nil)
+	em.mdm.On("ValidateAll", em.ctx, core.DataArray{}).Return(&data.DataValidation{Reason: "still bad"}, nil)
+
+	result, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, "still bad", result.Detail)
+}
+
+func TestRevalidateQuarantineEntryMessageGetFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	entry := &core.QuarantineEntry{
+		ID:      fftypes.NewUUID(),
+		Reason:  core.QuarantineReasonDataInvalid,
+		Message: fftypes.NewUUID(),
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdm.On("GetMessageWithDataCached", em.ctx, entry.Message).Return(nil, nil, false, fmt.Errorf("pop"))
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRevalidateQuarantineEntryMessageValidateFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	entry := &core.QuarantineEntry{
+		ID:      fftypes.NewUUID(),
+		Reason:  core.QuarantineReasonDataInvalid,
+		Message: msg.Header.ID,
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdm.On("GetMessageWithDataCached", em.ctx, msg.Header.ID).Return(msg, core.DataArray{}, true, nil)
+	em.mdm.On("ValidateAll", em.ctx, core.DataArray{}).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRevalidateQuarantineEntryMessageReviewFail(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	msg := newQuarantinedMessage()
+	entry := &core.QuarantineEntry{
+		ID:      fftypes.NewUUID(),
+		Reason:  core.QuarantineReasonDataInvalid,
+		Message: msg.Header.ID,
+	}
+	em.mdi.On("GetQuarantineEntryByID", em.ctx, "ns1", entry.ID).Return(entry, nil)
+	em.mdm.On("GetMessageWithDataCached", em.ctx, msg.Header.ID).Return(msg, core.DataArray{}, true, nil)
+	em.mdm.On("ValidateAll", em.ctx, core.DataArray{}).Return(&data.DataValidation{Valid: true}, nil)
+	em.mdi.On("GetMessageByID", em.ctx, "ns1", msg.Header.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := em.RevalidateQuarantineEntry(em.ctx, entry.ID.String())
+	assert.EqualError(t, err, "pop")
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/data"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -77,6 +78,8 @@ type subscriptionManager struct {
 	namespace                 *core.Namespace
 	enricher                  *eventEnricher
 	database                  database.Plugin
+	metrics                   metrics.Manager
+	sla                       *slaReporter
 	data                      data.Manager
 	txHelper                  txcommon.Helper
 	eventNotifier             *eventNotifier
@@ -96,13 +99,15 @@ type subscriptionManager struct {
 	defaultBatchTimeout time.Duration
 }
 
-func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *eventEnricher, di database.Plugin, dm data.Manager, en *eventNotifier, bm broadcast.Manager, pm privatemessaging.Manager, txHelper txcommon.Helper, transports map[string]events.Plugin) (*subscriptionManager, error) {
+func newSubscriptionManager(ctx context.Context, ns *core.Namespace, enricher *eventEnricher, di database.Plugin, dm data.Manager, en *eventNotifier, bm broadcast.Manager, pm privatemessaging.Manager, txHelper txcommon.Helper, mm metrics.Manager, transports map[string]events.Plugin) (*subscriptionManager, error) {
 	ctx, cancelCtx := context.WithCancel(ctx)
 	sm := &subscriptionManager{
 		ctx:                       ctx,
 		namespace:                 ns,
 		enricher:                  enricher,
 		database:                  di,
+		metrics:                   mm,
+		sla:                       newSLAReporter(ctx, ns.Name, di),
 		data:                      dm,
 		transports:                transports,
 		connections:               make(map[string]*connection),
@@ -156,6 +161,7 @@ func (sm *subscriptionManager) start() error {
 	}
 	log.L(sm.ctx).Infof("Subscription manager started - loaded %d durable subscriptions", len(sm.durableSubs))
 	go sm.subscriptionEventListener()
+	sm.sla.start()
 	return nil
 }
 
@@ -288,6 +294,12 @@ func (sm *subscriptionManager) parseSubscriptionDef(ctx context.Context, subDef
 		}
 	}
 
+	for eventType := range subDef.Options.Priority {
+		if _, err := fftypes.FFEnumParseString(ctx, "eventtype", eventType); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := transport.ValidateOptions(ctx, &subDef.Options); err != nil {
 		return nil, err
 	}
@@ -463,7 +475,7 @@ func (sm *subscriptionManager) registerConnection(ei events.Plugin, connID strin
 
 	// Make sure we don't have dispatchers now for any that don't match
 	for subID, d := range conn.dispatchers {
-		if !d.subscription.definition.Ephemeral && !conn.matcher(d.subscription.definition.SubscriptionRef) {
+		if !d.subscription.definition.Ephemeral && !conn.matcher(d.subscription.definition) {
 			d.close()
 			delete(conn.dispatchers, subID)
 		}
@@ -481,9 +493,9 @@ func (sm *subscriptionManager) matchSubToConnLocked(conn *connection, sub *subsc
 		log.L(sm.ctx).Warnf("Invalid connection/subscription registered: conn=%+v sub=%+v", conn, sub)
 		return
 	}
-	if conn.transport == sub.definition.Transport && conn.matcher(sub.definition.SubscriptionRef) {
+	if conn.transport == sub.definition.Transport && conn.matcher(sub.definition) {
 		if _, ok := conn.dispatchers[*sub.definition.ID]; !ok {
-			dispatcher := newEventDispatcher(sm.ctx, sm.enricher, conn.ei, sm.database, sm.data, sm.broadcast, sm.messaging, conn.id, sub, sm.eventNotifier, sm.txHelper)
+			dispatcher := newEventDispatcher(sm.ctx, sm.enricher, conn.ei, sm.database, sm.data, sm.broadcast, sm.messaging, conn.id, sub, sm.eventNotifier, sm.txHelper, sm.metrics, sm.sla)
 			conn.dispatchers[*sub.definition.ID] = dispatcher
 			dispatcher.start()
 		}
@@ -520,7 +532,7 @@ func (sm *subscriptionManager) ephemeralSubscription(ei events.Plugin, connID, n
 	}
 
 	// Create the dispatcher, and start immediately
-	dispatcher := newEventDispatcher(sm.ctx, sm.enricher, ei, sm.database, sm.data, sm.broadcast, sm.messaging, connID, newSub, sm.eventNotifier, sm.txHelper)
+	dispatcher := newEventDispatcher(sm.ctx, sm.enricher, ei, sm.database, sm.data, sm.broadcast, sm.messaging, connID, newSub, sm.eventNotifier, sm.txHelper, sm.metrics, sm.sla)
 	dispatcher.start()
 
 	conn.dispatchers[*subID] = dispatcher
@@ -574,42 +586,48 @@ func (sm *subscriptionManager) deliveryResponse(ei events.Plugin, connID string,
 	dispatcher.deliveryResponse(inflight)
 }
 
-func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
-	if sub.eventMatcher != nil && !sub.eventMatcher.MatchString(string(event.Type)) {
-		return false
-	}
+// eventFilterFields are the fields of an event the subscription filters apply to, empty where the event does not have them
+type eventFilterFields struct {
+	tag        string
+	topic      string
+	group      string
+	author     string
+	txType     string
+	beName     string
+	beListener string
+}
 
-	msg := event.Message
-	tx := event.Transaction
-	be := event.BlockchainEvent
-	tag := ""
-	topic := event.Topic
-	group := ""
-	author := ""
-	txType := ""
-	beName := ""
-	beListener := ""
-
-	if msg != nil {
-		tag = msg.Header.Tag
-		author = msg.Header.Author
+func getEventFilterFields(event *core.EnrichedEvent) *eventFilterFields {
+	f := &eventFilterFields{
+		topic: event.Topic,
+	}
+	if msg := event.Message; msg != nil {
+		f.tag = msg.Header.Tag
+		f.author = msg.Header.Author
 		if msg.Header.Group != nil {
-			group = msg.Header.Group.String()
+			f.group = msg.Header.Group.String()
 		}
 	}
-
-	if tx != nil {
-		txType = tx.Type.String()
+	if tx := event.Transaction; tx != nil {
+		f.txType = tx.Type.String()
+	}
+	if be := event.BlockchainEvent; be != nil {
+		f.beName = be.Name
+		f.beListener = be.Listener.String()
 	}
+	return f
+}
 
-	if be != nil {
-		beName = be.Name
-		beListener = be.Listener.String()
+func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
+	if sub.eventMatcher != nil && !sub.eventMatcher.MatchString(string(event.Type)) {
+		return false
 	}
 
+	f := getEventFilterFields(event)
+
 	if sub.topicFilter != nil {
 		topicsMatch := false
-		if sub.topicFilter.MatchString(topic) {
+		if sub.topicFilter.MatchString(f.topic) {
 			topicsMatch = true
 		}
 		if !topicsMatch {
@@ -618,30 +636,70 @@ func (sub *subscription) MatchesEvent(event *core.EnrichedEvent) bool {
 	}
 
 	if sub.messageFilter != nil {
-		if sub.messageFilter.tagFilter != nil && !sub.messageFilter.tagFilter.MatchString(tag) {
+		if sub.messageFilter.tagFilter != nil && !sub.messageFilter.tagFilter.MatchString(f.tag) {
 			return false
 		}
-		if sub.messageFilter.authorFilter != nil && !sub.messageFilter.authorFilter.MatchString(author) {
+		if sub.messageFilter.authorFilter != nil && !sub.messageFilter.authorFilter.MatchString(f.author) {
 			return false
 		}
-		if sub.messageFilter.groupFilter != nil && !sub.messageFilter.groupFilter.MatchString(group) {
+		if sub.messageFilter.groupFilter != nil && !sub.messageFilter.groupFilter.MatchString(f.group) {
 			return false
 		}
 	}
 
 	if sub.transactionFilter != nil {
-		if sub.transactionFilter.typeFilter != nil && !sub.transactionFilter.typeFilter.MatchString(txType) {
+		if sub.transactionFilter.typeFilter != nil && !sub.transactionFilter.typeFilter.MatchString(f.txType) {
 			return false
 		}
 	}
 
 	if sub.blockchainFilter != nil {
-		if sub.blockchainFilter.nameFilter != nil && !sub.blockchainFilter.nameFilter.MatchString(beName) {
+		if sub.blockchainFilter.nameFilter != nil && !sub.blockchainFilter.nameFilter.MatchString(f.beName) {
 			return false
 		}
-		if sub.blockchainFilter.listenerFilter != nil && !sub.blockchainFilter.listenerFilter.MatchString(beListener) {
+		if sub.blockchainFilter.listenerFilter != nil && !sub.blockchainFilter.listenerFilter.MatchString(f.beListener) {
 			return false
 		}
 	}
 	return true
 }
+
+// explainMatch evaluates every filter of the subscription against the event, in the same order as MatchesEvent,
+// rather than stopping at the first filter that does not match
+func (sub *subscription) explainMatch(event *core.EnrichedEvent) *core.SubscriptionTestResult {
+	result := &core.SubscriptionTestResult{
+		Matched: true,
+		Event:   event,
+		Filters: []*core.SubscriptionFilterResult{},
+	}
+	check := func(name string, re *regexp.Regexp, value string) {
+		if re == nil {
+			return
+		}
+		matched := re.MatchString(value)
+		result.Filters = append(result.Filters, &core.SubscriptionFilterResult{
+			Filter:  name,
+			Pattern: re.String(),
+			Value:   value,
+			Matched: matched,
+		})
+		result.Matched = result.Matched && matched
+	}
+
+	f := getEventFilterFields(event)
+	check("events", sub.eventMatcher, string(event.Type))
+	check("topic", sub.topicFilter, f.topic)
+	if sub.messageFilter != nil {
+		check("message.tag", sub.messageFilter.tagFilter, f.tag)
+		check("message.author", sub.messageFilter.authorFilter, f.author)
+		check("message.group", sub.messageFilter.groupFilter, f.group)
+	}
+	if sub.transactionFilter != nil {
+		check("transaction.type", sub.transactionFilter.typeFilter, f.txType)
+	}
+	if sub.blockchainFilter != nil {
+		check("blockchainevent.name", sub.blockchainFilter.nameFilter, f.beName)
+		check("blockchainevent.listener", sub.blockchainFilter.listenerFilter, f.beListener)
+	}
+	return result
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -51,6 +52,8 @@ func newTestSubManager(t *testing.T, mei *eventsmocks.Plugin) (*subscriptionMana
 	mbm := &broadcastmocks.Manager{}
 	mpm := &privatemessagingmocks.Manager{}
 	mom := &operationmocks.Manager{}
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
 	ctx := context.Background()
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
@@ -64,7 +67,7 @@ func newTestSubManager(t *testing.T, mei *eventsmocks.Plugin) (*subscriptionMana
 	mei.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mdi.On("GetEvents", mock.Anything, mock.Anything, mock.Anything).Return([]*core.Event{}, nil, nil).Maybe()
 	mdi.On("GetOffset", mock.Anything, mock.Anything, mock.Anything).Return(&core.Offset{RowID: 3333333, Current: 0}, nil).Maybe()
-	sm, err := newSubscriptionManager(ctx, &core.Namespace{Name: "ns1"}, enricher, mdi, mdm, newEventNotifier(ctx, "ut"), mbm, mpm, txHelper, nil)
+	sm, err := newSubscriptionManager(ctx, &core.Namespace{Name: "ns1"}, enricher, mdi, mdm, newEventNotifier(ctx, "ut"), mbm, mpm, txHelper, mmi, nil)
 	assert.NoError(t, err)
 	sm.transports = map[string]events.Plugin{
 		"ut": mei,
@@ -109,10 +112,10 @@ func TestRegisterDurableSubscriptions(t *testing.T) {
 	}
 	be := &boundCallbacks{sm: sm, ei: mei}
 
-	be.RegisterConnection("conn1", func(sr core.SubscriptionRef) bool {
+	be.RegisterConnection("conn1", func(sr *core.Subscription) bool {
 		return *sr.ID == *sub2
 	})
-	be.RegisterConnection("conn2", func(sr core.SubscriptionRef) bool {
+	be.RegisterConnection("conn2", func(sr *core.Subscription) bool {
 		return *sr.ID == *sub1
 	})
 
@@ -140,7 +143,7 @@ func TestReloadDurableSubscription(t *testing.T) {
 		id:          "conn1",
 		transport:   "ut",
 		dispatchers: make(map[fftypes.UUID]*eventDispatcher),
-		matcher: func(sr core.SubscriptionRef) bool {
+		matcher: func(sr *core.Subscription) bool {
 			return sr.Namespace == "ns1" && sr.Name == "sub1"
 		},
 	}
@@ -311,6 +314,21 @@ func TestCreateSubscriptionBadEventilter(t *testing.T) {
 	assert.Regexp(t, "FF10171.*events", err)
 }
 
+func TestCreateSubscriptionBadPriorityEventType(t *testing.T) {
+	mei := &eventsmocks.Plugin{}
+	sm, cancel := newTestSubManager(t, mei)
+	defer cancel()
+	_, err := sm.parseSubscriptionDef(sm.ctx, &core.Subscription{
+		Options: core.SubscriptionOptions{
+			SubscriptionCoreOptions: core.SubscriptionCoreOptions{
+				Priority: map[string]int{"not_an_event": 10},
+			},
+		},
+		Transport: "ut",
+	})
+	assert.Regexp(t, "FF00.*not_an_event", err)
+}
+
 func TestCreateSubscriptionBadTopicFilter(t *testing.T) {
 	mei := &eventsmocks.Plugin{}
 	sm, cancel := newTestSubManager(t, mei)
@@ -658,7 +676,7 @@ func TestConnIDSafetyChecking(t *testing.T) {
 		dispatchers: map[fftypes.UUID]*eventDispatcher{},
 	}
 
-	err := be2.RegisterConnection("conn1", func(sr core.SubscriptionRef) bool { return true })
+	err := be2.RegisterConnection("conn1", func(sr *core.Subscription) bool { return true })
 	assert.Regexp(t, "FF10190", err)
 
 	err = be2.EphemeralSubscription("conn1", "ns1", &core.SubscriptionFilter{}, &core.SubscriptionOptions{})
@@ -699,7 +717,7 @@ func TestNewDurableSubscriptionUnknownTransport(t *testing.T) {
 		ei:        mei,
 		id:        "conn1",
 		transport: "ut",
-		matcher: func(sr core.SubscriptionRef) bool {
+		matcher: func(sr *core.Subscription) bool {
 			return sr.Namespace == "ns1" && sr.Name == "sub1"
 		},
 		dispatchers: map[fftypes.UUID]*eventDispatcher{},
@@ -731,7 +749,7 @@ func TestNewDurableSubscriptionOK(t *testing.T) {
 		ei:        mei,
 		id:        "conn1",
 		transport: "ut",
-		matcher: func(sr core.SubscriptionRef) bool {
+		matcher: func(sr *core.Subscription) bool {
 			return sr.Namespace == "ns1" && sr.Name == "sub1"
 		},
 		dispatchers: map[fftypes.UUID]*eventDispatcher{},
@@ -779,7 +797,7 @@ func TestUpdatedDurableSubscriptionNoOp(t *testing.T) {
 		ei:        mei,
 		id:        "conn1",
 		transport: "ut",
-		matcher: func(sr core.SubscriptionRef) bool {
+		matcher: func(sr *core.Subscription) bool {
 			return sr.Namespace == "ns1" && sr.Name == "sub1"
 		},
 		dispatchers: map[fftypes.UUID]*eventDispatcher{
@@ -824,7 +842,7 @@ func TestUpdatedDurableSubscriptionOK(t *testing.T) {
 		ei:        mei,
 		id:        "conn1",
 		transport: "ut",
-		matcher: func(sr core.SubscriptionRef) bool {
+		matcher: func(sr *core.Subscription) bool {
 			return sr.Namespace == "ns1" && sr.Name == "sub1"
 		},
 		dispatchers: map[fftypes.UUID]*eventDispatcher{
@@ -847,7 +865,7 @@ func TestMatchedSubscriptionWithLockUnknownTransport(t *testing.T) {
 	defer cancel()
 
 	conn := &connection{
-		matcher: func(sr core.SubscriptionRef) bool { return true },
+		matcher: func(sr *core.Subscription) bool { return true },
 	}
 	sm.matchSubToConnLocked(conn, &subscription{definition: &core.Subscription{Transport: "Wrong!"}})
 	assert.Nil(t, conn.dispatchers)
@@ -891,7 +909,7 @@ func TestDeleteDurableSubscriptionOk(t *testing.T) {
 		ei:        mei,
 		id:        "conn1",
 		transport: "ut",
-		matcher: func(sr core.SubscriptionRef) bool {
+		matcher: func(sr *core.Subscription) bool {
 			return sr.Namespace == "ns1" && sr.Name == "sub1"
 		},
 		dispatchers: map[fftypes.UUID]*eventDispatcher{
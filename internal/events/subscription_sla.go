@@ -0,0 +1,122 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+type slaKey struct {
+	subscription fftypes.UUID
+	day          string
+}
+
+// slaReporter accumulates the time taken for durable subscriptions to acknowledge events in memory,
+// and periodically merges the daily aggregates into the database
+type slaReporter struct {
+	ctx           context.Context
+	namespace     string
+	database      database.Plugin
+	enabled       bool
+	target        time.Duration
+	flushInterval time.Duration
+	mux           sync.Mutex
+	pending       map[slaKey]*core.SubscriptionSLA
+}
+
+func newSLAReporter(ctx context.Context, ns string, di database.Plugin) *slaReporter {
+	return &slaReporter{
+		ctx:           ctx,
+		namespace:     ns,
+		database:      di,
+		enabled:       config.GetBool(coreconfig.SubscriptionSLAEnabled),
+		target:        config.GetDuration(coreconfig.SubscriptionSLATarget),
+		flushInterval: config.GetDuration(coreconfig.SubscriptionSLAFlushInterval),
+		pending:       make(map[slaKey]*core.SubscriptionSLA),
+	}
+}
+
+func (sr *slaReporter) start() {
+	if sr.enabled {
+		go sr.flushLoop()
+	}
+}
+
+func (sr *slaReporter) recordAck(subscription *fftypes.UUID, event *core.Event, ackTime time.Time) {
+	if !sr.enabled || event.Created == nil {
+		return
+	}
+	key := slaKey{subscription: *subscription, day: core.SLADay(ackTime)}
+
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+	sla := sr.pending[key]
+	if sla == nil {
+		sla = core.NewSubscriptionSLA(sr.namespace, subscription, key.day, sr.target)
+		sr.pending[key] = sla
+	}
+	sla.Record(ackTime.Sub(*event.Created.Time()))
+}
+
+func (sr *slaReporter) flush(ctx context.Context) {
+	sr.mux.Lock()
+	pending := sr.pending
+	sr.pending = make(map[slaKey]*core.SubscriptionSLA)
+	sr.mux.Unlock()
+
+	for key, sla := range pending {
+		if err := sr.database.AddSubscriptionSLA(ctx, sla); err != nil {
+			// Keep the counts to retry on the next flush
+			log.L(ctx).Warnf("Failed to record SLA for subscription %s on %s: %s", key.subscription, key.day, err)
+			sr.requeue(key, sla)
+		}
+	}
+}
+
+func (sr *slaReporter) requeue(key slaKey, sla *core.SubscriptionSLA) {
+	sr.mux.Lock()
+	defer sr.mux.Unlock()
+	if recorded := sr.pending[key]; recorded != nil {
+		sla.Merge(recorded)
+	}
+	sr.pending[key] = sla
+}
+
+func (sr *slaReporter) flushLoop() {
+	ticker := time.NewTicker(sr.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			sr.flush(sr.ctx)
+		case <-sr.ctx.Done():
+			// Write the counts accumulated since the last flush, as the context of the namespace is now closed
+			sr.flush(log.WithLogger(context.Background(), log.L(sr.ctx)))
+			log.L(sr.ctx).Debugf("SLA reporter exiting")
+			return
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSLAReporter(t *testing.T) (*slaReporter, *databasemocks.Plugin, func()) {
+	coreconfig.Reset()
+	config.Set(coreconfig.SubscriptionSLATarget, "1s")
+	config.Set(coreconfig.SubscriptionSLAFlushInterval, "1ms")
+	mdi := &databasemocks.Plugin{}
+	ctx, cancel := context.WithCancel(context.Background())
+	return newSLAReporter(ctx, "ns1", mdi), mdi, func() {
+		cancel()
+		mdi.AssertExpectations(t)
+	}
+}
+
+func TestSLAReporterRecordAndFlush(t *testing.T) {
+	sr, mdi, done := newTestSLAReporter(t)
+	defer done()
+
+	subID := fftypes.NewUUID()
+	now := time.Now()
+	createdBefore := func(d time.Duration) *fftypes.FFTime {
+		created := fftypes.FFTime(now.Add(-d))
+		return &created
+	}
+	sr.recordAck(subID, &core.Event{Created: createdBefore(500 * time.Millisecond)}, now)
+	sr.recordAck(subID, &core.Event{Created: createdBefore(2 * time.Second)}, now)
+	sr.recordAck(subID, &core.Event{}, now) // ignored
+
+	mdi.On("AddSubscriptionSLA", mock.Anything, mock.MatchedBy(func(sla *core.SubscriptionSLA) bool {
+		return sla.Namespace == "ns1" &&
+			sla.Subscription.Equals(subID) &&
+			sla.Day == core.SLADay(now) &&
+			sla.Target.String() == "1s" &&
+			sla.Delivered == 2 &&
+			sla.WithinTarget == 1
+	})).Return(nil).Once()
+
+	sr.flush(context.Background())
+	assert.Empty(t, sr.pending)
+}
+
+func TestSLAReporterDisabled(t *testing.T) {
+	sr, _, done := newTestSLAReporter(t)
+	defer done()
+	sr.enabled = false
+
+	sr.start()
+	sr.recordAck(fftypes.NewUUID(), &core.Event{Created: fftypes.Now()}, time.Now())
+	assert.Empty(t, sr.pending)
+}
+
+func TestSLAReporterFlushFailRequeue(t *testing.T) {
+	sr, mdi, done := newTestSLAReporter(t)
+	defer done()
+
+	subID := fftypes.NewUUID()
+	now := time.Now()
+	sr.recordAck(subID, &core.Event{Created: fftypes.Now()}, now)
+
+	mdi.On("AddSubscriptionSLA", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	addCall := mdi.On("AddSubscriptionSLA", mock.Anything, mock.Anything).Return(fmt.Errorf("pop")).Once()
+	addCall.RunFn = func(a mock.Arguments) {
+		// Simulate an acknowledgement arriving while the flush is in progress
+		sr.recordAck(subID, &core.Event{Created: fftypes.Now()}, now)
+	}
+	mdi.On("AddSubscriptionSLA", mock.Anything, mock.MatchedBy(func(sla *core.SubscriptionSLA) bool {
+		return sla.Delivered == 2
+	})).Return(nil).Once()
+
+	sr.flush(context.Background())
+	sr.flush(context.Background())
+	sr.flush(context.Background())
+	assert.Empty(t, sr.pending)
+}
+
+func TestSLAReporterFlushLoop(t *testing.T) {
+	sr, mdi, done := newTestSLAReporter(t)
+	defer done()
+
+	subID := fftypes.NewUUID()
+	sr.recordAck(subID, &core.Event{Created: fftypes.Now()}, time.Now())
+
+	var cancelCtx context.CancelFunc
+	sr.ctx, cancelCtx = context.WithCancel(sr.ctx)
+	mdi.On("AddSubscriptionSLA", mock.Anything, mock.Anything).Return(nil).Once().Run(func(a mock.Arguments) {
+		// Record another that is written by the final flush on close
+		sr.recordAck(subID, &core.Event{Created: fftypes.Now()}, time.Now())
+		cancelCtx()
+	})
+	mdi.On("AddSubscriptionSLA", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	}), mock.Anything).Return(nil).Once()
+
+	sr.flushLoop()
+	assert.Empty(t, sr.pending)
+}
@@ -81,7 +81,7 @@ func (se *Events) SetHandler(namespace string, handler events.Callbacks) error {
 	}
 	se.callbacks.handlers[namespace] = handler
 	// We have a single logical connection, that matches all subscriptions
-	return handler.RegisterConnection(se.connID, func(sr core.SubscriptionRef) bool { return true })
+	return handler.RegisterConnection(se.connID, func(sub *core.Subscription) bool { return true })
 }
 
 func (se *Events) Capabilities() *events.Capabilities {
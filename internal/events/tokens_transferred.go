@@ -36,29 +36,45 @@ import (
 //   - The LocalID must not have been used yet. Connectors are allowed to emit multiple events in response to a single operation,
 //     but only the first of them can use the original LocalID.
 func (em *eventManager) loadTransferID(ctx context.Context, tx *fftypes.UUID, transfer *core.TokenTransfer) (*fftypes.UUID, error) {
-	op, err := em.txHelper.FindOperationInTransaction(ctx, tx, core.OpTypeTokenTransfer)
+	ops, err := em.txHelper.FindOperationsInTransaction(ctx, tx, core.OpTypeTokenTransfer)
 	if err != nil {
 		return nil, err
 	}
-	if op != nil {
+	for _, op := range ops {
 		// This transfer matches a transfer transaction+operation submitted by this node.
 		// Check the operation inputs to see if they match the connector and pool on this event.
-		if input, err := txcommon.RetrieveTokenTransferInputs(ctx, op); err != nil {
+		input, err := txcommon.RetrieveTokenTransferInputs(ctx, op)
+		if err != nil {
 			log.L(ctx).Warnf("Failed to read operation inputs for token transfer '%s': %s", transfer.ProtocolID, err)
-		} else if input != nil && input.Connector == transfer.Connector && input.Pool.Equals(transfer.Pool) {
-			// Check if the LocalID has already been used
-			if existing, err := em.database.GetTokenTransferByID(ctx, em.namespace.Name, input.LocalID); err != nil {
-				return nil, err
-			} else if existing == nil {
-				// Everything matches - use the LocalID that was assigned up-front when the operation was submitted
-				return input.LocalID, nil
-			}
+			continue
+		}
+		if input == nil || input.Connector != transfer.Connector || !input.Pool.Equals(transfer.Pool) {
+			continue
+		}
+		// Where a transaction contains several transfers, such as a mint distribution, the event must also
+		// match the details of the transfer submitted by the operation
+		if len(ops) > 1 && !sameTokenTransfer(input, transfer) {
+			continue
+		}
+		// Check if the LocalID has already been used
+		if existing, err := em.database.GetTokenTransferByID(ctx, em.namespace.Name, input.LocalID); err != nil {
+			return nil, err
+		} else if existing == nil {
+			// Everything matches - use the LocalID that was assigned up-front when the operation was submitted
+			return input.LocalID, nil
 		}
 	}
 
 	return fftypes.NewUUID(), nil
 }
 
+func sameTokenTransfer(input, transfer *core.TokenTransfer) bool {
+	return input.Type == transfer.Type &&
+		input.To == transfer.To &&
+		input.TokenIndex == transfer.TokenIndex &&
+		input.Amount.Int().Cmp(transfer.Amount.Int()) == 0
+}
+
 func (em *eventManager) persistTokenTransfer(ctx context.Context, transfer *tokens.TokenTransfer) (valid bool, err error) {
 	// Check that this is from a known pool
 	pool, err := em.getPoolByIDOrLocator(ctx, transfer.Pool, transfer.Connector, transfer.PoolLocator)
@@ -103,7 +103,7 @@ func TestPersistTransferOpFail(t *testing.T) {
 	}
 
 	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(pool, nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(nil, fmt.Errorf("pop"))
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(nil, fmt.Errorf("pop"))
 
 	valid, err := em.persistTokenTransfer(em.ctx, transfer)
 	assert.False(t, valid)
@@ -127,7 +127,7 @@ func TestPersistTransferBadOp(t *testing.T) {
 	}
 
 	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(pool, nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(op, nil)
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return([]*core.Operation{op}, nil)
 	em.mth.On("PersistTransaction", mock.Anything, transfer.TX.ID, core.TransactionTypeTokenTransfer, "0xffffeeee").Return(false, fmt.Errorf("pop"))
 
 	valid, err := em.persistTokenTransfer(em.ctx, transfer)
@@ -153,7 +153,7 @@ func TestPersistTransferTxFail(t *testing.T) {
 	}
 
 	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(pool, nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(op, nil)
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return([]*core.Operation{op}, nil)
 	em.mth.On("PersistTransaction", mock.Anything, transfer.TX.ID, core.TransactionTypeTokenTransfer, "0xffffeeee").Return(false, fmt.Errorf("pop"))
 
 	valid, err := em.persistTokenTransfer(em.ctx, transfer)
@@ -181,7 +181,7 @@ func TestPersistTransferGetTransferFail(t *testing.T) {
 	}
 
 	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(pool, nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(op, nil)
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return([]*core.Operation{op}, nil)
 	em.mdi.On("GetTokenTransferByID", em.ctx, "ns1", localID).Return(nil, fmt.Errorf("pop"))
 
 	valid, err := em.persistTokenTransfer(em.ctx, transfer)
@@ -209,7 +209,7 @@ func TestPersistTransferBlockchainEventFail(t *testing.T) {
 	}
 
 	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(pool, nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(op, nil)
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return([]*core.Operation{op}, nil)
 	em.mth.On("PersistTransaction", mock.Anything, transfer.TX.ID, core.TransactionTypeTokenTransfer, "0xffffeeee").Return(true, nil)
 	em.mdi.On("GetTokenTransferByID", em.ctx, "ns1", localID).Return(nil, nil)
 	em.mth.On("InsertOrGetBlockchainEvent", em.ctx, mock.MatchedBy(func(e *core.BlockchainEvent) bool {
@@ -243,7 +243,7 @@ func TestTokensTransferredWithTransactionRegenerateLocalID(t *testing.T) {
 	}
 
 	em.mam.On("GetTokenPoolByLocator", em.ctx, "erc1155", "F1").Return(pool, nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(op, nil)
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return([]*core.Operation{op}, nil)
 	em.mth.On("PersistTransaction", mock.Anything, transfer.TX.ID, core.TransactionTypeTokenTransfer, "0xffffeeee").Return(true, nil)
 	em.mdi.On("GetTokenTransferByID", em.ctx, "ns1", localID).Return(&core.TokenTransfer{}, nil)
 	em.mth.On("InsertOrGetBlockchainEvent", em.ctx, mock.MatchedBy(func(e *core.BlockchainEvent) bool {
@@ -291,7 +291,7 @@ func TestTokensTransferredWithExistingTransfer(t *testing.T) {
 	em.mdi.On("InsertEvent", em.ctx, mock.MatchedBy(func(ev *core.Event) bool {
 		return ev.Type == core.EventTypeBlockchainEventReceived && ev.Namespace == pool.Namespace
 	})).Return(nil)
-	em.mth.On("FindOperationInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(op, nil)
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return([]*core.Operation{op}, nil)
 	em.mth.On("PersistTransaction", mock.Anything, transfer.TX.ID, core.TransactionTypeTokenTransfer, "0xffffeeee").Return(true, nil)
 	em.mdi.On("GetTokenTransferByID", em.ctx, "ns1", localID).Return(&core.TokenTransfer{}, nil)
 	em.mdi.On("InsertOrGetTokenTransfer", em.ctx, &transfer.TokenTransfer).Return(&core.TokenTransfer{Type: core.TokenTransferTypeMint}, nil)
@@ -305,6 +305,48 @@ func TestTokensTransferredWithExistingTransfer(t *testing.T) {
 	mti.AssertExpectations(t)
 }
 
+func TestLoadTransferIDMintDistribution(t *testing.T) {
+	em := newTestEventManager(t)
+	defer em.cleanup(t)
+
+	transfer := newTransfer()
+	transfer.Pool = fftypes.NewUUID()
+	mintID := fftypes.NewUUID()
+	transferID := fftypes.NewUUID()
+	ops := []*core.Operation{{
+		Input: fftypes.JSONObject{
+			"localId": "bad",
+		},
+	}, {
+		Input: fftypes.JSONObject{
+			"type":      "mint",
+			"localId":   mintID.String(),
+			"connector": transfer.Connector,
+			"pool":      transfer.Pool.String(),
+			"to":        "0x1",
+			"amount":    "3",
+		},
+	}, {
+		Input: fftypes.JSONObject{
+			"type":       "transfer",
+			"localId":    transferID.String(),
+			"connector":  transfer.Connector,
+			"pool":       transfer.Pool.String(),
+			"tokenIndex": "0",
+			"from":       "0x1",
+			"to":         "0x2",
+			"amount":     "1",
+		},
+	}}
+
+	em.mth.On("FindOperationsInTransaction", em.ctx, transfer.TX.ID, core.OpTypeTokenTransfer).Return(ops, nil)
+	em.mdi.On("GetTokenTransferByID", em.ctx, "ns1", transferID).Return(nil, nil)
+
+	localID, err := em.loadTransferID(em.ctx, transfer.TX.ID, &transfer.TokenTransfer)
+	assert.NoError(t, err)
+	assert.Equal(t, transferID, localID)
+}
+
 func TestTokensTransferredBadPool(t *testing.T) {
 	em := newTestEventManager(t)
 	defer em.cleanup(t)
@@ -110,7 +110,7 @@ func (wh *WebHooks) SetHandler(namespace string, handler events.Callbacks) error
 	}
 	wh.callbacks.handlers[namespace] = handler
 	// We have a single logical connection, that matches all subscriptions
-	return handler.RegisterConnection(wh.connID, func(sr core.SubscriptionRef) bool { return true })
+	return handler.RegisterConnection(wh.connID, func(sub *core.Subscription) bool { return true })
 }
 
 func (wh *WebHooks) Capabilities() *events.Capabilities {
@@ -588,6 +588,22 @@ func (wh *WebHooks) BatchDeliveryRequest(ctx context.Context, connID string, sub
 	return nil
 }
 
+// DeliverCallback makes a one-off delivery of an event to a URL, outside of any subscription.
+// The request is built and sent in the same way as for a webhook subscription with default options,
+// but no delivery response is emitted - so the caller is responsible for handling any failure.
+func (wh *WebHooks) DeliverCallback(ctx context.Context, callbackURL string, event *core.EventDelivery) error {
+	sub := &core.Subscription{SubscriptionRef: event.Subscription}
+	sub.Options.TransportOptions()["url"] = callbackURL
+	_, res, err := wh.attemptRequest(ctx, sub, []*core.CombinedEventDataDelivery{{Event: event}}, false)
+	if err != nil {
+		return err
+	}
+	if res.Status < 200 || res.Status >= 300 {
+		return i18n.NewError(ctx, coremsgs.MsgCallbackFailed, callbackURL, event.ID, res.Status)
+	}
+	return nil
+}
+
 func (wh *WebHooks) NamespaceRestarted(ns string, startTime time.Time) {
 	// no-op
 }
@@ -54,7 +54,7 @@ func newTestWebHooks(t *testing.T) (wh *WebHooks, cancel func()) {
 	cbs := &eventsmocks.Callbacks{}
 	rc := cbs.On("RegisterConnection", mock.Anything, mock.Anything).Return(nil)
 	rc.RunFn = func(a mock.Arguments) {
-		assert.Equal(t, true, a[1].(events.SubscriptionMatcher)(core.SubscriptionRef{}))
+		assert.Equal(t, true, a[1].(events.SubscriptionMatcher)(&core.Subscription{}))
 	}
 	wh = &WebHooks{}
 	ctx, cancelCtx := context.WithCancel(context.Background())
@@ -1474,3 +1474,66 @@ func TestRequestWithBodyReplyEndToEndWithBatch(t *testing.T) {
 func TestFirstDataNeverNil(t *testing.T) {
 	assert.NotNil(t, (&whPayload{}).firstData())
 }
+
+func TestDeliverCallbackOk(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	msgID := fftypes.NewUUID()
+
+	called := false
+	r := mux.NewRouter()
+	r.HandleFunc("/callback", func(res http.ResponseWriter, req *http.Request) {
+		var body fftypes.JSONObject
+		err := json.NewDecoder(req.Body).Decode(&body)
+		assert.NoError(t, err)
+		assert.Equal(t, string(core.EventTypeMessageConfirmed), body.GetString("type"))
+		assert.Equal(t, msgID.String(), body.GetObject("message").GetObject("header").GetString("id"))
+		res.WriteHeader(204)
+		called = true
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	event := &core.EventDelivery{
+		EnrichedEvent: core.EnrichedEvent{
+			Event: core.Event{
+				ID:   fftypes.NewUUID(),
+				Type: core.EventTypeMessageConfirmed,
+			},
+			Message: &core.Message{
+				Header: core.MessageHeader{
+					ID: msgID,
+				},
+				State: core.MessageStateConfirmed,
+			},
+		},
+	}
+
+	err := wh.DeliverCallback(wh.ctx, fmt.Sprintf("http://%s/callback", server.Listener.Addr()), event)
+	assert.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestDeliverCallbackErrorStatus(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	r := mux.NewRouter()
+	r.HandleFunc("/callback", func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+	}).Methods(http.MethodPost)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	err := wh.DeliverCallback(wh.ctx, fmt.Sprintf("http://%s/callback", server.Listener.Addr()), &core.EventDelivery{})
+	assert.Regexp(t, "FF10536", err)
+}
+
+func TestDeliverCallbackRequestFail(t *testing.T) {
+	wh, cancel := newTestWebHooks(t)
+	defer cancel()
+
+	err := wh.DeliverCallback(wh.ctx, "http://localhost:0/callback", &core.EventDelivery{})
+	assert.Error(t, err)
+}
@@ -57,6 +57,7 @@ type websocketConnection struct {
 	remoteAddr      string
 	userAgent       string
 	header          http.Header
+	principal       string
 	auth            core.Authorizer
 	namespaceScoped bool // if true then any request to listen is asserted to be in the context of namespace
 	namespace       string
@@ -78,6 +79,7 @@ func newConnection(pCtx context.Context, ws *WebSockets, wsConn *websocket.Conn,
 		remoteAddr:   req.RemoteAddr,
 		userAgent:    req.UserAgent(),
 		header:       req.Header,
+		principal:    core.RequestPrincipal(req),
 		auth:         auth,
 	}
 	go wc.sendLoop()
@@ -380,11 +382,16 @@ func (wc *websocketConnection) handleStart(start *core.WSStart) (err error) {
 	return wc.ws.start(wc, start)
 }
 
-func (wc *websocketConnection) durableSubMatcher(sr core.SubscriptionRef) bool {
+func (wc *websocketConnection) durableSubMatcher(sub *core.Subscription) bool {
 	wc.mux.Lock()
 	defer wc.mux.Unlock()
 	for _, startedSub := range wc.started {
-		if !startedSub.Ephemeral && startedSub.Namespace == sr.Namespace && startedSub.Name == sr.Name {
+		if !startedSub.Ephemeral && startedSub.Namespace == sub.Namespace && startedSub.Name == sub.Name {
+			// Subscriptions created by an authenticated principal can only be attached to by that principal
+			if sub.Owner != "" && sub.Owner != wc.principal {
+				log.L(wc.ctx).Warnf("Not attaching to subscription '%s:%s' owned by a different principal", sub.Namespace, sub.Name)
+				return false
+			}
 			return true
 		}
 	}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
@@ -113,7 +114,34 @@ func (ws *WebSockets) DeliveryRequest(ctx context.Context, connID string, sub *c
 	return conn.dispatch(event)
 }
 
+// authorizeUpgrade checks the credentials on the upgrade request, when the namespace of the connection is
+// known up front. Otherwise each start message on the connection is authorized for its namespace.
+func (ws *WebSockets) authorizeUpgrade(res http.ResponseWriter, req *http.Request, namespace string) bool {
+	if ws.auth == nil || namespace == "" {
+		return true
+	}
+	err := ws.auth.Authorize(req.Context(), &fftypes.AuthReq{
+		Method:    req.Method,
+		URL:       req.URL,
+		Header:    req.Header,
+		Namespace: namespace,
+	})
+	if err != nil {
+		log.L(ws.ctx).Errorf("WebSocket upgrade unauthorized: %s", err)
+		status := http.StatusUnauthorized
+		if ffErr, ok := err.(i18n.FFError); ok {
+			status = ffErr.HTTPStatus()
+		}
+		http.Error(res, err.Error(), status)
+		return false
+	}
+	return true
+}
+
 func (ws *WebSockets) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	if !ws.authorizeUpgrade(res, req, req.URL.Query().Get("namespace")) {
+		return
+	}
 	wsConn, err := ws.upgrader.Upgrade(res, req, nil)
 	if err != nil {
 		log.L(ws.ctx).Errorf("WebSocket upgrade failed: %s", err)
@@ -129,6 +157,9 @@ func (ws *WebSockets) ServeHTTP(res http.ResponseWriter, req *http.Request) {
 }
 
 func (ws *WebSockets) ServeHTTPNamespaced(namespace string, res http.ResponseWriter, req *http.Request) {
+	if !ws.authorizeUpgrade(res, req, namespace) {
+		return
+	}
 
 	wsConn, err := ws.upgrader.Upgrade(res, req, nil)
 	if err != nil {
@@ -162,9 +193,7 @@ func (ws *WebSockets) start(wc *websocketConnection, start *core.WSStart) error
 			return cb.EphemeralSubscription(wc.connID, start.Namespace, &start.Filter, &start.Options)
 		}
 		// We can have multiple subscriptions on a single connection
-		return cb.RegisterConnection(wc.connID, func(sr core.SubscriptionRef) bool {
-			return wc.durableSubMatcher(sr)
-		})
+		return cb.RegisterConnection(wc.connID, wc.durableSubMatcher)
 	}
 	return i18n.NewError(ws.ctx, coremsgs.MsgNamespaceDoesNotExist)
 }
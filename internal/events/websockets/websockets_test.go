@@ -308,9 +308,9 @@ func TestStartReceiveDurable(t *testing.T) {
 		mock.Anything,
 	).Return(nil).Run(func(args mock.Arguments) {
 		subMatch := args[1].(events.SubscriptionMatcher)
-		assert.True(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}))
+		assert.True(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}}))
 	})
 	ack := cbs.On("DeliveryResponse",
 		mock.MatchedBy(func(s string) bool { return s == connID }),
@@ -389,9 +389,9 @@ func TestStartReceiveDurableBatch(t *testing.T) {
 		mock.Anything,
 	).Return(nil).Run(func(args mock.Arguments) {
 		subMatch := args[1].(events.SubscriptionMatcher)
-		assert.True(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}))
+		assert.True(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}}))
 	})
 	ack := cbs.On("DeliveryResponse",
 		mock.MatchedBy(func(s string) bool { return s == connID }),
@@ -471,9 +471,9 @@ func TestStartReceiveDurableWithAuth(t *testing.T) {
 		mock.Anything,
 	).Run(func(args mock.Arguments) {
 		subMatch := args[1].(events.SubscriptionMatcher)
-		assert.True(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}))
+		assert.True(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}}))
 		close(waitSubscribed)
 	}).Return(nil)
 	ack := cbs.On("DeliveryResponse",
@@ -549,8 +549,8 @@ func TestStartReceiveDurableUnauthorized(t *testing.T) {
 		mock.Anything,
 	).Return(nil).Run(func(args mock.Arguments) {
 		subMatch := args[1].(events.SubscriptionMatcher)
-		assert.True(t, subMatch(core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}))
+		assert.True(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}}))
 		close(waitSubscribed)
 	})
 	ack := cbs.On("DeliveryResponse",
@@ -1136,9 +1136,9 @@ func TestNamespaceScopedSuccess(t *testing.T) {
 		mock.Anything,
 	).Return(nil).Run(func(args mock.Arguments) {
 		subMatch := args[1].(events.SubscriptionMatcher)
-		assert.True(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}))
-		assert.False(t, subMatch(core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}))
+		assert.True(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns2", Name: "sub1"}}))
+		assert.False(t, subMatch(&core.Subscription{SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"}}))
 		close(waitSubscribed)
 	})
 	ack := cbs.On("DeliveryResponse",
@@ -1219,4 +1219,62 @@ func TestHandleStartWrongNamespace(t *testing.T) {
 	err := wc.handleStart(startMessage)
 	assert.Error(t, err)
 	assert.Regexp(t, "FF10462", err)
-}
\ No newline at end of file
+}
+
+type testErrAuthorizer struct{}
+
+func (t *testErrAuthorizer) Authorize(ctx context.Context, authReq *fftypes.AuthReq) error {
+	return fmt.Errorf("pop")
+}
+
+func TestUpgradeUnauthorized(t *testing.T) {
+	ws := &WebSockets{ctx: context.Background(), auth: &testAuthorizer{}}
+
+	req := httptest.NewRequest("GET", "/ws?namespace=ns2", nil)
+	res := httptest.NewRecorder()
+	ws.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Regexp(t, "FF00169", res.Body.String())
+
+	req = httptest.NewRequest("GET", "/api/v1/namespaces/ns2/ws", nil)
+	res = httptest.NewRecorder()
+	ws.ServeHTTPNamespaced("ns2", res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+
+	ws.auth = &testErrAuthorizer{}
+	req = httptest.NewRequest("GET", "/api/v1/namespaces/ns1/ws", nil)
+	res = httptest.NewRecorder()
+	ws.ServeHTTPNamespaced("ns1", res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Regexp(t, "pop", res.Body.String())
+}
+
+func TestUpgradeAuthorizedNamespace(t *testing.T) {
+	cbs := &eventsmocks.Callbacks{}
+	ws, _, cancel := newTestWebsockets(t, cbs, &testAuthorizer{}, "namespace=ns1")
+	defer cancel()
+
+	assert.Len(t, ws.GetStatus().Connections, 1)
+}
+
+func TestDurableSubMatcherOwner(t *testing.T) {
+	wc := &websocketConnection{
+		ctx:       context.Background(),
+		principal: "basic:user1",
+		started: []*websocketStartedSub{
+			{WSStart: core.WSStart{Namespace: "ns1", Name: "sub1"}},
+			{WSStart: core.WSStart{Namespace: "ns1", Name: "sub2"}},
+		},
+	}
+	assert.True(t, wc.durableSubMatcher(&core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub1"},
+		Owner:           "basic:user1",
+	}))
+	assert.True(t, wc.durableSubMatcher(&core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"},
+	}))
+	assert.False(t, wc.durableSubMatcher(&core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{Namespace: "ns1", Name: "sub2"},
+		Owner:           "basic:user2",
+	}))
+}
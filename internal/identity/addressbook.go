@@ -0,0 +1,82 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// ResolveAddressAlias returns the address for an input that matches the name of an entry in the address book
+// of the namespace, or the input unchanged if there is no matching entry
+func (im *identityManager) ResolveAddressAlias(ctx context.Context, input string) (string, error) {
+	if input == "" {
+		return input, nil
+	}
+	entry, err := im.database.GetAddressBookEntryByName(ctx, im.namespace, input)
+	if err != nil || entry == nil {
+		return input, err
+	}
+	log.L(ctx).Debugf("Resolved address book alias '%s' to '%s'", input, entry.Address)
+	return entry.Address, nil
+}
+
+func (im *identityManager) CreateAddressBookEntry(ctx context.Context, entry *core.AddressBookEntry) (*core.AddressBookEntry, error) {
+	if err := fftypes.ValidateFFNameField(ctx, entry.Name, "name"); err != nil {
+		return nil, err
+	}
+	if entry.Address == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAddressBookAddressMissing)
+	}
+	entry.ID = fftypes.NewUUID()
+	entry.Namespace = im.namespace
+	entry.Created = fftypes.Now()
+	entry.Updated = entry.Created
+	if err := im.database.UpsertAddressBookEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (im *identityManager) GetAddressBookEntries(ctx context.Context, filter ffapi.AndFilter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error) {
+	return im.database.GetAddressBookEntries(ctx, im.namespace, filter)
+}
+
+func (im *identityManager) GetAddressBookEntryByName(ctx context.Context, name string) (*core.AddressBookEntry, error) {
+	entry, err := im.database.GetAddressBookEntryByName(ctx, im.namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return entry, nil
+}
+
+func (im *identityManager) DeleteAddressBookEntry(ctx context.Context, name string) error {
+	entry, err := im.GetAddressBookEntryByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	return im.database.DeleteAddressBookEntry(ctx, im.namespace, entry.ID)
+}
@@ -0,0 +1,247 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveAddressAliasEmpty(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	address, err := im.ResolveAddressAlias(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "", address)
+}
+
+func TestResolveAddressAliasMatch(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(&core.AddressBookEntry{
+		Name:    "treasury",
+		Address: "0x1111",
+	}, nil)
+
+	address, err := im.ResolveAddressAlias(ctx, "treasury")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1111", address)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveAddressAliasNoMatch(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "0x2222").Return(nil, nil)
+
+	address, err := im.ResolveAddressAlias(ctx, "0x2222")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x2222", address)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveAddressAliasFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.ResolveAddressAlias(ctx, "treasury")
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveInputSigningKeyAlias(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(&core.AddressBookEntry{
+		Name:    "treasury",
+		Address: "0x1111",
+	}, nil)
+
+	mbi := im.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("ResolveSigningKey", ctx, "0x1111", blockchain.ResolveKeyIntentSign).Return("0x1111", nil)
+
+	resolvedKey, err := im.ResolveInputSigningKey(ctx, "treasury", KeyNormalizationBlockchainPlugin)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1111", resolvedKey)
+
+	mdi.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestResolveInputSigningKeyAliasFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.ResolveInputSigningKey(ctx, "treasury", KeyNormalizationBlockchainPlugin)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateAddressBookEntry(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("UpsertAddressBookEntry", ctx, mock.MatchedBy(func(entry *core.AddressBookEntry) bool {
+		return entry.ID != nil && entry.Namespace == "ns1" && entry.Created != nil && entry.Updated == entry.Created
+	})).Return(nil)
+
+	entry, err := im.CreateAddressBookEntry(ctx, &core.AddressBookEntry{
+		Name:    "treasury",
+		Address: "0x1111",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1111", entry.Address)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateAddressBookEntryBadName(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.CreateAddressBookEntry(ctx, &core.AddressBookEntry{
+		Name:    "!bad",
+		Address: "0x1111",
+	})
+	assert.Regexp(t, "FF00140", err)
+}
+
+func TestCreateAddressBookEntryMissingAddress(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.CreateAddressBookEntry(ctx, &core.AddressBookEntry{
+		Name: "treasury",
+	})
+	assert.Regexp(t, "FF10574", err)
+}
+
+func TestCreateAddressBookEntryFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("UpsertAddressBookEntry", ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := im.CreateAddressBookEntry(ctx, &core.AddressBookEntry{
+		Name:    "treasury",
+		Address: "0x1111",
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetAddressBookEntries(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	fb := database.AddressBookQueryFactory.NewFilter(ctx)
+	f := fb.And(fb.Eq("name", "treasury"))
+	mdi.On("GetAddressBookEntries", ctx, "ns1", f).Return([]*core.AddressBookEntry{}, nil, nil)
+
+	_, _, err := im.GetAddressBookEntries(ctx, f)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetAddressBookEntryByName(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(&core.AddressBookEntry{
+		Name:    "treasury",
+		Address: "0x1111",
+	}, nil)
+
+	entry, err := im.GetAddressBookEntryByName(ctx, "treasury")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1111", entry.Address)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetAddressBookEntryByNameNotFound(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(nil, nil)
+
+	_, err := im.GetAddressBookEntryByName(ctx, "treasury")
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetAddressBookEntryByNameFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.GetAddressBookEntryByName(ctx, "treasury")
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDeleteAddressBookEntry(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	id := fftypes.NewUUID()
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(&core.AddressBookEntry{
+		ID:   id,
+		Name: "treasury",
+	}, nil)
+	mdi.On("DeleteAddressBookEntry", ctx, "ns1", id).Return(nil)
+
+	err := im.DeleteAddressBookEntry(ctx, "treasury")
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDeleteAddressBookEntryNotFound(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "treasury").Return(nil, nil)
+
+	err := im.DeleteAddressBookEntry(ctx, "treasury")
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,100 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func hashAPIKey(key string) *fftypes.Bytes32 {
+	hash := fftypes.Bytes32(sha256.Sum256([]byte(key)))
+	return &hash
+}
+
+// CreateAPIKey generates a new random key with the requested scope. Only the hash of the key is stored, so the
+// returned entry is the only place the key itself is available.
+func (im *identityManager) CreateAPIKey(ctx context.Context, key *core.APIKey) (*core.APIKey, error) {
+	if err := fftypes.ValidateFFNameField(ctx, key.Name, "name"); err != nil {
+		return nil, err
+	}
+	if key.Scope == "" {
+		key.Scope = core.APIKeyScopeReadOnly
+	}
+	scope, err := fftypes.FFEnumParseString(ctx, "apikeyscope", string(key.Scope))
+	if err != nil {
+		return nil, err
+	}
+	fb := database.APIKeyQueryFactory.NewFilter(ctx)
+	existing, _, err := im.database.GetAPIKeys(ctx, im.namespace, fb.And(fb.Eq("name", key.Name)).Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) > 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAPIKeyNameExists, key.Name)
+	}
+
+	key.ID = fftypes.NewUUID()
+	key.Namespace = im.namespace
+	key.Scope = scope
+	key.Key = fftypes.NewRandB32().String()
+	key.Hash = hashAPIKey(key.Key)
+	key.Created = fftypes.Now()
+	if err := im.database.InsertAPIKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ResolveAPIKey returns the API key of the namespace matching the supplied key, or nil if there is no match
+func (im *identityManager) ResolveAPIKey(ctx context.Context, key string) (*core.APIKey, error) {
+	return im.database.GetAPIKeyByHash(ctx, im.namespace, hashAPIKey(key))
+}
+
+func (im *identityManager) GetAPIKeys(ctx context.Context, filter ffapi.AndFilter) ([]*core.APIKey, *ffapi.FilterResult, error) {
+	return im.database.GetAPIKeys(ctx, im.namespace, filter)
+}
+
+func (im *identityManager) GetAPIKeyByID(ctx context.Context, id string) (*core.APIKey, error) {
+	keyID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	key, err := im.database.GetAPIKeyByID(ctx, im.namespace, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return key, nil
+}
+
+func (im *identityManager) DeleteAPIKey(ctx context.Context, id string) error {
+	key, err := im.GetAPIKeyByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return im.database.DeleteAPIKey(ctx, im.namespace, key.ID)
+}
@@ -0,0 +1,181 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeys", ctx, "ns1", mock.Anything).Return([]*core.APIKey{}, nil, nil)
+	mdi.On("InsertAPIKey", ctx, mock.MatchedBy(func(key *core.APIKey) bool {
+		return key.Namespace == "ns1" && key.Scope == core.APIKeyScopeTokens && *key.Hash == *hashAPIKey(key.Key)
+	})).Return(nil)
+
+	key, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "partner1", Scope: "tokens"})
+	assert.NoError(t, err)
+	assert.NotNil(t, key.ID)
+	assert.Len(t, key.Key, 64)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyDefaultScope(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeys", ctx, "ns1", mock.Anything).Return([]*core.APIKey{}, nil, nil)
+	mdi.On("InsertAPIKey", ctx, mock.Anything).Return(nil)
+
+	key, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "partner1"})
+	assert.NoError(t, err)
+	assert.Equal(t, core.APIKeyScopeReadOnly, key.Scope)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyBadName(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "!bad", Scope: core.APIKeyScopeAdmin})
+	assert.Regexp(t, "FF00140", err)
+}
+
+func TestCreateAPIKeyBadScope(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "partner1", Scope: "superuser"})
+	assert.Regexp(t, "FF00172", err)
+}
+
+func TestCreateAPIKeyNameExists(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeys", ctx, "ns1", mock.Anything).Return([]*core.APIKey{{Name: "partner1"}}, nil, nil)
+
+	_, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "partner1", Scope: core.APIKeyScopeAdmin})
+	assert.Regexp(t, "FF10606", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyQueryFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeys", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "partner1", Scope: core.APIKeyScopeAdmin})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyInsertFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeys", ctx, "ns1", mock.Anything).Return([]*core.APIKey{}, nil, nil)
+	mdi.On("InsertAPIKey", ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := im.CreateAPIKey(ctx, &core.APIKey{Name: "partner1", Scope: core.APIKeyScopeAdmin})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeyByHash", ctx, "ns1", hashAPIKey("secret")).Return(&core.APIKey{Name: "partner1"}, nil)
+
+	key, err := im.ResolveAPIKey(ctx, "secret")
+	assert.NoError(t, err)
+	assert.Equal(t, "partner1", key.Name)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetAPIKeys(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeys", ctx, "ns1", mock.Anything).Return([]*core.APIKey{}, nil, nil)
+
+	_, _, err := im.GetAPIKeys(ctx, nil)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	id := fftypes.NewUUID()
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeyByID", ctx, "ns1", id).Return(&core.APIKey{ID: id}, nil)
+	mdi.On("DeleteAPIKey", ctx, "ns1", id).Return(nil)
+
+	err := im.DeleteAPIKey(ctx, id.String())
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestDeleteAPIKeyNotFound(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	id := fftypes.NewUUID()
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeyByID", ctx, "ns1", id).Return(nil, nil)
+
+	err := im.DeleteAPIKey(ctx, id.String())
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetAPIKeyByIDBadID(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	_, err := im.GetAPIKeyByID(ctx, "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetAPIKeyByIDFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAPIKeyByID", ctx, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.GetAPIKeyByID(ctx, fftypes.NewUUID().String())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
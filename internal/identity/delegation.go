@@ -0,0 +1,94 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// IsDelegated checks whether the delegate holds a confirmed delegation from the delegator, that covers the scope
+// and had not expired at the specified time
+func (im *identityManager) IsDelegated(ctx context.Context, delegatorDID, delegateDID string, scope core.DelegationScope, at *fftypes.FFTime) (bool, error) {
+	fb := database.DelegationQueryFactory.NewFilter(ctx)
+	delegations, _, err := im.database.GetDelegations(ctx, im.namespace, fb.And(
+		fb.Eq("delegator", delegatorDID),
+		fb.Eq("delegate", delegateDID),
+	))
+	if err != nil {
+		return false, err
+	}
+	for _, delegation := range delegations {
+		if delegation.Allows(scope, at) {
+			log.L(ctx).Debugf("Identity '%s' submitting on behalf of '%s' under delegation %s", delegateDID, delegatorDID, delegation.ID)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ResolveDelegatedAuthor resolves the identity an input is submitted on behalf of, to a DID, and checks that the identity
+// that owns the signing key holds a current delegation from it that covers the scope
+func (im *identityManager) ResolveDelegatedAuthor(ctx context.Context, author, key string, scope core.DelegationScope) (string, error) {
+	if im.blockchain == nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgBlockchainNotConfigured)
+	}
+	signer, err := im.FindIdentityForVerifier(ctx, []core.IdentityType{
+		core.IdentityTypeOrg,
+		core.IdentityTypeCustom,
+	}, &core.VerifierRef{
+		Type:  im.blockchain.VerifierType(),
+		Value: key,
+	})
+	if err != nil {
+		return "", err
+	}
+	if signer == nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgAuthorMissingForKey, key)
+	}
+	delegator, _, err := im.resolveDelegator(ctx, author, signer, scope)
+	if err != nil {
+		return "", err
+	}
+	if delegator == nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgNotDelegated, signer.DID, author, scope)
+	}
+	return delegator.DID, nil
+}
+
+// resolveDelegator resolves the author a signing identity is submitting on behalf of, returning nil if the signing
+// identity does not hold a current delegation from that author
+func (im *identityManager) resolveDelegator(ctx context.Context, author string, signer *core.Identity, scope core.DelegationScope) (delegator *core.Identity, retryable bool, err error) {
+	delegator, retryable, err = im.CachedIdentityLookupMustExist(ctx, author)
+	if err != nil {
+		return nil, retryable, err
+	}
+	if delegator.DID == signer.DID {
+		return delegator, false, nil
+	}
+	delegated, err := im.IsDelegated(ctx, delegator.DID, signer.DID, scope, fftypes.Now())
+	if err != nil || !delegated {
+		return nil, err != nil, err
+	}
+	return delegator, false, nil
+}
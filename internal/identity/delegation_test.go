@@ -0,0 +1,271 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package identity
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockDelegateIdentity(ctx context.Context, mdi *databasemocks.Plugin, key string) *core.Identity {
+	delegate := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:        fftypes.NewUUID(),
+			DID:       "did:firefly:org/gateway",
+			Namespace: "ns1",
+			Name:      "gateway",
+			Type:      core.IdentityTypeOrg,
+		},
+	}
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", key).
+		Return((&core.Verifier{
+			Identity:  delegate.ID,
+			Namespace: "ns1",
+			VerifierRef: core.VerifierRef{
+				Type:  core.VerifierTypeEthAddress,
+				Value: key,
+			},
+		}).Seal(), nil)
+	mdi.On("GetIdentityByID", ctx, "ns1", delegate.ID).Return(delegate, nil)
+	return delegate
+}
+
+func mockDelegatorIdentity(ctx context.Context, mdi *databasemocks.Plugin) *core.Identity {
+	delegator := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:        fftypes.NewUUID(),
+			DID:       "did:firefly:org/customer",
+			Namespace: "ns1",
+			Name:      "customer",
+			Type:      core.IdentityTypeOrg,
+		},
+	}
+	mdi.On("GetIdentityByDID", ctx, "ns1", delegator.DID).Return(delegator, nil)
+	return delegator
+}
+
+func TestIsDelegatedMatch(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return([]*core.Delegation{
+		{ID: fftypes.NewUUID(), Scope: fftypes.FFStringArray{"transfers"}},
+		{ID: fftypes.NewUUID(), Scope: fftypes.FFStringArray{"messages"}},
+	}, nil, nil)
+
+	delegated, err := im.IsDelegated(ctx, "did:firefly:org/customer", "did:firefly:org/gateway", core.DelegationScopeMessages, fftypes.Now())
+	assert.NoError(t, err)
+	assert.True(t, delegated)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestIsDelegatedExpired(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	expires := fftypes.Now()
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return([]*core.Delegation{
+		{ID: fftypes.NewUUID(), Scope: fftypes.FFStringArray{"messages"}, Expires: expires},
+	}, nil, nil)
+
+	delegated, err := im.IsDelegated(ctx, "did:firefly:org/customer", "did:firefly:org/gateway", core.DelegationScopeMessages, expires)
+	assert.NoError(t, err)
+	assert.False(t, delegated)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestIsDelegatedFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := im.IsDelegated(ctx, "did:firefly:org/customer", "did:firefly:org/gateway", core.DelegationScopeMessages, fftypes.Now())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorOk(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mockDelegateIdentity(ctx, mdi, "0x1234")
+	delegator := mockDelegatorIdentity(ctx, mdi)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return([]*core.Delegation{
+		{ID: fftypes.NewUUID(), Scope: fftypes.FFStringArray{"transfers"}},
+	}, nil, nil)
+
+	author, err := im.ResolveDelegatedAuthor(ctx, delegator.DID, "0x1234", core.DelegationScopeTransfers)
+	assert.NoError(t, err)
+	assert.Equal(t, delegator.DID, author)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorSelf(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	delegate := mockDelegateIdentity(ctx, mdi, "0x1234")
+	mdi.On("GetIdentityByName", ctx, core.IdentityTypeOrg, "ns1", "gateway").Return(delegate, nil)
+
+	author, err := im.ResolveDelegatedAuthor(ctx, "gateway", "0x1234", core.DelegationScopeTransfers)
+	assert.NoError(t, err)
+	assert.Equal(t, delegate.DID, author)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorNotDelegated(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mockDelegateIdentity(ctx, mdi, "0x1234")
+	delegator := mockDelegatorIdentity(ctx, mdi)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return([]*core.Delegation{
+		{ID: fftypes.NewUUID(), Scope: fftypes.FFStringArray{"messages"}},
+	}, nil, nil)
+
+	_, err := im.ResolveDelegatedAuthor(ctx, delegator.DID, "0x1234", core.DelegationScopeTransfers)
+	assert.Regexp(t, "FF10577", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorDelegationsFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mockDelegateIdentity(ctx, mdi, "0x1234")
+	delegator := mockDelegatorIdentity(ctx, mdi)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := im.ResolveDelegatedAuthor(ctx, delegator.DID, "0x1234", core.DelegationScopeTransfers)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorUnknownDelegator(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mockDelegateIdentity(ctx, mdi, "0x1234")
+	mdi.On("GetIdentityByDID", ctx, "ns1", "did:firefly:org/customer").Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.ResolveDelegatedAuthor(ctx, "did:firefly:org/customer", "0x1234", core.DelegationScopeTransfers)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorUnregisteredKey(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x1234").Return(nil, nil)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("GetNetworkVersion").Return(2)
+
+	_, err := im.ResolveDelegatedAuthor(ctx, "did:firefly:org/customer", "0x1234", core.DelegationScopeTransfers)
+	assert.Regexp(t, "FF10356", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorKeyLookupFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x1234").Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.ResolveDelegatedAuthor(ctx, "did:firefly:org/customer", "0x1234", core.DelegationScopeTransfers)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveDelegatedAuthorNoBlockchain(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+	im.blockchain = nil
+
+	_, err := im.ResolveDelegatedAuthor(ctx, "did:firefly:org/customer", "0x1234", core.DelegationScopeTransfers)
+	assert.Regexp(t, "FF10417", err)
+}
+
+func TestResolveInputSigningIdentityDelegated(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "0x1234").Return(nil, nil)
+	mockDelegateIdentity(ctx, mdi, "0x1234")
+	delegator := mockDelegatorIdentity(ctx, mdi)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return([]*core.Delegation{
+		{ID: fftypes.NewUUID(), Scope: fftypes.FFStringArray{"messages"}},
+	}, nil, nil)
+
+	mbi := im.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("ResolveSigningKey", ctx, "0x1234", blockchain.ResolveKeyIntentSign).Return("0x1234", nil)
+
+	signer := &core.SignerRef{
+		Key:    "0x1234",
+		Author: delegator.DID,
+	}
+	err := im.ResolveInputSigningIdentity(ctx, signer)
+	assert.NoError(t, err)
+	assert.Equal(t, delegator.DID, signer.Author)
+	assert.Equal(t, "0x1234", signer.Key)
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveInputSigningIdentityDelegationsFail(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "0x1234").Return(nil, nil)
+	mockDelegateIdentity(ctx, mdi, "0x1234")
+	delegator := mockDelegatorIdentity(ctx, mdi)
+	mdi.On("GetDelegations", ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	mbi := im.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("ResolveSigningKey", ctx, "0x1234", blockchain.ResolveKeyIntentSign).Return("0x1234", nil)
+
+	err := im.ResolveInputSigningIdentity(ctx, &core.SignerRef{
+		Key:    "0x1234",
+		Author: delegator.DID,
+	})
+	assert.EqualError(t, err, "pop")
+
+	mbi.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
@@ -18,9 +18,13 @@ package identity
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"strings"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
@@ -31,6 +35,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/keymanager"
 )
 
 const (
@@ -47,6 +52,8 @@ type Manager interface {
 	ResolveMultipartyRootVerifier(ctx context.Context) (*core.VerifierRef, error)
 
 	FindIdentityForVerifier(ctx context.Context, iTypes []core.IdentityType, verifier *core.VerifierRef) (identity *core.Identity, err error)
+	IsVerifierRevoked(ctx context.Context, verifier *core.VerifierRef) (revoked bool, err error)
+	IsVerifierRevokedAtPin(ctx context.Context, verifier *core.VerifierRef, pinSequence int64) (revoked bool, err error)
 	CachedIdentityLookupByID(ctx context.Context, id *fftypes.UUID) (identity *core.Identity, err error)
 	CachedIdentityLookupMustExist(ctx context.Context, did string) (identity *core.Identity, retryable bool, err error)
 	CachedIdentityLookupNilOK(ctx context.Context, did string) (identity *core.Identity, retryable bool, err error)
@@ -55,24 +62,43 @@ type Manager interface {
 	GetRootOrg(ctx context.Context) (org *core.Identity, err error)
 	VerifyIdentityChain(ctx context.Context, identity *core.Identity) (immediateParent *core.Identity, retryable bool, err error)
 	ValidateNodeOwner(ctx context.Context, node *core.Identity, identity *core.Identity) (valid bool, err error)
+	VerifyOrgCertificate(ctx context.Context, claim *core.IdentityClaim, claimTime *fftypes.FFTime) (verifiedSubject string, err error)
+	VerifyClaimSignature(ctx context.Context, signingKey string, claim *core.IdentityClaim) error
+
+	ResolveAddressAlias(ctx context.Context, input string) (string, error)
+	CreateAddressBookEntry(ctx context.Context, entry *core.AddressBookEntry) (*core.AddressBookEntry, error)
+	GetAddressBookEntries(ctx context.Context, filter ffapi.AndFilter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error)
+	GetAddressBookEntryByName(ctx context.Context, name string) (*core.AddressBookEntry, error)
+	DeleteAddressBookEntry(ctx context.Context, name string) error
+
+	CreateAPIKey(ctx context.Context, key *core.APIKey) (*core.APIKey, error)
+	ResolveAPIKey(ctx context.Context, key string) (*core.APIKey, error)
+	GetAPIKeys(ctx context.Context, filter ffapi.AndFilter) ([]*core.APIKey, *ffapi.FilterResult, error)
+	GetAPIKeyByID(ctx context.Context, id string) (*core.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id string) error
+
+	IsDelegated(ctx context.Context, delegatorDID, delegateDID string, scope core.DelegationScope, at *fftypes.FFTime) (bool, error)
+	ResolveDelegatedAuthor(ctx context.Context, author, key string, scope core.DelegationScope) (string, error)
 }
 
 type identityManager struct {
 	database      database.Plugin
 	blockchain    blockchain.Plugin  // optional
+	keymanager    keymanager.Plugin  // optional
 	multiparty    multiparty.Manager // optional
 	namespace     string
 	defaultKey    string
 	identityCache cache.CInterface
 }
 
-func NewIdentityManager(ctx context.Context, ns, defaultKey string, di database.Plugin, bi blockchain.Plugin, mp multiparty.Manager, cacheManager cache.Manager) (Manager, error) {
+func NewIdentityManager(ctx context.Context, ns, defaultKey string, di database.Plugin, bi blockchain.Plugin, km keymanager.Plugin, mp multiparty.Manager, cacheManager cache.Manager) (Manager, error) {
 	if di == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "IdentityManager")
 	}
 	im := &identityManager{
 		database:   di,
 		blockchain: bi,
+		keymanager: km,
 		namespace:  ns,
 		multiparty: mp,
 		defaultKey: defaultKey,
@@ -160,6 +186,9 @@ func (im *identityManager) resolveInputSigningKey(ctx context.Context, inputKey
 		}
 		return verifierRef.Value, nil
 	}
+	if inputKey, err = im.resolveKeyReference(ctx, inputKey, intent); err != nil {
+		return "", err
+	}
 	// If the caller is not confident that the blockchain plugin/connector should be used to resolve,
 	// for example it might be a different blockchain (Eth vs Fabric etc.), or it has its own
 	// verification/management of keys, it should set `namespaces.predefined[].asset.manager.keyNormalization: "none"` in the config.
@@ -220,8 +249,12 @@ func (im *identityManager) ResolveInputSigningIdentity(ctx context.Context, sign
 		}
 
 	case signerRef.Key != "":
-		// Key specified: normalize it, then check it against author (if specified)
-		if verifier, err = im.resolveInputKeyViaBlockchainPlugin(ctx, signerRef.Key, blockchain.ResolveKeyIntentSign); err != nil {
+		// Key specified: resolve any alias, apply the key manager policy and normalize it, then check it against author (if specified)
+		keyRef, err := im.resolveKeyReference(ctx, signerRef.Key, blockchain.ResolveKeyIntentSign)
+		if err != nil {
+			return err
+		}
+		if verifier, err = im.resolveInputKeyViaBlockchainPlugin(ctx, keyRef, blockchain.ResolveKeyIntentSign); err != nil {
 			return err
 		}
 		signerRef.Key = verifier.Value
@@ -240,7 +273,15 @@ func (im *identityManager) ResolveInputSigningIdentity(ctx context.Context, sign
 				signerRef.Author = identity.DID
 			}
 			if signerRef.Author != identity.DID {
-				return i18n.NewError(ctx, coremsgs.MsgAuthorRegistrationMismatch, verifier.Value, signerRef.Author, identity.DID)
+				// The author can be another identity, that has delegated sending messages to the identity of the key
+				delegator, retryable, err := im.resolveDelegator(ctx, signerRef.Author, identity, core.DelegationScopeMessages)
+				if err != nil && retryable {
+					return err
+				}
+				if delegator == nil {
+					return i18n.NewError(ctx, coremsgs.MsgAuthorRegistrationMismatch, verifier.Value, signerRef.Author, identity.DID)
+				}
+				signerRef.Author = delegator.DID
 			}
 		case signerRef.Author != "":
 			// Key is unrecognized, but an author was specified: use the key and resolve author to DID
@@ -329,6 +370,20 @@ func (im *identityManager) ResolveMultipartyRootVerifier(ctx context.Context) (*
 	return im.resolveInputKeyViaBlockchainPlugin(ctx, orgKey, blockchain.ResolveKeyIntentSign)
 }
 
+// resolveKeyReference maps a key supplied on an API call through the address book of the namespace, then through
+// the key manager plugin (if one is configured), so that aliases are resolved and keys the key manager does not permit
+// signing with are rejected
+func (im *identityManager) resolveKeyReference(ctx context.Context, inputKey string, intent blockchain.ResolveKeyIntent) (string, error) {
+	inputKey, err := im.ResolveAddressAlias(ctx, inputKey)
+	if err != nil {
+		return "", err
+	}
+	if im.keymanager == nil {
+		return inputKey, nil
+	}
+	return im.keymanager.ResolveKey(ctx, inputKey, intent)
+}
+
 // resolveInputKeyViaBlockchainPlugin calls the blockchain plugin to resolve an input key string, to the
 // blockchain native representation of that key. Which might involve sophisticated processing.
 // See ResolveInputSigningKey on the blockchain connector
@@ -360,6 +415,121 @@ func (im *identityManager) FindIdentityForVerifier(ctx context.Context, iTypes [
 	return nil, nil
 }
 
+// IsVerifierRevoked checks whether the specified verifier has been revoked. This is deliberately not cached,
+// so that a revocation takes effect for the very next message processed after it is confirmed.
+func (im *identityManager) IsVerifierRevoked(ctx context.Context, verifierRef *core.VerifierRef) (revoked bool, err error) {
+	verifier, err := im.database.GetVerifierByValue(ctx, verifierRef.Type, im.namespace, verifierRef.Value)
+	if err != nil {
+		return false, err
+	}
+	return verifier != nil && verifier.Revoked != nil, nil
+}
+
+// IsVerifierRevokedAtPin checks whether the specified verifier had been revoked at the point in the sequence of pins
+// given, so that a message pinned before the revocation is processed the same way on every node - regardless of
+// whether the node has processed the revocation by the time it processes the message.
+func (im *identityManager) IsVerifierRevokedAtPin(ctx context.Context, verifierRef *core.VerifierRef, pinSequence int64) (revoked bool, err error) {
+	verifier, err := im.database.GetVerifierByValue(ctx, verifierRef.Type, im.namespace, verifierRef.Value)
+	if err != nil {
+		return false, err
+	}
+	return verifier != nil && verifier.Revoked != nil && (verifier.RevokedPin == 0 || pinSequence > verifier.RevokedPin), nil
+}
+
+// VerifyOrgCertificate checks that the certificate chain presented in the claim of an org chains to one of the trust roots
+// configured for the namespace, and was issued to the org name. The chain is checked at the time the claim was made,
+// rather than the current time, so every node reaches the same result for the same claim however late it processes it.
+// The claim must also be signed with the key of the certificate, to prove the registrant holds it.
+// Returns the subject DN of the certificate, or an empty string if organization verification is not configured.
+func (im *identityManager) VerifyOrgCertificate(ctx context.Context, claim *core.IdentityClaim, claimTime *fftypes.FFTime) (verifiedSubject string, err error) {
+	if im.multiparty == nil || im.multiparty.OrgTrustRoots() == nil {
+		return "", nil
+	}
+	org := claim.Identity
+	if claimTime == nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgOrgCertificateInvalid, org.Name, "claim has no timestamp")
+	}
+
+	var chain []*x509.Certificate
+	rest := []byte(claim.Certificate)
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", i18n.NewError(ctx, coremsgs.MsgOrgCertificateInvalid, org.Name, err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return "", i18n.NewError(ctx, coremsgs.MsgOrgCertificateInvalid, org.Name, "no certificate supplied")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	leaf := chain[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         im.multiparty.OrgTrustRoots(),
+		Intermediates: intermediates,
+		CurrentTime:   *claimTime.Time(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgOrgCertificateInvalid, org.Name, err)
+	}
+	if leaf.Subject.CommonName != org.Name {
+		return "", i18n.NewError(ctx, coremsgs.MsgOrgCertificateInvalid, org.Name, fmt.Sprintf("subject '%s' not issued to organization", leaf.Subject))
+	}
+	if err := verifyCertificateSignature(leaf, claim.SigningPayload(), claim.CertificateSignature); err != nil {
+		return "", i18n.NewError(ctx, coremsgs.MsgOrgCertificateInvalid, org.Name, fmt.Sprintf("claim not signed with certificate key: %s", err))
+	}
+
+	log.L(ctx).Infof("Verified certificate of organization '%s': %s", org.Name, leaf.Subject)
+	return leaf.Subject.String(), nil
+}
+
+// verifyCertificateSignature checks a base64 encoded signature over the payload, made with the private key of the certificate
+// using SHA-256 - as ASN.1 for ECDSA keys, PKCS #1 v1.5 for RSA keys, or a plain Ed25519 signature
+func verifyCertificateSignature(cert *x509.Certificate, payload []byte, signatureB64 string) error {
+	signature, err := base64.StdEncoding.DecodeString(signatureB64)
+	if err == nil && len(signature) == 0 {
+		err = fmt.Errorf("no signature supplied")
+	}
+	if err != nil {
+		return err
+	}
+	var algo x509.SignatureAlgorithm
+	switch cert.PublicKeyAlgorithm {
+	case x509.ECDSA:
+		algo = x509.ECDSAWithSHA256
+	case x509.RSA:
+		algo = x509.SHA256WithRSA
+	default:
+		algo = x509.PureEd25519
+	}
+	return cert.CheckSignature(algo, payload, signature)
+}
+
+// VerifyClaimSignature asks the blockchain plugin to check the signature on an identity claim was made by the
+// key that submitted it, so trust in the claim does not rest only on the connector reporting the submitting key.
+// Unsigned claims are accepted, unless the namespace is configured to require signed claims.
+func (im *identityManager) VerifyClaimSignature(ctx context.Context, signingKey string, claim *core.IdentityClaim) error {
+	if claim.Signature == nil {
+		if im.multiparty != nil && im.multiparty.RequireSignedClaims() {
+			return i18n.NewError(ctx, coremsgs.MsgDefRejectedClaimUnsigned, claim.Identity.DID)
+		}
+		return nil
+	}
+	if err := im.blockchain.VerifySignature(ctx, signingKey, claim.SigningPayload(), claim.Signature); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Verified signature on claim of identity '%s' by key '%s'", claim.Identity.DID, signingKey)
+	return nil
+}
+
 func (im *identityManager) VerifyIdentityChain(ctx context.Context, checkIdentity *core.Identity) (immediateParent *core.Identity, retryable bool, err error) {
 
 	err = checkIdentity.Validate(ctx)
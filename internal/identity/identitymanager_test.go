@@ -18,8 +18,20 @@ package identity
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -30,6 +42,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/keymanagermocks"
 	"github.com/hyperledger/firefly/mocks/multipartymocks"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -48,7 +61,7 @@ func newTestIdentityManager(t *testing.T) (context.Context, *identityManager) {
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress).Maybe()
 	ns := "ns1"
-	im, err := NewIdentityManager(ctx, ns, "", mdi, mbi, mmp, cmi)
+	im, err := NewIdentityManager(ctx, ns, "", mdi, mbi, nil, mmp, cmi)
 	assert.NoError(t, err)
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
@@ -61,7 +74,7 @@ func newTestIdentityManager(t *testing.T) (context.Context, *identityManager) {
 }
 
 func TestNewIdentityManagerMissingDeps(t *testing.T) {
-	_, err := NewIdentityManager(context.Background(), "", "", nil, nil, nil, nil)
+	_, err := NewIdentityManager(context.Background(), "", "", nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -85,7 +98,7 @@ func TestCacheInitFail(t *testing.T) {
 		ns,
 	)).Return(nil, cacheInitError).Once()
 	defer iErrcmi.AssertExpectations(t)
-	_, err := NewIdentityManager(ctx, ns, "", mdi, mbi, mmp, iErrcmi)
+	_, err := NewIdentityManager(ctx, ns, "", mdi, mbi, nil, mmp, iErrcmi)
 	assert.Equal(t, cacheInitError, err)
 
 }
@@ -306,12 +319,14 @@ func TestResolveInputSigningIdentityByKeyOk(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
 
 	idID := fftypes.NewUUID()
 
-	mdi := im.database.(*databasemocks.Plugin)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").
 		Return((&core.Verifier{
 			Identity:  idID,
@@ -349,6 +364,9 @@ func TestResolveInputSigningIdentityAnonymousKeyWithAuthorOk(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mmp := im.multiparty.(*multipartymocks.Manager)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
@@ -356,7 +374,6 @@ func TestResolveInputSigningIdentityAnonymousKeyWithAuthorOk(t *testing.T) {
 
 	idID := fftypes.NewUUID()
 
-	mdi := im.database.(*databasemocks.Plugin)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").Return(nil, nil)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").Return(nil, nil)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, core.LegacySystemNamespace, "fullkey123").Return(nil, nil)
@@ -390,12 +407,14 @@ func TestResolveInputSigningIdentityKeyWithNoAuthorFail(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mmp := im.multiparty.(*multipartymocks.Manager)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
 	mmp.On("GetNetworkVersion").Return(1)
 
-	mdi := im.database.(*databasemocks.Plugin)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").Return(nil, nil)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").Return(nil, nil)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, core.LegacySystemNamespace, "fullkey123").Return(nil, nil)
@@ -415,12 +434,14 @@ func TestResolveInputSigningIdentityByKeyDIDMismatch(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
 
 	idID := fftypes.NewUUID()
 
-	mdi := im.database.(*databasemocks.Plugin)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").
 		Return((&core.Verifier{
 			Identity:  idID,
@@ -441,6 +462,11 @@ func TestResolveInputSigningIdentityByKeyDIDMismatch(t *testing.T) {
 			},
 		}, nil)
 
+	mdi.On("GetIdentityByDID", ctx, "ns1", "did:firefly:ns/ns1/notmyid").Return(nil, nil)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("GetNetworkVersion").Return(2)
+
 	msgIdentity := &core.SignerRef{
 		Key:    "mykey123",
 		Author: "did:firefly:ns/ns1/notmyid",
@@ -457,12 +483,14 @@ func TestResolveInputSigningIdentityByKeyNotFound(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mmp := im.multiparty.(*multipartymocks.Manager)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
 	mmp.On("GetNetworkVersion").Return(1)
 
-	mdi := im.database.(*databasemocks.Plugin)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").
 		Return(nil, nil)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").
@@ -491,10 +519,12 @@ func TestResolveInputSigningIdentityByKeyFail(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
 
-	mdi := im.database.(*databasemocks.Plugin)
 	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "fullkey123").
 		Return(nil, fmt.Errorf("pop"))
 
@@ -513,6 +543,9 @@ func TestResolveInputSigningIdentityByKeyResolveFail(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "mykey123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mbi.On("ResolveSigningKey", ctx, "mykey123", blockchain.ResolveKeyIntentSign).Return("", fmt.Errorf("pop"))
 
@@ -525,6 +558,26 @@ func TestResolveInputSigningIdentityByKeyResolveFail(t *testing.T) {
 	mbi.AssertExpectations(t)
 }
 
+func TestResolveInputSigningIdentityByKeyKeyManagerFail(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "alias1").Return(nil, nil)
+	mkm := &keymanagermocks.Plugin{}
+	im.keymanager = mkm
+
+	mkm.On("ResolveKey", ctx, "alias1", blockchain.ResolveKeyIntentSign).Return("", fmt.Errorf("pop"))
+
+	msgIdentity := &core.SignerRef{
+		Key: "alias1",
+	}
+	err := im.ResolveInputSigningIdentity(ctx, msgIdentity)
+	assert.Regexp(t, "pop", err)
+
+	mkm.AssertExpectations(t)
+}
+
 func TestResolveInputSigningIdentityByOrgNameOk(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
@@ -667,6 +720,10 @@ func TestResolveInputSigningKeyNoDefaultNoBlockchain(t *testing.T) {
 
 func TestResolveInputSigningKeyDefaultNoBlockchainInputFallback(t *testing.T) {
 	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "testKey").Return(nil, nil)
+
 	im.blockchain = nil
 	im.defaultKey = "key123"
 
@@ -755,6 +812,9 @@ func TestResolveInputSigningKeyOk(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "key123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mbi.On("ResolveSigningKey", ctx, "key123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
 
@@ -769,6 +829,9 @@ func TestResolveInputSigningKeyFail(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "key123").Return(nil, nil)
+
 	mbi := im.blockchain.(*blockchainmocks.Plugin)
 	mbi.On("ResolveSigningKey", ctx, "key123", blockchain.ResolveKeyIntentSign).Return("", fmt.Errorf("pop"))
 
@@ -778,10 +841,51 @@ func TestResolveInputSigningKeyFail(t *testing.T) {
 	mbi.AssertExpectations(t)
 }
 
+func TestResolveInputSigningKeyKeyManagerOk(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "alias1").Return(nil, nil)
+	mkm := &keymanagermocks.Plugin{}
+	im.keymanager = mkm
+
+	mkm.On("ResolveKey", ctx, "alias1", blockchain.ResolveKeyIntentSign).Return("key123", nil)
+	mbi := im.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("ResolveSigningKey", ctx, "key123", blockchain.ResolveKeyIntentSign).Return("fullkey123", nil)
+
+	resolvedKey, err := im.ResolveInputSigningKey(ctx, "alias1", KeyNormalizationBlockchainPlugin)
+	assert.NoError(t, err)
+	assert.Equal(t, "fullkey123", resolvedKey)
+
+	mkm.AssertExpectations(t)
+	mbi.AssertExpectations(t)
+}
+
+func TestResolveInputSigningKeyKeyManagerFail(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "alias1").Return(nil, nil)
+	mkm := &keymanagermocks.Plugin{}
+	im.keymanager = mkm
+
+	mkm.On("ResolveKey", ctx, "alias1", blockchain.ResolveKeyIntentSign).Return("", fmt.Errorf("pop"))
+
+	_, err := im.ResolveInputSigningKey(ctx, "alias1", KeyNormalizationBlockchainPlugin)
+	assert.Regexp(t, "pop", err)
+
+	mkm.AssertExpectations(t)
+}
+
 func TestResolveInputSigningKeyBypass(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
 
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetAddressBookEntryByName", ctx, "ns1", "different-type-of-key").Return(nil, nil)
+
 	key, err := im.ResolveInputSigningKey(ctx, "different-type-of-key", KeyNormalizationNone)
 	assert.NoError(t, err)
 	assert.Equal(t, "different-type-of-key", key)
@@ -952,6 +1056,99 @@ func TestCachedIdentityLookupByVerifierRefNotFound(t *testing.T) {
 
 }
 
+func TestIsVerifierRevoked(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").
+		Return(&core.Verifier{
+			VerifierRef: core.VerifierRef{
+				Type:  core.VerifierTypeEthAddress,
+				Value: "0x12345",
+			},
+			Revoked: fftypes.Now(),
+		}, nil).Once()
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").
+		Return(nil, nil).Once()
+
+	revoked, err := im.IsVerifierRevoked(ctx, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	})
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = im.IsVerifierRevoked(ctx, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	})
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestIsVerifierRevokedFail(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").Return(nil, fmt.Errorf("pop"))
+
+	_, err := im.IsVerifierRevoked(ctx, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	})
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestIsVerifierRevokedAtPin(t *testing.T) {
+
+	ctx, im := newTestIdentityManager(t)
+	verifierRef := &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	}
+
+	mdi := im.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").
+		Return(&core.Verifier{
+			VerifierRef: *verifierRef,
+			Revoked:     fftypes.Now(),
+			RevokedPin:  100,
+		}, nil).Twice()
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").
+		Return(&core.Verifier{
+			VerifierRef: *verifierRef,
+			Revoked:     fftypes.Now(),
+		}, nil).Once()
+	mdi.On("GetVerifierByValue", ctx, core.VerifierTypeEthAddress, "ns1", "0x12345").
+		Return(nil, fmt.Errorf("pop")).Once()
+
+	// Pinned before (or in the same batch as) the revocation
+	revoked, err := im.IsVerifierRevokedAtPin(ctx, verifierRef, 100)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Pinned after the revocation
+	revoked, err = im.IsVerifierRevokedAtPin(ctx, verifierRef, 101)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	// A revocation that was not pinned applies to every message
+	revoked, err = im.IsVerifierRevokedAtPin(ctx, verifierRef, 1)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	_, err = im.IsVerifierRevokedAtPin(ctx, verifierRef, 1)
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestCachedIdentityLookupMustExistCaching(t *testing.T) {
 
 	ctx, im := newTestIdentityManager(t)
@@ -1674,3 +1871,303 @@ func TestValidateNodeOwnerGetError(t *testing.T) {
 
 	mdi.AssertExpectations(t)
 }
+
+func newTestCert(t *testing.T, cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn, Organization: []string{"FireFly"}},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func newTestOrgClaim(t *testing.T, certPEM string, key crypto.Signer) *core.IdentityClaim {
+	claim := &core.IdentityClaim{
+		Identity: &core.Identity{
+			IdentityBase: core.IdentityBase{Name: "org1", DID: "did:firefly:org/org1"},
+		},
+		Certificate: certPEM,
+	}
+	if key != nil {
+		hash := sha256.Sum256(claim.SigningPayload())
+		sig, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+		assert.NoError(t, err)
+		claim.CertificateSignature = base64.StdEncoding.EncodeToString(sig)
+	}
+	return claim
+}
+
+func TestVerifyOrgCertificateNotConfigured(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(nil)
+
+	subject, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, "", nil), fftypes.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, subject)
+
+	im.multiparty = nil
+	subject, err = im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, "", nil), fftypes.Now())
+	assert.NoError(t, err)
+	assert.Empty(t, subject)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateOk(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	ca, caKey, _ := newTestCert(t, "root", nil, nil)
+	_, leafKey, leafPEM := newTestCert(t, "org1", ca, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	subject, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, leafKey), fftypes.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "CN=org1,O=FireFly", subject)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateWithIntermediateOk(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	ca, caKey, _ := newTestCert(t, "root", nil, nil)
+	intermediate, intermediateKey, intermediatePEM := newTestCert(t, "intermediate", ca, caKey)
+	_, leafKey, leafPEM := newTestCert(t, "org1", intermediate, intermediateKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	subject, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM+intermediatePEM, leafKey), fftypes.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, "CN=org1,O=FireFly", subject)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateCheckedAtClaimTime(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	ca, caKey, _ := newTestCert(t, "root", nil, nil)
+	_, leafKey, leafPEM := newTestCert(t, "org1", ca, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	// The certificate was valid when the claim was made, even though it has since expired
+	claimTime := fftypes.FFTime(time.Now().Add(-30 * time.Minute))
+	notYetValid := fftypes.FFTime(time.Now().Add(-2 * time.Hour))
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, leafKey), &claimTime)
+	assert.NoError(t, err)
+	_, err = im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, leafKey), &notYetValid)
+	assert.Regexp(t, "FF10483", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateNoClaimTime(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(x509.NewCertPool())
+
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, "", nil), nil)
+	assert.Regexp(t, "FF10483.*no timestamp", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateMissing(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(x509.NewCertPool())
+
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, "", nil), fftypes.Now())
+	assert.Regexp(t, "FF10483.*no certificate", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateBadPEM(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(x509.NewCertPool())
+
+	badPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("!cert")}))
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, badPEM, nil), fftypes.Now())
+	assert.Regexp(t, "FF10483", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateUntrusted(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	ca, _, _ := newTestCert(t, "root", nil, nil)
+	otherCA, otherCAKey, _ := newTestCert(t, "other", nil, nil)
+	_, leafKey, leafPEM := newTestCert(t, "org1", otherCA, otherCAKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, leafKey), fftypes.Now())
+	assert.Regexp(t, "FF10483", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateWrongName(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	ca, caKey, _ := newTestCert(t, "root", nil, nil)
+	_, leafKey, leafPEM := newTestCert(t, "org2", ca, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, leafKey), fftypes.Now())
+	assert.Regexp(t, "FF10483.*CN=org2", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateUnsigned(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	ca, caKey, _ := newTestCert(t, "root", nil, nil)
+	_, _, leafPEM := newTestCert(t, "org1", ca, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	_, err := im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, nil), fftypes.Now())
+	assert.Regexp(t, "FF10483.*no signature", err)
+
+	claim := newTestOrgClaim(t, leafPEM, nil)
+	claim.CertificateSignature = "!base64"
+	_, err = im.VerifyOrgCertificate(ctx, claim, fftypes.Now())
+	assert.Regexp(t, "FF10483.*not signed", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyOrgCertificateSignedWithOtherKey(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	// Any public certificate issued to the org name can be presented, but only the holder of its key can sign
+	ca, caKey, _ := newTestCert(t, "root", nil, nil)
+	_, _, leafPEM := newTestCert(t, "org1", ca, caKey)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("OrgTrustRoots").Return(roots)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	_, err = im.VerifyOrgCertificate(ctx, newTestOrgClaim(t, leafPEM, otherKey), fftypes.Now())
+	assert.Regexp(t, "FF10483.*not signed", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyCertificateSignatureKeyTypes(t *testing.T) {
+	payload := []byte(`{"did":"did:firefly:org/org1"}`)
+	hash := sha256.Sum256(payload)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaSig, err := rsaKey.Sign(rand.Reader, hash[:], crypto.SHA256)
+	assert.NoError(t, err)
+	rsaCert := &x509.Certificate{PublicKeyAlgorithm: x509.RSA, PublicKey: &rsaKey.PublicKey}
+	assert.NoError(t, verifyCertificateSignature(rsaCert, payload, base64.StdEncoding.EncodeToString(rsaSig)))
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	edCert := &x509.Certificate{PublicKeyAlgorithm: x509.Ed25519, PublicKey: edPub}
+	assert.NoError(t, verifyCertificateSignature(edCert, payload, base64.StdEncoding.EncodeToString(ed25519.Sign(edKey, payload))))
+}
+
+func TestVerifyClaimSignatureUnsigned(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("RequireSignedClaims").Return(false)
+
+	claim := &core.IdentityClaim{Identity: &core.Identity{IdentityBase: core.IdentityBase{DID: "did:firefly:org/org1"}}}
+	err := im.VerifyClaimSignature(ctx, "0x12345", claim)
+	assert.NoError(t, err)
+
+	im.multiparty = nil
+	err = im.VerifyClaimSignature(ctx, "0x12345", claim)
+	assert.NoError(t, err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyClaimSignatureRequired(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	mmp := im.multiparty.(*multipartymocks.Manager)
+	mmp.On("RequireSignedClaims").Return(true)
+
+	claim := &core.IdentityClaim{Identity: &core.Identity{IdentityBase: core.IdentityBase{DID: "did:firefly:org/org1"}}}
+	err := im.VerifyClaimSignature(ctx, "0x12345", claim)
+	assert.Regexp(t, "FF10597.*did:firefly:org/org1", err)
+
+	mmp.AssertExpectations(t)
+}
+
+func TestVerifyClaimSignature(t *testing.T) {
+	ctx, im := newTestIdentityManager(t)
+
+	parent := fftypes.NewUUID()
+	sig := &core.KeySignature{Value: "0xabcd"}
+	claim := &core.IdentityClaim{
+		Identity:  &core.Identity{IdentityBase: core.IdentityBase{DID: "did:firefly:custom1", Parent: parent}},
+		Signature: sig,
+	}
+	payload := []byte(`{"did":"did:firefly:custom1","parent":"` + parent.String() + `"}`)
+
+	mbi := im.blockchain.(*blockchainmocks.Plugin)
+	mbi.On("VerifySignature", ctx, "0x12345", payload, sig).Return(nil).Once()
+	mbi.On("VerifySignature", ctx, "0x12345", payload, sig).Return(fmt.Errorf("pop")).Once()
+
+	err := im.VerifyClaimSignature(ctx, "0x12345", claim)
+	assert.NoError(t, err)
+
+	err = im.VerifyClaimSignature(ctx, "0x12345", claim)
+	assert.Regexp(t, "pop", err)
+
+	mbi.AssertExpectations(t)
+}
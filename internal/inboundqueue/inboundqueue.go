@@ -0,0 +1,221 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inboundqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// Queue is the inbound event queue of one plugin. Each connection the plugin holds to its connector
+// (one per namespace, or a single shared connection) has its own queue, bounded to a maximum length.
+//
+// The event loop of the plugin stores each event with Enqueue, and can acknowledge it to the connector
+// as soon as that returns - so a burst of events only has to be written to the database before the
+// next one is read from the websocket. When a queue is full, Enqueue blocks, which pushes back on the
+// connector rather than holding an unbounded number of events in memory.
+//
+// The events are delivered to the handler of the plugin in order by a consumer for each connection,
+// and only removed from the database once they have been processed. Events that were queued, but not
+// processed, before a restart are delivered when the consumer starts again.
+type Queue struct {
+	plugin      string
+	database    database.Plugin
+	maxLength   int
+	batchSize   int
+	pollTimeout time.Duration
+	retry       *retry.Retry
+	mux         sync.Mutex
+	connections map[string]*connection
+}
+
+type connection struct {
+	namespace string
+	length    int
+	newEvents chan bool
+	space     chan bool
+	consumer  sync.Mutex
+}
+
+func NewQueue(plugin string, di database.Plugin) *Queue {
+	return &Queue{
+		plugin:      plugin,
+		database:    di,
+		maxLength:   config.GetInt(coreconfig.EventInboundQueueMaxLength),
+		batchSize:   config.GetInt(coreconfig.EventInboundQueueBatchSize),
+		pollTimeout: config.GetDuration(coreconfig.EventInboundQueuePollTimeout),
+		retry: &retry.Retry{
+			InitialDelay: config.GetDuration(coreconfig.EventInboundQueueRetryInitDelay),
+			MaximumDelay: config.GetDuration(coreconfig.EventInboundQueueRetryMaxDelay),
+			Factor:       config.GetFloat64(coreconfig.EventInboundQueueRetryFactor),
+		},
+		connections: make(map[string]*connection),
+	}
+}
+
+// getConnection returns the state of the queue for a connection, counting the events already
+// in the database the first time the connection is used
+func (q *Queue) getConnection(ctx context.Context, namespace string) (*connection, error) {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	if c, ok := q.connections[namespace]; ok {
+		return c, nil
+	}
+	var length int64
+	err := q.retry.Do(ctx, "count inbound events", func(attempt int) (retry bool, err error) {
+		fb := database.InboundEventQueryFactory.NewFilter(ctx)
+		_, res, err := q.database.GetInboundEvents(ctx, q.plugin, namespace, fb.And().Count(true).Limit(1))
+		if err == nil && res.TotalCount != nil {
+			length = *res.TotalCount
+		}
+		return true, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	c := &connection{
+		namespace: namespace,
+		length:    int(length),
+		newEvents: make(chan bool, 1),
+		space:     make(chan bool, 1),
+	}
+	q.connections[namespace] = c
+	return c, nil
+}
+
+func (q *Queue) Enqueue(ctx context.Context, namespace string, payload []byte) error {
+	c, err := q.getConnection(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	// There is a single event loop reading each connection, so once there is space it cannot be taken
+	for q.isFull(c) {
+		log.L(ctx).Debugf("Inbound event queue for plugin '%s' is full (namespace=%s)", q.plugin, namespace)
+		select {
+		case <-c.space:
+		case <-ctx.Done():
+			return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+	}
+
+	event := &core.InboundEvent{
+		Plugin:    q.plugin,
+		Namespace: namespace,
+		Payload:   string(payload),
+		Created:   fftypes.Now(),
+	}
+	err = q.retry.Do(ctx, "enqueue inbound event", func(attempt int) (retry bool, err error) {
+		return true, q.database.InsertInboundEvent(ctx, event)
+	})
+	if err != nil {
+		return err
+	}
+	log.L(ctx).Debugf("Inbound event %d queued for plugin '%s' (namespace=%s)", event.Sequence, q.plugin, namespace)
+
+	q.mux.Lock()
+	c.length++
+	q.mux.Unlock()
+	signal(c.newEvents)
+	return nil
+}
+
+func (q *Queue) Consume(ctx context.Context, namespace string, handler core.InboundEventHandler) {
+	go q.consumeLoop(ctx, namespace, handler)
+}
+
+func (q *Queue) consumeLoop(ctx context.Context, namespace string, handler core.InboundEventHandler) {
+	c, err := q.getConnection(ctx, namespace)
+	if err != nil {
+		log.L(ctx).Debugf("Inbound event consumer for plugin '%s' exiting (namespace=%s): %s", q.plugin, namespace, err)
+		return
+	}
+
+	// Only one consumer delivers the events of a connection at a time, so a consumer started while
+	// a previous one is still exiting waits for it
+	c.consumer.Lock()
+	defer c.consumer.Unlock()
+	for {
+		err := q.retry.Do(ctx, "deliver inbound events", func(attempt int) (retry bool, err error) {
+			return true, q.deliverEvents(ctx, c, handler)
+		})
+		if err != nil {
+			log.L(ctx).Debugf("Inbound event consumer for plugin '%s' exiting (namespace=%s)", q.plugin, namespace)
+			return
+		}
+		select {
+		case <-c.newEvents:
+		case <-time.After(q.pollTimeout):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Inbound event consumer for plugin '%s' exiting (namespace=%s)", q.plugin, namespace)
+			return
+		}
+	}
+}
+
+// deliverEvents delivers queued events in order until the queue is empty. On error the next attempt
+// starts again from the event that failed, as it has not been removed from the queue.
+func (q *Queue) deliverEvents(ctx context.Context, c *connection, handler core.InboundEventHandler) error {
+	for {
+		fb := database.InboundEventQueryFactory.NewFilter(ctx)
+		events, _, err := q.database.GetInboundEvents(ctx, q.plugin, c.namespace, fb.And().Sort("sequence").Limit(uint64(q.batchSize)))
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		for _, event := range events {
+			if err := handler(ctx, []byte(event.Payload)); err != nil {
+				return err
+			}
+			if err := q.database.DeleteInboundEvent(ctx, event.Sequence); err != nil && err != fftypes.DeleteRecordNotFound {
+				return err
+			}
+			q.mux.Lock()
+			c.length--
+			q.mux.Unlock()
+			signal(c.space)
+		}
+
+		if len(events) < q.batchSize {
+			return nil
+		}
+	}
+}
+
+func (q *Queue) isFull(c *connection) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	return c.length >= q.maxLength
+}
+
+func signal(ch chan bool) {
+	select {
+	case ch <- true:
+	default:
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inboundqueue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestQueue(t *testing.T) (*Queue, *databasemocks.Plugin) {
+	coreconfig.Reset()
+	config.Set(coreconfig.EventInboundQueueMaxLength, 2)
+	config.Set(coreconfig.EventInboundQueueBatchSize, 2)
+	config.Set(coreconfig.EventInboundQueueRetryInitDelay, "1ms")
+	mdi := &databasemocks.Plugin{}
+	q := NewQueue("erc20", mdi)
+	return q, mdi
+}
+
+func countResult(count int64) *ffapi.FilterResult {
+	return &ffapi.FilterResult{TotalCount: &count}
+}
+
+func cancelledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestEnqueueAndConsume(t *testing.T) {
+	q, mdi := newTestQueue(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, countResult(0), nil).Once()
+	mdi.On("InsertInboundEvent", mock.Anything, mock.MatchedBy(func(event *core.InboundEvent) bool {
+		event.Sequence = 1
+		return event.Plugin == "erc20" && event.Namespace == "ns1" && event.Payload == `{"id":"1"}`
+	})).Return(nil)
+
+	err := q.Enqueue(ctx, "ns1", []byte(`{"id":"1"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, q.connections["ns1"].length)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{
+		{Sequence: 1, Payload: `{"id":"1"}`},
+	}, nil, nil).Once()
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{}, nil, nil)
+	mdi.On("DeleteInboundEvent", mock.Anything, int64(1)).Return(nil)
+
+	handled := make(chan string)
+	q.Consume(ctx, "ns1", func(ctx context.Context, payload []byte) error {
+		handled <- string(payload)
+		return nil
+	})
+	assert.Equal(t, `{"id":"1"}`, <-handled)
+
+	// The space is released once the event is removed from the queue
+	<-q.connections["ns1"].space
+	assert.Equal(t, 0, q.connections["ns1"].length)
+	cancel()
+}
+
+func TestEnqueueWaitsForSpace(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, countResult(2), nil).Once()
+	mdi.On("InsertInboundEvent", mock.Anything, mock.Anything).Return(nil)
+
+	c, err := q.getConnection(context.Background(), "ns1")
+	assert.NoError(t, err)
+	go func() {
+		q.mux.Lock()
+		c.length--
+		q.mux.Unlock()
+		signal(c.space)
+	}()
+
+	err = q.Enqueue(context.Background(), "ns1", []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.length)
+}
+
+func TestEnqueueFullContextCancelled(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, countResult(2), nil).Once()
+
+	_, err := q.getConnection(context.Background(), "ns1")
+	assert.NoError(t, err)
+
+	err = q.Enqueue(cancelledContext(), "ns1", []byte(`{}`))
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestEnqueueCountFail(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := q.Enqueue(cancelledContext(), "ns1", []byte(`{}`))
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestEnqueueInsertFail(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, countResult(0), nil).Once()
+	mdi.On("InsertInboundEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := q.getConnection(context.Background(), "ns1")
+	assert.NoError(t, err)
+
+	err = q.Enqueue(cancelledContext(), "ns1", []byte(`{}`))
+	assert.Regexp(t, "FF00154", err)
+	assert.Equal(t, 0, q.connections["ns1"].length)
+}
+
+func TestConsumeCountFail(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	q.consumeLoop(cancelledContext(), "ns1", func(ctx context.Context, payload []byte) error {
+		return nil
+	})
+	assert.Empty(t, q.connections)
+}
+
+func TestConsumeDeliverFail(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, countResult(1), nil).Once()
+	_, err := q.getConnection(context.Background(), "ns1")
+	assert.NoError(t, err)
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{
+		{Sequence: 1, Payload: `{"id":"1"}`},
+	}, nil, nil)
+
+	q.consumeLoop(cancelledContext(), "ns1", func(ctx context.Context, payload []byte) error {
+		return fmt.Errorf("pop")
+	})
+	assert.Equal(t, 1, q.connections["ns1"].length)
+	mdi.AssertNotCalled(t, "DeleteInboundEvent", mock.Anything, mock.Anything)
+}
+
+func TestConsumePollAndExit(t *testing.T) {
+	q, mdi := newTestQueue(t)
+	config.Set(coreconfig.EventInboundQueuePollTimeout, "1ms")
+	q = NewQueue("erc20", mdi)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, countResult(0), nil).Once()
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{}, nil, nil).Once()
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{}, nil, nil).Run(func(args mock.Arguments) {
+		cancel()
+	})
+
+	q.consumeLoop(ctx, "ns1", func(ctx context.Context, payload []byte) error {
+		return nil
+	})
+	mdi.AssertExpectations(t)
+}
+
+func TestDeliverEventsFullBatches(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	c := &connection{namespace: "ns1", length: 3, newEvents: make(chan bool, 1), space: make(chan bool, 1)}
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{
+		{Sequence: 1, Payload: `{"id":"1"}`},
+		{Sequence: 2, Payload: `{"id":"2"}`},
+	}, nil, nil).Once()
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{
+		{Sequence: 3, Payload: `{"id":"3"}`},
+	}, nil, nil).Once()
+	mdi.On("DeleteInboundEvent", mock.Anything, int64(1)).Return(nil)
+	mdi.On("DeleteInboundEvent", mock.Anything, int64(2)).Return(fftypes.DeleteRecordNotFound)
+	mdi.On("DeleteInboundEvent", mock.Anything, int64(3)).Return(nil)
+
+	var handled []string
+	err := q.deliverEvents(context.Background(), c, func(ctx context.Context, payload []byte) error {
+		handled = append(handled, string(payload))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`{"id":"1"}`, `{"id":"2"}`, `{"id":"3"}`}, handled)
+	assert.Equal(t, 0, c.length)
+	mdi.AssertExpectations(t)
+}
+
+func TestDeliverEventsDeleteFail(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	c := &connection{namespace: "ns1", length: 1, newEvents: make(chan bool, 1), space: make(chan bool, 1)}
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return([]*core.InboundEvent{
+		{Sequence: 1, Payload: `{"id":"1"}`},
+	}, nil, nil).Once()
+	mdi.On("DeleteInboundEvent", mock.Anything, int64(1)).Return(fmt.Errorf("pop"))
+
+	err := q.deliverEvents(context.Background(), c, func(ctx context.Context, payload []byte) error {
+		return nil
+	})
+	assert.Regexp(t, "pop", err)
+	assert.Equal(t, 1, c.length)
+}
+
+func TestDeliverEventsQueryFail(t *testing.T) {
+	q, mdi := newTestQueue(t)
+
+	c := &connection{namespace: "ns1"}
+	mdi.On("GetInboundEvents", mock.Anything, "erc20", "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := q.deliverEvents(context.Background(), c, func(ctx context.Context, payload []byte) error {
+		return nil
+	})
+	assert.Regexp(t, "pop", err)
+}
@@ -0,0 +1,37 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethsigner
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly/internal/proxy"
+)
+
+const (
+	// EthSignerConfAliases is a map of alias names to the keys they refer to
+	EthSignerConfAliases = "aliases"
+	// EthSignerConfAccountsCacheTTL is how long the list of accounts held by the signer is cached
+	EthSignerConfAccountsCacheTTL = "accountsCacheTTL"
+)
+
+func (e *EthSigner) InitConfig(config config.Section) {
+	ffresty.InitConfig(config)
+	proxy.InitConfig(config)
+	config.AddKnownKey(EthSignerConfAliases)
+	config.AddKnownKey(EthSignerConfAccountsCacheTTL, "1m")
+}
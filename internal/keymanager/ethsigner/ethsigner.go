@@ -0,0 +1,151 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethsigner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/proxy"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/keymanager"
+)
+
+// EthSigner is a key manager backed by a remote signer exposing the Ethereum JSON-RPC eth_accounts method,
+// such as EthSigner or Web3Signer. Only the accounts held by the signer are permitted for signing.
+type EthSigner struct {
+	ctx          context.Context
+	capabilities *keymanager.Capabilities
+	client       *resty.Client
+	aliases      map[string]string
+	cacheTTL     time.Duration
+
+	accountsMux     sync.Mutex
+	accounts        map[string]bool
+	accountsFetched time.Time
+}
+
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type accountsResponse struct {
+	Result []string  `json:"result"`
+	Error  *rpcError `json:"error,omitempty"`
+}
+
+func (e *EthSigner) Name() string {
+	return "ethsigner"
+}
+
+func (e *EthSigner) Init(ctx context.Context, config config.Section) (err error) {
+	e.ctx = log.WithLogField(ctx, "keymanager", "ethsigner")
+	e.capabilities = &keymanager.Capabilities{}
+
+	if config.GetString(ffresty.HTTPConfigURL) == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", "keymanager.ethsigner")
+	}
+	if e.client, err = proxy.NewRESTClient(e.ctx, config); err != nil {
+		return err
+	}
+
+	e.aliases = make(map[string]string)
+	for alias, key := range config.GetObject(EthSignerConfAliases) {
+		keyString, ok := key.(string)
+		if !ok || keyString == "" {
+			return i18n.NewError(ctx, coremsgs.MsgKeyManagerInvalidAlias, alias)
+		}
+		e.aliases[alias] = keyString
+	}
+	e.cacheTTL = config.GetDuration(EthSignerConfAccountsCacheTTL)
+	return nil
+}
+
+func (e *EthSigner) Capabilities() *keymanager.Capabilities {
+	return e.capabilities
+}
+
+func (e *EthSigner) ResolveKey(ctx context.Context, keyRef string, intent blockchain.ResolveKeyIntent) (string, error) {
+	key := keyRef
+	if aliased, ok := e.aliases[keyRef]; ok {
+		key = aliased
+	}
+	if intent != blockchain.ResolveKeyIntentSign {
+		return key, nil
+	}
+
+	accounts, err := e.getAccounts(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !accounts[strings.ToLower(key)] {
+		return "", i18n.NewError(ctx, coremsgs.MsgKeyManagerKeyNotPermitted, keyRef, e.Name())
+	}
+	return key, nil
+}
+
+// getAccounts returns the set of accounts held by the signer, in lower case, refreshing the
+// cached set when it is older than the configured TTL
+func (e *EthSigner) getAccounts(ctx context.Context) (map[string]bool, error) {
+	e.accountsMux.Lock()
+	defer e.accountsMux.Unlock()
+
+	if e.accounts != nil && time.Since(e.accountsFetched) < e.cacheTTL {
+		return e.accounts, nil
+	}
+
+	var rpcRes accountsResponse
+	res, err := e.client.R().
+		SetContext(ctx).
+		SetBody(&rpcRequest{
+			JSONRPC: "2.0",
+			ID:      1,
+			Method:  "eth_accounts",
+			Params:  []interface{}{},
+		}).
+		SetResult(&rpcRes).
+		Post("/")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(ctx, res, err, coremsgs.MsgKeyManagerRESTErr)
+	}
+	if rpcRes.Error != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgKeyManagerRPCErr, rpcRes.Error.Code, rpcRes.Error.Message)
+	}
+
+	e.accounts = make(map[string]bool, len(rpcRes.Result))
+	for _, account := range rpcRes.Result {
+		e.accounts[strings.ToLower(account)] = true
+	}
+	e.accountsFetched = time.Now()
+	return e.accounts, nil
+}
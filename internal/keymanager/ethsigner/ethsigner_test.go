@@ -0,0 +1,170 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ethsigner
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("ethsigner_unit_tests")
+
+func resetConf() {
+	coreconfig.Reset()
+	e := &EthSigner{}
+	e.InitConfig(utConfig)
+}
+
+func newTestEthSigner(t *testing.T) (*EthSigner, func()) {
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+
+	resetConf()
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.Set(ffresty.HTTPCustomClient, mockedClient)
+	utConfig.Set(EthSignerConfAliases, map[string]interface{}{
+		"treasury": "0x1111111111111111111111111111111111111111",
+	})
+
+	e := &EthSigner{}
+	err := e.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	return e, httpmock.DeactivateAndReset
+}
+
+func TestInitMissingURL(t *testing.T) {
+	e := &EthSigner{}
+	resetConf()
+
+	err := e.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138", err)
+}
+
+func TestInitBadTLSConfig(t *testing.T) {
+	e := &EthSigner{}
+	resetConf()
+
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := e.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestInitBadAlias(t *testing.T) {
+	e := &EthSigner{}
+	resetConf()
+
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.Set(EthSignerConfAliases, map[string]interface{}{
+		"treasury": 12345,
+	})
+	err := e.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10573.*treasury", err)
+}
+
+func TestInit(t *testing.T) {
+	e, done := newTestEthSigner(t)
+	defer done()
+
+	assert.Equal(t, "ethsigner", e.Name())
+	assert.NotNil(t, e.Capabilities())
+}
+
+func TestResolveKeyAliasSign(t *testing.T) {
+	e, done := newTestEthSigner(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result":  []string{"0x1111111111111111111111111111111111111111", "0xAbCdEf0000000000000000000000000000000000"},
+		}))
+
+	key, err := e.ResolveKey(context.Background(), "treasury", blockchain.ResolveKeyIntentSign)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", key)
+
+	// Comparison is case insensitive, and the accounts are cached
+	key, err = e.ResolveKey(context.Background(), "0xabcdef0000000000000000000000000000000000", blockchain.ResolveKeyIntentSign)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xabcdef0000000000000000000000000000000000", key)
+
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestResolveKeyNotPermitted(t *testing.T) {
+	e, done := newTestEthSigner(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"result": []string{"0x1111111111111111111111111111111111111111"},
+		}))
+
+	_, err := e.ResolveKey(context.Background(), "0x2222222222222222222222222222222222222222", blockchain.ResolveKeyIntentSign)
+	assert.Regexp(t, "FF10570", err)
+}
+
+func TestResolveKeyQuery(t *testing.T) {
+	e, done := newTestEthSigner(t)
+	defer done()
+
+	key, err := e.ResolveKey(context.Background(), "treasury", blockchain.ResolveKeyIntentQuery)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x1111111111111111111111111111111111111111", key)
+
+	assert.Equal(t, 0, httpmock.GetTotalCallCount())
+}
+
+func TestResolveKeyHTTPError(t *testing.T) {
+	e, done := newTestEthSigner(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewStringResponder(500, "pop"))
+
+	_, err := e.ResolveKey(context.Background(), "treasury", blockchain.ResolveKeyIntentSign)
+	assert.Regexp(t, "FF10571", err)
+}
+
+func TestResolveKeyRPCError(t *testing.T) {
+	e, done := newTestEthSigner(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", "http://localhost:12345/",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    -32601,
+				"message": "Method not found",
+			},
+		}))
+
+	_, err := e.ResolveKey(context.Background(), "treasury", blockchain.ResolveKeyIntentSign)
+	assert.Regexp(t, "FF10572.*Method not found", err)
+}
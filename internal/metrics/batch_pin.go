@@ -21,17 +21,26 @@ import (
 )
 
 var BatchPinCounter prometheus.Counter
+var BatchPinConfirmHistogram prometheus.Histogram
 
 // MetricsBatchPin is the prometheus metric for total number of batch pins submitted
 var MetricsBatchPin = "ff_batchpin_total"
 
+// MetricsBatchPinConfirm is the prometheus metric for the time between submitting a batch pin, and the pin being confirmed by the blockchain
+var MetricsBatchPinConfirm = "ff_batchpin_confirm_seconds"
+
 func InitBatchPinMetrics() {
 	BatchPinCounter = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: MetricsBatchPin,
 		Help: "Number of batch pins submitted",
 	})
+	BatchPinConfirmHistogram = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name: MetricsBatchPinConfirm,
+		Help: "Time taken for a submitted batch pin to be confirmed by the blockchain",
+	})
 }
 
 func RegisterBatchPinMetrics() {
 	registry.MustRegister(BatchPinCounter)
+	registry.MustRegister(BatchPinConfirmHistogram)
 }
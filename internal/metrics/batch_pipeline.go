@@ -0,0 +1,76 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var BatchFlushHistogram *prometheus.HistogramVec
+var BatchMessagesCounter *prometheus.CounterVec
+var BatchFlushErrorsCounter *prometheus.CounterVec
+var BatchWorkerWaitHistogram *prometheus.HistogramVec
+var BatchAssemblyHistogram *prometheus.HistogramVec
+
+// BatchFlushHistogramName is the prometheus metric for tracking the time taken to seal, dispatch and finalize batches
+var BatchFlushHistogramName = "ff_batch_flush_seconds"
+
+// BatchMessagesCounterName is the prometheus metric for tracking the total number of messages dispatched in batches
+var BatchMessagesCounterName = "ff_batch_messages_total"
+
+// BatchFlushErrorsCounterName is the prometheus metric for tracking the total number of failed attempts to flush a batch
+var BatchFlushErrorsCounterName = "ff_batch_flush_errors_total"
+
+// BatchWorkerWaitHistogramName is the prometheus metric for tracking how long batches wait for a worker in the pipeline of their namespace
+var BatchWorkerWaitHistogramName = "ff_batch_worker_wait_seconds"
+
+// BatchAssemblyHistogramName is the prometheus metric for tracking how long the oldest message in a batch waited for the batch to be flushed
+var BatchAssemblyHistogramName = "ff_batch_assembly_seconds"
+
+var NamespaceLabelName = "namespace"
+var DispatcherLabelName = "dispatcher"
+
+func InitBatchPipelineMetrics() {
+	BatchFlushHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: BatchFlushHistogramName,
+		Help: "Time taken to seal, dispatch and finalize a batch",
+	}, []string{NamespaceLabelName, DispatcherLabelName})
+	BatchMessagesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: BatchMessagesCounterName,
+		Help: "Number of messages dispatched in batches",
+	}, []string{NamespaceLabelName, DispatcherLabelName})
+	BatchFlushErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: BatchFlushErrorsCounterName,
+		Help: "Number of failed attempts to flush a batch",
+	}, []string{NamespaceLabelName, DispatcherLabelName})
+	BatchWorkerWaitHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: BatchWorkerWaitHistogramName,
+		Help: "Time a batch waited for a worker in the batch pipeline of its namespace",
+	}, []string{NamespaceLabelName})
+	BatchAssemblyHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: BatchAssemblyHistogramName,
+		Help: "Time between the oldest message in a batch being submitted, and the flush of the batch starting",
+	}, []string{NamespaceLabelName, DispatcherLabelName})
+}
+
+func RegisterBatchPipelineMetrics() {
+	registry.MustRegister(BatchFlushHistogram)
+	registry.MustRegister(BatchMessagesCounter)
+	registry.MustRegister(BatchFlushErrorsCounter)
+	registry.MustRegister(BatchWorkerWaitHistogram)
+	registry.MustRegister(BatchAssemblyHistogram)
+}
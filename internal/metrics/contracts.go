@@ -23,6 +23,10 @@ import (
 var BlockchainTransactionsCounter *prometheus.CounterVec
 var BlockchainQueriesCounter *prometheus.CounterVec
 var BlockchainEventsCounter *prometheus.CounterVec
+var ContractQueryCacheHitsCounter *prometheus.CounterVec
+var ContractQueryCacheMissesCounter *prometheus.CounterVec
+var BlockchainEventDecodeCacheHitsCounter *prometheus.CounterVec
+var BlockchainEventDecodeCacheMissesCounter *prometheus.CounterVec
 
 // BlockchainTransactionsCounterName is the prometheus metric for tracking the total number of blockchain transactions
 var BlockchainTransactionsCounterName = "ff_blockchain_transactions_total"
@@ -33,9 +37,22 @@ var BlockchainQueriesCounterName = "ff_blockchain_queries_total"
 // BlockchainEventsCounterName is the prometheus metric for tracking the total number of blockchain events
 var BlockchainEventsCounterName = "ff_blockchain_events_total"
 
+// ContractQueryCacheHitsCounterName is the prometheus metric for tracking the number of contract API queries served from the cache
+var ContractQueryCacheHitsCounterName = "ff_contract_query_cache_hits_total"
+
+// ContractQueryCacheMissesCounterName is the prometheus metric for tracking the number of contract API queries that missed the cache
+var ContractQueryCacheMissesCounterName = "ff_contract_query_cache_misses_total"
+
+// BlockchainEventDecodeCacheHitsCounterName is the prometheus metric for tracking the number of blockchain events decoded with a cached decoder
+var BlockchainEventDecodeCacheHitsCounterName = "ff_blockchain_event_decode_cache_hits_total"
+
+// BlockchainEventDecodeCacheMissesCounterName is the prometheus metric for tracking the number of blockchain events that required a new decoder
+var BlockchainEventDecodeCacheMissesCounterName = "ff_blockchain_event_decode_cache_misses_total"
+
 var LocationLabelName = "location"
 var MethodNameLabelName = "methodName"
 var SignatureLabelName = "signature"
+var APINameLabelName = "api"
 
 func InitBlockchainMetrics() {
 	BlockchainTransactionsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -50,10 +67,30 @@ func InitBlockchainMetrics() {
 		Name: BlockchainEventsCounterName,
 		Help: "Number of blockchain events",
 	}, []string{LocationLabelName, SignatureLabelName})
+	ContractQueryCacheHitsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: ContractQueryCacheHitsCounterName,
+		Help: "Number of contract API queries served from the cache",
+	}, []string{APINameLabelName, MethodNameLabelName})
+	ContractQueryCacheMissesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: ContractQueryCacheMissesCounterName,
+		Help: "Number of contract API queries that missed the cache",
+	}, []string{APINameLabelName, MethodNameLabelName})
+	BlockchainEventDecodeCacheHitsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: BlockchainEventDecodeCacheHitsCounterName,
+		Help: "Number of blockchain events decoded with a cached decoder",
+	}, []string{SignatureLabelName})
+	BlockchainEventDecodeCacheMissesCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: BlockchainEventDecodeCacheMissesCounterName,
+		Help: "Number of blockchain events that required a new decoder",
+	}, []string{SignatureLabelName})
 }
 
 func RegisterBlockchainMetrics() {
 	registry.MustRegister(BlockchainTransactionsCounter)
 	registry.MustRegister(BlockchainQueriesCounter)
 	registry.MustRegister(BlockchainEventsCounter)
+	registry.MustRegister(ContractQueryCacheHitsCounter)
+	registry.MustRegister(ContractQueryCacheMissesCounter)
+	registry.MustRegister(BlockchainEventDecodeCacheHitsCounter)
+	registry.MustRegister(BlockchainEventDecodeCacheMissesCounter)
 }
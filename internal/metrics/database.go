@@ -0,0 +1,49 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var DatabaseQueryHistogram *prometheus.HistogramVec
+var DatabaseErrorsCounter *prometheus.CounterVec
+
+// DatabaseQueryHistogramName is the prometheus metric for tracking the time taken by database operations
+var DatabaseQueryHistogramName = "ff_database_query_seconds"
+
+// DatabaseErrorsCounterName is the prometheus metric for tracking the total number of failed database operations
+var DatabaseErrorsCounterName = "ff_database_errors_total"
+
+var TableLabelName = "table"
+var OperationLabelName = "operation"
+
+func InitDatabaseMetrics() {
+	DatabaseQueryHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: DatabaseQueryHistogramName,
+		Help: "Time taken by database operations",
+	}, []string{TableLabelName, OperationLabelName})
+	DatabaseErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: DatabaseErrorsCounterName,
+		Help: "Number of failed database operations",
+	}, []string{TableLabelName, OperationLabelName})
+}
+
+func RegisterDatabaseMetrics() {
+	registry.MustRegister(DatabaseQueryHistogram)
+	registry.MustRegister(DatabaseErrorsCounter)
+}
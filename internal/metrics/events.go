@@ -0,0 +1,39 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var EventDispatchLagHistogram *prometheus.HistogramVec
+
+// EventDispatchLagHistogramName is the prometheus metric for tracking the time between an event being created, and it being dispatched to a subscription
+var EventDispatchLagHistogramName = "ff_event_dispatch_lag_seconds"
+
+var TransportLabelName = "transport"
+
+func InitEventMetrics() {
+	EventDispatchLagHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: EventDispatchLagHistogramName,
+		Help: "Time between an event being created, and it being dispatched to a subscription",
+	}, []string{NamespaceLabelName, TransportLabelName})
+}
+
+func RegisterEventMetrics() {
+	registry.MustRegister(EventDispatchLagHistogram)
+}
@@ -30,7 +30,8 @@ import (
 var mutex = &sync.Mutex{}
 
 type Manager interface {
-	CountBatchPin()
+	CountBatchPin(batchID *fftypes.UUID)
+	BatchPinConfirmed(batchID *fftypes.UUID)
 	MessageSubmitted(msg *core.Message)
 	MessageConfirmed(msg *core.Message, eventType fftypes.FFEnum)
 	TransferSubmitted(transfer *core.TokenTransfer)
@@ -39,6 +40,18 @@ type Manager interface {
 	BlockchainTransaction(location, methodName string)
 	BlockchainQuery(location, methodName string)
 	BlockchainEvent(location, signature string)
+	ContractQueryCacheHit(apiName, methodPath string)
+	ContractQueryCacheMiss(apiName, methodPath string)
+	BlockchainEventDecodeCacheHit(signature string)
+	BlockchainEventDecodeCacheMiss(signature string)
+	BatchFlushed(namespace, dispatcher string, messages int, duration time.Duration)
+	BatchFlushFailed(namespace, dispatcher string)
+	BatchWorkerWait(namespace string, wait time.Duration)
+	BatchAssembled(namespace, dispatcher string, wait time.Duration)
+	EventDispatched(namespace, transport string, lag time.Duration)
+	DatabaseOperation(table, operation string, duration time.Duration, err error)
+	PluginOperationFailed(namespace, plugin string, opType core.OpType)
+	PluginEventValidationFailed(plugin, eventType, failure string)
 	AddTime(id string)
 	GetTime(id string) time.Time
 	DeleteTime(id string)
@@ -61,14 +74,24 @@ func NewMetricsManager(ctx context.Context) Manager {
 	return mm
 }
 
-func (mm *metricsManager) CountBatchPin() {
+func (mm *metricsManager) CountBatchPin(batchID *fftypes.UUID) {
 	BatchPinCounter.Inc()
+	mm.AddTime(batchID.String())
+}
+
+func (mm *metricsManager) BatchPinConfirmed(batchID *fftypes.UUID) {
+	submitTime := mm.GetTime(batchID.String())
+	if !submitTime.IsZero() {
+		// Only the node that submitted the pin has a submission time recorded
+		mm.DeleteTime(batchID.String())
+		BatchPinConfirmHistogram.Observe(time.Since(submitTime).Seconds())
+	}
 }
 
 func (mm *metricsManager) MessageSubmitted(msg *core.Message) {
 	if len(msg.Header.ID.String()) > 0 {
 		switch msg.Header.Type {
-		case core.MessageTypeBroadcast:
+		case core.MessageTypeBroadcast, core.MessageTypeBroadcastPinOnly:
 			BroadcastSubmittedCounter.Inc()
 		case core.MessageTypePrivate:
 			PrivateMsgSubmittedCounter.Inc()
@@ -83,7 +106,7 @@ func (mm *metricsManager) MessageConfirmed(msg *core.Message, eventType fftypes.
 	mm.DeleteTime(msg.Header.ID.String())
 
 	switch msg.Header.Type {
-	case core.MessageTypeBroadcast:
+	case core.MessageTypeBroadcast, core.MessageTypeBroadcastPinOnly:
 		if !eventTime.IsZero() {
 			// Check that we recorded the submission
 			// as we might not be the party submitting
@@ -162,6 +185,58 @@ func (mm *metricsManager) BlockchainEvent(location, signature string) {
 	BlockchainEventsCounter.WithLabelValues(location, signature).Inc()
 }
 
+func (mm *metricsManager) ContractQueryCacheHit(apiName, methodPath string) {
+	ContractQueryCacheHitsCounter.WithLabelValues(apiName, methodPath).Inc()
+}
+
+func (mm *metricsManager) ContractQueryCacheMiss(apiName, methodPath string) {
+	ContractQueryCacheMissesCounter.WithLabelValues(apiName, methodPath).Inc()
+}
+
+func (mm *metricsManager) BlockchainEventDecodeCacheHit(signature string) {
+	BlockchainEventDecodeCacheHitsCounter.WithLabelValues(signature).Inc()
+}
+
+func (mm *metricsManager) BlockchainEventDecodeCacheMiss(signature string) {
+	BlockchainEventDecodeCacheMissesCounter.WithLabelValues(signature).Inc()
+}
+
+func (mm *metricsManager) BatchFlushed(namespace, dispatcher string, messages int, duration time.Duration) {
+	BatchFlushHistogram.WithLabelValues(namespace, dispatcher).Observe(duration.Seconds())
+	BatchMessagesCounter.WithLabelValues(namespace, dispatcher).Add(float64(messages))
+}
+
+func (mm *metricsManager) BatchFlushFailed(namespace, dispatcher string) {
+	BatchFlushErrorsCounter.WithLabelValues(namespace, dispatcher).Inc()
+}
+
+func (mm *metricsManager) BatchWorkerWait(namespace string, wait time.Duration) {
+	BatchWorkerWaitHistogram.WithLabelValues(namespace).Observe(wait.Seconds())
+}
+
+func (mm *metricsManager) BatchAssembled(namespace, dispatcher string, wait time.Duration) {
+	BatchAssemblyHistogram.WithLabelValues(namespace, dispatcher).Observe(wait.Seconds())
+}
+
+func (mm *metricsManager) EventDispatched(namespace, transport string, lag time.Duration) {
+	EventDispatchLagHistogram.WithLabelValues(namespace, transport).Observe(lag.Seconds())
+}
+
+func (mm *metricsManager) DatabaseOperation(table, operation string, duration time.Duration, err error) {
+	DatabaseQueryHistogram.WithLabelValues(table, operation).Observe(duration.Seconds())
+	if err != nil {
+		DatabaseErrorsCounter.WithLabelValues(table, operation).Inc()
+	}
+}
+
+func (mm *metricsManager) PluginOperationFailed(namespace, plugin string, opType core.OpType) {
+	PluginErrorsCounter.WithLabelValues(namespace, plugin, opType.String()).Inc()
+}
+
+func (mm *metricsManager) PluginEventValidationFailed(plugin, eventType, failure string) {
+	PluginEventValidationFailuresCounter.WithLabelValues(plugin, eventType, failure).Inc()
+}
+
 func (mm *metricsManager) AddTime(id string) {
 	mutex.Lock()
 	mm.timeMap[id] = time.Now()
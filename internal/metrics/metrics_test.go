@@ -18,6 +18,7 @@ package metrics
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -62,7 +63,27 @@ func newTestMetricsManager(t *testing.T) (*metricsManager, func()) {
 func TestCountBatchPin(t *testing.T) {
 	mm, cancel := newTestMetricsManager(t)
 	defer cancel()
-	mm.CountBatchPin()
+	batchID := fftypes.NewUUID()
+	mm.CountBatchPin(batchID)
+	assert.Equal(t, float64(1), testutil.ToFloat64(BatchPinCounter))
+	assert.False(t, mm.GetTime(batchID.String()).IsZero())
+}
+
+func TestBatchPinConfirmed(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	batchID := fftypes.NewUUID()
+	mm.CountBatchPin(batchID)
+	mm.BatchPinConfirmed(batchID)
+	assert.Empty(t, mm.timeMap)
+	assert.Equal(t, 1, testutil.CollectAndCount(BatchPinConfirmHistogram))
+}
+
+func TestBatchPinConfirmedNotSubmitted(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.BatchPinConfirmed(fftypes.NewUUID())
+	assert.Empty(t, mm.timeMap)
 }
 
 func TestMessageSubmittedBroadcast(t *testing.T) {
@@ -204,6 +225,86 @@ func TestBlockchainQuery(t *testing.T) {
 	assert.Equal(t, float64(1), v)
 }
 
+func TestContractQueryCache(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.ContractQueryCacheHit("api1", "get")
+	mm.ContractQueryCacheMiss("api1", "get")
+	m, err := ContractQueryCacheHitsCounter.GetMetricWith(prometheus.Labels{APINameLabelName: "api1", MethodNameLabelName: "get"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+	m, err = ContractQueryCacheMissesCounter.GetMetricWith(prometheus.Labels{APINameLabelName: "api1", MethodNameLabelName: "get"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+}
+
+func TestBlockchainEventDecodeCache(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.BlockchainEventDecodeCacheHit("Transfer(address,address,uint256)")
+	mm.BlockchainEventDecodeCacheMiss("Transfer(address,address,uint256)")
+	m, err := BlockchainEventDecodeCacheHitsCounter.GetMetricWith(prometheus.Labels{SignatureLabelName: "Transfer(address,address,uint256)"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+	m, err = BlockchainEventDecodeCacheMissesCounter.GetMetricWith(prometheus.Labels{SignatureLabelName: "Transfer(address,address,uint256)"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+}
+
+func TestBatchPipeline(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.BatchFlushed("ns1", "broadcast", 5, time.Second)
+	mm.BatchFlushFailed("ns1", "broadcast")
+	mm.BatchWorkerWait("ns1", time.Millisecond)
+	mm.BatchAssembled("ns1", "broadcast", time.Millisecond)
+	m, err := BatchMessagesCounter.GetMetricWith(prometheus.Labels{NamespaceLabelName: "ns1", DispatcherLabelName: "broadcast"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(5), testutil.ToFloat64(m))
+	m, err = BatchFlushErrorsCounter.GetMetricWith(prometheus.Labels{NamespaceLabelName: "ns1", DispatcherLabelName: "broadcast"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+	assert.Equal(t, 1, testutil.CollectAndCount(BatchFlushHistogram))
+	assert.Equal(t, 1, testutil.CollectAndCount(BatchWorkerWaitHistogram))
+	assert.Equal(t, 1, testutil.CollectAndCount(BatchAssemblyHistogram))
+}
+
+func TestEventDispatched(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.EventDispatched("ns1", "websockets", time.Millisecond)
+	assert.Equal(t, 1, testutil.CollectAndCount(EventDispatchLagHistogram))
+}
+
+func TestDatabaseOperation(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.DatabaseOperation("messages", "insert", time.Millisecond, nil)
+	mm.DatabaseOperation("messages", "insert", time.Millisecond, fmt.Errorf("pop"))
+	m, err := DatabaseErrorsCounter.GetMetricWith(prometheus.Labels{TableLabelName: "messages", OperationLabelName: "insert"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+	assert.Equal(t, 1, testutil.CollectAndCount(DatabaseQueryHistogram))
+}
+
+func TestPluginOperationFailed(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.PluginOperationFailed("ns1", "ethereum", core.OpTypeBlockchainPinBatch)
+	m, err := PluginErrorsCounter.GetMetricWith(prometheus.Labels{NamespaceLabelName: "ns1", PluginLabelName: "ethereum", OperationTypeLabelName: "blockchain_pin_batch"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+}
+
+func TestPluginEventValidationFailed(t *testing.T) {
+	mm, cancel := newTestMetricsManager(t)
+	defer cancel()
+	mm.PluginEventValidationFailed("erc1155", "token-transfer", "required")
+	m, err := PluginEventValidationFailuresCounter.GetMetricWith(prometheus.Labels{PluginLabelName: "erc1155", EventTypeLabelName: "token-transfer", FailureLabelName: "required"})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), testutil.ToFloat64(m))
+}
+
 func TestBlockchainEvents(t *testing.T) {
 	mm, cancel := newTestMetricsManager(t)
 	defer cancel()
@@ -0,0 +1,51 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var PluginErrorsCounter *prometheus.CounterVec
+var PluginEventValidationFailuresCounter *prometheus.CounterVec
+
+// PluginErrorsCounterName is the prometheus metric for tracking the total number of failed operations submitted to plugins
+var PluginErrorsCounterName = "ff_plugin_errors_total"
+
+// PluginEventValidationFailuresCounterName is the prometheus metric for tracking the total number of events from plugins that failed validation
+var PluginEventValidationFailuresCounterName = "ff_plugin_event_validation_failures_total"
+
+var PluginLabelName = "plugin"
+var OperationTypeLabelName = "type"
+var EventTypeLabelName = "event"
+var FailureLabelName = "failure"
+
+func InitPluginMetrics() {
+	PluginErrorsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: PluginErrorsCounterName,
+		Help: "Number of operations that failed when submitted to a plugin",
+	}, []string{NamespaceLabelName, PluginLabelName, OperationTypeLabelName})
+	PluginEventValidationFailuresCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: PluginEventValidationFailuresCounterName,
+		Help: "Number of events received from a plugin that were rejected because they failed validation",
+	}, []string{PluginLabelName, EventTypeLabelName, FailureLabelName})
+}
+
+func RegisterPluginMetrics() {
+	registry.MustRegister(PluginErrorsCounter)
+	registry.MustRegister(PluginEventValidationFailuresCounter)
+}
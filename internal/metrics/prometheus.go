@@ -88,6 +88,10 @@ func initMetricsCollectors() {
 	InitTokenBurnMetrics()
 	InitBatchPinMetrics()
 	InitBlockchainMetrics()
+	InitBatchPipelineMetrics()
+	InitEventMetrics()
+	InitDatabaseMetrics()
+	InitPluginMetrics()
 }
 
 func registerMetricsCollectors() {
@@ -101,4 +105,8 @@ func registerMetricsCollectors() {
 	RegisterTokenTransferMetrics()
 	RegisterTokenBurnMetrics()
 	RegisterBlockchainMetrics()
+	RegisterBatchPipelineMetrics()
+	RegisterEventMetrics()
+	RegisterDatabaseMetrics()
+	RegisterPluginMetrics()
 }
@@ -18,7 +18,11 @@ package multiparty
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -30,6 +34,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/sequencer"
 )
 
 type Manager interface {
@@ -41,6 +46,12 @@ type Manager interface {
 	// LocalNode returns configuration details for the local node identity
 	LocalNode() LocalNode
 
+	// OrgTrustRoots returns the CAs that organization certificates must chain to, or nil if organizations are not verified
+	OrgTrustRoots() *x509.CertPool
+
+	// RequireSignedClaims returns true if identity claims must be signed by the key that submits them
+	RequireSignedClaims() bool
+
 	// ConfigureContract initializes the subscription to the FireFly contract
 	// - Determines the active multiparty contract entry from the config, and updates the namespace with contract info
 	// - Resolves the multiparty contract address and version, and initializes subscriptions for contract events
@@ -52,9 +63,22 @@ type Manager interface {
 	// - Updates the namespace contract info to record the point of termination and the newly active contract
 	TerminateContract(ctx context.Context, location *fftypes.JSONAny, termination *blockchain.Event) (err error)
 
+	// ScheduleContractMigration prepares to switch to the next configured FireFly contract at an agreed block number
+	// - Validates that the migration is to the contract after the active one, and that no other migration is pending
+	// - Subscribes to the new contract, so that events from both contracts are received during the transition
+	// - Updates the namespace contract info to record the pending contract
+	ScheduleContractMigration(ctx context.Context, migration *core.ContractMigration) (retryable bool, err error)
+
+	// CheckContractMigration switches to the pending FireFly contract, if the given event is at or beyond its activation block
+	CheckContractMigration(ctx context.Context, event *blockchain.Event) error
+
 	// GetNetworkVersion returns the network version of the active FireFly contract
 	GetNetworkVersion() int
 
+	// RequeryPins asks the blockchain plugin to re-deliver the batch pin events of the active FireFly contract,
+	// from the given protocol ID, to recover events that have been missed
+	RequeryPins(ctx context.Context, fromProtocolID string) error
+
 	// SubmitBatchPin sequences a batch of message globally to all viewers of a given ledger
 	SubmitBatchPin(ctx context.Context, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error
 
@@ -67,16 +91,36 @@ type Manager interface {
 }
 
 type Config struct {
-	Enabled   bool
-	Org       RootOrg
-	Node      LocalNode
-	Contracts []blockchain.MultipartyContract
+	Enabled             bool
+	Org                 RootOrg
+	Node                LocalNode
+	Contracts           []blockchain.MultipartyContract
+	OrgTrustRoots       *x509.CertPool
+	RequireSignedClaims bool
+	Anchor              Anchor
 }
 
+// Anchor configures a second blockchain, to which every batch is also pinned
+type Anchor struct {
+	Key            string
+	Location       *fftypes.JSONAny
+	Mode           string
+	DigestInterval time.Duration
+}
+
+const (
+	// AnchorModeBatch pins every batch to the anchor chain
+	AnchorModeBatch = "batch"
+	// AnchorModeDigest periodically pins a Merkle root of the batches pinned in a window of time to the anchor chain
+	AnchorModeDigest = "digest"
+)
+
 type RootOrg struct {
-	Name        string
-	Description string
-	Key         string
+	Name                 string
+	Description          string
+	Key                  string
+	Certificate          string
+	CertificateSignature string
 }
 
 type LocalNode struct {
@@ -88,14 +132,16 @@ type multipartyManager struct {
 	namespace  *core.Namespace
 	database   database.Plugin
 	blockchain blockchain.Plugin
+	anchor     blockchain.Plugin // optional
+	sequencer  sequencer.Plugin
 	operations operations.Manager
 	metrics    metrics.Manager
 	txHelper   txcommon.Helper
 	config     Config
 }
 
-func NewMultipartyManager(ctx context.Context, ns *core.Namespace, config Config, di database.Plugin, bi blockchain.Plugin, om operations.Manager, mm metrics.Manager, th txcommon.Helper) (Manager, error) {
-	if di == nil || bi == nil || mm == nil || om == nil || th == nil {
+func NewMultipartyManager(ctx context.Context, ns *core.Namespace, config Config, di database.Plugin, bi, abi blockchain.Plugin, sp sequencer.Plugin, om operations.Manager, mm metrics.Manager, th txcommon.Helper) (Manager, error) {
+	if di == nil || bi == nil || sp == nil || mm == nil || om == nil || th == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "MultipartyManager")
 	}
 	mp := &multipartyManager{
@@ -103,12 +149,15 @@ func NewMultipartyManager(ctx context.Context, ns *core.Namespace, config Config
 		config:     config,
 		database:   di,
 		blockchain: bi,
+		anchor:     abi,
+		sequencer:  sp,
 		operations: om,
 		metrics:    mm,
 		txHelper:   th,
 	}
 	om.RegisterHandler(ctx, mp, []core.OpType{
 		core.OpTypeBlockchainPinBatch,
+		core.OpTypeBlockchainAnchorBatch,
 		core.OpTypeBlockchainNetworkAction,
 	})
 	return mp, nil
@@ -126,6 +175,14 @@ func (mm *multipartyManager) LocalNode() LocalNode {
 	return mm.config.Node
 }
 
+func (mm *multipartyManager) OrgTrustRoots() *x509.CertPool {
+	return mm.config.OrgTrustRoots
+}
+
+func (mm *multipartyManager) RequireSignedClaims() bool {
+	return mm.config.RequireSignedClaims
+}
+
 func (mm *multipartyManager) ConfigureContract(ctx context.Context) (err error) {
 	return mm.configureContractCommon(ctx, false)
 }
@@ -139,7 +196,23 @@ func (mm *multipartyManager) configureContractCommon(ctx context.Context, migrat
 	}
 	active := mm.namespace.Contracts.Active
 	log.L(ctx).Infof("Resolving FireFly contract at index %d", active.Index)
-	current, err := mm.resolveFireFlyContract(ctx, active.Index)
+	if err := mm.subscribeContract(ctx, active, !migration); err != nil {
+		return err
+	}
+
+	// A contract migration that is scheduled, but has not yet reached its activation block,
+	// requires us to continue listening to both contracts
+	if pending := mm.namespace.Contracts.Pending; pending != nil && !migration {
+		log.L(ctx).Infof("Resolving pending FireFly contract at index %d", pending.Index)
+		if err := mm.subscribeContract(ctx, pending, true); err != nil {
+			return err
+		}
+	}
+	return mm.database.UpsertNamespace(ctx, mm.namespace, true)
+}
+
+func (mm *multipartyManager) subscribeContract(ctx context.Context, contract *core.MultipartyContract, checkLocation bool) error {
+	current, err := mm.resolveFireFlyContract(ctx, contract.Index)
 	if err != nil {
 		return err
 	}
@@ -149,9 +222,9 @@ func (mm *multipartyManager) configureContractCommon(ctx context.Context, migrat
 		return err
 	}
 
-	if !migration {
-		if !active.Location.IsNil() && active.Location.String() != current.Location.String() {
-			log.L(ctx).Warnf("FireFly contract location changed from %s to %s", active.Location, current.Location)
+	if checkLocation {
+		if !contract.Location.IsNil() && contract.Location.String() != current.Location.String() {
+			log.L(ctx).Warnf("FireFly contract location changed from %s to %s", contract.Location, current.Location)
 		}
 	}
 
@@ -172,14 +245,14 @@ func (mm *multipartyManager) configureContractCommon(ctx context.Context, migrat
 	}
 
 	subID, err := mm.blockchain.AddFireflySubscription(ctx, mm.namespace, current, lastProtocolID)
-	if err == nil {
-		active.Location = current.Location
-		active.FirstEvent = current.FirstEvent
-		active.Info.Subscription = subID
-		active.Info.Version = version
-		err = mm.database.UpsertNamespace(ctx, mm.namespace, true)
+	if err != nil {
+		return err
 	}
-	return err
+	contract.Location = current.Location
+	contract.FirstEvent = current.FirstEvent
+	contract.Info.Subscription = subID
+	contract.Info.Version = version
+	return nil
 }
 
 func (mm *multipartyManager) resolveFireFlyContract(ctx context.Context, contractIndex int) (contract *blockchain.MultipartyContract, err error) {
@@ -209,6 +282,10 @@ func (mm *multipartyManager) TerminateContract(ctx context.Context, location *ff
 		return nil
 	}
 	log.L(ctx).Infof("Processing termination of contract #%d at '%s'", contracts.Active.Index, contracts.Active.Location)
+	if contracts.Pending != nil {
+		// We are already listening to the next contract, so switch to it immediately
+		return mm.activatePendingContract(ctx, termination.ProtocolID)
+	}
 	mm.blockchain.RemoveFireflySubscription(ctx, contracts.Active.Info.Subscription)
 	contracts.Active.Info.FinalEvent = termination.ProtocolID
 	contracts.Terminated = append(contracts.Terminated, contracts.Active)
@@ -216,10 +293,78 @@ func (mm *multipartyManager) TerminateContract(ctx context.Context, location *ff
 	return mm.configureContractCommon(ctx, true)
 }
 
+func (mm *multipartyManager) ScheduleContractMigration(ctx context.Context, migration *core.ContractMigration) (retryable bool, err error) {
+	contracts := mm.namespace.Contracts
+	if contracts.Pending != nil {
+		if contracts.Pending.Index == migration.Index && contracts.Pending.Info.ActivationBlock == migration.BlockNumber {
+			log.L(ctx).Infof("Migration to contract #%d at block %d is already scheduled", migration.Index, migration.BlockNumber)
+			return false, nil
+		}
+		return false, i18n.NewError(ctx, coremsgs.MsgContractMigrationPending, contracts.Pending.Index, contracts.Pending.Info.ActivationBlock)
+	}
+	if migration.Index != contracts.Active.Index+1 {
+		return false, i18n.NewError(ctx, coremsgs.MsgContractMigrationInvalidIndex, migration.Index, contracts.Active.Index+1)
+	}
+	if migration.BlockNumber <= 0 {
+		return false, i18n.NewError(ctx, coremsgs.MsgContractMigrationInvalidBlock)
+	}
+	if _, err := mm.resolveFireFlyContract(ctx, migration.Index); err != nil {
+		return false, err
+	}
+
+	log.L(ctx).Infof("Scheduling migration from contract #%d to contract #%d at block %d", contracts.Active.Index, migration.Index, migration.BlockNumber)
+	pending := &core.MultipartyContract{
+		Index: migration.Index,
+		Info: core.MultipartyContractInfo{
+			ActivationBlock: migration.BlockNumber,
+		},
+	}
+	if err := mm.subscribeContract(ctx, pending, false); err != nil {
+		return true, err
+	}
+	contracts.Pending = pending
+	return true, mm.database.UpsertNamespace(ctx, mm.namespace, true)
+}
+
+func (mm *multipartyManager) CheckContractMigration(ctx context.Context, event *blockchain.Event) error {
+	contracts := mm.namespace.Contracts
+	if contracts == nil || contracts.Pending == nil || event == nil {
+		return nil
+	}
+	blockNumber, err := strconv.ParseInt(strings.Split(event.ProtocolID, "/")[0], 10, 64)
+	if err != nil {
+		log.L(ctx).Debugf("Unable to determine block number of event '%s' for contract migration", event.ProtocolID)
+		return nil
+	}
+	if blockNumber < contracts.Pending.Info.ActivationBlock {
+		return nil
+	}
+	return mm.activatePendingContract(ctx, event.ProtocolID)
+}
+
+func (mm *multipartyManager) activatePendingContract(ctx context.Context, finalEvent string) error {
+	contracts := mm.namespace.Contracts
+	log.L(ctx).Infof("Switching from contract #%d at '%s' to contract #%d at '%s'", contracts.Active.Index, contracts.Active.Location, contracts.Pending.Index, contracts.Pending.Location)
+	mm.blockchain.RemoveFireflySubscription(ctx, contracts.Active.Info.Subscription)
+	contracts.Active.Info.FinalEvent = finalEvent
+	contracts.Terminated = append(contracts.Terminated, contracts.Active)
+	contracts.Active = contracts.Pending
+	contracts.Pending = nil
+	return mm.database.UpsertNamespace(ctx, mm.namespace, true)
+}
+
 func (mm *multipartyManager) GetNetworkVersion() int {
 	return mm.namespace.Contracts.Active.Info.Version
 }
 
+func (mm *multipartyManager) RequeryPins(ctx context.Context, fromProtocolID string) error {
+	subID := mm.namespace.Contracts.Active.Info.Subscription
+	if subID == "" {
+		return i18n.NewError(ctx, coremsgs.MsgNoFireflySubscription, mm.namespace.Name)
+	}
+	return mm.blockchain.RequeryFireflySubscription(ctx, subID, fromProtocolID)
+}
+
 func (mm *multipartyManager) SubmitNetworkAction(ctx context.Context, signingKey string, action *core.NetworkAction, idempotentSubmit bool) error {
 	if action.Type != core.NetworkActionTerminate {
 		return i18n.NewError(ctx, coremsgs.MsgUnrecognizedNetworkAction, action.Type)
@@ -273,7 +418,7 @@ func (mm *multipartyManager) SubmitBatchPin(ctx context.Context, batch *core.Bat
 	}
 
 	op := core.NewOperation(
-		mm.blockchain,
+		mm.sequencer,
 		mm.namespace.Name,
 		batch.TX.ID,
 		core.OpTypeBlockchainPinBatch)
@@ -283,8 +428,29 @@ func (mm *multipartyManager) SubmitBatchPin(ctx context.Context, batch *core.Bat
 	}
 
 	if mm.metrics.IsMetricsEnabled() {
-		mm.metrics.CountBatchPin()
+		mm.metrics.CountBatchPin(batch.ID)
+	}
+	if _, err := mm.operations.RunOperation(ctx, opBatchPin(op, batch, contexts, payloadRef), idempotentSubmit); err != nil {
+		return err
+	}
+	return mm.submitBatchAnchor(ctx, batch, contexts, payloadRef, idempotentSubmit)
+}
+
+// submitBatchAnchor pins the batch a second time to the anchor chain, if one is configured in batch mode. The anchor does not
+// take part in sequencing, so is not waited for - it is recorded on the batch when the transaction is accepted.
+func (mm *multipartyManager) submitBatchAnchor(ctx context.Context, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
+	if mm.anchor == nil || mm.config.Anchor.Mode == AnchorModeDigest {
+		return nil
+	}
+	op := core.NewOperation(
+		mm.anchor,
+		mm.namespace.Name,
+		batch.TX.ID,
+		core.OpTypeBlockchainAnchorBatch)
+	addBatchPinInputs(op, batch.ID, contexts, payloadRef)
+	if err := mm.operations.AddOrReuseOperation(ctx, op); err != nil {
+		return err
 	}
-	_, err := mm.operations.RunOperation(ctx, opBatchPin(op, batch, contexts, payloadRef), idempotentSubmit)
+	_, err := mm.operations.RunOperation(ctx, opBatchAnchor(op, batch, contexts, payloadRef), idempotentSubmit)
 	return err
 }
@@ -18,12 +18,14 @@ package multiparty
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/sequencer/batchpin"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
@@ -72,6 +74,7 @@ func newTestMultipartyManager() *testMultipartyManager {
 
 	nm.multipartyManager.database = nm.mdi
 	nm.multipartyManager.blockchain = nm.mbi
+	nm.multipartyManager.sequencer = batchpin.NewSequencer(nm.mbi)
 	nm.multipartyManager.operations = nm.mom
 	nm.multipartyManager.metrics = nm.mmi
 	nm.multipartyManager.txHelper = nm.mth
@@ -85,26 +88,31 @@ func TestNewMultipartyManager(t *testing.T) {
 	mmi := &metricsmocks.Manager{}
 	mth := &txcommonmocks.Helper{}
 	config := Config{
-		Org:       RootOrg{Name: "org1"},
-		Node:      LocalNode{Name: "node1"},
-		Contracts: []blockchain.MultipartyContract{},
+		Org:                 RootOrg{Name: "org1"},
+		Node:                LocalNode{Name: "node1"},
+		Contracts:           []blockchain.MultipartyContract{},
+		OrgTrustRoots:       x509.NewCertPool(),
+		RequireSignedClaims: true,
 	}
 	mom.On("RegisterHandler", mock.Anything, mock.Anything, []core.OpType{
 		core.OpTypeBlockchainPinBatch,
+		core.OpTypeBlockchainAnchorBatch,
 		core.OpTypeBlockchainNetworkAction,
 	}).Return()
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	nm, err := NewMultipartyManager(context.Background(), ns, config, mdi, mbi, mom, mmi, mth)
+	nm, err := NewMultipartyManager(context.Background(), ns, config, mdi, mbi, nil, batchpin.NewSequencer(mbi), mom, mmi, mth)
 	assert.NotNil(t, nm)
 	assert.NoError(t, err)
 	assert.Equal(t, "MultipartyManager", nm.Name())
 	assert.Equal(t, config.Org, nm.RootOrg())
 	assert.Equal(t, config.Node, nm.LocalNode())
+	assert.Equal(t, config.OrgTrustRoots, nm.OrgTrustRoots())
+	assert.True(t, nm.RequireSignedClaims())
 }
 
 func TestInitFail(t *testing.T) {
 	config := Config{Contracts: []blockchain.MultipartyContract{}}
-	_, err := NewMultipartyManager(context.Background(), &core.Namespace{}, config, nil, nil, nil, nil, nil)
+	_, err := NewMultipartyManager(context.Background(), &core.Namespace{}, config, nil, nil, nil, nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -457,6 +465,134 @@ func TestSubmitBatchPinOk(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSubmitBatchPinWithAnchorOk(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	ctx := context.Background()
+	manchor := &blockchainmocks.Plugin{}
+	mp.anchor = manchor
+
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		TX: core.TransactionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	contexts := []*fftypes.Bytes32{}
+
+	mp.mbi.On("Name").Return("ut")
+	manchor.On("Name").Return("anchor")
+	mp.mom.On("AddOrReuseOperation", ctx, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainPinBatch && op.Plugin == "ut"
+	})).Return(nil)
+	mp.mom.On("AddOrReuseOperation", ctx, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainAnchorBatch && op.Plugin == "anchor" &&
+			*op.Transaction == *batch.TX.ID && op.Input.GetString("batch") == batch.ID.String()
+	})).Return(nil)
+	mp.mmi.On("IsMetricsEnabled").Return(false)
+	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		_, ok := op.Data.(txcommon.BatchPinData)
+		return ok && op.Type == core.OpTypeBlockchainPinBatch
+	}), false).Return(nil, nil)
+	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		data, ok := op.Data.(batchAnchorData)
+		return ok && op.Type == core.OpTypeBlockchainAnchorBatch && data.Batch == batch
+	}), false).Return(nil, nil)
+
+	err := mp.SubmitBatchPin(ctx, batch, contexts, "payload1", false)
+	assert.NoError(t, err)
+
+	manchor.AssertExpectations(t)
+}
+
+func TestSubmitBatchPinWithAnchorDigestMode(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	ctx := context.Background()
+	mp.anchor = &blockchainmocks.Plugin{}
+	mp.config.Anchor.Mode = AnchorModeDigest
+
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		TX: core.TransactionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mp.mbi.On("Name").Return("ut")
+	mp.mom.On("AddOrReuseOperation", ctx, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainPinBatch
+	})).Return(nil)
+	mp.mmi.On("IsMetricsEnabled").Return(false)
+	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
+		return op.Type == core.OpTypeBlockchainPinBatch
+	}), false).Return(nil, nil)
+
+	err := mp.SubmitBatchPin(ctx, batch, []*fftypes.Bytes32{}, "payload1", false)
+	assert.NoError(t, err)
+}
+
+func TestSubmitBatchPinWithAnchorAddOpFail(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	ctx := context.Background()
+	manchor := &blockchainmocks.Plugin{}
+	mp.anchor = manchor
+
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		TX: core.TransactionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mp.mbi.On("Name").Return("ut")
+	manchor.On("Name").Return("anchor")
+	mp.mom.On("AddOrReuseOperation", ctx, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainPinBatch
+	})).Return(nil)
+	mp.mom.On("AddOrReuseOperation", ctx, mock.MatchedBy(func(op *core.Operation) bool {
+		return op.Type == core.OpTypeBlockchainAnchorBatch
+	})).Return(fmt.Errorf("pop"))
+	mp.mmi.On("IsMetricsEnabled").Return(false)
+	mp.mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, nil)
+
+	err := mp.SubmitBatchPin(ctx, batch, []*fftypes.Bytes32{}, "payload1", false)
+	assert.EqualError(t, err, "pop")
+
+	manchor.AssertExpectations(t)
+}
+
+func TestSubmitBatchPinRunFailSkipsAnchor(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	ctx := context.Background()
+	mp.anchor = &blockchainmocks.Plugin{}
+
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+		},
+		TX: core.TransactionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+
+	mp.mbi.On("Name").Return("ut")
+	mp.mom.On("AddOrReuseOperation", ctx, mock.Anything).Return(nil)
+	mp.mmi.On("IsMetricsEnabled").Return(false)
+	mp.mom.On("RunOperation", mock.Anything, mock.Anything, false).Return(nil, fmt.Errorf("pop"))
+
+	err := mp.SubmitBatchPin(ctx, batch, []*fftypes.Bytes32{}, "payload1", false)
+	assert.EqualError(t, err, "pop")
+}
+
 func TestSubmitPinnedBatchWithMetricsOk(t *testing.T) {
 	mp := newTestMultipartyManager()
 	defer mp.cleanup(t)
@@ -485,7 +621,7 @@ func TestSubmitPinnedBatchWithMetricsOk(t *testing.T) {
 		return true
 	})).Return(nil)
 	mp.mmi.On("IsMetricsEnabled").Return(true)
-	mp.mmi.On("CountBatchPin").Return()
+	mp.mmi.On("CountBatchPin", mock.Anything).Return()
 	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
 		data := op.Data.(txcommon.BatchPinData)
 		return op.Type == core.OpTypeBlockchainPinBatch && data.Batch == batch
@@ -618,7 +754,7 @@ func TestSubmitBatchPinWithBatchOpNotFound(t *testing.T) {
 		return true
 	})).Return(nil)
 	mp.mmi.On("IsMetricsEnabled").Return(true)
-	mp.mmi.On("CountBatchPin").Return()
+	mp.mmi.On("CountBatchPin", mock.Anything).Return()
 	mp.mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
 		data := op.Data.(txcommon.BatchPinData)
 		return op.Type == core.OpTypeBlockchainPinBatch && data.Batch == batch
@@ -746,3 +882,234 @@ func TestTerminateContractWrongAddress(t *testing.T) {
 	err := mp.TerminateContract(context.Background(), fftypes.JSONAnyPtr("{}"), &blockchain.Event{})
 	assert.NoError(t, err)
 }
+
+func TestRequeryPins(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	mp.namespace.Contracts.Active.Info.Subscription = "sub1"
+	mp.mbi.On("RequeryFireflySubscription", context.Background(), "sub1", "000000000010/000000/000000").Return(nil)
+
+	err := mp.RequeryPins(context.Background(), "000000000010/000000/000000")
+	assert.NoError(t, err)
+}
+
+func TestRequeryPinsNoSubscription(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	err := mp.RequeryPins(context.Background(), "")
+	assert.Regexp(t, "FF10558", err)
+}
+
+func newTestMigrationContracts(mp *testMultipartyManager) (*fftypes.JSONAny, *fftypes.JSONAny) {
+	location1 := fftypes.JSONAnyPtr(fftypes.JSONObject{
+		"address": "0x123",
+	}.String())
+	location2 := fftypes.JSONAnyPtr(fftypes.JSONObject{
+		"address": "0x456",
+	}.String())
+	mp.multipartyManager.namespace.Contracts = &core.MultipartyContracts{
+		Active: &core.MultipartyContract{
+			Index:    0,
+			Location: location1,
+			Info:     core.MultipartyContractInfo{Subscription: "sub1"},
+		},
+	}
+	mp.multipartyManager.config.Contracts = []blockchain.MultipartyContract{{
+		FirstEvent: "0",
+		Location:   location1,
+	}, {
+		FirstEvent: "newest",
+		Location:   location2,
+	}}
+	return location1, location2
+}
+
+func TestScheduleContractMigration(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	_, location2 := newTestMigrationContracts(mp)
+
+	mp.mbi.On("GetNetworkVersion", mock.Anything, location2).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.BlockchainEvent{
+		{Namespace: "ns1", ID: fftypes.NewUUID(), ProtocolID: "000000000050/000000/000000"},
+	}, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.MatchedBy(func(c *blockchain.MultipartyContract) bool {
+		return c.Location == location2
+	}), "000000000050/000000/000000").Return("sub2", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	_, err := mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 100})
+	assert.NoError(t, err)
+
+	pending := mp.namespace.Contracts.Pending
+	assert.Equal(t, 1, pending.Index)
+	assert.Equal(t, location2, pending.Location)
+	assert.Equal(t, "sub2", pending.Info.Subscription)
+	assert.Equal(t, 2, pending.Info.Version)
+	assert.Equal(t, int64(100), pending.Info.ActivationBlock)
+	assert.Equal(t, "sub1", mp.namespace.Contracts.Active.Info.Subscription)
+
+	// Re-delivery of the same migration is a no-op
+	retryable, err := mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 100})
+	assert.NoError(t, err)
+	assert.False(t, retryable)
+
+	// A different migration is rejected while one is pending
+	retryable, err = mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 200})
+	assert.Regexp(t, "FF10560", err)
+	assert.False(t, retryable)
+}
+
+func TestScheduleContractMigrationBadIndex(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	newTestMigrationContracts(mp)
+
+	retryable, err := mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 2, BlockNumber: 100})
+	assert.Regexp(t, "FF10559", err)
+	assert.False(t, retryable)
+}
+
+func TestScheduleContractMigrationBadBlock(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	newTestMigrationContracts(mp)
+
+	retryable, err := mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 1})
+	assert.Regexp(t, "FF10561", err)
+	assert.False(t, retryable)
+}
+
+func TestScheduleContractMigrationNotConfigured(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	newTestMigrationContracts(mp)
+	mp.multipartyManager.config.Contracts = mp.multipartyManager.config.Contracts[0:1]
+
+	retryable, err := mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 100})
+	assert.Regexp(t, "FF10396", err)
+	assert.False(t, retryable)
+}
+
+func TestScheduleContractMigrationSubscribeFail(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	newTestMigrationContracts(mp)
+
+	mp.mbi.On("GetNetworkVersion", mock.Anything, mock.Anything).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.Anything, "").Return("", fmt.Errorf("pop"))
+
+	retryable, err := mp.ScheduleContractMigration(context.Background(), &core.ContractMigration{Index: 1, BlockNumber: 100})
+	assert.EqualError(t, err, "pop")
+	assert.True(t, retryable)
+	assert.Nil(t, mp.namespace.Contracts.Pending)
+}
+
+func TestCheckContractMigration(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	location1, location2 := newTestMigrationContracts(mp)
+	mp.namespace.Contracts.Pending = &core.MultipartyContract{
+		Index:    1,
+		Location: location2,
+		Info: core.MultipartyContractInfo{
+			Subscription:    "sub2",
+			ActivationBlock: 100,
+		},
+	}
+
+	// Events before the activation block, or without a parseable block, leave both contracts in place
+	err := mp.CheckContractMigration(context.Background(), &blockchain.Event{ProtocolID: "000000000099/000000/000000"})
+	assert.NoError(t, err)
+	err = mp.CheckContractMigration(context.Background(), &blockchain.Event{ProtocolID: "bad"})
+	assert.NoError(t, err)
+	assert.NotNil(t, mp.namespace.Contracts.Pending)
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err = mp.CheckContractMigration(context.Background(), &blockchain.Event{ProtocolID: "000000000100/000001/000000"})
+	assert.NoError(t, err)
+	assert.Nil(t, mp.namespace.Contracts.Pending)
+	assert.Equal(t, location2, mp.namespace.Contracts.Active.Location)
+	assert.Equal(t, "sub2", mp.namespace.Contracts.Active.Info.Subscription)
+	assert.Len(t, mp.namespace.Contracts.Terminated, 1)
+	assert.Equal(t, location1, mp.namespace.Contracts.Terminated[0].Location)
+	assert.Equal(t, "000000000100/000001/000000", mp.namespace.Contracts.Terminated[0].Info.FinalEvent)
+
+	// Nothing further to switch
+	err = mp.CheckContractMigration(context.Background(), &blockchain.Event{ProtocolID: "000000000101/000000/000000"})
+	assert.NoError(t, err)
+}
+
+func TestTerminateContractWithPendingMigration(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	location1, location2 := newTestMigrationContracts(mp)
+	mp.namespace.Contracts.Pending = &core.MultipartyContract{
+		Index:    1,
+		Location: location2,
+		Info: core.MultipartyContractInfo{
+			Subscription:    "sub2",
+			ActivationBlock: 100,
+		},
+	}
+
+	mp.mbi.On("RemoveFireflySubscription", mock.Anything, "sub1").Return()
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.TerminateContract(context.Background(), location1, &blockchain.Event{ProtocolID: "000000000050/000000/000000"})
+	assert.NoError(t, err)
+	assert.Nil(t, mp.namespace.Contracts.Pending)
+	assert.Equal(t, "sub2", mp.namespace.Contracts.Active.Info.Subscription)
+}
+
+func TestConfigureContractWithPendingMigration(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	location1, location2 := newTestMigrationContracts(mp)
+	mp.namespace.Contracts.Pending = &core.MultipartyContract{
+		Index:    1,
+		Location: location2,
+		Info: core.MultipartyContractInfo{
+			ActivationBlock: 100,
+		},
+	}
+
+	mp.mbi.On("GetNetworkVersion", mock.Anything, mock.Anything).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.MatchedBy(func(c *blockchain.MultipartyContract) bool {
+		return c.Location == location1
+	}), "").Return("sub1", nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.MatchedBy(func(c *blockchain.MultipartyContract) bool {
+		return c.Location == location2
+	}), "").Return("sub2", nil)
+	mp.mdi.On("UpsertNamespace", mock.Anything, mp.namespace, true).Return(nil)
+
+	err := mp.ConfigureContract(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "sub1", mp.namespace.Contracts.Active.Info.Subscription)
+	assert.Equal(t, "sub2", mp.namespace.Contracts.Pending.Info.Subscription)
+	assert.Equal(t, int64(100), mp.namespace.Contracts.Pending.Info.ActivationBlock)
+}
+
+func TestConfigureContractWithPendingMigrationFail(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	_, location2 := newTestMigrationContracts(mp)
+	mp.namespace.Contracts.Pending = &core.MultipartyContract{
+		Index:    1,
+		Location: location2,
+	}
+
+	mp.mbi.On("GetNetworkVersion", mock.Anything, mock.Anything).Return(2, nil)
+	mp.mdi.On("GetBlockchainEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, nil)
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.Anything, "").Return("sub1", nil).Once()
+	mp.mbi.On("AddFireflySubscription", mock.Anything, mp.namespace, mock.Anything, "").Return("", fmt.Errorf("pop")).Once()
+
+	err := mp.ConfigureContract(context.Background())
+	assert.EqualError(t, err, "pop")
+}
@@ -18,16 +18,25 @@ package multiparty
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
 )
 
+// batchAnchorData is the data for pinning a batch to the anchor chain, which is distinct from the
+// txcommon.BatchPinData used for the batch pin itself, so that each is routed to its own plugin
+type batchAnchorData struct {
+	txcommon.BatchPinData
+}
+
 type networkActionData struct {
 	Type core.NetworkActionType `json:"type"`
 	Key  string                 `json:"key"`
@@ -90,6 +99,19 @@ func (mm *multipartyManager) PrepareOperation(ctx context.Context, op *core.Oper
 		}
 		return opBatchPin(op, batch, contexts, payloadRef), nil
 
+	case core.OpTypeBlockchainAnchorBatch:
+		batchID, contexts, payloadRef, err := retrieveBatchPinInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		batch, err := mm.database.GetBatchByID(ctx, mm.namespace.Name, batchID)
+		if err != nil {
+			return nil, err
+		} else if batch == nil {
+			return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+		}
+		return opBatchAnchor(op, batch, contexts, payloadRef), nil
+
 	case core.OpTypeBlockchainNetworkAction:
 		actionType, signingKey := retrieveNetworkActionInputs(op)
 		return opNetworkAction(op, actionType, signingKey), nil
@@ -104,7 +126,7 @@ func (mm *multipartyManager) RunOperation(ctx context.Context, op *core.Prepared
 	case txcommon.BatchPinData:
 		batch := data.Batch
 		contract := mm.namespace.Contracts.Active
-		err = mm.blockchain.SubmitBatchPin(ctx, op.NamespacedIDString(), batch.Namespace, batch.Key, &blockchain.BatchPin{
+		err = mm.sequencer.SubmitBatchPin(ctx, op.NamespacedIDString(), batch.Namespace, batch.Key, &blockchain.BatchPin{
 			TransactionID:   batch.TX.ID,
 			BatchID:         batch.ID,
 			BatchHash:       batch.Hash,
@@ -112,6 +134,19 @@ func (mm *multipartyManager) RunOperation(ctx context.Context, op *core.Prepared
 			Contexts:        data.Contexts,
 		}, contract.Location)
 		return nil, operations.ErrTernary(err, core.OpPhaseInitializing, core.OpPhasePending), err
+	case batchAnchorData:
+		if mm.anchor == nil {
+			return nil, core.OpPhaseInitializing, i18n.NewError(ctx, coremsgs.MsgNoAnchorChain, mm.namespace.Name)
+		}
+		batch := data.Batch
+		err = mm.anchor.SubmitBatchPin(ctx, op.NamespacedIDString(), batch.Namespace, mm.config.Anchor.Key, &blockchain.BatchPin{
+			TransactionID:   batch.TX.ID,
+			BatchID:         batch.ID,
+			BatchHash:       batch.Hash,
+			BatchPayloadRef: data.PayloadRef,
+			Contexts:        data.Contexts,
+		}, mm.config.Anchor.Location)
+		return nil, operations.ErrTernary(err, core.OpPhaseInitializing, core.OpPhasePending), err
 	case networkActionData:
 		contract := mm.namespace.Contracts.Active
 		err = mm.blockchain.SubmitNetworkAction(ctx, op.NamespacedIDString(), data.Key, data.Type, contract.Location)
@@ -122,7 +157,22 @@ func (mm *multipartyManager) RunOperation(ctx context.Context, op *core.Prepared
 }
 
 func (mm *multipartyManager) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
-	return nil
+	if op.Type != core.OpTypeBlockchainAnchorBatch || update.Status != core.OpStatusSucceeded || update.BlockchainTXID == "" {
+		return nil
+	}
+	batchID, err := fftypes.ParseUUID(ctx, op.Input.GetString("batch"))
+	if err != nil {
+		log.L(ctx).Warnf("Unable to record anchor for operation '%s': %s", op.ID, err)
+		return nil
+	}
+	anchor, _ := json.Marshal(&core.BatchAnchor{
+		Plugin:       op.Plugin,
+		BlockchainID: update.BlockchainTXID,
+		Operation:    op.ID,
+	})
+	log.L(ctx).Infof("Batch %s anchored to '%s' in transaction %s", batchID, op.Plugin, update.BlockchainTXID)
+	return mm.database.UpdateBatch(ctx, mm.namespace.Name, batchID,
+		database.BatchQueryFactory.NewUpdate(ctx).Set("anchor", fftypes.JSONAnyPtrBytes(anchor)))
 }
 
 func opBatchPin(op *core.Operation, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string) *core.PreparedOperation {
@@ -135,6 +185,16 @@ func opBatchPin(op *core.Operation, batch *core.BatchPersisted, contexts []*ffty
 	}
 }
 
+func opBatchAnchor(op *core.Operation, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string) *core.PreparedOperation {
+	return &core.PreparedOperation{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Plugin:    op.Plugin,
+		Type:      op.Type,
+		Data:      batchAnchorData{txcommon.BatchPinData{Batch: batch, Contexts: contexts, PayloadRef: payloadRef}},
+	}
+}
+
 func opNetworkAction(op *core.Operation, actionType core.NetworkActionType, key string) *core.PreparedOperation {
 	return &core.PreparedOperation{
 		ID:        op.ID,
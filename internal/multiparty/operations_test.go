@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/txcommon"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -213,5 +216,164 @@ func TestRunBatchPinV1(t *testing.T) {
 func TestOperationUpdate(t *testing.T) {
 	mp := newTestMultipartyManager()
 	defer mp.cleanup(t)
-	assert.NoError(t, mp.OnOperationUpdate(context.Background(), nil, nil))
+	op := &core.Operation{Type: core.OpTypeBlockchainPinBatch}
+	assert.NoError(t, mp.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{Status: core.OpStatusSucceeded}))
+}
+
+func TestPrepareAndRunBatchAnchor(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+	manchor := &blockchainmocks.Plugin{}
+	mp.anchor = manchor
+	mp.config.Anchor = Anchor{
+		Key:      "0xabc",
+		Location: fftypes.JSONAnyPtr(`{"address":"0x456"}`),
+	}
+
+	op := &core.Operation{
+		Type:      core.OpTypeBlockchainAnchorBatch,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{
+			ID: fftypes.NewUUID(),
+			SignerRef: core.SignerRef{
+				Key: "0x123",
+			},
+			Namespace: "ns1",
+		},
+		TX: core.TransactionRef{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	contexts := []*fftypes.Bytes32{
+		fftypes.NewRandB32(),
+	}
+	addBatchPinInputs(op, batch.ID, contexts, "payload1")
+
+	mp.mdi.On("GetBatchByID", context.Background(), "ns1", batch.ID).Return(batch, nil)
+	manchor.On("SubmitBatchPin", context.Background(), "ns1:"+op.ID.String(), "ns1", "0xabc", mock.MatchedBy(func(pin *blockchain.BatchPin) bool {
+		return pin.BatchID == batch.ID && pin.TransactionID == batch.TX.ID && pin.BatchPayloadRef == "payload1"
+	}), mp.config.Anchor.Location).Return(nil)
+
+	po, err := mp.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, batch, po.Data.(batchAnchorData).Batch)
+
+	_, phase, err := mp.RunOperation(context.Background(), po)
+
+	assert.Equal(t, core.OpPhasePending, phase)
+	assert.NoError(t, err)
+
+	manchor.AssertExpectations(t)
+}
+
+func TestPrepareOperationBatchAnchorBadBatch(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	op := &core.Operation{
+		Type:  core.OpTypeBlockchainAnchorBatch,
+		Input: fftypes.JSONObject{"batch": "bad"},
+	}
+
+	_, err := mp.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestPrepareOperationBatchAnchorError(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	op := &core.Operation{
+		Type: core.OpTypeBlockchainAnchorBatch,
+		Input: fftypes.JSONObject{
+			"batch":    batchID.String(),
+			"contexts": []string{},
+		},
+	}
+
+	mp.mdi.On("GetBatchByID", context.Background(), "ns1", batchID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := mp.PrepareOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPrepareOperationBatchAnchorNotFound(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	op := &core.Operation{
+		Type: core.OpTypeBlockchainAnchorBatch,
+		Input: fftypes.JSONObject{
+			"batch":    batchID.String(),
+			"contexts": []string{},
+		},
+	}
+
+	mp.mdi.On("GetBatchByID", context.Background(), "ns1", batchID).Return(nil, nil)
+
+	_, err := mp.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestRunBatchAnchorNoAnchorChain(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	op := &core.Operation{
+		Type:      core.OpTypeBlockchainAnchorBatch,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	batch := &core.BatchPersisted{}
+
+	_, phase, err := mp.RunOperation(context.Background(), opBatchAnchor(op, batch, nil, "payload1"))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.Regexp(t, "FF10562", err)
+}
+
+func TestOperationUpdateBatchAnchor(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	op := &core.Operation{
+		ID:     fftypes.NewUUID(),
+		Type:   core.OpTypeBlockchainAnchorBatch,
+		Plugin: "anchorchain",
+		Input:  fftypes.JSONObject{"batch": batchID.String()},
+	}
+
+	mp.mdi.On("UpdateBatch", context.Background(), "ns1", batchID, mock.MatchedBy(func(u ffapi.Update) bool {
+		info, _ := u.Finalize()
+		return len(info.SetOperations) == 1 && info.SetOperations[0].Field == "anchor"
+	})).Return(nil)
+
+	err := mp.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status:         core.OpStatusSucceeded,
+		BlockchainTXID: "0xtx1",
+	})
+	assert.NoError(t, err)
+}
+
+func TestOperationUpdateBatchAnchorBadBatch(t *testing.T) {
+	mp := newTestMultipartyManager()
+	defer mp.cleanup(t)
+
+	op := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Type:  core.OpTypeBlockchainAnchorBatch,
+		Input: fftypes.JSONObject{"batch": "bad"},
+	}
+
+	err := mp.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{
+		Status:         core.OpStatusSucceeded,
+		BlockchainTXID: "0xtx1",
+	})
+	assert.NoError(t, err)
 }
@@ -20,12 +20,17 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/auth/authfactory"
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/assets"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/database/difactory"
 	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
 	"github.com/hyperledger/firefly/internal/events/eifactory"
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
+	"github.com/hyperledger/firefly/internal/keymanager/kmfactory"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/internal/sequencer/sqfactory"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -47,6 +52,7 @@ var (
 	sharedstorageConfig = config.RootArray("plugins.sharedstorage")
 	dataexchangeConfig  = config.RootArray("plugins.dataexchange")
 	identityConfig      = config.RootArray("plugins.identity")
+	keymanagerConfig    = config.RootArray("plugins.keymanager")
 	authConfig          = config.RootArray("plugins.auth")
 	eventsConfig        = config.RootSection("events") // still at root
 )
@@ -55,8 +61,24 @@ func InitConfig() {
 	namespacePredefined.AddKnownKey(coreconfig.NamespaceName)
 	namespacePredefined.AddKnownKey(coreconfig.NamespaceDescription)
 	namespacePredefined.AddKnownKey(coreconfig.NamespacePlugins)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceIsolated, false)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceSandbox, false)
 	namespacePredefined.AddKnownKey(coreconfig.NamespaceDefaultKey)
 	namespacePredefined.AddKnownKey(coreconfig.NamespaceAssetKeyNormalization)
+	poolTemplatesConf := namespacePredefined.SubArray(coreconfig.NamespaceAssetPoolTemplates)
+	poolTemplatesConf.AddKnownKey(coreconfig.NamespaceAssetPoolTemplateName)
+	poolTemplatesConf.AddKnownKey(coreconfig.NamespaceAssetPoolTemplateConnector)
+	poolTemplatesConf.AddKnownKey(coreconfig.NamespaceAssetPoolTemplateType)
+	poolTemplatesConf.AddKnownKey(coreconfig.NamespaceAssetPoolTemplateStandard)
+	poolTemplatesConf.AddKnownKey(coreconfig.NamespaceAssetPoolTemplateDecimals)
+	poolTemplatesConf.AddKnownKey(coreconfig.NamespaceAssetPoolTemplateConfig)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceDownloadPriorityBatch)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceDownloadPriorityBlob)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceBatchWorkers)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceBatchQueueDepth)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceValidationPolicy)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceValidationDatatypes)
+	namespacePredefined.AddKnownKey(coreconfig.NamespaceHashCanonicalization)
 
 	multipartyConf := namespacePredefined.SubSection(coreconfig.NamespaceMultiparty)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyEnabled)
@@ -64,8 +86,20 @@ func InitConfig() {
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgName)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgDescription)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgKey)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgCertificate)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgCertificateSignature)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyOrgVerificationTrustRoots)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyIdentityClaimsRequireSignature, false)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyNodeName)
 	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyNodeDescription)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartySequencerType, sqfactory.TypeBlockchain)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartySequencerPollInterval, "500ms")
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartySequencerBatchSize, 50)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyAnchorPlugin)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyAnchorKey)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyAnchorLocation)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyAnchorMode, multiparty.AnchorModeBatch)
+	multipartyConf.AddKnownKey(coreconfig.NamespaceMultipartyAnchorDigestInterval, "1m")
 
 	contractConf := multipartyConf.SubArray(coreconfig.NamespaceMultipartyContract)
 	contractConf.AddKnownKey(coreconfig.NamespaceMultipartyContractFirstEvent, string(core.SubOptsFirstEventOldest))
@@ -82,7 +116,10 @@ func InitConfig() {
 	ssfactory.InitConfig(sharedstorageConfig)
 	dxfactory.InitConfig(dataexchangeConfig)
 	iifactory.InitConfig(identityConfig)
+	kmfactory.InitConfig(keymanagerConfig)
 	tifactory.InitConfig(tokensConfig)
 	authfactory.InitConfigArray(authConfig)
 	eifactory.InitConfig(eventsConfig)
+	operations.InitConfig()
+	assets.InitConfig()
 }
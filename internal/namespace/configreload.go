@@ -23,8 +23,10 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/internal/orchestrator"
 	"github.com/spf13/viper"
 )
@@ -61,6 +63,35 @@ func (nm *namespaceManager) configFileChanged() {
 }
 
 func (nm *namespaceManager) configReloaded(ctx context.Context) {
+	_ = nm.applyConfigChanges(ctx, nil)
+}
+
+// RestartPlugin re-reads the configuration, and then forces the named plugin to be
+// stopped and re-initialized - along with every namespace that uses it - even if its
+// configuration is unchanged. Any other configuration changes are applied at the same time.
+func (nm *namespaceManager) RestartPlugin(ctx context.Context, pluginType, name string) error {
+	nm.nsMux.Lock()
+	existing := nm.plugins[name]
+	nm.nsMux.Unlock()
+	if existing == nil || string(existing.category) != pluginType {
+		return i18n.NewError(ctx, coremsgs.MsgPluginNotFound, pluginType, name)
+	}
+
+	log.L(ctx).Infof("Restarting %s plugin '%s'. Loaded at %s", pluginType, name, existing.loadTime)
+	if err := nm.reloadConfig(); err != nil {
+		log.L(ctx).Errorf("Failed to re-read configuration for plugin restart: %s", err)
+		return err
+	}
+	return nm.applyConfigChanges(ctx, map[string]bool{name: true})
+}
+
+// applyConfigChanges stops and restarts the plugins and namespaces affected by the current
+// configuration, including any plugins explicitly requested for restart
+func (nm *namespaceManager) applyConfigChanges(ctx context.Context, restartPlugins map[string]bool) error {
+	// Only one reload can be in progress at a time
+	nm.reloadMux.Lock()
+	defer nm.reloadMux.Unlock()
+
 	// Always make sure log level is up to date
 	log.SetLevel(config.GetString(config.LogLevel))
 
@@ -73,18 +104,18 @@ func (nm *namespaceManager) configReloaded(ctx context.Context) {
 	allPluginsInNewConf, err := nm.loadPlugins(ctx, rawConfig)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to initialize plugins after config reload: %s", err)
-		return
+		return err
 	}
 
 	// Analyze the new list to see which plugins need to be updated,
 	// so we load the namespaces against the correct list of plugins
-	availablePlugins, updatedPlugins, pluginsToStop := nm.analyzePluginChanges(ctx, allPluginsInNewConf)
+	availablePlugins, updatedPlugins, pluginsToStop := nm.analyzePluginChanges(ctx, allPluginsInNewConf, restartPlugins)
 
 	// Build the new set of namespaces (including those that are unchanged)
 	allNewNamespaces, err := nm.loadNamespaces(ctx, rawConfig, availablePlugins)
 	if err != nil {
 		log.L(ctx).Errorf("Failed to load namespaces after config reload: %s", err)
-		return
+		return err
 	}
 
 	// From this point we need to block any API calls resolving namespaces,
@@ -115,16 +146,17 @@ func (nm *namespaceManager) configReloaded(ctx context.Context) {
 	if err = nm.initPlugins(updatedPlugins); err != nil {
 		log.L(ctx).Errorf("Failed to initialize plugins after config reload: %s", err)
 		nm.cancelCtx() // stop the world
-		return
+		return err
 	}
 
 	// Now we can start all the new things
 	if err = nm.startNamespacesAndPlugins(updatedNamespaces, updatedPlugins); err != nil {
 		log.L(ctx).Errorf("Failed to initialize namespaces after config reload: %s", err)
 		nm.cancelCtx() // stop the world
-		return
+		return err
 	}
 
+	return nil
 }
 
 func (nm *namespaceManager) stopDefunctNamespaces(ctx context.Context, newPlugins map[string]*plugin, newNamespaces map[string]*namespace) (availableNamespaces, updatedNamespaces map[string]*namespace) {
@@ -145,8 +177,8 @@ func (nm *namespaceManager) stopDefunctNamespaces(ctx context.Context, newPlugin
 			for _, pluginName := range newNS.pluginNames {
 				existingPlugin := nm.plugins[pluginName]
 				newPlugin := newPlugins[pluginName]
-				if existingPlugin == nil || newPlugin == nil ||
-					!existingPlugin.configHash.Equals(newPlugin.configHash) {
+				// Unchanged plugins are carried over as-is, so a different instance means it is being replaced
+				if existingPlugin == nil || newPlugin == nil || existingPlugin != newPlugin {
 					changes = append(changes, fmt.Sprintf("plugin:%s", pluginName))
 				}
 			}
@@ -185,7 +217,7 @@ func (nm *namespaceManager) stopDefunctNamespaces(ctx context.Context, newPlugin
 
 }
 
-func (nm *namespaceManager) analyzePluginChanges(ctx context.Context, newPlugins map[string]*plugin) (availablePlugins, updatedPlugins, pluginsToStop map[string]*plugin) {
+func (nm *namespaceManager) analyzePluginChanges(ctx context.Context, newPlugins map[string]*plugin, restartPlugins map[string]bool) (availablePlugins, updatedPlugins, pluginsToStop map[string]*plugin) {
 
 	// build a set of all the plugins we've either added new, or have changed
 	availablePlugins = make(map[string]*plugin)
@@ -193,7 +225,7 @@ func (nm *namespaceManager) analyzePluginChanges(ctx context.Context, newPlugins
 	pluginsToStop = make(map[string]*plugin)
 	for pluginName, newPlugin := range newPlugins {
 		if existingPlugin := nm.plugins[pluginName]; existingPlugin != nil {
-			if existingPlugin.configHash.Equals(newPlugin.configHash) {
+			if existingPlugin.configHash.Equals(newPlugin.configHash) && !restartPlugins[pluginName] {
 				log.L(ctx).Debugf("Plugin '%s' unchanged after config reload", pluginName)
 				availablePlugins[pluginName] = existingPlugin
 				continue
@@ -415,7 +415,7 @@ func mockInitConfig(nmm *nmMocks) {
 	nmm.mbi.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	nmm.mdx.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	nmm.mps.On("Init", mock.Anything, mock.Anything).Return(nil)
-	nmm.mti[1].On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	nmm.mti[1].On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	nmm.mei[0].On("Init", mock.Anything, mock.Anything).Return(nil)
 	nmm.mei[1].On("Init", mock.Anything, mock.Anything).Return(nil)
 	nmm.mei[2].On("Init", mock.Anything, mock.Anything).Return(nil)
@@ -1070,3 +1070,123 @@ namespaces:
 	nm.WaitStop()
 
 }
+
+func TestRestartPlugin(t *testing.T) {
+	logrus.SetLevel(logrus.TraceLevel)
+
+	nm, nmm, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(exampleConfig1base))
+	assert.NoError(t, err)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+
+	mockInitConfig(nmm)
+	waitInit := namespaceInitWaiter(t, nmm, []string{"ns1", "ns2"})
+
+	err = nm.Init(ctx, cancelCtx, make(chan bool), func() error {
+		coreconfig.Reset()
+		InitConfig()
+		viper.SetConfigType("yaml")
+		return viper.ReadConfig(strings.NewReader(exampleConfig1base))
+	})
+	assert.NoError(t, err)
+
+	err = nm.Start()
+	assert.NoError(t, err)
+	waitInit.Wait()
+
+	originalPlugins := nm.plugins
+	originalNS := nm.namespaces
+
+	// Only ns2 uses the plugin, so only ns2 should restart
+	waitInit = namespaceInitWaiter(t, nmm, []string{"ns2"})
+	err = nm.RestartPlugin(nm.ctx, "blockchain", "blockchain-ns2")
+	assert.NoError(t, err)
+	waitInit.Wait()
+
+	// Check that we didn't cancel the context
+	select {
+	case <-nm.ctx.Done():
+		assert.Fail(t, "Error occurred in plugin restart")
+	default:
+	}
+
+	for name, p := range originalPlugins {
+		if name == "blockchain-ns2" {
+			assert.False(t, p == nm.plugins[name], name)
+			assert.Equal(t, p.configHash, nm.plugins[name].configHash, name)
+			assert.Error(t, p.ctx.Err(), name)
+		} else {
+			assert.True(t, p == nm.plugins[name], name)
+		}
+	}
+	assert.True(t, originalNS["ns1"] == nm.namespaces["ns1"])
+	assert.False(t, originalNS["ns2"] == nm.namespaces["ns2"])
+
+}
+
+func TestRestartPluginNotFound(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	err := nm.RestartPlugin(context.Background(), "tokens", "unknown")
+	assert.Regexp(t, "FF10524", err)
+}
+
+func TestRestartPluginWrongType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.plugins = map[string]*plugin{
+		"postgres": {name: "postgres", category: pluginCategoryDatabase},
+	}
+
+	err := nm.RestartPlugin(context.Background(), "tokens", "postgres")
+	assert.Regexp(t, "FF10524", err)
+}
+
+func TestRestartPluginReloadConfigFail(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.plugins = map[string]*plugin{
+		"postgres": {name: "postgres", category: pluginCategoryDatabase},
+	}
+	nm.reloadConfig = func() error { return fmt.Errorf("pop") }
+
+	err := nm.RestartPlugin(context.Background(), "database", "postgres")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRestartPluginLoadNamespacesFail(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nm.plugins = map[string]*plugin{
+		"postgres": {name: "postgres", category: pluginCategoryDatabase},
+	}
+	nm.reloadConfig = func() error {
+		coreconfig.Reset()
+		InitConfig()
+		viper.SetConfigType("yaml")
+		return viper.ReadConfig(strings.NewReader(`
+plugins:
+  database:
+  - name: "postgres"
+    type: "postgres"
+namespaces:
+  predefined:
+  - name: default
+    plugins:
+    - postgres
+    - missing
+`))
+	}
+
+	err := nm.RestartPlugin(context.Background(), "database", "postgres")
+	assert.Regexp(t, "FF10390", err)
+}
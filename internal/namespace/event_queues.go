@@ -0,0 +1,61 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/inboundqueue"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// initEventQueues gives each blockchain, data exchange and tokens plugin a persistent queue for the events
+// it receives from its connector, so they are acknowledged as soon as they are stored
+func (nm *namespaceManager) initEventQueues(pluginsToStart map[string]*plugin) error {
+	di, err := nm.eventQueueDatabase()
+	if err != nil {
+		return err
+	}
+	for name, p := range pluginsToStart {
+		switch p.category {
+		case pluginCategoryBlockchain:
+			p.blockchain.SetEventQueue(inboundqueue.NewQueue(name, di))
+		case pluginCategoryDataexchange:
+			p.dataexchange.SetEventQueue(inboundqueue.NewQueue(name, di))
+		case pluginCategoryTokens:
+			p.tokens.SetEventQueue(inboundqueue.NewQueue(name, di))
+		}
+	}
+	return nil
+}
+
+// eventQueueDatabase returns the database plugin named in the config, or the only database plugin if none is named
+func (nm *namespaceManager) eventQueueDatabase() (database.Plugin, error) {
+	name := config.GetString(coreconfig.EventInboundQueueDatabase)
+	var found []database.Plugin
+	for pluginName, p := range nm.plugins {
+		if p.category == pluginCategoryDatabase && (name == "" || pluginName == name) {
+			found = append(found, p.database)
+		}
+	}
+	if len(found) != 1 {
+		return nil, i18n.NewError(nm.ctx, coremsgs.MsgInboundQueueDatabaseNotFound, name)
+	}
+	return found[0], nil
+}
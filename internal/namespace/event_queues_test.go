@@ -0,0 +1,99 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/inboundqueue"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/tokenmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func isInboundQueue(queue interface{}) bool {
+	_, ok := queue.(*inboundqueue.Queue)
+	return ok
+}
+
+func TestInitEventQueues(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.EventInboundQueueEnabled, true)
+	mbi := &blockchainmocks.Plugin{}
+	mdx := &dataexchangemocks.Plugin{}
+	mti := &tokenmocks.Plugin{}
+	plugins := map[string]*plugin{
+		"postgres": {name: "postgres", category: pluginCategoryDatabase, database: &databasemocks.Plugin{}},
+		"ethereum": {name: "ethereum", category: pluginCategoryBlockchain, blockchain: mbi},
+		"ffdx":     {name: "ffdx", category: pluginCategoryDataexchange, dataexchange: mdx},
+		"erc1155":  {name: "erc1155", category: pluginCategoryTokens, tokens: mti},
+	}
+	nm := &namespaceManager{ctx: context.Background(), plugins: plugins}
+
+	mbi.On("SetEventQueue", mock.MatchedBy(isInboundQueue)).Return()
+	mdx.On("SetEventQueue", mock.MatchedBy(isInboundQueue)).Return()
+	mti.On("SetEventQueue", mock.MatchedBy(isInboundQueue)).Return()
+
+	err := nm.initEventQueues(map[string]*plugin{
+		"ethereum": plugins["ethereum"],
+		"ffdx":     plugins["ffdx"],
+		"erc1155":  plugins["erc1155"],
+	})
+	assert.NoError(t, err)
+
+	mbi.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mti.AssertExpectations(t)
+}
+
+func TestInitPluginsEventQueueNoDatabase(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.EventInboundQueueEnabled, true)
+	nm := &namespaceManager{ctx: context.Background(), plugins: map[string]*plugin{}}
+
+	err := nm.initPlugins(map[string]*plugin{})
+	assert.Regexp(t, "FF10591", err)
+}
+
+func TestEventQueueDatabaseNamed(t *testing.T) {
+	coreconfig.Reset()
+	mdi1 := &databasemocks.Plugin{}
+	mdi2 := &databasemocks.Plugin{}
+	nm := &namespaceManager{ctx: context.Background(), plugins: map[string]*plugin{
+		"database1": {name: "database1", category: pluginCategoryDatabase, database: mdi1},
+		"database2": {name: "database2", category: pluginCategoryDatabase, database: mdi2},
+	}}
+
+	// A name is required to choose between multiple database plugins
+	_, err := nm.eventQueueDatabase()
+	assert.Regexp(t, "FF10591", err)
+
+	config.Set(coreconfig.EventInboundQueueDatabase, "database2")
+	di, err := nm.eventQueueDatabase()
+	assert.NoError(t, err)
+	assert.Equal(t, mdi2, di)
+
+	config.Set(coreconfig.EventInboundQueueDatabase, "database3")
+	_, err = nm.eventQueueDatabase()
+	assert.Regexp(t, "FF10591.*database3", err)
+}
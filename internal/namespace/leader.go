@@ -0,0 +1,175 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// clusterConfig controls leader election between instances that share a database.
+//
+// Each namespace has its own leadership lease, held in the database of that namespace.
+// The leader runs the full orchestrator. Other instances initialize the namespace so
+// they can serve reads, but wait in standby until they can take the lease.
+type clusterConfig struct {
+	enabled       bool
+	instanceID    string
+	leaseTTL      time.Duration
+	renewInterval time.Duration
+}
+
+func loadClusterConfig() clusterConfig {
+	cc := clusterConfig{
+		enabled:       config.GetBool(coreconfig.ClusterEnabled),
+		instanceID:    config.GetString(coreconfig.ClusterInstanceID),
+		leaseTTL:      config.GetDuration(coreconfig.ClusterLeaseTTL),
+		renewInterval: config.GetDuration(coreconfig.ClusterLeaseRenewInterval),
+	}
+	if cc.instanceID == "" {
+		hostname, _ := os.Hostname()
+		cc.instanceID = fmt.Sprintf("%s-%s", hostname, fftypes.NewUUID().String()[0:8])
+	}
+	return cc
+}
+
+func (nm *namespaceManager) IsStandby(ns string) bool {
+	nm.nsMux.Lock()
+	defer nm.nsMux.Unlock()
+	namespace := nm.namespaces[ns]
+	return namespace != nil && namespace.standby
+}
+
+func (nm *namespaceManager) acquireLeaderLease(ctx context.Context, ns *namespace) (bool, error) {
+	expiry := fftypes.FFTime(time.Now().Add(nm.cluster.leaseTTL))
+	return ns.plugins.Database.Plugin.AcquireLeaderLease(ctx, ns.Name, nm.cluster.instanceID, &expiry)
+}
+
+// waitForLeadership blocks until this instance holds the leadership lease of the namespace,
+// serving the namespace in standby until then
+func (nm *namespaceManager) waitForLeadership(ns *namespace) error {
+	if ns.leader {
+		// We already hold the lease from a previous attempt to start
+		return nil
+	}
+	for {
+		acquired, err := nm.acquireLeaderLease(ns.ctx, ns)
+		if err != nil {
+			log.L(nm.ctx).Warnf("Failed to acquire leadership of namespace '%s': %s", ns.Name, err)
+		}
+		if acquired {
+			log.L(nm.ctx).Infof("Instance '%s' is leader for namespace '%s'", nm.cluster.instanceID, ns.Name)
+			nm.nsMux.Lock()
+			ns.leader = true
+			ns.standby = false
+			nm.nsMux.Unlock()
+			go nm.leaseRenewer(ns)
+			return nil
+		}
+		if !ns.standby {
+			log.L(nm.ctx).Infof("Instance '%s' is standby for namespace '%s'", nm.cluster.instanceID, ns.Name)
+			nm.nsMux.Lock()
+			ns.standby = true
+			nm.nsMux.Unlock()
+		}
+		select {
+		case <-ns.ctx.Done():
+			return i18n.NewError(ns.ctx, coremsgs.MsgContextCanceled)
+		case <-time.After(nm.cluster.renewInterval):
+		}
+	}
+}
+
+// leaseRenewer keeps renewing the leadership lease while the namespace is running.
+// If the lease cannot be renewed before it expires, the namespace is restarted in standby
+// as soon as the lease expires, as another instance might take over from that point.
+func (nm *namespaceManager) leaseRenewer(ns *namespace) {
+	lastRenewed := time.Now()
+	for {
+		// Never wait beyond the expiry of the lease we hold
+		wait := nm.cluster.renewInterval
+		if untilExpiry := time.Until(lastRenewed.Add(nm.cluster.leaseTTL)); untilExpiry < wait {
+			wait = untilExpiry
+		}
+		select {
+		case <-ns.ctx.Done():
+			// Release the lease so a standby instance can take over straight away
+			ctx, cancelCtx := context.WithTimeout(context.Background(), nm.cluster.renewInterval)
+			if err := ns.plugins.Database.Plugin.ReleaseLeaderLease(ctx, ns.Name, nm.cluster.instanceID); err != nil {
+				log.L(nm.ctx).Warnf("Failed to release leadership of namespace '%s': %s", ns.Name, err)
+			}
+			cancelCtx()
+			return
+		case <-time.After(wait):
+		}
+
+		if time.Since(lastRenewed) < nm.cluster.leaseTTL {
+			// Do not let a slow renewal keep us acting as leader after the lease expires
+			renewTime := time.Now()
+			ctx, cancelCtx := context.WithDeadline(ns.ctx, lastRenewed.Add(nm.cluster.leaseTTL))
+			acquired, err := nm.acquireLeaderLease(ctx, ns)
+			cancelCtx()
+			switch {
+			case acquired:
+				lastRenewed = renewTime
+				continue
+			case err != nil && time.Since(lastRenewed) < nm.cluster.leaseTTL:
+				log.L(nm.ctx).Warnf("Failed to renew leadership of namespace '%s' - retrying: %s", ns.Name, err)
+				continue
+			}
+		}
+		log.L(nm.ctx).Warnf("Instance '%s' lost leadership of namespace '%s'", nm.cluster.instanceID, ns.Name)
+		nm.restartInStandby(ns)
+		return
+	}
+}
+
+func (nm *namespaceManager) restartInStandby(ns *namespace) {
+	// Do not run concurrently with a config reload, which might also be stopping this namespace
+	nm.reloadMux.Lock()
+	defer nm.reloadMux.Unlock()
+
+	nm.nsMux.Lock()
+	current := nm.namespaces[ns.Name]
+	nm.nsMux.Unlock()
+	if current != ns {
+		log.L(nm.ctx).Debugf("Namespace '%s' has been replaced since leadership was lost", ns.Name)
+		return
+	}
+
+	nm.stopNamespace(nm.ctx, ns)
+	nm.cacheManager.ResetCachesForNamespace(ns.Name)
+
+	nm.nsMux.Lock()
+	ns.started = false
+	ns.leader = false
+	nm.nsMux.Unlock()
+
+	if err := nm.startNamespacesAndPlugins(map[string]*namespace{ns.Name: ns}, nil); err != nil {
+		log.L(nm.ctx).Errorf("Failed to restart namespace '%s' after losing leadership: %s", ns.Name, err)
+		nm.cancelCtx() // stop the world
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testClusterConfig() clusterConfig {
+	return clusterConfig{
+		enabled:       true,
+		instanceID:    "instance1",
+		leaseTTL:      time.Minute,
+		renewInterval: time.Millisecond,
+	}
+}
+
+func TestLoadClusterConfigDefaults(t *testing.T) {
+	coreconfig.Reset()
+	cc := loadClusterConfig()
+	assert.False(t, cc.enabled)
+	assert.NotEmpty(t, cc.instanceID)
+	assert.Equal(t, 15*time.Second, cc.leaseTTL)
+	assert.Equal(t, 5*time.Second, cc.renewInterval)
+}
+
+func TestLoadClusterConfigInstanceID(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.ClusterEnabled, true)
+	config.Set(coreconfig.ClusterInstanceID, "instance1")
+	cc := loadClusterConfig()
+	assert.True(t, cc.enabled)
+	assert.Equal(t, "instance1", cc.instanceID)
+}
+
+func TestInitAndStartNamespaceClusterLeader(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+	nm.cluster.renewInterval = time.Minute
+
+	nmm.mo.On("PreInit", mock.Anything, mock.Anything).Return()
+	nmm.mo.On("Init").Return(nil)
+	nmm.mo.On("Start").Return(nil)
+	nmm.mdi.On("GetNamespace", mock.Anything, "default").Return(nil, nil)
+	nmm.mdi.On("UpsertNamespace", mock.Anything, mock.AnythingOfType("*core.Namespace"), true).Return(nil)
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(true, nil).Once()
+	nmm.mdi.On("ReleaseLeaderLease", mock.Anything, "default", "instance1").Return(nil).Maybe()
+
+	ns := nm.namespaces["default"]
+	err := nm.preInitNamespace(ns)
+	assert.NoError(t, err)
+	err = nm.initAndStartNamespace(ns)
+	assert.NoError(t, err)
+
+	assert.True(t, ns.leader)
+	assert.False(t, nm.IsStandby("default"))
+}
+
+func TestInitAndStartNamespaceClusterStandby(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+
+	nmm.mo.On("PreInit", mock.Anything, mock.Anything).Return()
+	nmm.mo.On("Init").Return(nil)
+	nmm.mdi.On("GetNamespace", mock.Anything, "default").Return(nil, nil)
+	nmm.mdi.On("UpsertNamespace", mock.Anything, mock.AnythingOfType("*core.Namespace"), true).Return(nil)
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(false, fmt.Errorf("pop")).Once()
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(false, nil).Run(func(args mock.Arguments) {
+		// Standby namespaces serve reads, but not writes
+		assert.True(t, nm.IsStandby("default"))
+		or, err := nm.Orchestrator(context.Background(), "default", false)
+		assert.NoError(t, err)
+		assert.Equal(t, nmm.mo, or)
+		nss, err := nm.GetNamespaces(context.Background(), false)
+		assert.NoError(t, err)
+		assert.Len(t, nss, 1)
+		assert.False(t, nss[0].Initializing)
+		nm.namespaces["default"].cancelCtx()
+	})
+
+	ns := nm.namespaces["default"]
+	err := nm.preInitNamespace(ns)
+	assert.NoError(t, err)
+	err = nm.initAndStartNamespace(ns)
+	assert.Regexp(t, "FF00154", err)
+
+	assert.False(t, ns.leader)
+}
+
+func TestWaitForLeadershipAlreadyLeader(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	err := nm.waitForLeadership(&namespace{leader: true})
+	assert.NoError(t, err)
+}
+
+func TestIsStandbyUnknownNamespace(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+
+	assert.False(t, nm.IsStandby("unknown"))
+}
+
+func TestLeaseRenewerReleaseOnStop(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+
+	ns := nm.namespaces["default"]
+	ns.ctx, ns.cancelCtx = context.WithCancel(context.Background())
+	ns.cancelCtx()
+
+	nmm.mdi.On("ReleaseLeaderLease", mock.Anything, "default", "instance1").Return(fmt.Errorf("pop"))
+
+	nm.leaseRenewer(ns)
+}
+
+func TestLeaseRenewerStepDownAtExpiry(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+	nm.cluster.leaseTTL = 10 * time.Millisecond
+	nm.cluster.renewInterval = time.Minute
+
+	// The namespace has been replaced, so is not restarted
+	ns := &namespace{plugins: nm.namespaces["default"].plugins}
+	ns.Name = "default"
+	ns.ctx, ns.cancelCtx = context.WithCancel(context.Background())
+	defer ns.cancelCtx()
+
+	// Steps down when the lease expires, without waiting for the next renewal
+	startTime := time.Now()
+	nm.leaseRenewer(ns)
+	assert.Less(t, time.Since(startTime), nm.cluster.renewInterval)
+}
+
+func TestLeaseRenewerFailPastTTL(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+	nm.cluster.leaseTTL = 50 * time.Millisecond
+
+	// The namespace has been replaced, so is not restarted
+	ns := &namespace{plugins: nm.namespaces["default"].plugins}
+	ns.Name = "default"
+	ns.ctx, ns.cancelCtx = context.WithCancel(context.Background())
+	defer ns.cancelCtx()
+
+	// Renewals cannot run past the expiry of the lease
+	nmm.mdi.On("AcquireLeaderLease", mock.MatchedBy(func(ctx context.Context) bool {
+		_, hasDeadline := ctx.Deadline()
+		return hasDeadline
+	}), "default", "instance1", mock.Anything).Return(false, fmt.Errorf("pop"))
+
+	nm.leaseRenewer(ns)
+}
+
+func TestLeaseRenewerLostLeadership(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+
+	ns := nm.namespaces["default"]
+	nmm.mo.On("PreInit", mock.Anything, mock.Anything).Return()
+	nmm.mdi.On("GetNamespace", mock.Anything, "default").Return(nil, nil)
+	nmm.mdi.On("UpsertNamespace", mock.Anything, mock.AnythingOfType("*core.Namespace"), true).Return(nil)
+	err := nm.preInitNamespace(ns)
+	assert.NoError(t, err)
+	ns.leader = true
+	ns.started = true
+
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(true, nil).Once()
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(false, fmt.Errorf("pop")).Once()
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(false, nil).Once()
+	nmm.mo.On("WaitStop").Return()
+
+	// After restart, the namespace waits in standby again
+	restarted := make(chan struct{})
+	nmm.mo.On("Init").Return(nil)
+	nmm.mdi.On("AcquireLeaderLease", mock.Anything, "default", "instance1", mock.Anything).Return(false, nil).Run(func(args mock.Arguments) {
+		select {
+		case <-restarted:
+		default:
+			close(restarted)
+		}
+	})
+
+	nm.leaseRenewer(ns)
+	<-restarted
+
+	assert.False(t, ns.leader)
+	assert.False(t, ns.started)
+	assert.True(t, nm.IsStandby("default"))
+}
+
+func TestRestartInStandbyFail(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+	nm.cluster = testClusterConfig()
+
+	ns := nm.namespaces["default"]
+	nmm.mo.On("PreInit", mock.Anything, mock.Anything).Return()
+	nmm.mdi.On("GetNamespace", mock.Anything, "default").Return(nil, nil).Once()
+	nmm.mdi.On("UpsertNamespace", mock.Anything, mock.AnythingOfType("*core.Namespace"), true).Return(nil)
+	err := nm.preInitNamespace(ns)
+	assert.NoError(t, err)
+
+	nmm.mo.On("WaitStop").Return()
+	nmm.mdi.On("GetNamespace", mock.Anything, "default").Return(nil, fmt.Errorf("pop"))
+
+	nm.restartInStandby(ns)
+
+	<-nm.ctx.Done()
+}
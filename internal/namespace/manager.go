@@ -19,7 +19,9 @@ package namespace
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -32,17 +34,24 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/batch"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/cache"
+	"github.com/hyperledger/firefly/internal/chaos"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/database/difactory"
 	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
 	"github.com/hyperledger/firefly/internal/events/eifactory"
 	"github.com/hyperledger/firefly/internal/events/system"
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
+	"github.com/hyperledger/firefly/internal/keymanager/kmfactory"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/internal/sequencer/sqfactory"
+	"github.com/hyperledger/firefly/internal/shareddownload"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
 	"github.com/hyperledger/firefly/internal/spievents"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
@@ -52,6 +61,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 	"github.com/hyperledger/firefly/pkg/events"
 	"github.com/hyperledger/firefly/pkg/identity"
+	"github.com/hyperledger/firefly/pkg/keymanager"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 	"github.com/hyperledger/firefly/pkg/tokens"
 	"github.com/spf13/viper"
@@ -62,11 +72,14 @@ type Manager interface {
 	Start() error
 	WaitStop()
 	Reset(ctx context.Context) error
+	RestartPlugin(ctx context.Context, pluginType, name string) error
+	IsStandby(ns string) bool
 
 	Orchestrator(ctx context.Context, ns string, includeInitializing bool) (orchestrator.Orchestrator, error)
 	MustOrchestrator(ns string) orchestrator.Orchestrator
 	SPIEvents() spievents.Manager
 	GetNamespaces(ctx context.Context, includeInitializing bool) ([]*core.NamespaceWithInitStatus, error)
+	GetReadiness(ctx context.Context) *core.NodeReadiness
 	GetOperationByNamespacedID(ctx context.Context, nsOpID string) (*core.Operation, error)
 	ResolveOperationByNamespacedID(ctx context.Context, nsOpID string, op *core.OperationUpdateDTO) error
 	Authorize(ctx context.Context, authReq *fftypes.AuthReq) error
@@ -81,8 +94,11 @@ type namespace struct {
 	config       orchestrator.Config
 	configHash   *fftypes.Bytes32
 	pluginNames  []string
+	isolated     bool
 	plugins      *orchestrator.Plugins
 	started      bool
+	standby      bool
+	leader       bool
 	initError    string
 }
 
@@ -92,6 +108,7 @@ type namespaceManager struct {
 	ctx                 context.Context
 	cancelCtx           context.CancelFunc
 	nsMux               sync.Mutex
+	reloadMux           sync.Mutex
 	namespaces          map[string]*namespace
 	plugins             map[string]*plugin
 	metricsEnabled      bool
@@ -101,6 +118,7 @@ type namespaceManager struct {
 	tokenBroadcastNames map[string]string
 	watchConfig         func() // indirect from viper.WatchConfig for testing
 	nsStartupRetry      *retry.Retry
+	cluster             clusterConfig
 
 	orchestratorFactory  func(ns *core.Namespace, config orchestrator.Config, plugins *orchestrator.Plugins, metrics metrics.Manager, cacheManager cache.Manager) orchestrator.Orchestrator
 	blockchainFactory    func(ctx context.Context, pluginType string) (blockchain.Plugin, error)
@@ -109,6 +127,7 @@ type namespaceManager struct {
 	sharedstorageFactory func(ctx context.Context, pluginType string) (sharedstorage.Plugin, error)
 	tokensFactory        func(ctx context.Context, pluginType string) (tokens.Plugin, error)
 	identityFactory      func(ctx context.Context, pluginType string) (identity.Plugin, error)
+	keymanagerFactory    func(ctx context.Context, pluginType string) (keymanager.Plugin, error)
 	eventsFactory        func(ctx context.Context, pluginType string) (events.Plugin, error)
 	authFactory          func(ctx context.Context, pluginType string) (auth.Plugin, error)
 }
@@ -122,6 +141,7 @@ const (
 	pluginCategorySharedstorage pluginCategory = "sharedstorage"
 	pluginCategoryTokens        pluginCategory = "tokens"
 	pluginCategoryIdentity      pluginCategory = "identity"
+	pluginCategoryKeyManager    pluginCategory = "keymanager"
 	pluginCategoryEvents        pluginCategory = "events"
 	pluginCategoryAuth          pluginCategory = "auth"
 )
@@ -142,6 +162,7 @@ type plugin struct {
 	sharedstorage sharedstorage.Plugin
 	tokens        tokens.Plugin
 	identity      identity.Plugin
+	keymanager    keymanager.Plugin
 	events        events.Plugin
 	auth          auth.Plugin
 }
@@ -172,6 +193,7 @@ func NewNamespaceManager() Manager {
 		sharedstorageFactory: ssfactory.GetPlugin,
 		tokensFactory:        tifactory.GetPlugin,
 		identityFactory:      iifactory.GetPlugin,
+		keymanagerFactory:    kmfactory.GetPlugin,
 		eventsFactory:        eifactory.GetPlugin,
 		authFactory:          authfactory.GetPlugin,
 		nsStartupRetry: &retry.Retry{
@@ -179,6 +201,7 @@ func NewNamespaceManager() Manager {
 			MaximumDelay: config.GetDuration(coreconfig.NamespacesRetryMaxDelay),
 			Factor:       config.GetFloat64(coreconfig.NamespacesRetryFactor),
 		},
+		cluster: loadClusterConfig(),
 	}
 	return nm
 }
@@ -337,6 +360,13 @@ func (nm *namespaceManager) initAndStartNamespace(ns *namespace) error {
 	}
 	log.L(nm.ctx).Infof("Initialized namespace '%s' multiparty=%s version=%s", ns.Name, strconv.FormatBool(multiparty), version)
 
+	// In a cluster, only the leader goes on to start the namespace
+	if nm.cluster.enabled {
+		if err := nm.waitForLeadership(ns); err != nil {
+			return err
+		}
+	}
+
 	// Check if we need to start up a V1 system namespace as a side effect of having initialized this namespace
 	// Note we do that start synchronous to this namespace starting.
 	if err := nm.startV1NamespaceIfRequired(ns); err != nil {
@@ -477,6 +507,10 @@ func (nm *namespaceManager) loadPlugins(ctx context.Context, rawConfig fftypes.J
 		return nil, err
 	}
 
+	if err := nm.getKeyManagerPlugins(ctx, newPlugins, rawConfig); err != nil {
+		return nil, err
+	}
+
 	if err := nm.getBlockchainPlugins(ctx, newPlugins, rawConfig); err != nil {
 		return nil, err
 	}
@@ -539,6 +573,7 @@ func (nm *namespaceManager) getTokensPlugins(ctx context.Context, plugins map[st
 		if err != nil {
 			return err
 		}
+		pc.tokens = chaos.WrapTokens(ctx, pc.name, pc.tokens)
 	}
 
 	return nil
@@ -562,6 +597,7 @@ func (nm *namespaceManager) getDatabasePlugins(ctx context.Context, plugins map[
 		if err != nil {
 			return err
 		}
+		pc.database = chaos.WrapDatabase(ctx, pc.name, pc.database)
 	}
 
 	return nil
@@ -618,6 +654,7 @@ func (nm *namespaceManager) getDataExchangePlugins(ctx context.Context, plugins
 		if err != nil {
 			return err
 		}
+		pc.dataexchange = chaos.WrapDataExchange(ctx, pc.name, pc.dataexchange)
 	}
 
 	return nil
@@ -644,6 +681,27 @@ func (nm *namespaceManager) getIdentityPlugins(ctx context.Context, plugins map[
 	return nil
 }
 
+func (nm *namespaceManager) getKeyManagerPlugins(ctx context.Context, plugins map[string]*plugin, rawConfig fftypes.JSONObject) (err error) {
+	configSize := keymanagerConfig.ArraySize()
+	rawPluginKeyManagerConfig := rawConfig.GetObject("plugins").GetObjectArray("keymanager")
+	if len(rawPluginKeyManagerConfig) != configSize {
+		log.L(ctx).Errorf("Expected len(%d) for plugins.keymanager: %s", configSize, rawPluginKeyManagerConfig)
+		return i18n.NewError(ctx, coremsgs.MsgConfigArrayVsRawConfigMismatch)
+	}
+	for i := 0; i < configSize; i++ {
+		config := keymanagerConfig.ArrayEntry(i)
+		pc, err := nm.validatePluginConfig(ctx, plugins, pluginCategoryKeyManager, config, rawPluginKeyManagerConfig[i])
+		if err == nil {
+			pc.keymanager, err = nm.keymanagerFactory(ctx, pc.pluginType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (nm *namespaceManager) getBlockchainPlugins(ctx context.Context, plugins map[string]*plugin, rawConfig fftypes.JSONObject) (err error) {
 	blockchainConfigArraySize := blockchainConfig.ArraySize()
 	rawPluginBlockchainsConfig := rawConfig.GetObject("plugins").GetObjectArray("blockchain")
@@ -660,6 +718,7 @@ func (nm *namespaceManager) getBlockchainPlugins(ctx context.Context, plugins ma
 		if err != nil {
 			return err
 		}
+		pc.blockchain = chaos.WrapBlockchain(ctx, pc.name, pc.blockchain)
 	}
 
 	return nil
@@ -701,16 +760,23 @@ func (nm *namespaceManager) initPlugins(pluginsToStart map[string]*plugin) (err
 			if err = p.blockchain.Init(p.ctx, nm.cancelCtx /* allow plugin to stop whole process */, p.config, nm.metrics, nm.cacheManager); err != nil {
 				return err
 			}
+			p.blockchain.SetConnectionListener(nm.newPluginConnectionListener(p))
 		case pluginCategoryDataexchange:
 			if err = p.dataexchange.Init(p.ctx, nm.cancelCtx /* allow plugin to stop whole process */, p.config); err != nil {
 				return err
 			}
+			p.dataexchange.SetConnectionListener(nm.newPluginConnectionListener(p))
 		case pluginCategorySharedstorage:
 			if err = p.sharedstorage.Init(p.ctx, p.config); err != nil {
 				return err
 			}
 		case pluginCategoryTokens:
-			if err = p.tokens.Init(p.ctx, nm.cancelCtx /* allow plugin to stop whole process */, name, p.config); err != nil {
+			if err = p.tokens.Init(p.ctx, nm.cancelCtx /* allow plugin to stop whole process */, name, p.config, nm.metrics); err != nil {
+				return err
+			}
+			p.tokens.SetConnectionListener(nm.newPluginConnectionListener(p))
+		case pluginCategoryKeyManager:
+			if err = p.keymanager.Init(p.ctx, p.config); err != nil {
 				return err
 			}
 		case pluginCategoryEvents:
@@ -723,6 +789,9 @@ func (nm *namespaceManager) initPlugins(pluginsToStart map[string]*plugin) (err
 			}
 		}
 	}
+	if config.GetBool(coreconfig.EventInboundQueueEnabled) {
+		return nm.initEventQueues(pluginsToStart)
+	}
 	return nil
 }
 
@@ -758,9 +827,99 @@ func (nm *namespaceManager) loadNamespaces(ctx context.Context, rawConfig fftype
 	if !foundDefault && size > 0 {
 		return nil, i18n.NewError(ctx, coremsgs.MsgDefaultNamespaceNotFound, defaultName)
 	}
+	if err := nm.validateNSIsolation(ctx, newNS, availablePlugins); err != nil {
+		return nil, err
+	}
 	return newNS, err
 }
 
+// validateNSIsolation checks that no stateful plugin bound to an isolated namespace is shared with another
+// namespace, so that one node can serve multiple chains/tenants without them observing each other's state.
+func (nm *namespaceManager) validateNSIsolation(ctx context.Context, newNS map[string]*namespace, availablePlugins map[string]*plugin) error {
+	// Sort the names so the error reported for a conflict is deterministic
+	nsNames := make([]string, 0, len(newNS))
+	for name := range newNS {
+		nsNames = append(nsNames, name)
+	}
+	sort.Strings(nsNames)
+
+	boundTo := make(map[string][]string)
+	for _, name := range nsNames {
+		for _, pluginName := range newNS[name].pluginNames {
+			boundTo[pluginName] = append(boundTo[pluginName], name)
+		}
+	}
+
+	for _, name := range nsNames {
+		ns := newNS[name]
+		if !ns.isolated {
+			continue
+		}
+		for _, pluginName := range ns.pluginNames {
+			p := availablePlugins[pluginName]
+			switch p.category {
+			case pluginCategoryBlockchain,
+				pluginCategoryDatabase,
+				pluginCategoryDataexchange,
+				pluginCategorySharedstorage,
+				pluginCategoryTokens:
+				for _, other := range boundTo[pluginName] {
+					if other != name {
+						return i18n.NewError(ctx, coremsgs.MsgNamespacePluginNotIsolated, name, p.category, pluginName, other)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func loadValidationPolicies(ctx context.Context, conf config.Section) (policies data.ValidationPolicies, err error) {
+	policy := conf.GetString(coreconfig.NamespaceValidationPolicy)
+	if policy == "" {
+		policy = config.GetString(coreconfig.ValidationPolicy)
+	}
+	if policies.Default, err = fftypes.FFEnumParseString(ctx, "validationpolicy", policy); err != nil {
+		return policies, err
+	}
+	datatypes := conf.GetObject(coreconfig.NamespaceValidationDatatypes)
+	policies.Datatypes = make(map[string]core.ValidationPolicy, len(datatypes))
+	for name := range datatypes {
+		if policies.Datatypes[name], err = fftypes.FFEnumParseString(ctx, "validationpolicy", datatypes.GetString(name)); err != nil {
+			return policies, err
+		}
+	}
+	return policies, nil
+}
+
+func loadPoolTemplates(ctx context.Context, conf config.ArraySection) (map[string]*core.TokenPoolTemplate, error) {
+	templates := make(map[string]*core.TokenPoolTemplate)
+	for i := 0; i < conf.ArraySize(); i++ {
+		entry := conf.ArrayEntry(i)
+		template := &core.TokenPoolTemplate{
+			Name:      entry.GetString(coreconfig.NamespaceAssetPoolTemplateName),
+			Connector: entry.GetString(coreconfig.NamespaceAssetPoolTemplateConnector),
+			Standard:  entry.GetString(coreconfig.NamespaceAssetPoolTemplateStandard),
+			Decimals:  entry.GetInt(coreconfig.NamespaceAssetPoolTemplateDecimals),
+			Config:    entry.GetObject(coreconfig.NamespaceAssetPoolTemplateConfig),
+		}
+		if err := fftypes.ValidateFFNameFieldNoUUID(ctx, template.Name, fmt.Sprintf("%s[%d].name", coreconfig.NamespaceAssetPoolTemplates, i)); err != nil {
+			return nil, err
+		}
+		if templates[template.Name] != nil {
+			return nil, i18n.NewError(ctx, coremsgs.MsgDuplicateTokenPoolTemplate, template.Name)
+		}
+		if tokenType := entry.GetString(coreconfig.NamespaceAssetPoolTemplateType); tokenType != "" {
+			var err error
+			if template.Type, err = fftypes.FFEnumParseString(ctx, "tokentype", tokenType); err != nil {
+				return nil, err
+			}
+		}
+		templates[template.Name] = template
+	}
+	return templates, nil
+}
+
 func (nm *namespaceManager) loadTLSConfig(ctx context.Context, tlsConfigs map[string]*tls.Config, conf config.ArraySection) (err error) {
 	tlsConfigArraySize := conf.ArraySize()
 
@@ -803,11 +962,35 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		keyNormalization = config.GetString(coreconfig.AssetManagerKeyNormalization)
 	}
 
+	downloadPriorities := shareddownload.Priorities{
+		Batch: config.GetInt(coreconfig.DownloadPriorityBatch),
+		Blob:  config.GetInt(coreconfig.DownloadPriorityBlob),
+	}
+	if conf.Get(coreconfig.NamespaceDownloadPriorityBatch) != nil {
+		downloadPriorities.Batch = conf.GetInt(coreconfig.NamespaceDownloadPriorityBatch)
+	}
+	if conf.Get(coreconfig.NamespaceDownloadPriorityBlob) != nil {
+		downloadPriorities.Blob = conf.GetInt(coreconfig.NamespaceDownloadPriorityBlob)
+	}
+
+	batchPipeline := batch.PipelineOptions{
+		Workers:    config.GetInt(coreconfig.BatchManagerWorkers),
+		QueueDepth: config.GetInt(coreconfig.BatchManagerQueueDepth),
+	}
+	if conf.Get(coreconfig.NamespaceBatchWorkers) != nil {
+		batchPipeline.Workers = conf.GetInt(coreconfig.NamespaceBatchWorkers)
+	}
+	if conf.Get(coreconfig.NamespaceBatchQueueDepth) != nil {
+		batchPipeline.QueueDepth = conf.GetInt(coreconfig.NamespaceBatchQueueDepth)
+	}
+
 	multipartyConf := conf.SubSection(coreconfig.NamespaceMultiparty)
 	// If any multiparty org information is configured (here or at the root), assume multiparty mode by default
 	orgName := multipartyConf.GetString(coreconfig.NamespaceMultipartyOrgName)
 	orgKey := multipartyConf.GetString(coreconfig.NamespaceMultipartyOrgKey)
 	orgDesc := multipartyConf.GetString(coreconfig.NamespaceMultipartyOrgDescription)
+	orgCert := multipartyConf.GetString(coreconfig.NamespaceMultipartyOrgCertificate)
+	orgCertSig := multipartyConf.GetString(coreconfig.NamespaceMultipartyOrgCertificateSignature)
 	nodeName := multipartyConf.GetString(coreconfig.NamespaceMultipartyNodeName)
 	nodeDesc := multipartyConf.GetString(coreconfig.NamespaceMultipartyNodeDescription)
 	deprecatedOrgName := config.GetString(coreconfig.OrgName)
@@ -853,17 +1036,26 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		}
 	}
 
-	// If no plugins are listed under this namespace, use all defined plugins by default
+	anchorPluginName := ""
+	if multipartyEnabled.(bool) {
+		anchorPluginName = multipartyConf.GetString(coreconfig.NamespaceMultipartyAnchorPlugin)
+	}
+
+	// If no plugins are listed under this namespace, use all defined plugins by default (other than the anchor chain)
 	pluginsRaw := conf.Get(coreconfig.NamespacePlugins)
 	pluginNames := conf.GetStringSlice(coreconfig.NamespacePlugins)
 	if pluginsRaw == nil {
 		for pluginName := range nm.plugins {
+			if pluginName == anchorPluginName {
+				continue
+			}
 			p := availablePlugins[pluginName]
 			switch p.category {
 			case pluginCategoryBlockchain,
 				pluginCategoryDatabase,
 				pluginCategoryDataexchange,
 				pluginCategoryIdentity,
+				pluginCategoryKeyManager,
 				pluginCategorySharedstorage,
 				pluginCategoryTokens,
 				pluginCategoryAuth:
@@ -881,13 +1073,41 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		return nil, err
 	}
 
+	validation, err := loadValidationPolicies(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	canonicalization := conf.GetString(coreconfig.NamespaceHashCanonicalization)
+	if canonicalization == "" {
+		canonicalization = config.GetString(coreconfig.HashCanonicalization)
+	}
+	dataCanonicalization, err := core.ParseDataCanonicalization(ctx, canonicalization)
+	if err != nil {
+		return nil, err
+	}
+
+	poolTemplates, err := loadPoolTemplates(ctx, conf.SubArray(coreconfig.NamespaceAssetPoolTemplates))
+	if err != nil {
+		return nil, err
+	}
+
 	config := orchestrator.Config{
 		DefaultKey:                  conf.GetString(coreconfig.NamespaceDefaultKey),
 		TokenBroadcastNames:         nm.tokenBroadcastNames,
 		KeyNormalization:            keyNormalization,
+		PoolTemplates:               poolTemplates,
+		Sandbox:                     conf.GetBool(coreconfig.NamespaceSandbox),
+		DownloadPriorities:          downloadPriorities,
+		BatchPipeline:               batchPipeline,
+		Validation:                  validation,
+		DataCanonicalization:        dataCanonicalization,
 		MaxHistoricalEventScanLimit: config.GetInt(coreconfig.SubscriptionMaxHistoricalEventScanLength),
 	}
 	if multipartyEnabled.(bool) {
+		if config.Sandbox {
+			return nil, i18n.NewError(ctx, coremsgs.MsgSandboxNamespaceMultiparty, name)
+		}
 		contractsConf := multipartyConf.SubArray(coreconfig.NamespaceMultipartyContract)
 		contractConfArraySize := contractsConf.ArraySize()
 		contracts := make([]blockchain.MultipartyContract, contractConfArraySize)
@@ -908,9 +1128,36 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		config.Multiparty.Org.Name = orgName
 		config.Multiparty.Org.Key = orgKey
 		config.Multiparty.Org.Description = orgDesc
+		config.Multiparty.Org.Certificate = orgCert
+		config.Multiparty.Org.CertificateSignature = orgCertSig
 		config.Multiparty.Contracts = contracts
 		config.Multiparty.Node.Name = nodeName
 		config.Multiparty.Node.Description = nodeDesc
+
+		config.Sequencer.Type = multipartyConf.GetString(coreconfig.NamespaceMultipartySequencerType)
+		if err := sqfactory.CheckType(ctx, config.Sequencer.Type); err != nil {
+			return nil, err
+		}
+		config.Sequencer.PollInterval = multipartyConf.GetDuration(coreconfig.NamespaceMultipartySequencerPollInterval)
+		config.Sequencer.BatchSize = multipartyConf.GetInt(coreconfig.NamespaceMultipartySequencerBatchSize)
+
+		if trustRoots := multipartyConf.GetString(coreconfig.NamespaceMultipartyOrgVerificationTrustRoots); trustRoots != "" {
+			config.Multiparty.OrgTrustRoots = x509.NewCertPool()
+			if !config.Multiparty.OrgTrustRoots.AppendCertsFromPEM([]byte(trustRoots)) {
+				return nil, i18n.NewError(ctx, coremsgs.MsgInvalidOrgTrustRoots, name)
+			}
+		}
+		config.Multiparty.RequireSignedClaims = multipartyConf.GetBool(coreconfig.NamespaceMultipartyIdentityClaimsRequireSignature)
+
+		if anchorPluginName != "" {
+			config.Multiparty.Anchor.Key = multipartyConf.GetString(coreconfig.NamespaceMultipartyAnchorKey)
+			config.Multiparty.Anchor.Location = fftypes.JSONAnyPtr(multipartyConf.GetObject(coreconfig.NamespaceMultipartyAnchorLocation).String())
+			config.Multiparty.Anchor.Mode = multipartyConf.GetString(coreconfig.NamespaceMultipartyAnchorMode)
+			if config.Multiparty.Anchor.Mode != multiparty.AnchorModeBatch && config.Multiparty.Anchor.Mode != multiparty.AnchorModeDigest {
+				return nil, i18n.NewError(ctx, coremsgs.MsgInvalidAnchorMode, config.Multiparty.Anchor.Mode, name)
+			}
+			config.Multiparty.Anchor.DigestInterval = multipartyConf.GetDuration(coreconfig.NamespaceMultipartyAnchorDigestInterval)
+		}
 	}
 
 	ns = &namespace{
@@ -924,12 +1171,18 @@ func (nm *namespaceManager) loadNamespace(ctx context.Context, name string, inde
 		config:      config,
 		configHash:  nm.configHash(rawNSConfig),
 		pluginNames: pluginNames,
+		isolated:    conf.GetBool(coreconfig.NamespaceIsolated),
 	}
 	log.L(ctx).Tracef("Namespace %s config: %s", name, rawNSConfig.String())
 
 	if ns.plugins, err = nm.validateNSPlugins(ctx, ns, availablePlugins); err != nil {
 		return nil, err
 	}
+	if anchorPluginName != "" {
+		if err = nm.validateAnchorPlugin(ctx, ns, anchorPluginName, availablePlugins); err != nil {
+			return nil, err
+		}
+	}
 
 	if ns.config.Multiparty.Enabled {
 		err = nm.validateMultiPartyConfig(ctx, ns)
@@ -1003,6 +1256,14 @@ func (nm *namespaceManager) validateNSPlugins(ctx context.Context, ns *namespace
 				Name:   pluginName,
 				Plugin: p.identity,
 			}
+		case pluginCategoryKeyManager:
+			if result.KeyManager.Plugin != nil {
+				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "keymanager")
+			}
+			result.KeyManager = orchestrator.KeyManagerPlugin{
+				Name:   pluginName,
+				Plugin: p.keymanager,
+			}
 		case pluginCategoryAuth:
 			if result.Auth.Plugin != nil {
 				return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceMultiplePluginType, ns.Name, "auth")
@@ -1016,6 +1277,20 @@ func (nm *namespaceManager) validateNSPlugins(ctx context.Context, ns *namespace
 	return &result, nil
 }
 
+// validateAnchorPlugin resolves the second blockchain a multiparty namespace pins its batches to,
+// which must be a blockchain plugin distinct from the one used by the namespace itself
+func (nm *namespaceManager) validateAnchorPlugin(ctx context.Context, ns *namespace, pluginName string, availablePlugins map[string]*plugin) error {
+	p := availablePlugins[pluginName]
+	if p == nil || p.category != pluginCategoryBlockchain || pluginName == ns.plugins.Blockchain.Name {
+		return i18n.NewError(ctx, coremsgs.MsgInvalidAnchorPlugin, pluginName, ns.Name)
+	}
+	ns.plugins.Anchor = orchestrator.BlockchainPlugin{
+		Name:   pluginName,
+		Plugin: p.blockchain,
+	}
+	return nil
+}
+
 func (nm *namespaceManager) validateMultiPartyConfig(ctx context.Context, ns *namespace) error {
 
 	if ns.plugins.Database.Plugin == nil ||
@@ -1046,7 +1321,8 @@ func (nm *namespaceManager) Orchestrator(ctx context.Context, ns string, include
 	defer nm.nsMux.Unlock()
 	// Only return started namespaces from this call
 	if namespace, ok := nm.namespaces[ns]; ok && namespace != nil {
-		if !includeInitializing && !namespace.started {
+		// Standby namespaces are initialized, so can serve reads
+		if !includeInitializing && !namespace.started && !namespace.standby {
 			return nil, i18n.NewError(ctx, coremsgs.MsgNamespaceInitializing, ns)
 		}
 		return namespace.orchestrator, nil
@@ -1068,10 +1344,10 @@ func (nm *namespaceManager) GetNamespaces(ctx context.Context, includeInitializi
 	defer nm.nsMux.Unlock()
 	results := make([]*core.NamespaceWithInitStatus, 0, len(nm.namespaces))
 	for _, ns := range nm.namespaces {
-		if includeInitializing || ns.started {
+		if includeInitializing || ns.started || ns.standby {
 			results = append(results, &core.NamespaceWithInitStatus{
 				Namespace:           &ns.Namespace,
-				Initializing:        !ns.started,
+				Initializing:        !ns.started && !ns.standby,
 				InitializationError: ns.initError,
 			})
 		}
@@ -1079,6 +1355,43 @@ func (nm *namespaceManager) GetNamespaces(ctx context.Context, includeInitializi
 	return results, nil
 }
 
+// GetReadiness checks connectivity to each of the database, blockchain, data exchange and tokens
+// plugins. The checks are performed outside of the namespace lock, as they may block on the network.
+func (nm *namespaceManager) GetReadiness(ctx context.Context) *core.NodeReadiness {
+	nm.nsMux.Lock()
+	plugins := make([]*plugin, 0, len(nm.plugins))
+	for _, p := range nm.plugins {
+		plugins = append(plugins, p)
+	}
+	nm.nsMux.Unlock()
+	sort.Slice(plugins, func(i, j int) bool {
+		if plugins[i].category != plugins[j].category {
+			return plugins[i].category < plugins[j].category
+		}
+		return plugins[i].name < plugins[j].name
+	})
+
+	readiness := &core.NodeReadiness{Ready: true, Components: []*core.ComponentReadiness{}}
+	for _, p := range plugins {
+		var err error
+		switch p.category {
+		case pluginCategoryDatabase:
+			err = p.database.CheckReady(ctx)
+		case pluginCategoryBlockchain:
+			err = p.blockchain.CheckReady(ctx)
+		case pluginCategoryDataexchange:
+			err = p.dataexchange.CheckReady(ctx)
+		case pluginCategoryTokens:
+			err = p.tokens.CheckReady(ctx)
+		default:
+			// Other plugins are either embedded, or only contacted on demand
+			continue
+		}
+		readiness.AddComponent(p.name, string(p.category), p.pluginType, err)
+	}
+	return readiness
+}
+
 func (nm *namespaceManager) GetOperationByNamespacedID(ctx context.Context, nsOpID string) (*core.Operation, error) {
 	ns, u, err := core.ParseNamespacedOpID(ctx, nsOpID)
 	if err != nil {
@@ -39,15 +39,20 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/batch"
 	"github.com/hyperledger/firefly/internal/blockchain/bifactory"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/data"
 	"github.com/hyperledger/firefly/internal/database/difactory"
 	"github.com/hyperledger/firefly/internal/dataexchange/dxfactory"
 	"github.com/hyperledger/firefly/internal/events/eifactory"
 	"github.com/hyperledger/firefly/internal/identity/iifactory"
+	"github.com/hyperledger/firefly/internal/keymanager/kmfactory"
 	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/multiparty"
 	"github.com/hyperledger/firefly/internal/orchestrator"
+	"github.com/hyperledger/firefly/internal/shareddownload"
 	"github.com/hyperledger/firefly/internal/sharedstorage/ssfactory"
 	"github.com/hyperledger/firefly/internal/tokens/tifactory"
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
@@ -56,6 +61,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
 	"github.com/hyperledger/firefly/mocks/eventsmocks"
 	"github.com/hyperledger/firefly/mocks/identitymocks"
+	"github.com/hyperledger/firefly/mocks/keymanagermocks"
 	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/orchestratormocks"
@@ -68,6 +74,7 @@ import (
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 	"github.com/hyperledger/firefly/pkg/events"
 	"github.com/hyperledger/firefly/pkg/identity"
+	"github.com/hyperledger/firefly/pkg/keymanager"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 	"github.com/hyperledger/firefly/pkg/tokens"
 	"github.com/spf13/viper"
@@ -125,6 +132,9 @@ plugins:
   identity:
     - name: tbd
       type: tbd
+  keymanager:
+    - name: signer
+      type: ethsigner
 `
 
 type nmMocks struct {
@@ -140,6 +150,7 @@ type nmMocks struct {
 	mei []*eventsmocks.Plugin
 	mai *authmocks.Plugin
 	mii *identitymocks.Plugin
+	mkm *keymanagermocks.Plugin
 	mo  *orchestratormocks.Orchestrator
 }
 
@@ -155,6 +166,7 @@ func (nmm *nmMocks) cleanup(t *testing.T) {
 	nmm.mti[1].AssertExpectations(t)
 	nmm.mai.AssertExpectations(t)
 	nmm.mii.AssertExpectations(t)
+	nmm.mkm.AssertExpectations(t)
 	nmm.mei[0].AssertExpectations(t)
 	nmm.mei[1].AssertExpectations(t)
 	nmm.mei[2].AssertExpectations(t)
@@ -180,6 +192,7 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 		mei: []*eventsmocks.Plugin{{}, {}, {}},
 		mai: &authmocks.Plugin{},
 		mii: &identitymocks.Plugin{},
+		mkm: &keymanagermocks.Plugin{},
 		mo:  &orchestratormocks.Orchestrator{},
 	}
 	factoryMocks(&nmm.mbi.Mock, "ethereum")
@@ -192,6 +205,10 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 	factoryMocks(&nmm.mei[1].Mock, "websockets")
 	factoryMocks(&nmm.mei[2].Mock, "webhooks")
 	factoryMocks(&nmm.mai.Mock, "basicauth")
+	factoryMocks(&nmm.mkm.Mock, "ethsigner")
+	for _, m := range []*mock.Mock{&nmm.mbi.Mock, &nmm.mdx.Mock, &nmm.mti[0].Mock, &nmm.mti[1].Mock} {
+		m.On("SetConnectionListener", mock.Anything).Maybe()
+	}
 
 	nm.orchestratorFactory = func(ns *core.Namespace, config orchestrator.Config, plugins *orchestrator.Plugins, metrics metrics.Manager, cacheManager cache.Manager) orchestrator.Orchestrator {
 		return nmm.mo
@@ -217,6 +234,9 @@ func mockPluginFactories(inm Manager) (nmm *nmMocks) {
 	nm.identityFactory = func(ctx context.Context, pluginType string) (identity.Plugin, error) {
 		return nmm.mii, nil
 	}
+	nm.keymanagerFactory = func(ctx context.Context, pluginType string) (keymanager.Plugin, error) {
+		return nmm.mkm, nil
+	}
 	nm.eventsFactory = func(ctx context.Context, pluginType string) (events.Plugin, error) {
 		switch pluginType {
 		case "system":
@@ -275,12 +295,13 @@ func newTestNamespaceManager(t *testing.T, initConfig bool) (*namespaceManager,
 		nmm.mbi.On("Init", mock.Anything, mock.Anything, mock.Anything, nmm.mmi, mock.Anything).Return(nil).Once()
 		nmm.mdx.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 		nmm.mps.On("Init", mock.Anything, mock.Anything).Return(nil).Once()
-		nmm.mti[0].On("Init", mock.Anything, mock.Anything, "erc721", mock.Anything).Return(nil).Once()
-		nmm.mti[1].On("Init", mock.Anything, mock.Anything, "erc1155", mock.Anything).Return(nil).Once()
+		nmm.mti[0].On("Init", mock.Anything, mock.Anything, "erc721", mock.Anything, mock.Anything).Return(nil).Once()
+		nmm.mti[1].On("Init", mock.Anything, mock.Anything, "erc1155", mock.Anything, mock.Anything).Return(nil).Once()
 		nmm.mei[0].On("Init", mock.Anything, mock.Anything).Return(nil)
 		nmm.mei[1].On("Init", mock.Anything, mock.Anything).Return(nil)
 		nmm.mei[2].On("Init", mock.Anything, mock.Anything).Return(nil)
 		nmm.mai.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		nmm.mkm.On("Init", mock.Anything, mock.Anything).Return(nil).Once()
 
 		err = nmm.nm.Init(nmm.nm.ctx, nmm.nm.cancelCtx, nmm.nm.reset, nmm.nm.reloadConfig)
 		assert.NoError(t, err)
@@ -387,7 +408,7 @@ func TestInitTokensFail(t *testing.T) {
 	nm, nmm, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
-	nmm.mti[0].On("Init", mock.Anything, mock.Anything, "erc721", mock.Anything).Return(fmt.Errorf("pop"))
+	nmm.mti[0].On("Init", mock.Anything, mock.Anything, "erc721", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
 
 	err := nm.initPlugins(map[string]*plugin{
 		"erc721": nm.plugins["erc721"],
@@ -420,6 +441,19 @@ func TestInitAuthFail(t *testing.T) {
 	assert.EqualError(t, err, "pop")
 }
 
+func TestInitKeyManagerFail(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	mkm := &keymanagermocks.Plugin{}
+	mkm.On("Init", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	nm.plugins["signer"].keymanager = mkm
+	err := nm.initPlugins(map[string]*plugin{
+		"signer": nm.plugins["signer"],
+	})
+	assert.EqualError(t, err, "pop")
+}
+
 func TestInitOrchestratorFail(t *testing.T) {
 	nm, nmm, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -740,6 +774,22 @@ func TestDatabasePlugin(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDatabasePluginChaos(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	config.Set(coreconfig.ChaosEnabled, true)
+	config.Set(coreconfig.ChaosDatabaseErrorRate, 0.5)
+	difactory.InitConfig(databaseConfig)
+	config.Set("plugins.database", []fftypes.JSONObject{{}})
+	databaseConfig.AddKnownKey(coreconfig.PluginConfigName, "flapflip")
+	databaseConfig.AddKnownKey(coreconfig.PluginConfigType, "postgres")
+	plugins := make(map[string]*plugin)
+	err := nm.getDatabasePlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, "postgres", plugins["flapflip"].database.Name())
+	assert.NotEqual(t, "*postgres.Postgres", fmt.Sprintf("%T", plugins["flapflip"].database))
+}
+
 func TestDatabasePluginBadType(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, false)
 	defer cleanup()
@@ -802,6 +852,56 @@ func TestIdentityPluginNoType(t *testing.T) {
 	assert.Regexp(t, "FF10386.*type", err)
 }
 
+func TestKeyManagerPluginBadName(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	kmfactory.InitConfig(keymanagerConfig)
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigName, "wrong//")
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigType, "ethsigner")
+	config.Set("plugins.keymanager", []fftypes.JSONObject{{}})
+	err := nm.getKeyManagerPlugins(context.Background(), make(map[string]*plugin), nm.dumpRootConfig())
+	assert.Regexp(t, "FF00140.*name", err)
+}
+
+func TestKeyManagerPluginBadType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	kmfactory.InitConfig(keymanagerConfig)
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigName, "flapflip")
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigType, "wrong")
+	config.Set("plugins.keymanager", []fftypes.JSONObject{{}})
+	nm.keymanagerFactory = func(ctx context.Context, pluginType string) (keymanager.Plugin, error) {
+		return nil, fmt.Errorf("pop")
+	}
+	err := nm.getKeyManagerPlugins(context.Background(), make(map[string]*plugin), nm.dumpRootConfig())
+	assert.Regexp(t, "pop", err)
+}
+
+func TestKeyManagerPluginNoType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	kmfactory.InitConfig(keymanagerConfig)
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigName, "flapflip")
+	config.Set("plugins.keymanager", []fftypes.JSONObject{{}})
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	err := nm.Init(ctx, cancelCtx, nm.reset, nm.reloadConfig)
+	assert.Regexp(t, "FF10386.*type", err)
+}
+
+func TestKeyManagerPlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, false)
+	defer cleanup()
+	kmfactory.InitConfig(keymanagerConfig)
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigName, "flapflip")
+	keymanagerConfig.AddKnownKey(coreconfig.PluginConfigType, "ethsigner")
+	config.Set("plugins.keymanager", []fftypes.JSONObject{{}})
+	plugins := make(map[string]*plugin)
+	err := nm.getKeyManagerPlugins(context.Background(), plugins, nm.dumpRootConfig())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(plugins))
+}
+
 func TestIdentityPlugin(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, false)
 	defer cleanup()
@@ -1132,6 +1232,8 @@ func TestRawConfigCorrelation(t *testing.T) {
 	assert.Regexp(t, "FF10439", err)
 	err = nm.getIdentityPlugins(nm.ctx, nm.plugins, fftypes.JSONObject{})
 	assert.Regexp(t, "FF10439", err)
+	err = nm.getKeyManagerPlugins(nm.ctx, nm.plugins, fftypes.JSONObject{})
+	assert.Regexp(t, "FF10439", err)
 	err = nm.getAuthPlugin(nm.ctx, nm.plugins, fftypes.JSONObject{})
 	assert.Regexp(t, "FF10439", err)
 }
@@ -1315,207 +1417,151 @@ func TestLoadNamespacesUseDefaults(t *testing.T) {
 	assert.Equal(t, "oldest", newNS["ns1"].config.Multiparty.Contracts[0].FirstEvent)
 }
 
-func TestLoadTLSConfigsBadTLS(t *testing.T) {
+func TestLoadNamespacesDownloadPriorities(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
 	coreconfig.Reset()
 	viper.SetConfigType("yaml")
 	err := viper.ReadConfig(strings.NewReader(`
-namespaces:
-  default: ns1
-  predefined:
-  - name: ns1
-    tlsConfigs:
-    - name: myconfig
-      tls:
-        enabled: true
-        caFile: my-ca
-        certFile: my-cert
-        keyFile: my-key
+  download:
+    priority:
+      blob: 3
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+    - name: ns2
+      plugins: [postgres]
+      download:
+        priority:
+          batch: 1
+          blob: 5
   `))
 	assert.NoError(t, err)
 
-	// RawConfig to Section!
-	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
-	tlsConfigs := make(map[string]*tls.Config)
-	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
-	assert.Regexp(t, "FF00153", err)
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	assert.Equal(t, shareddownload.Priorities{Batch: 10, Blob: 3}, newNS["ns1"].config.DownloadPriorities)
+	assert.Equal(t, shareddownload.Priorities{Batch: 1, Blob: 5}, newNS["ns2"].config.DownloadPriorities)
 }
 
-func TestLoadTLSConfigsNotEnabled(t *testing.T) {
+func TestLoadNamespacesBatchPipeline(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
 	coreconfig.Reset()
 	viper.SetConfigType("yaml")
 	err := viper.ReadConfig(strings.NewReader(`
-namespaces:
-  default: ns1
-  predefined:
-  - name: ns1
-    tlsConfigs:
-    - name: myconfig
-      tls:
-        enabled: false
-        caFile: my-ca
-        certFile: my-cert
-        keyFile: my-key
+  batch:
+    manager:
+      workers: 4
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+    - name: ns2
+      plugins: [postgres]
+      batch:
+        workers: 1
+        queueDepth: 50
   `))
 	assert.NoError(t, err)
 
-	// RawConfig to Section!
-	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
-	tlsConfigs := make(map[string]*tls.Config)
-	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
 	assert.NoError(t, err)
-	assert.Nil(t, tlsConfigs["myconfig"])
-}
-
-func generateTestCertificates() (*os.File, *os.File, func()) {
-	// Create an X509 certificate pair
-	privatekey, _ := rsa.GenerateKey(rand.Reader, 2048)
-	publickey := &privatekey.PublicKey
-	var privateKeyBytes []byte = x509.MarshalPKCS1PrivateKey(privatekey)
-	privateKeyFile, _ := os.CreateTemp("", "key.pem")
-	privateKeyBlock := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: privateKeyBytes}
-	pem.Encode(privateKeyFile, privateKeyBlock)
-	serialNumber, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
-	x509Template := &x509.Certificate{
-		SerialNumber: serialNumber,
-		Subject: pkix.Name{
-			Organization: []string{"Unit Tests"},
-		},
-		NotBefore:             time.Now(),
-		NotAfter:              time.Now().Add(1000 * time.Second),
-		KeyUsage:              x509.KeyUsageDigitalSignature,
-		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
-		BasicConstraintsValid: true,
-		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
-	}
-	derBytes, _ := x509.CreateCertificate(rand.Reader, x509Template, x509Template, publickey, privatekey)
-	publicKeyFile, _ := os.CreateTemp("", "cert.pem")
-	pem.Encode(publicKeyFile, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
-
-	return publicKeyFile, privateKeyFile, func() {
-		os.Remove(publicKeyFile.Name())
-		os.Remove(privateKeyFile.Name())
-	}
+	assert.Equal(t, batch.PipelineOptions{Workers: 4, QueueDepth: 0}, newNS["ns1"].config.BatchPipeline)
+	assert.Equal(t, batch.PipelineOptions{Workers: 1, QueueDepth: 50}, newNS["ns2"].config.BatchPipeline)
 }
 
-func TestLoadTLSConfigs(t *testing.T) {
+func TestLoadNamespacesValidationPolicies(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
-	publicKeyFile, privateKeyFile, cleanCertificates := generateTestCertificates()
-	defer cleanCertificates()
-
-	caCert, err := os.ReadFile(publicKeyFile.Name())
-	if err != nil {
-		log.Fatal(err)
-	}
-	caCertPool := x509.NewCertPool()
-	caCertPool.AppendCertsFromPEM(caCert)
-
-	cert, err := tls.LoadX509KeyPair(publicKeyFile.Name(), privateKeyFile.Name())
-	assert.NoError(t, err)
-	expectedTLSConfig := &tls.Config{
-		RootCAs:      caCertPool,
-		Certificates: []tls.Certificate{cert},
-	}
-
 	coreconfig.Reset()
 	viper.SetConfigType("yaml")
-	err = viper.ReadConfig(strings.NewReader(fmt.Sprintf(`
-namespaces:
-  default: ns1
-  predefined:
-  - name: ns1
-    tlsConfigs:
-    - name: myconfig
-      tls:
-        enabled: true
-        caFile: %s
-        certFile: %s
-        keyFile: %s 
-  `, publicKeyFile.Name(), publicKeyFile.Name(), privateKeyFile.Name())))
+	err := viper.ReadConfig(strings.NewReader(`
+  validation:
+    policy: warn
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+    - name: ns2
+      plugins: [postgres]
+      validation:
+        policy: quarantine
+        datatypes:
+          orders: enforce
+  `))
 	assert.NoError(t, err)
 
-	// RawConfig to Section!
-	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
-	tlsConfigs := make(map[string]*tls.Config)
-	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
 	assert.NoError(t, err)
-	assert.NotNil(t, tlsConfigs["myconfig"])
-	assert.True(t, tlsConfigs["myconfig"].RootCAs.Equal(expectedTLSConfig.RootCAs))
-	assert.Equal(t, tlsConfigs["myconfig"].Certificates, expectedTLSConfig.Certificates)
+	assert.Equal(t, data.ValidationPolicies{
+		Default:   core.ValidationPolicyWarn,
+		Datatypes: map[string]core.ValidationPolicy{},
+	}, newNS["ns1"].config.Validation)
+	assert.Equal(t, data.ValidationPolicies{
+		Default: core.ValidationPolicyQuarantine,
+		Datatypes: map[string]core.ValidationPolicy{
+			"orders": core.ValidationPolicyEnforce,
+		},
+	}, newNS["ns2"].config.Validation)
 }
 
-func TestLoadTLSConfigsDuplicateConfigs(t *testing.T) {
+func TestLoadNamespacesHashCanonicalization(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
-	publicKeyFile, privateKeyFile, cleanCertificates := generateTestCertificates()
-	defer cleanCertificates()
-
 	coreconfig.Reset()
 	viper.SetConfigType("yaml")
-	err := viper.ReadConfig(strings.NewReader(fmt.Sprintf(`
-namespaces:
-  default: ns1
-  predefined:
-  - name: ns1
-    tlsConfigs:
-    - name: myconfig
-      tls:
-        enabled: true
-        caFile: %s
-        certFile: %s
-        keyFile: %s 
-    - name: myconfig
-      tls:
-        enabled: true
-        caFile: %s
-        certFile: %s
-        keyFile: %s 
-  `, publicKeyFile.Name(), publicKeyFile.Name(), privateKeyFile.Name(),
-		publicKeyFile.Name(), publicKeyFile.Name(), privateKeyFile.Name())))
+	err := viper.ReadConfig(strings.NewReader(`
+  hash:
+    canonicalization: jcs
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+    - name: ns2
+      plugins: [postgres]
+      hash:
+        canonicalization: none
+  `))
 	assert.NoError(t, err)
 
-	// RawConfig to Section!
-	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
-	tlsConfigs := make(map[string]*tls.Config)
-	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
-	assert.Regexp(t, "FF10454", err)
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	assert.Equal(t, core.DataCanonicalizationJCS, newNS["ns1"].config.DataCanonicalization)
+	assert.Empty(t, newNS["ns2"].config.DataCanonicalization)
 }
 
-func TestLoadNamespacesWithErrorTLSConfigs(t *testing.T) {
+func TestLoadNamespacesHashCanonicalizationInvalid(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
 	coreconfig.Reset()
 	viper.SetConfigType("yaml")
 	err := viper.ReadConfig(strings.NewReader(`
-namespaces:
-  default: ns1
-  predefined:
-  - name: ns1
-    tlsConfigs:
-    - name: myconfig
-      tls:
-        enabled: true
-        caFile: my-ca
-        certFile: my-cert
-        keyFile: my-key
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+      hash:
+        canonicalization: wrong
   `))
 	assert.NoError(t, err)
 
-	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-
-	assert.Regexp(t, "FF00153", err)
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF00172.*wrong", err)
 }
 
-func TestLoadNamespacesNonMultipartyNoDatabase(t *testing.T) {
+func TestLoadNamespacesPoolTemplates(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1526,16 +1572,38 @@ func TestLoadNamespacesNonMultipartyNoDatabase(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: []
+      plugins: [postgres]
+      asset:
+        manager:
+          poolTemplates:
+          - name: stablecoin
+            connector: erc20_erc721
+            type: fungible
+            standard: ERC20
+            decimals: 6
+            config:
+              factory: "0x1234"
+          - name: collectible
   `))
 	assert.NoError(t, err)
 
-	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10392", err)
-
-}
-
-func TestLoadNamespacesMultipartyUnknownPlugin(t *testing.T) {
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	templates := newNS["ns1"].config.PoolTemplates
+	assert.Len(t, templates, 2)
+	assert.Equal(t, &core.TokenPoolTemplate{
+		Name:      "stablecoin",
+		Connector: "erc20_erc721",
+		Type:      core.TokenTypeFungible,
+		Standard:  "ERC20",
+		Decimals:  6,
+		Config:    fftypes.JSONObject{"factory": "0x1234"},
+	}, templates["stablecoin"])
+	assert.Equal(t, "collectible", templates["collectible"].Name)
+	assert.Empty(t, templates["collectible"].Type)
+}
+
+func TestLoadNamespacesPoolTemplateBadName(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1546,17 +1614,491 @@ func TestLoadNamespacesMultipartyUnknownPlugin(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [basicauth, bad]
-      multiparty:
-        enabled: true
+      plugins: [postgres]
+      asset:
+        manager:
+          poolTemplates:
+          - connector: erc20_erc721
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF00140.*poolTemplates", err)
+}
+
+func TestLoadNamespacesPoolTemplateDuplicate(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+      asset:
+        manager:
+          poolTemplates:
+          - name: stablecoin
+          - name: stablecoin
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10551.*stablecoin", err)
+}
+
+func TestLoadNamespacesPoolTemplateBadType(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+      asset:
+        manager:
+          poolTemplates:
+          - name: stablecoin
+            type: wrong
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF00172.*wrong", err)
+}
+
+func TestLoadNamespacesValidationPolicyInvalid(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+      validation:
+        policy: wrong
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF00172.*wrong", err)
+}
+
+func TestLoadNamespacesValidationDatatypePolicyInvalid(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+      validation:
+        datatypes:
+          orders: wrong
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF00172.*wrong", err)
+}
+
+func TestLoadNamespacesIsolated(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      isolated: true
+      plugins: [postgres, erc721, basicauth]
+    - name: ns2
+      plugins: [sqlite3, erc1155, basicauth]
+  `))
+	assert.NoError(t, err)
+
+	availablePlugins := make(map[string]*plugin)
+	for name, p := range nm.plugins {
+		availablePlugins[name] = p
+	}
+	availablePlugins["sqlite3"] = &plugin{
+		name:     "sqlite3",
+		category: pluginCategoryDatabase,
+		database: nmm.mdi,
+	}
+
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), availablePlugins)
+	assert.NoError(t, err)
+	assert.True(t, newNS["ns1"].isolated)
+	assert.False(t, newNS["ns2"].isolated)
+}
+
+func TestLoadNamespacesIsolatedSharedPlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres, erc721]
+    - name: ns2
+      isolated: true
+      plugins: [postgres, erc1155]
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10518.*ns2.*database.*postgres.*ns1", err)
+}
+
+func TestLoadTLSConfigsBadTLS(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    tlsConfigs:
+    - name: myconfig
+      tls:
+        enabled: true
+        caFile: my-ca
+        certFile: my-cert
+        keyFile: my-key
+  `))
+	assert.NoError(t, err)
+
+	// RawConfig to Section!
+	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
+	tlsConfigs := make(map[string]*tls.Config)
+	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestLoadTLSConfigsNotEnabled(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    tlsConfigs:
+    - name: myconfig
+      tls:
+        enabled: false
+        caFile: my-ca
+        certFile: my-cert
+        keyFile: my-key
+  `))
+	assert.NoError(t, err)
+
+	// RawConfig to Section!
+	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
+	tlsConfigs := make(map[string]*tls.Config)
+	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfigs["myconfig"])
+}
+
+func generateTestCertificates() (*os.File, *os.File, func()) {
+	// Create an X509 certificate pair
+	privatekey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	publickey := &privatekey.PublicKey
+	var privateKeyBytes []byte = x509.MarshalPKCS1PrivateKey(privatekey)
+	privateKeyFile, _ := os.CreateTemp("", "key.pem")
+	privateKeyBlock := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: privateKeyBytes}
+	pem.Encode(privateKeyFile, privateKeyBlock)
+	serialNumber, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	x509Template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Unit Tests"},
+		},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(1000 * time.Second),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	derBytes, _ := x509.CreateCertificate(rand.Reader, x509Template, x509Template, publickey, privatekey)
+	publicKeyFile, _ := os.CreateTemp("", "cert.pem")
+	pem.Encode(publicKeyFile, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes})
+
+	return publicKeyFile, privateKeyFile, func() {
+		os.Remove(publicKeyFile.Name())
+		os.Remove(privateKeyFile.Name())
+	}
+}
+
+func TestLoadTLSConfigs(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	publicKeyFile, privateKeyFile, cleanCertificates := generateTestCertificates()
+	defer cleanCertificates()
+
+	caCert, err := os.ReadFile(publicKeyFile.Name())
+	if err != nil {
+		log.Fatal(err)
+	}
+	caCertPool := x509.NewCertPool()
+	caCertPool.AppendCertsFromPEM(caCert)
+
+	cert, err := tls.LoadX509KeyPair(publicKeyFile.Name(), privateKeyFile.Name())
+	assert.NoError(t, err)
+	expectedTLSConfig := &tls.Config{
+		RootCAs:      caCertPool,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err = viper.ReadConfig(strings.NewReader(fmt.Sprintf(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    tlsConfigs:
+    - name: myconfig
+      tls:
+        enabled: true
+        caFile: %s
+        certFile: %s
+        keyFile: %s 
+  `, publicKeyFile.Name(), publicKeyFile.Name(), privateKeyFile.Name())))
+	assert.NoError(t, err)
+
+	// RawConfig to Section!
+	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
+	tlsConfigs := make(map[string]*tls.Config)
+	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
+	assert.NoError(t, err)
+	assert.NotNil(t, tlsConfigs["myconfig"])
+	assert.True(t, tlsConfigs["myconfig"].RootCAs.Equal(expectedTLSConfig.RootCAs))
+	assert.Equal(t, tlsConfigs["myconfig"].Certificates, expectedTLSConfig.Certificates)
+}
+
+func TestLoadTLSConfigsDuplicateConfigs(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	publicKeyFile, privateKeyFile, cleanCertificates := generateTestCertificates()
+	defer cleanCertificates()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(fmt.Sprintf(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    tlsConfigs:
+    - name: myconfig
+      tls:
+        enabled: true
+        caFile: %s
+        certFile: %s
+        keyFile: %s 
+    - name: myconfig
+      tls:
+        enabled: true
+        caFile: %s
+        certFile: %s
+        keyFile: %s 
+  `, publicKeyFile.Name(), publicKeyFile.Name(), privateKeyFile.Name(),
+		publicKeyFile.Name(), publicKeyFile.Name(), privateKeyFile.Name())))
+	assert.NoError(t, err)
+
+	// RawConfig to Section!
+	tlsConfigArray := namespacePredefined.ArrayEntry(0).SubArray(coreconfig.NamespaceTLSConfigs)
+	tlsConfigs := make(map[string]*tls.Config)
+	err = nm.loadTLSConfig(nm.ctx, tlsConfigs, tlsConfigArray)
+	assert.Regexp(t, "FF10454", err)
+}
+
+func TestLoadNamespacesWithErrorTLSConfigs(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+namespaces:
+  default: ns1
+  predefined:
+  - name: ns1
+    tlsConfigs:
+    - name: myconfig
+      tls:
+        enabled: true
+        caFile: my-ca
+        certFile: my-cert
+        keyFile: my-key
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestLoadNamespacesNonMultipartyNoDatabase(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: []
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10392", err)
+
+}
+
+func TestLoadNamespacesMultipartyUnknownPlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [basicauth, bad]
+      multiparty:
+        enabled: true
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10390.*unknown", err)
+}
+
+func TestLoadNamespacesMultipartyMultipleBlockchains(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [ethereum, ethereum]
+      multiparty:
+        enabled: true
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10394.*blockchain", err)
+}
+
+func TestLoadNamespacesMultipartyMultipleDX(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [ffdx, ffdx]
+      multiparty:
+        enabled: true
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10394.*dataexchange", err)
+}
+
+func TestLoadNamespacesMultipartyMultipleSS(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [ipfs, ipfs]
+      multiparty:
+        enabled: true
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10394.*sharedstorage", err)
+}
+
+func TestLoadNamespacesMultipartyMultipleDB(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres, postgres]
+      multiparty:
+        enabled: true
   `))
 	assert.NoError(t, err)
 
 	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10390.*unknown", err)
+	assert.Regexp(t, "FF10394.*database", err)
 }
 
-func TestLoadNamespacesMultipartyMultipleBlockchains(t *testing.T) {
+func TestLoadNamespacesMultipartyMultipleAuths(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1567,17 +2109,17 @@ func TestLoadNamespacesMultipartyMultipleBlockchains(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [ethereum, ethereum]
+      plugins: [basicauth, basicauth]
       multiparty:
         enabled: true
   `))
 	assert.NoError(t, err)
 
 	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10394.*blockchain", err)
+	assert.Regexp(t, "FF10394.*auth", err)
 }
 
-func TestLoadNamespacesMultipartyMultipleDX(t *testing.T) {
+func TestLoadNamespacesMultipartyMultipleIdentity(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1588,17 +2130,17 @@ func TestLoadNamespacesMultipartyMultipleDX(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [ffdx, ffdx]
+      plugins: [tbd, tbd]
       multiparty:
         enabled: true
   `))
 	assert.NoError(t, err)
 
 	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10394.*dataexchange", err)
+	assert.Regexp(t, "FF10394.*identity", err)
 }
 
-func TestLoadNamespacesMultipartyMultipleSS(t *testing.T) {
+func TestLoadNamespacesMultipleKeyManager(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1609,17 +2151,57 @@ func TestLoadNamespacesMultipartyMultipleSS(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [ipfs, ipfs]
+      plugins: [postgres, signer, signer]
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10394.*keymanager", err)
+}
+
+func TestLoadNamespacesKeyManager(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres, signer]
+  `))
+	assert.NoError(t, err)
+
+	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	assert.Equal(t, "signer", nm.namespaces["ns1"].plugins.KeyManager.Name)
+	assert.Equal(t, nmm.mkm, nm.namespaces["ns1"].plugins.KeyManager.Plugin)
+}
+
+func TestInitNamespacesMultipartyWithAuth(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [ethereum, postgres, ipfs, ffdx, basicauth]
       multiparty:
         enabled: true
   `))
 	assert.NoError(t, err)
 
 	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10394.*sharedstorage", err)
+	assert.NoError(t, err)
 }
 
-func TestLoadNamespacesMultipartyMultipleDB(t *testing.T) {
+func TestLoadNamespacesNonMultipartyWithAuth(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1630,17 +2212,17 @@ func TestLoadNamespacesMultipartyMultipleDB(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [postgres, postgres]
+      plugins: [ethereum, postgres, basicauth]
       multiparty:
-        enabled: true
+        enabled: false
   `))
 	assert.NoError(t, err)
 
 	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10394.*database", err)
+	assert.NoError(t, err)
 }
 
-func TestLoadNamespacesMultipartyMultipleAuths(t *testing.T) {
+func TestLoadNamespacesMultipartyOrgVerification(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1651,17 +2233,25 @@ func TestLoadNamespacesMultipartyMultipleAuths(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [basicauth, basicauth]
       multiparty:
         enabled: true
+        org:
+          name: org1
+          certificate: org1-cert
+        orgVerification:
+          trustRoots: "-----BEGIN CERTIFICATE-----\nMIIC1DCCAbwCCQCdQsqbIH663DANBgkqhkiG9w0BAQsFADAsMRcwFQYDVQQDDA5k\nYXRhZXhjaGFuZ2VfMDERMA8GA1UECgwIbWVtYmVyXzAwHhcNMjIwMjI0MTUzMDE1\nWhcNMjMwMjI0MTUzMDE1WjAsMRcwFQYDVQQDDA5kYXRhZXhjaGFuZ2VfMDERMA8G\nA1UECgwIbWVtYmVyXzAwggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQC9\nItQpszirxOeONjzZQLgnp6iIUcu0v0NYhJ5QQM/a6JkzcTw+ZoxjwQZIAV/WRgVK\ngnp7Z+BXcGB7TqQsY3501tEG6st8zUgH2RHiIdPll9Uavxws2eQlrvW98STST1S8\n41OmIbetC7TWYLYvjtM2d8KjXgU96KtM6G7sVucOFxAkrM1UPrLVZOoUmUyXxery\nTzC16ssvnPHFylWwSD5PzHDRW3H+hYq6O3VE1VztZGmFQ/+9ZrPv3Io7fDyIa0vm\n7WWFiMFqO96vvh5Gnkzailaqs9ViXp4FE5c9ftEmXmzqI5YpVTI70MHlXKXoarD4\nuZnpRRcqACcBFl463WnzAgMBAAEwDQYJKoZIhvcNAQELBQADggEBAJruH13xnlvf\nat2QgeTsxjG4EQK8TDPEIthaA1eXP/69ShHeYNM62H9qP3QCjbY0i8eN9WdEzfGI\nSIWjDdviSNgPeH4KxyRL0Yiv43en8y0E0UcbqiiQrSdqjTDITBxo61cyOEYMmPiE\nynSPnGzt+iP3C64a/dAwfgTRFihgxc9WT+TcvJoZ58vku/Zi2+uA5qn9uLDHb0gF\nKXrACRvrRqOHXKoT1dJPUBnoiEhK4roB4y2yy0CNUP+tEwGLuGpFlek0GruYYEwz\nfAYpvKW5JGdcjD2SgmJ2iWdQQkhh5rNh5pAdSmzYf/x0psHTpVg0JrSC7et2hi6K\njklYSLaI4pI=\n-----END CERTIFICATE-----\n"
   `))
 	assert.NoError(t, err)
 
-	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10394.*auth", err)
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "org1-cert", newNS["ns1"].config.Multiparty.Org.Certificate)
+	assert.NotNil(t, newNS["ns1"].config.Multiparty.OrgTrustRoots)
+	assert.False(t, newNS["ns1"].config.Multiparty.RequireSignedClaims)
 }
 
-func TestLoadNamespacesMultipartyMultipleIdentity(t *testing.T) {
+func TestLoadNamespacesMultipartyRequireSignedClaims(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1672,17 +2262,22 @@ func TestLoadNamespacesMultipartyMultipleIdentity(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [tbd, tbd]
       multiparty:
         enabled: true
+        org:
+          name: org1
+        identityClaims:
+          requireSignature: true
   `))
 	assert.NoError(t, err)
 
-	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
-	assert.Regexp(t, "FF10394.*identity", err)
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+
+	assert.True(t, newNS["ns1"].config.Multiparty.RequireSignedClaims)
 }
 
-func TestInitNamespacesMultipartyWithAuth(t *testing.T) {
+func TestLoadNamespacesMultipartyOrgVerificationBadTrustRoots(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1693,17 +2288,60 @@ func TestInitNamespacesMultipartyWithAuth(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [ethereum, postgres, ipfs, ffdx, basicauth]
       multiparty:
         enabled: true
+        orgVerification:
+          trustRoots: not a certificate
   `))
 	assert.NoError(t, err)
 
-	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10482", err)
+}
+
+func TestLoadNamespacesMultipartyAnchor(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      multiparty:
+        enabled: true
+        anchor:
+          plugin: anchorchain
+          key: "0xabc"
+          location:
+            address: "0x456"
+  `))
+	assert.NoError(t, err)
+
+	manchor := &blockchainmocks.Plugin{}
+	nm.plugins["anchorchain"] = &plugin{
+		name:       "anchorchain",
+		category:   pluginCategoryBlockchain,
+		pluginType: "ethereum",
+		blockchain: manchor,
+	}
+
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
 	assert.NoError(t, err)
+
+	ns := newNS["ns1"]
+	assert.Equal(t, "ethereum", ns.plugins.Blockchain.Name)
+	assert.Equal(t, "anchorchain", ns.plugins.Anchor.Name)
+	assert.Equal(t, manchor, ns.plugins.Anchor.Plugin)
+	assert.NotContains(t, ns.pluginNames, "anchorchain")
+	assert.Equal(t, "0xabc", ns.config.Multiparty.Anchor.Key)
+	assert.Equal(t, "0x456", ns.config.Multiparty.Anchor.Location.JSONObject().GetString("address"))
+	assert.Equal(t, multiparty.AnchorModeBatch, ns.config.Multiparty.Anchor.Mode)
 }
 
-func TestLoadNamespacesNonMultipartyWithAuth(t *testing.T) {
+func TestLoadNamespacesMultipartyAnchorDigest(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
 
@@ -1714,14 +2352,148 @@ func TestLoadNamespacesNonMultipartyWithAuth(t *testing.T) {
     default: ns1
     predefined:
     - name: ns1
-      plugins: [ethereum, postgres, basicauth]
       multiparty:
-        enabled: false
+        enabled: true
+        anchor:
+          plugin: anchorchain
+          key: "0xabc"
+          mode: digest
+          digest:
+            interval: 5m
   `))
 	assert.NoError(t, err)
 
-	nm.namespaces, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	nm.plugins["anchorchain"] = &plugin{
+		name:       "anchorchain",
+		category:   pluginCategoryBlockchain,
+		pluginType: "ethereum",
+		blockchain: &blockchainmocks.Plugin{},
+	}
+
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+
+	ns := newNS["ns1"]
+	assert.Equal(t, multiparty.AnchorModeDigest, ns.config.Multiparty.Anchor.Mode)
+	assert.Equal(t, 5*time.Minute, ns.config.Multiparty.Anchor.DigestInterval)
+}
+
+func TestLoadNamespacesMultipartyAnchorBadMode(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      multiparty:
+        enabled: true
+        anchor:
+          plugin: anchorchain
+          mode: wrong
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10564.*wrong", err)
+}
+
+func TestLoadNamespacesMultipartyAnchorSamePlugin(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [ethereum, postgres, ipfs, ffdx]
+      multiparty:
+        enabled: true
+        anchor:
+          plugin: ethereum
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10563", err)
+}
+
+func TestLoadNamespacesMultipartyAnchorNotBlockchain(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [ethereum, postgres, ipfs, ffdx]
+      multiparty:
+        enabled: true
+        anchor:
+          plugin: erc721
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10563", err)
+}
+
+func TestLoadNamespacesMultipartyDatabaseSequencer(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      multiparty:
+        enabled: true
+        sequencer:
+          type: database
+          pollInterval: 1s
+          batchSize: 10
+  `))
+	assert.NoError(t, err)
+
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	assert.Equal(t, "database", newNS["ns1"].config.Sequencer.Type)
+	assert.Equal(t, time.Second, newNS["ns1"].config.Sequencer.PollInterval)
+	assert.Equal(t, 10, newNS["ns1"].config.Sequencer.BatchSize)
+}
+
+func TestLoadNamespacesMultipartyBadSequencer(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      multiparty:
+        enabled: true
+        sequencer:
+          type: raft
+  `))
 	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10527", err)
 }
 
 func TestLoadNamespacesMultipartyContract(t *testing.T) {
@@ -1981,6 +2753,31 @@ func TestGetNamespaces(t *testing.T) {
 	assert.Len(t, results, 1)
 }
 
+func TestGetReadiness(t *testing.T) {
+	nm, nmm, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	nmm.mdi.On("CheckReady", mock.Anything).Return(nil)
+	nmm.mbi.On("CheckReady", mock.Anything).Return(nil)
+	nmm.mdx.On("CheckReady", mock.Anything).Return(fmt.Errorf("pop"))
+	nmm.mti[0].On("CheckReady", mock.Anything).Return(nil)
+	nmm.mti[1].On("CheckReady", mock.Anything).Return(nil)
+
+	readiness := nm.GetReadiness(context.Background())
+	assert.False(t, readiness.Ready)
+	components := make([]string, len(readiness.Components))
+	for i, c := range readiness.Components {
+		components[i] = fmt.Sprintf("%s/%s/%s/%t/%s", c.Category, c.Name, c.Type, c.Ready, c.Error)
+	}
+	assert.Equal(t, []string{
+		"blockchain/ethereum/ethereum/true/",
+		"database/postgres/postgres/true/",
+		"dataexchange/ffdx/ffdx/false/pop",
+		"tokens/erc1155/type2/true/",
+		"tokens/erc721/type1/true/",
+	}, components)
+}
+
 func TestGetOperationByNamespacedID(t *testing.T) {
 	nm, _, cleanup := newTestNamespaceManager(t, true)
 	defer cleanup()
@@ -2133,3 +2930,48 @@ func TestOrchestratorWhileInitializing(t *testing.T) {
 	_, err := nm.Orchestrator(nm.ctx, "default", false)
 	assert.Regexp(t, "FF10441", err)
 }
+
+func TestLoadNamespacesSandbox(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      plugins: [postgres]
+    - name: sandbox1
+      plugins: [postgres]
+      sandbox: true
+  `))
+	assert.NoError(t, err)
+
+	newNS, err := nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.NoError(t, err)
+	assert.False(t, newNS["ns1"].config.Sandbox)
+	assert.True(t, newNS["sandbox1"].config.Sandbox)
+}
+
+func TestLoadNamespacesSandboxMultiparty(t *testing.T) {
+	nm, _, cleanup := newTestNamespaceManager(t, true)
+	defer cleanup()
+
+	coreconfig.Reset()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader(`
+  namespaces:
+    default: ns1
+    predefined:
+    - name: ns1
+      sandbox: true
+      multiparty:
+        enabled: true
+  `))
+	assert.NoError(t, err)
+
+	_, err = nm.loadNamespaces(context.Background(), nm.dumpRootConfig(), nm.plugins)
+	assert.Regexp(t, "FF10554", err)
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// pluginConnectionListener reports the connection of a single plugin to its runtime on the admin event stream
+type pluginConnectionListener struct {
+	nm     *namespaceManager
+	plugin *plugin
+}
+
+func (nm *namespaceManager) newPluginConnectionListener(p *plugin) core.PluginConnectionListener {
+	return &pluginConnectionListener{nm: nm, plugin: p}
+}
+
+func (pl *pluginConnectionListener) PluginConnectionChanged(namespace string, connected bool) {
+	eventType := core.ChangeEventTypePluginConnectionRestored
+	if connected {
+		log.L(pl.plugin.ctx).Infof("Connection restored for plugin '%s' (namespace=%s)", pl.plugin.name, namespace)
+	} else {
+		eventType = core.ChangeEventTypePluginConnectionLost
+		log.L(pl.plugin.ctx).Warnf("Connection lost for plugin '%s' (namespace=%s)", pl.plugin.name, namespace)
+	}
+	pl.nm.adminEvents.Dispatch(&core.ChangeEvent{
+		Collection: core.ChangeEventCollectionPlugins,
+		Type:       eventType,
+		Namespace:  namespace,
+		Plugin:     pl.plugin.name,
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly/mocks/spieventsmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPluginConnectionChanged(t *testing.T) {
+	mae := &spieventsmocks.Manager{}
+	nm := &namespaceManager{
+		adminEvents: mae,
+	}
+	listener := nm.newPluginConnectionListener(&plugin{name: "erc1155", ctx: context.Background()})
+
+	mae.On("Dispatch", mock.MatchedBy(func(ce *core.ChangeEvent) bool {
+		return ce.Collection == "plugins" && ce.Type == core.ChangeEventTypePluginConnectionLost &&
+			ce.Namespace == "ns1" && ce.Plugin == "erc1155"
+	})).Return().Once()
+	listener.PluginConnectionChanged("ns1", false)
+
+	mae.On("Dispatch", mock.MatchedBy(func(ce *core.ChangeEvent) bool {
+		return ce.Collection == "plugins" && ce.Type == core.ChangeEventTypePluginConnectionRestored &&
+			ce.Namespace == "ns1" && ce.Plugin == "erc1155"
+	})).Return().Once()
+	listener.PluginConnectionChanged("ns1", true)
+
+	mae.AssertExpectations(t)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (nm *networkMap) CreateDelegation(ctx context.Context, delegation *core.Delegation, waitConfirm bool) (*core.Delegation, error) {
+	delegation.ID = fftypes.NewUUID()
+	delegation.Namespace = nm.namespace
+	if err := delegation.Validate(ctx); err != nil {
+		return nil, err
+	}
+
+	delegator, _, err := nm.identity.CachedIdentityLookupMustExist(ctx, delegation.Delegator)
+	if err != nil {
+		return nil, err
+	}
+	delegate, _, err := nm.identity.CachedIdentityLookupMustExist(ctx, delegation.Delegate)
+	if err != nil {
+		return nil, err
+	}
+	if delegator.ID.Equals(delegate.ID) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDelegationToSelf, delegator.DID)
+	}
+	delegation.Delegator = delegator.DID
+	delegation.Delegate = delegate.DID
+
+	var signer *core.SignerRef
+	if nm.multiparty != nil {
+		// The delegation is signed by the same signer as the original claim of the delegating identity
+		if signer, err = nm.identity.ResolveIdentitySigner(ctx, delegator); err != nil {
+			return nil, err
+		}
+	}
+
+	err = nm.defsender.DefineDelegation(ctx, delegation, signer, waitConfirm)
+	return delegation, err
+}
+
+func (nm *networkMap) GetDelegationByID(ctx context.Context, id string) (*core.Delegation, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	delegation, err := nm.database.GetDelegationByID(ctx, nm.namespace, u)
+	if err != nil {
+		return nil, err
+	}
+	if delegation == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return delegation, nil
+}
+
+func (nm *networkMap) GetDelegations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Delegation, *ffapi.FilterResult, error) {
+	return nm.database.GetDelegations(ctx, nm.namespace, filter)
+}
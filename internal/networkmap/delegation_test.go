@@ -0,0 +1,222 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/definitionsmocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testDelegationInput() *core.Delegation {
+	return &core.Delegation{
+		Delegator: "customer",
+		Delegate:  "gateway",
+		Scope:     fftypes.FFStringArray{"messages", "transfers"},
+	}
+}
+
+func TestCreateDelegationOk(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	customer := testOrg("customer")
+	gateway := testOrg("gateway")
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "customer").Return(customer, false, nil)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "gateway").Return(gateway, false, nil)
+	signerRef := &core.SignerRef{Key: "0x12345"}
+	mim.On("ResolveIdentitySigner", nm.ctx, customer).Return(signerRef, nil)
+
+	mds := nm.defsender.(*definitionsmocks.Sender)
+	mds.On("DefineDelegation", nm.ctx, mock.MatchedBy(func(d *core.Delegation) bool {
+		return d.ID != nil && d.Namespace == "ns1" && d.Delegator == customer.DID && d.Delegate == gateway.DID
+	}), signerRef, true).Return(nil)
+
+	delegation, err := nm.CreateDelegation(nm.ctx, testDelegationInput(), true)
+	assert.NoError(t, err)
+	assert.Equal(t, customer.DID, delegation.Delegator)
+	assert.Equal(t, gateway.DID, delegation.Delegate)
+
+	mim.AssertExpectations(t)
+	mds.AssertExpectations(t)
+}
+
+func TestCreateDelegationGateway(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+	nm.multiparty = nil
+
+	customer := testOrg("customer")
+	gateway := testOrg("gateway")
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "customer").Return(customer, false, nil)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "gateway").Return(gateway, false, nil)
+
+	mds := nm.defsender.(*definitionsmocks.Sender)
+	mds.On("DefineDelegation", nm.ctx, mock.Anything, (*core.SignerRef)(nil), false).Return(nil)
+
+	_, err := nm.CreateDelegation(nm.ctx, testDelegationInput(), false)
+	assert.NoError(t, err)
+
+	mim.AssertExpectations(t)
+	mds.AssertExpectations(t)
+}
+
+func TestCreateDelegationInvalid(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	_, err := nm.CreateDelegation(nm.ctx, &core.Delegation{}, false)
+	assert.Regexp(t, "FF10575", err)
+}
+
+func TestCreateDelegationDelegatorNotFound(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "customer").Return(nil, false, fmt.Errorf("pop"))
+
+	_, err := nm.CreateDelegation(nm.ctx, testDelegationInput(), false)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+}
+
+func TestCreateDelegationDelegateNotFound(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "customer").Return(testOrg("customer"), false, nil)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "gateway").Return(nil, false, fmt.Errorf("pop"))
+
+	_, err := nm.CreateDelegation(nm.ctx, testDelegationInput(), false)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+}
+
+func TestCreateDelegationToSelf(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	customer := testOrg("customer")
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "customer").Return(customer, false, nil)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "gateway").Return(customer, false, nil)
+
+	_, err := nm.CreateDelegation(nm.ctx, testDelegationInput(), false)
+	assert.Regexp(t, "FF10576", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestCreateDelegationSignerFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	customer := testOrg("customer")
+	gateway := testOrg("gateway")
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "customer").Return(customer, false, nil)
+	mim.On("CachedIdentityLookupMustExist", nm.ctx, "gateway").Return(gateway, false, nil)
+	mim.On("ResolveIdentitySigner", nm.ctx, customer).Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.CreateDelegation(nm.ctx, testDelegationInput(), false)
+	assert.EqualError(t, err, "pop")
+
+	mim.AssertExpectations(t)
+}
+
+func TestGetDelegationByIDOk(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	id := fftypes.NewUUID()
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegationByID", nm.ctx, "ns1", id).Return(&core.Delegation{ID: id}, nil)
+
+	delegation, err := nm.GetDelegationByID(nm.ctx, id.String())
+	assert.NoError(t, err)
+	assert.Equal(t, id, delegation.ID)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetDelegationByIDNotFound(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	id := fftypes.NewUUID()
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegationByID", nm.ctx, "ns1", id).Return(nil, nil)
+
+	_, err := nm.GetDelegationByID(nm.ctx, id.String())
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetDelegationByIDFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	id := fftypes.NewUUID()
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegationByID", nm.ctx, "ns1", id).Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.GetDelegationByID(nm.ctx, id.String())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestGetDelegationByIDBadID(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	_, err := nm.GetDelegationByID(nm.ctx, "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetDelegations(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetDelegations", nm.ctx, "ns1", mock.Anything).Return([]*core.Delegation{}, nil, nil)
+
+	fb := database.DelegationQueryFactory.NewFilter(nm.ctx)
+	_, _, err := nm.GetDelegations(nm.ctx, fb.And(fb.Eq("delegate", "did:firefly:org/gateway")))
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
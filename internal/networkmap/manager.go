@@ -34,9 +34,13 @@ import (
 type Manager interface {
 	RegisterOrganization(ctx context.Context, org *core.IdentityCreateDTO, waitConfirm bool) (identity *core.Identity, err error)
 	RegisterNode(ctx context.Context, waitConfirm bool) (node *core.Identity, err error)
+	UpdateNode(ctx context.Context, waitConfirm bool) (node *core.Identity, err error)
 	RegisterNodeOrganization(ctx context.Context, waitConfirm bool) (org *core.Identity, err error)
 	RegisterIdentity(ctx context.Context, dto *core.IdentityCreateDTO, waitConfirm bool) (identity *core.Identity, err error)
 	UpdateIdentity(ctx context.Context, id string, dto *core.IdentityUpdateDTO, waitConfirm bool) (identity *core.Identity, err error)
+	RevokeVerifier(ctx context.Context, hash string, dto *core.VerifierRevocationDTO, waitConfirm bool) (revocation *core.VerifierRevocation, err error)
+	RevokeNode(ctx context.Context, nameOrID string, dto *core.VerifierRevocationDTO, waitConfirm bool) (revocation *core.VerifierRevocation, err error)
+	CreateDelegation(ctx context.Context, delegation *core.Delegation, waitConfirm bool) (*core.Delegation, error)
 
 	GetOrganizationByNameOrID(ctx context.Context, nameOrID string) (*core.Identity, error)
 	GetOrganizations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Identity, *ffapi.FilterResult, error)
@@ -54,6 +58,12 @@ type Manager interface {
 	GetVerifierByHash(ctx context.Context, hash string) (*core.Verifier, error)
 	GetDIDDocForIndentityByID(ctx context.Context, id string) (*DIDDocument, error)
 	GetDIDDocForIndentityByDID(ctx context.Context, did string) (*DIDDocument, error)
+	GetNodeStatus(ctx context.Context, nameOrID string) (*core.NodeStatus, error)
+	GetDelegationByID(ctx context.Context, id string) (*core.Delegation, error)
+	GetDelegations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Delegation, *ffapi.FilterResult, error)
+
+	Start()
+	WaitStop()
 }
 
 type networkMap struct {
@@ -65,6 +75,7 @@ type networkMap struct {
 	identity   identity.Manager
 	syncasync  syncasync.Bridge
 	multiparty multiparty.Manager // optional
+	monitor    *peerMonitor
 }
 
 func NewNetworkMap(ctx context.Context, ns string, di database.Plugin, dx dataexchange.Plugin, ds definitions.Sender, im identity.Manager, sa syncasync.Bridge, mm multiparty.Manager) (Manager, error) {
@@ -82,5 +93,6 @@ func NewNetworkMap(ctx context.Context, ns string, di database.Plugin, dx dataex
 		syncasync:  sa,
 		multiparty: mm,
 	}
+	nm.monitor = newPeerMonitor(ctx, nm)
 	return nm, nil
 }
@@ -0,0 +1,151 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (nm *networkMap) GetNodeStatus(ctx context.Context, nameOrID string) (*core.NodeStatus, error) {
+	node, err := nm.GetNodeByNameOrID(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return nm.nodeStatus(ctx, node)
+}
+
+// nodeStatus queries data exchange for the connectivity of a node. The local node does not have a peer status,
+// as messages to the local node are not sent via data exchange.
+func (nm *networkMap) nodeStatus(ctx context.Context, node *core.Identity) (status *core.NodeStatus, err error) {
+	status = &core.NodeStatus{
+		ID:       node.ID,
+		Name:     node.Name,
+		Local:    node.Name == nm.multiparty.LocalNode().Name,
+		PeerID:   nm.exchange.GetPeerID(node.Profile),
+		Endpoint: node.Profile,
+	}
+	if !status.Local {
+		if status.PeerStatus, err = nm.exchange.GetPeerStatus(ctx, node.Profile); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+func (nm *networkMap) Start() {
+	if nm.exchange != nil && nm.exchange.Capabilities().PeerConnectivity {
+		nm.monitor.start()
+	}
+}
+
+func (nm *networkMap) WaitStop() {
+	nm.monitor.close()
+}
+
+// peerMonitor periodically checks whether data exchange can reach each node in the namespace,
+// and emits a node_connectivity_changed event each time the peer status of a node changes
+type peerMonitor struct {
+	ctx        context.Context
+	cancelFunc func()
+	nm         *networkMap
+	interval   time.Duration
+	statuses   map[fftypes.UUID]core.PeerStatus
+	wg         sync.WaitGroup
+}
+
+func newPeerMonitor(ctx context.Context, nm *networkMap) *peerMonitor {
+	mon := &peerMonitor{
+		nm:       nm,
+		interval: config.GetDuration(coreconfig.PeerMonitorInterval),
+		statuses: make(map[fftypes.UUID]core.PeerStatus),
+	}
+	mon.ctx, mon.cancelFunc = context.WithCancel(ctx)
+	return mon
+}
+
+func (mon *peerMonitor) start() {
+	if mon.interval <= 0 {
+		return
+	}
+	mon.wg.Add(1)
+	go mon.monitorLoop()
+}
+
+func (mon *peerMonitor) monitorLoop() {
+	defer mon.wg.Done()
+	ctx := log.WithLogField(mon.ctx, "role", "peermonitor")
+	for {
+		if err := mon.checkPeers(ctx); err != nil {
+			log.L(ctx).Errorf("Peer monitor failed to check the connectivity of nodes: %s", err)
+		}
+		select {
+		case <-time.After(mon.interval):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Peer monitor stopped")
+			return
+		}
+	}
+}
+
+// checkPeers records the peer status of each node. The first status seen for a node is only recorded,
+// so events are emitted for transitions that happen while this node is running.
+func (mon *peerMonitor) checkPeers(ctx context.Context) error {
+	fb := database.IdentityQueryFactory.NewFilter(ctx)
+	nodes, _, err := mon.nm.database.GetIdentities(ctx, mon.nm.namespace, fb.Eq("type", core.IdentityTypeNode))
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		status, err := mon.nm.nodeStatus(ctx, node)
+		if err != nil {
+			log.L(ctx).Warnf("Unable to check the connectivity of node '%s': %s", node.Name, err)
+			continue
+		}
+		if status.Local {
+			continue
+		}
+		previous, known := mon.statuses[*node.ID]
+		if known && previous != status.PeerStatus {
+			log.L(ctx).Infof("Node '%s' connectivity changed from %s to %s", node.Name, previous, status.PeerStatus)
+			event := core.NewEvent(core.EventTypeNodeConnectivityChanged, mon.nm.namespace, node.ID, nil, "")
+			if err := mon.nm.database.InsertEvent(ctx, event); err != nil {
+				return err
+			}
+		}
+		mon.statuses[*node.ID] = status.PeerStatus
+	}
+	return nil
+}
+
+func (mon *peerMonitor) close() {
+	mon.cancelFunc()
+	mon.wg.Wait()
+}
@@ -0,0 +1,239 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/multipartymocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestNode(name string) *core.Identity {
+	return &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:   fftypes.NewUUID(),
+			Type: core.IdentityTypeNode,
+			Name: name,
+		},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{"id": name + "-peer"},
+		},
+	}
+}
+
+func TestGetNodeStatus(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := newTestNode("node2")
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", nm.ctx, "ns1", node.ID).Return(node, nil)
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", node.Profile).Return("node2-peer")
+	mdx.On("GetPeerStatus", nm.ctx, node.Profile).Return(core.PeerStatusOnline, nil)
+
+	status, err := nm.GetNodeStatus(nm.ctx, node.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, &core.NodeStatus{
+		ID:         node.ID,
+		Name:       "node2",
+		PeerID:     "node2-peer",
+		Endpoint:   node.Profile,
+		PeerStatus: core.PeerStatusOnline,
+	}, status)
+
+	mdi.AssertExpectations(t)
+	mmp.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
+
+func TestGetNodeStatusLocal(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := newTestNode("node1")
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByName", nm.ctx, core.IdentityTypeNode, "ns1", "node1").Return(node, nil)
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", node.Profile).Return("node1-peer")
+
+	status, err := nm.GetNodeStatus(nm.ctx, "node1")
+	assert.NoError(t, err)
+	assert.True(t, status.Local)
+	assert.Empty(t, status.PeerStatus)
+
+	mdx.AssertExpectations(t)
+}
+
+func TestGetNodeStatusNotFound(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	id := fftypes.NewUUID()
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", nm.ctx, "ns1", id).Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.GetNodeStatus(nm.ctx, id.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetNodeStatusNotNode(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	id := fftypes.NewUUID()
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", nm.ctx, "ns1", id).Return(&core.Identity{
+		IdentityBase: core.IdentityBase{ID: id, Type: core.IdentityTypeOrg},
+	}, nil)
+
+	_, err := nm.GetNodeStatus(nm.ctx, id.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetNodeStatusFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := newTestNode("node2")
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", nm.ctx, "ns1", node.ID).Return(node, nil)
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", node.Profile).Return("node2-peer")
+	mdx.On("GetPeerStatus", nm.ctx, node.Profile).Return(core.PeerStatus(""), fmt.Errorf("pop"))
+
+	_, err := nm.GetNodeStatus(nm.ctx, node.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestPeerMonitorEmitsOnChange(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+	config.Set(coreconfig.PeerMonitorInterval, "1ms")
+	nm.monitor = newPeerMonitor(nm.ctx, nm)
+
+	localNode := newTestNode("node1")
+	node2 := newTestNode("node2")
+	node3 := newTestNode("node3")
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{localNode, node2, node3}, nil, nil)
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("Capabilities").Return(&dataexchange.Capabilities{PeerConnectivity: true})
+	mdx.On("GetPeerID", mock.Anything).Return("peer")
+	mdx.On("GetPeerStatus", mock.Anything, node2.Profile).Return(core.PeerStatusOnline, nil).Once()
+	mdx.On("GetPeerStatus", mock.Anything, node2.Profile).Return(core.PeerStatusOffline, nil)
+	mdx.On("GetPeerStatus", mock.Anything, node3.Profile).Return(core.PeerStatus(""), fmt.Errorf("pop"))
+
+	eventsEmitted := make(chan *core.Event, 1)
+	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeNodeConnectivityChanged && event.Reference.Equals(node2.ID)
+	})).Run(func(args mock.Arguments) {
+		eventsEmitted <- args[1].(*core.Event)
+	}).Return(nil).Once()
+
+	nm.Start()
+	<-eventsEmitted
+	nm.WaitStop()
+
+	// Further checks find the same status, so do not emit again
+	err := nm.monitor.checkPeers(nm.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, core.PeerStatusOffline, nm.monitor.statuses[*node2.ID])
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPeerMonitorQueryFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+	config.Set(coreconfig.PeerMonitorInterval, "1s")
+	nm.monitor = newPeerMonitor(nm.ctx, nm)
+
+	queried := make(chan struct{})
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
+		close(queried)
+	}).Once()
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("Capabilities").Return(&dataexchange.Capabilities{PeerConnectivity: true})
+
+	nm.Start()
+	<-queried
+	nm.WaitStop()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPeerMonitorInsertEventFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := newTestNode("node2")
+	nm.monitor.statuses[*node.ID] = core.PeerStatusOnline
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentities", mock.Anything, "ns1", mock.Anything).Return([]*core.Identity{node}, nil, nil)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", node.Profile).Return("node2-peer")
+	mdx.On("GetPeerStatus", mock.Anything, node.Profile).Return(core.PeerStatusOffline, nil)
+
+	err := nm.monitor.checkPeers(nm.ctx)
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, core.PeerStatusOnline, nm.monitor.statuses[*node.ID])
+}
+
+func TestPeerMonitorNotStarted(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	// Data exchange does not track connectivity
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("Capabilities").Return(&dataexchange.Capabilities{})
+	nm.Start()
+	nm.WaitStop()
+
+	// Disabled
+	config.Set(coreconfig.PeerMonitorInterval, "0")
+	nm.monitor = newPeerMonitor(nm.ctx, nm)
+	nm.monitor.start()
+	nm.WaitStop()
+
+	mdx.AssertExpectations(t)
+	assert.Equal(t, time.Duration(0), nm.monitor.interval)
+}
@@ -68,6 +68,20 @@ func (nm *networkMap) RegisterIdentity(ctx context.Context, dto *core.IdentityCr
 		return nil, err
 	}
 
+	claim := &core.IdentityClaim{
+		Identity:             identity,
+		Certificate:          dto.Certificate,
+		CertificateSignature: dto.CertificateSignature,
+		Signature:            dto.Signature,
+	}
+
+	// Check the org certificate against our own trust roots, before asking the network to do the same
+	if identity.Type == core.IdentityTypeOrg {
+		if _, err = nm.identity.VerifyOrgCertificate(ctx, claim, fftypes.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	var claimSigner *core.SignerRef
 	var parentSigner *core.SignerRef
 
@@ -103,13 +117,13 @@ func (nm *networkMap) RegisterIdentity(ctx context.Context, dto *core.IdentityCr
 
 	if waitConfirm {
 		return nm.syncasync.WaitForIdentity(ctx, identity.ID, func(ctx context.Context) error {
-			return nm.sendIdentityRequest(ctx, identity, claimSigner, parentSigner)
+			return nm.sendIdentityRequest(ctx, claim, claimSigner, parentSigner)
 		})
 	}
-	err = nm.sendIdentityRequest(ctx, identity, claimSigner, parentSigner)
+	err = nm.sendIdentityRequest(ctx, claim, claimSigner, parentSigner)
 	return identity, err
 }
 
-func (nm *networkMap) sendIdentityRequest(ctx context.Context, identity *core.Identity, claimSigner *core.SignerRef, parentSigner *core.SignerRef) error {
-	return nm.defsender.ClaimIdentity(ctx, &core.IdentityClaim{Identity: identity}, claimSigner, parentSigner)
+func (nm *networkMap) sendIdentityRequest(ctx context.Context, claim *core.IdentityClaim, claimSigner *core.SignerRef, parentSigner *core.SignerRef) error {
+	return nm.defsender.ClaimIdentity(ctx, claim, claimSigner, parentSigner)
 }
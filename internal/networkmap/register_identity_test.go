@@ -46,8 +46,11 @@ func TestRegisterIdentityOrgWithParentOk(t *testing.T) {
 
 	mds := nm.defsender.(*definitionsmocks.Sender)
 
+	sig := &core.KeySignature{Value: "0xabcd"}
 	mds.On("ClaimIdentity", nm.ctx,
-		mock.AnythingOfType("*core.IdentityClaim"),
+		mock.MatchedBy(func(claim *core.IdentityClaim) bool {
+			return claim.Signature == sig
+		}),
 		mock.MatchedBy(func(sr *core.SignerRef) bool {
 			return sr.Key == "0x12345"
 		}),
@@ -57,9 +60,10 @@ func TestRegisterIdentityOrgWithParentOk(t *testing.T) {
 	).Return(nil)
 
 	org, err := nm.RegisterIdentity(nm.ctx, &core.IdentityCreateDTO{
-		Name:   "child1",
-		Key:    "0x12345",
-		Parent: fftypes.NewUUID().String(),
+		Name:      "child1",
+		Key:       "0x12345",
+		Parent:    fftypes.NewUUID().String(),
+		Signature: sig,
 	}, false)
 	assert.NoError(t, err)
 	assert.NotNil(t, org)
@@ -244,6 +248,29 @@ func TestRegisterIdentityVerifyFail(t *testing.T) {
 	mim.AssertExpectations(t)
 }
 
+func TestRegisterIdentityOrgCertificateFail(t *testing.T) {
+
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("VerifyIdentityChain", nm.ctx, mock.AnythingOfType("*core.Identity")).Return(nil, false, nil)
+	mim.On("VerifyOrgCertificate", nm.ctx, mock.MatchedBy(func(claim *core.IdentityClaim) bool {
+		return claim.Identity.Name == "org1" && claim.Certificate == "bad-cert" && claim.CertificateSignature == "cert-sig"
+	}), mock.AnythingOfType("*fftypes.FFTime")).Return("", fmt.Errorf("pop"))
+
+	_, err := nm.RegisterIdentity(nm.ctx, &core.IdentityCreateDTO{
+		Name:                 "org1",
+		Type:                 core.IdentityTypeOrg,
+		Key:                  "0x12345",
+		Certificate:          "bad-cert",
+		CertificateSignature: "cert-sig",
+	}, false)
+	assert.Regexp(t, "pop", err)
+
+	mim.AssertExpectations(t)
+}
+
 func TestRegisterIdentityBadParent(t *testing.T) {
 
 	nm, cancel := newTestNetworkmap(t)
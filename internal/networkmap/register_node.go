@@ -19,6 +19,7 @@ package networkmap
 import (
 	"context"
 
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -44,10 +45,48 @@ func (nm *networkMap) RegisterNode(ctx context.Context, waitConfirm bool) (ident
 		},
 	}
 
-	nodeRequest.Profile, err = nm.exchange.GetEndpointInfo(ctx, localNodeName)
+	nodeRequest.Profile, err = nm.localNodeProfile(ctx, localNodeName)
 	if err != nil {
 		return nil, err
 	}
 
 	return nm.RegisterIdentity(ctx, nodeRequest, waitConfirm)
 }
+
+// UpdateNode broadcasts an update to the profile of the registered local node, advertising the current
+// endpoint of its data exchange, and the latest batch manifest version it can process
+func (nm *networkMap) UpdateNode(ctx context.Context, waitConfirm bool) (identity *core.Identity, err error) {
+	node, err := nm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNodeNotFound, nm.multiparty.LocalNode().Name)
+	}
+
+	profile, err := nm.localNodeProfile(ctx, node.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return nm.updateIdentityID(ctx, node.ID, &core.IdentityUpdateDTO{
+		IdentityProfile: core.IdentityProfile{
+			Description: node.Description,
+			Profile:     profile,
+		},
+	}, waitConfirm)
+}
+
+// localNodeProfile is the profile of the local node, which other nodes use to reach it through data exchange,
+// and to negotiate the version of the batch manifests they send to it
+func (nm *networkMap) localNodeProfile(ctx context.Context, localNodeName string) (fftypes.JSONObject, error) {
+	profile, err := nm.exchange.GetEndpointInfo(ctx, localNodeName)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		profile = fftypes.JSONObject{}
+	}
+	profile[core.NodeProfileManifestVersion] = core.ManifestVersionLatest
+	return profile, nil
+}
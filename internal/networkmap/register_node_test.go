@@ -52,7 +52,10 @@ func TestRegisterNodeOk(t *testing.T) {
 
 	mds := nm.defsender.(*definitionsmocks.Sender)
 	mds.On("ClaimIdentity", nm.ctx,
-		mock.AnythingOfType("*core.IdentityClaim"),
+		mock.MatchedBy(func(claim *core.IdentityClaim) bool {
+			return claim.Identity.Profile.GetString("id") == "peer1" &&
+				claim.Identity.Profile.GetInt64(core.NodeProfileManifestVersion) == int64(core.ManifestVersionLatest)
+		}),
 		signerRef,
 		(*core.SignerRef)(nil),
 	).Return(nil)
@@ -126,3 +129,91 @@ func TestRegisterNodeGetOwnerFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 
 }
+
+func TestUpdateNodeOk(t *testing.T) {
+
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := testNode("node1", testOrg("org1"))
+	node.Description = "my node"
+	signerRef := &core.SignerRef{Key: "0x23456"}
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", nm.ctx).Return(node, nil)
+	mim.On("CachedIdentityLookupByID", nm.ctx, node.ID).Return(node, nil)
+	mim.On("ResolveIdentitySigner", nm.ctx, node).Return(signerRef, nil)
+
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetEndpointInfo", nm.ctx, "node1").Return(nil, nil)
+
+	mds := nm.defsender.(*definitionsmocks.Sender)
+	mds.On("UpdateIdentity", nm.ctx,
+		mock.AnythingOfType("*core.Identity"),
+		mock.MatchedBy(func(update *core.IdentityUpdate) bool {
+			return update.Updates.Description == "my node" &&
+				update.Updates.Profile.GetInt64(core.NodeProfileManifestVersion) == int64(core.ManifestVersionLatest)
+		}),
+		signerRef,
+		true).Return(nil)
+
+	updated, err := nm.UpdateNode(nm.ctx, true)
+	assert.NoError(t, err)
+	assert.Equal(t, node.ID, updated.ID)
+
+	mim.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mds.AssertExpectations(t)
+}
+
+func TestUpdateNodeNotRegistered(t *testing.T) {
+
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", nm.ctx).Return(nil, nil)
+
+	mmp := nm.multiparty.(*multipartymocks.Manager)
+	mmp.On("LocalNode").Return(multiparty.LocalNode{Name: "node1"})
+
+	_, err := nm.UpdateNode(nm.ctx, true)
+	assert.Regexp(t, "FF10224.*node1", err)
+
+	mim.AssertExpectations(t)
+	mmp.AssertExpectations(t)
+}
+
+func TestUpdateNodeGetLocalNodeFail(t *testing.T) {
+
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", nm.ctx).Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.UpdateNode(nm.ctx, true)
+	assert.Regexp(t, "pop", err)
+
+	mim.AssertExpectations(t)
+}
+
+func TestUpdateNodePeerInfoFail(t *testing.T) {
+
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := testNode("node1", testOrg("org1"))
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", nm.ctx).Return(node, nil)
+
+	mdx := nm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetEndpointInfo", nm.ctx, "node1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.UpdateNode(nm.ctx, true)
+	assert.Regexp(t, "pop", err)
+
+	mim.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
@@ -41,7 +41,9 @@ func (nm *networkMap) RegisterNodeOrganization(ctx context.Context, waitConfirm
 		IdentityProfile: core.IdentityProfile{
 			Description: nm.multiparty.RootOrg().Description,
 		},
-		Key: key.Value,
+		Key:                  key.Value,
+		Certificate:          nm.multiparty.RootOrg().Certificate,
+		CertificateSignature: nm.multiparty.RootOrg().CertificateSignature,
 	}
 	return nm.RegisterOrganization(ctx, orgRequest, waitConfirm)
 }
@@ -63,13 +63,16 @@ func TestRegisterNodeOrgOk(t *testing.T) {
 		Value: "0x12345",
 	}, nil)
 	mim.On("VerifyIdentityChain", nm.ctx, mock.AnythingOfType("*core.Identity")).Return(nil, false, nil)
+	mim.On("VerifyOrgCertificate", nm.ctx, mock.AnythingOfType("*core.IdentityClaim"), mock.AnythingOfType("*fftypes.FFTime")).Return("", nil)
 
 	mmp := nm.multiparty.(*multipartymocks.Manager)
-	mmp.On("RootOrg").Return(multiparty.RootOrg{Name: "org0"})
+	mmp.On("RootOrg").Return(multiparty.RootOrg{Name: "org0", Certificate: "cert-pem", CertificateSignature: "cert-sig"})
 
 	mds := nm.defsender.(*definitionsmocks.Sender)
 	mds.On("ClaimIdentity", nm.ctx,
-		mock.AnythingOfType("*core.IdentityClaim"),
+		mock.MatchedBy(func(claim *core.IdentityClaim) bool {
+			return claim.Certificate == "cert-pem" && claim.CertificateSignature == "cert-sig"
+		}),
 		mock.MatchedBy(func(sr *core.SignerRef) bool {
 			return sr.Key == "0x12345"
 		}),
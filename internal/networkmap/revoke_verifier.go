@@ -0,0 +1,78 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+func (nm *networkMap) RevokeVerifier(ctx context.Context, hash string, dto *core.VerifierRevocationDTO, waitConfirm bool) (*core.VerifierRevocation, error) {
+	verifier, err := nm.GetVerifierByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if verifier.Revoked != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgVerifierAlreadyRevoked, verifier.Value)
+	}
+
+	identity, err := nm.identity.CachedIdentityLookupByID(ctx, verifier.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NoResult)
+	}
+
+	return nm.sendRevocation(ctx, identity, &core.VerifierRevocation{
+		Identity: identity.IdentityBase,
+		Verifier: &verifier.VerifierRef,
+		Reason:   dto.Reason,
+	}, waitConfirm)
+}
+
+func (nm *networkMap) RevokeNode(ctx context.Context, nameOrID string, dto *core.VerifierRevocationDTO, waitConfirm bool) (*core.VerifierRevocation, error) {
+	node, err := nm.GetNodeByNameOrID(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	return nm.sendRevocation(ctx, node, &core.VerifierRevocation{
+		Identity: node.IdentityBase,
+		Reason:   dto.Reason,
+	}, waitConfirm)
+}
+
+func (nm *networkMap) sendRevocation(ctx context.Context, identity *core.Identity, revocation *core.VerifierRevocation, waitConfirm bool) (*core.VerifierRevocation, error) {
+	var signer *core.SignerRef
+	if nm.multiparty != nil {
+		// The revocation is signed by the same signer as the original claim of the identity
+		var err error
+		if signer, err = nm.identity.ResolveIdentitySigner(ctx, identity); err != nil {
+			return nil, err
+		}
+	}
+
+	err := nm.defsender.RevokeVerifier(ctx, revocation, signer, waitConfirm)
+	return revocation, err
+}
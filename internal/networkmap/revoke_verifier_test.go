@@ -0,0 +1,221 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkmap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/definitionsmocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testVerifier(identity *core.Identity) *core.Verifier {
+	return (&core.Verifier{
+		Identity:  identity.ID,
+		Namespace: "ns1",
+		VerifierRef: core.VerifierRef{
+			Type:  core.VerifierTypeEthAddress,
+			Value: "0x12345",
+		},
+	}).Seal()
+}
+
+func testNode(name string, parent *core.Identity) *core.Identity {
+	return &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:        fftypes.NewUUID(),
+			Type:      core.IdentityTypeNode,
+			Namespace: "ns1",
+			Name:      name,
+			Parent:    parent.ID,
+			DID:       "did:firefly:node/" + name,
+		},
+	}
+}
+
+func TestRevokeVerifierOk(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	identity := testOrg("org1")
+	verifier := testVerifier(identity)
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByHash", nm.ctx, "ns1", verifier.Hash).Return(verifier, nil)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", nm.ctx, identity.ID).Return(identity, nil)
+	signerRef := &core.SignerRef{Key: "0x12345"}
+	mim.On("ResolveIdentitySigner", nm.ctx, identity).Return(signerRef, nil)
+
+	mds := nm.defsender.(*definitionsmocks.Sender)
+	mds.On("RevokeVerifier", nm.ctx, mock.MatchedBy(func(vr *core.VerifierRevocation) bool {
+		return vr.Identity.ID.Equals(identity.ID) && vr.Verifier.Value == "0x12345" && vr.Reason == "compromised"
+	}), signerRef, true).Return(nil)
+
+	revocation, err := nm.RevokeVerifier(nm.ctx, verifier.Hash.String(), &core.VerifierRevocationDTO{Reason: "compromised"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x12345", revocation.Verifier.Value)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mds.AssertExpectations(t)
+}
+
+func TestRevokeVerifierNotFound(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	hash := fftypes.NewRandB32()
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByHash", nm.ctx, "ns1", hash).Return(nil, nil)
+
+	_, err := nm.RevokeVerifier(nm.ctx, hash.String(), &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRevokeVerifierAlreadyRevoked(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	verifier := testVerifier(testOrg("org1"))
+	verifier.Revoked = fftypes.Now()
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByHash", nm.ctx, "ns1", verifier.Hash).Return(verifier, nil)
+
+	_, err := nm.RevokeVerifier(nm.ctx, verifier.Hash.String(), &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "FF10480", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRevokeVerifierIdentityLookupFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	identity := testOrg("org1")
+	verifier := testVerifier(identity)
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByHash", nm.ctx, "ns1", verifier.Hash).Return(verifier, nil)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", nm.ctx, identity.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.RevokeVerifier(nm.ctx, verifier.Hash.String(), &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestRevokeVerifierIdentityNotFound(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	identity := testOrg("org1")
+	verifier := testVerifier(identity)
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetVerifierByHash", nm.ctx, "ns1", verifier.Hash).Return(verifier, nil)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", nm.ctx, identity.ID).Return(nil, nil)
+
+	_, err := nm.RevokeVerifier(nm.ctx, verifier.Hash.String(), &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "FF10143", err)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestRevokeNodeOk(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := testNode("node1", testOrg("org1"))
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByName", nm.ctx, core.IdentityTypeNode, "ns1", "node1").Return(node, nil)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	signerRef := &core.SignerRef{Key: "0x12345"}
+	mim.On("ResolveIdentitySigner", nm.ctx, node).Return(signerRef, nil)
+
+	mds := nm.defsender.(*definitionsmocks.Sender)
+	mds.On("RevokeVerifier", nm.ctx, mock.MatchedBy(func(vr *core.VerifierRevocation) bool {
+		return vr.Identity.ID.Equals(node.ID) && vr.Verifier == nil
+	}), signerRef, false).Return(nil)
+
+	revocation, err := nm.RevokeNode(nm.ctx, "node1", &core.VerifierRevocationDTO{}, false)
+	assert.NoError(t, err)
+	assert.Nil(t, revocation.Verifier)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mds.AssertExpectations(t)
+}
+
+func TestRevokeNodeNotNode(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	org := testOrg("org1")
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByID", nm.ctx, "ns1", org.ID).Return(org, nil)
+
+	_, err := nm.RevokeNode(nm.ctx, org.ID.String(), &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRevokeNodeLookupFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	_, err := nm.RevokeNode(nm.ctx, "!bad", &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "FF00140", err)
+}
+
+func TestRevokeNodeResolveSignerFail(t *testing.T) {
+	nm, cancel := newTestNetworkmap(t)
+	defer cancel()
+
+	node := testNode("node1", testOrg("org1"))
+
+	mdi := nm.database.(*databasemocks.Plugin)
+	mdi.On("GetIdentityByName", nm.ctx, core.IdentityTypeNode, "ns1", "node1").Return(node, nil)
+
+	mim := nm.identity.(*identitymanagermocks.Manager)
+	mim.On("ResolveIdentitySigner", nm.ctx, node).Return(nil, fmt.Errorf("pop"))
+
+	_, err := nm.RevokeNode(nm.ctx, "node1", &core.VerifierRevocationDTO{}, false)
+	assert.Regexp(t, "pop", err)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
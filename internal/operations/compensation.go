@@ -0,0 +1,122 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// CompensationHandler can be registered for operation types whose effect can be reversed by a compensating
+// action - such as burning tokens that were minted by a transaction that could not be completed
+type CompensationHandler interface {
+	core.Named
+	// CompensateOperation submits an action that reverses the effect of a succeeded operation.
+	// Returns false if this particular operation has no effect that can be reversed.
+	CompensateOperation(ctx context.Context, op *core.Operation) (output fftypes.JSONObject, compensated bool, err error)
+}
+
+func (om *operationsManager) RegisterCompensation(ctx context.Context, handler CompensationHandler, ops []core.OpType) {
+	for _, opType := range ops {
+		log.L(ctx).Debugf("OpType=%s registered to compensation handler %s", opType, handler.Name())
+		om.compensations[opType] = handler
+	}
+}
+
+// CompensateTransaction attempts to reverse the effect of each succeeded operation in the transaction, in the
+// reverse order to which the operations were created. A record is kept of each attempt. Operations that have
+// already been compensated are not compensated again, and processing stops at the first compensating action
+// that fails - so the request can be made again once the problem is resolved.
+func (om *operationsManager) CompensateTransaction(ctx context.Context, txID *fftypes.UUID) ([]*core.Compensation, error) {
+	fb := database.CompensationQueryFactory.NewFilter(ctx)
+	previous, _, err := om.database.GetCompensations(ctx, om.namespace, fb.And(
+		fb.Eq("tx", txID),
+		fb.Neq("status", core.CompensationStatusFailed),
+	))
+	if err != nil {
+		return nil, err
+	}
+	done := make(map[fftypes.UUID]bool, len(previous))
+	for _, c := range previous {
+		done[*c.Operation] = true
+	}
+
+	ofb := database.OperationQueryFactory.NewFilter(ctx)
+	ops, _, err := om.database.GetOperations(ctx, om.namespace, ofb.And(
+		ofb.Eq("tx", txID),
+		ofb.Eq("status", core.OpStatusSucceeded),
+	).Sort("created").Descending())
+	if err != nil {
+		return nil, err
+	}
+
+	compensations := make([]*core.Compensation, 0, len(ops))
+	for _, op := range ops {
+		if done[*op.ID] {
+			log.L(ctx).Debugf("Operation %s has already been compensated", op.ID)
+			continue
+		}
+		compensation := om.compensateOperation(ctx, op)
+		if err := om.database.InsertCompensation(ctx, compensation); err != nil {
+			return nil, err
+		}
+		compensations = append(compensations, compensation)
+		if compensation.Status == core.CompensationStatusFailed {
+			break
+		}
+	}
+	return compensations, nil
+}
+
+func (om *operationsManager) compensateOperation(ctx context.Context, op *core.Operation) *core.Compensation {
+	compensation := &core.Compensation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   om.namespace,
+		Transaction: op.Transaction,
+		Operation:   op.ID,
+		Type:        op.Type,
+		Status:      core.CompensationStatusSkipped,
+		Created:     fftypes.Now(),
+	}
+
+	handler, ok := om.compensations[op.Type]
+	if !ok {
+		compensation.Error = i18n.NewError(ctx, coremsgs.MsgOperationNotCompensable, op.ID, op.Type).Error()
+		return compensation
+	}
+
+	log.L(ctx).Infof("Compensating %s operation %s via handler %s", op.Type, op.ID, handler.Name())
+	output, compensated, err := handler.CompensateOperation(ctx, op)
+	switch {
+	case err != nil:
+		log.L(ctx).Errorf("Failed to compensate %s operation %s: %s", op.Type, op.ID, err)
+		compensation.Status = core.CompensationStatusFailed
+		compensation.Error = err.Error()
+	case !compensated:
+		compensation.Error = i18n.NewError(ctx, coremsgs.MsgOperationNotCompensable, op.ID, op.Type).Error()
+	default:
+		compensation.Status = core.CompensationStatusSubmitted
+		compensation.Output = output
+	}
+	return compensation
+}
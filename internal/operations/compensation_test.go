@@ -0,0 +1,167 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockCompensationHandler struct {
+	Output      fftypes.JSONObject
+	Compensated bool
+	Err         error
+	Calls       []*core.Operation
+}
+
+func (m *mockCompensationHandler) Name() string {
+	return "MockCompensationHandler"
+}
+
+func (m *mockCompensationHandler) CompensateOperation(ctx context.Context, op *core.Operation) (fftypes.JSONObject, bool, error) {
+	m.Calls = append(m.Calls, op)
+	return m.Output, m.Compensated, m.Err
+}
+
+func succeededTestOperation(txID *fftypes.UUID, opType core.OpType) *core.Operation {
+	return &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: txID,
+		Type:        opType,
+		Status:      core.OpStatusSucceeded,
+	}
+}
+
+func TestCompensateTransaction(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	txID := fftypes.NewUUID()
+	transfer := succeededTestOperation(txID, core.OpTypeTokenTransfer)
+	alreadyDone := succeededTestOperation(txID, core.OpTypeTokenTransfer)
+	pin := succeededTestOperation(txID, core.OpTypeBlockchainPinBatch)
+	approval := succeededTestOperation(txID, core.OpTypeTokenApproval)
+
+	handler := &mockCompensationHandler{Compensated: true, Output: fftypes.JSONObject{"tx": "123"}}
+	om.RegisterCompensation(om.ctx, handler, []core.OpType{core.OpTypeTokenTransfer})
+	om.RegisterCompensation(om.ctx, &mockCompensationHandler{}, []core.OpType{core.OpTypeTokenApproval})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetCompensations", om.ctx, "ns1", mock.Anything).Return([]*core.Compensation{
+		{Operation: alreadyDone.ID, Status: core.CompensationStatusSubmitted},
+	}, nil, nil)
+	mdi.On("GetOperations", om.ctx, "ns1", mock.Anything).Return([]*core.Operation{approval, pin, alreadyDone, transfer}, nil, nil)
+	mdi.On("InsertCompensation", om.ctx, mock.Anything).Return(nil).Times(3)
+
+	compensations, err := om.CompensateTransaction(om.ctx, txID)
+	assert.NoError(t, err)
+	assert.Len(t, compensations, 3)
+
+	assert.Equal(t, approval.ID, compensations[0].Operation)
+	assert.Equal(t, core.CompensationStatusSkipped, compensations[0].Status)
+	assert.Regexp(t, "FF10496", compensations[0].Error)
+	assert.Equal(t, pin.ID, compensations[1].Operation)
+	assert.Equal(t, core.CompensationStatusSkipped, compensations[1].Status)
+	assert.Regexp(t, "FF10496", compensations[1].Error)
+	assert.Equal(t, transfer.ID, compensations[2].Operation)
+	assert.Equal(t, txID, compensations[2].Transaction)
+	assert.Equal(t, core.CompensationStatusSubmitted, compensations[2].Status)
+	assert.Equal(t, "123", compensations[2].Output.GetString("tx"))
+
+	assert.Equal(t, []*core.Operation{transfer}, handler.Calls)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCompensateTransactionStopsOnFailure(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	txID := fftypes.NewUUID()
+	op1 := succeededTestOperation(txID, core.OpTypeTokenTransfer)
+	op2 := succeededTestOperation(txID, core.OpTypeTokenTransfer)
+
+	handler := &mockCompensationHandler{Err: fmt.Errorf("pop")}
+	om.RegisterCompensation(om.ctx, handler, []core.OpType{core.OpTypeTokenTransfer})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetCompensations", om.ctx, "ns1", mock.Anything).Return([]*core.Compensation{}, nil, nil)
+	mdi.On("GetOperations", om.ctx, "ns1", mock.Anything).Return([]*core.Operation{op2, op1}, nil, nil)
+	mdi.On("InsertCompensation", om.ctx, mock.MatchedBy(func(c *core.Compensation) bool {
+		return c.Operation.Equals(op2.ID) && c.Status == core.CompensationStatusFailed && c.Error == "pop"
+	})).Return(nil).Once()
+
+	compensations, err := om.CompensateTransaction(om.ctx, txID)
+	assert.NoError(t, err)
+	assert.Len(t, compensations, 1)
+	assert.Len(t, handler.Calls, 1)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCompensateTransactionGetCompensationsFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetCompensations", om.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := om.CompensateTransaction(om.ctx, fftypes.NewUUID())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCompensateTransactionGetOperationsFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetCompensations", om.ctx, "ns1", mock.Anything).Return([]*core.Compensation{}, nil, nil)
+	mdi.On("GetOperations", om.ctx, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := om.CompensateTransaction(om.ctx, fftypes.NewUUID())
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestCompensateTransactionInsertFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	txID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetCompensations", om.ctx, "ns1", mock.Anything).Return([]*core.Compensation{}, nil, nil)
+	mdi.On("GetOperations", om.ctx, "ns1", mock.Anything).Return([]*core.Operation{
+		succeededTestOperation(txID, core.OpTypeBlockchainInvoke),
+	}, nil, nil)
+	mdi.On("InsertCompensation", om.ctx, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := om.CompensateTransaction(om.ctx, txID)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+)
+
+const (
+	// OpRetryPolicyType the type of operation the policy applies to
+	OpRetryPolicyType = "type"
+	// OpRetryPolicyMaxAttempts the maximum number of attempts, including the original operation
+	OpRetryPolicyMaxAttempts = "maxAttempts"
+	// OpRetryPolicyInitialDelay the delay before the first automatic retry
+	OpRetryPolicyInitialDelay = "initialDelay"
+	// OpRetryPolicyMaxDelay the maximum delay between automatic retries
+	OpRetryPolicyMaxDelay = "maxDelay"
+	// OpRetryPolicyFactor the backoff factor applied to the delay after each attempt
+	OpRetryPolicyFactor = "factor"
+
+	// OpWatchdogInterval how often the watchdog checks for stalled operations
+	OpWatchdogInterval = "interval"
+	// OpWatchdogThresholds the thresholds after which pending operations of each type are considered stalled
+	OpWatchdogThresholds = "thresholds"
+	// OpWatchdogThresholdType the type of operation the threshold applies to
+	OpWatchdogThresholdType = "type"
+	// OpWatchdogThresholdTimeout how long an operation can remain pending before it is considered stalled
+	OpWatchdogThresholdTimeout = "timeout"
+
+	// OpRecoveryEnabled whether operations and batches that were in-flight when the node stopped are recovered on startup
+	OpRecoveryEnabled = "enabled"
+	// OpRecoveryLimit the maximum number of in-flight operations, and batches, that are recovered on startup
+	OpRecoveryLimit = "limit"
+
+	// OpQueueEnabled whether operations are recorded in a durable queue until their plugin has been called
+	OpQueueEnabled = "enabled"
+	// OpQueueWorkers the maximum number of plugin calls for operations that are made concurrently
+	OpQueueWorkers = "workers"
+	// OpQueuePollInterval how often the queue is checked for operations that were never submitted
+	OpQueuePollInterval = "pollInterval"
+	// OpQueueBatchSize the maximum number of queued operations submitted on each poll
+	OpQueueBatchSize = "batchSize"
+
+	// OpReceiptsPollInterval how often the receipts of transactions attached to operations are looked up
+	OpReceiptsPollInterval = "pollInterval"
+
+	// OpLimitsMaxInputSize the maximum size of the input JSON persisted on an operation, before it is stored as data
+	OpLimitsMaxInputSize = "maxInputSize"
+	// OpLimitsMaxOutputSize the maximum size of the output JSON persisted on an operation, before it is stored as data
+	OpLimitsMaxOutputSize = "maxOutputSize"
+)
+
+var retryPoliciesConfig = config.RootArray("opretry.policies")
+
+var watchdogConfig = config.RootSection("opwatchdog")
+
+var watchdogThresholdsConfig = watchdogConfig.SubArray(OpWatchdogThresholds)
+
+var recoveryConfig = config.RootSection("oprecovery")
+
+var limitsConfig = config.RootSection("oplimits")
+
+var queueConfig = config.RootSection("opqueue")
+
+var receiptsConfig = config.RootSection("opreceipts")
+
+func InitConfig() {
+	retryPoliciesConfig.AddKnownKey(OpRetryPolicyType)
+	retryPoliciesConfig.AddKnownKey(OpRetryPolicyMaxAttempts, 3)
+	retryPoliciesConfig.AddKnownKey(OpRetryPolicyInitialDelay, "5s")
+	retryPoliciesConfig.AddKnownKey(OpRetryPolicyMaxDelay, "1m")
+	retryPoliciesConfig.AddKnownKey(OpRetryPolicyFactor, 2.0)
+
+	watchdogConfig.AddKnownKey(OpWatchdogInterval, "1m")
+	watchdogThresholdsConfig.AddKnownKey(OpWatchdogThresholdType)
+	watchdogThresholdsConfig.AddKnownKey(OpWatchdogThresholdTimeout, "5m")
+
+	recoveryConfig.AddKnownKey(OpRecoveryEnabled, true)
+	recoveryConfig.AddKnownKey(OpRecoveryLimit, 1000)
+
+	queueConfig.AddKnownKey(OpQueueEnabled, false)
+	queueConfig.AddKnownKey(OpQueueWorkers, 10)
+	queueConfig.AddKnownKey(OpQueuePollInterval, "10s")
+	queueConfig.AddKnownKey(OpQueueBatchSize, 50)
+
+	receiptsConfig.AddKnownKey(OpReceiptsPollInterval, "5s")
+
+	limitsConfig.AddKnownKey(OpLimitsMaxInputSize, "1Mb")
+	limitsConfig.AddKnownKey(OpLimitsMaxOutputSize, "1Mb")
+}
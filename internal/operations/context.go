@@ -28,6 +28,7 @@ import (
 
 type operationContextKey struct{}
 type operationContext map[string]*core.Operation
+type operationTagsContextKey struct{}
 
 func getOperationContext(ctx context.Context) operationContext {
 	ctxKey := operationContextKey{}
@@ -65,7 +66,28 @@ func RunWithOperationContext(ctx context.Context, fn func(ctx context.Context) e
 	return fn(createOperationRetryContext(ctx))
 }
 
+// WithOperationTags returns a context in which every operation that is created is tagged with
+// the supplied application-defined tags
+func WithOperationTags(ctx context.Context, tags fftypes.FFStringArray) context.Context {
+	return context.WithValue(ctx, operationTagsContextKey{}, tags)
+}
+
+// TagOperations applies the tags from the context to any of the operations that do not have their own
+func TagOperations(ctx context.Context, ops ...*core.Operation) {
+	tags, ok := ctx.Value(operationTagsContextKey{}).(fftypes.FFStringArray)
+	if !ok || len(tags) == 0 {
+		return
+	}
+	for _, op := range ops {
+		if len(op.Tags) == 0 {
+			op.Tags = tags
+		}
+	}
+}
+
 func (om *operationsManager) AddOrReuseOperation(ctx context.Context, op *core.Operation, hooks ...database.PostCompletionHook) error {
+	TagOperations(ctx, op)
+
 	// If a ops has been created via RunWithOperationCache, detect duplicate operation inserts
 	ops := getOperationContext(ctx)
 	if ops != nil {
@@ -78,7 +100,7 @@ func (om *operationsManager) AddOrReuseOperation(ctx context.Context, op *core.O
 				}
 				return nil
 			}
-			if err = om.database.InsertOperation(ctx, op, hooks...); err != nil {
+			if err = om.insertOperation(ctx, op, hooks...); err != nil {
 				return err
 			}
 			ops[key] = op
@@ -86,13 +108,25 @@ func (om *operationsManager) AddOrReuseOperation(ctx context.Context, op *core.O
 			return nil
 		}
 	}
-	err := om.database.InsertOperation(ctx, op, hooks...)
+	err := om.insertOperation(ctx, op, hooks...)
 	if err == nil {
 		om.cacheOperation(op)
 	}
 	return err
 }
 
+func (om *operationsManager) insertOperation(ctx context.Context, op *core.Operation, hooks ...database.PostCompletionHook) error {
+	// Only a reference is persisted for an input that exceeds the size limit
+	dbOp, err := om.limits.limitInput(ctx, op)
+	if err != nil {
+		return err
+	}
+	if err := om.database.InsertOperation(ctx, dbOp, hooks...); err != nil {
+		return err
+	}
+	return om.queue.enqueue(ctx, op)
+}
+
 func (om *operationsManager) BulkInsertOperations(ctx context.Context, ops ...*core.Operation) error {
 	// This efficiently inserts the operations.
 	// It's all-or nothing success/failure, as ops individually don't have idempotency duplicates to
@@ -100,7 +134,18 @@ func (om *operationsManager) BulkInsertOperations(ctx context.Context, ops ...*c
 	//
 	// Thin wrapper on the database, that manages cache. Expected to be run on a batch worker setting
 	// up idempotent transactions, not the context of an individual operation.
-	if err := om.database.InsertOperations(ctx, ops); err != nil {
+	dbOps := make([]*core.Operation, len(ops))
+	for i, op := range ops {
+		dbOp, err := om.limits.limitInput(ctx, op)
+		if err != nil {
+			return err
+		}
+		dbOps[i] = dbOp
+	}
+	if err := om.database.InsertOperations(ctx, dbOps); err != nil {
+		return err
+	}
+	if err := om.queue.enqueue(ctx, ops...); err != nil {
 		return err
 	}
 	for _, op := range ops {
@@ -128,6 +128,35 @@ func TestAddOrReuseOperationNoContext(t *testing.T) {
 	mdi.AssertExpectations(t)
 }
 
+func TestAddOrReuseOperationTagged(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	ctx := WithOperationTags(context.Background(), fftypes.FFStringArray{"invoice-123"})
+	op1 := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainPinBatch,
+	}
+	op2 := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainPinBatch,
+		Tags: fftypes.FFStringArray{"retry-of-invoice-456"},
+	}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperation", ctx, op1).Return(nil).Once()
+	mdi.On("InsertOperation", ctx, op2).Return(nil).Once()
+
+	err := om.AddOrReuseOperation(ctx, op1)
+	assert.NoError(t, err)
+	err = om.AddOrReuseOperation(ctx, op2)
+	assert.NoError(t, err)
+	assert.Equal(t, fftypes.FFStringArray{"invoice-123"}, op1.Tags)
+	assert.Equal(t, fftypes.FFStringArray{"retry-of-invoice-456"}, op2.Tags)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestGetContextKeyBadJSON(t *testing.T) {
 	op := &core.Operation{
 		Input: fftypes.JSONObject{
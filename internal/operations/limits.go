@@ -0,0 +1,122 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// operationLimits keeps the operations table healthy, by storing any input or output JSON that exceeds
+// the configured size as a data record, and persisting only a reference to that data on the operation
+type operationLimits struct {
+	manager       *operationsManager
+	maxInputSize  int64
+	maxOutputSize int64
+}
+
+func newOperationLimits(om *operationsManager) *operationLimits {
+	return &operationLimits{
+		manager:       om,
+		maxInputSize:  limitsConfig.GetByteSize(OpLimitsMaxInputSize),
+		maxOutputSize: limitsConfig.GetByteSize(OpLimitsMaxOutputSize),
+	}
+}
+
+// limitInput returns the operation to persist. This is the supplied operation if its input is within
+// the limit, or a copy referencing the overflow data otherwise - so the caller can continue to use the full input
+func (l *operationLimits) limitInput(ctx context.Context, op *core.Operation) (*core.Operation, error) {
+	input, err := l.limit(ctx, op.ID, "input", op.Input, l.maxInputSize)
+	if err != nil || input == nil {
+		return op, err
+	}
+	limited := *op
+	limited.Input = input
+	return &limited, nil
+}
+
+// limitOutput returns the output to persist, which references the overflow data if it exceeds the limit
+func (l *operationLimits) limitOutput(ctx context.Context, opID *fftypes.UUID, output fftypes.JSONObject) (fftypes.JSONObject, error) {
+	limited, err := l.limit(ctx, opID, "output", output, l.maxOutputSize)
+	if err != nil || limited == nil {
+		return output, err
+	}
+	return limited, nil
+}
+
+func (l *operationLimits) limit(ctx context.Context, opID *fftypes.UUID, field string, obj fftypes.JSONObject, maxSize int64) (fftypes.JSONObject, error) {
+	if maxSize <= 0 || obj == nil {
+		return nil, nil
+	}
+	value := obj.String()
+	if int64(len(value)) <= maxSize {
+		return nil, nil
+	}
+
+	data := &core.Data{
+		ID:        fftypes.NewUUID(),
+		Validator: core.ValidatorTypeJSON,
+		Namespace: l.manager.namespace,
+		Hash:      fftypes.HashString(value),
+		Created:   fftypes.Now(),
+		Value:     fftypes.JSONAnyPtr(value),
+		ValueSize: int64(len(value)),
+	}
+	if err := l.manager.database.UpsertData(ctx, data, database.UpsertOptimizationNew); err != nil {
+		return nil, err
+	}
+	log.L(ctx).Infof("Stored %s of operation %s as data %s, as its size %d exceeds the limit of %d", field, opID, data.ID, len(value), maxSize)
+	return fftypes.JSONObject{
+		core.OperationOverflowKey: fftypes.JSONObject{
+			"data": data.ID.String(),
+			"hash": data.Hash.String(),
+			"size": len(value),
+		},
+	}, nil
+}
+
+// restoreInput replaces an input that was stored as overflow data, with the full input from that data
+func (l *operationLimits) restoreInput(ctx context.Context, op *core.Operation) error {
+	overflow, ok := op.Input.GetObjectOk(core.OperationOverflowKey)
+	if !ok || len(op.Input) != 1 {
+		return nil
+	}
+	dataID, err := fftypes.ParseUUID(ctx, overflow.GetString("data"))
+	if err != nil {
+		return err
+	}
+	data, err := l.manager.database.GetDataByID(ctx, l.manager.namespace, dataID, true)
+	if err != nil {
+		return err
+	}
+	if data == nil || data.Value == nil {
+		return i18n.NewError(ctx, coremsgs.MsgOperationOverflowNotFound, op.ID, dataID)
+	}
+	var input fftypes.JSONObject
+	if err := json.Unmarshal(data.Value.Bytes(), &input); err != nil {
+		return err
+	}
+	op.Input = input
+	return nil
+}
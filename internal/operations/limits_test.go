@@ -0,0 +1,254 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestOperationsWithLimits(t *testing.T) (*operationsManager, func()) {
+	om, cancel := newTestOperations(t)
+	limitsConfig.Set(OpLimitsMaxInputSize, 100)
+	limitsConfig.Set(OpLimitsMaxOutputSize, 100)
+	om.limits = newOperationLimits(om)
+	return om, cancel
+}
+
+func largeJSON() fftypes.JSONObject {
+	return fftypes.JSONObject{"value": strings.Repeat("a", 200)}
+}
+
+func TestLimitInputWithinLimit(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Input: fftypes.JSONObject{"small": true}}
+	dbOp, err := om.limits.limitInput(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Same(t, op, dbOp)
+}
+
+func TestLimitInputDisabled(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	config.Set("oplimits.maxInputSize", 0)
+	om.limits = newOperationLimits(om)
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Input: largeJSON()}
+	dbOp, err := om.limits.limitInput(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Same(t, op, dbOp)
+}
+
+func TestAddOperationInputOverflow(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Input: largeJSON()}
+	var dataID *fftypes.UUID
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("UpsertData", mock.Anything, mock.MatchedBy(func(data *core.Data) bool {
+		dataID = data.ID
+		return data.Namespace == "ns1" && data.Value.String() == largeJSON().String() &&
+			data.Hash.Equals(fftypes.HashString(largeJSON().String()))
+	}), database.UpsertOptimizationNew).Return(nil)
+	mdi.On("InsertOperation", mock.Anything, mock.MatchedBy(func(dbOp *core.Operation) bool {
+		overflow := dbOp.Input.GetObject(core.OperationOverflowKey)
+		return dbOp.ID.Equals(op.ID) && overflow.GetString("data") == dataID.String() && overflow.GetInt64("size") == 212
+	})).Return(nil)
+
+	err := om.AddOrReuseOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, largeJSON().String(), op.Input.String())
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAddOperationInputOverflowFail(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Input: largeJSON()}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(fmt.Errorf("pop"))
+
+	err := om.AddOrReuseOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestBulkInsertOperationsInputOverflowFail(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeBlockchainInvoke, Input: largeJSON()}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(fmt.Errorf("pop"))
+
+	err := om.BulkInsertOperations(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveOperationOutputOverflow(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	opID := fftypes.NewUUID()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(nil)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", opID, mock.Anything, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		for _, set := range info.SetOperations {
+			if set.Field == "output" {
+				val, _ := set.Value.Value()
+				return strings.Contains(string(val.([]byte)), core.OperationOverflowKey)
+			}
+		}
+		return false
+	})).Return(true, nil)
+
+	err := om.ResolveOperationByID(context.Background(), opID, &core.OperationUpdateDTO{
+		Status: core.OpStatusSucceeded,
+		Output: largeJSON(),
+	})
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestResolveOperationOutputOverflowFail(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(fmt.Errorf("pop"))
+
+	err := om.ResolveOperationByID(context.Background(), fftypes.NewUUID(), &core.OperationUpdateDTO{
+		Status: core.OpStatusSucceeded,
+		Output: largeJSON(),
+	})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPrepareOperationRestoresInput(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	dataID := fftypes.NewUUID()
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeBlockchainInvoke,
+		Input: fftypes.JSONObject{
+			core.OperationOverflowKey: map[string]interface{}{"data": dataID.String()},
+		},
+	}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(&core.Data{
+		ID:    dataID,
+		Value: fftypes.JSONAnyPtr(largeJSON().String()),
+	}, nil)
+	mockHandler := &mockHandler{}
+	om.RegisterHandler(context.Background(), mockHandler, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	_, err := om.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, largeJSON().String(), op.Input.String())
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRestoreInputBadDataID(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	op := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Type:  core.OpTypeBlockchainInvoke,
+		Input: fftypes.JSONObject{core.OperationOverflowKey: fftypes.JSONObject{"data": "bad"}},
+	}
+	om.RegisterHandler(context.Background(), &mockHandler{}, []core.OpType{core.OpTypeBlockchainInvoke})
+	_, err := om.PrepareOperation(context.Background(), op)
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestRestoreInputLookupFail(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	dataID := fftypes.NewUUID()
+	op := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Input: fftypes.JSONObject{core.OperationOverflowKey: fftypes.JSONObject{"data": dataID.String()}},
+	}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(nil, fmt.Errorf("pop"))
+
+	err := om.limits.restoreInput(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestRestoreInputNotFound(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	dataID := fftypes.NewUUID()
+	op := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Input: fftypes.JSONObject{core.OperationOverflowKey: fftypes.JSONObject{"data": dataID.String()}},
+	}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(nil, nil)
+
+	err := om.limits.restoreInput(context.Background(), op)
+	assert.Regexp(t, "FF10529", err)
+}
+
+func TestRestoreInputBadJSON(t *testing.T) {
+	om, cancel := newTestOperationsWithLimits(t)
+	defer cancel()
+
+	dataID := fftypes.NewUUID()
+	op := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Input: fftypes.JSONObject{core.OperationOverflowKey: fftypes.JSONObject{"data": dataID.String()}},
+	}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetDataByID", mock.Anything, "ns1", dataID, true).Return(&core.Data{
+		ID:    dataID,
+		Value: fftypes.JSONAnyPtr(`"not an object"`),
+	}, nil)
+
+	err := om.limits.restoreInput(context.Background(), op)
+	assert.Error(t, err)
+}
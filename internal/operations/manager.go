@@ -21,12 +21,14 @@ import (
 	"database/sql/driver"
 	"fmt"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
@@ -41,6 +43,9 @@ type OperationHandler interface {
 
 type Manager interface {
 	RegisterHandler(ctx context.Context, handler OperationHandler, ops []core.OpType)
+	RegisterCompensation(ctx context.Context, handler CompensationHandler, ops []core.OpType)
+	RegisterReconciler(ctx context.Context, reconciler OperationReconciler, ops []core.OpType)
+	RegisterReceiptFetcher(ctx context.Context, fetcher ReceiptFetcher, ops []core.OpType)
 	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
 	RunOperation(ctx context.Context, op *core.PreparedOperation, idempotentSubmit bool) (fftypes.JSONObject, error)
 	RetryOperation(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error)
@@ -50,6 +55,10 @@ type Manager interface {
 	SubmitOperationUpdate(update *core.OperationUpdate)
 	GetOperationByIDCached(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error)
 	ResolveOperationByID(ctx context.Context, opID *fftypes.UUID, op *core.OperationUpdateDTO) error
+	AttachTransaction(ctx context.Context, opID *fftypes.UUID, attach *core.OperationAttachTX) (*core.Operation, error)
+	GetStalledOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)
+	CompensateTransaction(ctx context.Context, txID *fftypes.UUID) ([]*core.Compensation, error)
+	GetRecoveryReport(ctx context.Context) *core.RecoveryReport
 	Start() error
 	WaitStop()
 }
@@ -68,17 +77,27 @@ func ErrTernary(err error, ifErr, ifNoError core.OpPhase) core.OpPhase {
 }
 
 type operationsManager struct {
-	ctx       context.Context
-	namespace string
-	database  database.Plugin
-	handlers  map[core.OpType]OperationHandler
-	txHelper  txcommon.Helper
-	updater   *operationUpdater
-	cache     cache.CInterface
+	ctx             context.Context
+	namespace       string
+	database        database.Plugin
+	metrics         metrics.Manager
+	handlers        map[core.OpType]OperationHandler
+	compensations   map[core.OpType]CompensationHandler
+	reconcilers     map[core.OpType]OperationReconciler
+	receiptFetchers map[core.OpType]ReceiptFetcher
+	txHelper        txcommon.Helper
+	updater         *operationUpdater
+	retries         *retryEngine
+	watchdog        *operationWatchdog
+	recovery        *operationRecovery
+	limits          *operationLimits
+	queue           *submissionQueue
+	receipts        *receiptTracker
+	cache           cache.CInterface
 }
 
-func NewOperationsManager(ctx context.Context, ns string, di database.Plugin, txHelper txcommon.Helper, cacheManager cache.Manager) (Manager, error) {
-	if di == nil || txHelper == nil {
+func NewOperationsManager(ctx context.Context, ns string, di database.Plugin, txHelper txcommon.Helper, mm metrics.Manager, cacheManager cache.Manager) (Manager, error) {
+	if di == nil || txHelper == nil || mm == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "OperationsManager")
 	}
 
@@ -96,14 +115,26 @@ func NewOperationsManager(ctx context.Context, ns string, di database.Plugin, tx
 	}
 
 	om := &operationsManager{
-		ctx:       ctx,
-		namespace: ns,
-		database:  di,
-		txHelper:  txHelper,
-		handlers:  make(map[core.OpType]OperationHandler),
+		ctx:             ctx,
+		namespace:       ns,
+		database:        di,
+		metrics:         mm,
+		txHelper:        txHelper,
+		handlers:        make(map[core.OpType]OperationHandler),
+		compensations:   make(map[core.OpType]CompensationHandler),
+		reconcilers:     make(map[core.OpType]OperationReconciler),
+		receiptFetchers: make(map[core.OpType]ReceiptFetcher),
 	}
 	om.updater = newOperationUpdater(ctx, om, di, txHelper)
 	om.cache = cache
+	if om.retries, err = newRetryEngine(ctx, om); err != nil {
+		return nil, err
+	}
+	om.watchdog = newOperationWatchdog(ctx, om)
+	om.recovery = newOperationRecovery(ctx, om)
+	om.limits = newOperationLimits(om)
+	om.queue = newSubmissionQueue(ctx, om)
+	om.receipts = newReceiptTracker(ctx, om)
 	return om, nil
 }
 
@@ -119,6 +150,9 @@ func (om *operationsManager) PrepareOperation(ctx context.Context, op *core.Oper
 	if !ok {
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
 	}
+	if err := om.limits.restoreInput(ctx, op); err != nil {
+		return nil, err
+	}
 	return handler.PrepareOperation(ctx, op)
 }
 
@@ -163,6 +197,10 @@ func (om *operationsManager) ResubmitOperations(ctx context.Context, txID *fftyp
 }
 
 func (om *operationsManager) RunOperation(ctx context.Context, op *core.PreparedOperation, idempotentSubmit bool) (fftypes.JSONObject, error) {
+	return om.queue.submit(ctx, op, idempotentSubmit)
+}
+
+func (om *operationsManager) runOperation(ctx context.Context, op *core.PreparedOperation, idempotentSubmit bool) (fftypes.JSONObject, error) {
 	handler, ok := om.handlers[op.Type]
 	if !ok {
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
@@ -172,9 +210,10 @@ func (om *operationsManager) RunOperation(ctx context.Context, op *core.Prepared
 	outputs, phase, err := handler.RunOperation(ctx, op)
 	if err != nil {
 		conflictErr, conflictTestOk := err.(ConflictError)
+		conflict := conflictTestOk && conflictErr.IsConflictError()
 		var failState core.OpStatus
 		switch {
-		case conflictTestOk && conflictErr.IsConflictError():
+		case conflict:
 			// We are now pending - we know the connector has the action we're attempting to submit
 			//
 			// The async processing in SubmitOperationUpdate does not allow us to go back to pending, if
@@ -193,6 +232,9 @@ func (om *operationsManager) RunOperation(ctx context.Context, op *core.Prepared
 			// Ok, we're failed
 			failState = core.OpStatusFailed
 		}
+		if !conflict && om.metrics.IsMetricsEnabled() {
+			om.metrics.PluginOperationFailed(om.namespace, op.Plugin, op.Type)
+		}
 		om.SubmitOperationUpdate(&core.OperationUpdate{
 			NamespacedOpID: op.NamespacedIDString(),
 			Plugin:         op.Plugin,
@@ -256,7 +298,7 @@ func (om *operationsManager) RetryOperation(ctx context.Context, opID *fftypes.U
 		op.Output = nil
 		op.Created = fftypes.Now()
 		op.Updated = op.Created
-		if err = om.database.InsertOperation(ctx, op); err != nil {
+		if err = om.insertOperation(ctx, op); err != nil {
 			return err
 		}
 		om.cacheOperation(op)
@@ -284,6 +326,14 @@ func (om *operationsManager) ResolveOperationByID(ctx context.Context, opID *fft
 	return om.updater.resolveOperation(ctx, om.namespace, opID, op.Status, op.Error, op.Output)
 }
 
+func (om *operationsManager) GetStalledOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	stalledFilter, ok := om.watchdog.stalledFilter(filter)
+	if !ok {
+		return []*core.Operation{}, nil, nil
+	}
+	return om.database.GetOperations(ctx, om.namespace, stalledFilter)
+}
+
 func (om *operationsManager) SubmitOperationUpdate(update *core.OperationUpdate) {
 	errString := ""
 	if update.ErrorMessage != "" {
@@ -295,10 +345,19 @@ func (om *operationsManager) SubmitOperationUpdate(update *core.OperationUpdate)
 
 func (om *operationsManager) Start() error {
 	om.updater.start()
+	om.watchdog.start()
+	om.recovery.start()
+	om.queue.start()
+	om.receipts.start()
 	return nil
 }
 
 func (om *operationsManager) WaitStop() {
+	om.receipts.close()
+	om.queue.close()
+	om.recovery.close()
+	om.watchdog.close()
+	om.retries.close()
 	om.updater.close()
 }
 
@@ -31,6 +31,7 @@ import (
 	"github.com/hyperledger/firefly/mocks/cachemocks"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/datamocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,7 @@ func (m *mockHandler) OnOperationUpdate(ctx context.Context, op *core.Operation,
 
 func newTestOperations(t *testing.T) (*operationsManager, func()) {
 	coreconfig.Reset()
+	InitConfig()
 	config.Set(coreconfig.OpUpdateWorkerCount, 1)
 	ctx, cancel := context.WithCancel(context.Background())
 	mdi := &databasemocks.Plugin{}
@@ -94,8 +96,11 @@ func newTestOperations(t *testing.T) (*operationsManager, func()) {
 		}
 	}
 
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+
 	ns := "ns1"
-	om, err := NewOperationsManager(ctx, ns, mdi, txHelper, cmi)
+	om, err := NewOperationsManager(ctx, ns, mdi, txHelper, mmi, cmi)
 	assert.NoError(t, err)
 	cmi.AssertCalled(t, "GetCache", cache.NewCacheConfig(
 		ctx,
@@ -107,13 +112,14 @@ func newTestOperations(t *testing.T) (*operationsManager, func()) {
 }
 
 func TestInitFail(t *testing.T) {
-	_, err := NewOperationsManager(context.Background(), "ns1", nil, nil, nil)
+	_, err := NewOperationsManager(context.Background(), "ns1", nil, nil, nil, nil)
 	assert.Regexp(t, "FF10128", err)
 }
 
 func TestCacheInitFail(t *testing.T) {
 	cacheInitError := errors.New("Initialization error.")
 	coreconfig.Reset()
+	InitConfig()
 	config.Set(coreconfig.OpUpdateWorkerCount, 1)
 	mdi := &databasemocks.Plugin{}
 	mdi.On("Capabilities").Return(&database.Capabilities{
@@ -127,10 +133,27 @@ func TestCacheInitFail(t *testing.T) {
 	ns := "ns1"
 	ecmi := &cachemocks.Manager{}
 	ecmi.On("GetCache", mock.Anything).Return(nil, cacheInitError)
-	_, err := NewOperationsManager(ctx, ns, mdi, txHelper, ecmi)
+	_, err := NewOperationsManager(ctx, ns, mdi, txHelper, &metricsmocks.Manager{}, ecmi)
 	assert.Equal(t, cacheInitError, err)
 }
 
+func TestRetryEngineInitFail(t *testing.T) {
+	coreconfig.Reset()
+	setRetryPolicyConfig(t, "type: blockchain_invoke")
+	config.Set(coreconfig.OpUpdateWorkerCount, 1)
+	mdi := &databasemocks.Plugin{}
+	mdi.On("Capabilities").Return(&database.Capabilities{
+		Concurrency: true,
+	})
+	mdm := &datamocks.Manager{}
+	ctx := context.Background()
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
+	_, err := NewOperationsManager(ctx, "ns1", mdi, txHelper, &metricsmocks.Manager{}, cmi)
+	assert.Regexp(t, "FF10495", err)
+}
+
 func TestPrepareOperationNotSupported(t *testing.T) {
 	om, cancel := newTestOperations(t)
 	defer cancel()
@@ -244,9 +267,15 @@ func TestRunOperationFailNonIdempotentInit(t *testing.T) {
 	op := &core.PreparedOperation{
 		ID:        fftypes.NewUUID(),
 		Namespace: "ns1",
+		Plugin:    "ethereum",
 		Type:      core.OpTypeBlockchainPinBatch,
 	}
 
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("PluginOperationFailed", "ns1", "ethereum", core.OpTypeBlockchainPinBatch).Return()
+	om.metrics = mmi
+
 	om.RegisterHandler(ctx, &mockHandler{
 		RunErr: fmt.Errorf("pop"),
 		Phase:  core.OpPhaseInitializing,
@@ -258,6 +287,7 @@ func TestRunOperationFailNonIdempotentInit(t *testing.T) {
 	assert.Equal(t, core.OpStatusFailed, update.Status)
 
 	assert.EqualError(t, err, "pop")
+	mmi.AssertExpectations(t)
 }
 
 func TestRunOperationFailConflict(t *testing.T) {
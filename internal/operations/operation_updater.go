@@ -298,6 +298,10 @@ func (ou *operationUpdater) doUpdate(ctx context.Context, update *core.Operation
 		return err
 	}
 
+	if update.Status == core.OpStatusFailed {
+		ou.manager.retries.operationFailed(op)
+	}
+
 	return nil
 }
 
@@ -367,6 +371,9 @@ func (ou *operationUpdater) resolveOperation(ctx context.Context, ns string, id
 		update = update.Set("error", *errorMsg)
 	}
 	if output != nil {
+		if output, err = ou.manager.limits.limitOutput(ctx, id, output); err != nil {
+			return err
+		}
 		update = update.Set("output", output)
 	}
 	ok, err := ou.database.UpdateOperation(ctx, ns, id, filter, update)
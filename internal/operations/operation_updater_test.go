@@ -67,6 +67,7 @@ func newTestOperationUpdaterCommon(t *testing.T, dbCapabilities *database.Capabi
 	cmi := &cachemocks.Manager{}
 	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
 	txHelper, _ := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
+	mom.retries, _ = newRetryEngine(ctx, mom)
 	return newOperationUpdater(context.Background(), mom, mdi, txHelper)
 }
 
@@ -217,6 +218,7 @@ func TestSubmitUpdateWorkerE2ESuccess(t *testing.T) {
 		close(done)
 	}).Once()
 
+	om.recovery.enabled = false
 	om.Start()
 	om.SubmitOperationUpdate(&core.OperationUpdate{
 		NamespacedOpID: "ns1:" + opID1.String(),
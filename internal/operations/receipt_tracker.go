@@ -0,0 +1,215 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// attachedTXOutputKey is the key in the output of an operation that records the hash of an attached transaction,
+// so that tracking resumes after a restart
+const attachedTXOutputKey = "transactionHash"
+
+// ReceiptFetcher can be registered for operation types where a transaction that was submitted outside of
+// FireFly, such as one signed in a wallet, can be attached to the operation
+type ReceiptFetcher interface {
+	core.Named
+	// GetTransactionReceipt returns the receipt of a transaction, or nil if it has not yet been mined
+	GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error)
+}
+
+func (om *operationsManager) RegisterReceiptFetcher(ctx context.Context, fetcher ReceiptFetcher, ops []core.OpType) {
+	for _, opType := range ops {
+		log.L(ctx).Debugf("OpType=%s registered to receipt fetcher %s", opType, fetcher.Name())
+		om.receiptFetchers[opType] = fetcher
+	}
+}
+
+// AttachTransaction records the hash of a transaction that was submitted outside of FireFly against an operation,
+// and tracks its receipt until the operation can be completed
+func (om *operationsManager) AttachTransaction(ctx context.Context, opID *fftypes.UUID, attach *core.OperationAttachTX) (*core.Operation, error) {
+	if attach.TransactionHash == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAttachTxHashMissing)
+	}
+	op, err := om.database.GetOperationByID(ctx, om.namespace, opID)
+	if err != nil {
+		return nil, err
+	}
+	if op == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if _, ok := om.receiptFetchers[op.Type]; !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAttachTxNotSupported, op.Type)
+	}
+	if op.Status == core.OpStatusSucceeded || op.Status == core.OpStatusFailed {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAttachTxOpComplete, op.ID, op.Status)
+	}
+
+	output := fftypes.JSONObject{}
+	for k, v := range op.Output {
+		output[k] = v
+	}
+	output[attachedTXOutputKey] = attach.TransactionHash
+	if err := om.updater.resolveOperation(ctx, om.namespace, op.ID, core.OpStatusPending, nil, output); err != nil {
+		return nil, err
+	}
+	op.Status = core.OpStatusPending
+	op.Output = output
+	log.L(ctx).Infof("Transaction %s attached to operation %s", attach.TransactionHash, op.ID)
+	om.receipts.track(op, attach.TransactionHash)
+	return op, nil
+}
+
+type trackedReceipt struct {
+	op     *core.Operation
+	txHash string
+}
+
+// receiptTracker polls the connector for the receipts of transactions attached to pending operations,
+// and delivers each as an operation update once the transaction has been mined
+type receiptTracker struct {
+	ctx        context.Context
+	cancelFunc func()
+	manager    *operationsManager
+	interval   time.Duration
+	tracked    map[fftypes.UUID]*trackedReceipt
+	mux        sync.Mutex
+	wg         sync.WaitGroup
+}
+
+func newReceiptTracker(ctx context.Context, om *operationsManager) *receiptTracker {
+	rt := &receiptTracker{
+		manager:  om,
+		interval: receiptsConfig.GetDuration(OpReceiptsPollInterval),
+		tracked:  make(map[fftypes.UUID]*trackedReceipt),
+	}
+	rt.ctx, rt.cancelFunc = context.WithCancel(ctx)
+	return rt
+}
+
+func (rt *receiptTracker) track(op *core.Operation, txHash string) {
+	rt.mux.Lock()
+	defer rt.mux.Unlock()
+	rt.tracked[*op.ID] = &trackedReceipt{op: op, txHash: txHash}
+}
+
+func (rt *receiptTracker) start() {
+	if len(rt.manager.receiptFetchers) == 0 {
+		return
+	}
+	rt.wg.Add(1)
+	go rt.trackLoop()
+}
+
+func (rt *receiptTracker) trackLoop() {
+	defer rt.wg.Done()
+	ctx := log.WithLogField(rt.ctx, "role", "opreceipts")
+	if err := rt.loadAttached(ctx); err != nil {
+		log.L(ctx).Errorf("Failed to load operations with attached transactions: %s", err)
+	}
+	for {
+		select {
+		case <-time.After(rt.interval):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Receipt tracker stopped")
+			return
+		}
+		rt.checkReceipts(ctx)
+	}
+}
+
+// loadAttached resumes tracking the pending operations that had a transaction attached before a restart
+func (rt *receiptTracker) loadAttached(ctx context.Context) error {
+	opTypes := make([]driver.Value, 0, len(rt.manager.receiptFetchers))
+	for opType := range rt.manager.receiptFetchers {
+		opTypes = append(opTypes, opType)
+	}
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	ops, _, err := rt.manager.database.GetOperations(ctx, rt.manager.namespace, fb.And(
+		fb.In("type", opTypes),
+		fb.Eq("status", core.OpStatusPending),
+	))
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if txHash := op.Output.GetString(attachedTXOutputKey); txHash != "" {
+			rt.track(op, txHash)
+		}
+	}
+	return nil
+}
+
+func (rt *receiptTracker) checkReceipts(ctx context.Context) {
+	rt.mux.Lock()
+	tracked := make([]*trackedReceipt, 0, len(rt.tracked))
+	for _, t := range rt.tracked {
+		tracked = append(tracked, t)
+	}
+	rt.mux.Unlock()
+
+	for _, t := range tracked {
+		receipt, err := rt.manager.receiptFetchers[t.op.Type].GetTransactionReceipt(ctx, t.txHash)
+		if err != nil {
+			log.L(ctx).Warnf("Failed to get receipt of transaction %s attached to operation %s: %s", t.txHash, t.op.ID, err)
+			continue
+		}
+		if receipt == nil {
+			continue
+		}
+		rt.manager.SubmitOperationUpdate(receiptOperationUpdate(t, receipt))
+		rt.mux.Lock()
+		delete(rt.tracked, *t.op.ID)
+		rt.mux.Unlock()
+	}
+}
+
+func receiptOperationUpdate(t *trackedReceipt, receipt *blockchain.TransactionReceipt) *core.OperationUpdate {
+	output := fftypes.JSONObject{}
+	for k, v := range receipt.Info {
+		output[k] = v
+	}
+	output[attachedTXOutputKey] = t.txHash
+	update := &core.OperationUpdate{
+		Plugin:         t.op.Plugin,
+		NamespacedOpID: (&core.PreparedOperation{ID: t.op.ID, Namespace: t.op.Namespace}).NamespacedIDString(),
+		Status:         core.OpStatusSucceeded,
+		BlockchainTXID: t.txHash,
+		Output:         output,
+	}
+	if !receipt.Success {
+		update.Status = core.OpStatusFailed
+		update.ErrorMessage = receipt.ErrorMessage
+	}
+	return update
+}
+
+func (rt *receiptTracker) close() {
+	rt.cancelFunc()
+	rt.wg.Wait()
+}
@@ -0,0 +1,252 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockReceiptFetcher struct {
+	receipts map[string]*blockchain.TransactionReceipt
+	err      error
+}
+
+func (m *mockReceiptFetcher) Name() string {
+	return "MockReceiptFetcher"
+}
+
+func (m *mockReceiptFetcher) GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error) {
+	return m.receipts[txHash], m.err
+}
+
+func newTestReceiptTracker(t *testing.T, fetcher *mockReceiptFetcher) (*operationsManager, func()) {
+	om, cancel := newTestOperations(t)
+	om.RegisterReceiptFetcher(om.ctx, fetcher, []core.OpType{core.OpTypeBlockchainInvoke})
+	om.updater.workQueues = []chan *core.OperationUpdate{
+		make(chan *core.OperationUpdate, 1),
+	}
+	return om, cancel
+}
+
+func TestAttachTransaction(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	op.Output = fftypes.JSONObject{"existing": "value"}
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", om.ctx, "ns1", op.ID).Return(op, nil)
+	mdi.On("UpdateOperation", om.ctx, "ns1", op.ID, mock.Anything, mock.Anything).Return(true, nil)
+
+	attached, err := om.AttachTransaction(om.ctx, op.ID, &core.OperationAttachTX{TransactionHash: "0x12345"})
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpStatusPending, attached.Status)
+	assert.Equal(t, "0x12345", attached.Output.GetString("transactionHash"))
+	assert.Equal(t, "value", attached.Output.GetString("existing"))
+	assert.Equal(t, "0x12345", om.receipts.tracked[*op.ID].txHash)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAttachTransactionMissingHash(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	_, err := om.AttachTransaction(om.ctx, fftypes.NewUUID(), &core.OperationAttachTX{})
+	assert.Regexp(t, "FF10610", err)
+}
+
+func TestAttachTransactionGetFail(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", om.ctx, "ns1", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := om.AttachTransaction(om.ctx, fftypes.NewUUID(), &core.OperationAttachTX{TransactionHash: "0x12345"})
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAttachTransactionNotFound(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", om.ctx, "ns1", mock.Anything).Return(nil, nil)
+
+	_, err := om.AttachTransaction(om.ctx, fftypes.NewUUID(), &core.OperationAttachTX{TransactionHash: "0x12345"})
+	assert.Regexp(t, "FF10109", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAttachTransactionNotSupported(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeDataExchangeSendBlob, core.OpStatusPending)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", om.ctx, "ns1", op.ID).Return(op, nil)
+
+	_, err := om.AttachTransaction(om.ctx, op.ID, &core.OperationAttachTX{TransactionHash: "0x12345"})
+	assert.Regexp(t, "FF10608", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAttachTransactionComplete(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusSucceeded)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", om.ctx, "ns1", op.ID).Return(op, nil)
+
+	_, err := om.AttachTransaction(om.ctx, op.ID, &core.OperationAttachTX{TransactionHash: "0x12345"})
+	assert.Regexp(t, "FF10609", err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAttachTransactionUpdateFail(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationByID", om.ctx, "ns1", op.ID).Return(op, nil)
+	mdi.On("UpdateOperation", om.ctx, "ns1", op.ID, mock.Anything, mock.Anything).Return(false, fmt.Errorf("pop"))
+
+	_, err := om.AttachTransaction(om.ctx, op.ID, &core.OperationAttachTX{TransactionHash: "0x12345"})
+	assert.EqualError(t, err, "pop")
+	assert.Empty(t, om.receipts.tracked)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestReceiptTrackerNoFetchers(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	om.receipts.start()
+	om.receipts.close()
+}
+
+func TestReceiptTrackerLoadAndComplete(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{
+		receipts: map[string]*blockchain.TransactionReceipt{
+			"0x12345": {TransactionHash: "0x12345", Success: true, ProtocolID: "000000000010/000000", Info: fftypes.JSONObject{"blockNumber": "10"}},
+		},
+	})
+	defer cancel()
+	om.receipts.interval = 0
+
+	attached := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	attached.Plugin = "ethereum"
+	attached.Output = fftypes.JSONObject{"transactionHash": "0x12345"}
+	notAttached := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{attached, notAttached}, nil, nil)
+
+	om.receipts.start()
+	update := <-om.updater.workQueues[0]
+	om.receipts.close()
+
+	assert.Equal(t, core.OpStatusSucceeded, update.Status)
+	assert.Equal(t, "ethereum", update.Plugin)
+	assert.Equal(t, "ns1:"+attached.ID.String(), update.NamespacedOpID)
+	assert.Equal(t, "0x12345", update.BlockchainTXID)
+	assert.Equal(t, "10", update.Output.GetString("blockNumber"))
+	assert.Equal(t, "0x12345", update.Output.GetString("transactionHash"))
+	assert.Empty(t, om.receipts.tracked)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestReceiptTrackerLoadFail(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := om.receipts.loadAttached(om.ctx)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestReceiptTrackerLoopLoadFail(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{})
+	defer cancel()
+
+	loaded := make(chan struct{})
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Run(func(args mock.Arguments) {
+		close(loaded)
+	}).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	om.receipts.start()
+	<-loaded
+	om.receipts.close()
+	assert.Empty(t, om.receipts.tracked)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestReceiptTrackerFailedTransaction(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{
+		receipts: map[string]*blockchain.TransactionReceipt{
+			"0x12345": {TransactionHash: "0x12345", Success: false, ErrorMessage: "reverted"},
+		},
+	})
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	om.receipts.track(op, "0x12345")
+	om.receipts.checkReceipts(om.ctx)
+
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusFailed, update.Status)
+	assert.Equal(t, "reverted", update.ErrorMessage)
+	assert.Empty(t, om.receipts.tracked)
+}
+
+func TestReceiptTrackerNotMinedOrError(t *testing.T) {
+	om, cancel := newTestReceiptTracker(t, &mockReceiptFetcher{err: fmt.Errorf("pop")})
+	defer cancel()
+
+	op1 := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	om.receipts.track(op1, "0x12345")
+	om.receipts.checkReceipts(om.ctx)
+	assert.Len(t, om.receipts.tracked, 1)
+
+	om.receipts.manager.receiptFetchers[core.OpTypeBlockchainInvoke] = &mockReceiptFetcher{}
+	om.receipts.checkReceipts(om.ctx)
+	assert.Len(t, om.receipts.tracked, 1)
+}
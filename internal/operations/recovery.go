@@ -0,0 +1,225 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// OperationReconciler can be registered for operation types where the connector can be asked for the
+// latest status of an operation, using the ID that FireFly submitted it with
+type OperationReconciler interface {
+	core.Named
+	// ReconcileOperation asks the connector for the operation, delivering any update that was missed while
+	// the node was stopped. Returns false if the connector has no record of the operation.
+	ReconcileOperation(ctx context.Context, op *core.Operation) (known bool, err error)
+}
+
+func (om *operationsManager) RegisterReconciler(ctx context.Context, reconciler OperationReconciler, ops []core.OpType) {
+	for _, opType := range ops {
+		log.L(ctx).Debugf("OpType=%s registered to reconciler %s", opType, reconciler.Name())
+		om.reconcilers[opType] = reconciler
+	}
+}
+
+func (om *operationsManager) GetRecoveryReport(ctx context.Context) *core.RecoveryReport {
+	return om.recovery.getReport()
+}
+
+// operationRecovery runs once on startup. It finds the operations and batches that were in-flight when
+// the node stopped, reconciles what it can with the connectors, resumes operations that were never
+// submitted, and keeps a report of what it found
+type operationRecovery struct {
+	ctx        context.Context
+	cancelFunc func()
+	manager    *operationsManager
+	enabled    bool
+	limit      uint64
+	report     *core.RecoveryReport
+	reportMux  sync.Mutex
+	wg         sync.WaitGroup
+}
+
+func newOperationRecovery(ctx context.Context, om *operationsManager) *operationRecovery {
+	r := &operationRecovery{
+		manager: om,
+		enabled: recoveryConfig.GetBool(OpRecoveryEnabled),
+		limit:   uint64(recoveryConfig.GetInt(OpRecoveryLimit)),
+	}
+	r.ctx, r.cancelFunc = context.WithCancel(ctx)
+	return r
+}
+
+func (r *operationRecovery) start() {
+	if !r.enabled {
+		return
+	}
+	// Only work from before this point is recovered
+	startTime := fftypes.Now()
+	r.reportMux.Lock()
+	r.report = &core.RecoveryReport{
+		Namespace:  r.manager.namespace,
+		Started:    startTime,
+		Operations: []*core.RecoveredOperation{},
+		Batches:    []*core.RecoveredBatch{},
+	}
+	r.reportMux.Unlock()
+	r.wg.Add(1)
+	go r.recoveryLoop(startTime)
+}
+
+func (r *operationRecovery) recoveryLoop(startTime *fftypes.FFTime) {
+	defer r.wg.Done()
+	ctx := log.WithLogField(r.ctx, "role", "oprecovery")
+	err := r.recoverOperations(ctx, startTime)
+	if err == nil {
+		err = r.findUnconfirmedBatches(ctx, startTime)
+	}
+
+	r.reportMux.Lock()
+	defer r.reportMux.Unlock()
+	if err != nil {
+		log.L(ctx).Errorf("Startup recovery failed: %s", err)
+		r.report.Error = err.Error()
+	}
+	r.report.Completed = fftypes.Now()
+	log.L(ctx).Infof("Startup recovery complete: operations=%d batches=%d", len(r.report.Operations), len(r.report.Batches))
+}
+
+func (r *operationRecovery) recoverOperations(ctx context.Context, startTime *fftypes.FFTime) error {
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	ops, _, err := r.manager.database.GetOperations(ctx, r.manager.namespace, fb.And(
+		fb.In("status", []driver.Value{core.OpStatusInitialized, core.OpStatusPending}),
+		fb.Lt("created", startTime),
+	).Sort("created").Limit(r.limit))
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		recovered := r.recoverOperation(ctx, op)
+		r.reportMux.Lock()
+		r.report.Operations = append(r.report.Operations, recovered)
+		r.reportMux.Unlock()
+	}
+	return nil
+}
+
+func (r *operationRecovery) recoverOperation(ctx context.Context, op *core.Operation) *core.RecoveredOperation {
+	recovered := &core.RecoveredOperation{
+		ID:          op.ID,
+		Type:        op.Type,
+		Transaction: op.Transaction,
+		Status:      op.Status,
+		Action:      core.RecoveryActionUnresolved,
+	}
+
+	if reconciler, ok := r.manager.reconcilers[op.Type]; ok {
+		known, err := reconciler.ReconcileOperation(ctx, op)
+		switch {
+		case err != nil:
+			log.L(ctx).Warnf("Failed to reconcile %s operation %s: %s", op.Type, op.ID, err)
+			recovered.Error = err.Error()
+			return recovered
+		case known:
+			log.L(ctx).Infof("Reconciled %s operation %s with the connector", op.Type, op.ID)
+			recovered.Action = core.RecoveryActionReconciled
+			return recovered
+		}
+	}
+
+	if op.Status != core.OpStatusInitialized {
+		log.L(ctx).Warnf("Outcome of %s operation %s is unknown", op.Type, op.ID)
+		recovered.Error = i18n.NewError(ctx, coremsgs.MsgOperationOutcomeUnknown).Error()
+		return recovered
+	}
+
+	// The operation never reached the connector, so it is safe to submit it again
+	if r.manager.queue.enabled {
+		// The submission queue makes the plugin call, so that it is not made twice
+		if err := r.manager.queue.ensureQueued(ctx, op); err != nil {
+			log.L(ctx).Warnf("Failed to queue %s operation %s: %s", op.Type, op.ID, err)
+			recovered.Error = err.Error()
+			return recovered
+		}
+		log.L(ctx).Infof("Queued %s operation %s for submission", op.Type, op.ID)
+		recovered.Action = core.RecoveryActionQueued
+		return recovered
+	}
+	prepOp, err := r.manager.PrepareOperation(ctx, op)
+	if err == nil {
+		_, err = r.manager.RunOperation(ctx, prepOp, true)
+	}
+	if err != nil {
+		log.L(ctx).Warnf("Failed to resume %s operation %s: %s", op.Type, op.ID, err)
+		recovered.Error = err.Error()
+		return recovered
+	}
+	log.L(ctx).Infof("Resumed %s operation %s", op.Type, op.ID)
+	recovered.Action = core.RecoveryActionResumed
+	return recovered
+}
+
+func (r *operationRecovery) findUnconfirmedBatches(ctx context.Context, startTime *fftypes.FFTime) error {
+	fb := database.BatchQueryFactory.NewFilter(ctx)
+	batches, _, err := r.manager.database.GetBatches(ctx, r.manager.namespace, fb.And(
+		fb.Eq("confirmed", nil),
+		fb.Lt("created", startTime),
+	).Sort("created").Limit(r.limit))
+	if err != nil {
+		return err
+	}
+
+	r.reportMux.Lock()
+	defer r.reportMux.Unlock()
+	for _, batch := range batches {
+		r.report.Batches = append(r.report.Batches, &core.RecoveredBatch{
+			ID:          batch.ID,
+			Type:        batch.Type,
+			Transaction: batch.TX.ID,
+			Created:     batch.Created,
+		})
+	}
+	return nil
+}
+
+// getReport returns a copy of the report, which is safe to serialize while recovery is in progress
+func (r *operationRecovery) getReport() *core.RecoveryReport {
+	r.reportMux.Lock()
+	defer r.reportMux.Unlock()
+	if r.report == nil {
+		return nil
+	}
+	report := *r.report
+	report.Operations = append([]*core.RecoveredOperation{}, r.report.Operations...)
+	report.Batches = append([]*core.RecoveredBatch{}, r.report.Batches...)
+	return &report
+}
+
+func (r *operationRecovery) close() {
+	r.cancelFunc()
+	r.wg.Wait()
+}
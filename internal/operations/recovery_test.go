@@ -0,0 +1,203 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockReconciler struct {
+	known map[fftypes.UUID]bool
+	err   error
+}
+
+func (m *mockReconciler) Name() string {
+	return "MockReconciler"
+}
+
+func (m *mockReconciler) ReconcileOperation(ctx context.Context, op *core.Operation) (bool, error) {
+	return m.known[*op.ID], m.err
+}
+
+func recoveryTestOperation(opType core.OpType, status core.OpStatus) *core.Operation {
+	return &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: fftypes.NewUUID(),
+		Type:        opType,
+		Status:      status,
+	}
+}
+
+func runTestRecovery(om *operationsManager) *core.RecoveryReport {
+	om.recovery.start()
+	om.recovery.close()
+	return om.GetRecoveryReport(om.ctx)
+}
+
+func TestRecoveryDisabled(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	config.Set("oprecovery.enabled", false)
+	om.recovery = newOperationRecovery(om.ctx, om)
+
+	report := runTestRecovery(om)
+	assert.Nil(t, report)
+}
+
+func TestRecoveryOperationsAndBatches(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	reconciled := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	unknownPending := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	unknownInitialized := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	noReconciler := recoveryTestOperation(core.OpTypeDataExchangeSendBlob, core.OpStatusPending)
+	batch := &core.BatchPersisted{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Type: core.BatchTypeBroadcast, Created: fftypes.Now()},
+		TX:          core.TransactionRef{ID: fftypes.NewUUID()},
+	}
+
+	om.RegisterReconciler(om.ctx, &mockReconciler{
+		known: map[fftypes.UUID]bool{*reconciled.ID: true},
+	}, []core.OpType{core.OpTypeBlockchainInvoke})
+	om.RegisterHandler(om.ctx, &mockHandler{
+		Phase:    core.OpPhasePending,
+		Prepared: &core.PreparedOperation{ID: unknownInitialized.ID, Namespace: "ns1", Type: core.OpTypeBlockchainInvoke},
+	}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	// The resumed operation moves to pending through the updater
+	om.updater.workQueues = []chan *core.OperationUpdate{
+		make(chan *core.OperationUpdate, 1),
+	}
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{
+		reconciled, unknownPending, unknownInitialized, noReconciler,
+	}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{batch}, nil, nil)
+
+	report := runTestRecovery(om)
+	assert.Equal(t, "ns1", report.Namespace)
+	assert.NotNil(t, report.Completed)
+	assert.Empty(t, report.Error)
+	assert.Len(t, report.Operations, 4)
+	assert.Equal(t, core.RecoveryActionReconciled, report.Operations[0].Action)
+	assert.Equal(t, core.RecoveryActionUnresolved, report.Operations[1].Action)
+	assert.Regexp(t, "FF10526", report.Operations[1].Error)
+	assert.Equal(t, core.RecoveryActionResumed, report.Operations[2].Action)
+	assert.Equal(t, core.RecoveryActionUnresolved, report.Operations[3].Action)
+	assert.Regexp(t, "FF10526", report.Operations[3].Error)
+	assert.Len(t, report.Batches, 1)
+	assert.Equal(t, batch.ID, report.Batches[0].ID)
+	assert.Equal(t, batch.TX.ID, report.Batches[0].Transaction)
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusPending, update.Status)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestRecoveryReconcileFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterReconciler(om.ctx, &mockReconciler{err: fmt.Errorf("pop")}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{op}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+
+	report := runTestRecovery(om)
+	assert.Equal(t, core.RecoveryActionUnresolved, report.Operations[0].Action)
+	assert.Equal(t, "pop", report.Operations[0].Error)
+}
+
+func TestRecoveryResumeFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterHandler(om.ctx, &mockHandler{PrepErr: fmt.Errorf("pop")}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{op}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+
+	report := runTestRecovery(om)
+	assert.Equal(t, core.RecoveryActionUnresolved, report.Operations[0].Action)
+	assert.Equal(t, "pop", report.Operations[0].Error)
+}
+
+func TestRecoveryGetOperationsFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	report := runTestRecovery(om)
+	assert.Equal(t, "pop", report.Error)
+	assert.NotNil(t, report.Completed)
+	assert.Empty(t, report.Operations)
+}
+
+func TestRecoveryGetBatchesFail(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	report := runTestRecovery(om)
+	assert.Equal(t, "pop", report.Error)
+	assert.Empty(t, report.Batches)
+}
+
+func TestRecoveryQueued(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	queued := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	queueFail := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{queued, queueFail}, nil, nil)
+	mdi.On("GetBatches", mock.Anything, "ns1", mock.Anything).Return([]*core.BatchPersisted{}, nil, nil)
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{
+		{ID: queued.ID},
+	}, nil, nil).Once()
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+
+	report := runTestRecovery(om)
+	assert.Len(t, report.Operations, 2)
+	assert.Equal(t, core.RecoveryActionQueued, report.Operations[0].Action)
+	assert.Equal(t, core.RecoveryActionUnresolved, report.Operations[1].Action)
+	assert.Equal(t, "pop", report.Operations[1].Error)
+
+	mdi.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// The operation types that are safe to submit again after a failure, so can be retried automatically
+var automaticRetryOpTypes = map[core.OpType]bool{
+	core.OpTypeBlockchainPinBatch:     true,
+	core.OpTypeBlockchainAnchorBatch:  true,
+	core.OpTypeBlockchainAnchorDigest: true,
+	core.OpTypeDataExchangeSendBatch:  true,
+	core.OpTypeDataExchangeSendBlob:   true,
+	core.OpTypeTokenCreatePool:        true,
+	core.OpTypeTokenActivatePool:      true,
+	core.OpTypeTokenTransfer:          true,
+	core.OpTypeTokenApproval:          true,
+}
+
+// retryPolicy is the automatic retry policy for one type of operation
+type retryPolicy struct {
+	retry.Retry
+	maxAttempts int
+}
+
+// delay returns how long to wait before the next attempt, after the given number of failed attempts
+func (rp *retryPolicy) delay(attempts int) time.Duration {
+	delay := float64(rp.InitialDelay) * math.Pow(rp.Factor, float64(attempts-1))
+	if delay > float64(rp.MaximumDelay) {
+		return rp.MaximumDelay
+	}
+	return time.Duration(delay)
+}
+
+// retryEngine retries failed operations automatically, according to the policy configured for their type.
+// Each attempt is a new operation, linked from the one it retries - exactly as for a manual retry.
+type retryEngine struct {
+	ctx        context.Context
+	cancelFunc func()
+	manager    *operationsManager
+	policies   map[core.OpType]*retryPolicy
+	pending    map[fftypes.UUID]bool
+	mux        sync.Mutex
+	wg         sync.WaitGroup
+}
+
+func newRetryEngine(ctx context.Context, om *operationsManager) (*retryEngine, error) {
+	re := &retryEngine{
+		manager:  om,
+		policies: make(map[core.OpType]*retryPolicy),
+		pending:  make(map[fftypes.UUID]bool),
+	}
+	for i := 0; i < retryPoliciesConfig.ArraySize(); i++ {
+		conf := retryPoliciesConfig.ArrayEntry(i)
+		opType := core.OpType(conf.GetString(OpRetryPolicyType))
+		if !automaticRetryOpTypes[opType] {
+			return nil, i18n.NewError(ctx, coremsgs.MsgOperationRetryPolicyNotSupported, opType)
+		}
+		re.policies[opType] = &retryPolicy{
+			Retry: retry.Retry{
+				InitialDelay: conf.GetDuration(OpRetryPolicyInitialDelay),
+				MaximumDelay: conf.GetDuration(OpRetryPolicyMaxDelay),
+				Factor:       conf.GetFloat64(OpRetryPolicyFactor),
+			},
+			maxAttempts: conf.GetInt(OpRetryPolicyMaxAttempts),
+		}
+		log.L(ctx).Infof("Automatic retry policy for %s operations: maxAttempts=%d", opType, re.policies[opType].maxAttempts)
+	}
+	re.ctx, re.cancelFunc = context.WithCancel(ctx)
+	return re, nil
+}
+
+// operationFailed is called once an operation has been updated to failed, and schedules
+// an automatic retry if there is a policy for its type
+func (re *retryEngine) operationFailed(op *core.Operation) {
+	policy, ok := re.policies[op.Type]
+	if !ok || op.Retry != nil {
+		return
+	}
+
+	re.mux.Lock()
+	defer re.mux.Unlock()
+	if re.pending[*op.ID] {
+		return
+	}
+	re.pending[*op.ID] = true
+	re.wg.Add(1)
+	go re.retryAfterDelay(op.ID, policy)
+}
+
+func (re *retryEngine) retryAfterDelay(opID *fftypes.UUID, policy *retryPolicy) {
+	defer re.wg.Done()
+	defer func() {
+		re.mux.Lock()
+		delete(re.pending, *opID)
+		re.mux.Unlock()
+	}()
+
+	ctx := log.WithLogField(re.ctx, "opretry", opID.String())
+	attempts, err := re.countAttempts(ctx, opID)
+	if err != nil {
+		log.L(ctx).Errorf("Unable to determine previous attempts of operation %s: %s", opID, err)
+		return
+	}
+	if attempts >= policy.maxAttempts {
+		log.L(ctx).Warnf("Operation %s failed after %d attempts - no further automatic retries", opID, attempts)
+		return
+	}
+
+	select {
+	case <-time.After(policy.delay(attempts)):
+	case <-ctx.Done():
+		log.L(ctx).Debugf("Automatic retry of operation %s cancelled", opID)
+		return
+	}
+
+	// The operation might have been retried manually while we were waiting
+	op, err := re.manager.GetOperationByIDCached(ctx, opID)
+	if err != nil || op == nil || op.Status != core.OpStatusFailed || op.Retry != nil {
+		log.L(ctx).Debugf("Skipping automatic retry of operation %s", opID)
+		return
+	}
+
+	log.L(ctx).Infof("Automatically retrying %s operation %s (attempt %d of %d)", op.Type, opID, attempts+1, policy.maxAttempts)
+	newOp, err := re.manager.RetryOperation(ctx, opID)
+	if err != nil {
+		// If the new attempt failed to submit, it is updated to failed - and handled by the policy in turn
+		log.L(ctx).Errorf("Automatic retry of operation %s failed: %s", opID, err)
+		return
+	}
+	log.L(ctx).Infof("Operation %s retried as %s", opID, newOp.ID)
+}
+
+// countAttempts follows the retry links back from an operation, to find how many attempts have been made
+func (re *retryEngine) countAttempts(ctx context.Context, opID *fftypes.UUID) (int, error) {
+	attempts := 1
+	for {
+		fb := database.OperationQueryFactory.NewFilter(ctx)
+		previous, _, err := re.manager.database.GetOperations(ctx, re.manager.namespace, fb.And(fb.Eq("retry", opID)).Limit(1))
+		if err != nil {
+			return -1, err
+		}
+		if len(previous) == 0 {
+			return attempts, nil
+		}
+		attempts++
+		opID = previous[0].ID
+	}
+}
+
+func (re *retryEngine) close() {
+	re.cancelFunc()
+	re.wg.Wait()
+}
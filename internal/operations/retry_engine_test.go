@@ -0,0 +1,197 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/retry"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setRetryPolicyConfig(t *testing.T, policy string) {
+	InitConfig()
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader("opretry:\n  policies:\n  - " + policy))
+	assert.NoError(t, err)
+}
+
+func newTestOperationsWithRetryPolicy(t *testing.T, policy string) (*operationsManager, func()) {
+	om, cancel := newTestOperations(t)
+	setRetryPolicyConfig(t, policy)
+	var err error
+	om.retries, err = newRetryEngine(om.ctx, om)
+	assert.NoError(t, err)
+	return om, func() {
+		om.retries.close()
+		cancel()
+	}
+}
+
+func failedTestOperation(om *operationsManager, opType core.OpType) *core.Operation {
+	op := &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Plugin:      "blockchain",
+		Transaction: fftypes.NewUUID(),
+		Type:        opType,
+		Status:      core.OpStatusFailed,
+	}
+	om.cacheOperation(op)
+	return op
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	rp := &retryPolicy{
+		Retry: retry.Retry{
+			InitialDelay: 1 * time.Second,
+			MaximumDelay: 5 * time.Second,
+			Factor:       2.0,
+		},
+	}
+	assert.Equal(t, 1*time.Second, rp.delay(1))
+	assert.Equal(t, 2*time.Second, rp.delay(2))
+	assert.Equal(t, 4*time.Second, rp.delay(3))
+	assert.Equal(t, 5*time.Second, rp.delay(4))
+}
+
+func TestNewRetryEngineUnsupportedType(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+	setRetryPolicyConfig(t, "type: blockchain_invoke")
+
+	_, err := newRetryEngine(om.ctx, om)
+	assert.Regexp(t, "FF10495", err)
+}
+
+func TestAutomaticRetry(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: blockchain_pin_batch, initialDelay: 1ms}")
+	defer cancel()
+	assert.Equal(t, 3, om.retries.policies[core.OpTypeBlockchainPinBatch].maxAttempts)
+
+	op := failedTestOperation(om, core.OpTypeBlockchainPinBatch)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", op.Transaction).Return(&core.Transaction{ID: op.Transaction}, nil)
+	mdi.On("InsertOperation", mock.Anything, mock.MatchedBy(func(newOp *core.Operation) bool {
+		return !newOp.ID.Equals(op.ID) && newOp.Status == core.OpStatusInitialized
+	})).Return(nil)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", op.ID, mock.Anything, mock.Anything).Return(true, nil)
+
+	om.RegisterHandler(om.ctx, &mockHandler{Prepared: &core.PreparedOperation{ID: op.ID, Type: op.Type}}, []core.OpType{core.OpTypeBlockchainPinBatch})
+	om.retries.operationFailed(op)
+	om.retries.wg.Wait()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAutomaticRetryRunFail(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: token_transfer, initialDelay: 1ms}")
+	defer cancel()
+
+	op := failedTestOperation(om, core.OpTypeTokenTransfer)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	mdi.On("GetTransactionByID", mock.Anything, "ns1", op.Transaction).Return(nil, fmt.Errorf("pop"))
+
+	om.retries.operationFailed(op)
+	om.retries.wg.Wait()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAutomaticRetryMaxAttempts(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: dataexchange_send_batch, maxAttempts: 2}")
+	defer cancel()
+
+	op := failedTestOperation(om, core.OpTypeDataExchangeSendBatch)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{{ID: fftypes.NewUUID()}}, nil, nil).Once()
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil).Once()
+
+	om.retries.operationFailed(op)
+	om.retries.wg.Wait()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAutomaticRetryCountAttemptsFail(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: dataexchange_send_blob}")
+	defer cancel()
+
+	op := failedTestOperation(om, core.OpTypeDataExchangeSendBlob)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	om.retries.operationFailed(op)
+	om.retries.wg.Wait()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAutomaticRetryAlreadyRetried(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: token_approval, initialDelay: 1ms}")
+	defer cancel()
+
+	op := failedTestOperation(om, core.OpTypeTokenApproval)
+	retried := *op
+	retried.Retry = fftypes.NewUUID()
+	om.cacheOperation(&retried)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+
+	om.retries.operationFailed(op)
+	om.retries.wg.Wait()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestAutomaticRetryNoPolicy(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: token_create_pool}")
+	defer cancel()
+
+	om.retries.operationFailed(failedTestOperation(om, core.OpTypeBlockchainInvoke))
+	assert.Empty(t, om.retries.pending)
+}
+
+func TestAutomaticRetryCancelledAndDuplicate(t *testing.T) {
+	om, cancel := newTestOperationsWithRetryPolicy(t, "{type: token_activate_pool, initialDelay: 1m}")
+
+	op := failedTestOperation(om, core.OpTypeTokenActivatePool)
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+
+	om.retries.operationFailed(op)
+	om.retries.operationFailed(op)
+	cancel()
+
+	mdi.AssertNumberOfCalls(t, "GetOperations", 1)
+}
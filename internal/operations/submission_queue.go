@@ -0,0 +1,238 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// submissionQueue performs the plugin calls of operations, with bounded concurrency. When it is enabled,
+// each initialized operation is recorded in a durable queue, in the same database transaction as the
+// operation and its parent record. The entry is removed once the plugin has been called, and any entries
+// that remain - because the node stopped before the call was made - are submitted by a background loop.
+type submissionQueue struct {
+	ctx          context.Context
+	cancelFunc   func()
+	manager      *operationsManager
+	enabled      bool
+	slots        chan struct{}
+	pollInterval time.Duration
+	batchSize    uint64
+	inflight     map[fftypes.UUID]*submission
+	inflightMux  sync.Mutex
+	wg           sync.WaitGroup
+}
+
+// submission is a plugin call that is in progress, which other submitters of the same operation wait for
+type submission struct {
+	done    chan struct{}
+	outputs fftypes.JSONObject
+	err     error
+}
+
+func newSubmissionQueue(ctx context.Context, om *operationsManager) *submissionQueue {
+	sq := &submissionQueue{
+		manager:      om,
+		enabled:      queueConfig.GetBool(OpQueueEnabled),
+		slots:        make(chan struct{}, queueConfig.GetInt(OpQueueWorkers)),
+		pollInterval: queueConfig.GetDuration(OpQueuePollInterval),
+		batchSize:    uint64(queueConfig.GetInt(OpQueueBatchSize)),
+		inflight:     make(map[fftypes.UUID]*submission),
+	}
+	sq.ctx, sq.cancelFunc = context.WithCancel(ctx)
+	return sq
+}
+
+// enqueue records the initialized operations in the queue, using the database transaction of the context
+func (sq *submissionQueue) enqueue(ctx context.Context, ops ...*core.Operation) error {
+	if !sq.enabled {
+		return nil
+	}
+	for _, op := range ops {
+		if op.Status == core.OpStatusInitialized {
+			if err := sq.insertEntry(ctx, op, fftypes.Now()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sq *submissionQueue) insertEntry(ctx context.Context, op *core.Operation, created *fftypes.FFTime) error {
+	return sq.manager.database.InsertOperationQueueEntry(ctx, &core.OperationQueueEntry{
+		ID:        op.ID,
+		Namespace: op.Namespace,
+		Type:      op.Type,
+		Created:   created,
+	})
+}
+
+// ensureQueued adds an operation found on startup to the queue, if it is not already queued. It is given
+// the time the operation was created, so it is picked up by the next poll.
+func (sq *submissionQueue) ensureQueued(ctx context.Context, op *core.Operation) error {
+	fb := database.OperationQueueQueryFactory.NewFilter(ctx)
+	entries, _, err := sq.manager.database.GetOperationQueueEntries(ctx, sq.manager.namespace, fb.And(fb.Eq("id", op.ID)))
+	if err != nil || len(entries) > 0 {
+		return err
+	}
+	return sq.insertEntry(ctx, op, op.Created)
+}
+
+// submit performs the plugin call for an operation, once a slot is free. If the operation is already
+// being submitted, it waits for the result of that submission rather than calling the plugin again.
+func (sq *submissionQueue) submit(ctx context.Context, op *core.PreparedOperation, idempotentSubmit bool) (fftypes.JSONObject, error) {
+	if !sq.enabled {
+		return sq.manager.runOperation(ctx, op, idempotentSubmit)
+	}
+
+	sq.inflightMux.Lock()
+	s, running := sq.inflight[*op.ID]
+	if !running {
+		s = &submission{done: make(chan struct{})}
+		sq.inflight[*op.ID] = s
+	}
+	sq.inflightMux.Unlock()
+	if running {
+		select {
+		case <-s.done:
+			return s.outputs, s.err
+		case <-ctx.Done():
+			return nil, i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+	}
+
+	defer func() {
+		sq.inflightMux.Lock()
+		delete(sq.inflight, *op.ID)
+		sq.inflightMux.Unlock()
+		close(s.done)
+	}()
+
+	select {
+	case sq.slots <- struct{}{}:
+	case <-ctx.Done():
+		// The entry remains in the queue, so the operation will be submitted by the background loop
+		s.err = i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		return nil, s.err
+	}
+	s.outputs, s.err = sq.manager.runOperation(ctx, op, idempotentSubmit)
+	<-sq.slots
+
+	// The plugin has been called, and the outcome recorded on the operation
+	if err := sq.manager.database.DeleteOperationQueueEntry(ctx, op.Namespace, op.ID); err != nil && err != fftypes.DeleteRecordNotFound {
+		log.L(ctx).Warnf("Failed to remove %s operation %s from the submission queue: %s", op.Type, op.ID, err)
+	}
+	return s.outputs, s.err
+}
+
+func (sq *submissionQueue) start() {
+	if !sq.enabled {
+		return
+	}
+	sq.wg.Add(1)
+	go sq.dispatchLoop()
+}
+
+func (sq *submissionQueue) dispatchLoop() {
+	defer sq.wg.Done()
+	ctx := log.WithLogField(sq.ctx, "role", "opqueue")
+	for {
+		if err := sq.submitQueued(ctx); err != nil {
+			log.L(ctx).Errorf("Failed to read the operation submission queue: %s", err)
+		}
+		select {
+		case <-time.After(sq.pollInterval):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Operation submission queue stopped")
+			return
+		}
+	}
+}
+
+// submitQueued submits the operations that remain in the queue. Entries newer than the poll interval are
+// left alone, as the request that created them is expected to be submitting them.
+func (sq *submissionQueue) submitQueued(ctx context.Context) error {
+	fb := database.OperationQueueQueryFactory.NewFilter(ctx)
+	cutoff := fftypes.FFTime(time.Now().Add(-sq.pollInterval))
+	entries, _, err := sq.manager.database.GetOperationQueueEntries(ctx, sq.manager.namespace, fb.And(
+		fb.Lt("created", &cutoff),
+	).Sort("sequence").Limit(sq.batchSize))
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, entry := range entries {
+		wg.Add(1)
+		go func(entry *core.OperationQueueEntry) {
+			defer wg.Done()
+			sq.submitQueuedEntry(ctx, entry)
+		}(entry)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (sq *submissionQueue) submitQueuedEntry(ctx context.Context, entry *core.OperationQueueEntry) {
+	op, err := sq.manager.database.GetOperationByID(ctx, sq.manager.namespace, entry.ID)
+	if err != nil {
+		log.L(ctx).Warnf("Failed to read queued %s operation %s: %s", entry.Type, entry.ID, err)
+		return
+	}
+	if op == nil || op.Status != core.OpStatusInitialized {
+		// The plugin was called, but the node stopped before the entry was removed
+		if err := sq.manager.database.DeleteOperationQueueEntry(ctx, sq.manager.namespace, entry.ID); err != nil {
+			log.L(ctx).Warnf("Failed to remove %s operation %s from the submission queue: %s", entry.Type, entry.ID, err)
+		}
+		return
+	}
+
+	prepOp, err := sq.manager.PrepareOperation(ctx, op)
+	if err != nil {
+		// The operation can never be submitted, so it is failed rather than being retried on each poll
+		log.L(ctx).Errorf("Failed to prepare queued %s operation %s: %s", op.Type, op.ID, err)
+		sq.manager.SubmitOperationUpdate(&core.OperationUpdate{
+			NamespacedOpID: op.Namespace + ":" + op.ID.String(),
+			Plugin:         op.Plugin,
+			Status:         core.OpStatusFailed,
+			ErrorMessage:   err.Error(),
+		})
+		if err := sq.manager.database.DeleteOperationQueueEntry(ctx, sq.manager.namespace, entry.ID); err != nil {
+			log.L(ctx).Warnf("Failed to remove %s operation %s from the submission queue: %s", entry.Type, entry.ID, err)
+		}
+		return
+	}
+
+	log.L(ctx).Infof("Submitting queued %s operation %s", op.Type, op.ID)
+	if _, err := sq.submit(ctx, prepOp, true); err != nil {
+		log.L(ctx).Warnf("Queued %s operation %s failed: %s", op.Type, op.ID, err)
+	}
+}
+
+func (sq *submissionQueue) close() {
+	sq.cancelFunc()
+	sq.wg.Wait()
+}
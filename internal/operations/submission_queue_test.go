@@ -0,0 +1,374 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type blockingHandler struct {
+	mockHandler
+	started chan struct{}
+	release chan struct{}
+	calls   int32
+}
+
+func (bh *blockingHandler) RunOperation(ctx context.Context, op *core.PreparedOperation) (outputs fftypes.JSONObject, phase core.OpPhase, err error) {
+	atomic.AddInt32(&bh.calls, 1)
+	bh.started <- struct{}{}
+	<-bh.release
+	return fftypes.JSONObject{"test": "output"}, core.OpPhasePending, nil
+}
+
+func newTestOperationsWithQueue(t *testing.T) (*operationsManager, func()) {
+	om, cancel := newTestOperations(t)
+	queueConfig.Set(OpQueueEnabled, true)
+	queueConfig.Set(OpQueueWorkers, 1)
+	queueConfig.Set(OpQueuePollInterval, "1ms")
+	om.queue = newSubmissionQueue(om.ctx, om)
+	om.updater.workQueues = []chan *core.OperationUpdate{
+		make(chan *core.OperationUpdate, 10),
+	}
+	return om, cancel
+}
+
+func queueTestPreparedOp(op *core.Operation) *core.PreparedOperation {
+	return &core.PreparedOperation{ID: op.ID, Namespace: op.Namespace, Type: op.Type}
+}
+
+func TestQueueDisabled(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	assert.False(t, om.queue.enabled)
+	assert.NoError(t, om.queue.enqueue(om.ctx, recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)))
+	om.queue.start()
+	om.queue.close()
+}
+
+func TestQueueEnqueueInsertOperation(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperation", mock.Anything, op).Return(nil)
+	mdi.On("InsertOperationQueueEntry", mock.Anything, mock.MatchedBy(func(entry *core.OperationQueueEntry) bool {
+		return entry.ID.Equals(op.ID) && entry.Namespace == "ns1" && entry.Type == op.Type && entry.Created != nil
+	})).Return(nil)
+
+	err := om.insertOperation(om.ctx, op)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueEnqueueInsertOperationFail(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperation", mock.Anything, op).Return(nil)
+	mdi.On("InsertOperationQueueEntry", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := om.insertOperation(om.ctx, op)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueEnqueueBulkInsertOperations(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	initialized := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	pending := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperations", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOperationQueueEntry", mock.Anything, mock.MatchedBy(func(entry *core.OperationQueueEntry) bool {
+		return entry.ID.Equals(initialized.ID)
+	})).Return(nil).Once()
+
+	err := om.BulkInsertOperations(om.ctx, initialized, pending)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueEnqueueBulkInsertOperationsFail(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("InsertOperations", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOperationQueueEntry", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := om.BulkInsertOperations(om.ctx, op)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueSubmitRemovesEntry(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterHandler(om.ctx, &mockHandler{Phase: core.OpPhasePending, Outputs: fftypes.JSONObject{"test": "output"}}, []core.OpType{core.OpTypeBlockchainInvoke})
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", op.ID).Return(fftypes.DeleteRecordNotFound)
+
+	outputs, err := om.RunOperation(om.ctx, queueTestPreparedOp(op), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "output", outputs.GetString("test"))
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusPending, update.Status)
+	assert.Empty(t, om.queue.inflight)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueSubmitRemoveEntryFail(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterHandler(om.ctx, &mockHandler{RunErr: fmt.Errorf("pop")}, []core.OpType{core.OpTypeBlockchainInvoke})
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", op.ID).Return(fmt.Errorf("pop2"))
+
+	_, err := om.RunOperation(om.ctx, queueTestPreparedOp(op), true)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueSubmitDuplicateWaits(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	bh := &blockingHandler{started: make(chan struct{}, 1), release: make(chan struct{})}
+	om.RegisterHandler(om.ctx, bh, []core.OpType{core.OpTypeBlockchainInvoke})
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", op.ID).Return(nil).Once()
+
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		_, err := om.RunOperation(om.ctx, queueTestPreparedOp(op), true)
+		assert.NoError(t, err)
+	}()
+	<-bh.started
+
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		outputs, err := om.RunOperation(om.ctx, queueTestPreparedOp(op), true)
+		assert.NoError(t, err)
+		assert.Equal(t, "output", outputs.GetString("test"))
+	}()
+	for {
+		// Wait for the second submitter to find the in-flight submission
+		om.queue.inflightMux.Lock()
+		_, running := om.queue.inflight[*op.ID]
+		om.queue.inflightMux.Unlock()
+		if running {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	close(bh.release)
+	<-firstDone
+	<-secondDone
+	assert.Equal(t, int32(1), atomic.LoadInt32(&bh.calls))
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueSubmitDuplicateCancelled(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.queue.inflight[*op.ID] = &submission{done: make(chan struct{})}
+
+	ctx, cancelCtx := context.WithCancel(om.ctx)
+	cancelCtx()
+	_, err := om.RunOperation(ctx, queueTestPreparedOp(op), true)
+	assert.Regexp(t, "FF00154", err)
+}
+
+func TestQueueSubmitNoSlotCancelled(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.queue.slots <- struct{}{}
+
+	ctx, cancelCtx := context.WithCancel(om.ctx)
+	cancelCtx()
+	_, err := om.RunOperation(ctx, queueTestPreparedOp(op), true)
+	assert.Regexp(t, "FF00154", err)
+	assert.Empty(t, om.queue.inflight)
+}
+
+func TestQueueSubmitQueued(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	initialized := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	pending := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusPending)
+	missing := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	readFail := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterHandler(om.ctx, &mockHandler{
+		Phase:    core.OpPhasePending,
+		Prepared: queueTestPreparedOp(initialized),
+	}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{
+		{ID: initialized.ID, Namespace: "ns1", Type: initialized.Type},
+		{ID: pending.ID, Namespace: "ns1", Type: pending.Type},
+		{ID: missing.ID, Namespace: "ns1", Type: missing.Type},
+		{ID: readFail.ID, Namespace: "ns1", Type: readFail.Type},
+	}, nil, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", initialized.ID).Return(initialized, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", pending.ID).Return(pending, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", missing.ID).Return(nil, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", readFail.ID).Return(nil, fmt.Errorf("pop"))
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", initialized.ID).Return(nil)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", pending.ID).Return(nil)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", missing.ID).Return(fmt.Errorf("pop"))
+
+	err := om.queue.submitQueued(om.ctx)
+	assert.NoError(t, err)
+	update := <-om.updater.workQueues[0]
+	assert.Equal(t, core.OpStatusPending, update.Status)
+	assert.Equal(t, "ns1:"+initialized.ID.String(), update.NamespacedOpID)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueSubmitQueuedPrepareFail(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	op2 := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterHandler(om.ctx, &mockHandler{PrepErr: fmt.Errorf("pop")}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{
+		{ID: op.ID, Namespace: "ns1", Type: op.Type},
+		{ID: op2.ID, Namespace: "ns1", Type: op2.Type},
+	}, nil, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", op.ID).Return(op, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", op2.ID).Return(op2, nil)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", op.ID).Return(nil)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", op2.ID).Return(fmt.Errorf("pop"))
+
+	err := om.queue.submitQueued(om.ctx)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		update := <-om.updater.workQueues[0]
+		assert.Equal(t, core.OpStatusFailed, update.Status)
+		assert.Equal(t, "pop", update.ErrorMessage)
+	}
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueSubmitQueuedRunFail(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	op := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	om.RegisterHandler(om.ctx, &mockHandler{
+		Prepared: queueTestPreparedOp(op),
+		RunErr:   fmt.Errorf("pop"),
+	}, []core.OpType{core.OpTypeBlockchainInvoke})
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{
+		{ID: op.ID, Namespace: "ns1", Type: op.Type},
+	}, nil, nil)
+	mdi.On("GetOperationByID", mock.Anything, "ns1", op.ID).Return(op, nil)
+	mdi.On("DeleteOperationQueueEntry", mock.Anything, "ns1", op.ID).Return(nil)
+
+	err := om.queue.submitQueued(om.ctx)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueDispatchLoop(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	polled := make(chan struct{}, 1)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{}, nil, nil).Run(func(args mock.Arguments) {
+		select {
+		case polled <- struct{}{}:
+		default:
+		}
+	})
+
+	om.queue.start()
+	<-polled
+	om.queue.close()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestQueueEnsureQueued(t *testing.T) {
+	om, cancel := newTestOperationsWithQueue(t)
+	defer cancel()
+
+	queued := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	unqueued := recoveryTestOperation(core.OpTypeBlockchainInvoke, core.OpStatusInitialized)
+	unqueued.Created = fftypes.Now()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{
+		{ID: queued.ID},
+	}, nil, nil).Once()
+	mdi.On("GetOperationQueueEntries", mock.Anything, "ns1", mock.Anything).Return([]*core.OperationQueueEntry{}, nil, nil).Once()
+	mdi.On("InsertOperationQueueEntry", mock.Anything, mock.MatchedBy(func(entry *core.OperationQueueEntry) bool {
+		return entry.ID.Equals(unqueued.ID) && entry.Created == unqueued.Created
+	})).Return(nil)
+
+	assert.NoError(t, om.queue.ensureQueued(om.ctx, queued))
+	assert.NoError(t, om.queue.ensureQueued(om.ctx, unqueued))
+
+	mdi.AssertExpectations(t)
+}
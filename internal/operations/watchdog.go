@@ -0,0 +1,144 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// operationThreshold is how long operations of a given type can remain pending before they are considered stalled
+type operationThreshold struct {
+	opType  core.OpType
+	timeout time.Duration
+}
+
+// operationWatchdog periodically looks for operations that have remained pending for longer than the
+// threshold configured for their type, and emits an operation_stalled event the first time each is found
+type operationWatchdog struct {
+	ctx        context.Context
+	cancelFunc func()
+	manager    *operationsManager
+	interval   time.Duration
+	thresholds []*operationThreshold
+	reported   map[fftypes.UUID]bool
+	wg         sync.WaitGroup
+}
+
+func newOperationWatchdog(ctx context.Context, om *operationsManager) *operationWatchdog {
+	wd := &operationWatchdog{
+		manager:  om,
+		interval: watchdogConfig.GetDuration(OpWatchdogInterval),
+		reported: make(map[fftypes.UUID]bool),
+	}
+	for i := 0; i < watchdogThresholdsConfig.ArraySize(); i++ {
+		conf := watchdogThresholdsConfig.ArrayEntry(i)
+		threshold := &operationThreshold{
+			opType:  core.OpType(conf.GetString(OpWatchdogThresholdType)),
+			timeout: conf.GetDuration(OpWatchdogThresholdTimeout),
+		}
+		wd.thresholds = append(wd.thresholds, threshold)
+		log.L(ctx).Infof("Operation watchdog threshold for %s operations: %s", threshold.opType, threshold.timeout)
+	}
+	wd.ctx, wd.cancelFunc = context.WithCancel(ctx)
+	return wd
+}
+
+// stalledFilter adds the conditions that match stalled operations to the supplied filter.
+// Returns false if there are no thresholds configured, so no operation can be stalled.
+func (wd *operationWatchdog) stalledFilter(filter ffapi.AndFilter) (ffapi.AndFilter, bool) {
+	if len(wd.thresholds) == 0 {
+		return nil, false
+	}
+	fb := filter.Builder()
+	now := time.Now()
+	byType := fb.Or()
+	for _, threshold := range wd.thresholds {
+		cutoff := fftypes.FFTime(now.Add(-threshold.timeout))
+		byType.Condition(fb.And(
+			fb.Eq("type", threshold.opType),
+			fb.Lt("updated", &cutoff),
+		))
+	}
+	return filter.Condition(fb.Eq("status", core.OpStatusPending), byType), true
+}
+
+func (wd *operationWatchdog) start() {
+	if len(wd.thresholds) == 0 {
+		return
+	}
+	wd.wg.Add(1)
+	go wd.watchLoop()
+}
+
+func (wd *operationWatchdog) watchLoop() {
+	defer wd.wg.Done()
+	ctx := log.WithLogField(wd.ctx, "role", "opwatchdog")
+	for {
+		select {
+		case <-time.After(wd.interval):
+		case <-ctx.Done():
+			log.L(ctx).Debugf("Operation watchdog stopped")
+			return
+		}
+		if err := wd.checkStalled(ctx); err != nil {
+			log.L(ctx).Errorf("Operation watchdog failed to check for stalled operations: %s", err)
+		}
+	}
+}
+
+// checkStalled emits an event for each newly stalled operation. Operations that are no longer stalled
+// are forgotten, so an event will be emitted again if they stall again in future.
+func (wd *operationWatchdog) checkStalled(ctx context.Context) error {
+	filter, _ := wd.stalledFilter(database.OperationQueryFactory.NewFilter(ctx).And())
+	ops, _, err := wd.manager.database.GetOperations(ctx, wd.manager.namespace, filter)
+	if err != nil {
+		return err
+	}
+
+	stalled := make(map[fftypes.UUID]bool, len(ops))
+	for _, op := range ops {
+		stalled[*op.ID] = true
+		if wd.reported[*op.ID] {
+			continue
+		}
+		log.L(ctx).Warnf("Operation %s of type %s has been pending since %s", op.ID, op.Type, op.Updated)
+		event := core.NewEvent(core.EventTypeOperationStalled, op.Namespace, op.ID, op.Transaction, "")
+		if err := wd.manager.database.InsertEvent(ctx, event); err != nil {
+			return err
+		}
+		wd.reported[*op.ID] = true
+	}
+	for id := range wd.reported {
+		if !stalled[id] {
+			delete(wd.reported, id)
+		}
+	}
+	return nil
+}
+
+func (wd *operationWatchdog) close() {
+	wd.cancelFunc()
+	wd.wg.Wait()
+}
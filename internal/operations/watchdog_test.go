@@ -0,0 +1,185 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operations
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestOperationsWithWatchdog(t *testing.T, watchdog string) (*operationsManager, func()) {
+	om, cancel := newTestOperations(t)
+	viper.SetConfigType("yaml")
+	err := viper.ReadConfig(strings.NewReader("opwatchdog:\n" + watchdog))
+	assert.NoError(t, err)
+	om.watchdog = newOperationWatchdog(om.ctx, om)
+	return om, func() {
+		om.watchdog.close()
+		cancel()
+	}
+}
+
+func stalledTestOperation() *core.Operation {
+	return &core.Operation{
+		ID:          fftypes.NewUUID(),
+		Namespace:   "ns1",
+		Transaction: fftypes.NewUUID(),
+		Type:        core.OpTypeBlockchainInvoke,
+		Status:      core.OpStatusPending,
+		Updated:     fftypes.UnixTime(0),
+	}
+}
+
+func TestWatchdogEmitsStalledEventOnce(t *testing.T) {
+	om, cancel := newTestOperationsWithWatchdog(t, "  interval: 1ms\n  thresholds:\n  - {type: blockchain_invoke, timeout: 1s}")
+	defer cancel()
+	assert.Equal(t, time.Second, om.watchdog.thresholds[0].timeout)
+
+	op := stalledTestOperation()
+	eventsEmitted := make(chan *core.Event, 1)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{op}, nil, nil)
+	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeOperationStalled && event.Reference.Equals(op.ID) && event.Transaction.Equals(op.Transaction)
+	})).Run(func(args mock.Arguments) {
+		eventsEmitted <- args[1].(*core.Event)
+	}).Return(nil).Once()
+
+	om.watchdog.start()
+	<-eventsEmitted
+	om.watchdog.close()
+
+	// Further checks find the same operation, but do not emit it again
+	err := om.watchdog.checkStalled(om.ctx)
+	assert.NoError(t, err)
+	assert.True(t, om.watchdog.reported[*op.ID])
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchdogForgetsRecoveredOperations(t *testing.T) {
+	om, cancel := newTestOperationsWithWatchdog(t, "  thresholds:\n  - {type: blockchain_invoke}")
+	defer cancel()
+	assert.Equal(t, 5*time.Minute, om.watchdog.thresholds[0].timeout)
+
+	recovered := fftypes.NewUUID()
+	om.watchdog.reported[*recovered] = true
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
+
+	err := om.watchdog.checkStalled(om.ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, om.watchdog.reported)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchdogCheckQueryFail(t *testing.T) {
+	om, cancel := newTestOperationsWithWatchdog(t, "  thresholds:\n  - {type: blockchain_invoke}")
+	defer cancel()
+
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := om.watchdog.checkStalled(om.ctx)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchdogLoopCheckFail(t *testing.T) {
+	om, cancel := newTestOperationsWithWatchdog(t, "  interval: 1ms\n  thresholds:\n  - {type: blockchain_invoke}")
+	defer cancel()
+
+	checked := make(chan struct{}, 1)
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Run(func(args mock.Arguments) {
+		select {
+		case checked <- struct{}{}:
+		default:
+		}
+	}).Return(nil, nil, fmt.Errorf("pop"))
+
+	om.watchdog.start()
+	<-checked
+	<-checked
+	om.watchdog.close()
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchdogCheckInsertEventFail(t *testing.T) {
+	om, cancel := newTestOperationsWithWatchdog(t, "  thresholds:\n  - {type: blockchain_invoke}")
+	defer cancel()
+
+	op := stalledTestOperation()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return([]*core.Operation{op}, nil, nil)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := om.watchdog.checkStalled(om.ctx)
+	assert.EqualError(t, err, "pop")
+	assert.False(t, om.watchdog.reported[*op.ID])
+
+	mdi.AssertExpectations(t)
+}
+
+func TestWatchdogNoThresholds(t *testing.T) {
+	om, cancel := newTestOperations(t)
+	defer cancel()
+
+	om.watchdog.start()
+	om.watchdog.close()
+
+	ops, _, err := om.GetStalledOperations(om.ctx, database.OperationQueryFactory.NewFilter(om.ctx).And())
+	assert.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+func TestGetStalledOperations(t *testing.T) {
+	om, cancel := newTestOperationsWithWatchdog(t, "  thresholds:\n  - {type: blockchain_invoke, timeout: 1m}\n  - {type: token_transfer, timeout: 1h}")
+	defer cancel()
+
+	op := stalledTestOperation()
+	mdi := om.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		info, err := filter.Finalize()
+		assert.NoError(t, err)
+		return strings.HasPrefix(info.String(), "( tx == ") &&
+			strings.Contains(info.String(), "( status == 'Pending' ) && ( ( ( type == 'blockchain_invoke' ) && ( updated << ") &&
+			strings.Contains(info.String(), "( ( type == 'token_transfer' ) && ( updated << ")
+	})).Return([]*core.Operation{op}, nil, nil)
+
+	fb := database.OperationQueryFactory.NewFilter(om.ctx)
+	ops, _, err := om.GetStalledOperations(om.ctx, fb.And(fb.Eq("tx", op.Transaction)))
+	assert.NoError(t, err)
+	assert.Len(t, ops, 1)
+
+	mdi.AssertExpectations(t)
+}
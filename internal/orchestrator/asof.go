@@ -0,0 +1,101 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// resolveAsOf turns an 'asof' query value into a point in time. An integer is the sequence of an event,
+// and resolves to the time that event was created. Anything else must be a timestamp.
+func (or *orchestrator) resolveAsOf(ctx context.Context, asOf string) (*fftypes.FFTime, error) {
+	if sequence, err := strconv.ParseInt(asOf, 10, 64); err == nil {
+		fb := database.EventQueryFactory.NewFilter(ctx)
+		events, _, err := or.database().GetEvents(ctx, or.namespace.Name, fb.Eq("sequence", sequence))
+		if err != nil {
+			return nil, err
+		}
+		if len(events) == 0 {
+			return nil, i18n.NewError(ctx, coremsgs.MsgAsOfEventNotFound, sequence)
+		}
+		return events[0].Created, nil
+	}
+	t, err := fftypes.ParseTimeString(asOf)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgInvalidAsOf, asOf)
+	}
+	return t, nil
+}
+
+// messageAsOf rewinds the state of a message to a point in time. The confirmed time is set on the final
+// transition of a message (to confirmed, rejected or quarantined), so before then the message was still
+// awaiting confirmation. Messages in any other state are returned unchanged.
+func messageAsOf(msg *core.Message, asOf *fftypes.FFTime) *core.Message {
+	if msg.Confirmed != nil && msg.Confirmed.Time().After(*asOf.Time()) {
+		msg.State = core.MessageStatePending
+		msg.Confirmed = nil
+		msg.RejectReason = ""
+	}
+	return msg
+}
+
+func (or *orchestrator) GetMessageByIDAsOf(ctx context.Context, id, asOf string) (*core.Message, error) {
+	t, err := or.resolveAsOf(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := or.getMessageByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Header.Created != nil && msg.Header.Created.Time().After(*t.Time()) {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return messageAsOf(msg, t), nil
+}
+
+func (or *orchestrator) GetMessagesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf string) ([]*core.Message, *ffapi.FilterResult, error) {
+	t, err := or.resolveAsOf(ctx, asOf)
+	if err != nil {
+		return nil, nil, err
+	}
+	filter = filter.Condition(filter.Builder().Lte("created", t))
+	msgs, fr, err := or.database().GetMessages(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, msg := range msgs {
+		messageAsOf(msg, t)
+	}
+	return msgs, fr, nil
+}
+
+func (or *orchestrator) GetTokenBalancesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf string) ([]*core.TokenBalance, *ffapi.FilterResult, error) {
+	t, err := or.resolveAsOf(ctx, asOf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return or.assets.GetTokenBalancesAsOf(ctx, filter, t)
+}
@@ -0,0 +1,222 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestAsOfMessage(created, confirmed time.Time) *core.Message {
+	c := fftypes.FFTime(created)
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			ID:      fftypes.NewUUID(),
+			Created: &c,
+		},
+		State:        core.MessageStateRejected,
+		RejectReason: "bad",
+	}
+	if !confirmed.IsZero() {
+		cf := fftypes.FFTime(confirmed)
+		msg.Confirmed = &cf
+	}
+	return msg
+}
+
+func TestResolveAsOfTimestamp(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	ts, err := or.resolveAsOf(context.Background(), "2024-03-31T23:59:59Z")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-31T23:59:59Z", ts.String())
+}
+
+func TestResolveAsOfSequence(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	created := fftypes.Now()
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi, err := filter.Finalize()
+		return err == nil && fi.String() == "sequence == 12345"
+	})).Return([]*core.Event{{Sequence: 12345, Created: created}}, nil, nil)
+
+	ts, err := or.resolveAsOf(context.Background(), "12345")
+	assert.NoError(t, err)
+	assert.Equal(t, created, ts)
+}
+
+func TestResolveAsOfSequenceNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{}, nil, nil)
+
+	_, err := or.resolveAsOf(context.Background(), "12345")
+	assert.Regexp(t, "FF10586", err)
+}
+
+func TestResolveAsOfSequenceFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.resolveAsOf(context.Background(), "12345")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestResolveAsOfInvalid(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.resolveAsOf(context.Background(), "end of quarter")
+	assert.Regexp(t, "FF10585", err)
+}
+
+func TestGetMessageByIDAsOfBeforeConfirmed(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := newTestAsOfMessage(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+
+	res, err := or.GetMessageByIDAsOf(context.Background(), msg.Header.ID.String(), "2024-03-31T23:59:59Z")
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStatePending, res.State)
+	assert.Nil(t, res.Confirmed)
+	assert.Empty(t, res.RejectReason)
+}
+
+func TestGetMessageByIDAsOfAfterConfirmed(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := newTestAsOfMessage(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC))
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+
+	res, err := or.GetMessageByIDAsOf(context.Background(), msg.Header.ID.String(), "2024-03-31T23:59:59Z")
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStateRejected, res.State)
+	assert.NotNil(t, res.Confirmed)
+	assert.Equal(t, "bad", res.RejectReason)
+}
+
+func TestGetMessageByIDAsOfNotCreated(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := newTestAsOfMessage(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+
+	_, err := or.GetMessageByIDAsOf(context.Background(), msg.Header.ID.String(), "2024-03-31T23:59:59Z")
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetMessageByIDAsOfNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+
+	_, err := or.GetMessageByIDAsOf(context.Background(), fftypes.NewUUID().String(), "2024-03-31T23:59:59Z")
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetMessageByIDAsOfBadAsOf(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.GetMessageByIDAsOf(context.Background(), fftypes.NewUUID().String(), "!time")
+	assert.Regexp(t, "FF10585", err)
+}
+
+func TestGetMessagesAsOf(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg1 := newTestAsOfMessage(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+	msg2 := newTestAsOfMessage(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{})
+	msg2.State = core.MessageStateSent
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg1, msg2}, nil, nil)
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	msgs, _, err := or.GetMessagesAsOf(context.Background(), fb.And(fb.Eq("tag", "tag1")), "2024-03-31T23:59:59Z")
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageStatePending, msgs[0].State)
+	assert.Equal(t, core.MessageStateSent, msgs[1].State)
+
+	calculatedFilter, err := or.mdi.Calls[0].Arguments[2].(ffapi.Filter).Finalize()
+	assert.NoError(t, err)
+	ts, _ := fftypes.ParseTimeString("2024-03-31T23:59:59Z")
+	assert.Equal(t, fmt.Sprintf("( tag == 'tag1' ) && ( created <= %d )", ts.UnixNano()), calculatedFilter.String())
+}
+
+func TestGetMessagesAsOfFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetMessagesAsOf(context.Background(), fb.And(), "2024-03-31T23:59:59Z")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessagesAsOfBadAsOf(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetMessagesAsOf(context.Background(), fb.And(), "!time")
+	assert.Regexp(t, "FF10585", err)
+}
+
+func TestGetTokenBalancesAsOf(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	ts, _ := fftypes.ParseTimeString("2024-03-31T23:59:59Z")
+	fb := database.TokenBalanceQueryFactory.NewFilter(context.Background())
+	filter := fb.And()
+	balances := []*core.TokenBalance{{Key: "0x1"}}
+	or.mam.On("GetTokenBalancesAsOf", mock.Anything, filter, ts).Return(balances, nil, nil)
+
+	res, _, err := or.GetTokenBalancesAsOf(context.Background(), filter, "2024-03-31T23:59:59Z")
+	assert.NoError(t, err)
+	assert.Equal(t, balances, res)
+}
+
+func TestGetTokenBalancesAsOfBadAsOf(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	fb := database.TokenBalanceQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetTokenBalancesAsOf(context.Background(), fb.And(), "!time")
+	assert.Regexp(t, "FF10585", err)
+}
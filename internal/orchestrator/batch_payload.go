@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// GetBatchPayload returns the full payload of a batch, in the form it would be written to shared storage.
+// This allows the payload of a pin-only broadcast to be distributed to other members out-of-band.
+func (or *orchestrator) GetBatchPayload(ctx context.Context, id string) (*core.Batch, error) {
+	persisted, err := or.GetBatchByID(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if persisted == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	batch, err := or.data.HydrateBatch(ctx, persisted)
+	if err != nil {
+		return nil, err
+	}
+	batch.Namespace = or.namespace.NetworkName
+	return batch, nil
+}
+
+// AttachBatchPayload stores a batch payload received out-of-band, after verifying it against the pins
+// received from the blockchain. The batch is then processed in the same way as a downloaded batch.
+func (or *orchestrator) AttachBatchPayload(ctx context.Context, id string, batch *core.Batch) (*core.BatchPersisted, error) {
+	batchID, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !batchID.Equals(batch.ID) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchPayloadIDMismatch, batch.ID, batchID)
+	}
+	return or.events.AttachBatchPayload(ctx, batch)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetBatchPayloadOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.namespace.NetworkName = "ns2"
+
+	bp := &core.BatchPersisted{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Namespace: "ns"}}
+	batch := &core.Batch{BatchHeader: bp.BatchHeader}
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdm.On("HydrateBatch", mock.Anything, bp).Return(batch, nil)
+
+	res, err := or.GetBatchPayload(or.ctx, bp.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, bp.ID, res.ID)
+	assert.Equal(t, "ns2", res.Namespace)
+}
+
+func TestGetBatchPayloadBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.GetBatchPayload(or.ctx, "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetBatchPayloadNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	id := fftypes.NewUUID()
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", id).Return(nil, nil)
+
+	_, err := or.GetBatchPayload(or.ctx, id.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetBatchPayloadHydrateFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp := &core.BatchPersisted{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID(), Namespace: "ns"}}
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdm.On("HydrateBatch", mock.Anything, bp).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.GetBatchPayload(or.ctx, bp.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestAttachBatchPayloadOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batch := &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}}
+	bp := &core.BatchPersisted{BatchHeader: batch.BatchHeader}
+	or.mem.On("AttachBatchPayload", mock.Anything, batch).Return(bp, nil)
+
+	res, err := or.AttachBatchPayload(or.ctx, batch.ID.String(), batch)
+	assert.NoError(t, err)
+	assert.Equal(t, bp, res)
+}
+
+func TestAttachBatchPayloadBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.AttachBatchPayload(or.ctx, "bad", &core.Batch{})
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestAttachBatchPayloadIDMismatch(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batch := &core.Batch{BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()}}
+	_, err := or.AttachBatchPayload(or.ctx, fftypes.NewUUID().String(), batch)
+	assert.Regexp(t, "FF10531", err)
+}
@@ -0,0 +1,200 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// VerifyBatch re-computes the hash of a batch from the messages and data stored locally, and compares it
+// to the hash of the batch and the hash recorded in any on-chain pins. Discrepancies are returned in the
+// report, rather than as errors - errors are only returned if the records cannot be queried.
+func (or *orchestrator) VerifyBatch(ctx context.Context, id string) (*core.BatchVerification, error) {
+	batch, err := or.GetBatchByID(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if batch == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	report, _, err := or.verifyStoredBatch(ctx, batch)
+	return report, err
+}
+
+func newBatchVerification(batch *core.BatchPersisted) *core.BatchVerification {
+	return &core.BatchVerification{
+		Batch:      batch.ID,
+		Valid:      true,
+		Hash:       batch.Hash,
+		Mismatches: []*core.BatchMismatch{},
+	}
+}
+
+// verifyStoredBatch replays the hash of a batch from the messages and data stored locally, returning
+// the on-chain pins of the batch alongside the report
+func (or *orchestrator) verifyStoredBatch(ctx context.Context, batch *core.BatchPersisted) (*core.BatchVerification, []*core.Pin, error) {
+	report := newBatchVerification(batch)
+	pins, err := or.verifyBatchPins(ctx, batch, report)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var manifest core.BatchManifest
+	if err := batch.Manifest.Unmarshal(ctx, &manifest); err != nil {
+		report.AddMismatch(&core.BatchMismatch{
+			Type:  core.BatchMismatchTypeManifest,
+			Error: err.Error(),
+		})
+		return report, pins, nil
+	}
+	report.HashAlgorithm = manifest.HashAlgorithm
+
+	messages, data, err := or.verifyBatchContent(ctx, batch, &manifest, report)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(messages) != len(manifest.Messages) || len(data) != len(manifest.Data) {
+		// The batch cannot be replayed with missing content
+		return report, pins, nil
+	}
+
+	// Replay the sealing of the manifest, with the same compression and hash algorithm
+	regenerated := batch.GenManifest(messages, data).
+		SetCompression(manifest.Compression).
+		SetHashAlgorithm(manifest.HashAlgorithm)
+	if manifest.Version >= core.ManifestVersion3 {
+		regenerated.SetEntryDetails(messages, data)
+	}
+	if regenerated.String() != batch.Manifest.String() {
+		report.AddMismatch(&core.BatchMismatch{
+			Type:     core.BatchMismatchTypeManifest,
+			Expected: batch.Manifest.String(),
+			Found:    regenerated.String(),
+		})
+	}
+	verifyBatchHash(ctx, batch.Hash, regenerated, &core.BatchPayload{TX: batch.TX, Messages: messages, Data: data}, report)
+	return report, pins, nil
+}
+
+// verifyBatchHash compares the hash of a batch to the hash calculated from its manifest
+func verifyBatchHash(ctx context.Context, hash *fftypes.Bytes32, manifest *core.BatchManifest, payload *core.BatchPayload, report *core.BatchVerification) {
+	var err error
+	report.CalculatedHash, err = manifest.Hash(ctx)
+	if err != nil {
+		report.AddMismatch(&core.BatchMismatch{
+			Type:  core.BatchMismatchTypeBatchHash,
+			Error: err.Error(),
+		})
+		return
+	}
+	if !report.CalculatedHash.Equals(hash) {
+		// Batches written by v0.13 and older environments are hashed on the whole payload
+		if payloadHash := payload.Hash(); payloadHash.Equals(hash) {
+			report.CalculatedHash = payloadHash
+		} else {
+			report.AddMismatch(&core.BatchMismatch{
+				Type:     core.BatchMismatchTypeBatchHash,
+				Expected: hash.String(),
+				Found:    report.CalculatedHash.String(),
+			})
+		}
+	}
+}
+
+func (or *orchestrator) verifyBatchPins(ctx context.Context, batch *core.BatchPersisted, report *core.BatchVerification) ([]*core.Pin, error) {
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := or.database().GetPins(ctx, or.namespace.Name, fb.Eq("batch", batch.ID))
+	if err != nil {
+		return nil, err
+	}
+	report.Pins = len(pins)
+	for _, pin := range pins {
+		report.PinnedHash = pin.BatchHash
+		if !pin.BatchHash.Equals(batch.Hash) {
+			report.AddMismatch(&core.BatchMismatch{
+				Type:     core.BatchMismatchTypePin,
+				Expected: pin.BatchHash.String(),
+				Found:    batch.Hash.String(),
+			})
+		}
+	}
+	if len(pins) == 0 && batch.TX.Type != core.TransactionTypeUnpinned {
+		report.AddMismatch(&core.BatchMismatch{
+			Type:  core.BatchMismatchTypePin,
+			Error: i18n.NewError(ctx, coremsgs.MsgBatchVerifyNoPins, batch.ID).Error(),
+		})
+	}
+	return pins, nil
+}
+
+func (or *orchestrator) verifyBatchContent(ctx context.Context, batch *core.BatchPersisted, manifest *core.BatchManifest, report *core.BatchVerification) ([]*core.Message, core.DataArray, error) {
+	messages := make([]*core.Message, 0, len(manifest.Messages))
+	for _, entry := range manifest.Messages {
+		msg, err := or.database().GetMessageByID(ctx, or.namespace.Name, entry.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		mismatch := &core.BatchMismatch{Type: core.BatchMismatchTypeMessage, ID: entry.ID, Expected: entry.Hash.String()}
+		switch {
+		case msg == nil:
+			mismatch.Error = i18n.NewError(ctx, coremsgs.MsgBatchVerifyNotFound, "message", entry.ID).Error()
+			report.AddMismatch(mismatch)
+			continue
+		case !msg.Hash.Equals(entry.Hash):
+			mismatch.Found = msg.Hash.String()
+			report.AddMismatch(mismatch)
+		default:
+			if err := msg.Verify(ctx); err != nil {
+				mismatch.Found = msg.Hash.String()
+				mismatch.Error = err.Error()
+				report.AddMismatch(mismatch)
+			}
+		}
+		messages = append(messages, msg.BatchMessage())
+	}
+
+	data := make(core.DataArray, 0, len(manifest.Data))
+	for _, entry := range manifest.Data {
+		d, err := or.database().GetDataByID(ctx, or.namespace.Name, entry.ID, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		mismatch := &core.BatchMismatch{Type: core.BatchMismatchTypeData, ID: entry.ID, Expected: entry.Hash.String()}
+		if d == nil {
+			mismatch.Error = i18n.NewError(ctx, coremsgs.MsgBatchVerifyNotFound, "data", entry.ID).Error()
+			report.AddMismatch(mismatch)
+			continue
+		}
+		hash, err := d.CalcHash(ctx)
+		switch {
+		case err != nil:
+			mismatch.Found = d.Hash.String()
+			mismatch.Error = err.Error()
+			report.AddMismatch(mismatch)
+		case !hash.Equals(entry.Hash) || !d.Hash.Equals(entry.Hash):
+			mismatch.Found = hash.String()
+			report.AddMismatch(mismatch)
+		}
+		data = append(data, d.BatchData(batch.Type))
+	}
+	return messages, data, nil
+}
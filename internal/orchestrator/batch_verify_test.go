@@ -0,0 +1,371 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestVerifyBatch(t *testing.T) (*core.BatchPersisted, *core.Message, *core.Data) {
+	ctx := context.Background()
+	data := &core.Data{
+		ID:    fftypes.NewUUID(),
+		Value: fftypes.JSONAnyPtr(`{"some":"data"}`),
+	}
+	err := data.Seal(ctx, nil)
+	assert.NoError(t, err)
+	msg := &core.Message{
+		Header: core.MessageHeader{
+			Type:   core.MessageTypeBroadcast,
+			Topics: fftypes.FFStringArray{"topic1"},
+			SignerRef: core.SignerRef{
+				Author: "did:firefly:org/org1",
+				Key:    "0x12345",
+			},
+		},
+		Data: core.DataRefs{{ID: data.ID, Hash: data.Hash}},
+	}
+	err = msg.Seal(ctx)
+	assert.NoError(t, err)
+	batch := &core.Batch{
+		BatchHeader: core.BatchHeader{
+			ID:   fftypes.NewUUID(),
+			Type: core.BatchTypeBroadcast,
+		},
+		Payload: core.BatchPayload{
+			TX: core.TransactionRef{
+				ID:   fftypes.NewUUID(),
+				Type: core.TransactionTypeBatchPin,
+			},
+			Messages: []*core.Message{msg.BatchMessage()},
+			Data:     core.DataArray{data.BatchData(core.BatchTypeBroadcast)},
+		},
+	}
+	bp, manifest := batch.Confirmed()
+	bp.Hash, err = manifest.Hash(ctx)
+	assert.NoError(t, err)
+	return bp, msg, data
+}
+
+func mockVerifyBatchLookups(or *testOrchestrator, bp *core.BatchPersisted, msg *core.Message, data *core.Data, pinnedHash *fftypes.Bytes32) {
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: bp.ID, BatchHash: pinnedHash, Signer: "0x12345"},
+	}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+}
+
+func TestVerifyBatchOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Empty(t, report.Mismatches)
+	assert.Equal(t, bp.Hash, report.CalculatedHash)
+	assert.Equal(t, bp.Hash, report.PinnedHash)
+	assert.Equal(t, 1, report.Pins)
+}
+
+func TestVerifyBatchHashAlgorithm(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	var manifest core.BatchManifest
+	_ = bp.Manifest.Unmarshal(context.Background(), &manifest)
+	manifest.SetHashAlgorithm(core.HashAlgorithmSHA3_256)
+	bp.Manifest = fftypes.JSONAnyPtr(manifest.String())
+	bp.Hash, _ = manifest.Hash(context.Background())
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, core.HashAlgorithmSHA3_256, report.HashAlgorithm)
+	assert.Equal(t, bp.Hash, report.CalculatedHash)
+}
+
+func TestVerifyBatchManifestEntryDetails(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	batchData := data.BatchData(core.BatchTypeBroadcast)
+	manifest := bp.GenManifest([]*core.Message{msg.BatchMessage()}, core.DataArray{batchData}).
+		SetEntryDetails([]*core.Message{msg.BatchMessage()}, core.DataArray{batchData})
+	bp.Manifest = fftypes.JSONAnyPtr(manifest.String())
+	bp.Hash, _ = manifest.Hash(context.Background())
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Empty(t, report.Mismatches)
+	assert.Equal(t, bp.Hash, report.CalculatedHash)
+}
+
+func TestVerifyBatchUnsupportedHashAlgorithm(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	var manifest core.BatchManifest
+	_ = bp.Manifest.Unmarshal(context.Background(), &manifest)
+	manifest.SetHashAlgorithm("md5")
+	bp.Manifest = fftypes.JSONAnyPtr(manifest.String())
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Mismatches, 1)
+	assert.Equal(t, core.BatchMismatchTypeBatchHash, report.Mismatches[0].Type)
+	assert.Regexp(t, "FF10511", report.Mismatches[0].Error)
+}
+
+func TestVerifyBatchLegacyPayloadHash(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	bp.Hash = (&core.BatchPayload{
+		TX:       bp.TX,
+		Messages: []*core.Message{msg.BatchMessage()},
+		Data:     core.DataArray{data.BatchData(bp.Type)},
+	}).Hash()
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, bp.Hash, report.CalculatedHash)
+}
+
+func TestVerifyBatchHashMismatch(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	calculated := bp.Hash
+	bp.Hash = fftypes.NewRandB32()
+	mockVerifyBatchLookups(or, bp, msg, data, fftypes.NewRandB32())
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Mismatches, 2)
+	assert.Equal(t, core.BatchMismatchTypePin, report.Mismatches[0].Type)
+	assert.Equal(t, bp.Hash.String(), report.Mismatches[0].Found)
+	assert.Equal(t, core.BatchMismatchTypeBatchHash, report.Mismatches[1].Type)
+	assert.Equal(t, bp.Hash.String(), report.Mismatches[1].Expected)
+	assert.Equal(t, calculated.String(), report.Mismatches[1].Found)
+}
+
+func TestVerifyBatchManifestMismatch(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	var manifest core.BatchManifest
+	_ = bp.Manifest.Unmarshal(context.Background(), &manifest)
+	reformatted, _ := json.MarshalIndent(&manifest, "", "  ")
+	bp.Manifest = fftypes.JSONAnyPtrBytes(reformatted)
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Mismatches, 1)
+	assert.Equal(t, core.BatchMismatchTypeManifest, report.Mismatches[0].Type)
+	assert.Equal(t, bp.Hash, report.CalculatedHash)
+}
+
+func TestVerifyBatchBadManifest(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, _, _ := newTestVerifyBatch(t)
+	bp.Manifest = fftypes.JSONAnyPtr("!json")
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Mismatches, 2)
+	assert.Equal(t, core.BatchMismatchTypePin, report.Mismatches[0].Type)
+	assert.Regexp(t, "FF10512", report.Mismatches[0].Error)
+	assert.Equal(t, core.BatchMismatchTypeManifest, report.Mismatches[1].Type)
+}
+
+func TestVerifyBatchUnpinnedNoPins(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	bp.TX.Type = core.TransactionTypeUnpinned
+	manifest := bp.GenManifest([]*core.Message{msg.BatchMessage()}, core.DataArray{data.BatchData(core.BatchTypeBroadcast)})
+	bp.Manifest = fftypes.JSONAnyPtr(manifest.String())
+	bp.Hash, _ = manifest.Hash(context.Background())
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(data, nil)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.Empty(t, report.Mismatches)
+	assert.True(t, report.Valid)
+	assert.Zero(t, report.Pins)
+}
+
+func TestVerifyBatchContentMissing(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{{BatchHash: bp.Hash}}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(nil, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(nil, nil)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Nil(t, report.CalculatedHash)
+	assert.Len(t, report.Mismatches, 2)
+	assert.Equal(t, core.BatchMismatchTypeMessage, report.Mismatches[0].Type)
+	assert.Equal(t, msg.Header.ID, report.Mismatches[0].ID)
+	assert.Regexp(t, "FF10513.*message", report.Mismatches[0].Error)
+	assert.Equal(t, core.BatchMismatchTypeData, report.Mismatches[1].Type)
+	assert.Regexp(t, "FF10513.*data", report.Mismatches[1].Error)
+}
+
+func TestVerifyBatchContentTampered(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	msg.Header.Tag = "tampered"
+	data.Value = fftypes.JSONAnyPtr(`{"some":"tampered"}`)
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Mismatches, 2)
+	assert.Equal(t, core.BatchMismatchTypeMessage, report.Mismatches[0].Type)
+	assert.Regexp(t, "FF00132", report.Mismatches[0].Error)
+	assert.Equal(t, core.BatchMismatchTypeData, report.Mismatches[1].Type)
+	assert.Equal(t, data.Hash.String(), report.Mismatches[1].Expected)
+	assert.NotEqual(t, data.Hash.String(), report.Mismatches[1].Found)
+	// The manifest is unchanged, as it is regenerated from the stored hashes
+	assert.Equal(t, bp.Hash, report.CalculatedHash)
+}
+
+func TestVerifyBatchContentHashesChanged(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	msg.Hash = fftypes.NewRandB32()
+	data.HashAlgorithm = "md5"
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+
+	report, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Len(t, report.Mismatches, 4)
+	assert.Equal(t, core.BatchMismatchTypeMessage, report.Mismatches[0].Type)
+	assert.Equal(t, msg.Hash.String(), report.Mismatches[0].Found)
+	assert.Equal(t, core.BatchMismatchTypeData, report.Mismatches[1].Type)
+	assert.Regexp(t, "FF10511", report.Mismatches[1].Error)
+	assert.Equal(t, core.BatchMismatchTypeManifest, report.Mismatches[2].Type)
+	assert.Equal(t, core.BatchMismatchTypeBatchHash, report.Mismatches[3].Type)
+}
+
+func TestVerifyBatchGetMessageFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchGetDataFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetDataByID", mock.Anything, "ns", data.ID, true).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchGetPinsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, _, _ := newTestVerifyBatch(t)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.VerifyBatch(context.Background(), bp.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyBatchNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batchID := fftypes.NewUUID()
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batchID).Return(nil, nil)
+
+	_, err := or.VerifyBatch(context.Background(), batchID.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestVerifyBatchBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.VerifyBatch(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
@@ -18,6 +18,7 @@ package orchestrator
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
@@ -26,6 +27,8 @@ import (
 	"github.com/hyperledger/firefly/pkg/database"
 )
 
+const chartAggregateDefaultBuckets = 60
+
 func (or *orchestrator) getHistogramIntervals(startTime int64, endTime int64, numBuckets int64) (intervals []core.ChartHistogramInterval) {
 	timeIntervalLength := (endTime - startTime) / numBuckets
 
@@ -56,3 +59,32 @@ func (or *orchestrator) GetChartHistogram(ctx context.Context, startTime int64,
 
 	return histogram, nil
 }
+
+// GetChartAggregate returns the counts of a collection in buckets of a fixed interval, aligned to the interval.
+// Without a start time, the buckets cover the default number of intervals before the end time - which defaults to now.
+func (or *orchestrator) GetChartAggregate(ctx context.Context, collection database.CollectionName, interval core.ChartInterval, startTime, endTime *fftypes.FFTime) ([]*core.ChartHistogram, error) {
+	width, ok := core.ChartIntervalDurations[interval]
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidChartInterval, interval)
+	}
+	end := time.Now()
+	if endTime != nil {
+		end = *endTime.Time()
+	}
+	start := end.Add(-width * chartAggregateDefaultBuckets)
+	if startTime != nil {
+		start = *startTime.Time()
+	}
+	if !start.Before(end) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgHistogramInvalidTimes)
+	}
+
+	// Align the buckets to the interval, and include the bucket containing the end time
+	start = start.Truncate(width)
+	buckets := int64((end.Sub(start) + width - 1) / width)
+	if buckets > core.ChartHistogramMaxBuckets {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidNumberOfIntervals, core.ChartHistogramMinBuckets, core.ChartHistogramMaxBuckets)
+	}
+	bucketStart := fftypes.FFTime(start)
+	return or.database().GetChartAggregate(ctx, or.namespace.Name, collection, &bucketStart, width, int(buckets))
+}
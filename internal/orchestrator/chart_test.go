@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -73,3 +74,51 @@ func TestGetHistogramSuccess(t *testing.T) {
 	_, err := or.GetChartHistogram(context.Background(), 1000000000, 1000000010, 10, database.CollectionName("test"))
 	assert.NoError(t, err)
 }
+
+func chartTime(t *testing.T, s string) *fftypes.FFTime {
+	ts, err := fftypes.ParseTimeString(s)
+	assert.NoError(t, err)
+	return ts
+}
+
+func TestGetChartAggregateAligned(t *testing.T) {
+	or := newTestOrchestrator()
+	or.mdi.On("GetChartAggregate", context.Background(), "ns", database.CollectionName("messages"),
+		chartTime(t, "2023-01-01T10:00:00Z"), time.Hour, 3).Return([]*core.ChartHistogram{}, nil)
+	_, err := or.GetChartAggregate(context.Background(), database.CollectionName("messages"), core.ChartIntervalHour,
+		chartTime(t, "2023-01-01T10:30:00Z"), chartTime(t, "2023-01-01T12:15:00Z"))
+	assert.NoError(t, err)
+	or.mdi.AssertExpectations(t)
+}
+
+func TestGetChartAggregateDefaults(t *testing.T) {
+	or := newTestOrchestrator()
+	or.mdi.On("GetChartAggregate", context.Background(), "ns", database.CollectionName("messages"),
+		mock.Anything, time.Minute, mock.MatchedBy(func(buckets int) bool {
+			// 60 intervals, plus the partial interval at the start when now is not aligned
+			return buckets == 60 || buckets == 61
+		})).Return([]*core.ChartHistogram{}, nil)
+	_, err := or.GetChartAggregate(context.Background(), database.CollectionName("messages"), core.ChartIntervalMinute, nil, nil)
+	assert.NoError(t, err)
+	or.mdi.AssertExpectations(t)
+}
+
+func TestGetChartAggregateBadInterval(t *testing.T) {
+	or := newTestOrchestrator()
+	_, err := or.GetChartAggregate(context.Background(), database.CollectionName("messages"), "week", nil, nil)
+	assert.Regexp(t, "FF10600", err)
+}
+
+func TestGetChartAggregateBadStartEndTimes(t *testing.T) {
+	or := newTestOrchestrator()
+	_, err := or.GetChartAggregate(context.Background(), database.CollectionName("messages"), core.ChartIntervalMinute,
+		chartTime(t, "2023-01-01T12:00:00Z"), chartTime(t, "2023-01-01T10:00:00Z"))
+	assert.Regexp(t, "FF10300", err)
+}
+
+func TestGetChartAggregateTooManyBuckets(t *testing.T) {
+	or := newTestOrchestrator()
+	_, err := or.GetChartAggregate(context.Background(), database.CollectionName("messages"), core.ChartIntervalMinute,
+		chartTime(t, "2023-01-01T00:00:00Z"), chartTime(t, "2023-01-02T00:00:00Z"))
+	assert.Regexp(t, "FF10298", err)
+}
@@ -45,13 +45,27 @@ func (or *orchestrator) GetTransactionOperations(ctx context.Context, id string)
 	if err != nil {
 		return nil, nil, err
 	}
+	// Operations are returned in the order they were submitted
 	fb := database.OperationQueryFactory.NewFilter(ctx)
 	filter := fb.And(
 		fb.Eq("tx", u),
-	)
+	).Sort("created").Ascending()
 	return or.database().GetOperations(ctx, or.namespace.Name, filter)
 }
 
+func (or *orchestrator) GetTransactionEvents(ctx context.Context, id string) ([]*core.Event, *ffapi.FilterResult, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Events are returned in the order they were emitted
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("tx", u),
+	).Sort("sequence").Ascending()
+	return or.database().GetEvents(ctx, or.namespace.Name, filter)
+}
+
 func (or *orchestrator) getMessageByID(ctx context.Context, id string) (*core.Message, error) {
 	u, err := fftypes.ParseUUID(ctx, id)
 	if err != nil {
@@ -283,6 +297,14 @@ func (or *orchestrator) GetOperations(ctx context.Context, filter ffapi.AndFilte
 	return or.database().GetOperations(ctx, or.namespace.Name, filter)
 }
 
+func (or *orchestrator) GetOperationsByTags(ctx context.Context, tags []string, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	return or.database().GetOperationsByTags(ctx, or.namespace.Name, tags, filter)
+}
+
+func (or *orchestrator) GetStalledOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	return or.operations.GetStalledOperations(ctx, filter)
+}
+
 func (or *orchestrator) GetEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error) {
 	return or.database().GetEvents(ctx, or.namespace.Name, filter)
 }
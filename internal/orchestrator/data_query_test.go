@@ -60,6 +60,26 @@ func TestGetTransactionOperationsOk(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetTransactionEventsOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	txID := fftypes.NewUUID()
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		fi, _ := filter.Finalize()
+		return fi.String() == fmt.Sprintf("( tx == '%s' ) sort=sequence", txID)
+	})).Return([]*core.Event{}, nil, nil)
+	_, _, err := or.GetTransactionEvents(context.Background(), txID.String())
+	assert.NoError(t, err)
+	or.mdi.AssertExpectations(t)
+}
+
+func TestGetTransactionEventsBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	_, _, err := or.GetTransactionEvents(context.Background(), "")
+	assert.Regexp(t, "FF00138", err)
+}
+
 func TestGetTransactionOperationBadID(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -635,6 +655,25 @@ func TestGetOperations(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetOperationsByTags(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetOperationsByTags", mock.Anything, "ns", []string{"invoice-123"}, mock.Anything).Return([]*core.Operation{}, nil, nil)
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetOperationsByTags(context.Background(), []string{"invoice-123"}, fb.And())
+	assert.NoError(t, err)
+}
+
+func TestGetStalledOperations(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	fb := database.OperationQueryFactory.NewFilter(context.Background())
+	f := fb.And()
+	or.mom.On("GetStalledOperations", mock.Anything, f).Return([]*core.Operation{}, nil, nil)
+	_, _, err := or.GetStalledOperations(context.Background(), f)
+	assert.NoError(t, err)
+}
+
 func TestGetEvents(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -0,0 +1,274 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (or *orchestrator) ExportDefinitions(ctx context.Context) (*core.DefinitionBundle, error) {
+	bundle := &core.DefinitionBundle{
+		Namespace: or.namespace.Name,
+		Exported:  fftypes.Now(),
+	}
+
+	var err error
+	dfb := database.DatatypeQueryFactory.NewFilter(ctx)
+	if bundle.Datatypes, _, err = or.database().GetDatatypes(ctx, or.namespace.Name, dfb.And().Sort("created")); err != nil {
+		return nil, err
+	}
+
+	ffb := database.FFIQueryFactory.NewFilter(ctx)
+	ffis, _, err := or.database().GetFFIs(ctx, or.namespace.Name, ffb.And())
+	if err != nil {
+		return nil, err
+	}
+	ffisByID := make(map[fftypes.UUID]*fftypes.FFI, len(ffis))
+	bundle.FFIs = make([]*fftypes.FFI, 0, len(ffis))
+	for _, ffi := range ffis {
+		withChildren, err := or.contracts.GetFFIByIDWithChildren(ctx, ffi.ID)
+		if err != nil {
+			return nil, err
+		}
+		ffisByID[*withChildren.ID] = withChildren
+		bundle.FFIs = append(bundle.FFIs, withChildren)
+	}
+
+	afb := database.ContractAPIQueryFactory.NewFilter(ctx)
+	if bundle.ContractAPIs, _, err = or.database().GetContractAPIs(ctx, or.namespace.Name, afb.And()); err != nil {
+		return nil, err
+	}
+	for _, api := range bundle.ContractAPIs {
+		exportFFIReference(api.Interface, ffisByID)
+	}
+
+	// Pools that never became active have no definition to carry across
+	pfb := database.TokenPoolQueryFactory.NewFilter(ctx)
+	if bundle.TokenPools, _, err = or.database().GetTokenPools(ctx, or.namespace.Name, pfb.And(pfb.Eq("active", true)).Sort("created")); err != nil {
+		return nil, err
+	}
+	for _, pool := range bundle.TokenPools {
+		exportFFIReference(pool.Interface, ffisByID)
+	}
+
+	gfb := database.GroupQueryFactory.NewFilter(ctx)
+	if bundle.Groups, _, err = or.database().GetGroups(ctx, or.namespace.Name, gfb.And().Sort("created")); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// exportFFIReference fills in the name and version of a referenced interface, as the ID
+// will be different in the environment the bundle is imported into
+func exportFFIReference(ref *fftypes.FFIReference, ffisByID map[fftypes.UUID]*fftypes.FFI) {
+	if ref == nil || ref.ID == nil {
+		return
+	}
+	if ffi, ok := ffisByID[*ref.ID]; ok {
+		ref.Name = ffi.Name
+		ref.Version = ffi.Version
+	}
+}
+
+// importFFIReference drops the ID of a referenced interface that can be resolved by name and version
+func importFFIReference(ref *fftypes.FFIReference) {
+	if ref != nil && ref.Name != "" && ref.Version != "" {
+		ref.ID = nil
+	}
+}
+
+func definitionImported(item *core.DefinitionImportItem, id *fftypes.UUID, err error) *core.DefinitionImportItem {
+	if err != nil {
+		item.Status = core.DefinitionImportStatusFailed
+		item.Error = err.Error()
+		return item
+	}
+	item.ID = id
+	item.Status = core.DefinitionImportStatusImported
+	return item
+}
+
+func definitionSkipped(item *core.DefinitionImportItem, id *fftypes.UUID) *core.DefinitionImportItem {
+	item.ID = id
+	item.Status = core.DefinitionImportStatusSkipped
+	return item
+}
+
+func (or *orchestrator) ImportDefinitions(ctx context.Context, httpServerURL string, bundle *core.DefinitionBundle, waitConfirm bool) (*core.DefinitionImportResult, error) {
+	result := &core.DefinitionImportResult{
+		Namespace:   or.namespace.Name,
+		Definitions: []*core.DefinitionImportItem{},
+	}
+	add := func(item *core.DefinitionImportItem, err error) error {
+		if err == nil {
+			result.Definitions = append(result.Definitions, item)
+		}
+		return err
+	}
+
+	// Definitions are imported in dependency order - interfaces before the APIs and pools that reference them
+	for _, datatype := range bundle.Datatypes {
+		if err := add(or.importDatatype(ctx, datatype, waitConfirm)); err != nil {
+			return nil, err
+		}
+	}
+	for _, ffi := range bundle.FFIs {
+		if err := add(or.importFFI(ctx, ffi, waitConfirm)); err != nil {
+			return nil, err
+		}
+	}
+	for _, api := range bundle.ContractAPIs {
+		if err := add(or.importContractAPI(ctx, httpServerURL, api, waitConfirm)); err != nil {
+			return nil, err
+		}
+	}
+	for _, pool := range bundle.TokenPools {
+		if err := add(or.importTokenPool(ctx, pool, waitConfirm)); err != nil {
+			return nil, err
+		}
+	}
+	for _, group := range bundle.Groups {
+		if err := add(or.importGroup(ctx, group)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (or *orchestrator) importDatatype(ctx context.Context, datatype *core.Datatype, waitConfirm bool) (*core.DefinitionImportItem, error) {
+	item := &core.DefinitionImportItem{Type: core.DefinitionTypeDatatype, Name: datatype.Name, Version: datatype.Version}
+	existing, err := or.database().GetDatatypeByName(ctx, or.namespace.Name, datatype.Name, datatype.Version)
+	if err != nil {
+		return nil, err
+	} else if existing != nil {
+		return definitionSkipped(item, existing.ID), nil
+	}
+
+	newDatatype := &core.Datatype{
+		Name:      datatype.Name,
+		Version:   datatype.Version,
+		Validator: datatype.Validator,
+		Value:     datatype.Value,
+	}
+	err = or.defsender.DefineDatatype(ctx, newDatatype, waitConfirm)
+	return definitionImported(item, newDatatype.ID, err), nil
+}
+
+func (or *orchestrator) importFFI(ctx context.Context, ffi *fftypes.FFI, waitConfirm bool) (*core.DefinitionImportItem, error) {
+	item := &core.DefinitionImportItem{Type: core.DefinitionTypeFFI, Name: ffi.Name, Version: ffi.Version}
+	existing, err := or.database().GetFFI(ctx, or.namespace.Name, ffi.Name, ffi.Version)
+	if err != nil {
+		return nil, err
+	} else if existing != nil {
+		return definitionSkipped(item, existing.ID), nil
+	}
+
+	// Published interfaces are broadcast again to the network of this namespace
+	ffi.Message = nil
+	err = or.defsender.DefineFFI(ctx, ffi, waitConfirm)
+	return definitionImported(item, ffi.ID, err), nil
+}
+
+func (or *orchestrator) importContractAPI(ctx context.Context, httpServerURL string, api *core.ContractAPI, waitConfirm bool) (*core.DefinitionImportItem, error) {
+	item := &core.DefinitionImportItem{Type: core.DefinitionTypeContractAPI, Name: api.Name, Version: api.Version}
+	existing, err := or.database().GetContractAPIByNameAndVersion(ctx, or.namespace.Name, api.Name, api.Version)
+	if err != nil {
+		return nil, err
+	} else if existing != nil {
+		return definitionSkipped(item, existing.ID), nil
+	}
+
+	api.ID = nil
+	api.Message = nil
+	api.URLs = core.ContractURLs{}
+	importFFIReference(api.Interface)
+	err = or.defsender.DefineContractAPI(ctx, httpServerURL, api, waitConfirm)
+	return definitionImported(item, api.ID, err), nil
+}
+
+func (or *orchestrator) importTokenPool(ctx context.Context, pool *core.TokenPool, waitConfirm bool) (*core.DefinitionImportItem, error) {
+	item := &core.DefinitionImportItem{Type: core.DefinitionTypeTokenPool, Name: pool.Name}
+	existing, err := or.database().GetTokenPool(ctx, or.namespace.Name, pool.Name)
+	if err != nil {
+		return nil, err
+	} else if existing != nil {
+		return definitionSkipped(item, existing.ID), nil
+	}
+
+	// The pool is created afresh by the connector in this environment, signed with the default key
+	input := &core.TokenPoolInput{
+		TokenPool: core.TokenPool{
+			Type:        pool.Type,
+			Name:        pool.Name,
+			NetworkName: pool.NetworkName,
+			Symbol:      pool.Symbol,
+			Connector:   pool.Connector,
+			Config:      pool.Config,
+			Interface:   pool.Interface,
+			Published:   pool.Published,
+		},
+	}
+	importFFIReference(input.Interface)
+	created, err := or.assets.CreateTokenPool(ctx, input, waitConfirm)
+	if err != nil {
+		return definitionImported(item, nil, err), nil
+	}
+	return definitionImported(item, created.ID, nil), nil
+}
+
+func (or *orchestrator) importGroup(ctx context.Context, group *core.Group) (*core.DefinitionImportItem, error) {
+	item := &core.DefinitionImportItem{Type: core.DefinitionTypeGroup, Name: group.Name}
+	if or.messaging == nil {
+		return definitionImported(item, nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)), nil
+	}
+
+	// Groups are scoped to the network name of the namespace, so the hash is recalculated on import.
+	// The group is distributed to the other members along with the first private message sent to it.
+	newGroup := &core.Group{
+		GroupIdentity: core.GroupIdentity{
+			Namespace: or.namespace.NetworkName,
+			Name:      group.Name,
+			Members:   group.Members,
+		},
+		LocalNamespace: or.namespace.Name,
+		Created:        fftypes.Now(),
+	}
+	newGroup.Seal()
+	item.Hash = newGroup.Hash
+
+	existing, err := or.database().GetGroupByHash(ctx, or.namespace.Name, newGroup.Hash)
+	if err != nil {
+		return nil, err
+	} else if existing != nil {
+		return definitionSkipped(item, nil), nil
+	}
+
+	if err := newGroup.Validate(ctx, true); err != nil {
+		return definitionImported(item, nil, err), nil
+	}
+	if err := or.database().UpsertGroup(ctx, newGroup, database.UpsertOptimizationNew); err != nil {
+		return nil, err
+	}
+	return definitionImported(item, nil, nil), nil
+}
@@ -0,0 +1,335 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testDefinitionBundle() *core.DefinitionBundle {
+	ffiID := fftypes.NewUUID()
+	return &core.DefinitionBundle{
+		Namespace: "dev",
+		Datatypes: []*core.Datatype{
+			{ID: fftypes.NewUUID(), Name: "widget", Version: "1.0", Value: fftypes.JSONAnyPtr(`{}`)},
+		},
+		FFIs: []*fftypes.FFI{
+			{ID: ffiID, Name: "erc20", Version: "1.0"},
+		},
+		ContractAPIs: []*core.ContractAPI{
+			{ID: fftypes.NewUUID(), Name: "coin", Version: "1.0", Interface: &fftypes.FFIReference{ID: ffiID, Name: "erc20", Version: "1.0"}},
+		},
+		TokenPools: []*core.TokenPool{
+			{ID: fftypes.NewUUID(), Name: "pool1", Type: core.TokenTypeFungible, Connector: "erc20_erc721", Key: "0x12345"},
+		},
+		Groups: []*core.Group{
+			{
+				GroupIdentity: core.GroupIdentity{
+					Namespace: "dev",
+					Name:      "group1",
+					Members:   core.Members{{Identity: "did:firefly:org/org1", Node: fftypes.NewUUID()}},
+				},
+			},
+		},
+	}
+}
+
+func TestExportDefinitions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	ffi := &fftypes.FFI{ID: fftypes.NewUUID(), Name: "erc20", Version: "1.0"}
+	ffiWithChildren := &fftypes.FFI{ID: ffi.ID, Name: "erc20", Version: "1.0", Methods: []*fftypes.FFIMethod{{Name: "transfer"}}}
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{{Name: "widget"}}, nil, nil)
+	or.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{ffi}, nil, nil)
+	or.mcm.On("GetFFIByIDWithChildren", mock.Anything, ffi.ID).Return(ffiWithChildren, nil)
+	or.mdi.On("GetContractAPIs", mock.Anything, "ns", mock.Anything).Return([]*core.ContractAPI{
+		{Name: "coin", Interface: &fftypes.FFIReference{ID: ffi.ID}},
+		{Name: "other", Interface: &fftypes.FFIReference{ID: fftypes.NewUUID()}},
+	}, nil, nil)
+	or.mdi.On("GetTokenPools", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		return f.String() == "( active == true ) sort=created"
+	})).Return([]*core.TokenPool{{Name: "pool1"}}, nil, nil)
+	or.mdi.On("GetGroups", mock.Anything, "ns", mock.Anything).Return([]*core.Group{}, nil, nil)
+
+	bundle, err := or.ExportDefinitions(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", bundle.Namespace)
+	assert.Len(t, bundle.Datatypes, 1)
+	assert.Len(t, bundle.FFIs[0].Methods, 1)
+	assert.Equal(t, "erc20", bundle.ContractAPIs[0].Interface.Name)
+	assert.Equal(t, "1.0", bundle.ContractAPIs[0].Interface.Version)
+	assert.Empty(t, bundle.ContractAPIs[1].Interface.Name)
+	assert.Len(t, bundle.TokenPools, 1)
+	assert.Empty(t, bundle.Groups)
+}
+
+func TestExportDefinitionsDatatypesFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.ExportDefinitions(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExportDefinitionsFFIsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	or.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.ExportDefinitions(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExportDefinitionsFFIChildrenFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	ffi := &fftypes.FFI{ID: fftypes.NewUUID()}
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	or.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{ffi}, nil, nil)
+	or.mcm.On("GetFFIByIDWithChildren", mock.Anything, ffi.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.ExportDefinitions(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExportDefinitionsContractAPIsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	or.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	or.mdi.On("GetContractAPIs", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.ExportDefinitions(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExportDefinitionsTokenPoolsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	or.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	or.mdi.On("GetContractAPIs", mock.Anything, "ns", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	or.mdi.On("GetTokenPools", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.ExportDefinitions(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExportDefinitionsGroupsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	or.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	or.mdi.On("GetContractAPIs", mock.Anything, "ns", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	or.mdi.On("GetTokenPools", mock.Anything, "ns", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+	or.mdi.On("GetGroups", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.ExportDefinitions(context.Background())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportDefinitions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bundle := testDefinitionBundle()
+	poolID := fftypes.NewUUID()
+	or.mdi.On("GetDatatypeByName", mock.Anything, "ns", "widget", "1.0").Return(nil, nil)
+	or.mds.On("DefineDatatype", mock.Anything, mock.MatchedBy(func(dt *core.Datatype) bool {
+		return dt.ID == nil && dt.Name == "widget"
+	}), true).Run(func(args mock.Arguments) {
+		args[1].(*core.Datatype).ID = fftypes.NewUUID()
+	}).Return(nil)
+	or.mdi.On("GetFFI", mock.Anything, "ns", "erc20", "1.0").Return(nil, nil)
+	or.mds.On("DefineFFI", mock.Anything, bundle.FFIs[0], true).Return(nil)
+	or.mdi.On("GetContractAPIByNameAndVersion", mock.Anything, "ns", "coin", "1.0").Return(nil, nil)
+	or.mds.On("DefineContractAPI", mock.Anything, "http://localhost", mock.MatchedBy(func(api *core.ContractAPI) bool {
+		return api.ID == nil && api.Interface.ID == nil && api.Interface.Name == "erc20"
+	}), true).Return(nil)
+	or.mdi.On("GetTokenPool", mock.Anything, "ns", "pool1").Return(nil, nil)
+	or.mam.On("CreateTokenPool", mock.Anything, mock.MatchedBy(func(pool *core.TokenPoolInput) bool {
+		return pool.ID == nil && pool.Key == "" && pool.Connector == "erc20_erc721"
+	}), true).Return(&core.TokenPool{ID: poolID}, nil)
+	or.mdi.On("GetGroupByHash", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	or.mdi.On("UpsertGroup", mock.Anything, mock.MatchedBy(func(group *core.Group) bool {
+		return group.Namespace == "ns" && group.LocalNamespace == "ns" && group.Hash.Equals(group.GroupIdentity.Hash())
+	}), database.UpsertOptimizationNew).Return(nil)
+
+	result, err := or.ImportDefinitions(context.Background(), "http://localhost", bundle, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", result.Namespace)
+	assert.Len(t, result.Definitions, 5)
+	for _, item := range result.Definitions {
+		assert.Equal(t, core.DefinitionImportStatusImported, item.Status)
+	}
+	assert.NotNil(t, result.Definitions[0].ID)
+	assert.Equal(t, poolID, result.Definitions[3].ID)
+	assert.NotNil(t, result.Definitions[4].Hash)
+}
+
+func TestImportDefinitionsSkipExisting(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bundle := testDefinitionBundle()
+	or.mdi.On("GetDatatypeByName", mock.Anything, "ns", "widget", "1.0").Return(&core.Datatype{ID: fftypes.NewUUID()}, nil)
+	or.mdi.On("GetFFI", mock.Anything, "ns", "erc20", "1.0").Return(&fftypes.FFI{ID: fftypes.NewUUID()}, nil)
+	or.mdi.On("GetContractAPIByNameAndVersion", mock.Anything, "ns", "coin", "1.0").Return(&core.ContractAPI{ID: fftypes.NewUUID()}, nil)
+	or.mdi.On("GetTokenPool", mock.Anything, "ns", "pool1").Return(&core.TokenPool{ID: fftypes.NewUUID()}, nil)
+	or.mdi.On("GetGroupByHash", mock.Anything, "ns", mock.Anything).Return(&core.Group{}, nil)
+
+	result, err := or.ImportDefinitions(context.Background(), "http://localhost", bundle, false)
+	assert.NoError(t, err)
+	assert.Len(t, result.Definitions, 5)
+	for _, item := range result.Definitions {
+		assert.Equal(t, core.DefinitionImportStatusSkipped, item.Status)
+	}
+}
+
+func TestImportDefinitionsFailures(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bundle := testDefinitionBundle()
+	bundle.Groups[0].Members[0].Node = nil
+	or.mdi.On("GetDatatypeByName", mock.Anything, "ns", "widget", "1.0").Return(nil, nil)
+	or.mds.On("DefineDatatype", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop1"))
+	or.mdi.On("GetFFI", mock.Anything, "ns", "erc20", "1.0").Return(nil, nil)
+	or.mds.On("DefineFFI", mock.Anything, mock.Anything, false).Return(fmt.Errorf("pop2"))
+	or.mdi.On("GetContractAPIByNameAndVersion", mock.Anything, "ns", "coin", "1.0").Return(nil, nil)
+	or.mds.On("DefineContractAPI", mock.Anything, "", mock.Anything, false).Return(fmt.Errorf("pop3"))
+	or.mdi.On("GetTokenPool", mock.Anything, "ns", "pool1").Return(nil, nil)
+	or.mam.On("CreateTokenPool", mock.Anything, mock.Anything, false).Return(nil, fmt.Errorf("pop4"))
+	or.mdi.On("GetGroupByHash", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+
+	result, err := or.ImportDefinitions(context.Background(), "", bundle, false)
+	assert.NoError(t, err)
+	assert.Len(t, result.Definitions, 5)
+	for i, item := range result.Definitions {
+		assert.Equal(t, core.DefinitionImportStatusFailed, item.Status)
+		assert.Nil(t, item.ID)
+		if i < 4 {
+			assert.Equal(t, fmt.Sprintf("pop%d", i+1), item.Error)
+		}
+	}
+	assert.Regexp(t, "FF00117", result.Definitions[4].Error)
+}
+
+func TestImportDefinitionsGroupsNotSupported(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.messaging = nil
+
+	result, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		Groups: testDefinitionBundle().Groups,
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, core.DefinitionImportStatusFailed, result.Definitions[0].Status)
+	assert.Regexp(t, "FF10414", result.Definitions[0].Error)
+}
+
+func TestImportDefinitionsDatatypeLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetDatatypeByName", mock.Anything, "ns", "widget", "1.0").Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		Datatypes: testDefinitionBundle().Datatypes,
+	}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportDefinitionsFFILookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetFFI", mock.Anything, "ns", "erc20", "1.0").Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		FFIs: testDefinitionBundle().FFIs,
+	}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportDefinitionsContractAPILookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetContractAPIByNameAndVersion", mock.Anything, "ns", "coin", "1.0").Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		ContractAPIs: testDefinitionBundle().ContractAPIs,
+	}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportDefinitionsTokenPoolLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetTokenPool", mock.Anything, "ns", "pool1").Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		TokenPools: testDefinitionBundle().TokenPools,
+	}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportDefinitionsGroupLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetGroupByHash", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		Groups: testDefinitionBundle().Groups,
+	}, false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestImportDefinitionsGroupUpsertFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetGroupByHash", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	or.mdi.On("UpsertGroup", mock.Anything, mock.Anything, database.UpsertOptimizationNew).Return(fmt.Errorf("pop"))
+
+	_, err := or.ImportDefinitions(context.Background(), "", &core.DefinitionBundle{
+		Groups: testDefinitionBundle().Groups,
+	}, false)
+	assert.EqualError(t, err, "pop")
+}
@@ -0,0 +1,250 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// embedder batch-fetches the records referenced by a page of items, with one query per type of record,
+// so they can be embedded in the items without a further call for each one
+type embedder struct {
+	or          *orchestrator
+	data        bool
+	transaction bool
+	events      bool
+}
+
+func (or *orchestrator) newEmbedder(embed []core.EmbedType) *embedder {
+	e := &embedder{or: or}
+	for _, t := range embed {
+		switch t {
+		case core.EmbedTypeData:
+			e.data = true
+		case core.EmbedTypeTransaction:
+			e.transaction = true
+		case core.EmbedTypeEvents:
+			e.events = true
+		}
+	}
+	return e
+}
+
+// idValues returns the distinct non-nil IDs in a list
+func idValues(ids []*fftypes.UUID) []driver.Value {
+	seen := make(map[fftypes.UUID]bool, len(ids))
+	values := make([]driver.Value, 0, len(ids))
+	for _, id := range ids {
+		if id != nil && !seen[*id] {
+			seen[*id] = true
+			values = append(values, id)
+		}
+	}
+	return values
+}
+
+// messageData returns the data of each message, in the order it is referenced by the message
+func (e *embedder) messageData(ctx context.Context, msgs []*core.Message) (map[fftypes.UUID]core.DataArray, error) {
+	var dataIDs []*fftypes.UUID
+	for _, msg := range msgs {
+		for _, ref := range msg.Data {
+			dataIDs = append(dataIDs, ref.ID)
+		}
+	}
+	byMessage := make(map[fftypes.UUID]core.DataArray, len(msgs))
+	ids := idValues(dataIDs)
+	if len(ids) == 0 {
+		return byMessage, nil
+	}
+	fb := database.DataQueryFactory.NewFilter(ctx)
+	data, _, err := e.or.database().GetData(ctx, e.or.namespace.Name, fb.In("id", ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[fftypes.UUID]*core.Data, len(data))
+	for _, d := range data {
+		byID[*d.ID] = d
+	}
+	for _, msg := range msgs {
+		msgData := make(core.DataArray, 0, len(msg.Data))
+		for _, ref := range msg.Data {
+			if ref.ID != nil && byID[*ref.ID] != nil {
+				msgData = append(msgData, byID[*ref.ID])
+			}
+		}
+		byMessage[*msg.Header.ID] = msgData
+	}
+	return byMessage, nil
+}
+
+func (e *embedder) transactions(ctx context.Context, txIDs []*fftypes.UUID) (map[fftypes.UUID]*core.Transaction, error) {
+	byID := make(map[fftypes.UUID]*core.Transaction)
+	ids := idValues(txIDs)
+	if len(ids) == 0 {
+		return byID, nil
+	}
+	fb := database.TransactionQueryFactory.NewFilter(ctx)
+	txs, _, err := e.or.database().GetTransactions(ctx, e.or.namespace.Name, fb.In("id", ids))
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		byID[*tx.ID] = tx
+	}
+	return byID, nil
+}
+
+// eventsFor returns the events that reference each item, or are correlated to it, in the order they were emitted
+func (e *embedder) eventsFor(ctx context.Context, itemIDs []*fftypes.UUID) (map[fftypes.UUID][]*core.Event, error) {
+	byItem := make(map[fftypes.UUID][]*core.Event)
+	ids := idValues(itemIDs)
+	if len(ids) == 0 {
+		return byItem, nil
+	}
+	fb := database.EventQueryFactory.NewFilter(ctx)
+	filter := fb.Sort("sequence").And(fb.Or(fb.In("reference", ids), fb.In("correlator", ids)))
+	events, _, err := e.or.database().GetEvents(ctx, e.or.namespace.Name, filter)
+	if err != nil {
+		return nil, err
+	}
+	items := make(map[fftypes.UUID]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		if id != nil {
+			items[*id] = true
+		}
+	}
+	for _, event := range events {
+		switch {
+		case event.Reference != nil && items[*event.Reference]:
+			byItem[*event.Reference] = append(byItem[*event.Reference], event)
+		case event.Correlator != nil && items[*event.Correlator]:
+			byItem[*event.Correlator] = append(byItem[*event.Correlator], event)
+		}
+	}
+	return byItem, nil
+}
+
+func (or *orchestrator) GetMessagesWithEmbedded(ctx context.Context, filter ffapi.AndFilter, embed []core.EmbedType) ([]*core.MessageWithEmbedded, *ffapi.FilterResult, error) {
+	msgs, fr, err := or.database().GetMessages(ctx, or.namespace.Name, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	e := or.newEmbedder(embed)
+	msgIDs := make([]*fftypes.UUID, len(msgs))
+	txIDs := make([]*fftypes.UUID, len(msgs))
+	for i, msg := range msgs {
+		msgIDs[i] = msg.Header.ID
+		txIDs[i] = msg.TransactionID
+	}
+
+	var data map[fftypes.UUID]core.DataArray
+	var txs map[fftypes.UUID]*core.Transaction
+	var events map[fftypes.UUID][]*core.Event
+	if e.data {
+		if data, err = e.messageData(ctx, msgs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if e.transaction {
+		if txs, err = e.transactions(ctx, txIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if e.events {
+		if events, err = e.eventsFor(ctx, msgIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	results := make([]*core.MessageWithEmbedded, len(msgs))
+	for i, msg := range msgs {
+		embedded := &core.Embedded{
+			Data:   data[*msg.Header.ID],
+			Events: events[*msg.Header.ID],
+		}
+		if msg.TransactionID != nil {
+			embedded.Transaction = txs[*msg.TransactionID]
+		}
+		results[i] = &core.MessageWithEmbedded{Message: *msg, Embedded: embedded}
+	}
+	return results, fr, nil
+}
+
+func (or *orchestrator) GetTokenTransfersWithEmbedded(ctx context.Context, filter ffapi.AndFilter, embed []core.EmbedType) ([]*core.TokenTransferWithEmbedded, *ffapi.FilterResult, error) {
+	transfers, fr, err := or.assets.GetTokenTransfers(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	e := or.newEmbedder(embed)
+	localIDs := make([]*fftypes.UUID, len(transfers))
+	txIDs := make([]*fftypes.UUID, len(transfers))
+	msgIDs := make([]*fftypes.UUID, len(transfers))
+	for i, transfer := range transfers {
+		localIDs[i] = transfer.LocalID
+		txIDs[i] = transfer.TX.ID
+		msgIDs[i] = transfer.Message
+	}
+
+	var data map[fftypes.UUID]core.DataArray
+	var txs map[fftypes.UUID]*core.Transaction
+	var events map[fftypes.UUID][]*core.Event
+	if e.data {
+		// The data of a transfer is the data of the message sent with it
+		var msgs []*core.Message
+		if ids := idValues(msgIDs); len(ids) > 0 {
+			fb := database.MessageQueryFactory.NewFilter(ctx)
+			if msgs, _, err = or.database().GetMessages(ctx, or.namespace.Name, fb.In("id", ids)); err != nil {
+				return nil, nil, err
+			}
+		}
+		if data, err = e.messageData(ctx, msgs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if e.transaction {
+		if txs, err = e.transactions(ctx, txIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+	if e.events {
+		if events, err = e.eventsFor(ctx, localIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	results := make([]*core.TokenTransferWithEmbedded, len(transfers))
+	for i, transfer := range transfers {
+		embedded := &core.Embedded{}
+		if transfer.Message != nil {
+			embedded.Data = data[*transfer.Message]
+		}
+		if transfer.TX.ID != nil {
+			embedded.Transaction = txs[*transfer.TX.ID]
+		}
+		if transfer.LocalID != nil {
+			embedded.Events = events[*transfer.LocalID]
+		}
+		results[i] = &core.TokenTransferWithEmbedded{TokenTransfer: *transfer, Embedded: embedded}
+	}
+	return results, fr, nil
+}
@@ -0,0 +1,261 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var allEmbedTypes = []core.EmbedType{core.EmbedTypeData, core.EmbedTypeTransaction, core.EmbedTypeEvents}
+
+func TestGetMessagesWithEmbeddedOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	d1 := &core.Data{ID: fftypes.NewUUID()}
+	d2 := &core.Data{ID: fftypes.NewUUID()}
+	tx := &core.Transaction{ID: fftypes.NewUUID()}
+	msg1 := &core.Message{
+		Header:        core.MessageHeader{ID: fftypes.NewUUID()},
+		Data:          core.DataRefs{{ID: d2.ID}, {ID: d1.ID}, {ID: fftypes.NewUUID()}},
+		TransactionID: tx.ID,
+	}
+	msg2 := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID()},
+		Data:   core.DataRefs{{ID: d1.ID}},
+	}
+	ev1 := &core.Event{ID: fftypes.NewUUID(), Reference: msg1.Header.ID}
+	ev2 := &core.Event{ID: fftypes.NewUUID(), Reference: fftypes.NewUUID(), Correlator: msg1.Header.ID}
+	ev3 := &core.Event{ID: fftypes.NewUUID(), Reference: msg2.Header.ID}
+	ev4 := &core.Event{ID: fftypes.NewUUID(), Reference: fftypes.NewUUID()}
+
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg1, msg2}, nil, nil)
+	or.mdi.On("GetData", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		return f.String() == fmt.Sprintf("id IN ['%s','%s','%s']", d2.ID, d1.ID, msg1.Data[2].ID)
+	})).Return(core.DataArray{d1, d2}, nil, nil)
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		return f.String() == fmt.Sprintf("id IN ['%s']", tx.ID)
+	})).Return([]*core.Transaction{tx}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		ids := fmt.Sprintf("['%s','%s']", msg1.Header.ID, msg2.Header.ID)
+		return f.String() == fmt.Sprintf("( ( reference IN %s ) || ( correlator IN %s ) ) sort=sequence", ids, ids)
+	})).Return([]*core.Event{ev1, ev2, ev3, ev4}, nil, nil)
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	msgs, _, err := or.GetMessagesWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 2)
+	assert.Equal(t, msg1.Header.ID, msgs[0].Header.ID)
+	assert.Equal(t, core.DataArray{d2, d1}, msgs[0].Embedded.Data)
+	assert.Equal(t, tx, msgs[0].Embedded.Transaction)
+	assert.Equal(t, []*core.Event{ev1, ev2}, msgs[0].Embedded.Events)
+	assert.Equal(t, core.DataArray{d1}, msgs[1].Embedded.Data)
+	assert.Nil(t, msgs[1].Embedded.Transaction)
+	assert.Equal(t, []*core.Event{ev3}, msgs[1].Embedded.Events)
+}
+
+func TestGetMessagesWithEmbeddedEmpty(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{}, nil, nil)
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	msgs, _, err := or.GetMessagesWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+}
+
+func TestGetMessagesWithEmbeddedFailMessages(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetMessagesWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessagesWithEmbeddedFailData(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID()},
+		Data:   core.DataRefs{{ID: fftypes.NewUUID()}},
+	}
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	or.mdi.On("GetData", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetMessagesWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessagesWithEmbeddedFailTransactions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := &core.Message{
+		Header:        core.MessageHeader{ID: fftypes.NewUUID()},
+		TransactionID: fftypes.NewUUID(),
+	}
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetMessagesWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessagesWithEmbeddedFailEvents(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID()},
+	}
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetMessagesWithEmbedded(context.Background(), fb.And(), []core.EmbedType{core.EmbedTypeEvents})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTokenTransfersWithEmbeddedOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	d1 := &core.Data{ID: fftypes.NewUUID()}
+	tx := &core.Transaction{ID: fftypes.NewUUID()}
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID()},
+		Data:   core.DataRefs{{ID: d1.ID}},
+	}
+	transfer1 := &core.TokenTransfer{
+		LocalID: fftypes.NewUUID(),
+		Message: msg.Header.ID,
+		TX:      core.TransactionRef{ID: tx.ID},
+	}
+	transfer2 := &core.TokenTransfer{
+		LocalID: fftypes.NewUUID(),
+	}
+	ev1 := &core.Event{ID: fftypes.NewUUID(), Reference: transfer1.LocalID}
+	ev2 := &core.Event{ID: fftypes.NewUUID(), Reference: fftypes.NewUUID(), Correlator: transfer2.LocalID}
+
+	or.mam.On("GetTokenTransfers", mock.Anything, mock.Anything).Return([]*core.TokenTransfer{transfer1, transfer2}, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		return f.String() == fmt.Sprintf("id IN ['%s']", msg.Header.ID)
+	})).Return([]*core.Message{msg}, nil, nil)
+	or.mdi.On("GetData", mock.Anything, "ns", mock.Anything).Return(core.DataArray{d1}, nil, nil)
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return([]*core.Transaction{tx}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{ev1, ev2}, nil, nil)
+
+	fb := database.TokenTransferQueryFactory.NewFilter(context.Background())
+	transfers, _, err := or.GetTokenTransfersWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.NoError(t, err)
+	assert.Len(t, transfers, 2)
+	assert.Equal(t, core.DataArray{d1}, transfers[0].Embedded.Data)
+	assert.Equal(t, tx, transfers[0].Embedded.Transaction)
+	assert.Equal(t, []*core.Event{ev1}, transfers[0].Embedded.Events)
+	assert.Nil(t, transfers[1].Embedded.Data)
+	assert.Nil(t, transfers[1].Embedded.Transaction)
+	assert.Equal(t, []*core.Event{ev2}, transfers[1].Embedded.Events)
+}
+
+func TestGetTokenTransfersWithEmbeddedFailTransfers(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mam.On("GetTokenTransfers", mock.Anything, mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenTransferQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetTokenTransfersWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTokenTransfersWithEmbeddedFailMessages(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	transfer := &core.TokenTransfer{LocalID: fftypes.NewUUID(), Message: fftypes.NewUUID()}
+	or.mam.On("GetTokenTransfers", mock.Anything, mock.Anything).Return([]*core.TokenTransfer{transfer}, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenTransferQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetTokenTransfersWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTokenTransfersWithEmbeddedFailData(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msg := &core.Message{
+		Header: core.MessageHeader{ID: fftypes.NewUUID()},
+		Data:   core.DataRefs{{ID: fftypes.NewUUID()}},
+	}
+	transfer := &core.TokenTransfer{LocalID: fftypes.NewUUID(), Message: msg.Header.ID}
+	or.mam.On("GetTokenTransfers", mock.Anything, mock.Anything).Return([]*core.TokenTransfer{transfer}, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	or.mdi.On("GetData", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenTransferQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetTokenTransfersWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTokenTransfersWithEmbeddedFailTransactions(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	transfer := &core.TokenTransfer{LocalID: fftypes.NewUUID(), TX: core.TransactionRef{ID: fftypes.NewUUID()}}
+	or.mam.On("GetTokenTransfers", mock.Anything, mock.Anything).Return([]*core.TokenTransfer{transfer}, nil, nil)
+	or.mdi.On("GetTransactions", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenTransferQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetTokenTransfersWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTokenTransfersWithEmbeddedFailEvents(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	transfer := &core.TokenTransfer{LocalID: fftypes.NewUUID()}
+	or.mam.On("GetTokenTransfers", mock.Anything, mock.Anything).Return([]*core.TokenTransfer{transfer}, nil, nil)
+	or.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	fb := database.TokenTransferQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetTokenTransfersWithEmbedded(context.Background(), fb.And(), allEmbedTypes)
+	assert.EqualError(t, err, "pop")
+}
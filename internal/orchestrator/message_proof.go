@@ -0,0 +1,102 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+// GetMessageProof exports a self-contained proof that a message was pinned to the blockchain, which a third party
+// can verify offline. It bundles the message, the manifest of its batch that was hashed to the pinned batch hash,
+// the pins of the message, the transaction that recorded them, and the Merkle proof of the batch in an anchor
+// digest when digest mode is enabled.
+func (or *orchestrator) GetMessageProof(ctx context.Context, id string) (*core.MessageProof, error) {
+	msg, err := or.getMessageByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if msg.BatchID == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMessageNotPinned, msg.Header.ID)
+	}
+	batch, err := or.database().GetBatchByID(ctx, or.namespace.Name, msg.BatchID)
+	if err != nil {
+		return nil, err
+	} else if batch == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	// The pins of the message follow on from the pins of the messages before it in the batch, one per topic
+	var manifest core.BatchManifest
+	if err := batch.Manifest.Unmarshal(ctx, &manifest); err != nil {
+		return nil, err
+	}
+	var baseIndex, topics int64
+	for _, entry := range manifest.Messages {
+		if entry.ID.Equals(msg.Header.ID) {
+			topics = int64(entry.Topics)
+			break
+		}
+		baseIndex += int64(entry.Topics)
+	}
+	fb := database.PinQueryFactory.NewFilter(ctx)
+	pins, _, err := or.database().GetPins(ctx, or.namespace.Name, fb.And(
+		fb.Eq("batch", batch.ID),
+		fb.Gte("index", baseIndex),
+		fb.Lt("index", baseIndex+topics),
+	).Sort("index"))
+	if err != nil {
+		return nil, err
+	}
+	if len(pins) == 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgMessageNotPinned, msg.Header.ID)
+	}
+
+	proof := &core.MessageProof{
+		Format:    core.MessageProofFormatV1,
+		Namespace: or.namespace.Name,
+		Message:   msg,
+		Batch: &core.MessageProofBatch{
+			ID:       batch.ID,
+			Hash:     batch.Hash,
+			Manifest: batch.Manifest.String(),
+		},
+		Pins: pins,
+		Transaction: &core.PinTransaction{
+			ID:     batch.TX.ID,
+			Type:   batch.TX.Type,
+			Signer: pins[0].Signer,
+		},
+	}
+	tx, err := or.database().GetTransactionByID(ctx, or.namespace.Name, batch.TX.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		proof.Transaction.BlockchainIDs = tx.BlockchainIDs
+	}
+	if or.anchoring != nil {
+		if proof.Anchor, err = or.anchoring.LookupBatchAnchorProof(ctx, batch.ID); err != nil {
+			return nil, err
+		}
+	}
+	return proof, nil
+}
@@ -0,0 +1,222 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/anchoringmocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockMessageProofLookups(or *testOrchestrator, bp *core.BatchPersisted, msg *core.Message) {
+	msg.BatchID = bp.ID
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+}
+
+func TestGetMessageProofOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: bp.ID, BatchHash: bp.Hash, Signer: "0x12345"},
+	}, nil, nil)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", bp.TX.ID).Return(&core.Transaction{
+		ID:            bp.TX.ID,
+		BlockchainIDs: fftypes.FFStringArray{"0xabcd"},
+	}, nil)
+
+	proof, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, core.MessageProofFormatV1, proof.Format)
+	assert.Equal(t, bp.Hash, proof.Batch.Hash)
+	assert.Equal(t, fftypes.FFStringArray{"0xabcd"}, proof.Transaction.BlockchainIDs)
+	assert.Nil(t, proof.Anchor)
+
+	// The exported proof must verify offline
+	err = proof.Verify(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestGetMessageProofAnchored(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mam := &anchoringmocks.Manager{}
+	or.anchoring = mam
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: bp.ID, BatchHash: bp.Hash, Signer: "0x12345"},
+	}, nil, nil)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", bp.TX.ID).Return(nil, nil)
+	anchor := &core.BatchAnchorProof{Batch: bp.ID, BatchHash: bp.Hash, Root: bp.Hash}
+	mam.On("LookupBatchAnchorProof", mock.Anything, bp.ID).Return(anchor, nil)
+
+	proof, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, anchor, proof.Anchor)
+
+	mam.AssertExpectations(t)
+}
+
+func TestGetMessageProofAnchorFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mam := &anchoringmocks.Manager{}
+	or.anchoring = mam
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: bp.ID, BatchHash: bp.Hash, Signer: "0x12345"},
+	}, nil, nil)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", bp.TX.ID).Return(nil, nil)
+	mam.On("LookupBatchAnchorProof", mock.Anything, bp.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.EqualError(t, err, "pop")
+
+	mam.AssertExpectations(t)
+}
+
+func TestGetMessageProofTransactionFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: bp.ID, BatchHash: bp.Hash, Signer: "0x12345"},
+	}, nil, nil)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", bp.TX.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessageProofNoPins(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.Regexp(t, "FF10578", err)
+}
+
+func TestGetMessageProofPinsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessageProofPinIndexes(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	// The message is the second in the batch, after a message with two topics
+	bp, msg, _ := newTestVerifyBatch(t)
+	bp.Manifest = fftypes.JSONAnyPtr(fmt.Sprintf(`{"messages":[{"id":"%s","topics":2},{"id":"%s","topics":1}]}`, fftypes.NewUUID(), msg.Header.ID))
+	mockMessageProofLookups(or, bp, msg)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.Regexp(t, "FF10578", err)
+	calculatedFilter, err := or.mdi.Calls[2].Arguments[2].(ffapi.Filter).Finalize()
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(
+		`( batch == '%s' ) && ( index >= 2 ) && ( index << 3 ) sort=index`, bp.ID,
+	), calculatedFilter.String())
+}
+
+func TestGetMessageProofBadManifest(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	bp.Manifest = fftypes.JSONAnyPtr("!json")
+	mockMessageProofLookups(or, bp, msg)
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.Regexp(t, "invalid character", err)
+}
+
+func TestGetMessageProofBatchNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	msg.BatchID = bp.ID
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(nil, nil)
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestGetMessageProofBatchLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	msg.BatchID = bp.ID
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetMessageProofNotBatched(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+
+	_, err := or.GetMessageProof(context.Background(), msg.Header.ID.String())
+	assert.Regexp(t, "FF10578", err)
+}
+
+func TestGetMessageProofMessageNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	id := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", id).Return(nil, nil)
+
+	_, err := or.GetMessageProof(context.Background(), id.String())
+	assert.Regexp(t, "FF10109", err)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// connectorReconciler asks the blockchain connector for the latest status of an operation during
+// startup recovery. Token connectors submit their transactions through the same blockchain connector,
+// so their operations are reconciled the same way.
+type connectorReconciler struct {
+	or *orchestrator
+}
+
+func (cr *connectorReconciler) Name() string {
+	return cr.or.blockchain().Name()
+}
+
+func (cr *connectorReconciler) ReconcileOperation(ctx context.Context, op *core.Operation) (bool, error) {
+	// Where the connector knows the operation, the lookup also delivers any receipt that was missed
+	status, err := cr.or.blockchain().GetTransactionStatus(ctx, op)
+	if err != nil {
+		return false, err
+	}
+	return status != nil, nil
+}
+
+// connectorReceiptFetcher looks up the receipts of transactions that were submitted outside of FireFly,
+// and attached to blockchain operations
+type connectorReceiptFetcher struct {
+	or *orchestrator
+}
+
+func (rf *connectorReceiptFetcher) Name() string {
+	return rf.or.blockchain().Name()
+}
+
+func (rf *connectorReceiptFetcher) GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error) {
+	return rf.or.blockchain().GetTransactionReceipt(ctx, txHash)
+}
+
+func (or *orchestrator) registerReconcilers(ctx context.Context) {
+	if or.blockchain() == nil {
+		return
+	}
+	or.operations.RegisterReconciler(ctx, &connectorReconciler{or: or}, []core.OpType{
+		core.OpTypeBlockchainPinBatch,
+		core.OpTypeBlockchainNetworkAction,
+		core.OpTypeBlockchainContractDeploy,
+		core.OpTypeBlockchainContractDeployABI,
+		core.OpTypeBlockchainInvoke,
+		core.OpTypeBlockchainInvokeBatch,
+		core.OpTypeTokenCreatePool,
+		core.OpTypeTokenActivatePool,
+		core.OpTypeTokenTransfer,
+		core.OpTypeTokenApproval,
+	})
+	or.operations.RegisterReceiptFetcher(ctx, &connectorReceiptFetcher{or: or}, []core.OpType{
+		core.OpTypeBlockchainPinBatch,
+		core.OpTypeBlockchainNetworkAction,
+		core.OpTypeBlockchainContractDeploy,
+		core.OpTypeBlockchainContractDeployABI,
+		core.OpTypeBlockchainInvoke,
+		core.OpTypeBlockchainInvokeBatch,
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly/internal/operations"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterReconcilers(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	var reconciler *connectorReconciler
+	or.mom.On("RegisterReconciler", mock.Anything, mock.Anything, mock.MatchedBy(func(ops []core.OpType) bool {
+		return len(ops) == 10
+	})).Run(func(args mock.Arguments) {
+		reconciler = args[1].(*connectorReconciler)
+	})
+	var fetcher *connectorReceiptFetcher
+	or.mom.On("RegisterReceiptFetcher", mock.Anything, mock.Anything, mock.MatchedBy(func(ops []core.OpType) bool {
+		return len(ops) == 6
+	})).Run(func(args mock.Arguments) {
+		fetcher = args[1].(*connectorReceiptFetcher)
+	})
+
+	or.registerReconcilers(context.Background())
+	assert.Equal(t, "mock-bi", reconciler.Name())
+	assert.Equal(t, "mock-bi", fetcher.Name())
+}
+
+func TestRegisterReconcilersNoBlockchain(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.plugins.Blockchain.Plugin = nil
+
+	or.registerReconcilers(context.Background())
+}
+
+func TestReconcileOperation(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeBlockchainInvoke}
+	or.mbi.On("GetTransactionStatus", mock.Anything, op).Return(&txnStatus{TxnId: "abc123"}, nil)
+
+	cr := &connectorReconciler{or: &or.orchestrator}
+	known, err := cr.ReconcileOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.True(t, known)
+}
+
+func TestReconcileOperationUnknown(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeTokenTransfer}
+	or.mbi.On("GetTransactionStatus", mock.Anything, op).Return(nil, nil)
+
+	cr := &connectorReconciler{or: &or.orchestrator}
+	known, err := cr.ReconcileOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.False(t, known)
+}
+
+func TestReconcileOperationFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	op := &core.Operation{Type: core.OpTypeBlockchainInvoke}
+	or.mbi.On("GetTransactionStatus", mock.Anything, op).Return(nil, fmt.Errorf("pop"))
+
+	cr := &connectorReconciler{or: &or.orchestrator}
+	_, err := cr.ReconcileOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestInitOperationsRegistersReconcilers(t *testing.T) {
+	operations.InitConfig()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.operations = nil
+	or.config.Multiparty.Enabled = true
+	or.mdi.On("Capabilities").Return(&database.Capabilities{Concurrency: false})
+	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := or.initManagers(context.Background())
+	assert.EqualError(t, err, "pop")
+	assert.NotNil(t, or.operations)
+}
+
+func TestGetTransactionReceipt(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	receipt := &blockchain.TransactionReceipt{TransactionHash: "0x12345", Success: true}
+	or.mbi.On("GetTransactionReceipt", mock.Anything, "0x12345").Return(receipt, nil)
+
+	rf := &connectorReceiptFetcher{or: &or.orchestrator}
+	result, err := rf.GetTransactionReceipt(context.Background(), "0x12345")
+	assert.NoError(t, err)
+	assert.Equal(t, receipt, result)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/anchoring"
 	"github.com/hyperledger/firefly/internal/assets"
 	"github.com/hyperledger/firefly/internal/batch"
 	"github.com/hyperledger/firefly/internal/broadcast"
@@ -40,7 +41,9 @@ import (
 	"github.com/hyperledger/firefly/internal/networkmap"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/privatemessaging"
+	"github.com/hyperledger/firefly/internal/sequencer/sqfactory"
 	"github.com/hyperledger/firefly/internal/shareddownload"
+	"github.com/hyperledger/firefly/internal/stats"
 	"github.com/hyperledger/firefly/internal/syncasync"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/internal/txwriter"
@@ -50,6 +53,8 @@ import (
 	"github.com/hyperledger/firefly/pkg/dataexchange"
 	eventsplugin "github.com/hyperledger/firefly/pkg/events"
 	idplugin "github.com/hyperledger/firefly/pkg/identity"
+	"github.com/hyperledger/firefly/pkg/keymanager"
+	"github.com/hyperledger/firefly/pkg/sequencer"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 	"github.com/hyperledger/firefly/pkg/tokens"
 )
@@ -77,33 +82,56 @@ type Orchestrator interface {
 	// Status
 	GetStatus(ctx context.Context) (*core.NamespaceStatus, error)
 	GetMultipartyStatus(ctx context.Context) (*core.NamespaceMultipartyStatus, error)
+	GetNamespaceStats(ctx context.Context) (*core.NamespaceStats, error)
 
 	// Subscription management
 	GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error)
 	GetSubscriptionByID(ctx context.Context, id string) (*core.Subscription, error)
 	GetSubscriptionByIDWithStatus(ctx context.Context, id string) (*core.SubscriptionWithStatus, error)
 	GetSubscriptionEventsHistorical(ctx context.Context, subscription *core.Subscription, filter ffapi.AndFilter, startSequence int, endSequence int) ([]*core.EnrichedEvent, *ffapi.FilterResult, error)
+	GetSubscriptionSLAs(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error)
+	TestSubscription(ctx context.Context, id string, input *core.SubscriptionTestInput) (*core.SubscriptionTestResult, error)
 	CreateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error)
 	CreateUpdateSubscription(ctx context.Context, subDef *core.Subscription) (*core.Subscription, error)
-	DeleteSubscription(ctx context.Context, id string) error
+	DeleteSubscription(ctx context.Context, id, owner string) error
 
 	// Data Query
 	GetNamespace(ctx context.Context) *core.Namespace
 	GetTransactionByID(ctx context.Context, id string) (*core.Transaction, error)
 	GetTransactionOperations(ctx context.Context, id string) ([]*core.Operation, *ffapi.FilterResult, error)
 	GetTransactionBlockchainEvents(ctx context.Context, id string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error)
+	GetTransactionEvents(ctx context.Context, id string) ([]*core.Event, *ffapi.FilterResult, error)
 	GetTransactionStatus(ctx context.Context, id string) (*core.TransactionStatus, error)
+	GetTransactionCompensations(ctx context.Context, id string) ([]*core.Compensation, *ffapi.FilterResult, error)
+	CompensateTransaction(ctx context.Context, id string) ([]*core.Compensation, error)
 	GetTransactions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Transaction, *ffapi.FilterResult, error)
 	GetMessageByID(ctx context.Context, id string) (*core.Message, error)
+	GetMessageByIDAsOf(ctx context.Context, id, asOf string) (*core.Message, error)
+	GetMessageProof(ctx context.Context, id string) (*core.MessageProof, error)
 	GetMessageByIDWithData(ctx context.Context, id string) (*core.MessageInOut, error)
 	GetMessages(ctx context.Context, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)
+	GetMessagesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf string) ([]*core.Message, *ffapi.FilterResult, error)
 	GetMessagesWithData(ctx context.Context, filter ffapi.AndFilter) ([]*core.MessageInOut, *ffapi.FilterResult, error)
+	GetMessagesWithEmbedded(ctx context.Context, filter ffapi.AndFilter, embed []core.EmbedType) ([]*core.MessageWithEmbedded, *ffapi.FilterResult, error)
+	GetTokenTransfersWithEmbedded(ctx context.Context, filter ffapi.AndFilter, embed []core.EmbedType) ([]*core.TokenTransferWithEmbedded, *ffapi.FilterResult, error)
+	GetTokenBalancesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf string) ([]*core.TokenBalance, *ffapi.FilterResult, error)
 	GetMessageTransaction(ctx context.Context, id string) (*core.Transaction, error)
 	GetMessageEvents(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)
 	GetMessageData(ctx context.Context, id string) (core.DataArray, error)
 	GetMessagesForData(ctx context.Context, dataID string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)
+	GetQuarantinedMessages(ctx context.Context, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)
+	ReviewQuarantinedMessage(ctx context.Context, id string, review *core.QuarantineReview) (*core.Message, error)
+	GetQuarantineEntries(ctx context.Context, filter ffapi.AndFilter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error)
+	GetQuarantineEntryByID(ctx context.Context, id string) (*core.QuarantineEntry, error)
+	RevalidateQuarantineEntry(ctx context.Context, id string) (*core.QuarantineRevalidation, error)
+	PurgeQuarantineEntry(ctx context.Context, id string) error
 	GetBatchByID(ctx context.Context, id string) (*core.BatchPersisted, error)
 	GetBatches(ctx context.Context, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error)
+	VerifyBatch(ctx context.Context, id string) (*core.BatchVerification, error)
+	GetBatchAnchorProof(ctx context.Context, id string) (*core.BatchAnchorProof, error)
+	Verify(ctx context.Context, input *core.VerifyInput) (*core.VerificationReport, error)
+	GetBatchPayload(ctx context.Context, id string) (*core.Batch, error)
+	AttachBatchPayload(ctx context.Context, id string, batch *core.Batch) (*core.BatchPersisted, error)
 	GetDataByID(ctx context.Context, id string) (*core.Data, error)
 	GetData(ctx context.Context, filter ffapi.AndFilter) (core.DataArray, *ffapi.FilterResult, error)
 	GetDataSubPaths(ctx context.Context, path string) ([]string, error)
@@ -113,6 +141,8 @@ type Orchestrator interface {
 	GetOperationByID(ctx context.Context, id string) (*core.Operation, error)
 	GetOperationByIDWithStatus(ctx context.Context, id string) (*core.OperationWithDetail, error)
 	GetOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)
+	GetStalledOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)
+	GetOperationsByTags(ctx context.Context, tags []string, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)
 	GetEventByID(ctx context.Context, id string) (*core.Event, error)
 	GetEventByIDWithReference(ctx context.Context, id string) (*core.EnrichedEvent, error)
 	GetEvents(ctx context.Context, filter ffapi.AndFilter) ([]*core.Event, *ffapi.FilterResult, error)
@@ -123,14 +153,21 @@ type Orchestrator interface {
 	GetNextPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error)
 	RewindPins(ctx context.Context, rewind *core.PinRewind) (*core.PinRewind, error)
 
+	// Definition export/import
+	ExportDefinitions(ctx context.Context) (*core.DefinitionBundle, error)
+	ImportDefinitions(ctx context.Context, httpServerURL string, bundle *core.DefinitionBundle, waitConfirm bool) (*core.DefinitionImportResult, error)
+	ReplayToSandbox(ctx context.Context, httpServerURL string, source Orchestrator, input *core.SandboxReplayInput) (*core.SandboxReplayResult, error)
+
 	// Charts
 	GetChartHistogram(ctx context.Context, startTime int64, endTime int64, buckets int64, tableName database.CollectionName) ([]*core.ChartHistogram, error)
+	GetChartAggregate(ctx context.Context, collection database.CollectionName, interval core.ChartInterval, startTime, endTime *fftypes.FFTime) ([]*core.ChartHistogram, error)
 
 	// Message Routing
 	RequestReply(ctx context.Context, msg *core.MessageInOut) (reply *core.MessageInOut, err error)
 
 	// Network Operations
 	SubmitNetworkAction(ctx context.Context, action *core.NetworkAction) error
+	ScheduleContractMigration(ctx context.Context, migration *core.ContractMigration, waitConfirm bool) error
 
 	// Authorizer
 	Authorize(ctx context.Context, authReq *fftypes.AuthReq) error
@@ -166,6 +203,11 @@ type IdentityPlugin struct {
 	Plugin idplugin.Plugin
 }
 
+type KeyManagerPlugin struct {
+	Name   string
+	Plugin keymanager.Plugin
+}
+
 type AuthPlugin struct {
 	Name   string
 	Plugin auth.Plugin
@@ -173,7 +215,9 @@ type AuthPlugin struct {
 
 type Plugins struct {
 	Blockchain    BlockchainPlugin
+	Anchor        BlockchainPlugin // optional second chain, that batches are also pinned to
 	Identity      IdentityPlugin
+	KeyManager    KeyManagerPlugin
 	SharedStorage SharedStoragePlugin
 	DataExchange  DataExchangePlugin
 	Database      DatabasePlugin
@@ -185,9 +229,16 @@ type Plugins struct {
 type Config struct {
 	DefaultKey                  string
 	KeyNormalization            string
+	PoolTemplates               map[string]*core.TokenPoolTemplate
+	Sandbox                     bool
 	Multiparty                  multiparty.Config
 	TokenBroadcastNames         map[string]string
 	MaxHistoricalEventScanLimit int
+	DownloadPriorities          shareddownload.Priorities
+	BatchPipeline               batch.PipelineOptions
+	Sequencer                   sqfactory.Config
+	Validation                  data.ValidationPolicies
+	DataCanonicalization        core.DataCanonicalization
 }
 
 type orchestrator struct {
@@ -195,15 +246,18 @@ type orchestrator struct {
 	cancelCtx               context.CancelFunc
 	started                 bool
 	startedBlockchainPlugin bool
+	startedAnchorPlugin     bool
 	startedLock             sync.Mutex
 	namespace               *core.Namespace
 	config                  Config
 	plugins                 *Plugins
 	multiparty              multiparty.Manager       // only for multiparty
+	sequencer               sequencer.Plugin         // only for multiparty
 	batch                   batch.Manager            // only for multiparty
 	broadcast               broadcast.Manager        // only for multiparty
 	messaging               privatemessaging.Manager // only for multiparty
 	sharedDownload          shareddownload.Manager   // only for multiparty
+	anchoring               anchoring.Manager        // only for multiparty, with a digest anchor chain
 	identity                identity.Manager
 	events                  events.EventManager
 	networkmap              networkmap.Manager
@@ -217,6 +271,7 @@ type orchestrator struct {
 	metrics                 metrics.Manager
 	cacheManager            cache.Manager
 	operations              operations.Manager
+	stats                   stats.Manager
 	txHelper                txcommon.Helper
 	txWriter                txwriter.Writer
 }
@@ -263,6 +318,10 @@ func (or *orchestrator) blockchain() blockchain.Plugin {
 	return or.plugins.Blockchain.Plugin
 }
 
+func (or *orchestrator) keymanager() keymanager.Plugin {
+	return or.plugins.KeyManager.Plugin
+}
+
 func (or *orchestrator) dataexchange() dataexchange.Plugin {
 	return or.plugins.DataExchange.Plugin
 }
@@ -293,6 +352,12 @@ func (or *orchestrator) Start() (err error) {
 	if err == nil {
 		err = or.events.Start()
 	}
+	if err == nil && or.config.Multiparty.Enabled {
+		err = or.sequencer.Start()
+	}
+	if err == nil && or.config.Multiparty.Enabled {
+		or.networkmap.Start()
+	}
 	if err == nil {
 		err = or.operations.Start()
 	}
@@ -302,6 +367,12 @@ func (or *orchestrator) Start() (err error) {
 	if err == nil {
 		err = or.assets.Start()
 	}
+	if err == nil && or.anchoring != nil {
+		err = or.anchoring.Start()
+	}
+	if err == nil {
+		or.stats.Start()
+	}
 
 	or.started = true
 	return err
@@ -315,12 +386,22 @@ func (or *orchestrator) WaitStop() {
 	if err != nil {
 		log.L(or.ctx).Errorf("Error purging namespace '%s' from blockchain plugin '%s': %s", or.namespace.Name, or.plugins.Blockchain.Name, err.Error())
 	}
+	if or.plugins.Anchor.Plugin != nil {
+		err := or.plugins.Anchor.Plugin.StopNamespace(or.ctx, or.namespace.Name)
+		if err != nil {
+			log.L(or.ctx).Errorf("Error purging namespace '%s' from anchor blockchain plugin '%s': %s", or.namespace.Name, or.plugins.Anchor.Name, err.Error())
+		}
+	}
 	for _, t := range or.plugins.Tokens {
 		err := t.Plugin.StopNamespace(or.ctx, or.namespace.Name)
 		if err != nil {
 			log.L(or.ctx).Errorf("Error purging namespace '%s' from tokens plugin '%s': %s", or.namespace.Name, t.Name, err.Error())
 		}
 	}
+	if or.sequencer != nil {
+		or.sequencer.WaitStop()
+		or.sequencer = nil
+	}
 	if or.batch != nil {
 		or.batch.WaitStop()
 		or.batch = nil
@@ -345,6 +426,18 @@ func (or *orchestrator) WaitStop() {
 		or.operations.WaitStop()
 		or.operations = nil
 	}
+	if or.networkmap != nil {
+		or.networkmap.WaitStop()
+		or.networkmap = nil
+	}
+	if or.anchoring != nil {
+		or.anchoring.WaitStop()
+		or.anchoring = nil
+	}
+	if or.stats != nil {
+		or.stats.WaitStop()
+		or.stats = nil
+	}
 	if or.txWriter != nil {
 		or.txWriter.Close()
 	}
@@ -426,6 +519,11 @@ func setHandlers(ctx context.Context,
 		plugins.Blockchain.Plugin.SetOperationHandler(namespace.Name, bc)
 	}
 
+	if plugins.Anchor.Plugin != nil {
+		// Only operation updates are required from the anchor chain - its events are not processed
+		plugins.Anchor.Plugin.SetOperationHandler(namespace.Name, bc)
+	}
+
 	if plugins.SharedStorage.Plugin != nil {
 		plugins.SharedStorage.Plugin.SetHandler(namespace.Name, bc)
 	}
@@ -461,7 +559,7 @@ func (or *orchestrator) initMultiParty(ctx context.Context) error {
 
 func (or *orchestrator) initMultiPartyComponents(ctx context.Context) (err error) {
 	if or.batch == nil {
-		or.batch, err = batch.NewBatchManager(ctx, or.namespace.Name, or.database(), or.data, or.identity, or.txHelper)
+		or.batch, err = batch.NewBatchManager(ctx, or.namespace.Name, or.database(), or.data, or.identity, or.txHelper, or.metrics, or.config.BatchPipeline)
 		if err != nil {
 			return err
 		}
@@ -484,7 +582,14 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 	}
 
 	if or.operations == nil {
-		if or.operations, err = operations.NewOperationsManager(ctx, or.namespace.Name, or.database(), or.txHelper, or.cacheManager); err != nil {
+		if or.operations, err = operations.NewOperationsManager(ctx, or.namespace.Name, or.database(), or.txHelper, or.metrics, or.cacheManager); err != nil {
+			return err
+		}
+		or.registerReconcilers(ctx)
+	}
+
+	if or.stats == nil {
+		if or.stats, err = stats.NewStatsManager(ctx, or.namespace.Name, or.database()); err != nil {
 			return err
 		}
 	}
@@ -494,8 +599,14 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 	}
 
 	if or.config.Multiparty.Enabled {
+		if or.sequencer == nil {
+			if or.sequencer, err = sqfactory.NewSequencer(or.ctx, or.namespace, or.config.Sequencer, or.database(), or.blockchain()); err != nil {
+				return err
+			}
+			or.sequencer.SetHandler(&or.bc)
+		}
 		if or.multiparty == nil {
-			or.multiparty, err = multiparty.NewMultipartyManager(or.ctx, or.namespace, or.config.Multiparty, or.database(), or.blockchain(), or.operations, or.metrics, or.txHelper)
+			or.multiparty, err = multiparty.NewMultipartyManager(or.ctx, or.namespace, or.config.Multiparty, or.database(), or.blockchain(), or.plugins.Anchor.Plugin, or.sequencer, or.operations, or.metrics, or.txHelper)
 			if err != nil {
 				return err
 			}
@@ -503,10 +614,16 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 		if err = or.multiparty.ConfigureContract(ctx); err != nil {
 			return err
 		}
+		if or.anchoring == nil && or.plugins.Anchor.Plugin != nil && or.config.Multiparty.Anchor.Mode == multiparty.AnchorModeDigest {
+			or.anchoring, err = anchoring.NewAnchorManager(or.ctx, or.namespace, or.config.Multiparty.Anchor, or.database(), or.plugins.Anchor.Plugin, or.operations, or.txHelper)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if or.identity == nil {
-		or.identity, err = identity.NewIdentityManager(ctx, or.namespace.Name, or.config.DefaultKey, or.database(), or.blockchain(), or.multiparty, or.cacheManager)
+		or.identity, err = identity.NewIdentityManager(ctx, or.namespace.Name, or.config.DefaultKey, or.database(), or.blockchain(), or.keymanager(), or.multiparty, or.cacheManager)
 		if err != nil {
 			return err
 		}
@@ -528,7 +645,7 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 		}
 
 		if or.sharedDownload == nil {
-			or.sharedDownload, err = shareddownload.NewDownloadManager(ctx, or.namespace, or.database(), or.sharedstorage(), or.dataexchange(), or.operations, &or.bc)
+			or.sharedDownload, err = shareddownload.NewDownloadManager(ctx, or.namespace, or.database(), or.sharedstorage(), or.dataexchange(), or.operations, &or.bc, or.config.DownloadPriorities)
 			if err != nil {
 				return err
 			}
@@ -537,7 +654,7 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 
 	if or.blockchain() != nil {
 		if or.contracts == nil {
-			or.contracts, err = contracts.NewContractManager(ctx, or.namespace.Name, or.database(), or.blockchain(), or.data, or.broadcast, or.messaging, or.batch, or.identity, or.operations, or.txHelper, or.txWriter, or.syncasync, or.cacheManager)
+			or.contracts, err = contracts.NewContractManager(ctx, or.namespace.Name, or.database(), or.blockchain(), or.data, or.broadcast, or.messaging, or.batch, or.identity, or.metrics, or.operations, or.txHelper, or.txWriter, or.syncasync, or.cacheManager)
 			if err != nil {
 				return err
 			}
@@ -545,17 +662,20 @@ func (or *orchestrator) initManagers(ctx context.Context) (err error) {
 	}
 
 	if or.assets == nil {
-		or.assets, err = assets.NewAssetManager(ctx, or.namespace.Name, or.config.KeyNormalization, or.database(), or.tokens(), or.identity, or.syncasync, or.broadcast, or.messaging, or.metrics, or.operations, or.contracts, or.txHelper, or.cacheManager)
+		or.assets, err = assets.NewAssetManager(ctx, or.namespace.Name, or.config.KeyNormalization, or.config.PoolTemplates, or.database(), or.tokens(), or.identity, or.syncasync, or.broadcast, or.messaging, or.metrics, or.operations, or.contracts, or.txHelper, or.cacheManager)
 		if err != nil {
 			return err
 		}
 	}
 
 	if or.defsender == nil {
-		or.defsender, or.defhandler, err = definitions.NewDefinitionSender(ctx, or.namespace, or.config.Multiparty.Enabled, or.database(), or.blockchain(), or.dataexchange(), or.broadcast, or.identity, or.data, or.assets, or.contracts, or.config.TokenBroadcastNames)
+		or.defsender, or.defhandler, err = definitions.NewDefinitionSender(ctx, or.namespace, or.config.Multiparty.Enabled, or.database(), or.blockchain(), or.dataexchange(), or.broadcast, or.identity, or.data, or.assets, or.contracts, or.multiparty, or.config.TokenBroadcastNames)
 		if err != nil {
 			return err
 		}
+		if or.contracts != nil {
+			or.contracts.SetAPIDefiner(or.defsender)
+		}
 	}
 
 	if or.networkmap == nil {
@@ -580,9 +700,16 @@ func (or *orchestrator) initComponents(ctx context.Context) (err error) {
 		}
 		or.startedBlockchainPlugin = true
 	}
+	if or.plugins.Anchor.Plugin != nil && !or.startedAnchorPlugin {
+		err = or.plugins.Anchor.Plugin.StartNamespace(ctx, or.namespace.Name)
+		if err != nil {
+			return err
+		}
+		or.startedAnchorPlugin = true
+	}
 
 	if or.data == nil {
-		or.data, err = data.NewDataManager(ctx, or.namespace, or.database(), or.dataexchange(), or.cacheManager)
+		or.data, err = data.NewDataManager(ctx, or.namespace, or.database(), or.dataexchange(), or.sharedstorage(), or.cacheManager, or.config.Validation, or.config.DataCanonicalization)
 		if err != nil {
 			return err
 		}
@@ -615,6 +742,25 @@ func (or *orchestrator) SubmitNetworkAction(ctx context.Context, action *core.Ne
 	return or.multiparty.SubmitNetworkAction(ctx, key, action, false /* network actions do not support idempotency keys currently */)
 }
 
+func (or *orchestrator) ScheduleContractMigration(ctx context.Context, migration *core.ContractMigration, waitConfirm bool) error {
+	if or.multiparty == nil || or.namespace.Contracts == nil || or.namespace.Contracts.Active == nil {
+		return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+	}
+	if migration.BlockNumber <= 0 {
+		return i18n.NewError(ctx, coremsgs.MsgContractMigrationInvalidBlock)
+	}
+	// Migrations always move the network on to the next configured contract
+	migration.Index = or.namespace.Contracts.Active.Index + 1
+	return or.defsender.DefineContractMigration(ctx, migration, waitConfirm)
+}
+
+func (or *orchestrator) GetBatchAnchorProof(ctx context.Context, id string) (*core.BatchAnchorProof, error) {
+	if or.anchoring == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgAnchorDigestNotEnabled, or.namespace.Name)
+	}
+	return or.anchoring.GetBatchAnchorProof(ctx, id)
+}
+
 func (or *orchestrator) Authorize(ctx context.Context, authReq *fftypes.AuthReq) error {
 	authReq.Namespace = or.namespace.Name
 	if or.plugins.Auth.Plugin != nil {
@@ -28,6 +28,8 @@ import (
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/identity"
+	"github.com/hyperledger/firefly/internal/multiparty"
+	"github.com/hyperledger/firefly/mocks/anchoringmocks"
 	"github.com/hyperledger/firefly/mocks/assetmocks"
 	"github.com/hyperledger/firefly/mocks/batchmocks"
 	"github.com/hyperledger/firefly/mocks/blockchainmocks"
@@ -46,9 +48,11 @@ import (
 	"github.com/hyperledger/firefly/mocks/networkmapmocks"
 	"github.com/hyperledger/firefly/mocks/operationmocks"
 	"github.com/hyperledger/firefly/mocks/privatemessagingmocks"
+	"github.com/hyperledger/firefly/mocks/sequencermocks"
 	"github.com/hyperledger/firefly/mocks/shareddownloadmocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
 	"github.com/hyperledger/firefly/mocks/spieventsmocks"
+	"github.com/hyperledger/firefly/mocks/statsmocks"
 	"github.com/hyperledger/firefly/mocks/tokenmocks"
 	"github.com/hyperledger/firefly/mocks/txcommonmocks"
 	"github.com/hyperledger/firefly/mocks/txwritermocks"
@@ -89,6 +93,8 @@ type testOrchestrator struct {
 	mmp *multipartymocks.Manager
 	mds *definitionsmocks.Sender
 	mtw *txwritermocks.Writer
+	msq *sequencermocks.Plugin
+	mst *statsmocks.Manager
 }
 
 func (tor *testOrchestrator) cleanup(t *testing.T) {
@@ -115,6 +121,8 @@ func (tor *testOrchestrator) cleanup(t *testing.T) {
 	tor.mae.AssertExpectations(t)
 	tor.mdh.AssertExpectations(t)
 	tor.mmp.AssertExpectations(t)
+	tor.msq.AssertExpectations(t)
+	tor.mst.AssertExpectations(t)
 }
 
 func newTestOrchestrator() *testOrchestrator {
@@ -151,8 +159,11 @@ func newTestOrchestrator() *testOrchestrator {
 		mmp: &multipartymocks.Manager{},
 		mds: &definitionsmocks.Sender{},
 		mtw: &txwritermocks.Writer{},
+		msq: &sequencermocks.Plugin{},
+		mst: &statsmocks.Manager{},
 	}
 	tor.orchestrator.multiparty = tor.mmp
+	tor.orchestrator.sequencer = tor.msq
 	tor.orchestrator.data = tor.mdm
 	tor.orchestrator.batch = tor.mba
 	tor.orchestrator.broadcast = tor.mbm
@@ -168,6 +179,7 @@ func newTestOrchestrator() *testOrchestrator {
 	tor.orchestrator.sharedDownload = tor.msd
 	tor.orchestrator.txHelper = tor.mth
 	tor.orchestrator.txWriter = tor.mtw
+	tor.orchestrator.stats = tor.mst
 	tor.orchestrator.defhandler = tor.mdh
 	tor.orchestrator.defsender = tor.mds
 	tor.orchestrator.config.Multiparty.Enabled = true
@@ -326,6 +338,91 @@ func TestInitEventsComponentStartNamespaceFail(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 }
 
+func TestInitAnchorStartNamespaceFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	manchor := &blockchainmocks.Plugin{}
+	or.plugins.Anchor = BlockchainPlugin{Name: "anchor", Plugin: manchor}
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	manchor.On("StartNamespace", mock.Anything, "ns").Return(fmt.Errorf("pop"))
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "pop", err)
+	manchor.AssertExpectations(t)
+}
+
+func TestInitAnchorOK(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	manchor := &blockchainmocks.Plugin{}
+	or.plugins.Anchor = BlockchainPlugin{Name: "anchor", Plugin: manchor}
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	manchor.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(nil)
+	err := or.initComponents(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, or.startedAnchorPlugin)
+	manchor.AssertExpectations(t)
+
+	manchor.On("SetOperationHandler", "ns", mock.Anything).Return()
+	or.mdi.On("SetHandler", "ns", mock.Anything).Return()
+	or.mbi.On("SetHandler", "ns", mock.Anything).Return()
+	or.mbi.On("SetOperationHandler", "ns", mock.Anything).Return()
+	or.mps.On("SetHandler", "ns", mock.Anything).Return()
+	or.mdx.On("SetHandler", "ns", mock.Anything, mock.Anything).Return()
+	or.mdx.On("SetOperationHandler", "ns", mock.Anything).Return()
+	or.mti.On("SetHandler", "ns", mock.Anything).Return(nil)
+	or.mti.On("SetOperationHandler", "ns", mock.Anything).Return()
+	setHandlers(or.ctx, or.plugins, or.namespace, "node1", or, &or.bc)
+
+	or.started = true
+	or.mba.On("WaitStop").Return(nil)
+	or.mbm.On("WaitStop").Return(nil)
+	or.mdm.On("WaitStop").Return(nil)
+	or.msd.On("WaitStop").Return(nil)
+	or.mom.On("WaitStop").Return(nil)
+	or.mst.On("WaitStop").Return()
+	or.mem.On("WaitStop").Return(nil)
+	or.mtw.On("Close").Return(nil)
+	or.msq.On("WaitStop").Return()
+	or.mnm.On("WaitStop").Return()
+	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	manchor.On("StopNamespace", mock.Anything, "ns").Return(fmt.Errorf("pop"))
+	or.WaitStop()
+	manchor.AssertExpectations(t)
+}
+
+func TestInitAnchorDigestOK(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	manchor := &blockchainmocks.Plugin{}
+	or.plugins.Anchor = BlockchainPlugin{Name: "anchor", Plugin: manchor}
+	or.config.Multiparty.Anchor.Mode = multiparty.AnchorModeDigest
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	manchor.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(nil)
+	or.mom.On("RegisterHandler", mock.Anything, mock.Anything, []core.OpType{core.OpTypeBlockchainAnchorDigest}).Return()
+	err := or.initComponents(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, or.anchoring)
+	manchor.AssertExpectations(t)
+}
+
+func TestInitAnchorDigestFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	manchor := &blockchainmocks.Plugin{}
+	or.plugins.Anchor = BlockchainPlugin{Name: "anchor", Plugin: manchor}
+	or.plugins.Database.Plugin = nil
+	or.config.Multiparty.Anchor.Mode = multiparty.AnchorModeDigest
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	manchor.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(nil)
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "FF10128", err)
+	manchor.AssertExpectations(t)
+}
+
 func TestInitNetworkMapComponentFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -347,6 +444,29 @@ func TestInitMultipartyComponentFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitSequencerComponentFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	or.sequencer = nil
+	or.multiparty = nil
+	or.config.Sequencer.Type = "wrong"
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "FF10527", err)
+}
+
+func TestInitSequencerComponentOK(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	or.plugins.Database.Plugin = nil
+	or.sequencer = nil
+	or.multiparty = nil
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "FF10128", err)
+	assert.Equal(t, "mock-bi", or.sequencer.Name())
+}
+
 func TestInitMultipartyComponentConfigureFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -399,6 +519,17 @@ func TestInitDefSenderComponentFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitDefSenderSetsAPIDefiner(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.defsender = nil
+	or.mmp.On("ConfigureContract", mock.Anything, mock.Anything).Return(nil)
+	or.mcm.On("SetAPIDefiner", mock.Anything).Return()
+	err := or.initManagers(context.Background())
+	assert.NoError(t, err)
+	assert.NotNil(t, or.defsender)
+}
+
 func TestInitDataComponentFail(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
@@ -453,6 +584,16 @@ func TestInitOperationsComponentFail(t *testing.T) {
 	assert.Regexp(t, "FF10128", err)
 }
 
+func TestInitStatsComponentFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.plugins.Database.Plugin = nil
+	or.stats = nil
+	or.mbi.On("StartNamespace", mock.Anything, "ns").Return(nil)
+	err := or.initComponents(context.Background())
+	assert.Regexp(t, "FF10128.*StatsManager", err)
+}
+
 func TestStartBatchFail(t *testing.T) {
 	coreconfig.Reset()
 	or := newTestOrchestrator()
@@ -483,15 +624,21 @@ func TestStartStopOk(t *testing.T) {
 	or.mbm.On("Start").Return(nil)
 	or.msd.On("Start").Return(nil)
 	or.mom.On("Start").Return(nil)
+	or.mst.On("Start").Return()
 	or.mtw.On("Start").Return()
 	or.mam.On("Start").Return(nil)
+	or.msq.On("Start").Return(nil)
+	or.mnm.On("Start").Return()
 	or.mba.On("WaitStop").Return(nil)
 	or.mbm.On("WaitStop").Return(nil)
 	or.mdm.On("WaitStop").Return(nil)
 	or.msd.On("WaitStop").Return(nil)
 	or.mom.On("WaitStop").Return(nil)
+	or.mst.On("WaitStop").Return()
 	or.mem.On("WaitStop").Return(nil)
 	or.mtw.On("Close").Return(nil)
+	or.msq.On("WaitStop").Return()
+	or.mnm.On("WaitStop").Return()
 	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(nil)
 	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
 	err := or.Start()
@@ -506,15 +653,21 @@ func TestStartStopOk(t *testing.T) {
 	or.mbm.On("Start").Return(nil)
 	or.msd.On("Start").Return(nil)
 	or.mom.On("Start").Return(nil)
+	or.mst.On("Start").Return()
 	or.mtw.On("Start").Return()
 	or.mam.On("Start").Return(nil)
+	or.msq.On("Start").Return(nil)
+	or.mnm.On("Start").Return()
 	or.mba.On("WaitStop").Return(nil)
 	or.mbm.On("WaitStop").Return(nil)
 	or.mdm.On("WaitStop").Return(nil)
 	or.msd.On("WaitStop").Return(nil)
 	or.mom.On("WaitStop").Return(nil)
+	or.mst.On("WaitStop").Return()
 	or.mem.On("WaitStop").Return(nil)
 	or.mtw.On("Close").Return(nil)
+	or.msq.On("WaitStop").Return()
+	or.mnm.On("WaitStop").Return()
 	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(fmt.Errorf("pop"))
 	or.mti.On("StopNamespace", mock.Anything, "ns").Return(fmt.Errorf("pop"))
 	err = or.Start()
@@ -523,6 +676,63 @@ func TestStartStopOk(t *testing.T) {
 	or.WaitStop() // swallows dups
 }
 
+func TestStartStopAnchoring(t *testing.T) {
+	coreconfig.Reset()
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mam := &anchoringmocks.Manager{}
+	or.anchoring = mam
+	or.mdm.On("Start").Return(nil)
+	or.mba.On("Start").Return(nil)
+	or.mem.On("Start").Return(nil)
+	or.mbm.On("Start").Return(nil)
+	or.msd.On("Start").Return(nil)
+	or.mom.On("Start").Return(nil)
+	or.mtw.On("Start").Return()
+	or.mam.On("Start").Return(nil)
+	or.msq.On("Start").Return(nil)
+	or.mnm.On("Start").Return()
+	mam.On("Start").Return(fmt.Errorf("pop"))
+	or.mba.On("WaitStop").Return(nil)
+	or.mbm.On("WaitStop").Return(nil)
+	or.mdm.On("WaitStop").Return(nil)
+	or.msd.On("WaitStop").Return(nil)
+	or.mom.On("WaitStop").Return(nil)
+	or.mst.On("WaitStop").Return()
+	or.mem.On("WaitStop").Return(nil)
+	or.mtw.On("Close").Return(nil)
+	or.msq.On("WaitStop").Return()
+	or.mnm.On("WaitStop").Return()
+	mam.On("WaitStop").Return()
+	or.mbi.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	or.mti.On("StopNamespace", mock.Anything, "ns").Return(nil)
+	err := or.Start()
+	assert.EqualError(t, err, "pop")
+	or.WaitStop()
+	assert.Nil(t, or.anchoring)
+	mam.AssertExpectations(t)
+}
+
+func TestGetBatchAnchorProof(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	mam := &anchoringmocks.Manager{}
+	or.anchoring = mam
+	proof := &core.BatchAnchorProof{}
+	mam.On("GetBatchAnchorProof", context.Background(), "batch1").Return(proof, nil)
+	result, err := or.GetBatchAnchorProof(context.Background(), "batch1")
+	assert.NoError(t, err)
+	assert.Equal(t, proof, result)
+	mam.AssertExpectations(t)
+}
+
+func TestGetBatchAnchorProofNotEnabled(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	_, err := or.GetBatchAnchorProof(context.Background(), "batch1")
+	assert.Regexp(t, "FF10565", err)
+}
+
 func TestPurge(t *testing.T) {
 	coreconfig.Reset()
 	or := newTestOrchestrator()
@@ -599,6 +809,34 @@ func TestNetworkActionNonMultiparty(t *testing.T) {
 	assert.Regexp(t, "FF10414", err)
 }
 
+func TestScheduleContractMigration(t *testing.T) {
+	or := newTestOrchestrator()
+	or.namespace.Contracts = &core.MultipartyContracts{
+		Active: &core.MultipartyContract{Index: 1},
+	}
+	migration := &core.ContractMigration{BlockNumber: 100}
+	or.mds.On("DefineContractMigration", context.Background(), migration, true).Return(nil)
+	err := or.ScheduleContractMigration(context.Background(), migration, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, migration.Index)
+}
+
+func TestScheduleContractMigrationBadBlock(t *testing.T) {
+	or := newTestOrchestrator()
+	or.namespace.Contracts = &core.MultipartyContracts{
+		Active: &core.MultipartyContract{},
+	}
+	err := or.ScheduleContractMigration(context.Background(), &core.ContractMigration{}, false)
+	assert.Regexp(t, "FF10561", err)
+}
+
+func TestScheduleContractMigrationNonMultiparty(t *testing.T) {
+	or := newTestOrchestrator()
+	or.multiparty = nil
+	err := or.ScheduleContractMigration(context.Background(), &core.ContractMigration{BlockNumber: 100}, false)
+	assert.Regexp(t, "FF10414", err)
+}
+
 func TestAuthorize(t *testing.T) {
 	or := newTestOrchestrator()
 	auth := &authmocks.Plugin{}
@@ -30,8 +30,14 @@ func (or *orchestrator) OrderedUUIDCollectionNSEvent(resType database.OrderedUUI
 	}
 	switch {
 	case eventType == core.ChangeEventTypeCreated && resType == database.CollectionMessages:
+		if or.stats != nil {
+			or.stats.MessageCreated()
+		}
 		or.batch.NewMessages() <- sequence
 	case eventType == core.ChangeEventTypeCreated && resType == database.CollectionEvents:
+		if or.stats != nil {
+			or.stats.EventCreated()
+		}
 		or.events.NewEvents() <- sequence
 	}
 }
@@ -53,11 +59,21 @@ func (or *orchestrator) UUIDCollectionNSEvent(resType database.UUIDCollectionNS,
 	}
 	switch {
 	case eventType == core.ChangeEventTypeCreated && resType == database.CollectionSubscriptions:
+		if or.stats != nil {
+			or.stats.SubscriptionCreated()
+		}
 		or.events.NewSubscriptions() <- id
 	case eventType == core.ChangeEventTypeDeleted && resType == database.CollectionSubscriptions:
+		if or.stats != nil {
+			or.stats.SubscriptionDeleted()
+		}
 		or.events.DeletedSubscriptions() <- id
 	case eventType == core.ChangeEventTypeUpdated && resType == database.CollectionSubscriptions:
 		or.events.SubscriptionUpdates() <- id
+	case eventType == core.ChangeEventTypeCreated && resType == database.CollectionOperations:
+		if or.stats != nil {
+			or.stats.OperationCreated()
+		}
 	}
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/mocks/batchmocks"
 	"github.com/hyperledger/firefly/mocks/eventmocks"
+	"github.com/hyperledger/firefly/mocks/statsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
 )
@@ -126,3 +127,32 @@ func TestUUIDCollectionWrongNS(t *testing.T) {
 	}
 	o.UUIDCollectionNSEvent(database.CollectionSubscriptions, core.ChangeEventTypeCreated, "ns2", fftypes.NewUUID())
 }
+
+func TestStatsCollectionEvents(t *testing.T) {
+	mb := &batchmocks.Manager{}
+	mem := &eventmocks.EventManager{}
+	mst := &statsmocks.Manager{}
+	o := &orchestrator{
+		namespace: &core.Namespace{Name: "ns1", NetworkName: "ns1"},
+		batch:     mb,
+		events:    mem,
+		stats:     mst,
+	}
+	mb.On("NewMessages").Return((chan<- int64)(make(chan int64, 1)))
+	mem.On("NewEvents").Return((chan<- int64)(make(chan int64, 1)))
+	mem.On("NewSubscriptions").Return((chan<- *fftypes.UUID)(make(chan *fftypes.UUID, 1)))
+	mem.On("DeletedSubscriptions").Return((chan<- *fftypes.UUID)(make(chan *fftypes.UUID, 1)))
+	mst.On("MessageCreated").Return()
+	mst.On("EventCreated").Return()
+	mst.On("SubscriptionCreated").Return()
+	mst.On("SubscriptionDeleted").Return()
+	mst.On("OperationCreated").Return()
+	o.OrderedUUIDCollectionNSEvent(database.CollectionMessages, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID(), 1)
+	o.OrderedUUIDCollectionNSEvent(database.CollectionEvents, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID(), 1)
+	o.UUIDCollectionNSEvent(database.CollectionSubscriptions, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID())
+	o.UUIDCollectionNSEvent(database.CollectionSubscriptions, core.ChangeEventTypeDeleted, "ns1", fftypes.NewUUID())
+	o.UUIDCollectionNSEvent(database.CollectionOperations, core.ChangeEventTypeCreated, "ns1", fftypes.NewUUID())
+	mb.AssertExpectations(t)
+	mem.AssertExpectations(t)
+	mst.AssertExpectations(t)
+}
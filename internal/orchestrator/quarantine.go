@@ -0,0 +1,72 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// GetQuarantinedMessages returns the messages held for review, because their data failed validation
+func (or *orchestrator) GetQuarantinedMessages(ctx context.Context, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	filter = filter.Condition(filter.Builder().Eq("state", core.MessageStateQuarantined))
+	return or.database().GetMessages(ctx, or.namespace.Name, filter)
+}
+
+// ReviewQuarantinedMessage accepts or rejects a message held for review
+func (or *orchestrator) ReviewQuarantinedMessage(ctx context.Context, id string, review *core.QuarantineReview) (*core.Message, error) {
+	return or.events.ReviewQuarantinedMessage(ctx, id, review)
+}
+
+// GetQuarantineEntries returns the inbound items that were quarantined because they failed validation
+func (or *orchestrator) GetQuarantineEntries(ctx context.Context, filter ffapi.AndFilter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error) {
+	return or.database().GetQuarantineEntries(ctx, or.namespace.Name, filter)
+}
+
+func (or *orchestrator) GetQuarantineEntryByID(ctx context.Context, id string) (*core.QuarantineEntry, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := or.database().GetQuarantineEntryByID(ctx, or.namespace.Name, u)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return entry, nil
+}
+
+// RevalidateQuarantineEntry re-runs the validation of a quarantined item, processing it if it is now valid
+func (or *orchestrator) RevalidateQuarantineEntry(ctx context.Context, id string) (*core.QuarantineRevalidation, error) {
+	return or.events.RevalidateQuarantineEntry(ctx, id)
+}
+
+// PurgeQuarantineEntry discards a quarantined item, without processing it
+func (or *orchestrator) PurgeQuarantineEntry(ctx context.Context, id string) error {
+	entry, err := or.GetQuarantineEntryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return or.database().DeleteQuarantineEntry(ctx, or.namespace.Name, entry.ID)
+}
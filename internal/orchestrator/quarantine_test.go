@@ -0,0 +1,129 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetQuarantinedMessages(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.AndFilter) bool {
+		f, _ := filter.Finalize()
+		return f.String() == "( topics == 'topic1' ) && ( state == 'quarantined' )"
+	})).Return([]*core.Message{}, nil, nil)
+	fb := database.MessageQueryFactory.NewFilter(context.Background())
+	f := fb.And(fb.Eq("topics", "topic1"))
+	_, _, err := or.GetQuarantinedMessages(context.Background(), f)
+	assert.NoError(t, err)
+}
+
+func TestReviewQuarantinedMessage(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	review := &core.QuarantineReview{Action: core.ReviewActionAccept}
+	msg := &core.Message{State: core.MessageStateConfirmed}
+	or.mem.On("ReviewQuarantinedMessage", or.ctx, "id1", review).Return(msg, nil)
+
+	res, err := or.ReviewQuarantinedMessage(or.ctx, "id1", review)
+	assert.NoError(t, err)
+	assert.Equal(t, msg, res)
+}
+
+func TestGetQuarantineEntries(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetQuarantineEntries", mock.Anything, "ns", mock.Anything).Return([]*core.QuarantineEntry{}, nil, nil)
+	fb := database.QuarantineQueryFactory.NewFilter(context.Background())
+	f := fb.And(fb.Eq("reason", core.QuarantineReasonDataHashMismatch))
+	_, _, err := or.GetQuarantineEntries(context.Background(), f)
+	assert.NoError(t, err)
+}
+
+func TestGetQuarantineEntryByID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	entry := &core.QuarantineEntry{ID: fftypes.NewUUID()}
+	or.mdi.On("GetQuarantineEntryByID", mock.Anything, "ns", entry.ID).Return(entry, nil)
+	res, err := or.GetQuarantineEntryByID(context.Background(), entry.ID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, entry, res)
+}
+
+func TestGetQuarantineEntryByIDBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	_, err := or.GetQuarantineEntryByID(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetQuarantineEntryByIDFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetQuarantineEntryByID", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
+	_, err := or.GetQuarantineEntryByID(context.Background(), fftypes.NewUUID().String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetQuarantineEntryByIDNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetQuarantineEntryByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	_, err := or.GetQuarantineEntryByID(context.Background(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestRevalidateQuarantineEntry(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	result := &core.QuarantineRevalidation{Valid: true}
+	or.mem.On("RevalidateQuarantineEntry", or.ctx, "id1").Return(result, nil)
+
+	res, err := or.RevalidateQuarantineEntry(or.ctx, "id1")
+	assert.NoError(t, err)
+	assert.Equal(t, result, res)
+}
+
+func TestPurgeQuarantineEntry(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	entry := &core.QuarantineEntry{ID: fftypes.NewUUID()}
+	or.mdi.On("GetQuarantineEntryByID", mock.Anything, "ns", entry.ID).Return(entry, nil)
+	or.mdi.On("DeleteQuarantineEntry", mock.Anything, "ns", entry.ID).Return(nil)
+	err := or.PurgeQuarantineEntry(context.Background(), entry.ID.String())
+	assert.NoError(t, err)
+}
+
+func TestPurgeQuarantineEntryNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+	or.mdi.On("GetQuarantineEntryByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	err := or.PurgeQuarantineEntry(context.Background(), fftypes.NewUUID().String())
+	assert.Regexp(t, "FF10109", err)
+}
@@ -0,0 +1,157 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const sandboxReplayPageSize = 100
+
+// ReplayToSandbox clones the definitions of a source namespace into this sandbox namespace, then replays a range of
+// the events of the source through it - so changes to subscriptions and the applications consuming them can be tested
+// against real history, without any effect on the source namespace or the network.
+func (or *orchestrator) ReplayToSandbox(ctx context.Context, httpServerURL string, source Orchestrator, input *core.SandboxReplayInput) (*core.SandboxReplayResult, error) {
+	if !or.config.Sandbox {
+		return nil, i18n.NewError(ctx, coremsgs.MsgNotSandboxNamespace, or.namespace.Name)
+	}
+	sourceNS := source.GetNamespace(ctx).Name
+	if sourceNS == or.namespace.Name {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSandboxReplaySameNamespace, sourceNS)
+	}
+	result := &core.SandboxReplayResult{
+		Source:  sourceNS,
+		Sandbox: or.namespace.Name,
+	}
+
+	if input.Definitions {
+		bundle, err := source.ExportDefinitions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if result.Definitions, err = or.ImportDefinitions(ctx, httpServerURL, bundle, true); err != nil {
+			return nil, err
+		}
+	}
+
+	from := input.FromSequence
+	for {
+		fb := database.EventQueryFactory.NewFilter(ctx)
+		conditions := []ffapi.Filter{fb.Gte("sequence", from)}
+		if input.ToSequence > 0 {
+			conditions = append(conditions, fb.Lte("sequence", input.ToSequence))
+		}
+		if len(input.Types) > 0 {
+			types := make([]driver.Value, len(input.Types))
+			for i, t := range input.Types {
+				types[i] = t
+			}
+			conditions = append(conditions, fb.In("type", types))
+		}
+		events, _, err := source.GetEvents(ctx, fb.Sort("sequence").Limit(sandboxReplayPageSize).And(conditions...))
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			if err := or.database().RunAsGroup(ctx, func(ctx context.Context) error {
+				return or.replaySandboxEvents(ctx, source, events, result)
+			}); err != nil {
+				return nil, err
+			}
+			from = events[len(events)-1].Sequence + 1
+		}
+		if len(events) < sandboxReplayPageSize {
+			break
+		}
+	}
+	log.L(ctx).Infof("Replayed %d events from namespace '%s' into sandbox '%s'", result.Events, sourceNS, or.namespace.Name)
+	return result, nil
+}
+
+func (or *orchestrator) replaySandboxEvents(ctx context.Context, source Orchestrator, events []*core.Event, result *core.SandboxReplayResult) error {
+	for _, event := range events {
+		if err := or.copySandboxEventReference(ctx, source, event, result); err != nil {
+			return err
+		}
+		// Events are unique across all namespaces, so each is replayed with a new ID
+		replayed := core.NewEvent(event.Type, or.namespace.Name, event.Reference, event.Transaction, event.Topic)
+		replayed.Correlator = event.Correlator
+		replayed.Created = event.Created
+		if err := or.database().InsertEvent(ctx, replayed); err != nil {
+			return err
+		}
+		result.Events++
+		result.LastSequence = event.Sequence
+	}
+	return nil
+}
+
+// copySandboxEventReference copies the message or transaction an event refers to into the sandbox, keeping the
+// same ID, so the replayed event can be enriched and delivered in the same way as the original
+func (or *orchestrator) copySandboxEventReference(ctx context.Context, source Orchestrator, event *core.Event, result *core.SandboxReplayResult) error {
+	switch event.Type {
+	case core.EventTypeMessageConfirmed, core.EventTypeMessageRejected, core.EventTypeMessageQuarantined, core.EventTypeMessageValidationWarning:
+		fb := database.MessageQueryFactory.NewFilter(ctx)
+		msgs, _, err := source.GetMessages(ctx, fb.And(fb.Eq("id", event.Reference)))
+		if err != nil || len(msgs) == 0 {
+			return err
+		}
+		msg := msgs[0]
+		data, err := source.GetMessageData(ctx, msg.Header.ID.String())
+		if err != nil {
+			return err
+		}
+		for _, d := range data {
+			copied := *d
+			copied.Namespace = or.namespace.Name
+			if err := or.database().UpsertData(ctx, &copied, database.UpsertOptimizationSkip); err != nil {
+				return err
+			}
+		}
+		copied := *msg
+		copied.LocalNamespace = or.namespace.Name
+		if err := or.database().UpsertMessage(ctx, &copied, database.UpsertOptimizationSkip); err != nil {
+			return err
+		}
+		result.Messages++
+
+	case core.EventTypeTransactionSubmitted:
+		tx, err := source.GetTransactionByID(ctx, event.Reference.String())
+		if err != nil || tx == nil {
+			return err
+		}
+		existing, err := or.database().GetTransactionByID(ctx, or.namespace.Name, tx.ID)
+		if err != nil || existing != nil {
+			return err
+		}
+		copied := *tx
+		copied.Namespace = or.namespace.Name
+		if err := or.database().InsertTransaction(ctx, &copied); err != nil {
+			return err
+		}
+		result.Transactions++
+	}
+	return nil
+}
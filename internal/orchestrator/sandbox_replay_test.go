@@ -0,0 +1,325 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSandboxReplay() (sandbox, source *testOrchestrator) {
+	source = newTestOrchestrator()
+	sandbox = newTestOrchestrator()
+	sandbox.namespace = &core.Namespace{Name: "sandbox", NetworkName: "sandbox"}
+	sandbox.config.Multiparty.Enabled = false
+	sandbox.config.Sandbox = true
+	rag := sandbox.mdi.On("RunAsGroup", mock.Anything, mock.Anything).Maybe()
+	rag.RunFn = func(a mock.Arguments) {
+		rag.ReturnArguments = mock.Arguments{a[1].(func(context.Context) error)(a[0].(context.Context))}
+	}
+	return sandbox, source
+}
+
+func eventFilterString(filter ffapi.Filter) string {
+	f, _ := filter.Finalize()
+	return f.String()
+}
+
+func TestReplayToSandbox(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}, LocalNamespace: "ns"}
+	data := &core.Data{ID: fftypes.NewUUID(), Namespace: "ns"}
+	tx := &core.Transaction{ID: fftypes.NewUUID(), Namespace: "ns"}
+	events := make([]*core.Event, sandboxReplayPageSize)
+	for i := range events {
+		events[i] = &core.Event{ID: fftypes.NewUUID(), Type: core.EventTypeIdentityConfirmed, Namespace: "ns", Reference: fftypes.NewUUID(), Sequence: int64(i + 5)}
+	}
+	events[0].Type = core.EventTypeMessageConfirmed
+	events[0].Reference = msg.Header.ID
+	events[0].Correlator = fftypes.NewUUID()
+	events[1].Type = core.EventTypeTransactionSubmitted
+	events[1].Reference = tx.ID
+	events[1].Transaction = tx.ID
+
+	source.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{}, nil, nil)
+	source.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	source.mdi.On("GetContractAPIs", mock.Anything, "ns", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	source.mdi.On("GetTokenPools", mock.Anything, "ns", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+	source.mdi.On("GetGroups", mock.Anything, "ns", mock.Anything).Return([]*core.Group{}, nil, nil)
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return eventFilterString(filter) == "( sequence >= 5 ) && ( sequence <= 500 ) && ( type IN ['message_confirmed','transaction_submitted','identity_confirmed'] ) sort=sequence limit=100"
+	})).Return(events, nil, nil)
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		return eventFilterString(filter) == "( sequence >= 105 ) && ( sequence <= 500 ) && ( type IN ['message_confirmed','transaction_submitted','identity_confirmed'] ) sort=sequence limit=100"
+	})).Return([]*core.Event{}, nil, nil)
+	source.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	source.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	source.mdm.On("GetMessageDataCached", mock.Anything, msg).Return(core.DataArray{data}, true, nil)
+	source.mth.On("GetTransactionByIDCached", mock.Anything, tx.ID).Return(tx, nil)
+
+	sandbox.mdi.On("UpsertData", mock.Anything, mock.MatchedBy(func(d *core.Data) bool {
+		return d.ID == data.ID && d.Namespace == "sandbox"
+	}), database.UpsertOptimizationSkip).Return(nil)
+	sandbox.mdi.On("UpsertMessage", mock.Anything, mock.MatchedBy(func(m *core.Message) bool {
+		return m.Header.ID == msg.Header.ID && m.LocalNamespace == "sandbox"
+	}), database.UpsertOptimizationSkip).Return(nil)
+	sandbox.mdi.On("GetTransactionByID", mock.Anything, "sandbox", tx.ID).Return(nil, nil)
+	sandbox.mdi.On("InsertTransaction", mock.Anything, mock.MatchedBy(func(t *core.Transaction) bool {
+		return t.ID == tx.ID && t.Namespace == "sandbox"
+	})).Return(nil)
+	sandbox.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Namespace == "sandbox" && e.ID != events[0].ID && e.Reference == events[0].Reference && e.Correlator == events[0].Correlator
+	})).Return(nil).Once()
+	sandbox.mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(e *core.Event) bool {
+		return e.Namespace == "sandbox" && e.Type != core.EventTypeMessageConfirmed
+	})).Return(nil).Times(sandboxReplayPageSize - 1)
+
+	result, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{
+		Source:       "ns",
+		Definitions:  true,
+		FromSequence: 5,
+		ToSequence:   500,
+		Types:        fftypes.FFStringArray{"message_confirmed", "transaction_submitted", "identity_confirmed"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", result.Source)
+	assert.Equal(t, "sandbox", result.Sandbox)
+	assert.Equal(t, "sandbox", result.Definitions.Namespace)
+	assert.Equal(t, sandboxReplayPageSize, result.Events)
+	assert.Equal(t, 1, result.Messages)
+	assert.Equal(t, 1, result.Transactions)
+	assert.Equal(t, int64(104), result.LastSequence)
+	assert.Equal(t, "ns", msg.LocalNamespace)
+	assert.Equal(t, "ns", data.Namespace)
+	assert.Equal(t, "ns", tx.Namespace)
+}
+
+func TestReplayToSandboxNotSandbox(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+	sandbox.config.Sandbox = false
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.Regexp(t, "FF10555", err)
+}
+
+func TestReplayToSandboxSameNamespace(t *testing.T) {
+	sandbox, _ := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", sandbox, &core.SandboxReplayInput{Source: "sandbox"})
+	assert.Regexp(t, "FF10556", err)
+}
+
+func TestReplayToSandboxExportFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	source.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns", Definitions: true})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxImportFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	source.mdi.On("GetDatatypes", mock.Anything, "ns", mock.Anything).Return([]*core.Datatype{{Name: "widget", Version: "1.0"}}, nil, nil)
+	source.mdi.On("GetFFIs", mock.Anything, "ns", mock.Anything).Return([]*fftypes.FFI{}, nil, nil)
+	source.mdi.On("GetContractAPIs", mock.Anything, "ns", mock.Anything).Return([]*core.ContractAPI{}, nil, nil)
+	source.mdi.On("GetTokenPools", mock.Anything, "ns", mock.Anything).Return([]*core.TokenPool{}, nil, nil)
+	source.mdi.On("GetGroups", mock.Anything, "ns", mock.Anything).Return([]*core.Group{}, nil, nil)
+	sandbox.mdi.On("GetDatatypeByName", mock.Anything, "sandbox", "widget", "1.0").Return(nil, fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns", Definitions: true})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxGetEventsFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxInsertEventFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeIdentityConfirmed},
+	}, nil, nil)
+	sandbox.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxMessageNotFound(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeMessageRejected, Reference: fftypes.NewUUID()},
+	}, nil, nil)
+	source.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{}, nil, nil)
+	sandbox.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Events)
+	assert.Equal(t, 0, result.Messages)
+}
+
+func TestReplayToSandboxGetMessagesFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: fftypes.NewUUID()},
+	}, nil, nil)
+	source.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxGetMessageDataFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: msg.Header.ID},
+	}, nil, nil)
+	source.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	source.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxUpsertDataFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: msg.Header.ID},
+	}, nil, nil)
+	source.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	source.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	source.mdm.On("GetMessageDataCached", mock.Anything, msg).Return(core.DataArray{{ID: fftypes.NewUUID()}}, true, nil)
+	sandbox.mdi.On("UpsertData", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxUpsertMessageFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	msg := &core.Message{Header: core.MessageHeader{ID: fftypes.NewUUID()}}
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed, Reference: msg.Header.ID},
+	}, nil, nil)
+	source.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{msg}, nil, nil)
+	source.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	source.mdm.On("GetMessageDataCached", mock.Anything, msg).Return(core.DataArray{}, true, nil)
+	sandbox.mdi.On("UpsertMessage", mock.Anything, mock.Anything, database.UpsertOptimizationSkip).Return(fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestReplayToSandboxTransactionNotFound(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeTransactionSubmitted, Reference: txID},
+	}, nil, nil)
+	source.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(nil, nil)
+	sandbox.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Transactions)
+}
+
+func TestReplayToSandboxTransactionExists(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	tx := &core.Transaction{ID: fftypes.NewUUID()}
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeTransactionSubmitted, Reference: tx.ID},
+	}, nil, nil)
+	source.mth.On("GetTransactionByIDCached", mock.Anything, tx.ID).Return(tx, nil)
+	sandbox.mdi.On("GetTransactionByID", mock.Anything, "sandbox", tx.ID).Return(tx, nil)
+	sandbox.mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Transactions)
+}
+
+func TestReplayToSandboxInsertTransactionFail(t *testing.T) {
+	sandbox, source := newTestSandboxReplay()
+	defer sandbox.cleanup(t)
+	defer source.cleanup(t)
+
+	tx := &core.Transaction{ID: fftypes.NewUUID()}
+	source.mdi.On("GetEvents", mock.Anything, "ns", mock.Anything).Return([]*core.Event{
+		{ID: fftypes.NewUUID(), Type: core.EventTypeTransactionSubmitted, Reference: tx.ID},
+	}, nil, nil)
+	source.mth.On("GetTransactionByIDCached", mock.Anything, tx.ID).Return(tx, nil)
+	sandbox.mdi.On("GetTransactionByID", mock.Anything, "sandbox", tx.ID).Return(nil, nil)
+	sandbox.mdi.On("InsertTransaction", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, err := sandbox.ReplayToSandbox(context.Background(), "http://localhost", source, &core.SandboxReplayInput{Source: "ns"})
+	assert.EqualError(t, err, "pop")
+}
@@ -134,6 +134,10 @@ func (or *orchestrator) GetStatus(ctx context.Context) (status *core.NamespaceSt
 	return status, nil
 }
 
+func (or *orchestrator) GetNamespaceStats(ctx context.Context) (*core.NamespaceStats, error) {
+	return or.stats.GetStats(ctx)
+}
+
 // Get the earliest incomplete identity claim message for this org, if it exists
 func (or *orchestrator) getRegistrationMessage(ctx context.Context) (msg *core.MessageInOut, err error) {
 	fb := database.MessageQueryFactory.NewFilter(ctx)
@@ -197,6 +201,7 @@ func (or *orchestrator) GetMultipartyStatus(ctx context.Context) (mpStatus *core
 			MultipartyContract: *or.namespace.Contracts.Active,
 			Status:             core.ContractListenerStatusUnknown,
 		}
+		mpStatus.Contracts.Pending = or.namespace.Contracts.Pending
 		mpStatus.Contracts.Terminated = or.namespace.Contracts.Terminated
 		log.L(ctx).Debugf("Looking up listener status with subscription ID: %s", mpStatus.Contracts.Active.Info.Subscription)
 		ok, _, listenerStatus, err := or.blockchain().GetContractListenerStatus(ctx, or.namespace.Name, mpStatus.Contracts.Active.Info.Subscription, false)
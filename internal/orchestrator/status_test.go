@@ -967,3 +967,15 @@ func TestGetMultipartyErrorActiveListenerStatus(t *testing.T) {
 	assert.Regexp(t, "pop", err)
 
 }
+
+func TestGetNamespaceStats(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	stats := &core.NamespaceStats{Subscriptions: 3}
+	or.mst.On("GetStats", or.ctx).Return(stats, nil)
+
+	result, err := or.GetNamespaceStats(or.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, stats, result)
+}
@@ -84,7 +84,7 @@ func (or *orchestrator) createUpdateSubscription(ctx context.Context, subDef *co
 	return subDef, or.events.CreateUpdateDurableSubscription(ctx, subDef, mustNew)
 }
 
-func (or *orchestrator) DeleteSubscription(ctx context.Context, id string) error {
+func (or *orchestrator) DeleteSubscription(ctx context.Context, id, owner string) error {
 	u, err := fftypes.ParseUUID(ctx, id)
 	if err != nil {
 		return err
@@ -96,6 +96,10 @@ func (or *orchestrator) DeleteSubscription(ctx context.Context, id string) error
 	if sub == nil {
 		return i18n.NewError(ctx, coremsgs.Msg404NotFound)
 	}
+	// A subscription can only be deleted by the principal that created it, so its name cannot be taken over
+	if sub.Owner != "" && sub.Owner != owner {
+		return i18n.NewError(ctx, coremsgs.MsgSubscriptionOwnerMismatch, sub.Name)
+	}
 	return or.events.DeleteDurableSubscription(ctx, sub)
 }
 
@@ -192,3 +196,42 @@ func (or *orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, sub
 		TotalCount: &filterResultLength,
 	}, nil
 }
+
+func (or *orchestrator) GetSubscriptionSLAs(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error) {
+	sub, err := or.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sub == nil {
+		return nil, nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return or.database().GetSubscriptionSLAs(ctx, or.namespace.Name, sub.ID, filter)
+}
+
+func (or *orchestrator) TestSubscription(ctx context.Context, id string, input *core.SubscriptionTestInput) (*core.SubscriptionTestResult, error) {
+	if (input.Event == nil) == (input.EventID == nil) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgSubscriptionTestEventRequired)
+	}
+	sub, err := or.GetSubscriptionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	event := input.Event
+	if input.EventID != nil {
+		e, err := or.database().GetEventByID(ctx, or.namespace.Name, input.EventID)
+		if err != nil {
+			return nil, err
+		}
+		if e == nil {
+			return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+		}
+		if event, err = or.events.EnrichEvent(ctx, e); err != nil {
+			return nil, err
+		}
+	}
+	return or.events.TestSubscriptionFilter(ctx, sub, event)
+}
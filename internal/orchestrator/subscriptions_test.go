@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/events/system"
@@ -230,7 +231,7 @@ func TestDeleteSubscriptionBadUUID(t *testing.T) {
 	or := newTestOrchestrator()
 	defer or.cleanup(t)
 
-	err := or.DeleteSubscription(or.ctx, "! a UUID")
+	err := or.DeleteSubscription(or.ctx, "! a UUID", "")
 	assert.Regexp(t, "FF00138", err)
 }
 
@@ -239,7 +240,7 @@ func TestDeleteSubscriptionLookupError(t *testing.T) {
 	defer or.cleanup(t)
 
 	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, fmt.Errorf("pop"))
-	err := or.DeleteSubscription(or.ctx, fftypes.NewUUID().String())
+	err := or.DeleteSubscription(or.ctx, fftypes.NewUUID().String(), "")
 	assert.EqualError(t, err, "pop")
 }
 
@@ -248,7 +249,7 @@ func TestDeleteSubscriptionNotFound(t *testing.T) {
 	defer or.cleanup(t)
 
 	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
-	err := or.DeleteSubscription(or.ctx, fftypes.NewUUID().String())
+	err := or.DeleteSubscription(or.ctx, fftypes.NewUUID().String(), "")
 	assert.Regexp(t, "FF10109", err)
 }
 
@@ -265,7 +266,30 @@ func TestDeleteSubscription(t *testing.T) {
 	}
 	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
 	or.mem.On("DeleteDurableSubscription", mock.Anything, sub).Return(nil)
-	err := or.DeleteSubscription(or.ctx, sub.ID.String())
+	err := or.DeleteSubscription(or.ctx, sub.ID.String(), "")
+	assert.NoError(t, err)
+}
+
+func TestDeleteSubscriptionOwner(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Name:      "sub1",
+			Namespace: "ns1",
+		},
+		Owner: "basic:user1",
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mem.On("DeleteDurableSubscription", mock.Anything, sub).Return(nil).Once()
+
+	err := or.DeleteSubscription(or.ctx, sub.ID.String(), "basic:user2")
+	assert.Regexp(t, "FF10598", err)
+	err = or.DeleteSubscription(or.ctx, sub.ID.String(), "")
+	assert.Regexp(t, "FF10598", err)
+	err = or.DeleteSubscription(or.ctx, sub.ID.String(), "basic:user1")
 	assert.NoError(t, err)
 }
 
@@ -613,3 +637,154 @@ func TestGetHistoricalEventsForSubscriptionNoStartOrEndSequenceFails(t *testing.
 	_, _, err := or.GetSubscriptionEventsHistorical(context.Background(), &core.Subscription{}, filter, -1, -1)
 	assert.NotNil(t, err)
 }
+
+func TestGetSubscriptionSLAs(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Name:      "sub1",
+			Namespace: "ns",
+		},
+	}
+	slas := []*core.SubscriptionSLA{core.NewSubscriptionSLA("ns", sub.ID, "2024-01-01", time.Minute)}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	or.mdi.On("GetSubscriptionSLAs", mock.Anything, "ns", sub.ID, mock.Anything).Return(slas, nil, nil)
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	res, _, err := or.GetSubscriptionSLAs(context.Background(), sub.ID.String(), fb.And())
+	assert.NoError(t, err)
+	assert.Equal(t, slas, res)
+}
+
+func TestGetSubscriptionSLAsBadUUID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionSLAs(context.Background(), "! a UUID", fb.And())
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestGetSubscriptionSLAsNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+	fb := database.SubscriptionSLAQueryFactory.NewFilter(context.Background())
+	_, _, err := or.GetSubscriptionSLAs(context.Background(), fftypes.NewUUID().String(), fb.And())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func newTestSubscriptionForTest(or *testOrchestrator) *core.Subscription {
+	sub := &core.Subscription{
+		SubscriptionRef: core.SubscriptionRef{
+			ID:        fftypes.NewUUID(),
+			Name:      "sub1",
+			Namespace: "ns",
+		},
+	}
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", sub.ID).Return(sub, nil)
+	return sub
+}
+
+func TestTestSubscriptionSampleEvent(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestSubscriptionForTest(or)
+	event := &core.EnrichedEvent{Event: core.Event{Type: core.EventTypeMessageConfirmed}}
+	result := &core.SubscriptionTestResult{Matched: true}
+	or.mem.On("TestSubscriptionFilter", mock.Anything, sub, event).Return(result, nil)
+
+	res, err := or.TestSubscription(context.Background(), sub.ID.String(), &core.SubscriptionTestInput{Event: event})
+	assert.NoError(t, err)
+	assert.Equal(t, result, res)
+}
+
+func TestTestSubscriptionEventID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestSubscriptionForTest(or)
+	event := &core.Event{ID: fftypes.NewUUID(), Type: core.EventTypeMessageConfirmed}
+	enriched := &core.EnrichedEvent{Event: *event}
+	result := &core.SubscriptionTestResult{Matched: true}
+	or.mdi.On("GetEventByID", mock.Anything, "ns", event.ID).Return(event, nil)
+	or.mem.On("EnrichEvent", mock.Anything, event).Return(enriched, nil)
+	or.mem.On("TestSubscriptionFilter", mock.Anything, sub, enriched).Return(result, nil)
+
+	res, err := or.TestSubscription(context.Background(), sub.ID.String(), &core.SubscriptionTestInput{EventID: event.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, result, res)
+}
+
+func TestTestSubscriptionEnrichFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestSubscriptionForTest(or)
+	event := &core.Event{ID: fftypes.NewUUID()}
+	or.mdi.On("GetEventByID", mock.Anything, "ns", event.ID).Return(event, nil)
+	or.mem.On("EnrichEvent", mock.Anything, event).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.TestSubscription(context.Background(), sub.ID.String(), &core.SubscriptionTestInput{EventID: event.ID})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTestSubscriptionEventNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestSubscriptionForTest(or)
+	eventID := fftypes.NewUUID()
+	or.mdi.On("GetEventByID", mock.Anything, "ns", eventID).Return(nil, nil)
+
+	_, err := or.TestSubscription(context.Background(), sub.ID.String(), &core.SubscriptionTestInput{EventID: eventID})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestTestSubscriptionEventLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	sub := newTestSubscriptionForTest(or)
+	eventID := fftypes.NewUUID()
+	or.mdi.On("GetEventByID", mock.Anything, "ns", eventID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.TestSubscription(context.Background(), sub.ID.String(), &core.SubscriptionTestInput{EventID: eventID})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestTestSubscriptionNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetSubscriptionByID", mock.Anything, "ns", mock.Anything).Return(nil, nil)
+
+	_, err := or.TestSubscription(context.Background(), fftypes.NewUUID().String(), &core.SubscriptionTestInput{EventID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestTestSubscriptionBadUUID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.TestSubscription(context.Background(), "! a UUID", &core.SubscriptionTestInput{EventID: fftypes.NewUUID()})
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestTestSubscriptionEventRequired(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.TestSubscription(context.Background(), fftypes.NewUUID().String(), &core.SubscriptionTestInput{})
+	assert.Regexp(t, "FF10584", err)
+
+	_, err = or.TestSubscription(context.Background(), fftypes.NewUUID().String(), &core.SubscriptionTestInput{
+		Event:   &core.EnrichedEvent{},
+		EventID: fftypes.NewUUID(),
+	})
+	assert.Regexp(t, "FF10584", err)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+func (or *orchestrator) CompensateTransaction(ctx context.Context, id string) ([]*core.Compensation, error) {
+	tx, err := or.GetTransactionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if tx == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	return or.operations.CompensateTransaction(ctx, tx.ID)
+}
+
+func (or *orchestrator) GetTransactionCompensations(ctx context.Context, id string) ([]*core.Compensation, *ffapi.FilterResult, error) {
+	u, err := fftypes.ParseUUID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	fb := database.CompensationQueryFactory.NewFilter(ctx)
+	filter := fb.And(
+		fb.Eq("tx", u),
+	)
+	return or.database().GetCompensations(ctx, or.namespace.Name, filter)
+}
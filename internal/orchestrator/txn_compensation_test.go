@@ -0,0 +1,80 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompensateTransaction(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	or.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(&core.Transaction{ID: txID}, nil)
+	or.mom.On("CompensateTransaction", mock.Anything, txID).Return([]*core.Compensation{}, nil)
+
+	_, err := or.CompensateTransaction(context.Background(), txID.String())
+	assert.NoError(t, err)
+}
+
+func TestCompensateTransactionNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	or.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(nil, nil)
+
+	_, err := or.CompensateTransaction(context.Background(), txID.String())
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestCompensateTransactionLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	txID := fftypes.NewUUID()
+	or.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.CompensateTransaction(context.Background(), txID.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestGetTransactionCompensations(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	or.mdi.On("GetCompensations", mock.Anything, "ns", mock.Anything).Return([]*core.Compensation{}, nil, nil)
+
+	_, _, err := or.GetTransactionCompensations(context.Background(), fftypes.NewUUID().String())
+	assert.NoError(t, err)
+}
+
+func TestGetTransactionCompensationsBadID(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, _, err := or.GetTransactionCompensations(context.Background(), "bad")
+	assert.Regexp(t, "FF00138", err)
+}
@@ -62,6 +62,24 @@ func txBlockchainEventStatus(event *core.BlockchainEvent) *core.TransactionStatu
 	}
 }
 
+func txMessageStatus(msg *core.Message) *core.TransactionStatusDetails {
+	details := &core.TransactionStatusDetails{
+		Status:    core.OpStatusPending,
+		Type:      core.TransactionStatusTypeMessage,
+		SubType:   msg.Header.Type.String(),
+		Timestamp: msg.Confirmed,
+		ID:        msg.Header.ID,
+	}
+	switch msg.State {
+	case core.MessageStateConfirmed:
+		details.Status = core.OpStatusSucceeded
+	case core.MessageStateRejected, core.MessageStateCancelled:
+		details.Status = core.OpStatusFailed
+		details.Error = msg.RejectReason
+	}
+	return details
+}
+
 func (or *orchestrator) GetTransactionStatus(ctx context.Context, id string) (*core.TransactionStatus, error) {
 	result := &core.TransactionStatus{
 		Status:  core.OpStatusSucceeded,
@@ -125,6 +143,17 @@ func (or *orchestrator) GetTransactionStatus(ctx context.Context, id string) (*c
 				ID:        batches[0].ID,
 			})
 		}
+		// Each message pinned by the transaction must also be confirmed (or rejected) by the local node
+		mf := database.MessageQueryFactory.NewFilter(ctx)
+		msgs, _, err := or.database().GetMessages(ctx, or.namespace.Name, mf.Eq("txid", id))
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range msgs {
+			details := txMessageStatus(msg)
+			result.Details = append(result.Details, details)
+			updateStatus(result, details.Status)
+		}
 
 	case core.TransactionTypeTokenPool:
 		// Note: no assumptions about blockchain events here (may or may not contain one)
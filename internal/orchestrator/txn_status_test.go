@@ -74,11 +74,22 @@ func TestGetTransactionStatusBatchPinSuccess(t *testing.T) {
 			Confirmed: fftypes.UnixTime(2),
 		},
 	}
+	msgs := []*core.Message{
+		{
+			Header: core.MessageHeader{
+				ID:   fftypes.NewUUID(),
+				Type: core.MessageTypeBroadcast,
+			},
+			State:     core.MessageStateConfirmed,
+			Confirmed: fftypes.UnixTime(3),
+		},
+	}
 
 	or.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(tx, nil)
 	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(ops, nil, nil)
 	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
 	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return(batches, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(msgs, nil, nil)
 
 	status, err := or.GetTransactionStatus(context.Background(), txID.String())
 	assert.NoError(t, err)
@@ -86,6 +97,13 @@ func TestGetTransactionStatusBatchPinSuccess(t *testing.T) {
 	expectedStatus := compactJSON(`{
 		"status": "Succeeded",
 		"details": [
+			{
+				"type": "Message",
+				"subtype": "broadcast",
+				"status": "Succeeded",
+				"timestamp": "1970-01-01T00:00:03Z",
+				"id": "` + msgs[0].Header.ID.String() + `"
+			},
 			{
 				"type": "Batch",
 				"subtype": "broadcast",
@@ -141,6 +159,7 @@ func TestGetTransactionStatusBatchPinFail(t *testing.T) {
 	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(ops, nil, nil)
 	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
 	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return(batches, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{}, nil, nil)
 
 	status, err := or.GetTransactionStatus(context.Background(), txID.String())
 	assert.NoError(t, err)
@@ -195,6 +214,7 @@ func TestGetTransactionStatusBatchPinPending(t *testing.T) {
 	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(ops, nil, nil)
 	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
 	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return(batches, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return([]*core.Message{}, nil, nil)
 
 	status, err := or.GetTransactionStatus(context.Background(), txID.String())
 	assert.NoError(t, err)
@@ -225,6 +245,99 @@ func TestGetTransactionStatusBatchPinPending(t *testing.T) {
 	or.mdi.AssertExpectations(t)
 }
 
+func TestGetTransactionStatusBatchPinMessageRejected(t *testing.T) {
+	or := newTestOrchestrator()
+
+	txID := fftypes.NewUUID()
+	tx := &core.Transaction{
+		Namespace: "ns1",
+		Type:      core.TransactionTypeBatchPin,
+	}
+	events := []*core.BlockchainEvent{
+		{
+			Namespace: "ns1",
+			Name:      "BatchPin",
+			ID:        fftypes.NewUUID(),
+			Timestamp: fftypes.UnixTime(1),
+		},
+	}
+	batches := []*core.BatchPersisted{
+		{
+			BatchHeader: core.BatchHeader{
+				Namespace: "ns1",
+				ID:        fftypes.NewUUID(),
+				Type:      core.BatchTypePrivate,
+			},
+			Confirmed: fftypes.UnixTime(2),
+		},
+	}
+	msgs := []*core.Message{
+		{
+			Header: core.MessageHeader{
+				ID:   fftypes.NewUUID(),
+				Type: core.MessageTypePrivate,
+			},
+			State:        core.MessageStateRejected,
+			Confirmed:    fftypes.UnixTime(3),
+			RejectReason: "bad data",
+		},
+		{
+			Header: core.MessageHeader{
+				ID:   fftypes.NewUUID(),
+				Type: core.MessageTypePrivate,
+			},
+			State: core.MessageStatePending,
+		},
+	}
+
+	or.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(tx, nil)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return([]*core.Operation{}, nil, nil)
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(events, nil, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return(batches, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(msgs, nil, nil)
+
+	status, err := or.GetTransactionStatus(context.Background(), txID.String())
+	assert.NoError(t, err)
+
+	expectedStatus := compactJSON(`{
+		"status": "Failed",
+		"details": [
+			{
+				"type": "Message",
+				"subtype": "private",
+				"status": "Pending",
+				"id": "` + msgs[1].Header.ID.String() + `"
+			},
+			{
+				"type": "Message",
+				"subtype": "private",
+				"status": "Failed",
+				"timestamp": "1970-01-01T00:00:03Z",
+				"id": "` + msgs[0].Header.ID.String() + `",
+				"error": "bad data"
+			},
+			{
+				"type": "Batch",
+				"subtype": "private",
+				"status": "Succeeded",
+				"timestamp": "1970-01-01T00:00:02Z",
+				"id": "` + batches[0].ID.String() + `"
+			},
+			{
+				"type": "BlockchainEvent",
+				"subtype": "BatchPin",
+				"status": "Succeeded",
+				"timestamp": "1970-01-01T00:00:01Z",
+				"id": "` + events[0].ID.String() + `"
+			}
+		]
+	}`)
+	statusJSON, _ := json.Marshal(status)
+	assert.Equal(t, expectedStatus, string(statusJSON))
+
+	or.mdi.AssertExpectations(t)
+}
+
 func TestGetTransactionStatusTokenPoolSuccess(t *testing.T) {
 	or := newTestOrchestrator()
 
@@ -865,6 +978,27 @@ func TestGetTransactionStatusBatchError(t *testing.T) {
 	or.mdi.AssertExpectations(t)
 }
 
+func TestGetTransactionStatusMessagesError(t *testing.T) {
+	or := newTestOrchestrator()
+
+	txID := fftypes.NewUUID()
+	tx := &core.Transaction{
+		Namespace: "ns1",
+		Type:      core.TransactionTypeBatchPin,
+	}
+
+	or.mth.On("GetTransactionByIDCached", mock.Anything, txID).Return(tx, nil)
+	or.mdi.On("GetOperations", mock.Anything, "ns", mock.Anything).Return(nil, nil, nil)
+	or.mdi.On("GetBlockchainEvents", mock.Anything, "ns", mock.Anything).Return(nil, nil, nil)
+	or.mdi.On("GetBatches", mock.Anything, "ns", mock.Anything).Return(nil, nil, nil)
+	or.mdi.On("GetMessages", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.GetTransactionStatus(context.Background(), txID.String())
+	assert.EqualError(t, err, "pop")
+
+	or.mdi.AssertExpectations(t)
+}
+
 func TestGetTransactionStatusPoolError(t *testing.T) {
 	or := newTestOrchestrator()
 
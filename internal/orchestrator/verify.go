@@ -0,0 +1,210 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Verify produces a report for external auditors on a message stored locally, or a raw batch. The report
+// covers the signing key of each message against the key that signed the on-chain pin and the verifiers
+// registered to the author, the chain of hashes from the data up to the pinned batch hash, and the
+// blockchain transaction that recorded the pin.
+func (or *orchestrator) Verify(ctx context.Context, input *core.VerifyInput) (*core.VerificationReport, error) {
+	switch {
+	case input.Message != nil && input.Batch == nil:
+		return or.verifyMessage(ctx, input.Message)
+	case input.Batch != nil && input.Message == nil:
+		return or.verifyRawBatch(ctx, input.Batch)
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgVerifyInputInvalid)
+	}
+}
+
+func (or *orchestrator) verifyMessage(ctx context.Context, id *fftypes.UUID) (*core.VerificationReport, error) {
+	msg, err := or.database().GetMessageByID(ctx, or.namespace.Name, id)
+	if err != nil {
+		return nil, err
+	} else if msg == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+	if msg.BatchID == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgVerifyMessageNotBatched, msg.Header.ID)
+	}
+	batch, err := or.database().GetBatchByID(ctx, or.namespace.Name, msg.BatchID)
+	if err != nil {
+		return nil, err
+	} else if batch == nil {
+		return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
+	}
+
+	hashChain, pins, err := or.verifyStoredBatch(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	return or.buildVerificationReport(ctx, msg.Header.ID, hashChain, batch.TX, pins, []*core.Message{msg})
+}
+
+func (or *orchestrator) verifyRawBatch(ctx context.Context, batch *core.Batch) (*core.VerificationReport, error) {
+	if batch.ID == nil {
+		return nil, i18n.NewError(ctx, i18n.MsgMissingRequiredField, "batch.id")
+	}
+	if batch.Hash == nil {
+		return nil, i18n.NewError(ctx, i18n.MsgMissingRequiredField, "batch.hash")
+	}
+
+	// The raw batch stands in for what is stored locally - only the pins are looked up
+	persisted, manifest := batch.Confirmed()
+	hashChain := newBatchVerification(persisted)
+	hashChain.HashAlgorithm = manifest.HashAlgorithm
+	pins, err := or.verifyBatchPins(ctx, persisted, hashChain)
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range batch.Payload.Messages {
+		if err := msg.Verify(ctx); err != nil {
+			hashChain.AddMismatch(&core.BatchMismatch{
+				Type:  core.BatchMismatchTypeMessage,
+				ID:    msg.Header.ID,
+				Found: msg.Hash.String(),
+				Error: err.Error(),
+			})
+		}
+	}
+	for _, d := range batch.Payload.Data {
+		hash, err := d.CalcHash(ctx)
+		switch {
+		case err != nil:
+			hashChain.AddMismatch(&core.BatchMismatch{
+				Type:  core.BatchMismatchTypeData,
+				ID:    d.ID,
+				Found: d.Hash.String(),
+				Error: err.Error(),
+			})
+		case !hash.Equals(d.Hash):
+			hashChain.AddMismatch(&core.BatchMismatch{
+				Type:     core.BatchMismatchTypeData,
+				ID:       d.ID,
+				Expected: d.Hash.String(),
+				Found:    hash.String(),
+			})
+		}
+	}
+	verifyBatchHash(ctx, batch.Hash, manifest, &batch.Payload, hashChain)
+
+	return or.buildVerificationReport(ctx, nil, hashChain, batch.Payload.TX, pins, batch.Payload.Messages)
+}
+
+func (or *orchestrator) buildVerificationReport(ctx context.Context, msgID *fftypes.UUID, hashChain *core.BatchVerification, txRef core.TransactionRef, pins []*core.Pin, messages []*core.Message) (*core.VerificationReport, error) {
+	report := &core.VerificationReport{
+		Valid:      hashChain.Valid,
+		Message:    msgID,
+		Batch:      hashChain.Batch,
+		Signatures: make([]*core.SignatureVerification, 0, len(messages)),
+		HashChain:  hashChain,
+	}
+
+	var signer string
+	if len(pins) > 0 {
+		signer = pins[0].Signer
+	}
+	if txRef.ID != nil {
+		report.Pin = &core.PinTransaction{
+			ID:     txRef.ID,
+			Type:   txRef.Type,
+			Signer: signer,
+		}
+		tx, err := or.database().GetTransactionByID(ctx, or.namespace.Name, txRef.ID)
+		if err != nil {
+			return nil, err
+		}
+		if tx != nil {
+			report.Pin.BlockchainIDs = tx.BlockchainIDs
+		}
+	}
+
+	for _, msg := range messages {
+		sv, err := or.verifyMessageSignature(ctx, msg, signer)
+		if err != nil {
+			return nil, err
+		}
+		report.AddSignature(sv)
+	}
+	return report, nil
+}
+
+// verifyMessageSignature checks the key of a message was the one that signed the on-chain pin (if the batch
+// has been pinned), and that it is a current verifier of the identity that authored the message
+func (or *orchestrator) verifyMessageSignature(ctx context.Context, msg *core.Message, signer string) (*core.SignatureVerification, error) {
+	sv := &core.SignatureVerification{
+		Message: msg.Header.ID,
+		Author:  msg.Header.Author,
+		Key:     msg.Header.Key,
+		Signer:  signer,
+	}
+	if signer != "" && msg.Header.Key != signer {
+		sv.Error = i18n.NewError(ctx, coremsgs.MsgInvalidMessageSigner, msg.Header.ID, msg.Header.Key, signer).Error()
+		return sv, nil
+	}
+
+	verifier := &core.VerifierRef{
+		Type:  or.blockchain().VerifierType(),
+		Value: msg.Header.Key,
+	}
+	identity, err := or.identity.FindIdentityForVerifier(ctx, []core.IdentityType{
+		core.IdentityTypeOrg,
+		core.IdentityTypeCustom,
+	}, verifier)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		sv.Error = i18n.NewError(ctx, coremsgs.MsgVerifyKeyNotRegistered, msg.Header.Key).Error()
+		return sv, nil
+	}
+	sv.Identity = identity.ID
+	if identity.DID != msg.Header.Author {
+		// Broadcast and private messages can be signed on behalf of an author that has delegated to the signer
+		if msg.Header.Type == core.MessageTypeBroadcast || msg.Header.Type == core.MessageTypePrivate {
+			delegated, err := or.identity.IsDelegated(ctx, msg.Header.Author, identity.DID, core.DelegationScopeMessages, msg.Header.Created)
+			if err != nil {
+				return nil, err
+			}
+			sv.Delegated = delegated
+		}
+	}
+	if identity.DID != msg.Header.Author && !sv.Delegated {
+		sv.Error = i18n.NewError(ctx, coremsgs.MsgInvalidMessageIdentity, msg.Header.ID, msg.Header.Author, msg.Header.Key, identity.DID, identity.ID).Error()
+		return sv, nil
+	}
+
+	revoked, err := or.identity.IsVerifierRevoked(ctx, verifier)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		sv.Error = i18n.NewError(ctx, coremsgs.MsgRevokedMessageSigner, msg.Header.ID, msg.Header.Key).Error()
+		return sv, nil
+	}
+	sv.Valid = true
+	return sv, nil
+}
@@ -0,0 +1,374 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockVerifySignature(or *testOrchestrator, identity *core.Identity, revoked bool) {
+	or.mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	or.mim.On("FindIdentityForVerifier", mock.Anything, []core.IdentityType{core.IdentityTypeOrg, core.IdentityTypeCustom}, &core.VerifierRef{
+		Type:  core.VerifierTypeEthAddress,
+		Value: "0x12345",
+	}).Return(identity, nil)
+	or.mim.On("IsVerifierRevoked", mock.Anything, mock.Anything).Return(revoked, nil).Maybe()
+}
+
+func testVerifyIdentity() *core.Identity {
+	return &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID:  fftypes.NewUUID(),
+			DID: "did:firefly:org/org1",
+		},
+	}
+}
+
+func TestVerifyMessageOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	msg.BatchID = bp.ID
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", bp.TX.ID).Return(&core.Transaction{
+		ID:            bp.TX.ID,
+		BlockchainIDs: fftypes.FFStringArray{"0xabcd"},
+	}, nil)
+	identity := testVerifyIdentity()
+	mockVerifySignature(or, identity, false)
+
+	report, err := or.Verify(context.Background(), &core.VerifyInput{Message: msg.Header.ID})
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, msg.Header.ID, report.Message)
+	assert.Equal(t, bp.ID, report.Batch)
+	assert.True(t, report.HashChain.Valid)
+	assert.Equal(t, bp.Hash, report.HashChain.PinnedHash)
+	assert.Equal(t, &core.PinTransaction{
+		ID:            bp.TX.ID,
+		Type:          core.TransactionTypeBatchPin,
+		BlockchainIDs: fftypes.FFStringArray{"0xabcd"},
+		Signer:        "0x12345",
+	}, report.Pin)
+	assert.Len(t, report.Signatures, 1)
+	assert.True(t, report.Signatures[0].Valid)
+	assert.Equal(t, identity.ID, report.Signatures[0].Identity)
+}
+
+func TestVerifyMessageNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msgID := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(nil, nil)
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msgID})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestVerifyMessageLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msgID := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msgID})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyMessageNotBatched(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msgID := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(&core.Message{
+		Header: core.MessageHeader{ID: msgID},
+	}, nil)
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msgID})
+	assert.Regexp(t, "FF10547", err)
+}
+
+func TestVerifyMessageBatchNotFound(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msgID := fftypes.NewUUID()
+	batchID := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(&core.Message{
+		Header:  core.MessageHeader{ID: msgID},
+		BatchID: batchID,
+	}, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batchID).Return(nil, nil)
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msgID})
+	assert.Regexp(t, "FF10109", err)
+}
+
+func TestVerifyMessageBatchLookupFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	msgID := fftypes.NewUUID()
+	batchID := fftypes.NewUUID()
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msgID).Return(&core.Message{
+		Header:  core.MessageHeader{ID: msgID},
+		BatchID: batchID,
+	}, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", batchID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msgID})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyMessagePinsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, _ := newTestVerifyBatch(t)
+	msg.BatchID = bp.ID
+	or.mdi.On("GetMessageByID", mock.Anything, "ns", msg.Header.ID).Return(msg, nil)
+	or.mdi.On("GetBatchByID", mock.Anything, "ns", bp.ID).Return(bp, nil)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msg.Header.ID})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyMessageTransactionFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	bp, msg, data := newTestVerifyBatch(t)
+	msg.BatchID = bp.ID
+	mockVerifyBatchLookups(or, bp, msg, data, bp.Hash)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", bp.TX.ID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Message: msg.Header.ID})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyInputInvalid(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{})
+	assert.Regexp(t, "FF10546", err)
+
+	_, err = or.Verify(context.Background(), &core.VerifyInput{
+		Message: fftypes.NewUUID(),
+		Batch:   &core.Batch{},
+	})
+	assert.Regexp(t, "FF10546", err)
+}
+
+func newTestVerifyRawBatch(t *testing.T) *core.Batch {
+	bp, msg, data := newTestVerifyBatch(t)
+	return bp.GenInflight([]*core.Message{msg.BatchMessage()}, core.DataArray{data.BatchData(bp.Type)})
+}
+
+func TestVerifyRawBatchOk(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batch := newTestVerifyRawBatch(t)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{
+		{Batch: batch.ID, BatchHash: batch.Hash, Signer: "0x12345"},
+	}, nil, nil)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", batch.Payload.TX.ID).Return(nil, nil)
+	mockVerifySignature(or, testVerifyIdentity(), false)
+
+	report, err := or.Verify(context.Background(), &core.VerifyInput{Batch: batch})
+	assert.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Nil(t, report.Message)
+	assert.Equal(t, batch.ID, report.Batch)
+	assert.Equal(t, batch.Hash, report.HashChain.CalculatedHash)
+	assert.Equal(t, "0x12345", report.Pin.Signer)
+	assert.Empty(t, report.Pin.BlockchainIDs)
+	assert.Len(t, report.Signatures, 1)
+	assert.True(t, report.Signatures[0].Valid)
+}
+
+func TestVerifyRawBatchTampered(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batch := newTestVerifyRawBatch(t)
+	batch.Payload.Data[0].Value = fftypes.JSONAnyPtr(`{"some":"other data"}`)
+	batch.Payload.Messages[0].Header.Tag = "tampered"
+	batch.Payload.Data = append(batch.Payload.Data, &core.Data{ID: fftypes.NewUUID()})
+	batch.Payload.TX.ID = nil
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	mockVerifySignature(or, testVerifyIdentity(), false)
+
+	report, err := or.Verify(context.Background(), &core.VerifyInput{Batch: batch})
+	assert.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Nil(t, report.Pin)
+	assert.True(t, report.Signatures[0].Valid)
+	mismatchTypes := make([]core.BatchMismatchType, len(report.HashChain.Mismatches))
+	for i, m := range report.HashChain.Mismatches {
+		mismatchTypes[i] = m.Type
+	}
+	assert.Equal(t, []core.BatchMismatchType{
+		core.BatchMismatchTypePin,
+		core.BatchMismatchTypeMessage,
+		core.BatchMismatchTypeData,
+		core.BatchMismatchTypeData,
+		core.BatchMismatchTypeBatchHash,
+	}, mismatchTypes)
+}
+
+func TestVerifyRawBatchMissingFields(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Batch: &core.Batch{}})
+	assert.Regexp(t, "FF00112.*batch.id", err)
+
+	_, err = or.Verify(context.Background(), &core.VerifyInput{Batch: &core.Batch{
+		BatchHeader: core.BatchHeader{ID: fftypes.NewUUID()},
+	}})
+	assert.Regexp(t, "FF00112.*batch.hash", err)
+}
+
+func TestVerifyRawBatchPinsFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batch := newTestVerifyRawBatch(t)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Batch: batch})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyRawBatchSignatureFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	batch := newTestVerifyRawBatch(t)
+	or.mdi.On("GetPins", mock.Anything, "ns", mock.Anything).Return([]*core.Pin{}, nil, nil)
+	or.mdi.On("GetTransactionByID", mock.Anything, "ns", batch.Payload.TX.ID).Return(nil, nil)
+	or.mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	or.mim.On("FindIdentityForVerifier", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("pop"))
+
+	_, err := or.Verify(context.Background(), &core.VerifyInput{Batch: batch})
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyMessageSignatureSignerMismatch(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	sv, err := or.verifyMessageSignature(context.Background(), msg, "0x67890")
+	assert.NoError(t, err)
+	assert.False(t, sv.Valid)
+	assert.Regexp(t, "FF10452", sv.Error)
+}
+
+func TestVerifyMessageSignatureNotRegistered(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	mockVerifySignature(or, nil, false)
+	sv, err := or.verifyMessageSignature(context.Background(), msg, "")
+	assert.NoError(t, err)
+	assert.False(t, sv.Valid)
+	assert.Regexp(t, "FF10548.*0x12345", sv.Error)
+}
+
+func TestVerifyMessageSignatureWrongIdentity(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	identity := testVerifyIdentity()
+	identity.DID = "did:firefly:org/org2"
+	mockVerifySignature(or, identity, false)
+	or.mim.On("IsDelegated", mock.Anything, "did:firefly:org/org1", "did:firefly:org/org2", core.DelegationScopeMessages, msg.Header.Created).Return(false, nil)
+	sv, err := or.verifyMessageSignature(context.Background(), msg, "0x12345")
+	assert.NoError(t, err)
+	assert.False(t, sv.Valid)
+	assert.Equal(t, identity.ID, sv.Identity)
+	assert.Regexp(t, "FF10453", sv.Error)
+}
+
+func TestVerifyMessageSignatureDelegatedIdentity(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	identity := testVerifyIdentity()
+	identity.DID = "did:firefly:org/org2"
+	mockVerifySignature(or, identity, false)
+	or.mim.On("IsDelegated", mock.Anything, "did:firefly:org/org1", "did:firefly:org/org2", core.DelegationScopeMessages, msg.Header.Created).Return(true, nil)
+	sv, err := or.verifyMessageSignature(context.Background(), msg, "0x12345")
+	assert.NoError(t, err)
+	assert.True(t, sv.Valid)
+	assert.True(t, sv.Delegated)
+	assert.Empty(t, sv.Error)
+}
+
+func TestVerifyMessageSignatureDelegationCheckFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	identity := testVerifyIdentity()
+	identity.DID = "did:firefly:org/org2"
+	mockVerifySignature(or, identity, false)
+	or.mim.On("IsDelegated", mock.Anything, "did:firefly:org/org1", "did:firefly:org/org2", core.DelegationScopeMessages, msg.Header.Created).Return(false, fmt.Errorf("pop"))
+	_, err := or.verifyMessageSignature(context.Background(), msg, "0x12345")
+	assert.EqualError(t, err, "pop")
+}
+
+func TestVerifyMessageSignatureRevoked(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	mockVerifySignature(or, testVerifyIdentity(), true)
+	sv, err := or.verifyMessageSignature(context.Background(), msg, "0x12345")
+	assert.NoError(t, err)
+	assert.False(t, sv.Valid)
+	assert.Regexp(t, "FF10478", sv.Error)
+}
+
+func TestVerifyMessageSignatureRevokedCheckFail(t *testing.T) {
+	or := newTestOrchestrator()
+	defer or.cleanup(t)
+
+	_, msg, _ := newTestVerifyBatch(t)
+	or.mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	or.mim.On("FindIdentityForVerifier", mock.Anything, mock.Anything, mock.Anything).Return(testVerifyIdentity(), nil)
+	or.mim.On("IsVerifierRevoked", mock.Anything, mock.Anything).Return(false, fmt.Errorf("pop"))
+	_, err := or.verifyMessageSignature(context.Background(), msg, "0x12345")
+	assert.EqualError(t, err, "pop")
+}
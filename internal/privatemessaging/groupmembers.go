@@ -0,0 +1,93 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// ResolveGroupMembers resolves each member of a group to its org and node, along with the data exchange
+// endpoint of the node and whether data exchange can reach it - to diagnose why a private send is stuck.
+// Members that cannot be resolved are reported with an error, rather than failing the whole request.
+func (pm *privateMessaging) ResolveGroupMembers(ctx context.Context, hash string) ([]*core.ResolvedGroupMember, error) {
+	groupHash, err := fftypes.ParseBytes32(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	group, err := pm.database.GetGroupByHash(ctx, pm.namespace.Name, groupHash)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgGroupNotFound, groupHash)
+	}
+	localNode, err := pm.identity.GetLocalNode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*core.ResolvedGroupMember, len(group.Members))
+	for i, member := range group.Members {
+		if members[i], err = pm.resolveGroupMember(ctx, member, localNode); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+func (pm *privateMessaging) resolveGroupMember(ctx context.Context, member *core.Member, localNode *core.Identity) (*core.ResolvedGroupMember, error) {
+	resolved := &core.ResolvedGroupMember{
+		Identity: member.Identity,
+	}
+
+	org, _, err := pm.identity.CachedIdentityLookupNilOK(ctx, member.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if org == nil {
+		resolved.Error = i18n.NewError(ctx, coremsgs.MsgIdentityNotFoundByString, member.Identity).Error()
+		return resolved, nil
+	}
+	resolved.Org = org
+
+	node, err := pm.identity.CachedIdentityLookupByID(ctx, member.Node)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil || node.Type != core.IdentityTypeNode {
+		resolved.Error = i18n.NewError(ctx, coremsgs.MsgNodeNotFound, member.Node).Error()
+		return resolved, nil
+	}
+	resolved.Node = node
+	resolved.PeerID = pm.exchange.GetPeerID(node.Profile)
+	resolved.Endpoint = node.Profile
+
+	// Batches for the local node are not sent via data exchange
+	if node.ID.Equals(localNode.ID) {
+		resolved.Local = true
+		return resolved, nil
+	}
+	if resolved.PeerStatus, err = pm.exchange.GetPeerStatus(ctx, node.Profile); err != nil {
+		resolved.Error = err.Error()
+	}
+	return resolved, nil
+}
@@ -0,0 +1,196 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
+	"github.com/hyperledger/firefly/mocks/identitymanagermocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveGroupMembers(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	localOrg := newTestOrg("localorg")
+	localNode := newTestNode("node1", localOrg)
+	remoteOrg := newTestOrg("remoteorg")
+	remoteNode := newTestNode("node2", remoteOrg)
+	offlineOrg := newTestOrg("offlineorg")
+	offlineNode := newTestNode("node3", offlineOrg)
+	groupHash := fftypes.NewRandB32()
+	missingNodeID := fftypes.NewUUID()
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupHash).Return(&core.Group{
+		Hash: groupHash,
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{
+				{Identity: localOrg.DID, Node: localNode.ID},
+				{Identity: remoteOrg.DID, Node: remoteNode.ID},
+				{Identity: offlineOrg.DID, Node: offlineNode.ID},
+				{Identity: "did:firefly:org/missing", Node: localNode.ID},
+				{Identity: localOrg.DID, Node: missingNodeID},
+			},
+		},
+	}, nil)
+
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", pm.ctx).Return(localNode, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, localOrg.DID).Return(localOrg, false, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, remoteOrg.DID).Return(remoteOrg, false, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, offlineOrg.DID).Return(offlineOrg, false, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, "did:firefly:org/missing").Return(nil, false, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, localNode.ID).Return(localNode, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, remoteNode.ID).Return(remoteNode, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, offlineNode.ID).Return(offlineNode, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, missingNodeID).Return(nil, nil)
+
+	mdx := pm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", localNode.Profile).Return("node1-peer")
+	mdx.On("GetPeerID", remoteNode.Profile).Return("node2-peer")
+	mdx.On("GetPeerID", offlineNode.Profile).Return("node3-peer")
+	mdx.On("GetPeerStatus", pm.ctx, remoteNode.Profile).Return(core.PeerStatusOnline, nil)
+	mdx.On("GetPeerStatus", pm.ctx, offlineNode.Profile).Return(core.PeerStatus(""), fmt.Errorf("pop"))
+
+	members, err := pm.ResolveGroupMembers(pm.ctx, groupHash.String())
+	assert.NoError(t, err)
+	assert.Len(t, members, 5)
+
+	assert.Equal(t, localOrg, members[0].Org)
+	assert.Equal(t, localNode, members[0].Node)
+	assert.True(t, members[0].Local)
+	assert.Equal(t, "node1-peer", members[0].PeerID)
+	assert.Empty(t, members[0].PeerStatus)
+
+	assert.Equal(t, remoteOrg, members[1].Org)
+	assert.Equal(t, remoteNode, members[1].Node)
+	assert.False(t, members[1].Local)
+	assert.Equal(t, "node2-peer", members[1].PeerID)
+	assert.Equal(t, remoteNode.Profile, members[1].Endpoint)
+	assert.Equal(t, core.PeerStatusOnline, members[1].PeerStatus)
+	assert.Empty(t, members[1].Error)
+
+	assert.Equal(t, offlineNode, members[2].Node)
+	assert.Equal(t, "pop", members[2].Error)
+
+	assert.Nil(t, members[3].Org)
+	assert.Regexp(t, "FF10277", members[3].Error)
+
+	assert.Equal(t, localOrg, members[4].Org)
+	assert.Nil(t, members[4].Node)
+	assert.Regexp(t, "FF10224", members[4].Error)
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
+
+func TestResolveGroupMembersBadHash(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	_, err := pm.ResolveGroupMembers(pm.ctx, "!bad")
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestResolveGroupMembersGetGroupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	groupHash := fftypes.NewRandB32()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupHash).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.ResolveGroupMembers(pm.ctx, groupHash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestResolveGroupMembersGroupNotFound(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	groupHash := fftypes.NewRandB32()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupHash).Return(nil, nil)
+
+	_, err := pm.ResolveGroupMembers(pm.ctx, groupHash.String())
+	assert.Regexp(t, "FF10226", err)
+}
+
+func TestResolveGroupMembersLocalNodeFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	groupHash := fftypes.NewRandB32()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupHash).Return(&core.Group{Hash: groupHash}, nil)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", pm.ctx).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.ResolveGroupMembers(pm.ctx, groupHash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestResolveGroupMembersOrgLookupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	groupHash := fftypes.NewRandB32()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupHash).Return(&core.Group{
+		Hash: groupHash,
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{{Identity: "did:firefly:org/org1", Node: fftypes.NewUUID()}},
+		},
+	}, nil)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", pm.ctx).Return(&core.Identity{}, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, "did:firefly:org/org1").Return(nil, true, fmt.Errorf("pop"))
+
+	_, err := pm.ResolveGroupMembers(pm.ctx, groupHash.String())
+	assert.EqualError(t, err, "pop")
+}
+
+func TestResolveGroupMembersNodeLookupFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	org := newTestOrg("org1")
+	nodeID := fftypes.NewUUID()
+	groupHash := fftypes.NewRandB32()
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetGroupByHash", pm.ctx, "ns1", groupHash).Return(&core.Group{
+		Hash: groupHash,
+		GroupIdentity: core.GroupIdentity{
+			Members: core.Members{{Identity: org.DID, Node: nodeID}},
+		},
+	}, nil)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", pm.ctx).Return(&core.Identity{}, nil)
+	mim.On("CachedIdentityLookupNilOK", pm.ctx, org.DID).Return(org, false, nil)
+	mim.On("CachedIdentityLookupByID", pm.ctx, nodeID).Return(nil, fmt.Errorf("pop"))
+
+	_, err := pm.ResolveGroupMembers(pm.ctx, groupHash.String())
+	assert.EqualError(t, err, "pop")
+}
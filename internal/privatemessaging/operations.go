@@ -22,14 +22,17 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/dataexchange"
 )
 
 type transferBlobData struct {
-	Node *core.Identity `json:"node"`
-	Blob *core.Blob     `json:"blob"`
+	Node   *core.Identity `json:"node"`
+	Blob   *core.Blob     `json:"blob"`
+	Offset int64          `json:"offset,omitempty"`
 }
 
 type batchSendData struct {
@@ -100,7 +103,11 @@ func (pm *privateMessaging) PrepareOperation(ctx context.Context, op *core.Opera
 		} else if len(blobs) == 0 || blobs[0] == nil {
 			return nil, i18n.NewError(ctx, coremsgs.Msg404NotFound)
 		}
-		return opSendBlob(op, node, blobs[0]), nil
+		offset, err := pm.getTransferResumeOffset(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		return opSendBlob(op, node, blobs[0], offset), nil
 
 	case core.OpTypeDataExchangeSendBatch:
 		nodeID, groupHash, batchID, err := retrieveBatchSendInputs(ctx, op)
@@ -144,7 +151,14 @@ func (pm *privateMessaging) RunOperation(ctx context.Context, op *core.PreparedO
 		if err != nil {
 			return nil, core.OpPhaseInitializing, err
 		}
-		return nil, core.OpPhaseInitializing, pm.exchange.TransferBlob(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, data.Blob.PayloadRef)
+		if err := pm.transfers.acquire(ctx, op.ID, pm.exchange.GetPeerID(data.Node.Profile), data.Blob.Size-data.Offset); err != nil {
+			return nil, core.OpPhaseInitializing, err
+		}
+		err = pm.exchange.TransferBlob(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, data.Blob.PayloadRef, data.Offset)
+		if err != nil {
+			pm.transfers.release(op.ID)
+		}
+		return nil, core.OpPhaseInitializing, err
 
 	case batchSendData:
 		localNode, err := pm.identity.GetLocalNode(ctx)
@@ -152,9 +166,9 @@ func (pm *privateMessaging) RunOperation(ctx context.Context, op *core.PreparedO
 			return nil, core.OpPhaseInitializing, err
 		}
 
-		payload, err := json.Marshal(data.Transport)
+		payload, err := data.Transport.Serialize(ctx)
 		if err != nil {
-			return nil, core.OpPhaseInitializing, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+			return nil, core.OpPhaseInitializing, err
 		}
 		return nil, core.OpPhaseInitializing, pm.exchange.SendMessage(ctx, op.NamespacedIDString(), data.Node.Profile, localNode.Profile, payload)
 
@@ -163,17 +177,41 @@ func (pm *privateMessaging) RunOperation(ctx context.Context, op *core.PreparedO
 	}
 }
 
+// getTransferResumeOffset returns the offset that a retry of a blob transfer can resume from,
+// using the progress reported by the data exchange plugin on the operation being retried
+func (pm *privateMessaging) getTransferResumeOffset(ctx context.Context, op *core.Operation) (int64, error) {
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	retried, _, err := pm.database.GetOperations(ctx, pm.namespace.Name, fb.And(fb.Eq("retry", op.ID)).Limit(1))
+	if err != nil || len(retried) == 0 || retried[0].Output[dataexchange.TransferProgressKey] == nil {
+		return 0, err
+	}
+	var progress dataexchange.TransferProgress
+	b, _ := json.Marshal(retried[0].Output[dataexchange.TransferProgressKey])
+	if err := json.Unmarshal(b, &progress); err != nil {
+		log.L(ctx).Warnf("Ignoring invalid progress of blob transfer %s: %s", retried[0].ID, err)
+		return 0, nil
+	}
+	if progress.ResumeOffset > 0 {
+		log.L(ctx).Infof("Resuming blob transfer %s from offset %d of retried transfer %s", op.ID, progress.ResumeOffset, retried[0].ID)
+	}
+	return progress.ResumeOffset, nil
+}
+
 func (pm *privateMessaging) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
+	// Blob transfers hold their slot in the transfer limits until data exchange reports the outcome
+	if op.Type == core.OpTypeDataExchangeSendBlob && (update.Status == core.OpStatusSucceeded || update.Status == core.OpStatusFailed) {
+		pm.transfers.release(op.ID)
+	}
 	return nil
 }
 
-func opSendBlob(op *core.Operation, node *core.Identity, blob *core.Blob) *core.PreparedOperation {
+func opSendBlob(op *core.Operation, node *core.Identity, blob *core.Blob, offset int64) *core.PreparedOperation {
 	return &core.PreparedOperation{
 		ID:        op.ID,
 		Namespace: op.Namespace,
 		Plugin:    op.Plugin,
 		Type:      op.Type,
-		Data:      transferBlobData{Node: node, Blob: blob},
+		Data:      transferBlobData{Node: node, Blob: blob, Offset: offset},
 	}
 }
 
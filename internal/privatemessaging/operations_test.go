@@ -18,8 +18,10 @@ package privatemessaging
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
@@ -73,13 +75,16 @@ func TestPrepareAndRunTransferBlob(t *testing.T) {
 	mim := pm.identity.(*identitymanagermocks.Manager)
 	mim.On("CachedIdentityLookupByID", context.Background(), mock.Anything).Return(node, nil)
 	mdi.On("GetBlobs", context.Background(), "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdi.On("GetOperations", context.Background(), "ns1", mock.Anything).Return([]*core.Operation{}, nil, nil)
 	mim.On("GetLocalNode", context.Background()).Return(localNode, nil)
-	mdx.On("TransferBlob", context.Background(), "ns1:"+op.ID.String(), node.Profile, localNode.Profile, "payload").Return(nil)
+	mdx.On("GetPeerID", node.Profile).Return("peer1")
+	mdx.On("TransferBlob", context.Background(), "ns1:"+op.ID.String(), node.Profile, localNode.Profile, "payload", int64(0)).Return(nil)
 
 	po, err := pm.PrepareOperation(context.Background(), op)
 	assert.NoError(t, err)
 	assert.Equal(t, node, po.Data.(transferBlobData).Node)
 	assert.Equal(t, blob, po.Data.(transferBlobData).Blob)
+	assert.Zero(t, po.Data.(transferBlobData).Offset)
 
 	_, phase, err := pm.RunOperation(context.Background(), po)
 
@@ -91,6 +96,136 @@ func TestPrepareAndRunTransferBlob(t *testing.T) {
 	mim.AssertExpectations(t)
 }
 
+func TestPrepareAndRunTransferBlobResume(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+	dataID := fftypes.NewUUID()
+
+	op := &core.Operation{
+		Type:      core.OpTypeDataExchangeSendBlob,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	node := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID: fftypes.NewUUID(),
+		},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{
+				"id": "peer1",
+			},
+		},
+	}
+	localNode := &core.Identity{
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{
+				"id": "local1",
+			},
+		},
+	}
+	blob := &core.Blob{
+		Namespace:  "ns1",
+		Hash:       fftypes.NewRandB32(),
+		PayloadRef: "payload",
+		DataID:     dataID,
+	}
+	addTransferBlobInputs(op, node.ID, blob.Hash, dataID)
+	retried := &core.Operation{
+		ID:    fftypes.NewUUID(),
+		Retry: op.ID,
+		Output: fftypes.JSONObject{
+			"progress": map[string]interface{}{
+				"bytesSent":    float64(3000),
+				"chunksAcked":  float64(2),
+				"totalBytes":   float64(5000),
+				"resumeOffset": float64(2048),
+			},
+		},
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdx := pm.exchange.(*dataexchangemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", context.Background(), mock.Anything).Return(node, nil)
+	mdi.On("GetBlobs", context.Background(), "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdi.On("GetOperations", context.Background(), "ns1", mock.MatchedBy(func(filter ffapi.Filter) bool {
+		f, _ := filter.Finalize()
+		return strings.Contains(f.String(), "retry == '"+op.ID.String()+"'")
+	})).Return([]*core.Operation{retried}, nil, nil)
+	mim.On("GetLocalNode", context.Background()).Return(localNode, nil)
+	mdx.On("GetPeerID", node.Profile).Return("peer1")
+	mdx.On("TransferBlob", context.Background(), "ns1:"+op.ID.String(), node.Profile, localNode.Profile, "payload", int64(2048)).Return(nil)
+
+	po, err := pm.PrepareOperation(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2048), po.Data.(transferBlobData).Offset)
+
+	_, _, err = pm.RunOperation(context.Background(), po)
+	assert.NoError(t, err)
+
+	mdi.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
+func TestPrepareTransferBlobResumeBadProgress(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op := &core.Operation{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mdi.On("GetOperations", context.Background(), "ns1", mock.Anything).Return([]*core.Operation{{
+		ID: fftypes.NewUUID(),
+		Output: fftypes.JSONObject{
+			"progress": "bad",
+		},
+	}}, nil, nil)
+
+	offset, err := pm.getTransferResumeOffset(context.Background(), op)
+	assert.NoError(t, err)
+	assert.Zero(t, offset)
+
+	mdi.AssertExpectations(t)
+}
+
+func TestPrepareTransferBlobGetRetriedFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+	dataID := fftypes.NewUUID()
+
+	op := &core.Operation{
+		Type:      core.OpTypeDataExchangeSendBlob,
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+	}
+	node := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID: fftypes.NewUUID(),
+		},
+	}
+	blob := &core.Blob{
+		Hash:   fftypes.NewRandB32(),
+		DataID: dataID,
+	}
+	addTransferBlobInputs(op, node.ID, blob.Hash, dataID)
+
+	mdi := pm.database.(*databasemocks.Plugin)
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("CachedIdentityLookupByID", context.Background(), mock.Anything).Return(node, nil)
+	mdi.On("GetBlobs", context.Background(), "ns1", mock.Anything).Return([]*core.Blob{blob}, nil, nil)
+	mdi.On("GetOperations", context.Background(), "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	_, err := pm.PrepareOperation(context.Background(), op)
+	assert.EqualError(t, err, "pop")
+
+	mdi.AssertExpectations(t)
+	mim.AssertExpectations(t)
+}
+
 func TestPrepareAndRunBatchSend(t *testing.T) {
 	pm, cancel := newTestPrivateMessaging(t)
 	defer cancel()
@@ -640,16 +775,89 @@ func TestRunOperationBlobSendNodeFail(t *testing.T) {
 	mim := pm.identity.(*identitymanagermocks.Manager)
 	mim.On("GetLocalNode", context.Background()).Return(nil, fmt.Errorf("pop"))
 
-	_, phase, err := pm.RunOperation(context.Background(), opSendBlob(op, node, &core.Blob{}))
+	_, phase, err := pm.RunOperation(context.Background(), opSendBlob(op, node, &core.Blob{}, 0))
 
 	assert.Equal(t, core.OpPhaseInitializing, phase)
 	assert.EqualError(t, err, "pop")
 }
 
+func TestRunOperationBlobSendTransferFail(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	node := &core.Identity{
+		IdentityBase: core.IdentityBase{
+			ID: fftypes.NewUUID(),
+		},
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{"id": "peer1"},
+		},
+	}
+	localNode := &core.Identity{}
+	blob := &core.Blob{PayloadRef: "payload", Size: 1024}
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", context.Background()).Return(localNode, nil)
+	mdx := pm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", node.Profile).Return("peer1")
+	mdx.On("TransferBlob", context.Background(), "ns1:"+op.ID.String(), node.Profile, localNode.Profile, "payload", int64(0)).Return(fmt.Errorf("pop"))
+
+	_, phase, err := pm.RunOperation(context.Background(), opSendBlob(op, node, blob, 0))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.EqualError(t, err, "pop")
+	assert.Empty(t, pm.transfers.inflight)
+
+	mim.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+}
+
+func TestRunOperationBlobSendThrottleCanceled(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	pm.transfers.setLimits(core.TransferLimits{MaxParallel: 1})
+	pm.transfers.inflight[*fftypes.NewUUID()] = "peer2"
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Namespace: "ns1"}
+	node := &core.Identity{
+		IdentityProfile: core.IdentityProfile{
+			Profile: fftypes.JSONObject{"id": "peer1"},
+		},
+	}
+	mdx := pm.exchange.(*dataexchangemocks.Plugin)
+	mdx.On("GetPeerID", node.Profile).Return("peer1")
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	mim := pm.identity.(*identitymanagermocks.Manager)
+	mim.On("GetLocalNode", ctx).Return(&core.Identity{}, nil)
+	_, phase, err := pm.RunOperation(ctx, opSendBlob(op, node, &core.Blob{}, 0))
+
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+	assert.Regexp(t, "FF00154", err)
+
+	mdx.AssertExpectations(t)
+}
+
 func TestOperationUpdate(t *testing.T) {
 	pm, cancel := newTestPrivateMessaging(t)
 	defer cancel()
-	assert.NoError(t, pm.OnOperationUpdate(context.Background(), nil, nil))
+	assert.NoError(t, pm.OnOperationUpdate(context.Background(), &core.Operation{Type: core.OpTypeDataExchangeSendBatch}, &core.OperationUpdate{Status: core.OpStatusSucceeded}))
+}
+
+func TestOperationUpdateReleasesBlobTransfer(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	op := &core.Operation{ID: fftypes.NewUUID(), Type: core.OpTypeDataExchangeSendBlob}
+	assert.NoError(t, pm.transfers.acquire(context.Background(), op.ID, "peer1", 1024))
+
+	assert.NoError(t, pm.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{Status: core.OpStatusPending}))
+	assert.Len(t, pm.transfers.inflight, 1)
+
+	assert.NoError(t, pm.OnOperationUpdate(context.Background(), op, &core.OperationUpdate{Status: core.OpStatusFailed}))
+	assert.Empty(t, pm.transfers.inflight)
 }
 
 func TestRetrieveBSendBlobInputs(t *testing.T) {
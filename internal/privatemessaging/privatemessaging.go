@@ -51,6 +51,9 @@ type Manager interface {
 	NewMessage(msg *core.MessageInOut) syncasync.Sender
 	SendMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (out *core.Message, err error)
 	RequestReply(ctx context.Context, request *core.MessageInOut) (reply *core.MessageInOut, err error)
+	ResolveGroupMembers(ctx context.Context, hash string) ([]*core.ResolvedGroupMember, error)
+	GetTransferLimits(ctx context.Context) *core.TransferLimits
+	SetTransferLimits(ctx context.Context, limits *core.TransferLimits) (*core.TransferLimits, error)
 
 	// From operations.OperationHandler
 	PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error)
@@ -74,6 +77,7 @@ type privateMessaging struct {
 	metrics               metrics.Manager
 	operations            operations.Manager
 	orgFirstNodes         map[string]*core.Identity
+	transfers             *transferThrottle
 }
 
 type blobTransferTracker struct {
@@ -112,6 +116,12 @@ func NewPrivateMessaging(ctx context.Context, ns *core.Namespace, di database.Pl
 		metrics:               mm,
 		operations:            om,
 		orgFirstNodes:         make(map[string]*core.Identity),
+		transfers: newTransferThrottle(core.TransferLimits{
+			MaxParallel:           config.GetInt(coreconfig.PrivateMessagingTransfersMaxParallel),
+			MaxParallelPerPeer:    config.GetInt(coreconfig.PrivateMessagingTransfersMaxParallelPerPeer),
+			BytesPerSecond:        config.GetByteSize(coreconfig.PrivateMessagingTransfersBytesPerSecond),
+			BytesPerSecondPerPeer: config.GetByteSize(coreconfig.PrivateMessagingTransfersBytesPerSecondPerPeer),
+		}),
 	}
 
 	groupCache, err := cacheManager.GetCache(
@@ -128,12 +138,19 @@ func NewPrivateMessaging(ctx context.Context, ns *core.Namespace, di database.Pl
 
 	pm.groupManager.groupCache = groupCache
 
+	compression, err := fftypes.FFEnumParseString(ctx, "batchcompression", config.GetString(coreconfig.PrivateMessagingBatchCompression))
+	if err != nil {
+		return nil, err
+	}
+
 	bo := batch.DispatcherOptions{
-		BatchType:      core.BatchTypePrivate,
-		BatchMaxSize:   config.GetUint(coreconfig.PrivateMessagingBatchSize),
-		BatchMaxBytes:  pm.maxBatchPayloadLength,
-		BatchTimeout:   config.GetDuration(coreconfig.PrivateMessagingBatchTimeout),
-		DisposeTimeout: config.GetDuration(coreconfig.PrivateMessagingBatchAgentTimeout),
+		BatchType:        core.BatchTypePrivate,
+		BatchMaxSize:     config.GetUint(coreconfig.PrivateMessagingBatchSize),
+		BatchMaxBytes:    pm.maxBatchPayloadLength,
+		BatchTimeout:     config.GetDuration(coreconfig.PrivateMessagingBatchTimeout),
+		DisposeTimeout:   config.GetDuration(coreconfig.PrivateMessagingBatchAgentTimeout),
+		BatchCompression: compression,
+		TopicAffinity:    config.GetBool(coreconfig.PrivateMessagingBatchTopicAffinity),
 	}
 
 	ba.RegisterDispatcher(pinnedPrivateDispatcherName,
@@ -235,7 +252,7 @@ func (pm *privateMessaging) prepareBlobTransfers(ctx context.Context, data core.
 				operations = append(operations, &blobTransferTracker{
 					dataID:   d.ID,
 					blobHash: blob.Hash,
-					op:       opSendBlob(op, node, blob),
+					op:       opSendBlob(op, node, blob, 0),
 				})
 			}
 		}
@@ -125,6 +125,19 @@ func TestCacheInitFail(t *testing.T) {
 	assert.Equal(t, cacheInitError, err)
 }
 
+func TestBadCompression(t *testing.T) {
+	coreconfig.Reset()
+	config.Set(coreconfig.PrivateMessagingBatchCompression, "lz4")
+
+	ctx := context.Background()
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+
+	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
+	_, err := NewPrivateMessaging(ctx, ns, &databasemocks.Plugin{}, &dataexchangemocks.Plugin{}, &blockchainmocks.Plugin{}, &identitymanagermocks.Manager{}, &batchmocks.Manager{}, &datamocks.Manager{}, &syncasyncmocks.Bridge{}, &multipartymocks.Manager{}, &metricsmocks.Manager{}, &operationmocks.Manager{}, cmi)
+	assert.Regexp(t, "FF00172", err)
+}
+
 func mockRunAsGroupPassthrough(mdi *databasemocks.Plugin) {
 	rag := mdi.On("RunAsGroup", mock.Anything, mock.Anything).Maybe()
 	rag.RunFn = func(a mock.Arguments) {
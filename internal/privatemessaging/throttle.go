@@ -0,0 +1,179 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// transferThrottle limits the blob transfers submitted to data exchange, in total and to each peer,
+// so that a burst of large transfers cannot starve the batches of interactive messages.
+//
+// A transfer holds one of the parallel slots from submission until data exchange reports it has succeeded or failed.
+// The bytes per second limits are applied when transfers start, by delaying each start until the bytes of the
+// transfers started before it are within the rate.
+type transferThrottle struct {
+	mux           sync.Mutex
+	limits        core.TransferLimits
+	inflight      map[fftypes.UUID]string // the peer of each transfer in flight, by operation ID
+	peerInflight  map[string]int
+	nextStart     time.Time
+	peerNextStart map[string]time.Time
+	changed       chan struct{} // closed when a slot is released, or the limits change
+}
+
+func newTransferThrottle(limits core.TransferLimits) *transferThrottle {
+	return &transferThrottle{
+		limits:        limits,
+		inflight:      make(map[fftypes.UUID]string),
+		peerInflight:  make(map[string]int),
+		peerNextStart: make(map[string]time.Time),
+		changed:       make(chan struct{}),
+	}
+}
+
+func (tt *transferThrottle) getLimits() *core.TransferLimits {
+	tt.mux.Lock()
+	defer tt.mux.Unlock()
+	limits := tt.limits
+	return &limits
+}
+
+func (tt *transferThrottle) setLimits(limits core.TransferLimits) {
+	tt.mux.Lock()
+	defer tt.mux.Unlock()
+	tt.limits = limits
+	tt.notifyChanged()
+}
+
+// notifyChanged wakes all the transfers waiting for a slot - must be called with the lock held
+func (tt *transferThrottle) notifyChanged() {
+	close(tt.changed)
+	tt.changed = make(chan struct{})
+}
+
+// hasSlot checks whether another transfer to the peer is within the parallel limits - must be called with the lock held
+func (tt *transferThrottle) hasSlot(peer string) bool {
+	return (tt.limits.MaxParallel <= 0 || len(tt.inflight) < tt.limits.MaxParallel) &&
+		(tt.limits.MaxParallelPerPeer <= 0 || tt.peerInflight[peer] < tt.limits.MaxParallelPerPeer)
+}
+
+func transferDuration(size, bytesPerSecond int64) time.Duration {
+	if size <= 0 {
+		return 0
+	}
+	return time.Duration(float64(size) / float64(bytesPerSecond) * float64(time.Second))
+}
+
+// reserve returns the time a transfer of the given size to the peer can start within the rate limits,
+// and accounts for its bytes in the start time of the transfers that follow - must be called with the lock held
+func (tt *transferThrottle) reserve(peer string, size int64, now time.Time) time.Time {
+	start := now
+	if tt.limits.BytesPerSecond > 0 && tt.nextStart.After(start) {
+		start = tt.nextStart
+	}
+	if tt.limits.BytesPerSecondPerPeer > 0 && tt.peerNextStart[peer].After(start) {
+		start = tt.peerNextStart[peer]
+	}
+	if tt.limits.BytesPerSecond > 0 {
+		tt.nextStart = start.Add(transferDuration(size, tt.limits.BytesPerSecond))
+	}
+	if tt.limits.BytesPerSecondPerPeer > 0 {
+		tt.peerNextStart[peer] = start.Add(transferDuration(size, tt.limits.BytesPerSecondPerPeer))
+	}
+	return start
+}
+
+// acquire waits until a transfer of the given size to the peer is within the limits, and takes a slot for it
+func (tt *transferThrottle) acquire(ctx context.Context, opID *fftypes.UUID, peer string, size int64) error {
+	for {
+		tt.mux.Lock()
+		if _, ok := tt.inflight[*opID]; ok {
+			// A re-submission of a transfer that already holds a slot
+			tt.mux.Unlock()
+			return nil
+		}
+		if tt.hasSlot(peer) {
+			tt.inflight[*opID] = peer
+			tt.peerInflight[peer]++
+			start := tt.reserve(peer, size, time.Now())
+			tt.mux.Unlock()
+			return tt.waitForStart(ctx, opID, start)
+		}
+		changed := tt.changed
+		tt.mux.Unlock()
+
+		log.L(ctx).Debugf("Blob transfer %s to peer '%s' waiting for a slot", opID, peer)
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+		}
+	}
+}
+
+func (tt *transferThrottle) waitForStart(ctx context.Context, opID *fftypes.UUID, start time.Time) error {
+	delay := time.Until(start)
+	if delay <= 0 {
+		return nil
+	}
+	log.L(ctx).Debugf("Blob transfer %s delayed %s by the transfer rate limits", opID, delay)
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		tt.release(opID)
+		return i18n.NewError(ctx, coremsgs.MsgContextCanceled)
+	}
+}
+
+// release frees the slot held by a transfer, if any
+func (tt *transferThrottle) release(opID *fftypes.UUID) {
+	tt.mux.Lock()
+	defer tt.mux.Unlock()
+	peer, ok := tt.inflight[*opID]
+	if !ok {
+		return
+	}
+	delete(tt.inflight, *opID)
+	if tt.peerInflight[peer]--; tt.peerInflight[peer] <= 0 {
+		delete(tt.peerInflight, peer)
+	}
+	tt.notifyChanged()
+}
+
+func (pm *privateMessaging) GetTransferLimits(ctx context.Context) *core.TransferLimits {
+	return pm.transfers.getLimits()
+}
+
+func (pm *privateMessaging) SetTransferLimits(ctx context.Context, limits *core.TransferLimits) (*core.TransferLimits, error) {
+	if limits.MaxParallel < 0 || limits.MaxParallelPerPeer < 0 || limits.BytesPerSecond < 0 || limits.BytesPerSecondPerPeer < 0 {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidTransferLimits)
+	}
+	pm.transfers.setLimits(*limits)
+	log.L(ctx).Infof("Blob transfer limits updated: maxParallel=%d maxParallelPerPeer=%d bytesPerSecond=%d bytesPerSecondPerPeer=%d",
+		limits.MaxParallel, limits.MaxParallelPerPeer, limits.BytesPerSecond, limits.BytesPerSecondPerPeer)
+	return pm.transfers.getLimits(), nil
+}
@@ -0,0 +1,150 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package privatemessaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferThrottleMaxParallel(t *testing.T) {
+	tt := newTransferThrottle(core.TransferLimits{MaxParallel: 1})
+	op1, op2 := fftypes.NewUUID(), fftypes.NewUUID()
+
+	assert.NoError(t, tt.acquire(context.Background(), op1, "peer1", 100))
+	// A re-submission of the same transfer does not need another slot
+	assert.NoError(t, tt.acquire(context.Background(), op1, "peer1", 100))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- tt.acquire(context.Background(), op2, "peer2", 100)
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "acquired beyond the parallel limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	tt.release(op1)
+	assert.NoError(t, <-acquired)
+	assert.Equal(t, map[fftypes.UUID]string{*op2: "peer2"}, tt.inflight)
+
+	tt.release(op2)
+	tt.release(op2)
+	assert.Empty(t, tt.inflight)
+	assert.Empty(t, tt.peerInflight)
+}
+
+func TestTransferThrottleMaxParallelPerPeer(t *testing.T) {
+	tt := newTransferThrottle(core.TransferLimits{MaxParallelPerPeer: 1})
+
+	assert.NoError(t, tt.acquire(context.Background(), fftypes.NewUUID(), "peer1", 100))
+	assert.NoError(t, tt.acquire(context.Background(), fftypes.NewUUID(), "peer2", 100))
+	assert.False(t, tt.hasSlot("peer1"))
+	assert.True(t, tt.hasSlot("peer3"))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- tt.acquire(context.Background(), fftypes.NewUUID(), "peer1", 100)
+	}()
+	select {
+	case <-acquired:
+		assert.Fail(t, "acquired beyond the per-peer parallel limit")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// Raising the limits wakes the waiting transfer
+	tt.setLimits(core.TransferLimits{MaxParallelPerPeer: 2})
+	assert.NoError(t, <-acquired)
+	assert.Equal(t, 2, tt.peerInflight["peer1"])
+}
+
+func TestTransferThrottleWaitSlotCanceled(t *testing.T) {
+	tt := newTransferThrottle(core.TransferLimits{MaxParallel: 1})
+	assert.NoError(t, tt.acquire(context.Background(), fftypes.NewUUID(), "peer1", 100))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := tt.acquire(ctx, fftypes.NewUUID(), "peer1", 100)
+	assert.Regexp(t, "FF00154", err)
+	assert.Len(t, tt.inflight, 1)
+}
+
+func TestTransferThrottleBytesPerSecond(t *testing.T) {
+	tt := newTransferThrottle(core.TransferLimits{BytesPerSecond: 1000, BytesPerSecondPerPeer: 500})
+	now := time.Now()
+
+	assert.Equal(t, now, tt.reserve("peer1", 100, now))
+	assert.Equal(t, now.Add(100*time.Millisecond), tt.nextStart)
+	assert.Equal(t, now.Add(200*time.Millisecond), tt.peerNextStart["peer1"])
+
+	// The next transfer to the same peer waits for the per-peer rate
+	assert.Equal(t, now.Add(200*time.Millisecond), tt.reserve("peer1", 100, now))
+	// A transfer to another peer only waits for the overall rate
+	assert.Equal(t, now.Add(300*time.Millisecond), tt.reserve("peer2", 0, now))
+	assert.Equal(t, now.Add(300*time.Millisecond), tt.nextStart)
+}
+
+func TestTransferThrottleRateDelay(t *testing.T) {
+	tt := newTransferThrottle(core.TransferLimits{BytesPerSecond: 1000})
+
+	assert.NoError(t, tt.acquire(context.Background(), fftypes.NewUUID(), "peer1", 20))
+	start := time.Now()
+	assert.NoError(t, tt.acquire(context.Background(), fftypes.NewUUID(), "peer1", 20))
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
+
+func TestTransferThrottleRateDelayCanceled(t *testing.T) {
+	tt := newTransferThrottle(core.TransferLimits{BytesPerSecond: 1})
+
+	assert.NoError(t, tt.acquire(context.Background(), fftypes.NewUUID(), "peer1", 1000))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	opID := fftypes.NewUUID()
+	err := tt.acquire(ctx, opID, "peer1", 1000)
+	assert.Regexp(t, "FF00154", err)
+	assert.NotContains(t, tt.inflight, *opID)
+}
+
+func TestGetSetTransferLimits(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	assert.Equal(t, &core.TransferLimits{}, pm.GetTransferLimits(context.Background()))
+
+	limits, err := pm.SetTransferLimits(context.Background(), &core.TransferLimits{
+		MaxParallel:    5,
+		BytesPerSecond: 1048576,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, limits.MaxParallel)
+	assert.Equal(t, limits, pm.GetTransferLimits(context.Background()))
+}
+
+func TestSetTransferLimitsInvalid(t *testing.T) {
+	pm, cancel := newTestPrivateMessaging(t)
+	defer cancel()
+
+	_, err := pm.SetTransferLimits(context.Background(), &core.TransferLimits{MaxParallelPerPeer: -1})
+	assert.Regexp(t, "FF10501", err)
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/gorilla/websocket"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// ConfigNoProxy is a list of hosts, domains and CIDR ranges in a plugin's config section that are connected to directly,
+	// overriding the global proxy.noProxy list. The plugin's proxy.url is already a known key of every REST client section
+	ConfigNoProxy = "proxy.noProxy"
+)
+
+// InitConfig adds the proxy override keys to the config section of a plugin client
+func InitConfig(conf config.Section) {
+	conf.AddKnownKey(ConfigNoProxy)
+}
+
+type settings struct {
+	url     *url.URL
+	noProxy []string
+}
+
+// resolve returns the proxy settings for a plugin client, where anything set in the plugin's own config
+// section overrides the global proxy config. Returns nil when no proxy is configured
+func resolve(ctx context.Context, conf config.Section) (*settings, error) {
+	proxyURL := conf.GetString(ffresty.HTTPConfigProxyURL)
+	if proxyURL == "" {
+		proxyURL = config.GetString(coreconfig.ProxyURL)
+	}
+	if proxyURL == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil || u.Host == "" {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidProxyURL, proxyURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgInvalidProxyURL, proxyURL)
+	}
+	noProxy := conf.GetStringSlice(ConfigNoProxy)
+	if len(noProxy) == 0 {
+		noProxy = config.GetStringSlice(coreconfig.ProxyNoProxy)
+	}
+	return &settings{url: u, noProxy: noProxy}, nil
+}
+
+// proxyFunc returns nil for any URL matching the no-proxy list, as well as for localhost and loopback addresses
+func (s *settings) proxyFunc() func(*url.URL) (*url.URL, error) {
+	return (&httpproxy.Config{
+		HTTPProxy:  s.url.String(),
+		HTTPSProxy: s.url.String(),
+		NoProxy:    strings.Join(s.noProxy, ","),
+	}).ProxyFunc()
+}
+
+// NewRESTClient creates the REST client of a plugin, with requests routed through the outbound proxy
+// when one is configured globally or for the plugin
+func NewRESTClient(ctx context.Context, conf config.Section) (*resty.Client, error) {
+	client, err := ffresty.New(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	s, err := resolve(ctx, conf)
+	if err != nil || s == nil {
+		return client, err
+	}
+	transport, ok := client.GetClient().Transport.(*http.Transport)
+	if !ok {
+		return nil, i18n.NewError(ctx, coremsgs.MsgProxyCustomTransport)
+	}
+	proxyFunc := s.proxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	log.L(ctx).Infof("Requests to %s routed through proxy %s", client.BaseURL, s.url.Redacted())
+	return client, nil
+}
+
+// WSFactory creates the websocket clients of a plugin, which dial through the outbound proxy when one is
+// configured globally or for the plugin. The zero value creates clients that connect directly
+type WSFactory struct {
+	settings  *settings
+	newClient func(ctx context.Context, config *wsclient.WSConfig, beforeConnect wsclient.WSPreConnectHandler, afterConnect wsclient.WSPostConnectHandler) (wsclient.WSClient, error)
+}
+
+// NewWSFactory resolves the proxy settings for the websocket clients of a plugin
+func NewWSFactory(ctx context.Context, conf config.Section) (WSFactory, error) {
+	s, err := resolve(ctx, conf)
+	return WSFactory{settings: s, newClient: wsclient.New}, err
+}
+
+// New creates a websocket client in the same way as wsclient.New, with the proxy set on its dialer
+func (f WSFactory) New(ctx context.Context, wsConfig *wsclient.WSConfig, beforeConnect wsclient.WSPreConnectHandler, afterConnect wsclient.WSPostConnectHandler) (wsclient.WSClient, error) {
+	if f.settings == nil {
+		return wsclient.New(ctx, wsConfig, beforeConnect, afterConnect)
+	}
+	client, err := f.newClient(ctx, wsConfig, beforeConnect, afterConnect)
+	if err != nil {
+		return nil, err
+	}
+	dialer, err := getDialer(ctx, client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	f.settings.setWebSocketProxy(dialer, wsConfig.TLSClientConfig)
+	log.L(ctx).Infof("Websocket %s routed through proxy %s", client.URL(), f.settings.url.Redacted())
+	return client, nil
+}
+
+func (s *settings) setWebSocketProxy(dialer *websocket.Dialer, tlsConfig *tls.Config) {
+	proxyFunc := s.proxyFunc()
+	proxyURL := *s.url
+	if proxyURL.Scheme == "https" {
+		// The websocket dialer only speaks plain HTTP to a proxy, so the connection to an https proxy
+		// is wrapped in TLS before the HTTP CONNECT is sent through it
+		proxyURL.Scheme = "http"
+		if proxyURL.Port() == "" {
+			proxyURL.Host = net.JoinHostPort(proxyURL.Hostname(), "443")
+		}
+		netDialer := &net.Dialer{}
+		tlsDialer := &tls.Dialer{NetDialer: netDialer, Config: tlsConfig.Clone()}
+		dialer.NetDialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if addr == proxyURL.Host {
+				return tlsDialer.DialContext(ctx, network, addr)
+			}
+			return netDialer.DialContext(ctx, network, addr)
+		}
+	}
+	dialer.Proxy = func(req *http.Request) (*url.URL, error) {
+		if u, err := proxyFunc(req.URL); u == nil || err != nil {
+			return nil, err
+		}
+		return &proxyURL, nil
+	}
+}
+
+// getDialer returns the dialer of a websocket client, which the client does not expose
+func getDialer(ctx context.Context, client wsclient.WSClient) (*websocket.Dialer, error) {
+	v := reflect.ValueOf(client)
+	if v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Struct {
+		field := v.Elem().FieldByName("wsdialer")
+		if field.IsValid() && field.Type() == reflect.TypeOf(&websocket.Dialer{}) {
+			return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Interface().(*websocket.Dialer), nil // #nosec G103
+		}
+	}
+	return nil, i18n.NewError(ctx, coremsgs.MsgProxyWebSocketClient, client)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchpin
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/sequencer"
+)
+
+// Sequencer orders batches by pinning them to the blockchain, using the FireFly multi-party contract.
+//
+// The blockchain plugin delivers the resulting BatchPinComplete events to its own handler, so there is
+// nothing to start or stop here.
+type Sequencer struct {
+	blockchain blockchain.Plugin
+}
+
+func NewSequencer(bi blockchain.Plugin) *Sequencer {
+	return &Sequencer{blockchain: bi}
+}
+
+// Name is that of the blockchain plugin, so that operation updates from the plugin are matched to the
+// operations submitted through this sequencer
+func (s *Sequencer) Name() string {
+	return s.blockchain.Name()
+}
+
+func (s *Sequencer) SetHandler(handler sequencer.Callbacks) {}
+
+func (s *Sequencer) Start() error {
+	return nil
+}
+
+func (s *Sequencer) WaitStop() {}
+
+func (s *Sequencer) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *blockchain.BatchPin, location *fftypes.JSONAny) error {
+	return s.blockchain.SubmitBatchPin(ctx, nsOpID, networkNamespace, signingKey, batch, location)
+}
@@ -0,0 +1,47 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchpin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/sequencermocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchPinSequencer(t *testing.T) {
+	mbi := &blockchainmocks.Plugin{}
+	s := NewSequencer(mbi)
+
+	batch := &blockchain.BatchPin{BatchID: fftypes.NewUUID()}
+	location := fftypes.JSONAnyPtr(`{"address":"0x12345"}`)
+	mbi.On("Name").Return("ethereum")
+	mbi.On("SubmitBatchPin", context.Background(), "ns1:op1", "ns1", "0x23456", batch, location).Return(nil)
+
+	assert.Equal(t, "ethereum", s.Name())
+	s.SetHandler(&sequencermocks.Callbacks{})
+	assert.NoError(t, s.Start())
+	err := s.SubmitBatchPin(context.Background(), "ns1:op1", "ns1", "0x23456", batch, location)
+	assert.NoError(t, err)
+	s.WaitStop()
+
+	mbi.AssertExpectations(t)
+}
@@ -0,0 +1,223 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbsequencer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/sequencer"
+)
+
+// checkpointLedger distinguishes the position of the sequencer from the checkpoints of blockchain subscriptions
+const checkpointLedger = "sequencer"
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// Sequencer orders batches using a sequence allocated by the database. Every member of the network namespace
+// that reads the same database sees the batches in the same order, without a blockchain transaction for each
+// batch.
+//
+// Sequenced batches are delivered as BatchPinComplete events, with a checkpoint of their position in the
+// sequence, so delivery resumes from the right place after a restart.
+type Sequencer struct {
+	ctx          context.Context
+	cancelCtx    context.CancelFunc
+	namespace    *core.Namespace
+	database     database.Plugin
+	verifierType core.VerifierType
+	options      Options
+	handler      sequencer.Callbacks
+	lastSequence int64
+	newBatches   chan bool
+	done         chan struct{}
+}
+
+func NewSequencer(ctx context.Context, ns *core.Namespace, di database.Plugin, verifierType core.VerifierType, options Options) *Sequencer {
+	s := &Sequencer{
+		namespace:    ns,
+		database:     di,
+		verifierType: verifierType,
+		options:      options,
+		newBatches:   make(chan bool, 1),
+	}
+	s.ctx, s.cancelCtx = context.WithCancel(log.WithLogField(ctx, "role", "dbsequencer"))
+	return s
+}
+
+func (s *Sequencer) Name() string {
+	return "database"
+}
+
+func (s *Sequencer) SetHandler(handler sequencer.Callbacks) {
+	s.handler = handler
+}
+
+func (s *Sequencer) Start() error {
+	checkpoint, err := s.database.GetBlockchainCheckpoint(s.ctx, s.namespace.Name, checkpointLedger, s.namespace.NetworkName)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil {
+		s.lastSequence = checkpoint.BlockNumber
+	}
+	log.L(s.ctx).Infof("Database sequencer starting after sequence %d", s.lastSequence)
+	s.done = make(chan struct{})
+	go s.deliveryLoop()
+	return nil
+}
+
+func (s *Sequencer) WaitStop() {
+	s.cancelCtx()
+	if s.done != nil {
+		<-s.done
+	}
+}
+
+func (s *Sequencer) SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *blockchain.BatchPin, location *fftypes.JSONAny) error {
+	contexts := make([]string, len(batch.Contexts))
+	for i, hash := range batch.Contexts {
+		contexts[i] = hash.String()
+	}
+	sequenced := &core.SequencedBatch{
+		NetworkNamespace: s.namespace.NetworkName,
+		TransactionID:    batch.TransactionID,
+		TransactionType:  batch.TransactionType,
+		BatchID:          batch.BatchID,
+		BatchHash:        batch.BatchHash,
+		PayloadRef:       batch.BatchPayloadRef,
+		Contexts:         contexts,
+		Signer:           signingKey,
+		Created:          fftypes.Now(),
+	}
+	if err := s.database.InsertSequencedBatch(ctx, sequenced); err != nil {
+		return err
+	}
+	log.L(ctx).Infof("Batch %s sequenced at %d", batch.BatchID, sequenced.Sequence)
+
+	// The position in the sequence is final as soon as it is allocated
+	s.handler.OperationUpdate(&core.OperationUpdate{
+		NamespacedOpID: nsOpID,
+		Plugin:         s.Name(),
+		Status:         core.OpStatusSucceeded,
+		Output:         fftypes.JSONObject{"sequence": sequenced.Sequence},
+	})
+	select {
+	case s.newBatches <- true:
+	default:
+	}
+	return nil
+}
+
+func (s *Sequencer) deliveryLoop() {
+	defer close(s.done)
+	for {
+		if err := s.deliverBatches(); err != nil {
+			// The next poll retries from the same position
+			log.L(s.ctx).Warnf("Failed to deliver sequenced batches: %s", err)
+		}
+		select {
+		case <-s.newBatches:
+		case <-time.After(s.options.PollInterval):
+		case <-s.ctx.Done():
+			log.L(s.ctx).Debugf("Database sequencer stopped")
+			return
+		}
+	}
+}
+
+func (s *Sequencer) deliverBatches() error {
+	for {
+		fb := database.SequencedBatchQueryFactory.NewFilter(s.ctx)
+		batches, _, err := s.database.GetSequencedBatches(s.ctx, s.namespace.NetworkName, fb.And(
+			fb.Gt("sequence", s.lastSequence),
+		).Sort("sequence").Limit(uint64(s.options.BatchSize)))
+		if err != nil || len(batches) == 0 {
+			return err
+		}
+
+		events := make([]*blockchain.EventToDispatch, len(batches))
+		for i, batch := range batches {
+			if events[i], err = s.batchPinComplete(batch); err != nil {
+				return err
+			}
+		}
+		if err := s.handler.BlockchainEventBatch(events); err != nil {
+			return err
+		}
+		s.lastSequence = batches[len(batches)-1].Sequence
+
+		if len(batches) < s.options.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *Sequencer) batchPinComplete(batch *core.SequencedBatch) (*blockchain.EventToDispatch, error) {
+	contexts := make([]*fftypes.Bytes32, len(batch.Contexts))
+	for i, hashStr := range batch.Contexts {
+		hash, err := fftypes.ParseBytes32(s.ctx, hashStr)
+		if err != nil {
+			return nil, err
+		}
+		contexts[i] = hash
+	}
+	return &blockchain.EventToDispatch{
+		Type: blockchain.EventTypeBatchPinComplete,
+		BatchPinComplete: &blockchain.BatchPinCompleteEvent{
+			Namespace: s.namespace.Name,
+			Batch: &blockchain.BatchPin{
+				TransactionID:   batch.TransactionID,
+				TransactionType: batch.TransactionType,
+				BatchID:         batch.BatchID,
+				BatchHash:       batch.BatchHash,
+				BatchPayloadRef: batch.PayloadRef,
+				Contexts:        contexts,
+				Event: blockchain.Event{
+					Source:     s.Name(),
+					Name:       "BatchPin",
+					ProtocolID: fmt.Sprintf("%.12d", batch.Sequence),
+					Output:     fftypes.JSONObject{},
+					Info: fftypes.JSONObject{
+						"sequence":         batch.Sequence,
+						"networkNamespace": batch.NetworkNamespace,
+					},
+					Timestamp: batch.Created,
+					Checkpoint: &core.BlockchainCheckpoint{
+						Ledger:        checkpointLedger,
+						Subscription:  batch.NetworkNamespace,
+						BlockNumber:   batch.Sequence,
+						TransactionID: batch.TransactionID.String(),
+					},
+				},
+			},
+			SigningKey: &core.VerifierRef{
+				Type:  s.verifierType,
+				Value: batch.Signer,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,215 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbsequencer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/mocks/sequencermocks"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestSequencer(t *testing.T, batchSize int) (*Sequencer, *databasemocks.Plugin, *sequencermocks.Callbacks) {
+	mdi := &databasemocks.Plugin{}
+	mcb := &sequencermocks.Callbacks{}
+	ns := &core.Namespace{Name: "ns1", NetworkName: "net1"}
+	s := NewSequencer(context.Background(), ns, mdi, core.VerifierTypeEthAddress, Options{
+		PollInterval: 1 * time.Millisecond,
+		BatchSize:    batchSize,
+	})
+	s.SetHandler(mcb)
+	t.Cleanup(func() {
+		s.WaitStop()
+		mdi.AssertExpectations(t)
+		mcb.AssertExpectations(t)
+	})
+	return s, mdi, mcb
+}
+
+func testSequencedBatch(seq int64) *core.SequencedBatch {
+	return &core.SequencedBatch{
+		Sequence:         seq,
+		NetworkNamespace: "net1",
+		TransactionID:    fftypes.NewUUID(),
+		TransactionType:  core.TransactionTypeBatchPin,
+		BatchID:          fftypes.NewUUID(),
+		BatchHash:        fftypes.NewRandB32(),
+		PayloadRef:       "Qm12345",
+		Contexts:         fftypes.NewFFStringArray(fftypes.NewRandB32().String()),
+		Signer:           "0x12345",
+		Created:          fftypes.Now(),
+	}
+}
+
+func TestName(t *testing.T) {
+	s, _, _ := newTestSequencer(t, 10)
+	assert.Equal(t, "database", s.Name())
+}
+
+func TestSubmitBatchPin(t *testing.T) {
+	s, mdi, mcb := newTestSequencer(t, 10)
+
+	batch := &blockchain.BatchPin{
+		TransactionID: fftypes.NewUUID(),
+		BatchID:       fftypes.NewUUID(),
+		BatchHash:     fftypes.NewRandB32(),
+		Contexts:      []*fftypes.Bytes32{fftypes.NewRandB32()},
+	}
+	mdi.On("InsertSequencedBatch", mock.Anything, mock.MatchedBy(func(sb *core.SequencedBatch) bool {
+		return sb.NetworkNamespace == "net1" &&
+			sb.BatchID.Equals(batch.BatchID) &&
+			sb.Contexts[0] == batch.Contexts[0].String() &&
+			sb.Signer == "0x12345"
+	})).Run(func(args mock.Arguments) {
+		args[1].(*core.SequencedBatch).Sequence = 12
+	}).Return(nil)
+	mcb.On("OperationUpdate", mock.MatchedBy(func(update *core.OperationUpdate) bool {
+		return update.NamespacedOpID == "ns1:op1" &&
+			update.Plugin == "database" &&
+			update.Status == core.OpStatusSucceeded &&
+			update.Output.GetInt64("sequence") == 12
+	})).Return()
+
+	err := s.SubmitBatchPin(context.Background(), "ns1:op1", "ns1", "0x12345", batch, nil)
+	assert.NoError(t, err)
+	assert.Len(t, s.newBatches, 1)
+
+	// A second submission does not block on the notification
+	err = s.SubmitBatchPin(context.Background(), "ns1:op1", "ns1", "0x12345", batch, nil)
+	assert.NoError(t, err)
+}
+
+func TestSubmitBatchPinFail(t *testing.T) {
+	s, mdi, _ := newTestSequencer(t, 10)
+
+	mdi.On("InsertSequencedBatch", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := s.SubmitBatchPin(context.Background(), "ns1:op1", "ns1", "0x12345", &blockchain.BatchPin{}, nil)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestStartDeliversFromCheckpoint(t *testing.T) {
+	s, mdi, mcb := newTestSequencer(t, 10)
+
+	batch := testSequencedBatch(6)
+	mdi.On("GetBlockchainCheckpoint", mock.Anything, "ns1", "sequencer", "net1").Return(&core.BlockchainCheckpoint{BlockNumber: 5}, nil)
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.MatchedBy(func(f ffapi.Filter) bool {
+		fi, _ := f.Finalize()
+		return fi.String() == "( sequence >> 5 ) sort=sequence limit=10"
+	})).Return([]*core.SequencedBatch{batch}, nil, nil).Once()
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return([]*core.SequencedBatch{}, nil, nil)
+	delivered := make(chan []*blockchain.EventToDispatch)
+	mcb.On("BlockchainEventBatch", mock.Anything).Run(func(args mock.Arguments) {
+		delivered <- args[0].([]*blockchain.EventToDispatch)
+	}).Return(nil).Once()
+
+	err := s.Start()
+	assert.NoError(t, err)
+	events := <-delivered
+	s.WaitStop()
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, blockchain.EventTypeBatchPinComplete, events[0].Type)
+	event := events[0].BatchPinComplete
+	assert.Equal(t, "ns1", event.Namespace)
+	assert.Equal(t, batch.BatchID, event.Batch.BatchID)
+	assert.Equal(t, batch.Contexts[0], event.Batch.Contexts[0].String())
+	assert.Equal(t, "000000000006", event.Batch.Event.ProtocolID)
+	assert.Equal(t, int64(6), event.Batch.Event.Checkpoint.BlockNumber)
+	assert.Equal(t, "net1", event.Batch.Event.Checkpoint.Subscription)
+	assert.Equal(t, &core.VerifierRef{Type: core.VerifierTypeEthAddress, Value: "0x12345"}, event.SigningKey)
+	assert.Equal(t, int64(6), s.lastSequence)
+}
+
+func TestStartCheckpointFail(t *testing.T) {
+	s, mdi, _ := newTestSequencer(t, 10)
+
+	mdi.On("GetBlockchainCheckpoint", mock.Anything, "ns1", "sequencer", "net1").Return(nil, fmt.Errorf("pop"))
+
+	err := s.Start()
+	assert.EqualError(t, err, "pop")
+}
+
+func TestDeliverBatchesPages(t *testing.T) {
+	s, mdi, mcb := newTestSequencer(t, 1)
+
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return([]*core.SequencedBatch{testSequencedBatch(1)}, nil, nil).Once()
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return([]*core.SequencedBatch{testSequencedBatch(2)}, nil, nil).Once()
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return([]*core.SequencedBatch{}, nil, nil).Once()
+	mcb.On("BlockchainEventBatch", mock.Anything).Return(nil).Twice()
+
+	err := s.deliverBatches()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), s.lastSequence)
+}
+
+func TestDeliverBatchesQueryFail(t *testing.T) {
+	s, mdi, _ := newTestSequencer(t, 10)
+
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return(nil, nil, fmt.Errorf("pop"))
+
+	err := s.deliverBatches()
+	assert.EqualError(t, err, "pop")
+}
+
+func TestDeliverBatchesBadContext(t *testing.T) {
+	s, mdi, _ := newTestSequencer(t, 10)
+
+	batch := testSequencedBatch(1)
+	batch.Contexts = fftypes.NewFFStringArray("!wrong")
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return([]*core.SequencedBatch{batch}, nil, nil)
+
+	err := s.deliverBatches()
+	assert.Regexp(t, "FF00107", err)
+	assert.Equal(t, int64(0), s.lastSequence)
+}
+
+func TestDeliverBatchesHandlerFail(t *testing.T) {
+	s, mdi, mcb := newTestSequencer(t, 10)
+
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return([]*core.SequencedBatch{testSequencedBatch(1)}, nil, nil)
+	mcb.On("BlockchainEventBatch", mock.Anything).Return(fmt.Errorf("pop"))
+
+	err := s.deliverBatches()
+	assert.EqualError(t, err, "pop")
+	assert.Equal(t, int64(0), s.lastSequence)
+}
+
+func TestDeliveryLoopRetriesAndWakes(t *testing.T) {
+	s, mdi, _ := newTestSequencer(t, 10)
+
+	polled := make(chan bool, 3)
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mdi.On("GetSequencedBatches", mock.Anything, "net1", mock.Anything).Run(func(args mock.Arguments) {
+		polled <- true
+	}).Return([]*core.SequencedBatch{}, nil, nil)
+
+	s.options.PollInterval = 1 * time.Hour
+	s.newBatches <- true
+	s.done = make(chan struct{})
+	go s.deliveryLoop()
+	<-polled
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqfactory
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/sequencer/batchpin"
+	"github.com/hyperledger/firefly/internal/sequencer/dbsequencer"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+	"github.com/hyperledger/firefly/pkg/sequencer"
+)
+
+const (
+	// TypeBlockchain pins each batch to the blockchain, using the FireFly multi-party contract
+	TypeBlockchain = "blockchain"
+	// TypeDatabase orders batches using a sequence allocated by the database
+	TypeDatabase = "database"
+)
+
+// Config selects the sequencer for a namespace
+type Config struct {
+	Type         string
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+func CheckType(ctx context.Context, sequencerType string) error {
+	switch sequencerType {
+	case TypeBlockchain, TypeDatabase:
+		return nil
+	default:
+		return i18n.NewError(ctx, coremsgs.MsgUnknownSequencer, sequencerType)
+	}
+}
+
+func NewSequencer(ctx context.Context, ns *core.Namespace, config Config, di database.Plugin, bi blockchain.Plugin) (sequencer.Plugin, error) {
+	switch config.Type {
+	case "", TypeBlockchain:
+		return batchpin.NewSequencer(bi), nil
+	case TypeDatabase:
+		return dbsequencer.NewSequencer(ctx, ns, di, bi.VerifierType(), dbsequencer.Options{
+			PollInterval: config.PollInterval,
+			BatchSize:    config.BatchSize,
+		}), nil
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgUnknownSequencer, config.Type)
+	}
+}
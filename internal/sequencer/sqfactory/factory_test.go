@@ -0,0 +1,59 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqfactory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly/internal/sequencer/batchpin"
+	"github.com/hyperledger/firefly/internal/sequencer/dbsequencer"
+	"github.com/hyperledger/firefly/mocks/blockchainmocks"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckType(t *testing.T) {
+	assert.NoError(t, CheckType(context.Background(), TypeBlockchain))
+	assert.NoError(t, CheckType(context.Background(), TypeDatabase))
+	assert.Regexp(t, "FF10527.*raft", CheckType(context.Background(), "raft"))
+}
+
+func TestNewSequencerBlockchain(t *testing.T) {
+	s, err := NewSequencer(context.Background(), &core.Namespace{Name: "ns1"}, Config{}, &databasemocks.Plugin{}, &blockchainmocks.Plugin{})
+	assert.NoError(t, err)
+	assert.IsType(t, &batchpin.Sequencer{}, s)
+}
+
+func TestNewSequencerDatabase(t *testing.T) {
+	mbi := &blockchainmocks.Plugin{}
+	mbi.On("VerifierType").Return(core.VerifierTypeEthAddress)
+	s, err := NewSequencer(context.Background(), &core.Namespace{Name: "ns1"}, Config{
+		Type:         TypeDatabase,
+		PollInterval: time.Second,
+		BatchSize:    50,
+	}, &databasemocks.Plugin{}, mbi)
+	assert.NoError(t, err)
+	assert.IsType(t, &dbsequencer.Sequencer{}, s)
+}
+
+func TestNewSequencerUnknown(t *testing.T) {
+	_, err := NewSequencer(context.Background(), &core.Namespace{Name: "ns1"}, Config{Type: "raft"}, &databasemocks.Plugin{}, &blockchainmocks.Plugin{})
+	assert.Regexp(t, "FF10527", err)
+}
@@ -39,15 +39,31 @@ type Manager interface {
 	Start() error
 	WaitStop()
 
-	InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error
-	InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, payloadRef string, idempotentSubmit bool) error
+	InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, batchID *fftypes.UUID, batchHash *fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error
+	InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, hash *fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error
+}
+
+// Priorities determines the order in which queued downloads are dispatched to the workers.
+// Downloads with a higher priority are dispatched first.
+type Priorities struct {
+	Batch int
+	Blob  int
+}
+
+func (p *Priorities) forType(opType core.OpType) int {
+	if opType == core.OpTypeSharedStorageDownloadBatch {
+		return p.Batch
+	}
+	return p.Blob
 }
 
 // downloadManager operates a number of workers that can perform downloads/retries. Each download
 // will stay in pending state until a number of retries has been executed against, but each retry
 // will be dispatched individually to the workers. So a retrying downloads do not block new
 // downloads from getting a chance to use the workers.
-// Pending download operations are recovered on startup, and start a new retry loop.
+// Work is queued by priority, so (by default) batches are downloaded ahead of blobs.
+// Pending download operations are recovered on startup, and continue their retry loop from the
+// number of attempts recorded against the operation.
 type downloadManager struct {
 	ctx                        context.Context
 	cancelFunc                 func()
@@ -59,7 +75,8 @@ type downloadManager struct {
 	callbacks                  Callbacks
 	workerCount                int
 	workers                    []*downloadWorker
-	work                       chan *downloadWork
+	queue                      *downloadQueue
+	priorities                 Priorities
 	recoveryComplete           chan struct{}
 	broadcastBatchPayloadLimit int64
 	retryMaxAttempts           int
@@ -80,7 +97,7 @@ type Callbacks interface {
 	SharedStorageBlobDownloaded(hash fftypes.Bytes32, size int64, payloadRef string, dataID *fftypes.UUID) error
 }
 
-func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plugin, ss sharedstorage.Plugin, dx dataexchange.Plugin, om operations.Manager, cb Callbacks) (Manager, error) {
+func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plugin, ss sharedstorage.Plugin, dx dataexchange.Plugin, om operations.Manager, cb Callbacks, priorities Priorities) (Manager, error) {
 	if di == nil || dx == nil || ss == nil || cb == nil {
 		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "DownloadManager")
 	}
@@ -95,6 +112,7 @@ func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plu
 		dataexchange:               dx,
 		operations:                 om,
 		callbacks:                  cb,
+		priorities:                 priorities,
 		broadcastBatchPayloadLimit: config.GetByteSize(coreconfig.BroadcastBatchPayloadLimit),
 		workerCount:                config.GetInt(coreconfig.DownloadWorkerCount),
 		retryMaxAttempts:           config.GetInt(coreconfig.DownloadRetryMaxAttempts),
@@ -110,7 +128,11 @@ func NewDownloadManager(ctx context.Context, ns *core.Namespace, di database.Plu
 	if dm.retryMaxAttempts <= 0 {
 		dm.retryMaxAttempts = 1
 	}
-	dm.work = make(chan *downloadWork, workQueueLength)
+	dm.queue = newDownloadQueue(workQueueLength)
+	go func() {
+		<-dmCtx.Done()
+		dm.queue.close()
+	}()
 
 	dm.operations.RegisterHandler(ctx, dm, []core.OpType{
 		core.OpTypeSharedStorageDownloadBatch,
@@ -196,10 +218,16 @@ func (dm *downloadManager) recoverDownloads(startupTime *fftypes.FFTime) {
 				continue
 			}
 			recovered++
-			log.L(dm.ctx).Infof("Recovering pending download %s/%s", op.Type, op.ID)
+			// Continue from the attempts recorded before the restart, but always allow at least one more
+			attempts := int(op.Output.GetInt64("attempts"))
+			if attempts >= dm.retryMaxAttempts {
+				attempts = dm.retryMaxAttempts - 1
+			}
+			log.L(dm.ctx).Infof("Recovering pending download %s/%s (attempts=%d)", op.Type, op.ID, attempts)
 			dm.dispatchWork(&downloadWork{
 				dispatchedAt: time.Now(),
 				preparedOp:   preparedOp,
+				attempts:     attempts,
 			})
 		}
 	}
@@ -207,9 +235,28 @@ func (dm *downloadManager) recoverDownloads(startupTime *fftypes.FFTime) {
 }
 
 func (dm *downloadManager) dispatchWork(work *downloadWork) {
-	dm.work <- work
+	// Capture what we log before dispatching, as a worker might pick up the work immediately
+	op, attempts := work.preparedOp, work.attempts
+	priority := dm.priorities.forType(op.Type)
+	if !dm.queue.push(work, priority) {
+		log.L(dm.ctx).Debugf("Download operation %s/%s not dispatched as the download manager is stopping", op.Type, op.ID)
+		return
+	}
 	// Log after dispatching so we can see the dispatch delay if the queue got full
-	log.L(dm.ctx).Debugf("Dispatched download operation %s/%s (attempts=%d) to worker pool", work.preparedOp.Type, work.preparedOp.ID, work.attempts)
+	log.L(dm.ctx).Debugf("Dispatched download operation %s/%s (attempts=%d,priority=%d) to worker pool", op.Type, op.ID, attempts, priority)
+}
+
+// recordAttempts stores the number of attempts made so far against a pending download operation,
+// so the retry loop can continue from where it left off if the node restarts
+func (dm *downloadManager) recordAttempts(ctx context.Context, work *downloadWork) {
+	fb := database.OperationQueryFactory.NewFilter(ctx)
+	filter := fb.Neq("status", core.OpStatusSucceeded)
+	update := database.OperationQueryFactory.NewUpdate(ctx).Set("output", fftypes.JSONObject{
+		"attempts": work.attempts,
+	})
+	if _, err := dm.database.UpdateOperation(ctx, dm.namespace.Name, work.preparedOp.ID, filter, update); err != nil {
+		log.L(ctx).Warnf("Failed to record attempts for download operation %s/%s: %s", work.preparedOp.Type, work.preparedOp.ID, err)
+	}
 }
 
 // waitAndRetryDownload is a go routine to wait and re-dispatch a retrying download.
@@ -225,16 +272,16 @@ func (dm *downloadManager) waitAndRetryDownload(work *downloadWork) {
 	dm.dispatchWork(work)
 }
 
-func (dm *downloadManager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
+func (dm *downloadManager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, batchID *fftypes.UUID, batchHash *fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
 	op := core.NewOperation(dm.sharedstorage, dm.namespace.Name, tx, core.OpTypeSharedStorageDownloadBatch)
-	addDownloadBatchInputs(op, payloadRef)
-	return dm.createAndDispatchOp(ctx, op, opDownloadBatch(op, payloadRef), idempotentSubmit)
+	addDownloadBatchInputs(op, batchID, batchHash, payloadRef)
+	return dm.createAndDispatchOp(ctx, op, opDownloadBatch(op, batchID, batchHash, payloadRef), idempotentSubmit)
 }
 
-func (dm *downloadManager) InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
+func (dm *downloadManager) InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, hash *fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
 	op := core.NewOperation(dm.sharedstorage, dm.namespace.Name, tx, core.OpTypeSharedStorageDownloadBlob)
-	addDownloadBlobInputs(op, dataID, payloadRef)
-	return dm.createAndDispatchOp(ctx, op, opDownloadBlob(op, dataID, payloadRef), idempotentSubmit)
+	addDownloadBlobInputs(op, dataID, hash, payloadRef)
+	return dm.createAndDispatchOp(ctx, op, opDownloadBlob(op, dataID, hash, payloadRef), idempotentSubmit)
 }
 
 func (dm *downloadManager) createAndDispatchOp(ctx context.Context, op *core.Operation, preparedOp *core.PreparedOperation, idempotentSubmit bool) error {
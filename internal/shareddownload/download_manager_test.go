@@ -56,14 +56,14 @@ func newTestDownloadManager(t *testing.T) (*downloadManager, func()) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	ns := &core.Namespace{Name: "ns1", NetworkName: "ns1"}
-	pm, err := NewDownloadManager(ctx, ns, mdi, mss, mdx, mom, mci)
+	pm, err := NewDownloadManager(ctx, ns, mdi, mss, mdx, mom, mci, Priorities{Batch: 10})
 	assert.NoError(t, err)
 
 	return pm.(*downloadManager), cancel
 }
 
 func TestNewDownloadManagerMissingDeps(t *testing.T) {
-	_, err := NewDownloadManager(context.Background(), &core.Namespace{}, nil, nil, nil, nil, nil)
+	_, err := NewDownloadManager(context.Background(), &core.Namespace{}, nil, nil, nil, nil, nil, Priorities{})
 	assert.Regexp(t, "FF10128", err)
 }
 
@@ -79,9 +79,11 @@ func TestDownloadBatchE2EOk(t *testing.T) {
 	dm.workerCount = 1
 	dm.workers = []*downloadWorker{newDownloadWorker(dm, 0)}
 
-	reader := ioutil.NopCloser(strings.NewReader("some batch data"))
 	txID := fftypes.NewUUID()
 	batchID := fftypes.NewUUID()
+	batchHash := fftypes.NewRandB32()
+	batchData := fmt.Sprintf(`{"id":"%s","hash":"%s"}`, batchID, batchHash)
+	reader := ioutil.NopCloser(strings.NewReader(batchData))
 
 	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
 	mss.On("Name").Return("utss")
@@ -100,6 +102,7 @@ func TestDownloadBatchE2EOk(t *testing.T) {
 		assert.Equal(t, core.OpTypeSharedStorageDownloadBatch, op.Type)
 		assert.Equal(t, "ns1", op.Namespace)
 		assert.Equal(t, "ref1", op.Data.(downloadBatchData).PayloadRef)
+		assert.Equal(t, batchHash, op.Data.(downloadBatchData).BatchHash)
 		return true
 	}), mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
 		output, phase, err := dm.RunOperation(args[0].(context.Context), args[1].(*core.PreparedOperation))
@@ -110,9 +113,9 @@ func TestDownloadBatchE2EOk(t *testing.T) {
 	})
 
 	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
-	mci.On("SharedStorageBatchDownloaded", "ref1", []byte("some batch data")).Return(batchID, nil)
+	mci.On("SharedStorageBatchDownloaded", "ref1", []byte(batchData)).Return(batchID, nil)
 
-	err := dm.InitiateDownloadBatch(dm.ctx, txID, "ref1", false)
+	err := dm.InitiateDownloadBatch(dm.ctx, txID, batchID, batchHash, "ref1", false)
 	assert.NoError(t, err)
 
 	<-called
@@ -144,6 +147,13 @@ func TestDownloadBlobWithRetryOk(t *testing.T) {
 
 	mdx := dm.dataexchange.(*dataexchangemocks.Plugin)
 	mdx.On("UploadBlob", mock.Anything, "ns1", *dataID, mock.Anything).Return("", nil, int64(-1), fmt.Errorf("pop")).Twice()
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("UpdateOperation", mock.Anything, "ns1", mock.Anything, mock.Anything, mock.MatchedBy(func(update ffapi.Update) bool {
+		info, _ := update.Finalize()
+		return info.SetOperations[0].Field == "output"
+	})).Return(true, nil).Once()
+	mdi.On("UpdateOperation", mock.Anything, "ns1", mock.Anything, mock.Anything, mock.Anything).Return(false, fmt.Errorf("pop")).Once()
 	mdx.On("UploadBlob", mock.Anything, "ns1", *dataID, mock.Anything).Return("privateRef1", blobHash, int64(12345), nil)
 
 	called := make(chan struct{})
@@ -183,13 +193,14 @@ func TestDownloadBlobWithRetryOk(t *testing.T) {
 	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
 	mci.On("SharedStorageBlobDownloaded", *blobHash, int64(12345), "privateRef1", dataID).Return(nil)
 
-	err := dm.InitiateDownloadBlob(dm.ctx, txID, dataID, "ref1", false)
+	err := dm.InitiateDownloadBlob(dm.ctx, txID, dataID, blobHash, "ref1", false)
 	assert.NoError(t, err)
 
 	<-called
 
 	mss.AssertExpectations(t)
 	mdx.AssertExpectations(t)
+	mdi.AssertExpectations(t)
 	mci.AssertExpectations(t)
 	mom.AssertExpectations(t)
 
@@ -209,7 +220,7 @@ func TestDownloadBlobInsertOpFail(t *testing.T) {
 	mom := dm.operations.(*operationmocks.Manager)
 	mom.On("AddOrReuseOperation", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
 
-	err := dm.InitiateDownloadBlob(dm.ctx, txID, dataID, "ref1", false)
+	err := dm.InitiateDownloadBlob(dm.ctx, txID, dataID, fftypes.NewRandB32(), "ref1", false)
 	assert.Regexp(t, "pop", err)
 
 	mom.AssertExpectations(t)
@@ -221,6 +232,7 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 	dm, cancel := newTestDownloadManager(t)
 	defer cancel()
 	dm.workerCount = 1
+	dm.retryMaxAttempts = 3
 	dm.retryInitDelay = 1 * time.Microsecond
 	dm.workers = []*downloadWorker{newDownloadWorker(dm, 0)}
 
@@ -250,7 +262,7 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 			},
 		},
 		{
-			// This one will be re-submitted and be marked failed
+			// This one will be re-submitted and be marked failed, having used all its attempts before the restart
 			Type:      core.OpTypeSharedStorageDownloadBlob,
 			ID:        fftypes.NewUUID(),
 			Namespace: "ns1",
@@ -259,6 +271,9 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 				"dataId":     fftypes.NewUUID().String(),
 				"payloadRef": "ref1",
 			},
+			Output: fftypes.JSONObject{
+				"attempts": 5,
+			},
 		},
 		{
 			// This one will be re-submitted and succeed
@@ -283,7 +298,6 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 	}), mock.Anything).Return(nil, fmt.Errorf("pop")).Run(func(args mock.Arguments) {
 		_, _, err := dm.RunOperation(args[0].(context.Context), args[1].(*core.PreparedOperation))
 		assert.EqualError(t, err, "pop")
-		called <- true
 	})
 	mom.On("RunOperation", mock.Anything, mock.MatchedBy(func(op *core.PreparedOperation) bool {
 		return op.Type == core.OpTypeSharedStorageDownloadBatch && op.Data.(downloadBatchData).PayloadRef == "ref2"
@@ -296,7 +310,10 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 		assert.Equal(t, core.OpPhaseComplete, phase)
 		called <- true
 	})
-	mom.On("SubmitOperationUpdate", mock.Anything).Return(nil)
+	mom.On("SubmitOperationUpdate", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		assert.Equal(t, core.OpStatusFailed, args[0].(*core.OperationUpdate).Status)
+		called <- true
+	})
 
 	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
 	mci.On("SharedStorageBatchDownloaded", "ref2", []byte("some batch data")).Return(batchID, nil)
@@ -315,6 +332,19 @@ func TestDownloadManagerStartupRecoveryCombinations(t *testing.T) {
 
 }
 
+func TestDispatchWorkAfterStop(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	cancel()
+	dm.queue.close()
+
+	dm.dispatchWork(&downloadWork{
+		preparedOp: &core.PreparedOperation{Type: core.OpTypeSharedStorageDownloadBlob},
+	})
+	assert.Nil(t, dm.queue.pop())
+
+}
+
 func TestPrepareOperationUnknown(t *testing.T) {
 
 	dm, cancel := newTestDownloadManager(t)
@@ -0,0 +1,108 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shareddownload
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// downloadQueue is a bounded priority queue of downloads waiting for a worker. Higher priority
+// work is dispatched first, and work of equal priority is dispatched in the order it was queued.
+// Adding to a full queue blocks until a worker takes an item, or the queue is closed.
+type downloadQueue struct {
+	mux     sync.Mutex
+	changed *sync.Cond
+	items   downloadHeap
+	maxLen  int
+	nextSeq int64
+	closed  bool
+}
+
+type queuedDownload struct {
+	work     *downloadWork
+	priority int
+	seq      int64
+}
+
+type downloadHeap []*queuedDownload
+
+func (h downloadHeap) Len() int { return len(h) }
+
+func (h downloadHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h downloadHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *downloadHeap) Push(x interface{}) { *h = append(*h, x.(*queuedDownload)) }
+
+func (h *downloadHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+func newDownloadQueue(maxLen int) *downloadQueue {
+	q := &downloadQueue{maxLen: maxLen}
+	q.changed = sync.NewCond(&q.mux)
+	return q
+}
+
+// push adds work to the queue, returning false if the queue was closed before there was space
+func (q *downloadQueue) push(work *downloadWork, priority int) bool {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	for !q.closed && len(q.items) >= q.maxLen {
+		q.changed.Wait()
+	}
+	if q.closed {
+		return false
+	}
+	heap.Push(&q.items, &queuedDownload{work: work, priority: priority, seq: q.nextSeq})
+	q.nextSeq++
+	q.changed.Broadcast()
+	return true
+}
+
+// pop blocks until there is work in the queue, returning nil if the queue is closed
+func (q *downloadQueue) pop() *downloadWork {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	for !q.closed && len(q.items) == 0 {
+		q.changed.Wait()
+	}
+	if q.closed {
+		return nil
+	}
+	item := heap.Pop(&q.items).(*queuedDownload)
+	q.changed.Broadcast()
+	return item.work
+}
+
+func (q *downloadQueue) close() {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+	q.closed = true
+	q.changed.Broadcast()
+}
@@ -0,0 +1,88 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shareddownload
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadQueuePriorityOrder(t *testing.T) {
+	q := newDownloadQueue(10)
+
+	blob1 := &downloadWork{attempts: 1}
+	blob2 := &downloadWork{attempts: 2}
+	batch1 := &downloadWork{attempts: 3}
+	batch2 := &downloadWork{attempts: 4}
+	assert.True(t, q.push(blob1, 0))
+	assert.True(t, q.push(batch1, 10))
+	assert.True(t, q.push(blob2, 0))
+	assert.True(t, q.push(batch2, 10))
+
+	assert.Equal(t, batch1, q.pop())
+	assert.Equal(t, batch2, q.pop())
+	assert.Equal(t, blob1, q.pop())
+	assert.Equal(t, blob2, q.pop())
+}
+
+func TestDownloadQueueFullBlocksUntilPop(t *testing.T) {
+	q := newDownloadQueue(1)
+
+	first := &downloadWork{attempts: 1}
+	second := &downloadWork{attempts: 2}
+	assert.True(t, q.push(first, 0))
+
+	pushed := make(chan bool)
+	go func() {
+		pushed <- q.push(second, 0)
+	}()
+
+	assert.Equal(t, first, q.pop())
+	assert.True(t, <-pushed)
+	assert.Equal(t, second, q.pop())
+}
+
+func TestDownloadQueueCloseUnblocks(t *testing.T) {
+	q := newDownloadQueue(1)
+	assert.True(t, q.push(&downloadWork{}, 0))
+
+	pushed := make(chan bool)
+	go func() {
+		pushed <- q.push(&downloadWork{}, 0)
+	}()
+	popped := make(chan *downloadWork)
+	q2 := newDownloadQueue(1)
+	go func() {
+		popped <- q2.pop()
+	}()
+
+	// Give the goroutines a chance to block
+	time.Sleep(10 * time.Millisecond)
+	q.close()
+	q2.close()
+	assert.False(t, <-pushed)
+	assert.Nil(t, <-popped)
+}
+
+func TestPrioritiesForType(t *testing.T) {
+	p := &Priorities{Batch: 10, Blob: 5}
+	assert.Equal(t, 10, p.forType(core.OpTypeSharedStorageDownloadBatch))
+	assert.Equal(t, 5, p.forType(core.OpTypeSharedStorageDownloadBlob))
+}
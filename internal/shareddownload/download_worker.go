@@ -43,15 +43,13 @@ func newDownloadWorker(dm *downloadManager, idx int) *downloadWorker {
 func (dw *downloadWorker) downloadWorkerLoop() {
 	defer close(dw.done)
 
-	l := log.L(dw.ctx)
 	for {
-		select {
-		case <-dw.ctx.Done():
-			l.Debugf("Download worker shutting down")
+		work := dw.dm.queue.pop()
+		if work == nil {
+			log.L(dw.ctx).Debugf("Download worker shutting down")
 			return
-		case work := <-dw.dm.work:
-			dw.attemptWork(work)
 		}
+		dw.attemptWork(work)
 	}
 }
 
@@ -70,6 +68,7 @@ func (dw *downloadWorker) attemptWork(work *downloadWork) {
 				ErrorMessage:   err.Error(),
 			})
 		} else {
+			dw.dm.recordAttempts(dw.ctx, work)
 			go dw.dm.waitAndRetryDownload(work)
 		}
 	}
@@ -18,6 +18,7 @@ package shareddownload
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 
 	"github.com/docker/go-units"
@@ -29,16 +30,26 @@ import (
 )
 
 type downloadBatchData struct {
-	PayloadRef string `json:"payloadRef"`
+	BatchID    *fftypes.UUID    `json:"batchId"`
+	BatchHash  *fftypes.Bytes32 `json:"batchHash"`
+	PayloadRef string           `json:"payloadRef"`
 }
 
 type downloadBlobData struct {
-	DataID     *fftypes.UUID `json:"dataId"`
-	PayloadRef string        `json:"payloadRef"`
+	DataID     *fftypes.UUID    `json:"dataId"`
+	Hash       *fftypes.Bytes32 `json:"hash"`
+	PayloadRef string           `json:"payloadRef"`
 }
 
-func addDownloadBatchInputs(op *core.Operation, payloadRef string) {
+// downloadedBatchHeader is the subset of a downloaded batch needed to verify it against the pinned hash
+type downloadedBatchHeader struct {
+	Hash *fftypes.Bytes32 `json:"hash"`
+}
+
+func addDownloadBatchInputs(op *core.Operation, batchID *fftypes.UUID, batchHash *fftypes.Bytes32, payloadRef string) {
 	op.Input = fftypes.JSONObject{
+		"batchId":    batchID.String(),
+		"batchHash":  batchHash.String(),
 		"payloadRef": payloadRef,
 	}
 }
@@ -49,9 +60,10 @@ func getDownloadBatchOutputs(batchID *fftypes.UUID) fftypes.JSONObject {
 	}
 }
 
-func addDownloadBlobInputs(op *core.Operation, dataID *fftypes.UUID, payloadRef string) {
+func addDownloadBlobInputs(op *core.Operation, dataID *fftypes.UUID, hash *fftypes.Bytes32, payloadRef string) {
 	op.Input = fftypes.JSONObject{
 		"dataId":     dataID.String(),
+		"hash":       hash.String(),
 		"payloadRef": payloadRef,
 	}
 }
@@ -64,14 +76,34 @@ func getDownloadBlobOutputs(hash *fftypes.Bytes32, size int64, dxPayloadRef stri
 	}
 }
 
-func retrieveDownloadBatchInputs(op *core.Operation) (payloadRef string) {
-	return op.Input.GetString("payloadRef")
+// retrieveOptionalHash parses a hash input, which is not present on operations created by older versions
+func retrieveOptionalHash(ctx context.Context, op *core.Operation, key string) (*fftypes.Bytes32, error) {
+	if op.Input.GetString(key) == "" {
+		return nil, nil
+	}
+	return fftypes.ParseBytes32(ctx, op.Input.GetString(key))
 }
 
-func retrieveDownloadBlobInputs(ctx context.Context, op *core.Operation) (dataID *fftypes.UUID, payloadRef string, err error) {
+func retrieveDownloadBatchInputs(ctx context.Context, op *core.Operation) (batchID *fftypes.UUID, batchHash *fftypes.Bytes32, payloadRef string, err error) {
+	if op.Input.GetString("batchId") != "" {
+		if batchID, err = fftypes.ParseUUID(ctx, op.Input.GetString("batchId")); err != nil {
+			return nil, nil, "", err
+		}
+	}
+	if batchHash, err = retrieveOptionalHash(ctx, op, "batchHash"); err != nil {
+		return nil, nil, "", err
+	}
+	payloadRef = op.Input.GetString("payloadRef")
+	return
+}
+
+func retrieveDownloadBlobInputs(ctx context.Context, op *core.Operation) (dataID *fftypes.UUID, hash *fftypes.Bytes32, payloadRef string, err error) {
 	dataID, err = fftypes.ParseUUID(ctx, op.Input.GetString("dataId"))
 	if err != nil {
-		return nil, "", err
+		return nil, nil, "", err
+	}
+	if hash, err = retrieveOptionalHash(ctx, op, "hash"); err != nil {
+		return nil, nil, "", err
 	}
 	payloadRef = op.Input.GetString("payloadRef")
 	return
@@ -81,15 +113,18 @@ func (dm *downloadManager) PrepareOperation(ctx context.Context, op *core.Operat
 	switch op.Type {
 
 	case core.OpTypeSharedStorageDownloadBatch:
-		payloadRef := retrieveDownloadBatchInputs(op)
-		return opDownloadBatch(op, payloadRef), nil
+		batchID, batchHash, payloadRef, err := retrieveDownloadBatchInputs(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		return opDownloadBatch(op, batchID, batchHash, payloadRef), nil
 
 	case core.OpTypeSharedStorageDownloadBlob:
-		dataID, payloadRef, err := retrieveDownloadBlobInputs(ctx, op)
+		dataID, hash, payloadRef, err := retrieveDownloadBlobInputs(ctx, op)
 		if err != nil {
 			return nil, err
 		}
-		return opDownloadBlob(op, dataID, payloadRef), nil
+		return opDownloadBlob(op, dataID, hash, payloadRef), nil
 
 	default:
 		return nil, i18n.NewError(ctx, coremsgs.MsgOperationNotSupported, op.Type)
@@ -129,6 +164,21 @@ func (dm *downloadManager) downloadBatch(ctx context.Context, data downloadBatch
 		return nil, core.OpPhasePending, i18n.WrapError(ctx, err, coremsgs.MsgDownloadBatchMaxBytes, data.PayloadRef)
 	}
 
+	// Batches might be compressed by the sender, in which case the decompressed batch is subject to the same limit
+	batchBytes, _, err = core.DecompressBatchPayload(ctx, batchBytes, maxReadLimit)
+	if err != nil {
+		return nil, core.OpPhaseInitializing, err
+	}
+
+	// Verify the batch is the one that was pinned, before it is persisted
+	if data.BatchHash != nil {
+		var header downloadedBatchHeader
+		_ = json.Unmarshal(batchBytes, &header) // an unparsable batch has no hash, so fails verification
+		if !data.BatchHash.Equals(header.Hash) {
+			return nil, core.OpPhaseInitializing, dm.dataIntegrityFailure(ctx, data.BatchID, data.PayloadRef, header.Hash, data.BatchHash)
+		}
+	}
+
 	// Parse and store the batch
 	batchID, err := dm.callbacks.SharedStorageBatchDownloaded(data.PayloadRef, batchBytes)
 	if err != nil {
@@ -153,6 +203,14 @@ func (dm *downloadManager) downloadBlob(ctx context.Context, data downloadBlobDa
 	}
 	log.L(ctx).Infof("Transferred blob '%s' (%s) from shared storage '%s' to local data exchange '%s'", hash, units.HumanSizeWithPrecision(float64(blobSize), 2), data.PayloadRef, dxPayloadRef)
 
+	// Verify the blob is the one referred to by the data, and remove it from data exchange if not
+	if data.Hash != nil && !data.Hash.Equals(hash) {
+		if err := dm.dataexchange.DeleteBlob(ctx, dxPayloadRef); err != nil {
+			log.L(ctx).Warnf("Failed to delete blob '%s' from data exchange: %s", dxPayloadRef, err)
+		}
+		return nil, core.OpPhaseInitializing, dm.dataIntegrityFailure(ctx, data.DataID, data.PayloadRef, hash, data.Hash)
+	}
+
 	// then callback to store metadata
 	if err := dm.callbacks.SharedStorageBlobDownloaded(*hash, blobSize, dxPayloadRef, data.DataID); err != nil {
 		return nil, core.OpPhasePending, err
@@ -161,23 +219,36 @@ func (dm *downloadManager) downloadBlob(ctx context.Context, data downloadBlobDa
 	return getDownloadBlobOutputs(hash, blobSize, dxPayloadRef), core.OpPhaseComplete, nil
 }
 
+// dataIntegrityFailure records a security event for content downloaded from shared storage that does not
+// match the hash pinned for it, and returns the error that fails the download
+func (dm *downloadManager) dataIntegrityFailure(ctx context.Context, ref *fftypes.UUID, payloadRef string, hash, expectedHash *fftypes.Bytes32) error {
+	log.L(ctx).Errorf("Content downloaded from shared storage '%s' for '%s' has hash '%s', which does not match the pinned hash '%s'", payloadRef, ref, hash, expectedHash)
+	event := core.NewEvent(core.EventTypeDataIntegrityFailure, dm.namespace.Name, ref, nil, core.SystemTopicSecurity)
+	if err := dm.database.InsertEvent(ctx, event); err != nil {
+		return err
+	}
+	return i18n.NewError(ctx, coremsgs.MsgDownloadHashMismatch, payloadRef, hash, expectedHash)
+}
+
 func (dm *downloadManager) OnOperationUpdate(ctx context.Context, op *core.Operation, update *core.OperationUpdate) error {
 	return nil
 }
 
-func opDownloadBatch(op *core.Operation, payloadRef string) *core.PreparedOperation {
+func opDownloadBatch(op *core.Operation, batchID *fftypes.UUID, batchHash *fftypes.Bytes32, payloadRef string) *core.PreparedOperation {
 	return &core.PreparedOperation{
 		ID:        op.ID,
 		Namespace: op.Namespace,
 		Plugin:    op.Plugin,
 		Type:      op.Type,
 		Data: downloadBatchData{
+			BatchID:    batchID,
+			BatchHash:  batchHash,
 			PayloadRef: payloadRef,
 		},
 	}
 }
 
-func opDownloadBlob(op *core.Operation, dataID *fftypes.UUID, payloadRef string) *core.PreparedOperation {
+func opDownloadBlob(op *core.Operation, dataID *fftypes.UUID, hash *fftypes.Bytes32, payloadRef string) *core.PreparedOperation {
 	return &core.PreparedOperation{
 		ID:        op.ID,
 		Namespace: op.Namespace,
@@ -185,6 +256,7 @@ func opDownloadBlob(op *core.Operation, dataID *fftypes.UUID, payloadRef string)
 		Type:      op.Type,
 		Data: downloadBlobData{
 			DataID:     dataID,
+			Hash:       hash,
 			PayloadRef: payloadRef,
 		},
 	}
@@ -26,9 +26,11 @@ import (
 	"testing/iotest"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
 	"github.com/hyperledger/firefly/mocks/dataexchangemocks"
 	"github.com/hyperledger/firefly/mocks/shareddownloadmocks"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -108,6 +110,230 @@ func TestDownloadBatchDownloadCallbackFailed(t *testing.T) {
 	mci.AssertExpectations(t)
 }
 
+func TestDownloadBatchCompressed(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	batchID := fftypes.NewUUID()
+	batchHash := fftypes.NewRandB32()
+	batchData := fmt.Sprintf(`{"id":"%s","hash":"%s","compression":"zstd"}`, batchID, batchHash)
+	compressed, err := core.CompressBatchPayload(dm.ctx, core.BatchCompressionZstd, []byte(batchData))
+	assert.NoError(t, err)
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(ioutil.NopCloser(bytes.NewReader(compressed)), nil)
+
+	mci := dm.callbacks.(*shareddownloadmocks.Callbacks)
+	mci.On("SharedStorageBatchDownloaded", "ref1", []byte(batchData)).Return(batchID, nil)
+
+	_, phase, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+		BatchID:    batchID,
+		BatchHash:  batchHash,
+		PayloadRef: "ref1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+
+	mss.AssertExpectations(t)
+	mci.AssertExpectations(t)
+}
+
+func TestDownloadBatchDecompressTooLarge(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	dm.broadcastBatchPayloadLimit = 1
+	compressed, err := core.CompressBatchPayload(dm.ctx, core.BatchCompressionGzip, []byte(strings.Repeat("a", 2048)))
+	assert.NoError(t, err)
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(ioutil.NopCloser(bytes.NewReader(compressed)), nil)
+
+	_, phase, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+		PayloadRef: "ref1",
+	})
+	assert.Regexp(t, "FF10510", err)
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+
+	mss.AssertExpectations(t)
+}
+
+func TestDownloadBatchHashMismatch(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	batchID := fftypes.NewUUID()
+	reader := ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"id":"%s","hash":"%s"}`, batchID, fftypes.NewRandB32())))
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeDataIntegrityFailure && event.Reference.Equals(batchID) && event.Topic == core.SystemTopicSecurity
+	})).Return(nil)
+
+	_, phase, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+		BatchID:    batchID,
+		BatchHash:  fftypes.NewRandB32(),
+		PayloadRef: "ref1",
+	})
+	assert.Regexp(t, "FF10500", err)
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+
+	mss.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestDownloadBatchUnparsableWithHash(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	reader := ioutil.NopCloser(strings.NewReader("!json"))
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+
+	_, _, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+		BatchID:    fftypes.NewUUID(),
+		BatchHash:  fftypes.NewRandB32(),
+		PayloadRef: "ref1",
+	})
+	assert.Regexp(t, "FF10500", err)
+
+	mss.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestDownloadBatchHashMismatchInsertEventFail(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	reader := ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"hash":"%s"}`, fftypes.NewRandB32())))
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(fmt.Errorf("pop"))
+
+	_, _, err := dm.downloadBatch(dm.ctx, downloadBatchData{
+		BatchID:    fftypes.NewUUID(),
+		BatchHash:  fftypes.NewRandB32(),
+		PayloadRef: "ref1",
+	})
+	assert.Regexp(t, "pop", err)
+
+	mss.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestDownloadBlobHashMismatch(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	dataID := fftypes.NewUUID()
+	reader := ioutil.NopCloser(strings.NewReader("some blob data"))
+
+	mss := dm.sharedstorage.(*sharedstoragemocks.Plugin)
+	mss.On("DownloadData", mock.Anything, "ref1").Return(reader, nil)
+
+	mdx := dm.dataexchange.(*dataexchangemocks.Plugin)
+	mdx.On("UploadBlob", mock.Anything, "ns1", *dataID, reader).Return("privateRef1", fftypes.NewRandB32(), int64(12345), nil)
+	mdx.On("DeleteBlob", mock.Anything, "privateRef1").Return(fmt.Errorf("pop"))
+
+	mdi := dm.database.(*databasemocks.Plugin)
+	mdi.On("InsertEvent", mock.Anything, mock.MatchedBy(func(event *core.Event) bool {
+		return event.Type == core.EventTypeDataIntegrityFailure && event.Reference.Equals(dataID)
+	})).Return(nil)
+
+	_, phase, err := dm.downloadBlob(dm.ctx, downloadBlobData{
+		PayloadRef: "ref1",
+		DataID:     dataID,
+		Hash:       fftypes.NewRandB32(),
+	})
+	assert.Regexp(t, "FF10500", err)
+	assert.Equal(t, core.OpPhaseInitializing, phase)
+
+	mss.AssertExpectations(t)
+	mdx.AssertExpectations(t)
+	mdi.AssertExpectations(t)
+}
+
+func TestPrepareOperationBatchBadID(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	_, err := dm.PrepareOperation(dm.ctx, &core.Operation{
+		Type: core.OpTypeSharedStorageDownloadBatch,
+		Input: fftypes.JSONObject{
+			"batchId": "bad",
+		},
+	})
+	assert.Regexp(t, "FF00138", err)
+}
+
+func TestPrepareOperationBatchBadHash(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	_, err := dm.PrepareOperation(dm.ctx, &core.Operation{
+		Type: core.OpTypeSharedStorageDownloadBatch,
+		Input: fftypes.JSONObject{
+			"batchHash": "bad",
+		},
+	})
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestPrepareOperationBlobBadHash(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	_, err := dm.PrepareOperation(dm.ctx, &core.Operation{
+		Type: core.OpTypeSharedStorageDownloadBlob,
+		Input: fftypes.JSONObject{
+			"dataId": fftypes.NewUUID().String(),
+			"hash":   "bad",
+		},
+	})
+	assert.Regexp(t, "FF00107", err)
+}
+
+func TestPrepareOperationBlobWithHash(t *testing.T) {
+
+	dm, cancel := newTestDownloadManager(t)
+	defer cancel()
+
+	dataID := fftypes.NewUUID()
+	hash := fftypes.NewRandB32()
+	op := &core.Operation{
+		ID:   fftypes.NewUUID(),
+		Type: core.OpTypeSharedStorageDownloadBlob,
+	}
+	addDownloadBlobInputs(op, dataID, hash, "ref1")
+
+	po, err := dm.PrepareOperation(dm.ctx, op)
+	assert.NoError(t, err)
+	assert.Equal(t, downloadBlobData{
+		DataID:     dataID,
+		Hash:       hash,
+		PayloadRef: "ref1",
+	}, po.Data)
+}
+
 func TestDownloadBlobDownloadDataReadFail(t *testing.T) {
 
 	dm, cancel := newTestDownloadManager(t)
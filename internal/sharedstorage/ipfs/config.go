@@ -19,6 +19,7 @@ package ipfs
 import (
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly/internal/proxy"
 )
 
 const (
@@ -26,9 +27,20 @@ const (
 	IPFSConfAPISubconf = "api"
 	// IPFSConfGatewaySubconf is the http configuration to connect to the Gateway endpoint of IPFS
 	IPFSConfGatewaySubconf = "gateway"
+	// IPFSConfPinningSubconf is the http configuration to connect to an optional remote pinning service
+	IPFSConfPinningSubconf = "pinning"
 )
 
 func (i *IPFS) InitConfig(config config.Section) {
-	ffresty.InitConfig(config.SubSection(IPFSConfAPISubconf))
-	ffresty.InitConfig(config.SubSection(IPFSConfGatewaySubconf))
+	apiConf := config.SubSection(IPFSConfAPISubconf)
+	ffresty.InitConfig(apiConf)
+	proxy.InitConfig(apiConf)
+	gwConf := config.SubSection(IPFSConfGatewaySubconf)
+	ffresty.InitConfig(gwConf)
+	proxy.InitConfig(gwConf)
+
+	pinningConf := config.SubSection(IPFSConfPinningSubconf)
+	ffresty.InitConfig(pinningConf)
+	proxy.InitConfig(pinningConf)
+	pinningConf.SetDefault(ffresty.HTTPConfigRetryEnabled, true)
 }
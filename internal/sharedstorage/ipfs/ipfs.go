@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 
 	"io"
 
@@ -29,6 +30,8 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/i18n"
 	"github.com/hyperledger/firefly-common/pkg/log"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/proxy"
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/sharedstorage"
 )
 
@@ -37,6 +40,7 @@ type IPFS struct {
 	capabilities *sharedstorage.Capabilities
 	apiClient    *resty.Client
 	gwClient     *resty.Client
+	pinClient    *resty.Client // optional
 }
 
 type ipfsUploadResponse struct {
@@ -45,6 +49,12 @@ type ipfsUploadResponse struct {
 	Size json.Number `json:"Size"`
 }
 
+// ipfsPinStatus is the PinStatus object of the IPFS Pinning Service API
+type ipfsPinStatus struct {
+	RequestID string `json:"requestid"`
+	Status    string `json:"status"`
+}
+
 func (i *IPFS) Name() string {
 	return "ipfs"
 }
@@ -57,7 +67,7 @@ func (i *IPFS) Init(ctx context.Context, config config.Section) (err error) {
 	if apiConfig.GetString(ffresty.HTTPConfigURL) == "" {
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, apiConfig.Resolve(ffresty.HTTPConfigURL), "ipfs")
 	}
-	i.apiClient, err = ffresty.New(i.ctx, apiConfig)
+	i.apiClient, err = proxy.NewRESTClient(i.ctx, apiConfig)
 	if err != nil {
 		return err
 	}
@@ -65,11 +75,20 @@ func (i *IPFS) Init(ctx context.Context, config config.Section) (err error) {
 	if gwConfig.GetString(ffresty.HTTPConfigURL) == "" {
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, gwConfig.Resolve(ffresty.HTTPConfigURL), "ipfs")
 	}
-	i.gwClient, err = ffresty.New(i.ctx, gwConfig)
+	i.gwClient, err = proxy.NewRESTClient(i.ctx, gwConfig)
 	if err != nil {
 		return err
 	}
-	i.capabilities = &sharedstorage.Capabilities{}
+	pinningConfig := config.SubSection(IPFSConfPinningSubconf)
+	if pinningConfig.GetString(ffresty.HTTPConfigURL) != "" {
+		i.pinClient, err = proxy.NewRESTClient(i.ctx, pinningConfig)
+		if err != nil {
+			return err
+		}
+	}
+	i.capabilities = &sharedstorage.Capabilities{
+		Pinning: i.pinClient != nil,
+	}
 	return nil
 }
 
@@ -109,3 +128,44 @@ func (i *IPFS) DownloadData(ctx context.Context, payloadRef string) (data io.Rea
 	log.L(ctx).Infof("IPFS retrieved %s", payloadRef)
 	return res.RawBody(), nil
 }
+
+func (i *IPFS) PinData(ctx context.Context, payloadRef string) (*core.DataPin, error) {
+	var pinStatus ipfsPinStatus
+	res, err := i.pinClient.R().
+		SetContext(ctx).
+		SetBody(map[string]string{"cid": payloadRef}).
+		SetResult(&pinStatus).
+		Post("/pins")
+	if err != nil || !res.IsSuccess() {
+		return nil, ffresty.WrapRestErr(i.ctx, res, err, coremsgs.MsgIPFSPinningRESTErr)
+	}
+	log.L(ctx).Infof("IPFS pin requested for %s request=%s status=%s", payloadRef, pinStatus.RequestID, pinStatus.Status)
+
+	var status core.DataPinStatus
+	switch pinStatus.Status {
+	case "pinning":
+		status = core.DataPinStatusPinning
+	case "pinned":
+		status = core.DataPinStatusPinned
+	case "failed":
+		status = core.DataPinStatusFailed
+	default:
+		status = core.DataPinStatusQueued
+	}
+	return &core.DataPin{
+		Status:  status,
+		Request: pinStatus.RequestID,
+	}, nil
+}
+
+func (i *IPFS) UnpinData(ctx context.Context, pin *core.DataPin) error {
+	res, err := i.pinClient.R().
+		SetContext(ctx).
+		Delete(fmt.Sprintf("/pins/%s", pin.Request))
+	// A pin that no longer exists on the service does not need removing
+	if err != nil || (!res.IsSuccess() && res.StatusCode() != http.StatusNotFound) {
+		return ffresty.WrapRestErr(i.ctx, res, err, coremsgs.MsgIPFSPinningRESTErr)
+	}
+	log.L(ctx).Infof("IPFS pin removed request=%s", pin.Request)
+	return nil
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
@@ -89,6 +90,17 @@ func TestBadTLSConfigGW(t *testing.T) {
 	assert.Regexp(t, "FF00153", err)
 }
 
+func TestBadProxyConfigAPI(t *testing.T) {
+	i := &IPFS{}
+	resetConf()
+
+	apiConf := utConfig.SubSection(IPFSConfAPISubconf)
+	apiConf.Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	apiConf.Set(ffresty.HTTPConfigProxyURL, "ftp://proxy.example.com")
+	err := i.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10611", err)
+}
+
 func TestInit(t *testing.T) {
 	i := &IPFS{}
 	resetConf()
@@ -102,6 +114,7 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, "ipfs", i.Name())
 	assert.NoError(t, err)
 	assert.NotNil(t, i.Capabilities())
+	assert.False(t, i.Capabilities().Pinning)
 }
 
 func TestIPFSUploadSuccess(t *testing.T) {
@@ -229,3 +242,111 @@ func TestIPFSDownloadError(t *testing.T) {
 	assert.Regexp(t, "FF10136", err)
 
 }
+
+func TestBadTLSConfigPinning(t *testing.T) {
+	i := &IPFS{}
+	resetConf()
+
+	utConfig.SubSection(IPFSConfAPISubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.SubSection(IPFSConfGatewaySubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	pinConf := utConfig.SubSection(IPFSConfPinningSubconf)
+	pinConf.Set(ffresty.HTTPConfigURL, "http://localhost:23456")
+	tlsConf := pinConf.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := i.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func newTestPinningIPFS(t *testing.T) (*IPFS, func()) {
+	i := &IPFS{}
+
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+
+	resetConf()
+	utConfig.SubSection(IPFSConfAPISubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	utConfig.SubSection(IPFSConfGatewaySubconf).Set(ffresty.HTTPConfigURL, "http://localhost:12345")
+	pinConf := utConfig.SubSection(IPFSConfPinningSubconf)
+	pinConf.Set(ffresty.HTTPConfigURL, "http://localhost:23456")
+	pinConf.Set(ffresty.HTTPConfigRetryEnabled, false)
+	pinConf.Set(ffresty.HTTPCustomClient, mockedClient)
+
+	err := i.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	assert.True(t, i.Capabilities().Pinning)
+	return i, httpmock.DeactivateAndReset
+}
+
+func TestIPFSPinSuccess(t *testing.T) {
+	i, done := newTestPinningIPFS(t)
+	defer done()
+
+	for status, expected := range map[string]core.DataPinStatus{
+		"queued":  core.DataPinStatusQueued,
+		"pinning": core.DataPinStatusPinning,
+		"pinned":  core.DataPinStatusPinned,
+		"failed":  core.DataPinStatusFailed,
+	} {
+		httpmock.RegisterResponder("POST", "http://localhost:23456/pins",
+			func(req *http.Request) (*http.Response, error) {
+				var body map[string]string
+				err := json.NewDecoder(req.Body).Decode(&body)
+				assert.NoError(t, err)
+				assert.Equal(t, "QmRAQfHNnknnz8S936M2yJGhhVNA6wXJ4jTRP3VXtptmmL", body["cid"])
+				return httpmock.NewJsonResponderOrPanic(202, map[string]interface{}{
+					"requestid": "pin1",
+					"status":    status,
+				})(req)
+			})
+
+		pin, err := i.PinData(context.Background(), "QmRAQfHNnknnz8S936M2yJGhhVNA6wXJ4jTRP3VXtptmmL")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, pin.Status)
+		assert.Equal(t, "pin1", pin.Request)
+	}
+}
+
+func TestIPFSPinFail(t *testing.T) {
+	i, done := newTestPinningIPFS(t)
+	defer done()
+
+	httpmock.RegisterResponder("POST", "http://localhost:23456/pins",
+		httpmock.NewJsonResponderOrPanic(401, map[string]interface{}{"error": "pop"}))
+
+	_, err := i.PinData(context.Background(), "QmRAQfHNnknnz8S936M2yJGhhVNA6wXJ4jTRP3VXtptmmL")
+	assert.Regexp(t, "FF10497", err)
+}
+
+func TestIPFSUnpinSuccess(t *testing.T) {
+	i, done := newTestPinningIPFS(t)
+	defer done()
+
+	httpmock.RegisterResponder("DELETE", "http://localhost:23456/pins/pin1",
+		httpmock.NewStringResponder(202, ""))
+
+	err := i.UnpinData(context.Background(), &core.DataPin{Request: "pin1"})
+	assert.NoError(t, err)
+}
+
+func TestIPFSUnpinNotFound(t *testing.T) {
+	i, done := newTestPinningIPFS(t)
+	defer done()
+
+	httpmock.RegisterResponder("DELETE", "http://localhost:23456/pins/pin1",
+		httpmock.NewJsonResponderOrPanic(404, map[string]interface{}{"error": "not found"}))
+
+	err := i.UnpinData(context.Background(), &core.DataPin{Request: "pin1"})
+	assert.NoError(t, err)
+}
+
+func TestIPFSUnpinFail(t *testing.T) {
+	i, done := newTestPinningIPFS(t)
+	defer done()
+
+	httpmock.RegisterResponder("DELETE", "http://localhost:23456/pins/pin1",
+		httpmock.NewJsonResponderOrPanic(500, map[string]interface{}{"error": "pop"}))
+
+	err := i.UnpinData(context.Background(), &core.DataPin{Request: "pin1"})
+	assert.Regexp(t, "FF10497", err)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly/internal/proxy"
+)
+
+const (
+	// S3ConfBucket is the bucket that payloads are stored in
+	S3ConfBucket = "bucket"
+	// S3ConfRegion is the region used to sign requests
+	S3ConfRegion = "region"
+	// S3ConfAccessKeyID is the access key used to sign requests
+	S3ConfAccessKeyID = "accessKeyId"
+	// S3ConfSecretAccessKey is the secret key used to sign requests
+	S3ConfSecretAccessKey = "secretAccessKey"
+	// S3ConfPresignedRefsSubconf is the configuration for returning pre-signed URLs as payload references
+	S3ConfPresignedRefsSubconf = "presignedRefs"
+	// S3ConfPresignedRefsEnabled whether payload references are pre-signed download URLs, rather than object keys
+	S3ConfPresignedRefsEnabled = "enabled"
+	// S3ConfPresignedRefsExpiry how long a pre-signed payload reference remains valid
+	S3ConfPresignedRefsExpiry = "expiry"
+)
+
+func (s *S3) InitConfig(config config.Section) {
+	ffresty.InitConfig(config)
+	proxy.InitConfig(config)
+	config.AddKnownKey(S3ConfBucket)
+	config.AddKnownKey(S3ConfRegion, "us-east-1")
+	config.AddKnownKey(S3ConfAccessKeyID)
+	config.AddKnownKey(S3ConfSecretAccessKey)
+
+	presignedRefsConf := config.SubSection(S3ConfPresignedRefsSubconf)
+	presignedRefsConf.AddKnownKey(S3ConfPresignedRefsEnabled, false)
+	presignedRefsConf.AddKnownKey(S3ConfPresignedRefsExpiry, "168h")
+}
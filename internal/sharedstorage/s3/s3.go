@@ -0,0 +1,179 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/proxy"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/sharedstorage"
+)
+
+// requestExpiry is the validity of the pre-signed URLs used for the requests made by this node
+const requestExpiry = 15 * time.Minute
+
+var objectKeyRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+type S3 struct {
+	ctx          context.Context
+	capabilities *sharedstorage.Capabilities
+	client       *resty.Client
+	endpoint     *url.URL
+	bucket       string
+	signer       *presigner
+	presignRefs  bool
+	refExpiry    time.Duration
+}
+
+func (s *S3) Name() string {
+	return "s3"
+}
+
+func (s *S3) Init(ctx context.Context, config config.Section) (err error) {
+
+	s.ctx = log.WithLogField(ctx, "sharedstorage", "s3")
+
+	endpoint := config.GetString(ffresty.HTTPConfigURL)
+	if endpoint == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(ffresty.HTTPConfigURL), "s3")
+	}
+	if s.endpoint, err = url.Parse(endpoint); err != nil {
+		return err
+	}
+	s.bucket = config.GetString(S3ConfBucket)
+	if s.bucket == "" {
+		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, config.Resolve(S3ConfBucket), "s3")
+	}
+	s.client, err = proxy.NewRESTClient(s.ctx, config)
+	if err != nil {
+		return err
+	}
+	s.signer = &presigner{
+		accessKeyID:     config.GetString(S3ConfAccessKeyID),
+		secretAccessKey: config.GetString(S3ConfSecretAccessKey),
+		region:          config.GetString(S3ConfRegion),
+	}
+	presignedRefsConf := config.SubSection(S3ConfPresignedRefsSubconf)
+	s.presignRefs = presignedRefsConf.GetBool(S3ConfPresignedRefsEnabled)
+	s.refExpiry = presignedRefsConf.GetDuration(S3ConfPresignedRefsExpiry)
+	s.capabilities = &sharedstorage.Capabilities{}
+	return nil
+}
+
+func (s *S3) SetHandler(_ string, handler sharedstorage.Callbacks) {
+}
+
+func (s *S3) Capabilities() *sharedstorage.Capabilities {
+	return s.capabilities
+}
+
+// objectURL returns the path-style URL of an object, which is supported by S3 and all common S3 compatible stores
+func (s *S3) objectURL(key string) *url.URL {
+	u := *s.endpoint
+	u.RawQuery = ""
+	u.Path = path.Join("/", u.Path, s.bucket, key)
+	u.RawPath = ""
+	return &u
+}
+
+// isObjectURL checks a payload reference is a URL for an object in the configured bucket,
+// so that references received from other members cannot direct requests to other servers
+func (s *S3) isObjectURL(u *url.URL) bool {
+	prefix := path.Join("/", s.endpoint.Path, s.bucket) + "/"
+	return u.Scheme == s.endpoint.Scheme &&
+		u.Host == s.endpoint.Host &&
+		strings.HasPrefix(u.Path, prefix) &&
+		objectKeyRegex.MatchString(strings.TrimPrefix(u.Path, prefix))
+}
+
+func (s *S3) UploadData(ctx context.Context, data io.Reader) (string, error) {
+	// The payload is read fully to calculate the hash, which is used as the object key
+	payload, err := io.ReadAll(data)
+	if err != nil {
+		return "", i18n.WrapError(ctx, err, coremsgs.MsgS3RESTErr, err)
+	}
+	hash := sha256.Sum256(payload)
+	key := hex.EncodeToString(hash[:])
+
+	objectURL := s.objectURL(key)
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetBody(bytes.NewReader(payload)).
+		Put(s.signer.presign(http.MethodPut, objectURL, time.Now(), requestExpiry))
+	if err != nil || !res.IsSuccess() {
+		return "", ffresty.WrapRestErr(s.ctx, res, err, coremsgs.MsgS3RESTErr)
+	}
+	log.L(ctx).Infof("S3 published %s Size=%d", key, len(payload))
+
+	if s.presignRefs {
+		return s.signer.presign(http.MethodGet, objectURL, time.Now(), s.refExpiry), nil
+	}
+	return key, nil
+}
+
+func (s *S3) DownloadData(ctx context.Context, payloadRef string) (data io.ReadCloser, err error) {
+	var downloadURL string
+	if objectKeyRegex.MatchString(payloadRef) {
+		downloadURL = s.signer.presign(http.MethodGet, s.objectURL(payloadRef), time.Now(), requestExpiry)
+	} else {
+		u, err := url.Parse(payloadRef)
+		if err != nil || !s.isObjectURL(u) {
+			return nil, i18n.NewError(ctx, coremsgs.MsgS3InvalidPayloadRef, payloadRef)
+		}
+		downloadURL = payloadRef
+	}
+
+	res, err := s.client.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		Get(downloadURL)
+	ffresty.OnAfterResponse(s.client, res) // required using SetDoNotParseResponse
+	if err != nil || !res.IsSuccess() {
+		if res != nil && res.RawBody() != nil {
+			_ = res.RawBody().Close()
+		}
+		return nil, ffresty.WrapRestErr(s.ctx, res, err, coremsgs.MsgS3RESTErr)
+	}
+	log.L(ctx).Infof("S3 retrieved %s", payloadRef)
+	return res.RawBody(), nil
+}
+
+func (s *S3) PinData(ctx context.Context, payloadRef string) (*core.DataPin, error) {
+	return nil, i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+}
+
+func (s *S3) UnpinData(ctx context.Context, pin *core.DataPin) error {
+	return i18n.NewError(ctx, coremsgs.MsgActionNotSupported)
+}
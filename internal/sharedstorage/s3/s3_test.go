@@ -0,0 +1,256 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/fftls"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/sharedstoragemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var utConfig = config.RootSection("s3_unit_tests")
+
+// sha256 of "hello world"
+const helloKey = "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, fmt.Errorf("pop")
+}
+
+func resetConf() {
+	coreconfig.Reset()
+	s := &S3{}
+	s.InitConfig(utConfig)
+}
+
+func newTestS3(t *testing.T) (*S3, func()) {
+	mockedClient := &http.Client{}
+	httpmock.ActivateNonDefault(mockedClient)
+
+	resetConf()
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:9000")
+	utConfig.Set(ffresty.HTTPCustomClient, mockedClient)
+	utConfig.Set(S3ConfBucket, "firefly")
+	utConfig.Set(S3ConfAccessKeyID, "minio")
+	utConfig.Set(S3ConfSecretAccessKey, "minio123")
+
+	s := &S3{}
+	err := s.Init(context.Background(), utConfig)
+	assert.NoError(t, err)
+	return s, httpmock.DeactivateAndReset
+}
+
+func TestInitMissingURL(t *testing.T) {
+	s := &S3{}
+	resetConf()
+
+	utConfig.Set(S3ConfBucket, "firefly")
+	err := s.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*url", err)
+}
+
+func TestInitBadURL(t *testing.T) {
+	s := &S3{}
+	resetConf()
+
+	utConfig.Set(ffresty.HTTPConfigURL, ":::not a url")
+	err := s.Init(context.Background(), utConfig)
+	assert.Error(t, err)
+}
+
+func TestInitMissingBucket(t *testing.T) {
+	s := &S3{}
+	resetConf()
+
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:9000")
+	err := s.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF10138.*bucket", err)
+}
+
+func TestBadTLSConfig(t *testing.T) {
+	s := &S3{}
+	resetConf()
+
+	utConfig.Set(ffresty.HTTPConfigURL, "http://localhost:9000")
+	utConfig.Set(S3ConfBucket, "firefly")
+	tlsConf := utConfig.SubSection("tls")
+	tlsConf.Set(fftls.HTTPConfTLSEnabled, true)
+	tlsConf.Set(fftls.HTTPConfTLSCAFile, "!!!!!badness")
+	err := s.Init(context.Background(), utConfig)
+	assert.Regexp(t, "FF00153", err)
+}
+
+func TestInit(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	cbs := &sharedstoragemocks.Callbacks{}
+	s.SetHandler("ns1", cbs) // no-op
+
+	assert.Equal(t, "s3", s.Name())
+	assert.NotNil(t, s.Capabilities())
+	assert.False(t, s.Capabilities().Pinning)
+}
+
+func TestS3UploadSuccess(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	httpmock.RegisterResponder("PUT", "http://localhost:9000/firefly/"+helloKey,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "minio/"+req.URL.Query().Get("X-Amz-Date")[0:8]+"/us-east-1/s3/aws4_request", req.URL.Query().Get("X-Amz-Credential"))
+			assert.NotEmpty(t, req.URL.Query().Get("X-Amz-Signature"))
+			body, _ := io.ReadAll(req.Body)
+			assert.Equal(t, "hello world", string(body))
+			return httpmock.NewStringResponse(200, ""), nil
+		})
+
+	payloadRef, err := s.UploadData(context.Background(), bytes.NewReader([]byte(`hello world`)))
+	assert.NoError(t, err)
+	assert.Equal(t, helloKey, payloadRef)
+}
+
+func TestS3UploadPresignedRef(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+	s.presignRefs = true
+
+	httpmock.RegisterResponder("PUT", "http://localhost:9000/firefly/"+helloKey,
+		httpmock.NewStringResponder(200, ""))
+
+	payloadRef, err := s.UploadData(context.Background(), bytes.NewReader([]byte(`hello world`)))
+	assert.NoError(t, err)
+	u, err := url.Parse(payloadRef)
+	assert.NoError(t, err)
+	assert.Equal(t, "/firefly/"+helloKey, u.Path)
+	assert.Equal(t, "604800", u.Query().Get("X-Amz-Expires"))
+}
+
+func TestS3UploadReadFail(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	_, err := s.UploadData(context.Background(), errReader{})
+	assert.Regexp(t, "FF10498.*pop", err)
+}
+
+func TestS3UploadFail(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	httpmock.RegisterResponder("PUT", "http://localhost:9000/firefly/"+helloKey,
+		httpmock.NewStringResponder(403, "AccessDenied"))
+
+	_, err := s.UploadData(context.Background(), bytes.NewReader([]byte(`hello world`)))
+	assert.Regexp(t, "FF10498", err)
+}
+
+func TestS3DownloadByKeySuccess(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", "http://localhost:9000/firefly/"+helloKey,
+		func(req *http.Request) (*http.Response, error) {
+			assert.NotEmpty(t, req.URL.Query().Get("X-Amz-Signature"))
+			return httpmock.NewStringResponse(200, "hello world"), nil
+		})
+
+	r, err := s.DownloadData(context.Background(), helloKey)
+	assert.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+}
+
+func TestS3DownloadByURLSuccess(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", "http://localhost:9000/firefly/"+helloKey,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "remote-signature", req.URL.Query().Get("X-Amz-Signature"))
+			return httpmock.NewStringResponse(200, "hello world"), nil
+		})
+
+	r, err := s.DownloadData(context.Background(), "http://localhost:9000/firefly/"+helloKey+"?X-Amz-Signature=remote-signature")
+	assert.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world", string(b))
+}
+
+func TestS3DownloadOtherHost(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	_, err := s.DownloadData(context.Background(), "http://attacker.example.com/firefly/"+helloKey)
+	assert.Regexp(t, "FF10499", err)
+}
+
+func TestS3DownloadOtherBucket(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	_, err := s.DownloadData(context.Background(), "http://localhost:9000/other/"+helloKey)
+	assert.Regexp(t, "FF10499", err)
+}
+
+func TestS3DownloadBadRef(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	_, err := s.DownloadData(context.Background(), ":::not a ref")
+	assert.Regexp(t, "FF10499", err)
+}
+
+func TestS3DownloadFail(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", "http://localhost:9000/firefly/"+helloKey,
+		httpmock.NewStringResponder(404, "NoSuchKey"))
+
+	_, err := s.DownloadData(context.Background(), helloKey)
+	assert.Regexp(t, "FF10498", err)
+}
+
+func TestS3PinningNotSupported(t *testing.T) {
+	s, done := newTestS3(t)
+	defer done()
+
+	_, err := s.PinData(context.Background(), helloKey)
+	assert.Regexp(t, "FF10414", err)
+	err = s.UnpinData(context.Background(), &core.DataPin{Request: "req1"})
+	assert.Regexp(t, "FF10414", err)
+}
@@ -0,0 +1,325 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/hyperledger/firefly/pkg/database"
+)
+
+const (
+	eventPageSize    = 250
+	resyncRetryDelay = 5 * time.Second
+)
+
+// Manager maintains the statistics of a namespace incrementally, so dashboards can poll them
+// without running COUNT queries against the database on every request.
+//
+// The counts are loaded from the database when the manager starts, and resynchronized on an
+// interval. In between they are updated from the database change notifications for the namespace,
+// and by reading each new event once.
+type Manager interface {
+	Start()
+	WaitStop()
+	EventCreated()
+	MessageCreated()
+	OperationCreated()
+	SubscriptionCreated()
+	SubscriptionDeleted()
+	GetStats(ctx context.Context) (*core.NamespaceStats, error)
+}
+
+type statsManager struct {
+	ctx            context.Context
+	cancelCtx      context.CancelFunc
+	namespace      string
+	database       database.Plugin
+	resyncInterval time.Duration
+	retryDelay     time.Duration
+	newEvents      chan bool
+	done           chan struct{}
+
+	mux               sync.Mutex
+	resynced          *fftypes.FFTime
+	eventOffset       int64
+	messagesPending   int64
+	messagesConfirmed rateCounter
+	messagesRejected  rateCounter
+	events            rateCounter
+	transfers         rateCounter
+	subscriptions     int64
+	pendingOperations int64
+}
+
+func NewStatsManager(ctx context.Context, ns string, di database.Plugin) (Manager, error) {
+	if di == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgInitializationNilDepError, "StatsManager")
+	}
+	sm := &statsManager{
+		namespace:      ns,
+		database:       di,
+		resyncInterval: config.GetDuration(coreconfig.StatsResyncInterval),
+		retryDelay:     resyncRetryDelay,
+		newEvents:      make(chan bool, 1),
+	}
+	sm.ctx, sm.cancelCtx = context.WithCancel(log.WithLogField(ctx, "role", "stats"))
+	return sm, nil
+}
+
+func (sm *statsManager) Start() {
+	sm.done = make(chan struct{})
+	go sm.statsLoop()
+}
+
+func (sm *statsManager) WaitStop() {
+	sm.cancelCtx()
+	if sm.done != nil {
+		<-sm.done
+	}
+}
+
+// EventCreated wakes the stats loop to read the new events. It never blocks, as the loop reads
+// all events after its offset in one pass.
+func (sm *statsManager) EventCreated() {
+	select {
+	case sm.newEvents <- true:
+	default:
+	}
+}
+
+func (sm *statsManager) MessageCreated() {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	sm.messagesPending++
+}
+
+func (sm *statsManager) OperationCreated() {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	sm.pendingOperations++
+}
+
+func (sm *statsManager) SubscriptionCreated() {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	sm.subscriptions++
+}
+
+func (sm *statsManager) SubscriptionDeleted() {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	if sm.subscriptions > 0 {
+		sm.subscriptions--
+	}
+}
+
+func (sm *statsManager) GetStats(ctx context.Context) (*core.NamespaceStats, error) {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	if sm.resynced == nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgStatsNotAvailable, sm.namespace)
+	}
+	now := time.Now()
+	return &core.NamespaceStats{
+		Resynced: sm.resynced,
+		Messages: &core.MessageStats{
+			Pending:   sm.messagesPending,
+			Confirmed: sm.messagesConfirmed.counter(now),
+			Rejected:  sm.messagesRejected.counter(now),
+		},
+		Events:            sm.events.counter(now),
+		Transfers:         sm.transfers.counter(now),
+		Subscriptions:     sm.subscriptions,
+		PendingOperations: sm.pendingOperations,
+	}, nil
+}
+
+func (sm *statsManager) statsLoop() {
+	defer close(sm.done)
+	resyncTimer := time.NewTimer(0)
+	defer resyncTimer.Stop()
+	for {
+		select {
+		case <-resyncTimer.C:
+			delay := sm.resyncInterval
+			if err := sm.resync(sm.ctx); err != nil {
+				log.L(sm.ctx).Errorf("Failed to resynchronize namespace statistics: %s", err)
+				delay = sm.retryDelay
+			}
+			resyncTimer.Reset(delay)
+		case <-sm.newEvents:
+			if err := sm.readEvents(sm.ctx); err != nil {
+				// The events will be read on the next notification, or resynchronization
+				log.L(sm.ctx).Errorf("Failed to read events for namespace statistics: %s", err)
+			}
+		case <-sm.ctx.Done():
+			log.L(sm.ctx).Debugf("Stats loop exiting")
+			return
+		}
+	}
+}
+
+func (sm *statsManager) count(res *ffapi.FilterResult) int64 {
+	if res == nil || res.TotalCount == nil {
+		return 0
+	}
+	return *res.TotalCount
+}
+
+// resync replaces all the counts with those in the database. The event offset is read first, so that
+// no event is missed - an event that arrives while the counts are being read might be counted twice,
+// until the next resynchronization.
+func (sm *statsManager) resync(ctx context.Context) error {
+	efb := database.EventQueryFactory.NewFilter(ctx)
+	latest, res, err := sm.database.GetEvents(ctx, sm.namespace, efb.And().Sort("-sequence").Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	eventOffset := int64(-1)
+	if len(latest) > 0 {
+		eventOffset = latest[0].Sequence
+	}
+	events := sm.count(res)
+
+	mfb := database.MessageQueryFactory.NewFilter(ctx)
+	_, res, err = sm.database.GetMessages(ctx, sm.namespace, mfb.And(
+		mfb.In("state", []driver.Value{core.MessageStateStaged, core.MessageStateReady, core.MessageStateSent, core.MessageStatePending}),
+	).Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	messagesPending := sm.count(res)
+
+	_, res, err = sm.database.GetMessages(ctx, sm.namespace, mfb.And(mfb.Eq("state", core.MessageStateConfirmed)).Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	messagesConfirmed := sm.count(res)
+
+	_, res, err = sm.database.GetMessages(ctx, sm.namespace, mfb.And(mfb.Eq("state", core.MessageStateRejected)).Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	messagesRejected := sm.count(res)
+
+	tfb := database.TokenTransferQueryFactory.NewFilter(ctx)
+	_, res, err = sm.database.GetTokenTransfers(ctx, sm.namespace, tfb.And().Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	transfers := sm.count(res)
+
+	sfb := database.SubscriptionQueryFactory.NewFilter(ctx)
+	_, res, err = sm.database.GetSubscriptions(ctx, sm.namespace, sfb.And().Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	subscriptions := sm.count(res)
+
+	ofb := database.OperationQueryFactory.NewFilter(ctx)
+	_, res, err = sm.database.GetOperations(ctx, sm.namespace, ofb.And(
+		ofb.In("status", []driver.Value{core.OpStatusInitialized, core.OpStatusPending}),
+	).Limit(1).Count(true))
+	if err != nil {
+		return err
+	}
+	pendingOperations := sm.count(res)
+
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	sm.eventOffset = eventOffset
+	sm.events.total = events
+	sm.messagesPending = messagesPending
+	sm.messagesConfirmed.total = messagesConfirmed
+	sm.messagesRejected.total = messagesRejected
+	sm.transfers.total = transfers
+	sm.subscriptions = subscriptions
+	sm.pendingOperations = pendingOperations
+	sm.resynced = fftypes.Now()
+	log.L(ctx).Debugf("Resynchronized namespace statistics: events=%d offset=%d", events, eventOffset)
+	return nil
+}
+
+// readEvents counts each event after the offset, by type
+func (sm *statsManager) readEvents(ctx context.Context) error {
+	sm.mux.Lock()
+	offset := sm.eventOffset
+	resynced := sm.resynced != nil
+	sm.mux.Unlock()
+	if !resynced {
+		// Events are only counted after the initial counts are loaded
+		return nil
+	}
+
+	for {
+		fb := database.EventQueryFactory.NewFilter(ctx)
+		events, _, err := sm.database.GetEvents(ctx, sm.namespace, fb.And(fb.Gt("sequence", offset)).Sort("sequence").Limit(eventPageSize))
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		offset = sm.countEvents(events)
+		if len(events) < eventPageSize {
+			return nil
+		}
+	}
+}
+
+func (sm *statsManager) countEvents(events []*core.Event) int64 {
+	sm.mux.Lock()
+	defer sm.mux.Unlock()
+	now := time.Now()
+	for _, event := range events {
+		if event.Sequence <= sm.eventOffset {
+			// Already included in a resynchronization
+			continue
+		}
+		sm.eventOffset = event.Sequence
+		sm.events.add(now)
+		switch event.Type {
+		case core.EventTypeMessageConfirmed:
+			sm.messagesConfirmed.add(now)
+			sm.messageCompletedLocked()
+		case core.EventTypeMessageRejected:
+			sm.messagesRejected.add(now)
+			sm.messageCompletedLocked()
+		case core.EventTypeTransferConfirmed:
+			sm.transfers.add(now)
+		}
+	}
+	return sm.eventOffset
+}
+
+func (sm *statsManager) messageCompletedLocked() {
+	if sm.messagesPending > 0 {
+		sm.messagesPending--
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffapi"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/databasemocks"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestStatsManager(t *testing.T) (*statsManager, *databasemocks.Plugin, func()) {
+	coreconfig.Reset()
+	mdi := &databasemocks.Plugin{}
+	sm, err := NewStatsManager(context.Background(), "ns1", mdi)
+	assert.NoError(t, err)
+	return sm.(*statsManager), mdi, func() {
+		sm.WaitStop()
+		mdi.AssertExpectations(t)
+	}
+}
+
+func countResult(count int64) *ffapi.FilterResult {
+	return &ffapi.FilterResult{TotalCount: &count}
+}
+
+func mockResync(mdi *databasemocks.Plugin) {
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{{Sequence: 100}}, countResult(100), nil).Once()
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(5), nil).Once()
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(20), nil).Once()
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(2), nil).Once()
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(30), nil).Once()
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(3), nil).Once()
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, &ffapi.FilterResult{}, nil).Once()
+}
+
+func TestNewStatsManagerMissingDeps(t *testing.T) {
+	_, err := NewStatsManager(context.Background(), "ns1", nil)
+	assert.Regexp(t, "FF10128", err)
+}
+
+func TestGetStatsNotAvailable(t *testing.T) {
+	sm, _, cancel := newTestStatsManager(t)
+	defer cancel()
+	_, err := sm.GetStats(sm.ctx)
+	assert.Regexp(t, "FF10599", err)
+}
+
+func TestResyncAndCountEvents(t *testing.T) {
+	sm, mdi, cancel := newTestStatsManager(t)
+	defer cancel()
+
+	mockResync(mdi)
+	err := sm.resync(sm.ctx)
+	assert.NoError(t, err)
+
+	sm.MessageCreated()
+	sm.OperationCreated()
+	sm.SubscriptionCreated()
+	sm.SubscriptionDeleted()
+	sm.SubscriptionDeleted()
+
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{
+		{Sequence: 99, Type: core.EventTypeMessageConfirmed}, // already counted
+		{Sequence: 101, Type: core.EventTypeMessageConfirmed},
+		{Sequence: 102, Type: core.EventTypeMessageRejected},
+		{Sequence: 103, Type: core.EventTypeTransferConfirmed},
+		{Sequence: 104, Type: core.EventTypeIdentityConfirmed},
+	}, nil, nil).Once()
+	err = sm.readEvents(sm.ctx)
+	assert.NoError(t, err)
+
+	stats, err := sm.GetStats(sm.ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, stats.Resynced)
+	assert.Equal(t, &core.MessageStats{
+		Pending:   4,
+		Confirmed: &core.StatsCounter{Total: 21, RatePerMinute: 1},
+		Rejected:  &core.StatsCounter{Total: 3, RatePerMinute: 1},
+	}, stats.Messages)
+	assert.Equal(t, &core.StatsCounter{Total: 104, RatePerMinute: 4}, stats.Events)
+	assert.Equal(t, &core.StatsCounter{Total: 31, RatePerMinute: 1}, stats.Transfers)
+	assert.Equal(t, int64(2), stats.Subscriptions)
+	assert.Equal(t, int64(1), stats.PendingOperations)
+	assert.Equal(t, int64(104), sm.eventOffset)
+}
+
+func TestResyncNoEvents(t *testing.T) {
+	sm, mdi, cancel := newTestStatsManager(t)
+	defer cancel()
+
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, countResult(0), nil).Once()
+	mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil)
+	mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil)
+	mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil)
+	mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil)
+	err := sm.resync(sm.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), sm.eventOffset)
+}
+
+func TestResyncFail(t *testing.T) {
+	for i := 0; i < 7; i++ {
+		sm, mdi, cancel := newTestStatsManager(t)
+		calls := []func(err error){
+			func(err error) {
+				mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+			func(err error) {
+				mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+			func(err error) {
+				mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+			func(err error) {
+				mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+			func(err error) {
+				mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+			func(err error) {
+				mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+			func(err error) {
+				mdi.On("GetOperations", mock.Anything, "ns1", mock.Anything).Return(nil, nil, err).Once()
+			},
+		}
+		for j := 0; j < i; j++ {
+			calls[j](nil)
+		}
+		calls[i](fmt.Errorf("pop"))
+		mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, countResult(0), nil).Maybe()
+		mdi.On("GetMessages", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil).Maybe()
+		mdi.On("GetTokenTransfers", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil).Maybe()
+		mdi.On("GetSubscriptions", mock.Anything, "ns1", mock.Anything).Return(nil, countResult(0), nil).Maybe()
+
+		err := sm.resync(sm.ctx)
+		assert.Regexp(t, "pop", err)
+		assert.Nil(t, sm.resynced)
+		cancel()
+	}
+}
+
+func TestReadEventsBeforeResync(t *testing.T) {
+	sm, _, cancel := newTestStatsManager(t)
+	defer cancel()
+	err := sm.readEvents(sm.ctx)
+	assert.NoError(t, err)
+}
+
+func TestReadEventsPaged(t *testing.T) {
+	sm, mdi, cancel := newTestStatsManager(t)
+	defer cancel()
+
+	mockResync(mdi)
+	err := sm.resync(sm.ctx)
+	assert.NoError(t, err)
+
+	page := make([]*core.Event, eventPageSize)
+	for i := range page {
+		page[i] = &core.Event{Sequence: int64(101 + i), Type: core.EventTypeTransferConfirmed}
+	}
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(page, nil, nil).Once()
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Once()
+	err = sm.readEvents(sm.ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100+eventPageSize), sm.eventOffset)
+	assert.Equal(t, int64(30+eventPageSize), sm.transfers.total)
+}
+
+func TestReadEventsFail(t *testing.T) {
+	sm, mdi, cancel := newTestStatsManager(t)
+	defer cancel()
+
+	mockResync(mdi)
+	err := sm.resync(sm.ctx)
+	assert.NoError(t, err)
+
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	err = sm.readEvents(sm.ctx)
+	assert.Regexp(t, "pop", err)
+}
+
+func TestStatsLoop(t *testing.T) {
+	sm, mdi, cancel := newTestStatsManager(t)
+	defer cancel()
+	sm.resyncInterval = time.Hour
+	sm.retryDelay = time.Millisecond
+
+	// Fail the first resynchronization, and the first read after it succeeds
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	mockResync(mdi)
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return(nil, nil, fmt.Errorf("pop")).Once()
+	read := make(chan struct{})
+	var readOnce sync.Once
+	mdi.On("GetEvents", mock.Anything, "ns1", mock.Anything).Return([]*core.Event{}, nil, nil).Run(func(args mock.Arguments) {
+		readOnce.Do(func() { close(read) })
+	})
+
+	sm.Start()
+	for {
+		sm.EventCreated()
+		select {
+		case <-read:
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"time"
+
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+const rateWindowSeconds = 60
+
+// rateCounter is a total, with a sliding window of per-second buckets to calculate the rate over the last minute
+type rateCounter struct {
+	total   int64
+	buckets [rateWindowSeconds]int64
+	seconds [rateWindowSeconds]int64
+}
+
+func (rc *rateCounter) add(now time.Time) {
+	second := now.Unix()
+	i := second % rateWindowSeconds
+	if rc.seconds[i] != second {
+		rc.seconds[i] = second
+		rc.buckets[i] = 0
+	}
+	rc.buckets[i]++
+	rc.total++
+}
+
+func (rc *rateCounter) ratePerMinute(now time.Time) int64 {
+	second := now.Unix()
+	var rate int64
+	for i, s := range rc.seconds {
+		if second-s < rateWindowSeconds {
+			rate += rc.buckets[i]
+		}
+	}
+	return rate
+}
+
+func (rc *rateCounter) counter(now time.Time) *core.StatsCounter {
+	return &core.StatsCounter{
+		Total:         rc.total,
+		RatePerMinute: rc.ratePerMinute(now),
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateCounterWindow(t *testing.T) {
+	rc := &rateCounter{total: 10}
+	start := time.Unix(1000, 0)
+	rc.add(start)
+	rc.add(start)
+	rc.add(start.Add(30 * time.Second))
+	assert.Equal(t, int64(3), rc.ratePerMinute(start.Add(30*time.Second)))
+
+	// The first bucket drops out of the window
+	assert.Equal(t, int64(1), rc.ratePerMinute(start.Add(60*time.Second)))
+
+	// A bucket is reused when the window wraps around
+	rc.add(start.Add(60 * time.Second))
+	assert.Equal(t, int64(2), rc.ratePerMinute(start.Add(60*time.Second)))
+	assert.Equal(t, int64(14), rc.counter(start.Add(60*time.Second)).Total)
+}
@@ -436,9 +436,9 @@ func (sa *syncAsyncBridge) handleApprovalOpFailedEvent(event *core.EventDelivery
 	return nil
 }
 
-func (sa *syncAsyncBridge) handleOperationSucceededEvent(event *core.EventDelivery) error {
-	// See if this is a failure of an inflight invoke operation
-	inflight := sa.getInFlight(event.Namespace, invokeOperationConfirm, event.Reference)
+func (sa *syncAsyncBridge) handleOperationEvent(event *core.EventDelivery, reqType requestType, typeName string, succeeded bool) error {
+	// See if this is the outcome of an inflight operation of this type
+	inflight := sa.getInFlight(event.Namespace, reqType, event.Reference)
 	if inflight == nil {
 		return nil
 	}
@@ -448,59 +448,12 @@ func (sa *syncAsyncBridge) handleOperationSucceededEvent(event *core.EventDelive
 		return err
 	}
 
-	go sa.resolveSuccessfulOperation(inflight, "invoke", op)
-
-	return nil
-}
-
-func (sa *syncAsyncBridge) handleContractDeployOperationSucceededEvent(event *core.EventDelivery) error {
-	// See if this is a failure of an inflight invoke operation
-	inflight := sa.getInFlight(event.Namespace, deployOperationConfirm, event.Reference)
-	if inflight == nil {
-		return nil
-	}
-
-	op, err := sa.getOperationFromEvent(event)
-	if err != nil || op == nil {
-		return err
-	}
-
-	go sa.resolveSuccessfulOperation(inflight, "deploy", op)
-
-	return nil
-}
-
-func (sa *syncAsyncBridge) handleContractDeployOperationFailedEvent(event *core.EventDelivery) error {
-	// See if this is a failure of an inflight deploy operation
-	inflight := sa.getInFlight(event.Namespace, deployOperationConfirm, event.Reference)
-	if inflight == nil {
-		return nil
-	}
-
-	op, err := sa.getOperationFromEvent(event)
-	if err != nil || op == nil {
-		return err
-	}
-
-	go sa.resolveFailedOperation(inflight, "deploy", op)
-
-	return nil
-}
-
-func (sa *syncAsyncBridge) handleOperationFailedEvent(event *core.EventDelivery) error {
-	// See if this is a failure of an inflight invoke operation
-	inflight := sa.getInFlight(event.Namespace, invokeOperationConfirm, event.Reference)
-	if inflight == nil {
-		return nil
+	if succeeded {
+		go sa.resolveSuccessfulOperation(inflight, typeName, op)
+	} else {
+		go sa.resolveFailedOperation(inflight, typeName, op)
 	}
 
-	op, err := sa.getOperationFromEvent(event)
-	if err != nil || op == nil {
-		return err
-	}
-
-	go sa.resolveFailedOperation(inflight, "invoke", op)
-
 	return nil
 }
 
@@ -543,16 +496,16 @@ func (sa *syncAsyncBridge) eventCallback(event *core.EventDelivery) error {
 		return sa.handleApprovalOpFailedEvent(event)
 
 	case core.EventTypeBlockchainInvokeOpSucceeded:
-		return sa.handleOperationSucceededEvent(event)
+		return sa.handleOperationEvent(event, invokeOperationConfirm, "invoke", true)
 
 	case core.EventTypeBlockchainInvokeOpFailed:
-		return sa.handleOperationFailedEvent(event)
+		return sa.handleOperationEvent(event, invokeOperationConfirm, "invoke", false)
 
 	case core.EventTypeBlockchainContractDeployOpSucceeded:
-		return sa.handleContractDeployOperationSucceededEvent(event)
+		return sa.handleOperationEvent(event, deployOperationConfirm, "deploy", true)
 
 	case core.EventTypeBlockchainContractDeployOpFailed:
-		return sa.handleContractDeployOperationFailedEvent(event)
+		return sa.handleOperationEvent(event, deployOperationConfirm, "deploy", false)
 	}
 
 	return nil
@@ -648,66 +601,44 @@ func (sa *syncAsyncBridge) sendAndWait(ctx context.Context, ns string, id *fftyp
 	}
 }
 
-func (sa *syncAsyncBridge) WaitForReply(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.MessageInOut, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, messageReply, send)
+// waitFor sends the request, and waits for the correlating response of the given type
+func waitFor[T any](ctx context.Context, sa *syncAsyncBridge, id *fftypes.UUID, reqType requestType, send SendFunction) (T, error) {
+	var result T
+	reply, err := sa.sendAndWait(ctx, sa.namespace, id, reqType, send)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
-	return reply.(*core.MessageInOut), err
+	return reply.(T), nil
+}
+
+func (sa *syncAsyncBridge) WaitForReply(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.MessageInOut, error) {
+	return waitFor[*core.MessageInOut](ctx, sa, id, messageReply, send)
 }
 
 func (sa *syncAsyncBridge) WaitForMessage(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.Message, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, messageConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.Message), err
+	return waitFor[*core.Message](ctx, sa, id, messageConfirm, send)
 }
 
 func (sa *syncAsyncBridge) WaitForIdentity(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.Identity, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, identityConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.Identity), err
+	return waitFor[*core.Identity](ctx, sa, id, identityConfirm, send)
 }
 
 func (sa *syncAsyncBridge) WaitForTokenPool(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.TokenPool, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, tokenPoolConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.TokenPool), err
+	return waitFor[*core.TokenPool](ctx, sa, id, tokenPoolConfirm, send)
 }
 
 func (sa *syncAsyncBridge) WaitForTokenTransfer(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.TokenTransfer, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, tokenTransferConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.TokenTransfer), err
+	return waitFor[*core.TokenTransfer](ctx, sa, id, tokenTransferConfirm, send)
 }
 
 func (sa *syncAsyncBridge) WaitForTokenApproval(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.TokenApproval, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, tokenApproveConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.TokenApproval), err
+	return waitFor[*core.TokenApproval](ctx, sa, id, tokenApproveConfirm, send)
 }
 
 func (sa *syncAsyncBridge) WaitForInvokeOperation(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.Operation, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, invokeOperationConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.Operation), err
+	return waitFor[*core.Operation](ctx, sa, id, invokeOperationConfirm, send)
 }
 
 func (sa *syncAsyncBridge) WaitForDeployOperation(ctx context.Context, id *fftypes.UUID, send SendFunction) (*core.Operation, error) {
-	reply, err := sa.sendAndWait(ctx, sa.namespace, id, deployOperationConfirm, send)
-	if err != nil {
-		return nil, err
-	}
-	return reply.(*core.Operation), err
+	return waitFor[*core.Operation](ctx, sa, id, deployOperationConfirm, send)
 }
@@ -0,0 +1,155 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fftokens
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// connectorUnavailableError is returned without any further wrapping, when a request is rejected
+// by the circuit breaker because the connector is failing
+type connectorUnavailableError struct {
+	err error
+}
+
+func (ue *connectorUnavailableError) Error() string {
+	return ue.err.Error()
+}
+
+// circuitBreaker trips when the proportion of requests to the connector that fail in a window
+// reaches the threshold. Requests are then rejected immediately, rather than each waiting for
+// a timeout, until the open duration has passed. After that a limited number of probe requests
+// are let through - a success closes the circuit, and a failure opens it again.
+type circuitBreaker struct {
+	name           string
+	failureRatio   float64
+	minRequests    int
+	window         time.Duration
+	openDuration   time.Duration
+	halfOpenProbes int
+
+	mux         sync.Mutex
+	state       circuitState
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probes      int
+}
+
+// allow returns an error if the request must be rejected, and whether the request is a half-open probe.
+// Every probe must have its result recorded, as the circuit cannot leave the half-open state otherwise.
+func (cb *circuitBreaker) allow(ctx context.Context) (probe bool, err error) {
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+
+	now := time.Now()
+	switch cb.state {
+	case circuitOpen:
+		if now.Sub(cb.openedAt) < cb.openDuration {
+			return false, &connectorUnavailableError{err: i18n.NewError(ctx, coremsgs.MsgTokensConnectorUnavailable, cb.name)}
+		}
+		log.L(ctx).Infof("Token connector '%s' circuit half-open - sending probe requests", cb.name)
+		cb.state = circuitHalfOpen
+		cb.probes = 0
+		fallthrough
+	case circuitHalfOpen:
+		if cb.probes >= cb.halfOpenProbes {
+			return false, &connectorUnavailableError{err: i18n.NewError(ctx, coremsgs.MsgTokensConnectorUnavailable, cb.name)}
+		}
+		cb.probes++
+		return true, nil
+	default:
+		if now.Sub(cb.windowStart) >= cb.window {
+			cb.windowStart = now
+			cb.requests = 0
+			cb.failures = 0
+		}
+	}
+	return false, nil
+}
+
+func (cb *circuitBreaker) record(ctx context.Context, success bool) {
+	cb.mux.Lock()
+	defer cb.mux.Unlock()
+
+	switch cb.state {
+	case circuitHalfOpen:
+		if success {
+			log.L(ctx).Infof("Token connector '%s' circuit closed - connector has recovered", cb.name)
+			cb.state = circuitClosed
+			cb.windowStart = time.Now()
+			cb.requests = 0
+			cb.failures = 0
+		} else {
+			log.L(ctx).Warnf("Token connector '%s' circuit re-opened - probe request failed", cb.name)
+			cb.state = circuitOpen
+			cb.openedAt = time.Now()
+		}
+	case circuitClosed:
+		cb.requests++
+		if !success {
+			cb.failures++
+		}
+		if cb.requests >= cb.minRequests && float64(cb.failures)/float64(cb.requests) >= cb.failureRatio {
+			log.L(ctx).Errorf("Token connector '%s' circuit opened - %d of %d requests failed", cb.name, cb.failures, cb.requests)
+			cb.state = circuitOpen
+			cb.openedAt = time.Now()
+		}
+	}
+	// Results of requests that were in flight when the circuit opened are ignored
+}
+
+// circuitBreakerTransport applies the circuit breaker to every REST call made to the connector.
+// Errors connecting to the connector and 5xx responses count as failures. Requests abandoned
+// by the caller do not count either way - except for half-open probes, which count as failures
+// as the connector is likely still hanging, and so the circuit re-opens.
+type circuitBreakerTransport struct {
+	breaker *circuitBreaker
+	next    http.RoundTripper
+}
+
+func (cbt *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	probe, err := cbt.breaker.allow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cbt.next.RoundTrip(req)
+	switch {
+	case ctx.Err() == nil:
+		cbt.breaker.record(ctx, err == nil && res.StatusCode < http.StatusInternalServerError)
+	case probe:
+		cbt.breaker.record(ctx, false)
+	}
+	return res, err
+}
@@ -0,0 +1,217 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fftokens
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{
+		name:           "testtokens",
+		failureRatio:   0.5,
+		minRequests:    4,
+		window:         time.Minute,
+		openDuration:   time.Minute,
+		halfOpenProbes: 1,
+	}
+}
+
+func assertAllowed(t *testing.T, cb *circuitBreaker, ctx context.Context) bool {
+	probe, err := cb.allow(ctx)
+	assert.NoError(t, err)
+	return probe
+}
+
+func assertRejected(t *testing.T, cb *circuitBreaker, ctx context.Context) {
+	_, err := cb.allow(ctx)
+	assert.Regexp(t, "FF10602", err)
+}
+
+func newTestFFTokensWithBreaker(t *testing.T) (*FFTokens, *circuitBreaker, string, func()) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	breaker := newTestCircuitBreaker()
+	httpClient := h.client.GetClient()
+	httpClient.Transport = &circuitBreakerTransport{
+		breaker: breaker,
+		next:    httpClient.Transport,
+	}
+	return h, breaker, httpURL, done
+}
+
+func TestCircuitBreakerOpensOnFailureRatio(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	ctx := context.Background()
+
+	for _, success := range []bool{true, false, true} {
+		assertAllowed(t, cb, ctx)
+		cb.record(ctx, success)
+	}
+	assert.Equal(t, circuitClosed, cb.state)
+
+	assertAllowed(t, cb, ctx)
+	cb.record(ctx, false)
+	assert.Equal(t, circuitOpen, cb.state)
+
+	_, err := cb.allow(ctx)
+	assert.Regexp(t, "FF10602.*testtokens", err)
+	assert.IsType(t, &connectorUnavailableError{}, err)
+
+	// A request that was in flight when the circuit opened does not change the state
+	cb.record(ctx, true)
+	assert.Equal(t, circuitOpen, cb.state)
+}
+
+func TestCircuitBreakerWindowReset(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		assertAllowed(t, cb, ctx)
+		cb.record(ctx, false)
+	}
+	cb.windowStart = time.Now().Add(-2 * time.Minute)
+
+	// The failures in the previous window are forgotten
+	assertAllowed(t, cb, ctx)
+	cb.record(ctx, false)
+	assert.Equal(t, circuitClosed, cb.state)
+	assert.Equal(t, 1, cb.failures)
+}
+
+func TestCircuitBreakerHalfOpenRecovers(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	ctx := context.Background()
+	cb.state = circuitOpen
+	cb.openedAt = time.Now().Add(-2 * time.Minute)
+
+	assert.True(t, assertAllowed(t, cb, ctx))
+	assert.Equal(t, circuitHalfOpen, cb.state)
+	assertRejected(t, cb, ctx)
+
+	cb.record(ctx, true)
+	assert.Equal(t, circuitClosed, cb.state)
+	assertAllowed(t, cb, ctx)
+}
+
+func TestCircuitBreakerHalfOpenProbeFails(t *testing.T) {
+	cb := newTestCircuitBreaker()
+	ctx := context.Background()
+	cb.state = circuitOpen
+	cb.openedAt = time.Now().Add(-2 * time.Minute)
+
+	assertAllowed(t, cb, ctx)
+	cb.record(ctx, false)
+	assert.Equal(t, circuitOpen, cb.state)
+	assertRejected(t, cb, ctx)
+}
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	h, breaker, httpURL, done := newTestFFTokensWithBreaker(t)
+	defer done()
+	breaker.minRequests = 2
+
+	pool := &core.TokenPool{
+		Namespace:  "ns1",
+		Locator:    "N1",
+		PluginData: "ns1|pool1",
+	}
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/deactivatepool", httpURL),
+		httpmock.NewStringResponder(http.StatusBadGateway, `{"error":"Bad Gateway"}`))
+
+	for i := 0; i < 2; i++ {
+		err := h.DeactivateTokenPool(context.Background(), pool)
+		assert.Regexp(t, "FF10274", err)
+	}
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+
+	err := h.DeactivateTokenPool(context.Background(), pool)
+	assert.Regexp(t, "^FF10602", err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestCircuitBreakerIgnoresCancelledRequests(t *testing.T) {
+	h, breaker, httpURL, done := newTestFFTokensWithBreaker(t)
+	defer done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/deactivatepool", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return nil, fmt.Errorf("pop")
+		})
+
+	err := h.DeactivateTokenPool(ctx, &core.TokenPool{Namespace: "ns1"})
+	assert.Error(t, err)
+	assert.Equal(t, 0, breaker.requests)
+}
+
+func TestCircuitBreakerCancelledProbeReopens(t *testing.T) {
+	h, breaker, httpURL, done := newTestFFTokensWithBreaker(t)
+	defer done()
+	breaker.state = circuitOpen
+	breaker.openedAt = time.Now().Add(-2 * time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/deactivatepool", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return nil, fmt.Errorf("pop")
+		})
+
+	err := h.DeactivateTokenPool(ctx, &core.TokenPool{Namespace: "ns1"})
+	assert.Error(t, err)
+	assert.Equal(t, circuitOpen, breaker.state)
+
+	// The probe slot is released, so the circuit probes again once the open duration has passed
+	breaker.openedAt = time.Now().Add(-2 * time.Minute)
+	assert.True(t, assertAllowed(t, breaker, context.Background()))
+}
+
+func TestCircuitBreakerDisabledByDefault(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+	_, isBreaker := h.client.GetClient().Transport.(*circuitBreakerTransport)
+	assert.False(t, isBreaker)
+}
+
+func TestCircuitBreakerEnabled(t *testing.T) {
+	coreconfig.Reset()
+	h := &FFTokens{}
+	h.InitConfig(ffTokensConfig)
+	ffTokensConfig.AddKnownKey(ffresty.HTTPConfigURL, "http://localhost:12345")
+	ffTokensConfig.Set(FFTCircuitBreakerEnabled, true)
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
+	assert.NoError(t, err)
+	cbt, isBreaker := h.client.GetClient().Transport.(*circuitBreakerTransport)
+	assert.True(t, isBreaker)
+	assert.Equal(t, 0.5, cbt.breaker.failureRatio)
+	assert.Equal(t, 1, cbt.breaker.halfOpenProbes)
+}
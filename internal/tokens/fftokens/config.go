@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/proxy"
 )
 
 const (
@@ -31,6 +32,12 @@ const (
 	FFTBackgroundStartInitialDelay = "backgroundStart.initialDelay"
 	FFTBackgroundStartMaxDelay     = "backgroundStart.maxDelay"
 	FFTBackgroundStartFactor       = "backgroundStart.factor"
+	FFTCircuitBreakerEnabled       = "circuitBreaker.enabled"
+	FFTCircuitBreakerFailureRatio  = "circuitBreaker.failureRatio"
+	FFTCircuitBreakerMinRequests   = "circuitBreaker.minimumRequests"
+	FFTCircuitBreakerWindow        = "circuitBreaker.window"
+	FFTCircuitBreakerOpenDuration  = "circuitBreaker.openDuration"
+	FFTCircuitBreakerHalfOpenProbe = "circuitBreaker.halfOpenProbes"
 
 	defaultBackgroundInitialDelay = "5s"
 	defaultBackgroundRetryFactor  = 2.0
@@ -39,6 +46,7 @@ const (
 
 func (ft *FFTokens) InitConfig(config config.Section) {
 	wsclient.InitConfig(config)
+	proxy.InitConfig(config)
 
 	config.AddKnownKey(FFTEventRetryInitialDelay, 50*time.Millisecond)
 	config.AddKnownKey(FFTEventRetryMaxDelay, 30*time.Second)
@@ -47,4 +55,10 @@ func (ft *FFTokens) InitConfig(config config.Section) {
 	config.AddKnownKey(FFTBackgroundStartInitialDelay, defaultBackgroundInitialDelay)
 	config.AddKnownKey(FFTBackgroundStartMaxDelay, defaultBackgroundMaxDelay)
 	config.AddKnownKey(FFTBackgroundStartFactor, defaultBackgroundRetryFactor)
+	config.AddKnownKey(FFTCircuitBreakerEnabled, false)
+	config.AddKnownKey(FFTCircuitBreakerFailureRatio, 0.5)
+	config.AddKnownKey(FFTCircuitBreakerMinRequests, 10)
+	config.AddKnownKey(FFTCircuitBreakerWindow, "30s")
+	config.AddKnownKey(FFTCircuitBreakerOpenDuration, "30s")
+	config.AddKnownKey(FFTCircuitBreakerHalfOpenProbe, 1)
 }
@@ -0,0 +1,160 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fftokens
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"strings"
+
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly-common/pkg/log"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+//go:embed schemas/*.json
+var embeddedEventSchemas embed.FS
+
+// eventSchemaFiles contains the schemas that are compiled when the plugin is initialized
+var eventSchemaFiles fs.FS = embeddedEventSchemas
+
+// wsClientActionNack rejects an event that was delivered, for connectors that report the nack feature
+const wsClientActionNack core.WSClientPayloadType = "nack"
+
+const (
+	// validationFailureParse is reported for events that cannot be parsed at all
+	validationFailureParse = "parse"
+	// validationFailureInvalid is reported for schema failures that do not identify a specific keyword
+	validationFailureInvalid = "invalid"
+)
+
+type wsNack struct {
+	core.WSActionBase
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// compileEventSchemas compiles the JSON Schema for the data of each type of event the connector delivers
+func compileEventSchemas(ctx context.Context, fsys fs.FS) (map[msgType]*jsonschema.Schema, error) {
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	schemas := make(map[msgType]*jsonschema.Schema)
+	for _, t := range []msgType{
+		messageReceipt,
+		messageBatch,
+		messageTokenPool,
+		messageTokenMint,
+		messageTokenBurn,
+		messageTokenTransfer,
+		messageTokenApproval,
+		messageStarted,
+		messageActivated,
+	} {
+		name := "schemas/" + string(t) + ".json"
+		schema, err := fs.ReadFile(fsys, name)
+		if err == nil {
+			err = c.AddResource(name, strings.NewReader(string(schema)))
+		}
+		if err == nil {
+			schemas[t], err = c.Compile(name)
+		}
+		if err != nil {
+			return nil, i18n.WrapError(ctx, err, coremsgs.MsgSchemaLoadFailed, t)
+		}
+	}
+	return schemas, nil
+}
+
+// validateEvent checks the data of an event against the schema for its type. The type of the event that failed,
+// and the type of the failure, are returned along with the error. A batch is only valid if every event in it
+// is valid, so that a batch is never partially processed.
+func (ft *FFTokens) validateEvent(ctx context.Context, msg *wsEvent) (eventType msgType, failure string, err error) {
+	schema, ok := ft.eventSchemas[msg.Event]
+	if !ok {
+		// Unexpected event types are logged and skipped when they are handled
+		return msg.Event, "", nil
+	}
+	var data interface{} = map[string]interface{}{}
+	if msg.Data != nil {
+		data = map[string]interface{}(msg.Data)
+	}
+	if err := schema.Validate(data); err != nil {
+		return msg.Event, validationFailureType(err), i18n.NewError(ctx, coremsgs.MsgTokensInvalidEvent, msg.Event, ft.configuredName, err)
+	}
+	if msg.Event == messageBatch {
+		for _, event := range msg.Data.GetObjectArray("events") {
+			var batchMsg *wsEvent
+			if err := json.Unmarshal([]byte(event.String()), &batchMsg); err != nil {
+				return msg.Event, validationFailureParse, i18n.NewError(ctx, coremsgs.MsgTokensInvalidEvent, msg.Event, ft.configuredName, err)
+			}
+			if eventType, failure, err := ft.validateEvent(ctx, batchMsg); err != nil {
+				return eventType, failure, err
+			}
+		}
+	}
+	return msg.Event, "", nil
+}
+
+// validationFailureType returns the schema keyword that an event failed on, such as "required" or "pattern"
+func validationFailureType(err error) string {
+	var ve *jsonschema.ValidationError
+	if errors.As(err, &ve) {
+		for len(ve.Causes) > 0 {
+			ve = ve.Causes[0]
+		}
+		if keyword := ve.KeywordLocation[strings.LastIndex(ve.KeywordLocation, "/")+1:]; keyword != "" {
+			return keyword
+		}
+	}
+	return validationFailureInvalid
+}
+
+// rejectEvent records an event that failed validation, and tells the connector it has been rejected - with a nack
+// if the connector supports them, or otherwise with an ack as the event can never be processed
+func (ft *FFTokens) rejectEvent(ctx context.Context, namespace string, msg *wsEvent, eventType msgType, failure string, err error, ack bool) error {
+	log.L(ctx).Errorf("Rejecting event %s: %s", msg.ID, err)
+	if ft.metrics.IsMetricsEnabled() {
+		ft.metrics.PluginEventValidationFailed(ft.configuredName, string(eventType), failure)
+	}
+	if !ack || msg.Event == messageReceipt || msg.ID == "" {
+		return nil
+	}
+	if !ft.supports(featureNack) {
+		return ft.sendAck(ctx, namespace, msg.ID)
+	}
+	log.L(ctx).Debugf("Sending nack %s", msg.ID)
+	nack, _ := json.Marshal(wsNack{
+		WSActionBase: core.WSActionBase{
+			Type: wsClientActionNack,
+		},
+		ID:     msg.ID,
+		Reason: err.Error(),
+	})
+	return ft.wsconn[namespace].Send(ctx, nack)
+}
+
+// parseFailed records an event that could not be parsed, and so cannot be identified to be rejected
+func (ft *FFTokens) parseFailed() {
+	if ft.metrics.IsMetricsEnabled() {
+		ft.metrics.PluginEventValidationFailed(ft.configuredName, "unknown", validationFailureParse)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fftokens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/hyperledger/firefly-common/pkg/ffresty"
+	"github.com/hyperledger/firefly-common/pkg/wsclient"
+	"github.com/hyperledger/firefly/internal/coreconfig"
+	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
+	"github.com/hyperledger/firefly/mocks/wsmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCompileEventSchemasMissing(t *testing.T) {
+	_, err := compileEventSchemas(context.Background(), fstest.MapFS{})
+	assert.Regexp(t, "FF10196.*receipt", err)
+}
+
+func TestCompileEventSchemasBadSchema(t *testing.T) {
+	_, err := compileEventSchemas(context.Background(), fstest.MapFS{
+		"schemas/receipt.json": {Data: []byte(`{"type": 12345}`)},
+	})
+	assert.Regexp(t, "FF10196.*receipt", err)
+}
+
+func TestInitBadEventSchemas(t *testing.T) {
+	coreconfig.Reset()
+	h := &FFTokens{}
+	h.InitConfig(ffTokensConfig)
+	ffTokensConfig.AddKnownKey(ffresty.HTTPConfigURL, "http://localhost:8080")
+
+	defaultSchemas := eventSchemaFiles
+	eventSchemaFiles = fstest.MapFS{}
+	defer func() { eventSchemaFiles = defaultSchemas }()
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
+	assert.Regexp(t, "FF10196.*receipt", err)
+}
+
+func TestValidateEventMissingField(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	eventType, failure, err := h.validateEvent(context.Background(), &wsEvent{
+		Event: messageTokenPool,
+		Data:  map[string]interface{}{"type": "fungible"},
+	})
+	assert.Regexp(t, "FF10616.*token-pool.*testtokens", err)
+	assert.Equal(t, messageTokenPool, eventType)
+	assert.Equal(t, "required", failure)
+}
+
+func TestValidateEventBadAmount(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	_, failure, err := h.validateEvent(context.Background(), &wsEvent{
+		Event: messageTokenMint,
+		Data: map[string]interface{}{
+			"id":          "1",
+			"poolLocator": "F1",
+			"to":          "0x0",
+			"amount":      "-1",
+			"blockchain":  map[string]interface{}{"id": "1"},
+		},
+	})
+	assert.Regexp(t, "FF10616", err)
+	assert.Equal(t, "pattern", failure)
+}
+
+func TestValidateEventEmptyFrom(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	_, failure, err := h.validateEvent(context.Background(), &wsEvent{
+		Event: messageTokenTransfer,
+		Data: map[string]interface{}{
+			"id":          "1",
+			"poolLocator": "F1",
+			"from":        "",
+			"to":          "0x0",
+			"amount":      "1",
+			"blockchain":  map[string]interface{}{"id": "1"},
+		},
+	})
+	assert.Regexp(t, "FF10616", err)
+	assert.Equal(t, "minLength", failure)
+}
+
+func TestValidateEventUnknownType(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	eventType, failure, err := h.validateEvent(context.Background(), &wsEvent{Event: "unknown"})
+	assert.NoError(t, err)
+	assert.Equal(t, msgType("unknown"), eventType)
+	assert.Empty(t, failure)
+}
+
+func TestValidateEventBatchInvalidEvent(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	eventType, failure, err := h.validateEvent(context.Background(), &wsEvent{
+		Event: messageBatch,
+		Data: map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{
+					"event": "token-pool",
+					"data":  map[string]interface{}{"type": "fungible", "poolLocator": "F1"},
+				},
+				map[string]interface{}{
+					"event": "token-pool",
+					"data":  map[string]interface{}{"type": "fungible"},
+				},
+			},
+		},
+	})
+	assert.Regexp(t, "FF10616.*token-pool", err)
+	assert.Equal(t, messageTokenPool, eventType)
+	assert.Equal(t, "required", failure)
+}
+
+func TestValidateEventBatchUnparseableEvent(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	eventType, failure, err := h.validateEvent(context.Background(), &wsEvent{
+		Event: messageBatch,
+		Data: map[string]interface{}{
+			"events": []interface{}{
+				map[string]interface{}{
+					"id":    1,
+					"event": "token-pool",
+				},
+			},
+		},
+	})
+	assert.Regexp(t, "FF10616.*batch", err)
+	assert.Equal(t, messageBatch, eventType)
+	assert.Equal(t, validationFailureParse, failure)
+}
+
+func TestValidationFailureTypeNotSchemaError(t *testing.T) {
+	assert.Equal(t, validationFailureInvalid, validationFailureType(fmt.Errorf("pop")))
+}
+
+func TestHandleMessageInvalidBatchNotProcessed(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	wsm := &wsmocks.WSClient{}
+	h.wsconn["ns1"] = wsm
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("PluginEventValidationFailed", "testtokens", "token-pool", "required").Return()
+	h.metrics = mmi
+	wsm.On("Send", mock.Anything, mock.MatchedBy(func(b []byte) bool {
+		return string(b) == `{"type":"ack","id":"1"}`
+	})).Return(nil)
+
+	// The first event is valid, but must not be delivered as the second is not
+	retry, err := h.handleMessage(context.Background(), "ns1", []byte(`{
+		"id": "1",
+		"event": "batch",
+		"data": {
+			"events": [{
+				"event": "token-pool",
+				"data": {"type": "fungible", "poolLocator": "F1"}
+			}, {
+				"event": "token-pool",
+				"data": {"type": "fungible"}
+			}]
+		}
+	}`), true)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	wsm.AssertExpectations(t)
+	mmi.AssertExpectations(t)
+}
+
+func TestHandleMessageInvalidNack(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	wsm := &wsmocks.WSClient{}
+	h.wsconn["ns1"] = wsm
+	h.setConnectorInfo(&connectorInfo{ProtocolVersion: protocolVersion1, Features: []string{"nack"}})
+	wsm.On("Send", mock.Anything, mock.MatchedBy(func(b []byte) bool {
+		var nack wsNack
+		_ = json.Unmarshal(b, &nack)
+		return nack.Type == wsClientActionNack && nack.ID == "1" && strings.Contains(nack.Reason, "FF10616")
+	})).Return(nil)
+
+	retry, err := h.handleMessage(context.Background(), "ns1", []byte(`{"id":"1","event":"token-mint","data":{}}`), true)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	wsm.AssertExpectations(t)
+}
+
+func TestHandleMessageInvalidNoAck(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	retry, err := h.handleMessage(context.Background(), "ns1", []byte(`{"id":"1","event":"token-mint","data":{}}`), false)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+}
+
+func TestHandleMessageParseFailedMetric(t *testing.T) {
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(true)
+	mmi.On("PluginEventValidationFailed", "testtokens", "unknown", validationFailureParse).Return()
+	h := &FFTokens{
+		configuredName: "testtokens",
+		metrics:        mmi,
+	}
+
+	retry, err := h.handleMessage(context.Background(), "ns1", []byte(`!json`), true)
+	assert.NoError(t, err)
+	assert.False(t, retry)
+
+	mmi.AssertExpectations(t)
+}
+
+func TestReceiveMessageQueuedInvalidNotEnqueued(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	wsm := &wsmocks.WSClient{}
+	mq := &coremocks.InboundEventQueue{}
+	h.wsconn = map[string]wsclient.WSClient{"ns1": wsm}
+	h.eventQueue = mq
+	wsm.On("Send", mock.Anything, mock.MatchedBy(func(b []byte) bool {
+		return string(b) == `{"type":"ack","id":"1"}`
+	})).Return(nil)
+
+	err := h.receiveMessage(context.Background(), "ns1", []byte(`{"id":"1","event":"token-pool","data":{}}`))
+	assert.NoError(t, err)
+
+	wsm.AssertExpectations(t)
+	mq.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -19,7 +19,11 @@ package fftokens
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -34,9 +38,12 @@ import (
 	"github.com/hyperledger/firefly-signer/pkg/abi"
 	"github.com/hyperledger/firefly-signer/pkg/ffi2abi"
 	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/hyperledger/firefly/internal/metrics"
+	"github.com/hyperledger/firefly/internal/proxy"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 	"github.com/hyperledger/firefly/pkg/tokens"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
 type ConflictError struct {
@@ -59,9 +66,19 @@ type FFTokens struct {
 	configuredName  string
 	client          *resty.Client
 	wsconn          map[string]wsclient.WSClient
+	wsConnected     map[string]bool
+	wsConnectedMux  sync.Mutex
+	connections     core.PluginConnectionTracker
+	eventQueue      core.InboundEventQueue
 	wsConfig        *wsclient.WSConfig
+	wsFactory       proxy.WSFactory
 	retry           *retry.Retry
 	poolsToActivate map[string][]*core.TokenPool
+	metrics         metrics.Manager
+	eventSchemas    map[msgType]*jsonschema.Schema
+
+	connectorInfo    *connectorInfo
+	connectorInfoMux sync.Mutex
 }
 
 type callbacks struct {
@@ -288,10 +305,11 @@ func (ft *FFTokens) ConnectorName() string {
 	return ft.configuredName
 }
 
-func (ft *FFTokens) Init(ctx context.Context, cancelCtx context.CancelFunc, name string, config config.Section) (err error) {
+func (ft *FFTokens) Init(ctx context.Context, cancelCtx context.CancelFunc, name string, config config.Section, metrics metrics.Manager) (err error) {
 	ft.ctx = log.WithLogField(ctx, "proto", "fftokens")
 	ft.cancelCtx = cancelCtx
 	ft.configuredName = name
+	ft.metrics = metrics
 	ft.capabilities = &tokens.Capabilities{}
 	ft.callbacks = callbacks{
 		plugin:     ft,
@@ -303,20 +321,45 @@ func (ft *FFTokens) Init(ctx context.Context, cancelCtx context.CancelFunc, name
 		return i18n.NewError(ctx, coremsgs.MsgMissingPluginConfig, "url", "tokens.fftokens")
 	}
 
+	if ft.eventSchemas, err = compileEventSchemas(ctx, eventSchemaFiles); err != nil {
+		return err
+	}
+
 	ft.wsConfig, err = wsclient.GenerateConfig(ctx, config)
 	if err == nil {
-		ft.client, err = ffresty.New(ft.ctx, config)
+		ft.client, err = proxy.NewRESTClient(ft.ctx, config)
 	}
 
+	if err == nil {
+		ft.wsFactory, err = proxy.NewWSFactory(ctx, config)
+	}
 	if err != nil {
 		return err
 	}
 
+	ft.client.SetHeader(protocolVersionHeader, strconv.Itoa(protocolVersionSupported))
+
+	if config.GetBool(FFTCircuitBreakerEnabled) {
+		httpClient := ft.client.GetClient()
+		httpClient.Transport = &circuitBreakerTransport{
+			next: httpClient.Transport,
+			breaker: &circuitBreaker{
+				name:           name,
+				failureRatio:   config.GetFloat64(FFTCircuitBreakerFailureRatio),
+				minRequests:    config.GetInt(FFTCircuitBreakerMinRequests),
+				window:         config.GetDuration(FFTCircuitBreakerWindow),
+				openDuration:   config.GetDuration(FFTCircuitBreakerOpenDuration),
+				halfOpenProbes: config.GetInt(FFTCircuitBreakerHalfOpenProbe),
+			},
+		}
+	}
+
 	if ft.wsConfig.WSKeyPath == "" {
 		ft.wsConfig.WSKeyPath = "/api/ws"
 	}
 
 	ft.wsconn = make(map[string]wsclient.WSClient)
+	ft.wsConnected = make(map[string]bool)
 
 	ft.retry = &retry.Retry{
 		InitialDelay: config.GetDuration(FFTEventRetryInitialDelay),
@@ -343,10 +386,17 @@ func (ft *FFTokens) sendWSStartMsg(ctx context.Context, w wsclient.WSClient, nam
 
 func (ft *FFTokens) StartNamespace(ctx context.Context, namespace string, activePools []*core.TokenPool) (err error) {
 	if ft.wsconn[namespace] == nil {
-		ft.wsconn[namespace], err = wsclient.New(ctx, ft.wsConfig, nil, func(ctx context.Context, w wsclient.WSClient) error {
-			// On connect send start namespace message
-			// Will occur on reconnect as well
-			return ft.sendWSStartMsg(ctx, w, namespace)
+		ft.wsconn[namespace], err = ft.wsFactory.New(ctx, ft.wsConfig, func(ctx context.Context, w wsclient.WSClient) error {
+			// Called before every attempt to (re)connect, so the previous connection has been lost
+			ft.setWSConnected(namespace, false)
+			return nil
+		}, func(ctx context.Context, w wsclient.WSClient) error {
+			// On connect negotiate the protocol version, and send start namespace message
+			// Will occur on reconnect as well, in case the connector has been upgraded
+			ft.negotiateProtocol(ctx)
+			err := ft.sendWSStartMsg(ctx, w, namespace)
+			ft.setWSConnected(namespace, err == nil)
+			return err
 		})
 		if err != nil {
 			return err
@@ -376,10 +426,32 @@ func (ft *FFTokens) StopNamespace(ctx context.Context, namespace string) error {
 		wsconn.Close()
 	}
 	delete(ft.wsconn, namespace)
+	ft.wsConnectedMux.Lock()
+	delete(ft.wsConnected, namespace)
+	ft.wsConnectedMux.Unlock()
+	ft.connections.Remove(namespace)
 
 	return nil
 }
 
+func (ft *FFTokens) setWSConnected(namespace string, connected bool) {
+	ft.wsConnectedMux.Lock()
+	ft.wsConnected[namespace] = connected
+	ft.wsConnectedMux.Unlock()
+	ft.connections.Update(namespace, connected)
+}
+
+func (ft *FFTokens) CheckReady(ctx context.Context) error {
+	ft.wsConnectedMux.Lock()
+	defer ft.wsConnectedMux.Unlock()
+	for namespace, connected := range ft.wsConnected {
+		if !connected {
+			return i18n.NewError(ctx, coremsgs.MsgWebSocketNotConnected, fmt.Sprintf("%s (namespace=%s)", ft.configuredName, namespace))
+		}
+	}
+	return nil
+}
+
 func (ft *FFTokens) SetHandler(namespace string, handler tokens.Callbacks) {
 	ft.callbacks.writeLock.Lock()
 	defer ft.callbacks.writeLock.Unlock()
@@ -390,6 +462,14 @@ func (ft *FFTokens) SetHandler(namespace string, handler tokens.Callbacks) {
 	}
 }
 
+func (ft *FFTokens) SetConnectionListener(listener core.PluginConnectionListener) {
+	ft.connections.SetListener(listener)
+}
+
+func (ft *FFTokens) SetEventQueue(queue core.InboundEventQueue) {
+	ft.eventQueue = queue
+}
+
 func (ft *FFTokens) SetOperationHandler(namespace string, handler core.OperationCallbacks) {
 	ft.callbacks.writeLock.Lock()
 	defer ft.callbacks.writeLock.Unlock()
@@ -457,17 +537,27 @@ func (ft *FFTokens) buildBlockchainEvent(eventData fftypes.JSONObject) *blockcha
 	return nil
 }
 
+// chainPosition returns a numeric position of the event on the chain (such as the block number), if the connector
+// reported one in the blockchain info. Values may be JSON numbers, decimal strings or 0x prefixed hex strings.
+func chainPosition(info fftypes.JSONObject, key string) *int64 {
+	s := info.GetString(key)
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	if i, ok := new(big.Int).SetString(s, base); ok && i.IsInt64() {
+		v := i.Int64()
+		return &v
+	}
+	return nil
+}
+
 func (ft *FFTokens) handleTokenPoolCreate(ctx context.Context, eventData fftypes.JSONObject, txData *tokenData) (err error) {
 
 	tokenType := eventData.GetString("type")
 	poolLocator := eventData.GetString("poolLocator")
 	alternateLocators := eventData.GetStringArray("alternateLocators")
 
-	if tokenType == "" || poolLocator == "" {
-		log.L(ctx).Errorf("TokenPool event is not valid - missing data: %+v", eventData)
-		return nil // move on
-	}
-
 	// These fields are optional
 	standard := eventData.GetString("standard")
 	interfaceFormat := eventData.GetString("interfaceFormat")
@@ -529,16 +619,7 @@ func (ft *FFTokens) handleTokenTransfer(ctx context.Context, t core.TokenTransfe
 	value := eventData.GetString("amount")
 	blockchainEvent := ft.buildBlockchainEvent(eventData.GetObject("blockchain"))
 
-	if protocolID == "" ||
-		poolLocator == "" ||
-		value == "" ||
-		(t != core.TokenTransferTypeMint && fromAddress == "") ||
-		(t != core.TokenTransferTypeBurn && toAddress == "") ||
-		blockchainEvent == nil {
-		log.L(ctx).Errorf("%s event is not valid - missing data: %+v", t, eventData)
-		return nil // move on
-	}
-
+	// The required fields, and the format of the amount, have been checked against the schema of the event.
 	// These fields are optional
 	tokenIndex := eventData.GetString("tokenIndex")
 	uri := eventData.GetString("uri")
@@ -554,11 +635,7 @@ func (ft *FFTokens) handleTokenTransfer(ctx context.Context, t core.TokenTransfe
 	}
 
 	var amount fftypes.FFBigInt
-	_, ok := amount.Int().SetString(value, 10)
-	if !ok {
-		log.L(ctx).Errorf("%s event is not valid - invalid amount: %+v", t, eventData)
-		return nil // move on
-	}
+	amount.Int().SetString(value, 10)
 
 	txType := transferData.TXType
 	if txType == "" {
@@ -584,6 +661,9 @@ func (ft *FFTokens) handleTokenTransfer(ctx context.Context, t core.TokenTransfe
 				ID:   transferData.TX,
 				Type: txType,
 			},
+			BlockNumber:      chainPosition(blockchainEvent.Info, "blockNumber"),
+			TransactionIndex: chainPosition(blockchainEvent.Info, "transactionIndex"),
+			LogIndex:         chainPosition(blockchainEvent.Info, "logIndex"),
 		},
 		Event: blockchainEvent,
 	}
@@ -601,15 +681,6 @@ func (ft *FFTokens) handleTokenApproval(ctx context.Context, eventData fftypes.J
 	approved := eventData.GetBool("approved")
 	blockchainEvent := ft.buildBlockchainEvent(eventData.GetObject("blockchain"))
 
-	if protocolID == "" ||
-		subject == "" ||
-		poolLocator == "" ||
-		operatorAddress == "" ||
-		blockchainEvent == nil {
-		log.L(ctx).Errorf("Approval event is not valid - missing data: %+v", eventData)
-		return nil // move on
-	}
-
 	// These fields are optional
 	info := eventData.GetObject("info")
 	namespace, poolID := unpackPoolData(ctx, eventData.GetString("poolData"))
@@ -652,19 +723,24 @@ func (ft *FFTokens) handleTokenApproval(ctx context.Context, eventData fftypes.J
 	return ft.callbacks.TokensApproved(ctx, namespace, approval)
 }
 
-func (ft *FFTokens) handleMessage(ctx context.Context, namespace string, msgBytes []byte) (retry bool, err error) {
+func (ft *FFTokens) handleMessage(ctx context.Context, namespace string, msgBytes []byte, ack bool) (retry bool, err error) {
 	var msg *wsEvent
 	if err = json.Unmarshal(msgBytes, &msg); err != nil {
 		log.L(ctx).Errorf("Message cannot be parsed as JSON: %s\n%s", err, string(msgBytes))
+		ft.parseFailed()
 		return false, nil // Swallow this and move on
 	}
 	log.L(ctx).Debugf("Received %s event %s", msg.Event, msg.ID)
+	if eventType, failure, err := ft.validateEvent(ctx, msg); err != nil {
+		// Do not retry this - it will never be valid
+		return false, ft.rejectEvent(ctx, namespace, msg, eventType, failure, err, ack)
+	}
 	switch msg.Event {
 	case messageReceipt:
 		ft.handleReceipt(ctx, msg.Data)
 	case messageBatch:
 		for _, msg := range msg.Data.GetObjectArray("events") {
-			if retry, err = ft.handleMessage(ctx, namespace, []byte(msg.String())); err != nil {
+			if retry, err = ft.handleMessage(ctx, namespace, []byte(msg.String()), ack); err != nil {
 				return retry, err
 			}
 		}
@@ -690,25 +766,62 @@ func (ft *FFTokens) handleMessage(ctx context.Context, namespace string, msgByte
 		// All errors above are retryable
 		return true, err
 	}
-	if msg.Event != messageReceipt && msg.ID != "" {
-		log.L(ctx).Debugf("Sending ack %s", msg.ID)
-		ack, _ := json.Marshal(wsAck{
-			WSActionBase: core.WSActionBase{
-				Type: core.WSClientActionAck,
-			},
-			ID: msg.ID,
-		})
+	if ack && msg.Event != messageReceipt && msg.ID != "" {
 		// Do not retry this
-		return false, ft.wsconn[namespace].Send(ctx, ack)
+		return false, ft.sendAck(ctx, namespace, msg.ID)
 	}
 	return false, nil
 }
 
+func (ft *FFTokens) sendAck(ctx context.Context, namespace, id string) error {
+	log.L(ctx).Debugf("Sending ack %s", id)
+	ack, _ := json.Marshal(wsAck{
+		WSActionBase: core.WSActionBase{
+			Type: core.WSClientActionAck,
+		},
+		ID: id,
+	})
+	return ft.wsconn[namespace].Send(ctx, ack)
+}
+
+// receiveMessage processes a message read from the connector, unless there is an event queue - in which case
+// the message is acknowledged as soon as it is stored in the queue, and processed later by the consumer
+func (ft *FFTokens) receiveMessage(ctx context.Context, namespace string, msgBytes []byte) error {
+	if ft.eventQueue == nil {
+		return ft.handleMessageRetry(ctx, namespace, msgBytes)
+	}
+	var msg *wsEvent
+	if err := json.Unmarshal(msgBytes, &msg); err != nil {
+		log.L(ctx).Errorf("Message cannot be parsed as JSON: %s\n%s", err, string(msgBytes))
+		ft.parseFailed()
+		return nil // Swallow this and move on
+	}
+	if msg.Event == messageReceipt || msg.ID == "" {
+		// Nothing to acknowledge, so there is no need to queue the message
+		return ft.handleMessageRetry(ctx, namespace, msgBytes)
+	}
+	if eventType, failure, err := ft.validateEvent(ctx, msg); err != nil {
+		// Invalid events are rejected before they are queued, so the rejection is returned to the connector
+		return ft.rejectEvent(ctx, namespace, msg, eventType, failure, err, true)
+	}
+	if err := ft.eventQueue.Enqueue(ctx, namespace, msgBytes); err != nil {
+		return err
+	}
+	return ft.sendAck(ctx, namespace, msg.ID)
+}
+
+func (ft *FFTokens) handleQueuedMessage(namespace string) core.InboundEventHandler {
+	return func(ctx context.Context, msgBytes []byte) error {
+		_, err := ft.handleMessage(ctx, namespace, msgBytes, false /* acknowledged when queued */)
+		return err
+	}
+}
+
 func (ft *FFTokens) handleMessageRetry(ctx context.Context, namespace string, msgBytes []byte) (err error) {
 	eventCtx, done := context.WithCancel(ctx)
 	defer done()
 	return ft.retry.Do(eventCtx, "fftokens event", func(attempt int) (retry bool, err error) {
-		return ft.handleMessage(eventCtx, namespace, msgBytes) // We keep retrying on error until the context ends
+		return ft.handleMessage(eventCtx, namespace, msgBytes, true) // We keep retrying on error until the context ends
 	})
 }
 
@@ -739,6 +852,12 @@ func (ft *FFTokens) eventLoop(namespace string) {
 	defer wsconn.Close()
 	l := log.L(ft.ctx).WithField("role", "event-loop")
 	ctx := log.WithLogger(ft.ctx, l)
+	if ft.eventQueue != nil {
+		// Queued messages are processed for as long as the event loop is running
+		consumeCtx, stopConsuming := context.WithCancel(ctx)
+		defer stopConsuming()
+		ft.eventQueue.Consume(consumeCtx, namespace, ft.handleQueuedMessage(namespace))
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -750,7 +869,7 @@ func (ft *FFTokens) eventLoop(namespace string) {
 				ft.cancelCtx()
 				return
 			}
-			if err := ft.handleMessageRetry(ctx, namespace, msgBytes); err != nil {
+			if err := ft.receiveMessage(ctx, namespace, msgBytes); err != nil {
 				l.Errorf("Event loop exiting (%s). Terminating server!", err)
 				ft.cancelCtx()
 				return
@@ -765,6 +884,10 @@ func (ft *FFTokens) eventLoop(namespace string) {
 //
 //	"Bad Request: Field 'x' is required"
 func wrapError(ctx context.Context, errRes *tokenError, res *resty.Response, err error) error {
+	var unavailable *connectorUnavailableError
+	if errors.As(err, &unavailable) {
+		return unavailable.err
+	}
 	if errRes != nil && (errRes.Message != "" || errRes.Error != "") {
 		errMsgFromBody := errRes.Message
 		if errRes.Error != "" {
@@ -811,6 +934,9 @@ func (ft *FFTokens) CreateTokenPool(ctx context.Context, nsOpID string, pool *co
 			return core.OpPhaseComplete, i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, res.Body())
 		}
 		obj["poolData"] = packPoolData(pool.Namespace, pool.ID)
+		if _, _, err := ft.validateEvent(ctx, &wsEvent{Event: messageTokenPool, Data: obj}); err != nil {
+			return core.OpPhaseComplete, err
+		}
 		return core.OpPhaseComplete, ft.handleTokenPoolCreate(ctx, obj, tokenData)
 	}
 	// Default (HTTP 202): Request was accepted, and success/failure status will be delivered via websocket
@@ -845,6 +971,9 @@ func (ft *FFTokens) ActivateTokenPool(ctx context.Context, pool *core.TokenPool)
 		if err := json.Unmarshal(res.Body(), &obj); err != nil {
 			return core.OpPhaseComplete, i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, res.Body())
 		}
+		if _, _, err := ft.validateEvent(ctx, &wsEvent{Event: messageTokenPool, Data: obj}); err != nil {
+			return core.OpPhaseComplete, err
+		}
 		return core.OpPhaseComplete, ft.handleTokenPoolCreate(ctx, obj, &tokenData{
 			TX:     pool.TX.ID,
 			TXType: pool.TX.Type,
@@ -888,6 +1017,12 @@ func (ft *FFTokens) prepareABI(ctx context.Context, methods []*fftypes.FFIMethod
 }
 
 func (ft *FFTokens) CheckInterface(ctx context.Context, pool *core.TokenPool, methods []*fftypes.FFIMethod) (*fftypes.JSONAny, error) {
+	if !ft.supports(featureInterface) {
+		// Connector will use its default methods, as no interface can be passed to it
+		log.L(ctx).Infof("Tokens connector '%s' does not support contract interfaces (protocol version %d)", ft.configuredName, ft.protocolVersion())
+		return nil, nil
+	}
+
 	body := checkInterface{
 		PoolLocator: pool.Locator,
 		tokenInterface: tokenInterface{
@@ -932,6 +1067,10 @@ func (ft *FFTokens) MintTokens(ctx context.Context, nsOpID string, poolLocator s
 		MessageHash: mint.MessageHash,
 	})
 
+	if mint.URI != "" && !ft.supports(featureURI) {
+		return i18n.NewError(ctx, coremsgs.MsgTokensFeatureNotSupported, ft.configuredName, featureURI, ft.protocolVersion())
+	}
+
 	var iface interface{}
 	if methods != nil {
 		iface = methods.JSONObject()["mint"]
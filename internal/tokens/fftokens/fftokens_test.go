@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/mocks/coremocks"
+	"github.com/hyperledger/firefly/mocks/metricsmocks"
 	"github.com/hyperledger/firefly/mocks/tokenmocks"
 	"github.com/hyperledger/firefly/mocks/wsmocks"
 	"github.com/hyperledger/firefly/pkg/core"
@@ -47,6 +48,12 @@ import (
 
 var ffTokensConfig = config.RootSection("fftokens")
 
+func newTestMetrics() *metricsmocks.Manager {
+	mmi := &metricsmocks.Manager{}
+	mmi.On("IsMetricsEnabled").Return(false).Maybe()
+	return mmi
+}
+
 func newTestFFTokens(t *testing.T) (h *FFTokens, toServer, fromServer chan string, httpURL string, done func()) {
 	mockedClient := &http.Client{}
 	httpmock.ActivateNonDefault(mockedClient)
@@ -66,7 +73,7 @@ func newTestFFTokens(t *testing.T) (h *FFTokens, toServer, fromServer chan strin
 	config.Set("tokens", []fftypes.JSONObject{{}})
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig)
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
 	assert.NoError(t, err)
 	assert.Equal(t, "fftokens", h.Name())
 	assert.Equal(t, "testtokens", h.configuredName)
@@ -102,7 +109,7 @@ func TestInitBadURL(t *testing.T) {
 	ffTokensConfig.AddKnownKey(ffresty.HTTPConfigURL, "::::////")
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig)
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
 	assert.NoError(t, err)
 
 	err = h.StartNamespace(ctx, "ns1", []*core.TokenPool{})
@@ -118,7 +125,7 @@ func TestStartNamespaceConnectFail(t *testing.T) {
 	ffTokensConfig.Set(FFTBackgroundStart, true)
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig)
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
 	assert.NoError(t, err)
 
 	err = h.StartNamespace(ctx, "ns1", []*core.TokenPool{})
@@ -149,17 +156,44 @@ func TestInitBadTLS(t *testing.T) {
 	ffTokensConfig.AddKnownKey(ffresty.HTTPConfigURL, "http://localhost:8080")
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig)
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
 	assert.Regexp(t, "FF00153", err)
 }
 
+func TestInitBadProxy(t *testing.T) {
+	coreconfig.Reset()
+	h := &FFTokens{}
+	h.InitConfig(ffTokensConfig)
+
+	ffTokensConfig.AddKnownKey(ffresty.HTTPConfigURL, "http://localhost:8080")
+	ffTokensConfig.Set(ffresty.HTTPConfigProxyURL, "ftp://proxy.example.com")
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
+	assert.Regexp(t, "FF10611", err)
+}
+
+func TestInitWebSocketProxy(t *testing.T) {
+	coreconfig.Reset()
+	h := &FFTokens{}
+	h.InitConfig(ffTokensConfig)
+
+	ffTokensConfig.AddKnownKey(ffresty.HTTPConfigURL, "http://tokens.example.com:8080")
+	ffTokensConfig.Set(ffresty.HTTPConfigProxyURL, "http://proxy.example.com:8080")
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	defer cancelCtx()
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
+	assert.NoError(t, err)
+}
+
 func TestInitMissingURL(t *testing.T) {
 	coreconfig.Reset()
 	h := &FFTokens{}
 	h.InitConfig(ffTokensConfig)
 
 	ctx, cancelCtx := context.WithCancel(context.Background())
-	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig)
+	err := h.Init(ctx, cancelCtx, "testtokens", ffTokensConfig, newTestMetrics())
 	assert.Regexp(t, "FF10138", err)
 }
 
@@ -390,6 +424,30 @@ func TestCreateTokenPoolSynchronousBadResponse(t *testing.T) {
 	assert.Regexp(t, "FF00127", err)
 }
 
+func TestCreateTokenPoolSynchronousInvalidPool(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	opID := fftypes.NewUUID()
+	nsOpID := "ns1:" + opID.String()
+	pool := &core.TokenPool{
+		ID:        fftypes.NewUUID(),
+		Namespace: "ns1",
+		Name:      "new-pool",
+		Type:      "fungible",
+		Key:       "0x123",
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/createpool", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"type": "fungible",
+		}))
+
+	phase, err := h.CreateTokenPool(context.Background(), nsOpID, pool)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.Regexp(t, "FF10616.*token-pool", err)
+}
+
 func TestActivateTokenPool(t *testing.T) {
 	h, _, _, httpURL, done := newTestFFTokens(t)
 	defer done()
@@ -546,6 +604,25 @@ func TestActivateTokenPoolSynchronousBadResponse(t *testing.T) {
 	assert.Regexp(t, "FF00127", err)
 }
 
+func TestActivateTokenPoolSynchronousInvalidPool(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	pool := &core.TokenPool{
+		Namespace: "ns1",
+		Locator:   "N1",
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/activatepool", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{
+			"poolLocator": "N1",
+		}))
+
+	phase, err := h.ActivateTokenPool(context.Background(), pool)
+	assert.Equal(t, core.OpPhaseComplete, phase)
+	assert.Regexp(t, "FF10616.*token-pool", err)
+}
+
 func TestActivateTokenPoolNoContent(t *testing.T) {
 	h, _, _, httpURL, done := newTestFFTokens(t)
 	defer done()
@@ -1019,6 +1096,22 @@ func TestWSStartEvent(t *testing.T) {
 	assert.Equal(t, "{\"type\":\"start\",\"autoack\":null,\"namespace\":\"ns1\",\"name\":\"\",\"ephemeral\":false,\"filter\":{\"message\":{},\"transaction\":{},\"blockchainevent\":{}},\"options\":{}}", string(msg))
 }
 
+func TestCheckReady(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	assert.NoError(t, h.CheckReady(context.Background()))
+
+	h.setWSConnected("ns1", true)
+	h.setWSConnected("ns2", false)
+	err := h.CheckReady(context.Background())
+	assert.Regexp(t, "FF10514.*testtokens \\(namespace=ns2\\)", err)
+
+	err = h.StopNamespace(context.Background(), "ns2")
+	assert.NoError(t, err)
+	assert.NoError(t, h.CheckReady(context.Background()))
+}
+
 func TestReceiptEvents(t *testing.T) {
 	h, _, fromServer, _, done := newTestFFTokens(t)
 	defer done()
@@ -1205,9 +1298,13 @@ func TestPoolEvents(t *testing.T) {
 	}.String()
 
 	// token-pool: batch + callback fail (terminates loop)
+	failed := make(chan struct{})
 	mcb.On("TokenPoolCreated", mock.Anything, h, mock.MatchedBy(func(p *tokens.TokenPool) bool {
 		return p.PoolLocator == "F1" && p.Type == core.TokenTypeFungible && txID.Equals(p.TX.ID) && p.Event.ProtocolID == "000000000010/000020/000030"
-	})).Return(fmt.Errorf("pop")).Once()
+	})).Return(fmt.Errorf("pop")).Once().Run(func(args mock.Arguments) {
+		h.cancelCtx()
+		close(failed)
+	})
 	fromServer <- fftypes.JSONObject{
 		"id":    "9",
 		"event": "batch",
@@ -1230,6 +1327,7 @@ func TestPoolEvents(t *testing.T) {
 			}},
 		},
 	}.String()
+	<-failed
 }
 
 func TestTransferEvents(t *testing.T) {
@@ -1280,7 +1378,8 @@ func TestTransferEvents(t *testing.T) {
 
 	// token-mint: success
 	mcb.On("TokensTransferred", h, mock.MatchedBy(func(t *tokens.TokenTransfer) bool {
-		return t.Amount.Int().Int64() == 2 && t.To == "0x0" && t.TokenIndex == "" && *t.TX.ID == *txID && t.PoolLocator == "F1" && t.Event.ProtocolID == "000000000010/000020/000030"
+		return t.Amount.Int().Int64() == 2 && t.To == "0x0" && t.TokenIndex == "" && *t.TX.ID == *txID && t.PoolLocator == "F1" && t.Event.ProtocolID == "000000000010/000020/000030" &&
+			*t.BlockNumber == 10 && *t.TransactionIndex == 20 && *t.LogIndex == 30
 	})).Return(nil).Once()
 	fromServer <- fftypes.JSONObject{
 		"id":    "11",
@@ -1296,7 +1395,10 @@ func TestTransferEvents(t *testing.T) {
 			"blockchain": fftypes.JSONObject{
 				"id": "000000000010/000020/000030",
 				"info": fftypes.JSONObject{
-					"transactionHash": "0xffffeeee",
+					"transactionHash":  "0xffffeeee",
+					"blockNumber":      "10",
+					"transactionIndex": "0x14",
+					"logIndex":         30,
 				},
 			},
 		},
@@ -1306,7 +1408,8 @@ func TestTransferEvents(t *testing.T) {
 
 	// token-mint: invalid uuid (success)
 	mcb.On("TokensTransferred", h, mock.MatchedBy(func(t *tokens.TokenTransfer) bool {
-		return t.Amount.Int().Int64() == 1 && t.To == "0x0" && t.TokenIndex == "1" && t.PoolLocator == "N1" && t.Event.ProtocolID == "000000000010/000020/000030"
+		return t.Amount.Int().Int64() == 1 && t.To == "0x0" && t.TokenIndex == "1" && t.PoolLocator == "N1" && t.Event.ProtocolID == "000000000010/000020/000030" &&
+			t.BlockNumber == nil && t.TransactionIndex == nil && t.LogIndex == nil
 	})).Return(nil).Once()
 	fromServer <- fftypes.JSONObject{
 		"id":    "12",
@@ -1432,9 +1535,13 @@ func TestTransferEvents(t *testing.T) {
 	assert.JSONEq(t, `{"id":"16","type":"ack"}`, string(msg))
 
 	// token-transfer: callback fail
+	failed := make(chan struct{})
 	mcb.On("TokensTransferred", h, mock.MatchedBy(func(t *tokens.TokenTransfer) bool {
 		return t.Amount.Int().Int64() == 2 && t.From == "0x0" && t.To == "0x1" && t.TokenIndex == "" && messageID.Equals(t.Message) && t.PoolLocator == "F1" && t.Event.ProtocolID == "000000000010/000020/000030"
-	})).Return(fmt.Errorf("pop")).Once()
+	})).Return(fmt.Errorf("pop")).Once().Run(func(args mock.Arguments) {
+		h.cancelCtx()
+		close(failed)
+	})
 	fromServer <- fftypes.JSONObject{
 		"id":    "17",
 		"event": "token-transfer",
@@ -1454,6 +1561,7 @@ func TestTransferEvents(t *testing.T) {
 			},
 		},
 	}.String()
+	<-failed
 }
 
 func TestApprovalEvents(t *testing.T) {
@@ -1541,7 +1649,8 @@ func TestApprovalEvents(t *testing.T) {
 	mcb.On("TokensApproved", h, mock.MatchedBy(func(t *tokens.TokenApproval) bool {
 		return t.Approved == true && t.Operator == "0x0" && t.PoolLocator == "F1" && t.Event.ProtocolID == "000000000010/000020/000030"
 	})).Return(fmt.Errorf("pop")).Once().Run(func(args mock.Arguments) {
-		// We do not ack in the case of an error
+		// We do not ack in the case of an error, and stop the event from being retried
+		h.cancelCtx()
 		close(errProcessed)
 	})
 	fromServer <- fftypes.JSONObject{
@@ -1576,6 +1685,7 @@ func TestEventLoopReceiveClosed(t *testing.T) {
 		ctx:       context.Background(),
 		cancelCtx: func() { called = true },
 		wsconn:    map[string]wsclient.WSClient{"ns1": wsm},
+		metrics:   newTestMetrics(),
 	}
 	r := make(chan []byte)
 	close(r)
@@ -1593,6 +1703,7 @@ func TestEventLoopSendClosed(t *testing.T) {
 		cancelCtx: func() { called = true },
 		wsconn:    map[string]wsclient.WSClient{"ns1": wsm},
 		retry:     &retry.Retry{},
+		metrics:   newTestMetrics(),
 	}
 	r := make(chan []byte, 1)
 	r <- []byte(`{"id":"1"}`) // ignored but acked
@@ -1620,9 +1731,10 @@ func TestEventLoopClosedContext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	h := &FFTokens{
-		ctx:    ctx,
-		wsconn: map[string]wsclient.WSClient{"ns1": wsm},
-		retry:  &retry.Retry{},
+		ctx:     ctx,
+		wsconn:  map[string]wsclient.WSClient{"ns1": wsm},
+		retry:   &retry.Retry{},
+		metrics: newTestMetrics(),
 	}
 	r := make(chan []byte, 1)
 	wsm.On("Close").Return()
@@ -1630,6 +1742,67 @@ func TestEventLoopClosedContext(t *testing.T) {
 	h.eventLoop("ns1") // we're simply looking for it exiting
 }
 
+func TestEventLoopQueued(t *testing.T) {
+	wsm := &wsmocks.WSClient{}
+	mq := &coremocks.InboundEventQueue{}
+	called := false
+	h := &FFTokens{
+		ctx:       context.Background(),
+		cancelCtx: func() { called = true },
+		wsconn:    map[string]wsclient.WSClient{"ns1": wsm},
+		retry:     &retry.Retry{},
+		metrics:   newTestMetrics(),
+	}
+	h.SetEventQueue(mq)
+	r := make(chan []byte, 4)
+	r <- []byte(`!json`)
+	r <- []byte(`{"event":"receipt","data":{}}`)
+	r <- []byte(`{"id":"1","event":"token-pool","data":{}}`)
+	close(r)
+	var handler core.InboundEventHandler
+	mq.On("Consume", mock.Anything, "ns1", mock.Anything).Run(func(args mock.Arguments) {
+		handler = args[2].(core.InboundEventHandler)
+	})
+	mq.On("Enqueue", mock.Anything, "ns1", []byte(`{"id":"1","event":"token-pool","data":{}}`)).Return(nil)
+	wsm.On("Close").Return()
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	wsm.On("Send", mock.Anything, mock.MatchedBy(func(b []byte) bool {
+		return string(b) == `{"type":"ack","id":"1"}`
+	})).Return(nil).Once()
+	h.eventLoop("ns1")
+	assert.True(t, called)
+
+	// Queued messages are processed without another ack
+	err := handler(context.Background(), []byte(`{"id":"2","event":"unknown"}`))
+	assert.NoError(t, err)
+
+	mq.AssertExpectations(t)
+	wsm.AssertExpectations(t)
+}
+
+func TestEventLoopQueuedEnqueueFail(t *testing.T) {
+	wsm := &wsmocks.WSClient{}
+	mq := &coremocks.InboundEventQueue{}
+	called := false
+	h := &FFTokens{
+		ctx:        context.Background(),
+		cancelCtx:  func() { called = true },
+		wsconn:     map[string]wsclient.WSClient{"ns1": wsm},
+		retry:      &retry.Retry{},
+		eventQueue: mq,
+		metrics:    newTestMetrics(),
+	}
+	r := make(chan []byte, 1)
+	r <- []byte(`{"id":"1","event":"token-pool","data":{}}`)
+	mq.On("Consume", mock.Anything, "ns1", mock.Anything)
+	mq.On("Enqueue", mock.Anything, "ns1", mock.Anything).Return(fmt.Errorf("pop"))
+	wsm.On("Close").Return()
+	wsm.On("Receive").Return((<-chan []byte)(r))
+	h.eventLoop("ns1")
+	assert.True(t, called)
+	wsm.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
 func TestCallbacksWrongNamespace(t *testing.T) {
 	h, _, _, _, done := newTestFFTokens(t)
 	defer done()
@@ -1845,7 +2018,7 @@ func TestHandleEventRetryableFailure(t *testing.T) {
 				}
 			}]
 		}
-	}`))
+	}`), true)
 	assert.Regexp(t, "pop", err)
 	assert.True(t, retry)
 }
@@ -1887,7 +2060,7 @@ func TestHandleNamespaceStartedEnsureActive(t *testing.T) {
 	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/activatepool", httpURL),
 		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
 
-	_, err := h.handleMessage(context.Background(), "ns1", []byte(`{"event":"started","data":{"namespace": "ns1"}}`))
+	_, err := h.handleMessage(context.Background(), "ns1", []byte(`{"event":"started","data":{"namespace": "ns1"}}`), true)
 	assert.NoError(t, err)
 }
 
@@ -1900,14 +2073,14 @@ func TestHandleNamespaceStartedEnsureActiveError(t *testing.T) {
 	httpmock.RegisterResponder("POST", fmt.Sprintf("%s/api/v1/activatepool", httpURL),
 		httpmock.NewJsonResponderOrPanic(500, fftypes.JSONObject{}))
 
-	_, err := h.handleMessage(context.Background(), "ns1", []byte(`{"event":"started","data":{"namespace": "ns1"}}`))
+	_, err := h.handleMessage(context.Background(), "ns1", []byte(`{"event":"started","data":{"namespace": "ns1"}}`), true)
 	assert.NoError(t, err)
 }
 
 func TestHandlePoolActivated(t *testing.T) {
 	h, _, _, _, done := newTestFFTokens(t)
 	defer done()
-	_, err := h.handleMessage(context.Background(), "ns1", []byte(`{"event":"activated","dat":{"namespace": "ns1"}}`))
+	_, err := h.handleMessage(context.Background(), "ns1", []byte(`{"event":"activated","dat":{"namespace": "ns1"}}`), true)
 	assert.NoError(t, err)
 }
 
@@ -1917,3 +2090,41 @@ func TestConnectorName(t *testing.T) {
 	}
 	assert.Equal(t, h.ConnectorName(), "bob")
 }
+
+func TestChainPosition(t *testing.T) {
+	info := fftypes.JSONObject{
+		"decimal": "12",
+		"hex":     "0X1f",
+		"number":  float64(7),
+		"bad":     "nope",
+		"big":     "0x10000000000000000",
+	}
+	assert.Equal(t, int64(12), *chainPosition(info, "decimal"))
+	assert.Equal(t, int64(31), *chainPosition(info, "hex"))
+	assert.Equal(t, int64(7), *chainPosition(info, "number"))
+	assert.Nil(t, chainPosition(info, "bad"))
+	assert.Nil(t, chainPosition(info, "big"))
+	assert.Nil(t, chainPosition(info, "missing"))
+}
+
+func TestConnectionListener(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	mcl := coremocks.NewPluginConnectionListener(t)
+	h.SetConnectionListener(mcl)
+
+	h.setWSConnected("ns1", false)
+	h.setWSConnected("ns1", true)
+
+	mcl.On("PluginConnectionChanged", "ns1", false).Once()
+	h.setWSConnected("ns1", false)
+
+	mcl.On("PluginConnectionChanged", "ns1", true).Once()
+	h.setWSConnected("ns1", true)
+
+	// A restarted namespace starts afresh
+	err := h.StopNamespace(context.Background(), "ns1")
+	assert.NoError(t, err)
+	h.setWSConnected("ns1", false)
+}
@@ -0,0 +1,126 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fftokens
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hyperledger/firefly-common/pkg/log"
+)
+
+const (
+	// protocolVersion1 is the original fftokens API, assumed for connectors that report info without a version
+	protocolVersion1 = 1
+	// protocolVersion2 adds token URIs on mint, and contract interfaces for pool methods
+	protocolVersion2 = 2
+	// protocolVersionSupported is the highest protocol version understood by this plugin
+	protocolVersionSupported = protocolVersion2
+
+	// protocolVersionHeader is sent on every request, so the connector knows which protocol version FireFly speaks
+	protocolVersionHeader = "X-FireFly-Tokens-Protocol-Version"
+)
+
+type connectorFeature string
+
+const (
+	featureURI       connectorFeature = "uri"
+	featureInterface connectorFeature = "interface"
+	featureNack      connectorFeature = "nack"
+)
+
+// featureMinVersions is the protocol version at which each feature becomes mandatory for a connector.
+// Connectors reporting an older version may still opt in to individual features via their feature flags.
+// Features without a version are only used if the connector opts in.
+var featureMinVersions = map[connectorFeature]int{
+	featureURI:       protocolVersion2,
+	featureInterface: protocolVersion2,
+}
+
+type connectorInfo struct {
+	Name            string   `json:"name,omitempty"`
+	Version         string   `json:"version,omitempty"`
+	ProtocolVersion int      `json:"protocolVersion,omitempty"`
+	Features        []string `json:"features,omitempty"`
+}
+
+// negotiateProtocol queries the connector for its version and feature flags.
+// Connectors that do not implement the info endpoint are treated as legacy, and every request
+// field is sent as before. Other failures are logged, and the previously negotiated info is kept.
+func (ft *FFTokens) negotiateProtocol(ctx context.Context) {
+	var info connectorInfo
+	var errRes tokenError
+	res, err := ft.client.R().SetContext(ctx).
+		SetResult(&info).
+		SetError(&errRes).
+		Get("/api/v1/info")
+	switch {
+	case err == nil && res.IsSuccess():
+		if info.ProtocolVersion < protocolVersion1 {
+			info.ProtocolVersion = protocolVersion1
+		} else if info.ProtocolVersion > protocolVersionSupported {
+			info.ProtocolVersion = protocolVersionSupported
+		}
+		log.L(ctx).Infof("Tokens connector '%s' reported name=%s version=%s features=%v (negotiated protocol version %d)",
+			ft.configuredName, info.Name, info.Version, info.Features, info.ProtocolVersion)
+		ft.setConnectorInfo(&info)
+	case err == nil && res.StatusCode() == http.StatusNotFound:
+		log.L(ctx).Infof("Tokens connector '%s' does not report protocol info - assuming legacy protocol", ft.configuredName)
+		ft.setConnectorInfo(nil)
+	default:
+		log.L(ctx).Warnf("Failed to query protocol info from tokens connector '%s': %s", ft.configuredName, wrapError(ctx, &errRes, res, err))
+	}
+}
+
+func (ft *FFTokens) setConnectorInfo(info *connectorInfo) {
+	ft.connectorInfoMux.Lock()
+	defer ft.connectorInfoMux.Unlock()
+	ft.connectorInfo = info
+}
+
+func (ft *FFTokens) getConnectorInfo() *connectorInfo {
+	ft.connectorInfoMux.Lock()
+	defer ft.connectorInfoMux.Unlock()
+	return ft.connectorInfo
+}
+
+// protocolVersion returns the negotiated protocol version, or 0 for a legacy connector
+func (ft *FFTokens) protocolVersion() int {
+	if info := ft.getConnectorInfo(); info != nil {
+		return info.ProtocolVersion
+	}
+	return 0
+}
+
+// supports returns true if the connector can accept request fields (or websocket actions) for the given feature
+func (ft *FFTokens) supports(feature connectorFeature) bool {
+	info := ft.getConnectorInfo()
+	minVersion, versioned := featureMinVersions[feature]
+	if info == nil {
+		// Legacy connectors are sent every field, as they were before protocol negotiation
+		return versioned
+	}
+	if versioned && info.ProtocolVersion >= minVersion {
+		return true
+	}
+	for _, f := range info.Features {
+		if f == string(feature) {
+			return true
+		}
+	}
+	return false
+}
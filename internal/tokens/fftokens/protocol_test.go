@@ -0,0 +1,134 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fftokens
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/core"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateProtocolOnConnect(t *testing.T) {
+	h, toServer, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/info", httpURL),
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "2", req.Header.Get(protocolVersionHeader))
+			return httpmock.NewJsonResponse(200, fftypes.JSONObject{
+				"name":            "erc1155",
+				"version":         "v1.3.0",
+				"protocolVersion": 1,
+				"features":        []string{"interface"},
+			})
+		})
+
+	err := h.StartNamespace(context.Background(), "ns1", []*core.TokenPool{})
+	assert.NoError(t, err)
+	<-toServer
+
+	info := h.getConnectorInfo()
+	assert.Equal(t, "erc1155", info.Name)
+	assert.Equal(t, "v1.3.0", info.Version)
+	assert.Equal(t, 1, h.protocolVersion())
+	assert.True(t, h.supports(featureInterface))
+	assert.False(t, h.supports(featureURI))
+}
+
+func TestNegotiateProtocolVersionBounds(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/info", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{"protocolVersion": 99}))
+	h.negotiateProtocol(context.Background())
+	assert.Equal(t, protocolVersionSupported, h.protocolVersion())
+	assert.True(t, h.supports(featureURI))
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/info", httpURL),
+		httpmock.NewJsonResponderOrPanic(200, fftypes.JSONObject{}))
+	h.negotiateProtocol(context.Background())
+	assert.Equal(t, protocolVersion1, h.protocolVersion())
+	assert.False(t, h.supports(featureURI))
+	assert.False(t, h.supports(featureInterface))
+}
+
+func TestNegotiateProtocolLegacy(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	h.setConnectorInfo(&connectorInfo{ProtocolVersion: protocolVersion1})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/info", httpURL),
+		httpmock.NewStringResponder(404, `{"message":"not found"}`))
+	h.negotiateProtocol(context.Background())
+	assert.Nil(t, h.getConnectorInfo())
+	assert.Equal(t, 0, h.protocolVersion())
+	assert.True(t, h.supports(featureURI))
+	assert.True(t, h.supports(featureInterface))
+	assert.False(t, h.supports(featureNack))
+}
+
+func TestNegotiateProtocolFailKeepsPrevious(t *testing.T) {
+	h, _, _, httpURL, done := newTestFFTokens(t)
+	defer done()
+
+	h.setConnectorInfo(&connectorInfo{ProtocolVersion: protocolVersion1})
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s/api/v1/info", httpURL),
+		httpmock.NewStringResponder(500, `{"message":"pop"}`))
+	h.negotiateProtocol(context.Background())
+	assert.Equal(t, protocolVersion1, h.protocolVersion())
+}
+
+func TestMintTokensURINotSupported(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	h.setConnectorInfo(&connectorInfo{ProtocolVersion: protocolVersion1})
+	mint := &core.TokenTransfer{
+		Namespace: "ns1",
+		To:        "user1",
+		Key:       "0x123",
+		Amount:    *fftypes.NewFFBigInt(10),
+		TX: core.TransactionRef{
+			ID:   fftypes.NewUUID(),
+			Type: core.TransactionTypeTokenTransfer,
+		},
+		URI: "FLAPFLIP",
+	}
+	err := h.MintTokens(context.Background(), "ns1:"+fftypes.NewUUID().String(), "123", mint, nil)
+	assert.Regexp(t, "FF10590.*uri.*1", err)
+}
+
+func TestCheckInterfaceNotSupported(t *testing.T) {
+	h, _, _, _, done := newTestFFTokens(t)
+	defer done()
+
+	h.setConnectorInfo(&connectorInfo{ProtocolVersion: protocolVersion1})
+	pool := &core.TokenPool{
+		Locator:         "N1",
+		InterfaceFormat: core.TokenInterfaceFormatABI,
+	}
+	result, err := h.CheckInterface(context.Background(), pool, []*fftypes.FFIMethod{})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
@@ -829,6 +829,26 @@ func TestFindOperationInTransactionFail(t *testing.T) {
 	mdi.AssertExpectations(t)
 }
 
+func TestFindOperationsInTransaction(t *testing.T) {
+	mdi := &databasemocks.Plugin{}
+	mdm := &datamocks.Manager{}
+	ctx := context.Background()
+	cmi := &cachemocks.Manager{}
+	cmi.On("GetCache", mock.Anything).Return(cache.NewUmanagedCache(ctx, 100, 5*time.Minute), nil)
+	txHelper, _ := NewTransactionHelper(ctx, "ns1", mdi, mdm, cmi)
+
+	txID := fftypes.NewUUID()
+	ops := []*core.Operation{{ID: fftypes.NewUUID()}, {ID: fftypes.NewUUID()}}
+	mdi.On("GetOperations", ctx, "ns1", mock.Anything).Return(ops, nil, nil)
+
+	result, err := txHelper.FindOperationsInTransaction(ctx, txID, core.OpTypeTokenTransfer)
+
+	assert.NoError(t, err)
+	assert.Equal(t, ops, result)
+
+	mdi.AssertExpectations(t)
+}
+
 func TestSubmitNewTransactionBatchAllPlainOk(t *testing.T) {
 	mdi := &databasemocks.Plugin{}
 	mdm := &datamocks.Manager{}
@@ -101,11 +101,13 @@ func NewTransactionWriter(ctx context.Context, ns string, di database.Plugin, tx
 	return tw
 }
 
-func (tw *txWriter) WriteTransactionAndOps(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, operations ...*core.Operation) (*core.Transaction, error) {
+func (tw *txWriter) WriteTransactionAndOps(ctx context.Context, txType core.TransactionType, idempotencyKey core.IdempotencyKey, ops ...*core.Operation) (*core.Transaction, error) {
+	// Tags are applied on the calling context, as the operations might be inserted by a background worker
+	operations.TagOperations(ctx, ops...)
 	req := &request{
 		txType:         txType,
 		idempotencyKey: idempotencyKey,
-		operations:     operations,
+		operations:     ops,
 		result:         make(chan *result, 1), // allocate a slot for the result to avoid blocking
 	}
 	if tw.workerCount == 0 {
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/firefly/internal/cache"
 	"github.com/hyperledger/firefly/internal/coreconfig"
 	"github.com/hyperledger/firefly/internal/database/sqlcommon"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/internal/operations"
 	"github.com/hyperledger/firefly/internal/txcommon"
 	"github.com/hyperledger/firefly/mocks/databasemocks"
@@ -40,6 +41,7 @@ func newTestTransactionWriter(t *testing.T, dbCaps *database.Capabilities, mods
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
 	coreconfig.Reset()
+	operations.InitConfig()
 	config.Set(coreconfig.TransactionWriterCount, 1)
 	mdi := &databasemocks.Plugin{}
 	mdi.On("Capabilities").Return(dbCaps)
@@ -57,7 +59,7 @@ func newTestTransactionWriter(t *testing.T, dbCaps *database.Capabilities, mods
 
 	txh, err := txcommon.NewTransactionHelper(ctx, "ns1", mdi, mdm, cm)
 	assert.NoError(t, err)
-	ops, err := operations.NewOperationsManager(ctx, "ns1", mdi, txh, cm)
+	ops, err := operations.NewOperationsManager(ctx, "ns1", mdi, txh, metrics.NewMetricsManager(ctx), cm)
 	assert.NoError(t, err)
 	txw := NewTransactionWriter(ctx, "ns1", mdi, txh, ops).(*txWriter)
 	return ctx, txw, func() {
@@ -150,6 +152,27 @@ func TestBatchOfOneAsyncSuccess(t *testing.T) {
 
 }
 
+func TestBatchOfOneAsyncTagged(t *testing.T) {
+	ctx, txw, done := newTestTransactionWriter(t, &database.Capabilities{
+		Concurrency: true,
+	})
+	defer done()
+	txw.Start()
+
+	mdi := txw.database.(*databasemocks.Plugin)
+	mdi.On("InsertTransactions", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertEvent", mock.Anything, mock.Anything).Return(nil)
+	mdi.On("InsertOperations", mock.Anything, mock.MatchedBy(func(ops []*core.Operation) bool {
+		return len(ops) == 1 && ops[0].Tags.String() == "invoice-123"
+	})).Return(nil)
+
+	ctx = operations.WithOperationTags(ctx, fftypes.FFStringArray{"invoice-123"})
+	_, err := txw.WriteTransactionAndOps(ctx, core.TransactionTypeContractInvoke, "", &core.Operation{
+		ID: fftypes.NewUUID(),
+	})
+	assert.NoError(t, err)
+}
+
 func TestBatchOfOneInsertOpFail(t *testing.T) {
 	ctx, txw, done := newTestTransactionWriter(t, &database.Capabilities{
 		Concurrency: false, // will run inline
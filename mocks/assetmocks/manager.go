@@ -3,6 +3,8 @@
 package assetmocks
 
 import (
+	assets "github.com/hyperledger/firefly/internal/assets"
+
 	context "context"
 
 	ffapi "github.com/hyperledger/firefly-common/pkg/ffapi"
@@ -10,6 +12,8 @@ import (
 
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
 	syncasync "github.com/hyperledger/firefly/internal/syncasync"
@@ -116,6 +120,36 @@ func (_m *Manager) DeleteTokenPool(ctx context.Context, poolNameOrID string) err
 	return r0
 }
 
+// ExportTokenAccountHistory provides a mock function with given fields: ctx, poolNameOrID, key, format
+func (_m *Manager) ExportTokenAccountHistory(ctx context.Context, poolNameOrID string, key string, format string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, poolNameOrID, key, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTokenAccountHistory")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, poolNameOrID, key, format)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) io.ReadCloser); ok {
+		r0 = rf(ctx, poolNameOrID, key, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, poolNameOrID, key, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTokenAccountPools provides a mock function with given fields: ctx, key, filter
 func (_m *Manager) GetTokenAccountPools(ctx context.Context, key string, filter ffapi.AndFilter) ([]*core.TokenAccountPool, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, key, filter)
@@ -155,6 +189,36 @@ func (_m *Manager) GetTokenAccountPools(ctx context.Context, key string, filter
 	return r0, r1, r2
 }
 
+// GetTokenAccountSummary provides a mock function with given fields: ctx, key
+func (_m *Manager) GetTokenAccountSummary(ctx context.Context, key string) (*core.TokenAccountSummary, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenAccountSummary")
+	}
+
+	var r0 *core.TokenAccountSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.TokenAccountSummary, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.TokenAccountSummary); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TokenAccountSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTokenAccounts provides a mock function with given fields: ctx, filter
 func (_m *Manager) GetTokenAccounts(ctx context.Context, filter ffapi.AndFilter) ([]*core.TokenAccount, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -272,6 +336,45 @@ func (_m *Manager) GetTokenBalances(ctx context.Context, filter ffapi.AndFilter)
 	return r0, r1, r2
 }
 
+// GetTokenBalancesAsOf provides a mock function with given fields: ctx, filter, asOf
+func (_m *Manager) GetTokenBalancesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf *fftypes.FFTime) ([]*core.TokenBalance, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenBalancesAsOf")
+	}
+
+	var r0 []*core.TokenBalance
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, *fftypes.FFTime) ([]*core.TokenBalance, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, *fftypes.FFTime) []*core.TokenBalance); ok {
+		r0 = rf(ctx, filter, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TokenBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter, *fftypes.FFTime) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter, asOf)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter, *fftypes.FFTime) error); ok {
+		r2 = rf(ctx, filter, asOf)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTokenConnectors provides a mock function with given fields: ctx
 func (_m *Manager) GetTokenConnectors(ctx context.Context) []*core.TokenConnector {
 	ret := _m.Called(ctx)
@@ -382,6 +485,26 @@ func (_m *Manager) GetTokenPoolByNameOrID(ctx context.Context, poolNameOrID stri
 	return r0, r1
 }
 
+// GetTokenPoolTemplates provides a mock function with given fields: ctx
+func (_m *Manager) GetTokenPoolTemplates(ctx context.Context) []*core.TokenPoolTemplate {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenPoolTemplates")
+	}
+
+	var r0 []*core.TokenPoolTemplate
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.TokenPoolTemplate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TokenPoolTemplate)
+		}
+	}
+
+	return r0
+}
+
 // GetTokenPools provides a mock function with given fields: ctx, filter
 func (_m *Manager) GetTokenPools(ctx context.Context, filter ffapi.AndFilter) ([]*core.TokenPool, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -520,6 +643,36 @@ func (_m *Manager) MintTokens(ctx context.Context, transfer *core.TokenTransferI
 	return r0, r1
 }
 
+// MintTokensDistribution provides a mock function with given fields: ctx, input
+func (_m *Manager) MintTokensDistribution(ctx context.Context, input *core.TokenMintDistributionInput) (*core.TokenMintDistribution, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MintTokensDistribution")
+	}
+
+	var r0 *core.TokenMintDistribution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TokenMintDistributionInput) (*core.TokenMintDistribution, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TokenMintDistributionInput) *core.TokenMintDistribution); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TokenMintDistribution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.TokenMintDistributionInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *Manager) Name() string {
 	ret := _m.Called()
@@ -663,6 +816,11 @@ func (_m *Manager) RunOperation(ctx context.Context, op *core.PreparedOperation)
 	return r0, r1, r2
 }
 
+// SetTransferPolicy provides a mock function with given fields: policy
+func (_m *Manager) SetTransferPolicy(policy assets.TransferPolicy) {
+	_m.Called(policy)
+}
+
 // Start provides a mock function with given fields:
 func (_m *Manager) Start() error {
 	ret := _m.Called()
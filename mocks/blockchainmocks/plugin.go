@@ -118,6 +118,93 @@ func (_m *Plugin) CheckOverlappingLocations(ctx context.Context, left *fftypes.J
 	return r0, r1
 }
 
+// CheckReady provides a mock function with given fields: ctx
+func (_m *Plugin) CheckReady(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReady")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DecodeInvokeBatchReceipt provides a mock function with given fields: ctx, receipt
+func (_m *Plugin) DecodeInvokeBatchReceipt(ctx context.Context, receipt fftypes.JSONObject) ([]*core.ContractCallBatchItemResult, error) {
+	ret := _m.Called(ctx, receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecodeInvokeBatchReceipt")
+	}
+
+	var r0 []*core.ContractCallBatchItemResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.JSONObject) ([]*core.ContractCallBatchItemResult, error)); ok {
+		return rf(ctx, receipt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.JSONObject) []*core.ContractCallBatchItemResult); ok {
+		r0 = rf(ctx, receipt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ContractCallBatchItemResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.JSONObject) error); ok {
+		r1 = rf(ctx, receipt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DecodeInvokeReceipt provides a mock function with given fields: ctx, parsedMethod, events, receipt
+func (_m *Plugin) DecodeInvokeReceipt(ctx context.Context, parsedMethod interface{}, events []*fftypes.FFIEvent, receipt fftypes.JSONObject) (fftypes.JSONObject, []*core.ContractInvokeEvent, error) {
+	ret := _m.Called(ctx, parsedMethod, events, receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecodeInvokeReceipt")
+	}
+
+	var r0 fftypes.JSONObject
+	var r1 []*core.ContractInvokeEvent
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, []*fftypes.FFIEvent, fftypes.JSONObject) (fftypes.JSONObject, []*core.ContractInvokeEvent, error)); ok {
+		return rf(ctx, parsedMethod, events, receipt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}, []*fftypes.FFIEvent, fftypes.JSONObject) fftypes.JSONObject); ok {
+		r0 = rf(ctx, parsedMethod, events, receipt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(fftypes.JSONObject)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}, []*fftypes.FFIEvent, fftypes.JSONObject) []*core.ContractInvokeEvent); ok {
+		r1 = rf(ctx, parsedMethod, events, receipt)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*core.ContractInvokeEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, interface{}, []*fftypes.FFIEvent, fftypes.JSONObject) error); ok {
+		r2 = rf(ctx, parsedMethod, events, receipt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeleteContractListener provides a mock function with given fields: ctx, subscription, okNotFound
 func (_m *Plugin) DeleteContractListener(ctx context.Context, subscription *core.ContractListener, okNotFound bool) error {
 	ret := _m.Called(ctx, subscription, okNotFound)
@@ -164,6 +251,34 @@ func (_m *Plugin) DeployContract(ctx context.Context, nsOpID string, signingKey
 	return r0, r1
 }
 
+// DeployContractABI provides a mock function with given fields: ctx, nsOpID, signingKey, abiID, input, options
+func (_m *Plugin) DeployContractABI(ctx context.Context, nsOpID string, signingKey string, abiID string, input map[string]interface{}, options map[string]interface{}) (bool, error) {
+	ret := _m.Called(ctx, nsOpID, signingKey, abiID, input, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeployContractABI")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, map[string]interface{}, map[string]interface{}) (bool, error)); ok {
+		return rf(ctx, nsOpID, signingKey, abiID, input, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, map[string]interface{}, map[string]interface{}) bool); ok {
+		r0 = rf(ctx, nsOpID, signingKey, abiID, input, options)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, map[string]interface{}, map[string]interface{}) error); ok {
+		r1 = rf(ctx, nsOpID, signingKey, abiID, input, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateErrorSignature provides a mock function with given fields: ctx, errorDef
 func (_m *Plugin) GenerateErrorSignature(ctx context.Context, errorDef *fftypes.FFIErrorDefinition) string {
 	ret := _m.Called(ctx, errorDef)
@@ -305,6 +420,66 @@ func (_m *Plugin) GetAndConvertDeprecatedContractConfig(ctx context.Context) (*f
 	return r0, r1, r2
 }
 
+// GetContractABI provides a mock function with given fields: ctx, id
+func (_m *Plugin) GetContractABI(ctx context.Context, id string) (*core.ContractABI, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractABI")
+	}
+
+	var r0 *core.ContractABI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.ContractABI, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.ContractABI); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractABI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContractABIs provides a mock function with given fields: ctx
+func (_m *Plugin) GetContractABIs(ctx context.Context) ([]*core.ContractABI, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractABIs")
+	}
+
+	var r0 []*core.ContractABI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.ContractABI, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.ContractABI); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ContractABI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetContractListenerStatus provides a mock function with given fields: ctx, namespace, subID, okNotFound
 func (_m *Plugin) GetContractListenerStatus(ctx context.Context, namespace string, subID string, okNotFound bool) (bool, interface{}, fftypes.FFEnum, error) {
 	ret := _m.Called(ctx, namespace, subID, okNotFound)
@@ -407,6 +582,36 @@ func (_m *Plugin) GetNetworkVersion(ctx context.Context, location *fftypes.JSONA
 	return r0, r1
 }
 
+// GetTransactionReceipt provides a mock function with given fields: ctx, txHash
+func (_m *Plugin) GetTransactionReceipt(ctx context.Context, txHash string) (*blockchain.TransactionReceipt, error) {
+	ret := _m.Called(ctx, txHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionReceipt")
+	}
+
+	var r0 *blockchain.TransactionReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*blockchain.TransactionReceipt, error)); ok {
+		return rf(ctx, txHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *blockchain.TransactionReceipt); ok {
+		r0 = rf(ctx, txHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*blockchain.TransactionReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionStatus provides a mock function with given fields: ctx, operation
 func (_m *Plugin) GetTransactionStatus(ctx context.Context, operation *core.Operation) (interface{}, error) {
 	ret := _m.Called(ctx, operation)
@@ -488,6 +693,34 @@ func (_m *Plugin) InvokeContract(ctx context.Context, nsOpID string, signingKey
 	return r0, r1
 }
 
+// InvokeContractBatch provides a mock function with given fields: ctx, nsOpID, signingKey, aggregator, calls, allowFailure, options
+func (_m *Plugin) InvokeContractBatch(ctx context.Context, nsOpID string, signingKey string, aggregator *fftypes.JSONAny, calls []*blockchain.ContractBatchCall, allowFailure bool, options map[string]interface{}) (bool, error) {
+	ret := _m.Called(ctx, nsOpID, signingKey, aggregator, calls, allowFailure, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvokeContractBatch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *fftypes.JSONAny, []*blockchain.ContractBatchCall, bool, map[string]interface{}) (bool, error)); ok {
+		return rf(ctx, nsOpID, signingKey, aggregator, calls, allowFailure, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *fftypes.JSONAny, []*blockchain.ContractBatchCall, bool, map[string]interface{}) bool); ok {
+		r0 = rf(ctx, nsOpID, signingKey, aggregator, calls, allowFailure, options)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *fftypes.JSONAny, []*blockchain.ContractBatchCall, bool, map[string]interface{}) error); ok {
+		r1 = rf(ctx, nsOpID, signingKey, aggregator, calls, allowFailure, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *Plugin) Name() string {
 	ret := _m.Called()
@@ -601,6 +834,24 @@ func (_m *Plugin) RemoveFireflySubscription(ctx context.Context, subID string) {
 	_m.Called(ctx, subID)
 }
 
+// RequeryFireflySubscription provides a mock function with given fields: ctx, subID, fromProtocolID
+func (_m *Plugin) RequeryFireflySubscription(ctx context.Context, subID string, fromProtocolID string) error {
+	ret := _m.Called(ctx, subID, fromProtocolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeryFireflySubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, subID, fromProtocolID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResolveSigningKey provides a mock function with given fields: ctx, keyRef, intent
 func (_m *Plugin) ResolveSigningKey(ctx context.Context, keyRef string, intent blockchain.ResolveKeyIntent) (string, error) {
 	ret := _m.Called(ctx, keyRef, intent)
@@ -629,6 +880,16 @@ func (_m *Plugin) ResolveSigningKey(ctx context.Context, keyRef string, intent b
 	return r0, r1
 }
 
+// SetConnectionListener provides a mock function with given fields: listener
+func (_m *Plugin) SetConnectionListener(listener core.PluginConnectionListener) {
+	_m.Called(listener)
+}
+
+// SetEventQueue provides a mock function with given fields: queue
+func (_m *Plugin) SetEventQueue(queue core.InboundEventQueue) {
+	_m.Called(queue)
+}
+
 // SetHandler provides a mock function with given fields: namespace, handler
 func (_m *Plugin) SetHandler(namespace string, handler blockchain.Callbacks) {
 	_m.Called(namespace, handler)
@@ -711,6 +972,36 @@ func (_m *Plugin) SubmitNetworkAction(ctx context.Context, nsOpID string, signin
 	return r0
 }
 
+// UploadContractABI provides a mock function with given fields: ctx, upload
+func (_m *Plugin) UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error) {
+	ret := _m.Called(ctx, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadContractABI")
+	}
+
+	var r0 *core.ContractABI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractABIUpload) (*core.ContractABI, error)); ok {
+		return rf(ctx, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractABIUpload) *core.ContractABI); ok {
+		r0 = rf(ctx, upload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractABI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractABIUpload) error); ok {
+		r1 = rf(ctx, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateInvokeRequest provides a mock function with given fields: ctx, parsedMethod, input, hasMessage
 func (_m *Plugin) ValidateInvokeRequest(ctx context.Context, parsedMethod interface{}, input map[string]interface{}, hasMessage bool) error {
 	ret := _m.Called(ctx, parsedMethod, input, hasMessage)
@@ -747,6 +1038,24 @@ func (_m *Plugin) VerifierType() fftypes.FFEnum {
 	return r0
 }
 
+// VerifySignature provides a mock function with given fields: ctx, signingKey, payload, signature
+func (_m *Plugin) VerifySignature(ctx context.Context, signingKey string, payload []byte, signature *core.KeySignature) error {
+	ret := _m.Called(ctx, signingKey, payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignature")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []byte, *core.KeySignature) error); ok {
+		r0 = rf(ctx, signingKey, payload, signature)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPlugin creates a new instance of Plugin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPlugin(t interface {
@@ -48,6 +48,36 @@ func (_m *Manager) BroadcastMessage(ctx context.Context, in *core.MessageInOut,
 	return r0, r1
 }
 
+// BroadcastPinOnlyMessage provides a mock function with given fields: ctx, in, waitConfirm
+func (_m *Manager) BroadcastPinOnlyMessage(ctx context.Context, in *core.MessageInOut, waitConfirm bool) (*core.Message, error) {
+	ret := _m.Called(ctx, in, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BroadcastPinOnlyMessage")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageInOut, bool) (*core.Message, error)); ok {
+		return rf(ctx, in, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.MessageInOut, bool) *core.Message); ok {
+		r0 = rf(ctx, in, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.MessageInOut, bool) error); ok {
+		r1 = rf(ctx, in, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Name provides a mock function with given fields:
 func (_m *Manager) Name() string {
 	ret := _m.Called()
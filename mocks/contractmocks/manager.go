@@ -5,6 +5,8 @@ package contractmocks
 import (
 	context "context"
 
+	contracts "github.com/hyperledger/firefly/internal/contracts"
+
 	core "github.com/hyperledger/firefly/pkg/core"
 
 	ffapi "github.com/hyperledger/firefly-common/pkg/ffapi"
@@ -109,17 +111,17 @@ func (_m *Manager) ConstructContractListenerSignature(ctx context.Context, liste
 	return r0, r1
 }
 
-// DeleteContractAPI provides a mock function with given fields: ctx, apiName
-func (_m *Manager) DeleteContractAPI(ctx context.Context, apiName string) error {
-	ret := _m.Called(ctx, apiName)
+// DeleteContractAPI provides a mock function with given fields: ctx, apiName, version
+func (_m *Manager) DeleteContractAPI(ctx context.Context, apiName string, version string) error {
+	ret := _m.Called(ctx, apiName, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteContractAPI")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, apiName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, apiName, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -193,6 +195,66 @@ func (_m *Manager) DeployContract(ctx context.Context, req *core.ContractDeployR
 	return r0, r1
 }
 
+// DeployContractABI provides a mock function with given fields: ctx, req, waitConfirm
+func (_m *Manager) DeployContractABI(ctx context.Context, req *core.ContractABIDeployRequest, waitConfirm bool) (interface{}, error) {
+	ret := _m.Called(ctx, req, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeployContractABI")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractABIDeployRequest, bool) (interface{}, error)); ok {
+		return rf(ctx, req, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractABIDeployRequest, bool) interface{}); ok {
+		r0 = rf(ctx, req, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractABIDeployRequest, bool) error); ok {
+		r1 = rf(ctx, req, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffContractAPIVersions provides a mock function with given fields: ctx, apiName, fromVersion, toVersion
+func (_m *Manager) DiffContractAPIVersions(ctx context.Context, apiName string, fromVersion string, toVersion string) (*core.ContractAPIDiff, error) {
+	ret := _m.Called(ctx, apiName, fromVersion, toVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffContractAPIVersions")
+	}
+
+	var r0 *core.ContractAPIDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.ContractAPIDiff, error)); ok {
+		return rf(ctx, apiName, fromVersion, toVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.ContractAPIDiff); ok {
+		r0 = rf(ctx, apiName, fromVersion, toVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractAPIDiff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, apiName, fromVersion, toVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateFFI provides a mock function with given fields: ctx, generationRequest
 func (_m *Manager) GenerateFFI(ctx context.Context, generationRequest *fftypes.FFIGenerationRequest) (*fftypes.FFI, error) {
 	ret := _m.Called(ctx, generationRequest)
@@ -223,9 +285,69 @@ func (_m *Manager) GenerateFFI(ctx context.Context, generationRequest *fftypes.F
 	return r0, r1
 }
 
-// GetContractAPI provides a mock function with given fields: ctx, httpServerURL, apiName
-func (_m *Manager) GetContractAPI(ctx context.Context, httpServerURL string, apiName string) (*core.ContractAPI, error) {
-	ret := _m.Called(ctx, httpServerURL, apiName)
+// GetContractABI provides a mock function with given fields: ctx, id
+func (_m *Manager) GetContractABI(ctx context.Context, id string) (*core.ContractABI, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractABI")
+	}
+
+	var r0 *core.ContractABI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.ContractABI, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.ContractABI); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractABI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContractABIs provides a mock function with given fields: ctx
+func (_m *Manager) GetContractABIs(ctx context.Context) ([]*core.ContractABI, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractABIs")
+	}
+
+	var r0 []*core.ContractABI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*core.ContractABI, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*core.ContractABI); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ContractABI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContractAPI provides a mock function with given fields: ctx, httpServerURL, apiName, version
+func (_m *Manager) GetContractAPI(ctx context.Context, httpServerURL string, apiName string, version string) (*core.ContractAPI, error) {
+	ret := _m.Called(ctx, httpServerURL, apiName, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetContractAPI")
@@ -233,19 +355,19 @@ func (_m *Manager) GetContractAPI(ctx context.Context, httpServerURL string, api
 
 	var r0 *core.ContractAPI
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.ContractAPI, error)); ok {
-		return rf(ctx, httpServerURL, apiName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.ContractAPI, error)); ok {
+		return rf(ctx, httpServerURL, apiName, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.ContractAPI); ok {
-		r0 = rf(ctx, httpServerURL, apiName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.ContractAPI); ok {
+		r0 = rf(ctx, httpServerURL, apiName, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*core.ContractAPI)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, httpServerURL, apiName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, httpServerURL, apiName, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -253,9 +375,9 @@ func (_m *Manager) GetContractAPI(ctx context.Context, httpServerURL string, api
 	return r0, r1
 }
 
-// GetContractAPIInterface provides a mock function with given fields: ctx, apiName
-func (_m *Manager) GetContractAPIInterface(ctx context.Context, apiName string) (*fftypes.FFI, error) {
-	ret := _m.Called(ctx, apiName)
+// GetContractAPIInterface provides a mock function with given fields: ctx, apiName, version
+func (_m *Manager) GetContractAPIInterface(ctx context.Context, apiName string, version string) (*fftypes.FFI, error) {
+	ret := _m.Called(ctx, apiName, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetContractAPIInterface")
@@ -263,19 +385,19 @@ func (_m *Manager) GetContractAPIInterface(ctx context.Context, apiName string)
 
 	var r0 *fftypes.FFI
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*fftypes.FFI, error)); ok {
-		return rf(ctx, apiName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*fftypes.FFI, error)); ok {
+		return rf(ctx, apiName, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *fftypes.FFI); ok {
-		r0 = rf(ctx, apiName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *fftypes.FFI); ok {
+		r0 = rf(ctx, apiName, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*fftypes.FFI)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, apiName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, apiName, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -679,6 +801,84 @@ func (_m *Manager) GetFFIs(ctx context.Context, filter ffapi.AndFilter) ([]*ffty
 	return r0, r1, r2
 }
 
+// GetSharedFFIWithChildren provides a mock function with given fields: ctx, name, version
+func (_m *Manager) GetSharedFFIWithChildren(ctx context.Context, name string, version string) (*core.SharedFFI, *fftypes.FFI, error) {
+	ret := _m.Called(ctx, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedFFIWithChildren")
+	}
+
+	var r0 *core.SharedFFI
+	var r1 *fftypes.FFI
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.SharedFFI, *fftypes.FFI, error)); ok {
+		return rf(ctx, name, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.SharedFFI); ok {
+		r0 = rf(ctx, name, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SharedFFI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *fftypes.FFI); ok {
+		r1 = rf(ctx, name, version)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*fftypes.FFI)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, name, version)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSharedFFIs provides a mock function with given fields: ctx, filter
+func (_m *Manager) GetSharedFFIs(ctx context.Context, filter ffapi.AndFilter) ([]*core.SharedFFI, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedFFIs")
+	}
+
+	var r0 []*core.SharedFFI
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.SharedFFI, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.SharedFFI); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SharedFFI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // InvokeContract provides a mock function with given fields: ctx, req, waitConfirm
 func (_m *Manager) InvokeContract(ctx context.Context, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error) {
 	ret := _m.Called(ctx, req, waitConfirm)
@@ -709,9 +909,9 @@ func (_m *Manager) InvokeContract(ctx context.Context, req *core.ContractCallReq
 	return r0, r1
 }
 
-// InvokeContractAPI provides a mock function with given fields: ctx, apiName, methodPath, req, waitConfirm
-func (_m *Manager) InvokeContractAPI(ctx context.Context, apiName string, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error) {
-	ret := _m.Called(ctx, apiName, methodPath, req, waitConfirm)
+// InvokeContractAPI provides a mock function with given fields: ctx, apiName, version, methodPath, req, waitConfirm
+func (_m *Manager) InvokeContractAPI(ctx context.Context, apiName string, version string, methodPath string, req *core.ContractCallRequest, waitConfirm bool) (interface{}, error) {
+	ret := _m.Called(ctx, apiName, version, methodPath, req, waitConfirm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for InvokeContractAPI")
@@ -719,19 +919,49 @@ func (_m *Manager) InvokeContractAPI(ctx context.Context, apiName string, method
 
 	var r0 interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *core.ContractCallRequest, bool) (interface{}, error)); ok {
-		return rf(ctx, apiName, methodPath, req, waitConfirm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *core.ContractCallRequest, bool) (interface{}, error)); ok {
+		return rf(ctx, apiName, version, methodPath, req, waitConfirm)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, *core.ContractCallRequest, bool) interface{}); ok {
-		r0 = rf(ctx, apiName, methodPath, req, waitConfirm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *core.ContractCallRequest, bool) interface{}); ok {
+		r0 = rf(ctx, apiName, version, methodPath, req, waitConfirm)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, *core.ContractCallRequest, bool) error); ok {
-		r1 = rf(ctx, apiName, methodPath, req, waitConfirm)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *core.ContractCallRequest, bool) error); ok {
+		r1 = rf(ctx, apiName, version, methodPath, req, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InvokeContractBatch provides a mock function with given fields: ctx, req
+func (_m *Manager) InvokeContractBatch(ctx context.Context, req *core.ContractCallBatchRequest) (*core.ContractCallBatchResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InvokeContractBatch")
+	}
+
+	var r0 *core.ContractCallBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractCallBatchRequest) (*core.ContractCallBatchResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractCallBatchRequest) *core.ContractCallBatchResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractCallBatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractCallBatchRequest) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -878,6 +1108,71 @@ func (_m *Manager) RunOperation(ctx context.Context, op *core.PreparedOperation)
 	return r0, r1, r2
 }
 
+// SetAPIDefiner provides a mock function with given fields: definer
+func (_m *Manager) SetAPIDefiner(definer contracts.APIDefiner) {
+	_m.Called(definer)
+}
+
+// ShareFFI provides a mock function with given fields: ctx, name, version
+func (_m *Manager) ShareFFI(ctx context.Context, name string, version string) (*core.SharedFFI, error) {
+	ret := _m.Called(ctx, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShareFFI")
+	}
+
+	var r0 *core.SharedFFI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.SharedFFI, error)); ok {
+		return rf(ctx, name, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.SharedFFI); ok {
+		r0 = rf(ctx, name, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SharedFFI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadContractABI provides a mock function with given fields: ctx, upload
+func (_m *Manager) UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error) {
+	ret := _m.Called(ctx, upload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadContractABI")
+	}
+
+	var r0 *core.ContractABI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractABIUpload) (*core.ContractABI, error)); ok {
+		return rf(ctx, upload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractABIUpload) *core.ContractABI); ok {
+		r0 = rf(ctx, upload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractABI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractABIUpload) error); ok {
+		r1 = rf(ctx, upload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
@@ -16,6 +16,8 @@ import (
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Plugin is an autogenerated mock type for the Plugin type
@@ -23,6 +25,52 @@ type Plugin struct {
 	mock.Mock
 }
 
+// AcquireLeaderLease provides a mock function with given fields: ctx, namespace, holder, expiry
+func (_m *Plugin) AcquireLeaderLease(ctx context.Context, namespace string, holder string, expiry *fftypes.FFTime) (bool, error) {
+	ret := _m.Called(ctx, namespace, holder, expiry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireLeaderLease")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *fftypes.FFTime) (bool, error)); ok {
+		return rf(ctx, namespace, holder, expiry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *fftypes.FFTime) bool); ok {
+		r0 = rf(ctx, namespace, holder, expiry)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *fftypes.FFTime) error); ok {
+		r1 = rf(ctx, namespace, holder, expiry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddSubscriptionSLA provides a mock function with given fields: ctx, sla
+func (_m *Plugin) AddSubscriptionSLA(ctx context.Context, sla *core.SubscriptionSLA) error {
+	ret := _m.Called(ctx, sla)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSubscriptionSLA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.SubscriptionSLA) error); ok {
+		r0 = rf(ctx, sla)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Capabilities provides a mock function with given fields:
 func (_m *Plugin) Capabilities() *database.Capabilities {
 	ret := _m.Called()
@@ -43,6 +91,60 @@ func (_m *Plugin) Capabilities() *database.Capabilities {
 	return r0
 }
 
+// CheckReady provides a mock function with given fields: ctx
+func (_m *Plugin) CheckReady(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReady")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAPIKey provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteAPIKey(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAddressBookEntry provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteAddressBookEntry(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAddressBookEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBlob provides a mock function with given fields: ctx, sequence
 func (_m *Plugin) DeleteBlob(ctx context.Context, sequence int64) error {
 	ret := _m.Called(ctx, sequence)
@@ -61,6 +163,24 @@ func (_m *Plugin) DeleteBlob(ctx context.Context, sequence int64) error {
 	return r0
 }
 
+// DeleteBlockchainCheckpoints provides a mock function with given fields: ctx, namespace, subscription
+func (_m *Plugin) DeleteBlockchainCheckpoints(ctx context.Context, namespace string, subscription string) error {
+	ret := _m.Called(ctx, namespace, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlockchainCheckpoints")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, namespace, subscription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteContractAPI provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) DeleteContractAPI(ctx context.Context, namespace string, id *fftypes.UUID) error {
 	ret := _m.Called(ctx, namespace, id)
@@ -133,6 +253,24 @@ func (_m *Plugin) DeleteFFI(ctx context.Context, namespace string, id *fftypes.U
 	return r0
 }
 
+// DeleteInboundEvent provides a mock function with given fields: ctx, sequence
+func (_m *Plugin) DeleteInboundEvent(ctx context.Context, sequence int64) error {
+	ret := _m.Called(ctx, sequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInboundEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, sequence)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNonce provides a mock function with given fields: ctx, hash
 func (_m *Plugin) DeleteNonce(ctx context.Context, hash *fftypes.Bytes32) error {
 	ret := _m.Called(ctx, hash)
@@ -169,6 +307,42 @@ func (_m *Plugin) DeleteOffset(ctx context.Context, t fftypes.FFEnum, name strin
 	return r0
 }
 
+// DeleteOperationQueueEntry provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteOperationQueueEntry(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOperationQueueEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteQuarantineEntry provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) DeleteQuarantineEntry(ctx context.Context, namespace string, id *fftypes.UUID) error {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQuarantineEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteSubscriptionByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) DeleteSubscriptionByID(ctx context.Context, namespace string, id *fftypes.UUID) error {
 	ret := _m.Called(ctx, namespace, id)
@@ -259,6 +433,243 @@ func (_m *Plugin) DeleteTokenTransfers(ctx context.Context, namespace string, po
 	return r0
 }
 
+// GetAPIKeyByHash provides a mock function with given fields: ctx, namespace, hash
+func (_m *Plugin) GetAPIKeyByHash(ctx context.Context, namespace string, hash *fftypes.Bytes32) (*core.APIKey, error) {
+	ret := _m.Called(ctx, namespace, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeyByHash")
+	}
+
+	var r0 *core.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32) (*core.APIKey, error)); ok {
+		return rf(ctx, namespace, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.Bytes32) *core.APIKey); ok {
+		r0 = rf(ctx, namespace, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.Bytes32) error); ok {
+		r1 = rf(ctx, namespace, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPIKeyByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetAPIKeyByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.APIKey, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeyByID")
+	}
+
+	var r0 *core.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.APIKey, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.APIKey); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPIKeys provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetAPIKeys(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.APIKey, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeys")
+	}
+
+	var r0 []*core.APIKey
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.APIKey, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.APIKey); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAddressBookEntries provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetAddressBookEntries(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAddressBookEntries")
+	}
+
+	var r0 []*core.AddressBookEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.AddressBookEntry); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.AddressBookEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAddressBookEntryByName provides a mock function with given fields: ctx, namespace, name
+func (_m *Plugin) GetAddressBookEntryByName(ctx context.Context, namespace string, name string) (*core.AddressBookEntry, error) {
+	ret := _m.Called(ctx, namespace, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAddressBookEntryByName")
+	}
+
+	var r0 *core.AddressBookEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.AddressBookEntry, error)); ok {
+		return rf(ctx, namespace, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.AddressBookEntry); ok {
+		r0 = rf(ctx, namespace, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.AddressBookEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnchorDigestByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetAnchorDigestByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.AnchorDigest, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnchorDigestByID")
+	}
+
+	var r0 *core.AnchorDigest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.AnchorDigest, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.AnchorDigest); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.AnchorDigest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAnchorDigests provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetAnchorDigests(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.AnchorDigest, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAnchorDigests")
+	}
+
+	var r0 []*core.AnchorDigest
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.AnchorDigest, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.AnchorDigest); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.AnchorDigest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetBatchByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetBatchByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BatchPersisted, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -427,6 +838,36 @@ func (_m *Plugin) GetBlobs(ctx context.Context, namespace string, filter ffapi.F
 	return r0, r1, r2
 }
 
+// GetBlockchainCheckpoint provides a mock function with given fields: ctx, namespace, ledger, subscription
+func (_m *Plugin) GetBlockchainCheckpoint(ctx context.Context, namespace string, ledger string, subscription string) (*core.BlockchainCheckpoint, error) {
+	ret := _m.Called(ctx, namespace, ledger, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockchainCheckpoint")
+	}
+
+	var r0 *core.BlockchainCheckpoint
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.BlockchainCheckpoint, error)); ok {
+		return rf(ctx, namespace, ledger, subscription)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.BlockchainCheckpoint); ok {
+		r0 = rf(ctx, namespace, ledger, subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BlockchainCheckpoint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, namespace, ledger, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockchainEventByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetBlockchainEventByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.BlockchainEvent, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -526,6 +967,36 @@ func (_m *Plugin) GetBlockchainEvents(ctx context.Context, namespace string, fil
 	return r0, r1, r2
 }
 
+// GetChartAggregate provides a mock function with given fields: ctx, namespace, collection, startTime, bucketWidth, buckets
+func (_m *Plugin) GetChartAggregate(ctx context.Context, namespace string, collection database.CollectionName, startTime *fftypes.FFTime, bucketWidth time.Duration, buckets int) ([]*core.ChartHistogram, error) {
+	ret := _m.Called(ctx, namespace, collection, startTime, bucketWidth, buckets)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChartAggregate")
+	}
+
+	var r0 []*core.ChartHistogram
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, database.CollectionName, *fftypes.FFTime, time.Duration, int) ([]*core.ChartHistogram, error)); ok {
+		return rf(ctx, namespace, collection, startTime, bucketWidth, buckets)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, database.CollectionName, *fftypes.FFTime, time.Duration, int) []*core.ChartHistogram); ok {
+		r0 = rf(ctx, namespace, collection, startTime, bucketWidth, buckets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ChartHistogram)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, database.CollectionName, *fftypes.FFTime, time.Duration, int) error); ok {
+		r1 = rf(ctx, namespace, collection, startTime, bucketWidth, buckets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChartHistogram provides a mock function with given fields: ctx, namespace, intervals, collection
 func (_m *Plugin) GetChartHistogram(ctx context.Context, namespace string, intervals []core.ChartHistogramInterval, collection database.CollectionName) ([]*core.ChartHistogram, error) {
 	ret := _m.Called(ctx, namespace, intervals, collection)
@@ -556,6 +1027,45 @@ func (_m *Plugin) GetChartHistogram(ctx context.Context, namespace string, inter
 	return r0, r1
 }
 
+// GetCompensations provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetCompensations(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Compensation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCompensations")
+	}
+
+	var r0 []*core.Compensation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Compensation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Compensation); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Compensation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetContractAPIByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetContractAPIByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.ContractAPI, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -616,9 +1126,39 @@ func (_m *Plugin) GetContractAPIByName(ctx context.Context, namespace string, na
 	return r0, r1
 }
 
-// GetContractAPIByNetworkName provides a mock function with given fields: ctx, namespace, networkName
-func (_m *Plugin) GetContractAPIByNetworkName(ctx context.Context, namespace string, networkName string) (*core.ContractAPI, error) {
-	ret := _m.Called(ctx, namespace, networkName)
+// GetContractAPIByNameAndVersion provides a mock function with given fields: ctx, namespace, name, version
+func (_m *Plugin) GetContractAPIByNameAndVersion(ctx context.Context, namespace string, name string, version string) (*core.ContractAPI, error) {
+	ret := _m.Called(ctx, namespace, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractAPIByNameAndVersion")
+	}
+
+	var r0 *core.ContractAPI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.ContractAPI, error)); ok {
+		return rf(ctx, namespace, name, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.ContractAPI); ok {
+		r0 = rf(ctx, namespace, name, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.ContractAPI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, namespace, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContractAPIByNetworkName provides a mock function with given fields: ctx, namespace, networkName, version
+func (_m *Plugin) GetContractAPIByNetworkName(ctx context.Context, namespace string, networkName string, version string) (*core.ContractAPI, error) {
+	ret := _m.Called(ctx, namespace, networkName, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetContractAPIByNetworkName")
@@ -626,19 +1166,19 @@ func (_m *Plugin) GetContractAPIByNetworkName(ctx context.Context, namespace str
 
 	var r0 *core.ContractAPI
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.ContractAPI, error)); ok {
-		return rf(ctx, namespace, networkName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.ContractAPI, error)); ok {
+		return rf(ctx, namespace, networkName, version)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.ContractAPI); ok {
-		r0 = rf(ctx, namespace, networkName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.ContractAPI); ok {
+		r0 = rf(ctx, namespace, networkName, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*core.ContractAPI)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, namespace, networkName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, namespace, networkName, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -976,35 +1516,104 @@ func (_m *Plugin) GetDatatypeByID(ctx context.Context, namespace string, id *fft
 	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
 		r1 = rf(ctx, namespace, id)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDatatypeByName provides a mock function with given fields: ctx, namespace, name, version
+func (_m *Plugin) GetDatatypeByName(ctx context.Context, namespace string, name string, version string) (*core.Datatype, error) {
+	ret := _m.Called(ctx, namespace, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDatatypeByName")
+	}
+
+	var r0 *core.Datatype
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.Datatype, error)); ok {
+		return rf(ctx, namespace, name, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.Datatype); ok {
+		r0 = rf(ctx, namespace, name, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Datatype)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, namespace, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDatatypes provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetDatatypes(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Datatype, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDatatypes")
+	}
+
+	var r0 []*core.Datatype
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Datatype, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Datatype); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Datatype)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
 	}
 
-	return r0, r1
+	return r0, r1, r2
 }
 
-// GetDatatypeByName provides a mock function with given fields: ctx, namespace, name, version
-func (_m *Plugin) GetDatatypeByName(ctx context.Context, namespace string, name string, version string) (*core.Datatype, error) {
-	ret := _m.Called(ctx, namespace, name, version)
+// GetDelegationByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetDelegationByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Delegation, error) {
+	ret := _m.Called(ctx, namespace, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDatatypeByName")
+		panic("no return value specified for GetDelegationByID")
 	}
 
-	var r0 *core.Datatype
+	var r0 *core.Delegation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*core.Datatype, error)); ok {
-		return rf(ctx, namespace, name, version)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.Delegation, error)); ok {
+		return rf(ctx, namespace, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *core.Datatype); ok {
-		r0 = rf(ctx, namespace, name, version)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.Delegation); ok {
+		r0 = rf(ctx, namespace, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*core.Datatype)
+			r0 = ret.Get(0).(*core.Delegation)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = rf(ctx, namespace, name, version)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1012,25 +1621,25 @@ func (_m *Plugin) GetDatatypeByName(ctx context.Context, namespace string, name
 	return r0, r1
 }
 
-// GetDatatypes provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetDatatypes(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Datatype, *ffapi.FilterResult, error) {
+// GetDelegations provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetDelegations(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Delegation, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDatatypes")
+		panic("no return value specified for GetDelegations")
 	}
 
-	var r0 []*core.Datatype
+	var r0 []*core.Delegation
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Datatype, *ffapi.FilterResult, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Delegation, *ffapi.FilterResult, error)); ok {
 		return rf(ctx, namespace, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Datatype); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Delegation); ok {
 		r0 = rf(ctx, namespace, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Datatype)
+			r0 = ret.Get(0).([]*core.Delegation)
 		}
 	}
 
@@ -1663,6 +2272,45 @@ func (_m *Plugin) GetIdentityByName(ctx context.Context, iType fftypes.FFEnum, n
 	return r0, r1
 }
 
+// GetInboundEvents provides a mock function with given fields: ctx, plugin, namespace, filter
+func (_m *Plugin) GetInboundEvents(ctx context.Context, plugin string, namespace string, filter ffapi.Filter) ([]*core.InboundEvent, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, plugin, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInboundEvents")
+	}
+
+	var r0 []*core.InboundEvent
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ffapi.Filter) ([]*core.InboundEvent, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, plugin, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ffapi.Filter) []*core.InboundEvent); ok {
+		r0 = rf(ctx, plugin, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.InboundEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, plugin, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, plugin, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetMessageByID provides a mock function with given fields: ctx, namespace, id
 func (_m *Plugin) GetMessageByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Message, error) {
 	ret := _m.Called(ctx, namespace, id)
@@ -2068,6 +2716,45 @@ func (_m *Plugin) GetOperationByID(ctx context.Context, namespace string, id *ff
 	return r0, r1
 }
 
+// GetOperationQueueEntries provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetOperationQueueEntries(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.OperationQueueEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationQueueEntries")
+	}
+
+	var r0 []*core.OperationQueueEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.OperationQueueEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.OperationQueueEntry); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.OperationQueueEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetOperations provides a mock function with given fields: ctx, namespace, filter
 func (_m *Plugin) GetOperations(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Operation, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
@@ -2098,47 +2785,263 @@ func (_m *Plugin) GetOperations(ctx context.Context, namespace string, filter ff
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
-		r2 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetOperationsByTags provides a mock function with given fields: ctx, namespace, tags, filter
+func (_m *Plugin) GetOperationsByTags(ctx context.Context, namespace string, tags []string, filter ffapi.Filter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, tags, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationsByTags")
+	}
+
+	var r0 []*core.Operation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, ffapi.Filter) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, tags, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, ffapi.Filter) []*core.Operation); ok {
+		r0 = rf(ctx, namespace, tags, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, tags, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, []string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, tags, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPins provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetPins(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Pin, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPins")
+	}
+
+	var r0 []*core.Pin
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Pin, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Pin); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Pin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetQuarantineEntries provides a mock function with given fields: ctx, namespace, filter
+func (_m *Plugin) GetQuarantineEntries(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantineEntries")
+	}
+
+	var r0 []*core.QuarantineEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.QuarantineEntry); ok {
+		r0 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.QuarantineEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetQuarantineEntryByID provides a mock function with given fields: ctx, namespace, id
+func (_m *Plugin) GetQuarantineEntryByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.QuarantineEntry, error) {
+	ret := _m.Called(ctx, namespace, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantineEntryByID")
+	}
+
+	var r0 *core.QuarantineEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) (*core.QuarantineEntry, error)); ok {
+		return rf(ctx, namespace, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID) *core.QuarantineEntry); ok {
+		r0 = rf(ctx, namespace, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.QuarantineEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, namespace, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSequencedBatches provides a mock function with given fields: ctx, networkNamespace, filter
+func (_m *Plugin) GetSequencedBatches(ctx context.Context, networkNamespace string, filter ffapi.Filter) ([]*core.SequencedBatch, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, networkNamespace, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSequencedBatches")
+	}
+
+	var r0 []*core.SequencedBatch
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.SequencedBatch, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, networkNamespace, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.SequencedBatch); ok {
+		r0 = rf(ctx, networkNamespace, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SequencedBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, networkNamespace, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
+		r2 = rf(ctx, networkNamespace, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetSharedFFI provides a mock function with given fields: ctx, name, version
+func (_m *Plugin) GetSharedFFI(ctx context.Context, name string, version string) (*core.SharedFFI, error) {
+	ret := _m.Called(ctx, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedFFI")
+	}
+
+	var r0 *core.SharedFFI
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.SharedFFI, error)); ok {
+		return rf(ctx, name, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.SharedFFI); ok {
+		r0 = rf(ctx, name, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SharedFFI)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, name, version)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetPins provides a mock function with given fields: ctx, namespace, filter
-func (_m *Plugin) GetPins(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Pin, *ffapi.FilterResult, error) {
-	ret := _m.Called(ctx, namespace, filter)
+// GetSharedFFIs provides a mock function with given fields: ctx, filter
+func (_m *Plugin) GetSharedFFIs(ctx context.Context, filter ffapi.Filter) ([]*core.SharedFFI, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPins")
+		panic("no return value specified for GetSharedFFIs")
 	}
 
-	var r0 []*core.Pin
+	var r0 []*core.SharedFFI
 	var r1 *ffapi.FilterResult
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) ([]*core.Pin, *ffapi.FilterResult, error)); ok {
-		return rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) ([]*core.SharedFFI, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.Filter) []*core.Pin); ok {
-		r0 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.Filter) []*core.SharedFFI); ok {
+		r0 = rf(ctx, filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*core.Pin)
+			r0 = ret.Get(0).([]*core.SharedFFI)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.Filter) *ffapi.FilterResult); ok {
-		r1 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*ffapi.FilterResult)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.Filter) error); ok {
-		r2 = rf(ctx, namespace, filter)
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.Filter) error); ok {
+		r2 = rf(ctx, filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -2206,6 +3109,45 @@ func (_m *Plugin) GetSubscriptionByName(ctx context.Context, namespace string, n
 	return r0, r1
 }
 
+// GetSubscriptionSLAs provides a mock function with given fields: ctx, namespace, subscription, filter
+func (_m *Plugin) GetSubscriptionSLAs(ctx context.Context, namespace string, subscription *fftypes.UUID, filter ffapi.Filter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, namespace, subscription, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionSLAs")
+	}
+
+	var r0 []*core.SubscriptionSLA
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Filter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, namespace, subscription, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Filter) []*core.SubscriptionSLA); ok {
+		r0 = rf(ctx, namespace, subscription, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SubscriptionSLA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *fftypes.UUID, ffapi.Filter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, namespace, subscription, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *fftypes.UUID, ffapi.Filter) error); ok {
+		r2 = rf(ctx, namespace, subscription, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSubscriptions provides a mock function with given fields: ctx, namespace, filter
 func (_m *Plugin) GetSubscriptions(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Subscription, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
@@ -2245,6 +3187,36 @@ func (_m *Plugin) GetSubscriptions(ctx context.Context, namespace string, filter
 	return r0, r1, r2
 }
 
+// GetTokenAccountBalanceSummaries provides a mock function with given fields: ctx, namespace, key
+func (_m *Plugin) GetTokenAccountBalanceSummaries(ctx context.Context, namespace string, key string) ([]*core.TokenAccountPoolSummary, error) {
+	ret := _m.Called(ctx, namespace, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenAccountBalanceSummaries")
+	}
+
+	var r0 []*core.TokenAccountPoolSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*core.TokenAccountPoolSummary, error)); ok {
+		return rf(ctx, namespace, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*core.TokenAccountPoolSummary); ok {
+		r0 = rf(ctx, namespace, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TokenAccountPoolSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTokenAccountPools provides a mock function with given fields: ctx, namespace, key, filter
 func (_m *Plugin) GetTokenAccountPools(ctx context.Context, namespace string, key string, filter ffapi.Filter) ([]*core.TokenAccountPool, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, key, filter)
@@ -2284,6 +3256,36 @@ func (_m *Plugin) GetTokenAccountPools(ctx context.Context, namespace string, ke
 	return r0, r1, r2
 }
 
+// GetTokenAccountTransferSummaries provides a mock function with given fields: ctx, namespace, key
+func (_m *Plugin) GetTokenAccountTransferSummaries(ctx context.Context, namespace string, key string) ([]*core.TokenAccountPoolSummary, error) {
+	ret := _m.Called(ctx, namespace, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenAccountTransferSummaries")
+	}
+
+	var r0 []*core.TokenAccountPoolSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*core.TokenAccountPoolSummary, error)); ok {
+		return rf(ctx, namespace, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*core.TokenAccountPoolSummary); ok {
+		r0 = rf(ctx, namespace, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TokenAccountPoolSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, namespace, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTokenAccounts provides a mock function with given fields: ctx, namespace, filter
 func (_m *Plugin) GetTokenAccounts(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.TokenAccount, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, namespace, filter)
@@ -2910,6 +3912,42 @@ func (_m *Plugin) InitConfig(_a0 config.Section) {
 	_m.Called(_a0)
 }
 
+// InsertAPIKey provides a mock function with given fields: ctx, key
+func (_m *Plugin) InsertAPIKey(ctx context.Context, key *core.APIKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.APIKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertAnchorDigest provides a mock function with given fields: ctx, digest
+func (_m *Plugin) InsertAnchorDigest(ctx context.Context, digest *core.AnchorDigest) error {
+	ret := _m.Called(ctx, digest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertAnchorDigest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AnchorDigest) error); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertBlob provides a mock function with given fields: ctx, blob
 func (_m *Plugin) InsertBlob(ctx context.Context, blob *core.Blob) error {
 	ret := _m.Called(ctx, blob)
@@ -2971,6 +4009,24 @@ func (_m *Plugin) InsertBlockchainEvents(ctx context.Context, messages []*core.B
 	return r0
 }
 
+// InsertCompensation provides a mock function with given fields: ctx, compensation
+func (_m *Plugin) InsertCompensation(ctx context.Context, compensation *core.Compensation) error {
+	ret := _m.Called(ctx, compensation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertCompensation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Compensation) error); ok {
+		r0 = rf(ctx, compensation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertContractListener provides a mock function with given fields: ctx, sub
 func (_m *Plugin) InsertContractListener(ctx context.Context, sub *core.ContractListener) error {
 	ret := _m.Called(ctx, sub)
@@ -3007,6 +4063,24 @@ func (_m *Plugin) InsertDataArray(ctx context.Context, data core.DataArray) erro
 	return r0
 }
 
+// InsertDelegation provides a mock function with given fields: ctx, delegation
+func (_m *Plugin) InsertDelegation(ctx context.Context, delegation *core.Delegation) error {
+	ret := _m.Called(ctx, delegation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertDelegation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Delegation) error); ok {
+		r0 = rf(ctx, delegation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertEvent provides a mock function with given fields: ctx, data
 func (_m *Plugin) InsertEvent(ctx context.Context, data *core.Event) error {
 	ret := _m.Called(ctx, data)
@@ -3025,6 +4099,24 @@ func (_m *Plugin) InsertEvent(ctx context.Context, data *core.Event) error {
 	return r0
 }
 
+// InsertInboundEvent provides a mock function with given fields: ctx, event
+func (_m *Plugin) InsertInboundEvent(ctx context.Context, event *core.InboundEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertInboundEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.InboundEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertMessages provides a mock function with given fields: ctx, messages, hooks
 func (_m *Plugin) InsertMessages(ctx context.Context, messages []*core.Message, hooks ...database.PostCompletionHook) error {
 	_va := make([]interface{}, len(hooks))
@@ -3111,6 +4203,24 @@ func (_m *Plugin) InsertOperation(ctx context.Context, operation *core.Operation
 	return r0
 }
 
+// InsertOperationQueueEntry provides a mock function with given fields: ctx, entry
+func (_m *Plugin) InsertOperationQueueEntry(ctx context.Context, entry *core.OperationQueueEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOperationQueueEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.OperationQueueEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertOperations provides a mock function with given fields: ctx, ops, hooks
 func (_m *Plugin) InsertOperations(ctx context.Context, ops []*core.Operation, hooks ...database.PostCompletionHook) error {
 	_va := make([]interface{}, len(hooks))
@@ -3334,6 +4444,60 @@ func (_m *Plugin) InsertPins(ctx context.Context, pins []*core.Pin) error {
 	return r0
 }
 
+// InsertQuarantineEntry provides a mock function with given fields: ctx, entry
+func (_m *Plugin) InsertQuarantineEntry(ctx context.Context, entry *core.QuarantineEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertQuarantineEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.QuarantineEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertSequencedBatch provides a mock function with given fields: ctx, batch
+func (_m *Plugin) InsertSequencedBatch(ctx context.Context, batch *core.SequencedBatch) error {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSequencedBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.SequencedBatch) error); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// InsertSharedFFI provides a mock function with given fields: ctx, shared
+func (_m *Plugin) InsertSharedFFI(ctx context.Context, shared *core.SharedFFI) error {
+	ret := _m.Called(ctx, shared)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertSharedFFI")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.SharedFFI) error); ok {
+		r0 = rf(ctx, shared)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertTransaction provides a mock function with given fields: ctx, txn
 func (_m *Plugin) InsertTransaction(ctx context.Context, txn *core.Transaction) error {
 	ret := _m.Called(ctx, txn)
@@ -3388,6 +4552,24 @@ func (_m *Plugin) Name() string {
 	return r0
 }
 
+// ReleaseLeaderLease provides a mock function with given fields: ctx, namespace, holder
+func (_m *Plugin) ReleaseLeaderLease(ctx context.Context, namespace string, holder string) error {
+	ret := _m.Called(ctx, namespace, holder)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLeaderLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, namespace, holder)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplaceMessage provides a mock function with given fields: ctx, message
 func (_m *Plugin) ReplaceMessage(ctx context.Context, message *core.Message) error {
 	ret := _m.Called(ctx, message)
@@ -3429,6 +4611,24 @@ func (_m *Plugin) SetHandler(namespace string, handler database.Callbacks) {
 	_m.Called(namespace, handler)
 }
 
+// UpdateAnchorDigest provides a mock function with given fields: ctx, namespace, id, update
+func (_m *Plugin) UpdateAnchorDigest(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
+	ret := _m.Called(ctx, namespace, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAnchorDigest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *fftypes.UUID, ffapi.Update) error); ok {
+		r0 = rf(ctx, namespace, id, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateBatch provides a mock function with given fields: ctx, namespace, id, update
 func (_m *Plugin) UpdateBatch(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error {
 	ret := _m.Called(ctx, namespace, id, update)
@@ -3691,6 +4891,42 @@ func (_m *Plugin) UpdateTransaction(ctx context.Context, namespace string, id *f
 	return r0
 }
 
+// UpsertAddressBookEntry provides a mock function with given fields: ctx, entry
+func (_m *Plugin) UpsertAddressBookEntry(ctx context.Context, entry *core.AddressBookEntry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertAddressBookEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AddressBookEntry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertBlockchainCheckpoint provides a mock function with given fields: ctx, checkpoint
+func (_m *Plugin) UpsertBlockchainCheckpoint(ctx context.Context, checkpoint *core.BlockchainCheckpoint) error {
+	ret := _m.Called(ctx, checkpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBlockchainCheckpoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.BlockchainCheckpoint) error); ok {
+		r0 = rf(ctx, checkpoint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpsertContractAPI provides a mock function with given fields: ctx, api, optimization
 func (_m *Plugin) UpsertContractAPI(ctx context.Context, api *core.ContractAPI, optimization database.UpsertOptimization) error {
 	ret := _m.Called(ctx, api, optimization)
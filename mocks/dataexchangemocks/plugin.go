@@ -61,6 +61,24 @@ func (_m *Plugin) Capabilities() *dataexchange.Capabilities {
 	return r0
 }
 
+// CheckReady provides a mock function with given fields: ctx
+func (_m *Plugin) CheckReady(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReady")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBlob provides a mock function with given fields: ctx, payloadRef
 func (_m *Plugin) DeleteBlob(ctx context.Context, payloadRef string) error {
 	ret := _m.Called(ctx, payloadRef)
@@ -157,6 +175,34 @@ func (_m *Plugin) GetPeerID(peer fftypes.JSONObject) string {
 	return r0
 }
 
+// GetPeerStatus provides a mock function with given fields: ctx, peer
+func (_m *Plugin) GetPeerStatus(ctx context.Context, peer fftypes.JSONObject) (core.PeerStatus, error) {
+	ret := _m.Called(ctx, peer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPeerStatus")
+	}
+
+	var r0 core.PeerStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.JSONObject) (core.PeerStatus, error)); ok {
+		return rf(ctx, peer)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, fftypes.JSONObject) core.PeerStatus); ok {
+		r0 = rf(ctx, peer)
+	} else {
+		r0 = ret.Get(0).(core.PeerStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, fftypes.JSONObject) error); ok {
+		r1 = rf(ctx, peer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Init provides a mock function with given fields: ctx, cancelCtx, _a2
 func (_m *Plugin) Init(ctx context.Context, cancelCtx context.CancelFunc, _a2 config.Section) error {
 	ret := _m.Called(ctx, cancelCtx, _a2)
@@ -216,6 +262,16 @@ func (_m *Plugin) SendMessage(ctx context.Context, nsOpID string, peer fftypes.J
 	return r0
 }
 
+// SetConnectionListener provides a mock function with given fields: listener
+func (_m *Plugin) SetConnectionListener(listener core.PluginConnectionListener) {
+	_m.Called(listener)
+}
+
+// SetEventQueue provides a mock function with given fields: queue
+func (_m *Plugin) SetEventQueue(queue core.InboundEventQueue) {
+	_m.Called(queue)
+}
+
 // SetHandler provides a mock function with given fields: networkNamespace, nodeName, handler
 func (_m *Plugin) SetHandler(networkNamespace string, nodeName string, handler dataexchange.Callbacks) {
 	_m.Called(networkNamespace, nodeName, handler)
@@ -244,17 +300,17 @@ func (_m *Plugin) Start() error {
 	return r0
 }
 
-// TransferBlob provides a mock function with given fields: ctx, nsOpID, peer, sender, payloadRef
-func (_m *Plugin) TransferBlob(ctx context.Context, nsOpID string, peer fftypes.JSONObject, sender fftypes.JSONObject, payloadRef string) error {
-	ret := _m.Called(ctx, nsOpID, peer, sender, payloadRef)
+// TransferBlob provides a mock function with given fields: ctx, nsOpID, peer, sender, payloadRef, offset
+func (_m *Plugin) TransferBlob(ctx context.Context, nsOpID string, peer fftypes.JSONObject, sender fftypes.JSONObject, payloadRef string, offset int64) error {
+	ret := _m.Called(ctx, nsOpID, peer, sender, payloadRef, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TransferBlob")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, fftypes.JSONObject, fftypes.JSONObject, string) error); ok {
-		r0 = rf(ctx, nsOpID, peer, sender, payloadRef)
+	if rf, ok := ret.Get(0).(func(context.Context, string, fftypes.JSONObject, fftypes.JSONObject, string, int64) error); ok {
+		r0 = rf(ctx, nsOpID, peer, sender, payloadRef, offset)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -58,6 +58,36 @@ func (_m *Manager) CheckDatatype(ctx context.Context, datatype *core.Datatype) e
 	return r0
 }
 
+// CollectBlobs provides a mock function with given fields: ctx, dryRun
+func (_m *Manager) CollectBlobs(ctx context.Context, dryRun bool) (*core.BlobCollection, error) {
+	ret := _m.Called(ctx, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CollectBlobs")
+	}
+
+	var r0 *core.BlobCollection
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) (*core.BlobCollection, error)); ok {
+		return rf(ctx, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) *core.BlobCollection); ok {
+		r0 = rf(ctx, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BlobCollection)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteData provides a mock function with given fields: ctx, dataID
 func (_m *Manager) DeleteData(ctx context.Context, dataID string) error {
 	ret := _m.Called(ctx, dataID)
@@ -242,6 +272,41 @@ func (_m *Manager) HydrateBatch(ctx context.Context, persistedBatch *core.BatchP
 	return r0, r1
 }
 
+// InferDatatype provides a mock function with given fields: ctx, input
+func (_m *Manager) InferDatatype(ctx context.Context, input *core.DatatypeInferInput) (*core.Datatype, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InferDatatype")
+	}
+
+	var r0 *core.Datatype
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DatatypeInferInput) (*core.Datatype, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DatatypeInferInput) *core.Datatype); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Datatype)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.DatatypeInferInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateDatatype provides a mock function with given fields: ctx, datatype
+func (_m *Manager) MigrateDatatype(ctx context.Context, datatype *core.Datatype) {
+	_m.Called(ctx, datatype)
+}
+
 // PeekMessageCache provides a mock function with given fields: ctx, id, options
 func (_m *Manager) PeekMessageCache(ctx context.Context, id *fftypes.UUID, options ...data.CacheReadOption) (*core.Message, core.DataArray) {
 	_va := make([]interface{}, len(options))
@@ -349,6 +414,36 @@ func (_m *Manager) UploadBlob(ctx context.Context, inData *core.DataRefOrValue,
 	return r0, r1
 }
 
+// UploadBulk provides a mock function with given fields: ctx, inData
+func (_m *Manager) UploadBulk(ctx context.Context, inData []*core.DataRefOrValue) (core.DataArray, error) {
+	ret := _m.Called(ctx, inData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadBulk")
+	}
+
+	var r0 core.DataArray
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.DataRefOrValue) (core.DataArray, error)); ok {
+		return rf(ctx, inData)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*core.DataRefOrValue) core.DataArray); ok {
+		r0 = rf(ctx, inData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(core.DataArray)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*core.DataRefOrValue) error); ok {
+		r1 = rf(ctx, inData)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UploadJSON provides a mock function with given fields: ctx, inData
 func (_m *Manager) UploadJSON(ctx context.Context, inData *core.DataRefOrValue) (*core.Data, error) {
 	ret := _m.Called(ctx, inData)
@@ -379,23 +474,55 @@ func (_m *Manager) UploadJSON(ctx context.Context, inData *core.DataRefOrValue)
 	return r0, r1
 }
 
+// UploadProtobuf provides a mock function with given fields: ctx, inData, payload
+func (_m *Manager) UploadProtobuf(ctx context.Context, inData *core.DataRefOrValue, payload *ffapi.Multipart) (*core.Data, error) {
+	ret := _m.Called(ctx, inData, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadProtobuf")
+	}
+
+	var r0 *core.Data
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DataRefOrValue, *ffapi.Multipart) (*core.Data, error)); ok {
+		return rf(ctx, inData, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DataRefOrValue, *ffapi.Multipart) *core.Data); ok {
+		r0 = rf(ctx, inData, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Data)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.DataRefOrValue, *ffapi.Multipart) error); ok {
+		r1 = rf(ctx, inData, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateAll provides a mock function with given fields: ctx, _a1
-func (_m *Manager) ValidateAll(ctx context.Context, _a1 core.DataArray) (bool, error) {
+func (_m *Manager) ValidateAll(ctx context.Context, _a1 core.DataArray) (*data.DataValidation, error) {
 	ret := _m.Called(ctx, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ValidateAll")
 	}
 
-	var r0 bool
+	var r0 *data.DataValidation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, core.DataArray) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, core.DataArray) (*data.DataValidation, error)); ok {
 		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, core.DataArray) bool); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, core.DataArray) *data.DataValidation); ok {
 		r0 = rf(ctx, _a1)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*data.DataValidation)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, core.DataArray) error); ok {
@@ -53,6 +53,24 @@ func (_m *Sender) DefineContractAPI(ctx context.Context, httpServerURL string, a
 	return r0
 }
 
+// DefineContractMigration provides a mock function with given fields: ctx, migration, waitConfirm
+func (_m *Sender) DefineContractMigration(ctx context.Context, migration *core.ContractMigration, waitConfirm bool) error {
+	ret := _m.Called(ctx, migration, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DefineContractMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigration, bool) error); ok {
+		r0 = rf(ctx, migration, waitConfirm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DefineDatatype provides a mock function with given fields: ctx, datatype, waitConfirm
 func (_m *Sender) DefineDatatype(ctx context.Context, datatype *core.Datatype, waitConfirm bool) error {
 	ret := _m.Called(ctx, datatype, waitConfirm)
@@ -71,6 +89,24 @@ func (_m *Sender) DefineDatatype(ctx context.Context, datatype *core.Datatype, w
 	return r0
 }
 
+// DefineDelegation provides a mock function with given fields: ctx, def, signingIdentity, waitConfirm
+func (_m *Sender) DefineDelegation(ctx context.Context, def *core.Delegation, signingIdentity *core.SignerRef, waitConfirm bool) error {
+	ret := _m.Called(ctx, def, signingIdentity, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DefineDelegation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Delegation, *core.SignerRef, bool) error); ok {
+		r0 = rf(ctx, def, signingIdentity, waitConfirm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DefineFFI provides a mock function with given fields: ctx, ffi, waitConfirm
 func (_m *Sender) DefineFFI(ctx context.Context, ffi *fftypes.FFI, waitConfirm bool) error {
 	ret := _m.Called(ctx, ffi, waitConfirm)
@@ -125,9 +161,9 @@ func (_m *Sender) Name() string {
 	return r0
 }
 
-// PublishContractAPI provides a mock function with given fields: ctx, httpServerURL, name, networkName, waitConfirm
-func (_m *Sender) PublishContractAPI(ctx context.Context, httpServerURL string, name string, networkName string, waitConfirm bool) (*core.ContractAPI, error) {
-	ret := _m.Called(ctx, httpServerURL, name, networkName, waitConfirm)
+// PublishContractAPI provides a mock function with given fields: ctx, httpServerURL, name, version, networkName, waitConfirm
+func (_m *Sender) PublishContractAPI(ctx context.Context, httpServerURL string, name string, version string, networkName string, waitConfirm bool) (*core.ContractAPI, error) {
+	ret := _m.Called(ctx, httpServerURL, name, version, networkName, waitConfirm)
 
 	if len(ret) == 0 {
 		panic("no return value specified for PublishContractAPI")
@@ -135,19 +171,19 @@ func (_m *Sender) PublishContractAPI(ctx context.Context, httpServerURL string,
 
 	var r0 *core.ContractAPI
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) (*core.ContractAPI, error)); ok {
-		return rf(ctx, httpServerURL, name, networkName, waitConfirm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, bool) (*core.ContractAPI, error)); ok {
+		return rf(ctx, httpServerURL, name, version, networkName, waitConfirm)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) *core.ContractAPI); ok {
-		r0 = rf(ctx, httpServerURL, name, networkName, waitConfirm)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, bool) *core.ContractAPI); ok {
+		r0 = rf(ctx, httpServerURL, name, version, networkName, waitConfirm)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*core.ContractAPI)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool) error); ok {
-		r1 = rf(ctx, httpServerURL, name, networkName, waitConfirm)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string, bool) error); ok {
+		r1 = rf(ctx, httpServerURL, name, version, networkName, waitConfirm)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -215,6 +251,24 @@ func (_m *Sender) PublishTokenPool(ctx context.Context, poolNameOrID string, net
 	return r0, r1
 }
 
+// RevokeVerifier provides a mock function with given fields: ctx, def, signingIdentity, waitConfirm
+func (_m *Sender) RevokeVerifier(ctx context.Context, def *core.VerifierRevocation, signingIdentity *core.SignerRef, waitConfirm bool) error {
+	ret := _m.Called(ctx, def, signingIdentity, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeVerifier")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifierRevocation, *core.SignerRef, bool) error); ok {
+		r0 = rf(ctx, def, signingIdentity, waitConfirm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateIdentity provides a mock function with given fields: ctx, identity, def, signingIdentity, waitConfirm
 func (_m *Sender) UpdateIdentity(ctx context.Context, identity *core.Identity, def *core.IdentityUpdate, signingIdentity *core.SignerRef, waitConfirm bool) error {
 	ret := _m.Called(ctx, identity, def, signingIdentity, waitConfirm)
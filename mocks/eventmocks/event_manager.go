@@ -47,6 +47,36 @@ func (_m *EventManager) AddSystemEventListener(ns string, el system.EventListene
 	return r0
 }
 
+// AttachBatchPayload provides a mock function with given fields: ctx, batch
+func (_m *EventManager) AttachBatchPayload(ctx context.Context, batch *core.Batch) (*core.BatchPersisted, error) {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachBatchPayload")
+	}
+
+	var r0 *core.BatchPersisted
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Batch) (*core.BatchPersisted, error)); ok {
+		return rf(ctx, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Batch) *core.BatchPersisted); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BatchPersisted)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Batch) error); ok {
+		r1 = rf(ctx, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockchainEventBatch provides a mock function with given fields: batch
 func (_m *EventManager) BlockchainEventBatch(batch []*blockchain.EventToDispatch) error {
 	ret := _m.Called(batch)
@@ -351,6 +381,66 @@ func (_m *EventManager) ResolveTransportAndCapabilities(ctx context.Context, tra
 	return r0, r1, r2
 }
 
+// RevalidateQuarantineEntry provides a mock function with given fields: ctx, entryID
+func (_m *EventManager) RevalidateQuarantineEntry(ctx context.Context, entryID string) (*core.QuarantineRevalidation, error) {
+	ret := _m.Called(ctx, entryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevalidateQuarantineEntry")
+	}
+
+	var r0 *core.QuarantineRevalidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.QuarantineRevalidation, error)); ok {
+		return rf(ctx, entryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.QuarantineRevalidation); ok {
+		r0 = rf(ctx, entryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.QuarantineRevalidation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, entryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewQuarantinedMessage provides a mock function with given fields: ctx, msgID, review
+func (_m *EventManager) ReviewQuarantinedMessage(ctx context.Context, msgID string, review *core.QuarantineReview) (*core.Message, error) {
+	ret := _m.Called(ctx, msgID, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewQuarantinedMessage")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.QuarantineReview) (*core.Message, error)); ok {
+		return rf(ctx, msgID, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.QuarantineReview) *core.Message); ok {
+		r0 = rf(ctx, msgID, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.QuarantineReview) error); ok {
+		r1 = rf(ctx, msgID, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SharedStorageBatchDownloaded provides a mock function with given fields: ss, payloadRef, data
 func (_m *EventManager) SharedStorageBatchDownloaded(ss sharedstorage.Plugin, payloadRef string, data []byte) (*fftypes.UUID, error) {
 	ret := _m.Called(ss, payloadRef, data)
@@ -437,6 +527,36 @@ func (_m *EventManager) SubscriptionUpdates() chan<- *fftypes.UUID {
 	return r0
 }
 
+// TestSubscriptionFilter provides a mock function with given fields: ctx, sub, event
+func (_m *EventManager) TestSubscriptionFilter(ctx context.Context, sub *core.Subscription, event *core.EnrichedEvent) (*core.SubscriptionTestResult, error) {
+	ret := _m.Called(ctx, sub, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestSubscriptionFilter")
+	}
+
+	var r0 *core.SubscriptionTestResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, *core.EnrichedEvent) (*core.SubscriptionTestResult, error)); ok {
+		return rf(ctx, sub, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Subscription, *core.EnrichedEvent) *core.SubscriptionTestResult); ok {
+		r0 = rf(ctx, sub, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SubscriptionTestResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Subscription, *core.EnrichedEvent) error); ok {
+		r1 = rf(ctx, sub, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TokenPoolCreated provides a mock function with given fields: ctx, ti, pool
 func (_m *EventManager) TokenPoolCreated(ctx context.Context, ti tokens.Plugin, pool *tokens.TokenPool) error {
 	ret := _m.Called(ctx, ti, pool)
@@ -9,6 +9,8 @@ import (
 
 	core "github.com/hyperledger/firefly/pkg/core"
 
+	ffapi "github.com/hyperledger/firefly-common/pkg/ffapi"
+
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
 	mock "github.com/stretchr/testify/mock"
@@ -123,6 +125,102 @@ func (_m *Manager) CachedIdentityLookupNilOK(ctx context.Context, did string) (*
 	return r0, r1, r2
 }
 
+// CreateAPIKey provides a mock function with given fields: ctx, key
+func (_m *Manager) CreateAPIKey(ctx context.Context, key *core.APIKey) (*core.APIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAPIKey")
+	}
+
+	var r0 *core.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.APIKey) (*core.APIKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.APIKey) *core.APIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.APIKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAddressBookEntry provides a mock function with given fields: ctx, entry
+func (_m *Manager) CreateAddressBookEntry(ctx context.Context, entry *core.AddressBookEntry) (*core.AddressBookEntry, error) {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAddressBookEntry")
+	}
+
+	var r0 *core.AddressBookEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AddressBookEntry) (*core.AddressBookEntry, error)); ok {
+		return rf(ctx, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.AddressBookEntry) *core.AddressBookEntry); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.AddressBookEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.AddressBookEntry) error); ok {
+		r1 = rf(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAPIKey provides a mock function with given fields: ctx, id
+func (_m *Manager) DeleteAPIKey(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAPIKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAddressBookEntry provides a mock function with given fields: ctx, name
+func (_m *Manager) DeleteAddressBookEntry(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAddressBookEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindIdentityForVerifier provides a mock function with given fields: ctx, iTypes, verifier
 func (_m *Manager) FindIdentityForVerifier(ctx context.Context, iTypes []fftypes.FFEnum, verifier *core.VerifierRef) (*core.Identity, error) {
 	ret := _m.Called(ctx, iTypes, verifier)
@@ -153,6 +251,144 @@ func (_m *Manager) FindIdentityForVerifier(ctx context.Context, iTypes []fftypes
 	return r0, r1
 }
 
+// GetAPIKeyByID provides a mock function with given fields: ctx, id
+func (_m *Manager) GetAPIKeyByID(ctx context.Context, id string) (*core.APIKey, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeyByID")
+	}
+
+	var r0 *core.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.APIKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.APIKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAPIKeys provides a mock function with given fields: ctx, filter
+func (_m *Manager) GetAPIKeys(ctx context.Context, filter ffapi.AndFilter) ([]*core.APIKey, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeys")
+	}
+
+	var r0 []*core.APIKey
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.APIKey, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.APIKey); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAddressBookEntries provides a mock function with given fields: ctx, filter
+func (_m *Manager) GetAddressBookEntries(ctx context.Context, filter ffapi.AndFilter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAddressBookEntries")
+	}
+
+	var r0 []*core.AddressBookEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.AddressBookEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.AddressBookEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAddressBookEntryByName provides a mock function with given fields: ctx, name
+func (_m *Manager) GetAddressBookEntryByName(ctx context.Context, name string) (*core.AddressBookEntry, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAddressBookEntryByName")
+	}
+
+	var r0 *core.AddressBookEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.AddressBookEntry, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.AddressBookEntry); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.AddressBookEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLocalNode provides a mock function with given fields: ctx
 func (_m *Manager) GetLocalNode(ctx context.Context) (*core.Identity, error) {
 	ret := _m.Called(ctx)
@@ -241,6 +477,182 @@ func (_m *Manager) GetRootOrgDID(ctx context.Context) (string, error) {
 	return r0, r1
 }
 
+// IsDelegated provides a mock function with given fields: ctx, delegatorDID, delegateDID, scope, at
+func (_m *Manager) IsDelegated(ctx context.Context, delegatorDID string, delegateDID string, scope fftypes.FFEnum, at *fftypes.FFTime) (bool, error) {
+	ret := _m.Called(ctx, delegatorDID, delegateDID, scope, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsDelegated")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.FFEnum, *fftypes.FFTime) (bool, error)); ok {
+		return rf(ctx, delegatorDID, delegateDID, scope, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.FFEnum, *fftypes.FFTime) bool); ok {
+		r0 = rf(ctx, delegatorDID, delegateDID, scope, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(bool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, fftypes.FFEnum, *fftypes.FFTime) error); ok {
+		r1 = rf(ctx, delegatorDID, delegateDID, scope, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsVerifierRevoked provides a mock function with given fields: ctx, verifier
+func (_m *Manager) IsVerifierRevoked(ctx context.Context, verifier *core.VerifierRef) (bool, error) {
+	ret := _m.Called(ctx, verifier)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsVerifierRevoked")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifierRef) (bool, error)); ok {
+		return rf(ctx, verifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifierRef) bool); ok {
+		r0 = rf(ctx, verifier)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.VerifierRef) error); ok {
+		r1 = rf(ctx, verifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsVerifierRevokedAtPin provides a mock function with given fields: ctx, verifier, pinSequence
+func (_m *Manager) IsVerifierRevokedAtPin(ctx context.Context, verifier *core.VerifierRef, pinSequence int64) (bool, error) {
+	ret := _m.Called(ctx, verifier, pinSequence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsVerifierRevokedAtPin")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifierRef, int64) (bool, error)); ok {
+		return rf(ctx, verifier, pinSequence)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifierRef, int64) bool); ok {
+		r0 = rf(ctx, verifier, pinSequence)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.VerifierRef, int64) error); ok {
+		r1 = rf(ctx, verifier, pinSequence)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveAPIKey provides a mock function with given fields: ctx, key
+func (_m *Manager) ResolveAPIKey(ctx context.Context, key string) (*core.APIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveAPIKey")
+	}
+
+	var r0 *core.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.APIKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.APIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveAddressAlias provides a mock function with given fields: ctx, input
+func (_m *Manager) ResolveAddressAlias(ctx context.Context, input string) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveAddressAlias")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolveDelegatedAuthor provides a mock function with given fields: ctx, author, key, scope
+func (_m *Manager) ResolveDelegatedAuthor(ctx context.Context, author string, key string, scope fftypes.FFEnum) (string, error) {
+	ret := _m.Called(ctx, author, key, scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveDelegatedAuthor")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.FFEnum) (string, error)); ok {
+		return rf(ctx, author, key, scope)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, fftypes.FFEnum) string); ok {
+		r0 = rf(ctx, author, key, scope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, fftypes.FFEnum) error); ok {
+		r1 = rf(ctx, author, key, scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResolveIdentitySigner provides a mock function with given fields: ctx, _a1
 func (_m *Manager) ResolveIdentitySigner(ctx context.Context, _a1 *core.Identity) (*core.SignerRef, error) {
 	ret := _m.Called(ctx, _a1)
@@ -433,6 +845,24 @@ func (_m *Manager) ValidateNodeOwner(ctx context.Context, node *core.Identity, _
 	return r0, r1
 }
 
+// VerifyClaimSignature provides a mock function with given fields: ctx, signingKey, claim
+func (_m *Manager) VerifyClaimSignature(ctx context.Context, signingKey string, claim *core.IdentityClaim) error {
+	ret := _m.Called(ctx, signingKey, claim)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyClaimSignature")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.IdentityClaim) error); ok {
+		r0 = rf(ctx, signingKey, claim)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VerifyIdentityChain provides a mock function with given fields: ctx, _a1
 func (_m *Manager) VerifyIdentityChain(ctx context.Context, _a1 *core.Identity) (*core.Identity, bool, error) {
 	ret := _m.Called(ctx, _a1)
@@ -470,6 +900,34 @@ func (_m *Manager) VerifyIdentityChain(ctx context.Context, _a1 *core.Identity)
 	return r0, r1, r2
 }
 
+// VerifyOrgCertificate provides a mock function with given fields: ctx, claim, claimTime
+func (_m *Manager) VerifyOrgCertificate(ctx context.Context, claim *core.IdentityClaim, claimTime *fftypes.FFTime) (string, error) {
+	ret := _m.Called(ctx, claim, claimTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyOrgCertificate")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.IdentityClaim, *fftypes.FFTime) (string, error)); ok {
+		return rf(ctx, claim, claimTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.IdentityClaim, *fftypes.FFTime) string); ok {
+		r0 = rf(ctx, claim, claimTime)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.IdentityClaim, *fftypes.FFTime) error); ok {
+		r1 = rf(ctx, claim, claimTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
@@ -26,11 +26,46 @@ func (_m *Manager) BlockchainContractDeployment() {
 	_m.Called()
 }
 
+// BatchAssembled provides a mock function with given fields: namespace, dispatcher, wait
+func (_m *Manager) BatchAssembled(namespace string, dispatcher string, wait time.Duration) {
+	_m.Called(namespace, dispatcher, wait)
+}
+
+// BatchFlushFailed provides a mock function with given fields: namespace, dispatcher
+func (_m *Manager) BatchFlushFailed(namespace string, dispatcher string) {
+	_m.Called(namespace, dispatcher)
+}
+
+// BatchFlushed provides a mock function with given fields: namespace, dispatcher, messages, duration
+func (_m *Manager) BatchFlushed(namespace string, dispatcher string, messages int, duration time.Duration) {
+	_m.Called(namespace, dispatcher, messages, duration)
+}
+
+// BatchWorkerWait provides a mock function with given fields: namespace, wait
+func (_m *Manager) BatchWorkerWait(namespace string, wait time.Duration) {
+	_m.Called(namespace, wait)
+}
+
+// BatchPinConfirmed provides a mock function with given fields: batchID
+func (_m *Manager) BatchPinConfirmed(batchID *fftypes.UUID) {
+	_m.Called(batchID)
+}
+
 // BlockchainEvent provides a mock function with given fields: location, signature
 func (_m *Manager) BlockchainEvent(location string, signature string) {
 	_m.Called(location, signature)
 }
 
+// BlockchainEventDecodeCacheHit provides a mock function with given fields: signature
+func (_m *Manager) BlockchainEventDecodeCacheHit(signature string) {
+	_m.Called(signature)
+}
+
+// BlockchainEventDecodeCacheMiss provides a mock function with given fields: signature
+func (_m *Manager) BlockchainEventDecodeCacheMiss(signature string) {
+	_m.Called(signature)
+}
+
 // BlockchainQuery provides a mock function with given fields: location, methodName
 func (_m *Manager) BlockchainQuery(location string, methodName string) {
 	_m.Called(location, methodName)
@@ -41,9 +76,24 @@ func (_m *Manager) BlockchainTransaction(location string, methodName string) {
 	_m.Called(location, methodName)
 }
 
-// CountBatchPin provides a mock function with given fields:
-func (_m *Manager) CountBatchPin() {
-	_m.Called()
+// ContractQueryCacheHit provides a mock function with given fields: apiName, methodPath
+func (_m *Manager) ContractQueryCacheHit(apiName string, methodPath string) {
+	_m.Called(apiName, methodPath)
+}
+
+// ContractQueryCacheMiss provides a mock function with given fields: apiName, methodPath
+func (_m *Manager) ContractQueryCacheMiss(apiName string, methodPath string) {
+	_m.Called(apiName, methodPath)
+}
+
+// CountBatchPin provides a mock function with given fields: batchID
+func (_m *Manager) CountBatchPin(batchID *fftypes.UUID) {
+	_m.Called(batchID)
+}
+
+// DatabaseOperation provides a mock function with given fields: table, operation, duration, err
+func (_m *Manager) DatabaseOperation(table string, operation string, duration time.Duration, err error) {
+	_m.Called(table, operation, duration, err)
 }
 
 // DeleteTime provides a mock function with given fields: id
@@ -51,6 +101,11 @@ func (_m *Manager) DeleteTime(id string) {
 	_m.Called(id)
 }
 
+// EventDispatched provides a mock function with given fields: namespace, transport, lag
+func (_m *Manager) EventDispatched(namespace string, transport string, lag time.Duration) {
+	_m.Called(namespace, transport, lag)
+}
+
 // GetTime provides a mock function with given fields: id
 func (_m *Manager) GetTime(id string) time.Time {
 	ret := _m.Called(id)
@@ -97,6 +152,16 @@ func (_m *Manager) MessageSubmitted(msg *core.Message) {
 	_m.Called(msg)
 }
 
+// PluginEventValidationFailed provides a mock function with given fields: plugin, eventType, failure
+func (_m *Manager) PluginEventValidationFailed(plugin string, eventType string, failure string) {
+	_m.Called(plugin, eventType, failure)
+}
+
+// PluginOperationFailed provides a mock function with given fields: namespace, plugin, opType
+func (_m *Manager) PluginOperationFailed(namespace string, plugin string, opType fftypes.FFEnum) {
+	_m.Called(namespace, plugin, opType)
+}
+
 // TransferConfirmed provides a mock function with given fields: transfer
 func (_m *Manager) TransferConfirmed(transfer *core.TokenTransfer) {
 	_m.Called(transfer)
@@ -14,6 +14,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	multiparty "github.com/hyperledger/firefly/internal/multiparty"
+
+	x509 "crypto/x509"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -21,6 +23,24 @@ type Manager struct {
 	mock.Mock
 }
 
+// CheckContractMigration provides a mock function with given fields: ctx, event
+func (_m *Manager) CheckContractMigration(ctx context.Context, event *blockchain.Event) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckContractMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *blockchain.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ConfigureContract provides a mock function with given fields: ctx
 func (_m *Manager) ConfigureContract(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -93,6 +113,26 @@ func (_m *Manager) Name() string {
 	return r0
 }
 
+// OrgTrustRoots provides a mock function with given fields:
+func (_m *Manager) OrgTrustRoots() *x509.CertPool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for OrgTrustRoots")
+	}
+
+	var r0 *x509.CertPool
+	if rf, ok := ret.Get(0).(func() *x509.CertPool); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*x509.CertPool)
+		}
+	}
+
+	return r0
+}
+
 // PrepareOperation provides a mock function with given fields: ctx, op
 func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
 	ret := _m.Called(ctx, op)
@@ -123,6 +163,42 @@ func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*c
 	return r0, r1
 }
 
+// RequeryPins provides a mock function with given fields: ctx, fromProtocolID
+func (_m *Manager) RequeryPins(ctx context.Context, fromProtocolID string) error {
+	ret := _m.Called(ctx, fromProtocolID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeryPins")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, fromProtocolID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequireSignedClaims provides a mock function with given fields:
+func (_m *Manager) RequireSignedClaims() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequireSignedClaims")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // RootOrg provides a mock function with given fields:
 func (_m *Manager) RootOrg() multiparty.RootOrg {
 	ret := _m.Called()
@@ -178,6 +254,34 @@ func (_m *Manager) RunOperation(ctx context.Context, op *core.PreparedOperation)
 	return r0, r1, r2
 }
 
+// ScheduleContractMigration provides a mock function with given fields: ctx, migration
+func (_m *Manager) ScheduleContractMigration(ctx context.Context, migration *core.ContractMigration) (bool, error) {
+	ret := _m.Called(ctx, migration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleContractMigration")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigration) (bool, error)); ok {
+		return rf(ctx, migration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigration) bool); ok {
+		r0 = rf(ctx, migration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.ContractMigration) error); ok {
+		r1 = rf(ctx, migration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SubmitBatchPin provides a mock function with given fields: ctx, batch, contexts, payloadRef, idempotentSubmit
 func (_m *Manager) SubmitBatchPin(ctx context.Context, batch *core.BatchPersisted, contexts []*fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
 	ret := _m.Called(ctx, batch, contexts, payloadRef, idempotentSubmit)
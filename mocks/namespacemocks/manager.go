@@ -98,6 +98,26 @@ func (_m *Manager) GetOperationByNamespacedID(ctx context.Context, nsOpID string
 	return r0, r1
 }
 
+// GetReadiness provides a mock function with given fields: ctx
+func (_m *Manager) GetReadiness(ctx context.Context) *core.NodeReadiness {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReadiness")
+	}
+
+	var r0 *core.NodeReadiness
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NodeReadiness); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NodeReadiness)
+		}
+	}
+
+	return r0
+}
+
 // Init provides a mock function with given fields: ctx, cancelCtx, reset, reloadConfig
 func (_m *Manager) Init(ctx context.Context, cancelCtx context.CancelFunc, reset chan bool, reloadConfig func() error) error {
 	ret := _m.Called(ctx, cancelCtx, reset, reloadConfig)
@@ -116,6 +136,24 @@ func (_m *Manager) Init(ctx context.Context, cancelCtx context.CancelFunc, reset
 	return r0
 }
 
+// IsStandby provides a mock function with given fields: ns
+func (_m *Manager) IsStandby(ns string) bool {
+	ret := _m.Called(ns)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsStandby")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(ns)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // MustOrchestrator provides a mock function with given fields: ns
 func (_m *Manager) MustOrchestrator(ns string) orchestrator.Orchestrator {
 	ret := _m.Called(ns)
@@ -202,6 +240,24 @@ func (_m *Manager) ResolveOperationByNamespacedID(ctx context.Context, nsOpID st
 	return r0
 }
 
+// RestartPlugin provides a mock function with given fields: ctx, pluginType, name
+func (_m *Manager) RestartPlugin(ctx context.Context, pluginType string, name string) error {
+	ret := _m.Called(ctx, pluginType, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestartPlugin")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, pluginType, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SPIEvents provides a mock function with given fields:
 func (_m *Manager) SPIEvents() spievents.Manager {
 	ret := _m.Called()
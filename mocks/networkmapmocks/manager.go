@@ -18,6 +18,36 @@ type Manager struct {
 	mock.Mock
 }
 
+// CreateDelegation provides a mock function with given fields: ctx, delegation, waitConfirm
+func (_m *Manager) CreateDelegation(ctx context.Context, delegation *core.Delegation, waitConfirm bool) (*core.Delegation, error) {
+	ret := _m.Called(ctx, delegation, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDelegation")
+	}
+
+	var r0 *core.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Delegation, bool) (*core.Delegation, error)); ok {
+		return rf(ctx, delegation, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.Delegation, bool) *core.Delegation); ok {
+		r0 = rf(ctx, delegation, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.Delegation, bool) error); ok {
+		r1 = rf(ctx, delegation, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDIDDocForIndentityByDID provides a mock function with given fields: ctx, did
 func (_m *Manager) GetDIDDocForIndentityByDID(ctx context.Context, did string) (*networkmap.DIDDocument, error) {
 	ret := _m.Called(ctx, did)
@@ -78,6 +108,75 @@ func (_m *Manager) GetDIDDocForIndentityByID(ctx context.Context, id string) (*n
 	return r0, r1
 }
 
+// GetDelegationByID provides a mock function with given fields: ctx, id
+func (_m *Manager) GetDelegationByID(ctx context.Context, id string) (*core.Delegation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegationByID")
+	}
+
+	var r0 *core.Delegation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Delegation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Delegation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDelegations provides a mock function with given fields: ctx, filter
+func (_m *Manager) GetDelegations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Delegation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelegations")
+	}
+
+	var r0 []*core.Delegation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Delegation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Delegation); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Delegation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetIdentities provides a mock function with given fields: ctx, filter
 func (_m *Manager) GetIdentities(ctx context.Context, filter ffapi.AndFilter) ([]*core.Identity, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -345,6 +444,36 @@ func (_m *Manager) GetNodeByNameOrID(ctx context.Context, nameOrID string) (*cor
 	return r0, r1
 }
 
+// GetNodeStatus provides a mock function with given fields: ctx, nameOrID
+func (_m *Manager) GetNodeStatus(ctx context.Context, nameOrID string) (*core.NodeStatus, error) {
+	ret := _m.Called(ctx, nameOrID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNodeStatus")
+	}
+
+	var r0 *core.NodeStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.NodeStatus, error)); ok {
+		return rf(ctx, nameOrID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.NodeStatus); ok {
+		r0 = rf(ctx, nameOrID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NodeStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, nameOrID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNodes provides a mock function with given fields: ctx, filter
 func (_m *Manager) GetNodes(ctx context.Context, filter ffapi.AndFilter) ([]*core.Identity, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -681,6 +810,71 @@ func (_m *Manager) RegisterOrganization(ctx context.Context, org *core.IdentityC
 	return r0, r1
 }
 
+// RevokeNode provides a mock function with given fields: ctx, nameOrID, dto, waitConfirm
+func (_m *Manager) RevokeNode(ctx context.Context, nameOrID string, dto *core.VerifierRevocationDTO, waitConfirm bool) (*core.VerifierRevocation, error) {
+	ret := _m.Called(ctx, nameOrID, dto, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeNode")
+	}
+
+	var r0 *core.VerifierRevocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.VerifierRevocationDTO, bool) (*core.VerifierRevocation, error)); ok {
+		return rf(ctx, nameOrID, dto, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.VerifierRevocationDTO, bool) *core.VerifierRevocation); ok {
+		r0 = rf(ctx, nameOrID, dto, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.VerifierRevocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.VerifierRevocationDTO, bool) error); ok {
+		r1 = rf(ctx, nameOrID, dto, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeVerifier provides a mock function with given fields: ctx, hash, dto, waitConfirm
+func (_m *Manager) RevokeVerifier(ctx context.Context, hash string, dto *core.VerifierRevocationDTO, waitConfirm bool) (*core.VerifierRevocation, error) {
+	ret := _m.Called(ctx, hash, dto, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeVerifier")
+	}
+
+	var r0 *core.VerifierRevocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.VerifierRevocationDTO, bool) (*core.VerifierRevocation, error)); ok {
+		return rf(ctx, hash, dto, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.VerifierRevocationDTO, bool) *core.VerifierRevocation); ok {
+		r0 = rf(ctx, hash, dto, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.VerifierRevocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.VerifierRevocationDTO, bool) error); ok {
+		r1 = rf(ctx, hash, dto, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Start provides a mock function with given fields:
+func (_m *Manager) Start() {
+	_m.Called()
+}
+
 // UpdateIdentity provides a mock function with given fields: ctx, id, dto, waitConfirm
 func (_m *Manager) UpdateIdentity(ctx context.Context, id string, dto *core.IdentityUpdateDTO, waitConfirm bool) (*core.Identity, error) {
 	ret := _m.Called(ctx, id, dto, waitConfirm)
@@ -711,6 +905,41 @@ func (_m *Manager) UpdateIdentity(ctx context.Context, id string, dto *core.Iden
 	return r0, r1
 }
 
+// UpdateNode provides a mock function with given fields: ctx, waitConfirm
+func (_m *Manager) UpdateNode(ctx context.Context, waitConfirm bool) (*core.Identity, error) {
+	ret := _m.Called(ctx, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNode")
+	}
+
+	var r0 *core.Identity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool) (*core.Identity, error)); ok {
+		return rf(ctx, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bool) *core.Identity); ok {
+		r0 = rf(ctx, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Identity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = rf(ctx, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitStop provides a mock function with given fields:
+func (_m *Manager) WaitStop() {
+	_m.Called()
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
@@ -8,6 +8,8 @@ import (
 	core "github.com/hyperledger/firefly/pkg/core"
 	database "github.com/hyperledger/firefly/pkg/database"
 
+	ffapi "github.com/hyperledger/firefly-common/pkg/ffapi"
+
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
 	mock "github.com/stretchr/testify/mock"
@@ -45,6 +47,36 @@ func (_m *Manager) AddOrReuseOperation(ctx context.Context, op *core.Operation,
 	return r0
 }
 
+// AttachTransaction provides a mock function with given fields: ctx, opID, attach
+func (_m *Manager) AttachTransaction(ctx context.Context, opID *fftypes.UUID, attach *core.OperationAttachTX) (*core.Operation, error) {
+	ret := _m.Called(ctx, opID, attach)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachTransaction")
+	}
+
+	var r0 *core.Operation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *core.OperationAttachTX) (*core.Operation, error)); ok {
+		return rf(ctx, opID, attach)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *core.OperationAttachTX) *core.Operation); ok {
+		r0 = rf(ctx, opID, attach)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.UUID, *core.OperationAttachTX) error); ok {
+		r1 = rf(ctx, opID, attach)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BulkInsertOperations provides a mock function with given fields: ctx, ops
 func (_m *Manager) BulkInsertOperations(ctx context.Context, ops ...*core.Operation) error {
 	_va := make([]interface{}, len(ops))
@@ -70,6 +102,36 @@ func (_m *Manager) BulkInsertOperations(ctx context.Context, ops ...*core.Operat
 	return r0
 }
 
+// CompensateTransaction provides a mock function with given fields: ctx, txID
+func (_m *Manager) CompensateTransaction(ctx context.Context, txID *fftypes.UUID) ([]*core.Compensation, error) {
+	ret := _m.Called(ctx, txID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompensateTransaction")
+	}
+
+	var r0 []*core.Compensation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID) ([]*core.Compensation, error)); ok {
+		return rf(ctx, txID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID) []*core.Compensation); ok {
+		r0 = rf(ctx, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Compensation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *fftypes.UUID) error); ok {
+		r1 = rf(ctx, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetOperationByIDCached provides a mock function with given fields: ctx, opID
 func (_m *Manager) GetOperationByIDCached(ctx context.Context, opID *fftypes.UUID) (*core.Operation, error) {
 	ret := _m.Called(ctx, opID)
@@ -100,6 +162,65 @@ func (_m *Manager) GetOperationByIDCached(ctx context.Context, opID *fftypes.UUI
 	return r0, r1
 }
 
+// GetRecoveryReport provides a mock function with given fields: ctx
+func (_m *Manager) GetRecoveryReport(ctx context.Context) *core.RecoveryReport {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecoveryReport")
+	}
+
+	var r0 *core.RecoveryReport
+	if rf, ok := ret.Get(0).(func(context.Context) *core.RecoveryReport); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.RecoveryReport)
+		}
+	}
+
+	return r0
+}
+
+// GetStalledOperations provides a mock function with given fields: ctx, filter
+func (_m *Manager) GetStalledOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStalledOperations")
+	}
+
+	var r0 []*core.Operation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Operation); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // PrepareOperation provides a mock function with given fields: ctx, op
 func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*core.PreparedOperation, error) {
 	ret := _m.Called(ctx, op)
@@ -130,11 +251,26 @@ func (_m *Manager) PrepareOperation(ctx context.Context, op *core.Operation) (*c
 	return r0, r1
 }
 
+// RegisterCompensation provides a mock function with given fields: ctx, handler, ops
+func (_m *Manager) RegisterCompensation(ctx context.Context, handler operations.CompensationHandler, ops []fftypes.FFEnum) {
+	_m.Called(ctx, handler, ops)
+}
+
 // RegisterHandler provides a mock function with given fields: ctx, handler, ops
 func (_m *Manager) RegisterHandler(ctx context.Context, handler operations.OperationHandler, ops []fftypes.FFEnum) {
 	_m.Called(ctx, handler, ops)
 }
 
+// RegisterReceiptFetcher provides a mock function with given fields: ctx, fetcher, ops
+func (_m *Manager) RegisterReceiptFetcher(ctx context.Context, fetcher operations.ReceiptFetcher, ops []fftypes.FFEnum) {
+	_m.Called(ctx, fetcher, ops)
+}
+
+// RegisterReconciler provides a mock function with given fields: ctx, reconciler, ops
+func (_m *Manager) RegisterReconciler(ctx context.Context, reconciler operations.OperationReconciler, ops []fftypes.FFEnum) {
+	_m.Called(ctx, reconciler, ops)
+}
+
 // ResolveOperationByID provides a mock function with given fields: ctx, opID, op
 func (_m *Manager) ResolveOperationByID(ctx context.Context, opID *fftypes.UUID, op *core.OperationUpdateDTO) error {
 	ret := _m.Called(ctx, opID, op)
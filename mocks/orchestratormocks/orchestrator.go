@@ -36,6 +36,8 @@ import (
 
 	operations "github.com/hyperledger/firefly/internal/operations"
 
+	orchestrator "github.com/hyperledger/firefly/internal/orchestrator"
+
 	privatemessaging "github.com/hyperledger/firefly/internal/privatemessaging"
 )
 
@@ -64,6 +66,36 @@ func (_m *Orchestrator) Assets() assets.Manager {
 	return r0
 }
 
+// AttachBatchPayload provides a mock function with given fields: ctx, id, batch
+func (_m *Orchestrator) AttachBatchPayload(ctx context.Context, id string, batch *core.Batch) (*core.BatchPersisted, error) {
+	ret := _m.Called(ctx, id, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachBatchPayload")
+	}
+
+	var r0 *core.BatchPersisted
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.Batch) (*core.BatchPersisted, error)); ok {
+		return rf(ctx, id, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.Batch) *core.BatchPersisted); ok {
+		r0 = rf(ctx, id, batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BatchPersisted)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.Batch) error); ok {
+		r1 = rf(ctx, id, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Authorize provides a mock function with given fields: ctx, authReq
 func (_m *Orchestrator) Authorize(ctx context.Context, authReq *fftypes.AuthReq) error {
 	ret := _m.Called(ctx, authReq)
@@ -122,6 +154,36 @@ func (_m *Orchestrator) Broadcast() broadcast.Manager {
 	return r0
 }
 
+// CompensateTransaction provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) CompensateTransaction(ctx context.Context, id string) ([]*core.Compensation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompensateTransaction")
+	}
+
+	var r0 []*core.Compensation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.Compensation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.Compensation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Compensation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Contracts provides a mock function with given fields:
 func (_m *Orchestrator) Contracts() contracts.Manager {
 	ret := _m.Called()
@@ -242,17 +304,17 @@ func (_m *Orchestrator) DefinitionSender() definitions.Sender {
 	return r0
 }
 
-// DeleteSubscription provides a mock function with given fields: ctx, id
-func (_m *Orchestrator) DeleteSubscription(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// DeleteSubscription provides a mock function with given fields: ctx, id, owner
+func (_m *Orchestrator) DeleteSubscription(ctx context.Context, id string, owner string) error {
+	ret := _m.Called(ctx, id, owner)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteSubscription")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, owner)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -280,6 +342,66 @@ func (_m *Orchestrator) Events() events.EventManager {
 	return r0
 }
 
+// ExportDefinitions provides a mock function with given fields: ctx
+func (_m *Orchestrator) ExportDefinitions(ctx context.Context) (*core.DefinitionBundle, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportDefinitions")
+	}
+
+	var r0 *core.DefinitionBundle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.DefinitionBundle, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.DefinitionBundle); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.DefinitionBundle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatchAnchorProof provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetBatchAnchorProof(ctx context.Context, id string) (*core.BatchAnchorProof, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchAnchorProof")
+	}
+
+	var r0 *core.BatchAnchorProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BatchAnchorProof, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BatchAnchorProof); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BatchAnchorProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBatchByID provides a mock function with given fields: ctx, id
 func (_m *Orchestrator) GetBatchByID(ctx context.Context, id string) (*core.BatchPersisted, error) {
 	ret := _m.Called(ctx, id)
@@ -310,6 +432,36 @@ func (_m *Orchestrator) GetBatchByID(ctx context.Context, id string) (*core.Batc
 	return r0, r1
 }
 
+// GetBatchPayload provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetBatchPayload(ctx context.Context, id string) (*core.Batch, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatchPayload")
+	}
+
+	var r0 *core.Batch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.Batch, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.Batch); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Batch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBatches provides a mock function with given fields: ctx, filter
 func (_m *Orchestrator) GetBatches(ctx context.Context, filter ffapi.AndFilter) ([]*core.BatchPersisted, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -418,6 +570,36 @@ func (_m *Orchestrator) GetBlockchainEvents(ctx context.Context, filter ffapi.An
 	return r0, r1, r2
 }
 
+// GetChartAggregate provides a mock function with given fields: ctx, collection, interval, startTime, endTime
+func (_m *Orchestrator) GetChartAggregate(ctx context.Context, collection database.CollectionName, interval fftypes.FFEnum, startTime *fftypes.FFTime, endTime *fftypes.FFTime) ([]*core.ChartHistogram, error) {
+	ret := _m.Called(ctx, collection, interval, startTime, endTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChartAggregate")
+	}
+
+	var r0 []*core.ChartHistogram
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CollectionName, fftypes.FFEnum, *fftypes.FFTime, *fftypes.FFTime) ([]*core.ChartHistogram, error)); ok {
+		return rf(ctx, collection, interval, startTime, endTime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CollectionName, fftypes.FFEnum, *fftypes.FFTime, *fftypes.FFTime) []*core.ChartHistogram); ok {
+		r0 = rf(ctx, collection, interval, startTime, endTime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ChartHistogram)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CollectionName, fftypes.FFEnum, *fftypes.FFTime, *fftypes.FFTime) error); ok {
+		r1 = rf(ctx, collection, interval, startTime, endTime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChartHistogram provides a mock function with given fields: ctx, startTime, endTime, buckets, tableName
 func (_m *Orchestrator) GetChartHistogram(ctx context.Context, startTime int64, endTime int64, buckets int64, tableName database.CollectionName) ([]*core.ChartHistogram, error) {
 	ret := _m.Called(ctx, startTime, endTime, buckets, tableName)
@@ -814,6 +996,36 @@ func (_m *Orchestrator) GetMessageByID(ctx context.Context, id string) (*core.Me
 	return r0, r1
 }
 
+// GetMessageByIDAsOf provides a mock function with given fields: ctx, id, asOf
+func (_m *Orchestrator) GetMessageByIDAsOf(ctx context.Context, id string, asOf string) (*core.Message, error) {
+	ret := _m.Called(ctx, id, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageByIDAsOf")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*core.Message, error)); ok {
+		return rf(ctx, id, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *core.Message); ok {
+		r0 = rf(ctx, id, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMessageByIDWithData provides a mock function with given fields: ctx, id
 func (_m *Orchestrator) GetMessageByIDWithData(ctx context.Context, id string) (*core.MessageInOut, error) {
 	ret := _m.Called(ctx, id)
@@ -913,6 +1125,36 @@ func (_m *Orchestrator) GetMessageEvents(ctx context.Context, id string, filter
 	return r0, r1, r2
 }
 
+// GetMessageProof provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetMessageProof(ctx context.Context, id string) (*core.MessageProof, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessageProof")
+	}
+
+	var r0 *core.MessageProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.MessageProof, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.MessageProof); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.MessageProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMessageTransaction provides a mock function with given fields: ctx, id
 func (_m *Orchestrator) GetMessageTransaction(ctx context.Context, id string) (*core.Transaction, error) {
 	ret := _m.Called(ctx, id)
@@ -982,6 +1224,45 @@ func (_m *Orchestrator) GetMessages(ctx context.Context, filter ffapi.AndFilter)
 	return r0, r1, r2
 }
 
+// GetMessagesAsOf provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetMessagesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf string) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesAsOf")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, string) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, string) []*core.Message); ok {
+		r0 = rf(ctx, filter, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter, string) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter, asOf)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter, string) error); ok {
+		r2 = rf(ctx, filter, asOf)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetMessagesForData provides a mock function with given fields: ctx, dataID, filter
 func (_m *Orchestrator) GetMessagesForData(ctx context.Context, dataID string, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, dataID, filter)
@@ -1060,6 +1341,45 @@ func (_m *Orchestrator) GetMessagesWithData(ctx context.Context, filter ffapi.An
 	return r0, r1, r2
 }
 
+// GetMessagesWithEmbedded provides a mock function with given fields: ctx, filter, embed
+func (_m *Orchestrator) GetMessagesWithEmbedded(ctx context.Context, filter ffapi.AndFilter, embed []fftypes.FFEnum) ([]*core.MessageWithEmbedded, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter, embed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMessagesWithEmbedded")
+	}
+
+	var r0 []*core.MessageWithEmbedded
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) ([]*core.MessageWithEmbedded, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter, embed)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) []*core.MessageWithEmbedded); ok {
+		r0 = rf(ctx, filter, embed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.MessageWithEmbedded)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter, embed)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) error); ok {
+		r2 = rf(ctx, filter, embed)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetMultipartyStatus provides a mock function with given fields: ctx
 func (_m *Orchestrator) GetMultipartyStatus(ctx context.Context) (*core.NamespaceMultipartyStatus, error) {
 	ret := _m.Called(ctx)
@@ -1110,6 +1430,36 @@ func (_m *Orchestrator) GetNamespace(ctx context.Context) *core.Namespace {
 	return r0
 }
 
+// GetNamespaceStats provides a mock function with given fields: ctx
+func (_m *Orchestrator) GetNamespaceStats(ctx context.Context) (*core.NamespaceStats, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespaceStats")
+	}
+
+	var r0 *core.NamespaceStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*core.NamespaceStats, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *core.NamespaceStats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.NamespaceStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNextPins provides a mock function with given fields: ctx, filter
 func (_m *Orchestrator) GetNextPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.NextPin, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -1248,7 +1598,46 @@ func (_m *Orchestrator) GetOperations(ctx context.Context, filter ffapi.AndFilte
 	return r0, r1, r2
 }
 
-// GetPins provides a mock function with given fields: ctx, filter
+// GetOperationsByTags provides a mock function with given fields: ctx, tags, filter
+func (_m *Orchestrator) GetOperationsByTags(ctx context.Context, tags []string, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, tags, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOperationsByTags")
+	}
+
+	var r0 []*core.Operation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, tags, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, ffapi.AndFilter) []*core.Operation); ok {
+		r0 = rf(ctx, tags, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, tags, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, []string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, tags, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetPins provides a mock function with given fields: ctx, filter
 func (_m *Orchestrator) GetPins(ctx context.Context, filter ffapi.AndFilter) ([]*core.Pin, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
 
@@ -1287,6 +1676,153 @@ func (_m *Orchestrator) GetPins(ctx context.Context, filter ffapi.AndFilter) ([]
 	return r0, r1, r2
 }
 
+// GetQuarantineEntries provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetQuarantineEntries(ctx context.Context, filter ffapi.AndFilter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantineEntries")
+	}
+
+	var r0 []*core.QuarantineEntry
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.QuarantineEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.QuarantineEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetQuarantineEntryByID provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetQuarantineEntryByID(ctx context.Context, id string) (*core.QuarantineEntry, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantineEntryByID")
+	}
+
+	var r0 *core.QuarantineEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.QuarantineEntry, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.QuarantineEntry); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.QuarantineEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuarantinedMessages provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetQuarantinedMessages(ctx context.Context, filter ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantinedMessages")
+	}
+
+	var r0 []*core.Message
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Message, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Message); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetStalledOperations provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetStalledOperations(ctx context.Context, filter ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStalledOperations")
+	}
+
+	var r0 []*core.Operation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) ([]*core.Operation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter) []*core.Operation); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Operation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetStatus provides a mock function with given fields: ctx
 func (_m *Orchestrator) GetStatus(ctx context.Context) (*core.NamespaceStatus, error) {
 	ret := _m.Called(ctx)
@@ -1416,6 +1952,45 @@ func (_m *Orchestrator) GetSubscriptionEventsHistorical(ctx context.Context, sub
 	return r0, r1, r2
 }
 
+// GetSubscriptionSLAs provides a mock function with given fields: ctx, id, filter
+func (_m *Orchestrator) GetSubscriptionSLAs(ctx context.Context, id string, filter ffapi.AndFilter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscriptionSLAs")
+	}
+
+	var r0 []*core.SubscriptionSLA
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ffapi.AndFilter) []*core.SubscriptionSLA); ok {
+		r0 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.SubscriptionSLA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ffapi.AndFilter) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id, filter)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, ffapi.AndFilter) error); ok {
+		r2 = rf(ctx, id, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetSubscriptions provides a mock function with given fields: ctx, filter
 func (_m *Orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFilter) ([]*core.Subscription, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, filter)
@@ -1455,6 +2030,84 @@ func (_m *Orchestrator) GetSubscriptions(ctx context.Context, filter ffapi.AndFi
 	return r0, r1, r2
 }
 
+// GetTokenBalancesAsOf provides a mock function with given fields: ctx, filter
+func (_m *Orchestrator) GetTokenBalancesAsOf(ctx context.Context, filter ffapi.AndFilter, asOf string) ([]*core.TokenBalance, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenBalancesAsOf")
+	}
+
+	var r0 []*core.TokenBalance
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, string) ([]*core.TokenBalance, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, string) []*core.TokenBalance); ok {
+		r0 = rf(ctx, filter, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TokenBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter, string) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter, asOf)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter, string) error); ok {
+		r2 = rf(ctx, filter, asOf)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTokenTransfersWithEmbedded provides a mock function with given fields: ctx, filter, embed
+func (_m *Orchestrator) GetTokenTransfersWithEmbedded(ctx context.Context, filter ffapi.AndFilter, embed []fftypes.FFEnum) ([]*core.TokenTransferWithEmbedded, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, filter, embed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTokenTransfersWithEmbedded")
+	}
+
+	var r0 []*core.TokenTransferWithEmbedded
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) ([]*core.TokenTransferWithEmbedded, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, filter, embed)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) []*core.TokenTransferWithEmbedded); ok {
+		r0 = rf(ctx, filter, embed)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.TokenTransferWithEmbedded)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, filter, embed)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, ffapi.AndFilter, []fftypes.FFEnum) error); ok {
+		r2 = rf(ctx, filter, embed)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionBlockchainEvents provides a mock function with given fields: ctx, id
 func (_m *Orchestrator) GetTransactionBlockchainEvents(ctx context.Context, id string) ([]*core.BlockchainEvent, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, id)
@@ -1524,6 +2177,84 @@ func (_m *Orchestrator) GetTransactionByID(ctx context.Context, id string) (*cor
 	return r0, r1
 }
 
+// GetTransactionCompensations provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetTransactionCompensations(ctx context.Context, id string) ([]*core.Compensation, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionCompensations")
+	}
+
+	var r0 []*core.Compensation
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.Compensation, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.Compensation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Compensation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetTransactionEvents provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) GetTransactionEvents(ctx context.Context, id string) ([]*core.Event, *ffapi.FilterResult, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionEvents")
+	}
+
+	var r0 []*core.Event
+	var r1 *ffapi.FilterResult
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.Event, *ffapi.FilterResult, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.Event); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) *ffapi.FilterResult); ok {
+		r1 = rf(ctx, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*ffapi.FilterResult)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetTransactionOperations provides a mock function with given fields: ctx, id
 func (_m *Orchestrator) GetTransactionOperations(ctx context.Context, id string) ([]*core.Operation, *ffapi.FilterResult, error) {
 	ret := _m.Called(ctx, id)
@@ -1652,6 +2383,36 @@ func (_m *Orchestrator) Identity() identity.Manager {
 	return r0
 }
 
+// ImportDefinitions provides a mock function with given fields: ctx, httpServerURL, bundle, waitConfirm
+func (_m *Orchestrator) ImportDefinitions(ctx context.Context, httpServerURL string, bundle *core.DefinitionBundle, waitConfirm bool) (*core.DefinitionImportResult, error) {
+	ret := _m.Called(ctx, httpServerURL, bundle, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportDefinitions")
+	}
+
+	var r0 *core.DefinitionImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.DefinitionBundle, bool) (*core.DefinitionImportResult, error)); ok {
+		return rf(ctx, httpServerURL, bundle, waitConfirm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.DefinitionBundle, bool) *core.DefinitionImportResult); ok {
+		r0 = rf(ctx, httpServerURL, bundle, waitConfirm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.DefinitionImportResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.DefinitionBundle, bool) error); ok {
+		r1 = rf(ctx, httpServerURL, bundle, waitConfirm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Init provides a mock function with given fields:
 func (_m *Orchestrator) Init() error {
 	ret := _m.Called()
@@ -1755,6 +2516,54 @@ func (_m *Orchestrator) PrivateMessaging() privatemessaging.Manager {
 	return r0
 }
 
+// PurgeQuarantineEntry provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) PurgeQuarantineEntry(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeQuarantineEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplayToSandbox provides a mock function with given fields: ctx, httpServerURL, source, input
+func (_m *Orchestrator) ReplayToSandbox(ctx context.Context, httpServerURL string, source orchestrator.Orchestrator, input *core.SandboxReplayInput) (*core.SandboxReplayResult, error) {
+	ret := _m.Called(ctx, httpServerURL, source, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayToSandbox")
+	}
+
+	var r0 *core.SandboxReplayResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, orchestrator.Orchestrator, *core.SandboxReplayInput) (*core.SandboxReplayResult, error)); ok {
+		return rf(ctx, httpServerURL, source, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, orchestrator.Orchestrator, *core.SandboxReplayInput) *core.SandboxReplayResult); ok {
+		r0 = rf(ctx, httpServerURL, source, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SandboxReplayResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, orchestrator.Orchestrator, *core.SandboxReplayInput) error); ok {
+		r1 = rf(ctx, httpServerURL, source, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RequestReply provides a mock function with given fields: ctx, msg
 func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut) (*core.MessageInOut, error) {
 	ret := _m.Called(ctx, msg)
@@ -1785,6 +2594,66 @@ func (_m *Orchestrator) RequestReply(ctx context.Context, msg *core.MessageInOut
 	return r0, r1
 }
 
+// RevalidateQuarantineEntry provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) RevalidateQuarantineEntry(ctx context.Context, id string) (*core.QuarantineRevalidation, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevalidateQuarantineEntry")
+	}
+
+	var r0 *core.QuarantineRevalidation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.QuarantineRevalidation, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.QuarantineRevalidation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.QuarantineRevalidation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewQuarantinedMessage provides a mock function with given fields: ctx, id, review
+func (_m *Orchestrator) ReviewQuarantinedMessage(ctx context.Context, id string, review *core.QuarantineReview) (*core.Message, error) {
+	ret := _m.Called(ctx, id, review)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewQuarantinedMessage")
+	}
+
+	var r0 *core.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.QuarantineReview) (*core.Message, error)); ok {
+		return rf(ctx, id, review)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.QuarantineReview) *core.Message); ok {
+		r0 = rf(ctx, id, review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.QuarantineReview) error); ok {
+		r1 = rf(ctx, id, review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RewindPins provides a mock function with given fields: ctx, rewind
 func (_m *Orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind) (*core.PinRewind, error) {
 	ret := _m.Called(ctx, rewind)
@@ -1815,6 +2684,24 @@ func (_m *Orchestrator) RewindPins(ctx context.Context, rewind *core.PinRewind)
 	return r0, r1
 }
 
+// ScheduleContractMigration provides a mock function with given fields: ctx, migration, waitConfirm
+func (_m *Orchestrator) ScheduleContractMigration(ctx context.Context, migration *core.ContractMigration, waitConfirm bool) error {
+	ret := _m.Called(ctx, migration, waitConfirm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleContractMigration")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.ContractMigration, bool) error); ok {
+		r0 = rf(ctx, migration, waitConfirm)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields:
 func (_m *Orchestrator) Start() error {
 	ret := _m.Called()
@@ -1851,6 +2738,96 @@ func (_m *Orchestrator) SubmitNetworkAction(ctx context.Context, action *core.Ne
 	return r0
 }
 
+// TestSubscription provides a mock function with given fields: ctx, id, input
+func (_m *Orchestrator) TestSubscription(ctx context.Context, id string, input *core.SubscriptionTestInput) (*core.SubscriptionTestResult, error) {
+	ret := _m.Called(ctx, id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TestSubscription")
+	}
+
+	var r0 *core.SubscriptionTestResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionTestInput) (*core.SubscriptionTestResult, error)); ok {
+		return rf(ctx, id, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *core.SubscriptionTestInput) *core.SubscriptionTestResult); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.SubscriptionTestResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *core.SubscriptionTestInput) error); ok {
+		r1 = rf(ctx, id, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, input
+func (_m *Orchestrator) Verify(ctx context.Context, input *core.VerifyInput) (*core.VerificationReport, error) {
+	ret := _m.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *core.VerificationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifyInput) (*core.VerificationReport, error)); ok {
+		return rf(ctx, input)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.VerifyInput) *core.VerificationReport); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.VerificationReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.VerifyInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyBatch provides a mock function with given fields: ctx, id
+func (_m *Orchestrator) VerifyBatch(ctx context.Context, id string) (*core.BatchVerification, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyBatch")
+	}
+
+	var r0 *core.BatchVerification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.BatchVerification, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.BatchVerification); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.BatchVerification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WaitStop provides a mock function with given fields:
 func (_m *Orchestrator) WaitStop() {
 	_m.Called()
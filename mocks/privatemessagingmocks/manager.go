@@ -117,6 +117,26 @@ func (_m *Manager) GetGroups(ctx context.Context, filter ffapi.AndFilter) ([]*co
 	return r0, r1, r2
 }
 
+// GetTransferLimits provides a mock function with given fields: ctx
+func (_m *Manager) GetTransferLimits(ctx context.Context) *core.TransferLimits {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferLimits")
+	}
+
+	var r0 *core.TransferLimits
+	if rf, ok := ret.Get(0).(func(context.Context) *core.TransferLimits); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TransferLimits)
+		}
+	}
+
+	return r0
+}
+
 // Name provides a mock function with given fields:
 func (_m *Manager) Name() string {
 	ret := _m.Called()
@@ -215,6 +235,36 @@ func (_m *Manager) RequestReply(ctx context.Context, request *core.MessageInOut)
 	return r0, r1
 }
 
+// ResolveGroupMembers provides a mock function with given fields: ctx, hash
+func (_m *Manager) ResolveGroupMembers(ctx context.Context, hash string) ([]*core.ResolvedGroupMember, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveGroupMembers")
+	}
+
+	var r0 []*core.ResolvedGroupMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*core.ResolvedGroupMember, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*core.ResolvedGroupMember); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*core.ResolvedGroupMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResolveInitGroup provides a mock function with given fields: ctx, msg, creator
 func (_m *Manager) ResolveInitGroup(ctx context.Context, msg *core.Message, creator *core.Member) (*core.Group, error) {
 	ret := _m.Called(ctx, msg, creator)
@@ -312,6 +362,36 @@ func (_m *Manager) SendMessage(ctx context.Context, in *core.MessageInOut, waitC
 	return r0, r1
 }
 
+// SetTransferLimits provides a mock function with given fields: ctx, limits
+func (_m *Manager) SetTransferLimits(ctx context.Context, limits *core.TransferLimits) (*core.TransferLimits, error) {
+	ret := _m.Called(ctx, limits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTransferLimits")
+	}
+
+	var r0 *core.TransferLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TransferLimits) (*core.TransferLimits, error)); ok {
+		return rf(ctx, limits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *core.TransferLimits) *core.TransferLimits); ok {
+		r0 = rf(ctx, limits)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.TransferLimits)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *core.TransferLimits) error); ok {
+		r1 = rf(ctx, limits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewManager(t interface {
@@ -14,17 +14,17 @@ type Manager struct {
 	mock.Mock
 }
 
-// InitiateDownloadBatch provides a mock function with given fields: ctx, tx, payloadRef, idempotentSubmit
-func (_m *Manager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
-	ret := _m.Called(ctx, tx, payloadRef, idempotentSubmit)
+// InitiateDownloadBatch provides a mock function with given fields: ctx, tx, batchID, batchHash, payloadRef, idempotentSubmit
+func (_m *Manager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID, batchID *fftypes.UUID, batchHash *fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
+	ret := _m.Called(ctx, tx, batchID, batchHash, payloadRef, idempotentSubmit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for InitiateDownloadBatch")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, string, bool) error); ok {
-		r0 = rf(ctx, tx, payloadRef, idempotentSubmit)
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *fftypes.UUID, *fftypes.Bytes32, string, bool) error); ok {
+		r0 = rf(ctx, tx, batchID, batchHash, payloadRef, idempotentSubmit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,17 +32,17 @@ func (_m *Manager) InitiateDownloadBatch(ctx context.Context, tx *fftypes.UUID,
 	return r0
 }
 
-// InitiateDownloadBlob provides a mock function with given fields: ctx, tx, dataID, payloadRef, idempotentSubmit
-func (_m *Manager) InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, payloadRef string, idempotentSubmit bool) error {
-	ret := _m.Called(ctx, tx, dataID, payloadRef, idempotentSubmit)
+// InitiateDownloadBlob provides a mock function with given fields: ctx, tx, dataID, hash, payloadRef, idempotentSubmit
+func (_m *Manager) InitiateDownloadBlob(ctx context.Context, tx *fftypes.UUID, dataID *fftypes.UUID, hash *fftypes.Bytes32, payloadRef string, idempotentSubmit bool) error {
+	ret := _m.Called(ctx, tx, dataID, hash, payloadRef, idempotentSubmit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for InitiateDownloadBlob")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *fftypes.UUID, string, bool) error); ok {
-		r0 = rf(ctx, tx, dataID, payloadRef, idempotentSubmit)
+	if rf, ok := ret.Get(0).(func(context.Context, *fftypes.UUID, *fftypes.UUID, *fftypes.Bytes32, string, bool) error); ok {
+		r0 = rf(ctx, tx, dataID, hash, payloadRef, idempotentSubmit)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -7,6 +7,8 @@ import (
 
 	config "github.com/hyperledger/firefly-common/pkg/config"
 
+	core "github.com/hyperledger/firefly/pkg/core"
+
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
@@ -110,11 +112,59 @@ func (_m *Plugin) Name() string {
 	return r0
 }
 
+// PinData provides a mock function with given fields: ctx, payloadRef
+func (_m *Plugin) PinData(ctx context.Context, payloadRef string) (*core.DataPin, error) {
+	ret := _m.Called(ctx, payloadRef)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PinData")
+	}
+
+	var r0 *core.DataPin
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*core.DataPin, error)); ok {
+		return rf(ctx, payloadRef)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *core.DataPin); ok {
+		r0 = rf(ctx, payloadRef)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*core.DataPin)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payloadRef)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetHandler provides a mock function with given fields: namespace, handler
 func (_m *Plugin) SetHandler(namespace string, handler sharedstorage.Callbacks) {
 	_m.Called(namespace, handler)
 }
 
+// UnpinData provides a mock function with given fields: ctx, pin
+func (_m *Plugin) UnpinData(ctx context.Context, pin *core.DataPin) error {
+	ret := _m.Called(ctx, pin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpinData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *core.DataPin) error); ok {
+		r0 = rf(ctx, pin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UploadData provides a mock function with given fields: ctx, data
 func (_m *Plugin) UploadData(ctx context.Context, data io.Reader) (string, error) {
 	ret := _m.Called(ctx, data)
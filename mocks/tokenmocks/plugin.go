@@ -11,6 +11,8 @@ import (
 
 	fftypes "github.com/hyperledger/firefly-common/pkg/fftypes"
 
+	metrics "github.com/hyperledger/firefly/internal/metrics"
+
 	mock "github.com/stretchr/testify/mock"
 
 	tokens "github.com/hyperledger/firefly/pkg/tokens"
@@ -117,6 +119,24 @@ func (_m *Plugin) CheckInterface(ctx context.Context, pool *core.TokenPool, meth
 	return r0, r1
 }
 
+// CheckReady provides a mock function with given fields: ctx
+func (_m *Plugin) CheckReady(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckReady")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ConnectorName provides a mock function with given fields:
 func (_m *Plugin) ConnectorName() string {
 	ret := _m.Called()
@@ -181,17 +201,17 @@ func (_m *Plugin) DeactivateTokenPool(ctx context.Context, pool *core.TokenPool)
 	return r0
 }
 
-// Init provides a mock function with given fields: ctx, cancelCtx, name, _a3
-func (_m *Plugin) Init(ctx context.Context, cancelCtx context.CancelFunc, name string, _a3 config.Section) error {
-	ret := _m.Called(ctx, cancelCtx, name, _a3)
+// Init provides a mock function with given fields: ctx, cancelCtx, name, _a3, _a4
+func (_m *Plugin) Init(ctx context.Context, cancelCtx context.CancelFunc, name string, _a3 config.Section, _a4 metrics.Manager) error {
+	ret := _m.Called(ctx, cancelCtx, name, _a3, _a4)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Init")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, context.CancelFunc, string, config.Section) error); ok {
-		r0 = rf(ctx, cancelCtx, name, _a3)
+	if rf, ok := ret.Get(0).(func(context.Context, context.CancelFunc, string, config.Section, metrics.Manager) error); ok {
+		r0 = rf(ctx, cancelCtx, name, _a3, _a4)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -240,6 +260,16 @@ func (_m *Plugin) Name() string {
 	return r0
 }
 
+// SetConnectionListener provides a mock function with given fields: listener
+func (_m *Plugin) SetConnectionListener(listener core.PluginConnectionListener) {
+	_m.Called(listener)
+}
+
+// SetEventQueue provides a mock function with given fields: queue
+func (_m *Plugin) SetEventQueue(queue core.InboundEventQueue) {
+	_m.Called(queue)
+}
+
 // SetHandler provides a mock function with given fields: namespace, handler
 func (_m *Plugin) SetHandler(namespace string, handler tokens.Callbacks) {
 	_m.Called(namespace, handler)
@@ -59,9 +59,19 @@ type Plugin interface {
 	// If namespace is set, plugin will attempt to deliver only events for that namespace
 	SetOperationHandler(namespace string, handler core.OperationCallbacks)
 
+	// SetConnectionListener registers a listener to be notified when the connection to the runtime is lost or restored
+	SetConnectionListener(listener core.PluginConnectionListener)
+
+	// SetEventQueue registers a persistent queue to store events received from the runtime, so they can be acknowledged
+	// before they are processed. Without a queue, events are processed before they are acknowledged.
+	SetEventQueue(queue core.InboundEventQueue)
+
 	// Capabilities returns capabilities - not called until after Init
 	Capabilities() *Capabilities
 
+	// CheckReady returns an error if the blockchain connector cannot currently be reached
+	CheckReady(ctx context.Context) error
+
 	// VerifierType returns the verifier (key) type that is used by this blockchain
 	VerifierType() core.VerifierType
 
@@ -74,6 +84,10 @@ type Plugin interface {
 	// - Results in a string that can be stored/compared consistently with the key emitted on events signed by this key
 	ResolveSigningKey(ctx context.Context, keyRef string, intent ResolveKeyIntent) (string, error)
 
+	// VerifySignature checks that a signature over the payload was made by the signing key, using the signature
+	// scheme native to the blockchain. Returns an error if the signature is not valid for that key
+	VerifySignature(ctx context.Context, signingKey string, payload []byte, signature *core.KeySignature) error
+
 	// SubmitBatchPin sequences a batch of message globally to all viewers of a given ledger
 	SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *BatchPin, location *fftypes.JSONAny) error
 
@@ -83,6 +97,18 @@ type Plugin interface {
 	// DeployContract submits a new transaction to deploy a new instance of a smart contract
 	DeployContract(ctx context.Context, nsOpID, signingKey string, definition, contract *fftypes.JSONAny, input []interface{}, options map[string]interface{}) (submissionRejected bool, err error)
 
+	// UploadContractABI stores a contract ABI, and optionally its bytecode, in the blockchain connector
+	UploadContractABI(ctx context.Context, upload *core.ContractABIUpload) (*core.ContractABI, error)
+
+	// GetContractABIs lists the contract ABIs stored in the blockchain connector
+	GetContractABIs(ctx context.Context) ([]*core.ContractABI, error)
+
+	// GetContractABI returns a contract ABI stored in the blockchain connector, or nil if it does not exist
+	GetContractABI(ctx context.Context, id string) (*core.ContractABI, error)
+
+	// DeployContractABI submits a new transaction to deploy a new instance of a contract ABI stored in the blockchain connector
+	DeployContractABI(ctx context.Context, nsOpID, signingKey, abiID string, input, options map[string]interface{}) (submissionRejected bool, err error)
+
 	// ParseInterface processes an FFIMethod and FFIError array into a blockchain specific object, that will be
 	// cached for this given interface, and passed back on all future invocations.
 	ParseInterface(ctx context.Context, method *fftypes.FFIMethod, errors []*fftypes.FFIError) (interface{}, error)
@@ -96,6 +122,17 @@ type Plugin interface {
 	// QueryContract executes a method via custom on-chain logic and returns the result
 	QueryContract(ctx context.Context, signingKey string, location *fftypes.JSONAny, parsedMethod interface{}, input map[string]interface{}, options map[string]interface{}) (interface{}, error)
 
+	// InvokeContractBatch submits a set of method invocations in a single transaction, via the multicall aggregator
+	// contract at the supplied location. Only supported when the plugin reports the Multicall capability.
+	InvokeContractBatch(ctx context.Context, nsOpID, signingKey string, aggregator *fftypes.JSONAny, calls []*ContractBatchCall, allowFailure bool, options map[string]interface{}) (submissionRejected bool, err error)
+
+	// DecodeInvokeBatchReceipt decodes the result of each call from the receipt of a successful batch invocation
+	DecodeInvokeBatchReceipt(ctx context.Context, receipt fftypes.JSONObject) ([]*core.ContractCallBatchItemResult, error)
+
+	// DecodeInvokeReceipt decodes the return values of a method, and any of the supplied events emitted by the transaction,
+	// from the receipt of a successful invocation. Returns empty results if the receipt does not contain the information.
+	DecodeInvokeReceipt(ctx context.Context, parsedMethod interface{}, events []*fftypes.FFIEvent, receipt fftypes.JSONObject) (fftypes.JSONObject, []*core.ContractInvokeEvent, error)
+
 	// AddContractListener adds a new subscription to a user-specified contract and event
 	AddContractListener(ctx context.Context, subscription *core.ContractListener, lastProtocolID string) error
 
@@ -138,8 +175,25 @@ type Plugin interface {
 	// RemoveFireFlySubscription removes the provided FireFly subscription
 	RemoveFireflySubscription(ctx context.Context, subID string)
 
+	// RequeryFireflySubscription asks the connector to re-deliver the events of the provided FireFly subscription,
+	// starting from the block of the given protocol ID (which is required)
+	RequeryFireflySubscription(ctx context.Context, subID, fromProtocolID string) error
+
 	// Get the latest status of the given transaction
 	GetTransactionStatus(ctx context.Context, operation *core.Operation) (interface{}, error)
+
+	// GetTransactionReceipt looks up the receipt of a transaction by its hash, for transactions that were submitted
+	// to the chain outside of FireFly. Returns nil if the transaction has not yet been mined.
+	GetTransactionReceipt(ctx context.Context, txHash string) (*TransactionReceipt, error)
+}
+
+// TransactionReceipt is the outcome of a mined transaction, looked up by its hash
+type TransactionReceipt struct {
+	TransactionHash string
+	Success         bool
+	ProtocolID      string
+	ErrorMessage    string
+	Info            fftypes.JSONObject
 }
 
 type NormalizeType int
@@ -151,6 +205,25 @@ const (
 
 const FireFlyActionPrefix = "firefly:"
 
+// FFIMethodPinParam is the key in the details of an FFI method that names the parameter used to carry
+// the pin of a message included in an invocation. When it is not set, the last parameter is used.
+const FFIMethodPinParam = "pinParam"
+
+// PinParamIndex returns the index of the parameter of an FFI method that carries the pin of a message
+// included in an invocation, or -1 if the method has no such parameter
+func PinParamIndex(method *fftypes.FFIMethod) int {
+	pinParam := method.Details.GetString(FFIMethodPinParam)
+	if pinParam == "" {
+		return len(method.Params) - 1
+	}
+	for i, param := range method.Params {
+		if param.Name == pinParam {
+			return i
+		}
+	}
+	return -1
+}
+
 type EventType int
 
 const (
@@ -209,6 +282,15 @@ type Callbacks interface {
 // Capabilities the supported featureset of the blockchain
 // interface implemented by the plugin, with the specified config
 type Capabilities struct {
+	// Multicall indicates InvokeContractBatch can submit a set of invocations via an on-chain aggregator
+	Multicall bool
+}
+
+// ContractBatchCall is one method invocation within a batch
+type ContractBatchCall struct {
+	Location     *fftypes.JSONAny
+	ParsedMethod interface{}
+	Input        map[string]interface{}
 }
 
 // MultipartyContract represents the location and configuration of a FireFly multiparty contract for batch pinning of messages
@@ -287,4 +369,8 @@ type Event struct {
 
 	// Signature is the event signature, including the event name and output types
 	Signature string
+
+	// Checkpoint is the position of the event on its ledger, set by plugins that rely on FireFly to record
+	// checkpoints for each subscription, and discard events that are replayed by the connector (optional)
+	Checkpoint *core.BlockchainCheckpoint
 }
@@ -0,0 +1,30 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// AddressBookEntry maps a friendly name to a signing key or token account address, local to this node.
+// The name can be used in place of the address in any API input that accepts a key, or the to/from of a token transfer.
+type AddressBookEntry struct {
+	ID        *fftypes.UUID   `ffstruct:"AddressBookEntry" json:"id" ffexcludeinput:"true"`
+	Namespace string          `ffstruct:"AddressBookEntry" json:"namespace" ffexcludeinput:"true"`
+	Name      string          `ffstruct:"AddressBookEntry" json:"name"`
+	Address   string          `ffstruct:"AddressBookEntry" json:"address"`
+	Created   *fftypes.FFTime `ffstruct:"AddressBookEntry" json:"created" ffexcludeinput:"true"`
+	Updated   *fftypes.FFTime `ffstruct:"AddressBookEntry" json:"updated" ffexcludeinput:"true"`
+}
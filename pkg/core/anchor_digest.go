@@ -0,0 +1,122 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+)
+
+// AnchorDigest is a Merkle root of the batches pinned in a namespace during a window of time, which is
+// submitted to the anchor chain of the namespace in place of pinning each batch. The window is recorded
+// as the range of local pin sequences it covers.
+type AnchorDigest struct {
+	ID           *fftypes.UUID       `ffstruct:"AnchorDigest" json:"id"`
+	Namespace    string              `ffstruct:"AnchorDigest" json:"namespace"`
+	Root         *fftypes.Bytes32    `ffstruct:"AnchorDigest" json:"root"`
+	Plugin       string              `ffstruct:"AnchorDigest" json:"plugin"`
+	FirstPin     int64               `ffstruct:"AnchorDigest" json:"firstPin"`
+	LastPin      int64               `ffstruct:"AnchorDigest" json:"lastPin"`
+	Batches      AnchorDigestBatches `ffstruct:"AnchorDigest" json:"batches"`
+	Transaction  *fftypes.UUID       `ffstruct:"AnchorDigest" json:"tx"`
+	BlockchainID string              `ffstruct:"AnchorDigest" json:"blockchainId,omitempty"`
+	Created      *fftypes.FFTime     `ffstruct:"AnchorDigest" json:"created"`
+}
+
+// AnchorDigestBatch is one leaf of the Merkle tree of an anchor digest
+type AnchorDigestBatch struct {
+	ID   *fftypes.UUID    `ffstruct:"AnchorDigestBatch" json:"id"`
+	Hash *fftypes.Bytes32 `ffstruct:"AnchorDigestBatch" json:"hash"`
+}
+
+// AnchorDigestBatches are the leaves of the Merkle tree of an anchor digest, in order
+type AnchorDigestBatches []*AnchorDigestBatch
+
+// Scan implements sql.Scanner
+func (adb *AnchorDigestBatches) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if len(src) == 0 {
+			return nil
+		}
+		return json.Unmarshal(src, adb)
+	case string:
+		return adb.Scan([]byte(src))
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, adb)
+	}
+}
+
+// Value implements sql.Valuer
+func (adb AnchorDigestBatches) Value() (driver.Value, error) {
+	if adb == nil {
+		return nil, nil
+	}
+	return json.Marshal(adb)
+}
+
+// MerkleSiblingPosition is the side of the Merkle tree a sibling hash is on, relative to the hash being proved
+type MerkleSiblingPosition = fftypes.FFEnum
+
+var (
+	// MerkleSiblingLeft means the parent hash is sha256(sibling + current)
+	MerkleSiblingLeft = fftypes.FFEnumValue("merklesibling", "left")
+	// MerkleSiblingRight means the parent hash is sha256(current + sibling)
+	MerkleSiblingRight = fftypes.FFEnumValue("merklesibling", "right")
+)
+
+// MerkleProofStep is one level of a Merkle inclusion proof
+type MerkleProofStep struct {
+	Hash     *fftypes.Bytes32      `ffstruct:"MerkleProofStep" json:"hash"`
+	Position MerkleSiblingPosition `ffstruct:"MerkleProofStep" json:"position" ffenum:"merklesibling"`
+}
+
+// BatchAnchorProof proves a batch was included in an anchor digest. Starting from the batch hash,
+// hashing with each step of the proof in turn must result in the root submitted to the anchor chain.
+type BatchAnchorProof struct {
+	Batch        *fftypes.UUID      `ffstruct:"BatchAnchorProof" json:"batch"`
+	BatchHash    *fftypes.Bytes32   `ffstruct:"BatchAnchorProof" json:"batchHash"`
+	Digest       *fftypes.UUID      `ffstruct:"BatchAnchorProof" json:"digest"`
+	Root         *fftypes.Bytes32   `ffstruct:"BatchAnchorProof" json:"root"`
+	Plugin       string             `ffstruct:"BatchAnchorProof" json:"plugin"`
+	BlockchainID string             `ffstruct:"BatchAnchorProof" json:"blockchainId,omitempty"`
+	Proof        []*MerkleProofStep `ffstruct:"BatchAnchorProof" json:"proof"`
+}
+
+// CalculateRoot folds the steps of the proof over a batch hash, returning the Merkle root it results in
+func (bap *BatchAnchorProof) CalculateRoot(batchHash *fftypes.Bytes32) *fftypes.Bytes32 {
+	current := batchHash
+	for _, step := range bap.Proof {
+		h := sha256.New()
+		if step.Position == MerkleSiblingLeft {
+			h.Write(step.Hash[:])
+			h.Write(current[:])
+		} else {
+			h.Write(current[:])
+			h.Write(step.Hash[:])
+		}
+		current = fftypes.HashResult(h)
+	}
+	return current
+}
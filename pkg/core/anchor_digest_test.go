@@ -0,0 +1,82 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnchorDigestBatchesDatabaseSerialization(t *testing.T) {
+	batches1 := AnchorDigestBatches{
+		{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()},
+		{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()},
+	}
+
+	// Verify it serializes as bytes to the database
+	val1, err := batches1.Value()
+	assert.NoError(t, err)
+
+	// Verify it restores ok
+	var batches2 AnchorDigestBatches
+	err = batches2.Scan(string(val1.([]byte)))
+	assert.NoError(t, err)
+	assert.Equal(t, batches1, batches2)
+
+	// Verify it ignores nil and blank values
+	err = batches2.Scan(nil)
+	assert.NoError(t, err)
+	err = batches2.Scan([]byte{})
+	assert.NoError(t, err)
+	assert.Len(t, batches2, 2)
+
+	// A nil list is stored as null
+	var batches3 AnchorDigestBatches
+	val3, err := batches3.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, val3)
+
+	// Out of luck with anything else
+	err = batches2.Scan(false)
+	assert.Regexp(t, "FF00105", err)
+}
+
+func TestBatchAnchorProofCalculateRoot(t *testing.T) {
+	leaf := fftypes.NewRandB32()
+	left := fftypes.NewRandB32()
+	right := fftypes.NewRandB32()
+	proof := &BatchAnchorProof{
+		Proof: []*MerkleProofStep{
+			{Hash: left, Position: MerkleSiblingLeft},
+			{Hash: right, Position: MerkleSiblingRight},
+		},
+	}
+
+	h := sha256.New()
+	h.Write(left[:])
+	h.Write(leaf[:])
+	level1 := fftypes.HashResult(h)
+	h = sha256.New()
+	h.Write(level1[:])
+	h.Write(right[:])
+	assert.Equal(t, fftypes.HashResult(h), proof.CalculateRoot(leaf))
+
+	assert.Equal(t, leaf, (&BatchAnchorProof{}).CalculateRoot(leaf))
+}
@@ -0,0 +1,53 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// APIKeyScope is the set of API routes that an API key grants access to
+type APIKeyScope = fftypes.FFEnum
+
+var (
+	// APIKeyScopeReadOnly grants access to the routes that only read state
+	APIKeyScopeReadOnly = fftypes.FFEnumValue("apikeyscope", "readonly")
+	// APIKeyScopeMessaging grants read access, plus sending messages and uploading data
+	APIKeyScopeMessaging = fftypes.FFEnumValue("apikeyscope", "messaging")
+	// APIKeyScopeTokens grants read access, plus creating token pools, mints, burns, transfers and approvals
+	APIKeyScopeTokens = fftypes.FFEnumValue("apikeyscope", "tokens")
+	// APIKeyScopeAdmin grants access to every route of the namespace, including the management of API keys
+	APIKeyScopeAdmin = fftypes.FFEnumValue("apikeyscope", "admin")
+)
+
+// APIKey grants an application access to the API of a single namespace, limited to a scope. Only a hash of
+// the key is stored - the key itself is returned once, when the API key is created.
+type APIKey struct {
+	ID        *fftypes.UUID    `ffstruct:"APIKey" json:"id" ffexcludeinput:"true"`
+	Namespace string           `ffstruct:"APIKey" json:"namespace" ffexcludeinput:"true"`
+	Name      string           `ffstruct:"APIKey" json:"name"`
+	Scope     APIKeyScope      `ffstruct:"APIKey" json:"scope" ffenum:"apikeyscope"`
+	Key       string           `ffstruct:"APIKey" json:"key,omitempty" ffexcludeinput:"true"`
+	Hash      *fftypes.Bytes32 `ffstruct:"APIKey" json:"-"`
+	Created   *fftypes.FFTime  `ffstruct:"APIKey" json:"created" ffexcludeinput:"true"`
+}
+
+// Allows returns true if the scope of the key grants access to a route that requires the specified scope.
+// Every scope includes read access, and an admin key can access any route.
+func (k *APIKey) Allows(required APIKeyScope) bool {
+	return k.Scope == APIKeyScopeAdmin || k.Scope == required || required == APIKeyScopeReadOnly
+}
@@ -0,0 +1,46 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyAllows(t *testing.T) {
+	readOnly := &APIKey{Scope: APIKeyScopeReadOnly}
+	assert.True(t, readOnly.Allows(APIKeyScopeReadOnly))
+	assert.False(t, readOnly.Allows(APIKeyScopeMessaging))
+	assert.False(t, readOnly.Allows(APIKeyScopeAdmin))
+
+	messaging := &APIKey{Scope: APIKeyScopeMessaging}
+	assert.True(t, messaging.Allows(APIKeyScopeReadOnly))
+	assert.True(t, messaging.Allows(APIKeyScopeMessaging))
+	assert.False(t, messaging.Allows(APIKeyScopeTokens))
+	assert.False(t, messaging.Allows(APIKeyScopeAdmin))
+
+	tokens := &APIKey{Scope: APIKeyScopeTokens}
+	assert.True(t, tokens.Allows(APIKeyScopeTokens))
+	assert.False(t, tokens.Allows(APIKeyScopeMessaging))
+
+	admin := &APIKey{Scope: APIKeyScopeAdmin}
+	assert.True(t, admin.Allows(APIKeyScopeReadOnly))
+	assert.True(t, admin.Allows(APIKeyScopeMessaging))
+	assert.True(t, admin.Allows(APIKeyScopeTokens))
+	assert.True(t, admin.Allows(APIKeyScopeAdmin))
+}
@@ -18,6 +18,12 @@ package core
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 )
@@ -25,3 +31,58 @@ import (
 type Authorizer interface {
 	Authorize(ctx context.Context, authReq *fftypes.AuthReq) error
 }
+
+// RequestPrincipal identifies the caller of an HTTP request from its mTLS client certificate, basic auth
+// username, or bearer token - in that order. For a bearer token that is a JWT, the principal is the subject
+// (or username) claim, qualified by the issuer, so it is stable as tokens are refreshed. Other bearer tokens
+// are never stored, so the principal for the token is a hash of the token. Returns an empty string for an
+// anonymous request.
+func RequestPrincipal(req *http.Request) string {
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		return "x509:" + req.TLS.PeerCertificates[0].Subject.String()
+	}
+	if username, _, ok := req.BasicAuth(); ok {
+		return "basic:" + username
+	}
+	if token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		if subject := jwtSubject(token); subject != "" {
+			return "jwt:" + subject
+		}
+		hash := sha256.Sum256([]byte(token))
+		return "bearer:" + hex.EncodeToString(hash[:])
+	}
+	return ""
+}
+
+// jwtSubject returns the identity a JWT was issued to, or an empty string if the token is not a JWT with an
+// identity claim. The signature is not checked here - authenticating the token is the job of the auth plugin.
+func jwtSubject(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Issuer            string `json:"iss"`
+		Subject           string `json:"sub"`
+		Username          string `json:"username"`
+		PreferredUsername string `json:"preferred_username"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+	subject := claims.Subject
+	if subject == "" {
+		subject = claims.Username
+	}
+	if subject == "" {
+		subject = claims.PreferredUsername
+	}
+	if subject == "" || claims.Issuer == "" {
+		return subject
+	}
+	return claims.Issuer + "|" + subject
+}
@@ -0,0 +1,64 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testJWT(claims string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+}
+
+func TestRequestPrincipal(t *testing.T) {
+	req := httptest.NewRequest("GET", "/ws", nil)
+	assert.Empty(t, RequestPrincipal(req))
+
+	req.Header.Set("Authorization", "Bearer token1")
+	assert.Equal(t, "bearer:df3e6b0bb66ceaadca4f84cbc371fd66e04d20fe51fc414da8d1b84d31d178de", RequestPrincipal(req))
+
+	// The principal of a JWT does not change as the token is refreshed
+	req.Header.Set("Authorization", "Bearer "+testJWT(`{"iss":"https://idp1","sub":"app1","exp":1}`))
+	assert.Equal(t, "jwt:https://idp1|app1", RequestPrincipal(req))
+	req.Header.Set("Authorization", "Bearer "+testJWT(`{"iss":"https://idp1","sub":"app1","exp":2}`))
+	assert.Equal(t, "jwt:https://idp1|app1", RequestPrincipal(req))
+
+	req.SetBasicAuth("user1", "pass1")
+	assert.Equal(t, "basic:user1", RequestPrincipal(req))
+
+	req.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "app1", Organization: []string{"org1"}}}},
+	}
+	assert.Equal(t, "x509:CN=app1,O=org1", RequestPrincipal(req))
+}
+
+func TestJWTSubject(t *testing.T) {
+	assert.Equal(t, "user1", jwtSubject(testJWT(`{"username":"user1"}`)))
+	assert.Equal(t, "idp1|user2", jwtSubject(testJWT(`{"iss":"idp1","preferred_username":"user2"}`)))
+	assert.Empty(t, jwtSubject(testJWT(`{"iss":"idp1"}`)))
+	assert.Empty(t, jwtSubject(testJWT(`!json`)))
+	assert.Empty(t, jwtSubject("a.!!!.c"))
+	assert.Empty(t, jwtSubject("token1"))
+}
@@ -17,10 +17,13 @@
 package core
 
 import (
+	"context"
 	"crypto/sha256"
+	"database/sql/driver"
 	"encoding/json"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
 )
 
 // BatchType is the type of a batch
@@ -33,11 +36,37 @@ var (
 	BatchTypePrivate = fftypes.FFEnumValue("batchtype", "private")
 )
 
+// BatchPayloadRefPinOnly is the payload reference pinned for a batch that is not uploaded to shared storage.
+// Receivers do not download the batch, and instead wait for its payload to be attached
+const BatchPayloadRefPinOnly = "pinonly"
+
 const (
 	ManifestVersionUnset uint = 0
 	ManifestVersion1     uint = 1
+	// ManifestVersion2 adds the compression of the batch payload, and the hash algorithm of the batch,
+	// so nodes that cannot decompress or verify it reject the batch
+	ManifestVersion2 uint = 2
+	// ManifestVersion3 adds the content type, size and blob reference of each data entry, and the total
+	// size of each message, so receivers can make download decisions before fetching payloads.
+	// It is only used when every receiving node advertises support for it in its node profile
+	ManifestVersion3 uint = 3
+	// ManifestVersionLatest is the latest manifest version this node can process
+	ManifestVersionLatest = ManifestVersion3
 )
 
+// NodeProfileManifestVersion is the key in the profile of a node identity, advertising the latest
+// manifest version the node can process
+const NodeProfileManifestVersion = "manifestVersion"
+
+// NodeManifestVersion is the latest manifest version advertised in the profile of a node identity,
+// which is unset for nodes that do not advertise a version
+func NodeManifestVersion(node *Identity) uint {
+	if version := node.Profile.GetInt64(NodeProfileManifestVersion); version > 0 {
+		return uint(version)
+	}
+	return ManifestVersionUnset
+}
+
 // BatchHeader is the common fields between the serialized batch, and the batch manifest
 type BatchHeader struct {
 	ID        *fftypes.UUID    `ffstruct:"BatchHeader" json:"id"`
@@ -51,7 +80,24 @@ type BatchHeader struct {
 
 type MessageManifestEntry struct {
 	MessageRef
-	Topics int `json:"topics"` // We only need the count, to be able to match up the pins
+	Topics int   `json:"topics"`         // We only need the count, to be able to match up the pins
+	Size   int64 `json:"size,omitempty"` // The total size of the data values and blobs of the message, from version 3
+}
+
+// DataManifestEntry summarizes a data item in the batch. The content type, size and blob
+// are recorded from version 3 of the manifest
+type DataManifestEntry struct {
+	DataRef
+	ContentType string             `json:"contentType,omitempty"`
+	Size        int64              `json:"size,omitempty"`
+	Blob        *BlobManifestEntry `json:"blob,omitempty"`
+}
+
+// BlobManifestEntry references the blob attached to a data item in the batch
+type BlobManifestEntry struct {
+	Hash   *fftypes.Bytes32 `json:"hash"`
+	Size   int64            `json:"size"`
+	Public string           `json:"public,omitempty"`
 }
 
 // BatchManifest is all we need to persist to be able to reconstitute
@@ -64,15 +110,20 @@ type BatchManifest struct {
 	ID      *fftypes.UUID  `json:"id"`
 	TX      TransactionRef `json:"tx"`
 	SignerRef
-	Messages []*MessageManifestEntry `json:"messages"`
-	Data     DataRefs                `json:"data"`
+	Messages      []*MessageManifestEntry `json:"messages"`
+	Data          []*DataManifestEntry    `json:"data"`
+	Compression   BatchCompression        `json:"compression,omitempty"`
+	HashAlgorithm HashAlgorithm           `json:"hashAlgorithm,omitempty"`
 }
 
 // Batch is the full payload object used in-flight.
 type Batch struct {
 	BatchHeader
-	Hash    *fftypes.Bytes32 `ffstruct:"Batch" json:"hash"`
-	Payload BatchPayload     `ffstruct:"Batch" json:"payload"`
+	Hash            *fftypes.Bytes32 `ffstruct:"Batch" json:"hash"`
+	Payload         BatchPayload     `ffstruct:"Batch" json:"payload"`
+	Compression     BatchCompression `ffstruct:"Batch" json:"compression,omitempty" ffenum:"batchcompression"`
+	HashAlgorithm   HashAlgorithm    `ffstruct:"Batch" json:"hashAlgorithm,omitempty" ffenum:"hashalgorithm"`
+	ManifestVersion uint             `ffstruct:"Batch" json:"manifestVersion,omitempty"`
 }
 
 // BatchPersisted is the structure written to the database
@@ -82,6 +133,40 @@ type BatchPersisted struct {
 	Manifest  *fftypes.JSONAny `ffstruct:"Batch" json:"manifest"`
 	TX        TransactionRef   `ffstruct:"Batch" json:"tx"`
 	Confirmed *fftypes.FFTime  `ffstruct:"Batch" json:"confirmed"`
+	Anchor    *BatchAnchor     `ffstruct:"Batch" json:"anchor,omitempty" ffexcludeinput:"true"`
+}
+
+// BatchAnchor records a second pin of a batch, to the anchor chain of a namespace that is configured with one.
+// It is recorded only by the node that submitted the batch, once the anchor transaction is accepted.
+type BatchAnchor struct {
+	Plugin       string        `ffstruct:"BatchAnchor" json:"plugin"`
+	BlockchainID string        `ffstruct:"BatchAnchor" json:"blockchainId"`
+	Operation    *fftypes.UUID `ffstruct:"BatchAnchor" json:"operation"`
+}
+
+// Scan implements sql.Scanner
+func (ba *BatchAnchor) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		if len(src) == 0 {
+			return nil
+		}
+		return json.Unmarshal(src, ba)
+	case string:
+		return ba.Scan([]byte(src))
+	default:
+		return i18n.NewError(context.Background(), i18n.MsgTypeRestoreFailed, src, ba)
+	}
+}
+
+// Value implements sql.Valuer
+func (ba *BatchAnchor) Value() (driver.Value, error) {
+	if ba == nil {
+		return nil, nil
+	}
+	return json.Marshal(ba)
 }
 
 // BatchPayload contains the full JSON of the messages and data, but
@@ -103,6 +188,86 @@ func (bm *BatchManifest) String() string {
 	return string(b)
 }
 
+// SetCompression records the compression of the batch payload in the manifest, moving the manifest
+// to version 2 if the payload is compressed. Uncompressed batches keep a version 1 manifest, so
+// they are accepted by nodes that do not support compression.
+func (bm *BatchManifest) SetCompression(compression BatchCompression) *BatchManifest {
+	if IsCompressed(compression) {
+		bm.setMinVersion(ManifestVersion2)
+		bm.Compression = compression
+	}
+	return bm
+}
+
+// SetHashAlgorithm records the algorithm used to hash the manifest, moving the manifest to version 2
+// if it is not the SHA-256 default
+func (bm *BatchManifest) SetHashAlgorithm(algorithm HashAlgorithm) *BatchManifest {
+	if algorithm != "" && algorithm != HashAlgorithmSHA256 {
+		bm.setMinVersion(ManifestVersion2)
+		bm.HashAlgorithm = algorithm
+	}
+	return bm
+}
+
+// SetEntryDetails records the content type, size and blob of each data entry, and the total size of each
+// message, moving the manifest to version 3. Everything recorded is derived from the batch payload,
+// so a receiver regenerates an identical manifest from the payload it receives.
+func (bm *BatchManifest) SetEntryDetails(messages []*Message, data DataArray) *BatchManifest {
+	bm.setMinVersion(ManifestVersion3)
+	entries := make(map[fftypes.UUID]*DataManifestEntry, len(bm.Data))
+	for _, entry := range bm.Data {
+		entries[*entry.ID] = entry
+	}
+	for _, d := range data {
+		if d == nil || d.ID == nil || entries[*d.ID] == nil {
+			continue
+		}
+		entry := entries[*d.ID]
+		entry.ContentType = d.ContentType()
+		if d.Value != nil && d.Value.String() != fftypes.NullString {
+			entry.Size = d.Value.Length()
+		}
+		if d.Blob != nil && d.Blob.Hash != nil {
+			entry.Blob = &BlobManifestEntry{
+				Hash:   d.Blob.Hash,
+				Size:   d.Blob.Size,
+				Public: d.Blob.Public,
+			}
+		}
+	}
+	sizes := make(map[fftypes.UUID]int64, len(messages))
+	for _, m := range messages {
+		if m == nil || m.Header.ID == nil {
+			continue
+		}
+		for _, dr := range m.Data {
+			if dr.ID == nil || entries[*dr.ID] == nil {
+				continue
+			}
+			entry := entries[*dr.ID]
+			sizes[*m.Header.ID] += entry.Size
+			if entry.Blob != nil {
+				sizes[*m.Header.ID] += entry.Blob.Size
+			}
+		}
+	}
+	for _, entry := range bm.Messages {
+		entry.Size = sizes[*entry.ID]
+	}
+	return bm
+}
+
+func (bm *BatchManifest) setMinVersion(version uint) {
+	if bm.Version < version {
+		bm.Version = version
+	}
+}
+
+// Hash calculates the hash of the manifest, which is the hash of the batch, using the recorded algorithm
+func (bm *BatchManifest) Hash(ctx context.Context) (*fftypes.Bytes32, error) {
+	return HashBytes(ctx, bm.HashAlgorithm, []byte(bm.String()))
+}
+
 func (ma *BatchPayload) Hash() *fftypes.Bytes32 {
 	b, _ := json.Marshal(&ma)
 	var b32 fftypes.Bytes32 = sha256.Sum256(b)
@@ -115,7 +280,7 @@ func (ma *BatchPayload) Manifest(id *fftypes.UUID) *BatchManifest {
 		ID:       id,
 		TX:       ma.TX,
 		Messages: make([]*MessageManifestEntry, 0, len(ma.Messages)),
-		Data:     make(DataRefs, 0, len(ma.Data)),
+		Data:     make([]*DataManifestEntry, 0, len(ma.Data)),
 	}
 	for _, m := range ma.Messages {
 		if m != nil && m.Header.ID != nil {
@@ -130,9 +295,11 @@ func (ma *BatchPayload) Manifest(id *fftypes.UUID) *BatchManifest {
 	}
 	for _, d := range ma.Data {
 		if d != nil && d.ID != nil {
-			tm.Data = append(tm.Data, &DataRef{
-				ID:   d.ID,
-				Hash: d.Hash,
+			tm.Data = append(tm.Data, &DataManifestEntry{
+				DataRef: DataRef{
+					ID:   d.ID,
+					Hash: d.Hash,
+				},
 			})
 		}
 	}
@@ -148,7 +315,8 @@ func (b *BatchPersisted) GenManifest(messages []*Message, data DataArray) *Batch
 }
 
 func (b *BatchPersisted) GenInflight(messages []*Message, data DataArray) *Batch {
-	return &Batch{
+	sealed := b.sealedManifest()
+	batch := &Batch{
 		BatchHeader: b.BatchHeader,
 		Hash:        b.Hash,
 		Payload: BatchPayload{
@@ -156,12 +324,31 @@ func (b *BatchPersisted) GenInflight(messages []*Message, data DataArray) *Batch
 			Messages: messages,
 			Data:     data,
 		},
+		Compression:   sealed.Compression,
+		HashAlgorithm: sealed.HashAlgorithm,
 	}
+	if sealed.Version >= ManifestVersion3 {
+		batch.ManifestVersion = sealed.Version
+	}
+	return batch
+}
+
+// sealedManifest returns the manifest sealed into the batch, so the in-flight batch is sent with
+// the same compression and hash algorithm that contributed to its hash
+func (b *BatchPersisted) sealedManifest() *BatchManifest {
+	var manifest BatchManifest
+	if b.Manifest == nil || json.Unmarshal([]byte(*b.Manifest), &manifest) != nil {
+		return &BatchManifest{}
+	}
+	return &manifest
 }
 
 // Confirmed generates a newly confirmed persisted batch, including (re-)generating the manifest
 func (b *Batch) Confirmed() (*BatchPersisted, *BatchManifest) {
-	manifest := b.Payload.Manifest(b.ID)
+	manifest := b.Payload.Manifest(b.ID).SetCompression(b.Compression).SetHashAlgorithm(b.HashAlgorithm)
+	if b.ManifestVersion >= ManifestVersion3 {
+		manifest.SetEntryDetails(b.Payload.Messages, b.Payload.Data)
+	}
 	manifestString := manifest.String()
 	return &BatchPersisted{
 		BatchHeader: b.BatchHeader,
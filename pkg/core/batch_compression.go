@@ -0,0 +1,109 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"github.com/klauspost/compress/zstd"
+)
+
+// BatchCompression is the compression applied to the serialized payload of a batch, when it is
+// uploaded to shared storage or transferred over data exchange
+type BatchCompression = fftypes.FFEnum
+
+var (
+	// BatchCompressionNone the payload is uncompressed JSON
+	BatchCompressionNone = fftypes.FFEnumValue("batchcompression", "none")
+	// BatchCompressionGzip the payload is compressed with gzip
+	BatchCompressionGzip = fftypes.FFEnumValue("batchcompression", "gzip")
+	// BatchCompressionZstd the payload is compressed with zstd
+	BatchCompressionZstd = fftypes.FFEnumValue("batchcompression", "zstd")
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// IsCompressed returns true if the compression applies any encoding to the payload
+func IsCompressed(compression BatchCompression) bool {
+	return compression != "" && compression != BatchCompressionNone
+}
+
+// CompressBatchPayload compresses a serialized batch payload
+func CompressBatchPayload(ctx context.Context, compression BatchCompression, payload []byte) ([]byte, error) {
+	var buff bytes.Buffer
+	var w io.WriteCloser
+	switch compression {
+	case "", BatchCompressionNone:
+		return payload, nil
+	case BatchCompressionGzip:
+		w = gzip.NewWriter(&buff)
+	case BatchCompressionZstd:
+		w, _ = zstd.NewWriter(&buff) // only errors on invalid options
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgBatchCompressionUnsupported, compression)
+	}
+	// Writes to an in-memory buffer cannot fail
+	_, _ = w.Write(payload)
+	_ = w.Close()
+	return buff.Bytes(), nil
+}
+
+// DecompressBatchPayload detects the compression of a received batch payload, from the magic
+// bytes at the start of the payload, and returns the decompressed payload. Payloads that are
+// not compressed are returned unchanged. If maxLength is greater than zero, payloads that
+// decompress to more than maxLength bytes are rejected.
+func DecompressBatchPayload(ctx context.Context, payload []byte, maxLength int64) ([]byte, BatchCompression, error) {
+	var r io.Reader
+	var compression BatchCompression
+	switch {
+	case bytes.HasPrefix(payload, gzipMagic):
+		compression = BatchCompressionGzip
+		gr, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, compression, i18n.WrapError(ctx, err, coremsgs.MsgBatchDecompressFailed, compression)
+		}
+		r = gr
+	case bytes.HasPrefix(payload, zstdMagic):
+		compression = BatchCompressionZstd
+		zr, _ := zstd.NewReader(bytes.NewReader(payload)) // only errors on invalid options
+		defer zr.Close()
+		r = zr
+	default:
+		return payload, BatchCompressionNone, nil
+	}
+
+	if maxLength > 0 {
+		r = io.LimitReader(r, maxLength+1)
+	}
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, compression, i18n.WrapError(ctx, err, coremsgs.MsgBatchDecompressFailed, compression)
+	}
+	if maxLength > 0 && int64(len(decompressed)) > maxLength {
+		return nil, compression, i18n.NewError(ctx, coremsgs.MsgBatchDecompressedTooLarge, maxLength)
+	}
+	return decompressed, compression, nil
+}
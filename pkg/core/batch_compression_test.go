@@ -0,0 +1,84 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchCompressionRoundTrip(t *testing.T) {
+	payload := []byte(strings.Repeat(`{"some":"batch"}`, 100))
+	for _, c := range []BatchCompression{BatchCompressionGzip, BatchCompressionZstd} {
+		compressed, err := CompressBatchPayload(context.Background(), c, payload)
+		assert.NoError(t, err)
+		assert.Less(t, len(compressed), len(payload))
+
+		decompressed, detected, err := DecompressBatchPayload(context.Background(), compressed, int64(len(payload)))
+		assert.NoError(t, err)
+		assert.Equal(t, c, detected)
+		assert.Equal(t, payload, decompressed)
+	}
+}
+
+func TestBatchCompressionNone(t *testing.T) {
+	payload := []byte(`{"some":"batch"}`)
+	for _, c := range []BatchCompression{"", BatchCompressionNone} {
+		assert.False(t, IsCompressed(c))
+		compressed, err := CompressBatchPayload(context.Background(), c, payload)
+		assert.NoError(t, err)
+		assert.Equal(t, payload, compressed)
+	}
+
+	decompressed, detected, err := DecompressBatchPayload(context.Background(), payload, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, BatchCompressionNone, detected)
+	assert.Equal(t, payload, decompressed)
+}
+
+func TestBatchCompressionUnsupported(t *testing.T) {
+	_, err := CompressBatchPayload(context.Background(), "lz4", []byte(`{}`))
+	assert.Regexp(t, "FF10508", err)
+}
+
+func TestBatchDecompressTooLarge(t *testing.T) {
+	payload := []byte(strings.Repeat("a", 1024))
+	for _, c := range []BatchCompression{BatchCompressionGzip, BatchCompressionZstd} {
+		compressed, err := CompressBatchPayload(context.Background(), c, payload)
+		assert.NoError(t, err)
+		_, _, err = DecompressBatchPayload(context.Background(), compressed, 1023)
+		assert.Regexp(t, "FF10510", err)
+	}
+}
+
+func TestBatchDecompressCorrupt(t *testing.T) {
+	_, detected, err := DecompressBatchPayload(context.Background(), []byte{0x1f, 0x8b}, 0)
+	assert.Regexp(t, "FF10509", err)
+	assert.Equal(t, BatchCompressionGzip, detected)
+
+	_, detected, err = DecompressBatchPayload(context.Background(), []byte{0x28, 0xb5, 0x2f, 0xfd, 0x00}, 0)
+	assert.Regexp(t, "FF10509", err)
+	assert.Equal(t, BatchCompressionZstd, detected)
+
+	compressed, err := CompressBatchPayload(context.Background(), BatchCompressionGzip, []byte(`{"some":"batch"}`))
+	assert.NoError(t, err)
+	_, _, err = DecompressBatchPayload(context.Background(), compressed[0:len(compressed)-4], 0)
+	assert.Regexp(t, "FF10509", err)
+}
@@ -17,6 +17,7 @@
 package core
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -65,3 +66,176 @@ func TestSQLSerializedManifest(t *testing.T) {
 	assert.NotEqual(t, batch.Payload.Hash().String(), hex.EncodeToString(mfHash[:]))
 
 }
+
+func TestBatchManifestCompression(t *testing.T) {
+	b := &Batch{
+		BatchHeader: BatchHeader{ID: fftypes.NewUUID()},
+		Compression: BatchCompressionGzip,
+	}
+	bp, manifest := b.Confirmed()
+	assert.Equal(t, ManifestVersion2, manifest.Version)
+	assert.Equal(t, BatchCompressionGzip, manifest.Compression)
+
+	inflight := bp.GenInflight([]*Message{}, DataArray{})
+	assert.Equal(t, BatchCompressionGzip, inflight.Compression)
+
+	b.Compression = BatchCompressionNone
+	bp, manifest = b.Confirmed()
+	assert.Equal(t, ManifestVersion1, manifest.Version)
+	assert.Empty(t, manifest.Compression)
+	assert.Empty(t, bp.GenInflight([]*Message{}, DataArray{}).Compression)
+
+	bp.Manifest = fftypes.JSONAnyPtr("!json")
+	assert.Empty(t, bp.GenInflight([]*Message{}, DataArray{}).Compression)
+}
+
+func TestBatchManifestHashAlgorithm(t *testing.T) {
+	b := &Batch{
+		BatchHeader:   BatchHeader{ID: fftypes.NewUUID()},
+		HashAlgorithm: HashAlgorithmSHA3_256,
+	}
+	bp, manifest := b.Confirmed()
+	assert.Equal(t, ManifestVersion2, manifest.Version)
+	assert.Equal(t, HashAlgorithmSHA3_256, manifest.HashAlgorithm)
+
+	hash, err := manifest.Hash(context.Background())
+	assert.NoError(t, err)
+	expected, _ := HashBytes(context.Background(), HashAlgorithmSHA3_256, []byte(bp.Manifest.String()))
+	assert.Equal(t, expected, hash)
+	assert.Equal(t, HashAlgorithmSHA3_256, bp.GenInflight([]*Message{}, DataArray{}).HashAlgorithm)
+
+	b.HashAlgorithm = HashAlgorithmSHA256
+	bp, manifest = b.Confirmed()
+	assert.Equal(t, ManifestVersion1, manifest.Version)
+	assert.Empty(t, manifest.HashAlgorithm)
+	assert.Empty(t, bp.GenInflight([]*Message{}, DataArray{}).HashAlgorithm)
+
+	hash, err = manifest.Hash(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, fftypes.HashString(bp.Manifest.String()), hash)
+}
+
+func TestBatchManifestEntryDetails(t *testing.T) {
+	valueOnly := &Data{
+		ID:    fftypes.NewUUID(),
+		Hash:  fftypes.NewRandB32(),
+		Value: fftypes.JSONAnyPtr(`{"some":"data"}`),
+	}
+	blobWithMeta := &Data{
+		ID:    fftypes.NewUUID(),
+		Hash:  fftypes.NewRandB32(),
+		Value: fftypes.JSONAnyPtr(`{"mimetype":"image/png"}`),
+		Blob:  &BlobRef{Hash: fftypes.NewRandB32(), Size: 2048, Public: "ref1"},
+	}
+	blobOnly := &Data{
+		ID:   fftypes.NewUUID(),
+		Hash: fftypes.NewRandB32(),
+		Blob: &BlobRef{Hash: fftypes.NewRandB32(), Size: 100},
+	}
+	msg1 := &Message{
+		Header: MessageHeader{ID: fftypes.NewUUID()},
+		Data: DataRefs{
+			{ID: valueOnly.ID, Hash: valueOnly.Hash},
+			{ID: blobWithMeta.ID, Hash: blobWithMeta.Hash},
+		},
+	}
+	msg2 := &Message{
+		Header: MessageHeader{ID: fftypes.NewUUID()},
+		Data: DataRefs{
+			{ID: blobOnly.ID, Hash: blobOnly.Hash},
+			{ID: fftypes.NewUUID()}, // not in the batch
+			{},
+		},
+	}
+	b := &Batch{
+		BatchHeader:     BatchHeader{ID: fftypes.NewUUID()},
+		Compression:     BatchCompressionGzip,
+		ManifestVersion: ManifestVersion3,
+		Payload: BatchPayload{
+			Messages: []*Message{msg1, nil, msg2},
+			Data:     DataArray{valueOnly, blobWithMeta, nil, blobOnly},
+		},
+	}
+	bp, manifest := b.Confirmed()
+	assert.Equal(t, ManifestVersion3, manifest.Version)
+	assert.Equal(t, BatchCompressionGzip, manifest.Compression)
+
+	assert.Equal(t, "application/json", manifest.Data[0].ContentType)
+	assert.Equal(t, int64(15), manifest.Data[0].Size)
+	assert.Nil(t, manifest.Data[0].Blob)
+	assert.Equal(t, "image/png", manifest.Data[1].ContentType)
+	assert.Equal(t, int64(24), manifest.Data[1].Size)
+	assert.Equal(t, &BlobManifestEntry{Hash: blobWithMeta.Blob.Hash, Size: 2048, Public: "ref1"}, manifest.Data[1].Blob)
+	assert.Equal(t, "application/octet-stream", manifest.Data[2].ContentType)
+	assert.Zero(t, manifest.Data[2].Size)
+	assert.Equal(t, int64(15+24+2048), manifest.Messages[0].Size)
+	assert.Equal(t, int64(100), manifest.Messages[1].Size)
+
+	// The in-flight batch carries the version, so the receiver regenerates an identical manifest
+	inflight := bp.GenInflight(b.Payload.Messages, b.Payload.Data)
+	assert.Equal(t, ManifestVersion3, inflight.ManifestVersion)
+	_, regenerated := inflight.Confirmed()
+	assert.Equal(t, manifest.String(), regenerated.String())
+
+	// Setting the compression after the entry details does not lower the version
+	manifest.SetCompression(BatchCompressionGzip).SetHashAlgorithm(HashAlgorithmSHA3_256)
+	assert.Equal(t, ManifestVersion3, manifest.Version)
+
+	b.ManifestVersion = ManifestVersionUnset
+	bp, manifest = b.Confirmed()
+	assert.Equal(t, ManifestVersion2, manifest.Version)
+	assert.Empty(t, manifest.Data[0].ContentType)
+	assert.NotContains(t, manifest.String(), "size")
+	assert.Zero(t, bp.GenInflight(b.Payload.Messages, b.Payload.Data).ManifestVersion)
+}
+
+func TestNodeManifestVersion(t *testing.T) {
+	node := &Identity{}
+	assert.Equal(t, ManifestVersionUnset, NodeManifestVersion(node))
+
+	var profile fftypes.JSONObject
+	err := json.Unmarshal([]byte(`{"id":"peer1","manifestVersion":3}`), &profile)
+	assert.NoError(t, err)
+	node.Profile = profile
+	assert.Equal(t, ManifestVersion3, NodeManifestVersion(node))
+
+	node.Profile = fftypes.JSONObject{NodeProfileManifestVersion: -1}
+	assert.Equal(t, ManifestVersionUnset, NodeManifestVersion(node))
+}
+
+func TestBatchAnchorDatabaseSerialization(t *testing.T) {
+	opID := fftypes.NewUUID()
+	anchor1 := &BatchAnchor{
+		Plugin:       "anchorchain",
+		BlockchainID: "0x12345",
+		Operation:    opID,
+	}
+
+	// Verify it serializes as bytes to the database
+	val1, err := anchor1.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"plugin":"anchorchain","blockchainId":"0x12345","operation":"`+opID.String()+`"}`, string(val1.([]byte)))
+
+	// Verify it restores ok
+	anchor2 := &BatchAnchor{}
+	err = anchor2.Scan(string(val1.([]byte)))
+	assert.NoError(t, err)
+	assert.Equal(t, anchor1, anchor2)
+
+	// Verify it ignores nil and blank values
+	err = anchor2.Scan(nil)
+	assert.NoError(t, err)
+	err = anchor2.Scan([]byte{})
+	assert.NoError(t, err)
+	assert.Equal(t, "anchorchain", anchor2.Plugin)
+
+	// A nil anchor is stored as null
+	var anchor3 *BatchAnchor
+	val3, err := anchor3.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, val3)
+
+	// Out of luck with anything else
+	err = anchor2.Scan(false)
+	assert.Regexp(t, "FF00105", err)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// BatchMismatchType is the part of a batch that failed verification
+type BatchMismatchType = fftypes.FFEnum
+
+var (
+	// BatchMismatchTypeManifest the stored manifest could not be parsed, or does not match the one regenerated from the stored messages and data
+	BatchMismatchTypeManifest = fftypes.FFEnumValue("batchmismatchtype", "manifest")
+	// BatchMismatchTypeBatchHash the hash recalculated from the stored messages and data does not match the hash of the batch
+	BatchMismatchTypeBatchHash = fftypes.FFEnumValue("batchmismatchtype", "batch_hash")
+	// BatchMismatchTypePin the batch hash recorded by an on-chain pin does not match the hash of the batch, or no pin was found
+	BatchMismatchTypePin = fftypes.FFEnumValue("batchmismatchtype", "pin")
+	// BatchMismatchTypeMessage a message in the manifest is missing, or its hash does not verify
+	BatchMismatchTypeMessage = fftypes.FFEnumValue("batchmismatchtype", "message")
+	// BatchMismatchTypeData a data item in the manifest is missing, or its hash does not verify
+	BatchMismatchTypeData = fftypes.FFEnumValue("batchmismatchtype", "data")
+)
+
+// BatchMismatch is a single discrepancy found when verifying a batch
+type BatchMismatch struct {
+	Type     BatchMismatchType `ffstruct:"BatchMismatch" json:"type" ffenum:"batchmismatchtype"`
+	ID       *fftypes.UUID     `ffstruct:"BatchMismatch" json:"id,omitempty"`
+	Expected string            `ffstruct:"BatchMismatch" json:"expected,omitempty"`
+	Found    string            `ffstruct:"BatchMismatch" json:"found,omitempty"`
+	Error    string            `ffstruct:"BatchMismatch" json:"error,omitempty"`
+}
+
+// BatchVerification is the report of deterministically replaying the hash calculation of a batch,
+// from the messages and data stored locally, and comparing it to the hash pinned on-chain
+type BatchVerification struct {
+	Batch          *fftypes.UUID    `ffstruct:"BatchVerification" json:"batch"`
+	Valid          bool             `ffstruct:"BatchVerification" json:"valid"`
+	HashAlgorithm  HashAlgorithm    `ffstruct:"BatchVerification" json:"hashAlgorithm,omitempty" ffenum:"hashalgorithm"`
+	Hash           *fftypes.Bytes32 `ffstruct:"BatchVerification" json:"hash"`
+	CalculatedHash *fftypes.Bytes32 `ffstruct:"BatchVerification" json:"calculatedHash,omitempty"`
+	PinnedHash     *fftypes.Bytes32 `ffstruct:"BatchVerification" json:"pinnedHash,omitempty"`
+	Pins           int              `ffstruct:"BatchVerification" json:"pins"`
+	Mismatches     []*BatchMismatch `ffstruct:"BatchVerification" json:"mismatches"`
+}
+
+// AddMismatch records a discrepancy, marking the batch as invalid
+func (bv *BatchVerification) AddMismatch(mismatch *BatchMismatch) {
+	bv.Valid = false
+	bv.Mismatches = append(bv.Mismatches, mismatch)
+}
+
+// VerifyInput identifies what an auditor wants verified - either a message stored on this node,
+// or a raw batch obtained from elsewhere
+type VerifyInput struct {
+	Message *fftypes.UUID `ffstruct:"VerifyInput" json:"message,omitempty"`
+	Batch   *Batch        `ffstruct:"VerifyInput" json:"batch,omitempty"`
+}
+
+// SignatureVerification is the result of checking the signing key of a message against the key that
+// signed the on-chain pin, and the verifiers registered to the author of the message
+type SignatureVerification struct {
+	Message   *fftypes.UUID `ffstruct:"SignatureVerification" json:"message"`
+	Author    string        `ffstruct:"SignatureVerification" json:"author,omitempty"`
+	Key       string        `ffstruct:"SignatureVerification" json:"key,omitempty"`
+	Signer    string        `ffstruct:"SignatureVerification" json:"signer,omitempty"`
+	Identity  *fftypes.UUID `ffstruct:"SignatureVerification" json:"identity,omitempty"`
+	Delegated bool          `ffstruct:"SignatureVerification" json:"delegated,omitempty"`
+	Valid     bool          `ffstruct:"SignatureVerification" json:"valid"`
+	Error     string        `ffstruct:"SignatureVerification" json:"error,omitempty"`
+}
+
+// PinTransaction references the blockchain transaction that pinned a batch
+type PinTransaction struct {
+	ID            *fftypes.UUID         `ffstruct:"PinTransaction" json:"id,omitempty"`
+	Type          TransactionType       `ffstruct:"PinTransaction" json:"type,omitempty" ffenum:"txtype"`
+	BlockchainIDs fftypes.FFStringArray `ffstruct:"PinTransaction" json:"blockchainIds,omitempty"`
+	Signer        string                `ffstruct:"PinTransaction" json:"signer,omitempty"`
+}
+
+// VerificationReport is the outcome of verifying a message or batch for an external auditor - the
+// signatures of the messages, the integrity of the chain of hashes from the data up to the on-chain
+// pin, and the transaction that recorded the pin
+type VerificationReport struct {
+	Valid      bool                     `ffstruct:"VerificationReport" json:"valid"`
+	Message    *fftypes.UUID            `ffstruct:"VerificationReport" json:"message,omitempty"`
+	Batch      *fftypes.UUID            `ffstruct:"VerificationReport" json:"batch"`
+	Signatures []*SignatureVerification `ffstruct:"VerificationReport" json:"signatures"`
+	HashChain  *BatchVerification       `ffstruct:"VerificationReport" json:"hashChain"`
+	Pin        *PinTransaction          `ffstruct:"VerificationReport" json:"pin,omitempty"`
+}
+
+// AddSignature records the verification of a message signature, marking the report as invalid if it failed
+func (vr *VerificationReport) AddSignature(sv *SignatureVerification) {
+	if !sv.Valid {
+		vr.Valid = false
+	}
+	vr.Signatures = append(vr.Signatures, sv)
+}
@@ -0,0 +1,97 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchVerificationAddMismatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		mismatches []*BatchMismatch
+		valid      bool
+	}{
+		{
+			name:  "none",
+			valid: true,
+		},
+		{
+			name: "one",
+			mismatches: []*BatchMismatch{
+				{Type: BatchMismatchTypeBatchHash, Expected: "abc", Found: "def"},
+			},
+			valid: false,
+		},
+		{
+			name: "many",
+			mismatches: []*BatchMismatch{
+				{Type: BatchMismatchTypeMessage, ID: fftypes.NewUUID(), Error: "missing"},
+				{Type: BatchMismatchTypeData, ID: fftypes.NewUUID(), Error: "missing"},
+			},
+			valid: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			bv := &BatchVerification{Valid: true}
+			for _, m := range tc.mismatches {
+				bv.AddMismatch(m)
+			}
+			assert.Equal(t, tc.valid, bv.Valid)
+			assert.Equal(t, tc.mismatches, bv.Mismatches)
+		})
+	}
+}
+
+func TestVerificationReportAddSignature(t *testing.T) {
+	tests := []struct {
+		name       string
+		signatures []*SignatureVerification
+		valid      bool
+	}{
+		{
+			name: "all valid",
+			signatures: []*SignatureVerification{
+				{Message: fftypes.NewUUID(), Valid: true},
+				{Message: fftypes.NewUUID(), Valid: true},
+			},
+			valid: true,
+		},
+		{
+			name: "one invalid",
+			signatures: []*SignatureVerification{
+				{Message: fftypes.NewUUID(), Valid: false, Error: "pop"},
+				{Message: fftypes.NewUUID(), Valid: true},
+			},
+			valid: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := &VerificationReport{Valid: true}
+			for _, sv := range tc.signatures {
+				vr.AddSignature(sv)
+			}
+			assert.Equal(t, tc.valid, vr.Valid)
+			assert.Equal(t, tc.signatures, vr.Signatures)
+		})
+	}
+}
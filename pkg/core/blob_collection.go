@@ -0,0 +1,49 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// BlobCollectReason is why a blob was found to be eligible for garbage collection
+type BlobCollectReason = fftypes.FFEnum
+
+var (
+	// BlobCollectReasonDataDeleted the data item that the blob is attached to no longer exists
+	BlobCollectReasonDataDeleted = fftypes.FFEnumValue("blobcollectreason", "data_deleted")
+	// BlobCollectReasonUnreferenced no message references the data item that the blob is attached to
+	BlobCollectReasonUnreferenced = fftypes.FFEnumValue("blobcollectreason", "unreferenced")
+	// BlobCollectReasonExpired every message that references the blob was confirmed before the retention period
+	BlobCollectReasonExpired = fftypes.FFEnumValue("blobcollectreason", "expired")
+)
+
+// BlobCollection is the result of a blob garbage collection run
+type BlobCollection struct {
+	Namespace string           `ffstruct:"BlobCollection" json:"namespace"`
+	DryRun    bool             `ffstruct:"BlobCollection" json:"dryRun"`
+	Cutoff    *fftypes.FFTime  `ffstruct:"BlobCollection" json:"cutoff,omitempty"`
+	Scanned   int              `ffstruct:"BlobCollection" json:"scanned"`
+	Blobs     []*CollectedBlob `ffstruct:"BlobCollection" json:"blobs"`
+}
+
+// CollectedBlob is a single blob that was deleted, or would be deleted on a dry run
+type CollectedBlob struct {
+	Hash       *fftypes.Bytes32  `ffstruct:"CollectedBlob" json:"hash"`
+	DataID     *fftypes.UUID     `ffstruct:"CollectedBlob" json:"dataId,omitempty"`
+	PayloadRef string            `ffstruct:"CollectedBlob" json:"payloadRef,omitempty"`
+	Size       int64             `ffstruct:"CollectedBlob" json:"size"`
+	Reason     BlobCollectReason `ffstruct:"CollectedBlob" json:"reason" ffenum:"blobcollectreason"`
+}
@@ -0,0 +1,51 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// BlockchainCheckpoint is this local node's record of the last event processed from a connector subscription,
+// on a given ledger (such as a Fabric channel). It allows replayed events to be discarded when the connector
+// re-delivers events after a restart.
+type BlockchainCheckpoint struct {
+	Namespace        string          `json:"namespace"`
+	Ledger           string          `json:"ledger"`
+	Subscription     string          `json:"subscription"`
+	BlockNumber      int64           `json:"blockNumber"`
+	TransactionIndex int64           `json:"transactionIndex"`
+	EventIndex       int64           `json:"eventIndex"`
+	TransactionID    string          `json:"transactionId"`
+	Updated          *fftypes.FFTime `json:"updated,omitempty"`
+}
+
+// IsReplay returns true if this position is at, or before, the last checkpoint recorded for the subscription.
+// Connectors that do not supply transaction and event indexes report them as zero, so an event at the same
+// position is only considered a replay if it is from the same transaction.
+func (cp *BlockchainCheckpoint) IsReplay(last *BlockchainCheckpoint) bool {
+	switch {
+	case last == nil:
+		return false
+	case cp.BlockNumber != last.BlockNumber:
+		return cp.BlockNumber < last.BlockNumber
+	case cp.TransactionIndex != last.TransactionIndex:
+		return cp.TransactionIndex < last.TransactionIndex
+	case cp.EventIndex != last.EventIndex:
+		return cp.EventIndex < last.EventIndex
+	default:
+		return cp.TransactionID == last.TransactionID
+	}
+}
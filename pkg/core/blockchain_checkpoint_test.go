@@ -0,0 +1,42 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockchainCheckpointIsReplay(t *testing.T) {
+	last := &BlockchainCheckpoint{
+		BlockNumber:      10,
+		TransactionIndex: 2,
+		EventIndex:       1,
+		TransactionID:    "tx1",
+	}
+
+	assert.False(t, (&BlockchainCheckpoint{BlockNumber: 1}).IsReplay(nil))
+	assert.True(t, (&BlockchainCheckpoint{BlockNumber: 9, TransactionIndex: 5}).IsReplay(last))
+	assert.False(t, (&BlockchainCheckpoint{BlockNumber: 11}).IsReplay(last))
+	assert.True(t, (&BlockchainCheckpoint{BlockNumber: 10, TransactionIndex: 1, EventIndex: 3}).IsReplay(last))
+	assert.False(t, (&BlockchainCheckpoint{BlockNumber: 10, TransactionIndex: 3}).IsReplay(last))
+	assert.True(t, (&BlockchainCheckpoint{BlockNumber: 10, TransactionIndex: 2, EventIndex: 0}).IsReplay(last))
+	assert.False(t, (&BlockchainCheckpoint{BlockNumber: 10, TransactionIndex: 2, EventIndex: 2}).IsReplay(last))
+	assert.True(t, (&BlockchainCheckpoint{BlockNumber: 10, TransactionIndex: 2, EventIndex: 1, TransactionID: "tx1"}).IsReplay(last))
+	assert.False(t, (&BlockchainCheckpoint{BlockNumber: 10, TransactionIndex: 2, EventIndex: 1, TransactionID: "tx2"}).IsReplay(last))
+}
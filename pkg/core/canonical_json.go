@@ -0,0 +1,247 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// DataCanonicalization is how the JSON value of a data record is serialized before it is hashed.
+// It is recorded on the data record, with an empty value meaning the value is hashed exactly as stored,
+// so records hashed before canonicalization was configurable continue to verify.
+type DataCanonicalization = fftypes.FFEnum
+
+var (
+	// DataCanonicalizationNone hashes the JSON value exactly as it is stored
+	DataCanonicalizationNone = fftypes.FFEnumValue("datacanonicalization", "none")
+	// DataCanonicalizationJCS hashes the JSON Canonicalization Scheme (RFC 8785) serialization of the value
+	DataCanonicalizationJCS = fftypes.FFEnumValue("datacanonicalization", "jcs")
+)
+
+// ParseDataCanonicalization validates a configured canonicalization, returning the value that should be
+// recorded on data records - which is empty for the default of no canonicalization
+func ParseDataCanonicalization(ctx context.Context, canonicalization string) (DataCanonicalization, error) {
+	parsed, err := fftypes.FFEnumParseString(ctx, "datacanonicalization", canonicalization)
+	if err != nil || parsed == DataCanonicalizationNone {
+		return "", err
+	}
+	return parsed, nil
+}
+
+// CanonicalizeJSON serializes a JSON value with the JSON Canonicalization Scheme (RFC 8785), so that any two
+// serializations of the same logical value produce the same bytes. Object members are sorted by the UTF-16 code
+// units of their keys, whitespace is removed, strings use the minimal escaping of ECMAScript, and numbers are
+// formatted as ECMAScript formats an IEEE 754 double.
+func CanonicalizeJSON(ctx context.Context, b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	t, err := dec.Token()
+	if err == nil {
+		var canonical []byte
+		if canonical, err = canonicalJSONValue(ctx, dec, t); err == nil {
+			if _, err = dec.Token(); err == io.EOF {
+				return canonical, nil
+			}
+			if err == nil {
+				err = fmt.Errorf("unexpected data after the JSON value")
+			}
+		} else if _, isI18n := err.(i18n.FFError); isI18n {
+			return nil, err
+		}
+	}
+	return nil, i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, "value")
+}
+
+type canonicalJSONMember struct {
+	key   []uint16
+	value []byte
+}
+
+func canonicalJSONValue(ctx context.Context, dec *json.Decoder, t json.Token) ([]byte, error) {
+	switch v := t.(type) {
+	case json.Delim:
+		if v == '[' {
+			return canonicalJSONArray(ctx, dec)
+		}
+		return canonicalJSONObject(ctx, dec)
+	case string:
+		return canonicalJSONString(v), nil
+	case json.Number:
+		return canonicalJSONNumber(ctx, v)
+	case bool:
+		return []byte(strconv.FormatBool(v)), nil
+	default:
+		return []byte(fftypes.NullString), nil
+	}
+}
+
+func canonicalJSONArray(ctx context.Context, dec *json.Decoder) ([]byte, error) {
+	buf := []byte{'['}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		value, err := canonicalJSONValue(ctx, dec, t)
+		if err != nil {
+			return nil, err
+		}
+		if len(buf) > 1 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, value...)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return append(buf, ']'), nil
+}
+
+func canonicalJSONObject(ctx context.Context, dec *json.Decoder) ([]byte, error) {
+	members := []*canonicalJSONMember{}
+	keys := make(map[string]bool)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := t.(string) // the decoder only returns strings for object keys
+		if keys[key] {
+			return nil, i18n.NewError(ctx, coremsgs.MsgJSONCanonicalDuplicateKey, key)
+		}
+		keys[key] = true
+		if t, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		value, err := canonicalJSONValue(ctx, dec, t)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, &canonicalJSONMember{key: utf16.Encode([]rune(key)), value: value})
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	// Keys are sorted by their UTF-16 code units, which differs from code point order above U+FFFF
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i].key, members[j].key
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	buf := []byte{'{'}
+	for i, m := range members {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, canonicalJSONString(string(utf16.Decode(m.key)))...)
+		buf = append(buf, ':')
+		buf = append(buf, m.value...)
+	}
+	return append(buf, '}'), nil
+}
+
+func canonicalJSONString(s string) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(buf, `\u%04x`, r)
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+	return buf.Bytes()
+}
+
+// canonicalJSONNumber formats the number as the ECMAScript Number.prototype.toString() of the nearest IEEE 754 double
+func canonicalJSONNumber(ctx context.Context, n json.Number) ([]byte, error) {
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil || math.IsInf(f, 0) {
+		return nil, i18n.NewError(ctx, coremsgs.MsgJSONCanonicalNumber, n)
+	}
+	if f == 0 {
+		return []byte("0"), nil // including negative zero
+	}
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	// The shortest digits that round trip, and the position of the decimal point relative to them
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	exp, _ := strconv.Atoi(exponent)
+	k, point := len(digits), exp+1
+
+	var s string
+	switch {
+	case k <= point && point <= 21:
+		s = digits + strings.Repeat("0", point-k)
+	case 0 < point && point <= 21:
+		s = digits[:point] + "." + digits[point:]
+	case -6 < point && point <= 0:
+		s = "0." + strings.Repeat("0", -point) + digits
+	default:
+		s = digits[:1]
+		if k > 1 {
+			s += "." + digits[1:]
+		}
+		if point-1 >= 0 {
+			s += "e+" + strconv.Itoa(point-1)
+		} else {
+			s += "e" + strconv.Itoa(point-1)
+		}
+	}
+	return []byte(sign + s), nil
+}
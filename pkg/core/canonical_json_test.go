@@ -0,0 +1,119 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDataCanonicalization(t *testing.T) {
+	c, err := ParseDataCanonicalization(context.Background(), "none")
+	assert.NoError(t, err)
+	assert.Empty(t, c)
+
+	c, err = ParseDataCanonicalization(context.Background(), "JCS")
+	assert.NoError(t, err)
+	assert.Equal(t, DataCanonicalizationJCS, c)
+
+	_, err = ParseDataCanonicalization(context.Background(), "sorted")
+	assert.Regexp(t, "FF00172", err)
+}
+
+func TestCanonicalizeJSONRFC8785Sample(t *testing.T) {
+	canonical, err := CanonicalizeJSON(context.Background(), []byte(`{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'B\u0022\u005c\\\u0022\/",
+		"literals": [null, true, false]
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`, string(canonical))
+}
+
+func TestCanonicalizeJSONKeyOrderUTF16(t *testing.T) {
+	canonical, err := CanonicalizeJSON(context.Background(), []byte(`{
+		"\u20ac": "Euro Sign",
+		"\r": "Carriage Return",
+		"\ufb33": "Hebrew Letter Dalet With Dagesh",
+		"1": "One",
+		"\ud83d\ude00": "Emoji: Grinning Face",
+		"\u0080": "Control",
+		"\u00f6": "Latin Small Letter O With Diaeresis"
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\","+
+		"\"\u20ac\":\"Euro Sign\",\"\U0001F600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}", string(canonical))
+}
+
+func TestCanonicalizeJSONNested(t *testing.T) {
+	canonical, err := CanonicalizeJSON(context.Background(), []byte(` { "b" : [ { "d" : 1 , "c" : "\t\b\f" } , [ ] , { } ] , "ab" : 0, "a" : "ab" } `))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":"ab","ab":0,"b":[{"c":"\t\b\f","d":1},[],{}]}`, string(canonical))
+}
+
+func TestCanonicalizeJSONNumbers(t *testing.T) {
+	for in, out := range map[string]string{
+		"0":                      "0",
+		"-0":                     "0",
+		"1.0":                    "1",
+		"-12.50":                 "-12.5",
+		"100":                    "100",
+		"1e21":                   "1e+21",
+		"123456789012345678901":  "123456789012345680000",
+		"1e-7":                   "1e-7",
+		"0.000001":               "0.000001",
+		"-1.5e-7":                "-1.5e-7",
+		"5e-324":                 "5e-324",
+		"9007199254740993":       "9007199254740992",
+		"1.7976931348623157e308": "1.7976931348623157e+308",
+	} {
+		canonical, err := CanonicalizeJSON(context.Background(), []byte(in))
+		assert.NoError(t, err, in)
+		assert.Equal(t, out, string(canonical), in)
+	}
+}
+
+func TestCanonicalizeJSONNumberOutOfRange(t *testing.T) {
+	_, err := CanonicalizeJSON(context.Background(), []byte(`{"a":[1e400]}`))
+	assert.Regexp(t, "FF10589", err)
+}
+
+func TestCanonicalizeJSONDuplicateKey(t *testing.T) {
+	_, err := CanonicalizeJSON(context.Background(), []byte(`{"a":1,"a":2}`))
+	assert.Regexp(t, "FF10588.*'a'", err)
+}
+
+func TestCanonicalizeJSONInvalid(t *testing.T) {
+	for _, in := range []string{
+		``,
+		`{"a":1} {}`,
+		`{"a":1} x`,
+		`{"a":1`,
+		`{"a"`,
+		`{"a":}`,
+		`{1:2}`,
+		`[1,`,
+		`[}`,
+		`{"a":1]`,
+		`[1}`,
+	} {
+		_, err := CanonicalizeJSON(context.Background(), []byte(in))
+		assert.Regexp(t, "FF00127", err, in)
+	}
+}
@@ -26,8 +26,14 @@ const (
 	ChangeEventTypeUpdated ChangeEventType = "updated" // note bulk updates might not results in change events.
 	ChangeEventTypeDeleted ChangeEventType = "deleted"
 	ChangeEventTypeDropped ChangeEventType = "dropped" // See ChangeEventDropped structure, sent to client instead of ChangeEvent when dropping notifications
+
+	ChangeEventTypePluginConnectionLost     ChangeEventType = "plugin_connection_lost"     // The connection from a plugin to its runtime was lost
+	ChangeEventTypePluginConnectionRestored ChangeEventType = "plugin_connection_restored" // The connection from a plugin to its runtime was restored
 )
 
+// ChangeEventCollectionPlugins is the collection for plugin connection events, which are not database changes
+const ChangeEventCollectionPlugins = "plugins"
+
 type WSChangeEventCommandType = fftypes.FFEnum
 
 var (
@@ -66,4 +72,6 @@ type ChangeEvent struct {
 	DroppedSince *fftypes.FFTime `json:"droppedSince,omitempty"`
 	// DroppedCount only for ChangeEventTypeDropped. How many events dropped
 	DroppedCount int64 `json:"droppedCount,omitempty"`
+	// Plugin only for plugin connection events. The name of the plugin whose connection changed
+	Plugin string `json:"plugin,omitempty"`
 }
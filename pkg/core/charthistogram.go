@@ -16,7 +16,11 @@
 
 package core
 
-import "github.com/hyperledger/firefly-common/pkg/fftypes"
+import (
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
 
 const (
 	// ChartHistogramMaxBuckets max buckets that can be requested
@@ -25,6 +29,25 @@ const (
 	ChartHistogramMinBuckets = 1
 )
 
+// ChartInterval is the width of the time buckets of an aggregated chart
+type ChartInterval = fftypes.FFEnum
+
+var (
+	// ChartIntervalMinute buckets of one minute
+	ChartIntervalMinute = fftypes.FFEnumValue("chartinterval", "minute")
+	// ChartIntervalHour buckets of one hour
+	ChartIntervalHour = fftypes.FFEnumValue("chartinterval", "hour")
+	// ChartIntervalDay buckets of one day
+	ChartIntervalDay = fftypes.FFEnumValue("chartinterval", "day")
+)
+
+// ChartIntervalDurations are the bucket widths of each chart interval
+var ChartIntervalDurations = map[ChartInterval]time.Duration{
+	ChartIntervalMinute: time.Minute,
+	ChartIntervalHour:   time.Hour,
+	ChartIntervalDay:    24 * time.Hour,
+}
+
 // ChartHistogram is a list of buckets with types
 type ChartHistogram struct {
 	Count     string                `ffstruct:"ChartHistogram" json:"count"`
@@ -0,0 +1,45 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// CompensationStatus is the outcome of an attempt to compensate an operation
+type CompensationStatus = fftypes.FFEnum
+
+var (
+	// CompensationStatusSubmitted indicates the compensating action was submitted successfully
+	CompensationStatusSubmitted = fftypes.FFEnumValue("compensationstatus", "submitted")
+	// CompensationStatusSkipped indicates the operation has no effect that can be compensated
+	CompensationStatusSkipped = fftypes.FFEnumValue("compensationstatus", "skipped")
+	// CompensationStatusFailed indicates the compensating action failed to submit, and can be attempted again
+	CompensationStatusFailed = fftypes.FFEnumValue("compensationstatus", "failed")
+)
+
+// Compensation is an audit record of an attempt to reverse the effect of a succeeded operation,
+// when compensating the transaction it is part of
+type Compensation struct {
+	ID          *fftypes.UUID      `ffstruct:"Compensation" json:"id"`
+	Namespace   string             `ffstruct:"Compensation" json:"namespace"`
+	Transaction *fftypes.UUID      `ffstruct:"Compensation" json:"tx"`
+	Operation   *fftypes.UUID      `ffstruct:"Compensation" json:"operation"`
+	Type        OpType             `ffstruct:"Compensation" json:"type" ffenum:"optype"`
+	Status      CompensationStatus `ffstruct:"Compensation" json:"status" ffenum:"compensationstatus"`
+	Output      fftypes.JSONObject `ffstruct:"Compensation" json:"output,omitempty"`
+	Error       string             `ffstruct:"Compensation" json:"error,omitempty"`
+	Created     *fftypes.FFTime    `ffstruct:"Compensation" json:"created"`
+}
@@ -27,6 +27,8 @@ const (
 	SystemTopicDefinitions = "ff_definition"
 	// SystemBatchPinTopic is the FireFly event topic for events from the FireFly batch pin listener
 	SystemBatchPinTopic = "ff_batch_pin"
+	// SystemTopicSecurity is the FireFly event topic for security related events, such as the rejection of input from a revoked verifier
+	SystemTopicSecurity = "ff_security"
 )
 
 const (
@@ -44,6 +46,8 @@ const (
 	SystemTagDefineFFI = "ff_define_ffi"
 	// SystemTagDefineContractAPI is the tag for messages that broadcast contract APIs
 	SystemTagDefineContractAPI = "ff_define_contract_api"
+	// SystemTagDefineContractMigration is the tag for messages that broadcast a migration to the next FireFly multiparty contract
+	SystemTagDefineContractMigration = "ff_define_contract_migration"
 	// SystemTagIdentityClaim is the tag for messages that broadcast an identity claim
 	SystemTagIdentityClaim = "ff_identity_claim"
 	//nolint:gosec
@@ -51,6 +55,10 @@ const (
 	SystemTagIdentityVerification = "ff_identity_verification"
 	// SystemTagIdentityUpdate is the tag for messages that broadcast an identity update
 	SystemTagIdentityUpdate = "ff_identity_update"
+	// SystemTagRevokeVerifier is the tag for messages that broadcast the revocation of an identity verifier
+	SystemTagRevokeVerifier = "ff_revoke_verifier"
+	// SystemTagDefineDelegation is the tag for messages that broadcast a delegation of submission from one identity to another
+	SystemTagDefineDelegation = "ff_define_delegation"
 	// SystemTagGapFill is the tag for messages that provide a nonce gap fill for a message that failed to send
 	SystemTagGapFill = "ff_gap_fill"
 )
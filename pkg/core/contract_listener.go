@@ -45,7 +45,29 @@ type ContractListenerWithStatus struct {
 	Status interface{} `ffstruct:"ContractListenerWithStatus" json:"status,omitempty" ffexcludeinput:"true"`
 }
 type ContractListenerOptions struct {
-	FirstEvent string `ffstruct:"ContractListenerOptions" json:"firstEvent,omitempty"`
+	FirstEvent string                     `ffstruct:"ContractListenerOptions" json:"firstEvent,omitempty"`
+	Mappings   []*ContractListenerMapping `ffstruct:"ContractListenerOptions" json:"mappings,omitempty"`
+}
+
+type ContractListenerMappingType = fftypes.FFEnum
+
+var (
+	// MappingTypeString coerces the extracted value to a string
+	MappingTypeString = fftypes.FFEnumValue("listenermappingtype", "string")
+	// MappingTypeInteger coerces the extracted value to an arbitrary precision integer
+	MappingTypeInteger = fftypes.FFEnumValue("listenermappingtype", "integer")
+	// MappingTypeNumber coerces the extracted value to a floating point number
+	MappingTypeNumber = fftypes.FFEnumValue("listenermappingtype", "number")
+	// MappingTypeBoolean coerces the extracted value to a boolean
+	MappingTypeBoolean = fftypes.FFEnumValue("listenermappingtype", "boolean")
+)
+
+// ContractListenerMapping extracts a single value from the output of a blockchain event into
+// a named field of the structured record stored on the event
+type ContractListenerMapping struct {
+	Field string                      `ffstruct:"ContractListenerMapping" json:"field"`
+	Path  string                      `ffstruct:"ContractListenerMapping" json:"path,omitempty"`
+	Type  ContractListenerMappingType `ffstruct:"ContractListenerMapping" json:"type,omitempty" ffenum:"listenermappingtype"`
 }
 
 type ListenerStatusError struct {
@@ -45,15 +45,78 @@ type ContractCallRequest struct {
 	IdempotencyKey IdempotencyKey         `ffstruct:"ContractCallRequest" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
 }
 
+// ContractInvokeResult is returned when waiting for confirmation of an invocation, with the return values of the
+// method and the events emitted by the transaction decoded using the interface of the contract
+type ContractInvokeResult struct {
+	*Operation
+	ReturnValues fftypes.JSONObject     `ffstruct:"ContractInvokeResult" json:"returnValues,omitempty"`
+	Events       []*ContractInvokeEvent `ffstruct:"ContractInvokeResult" json:"events,omitempty"`
+}
+
+type ContractInvokeEvent struct {
+	Name      string             `ffstruct:"ContractInvokeEvent" json:"name"`
+	Signature string             `ffstruct:"ContractInvokeEvent" json:"signature"`
+	Output    fftypes.JSONObject `ffstruct:"ContractInvokeEvent" json:"output"`
+}
+
+// ContractCallBatchRequest submits a set of invocations as the operations of a single transaction, or as a
+// single call to an on-chain multicall aggregator when the blockchain plugin supports it
+type ContractCallBatchRequest struct {
+	Items          []*ContractCallRequest `ffstruct:"ContractCallBatchRequest" json:"items"`
+	Key            string                 `ffstruct:"ContractCallBatchRequest" json:"key,omitempty"`
+	Multicall      *fftypes.JSONAny       `ffstruct:"ContractCallBatchRequest" json:"multicall,omitempty"`
+	AllowFailure   bool                   `ffstruct:"ContractCallBatchRequest" json:"allowFailure,omitempty"`
+	Options        map[string]interface{} `ffstruct:"ContractCallBatchRequest" json:"options"`
+	IdempotencyKey IdempotencyKey         `ffstruct:"ContractCallBatchRequest" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
+}
+
+type ContractCallBatchResponse struct {
+	TX         *fftypes.UUID `ffstruct:"ContractCallBatchResponse" json:"tx,omitempty"`
+	Operations []*Operation  `ffstruct:"ContractCallBatchResponse" json:"operations"`
+}
+
+// ContractCallBatchItemResult is the outcome of one call within a multicall, as added to the output of the operation
+type ContractCallBatchItemResult struct {
+	Success    bool   `ffstruct:"ContractCallBatchItemResult" json:"success"`
+	ReturnData string `ffstruct:"ContractCallBatchItemResult" json:"returnData,omitempty"`
+}
+
 type ContractDeployRequest struct {
 	Key            string                 `ffstruct:"ContractDeployRequest" json:"key,omitempty"`
 	Input          []interface{}          `ffstruct:"ContractDeployRequest" json:"input"`
 	Definition     *fftypes.JSONAny       `ffstruct:"ContractDeployRequest" json:"definition"`
 	Contract       *fftypes.JSONAny       `ffstruct:"ContractDeployRequest" json:"contract"`
 	Options        map[string]interface{} `ffstruct:"ContractDeployRequest" json:"options"`
+	Interface      *fftypes.FFIReference  `ffstruct:"ContractDeployRequest" json:"interface,omitempty"`
+	APIName        string                 `ffstruct:"ContractDeployRequest" json:"apiName,omitempty"`
 	IdempotencyKey IdempotencyKey         `ffstruct:"ContractDeployRequest" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
 }
 
+// ContractABI is a contract ABI stored in the blockchain connector, from which new instances of the contract can be deployed
+type ContractABI struct {
+	ID          string           `ffstruct:"ContractABI" json:"id"`
+	Name        string           `ffstruct:"ContractABI" json:"name,omitempty"`
+	Description string           `ffstruct:"ContractABI" json:"description,omitempty"`
+	Deployable  bool             `ffstruct:"ContractABI" json:"deployable"`
+	Created     *fftypes.FFTime  `ffstruct:"ContractABI" json:"created,omitempty"`
+	ABI         *fftypes.JSONAny `ffstruct:"ContractABI" json:"abi,omitempty"`
+}
+
+type ContractABIUpload struct {
+	Name        string           `ffstruct:"ContractABIUpload" json:"name,omitempty"`
+	Description string           `ffstruct:"ContractABIUpload" json:"description,omitempty"`
+	ABI         *fftypes.JSONAny `ffstruct:"ContractABIUpload" json:"abi"`
+	Bytecode    string           `ffstruct:"ContractABIUpload" json:"bytecode,omitempty"`
+}
+
+type ContractABIDeployRequest struct {
+	ABI            string                 `ffstruct:"ContractABIDeployRequest" json:"abi,omitempty" ffexcludeinput:"true"`
+	Key            string                 `ffstruct:"ContractABIDeployRequest" json:"key,omitempty"`
+	Input          map[string]interface{} `ffstruct:"ContractABIDeployRequest" json:"input"`
+	Options        map[string]interface{} `ffstruct:"ContractABIDeployRequest" json:"options"`
+	IdempotencyKey IdempotencyKey         `ffstruct:"ContractABIDeployRequest" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
+}
+
 type ContractURLs struct {
 	API     string `ffstruct:"ContractURLs" json:"api"`
 	OpenAPI string `ffstruct:"ContractURLs" json:"openapi"`
@@ -61,15 +124,51 @@ type ContractURLs struct {
 }
 
 type ContractAPI struct {
-	ID          *fftypes.UUID         `ffstruct:"ContractAPI" json:"id,omitempty" ffexcludeinput:"true"`
-	Namespace   string                `ffstruct:"ContractAPI" json:"namespace,omitempty" ffexcludeinput:"true"`
-	Interface   *fftypes.FFIReference `ffstruct:"ContractAPI" json:"interface"`
-	Location    *fftypes.JSONAny      `ffstruct:"ContractAPI" json:"location,omitempty"`
-	Name        string                `ffstruct:"ContractAPI" json:"name"`
-	NetworkName string                `ffstruct:"ContractAPI" json:"networkName,omitempty"`
-	Message     *fftypes.UUID         `ffstruct:"ContractAPI" json:"message,omitempty" ffexcludeinput:"true"`
-	URLs        ContractURLs          `ffstruct:"ContractAPI" json:"urls" ffexcludeinput:"true"`
-	Published   bool                  `ffstruct:"ContractAPI" json:"published" ffexcludeinput:"true"`
+	ID            *fftypes.UUID         `ffstruct:"ContractAPI" json:"id,omitempty" ffexcludeinput:"true"`
+	Namespace     string                `ffstruct:"ContractAPI" json:"namespace,omitempty" ffexcludeinput:"true"`
+	Interface     *fftypes.FFIReference `ffstruct:"ContractAPI" json:"interface"`
+	Location      *fftypes.JSONAny      `ffstruct:"ContractAPI" json:"location,omitempty"`
+	Name          string                `ffstruct:"ContractAPI" json:"name"`
+	NetworkName   string                `ffstruct:"ContractAPI" json:"networkName,omitempty"`
+	Version       string                `ffstruct:"ContractAPI" json:"version,omitempty"`
+	QueryCacheTTL *fftypes.FFDuration   `ffstruct:"ContractAPI" json:"queryCacheTTL,omitempty"`
+	InterfaceHash *fftypes.Bytes32      `ffstruct:"ContractAPI" json:"interfaceHash,omitempty"`
+	Message       *fftypes.UUID         `ffstruct:"ContractAPI" json:"message,omitempty" ffexcludeinput:"true"`
+	URLs          ContractURLs          `ffstruct:"ContractAPI" json:"urls" ffexcludeinput:"true"`
+	Published     bool                  `ffstruct:"ContractAPI" json:"published" ffexcludeinput:"true"`
+}
+
+// SharedFFI is an entry in the global registry of contract interfaces, which allows an FFI defined in
+// one namespace to be bound by contract APIs in any other namespace
+type SharedFFI struct {
+	ID        *fftypes.UUID    `ffstruct:"SharedFFI" json:"id"`
+	Namespace string           `ffstruct:"SharedFFI" json:"namespace"`
+	Interface *fftypes.UUID    `ffstruct:"SharedFFI" json:"interface"`
+	Name      string           `ffstruct:"SharedFFI" json:"name"`
+	Version   string           `ffstruct:"SharedFFI" json:"version"`
+	Hash      *fftypes.Bytes32 `ffstruct:"SharedFFI" json:"hash"`
+	Created   *fftypes.FFTime  `ffstruct:"SharedFFI" json:"created"`
+}
+
+// ContractAPIDiff describes the changes between the interfaces bound to two versions of a contract API
+type ContractAPIDiff struct {
+	From    *ContractAPIVersionRef `ffstruct:"ContractAPIDiff" json:"from"`
+	To      *ContractAPIVersionRef `ffstruct:"ContractAPIDiff" json:"to"`
+	Methods *FFIDiffEntries        `ffstruct:"ContractAPIDiff" json:"methods"`
+	Events  *FFIDiffEntries        `ffstruct:"ContractAPIDiff" json:"events"`
+	Errors  *FFIDiffEntries        `ffstruct:"ContractAPIDiff" json:"errors"`
+}
+
+type ContractAPIVersionRef struct {
+	Version   string                `ffstruct:"ContractAPIVersionRef" json:"version"`
+	Location  *fftypes.JSONAny      `ffstruct:"ContractAPIVersionRef" json:"location,omitempty"`
+	Interface *fftypes.FFIReference `ffstruct:"ContractAPIVersionRef" json:"interface"`
+}
+
+type FFIDiffEntries struct {
+	Added   []string `ffstruct:"FFIDiffEntries" json:"added"`
+	Removed []string `ffstruct:"FFIDiffEntries" json:"removed"`
+	Changed []string `ffstruct:"FFIDiffEntries" json:"changed"`
 }
 
 func (c *ContractAPI) Validate(ctx context.Context) (err error) {
@@ -84,6 +183,11 @@ func (c *ContractAPI) Validate(ctx context.Context) (err error) {
 			return err
 		}
 	}
+	if c.Version != "" {
+		if err = fftypes.ValidateFFNameField(ctx, c.Version, "version"); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
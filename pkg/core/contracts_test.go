@@ -31,6 +31,10 @@ func TestValidateContractAPI(t *testing.T) {
 	}
 	err := api.Validate(context.Background())
 	assert.NoError(t, err)
+
+	api.Version = "v1.0.0"
+	err = api.Validate(context.Background())
+	assert.NoError(t, err)
 }
 
 func TestValidateInvalidContractAPI(t *testing.T) {
@@ -55,6 +59,14 @@ func TestValidateInvalidContractAPI(t *testing.T) {
 	}
 	err = api.Validate(context.Background())
 	assert.Regexp(t, "FF00140", err)
+
+	api = &ContractAPI{
+		Namespace: "ns1",
+		Name:      "banana",
+		Version:   "(%&@!^%^)",
+	}
+	err = api.Validate(context.Background())
+	assert.Regexp(t, "FF00140", err)
 }
 
 func TestContractAPITopic(t *testing.T) {
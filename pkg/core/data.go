@@ -25,6 +25,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 )
 
 type DataRef struct {
@@ -42,16 +43,47 @@ type BlobRef struct {
 	Public string           `ffstruct:"BlobRef" json:"public,omitempty"`
 }
 
+// DataPinStatus is the state of a request to a remote pinning service, to pin the shared storage copy of a data item
+type DataPinStatus = fftypes.FFEnum
+
+var (
+	// DataPinStatusQueued the pinning service has accepted the request, but not yet started pinning
+	DataPinStatusQueued = fftypes.FFEnumValue("datapinstatus", "queued")
+	// DataPinStatusPinning the pinning service is retrieving the data
+	DataPinStatusPinning = fftypes.FFEnumValue("datapinstatus", "pinning")
+	// DataPinStatusPinned the data is pinned by the pinning service
+	DataPinStatusPinned = fftypes.FFEnumValue("datapinstatus", "pinned")
+	// DataPinStatusFailed the pin request could not be submitted, or the pinning service failed to pin the data
+	DataPinStatusFailed = fftypes.FFEnumValue("datapinstatus", "failed")
+)
+
+// DataMigration records the provenance of a data item that was moved from an earlier version of its datatype
+type DataMigration struct {
+	FromVersion string          `ffstruct:"DataMigration" json:"fromVersion"`
+	Datatype    *fftypes.UUID   `ffstruct:"DataMigration" json:"datatype,omitempty"`
+	Migrated    *fftypes.FFTime `ffstruct:"DataMigration" json:"migrated,omitempty"`
+}
+
+// DataPin tracks the pin of the shared storage copy of a data item on a remote pinning service
+type DataPin struct {
+	Status  DataPinStatus `ffstruct:"DataPin" json:"status" ffenum:"datapinstatus"`
+	Request string        `ffstruct:"DataPin" json:"request,omitempty"`
+}
+
 type Data struct {
-	ID        *fftypes.UUID    `ffstruct:"Data" json:"id,omitempty"`
-	Validator ValidatorType    `ffstruct:"Data" json:"validator"`
-	Namespace string           `ffstruct:"Data" json:"namespace,omitempty"`
-	Hash      *fftypes.Bytes32 `ffstruct:"Data" json:"hash,omitempty"`
-	Created   *fftypes.FFTime  `ffstruct:"Data" json:"created,omitempty"`
-	Datatype  *DatatypeRef     `ffstruct:"Data" json:"datatype,omitempty"`
-	Value     *fftypes.JSONAny `ffstruct:"Data" json:"value"`
-	Public    string           `ffstruct:"Data" json:"public,omitempty"`
-	Blob      *BlobRef         `ffstruct:"Data" json:"blob,omitempty"`
+	ID               *fftypes.UUID        `ffstruct:"Data" json:"id,omitempty"`
+	Validator        ValidatorType        `ffstruct:"Data" json:"validator"`
+	Namespace        string               `ffstruct:"Data" json:"namespace,omitempty"`
+	Hash             *fftypes.Bytes32     `ffstruct:"Data" json:"hash,omitempty"`
+	HashAlgorithm    HashAlgorithm        `ffstruct:"Data" json:"hashAlgorithm,omitempty" ffenum:"hashalgorithm" ffexcludeinput:"true"`
+	Canonicalization DataCanonicalization `ffstruct:"Data" json:"canonicalization,omitempty" ffenum:"datacanonicalization" ffexcludeinput:"true"`
+	Created          *fftypes.FFTime      `ffstruct:"Data" json:"created,omitempty"`
+	Datatype         *DatatypeRef         `ffstruct:"Data" json:"datatype,omitempty"`
+	Value            *fftypes.JSONAny     `ffstruct:"Data" json:"value"`
+	Public           string               `ffstruct:"Data" json:"public,omitempty"`
+	Blob             *BlobRef             `ffstruct:"Data" json:"blob,omitempty"`
+	Pin              *DataPin             `ffstruct:"Data" json:"pin,omitempty" ffexcludeinput:"true"`
+	Migration        *DataMigration       `ffstruct:"Data" json:"migration,omitempty" ffexcludeinput:"true"`
 
 	ValueSize int64 `json:"-"` // Used internally for message size calculation, without full payload retrieval
 }
@@ -80,14 +112,16 @@ func (br *BlobRef) BatchBlobRef(batchType BatchType) *BlobRef {
 // This is what is transferred and hashed in a batch payload between nodes.
 func (d *Data) BatchData(batchType BatchType) *Data {
 	return &Data{
-		ID:        d.ID,
-		Validator: d.Validator,
-		Hash:      d.Hash,
-		Created:   d.Created,
-		Datatype:  d.Datatype,
-		Value:     d.Value,
-		Blob:      d.Blob.BatchBlobRef(batchType),
-		ValueSize: d.ValueSize,
+		ID:               d.ID,
+		Validator:        d.Validator,
+		Hash:             d.Hash,
+		HashAlgorithm:    d.HashAlgorithm,
+		Canonicalization: d.Canonicalization,
+		Created:          d.Created,
+		Datatype:         d.Datatype,
+		Value:            d.Value,
+		Blob:             d.Blob.BatchBlobRef(batchType),
+		ValueSize:        d.ValueSize,
 	}
 }
 
@@ -132,13 +166,31 @@ func (da DataArray) Refs() DataRefs {
 
 func CheckValidatorType(ctx context.Context, validator ValidatorType) error {
 	switch validator {
-	case ValidatorTypeJSON, ValidatorTypeNone, ValidatorTypeSystemDefinition:
+	case ValidatorTypeJSON, ValidatorTypeNone, ValidatorTypeSystemDefinition, ValidatorTypeProtobuf:
 		return nil
 	default:
 		return i18n.NewError(ctx, i18n.MsgUnknownValidatorType, validator)
 	}
 }
 
+// BinaryValue wraps a binary payload, such as an encoded protobuf message, as a base64 string data value
+func BinaryValue(payload []byte) *fftypes.JSONAny {
+	b, _ := json.Marshal(payload)
+	return fftypes.JSONAnyPtrBytes(b)
+}
+
+// DecodeBinaryValue extracts the binary payload from a base64 string data value
+func DecodeBinaryValue(ctx context.Context, value *fftypes.JSONAny) ([]byte, error) {
+	if value.IsNil() {
+		return nil, i18n.NewError(ctx, i18n.MsgDataValueIsNull)
+	}
+	var payload []byte
+	if err := json.Unmarshal(value.Bytes(), &payload); err != nil {
+		return nil, i18n.NewError(ctx, coremsgs.MsgDataValueNotBinary, err)
+	}
+	return payload, nil
+}
+
 const dataSizeEstimateBase = int64(256)
 
 func (d *Data) EstimateSize() int64 {
@@ -161,17 +213,46 @@ func (d *Data) CalcHash(ctx context.Context) (*fftypes.Bytes32, error) {
 	// The hash is either the blob hash, the value hash, or if both are supplied
 	// (e.g. a blob with associated metadata) it a hash of the two HEX hashes
 	// concattenated together (no spaces or separation).
+	// Blob hashes are calculated by data exchange, and are always SHA-256.
 	switch {
 	case !valueIsNull && (d.Blob == nil || d.Blob.Hash == nil):
-		return d.Value.Hash(), nil
+		return d.calcValueHash(ctx)
 	case valueIsNull && d.Blob != nil && d.Blob.Hash != nil:
 		return d.Blob.Hash, nil
 	default:
-		hash := sha256.New()
-		hash.Write([]byte(d.Value.Hash().String()))
-		hash.Write([]byte(d.Blob.Hash.String()))
-		return fftypes.HashResult(hash), nil
+		valueHash, err := d.calcValueHash(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return HashBytes(ctx, d.HashAlgorithm, []byte(valueHash.String()+d.Blob.Hash.String()))
+	}
+}
+
+// ContentType is the media type of the data. For data with a blob attachment, this is the mimetype
+// recorded in the value (as set by autometa on upload), and otherwise the data is a JSON value
+func (d *Data) ContentType() string {
+	if d.Blob != nil && d.Blob.Hash != nil {
+		if d.Value != nil {
+			if mimetype := d.Value.JSONObjectNowarn().GetString("mimetype"); mimetype != "" {
+				return mimetype
+			}
+		}
+		return "application/octet-stream"
+	}
+	return "application/json"
+}
+
+// calcValueHash hashes the value either exactly as stored, or in its canonical form if
+// a canonicalization is recorded on the data
+func (d *Data) calcValueHash(ctx context.Context) (*fftypes.Bytes32, error) {
+	value := []byte(*d.Value)
+	if d.Canonicalization == DataCanonicalizationJCS {
+		var err error
+		if value, err = CanonicalizeJSON(ctx, value); err != nil {
+			return nil, err
+		}
 	}
+	return HashBytes(ctx, d.HashAlgorithm, value)
 }
 
 func (d *Data) Seal(ctx context.Context, blob *Blob) (err error) {
@@ -54,6 +54,26 @@ func TestValidateBadValidator(t *testing.T) {
 	assert.Regexp(t, "FF00108", err)
 }
 
+func TestValidateProtobufValidator(t *testing.T) {
+	err := CheckValidatorType(context.Background(), ValidatorTypeProtobuf)
+	assert.NoError(t, err)
+}
+
+func TestBinaryValue(t *testing.T) {
+	value := BinaryValue([]byte{0x0a, 0x01, 0x61})
+	assert.Equal(t, `"CgFh"`, value.String())
+
+	payload, err := DecodeBinaryValue(context.Background(), value)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x01, 0x61}, payload)
+
+	_, err = DecodeBinaryValue(context.Background(), fftypes.JSONAnyPtr(`{"not":"binary"}`))
+	assert.Regexp(t, "FF10539", err)
+
+	_, err = DecodeBinaryValue(context.Background(), nil)
+	assert.Regexp(t, "FF00109", err)
+}
+
 func TestSealNoData(t *testing.T) {
 	d := &Data{}
 	err := d.Seal(context.Background(), nil)
@@ -302,3 +322,68 @@ func TestPath(t *testing.T) {
 	assert.Equal(t, "/sub/path/to", d.Blob.Path)
 
 }
+
+func TestSealHashAlgorithm(t *testing.T) {
+	d := &Data{
+		Value:         fftypes.JSONAnyPtr(`{"some":"data"}`),
+		HashAlgorithm: HashAlgorithmBLAKE2b256,
+	}
+	err := d.Seal(context.Background(), nil)
+	assert.NoError(t, err)
+	expected, _ := HashBytes(context.Background(), HashAlgorithmBLAKE2b256, []byte(`{"some":"data"}`))
+	assert.Equal(t, expected, d.Hash)
+	assert.Equal(t, HashAlgorithmBLAKE2b256, d.BatchData(BatchTypeBroadcast).HashAlgorithm)
+
+	blobHash := fftypes.NewRandB32()
+	d.Blob = &BlobRef{Hash: blobHash}
+	hash, err := d.CalcHash(context.Background())
+	assert.NoError(t, err)
+	expected, _ = HashBytes(context.Background(), HashAlgorithmBLAKE2b256, []byte(expected.String()+blobHash.String()))
+	assert.Equal(t, expected, hash)
+}
+
+func TestCalcHashUnsupportedAlgorithm(t *testing.T) {
+	d := &Data{
+		Value:         fftypes.JSONAnyPtr(`{"some":"data"}`),
+		HashAlgorithm: "md5",
+	}
+	_, err := d.CalcHash(context.Background())
+	assert.Regexp(t, "FF10511", err)
+
+	d.Blob = &BlobRef{Hash: fftypes.NewRandB32()}
+	_, err = d.CalcHash(context.Background())
+	assert.Regexp(t, "FF10511", err)
+}
+
+func TestSealCanonicalization(t *testing.T) {
+	d := &Data{
+		Value:            fftypes.JSONAnyPtr(`{ "some": "data", "amount": 1.50 }`),
+		Canonicalization: DataCanonicalizationJCS,
+	}
+	err := d.Seal(context.Background(), nil)
+	assert.NoError(t, err)
+	expected, _ := HashBytes(context.Background(), "", []byte(`{"amount":1.5,"some":"data"}`))
+	assert.Equal(t, expected, d.Hash)
+	assert.Equal(t, DataCanonicalizationJCS, d.BatchData(BatchTypeBroadcast).Canonicalization)
+	assert.Equal(t, `{ "some": "data", "amount": 1.50 }`, d.Value.String())
+
+	blobHash := fftypes.NewRandB32()
+	d.Blob = &BlobRef{Hash: blobHash}
+	hash, err := d.CalcHash(context.Background())
+	assert.NoError(t, err)
+	expected, _ = HashBytes(context.Background(), "", []byte(expected.String()+blobHash.String()))
+	assert.Equal(t, expected, hash)
+}
+
+func TestCalcHashCanonicalizationFail(t *testing.T) {
+	d := &Data{
+		Value:            fftypes.JSONAnyPtr(`{"some":"data","some":"more"}`),
+		Canonicalization: DataCanonicalizationJCS,
+	}
+	_, err := d.CalcHash(context.Background())
+	assert.Regexp(t, "FF10588", err)
+
+	d.Blob = &BlobRef{Hash: fftypes.NewRandB32()}
+	_, err = d.CalcHash(context.Background())
+	assert.Regexp(t, "FF10588", err)
+}
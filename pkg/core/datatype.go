@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
 	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
 )
 
 type ValidatorType = fftypes.FFEnum
@@ -32,23 +33,51 @@ var (
 	ValidatorTypeNone = fftypes.FFEnumValue("validatortype", "none")
 	// ValidatorTypeSystemDefinition is the validator type for system definitions
 	ValidatorTypeSystemDefinition = fftypes.FFEnumValue("validatortype", "definition")
+	// ValidatorTypeProtobuf is the validator type for protobuf messages, validated against a registered descriptor set
+	ValidatorTypeProtobuf = fftypes.FFEnumValue("validatortype", "protobuf")
+)
+
+// ValidationPolicy determines what happens to a received message, when its data fails validation against its datatype
+type ValidationPolicy = fftypes.FFEnum
+
+var (
+	// ValidationPolicyEnforce rejects the message
+	ValidationPolicyEnforce = fftypes.FFEnumValue("validationpolicy", "enforce")
+	// ValidationPolicyWarn confirms the message, and emits a warning event alongside it
+	ValidationPolicyWarn = fftypes.FFEnumValue("validationpolicy", "warn")
+	// ValidationPolicyQuarantine holds the message for review, before it is confirmed or rejected
+	ValidationPolicyQuarantine = fftypes.FFEnumValue("validationpolicy", "quarantine")
 )
 
 // Datatype is the structure defining a data definition, such as a JSON schema
 type Datatype struct {
-	ID        *fftypes.UUID    `ffstruct:"Datatype" json:"id,omitempty" ffexcludeinput:"true"`
-	Message   *fftypes.UUID    `ffstruct:"Datatype" json:"message,omitempty" ffexcludeinput:"true"`
-	Validator ValidatorType    `ffstruct:"Datatype" json:"validator" ffenum:"validatortype"`
-	Namespace string           `ffstruct:"Datatype" json:"namespace,omitempty" ffexcludeinput:"true"`
-	Name      string           `ffstruct:"Datatype" json:"name,omitempty"`
-	Version   string           `ffstruct:"Datatype" json:"version,omitempty"`
-	Hash      *fftypes.Bytes32 `ffstruct:"Datatype" json:"hash,omitempty" ffexcludeinput:"true"`
-	Created   *fftypes.FFTime  `ffstruct:"Datatype" json:"created,omitempty" ffexcludeinput:"true"`
-	Value     *fftypes.JSONAny `ffstruct:"Datatype" json:"value,omitempty"`
+	ID        *fftypes.UUID      `ffstruct:"Datatype" json:"id,omitempty" ffexcludeinput:"true"`
+	Message   *fftypes.UUID      `ffstruct:"Datatype" json:"message,omitempty" ffexcludeinput:"true"`
+	Validator ValidatorType      `ffstruct:"Datatype" json:"validator" ffenum:"validatortype"`
+	Namespace string             `ffstruct:"Datatype" json:"namespace,omitempty" ffexcludeinput:"true"`
+	Name      string             `ffstruct:"Datatype" json:"name,omitempty"`
+	Version   string             `ffstruct:"Datatype" json:"version,omitempty"`
+	Hash      *fftypes.Bytes32   `ffstruct:"Datatype" json:"hash,omitempty" ffexcludeinput:"true"`
+	Created   *fftypes.FFTime    `ffstruct:"Datatype" json:"created,omitempty" ffexcludeinput:"true"`
+	Value     *fftypes.JSONAny   `ffstruct:"Datatype" json:"value,omitempty"`
+	Migration *DatatypeMigration `ffstruct:"Datatype" json:"migration,omitempty"`
+}
+
+// DatatypeMigration registers that existing data stored against an earlier version of a datatype
+// should be moved to reference this version, as long as it is valid against this version
+type DatatypeMigration struct {
+	FromVersion string `ffstruct:"DatatypeMigration" json:"fromVersion"`
+}
+
+// DatatypeInferInput is a sample JSON payload, from which a JSON Schema datatype is generated
+type DatatypeInferInput struct {
+	Name    string           `ffstruct:"DatatypeInferInput" json:"name,omitempty"`
+	Version string           `ffstruct:"DatatypeInferInput" json:"version,omitempty"`
+	Sample  *fftypes.JSONAny `ffstruct:"DatatypeInferInput" json:"sample"`
 }
 
 func (dt *Datatype) Validate(ctx context.Context, existing bool) (err error) {
-	if dt.Validator != ValidatorTypeJSON {
+	if dt.Validator != ValidatorTypeJSON && dt.Validator != ValidatorTypeProtobuf {
 		return i18n.NewError(ctx, i18n.MsgUnknownFieldValue, "validator", dt.Validator)
 	}
 	if err = fftypes.ValidateFFNameFieldNoUUID(ctx, dt.Name, "name"); err != nil {
@@ -60,6 +89,14 @@ func (dt *Datatype) Validate(ctx context.Context, existing bool) (err error) {
 	if dt.Value == nil || len(*dt.Value) == 0 {
 		return i18n.NewError(ctx, i18n.MsgMissingRequiredField, "value")
 	}
+	if dt.Migration != nil {
+		if err = fftypes.ValidateFFNameField(ctx, dt.Migration.FromVersion, "migration.fromVersion"); err != nil {
+			return err
+		}
+		if dt.Migration.FromVersion == dt.Version {
+			return i18n.NewError(ctx, coremsgs.MsgDatatypeMigrateSameVersion, dt.Version)
+		}
+	}
 	if existing {
 		if dt.ID == nil {
 			return i18n.NewError(ctx, i18n.MsgNilID)
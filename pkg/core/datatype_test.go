@@ -54,6 +54,14 @@ func TestDatatypeValidation(t *testing.T) {
 	}
 	assert.Regexp(t, "FF00112.*value", dt.Validate(context.Background(), false))
 
+	dt = &Datatype{
+		Validator: ValidatorTypeProtobuf,
+		Namespace: "ok",
+		Name:      "ok",
+		Version:   "ok",
+	}
+	assert.Regexp(t, "FF00112.*value", dt.Validate(context.Background(), false))
+
 	dt = &Datatype{
 		Validator: ValidatorTypeJSON,
 		Namespace: "ok",
@@ -63,6 +71,15 @@ func TestDatatypeValidation(t *testing.T) {
 	}
 	assert.NoError(t, dt.Validate(context.Background(), false))
 
+	dt.Migration = &DatatypeMigration{FromVersion: "!wrong"}
+	assert.Regexp(t, "FF00140.*migration.fromVersion", dt.Validate(context.Background(), false))
+
+	dt.Migration = &DatatypeMigration{FromVersion: "ok"}
+	assert.Regexp(t, "FF10594", dt.Validate(context.Background(), false))
+
+	dt.Migration = &DatatypeMigration{FromVersion: "previous"}
+	assert.NoError(t, dt.Validate(context.Background(), false))
+
 	assert.Regexp(t, "FF00114", dt.Validate(context.Background(), true))
 
 	dt.ID = fftypes.NewUUID()
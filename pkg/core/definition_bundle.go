@@ -0,0 +1,76 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// DefinitionType identifies the kind of definition held in a definition bundle
+type DefinitionType = fftypes.FFEnum
+
+var (
+	// DefinitionTypeDatatype is a datatype definition
+	DefinitionTypeDatatype = fftypes.FFEnumValue("definitiontype", "datatype")
+	// DefinitionTypeFFI is a contract interface definition
+	DefinitionTypeFFI = fftypes.FFEnumValue("definitiontype", "ffi")
+	// DefinitionTypeContractAPI is a contract API definition
+	DefinitionTypeContractAPI = fftypes.FFEnumValue("definitiontype", "contractapi")
+	// DefinitionTypeTokenPool is a token pool definition
+	DefinitionTypeTokenPool = fftypes.FFEnumValue("definitiontype", "tokenpool")
+	// DefinitionTypeGroup is a privacy group
+	DefinitionTypeGroup = fftypes.FFEnumValue("definitiontype", "group")
+)
+
+// DefinitionImportStatus is the outcome of importing a single definition from a bundle
+type DefinitionImportStatus = fftypes.FFEnum
+
+var (
+	// DefinitionImportStatusImported indicates the definition was defined in the namespace
+	DefinitionImportStatusImported = fftypes.FFEnumValue("definitionimportstatus", "imported")
+	// DefinitionImportStatusSkipped indicates a definition with the same name already exists in the namespace
+	DefinitionImportStatusSkipped = fftypes.FFEnumValue("definitionimportstatus", "skipped")
+	// DefinitionImportStatusFailed indicates the definition could not be defined in the namespace
+	DefinitionImportStatusFailed = fftypes.FFEnumValue("definitionimportstatus", "failed")
+)
+
+// DefinitionBundle is a portable snapshot of the definitions of a namespace, which can be
+// exported from one environment and imported into another
+type DefinitionBundle struct {
+	Namespace    string          `ffstruct:"DefinitionBundle" json:"namespace"`
+	Exported     *fftypes.FFTime `ffstruct:"DefinitionBundle" json:"exported"`
+	Datatypes    []*Datatype     `ffstruct:"DefinitionBundle" json:"datatypes"`
+	FFIs         []*fftypes.FFI  `ffstruct:"DefinitionBundle" json:"interfaces"`
+	ContractAPIs []*ContractAPI  `ffstruct:"DefinitionBundle" json:"apis"`
+	TokenPools   []*TokenPool    `ffstruct:"DefinitionBundle" json:"tokenPools"`
+	Groups       []*Group        `ffstruct:"DefinitionBundle" json:"groups"`
+}
+
+// DefinitionImportResult reports the outcome of importing each definition in a bundle
+type DefinitionImportResult struct {
+	Namespace   string                  `ffstruct:"DefinitionImportResult" json:"namespace"`
+	Definitions []*DefinitionImportItem `ffstruct:"DefinitionImportResult" json:"definitions"`
+}
+
+// DefinitionImportItem is the outcome of importing a single definition
+type DefinitionImportItem struct {
+	Type    DefinitionType         `ffstruct:"DefinitionImportItem" json:"type" ffenum:"definitiontype"`
+	Name    string                 `ffstruct:"DefinitionImportItem" json:"name,omitempty"`
+	Version string                 `ffstruct:"DefinitionImportItem" json:"version,omitempty"`
+	Hash    *fftypes.Bytes32       `ffstruct:"DefinitionImportItem" json:"hash,omitempty"`
+	ID      *fftypes.UUID          `ffstruct:"DefinitionImportItem" json:"id,omitempty"`
+	Status  DefinitionImportStatus `ffstruct:"DefinitionImportItem" json:"status" ffenum:"definitionimportstatus"`
+	Error   string                 `ffstruct:"DefinitionImportItem" json:"error,omitempty"`
+}
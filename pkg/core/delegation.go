@@ -0,0 +1,83 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// DelegationScope is a type of submission that a delegation authorizes
+type DelegationScope = fftypes.FFEnum
+
+var (
+	// DelegationScopeMessages authorizes sending messages authored by the delegator
+	DelegationScopeMessages = fftypes.FFEnumValue("delegationscope", "messages")
+	// DelegationScopeTransfers authorizes submitting token transfers on behalf of the delegator
+	DelegationScopeTransfers = fftypes.FFEnumValue("delegationscope", "transfers")
+)
+
+// Delegation is a signed authorization from one registered identity (the delegator) for another identity (the delegate)
+// to submit messages and/or token transfers on its behalf, until an optional expiry. It is broadcast as a definition
+// signed by the delegator.
+type Delegation struct {
+	ID        *fftypes.UUID         `ffstruct:"Delegation" json:"id" ffexcludeinput:"true"`
+	Namespace string                `ffstruct:"Delegation" json:"namespace" ffexcludeinput:"true"`
+	Delegator string                `ffstruct:"Delegation" json:"delegator"`
+	Delegate  string                `ffstruct:"Delegation" json:"delegate"`
+	Scope     fftypes.FFStringArray `ffstruct:"Delegation" json:"scope"`
+	Expires   *fftypes.FFTime       `ffstruct:"Delegation" json:"expires,omitempty"`
+	Message   *fftypes.UUID         `ffstruct:"Delegation" json:"message,omitempty" ffexcludeinput:"true"`
+	Created   *fftypes.FFTime       `ffstruct:"Delegation" json:"created" ffexcludeinput:"true"`
+}
+
+// Validate checks the scope of the delegation is a non-empty list of known scopes
+func (d *Delegation) Validate(ctx context.Context) error {
+	if len(d.Scope) == 0 {
+		return i18n.NewError(ctx, coremsgs.MsgDelegationScopeMissing)
+	}
+	for _, scope := range d.Scope {
+		if _, err := fftypes.FFEnumParseString(ctx, "delegationscope", scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Allows returns true if the delegation covers the scope, and had not expired at the specified time
+func (d *Delegation) Allows(scope DelegationScope, at *fftypes.FFTime) bool {
+	if d.Expires != nil && !at.Time().Before(*d.Expires.Time()) {
+		return false
+	}
+	for _, s := range d.Scope {
+		if DelegationScope(s) == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *Delegation) Topic() string {
+	return fftypes.TypeNamespaceNameTopicHash("delegation", d.Namespace, d.Delegator)
+}
+
+func (d *Delegation) SetBroadcastMessage(msgID *fftypes.UUID) {
+	d.Message = msgID
+}
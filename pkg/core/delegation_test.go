@@ -0,0 +1,63 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelegationValidate(t *testing.T) {
+	d := &Delegation{}
+	assert.Regexp(t, "FF10575", d.Validate(context.Background()))
+
+	d.Scope = fftypes.NewFFStringArray("messages", "unknown")
+	assert.Regexp(t, "FF00172", d.Validate(context.Background()))
+
+	d.Scope = fftypes.NewFFStringArray("messages", "transfers")
+	assert.NoError(t, d.Validate(context.Background()))
+}
+
+func TestDelegationAllows(t *testing.T) {
+	now := fftypes.Now()
+	d := &Delegation{
+		Scope: fftypes.NewFFStringArray("messages"),
+	}
+	assert.True(t, d.Allows(DelegationScopeMessages, now))
+	assert.False(t, d.Allows(DelegationScopeTransfers, now))
+
+	expires := fftypes.FFTime(now.Time().Add(1 * time.Hour))
+	d.Expires = &expires
+	assert.True(t, d.Allows(DelegationScopeMessages, now))
+	assert.False(t, d.Allows(DelegationScopeMessages, d.Expires))
+}
+
+func TestDelegationTopic(t *testing.T) {
+	d := &Delegation{
+		Namespace: "ns1",
+		Delegator: "did:firefly:org/org1",
+	}
+	assert.Equal(t, fftypes.TypeNamespaceNameTopicHash("delegation", "ns1", "did:firefly:org/org1"), d.Topic())
+
+	msgID := fftypes.NewUUID()
+	d.SetBroadcastMessage(msgID)
+	assert.Equal(t, msgID, d.Message)
+}
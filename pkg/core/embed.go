@@ -0,0 +1,50 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// EmbedType is a type of referenced record that can be embedded in each item of a listing
+type EmbedType = fftypes.FFEnum
+
+var (
+	// EmbedTypeData embeds the data of the message
+	EmbedTypeData = fftypes.FFEnumValue("embedtype", "data")
+	// EmbedTypeTransaction embeds the transaction
+	EmbedTypeTransaction = fftypes.FFEnumValue("embedtype", "transaction")
+	// EmbedTypeEvents embeds the events that reference, or are correlated to, the item
+	EmbedTypeEvents = fftypes.FFEnumValue("embedtype", "events")
+)
+
+// Embedded holds the records referenced by an item of a listing, that were requested with the embed parameter
+type Embedded struct {
+	Data        DataArray    `ffstruct:"Embedded" json:"data,omitempty"`
+	Transaction *Transaction `ffstruct:"Embedded" json:"transaction,omitempty"`
+	Events      []*Event     `ffstruct:"Embedded" json:"events,omitempty"`
+}
+
+// MessageWithEmbedded is a message, with the referenced records that were requested with the embed parameter
+type MessageWithEmbedded struct {
+	Message
+	Embedded *Embedded `ffstruct:"MessageWithEmbedded" json:"embedded,omitempty"`
+}
+
+// TokenTransferWithEmbedded is a token transfer, with the referenced records that were requested with the embed parameter
+type TokenTransferWithEmbedded struct {
+	TokenTransfer
+	Embedded *Embedded `ffstruct:"TokenTransferWithEmbedded" json:"embedded,omitempty"`
+}
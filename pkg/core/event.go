@@ -29,12 +29,28 @@ var (
 	EventTypeMessageConfirmed = fftypes.FFEnumValue("eventtype", "message_confirmed")
 	// EventTypeMessageRejected occurs if a message is received and confirmed from a sequencing perspective, but is rejected as invalid (mismatch to schema, or duplicate system broadcast)
 	EventTypeMessageRejected = fftypes.FFEnumValue("eventtype", "message_rejected")
+	// EventTypeMessageQuarantined occurs if a message is received and confirmed from a sequencing perspective, but its data is invalid and the validation policy holds it for review
+	EventTypeMessageQuarantined = fftypes.FFEnumValue("eventtype", "message_quarantined")
+	// EventTypeMessageValidationWarning occurs alongside the confirmation of a message whose data is invalid, when the validation policy only warns
+	EventTypeMessageValidationWarning = fftypes.FFEnumValue("eventtype", "message_validation_warning")
 	// EventTypeDatatypeConfirmed occurs when a new datatype is ready for use (on the namespace of the datatype)
 	EventTypeDatatypeConfirmed = fftypes.FFEnumValue("eventtype", "datatype_confirmed")
 	// EventTypeIdentityConfirmed occurs when a new identity has been confirmed, as as result of a signed claim broadcast, and any associated claim verification
 	EventTypeIdentityConfirmed = fftypes.FFEnumValue("eventtype", "identity_confirmed")
 	// EventTypeIdentityUpdated occurs when an existing identity is update by the owner of that identity
 	EventTypeIdentityUpdated = fftypes.FFEnumValue("eventtype", "identity_updated")
+	// EventTypeVerifierRevoked occurs when one or more verifiers of an identity have been revoked
+	EventTypeVerifierRevoked = fftypes.FFEnumValue("eventtype", "verifier_revoked")
+	// EventTypeDelegationConfirmed occurs when a delegation of submission from one identity to another has been confirmed
+	EventTypeDelegationConfirmed = fftypes.FFEnumValue("eventtype", "delegation_confirmed")
+	// EventTypeRevokedSignerRejected is a security event that occurs when a message signed by a revoked verifier is rejected
+	EventTypeRevokedSignerRejected = fftypes.FFEnumValue("eventtype", "revoked_signer_rejected")
+	// EventTypeAggregationGapDetected occurs when a gap in the pins of a private message context could not be recovered by re-querying the blockchain, so the message is blocked
+	EventTypeAggregationGapDetected = fftypes.FFEnumValue("eventtype", "aggregation_gap_detected")
+	// EventTypePeerIdentityMismatch is a security event that occurs when a data exchange transfer arrives from a peer certificate that does not match the one pinned for the node
+	EventTypePeerIdentityMismatch = fftypes.FFEnumValue("eventtype", "peer_identity_mismatch")
+	// EventTypeDataIntegrityFailure is a security event that occurs when a batch or blob downloaded from shared storage does not match the hash pinned for it
+	EventTypeDataIntegrityFailure = fftypes.FFEnumValue("eventtype", "data_integrity_failure")
 	// EventTypePoolConfirmed occurs when a new token pool is ready for use
 	EventTypePoolConfirmed = fftypes.FFEnumValue("eventtype", "token_pool_confirmed")
 	// EventTypePoolOpFailed occurs when a token pool creation initiated by this node has failed (based on feedback from connector)
@@ -43,6 +59,8 @@ var (
 	EventTypeTransferConfirmed = fftypes.FFEnumValue("eventtype", "token_transfer_confirmed")
 	// EventTypeTransferOpFailed occurs when a token transfer submitted by this node has failed (based on feedback from connector)
 	EventTypeTransferOpFailed = fftypes.FFEnumValue("eventtype", "token_transfer_op_failed")
+	// EventTypeTransferDenied occurs when a mint, burn or transfer submitted by this node is denied by the transfer policy
+	EventTypeTransferDenied = fftypes.FFEnumValue("eventtype", "transfer_denied")
 	// EventTypeApprovalConfirmed occurs when a token approval has been confirmed
 	EventTypeApprovalConfirmed = fftypes.FFEnumValue("eventtype", "token_approval_confirmed")
 	// EventTypeApprovalOpFailed occurs when a token approval submitted by this node has failed (based on feedback from connector)
@@ -61,6 +79,10 @@ var (
 	EventTypeBlockchainContractDeployOpSucceeded = fftypes.FFEnumValue("eventtype", "blockchain_contract_deploy_op_succeeded")
 	// EventTypeBlockchainContractDeployOpFailed occurs when a contract deployment request has failed
 	EventTypeBlockchainContractDeployOpFailed = fftypes.FFEnumValue("eventtype", "blockchain_contract_deploy_op_failed")
+	// EventTypeOperationStalled occurs when an operation has remained pending for longer than the threshold configured for its type
+	EventTypeOperationStalled = fftypes.FFEnumValue("eventtype", "operation_stalled")
+	// EventTypeNodeConnectivityChanged occurs when the data exchange connectivity of a node in the network changes, such as a peer going offline
+	EventTypeNodeConnectivityChanged = fftypes.FFEnumValue("eventtype", "node_connectivity_changed")
 )
 
 // Event is an activity in the system, delivered reliably to applications, that indicates something has happened in the network
@@ -83,6 +105,7 @@ type EnrichedEvent struct {
 	ContractAPI       *ContractAPI     `ffstruct:"EnrichedEvent" json:"contractAPI,omitempty"`
 	ContractInterface *fftypes.FFI     `ffstruct:"EnrichedEvent" json:"contractInterface,omitempty"`
 	Datatype          *Datatype        `ffstruct:"EnrichedEvent" json:"datatype,omitempty"`
+	Delegation        *Delegation      `ffstruct:"EnrichedEvent" json:"delegation,omitempty"`
 	Identity          *Identity        `ffstruct:"EnrichedEvent" json:"identity,omitempty"`
 	Message           *Message         `ffstruct:"EnrichedEvent" json:"message,omitempty"`
 	TokenApproval     *TokenApproval   `ffstruct:"EnrichedEvent" json:"tokenApproval,omitempty"`
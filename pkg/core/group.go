@@ -125,3 +125,30 @@ func (group *Group) Topic() string {
 func (group *Group) SetBroadcastMessage(msgID *fftypes.UUID) {
 	group.Message = msgID
 }
+
+// PeerStatus is the connectivity of the local data exchange to the data exchange of another node
+type PeerStatus = fftypes.FFEnum
+
+var (
+	// PeerStatusOnline the data exchange is connected to the peer
+	PeerStatusOnline = fftypes.FFEnumValue("peerstatus", "online")
+	// PeerStatusOffline the peer is registered with the data exchange, but is not currently connected
+	PeerStatusOffline = fftypes.FFEnumValue("peerstatus", "offline")
+	// PeerStatusUnregistered the peer is not registered with the data exchange, so cannot be sent to
+	PeerStatusUnregistered = fftypes.FFEnumValue("peerstatus", "unregistered")
+	// PeerStatusUnknown the peer is registered, but the data exchange does not track whether it is connected
+	PeerStatusUnknown = fftypes.FFEnumValue("peerstatus", "unknown")
+)
+
+// ResolvedGroupMember is a member of a group, resolved to the org and node identities it references,
+// along with the data exchange endpoint of the node and its status
+type ResolvedGroupMember struct {
+	Identity   string             `ffstruct:"ResolvedGroupMember" json:"identity"`
+	Org        *Identity          `ffstruct:"ResolvedGroupMember" json:"org,omitempty"`
+	Node       *Identity          `ffstruct:"ResolvedGroupMember" json:"node,omitempty"`
+	Local      bool               `ffstruct:"ResolvedGroupMember" json:"local"`
+	PeerID     string             `ffstruct:"ResolvedGroupMember" json:"peerId,omitempty"`
+	Endpoint   fftypes.JSONObject `ffstruct:"ResolvedGroupMember" json:"endpoint,omitempty"`
+	PeerStatus PeerStatus         `ffstruct:"ResolvedGroupMember" json:"peerStatus,omitempty" ffenum:"peerstatus"`
+	Error      string             `ffstruct:"ResolvedGroupMember" json:"error,omitempty"`
+}
@@ -0,0 +1,77 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"hash"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+)
+
+// HashAlgorithm is the algorithm used to calculate the hashes of data and batches.
+// It is recorded alongside the hash, with an empty value meaning the SHA-256 default,
+// so records hashed before the algorithm was configurable continue to verify.
+type HashAlgorithm = fftypes.FFEnum
+
+var (
+	// HashAlgorithmSHA256 is the default SHA-256 algorithm
+	HashAlgorithmSHA256 = fftypes.FFEnumValue("hashalgorithm", "sha256")
+	// HashAlgorithmSHA3_256 is the SHA3-256 algorithm
+	HashAlgorithmSHA3_256 = fftypes.FFEnumValue("hashalgorithm", "sha3-256")
+	// HashAlgorithmBLAKE2b256 is the BLAKE2b-256 algorithm
+	HashAlgorithmBLAKE2b256 = fftypes.FFEnumValue("hashalgorithm", "blake2b-256")
+)
+
+// ParseHashAlgorithm validates a configured hash algorithm, returning the value that should be
+// recorded alongside hashes - which is empty for the SHA-256 default
+func ParseHashAlgorithm(ctx context.Context, algorithm string) (HashAlgorithm, error) {
+	parsed, err := fftypes.FFEnumParseString(ctx, "hashalgorithm", algorithm)
+	if err != nil || parsed == HashAlgorithmSHA256 {
+		return "", err
+	}
+	return parsed, nil
+}
+
+// NewHasher returns a new hash for the algorithm
+func NewHasher(ctx context.Context, algorithm HashAlgorithm) (hash.Hash, error) {
+	switch algorithm {
+	case "", HashAlgorithmSHA256:
+		return sha256.New(), nil
+	case HashAlgorithmSHA3_256:
+		return sha3.New256(), nil
+	case HashAlgorithmBLAKE2b256:
+		return blake2b.New256(nil) // only errors for keys longer than 64 bytes
+	default:
+		return nil, i18n.NewError(ctx, coremsgs.MsgHashAlgorithmUnsupported, algorithm)
+	}
+}
+
+// HashBytes calculates the hash of the supplied bytes with the algorithm
+func HashBytes(ctx context.Context, algorithm HashAlgorithm, b []byte) (*fftypes.Bytes32, error) {
+	h, err := NewHasher(ctx, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(b)
+	return fftypes.HashResult(h), nil
+}
@@ -0,0 +1,59 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHashAlgorithm(t *testing.T) {
+	a, err := ParseHashAlgorithm(context.Background(), "sha256")
+	assert.NoError(t, err)
+	assert.Empty(t, a)
+
+	a, err = ParseHashAlgorithm(context.Background(), "SHA3-256")
+	assert.NoError(t, err)
+	assert.Equal(t, HashAlgorithmSHA3_256, a)
+
+	a, err = ParseHashAlgorithm(context.Background(), "blake2b-256")
+	assert.NoError(t, err)
+	assert.Equal(t, HashAlgorithmBLAKE2b256, a)
+
+	_, err = ParseHashAlgorithm(context.Background(), "md5")
+	assert.Regexp(t, "FF00172", err)
+}
+
+func TestHashBytes(t *testing.T) {
+	for algorithm, expected := range map[HashAlgorithm]string{
+		"":                      "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		HashAlgorithmSHA256:     "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		HashAlgorithmSHA3_256:   "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+		HashAlgorithmBLAKE2b256: "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319",
+	} {
+		hash, err := HashBytes(context.Background(), algorithm, []byte("abc"))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, hash.String(), algorithm)
+	}
+}
+
+func TestHashBytesUnsupported(t *testing.T) {
+	_, err := HashBytes(context.Background(), "md5", []byte("abc"))
+	assert.Regexp(t, "FF10511", err)
+}
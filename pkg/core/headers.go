@@ -20,3 +20,6 @@ const (
 	HTTPHeadersBlobHashSHA256 = "x-ff-blob-hash-sha256"
 	HTTPHeadersBlobSize       = "x-ff-blob-size"
 )
+
+// MIMETypeProtobuf is the content type for the binary encoding of protobuf data, in content negotiation on the data APIs
+const MIMETypeProtobuf = "application/x-protobuf"
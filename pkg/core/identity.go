@@ -19,6 +19,7 @@ package core
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -72,9 +73,10 @@ type IdentityProfile struct {
 type Identity struct {
 	IdentityBase
 	IdentityProfile
-	Messages IdentityMessages `ffstruct:"Identity" json:"messages,omitempty" ffexcludeinput:"true"`
-	Created  *fftypes.FFTime  `ffstruct:"Identity" json:"created,omitempty" ffexcludeinput:"true"`
-	Updated  *fftypes.FFTime  `ffstruct:"Identity" json:"updated,omitempty"`
+	Messages        IdentityMessages `ffstruct:"Identity" json:"messages,omitempty" ffexcludeinput:"true"`
+	VerifiedSubject string           `ffstruct:"Identity" json:"verifiedSubject,omitempty" ffexcludeinput:"true"`
+	Created         *fftypes.FFTime  `ffstruct:"Identity" json:"created,omitempty" ffexcludeinput:"true"`
+	Updated         *fftypes.FFTime  `ffstruct:"Identity" json:"updated,omitempty"`
 }
 
 // IdentityWithVerifiers has an embedded array of verifiers
@@ -87,10 +89,13 @@ type IdentityWithVerifiers struct {
 // The blockchain key that will be used to establish the claim for the identity
 // needs to be provided.
 type IdentityCreateDTO struct {
-	Name   string       `ffstruct:"Identity" json:"name"`
-	Type   IdentityType `ffstruct:"Identity" json:"type,omitempty"`
-	Parent string       `ffstruct:"IdentityCreateDTO" json:"parent,omitempty"` // can be a DID for resolution, or the UUID directly
-	Key    string       `ffstruct:"IdentityCreateDTO" json:"key,omitempty"`
+	Name                 string        `ffstruct:"Identity" json:"name"`
+	Type                 IdentityType  `ffstruct:"Identity" json:"type,omitempty"`
+	Parent               string        `ffstruct:"IdentityCreateDTO" json:"parent,omitempty"` // can be a DID for resolution, or the UUID directly
+	Key                  string        `ffstruct:"IdentityCreateDTO" json:"key,omitempty"`
+	Certificate          string        `ffstruct:"IdentityCreateDTO" json:"certificate,omitempty"`
+	CertificateSignature string        `ffstruct:"IdentityCreateDTO" json:"certificateSignature,omitempty"`
+	Signature            *KeySignature `ffstruct:"IdentityCreateDTO" json:"signature,omitempty"`
 	IdentityProfile
 }
 
@@ -112,7 +117,34 @@ type SignerRef struct {
 // from the parent identity to be published (on the same topic) before the identity is considered valid
 // and is stored as a confirmed identity.
 type IdentityClaim struct {
-	Identity *Identity `ffstruct:"IdentityClaim" json:"identity"`
+	Identity             *Identity     `ffstruct:"IdentityClaim" json:"identity"`
+	Certificate          string        `ffstruct:"IdentityClaim" json:"certificate,omitempty"`
+	CertificateSignature string        `ffstruct:"IdentityClaim" json:"certificateSignature,omitempty"`
+	Signature            *KeySignature `ffstruct:"IdentityClaim" json:"signature,omitempty"`
+}
+
+// KeySignature is a signature made with a blockchain signing key, using the signature scheme native to the blockchain.
+// For Ethereum this is a hex encoded personal_sign signature, and for Fabric a base64 encoded ASN.1 ECDSA
+// signature over the SHA-256 hash of the payload, accompanied by the PEM encoded X.509 certificate of the signer.
+type KeySignature struct {
+	Value       string `ffstruct:"KeySignature" json:"value"`
+	Certificate string `ffstruct:"KeySignature" json:"certificate,omitempty"`
+}
+
+type identityClaimSigningPayload struct {
+	DID    string        `json:"did"`
+	Parent *fftypes.UUID `json:"parent,omitempty"`
+}
+
+// SigningPayload returns the bytes that a claim signature is made over. This is the compact JSON
+// {"did":"<did>","parent":"<uuid>"} of the claimed identity, with the parent omitted for root identities.
+// Both are known to the submitter before the claim is broadcast.
+func (ic *IdentityClaim) SigningPayload() []byte {
+	b, _ := json.Marshal(&identityClaimSigningPayload{
+		DID:    ic.Identity.DID,
+		Parent: ic.Identity.Parent,
+	})
+	return b
 }
 
 // IdentityVerification is the data payload used in message to broadcast a verification of a child identity.
@@ -149,6 +149,17 @@ func TestIdentityValidationCustom(t *testing.T) {
 
 }
 
+func TestIdentityClaimSigningPayload(t *testing.T) {
+	parent := fftypes.MustParseUUID("ad2a8c9d-6d8b-4d9c-9a5e-2f3b8f1c1d2e")
+	claim := &IdentityClaim{
+		Identity: &Identity{IdentityBase: IdentityBase{DID: "did:firefly:custom1", Parent: parent}},
+	}
+	assert.Equal(t, `{"did":"did:firefly:custom1","parent":"ad2a8c9d-6d8b-4d9c-9a5e-2f3b8f1c1d2e"}`, string(claim.SigningPayload()))
+
+	claim.Identity.Parent = nil
+	assert.Equal(t, `{"did":"did:firefly:custom1"}`, string(claim.SigningPayload()))
+}
+
 func TestIdentityCompare(t *testing.T) {
 
 	ctx := context.Background()
@@ -0,0 +1,48 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// InboundEvent is an event received by a plugin from its connector, held in the inbound event queue
+// until it has been processed. The plugin and namespace identify the connection it was received on.
+type InboundEvent struct {
+	Sequence  int64           `json:"sequence"`
+	Plugin    string          `json:"plugin"`
+	Namespace string          `json:"namespace"`
+	Payload   string          `json:"payload"`
+	Created   *fftypes.FFTime `json:"created"`
+}
+
+// InboundEventHandler processes an event taken from an inbound event queue. Events are removed from
+// the queue only once the handler returns without error, so an error means the event is retried.
+type InboundEventHandler func(ctx context.Context, payload []byte) error
+
+// InboundEventQueue is a bounded, persistent queue between the event loop of a plugin and the processing
+// of its events, so the plugin can acknowledge events to its connector as soon as they are stored, without
+// waiting for them to be processed. The namespace is empty for connections that are shared by all namespaces.
+type InboundEventQueue interface {
+	// Enqueue stores an event received on a connection, blocking while the queue for that connection is full
+	Enqueue(ctx context.Context, namespace string, payload []byte) error
+
+	// Consume delivers the events queued for a connection to the handler in order, until the context is done
+	Consume(ctx context.Context, namespace string, handler InboundEventHandler)
+}
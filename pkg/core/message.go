@@ -42,6 +42,8 @@ var (
 	MessageTypePrivate = fftypes.FFEnumValue("messagetype", "private")
 	// MessageTypeGroupInit is a special private message that contains the definition of the group
 	MessageTypeGroupInit = fftypes.FFEnumValue("messagetype", "groupinit")
+	// MessageTypeBroadcastPinOnly is a broadcast message where only the hash of the batch is pinned, and the payload is distributed out-of-band
+	MessageTypeBroadcastPinOnly = fftypes.FFEnumValue("messagetype", "broadcast_pinonly")
 	// MessageTypeDeprecatedTransferBroadcast is deprecated - use MessageTypeBroadcast (and refer to TxParent.Type)
 	MessageTypeDeprecatedTransferBroadcast = fftypes.FFEnumValue("messagetype", "transfer_broadcast")
 	// MessageTypeDeprecatedTransferPrivate is deprecated - use MessageTypePrivate (and refer to TxParent.Type)
@@ -70,6 +72,8 @@ var (
 	MessageStateRejected = fftypes.FFEnumValue("messagestate", "rejected")
 	// MessageStateCancelled is a message that was cancelled without being sent
 	MessageStateCancelled = fftypes.FFEnumValue("messagestate", "cancelled")
+	// MessageStateQuarantined is a message that has completed confirmation, but whose data failed validation and is held for review
+	MessageStateQuarantined = fftypes.FFEnumValue("messagestate", "quarantined")
 )
 
 // MessageHeader contains all fields that contribute to the hash
@@ -104,6 +108,7 @@ type Message struct {
 	Data           DataRefs              `ffstruct:"Message" json:"data" ffexcludeinput:"true"`
 	Pins           fftypes.FFStringArray `ffstruct:"Message" json:"pins,omitempty" ffexcludeinput:"true"`
 	IdempotencyKey IdempotencyKey        `ffstruct:"Message" json:"idempotencyKey,omitempty"`
+	Callback       string                `ffstruct:"Message" json:"callback,omitempty"`
 	Sequence       int64                 `ffstruct:"Message" json:"-"` // Local database sequence used internally for batch assembly
 }
 
@@ -269,6 +274,21 @@ func (m *Message) Verify(ctx context.Context) error {
 	return nil
 }
 
+// ReviewAction is the decision taken on a quarantined message
+type ReviewAction = fftypes.FFEnum
+
+var (
+	// ReviewActionAccept confirms a quarantined message, despite its invalid data
+	ReviewActionAccept = fftypes.FFEnumValue("reviewaction", "accept")
+	// ReviewActionReject rejects a quarantined message
+	ReviewActionReject = fftypes.FFEnumValue("reviewaction", "reject")
+)
+
+// QuarantineReview is the outcome of reviewing a quarantined message
+type QuarantineReview struct {
+	Action ReviewAction `ffstruct:"QuarantineReview" json:"action" ffenum:"reviewaction"`
+}
+
 func (m *Message) LocalSequence() int64 {
 	return m.Sequence
 }
@@ -288,6 +308,9 @@ const (
 
 	// ActionWait the message is still awaiting further pieces for aggregation and should be held in pending state
 	ActionWait
+
+	// ActionQuarantine the message was successfully processed, but its data was invalid and it should be held for review
+	ActionQuarantine
 )
 
 func (dma MessageAction) String() string {
@@ -300,6 +323,8 @@ func (dma MessageAction) String() string {
 		return "retry"
 	case ActionWait:
 		return "wait"
+	case ActionQuarantine:
+		return "quarantine"
 	default:
 		return "unknown"
 	}
@@ -0,0 +1,110 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
+
+// MessageProofFormatV1 identifies the layout of a message proof, so verifiers can reject layouts they do not understand
+const MessageProofFormatV1 = "firefly-message-proof-v1"
+
+// MessageProof is a self-contained bundle that proves a message was included in a batch pinned to the blockchain,
+// which can be checked offline with Verify (or the equivalent steps in another language) by a party that trusts
+// only the blockchain
+type MessageProof struct {
+	Format      string             `ffstruct:"MessageProof" json:"format"`
+	Namespace   string             `ffstruct:"MessageProof" json:"namespace"`
+	Message     *Message           `ffstruct:"MessageProof" json:"message"`
+	Batch       *MessageProofBatch `ffstruct:"MessageProof" json:"batch"`
+	Pins        []*Pin             `ffstruct:"MessageProof" json:"pins"`
+	Transaction *PinTransaction    `ffstruct:"MessageProof" json:"tx"`
+	Anchor      *BatchAnchorProof  `ffstruct:"MessageProof" json:"anchor,omitempty"`
+}
+
+// MessageProofBatch is the batch that contained the message, with the exact manifest that was hashed to produce the batch hash
+type MessageProofBatch struct {
+	ID       *fftypes.UUID    `ffstruct:"MessageProofBatch" json:"id"`
+	Hash     *fftypes.Bytes32 `ffstruct:"MessageProofBatch" json:"hash"`
+	Manifest string           `ffstruct:"MessageProofBatch" json:"manifest"`
+}
+
+// Verify checks every link in the chain of hashes from the message up to the pins, and the anchor root if present.
+// It does not check the blockchain itself - the verifier must confirm that the blockchain transaction recorded the
+// batch hash (or the anchor root), signed by the key of the pins.
+func (mp *MessageProof) Verify(ctx context.Context) error {
+	if mp.Format != MessageProofFormatV1 {
+		return i18n.NewError(ctx, coremsgs.MsgProofFormatUnsupported, mp.Format)
+	}
+	if mp.Message == nil {
+		return i18n.NewError(ctx, i18n.MsgMissingRequiredField, "message")
+	}
+	if mp.Batch == nil || mp.Batch.Hash == nil {
+		return i18n.NewError(ctx, i18n.MsgMissingRequiredField, "batch.hash")
+	}
+
+	// The message hash covers its header, and the IDs and hashes of its data
+	if err := mp.Message.Verify(ctx); err != nil {
+		return err
+	}
+
+	// The batch hash is the hash of the manifest, which lists the IDs and hashes of the messages
+	var manifest BatchManifest
+	if err := json.Unmarshal([]byte(mp.Batch.Manifest), &manifest); err != nil {
+		return i18n.WrapError(ctx, err, i18n.MsgJSONObjectParseFailed, "batch.manifest")
+	}
+	manifestHash, err := HashBytes(ctx, manifest.HashAlgorithm, []byte(mp.Batch.Manifest))
+	if err != nil {
+		return err
+	}
+	if !manifestHash.Equals(mp.Batch.Hash) {
+		return i18n.NewError(ctx, coremsgs.MsgProofBatchHashMismatch, manifestHash, mp.Batch.Hash)
+	}
+	found := false
+	for _, entry := range manifest.Messages {
+		if entry.ID.Equals(mp.Message.Header.ID) && entry.Hash.Equals(mp.Message.Hash) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return i18n.NewError(ctx, coremsgs.MsgProofMessageNotInBatch, mp.Message.Header.ID, mp.Message.Hash)
+	}
+
+	// Each pin of the message records the batch hash, and must be signed by the key of the message
+	if len(mp.Pins) == 0 {
+		return i18n.NewError(ctx, coremsgs.MsgMessageNotPinned, mp.Message.Header.ID)
+	}
+	for _, pin := range mp.Pins {
+		if !pin.BatchHash.Equals(mp.Batch.Hash) || pin.Signer != mp.Message.Header.Key {
+			return i18n.NewError(ctx, coremsgs.MsgProofPinMismatch, pin.Index, pin.BatchHash, pin.Signer)
+		}
+	}
+
+	if mp.Anchor != nil {
+		root := mp.Anchor.CalculateRoot(mp.Batch.Hash)
+		if !root.Equals(mp.Anchor.Root) {
+			return i18n.NewError(ctx, coremsgs.MsgProofAnchorMismatch, mp.Batch.Hash, root, mp.Anchor.Root)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMessageProof(t *testing.T) *MessageProof {
+	ctx := context.Background()
+	msg := &Message{
+		Header: MessageHeader{
+			Type: MessageTypeBroadcast,
+			SignerRef: SignerRef{
+				Author: "did:firefly:org/org1",
+				Key:    "0x12345",
+			},
+		},
+		Data: DataRefs{{ID: fftypes.NewUUID(), Hash: fftypes.NewRandB32()}},
+	}
+	err := msg.Seal(ctx)
+	assert.NoError(t, err)
+
+	batch := &Batch{
+		BatchHeader: BatchHeader{ID: fftypes.NewUUID()},
+		Payload: BatchPayload{
+			TX:       TransactionRef{ID: fftypes.NewUUID(), Type: TransactionTypeBatchPin},
+			Messages: []*Message{msg},
+		},
+	}
+	persisted, manifest := batch.Confirmed()
+	persisted.Hash, err = manifest.Hash(ctx)
+	assert.NoError(t, err)
+
+	return &MessageProof{
+		Format:  MessageProofFormatV1,
+		Message: msg,
+		Batch: &MessageProofBatch{
+			ID:       persisted.ID,
+			Hash:     persisted.Hash,
+			Manifest: persisted.Manifest.String(),
+		},
+		Pins: []*Pin{
+			{Batch: persisted.ID, BatchHash: persisted.Hash, Signer: "0x12345"},
+		},
+		Transaction: &PinTransaction{ID: batch.Payload.TX.ID},
+	}
+}
+
+func TestMessageProofVerifyOk(t *testing.T) {
+	proof := newTestMessageProof(t)
+	err := proof.Verify(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestMessageProofVerifyAnchorOk(t *testing.T) {
+	proof := newTestMessageProof(t)
+	sibling := fftypes.NewRandB32()
+	h := sha256.New()
+	h.Write(proof.Batch.Hash[:])
+	h.Write(sibling[:])
+	proof.Anchor = &BatchAnchorProof{
+		BatchHash: proof.Batch.Hash,
+		Root:      fftypes.HashResult(h),
+		Proof:     []*MerkleProofStep{{Hash: sibling, Position: MerkleSiblingRight}},
+	}
+	err := proof.Verify(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestMessageProofVerifyAnchorMismatch(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Anchor = &BatchAnchorProof{
+		BatchHash: proof.Batch.Hash,
+		Root:      fftypes.NewRandB32(),
+		Proof:     []*MerkleProofStep{{Hash: fftypes.NewRandB32(), Position: MerkleSiblingLeft}},
+	}
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10583", err)
+}
+
+func TestMessageProofVerifyBadFormat(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Format = "unknown"
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10579", err)
+}
+
+func TestMessageProofVerifyMissingMessage(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Message = nil
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF00112.*message", err)
+}
+
+func TestMessageProofVerifyMissingBatch(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Batch = nil
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF00112.*batch.hash", err)
+}
+
+func TestMessageProofVerifyMessageTampered(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Message.Header.Author = "did:firefly:org/org2"
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF00132", err)
+}
+
+func TestMessageProofVerifyBadManifest(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Batch.Manifest = "!json"
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF00127", err)
+}
+
+func TestMessageProofVerifyBadHashAlgorithm(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Batch.Manifest = `{"hashAlgorithm":"md5"}`
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10511", err)
+}
+
+func TestMessageProofVerifyBatchHashMismatch(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Batch.Hash = fftypes.NewRandB32()
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10580", err)
+}
+
+func TestMessageProofVerifyMessageNotInBatch(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Batch.Manifest = `{"messages":[]}`
+	proof.Batch.Hash, _ = HashBytes(context.Background(), "", []byte(proof.Batch.Manifest))
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10581", err)
+}
+
+func TestMessageProofVerifyNoPins(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Pins = nil
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10578", err)
+}
+
+func TestMessageProofVerifyPinWrongSigner(t *testing.T) {
+	proof := newTestMessageProof(t)
+	proof.Pins[0].Signer = "0x67890"
+	err := proof.Verify(context.Background())
+	assert.Regexp(t, "FF10582", err)
+}
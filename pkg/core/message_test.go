@@ -265,5 +265,6 @@ func TestMessageActions(t *testing.T) {
 	assert.Equal(t, "confirm", ActionConfirm.String())
 	assert.Equal(t, "retry", ActionRetry.String())
 	assert.Equal(t, "wait", ActionWait.String())
+	assert.Equal(t, "quarantine", ActionQuarantine.String())
 	assert.Equal(t, "unknown", MessageAction(99999).String())
 }
@@ -43,13 +43,15 @@ type NamespaceWithInitStatus struct {
 	InitializationError string `ffstruct:"NamespaceWithInitStatus" json:"initializationError,omitempty"`
 }
 
-// MultipartyContracts represent the currently active and any terminated FireFly multiparty contract(s)
+// MultipartyContracts represent the currently active, any pending, and any terminated FireFly multiparty contract(s)
 type MultipartyContracts struct {
 	Active     *MultipartyContract   `ffstruct:"MultipartyContracts" json:"active"`
+	Pending    *MultipartyContract   `ffstruct:"MultipartyContracts" json:"pending,omitempty"`
 	Terminated []*MultipartyContract `ffstruct:"MultipartyContracts" json:"terminated,omitempty"`
 }
 type MultipartyContractsWithActiveStatus struct {
 	Active     *MultipartyContractWithStatus `ffstruct:"MultipartyContracts" json:"active"`
+	Pending    *MultipartyContract           `ffstruct:"MultipartyContracts" json:"pending,omitempty"`
 	Terminated []*MultipartyContract         `ffstruct:"MultipartyContracts" json:"terminated,omitempty"`
 }
 
@@ -82,6 +84,8 @@ type MultipartyContractInfo struct {
 	Subscription string `ffstruct:"MultipartyContract" json:"subscription,omitempty"`
 	FinalEvent   string `ffstruct:"MultipartyContract" json:"finalEvent,omitempty"`
 	Version      int    `ffstruct:"MultipartyContract" json:"version,omitempty"`
+	// ActivationBlock is set on contracts switched to by a contract migration, which become active at the first event from this block onwards
+	ActivationBlock int64 `ffstruct:"MultipartyContract" json:"activationBlock,omitempty"`
 }
 
 // NetworkActionType is a type of action to perform
@@ -96,6 +100,23 @@ type NetworkAction struct {
 	Type NetworkActionType `ffstruct:"NetworkAction" json:"type" ffenum:"networkactiontype"`
 }
 
+// ContractMigration is a definition broadcast by a root org, to switch all members of the network from the active
+// FireFly multiparty contract to the next one configured, at an agreed block number
+type ContractMigration struct {
+	ID          *fftypes.UUID `ffstruct:"ContractMigration" json:"id,omitempty" ffexcludeinput:"true"`
+	Index       int           `ffstruct:"ContractMigration" json:"index" ffexcludeinput:"true"`
+	BlockNumber int64         `ffstruct:"ContractMigration" json:"blockNumber"`
+	Message     *fftypes.UUID `ffstruct:"ContractMigration" json:"message,omitempty" ffexcludeinput:"true"`
+}
+
+func (cm *ContractMigration) Topic() string {
+	return fftypes.TypeNamespaceNameTopicHash("contractmigration", "", "")
+}
+
+func (cm *ContractMigration) SetBroadcastMessage(msgID *fftypes.UUID) {
+	cm.Message = msgID
+}
+
 // Scan implements sql.Scanner
 func (fc *MultipartyContracts) Scan(src interface{}) error {
 	switch src := src.(type) {
@@ -74,3 +74,12 @@ func TestMultipartyContractsDatabaseSerialization(t *testing.T) {
 	err = contracts2.Scan(false)
 	assert.Regexp(t, "FF00105", err)
 }
+
+func TestContractMigrationDefinition(t *testing.T) {
+	cm := &ContractMigration{BlockNumber: 100}
+	assert.Equal(t, fftypes.TypeNamespaceNameTopicHash("contractmigration", "", ""), cm.Topic())
+
+	msgID := fftypes.NewUUID()
+	cm.SetBroadcastMessage(msgID)
+	assert.Equal(t, msgID, cm.Message)
+}
@@ -80,3 +80,13 @@ func (node *DeprecatedNode) Topic() string {
 func (node *DeprecatedNode) SetBroadcastMessage(msgID *fftypes.UUID) {
 	node.Migrated().SetBroadcastMessage(msgID)
 }
+
+// NodeStatus is the data exchange connectivity of a node in the network, as seen from the local node
+type NodeStatus struct {
+	ID         *fftypes.UUID      `ffstruct:"NodeStatus" json:"id"`
+	Name       string             `ffstruct:"NodeStatus" json:"name"`
+	Local      bool               `ffstruct:"NodeStatus" json:"local"`
+	PeerID     string             `ffstruct:"NodeStatus" json:"peerId,omitempty"`
+	Endpoint   fftypes.JSONObject `ffstruct:"NodeStatus" json:"endpoint,omitempty"`
+	PeerStatus PeerStatus         `ffstruct:"NodeStatus" json:"peerStatus,omitempty" ffenum:"peerstatus"`
+}
@@ -33,12 +33,20 @@ type OpType = fftypes.FFEnum
 var (
 	// OpTypeBlockchainPinBatch is a blockchain transaction to pin a batch
 	OpTypeBlockchainPinBatch = fftypes.FFEnumValue("optype", "blockchain_pin_batch")
+	// OpTypeBlockchainAnchorBatch is a blockchain transaction to pin a batch a second time, to the anchor chain of a namespace
+	OpTypeBlockchainAnchorBatch = fftypes.FFEnumValue("optype", "blockchain_anchor_batch")
+	// OpTypeBlockchainAnchorDigest is a blockchain transaction to pin the Merkle root of a window of batches, to the anchor chain of a namespace
+	OpTypeBlockchainAnchorDigest = fftypes.FFEnumValue("optype", "blockchain_anchor_digest")
 	// OpTypeBlockchainNetworkAction is an administrative action on a multiparty blockchain network
 	OpTypeBlockchainNetworkAction = fftypes.FFEnumValue("optype", "blockchain_network_action")
 	// OpTypeBlockchainContractDeploy is a smart contract deploy
 	OpTypeBlockchainContractDeploy = fftypes.FFEnumValue("optype", "blockchain_deploy")
+	// OpTypeBlockchainContractDeployABI is a smart contract deploy, from an ABI stored in the blockchain connector
+	OpTypeBlockchainContractDeployABI = fftypes.FFEnumValue("optype", "blockchain_deploy_abi")
 	// OpTypeBlockchainInvoke is a smart contract invoke
 	OpTypeBlockchainInvoke = fftypes.FFEnumValue("optype", "blockchain_invoke")
+	// OpTypeBlockchainInvokeBatch is a set of smart contract invocations submitted via an on-chain multicall aggregator
+	OpTypeBlockchainInvokeBatch = fftypes.FFEnumValue("optype", "blockchain_invoke_batch")
 	// OpTypeSharedStorageUploadBatch is a shared storage operation to upload broadcast data
 	OpTypeSharedStorageUploadBatch = fftypes.FFEnumValue("optype", "sharedstorage_upload_batch")
 	// OpTypeSharedStorageUploadBlob is a shared storage operation to upload blob data
@@ -65,9 +73,11 @@ var (
 
 func (op *Operation) IsBlockchainOperation() bool {
 	return op.Type == OpTypeBlockchainInvoke ||
+		op.Type == OpTypeBlockchainInvokeBatch ||
 		op.Type == OpTypeBlockchainNetworkAction ||
 		op.Type == OpTypeBlockchainPinBatch ||
-		op.Type == OpTypeBlockchainContractDeploy
+		op.Type == OpTypeBlockchainContractDeploy ||
+		op.Type == OpTypeBlockchainContractDeployABI
 }
 
 func (op *Operation) IsTokenOperation() bool {
@@ -102,6 +112,9 @@ func (op *Operation) DeepCopy() *Operation {
 		retryCopy := *op.Retry
 		cop.Retry = &retryCopy
 	}
+	if op.Tags != nil {
+		cop.Tags = append(fftypes.FFStringArray{}, op.Tags...)
+	}
 	if op.Input != nil {
 		cop.Input = deepCopyMap(op.Input)
 	}
@@ -184,20 +197,25 @@ func NewOperation(plugin Named, namespace string, tx *fftypes.UUID, opType OpTyp
 
 // Operation is a description of an action performed as part of a transaction submitted by this node
 type Operation struct {
-	ID          *fftypes.UUID      `ffstruct:"Operation" json:"id" ffexcludeinput:"true"`
-	Namespace   string             `ffstruct:"Operation" json:"namespace" ffexcludeinput:"true"`
-	Transaction *fftypes.UUID      `ffstruct:"Operation" json:"tx" ffexcludeinput:"true"`
-	Type        OpType             `ffstruct:"Operation" json:"type" ffenum:"optype" ffexcludeinput:"true"`
-	Status      OpStatus           `ffstruct:"Operation" json:"status"`
-	Plugin      string             `ffstruct:"Operation" json:"plugin" ffexcludeinput:"true"`
-	Input       fftypes.JSONObject `ffstruct:"Operation" json:"input,omitempty" ffexcludeinput:"true"`
-	Output      fftypes.JSONObject `ffstruct:"Operation" json:"output,omitempty"`
-	Error       string             `ffstruct:"Operation" json:"error,omitempty"`
-	Created     *fftypes.FFTime    `ffstruct:"Operation" json:"created,omitempty" ffexcludeinput:"true"`
-	Updated     *fftypes.FFTime    `ffstruct:"Operation" json:"updated,omitempty" ffexcludeinput:"true"`
-	Retry       *fftypes.UUID      `ffstruct:"Operation" json:"retry,omitempty" ffexcludeinput:"true"`
+	ID          *fftypes.UUID         `ffstruct:"Operation" json:"id" ffexcludeinput:"true"`
+	Namespace   string                `ffstruct:"Operation" json:"namespace" ffexcludeinput:"true"`
+	Transaction *fftypes.UUID         `ffstruct:"Operation" json:"tx" ffexcludeinput:"true"`
+	Type        OpType                `ffstruct:"Operation" json:"type" ffenum:"optype" ffexcludeinput:"true"`
+	Status      OpStatus              `ffstruct:"Operation" json:"status"`
+	Plugin      string                `ffstruct:"Operation" json:"plugin" ffexcludeinput:"true"`
+	Input       fftypes.JSONObject    `ffstruct:"Operation" json:"input,omitempty" ffexcludeinput:"true"`
+	Output      fftypes.JSONObject    `ffstruct:"Operation" json:"output,omitempty"`
+	Error       string                `ffstruct:"Operation" json:"error,omitempty"`
+	Created     *fftypes.FFTime       `ffstruct:"Operation" json:"created,omitempty" ffexcludeinput:"true"`
+	Updated     *fftypes.FFTime       `ffstruct:"Operation" json:"updated,omitempty" ffexcludeinput:"true"`
+	Retry       *fftypes.UUID         `ffstruct:"Operation" json:"retry,omitempty" ffexcludeinput:"true"`
+	Tags        fftypes.FFStringArray `ffstruct:"Operation" json:"tags,omitempty" ffexcludeinput:"true"`
 }
 
+// OperationOverflowKey is the only key of an operation input or output that was too large to store on the operation.
+// It references the data record that holds the full JSON, along with its hash and size
+const OperationOverflowKey = "overflow"
+
 // OperationUpdateDTO is the subset of fields on an operation that are mutable, via the SPI
 type OperationUpdateDTO struct {
 	Status OpStatus           `ffstruct:"Operation" json:"status"`
@@ -205,6 +223,12 @@ type OperationUpdateDTO struct {
 	Error  *string            `ffstruct:"Operation" json:"error,omitempty"`
 }
 
+// OperationAttachTX attaches a transaction that was submitted to the blockchain outside of FireFly, such as one
+// signed in a wallet, to an operation. The operation completes when the receipt of the transaction is available.
+type OperationAttachTX struct {
+	TransactionHash string `ffstruct:"OperationAttachTX" json:"transactionHash"`
+}
+
 // PreparedOperation is an operation that has gathered all the raw data ready to send to a plugin
 // It is never stored, but it should always be possible for the owning Manager to generate a
 // PreparedOperation from an Operation. Data is defined by the Manager, but should be JSON-serializable
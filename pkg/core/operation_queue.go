@@ -0,0 +1,32 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// OperationQueueEntry records that an operation is owed a submission to its plugin. It is written in
+// the same database transaction as the operation, and removed once the plugin has been called, so an
+// entry that remains after a restart identifies an operation that never reached its connector.
+type OperationQueueEntry struct {
+	Sequence  int64           `json:"sequence"`
+	ID        *fftypes.UUID   `json:"id"`
+	Namespace string          `json:"namespace"`
+	Type      OpType          `json:"type"`
+	Created   *fftypes.FFTime `json:"created"`
+}
@@ -112,6 +112,7 @@ func TestOperationDeepCopy(t *testing.T) {
 		Created:     fftypes.Now(),
 		Updated:     fftypes.Now(),
 		Retry:       fftypes.NewUUID(),
+		Tags:        fftypes.FFStringArray{"invoice-123"},
 	}
 
 	copyOp := op.DeepCopy()
@@ -130,6 +131,7 @@ func TestOperationDeepCopy(t *testing.T) {
 	assert.Equal(t, op.Created, copyOp.Created)
 	assert.Equal(t, op.Updated, copyOp.Updated)
 	assert.Equal(t, op.Retry, copyOp.Retry)
+	assert.Equal(t, op.Tags, copyOp.Tags)
 
 	// Modify the original and ensure the copy is not modified
 	*op.ID = *fftypes.NewUUID()
@@ -138,6 +140,9 @@ func TestOperationDeepCopy(t *testing.T) {
 	*op.Created = *fftypes.Now()
 	assert.NotEqual(t, copyOp.Created, op.Created)
 
+	op.Tags[0] = "invoice-456"
+	assert.Equal(t, "invoice-123", copyOp.Tags[0])
+
 	// Ensure the copy is a deep copy by comparing the pointers of the fields
 	assert.NotSame(t, copyOp.ID, op.ID)
 	assert.NotSame(t, copyOp.Created, op.Created)
@@ -153,7 +158,7 @@ func TestOperationDeepCopy(t *testing.T) {
 
 	// Ensure no new fields are added to the Operation struct
 	// If a new field is added, this test will fail and the DeepCopy function should be updated
-	assert.Equal(t, 12, reflect.TypeOf(Operation{}).NumField())
+	assert.Equal(t, 13, reflect.TypeOf(Operation{}).NumField())
 }
 func TestParseNamespacedOpID(t *testing.T) {
 
@@ -0,0 +1,75 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "sync"
+
+// PluginConnectionListener is notified when a long-lived connection from a plugin to its runtime
+// (such as the websocket to a connector) is lost, and again when it is restored.
+// The namespace is empty for connections that are shared by all namespaces.
+type PluginConnectionListener interface {
+	PluginConnectionChanged(namespace string, connected bool)
+}
+
+// PluginConnectionTracker tracks the state of each connection held by a plugin, and notifies the
+// listener when a connection that was established is lost, or a lost connection is restored.
+// Establishing the initial connection, and repeated failures to reconnect, are not reported.
+type PluginConnectionTracker struct {
+	mux       sync.Mutex
+	listener  PluginConnectionListener
+	connected map[string]bool
+	lost      map[string]bool
+}
+
+func (t *PluginConnectionTracker) SetListener(listener PluginConnectionListener) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.listener = listener
+}
+
+// Update records the current state of the connection for a namespace
+func (t *PluginConnectionTracker) Update(namespace string, connected bool) {
+	t.mux.Lock()
+	if t.connected == nil {
+		t.connected = make(map[string]bool)
+		t.lost = make(map[string]bool)
+	}
+	notify := false
+	switch {
+	case !connected && t.connected[namespace]:
+		t.lost[namespace] = true
+		notify = true
+	case connected && t.lost[namespace]:
+		delete(t.lost, namespace)
+		notify = true
+	}
+	t.connected[namespace] = connected
+	listener := t.listener
+	t.mux.Unlock()
+
+	if notify && listener != nil {
+		listener.PluginConnectionChanged(namespace, connected)
+	}
+}
+
+// Remove forgets the connection for a namespace, so that a later connection is treated as new
+func (t *PluginConnectionTracker) Remove(namespace string) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	delete(t.connected, namespace)
+	delete(t.lost, namespace)
+}
@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConnectionListener struct {
+	changes []string
+}
+
+func (tl *testConnectionListener) PluginConnectionChanged(namespace string, connected bool) {
+	state := "lost"
+	if connected {
+		state = "restored"
+	}
+	tl.changes = append(tl.changes, namespace+":"+state)
+}
+
+func TestPluginConnectionTracker(t *testing.T) {
+	var tracker PluginConnectionTracker
+	tracker.Update("ns1", false) // no listener, and never connected
+	tl := &testConnectionListener{}
+	tracker.SetListener(tl)
+
+	tracker.Update("ns1", false) // initial connection attempt
+	tracker.Update("ns1", true)  // initial connection
+	tracker.Update("ns2", true)
+	tracker.Update("ns1", false) // lost
+	tracker.Update("ns1", false) // failed reconnect attempt
+	tracker.Update("ns1", true)  // restored
+	tracker.Update("ns1", true)
+	assert.Equal(t, []string{"ns1:lost", "ns1:restored"}, tl.changes)
+
+	tracker.Update("ns2", false) // lost
+	tracker.Remove("ns2")
+	tracker.Update("ns2", true) // a new connection after the namespace restarted
+	assert.Equal(t, []string{"ns1:lost", "ns1:restored", "ns2:lost"}, tl.changes)
+}
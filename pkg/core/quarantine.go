@@ -0,0 +1,57 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// QuarantineReason is the reason code for an inbound item being quarantined
+type QuarantineReason = fftypes.FFEnum
+
+var (
+	// QuarantineReasonBatchInvalid is a batch that is missing required fields, or whose hash does not match its payload
+	QuarantineReasonBatchInvalid = fftypes.FFEnumValue("quarantinereason", "batch_invalid")
+	// QuarantineReasonDataHashMismatch is a data entry in a batch whose hash does not match its value, or the reference to it from a message
+	QuarantineReasonDataHashMismatch = fftypes.FFEnumValue("quarantinereason", "data_hash_mismatch")
+	// QuarantineReasonMessageInvalid is a message in a batch that fails verification, such as a hash mismatch
+	QuarantineReasonMessageInvalid = fftypes.FFEnumValue("quarantinereason", "message_invalid")
+	// QuarantineReasonDataInvalid is a message whose data fails validation against its datatype
+	QuarantineReasonDataInvalid = fftypes.FFEnumValue("quarantinereason", "data_invalid")
+)
+
+// QuarantineEntry records an inbound item that failed validation, so that it can be inspected, re-validated or purged.
+// Entries for batches that could not be persisted hold the full batch, so that it can be re-validated.
+type QuarantineEntry struct {
+	ID        *fftypes.UUID    `ffstruct:"QuarantineEntry" json:"id"`
+	Namespace string           `ffstruct:"QuarantineEntry" json:"namespace"`
+	Reason    QuarantineReason `ffstruct:"QuarantineEntry" json:"reason" ffenum:"quarantinereason"`
+	Detail    string           `ffstruct:"QuarantineEntry" json:"detail,omitempty"`
+	Batch     *fftypes.UUID    `ffstruct:"QuarantineEntry" json:"batch,omitempty"`
+	Message   *fftypes.UUID    `ffstruct:"QuarantineEntry" json:"message,omitempty"`
+	Data      *fftypes.UUID    `ffstruct:"QuarantineEntry" json:"data,omitempty"`
+	Payload   *fftypes.JSONAny `ffstruct:"QuarantineEntry" json:"payload,omitempty"`
+	Created   *fftypes.FFTime  `ffstruct:"QuarantineEntry" json:"created"`
+}
+
+// QuarantineRevalidation is the result of re-validating a quarantine entry. An entry that is now valid
+// is processed as if it had been valid when it was received, and removed from the quarantine.
+type QuarantineRevalidation struct {
+	Entry  *QuarantineEntry `ffstruct:"QuarantineRevalidation" json:"entry"`
+	Valid  bool             `ffstruct:"QuarantineRevalidation" json:"valid"`
+	Detail string           `ffstruct:"QuarantineRevalidation" json:"detail,omitempty"`
+}
@@ -0,0 +1,47 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+// ComponentReadiness is the result of checking connectivity to a single plugin
+type ComponentReadiness struct {
+	Name     string `ffstruct:"ComponentReadiness" json:"name"`
+	Category string `ffstruct:"ComponentReadiness" json:"category"`
+	Type     string `ffstruct:"ComponentReadiness" json:"type"`
+	Ready    bool   `ffstruct:"ComponentReadiness" json:"ready"`
+	Error    string `ffstruct:"ComponentReadiness" json:"error,omitempty"`
+}
+
+// NodeReadiness is the result of checking connectivity to every plugin the node depends on
+type NodeReadiness struct {
+	Ready      bool                  `ffstruct:"NodeReadiness" json:"ready"`
+	Components []*ComponentReadiness `ffstruct:"NodeReadiness" json:"components"`
+}
+
+// AddComponent records the readiness of a plugin, with the node only ready if all its plugins are
+func (nr *NodeReadiness) AddComponent(name, category, pluginType string, err error) {
+	component := &ComponentReadiness{
+		Name:     name,
+		Category: category,
+		Type:     pluginType,
+		Ready:    err == nil,
+	}
+	if err != nil {
+		component.Error = err.Error()
+		nr.Ready = false
+	}
+	nr.Components = append(nr.Components, component)
+}
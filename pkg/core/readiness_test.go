@@ -0,0 +1,39 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeReadiness(t *testing.T) {
+	nr := &NodeReadiness{Ready: true}
+	nr.AddComponent("database0", "database", "postgres", nil)
+	assert.True(t, nr.Ready)
+	nr.AddComponent("dx0", "dataexchange", "ffdx", fmt.Errorf("pop"))
+	assert.False(t, nr.Ready)
+	nr.AddComponent("tokens0", "tokens", "fftokens", nil)
+	assert.False(t, nr.Ready)
+	assert.Equal(t, []*ComponentReadiness{
+		{Name: "database0", Category: "database", Type: "postgres", Ready: true},
+		{Name: "dx0", Category: "dataexchange", Type: "ffdx", Ready: false, Error: "pop"},
+		{Name: "tokens0", Category: "tokens", Type: "fftokens", Ready: true},
+	}, nr.Components)
+}
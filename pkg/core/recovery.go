@@ -0,0 +1,61 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// RecoveryAction is the action taken on startup for an operation that was in-flight when the node stopped
+type RecoveryAction = fftypes.FFEnum
+
+var (
+	// RecoveryActionReconciled indicates the connector knew the operation, and delivered its latest status
+	RecoveryActionReconciled = fftypes.FFEnumValue("recoveryaction", "reconciled")
+	// RecoveryActionResumed indicates the operation had not been submitted to the connector, and was submitted again
+	RecoveryActionResumed = fftypes.FFEnumValue("recoveryaction", "resumed")
+	// RecoveryActionQueued indicates the operation had not been submitted to the connector, and was left to the submission queue
+	RecoveryActionQueued = fftypes.FFEnumValue("recoveryaction", "queued")
+	// RecoveryActionUnresolved indicates the operation could not be recovered automatically, and might need a manual retry
+	RecoveryActionUnresolved = fftypes.FFEnumValue("recoveryaction", "unresolved")
+)
+
+// RecoveredOperation records what startup recovery did with an operation that was in-flight when the node stopped
+type RecoveredOperation struct {
+	ID          *fftypes.UUID  `ffstruct:"RecoveredOperation" json:"id"`
+	Type        OpType         `ffstruct:"RecoveredOperation" json:"type" ffenum:"optype"`
+	Transaction *fftypes.UUID  `ffstruct:"RecoveredOperation" json:"tx"`
+	Status      OpStatus       `ffstruct:"RecoveredOperation" json:"status"`
+	Action      RecoveryAction `ffstruct:"RecoveredOperation" json:"action" ffenum:"recoveryaction"`
+	Error       string         `ffstruct:"RecoveredOperation" json:"error,omitempty"`
+}
+
+// RecoveredBatch is a batch that had not been confirmed when the node stopped
+type RecoveredBatch struct {
+	ID          *fftypes.UUID   `ffstruct:"RecoveredBatch" json:"id"`
+	Type        BatchType       `ffstruct:"RecoveredBatch" json:"type" ffenum:"batchtype"`
+	Transaction *fftypes.UUID   `ffstruct:"RecoveredBatch" json:"tx"`
+	Created     *fftypes.FFTime `ffstruct:"RecoveredBatch" json:"created"`
+}
+
+// RecoveryReport describes the work that was in-flight when the node stopped, and how it was recovered on startup
+type RecoveryReport struct {
+	Namespace  string                `ffstruct:"RecoveryReport" json:"namespace"`
+	Started    *fftypes.FFTime       `ffstruct:"RecoveryReport" json:"started"`
+	Completed  *fftypes.FFTime       `ffstruct:"RecoveryReport" json:"completed,omitempty"`
+	Error      string                `ffstruct:"RecoveryReport" json:"error,omitempty"`
+	Operations []*RecoveredOperation `ffstruct:"RecoveryReport" json:"operations"`
+	Batches    []*RecoveredBatch     `ffstruct:"RecoveryReport" json:"batches"`
+}
@@ -0,0 +1,39 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// SandboxReplayInput selects the definitions and range of events of a source namespace to replay into a sandbox namespace
+type SandboxReplayInput struct {
+	Source       string                `ffstruct:"SandboxReplayInput" json:"source"`
+	Definitions  bool                  `ffstruct:"SandboxReplayInput" json:"definitions,omitempty"`
+	FromSequence int64                 `ffstruct:"SandboxReplayInput" json:"fromSequence,omitempty"`
+	ToSequence   int64                 `ffstruct:"SandboxReplayInput" json:"toSequence,omitempty"`
+	Types        fftypes.FFStringArray `ffstruct:"SandboxReplayInput" json:"types,omitempty"`
+}
+
+// SandboxReplayResult reports what was replayed from a source namespace into a sandbox namespace
+type SandboxReplayResult struct {
+	Source       string                  `ffstruct:"SandboxReplayResult" json:"source"`
+	Sandbox      string                  `ffstruct:"SandboxReplayResult" json:"sandbox"`
+	Definitions  *DefinitionImportResult `ffstruct:"SandboxReplayResult" json:"definitions,omitempty"`
+	Events       int                     `ffstruct:"SandboxReplayResult" json:"events"`
+	Messages     int                     `ffstruct:"SandboxReplayResult" json:"messages"`
+	Transactions int                     `ffstruct:"SandboxReplayResult" json:"transactions"`
+	LastSequence int64                   `ffstruct:"SandboxReplayResult" json:"lastSequence,omitempty"`
+}
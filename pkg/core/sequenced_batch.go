@@ -0,0 +1,34 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// SequencedBatch is a batch pin that has been given its position in the sequence of a network namespace,
+// by a sequencer that uses the database to order batches rather than a blockchain
+type SequencedBatch struct {
+	Sequence         int64                 `json:"sequence"`
+	NetworkNamespace string                `json:"networkNamespace"`
+	TransactionID    *fftypes.UUID         `json:"transactionId"`
+	TransactionType  TransactionType       `json:"transactionType"`
+	BatchID          *fftypes.UUID         `json:"batchId"`
+	BatchHash        *fftypes.Bytes32      `json:"batchHash"`
+	PayloadRef       string                `json:"payloadRef,omitempty"`
+	Contexts         fftypes.FFStringArray `json:"contexts"`
+	Signer           string                `json:"signer"`
+	Created          *fftypes.FFTime       `json:"created"`
+}
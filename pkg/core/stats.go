@@ -0,0 +1,42 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import "github.com/hyperledger/firefly-common/pkg/fftypes"
+
+// NamespaceStats are counts and rates of activity in a namespace, maintained incrementally by the node
+type NamespaceStats struct {
+	Resynced          *fftypes.FFTime `ffstruct:"NamespaceStats" json:"resynced"`
+	Messages          *MessageStats   `ffstruct:"NamespaceStats" json:"messages"`
+	Events            *StatsCounter   `ffstruct:"NamespaceStats" json:"events"`
+	Transfers         *StatsCounter   `ffstruct:"NamespaceStats" json:"transfers"`
+	Subscriptions     int64           `ffstruct:"NamespaceStats" json:"subscriptions"`
+	PendingOperations int64           `ffstruct:"NamespaceStats" json:"pendingOperations"`
+}
+
+// MessageStats are counts of messages by state
+type MessageStats struct {
+	Pending   int64         `ffstruct:"MessageStats" json:"pending"`
+	Confirmed *StatsCounter `ffstruct:"MessageStats" json:"confirmed"`
+	Rejected  *StatsCounter `ffstruct:"MessageStats" json:"rejected"`
+}
+
+// StatsCounter is a count, with the rate over the last minute
+type StatsCounter struct {
+	Total         int64 `ffstruct:"StatsCounter" json:"total"`
+	RatePerMinute int64 `ffstruct:"StatsCounter" json:"ratePerMinute"`
+}
@@ -95,6 +95,7 @@ type SubscriptionCoreOptions struct {
 	WithData     *bool              `ffstruct:"SubscriptionCoreOptions" json:"withData,omitempty"`
 	Batch        *bool              `ffstruct:"SubscriptionCoreOptions" json:"batch,omitempty"`
 	BatchTimeout *string            `ffstruct:"SubscriptionCoreOptions" json:"batchTimeout,omitempty"`
+	Priority     map[string]int     `ffstruct:"SubscriptionCoreOptions" json:"priority,omitempty"`
 }
 
 // SubscriptionOptions customize the behavior of subscriptions
@@ -121,6 +122,7 @@ type Subscription struct {
 	Filter    SubscriptionFilter  `ffstruct:"Subscription" json:"filter"`
 	Options   SubscriptionOptions `ffstruct:"Subscription" json:"options"`
 	Ephemeral bool                `ffstruct:"Subscription" json:"ephemeral,omitempty" ffexcludeinput:"true"`
+	Owner     string              `ffstruct:"Subscription" json:"owner,omitempty" ffexcludeinput:"true"`
 	Created   *fftypes.FFTime     `ffstruct:"Subscription" json:"created" ffexcludeinput:"true"`
 	Updated   *fftypes.FFTime     `ffstruct:"Subscription" json:"updated" ffexcludeinput:"true"`
 }
@@ -134,6 +136,28 @@ type SubscriptionStatus struct {
 	CurrentOffset int64 `ffstruct:"SubscriptionStatus" json:"currentOffset,omitempty" ffexcludeinout:"true"`
 }
 
+// SubscriptionTestInput is a sample event to evaluate the filters of a subscription against - either supplied in full,
+// or the ID of an event already recorded in the namespace
+type SubscriptionTestInput struct {
+	Event   *EnrichedEvent `ffstruct:"SubscriptionTestInput" json:"event,omitempty"`
+	EventID *fftypes.UUID  `ffstruct:"SubscriptionTestInput" json:"eventId,omitempty"`
+}
+
+// SubscriptionTestResult reports whether an event would be delivered to a subscription, with the result of each filter
+type SubscriptionTestResult struct {
+	Matched bool                        `ffstruct:"SubscriptionTestResult" json:"matched"`
+	Event   *EnrichedEvent              `ffstruct:"SubscriptionTestResult" json:"event"`
+	Filters []*SubscriptionFilterResult `ffstruct:"SubscriptionTestResult" json:"filters"`
+}
+
+// SubscriptionFilterResult is the result of evaluating one regular expression of a subscription filter against a field of an event
+type SubscriptionFilterResult struct {
+	Filter  string `ffstruct:"SubscriptionFilterResult" json:"filter"`
+	Pattern string `ffstruct:"SubscriptionFilterResult" json:"pattern"`
+	Value   string `ffstruct:"SubscriptionFilterResult" json:"value"`
+	Matched bool   `ffstruct:"SubscriptionFilterResult" json:"matched"`
+}
+
 func (so *SubscriptionOptions) UnmarshalJSON(b []byte) error {
 	so.additionalOptions = fftypes.JSONObject{}
 	err := json.Unmarshal(b, &so.additionalOptions)
@@ -149,6 +173,7 @@ func (so *SubscriptionOptions) UnmarshalJSON(b []byte) error {
 	delete(so.additionalOptions, "firstEvent")
 	delete(so.additionalOptions, "readAhead")
 	delete(so.additionalOptions, "withData")
+	delete(so.additionalOptions, "priority")
 	return nil
 }
 
@@ -174,6 +199,9 @@ func (so SubscriptionOptions) MarshalJSON() ([]byte, error) {
 	if so.BatchTimeout != nil {
 		so.additionalOptions["batchTimeout"] = so.BatchTimeout
 	}
+	if len(so.Priority) > 0 {
+		so.additionalOptions["priority"] = so.Priority
+	}
 
 	return json.Marshal(&so.additionalOptions)
 }
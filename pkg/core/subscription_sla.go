@@ -0,0 +1,97 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+)
+
+// SubscriptionSLA is a daily aggregate of the time taken for the events delivered on a durable subscription
+// to be acknowledged, measured from the creation of each event - such as on confirmation of the pinned message
+// it refers to - until the application acknowledged it
+type SubscriptionSLA struct {
+	Namespace      string              `ffstruct:"SubscriptionSLA" json:"namespace"`
+	Subscription   *fftypes.UUID       `ffstruct:"SubscriptionSLA" json:"subscription"`
+	Day            string              `ffstruct:"SubscriptionSLA" json:"day"`
+	Target         *fftypes.FFDuration `ffstruct:"SubscriptionSLA" json:"target"`
+	Delivered      int64               `ffstruct:"SubscriptionSLA" json:"delivered"`
+	WithinTarget   int64               `ffstruct:"SubscriptionSLA" json:"withinTarget"`
+	TotalLatency   *fftypes.FFDuration `ffstruct:"SubscriptionSLA" json:"totalLatency"`
+	AverageLatency *fftypes.FFDuration `ffstruct:"SubscriptionSLA" json:"averageLatency"`
+	MinLatency     *fftypes.FFDuration `ffstruct:"SubscriptionSLA" json:"minLatency"`
+	MaxLatency     *fftypes.FFDuration `ffstruct:"SubscriptionSLA" json:"maxLatency"`
+	Updated        *fftypes.FFTime     `ffstruct:"SubscriptionSLA" json:"updated,omitempty"`
+}
+
+// SLADay returns the UTC day that an acknowledgement at the given time is aggregated into
+func SLADay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// NewSubscriptionSLA returns an empty aggregate for a subscription on a given day
+func NewSubscriptionSLA(namespace string, subscription *fftypes.UUID, day string, target time.Duration) *SubscriptionSLA {
+	return &SubscriptionSLA{
+		Namespace:      namespace,
+		Subscription:   subscription,
+		Day:            day,
+		Target:         durationPtr(target),
+		TotalLatency:   durationPtr(0),
+		AverageLatency: durationPtr(0),
+		MinLatency:     durationPtr(0),
+		MaxLatency:     durationPtr(0),
+	}
+}
+
+func durationPtr(d time.Duration) *fftypes.FFDuration {
+	ffd := fftypes.FFDuration(d)
+	return &ffd
+}
+
+// Record adds the latency of a single acknowledged event to the aggregate
+func (sla *SubscriptionSLA) Record(latency time.Duration) {
+	event := &SubscriptionSLA{
+		Target:       sla.Target,
+		Delivered:    1,
+		TotalLatency: durationPtr(latency),
+		MinLatency:   durationPtr(latency),
+		MaxLatency:   durationPtr(latency),
+	}
+	if latency <= time.Duration(*sla.Target) {
+		event.WithinTarget = 1
+	}
+	sla.Merge(event)
+}
+
+// Merge adds the counts of another aggregate for the same subscription and day, adopting its target
+func (sla *SubscriptionSLA) Merge(other *SubscriptionSLA) {
+	if other.Delivered == 0 {
+		return
+	}
+	if sla.Delivered == 0 || *other.MinLatency < *sla.MinLatency {
+		sla.MinLatency = durationPtr(time.Duration(*other.MinLatency))
+	}
+	if *other.MaxLatency > *sla.MaxLatency {
+		sla.MaxLatency = durationPtr(time.Duration(*other.MaxLatency))
+	}
+	sla.Target = durationPtr(time.Duration(*other.Target))
+	sla.Delivered += other.Delivered
+	sla.WithinTarget += other.WithinTarget
+	sla.TotalLatency = durationPtr(time.Duration(*sla.TotalLatency + *other.TotalLatency))
+	sla.AverageLatency = durationPtr(time.Duration(*sla.TotalLatency) / time.Duration(sla.Delivered))
+}
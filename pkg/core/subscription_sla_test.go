@@ -0,0 +1,62 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLADay(t *testing.T) {
+	ts, _ := time.Parse(time.RFC3339, "2024-03-01T23:30:00-05:00")
+	assert.Equal(t, "2024-03-02", SLADay(ts))
+}
+
+func TestSubscriptionSLARecordAndMerge(t *testing.T) {
+	subID := fftypes.NewUUID()
+	sla := NewSubscriptionSLA("ns1", subID, "2024-03-02", time.Second)
+	assert.Equal(t, int64(0), sla.Delivered)
+
+	sla.Record(500 * time.Millisecond)
+	sla.Record(2 * time.Second)
+	assert.Equal(t, int64(2), sla.Delivered)
+	assert.Equal(t, int64(1), sla.WithinTarget)
+	assert.Equal(t, "2.5s", sla.TotalLatency.String())
+	assert.Equal(t, "1.25s", sla.AverageLatency.String())
+	assert.Equal(t, "500ms", sla.MinLatency.String())
+	assert.Equal(t, "2s", sla.MaxLatency.String())
+
+	stored := NewSubscriptionSLA("ns1", subID, "2024-03-02", 5*time.Second)
+	stored.Merge(NewSubscriptionSLA("ns1", subID, "2024-03-02", time.Minute))
+	assert.Equal(t, "5s", stored.Target.String())
+
+	stored.Record(100 * time.Millisecond)
+	stored.Merge(sla)
+	assert.Equal(t, int64(3), stored.Delivered)
+	assert.Equal(t, int64(2), stored.WithinTarget)
+	assert.Equal(t, "1s", stored.Target.String())
+	assert.Equal(t, "2.6s", stored.TotalLatency.String())
+	assert.Equal(t, "100ms", stored.MinLatency.String())
+	assert.Equal(t, "2s", stored.MaxLatency.String())
+
+	b, err := stored.Target.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "\"1s\"", string(b))
+}
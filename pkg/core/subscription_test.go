@@ -37,6 +37,9 @@ func TestSubscriptionOptionsDatabaseSerialization(t *testing.T) {
 				WithData:     &yes,
 				Batch:        &yes,
 				BatchTimeout: &oneSec,
+				Priority: map[string]int{
+					EventTypeMessageRejected.String(): 10,
+				},
 			},
 			WebhookSubOptions: WebhookSubOptions{
 				TLSConfigName: "myconfig",
@@ -62,7 +65,8 @@ func TestSubscriptionOptionsDatabaseSerialization(t *testing.T) {
 		"tlsConfigName":"myconfig",
 		"withData":true,
 		"batch":true,
-		"batchTimeout":"1s"
+		"batchTimeout":"1s",
+		"priority":{"message_rejected":10}
 	}`, string(b1.([]byte)))
 
 	f1, err := sub1.Filter.Value()
@@ -79,12 +83,14 @@ func TestSubscriptionOptionsDatabaseSerialization(t *testing.T) {
 	assert.Equal(t, SubOptsFirstEventNewest, *sub2.Options.FirstEvent)
 	assert.Equal(t, uint16(50), *sub2.Options.ReadAhead)
 	assert.Equal(t, "myconfig", sub2.Options.TLSConfigName)
+	assert.Equal(t, 10, sub2.Options.Priority["message_rejected"])
 	assert.Equal(t, string(b1.([]byte)), string(b2.([]byte)))
 
 	// Confirm we don't pass core options, to transports
 	assert.Nil(t, sub2.Options.TransportOptions()["withData"])
 	assert.Nil(t, sub2.Options.TransportOptions()["firstEvent"])
 	assert.Nil(t, sub2.Options.TransportOptions()["readAhead"])
+	assert.Nil(t, sub2.Options.TransportOptions()["priority"])
 
 	// Confirm we get back the transport options
 	assert.Equal(t, float64(12345), sub2.Options.TransportOptions().GetObject("my-nested-opts")["myopt1"])
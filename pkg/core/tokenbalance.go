@@ -45,3 +45,23 @@ type TokenAccount struct {
 type TokenAccountPool struct {
 	Pool *fftypes.UUID `ffstruct:"TokenBalance" json:"pool,omitempty"`
 }
+
+// TokenAccountPoolSummary is the aggregated position of an account within a single token pool
+type TokenAccountPoolSummary struct {
+	Pool         *fftypes.UUID    `ffstruct:"TokenAccountPoolSummary" json:"pool,omitempty"`
+	Name         string           `ffstruct:"TokenAccountPoolSummary" json:"name,omitempty"`
+	Connector    string           `ffstruct:"TokenAccountPoolSummary" json:"connector,omitempty"`
+	Type         TokenType        `ffstruct:"TokenAccountPoolSummary" json:"type,omitempty" ffenum:"tokentype"`
+	Decimals     int              `ffstruct:"TokenAccountPoolSummary" json:"decimals"`
+	Balance      fftypes.FFBigInt `ffstruct:"TokenAccountPoolSummary" json:"balance"`
+	Tokens       int64            `ffstruct:"TokenAccountPoolSummary" json:"tokens"`
+	Transfers    int64            `ffstruct:"TokenAccountPoolSummary" json:"transfers"`
+	LastActivity *fftypes.FFTime  `ffstruct:"TokenAccountPoolSummary" json:"lastActivity,omitempty"`
+}
+
+// TokenAccountSummary aggregates the balances and recent activity of an account across all token pools
+type TokenAccountSummary struct {
+	Key    string                     `ffstruct:"TokenAccountSummary" json:"key"`
+	Pools  []*TokenAccountPoolSummary `ffstruct:"TokenAccountSummary" json:"pools"`
+	Recent []*TokenTransfer           `ffstruct:"TokenAccountSummary" json:"recent"`
+}
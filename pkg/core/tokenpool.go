@@ -38,9 +38,20 @@ var (
 
 type TokenPoolInput struct {
 	TokenPool
+	Template       string         `ffstruct:"TokenPoolInput" json:"template,omitempty" ffexcludeoutput:"true"`
 	IdempotencyKey IdempotencyKey `ffstruct:"TokenPoolInput" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
 }
 
+// TokenPoolTemplate is a named set of defaults for creating token pools, defined in the configuration of a namespace
+type TokenPoolTemplate struct {
+	Name      string             `ffstruct:"TokenPoolTemplate" json:"name"`
+	Connector string             `ffstruct:"TokenPoolTemplate" json:"connector,omitempty"`
+	Type      TokenType          `ffstruct:"TokenPoolTemplate" json:"type,omitempty" ffenum:"tokentype"`
+	Standard  string             `ffstruct:"TokenPoolTemplate" json:"standard,omitempty"`
+	Decimals  int                `ffstruct:"TokenPoolTemplate" json:"decimals,omitempty"`
+	Config    fftypes.JSONObject `ffstruct:"TokenPoolTemplate" json:"config,omitempty"`
+}
+
 type TokenPool struct {
 	ID              *fftypes.UUID         `ffstruct:"TokenPool" json:"id,omitempty" ffexcludeinput:"true"`
 	Type            TokenType             `ffstruct:"TokenPool" json:"type" ffenum:"tokentype"`
@@ -27,24 +27,27 @@ var (
 )
 
 type TokenTransfer struct {
-	Type            TokenTransferType  `ffstruct:"TokenTransfer" json:"type" ffenum:"tokentransfertype" ffexcludeinput:"true"`
-	LocalID         *fftypes.UUID      `ffstruct:"TokenTransfer" json:"localId,omitempty" ffexcludeinput:"true"`
-	Pool            *fftypes.UUID      `ffstruct:"TokenTransfer" json:"pool,omitempty"`
-	TokenIndex      string             `ffstruct:"TokenTransfer" json:"tokenIndex,omitempty"`
-	URI             string             `ffstruct:"TokenTransfer" json:"uri,omitempty"`
-	Connector       string             `ffstruct:"TokenTransfer" json:"connector,omitempty" ffexcludeinput:"true"`
-	Namespace       string             `ffstruct:"TokenTransfer" json:"namespace,omitempty" ffexcludeinput:"true"`
-	Key             string             `ffstruct:"TokenTransfer" json:"key,omitempty"`
-	From            string             `ffstruct:"TokenTransfer" json:"from,omitempty" ffexcludeinput:"postTokenMint"`
-	To              string             `ffstruct:"TokenTransfer" json:"to,omitempty" ffexcludeinput:"postTokenBurn"`
-	Amount          fftypes.FFBigInt   `ffstruct:"TokenTransfer" json:"amount"`
-	ProtocolID      string             `ffstruct:"TokenTransfer" json:"protocolId,omitempty" ffexcludeinput:"true"`
-	Message         *fftypes.UUID      `ffstruct:"TokenTransfer" json:"message,omitempty"`
-	MessageHash     *fftypes.Bytes32   `ffstruct:"TokenTransfer" json:"messageHash,omitempty" ffexcludeinput:"true"`
-	Created         *fftypes.FFTime    `ffstruct:"TokenTransfer" json:"created,omitempty" ffexcludeinput:"true"`
-	TX              TransactionRef     `ffstruct:"TokenTransfer" json:"tx" ffexcludeinput:"true"`
-	BlockchainEvent *fftypes.UUID      `ffstruct:"TokenTransfer" json:"blockchainEvent,omitempty" ffexcludeinput:"true"`
-	Config          fftypes.JSONObject `ffstruct:"TokenTransfer" json:"config,omitempty" ffexcludeoutput:"true"` // for REST calls only (not stored)
+	Type             TokenTransferType  `ffstruct:"TokenTransfer" json:"type" ffenum:"tokentransfertype" ffexcludeinput:"true"`
+	LocalID          *fftypes.UUID      `ffstruct:"TokenTransfer" json:"localId,omitempty" ffexcludeinput:"true"`
+	Pool             *fftypes.UUID      `ffstruct:"TokenTransfer" json:"pool,omitempty"`
+	TokenIndex       string             `ffstruct:"TokenTransfer" json:"tokenIndex,omitempty"`
+	URI              string             `ffstruct:"TokenTransfer" json:"uri,omitempty"`
+	Connector        string             `ffstruct:"TokenTransfer" json:"connector,omitempty" ffexcludeinput:"true"`
+	Namespace        string             `ffstruct:"TokenTransfer" json:"namespace,omitempty" ffexcludeinput:"true"`
+	Key              string             `ffstruct:"TokenTransfer" json:"key,omitempty"`
+	From             string             `ffstruct:"TokenTransfer" json:"from,omitempty" ffexcludeinput:"postTokenMint"`
+	To               string             `ffstruct:"TokenTransfer" json:"to,omitempty" ffexcludeinput:"postTokenBurn"`
+	Amount           fftypes.FFBigInt   `ffstruct:"TokenTransfer" json:"amount"`
+	ProtocolID       string             `ffstruct:"TokenTransfer" json:"protocolId,omitempty" ffexcludeinput:"true"`
+	Message          *fftypes.UUID      `ffstruct:"TokenTransfer" json:"message,omitempty"`
+	MessageHash      *fftypes.Bytes32   `ffstruct:"TokenTransfer" json:"messageHash,omitempty" ffexcludeinput:"true"`
+	Created          *fftypes.FFTime    `ffstruct:"TokenTransfer" json:"created,omitempty" ffexcludeinput:"true"`
+	TX               TransactionRef     `ffstruct:"TokenTransfer" json:"tx" ffexcludeinput:"true"`
+	BlockchainEvent  *fftypes.UUID      `ffstruct:"TokenTransfer" json:"blockchainEvent,omitempty" ffexcludeinput:"true"`
+	BlockNumber      *int64             `ffstruct:"TokenTransfer" json:"blockNumber,omitempty" ffexcludeinput:"true"`
+	TransactionIndex *int64             `ffstruct:"TokenTransfer" json:"transactionIndex,omitempty" ffexcludeinput:"true"`
+	LogIndex         *int64             `ffstruct:"TokenTransfer" json:"logIndex,omitempty" ffexcludeinput:"true"`
+	Config           fftypes.JSONObject `ffstruct:"TokenTransfer" json:"config,omitempty" ffexcludeoutput:"true"` // for REST calls only (not stored)
 }
 
 type TokenTransferInput struct {
@@ -52,4 +55,45 @@ type TokenTransferInput struct {
 	Message        *MessageInOut  `ffstruct:"TokenTransferInput" json:"message,omitempty"`
 	Pool           string         `ffstruct:"TokenTransferInput" json:"pool,omitempty"`
 	IdempotencyKey IdempotencyKey `ffstruct:"TokenTransferInput" json:"idempotencyKey,omitempty" ffexcludeoutput:"true"`
+	OnBehalfOf     string         `ffstruct:"TokenTransferInput" json:"onBehalfOf,omitempty" ffexcludeoutput:"true"`
+}
+
+// TokenMintRecipient is an account that receives a share of the tokens in a mint distribution
+type TokenMintRecipient struct {
+	To         string           `ffstruct:"TokenMintRecipient" json:"to"`
+	Amount     fftypes.FFBigInt `ffstruct:"TokenMintRecipient" json:"amount"`
+	TokenIndex string           `ffstruct:"TokenMintRecipient" json:"tokenIndex,omitempty"`
+}
+
+// TokenMintDistributionInput requests that tokens are minted and distributed to a list of recipients,
+// with the mint and all of the transfers tracked as a single transaction
+type TokenMintDistributionInput struct {
+	Pool           string                `ffstruct:"TokenMintDistributionInput" json:"pool,omitempty"`
+	Key            string                `ffstruct:"TokenMintDistributionInput" json:"key,omitempty"`
+	Recipients     []*TokenMintRecipient `ffstruct:"TokenMintDistributionInput" json:"recipients"`
+	Config         fftypes.JSONObject    `ffstruct:"TokenMintDistributionInput" json:"config,omitempty"`
+	IdempotencyKey IdempotencyKey        `ffstruct:"TokenMintDistributionInput" json:"idempotencyKey,omitempty"`
+}
+
+// TokenMintDistribution is the transaction of a mint distribution, and the mints and transfers submitted within it
+type TokenMintDistribution struct {
+	TX        TransactionRef   `ffstruct:"TokenMintDistribution" json:"tx"`
+	Transfers []*TokenTransfer `ffstruct:"TokenMintDistribution" json:"transfers"`
+}
+
+// TokenAccountHistoryEntry is a single transfer in the history of an account within a token pool,
+// along with the signed change it made to the account and the running balance after it was applied
+type TokenAccountHistoryEntry struct {
+	Created         *fftypes.FFTime   `ffstruct:"TokenAccountHistoryEntry" json:"created,omitempty"`
+	Type            TokenTransferType `ffstruct:"TokenAccountHistoryEntry" json:"type" ffenum:"tokentransfertype"`
+	LocalID         *fftypes.UUID     `ffstruct:"TokenAccountHistoryEntry" json:"localId,omitempty"`
+	TokenIndex      string            `ffstruct:"TokenAccountHistoryEntry" json:"tokenIndex,omitempty"`
+	From            string            `ffstruct:"TokenAccountHistoryEntry" json:"from,omitempty"`
+	To              string            `ffstruct:"TokenAccountHistoryEntry" json:"to,omitempty"`
+	Amount          fftypes.FFBigInt  `ffstruct:"TokenAccountHistoryEntry" json:"amount"`
+	Change          fftypes.FFBigInt  `ffstruct:"TokenAccountHistoryEntry" json:"change"`
+	Balance         fftypes.FFBigInt  `ffstruct:"TokenAccountHistoryEntry" json:"balance"`
+	ProtocolID      string            `ffstruct:"TokenAccountHistoryEntry" json:"protocolId,omitempty"`
+	TX              *fftypes.UUID     `ffstruct:"TokenAccountHistoryEntry" json:"tx,omitempty"`
+	BlockchainEvent *fftypes.UUID     `ffstruct:"TokenAccountHistoryEntry" json:"blockchainEvent,omitempty"`
 }
@@ -45,6 +45,8 @@ var (
 	TransactionTypeTokenApproval = fftypes.FFEnumValue("txtype", "token_approval")
 	// TransactionTypeDataPublish represents a publish to shared storage
 	TransactionTypeDataPublish = fftypes.FFEnumValue("txtype", "data_publish")
+	// TransactionTypeAnchorDigest represents a digest of pinned batches, submitted to the anchor chain of a namespace
+	TransactionTypeAnchorDigest = fftypes.FFEnumValue("txtype", "anchor_digest")
 )
 
 // TransactionRef refers to a transaction, in other types
@@ -80,6 +82,7 @@ var (
 	TransactionStatusTypeTokenPool       TransactionStatusType = "TokenPool"
 	TransactionStatusTypeTokenTransfer   TransactionStatusType = "TokenTransfer"
 	TransactionStatusTypeTokenApproval   TransactionStatusType = "TokenApproval"
+	TransactionStatusTypeMessage         TransactionStatusType = "Message"
 )
 
 type TransactionStatusDetails struct {
@@ -0,0 +1,26 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+// TransferLimits are the limits applied to blob transfers to other nodes over data exchange,
+// so that a burst of large private data sends does not starve other traffic. A zero value means unlimited.
+type TransferLimits struct {
+	MaxParallel           int   `ffstruct:"TransferLimits" json:"maxParallel"`
+	MaxParallelPerPeer    int   `ffstruct:"TransferLimits" json:"maxParallelPerPeer"`
+	BytesPerSecond        int64 `ffstruct:"TransferLimits" json:"bytesPerSecond"`
+	BytesPerSecondPerPeer int64 `ffstruct:"TransferLimits" json:"bytesPerSecondPerPeer"`
+}
@@ -16,7 +16,14 @@
 
 package core
 
-import "github.com/hyperledger/firefly-common/pkg/fftypes"
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly-common/pkg/i18n"
+	"github.com/hyperledger/firefly/internal/coremsgs"
+)
 
 type TransportPayloadType = fftypes.FFEnum
 
@@ -29,4 +36,41 @@ var (
 type TransportWrapper struct {
 	Group *Group `json:"group,omitempty"`
 	Batch *Batch `json:"batch,omitempty"`
+	// Compressed holds the compressed JSON of the group and batch, in place of the fields above, when the batch is compressed
+	Compressed []byte `json:"compressed,omitempty"`
+}
+
+// Serialize returns the JSON to transfer over data exchange, compressing the group and batch
+// if the batch requires compression
+func (tw *TransportWrapper) Serialize(ctx context.Context) ([]byte, error) {
+	payload, err := json.Marshal(tw)
+	if err != nil {
+		return nil, i18n.WrapError(ctx, err, coremsgs.MsgSerializationFailed)
+	}
+	if tw.Batch == nil || !IsCompressed(tw.Batch.Compression) {
+		return payload, nil
+	}
+	compressed, err := CompressBatchPayload(ctx, tw.Batch.Compression, payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&TransportWrapper{Compressed: compressed})
+}
+
+// Expand replaces a compressed wrapper received over data exchange with the group and batch it contains
+func (tw *TransportWrapper) Expand(ctx context.Context, maxLength int64) error {
+	if tw.Compressed == nil {
+		return nil
+	}
+	payload, _, err := DecompressBatchPayload(ctx, tw.Compressed, maxLength)
+	if err != nil {
+		return err
+	}
+	var expanded TransportWrapper
+	if err := json.Unmarshal(payload, &expanded); err != nil {
+		return i18n.WrapError(ctx, err, coremsgs.MsgJSONDecodeFailed)
+	}
+	*tw = expanded
+	tw.Compressed = nil
+	return nil
 }
@@ -17,6 +17,8 @@
 package core
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
@@ -53,3 +55,74 @@ func TestBatchManifest(t *testing.T) {
 	assert.Equal(t, tw.Batch.Payload.Data[1].Hash.String(), tm.Data[1].Hash.String())
 
 }
+
+func TestTransportWrapperSerializeUncompressed(t *testing.T) {
+	tw := &TransportWrapper{
+		Batch: &Batch{BatchHeader: BatchHeader{ID: fftypes.NewUUID()}},
+	}
+	payload, err := tw.Serialize(context.Background())
+	assert.NoError(t, err)
+
+	var received TransportWrapper
+	err = json.Unmarshal(payload, &received)
+	assert.NoError(t, err)
+	assert.Nil(t, received.Compressed)
+	err = received.Expand(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, tw.Batch.ID, received.Batch.ID)
+}
+
+func TestTransportWrapperSerializeCompressed(t *testing.T) {
+	tw := &TransportWrapper{
+		Group: &Group{Hash: fftypes.NewRandB32()},
+		Batch: &Batch{
+			BatchHeader: BatchHeader{ID: fftypes.NewUUID()},
+			Compression: BatchCompressionZstd,
+		},
+	}
+	payload, err := tw.Serialize(context.Background())
+	assert.NoError(t, err)
+
+	var received TransportWrapper
+	err = json.Unmarshal(payload, &received)
+	assert.NoError(t, err)
+	assert.Nil(t, received.Batch)
+	assert.NotNil(t, received.Compressed)
+
+	err = received.Expand(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Nil(t, received.Compressed)
+	assert.Equal(t, tw.Batch.ID, received.Batch.ID)
+	assert.Equal(t, BatchCompressionZstd, received.Batch.Compression)
+	assert.Equal(t, tw.Group.Hash, received.Group.Hash)
+}
+
+func TestTransportWrapperSerializeFail(t *testing.T) {
+	tw := &TransportWrapper{
+		Batch: &Batch{
+			Payload: BatchPayload{
+				Data: DataArray{{Value: fftypes.JSONAnyPtr("!json")}},
+			},
+		},
+	}
+	_, err := tw.Serialize(context.Background())
+	assert.Regexp(t, "FF10137", err)
+
+	tw = &TransportWrapper{
+		Batch: &Batch{Compression: "lz4"},
+	}
+	_, err = tw.Serialize(context.Background())
+	assert.Regexp(t, "FF10508", err)
+}
+
+func TestTransportWrapperExpandFail(t *testing.T) {
+	tw := &TransportWrapper{Compressed: []byte{0x1f, 0x8b}}
+	err := tw.Expand(context.Background(), 0)
+	assert.Regexp(t, "FF10509", err)
+
+	compressed, err := CompressBatchPayload(context.Background(), BatchCompressionGzip, []byte(`!json`))
+	assert.NoError(t, err)
+	tw = &TransportWrapper{Compressed: compressed}
+	err = tw.Expand(context.Background(), 0)
+	assert.Regexp(t, "FF10103", err)
+}
@@ -48,7 +48,23 @@ type Verifier struct {
 	Identity  *fftypes.UUID    `ffstruct:"Verifier" json:"identity,omitempty"`
 	Namespace string           `ffstruct:"Verifier" json:"namespace,omitempty"`
 	VerifierRef
-	Created *fftypes.FFTime `ffstruct:"Verifier" json:"created,omitempty"`
+	Created    *fftypes.FFTime `ffstruct:"Verifier" json:"created,omitempty"`
+	Revoked    *fftypes.FFTime `ffstruct:"Verifier" json:"revoked,omitempty"`
+	RevokedPin int64           `ffstruct:"Verifier" json:"revokedPin,omitempty"` // zero if the revocation was not pinned, in which case it applies to every message
+}
+
+// VerifierRevocation is the data payload used in a message to broadcast the revocation of a verifier
+// (or all verifiers) of an identity. Once the revocation is confirmed, any message pinned after the
+// revocation and signed by a revoked verifier is rejected.
+type VerifierRevocation struct {
+	Identity IdentityBase `ffstruct:"VerifierRevocation" json:"identity"`
+	Verifier *VerifierRef `ffstruct:"VerifierRevocation" json:"verifier,omitempty"` // nil revokes every verifier of the identity
+	Reason   string       `ffstruct:"VerifierRevocation" json:"reason,omitempty"`
+}
+
+// VerifierRevocationDTO is the input structure to submit to revoke a verifier, or a node
+type VerifierRevocationDTO struct {
+	Reason string `ffstruct:"VerifierRevocation" json:"reason,omitempty"`
 }
 
 // Seal updates the hash to be deterministically generated from the namespace+type+value, such that
@@ -61,3 +77,11 @@ func (v *Verifier) Seal() *Verifier {
 	v.Hash = fftypes.HashResult(h)
 	return v
 }
+
+func (vr *VerifierRevocation) Topic() string {
+	return vr.Identity.Topic()
+}
+
+func (vr *VerifierRevocation) SetBroadcastMessage(msgID *fftypes.UUID) {
+	// nop-op here, as the revocation is recorded directly on the verifiers it affects
+}
@@ -38,3 +38,20 @@ func TestVerifierSeal(t *testing.T) {
 	assert.Equal(t, "c7742ed06a6c36dece56d9c6d65d4ee6ba0db2a643e7f8efc75ec4e7ca31d45d", v.Hash.String())
 
 }
+
+func TestVerifierRevocationTopic(t *testing.T) {
+
+	vr := &VerifierRevocation{
+		Identity: IdentityBase{
+			ID:        fftypes.NewUUID(),
+			DID:       "did:firefly:org/org1",
+			Namespace: "ns1",
+		},
+	}
+	assert.Equal(t, vr.Identity.Topic(), vr.Topic())
+
+	// The revocation is recorded on the verifiers, not as a reference to the message
+	vr.SetBroadcastMessage(fftypes.NewUUID())
+	assert.Equal(t, vr.Identity.Topic(), vr.Topic())
+
+}
@@ -18,6 +18,7 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/ffapi"
@@ -65,6 +66,9 @@ type Plugin interface {
 
 	// Capabilities returns capabilities - not called until after Init
 	Capabilities() *Capabilities
+
+	// CheckReady returns an error if the database cannot currently be reached
+	CheckReady(ctx context.Context) error
 }
 
 type iNamespaceCollection interface {
@@ -75,6 +79,15 @@ type iNamespaceCollection interface {
 	GetNamespace(ctx context.Context, name string) (namespace *core.Namespace, err error)
 }
 
+type iLeaderLeaseCollection interface {
+	// AcquireLeaderLease - Take or extend the leadership lease of a namespace until the expiry, if it is unheld, expired, or already
+	//                      held by the holder. Returns false if another holder has a lease that has not yet expired
+	AcquireLeaderLease(ctx context.Context, namespace, holder string, expiry *fftypes.FFTime) (acquired bool, err error)
+
+	// ReleaseLeaderLease - Release the leadership lease of a namespace, if it is held by the holder
+	ReleaseLeaderLease(ctx context.Context, namespace, holder string) (err error)
+}
+
 type iMessageCollection interface {
 	// UpsertMessage - Upsert a message, with all the embedded data references.
 	//                 The database layer must ensure that if a record already exists, the hash of that existing record
@@ -233,6 +246,9 @@ type iOperationCollection interface {
 
 	// GetOperations - Get operation
 	GetOperations(ctx context.Context, namespace string, filter ffapi.Filter) (operation []*core.Operation, res *ffapi.FilterResult, err error)
+
+	// GetOperationsByTags - Get operations carrying any of the supplied tags
+	GetOperationsByTags(ctx context.Context, namespace string, tags []string, filter ffapi.Filter) (operation []*core.Operation, res *ffapi.FilterResult, err error)
 }
 
 type iSubscriptionCollection interface {
@@ -256,6 +272,14 @@ type iSubscriptionCollection interface {
 	DeleteSubscriptionByID(ctx context.Context, namespace string, id *fftypes.UUID) (err error)
 }
 
+type iSubscriptionSLACollection interface {
+	// AddSubscriptionSLA - Merge the counts into the stored daily aggregate for the subscription, creating it if required
+	AddSubscriptionSLA(ctx context.Context, sla *core.SubscriptionSLA) (err error)
+
+	// GetSubscriptionSLAs - Get the daily aggregates for a subscription
+	GetSubscriptionSLAs(ctx context.Context, namespace string, subscription *fftypes.UUID, filter ffapi.Filter) ([]*core.SubscriptionSLA, *ffapi.FilterResult, error)
+}
+
 type iEventCollection interface {
 	// InsertEvent - Insert an event. The order of the sequences added to the database, must match the order that
 	//               the rows/objects appear available to the event dispatcher. For a concurrency enabled database
@@ -332,6 +356,17 @@ type iNonceCollection interface {
 	DeleteNonce(ctx context.Context, hash *fftypes.Bytes32) (err error)
 }
 
+type iBlockchainCheckpointCollection interface {
+	// UpsertBlockchainCheckpoint - Record the position of the last event processed from a subscription on a ledger
+	UpsertBlockchainCheckpoint(ctx context.Context, checkpoint *core.BlockchainCheckpoint) (err error)
+
+	// GetBlockchainCheckpoint - Get the checkpoint of a subscription on a ledger
+	GetBlockchainCheckpoint(ctx context.Context, namespace, ledger, subscription string) (checkpoint *core.BlockchainCheckpoint, err error)
+
+	// DeleteBlockchainCheckpoints - Delete the checkpoints of a subscription, on all ledgers
+	DeleteBlockchainCheckpoints(ctx context.Context, namespace, subscription string) (err error)
+}
+
 type iNextPinCollection interface {
 	// InsertNextPin - insert a nextpin
 	InsertNextPin(ctx context.Context, nextpin *core.NextPin) (err error)
@@ -400,6 +435,9 @@ type iTokenBalanceCollection interface {
 	// GetTokenAccountPools - Get the list of pools referenced by a given account
 	GetTokenAccountPools(ctx context.Context, namespace, key string, filter ffapi.Filter) ([]*core.TokenAccountPool, *ffapi.FilterResult, error)
 
+	// GetTokenAccountBalanceSummaries - Get the total balance of an account in each pool, aggregated across all tokens in the pool
+	GetTokenAccountBalanceSummaries(ctx context.Context, namespace, key string) ([]*core.TokenAccountPoolSummary, error)
+
 	// DeleteTokenBalances - Delete token balances from a particular pool
 	DeleteTokenBalances(ctx context.Context, namespace string, poolID *fftypes.UUID) error
 }
@@ -418,6 +456,9 @@ type iTokenTransferCollection interface {
 	// GetTokenTransfers - Get token transfers
 	GetTokenTransfers(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.TokenTransfer, *ffapi.FilterResult, error)
 
+	// GetTokenAccountTransferSummaries - Get the count and latest time of the transfers into or out of an account in each pool
+	GetTokenAccountTransferSummaries(ctx context.Context, namespace, key string) ([]*core.TokenAccountPoolSummary, error)
+
 	// DeleteTokenTransfers - Delete token transfers from a particular pool
 	DeleteTokenTransfers(ctx context.Context, namespace string, poolID *fftypes.UUID) error
 }
@@ -466,6 +507,125 @@ type iFFICollection interface {
 	DeleteFFI(ctx context.Context, namespace string, id *fftypes.UUID) error
 }
 
+type iCompensationCollection interface {
+	// InsertCompensation - Record an attempt to compensate an operation
+	InsertCompensation(ctx context.Context, compensation *core.Compensation) error
+
+	// GetCompensations - Get compensation records
+	GetCompensations(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Compensation, *ffapi.FilterResult, error)
+}
+
+type iSequencedBatchCollection interface {
+	// InsertSequencedBatch - Append a batch pin to the sequence of a network namespace, allocating its position
+	InsertSequencedBatch(ctx context.Context, batch *core.SequencedBatch) error
+
+	// GetSequencedBatches - Get batch pins from the sequence of a network namespace
+	GetSequencedBatches(ctx context.Context, networkNamespace string, filter ffapi.Filter) ([]*core.SequencedBatch, *ffapi.FilterResult, error)
+}
+
+type iInboundEventCollection interface {
+	// InsertInboundEvent - Append an event received by a plugin to the inbound event queue
+	InsertInboundEvent(ctx context.Context, event *core.InboundEvent) error
+
+	// GetInboundEvents - Get the events queued for a connection of a plugin
+	GetInboundEvents(ctx context.Context, plugin, namespace string, filter ffapi.Filter) ([]*core.InboundEvent, *ffapi.FilterResult, error)
+
+	// DeleteInboundEvent - Remove an event from the inbound event queue once it has been processed
+	DeleteInboundEvent(ctx context.Context, sequence int64) error
+}
+
+type iOperationQueueCollection interface {
+	// InsertOperationQueueEntry - Record that an operation is owed a submission to its plugin
+	InsertOperationQueueEntry(ctx context.Context, entry *core.OperationQueueEntry) error
+
+	// GetOperationQueueEntries - Get the operations queued for submission in a namespace
+	GetOperationQueueEntries(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.OperationQueueEntry, *ffapi.FilterResult, error)
+
+	// DeleteOperationQueueEntry - Remove an operation from the queue once its plugin has been called
+	DeleteOperationQueueEntry(ctx context.Context, namespace string, id *fftypes.UUID) error
+}
+
+type iAnchorDigestCollection interface {
+	// InsertAnchorDigest - Record a digest of the batches pinned in a window of time
+	InsertAnchorDigest(ctx context.Context, digest *core.AnchorDigest) error
+
+	// UpdateAnchorDigest - Update an anchor digest
+	UpdateAnchorDigest(ctx context.Context, namespace string, id *fftypes.UUID, update ffapi.Update) error
+
+	// GetAnchorDigestByID - Get an anchor digest by ID
+	GetAnchorDigestByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.AnchorDigest, error)
+
+	// GetAnchorDigests - Get anchor digests
+	GetAnchorDigests(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.AnchorDigest, *ffapi.FilterResult, error)
+}
+
+type iAddressBookCollection interface {
+	// UpsertAddressBookEntry - Create an address book entry, or update the address of an existing entry with the same name
+	UpsertAddressBookEntry(ctx context.Context, entry *core.AddressBookEntry) error
+
+	// GetAddressBookEntryByName - Get an address book entry by name
+	GetAddressBookEntryByName(ctx context.Context, namespace, name string) (*core.AddressBookEntry, error)
+
+	// GetAddressBookEntries - Get address book entries
+	GetAddressBookEntries(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.AddressBookEntry, *ffapi.FilterResult, error)
+
+	// DeleteAddressBookEntry - Delete an address book entry
+	DeleteAddressBookEntry(ctx context.Context, namespace string, id *fftypes.UUID) error
+}
+
+type iAPIKeyCollection interface {
+	// InsertAPIKey - Create an API key, of which only the hash is stored
+	InsertAPIKey(ctx context.Context, key *core.APIKey) error
+
+	// GetAPIKeyByID - Get an API key by ID
+	GetAPIKeyByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.APIKey, error)
+
+	// GetAPIKeyByHash - Get the API key with the specified hash
+	GetAPIKeyByHash(ctx context.Context, namespace string, hash *fftypes.Bytes32) (*core.APIKey, error)
+
+	// GetAPIKeys - Get API keys
+	GetAPIKeys(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.APIKey, *ffapi.FilterResult, error)
+
+	// DeleteAPIKey - Delete an API key
+	DeleteAPIKey(ctx context.Context, namespace string, id *fftypes.UUID) error
+}
+
+type iQuarantineCollection interface {
+	// InsertQuarantineEntry - Record an inbound item that failed validation
+	InsertQuarantineEntry(ctx context.Context, entry *core.QuarantineEntry) error
+
+	// GetQuarantineEntryByID - Get a quarantine entry by ID
+	GetQuarantineEntryByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.QuarantineEntry, error)
+
+	// GetQuarantineEntries - Get quarantine entries
+	GetQuarantineEntries(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.QuarantineEntry, *ffapi.FilterResult, error)
+
+	// DeleteQuarantineEntry - Delete a quarantine entry
+	DeleteQuarantineEntry(ctx context.Context, namespace string, id *fftypes.UUID) error
+}
+
+type iDelegationCollection interface {
+	// InsertDelegation - Record a confirmed delegation
+	InsertDelegation(ctx context.Context, delegation *core.Delegation) error
+
+	// GetDelegationByID - Get a delegation by ID
+	GetDelegationByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.Delegation, error)
+
+	// GetDelegations - Get delegations
+	GetDelegations(ctx context.Context, namespace string, filter ffapi.Filter) ([]*core.Delegation, *ffapi.FilterResult, error)
+}
+
+type iSharedFFICollection interface {
+	// InsertSharedFFI - Add an interface to the registry of interfaces shared across namespaces
+	InsertSharedFFI(ctx context.Context, shared *core.SharedFFI) error
+
+	// GetSharedFFI - Get a shared interface by name and version
+	GetSharedFFI(ctx context.Context, name, version string) (*core.SharedFFI, error)
+
+	// GetSharedFFIs - Get shared interfaces
+	GetSharedFFIs(ctx context.Context, filter ffapi.Filter) ([]*core.SharedFFI, *ffapi.FilterResult, error)
+}
+
 type iFFIMethodCollection interface {
 	// UpsertFFIMethod - Upsert an FFI method
 	UpsertFFIMethod(ctx context.Context, method *fftypes.FFIMethod) error
@@ -498,7 +658,7 @@ type iFFIErrorCollection interface {
 
 type iContractAPICollection interface {
 	// InsertOrGetContractAPI - Insert a contract API
-	// If an API with the same name has already been recorded, does not insert but returns the existing row
+	// If an API with the same name and version has already been recorded, does not insert but returns the existing row
 	InsertOrGetContractAPI(ctx context.Context, api *core.ContractAPI) (*core.ContractAPI, error)
 
 	// UpsertFFIEvent - Upsert a contract API
@@ -510,11 +670,14 @@ type iContractAPICollection interface {
 	// GetContractAPIByID - Get a contract API by ID
 	GetContractAPIByID(ctx context.Context, namespace string, id *fftypes.UUID) (*core.ContractAPI, error)
 
-	// GetContractAPIByName - Get a contract API by name
+	// GetContractAPIByName - Get the latest version of a contract API by name
 	GetContractAPIByName(ctx context.Context, namespace, name string) (*core.ContractAPI, error)
 
-	// GetContractAPIByNetworkName - Get a contract API by network name
-	GetContractAPIByNetworkName(ctx context.Context, namespace, networkName string) (*core.ContractAPI, error)
+	// GetContractAPIByNameAndVersion - Get a specific version of a contract API by name
+	GetContractAPIByNameAndVersion(ctx context.Context, namespace, name, version string) (*core.ContractAPI, error)
+
+	// GetContractAPIByNetworkName - Get a specific version of a contract API by network name
+	GetContractAPIByNetworkName(ctx context.Context, namespace, networkName, version string) (*core.ContractAPI, error)
 
 	// DeleteContractAPI - Delete a contract API
 	DeleteContractAPI(ctx context.Context, namespace string, id *fftypes.UUID) error
@@ -569,6 +732,9 @@ type iBlockchainEventCollection interface {
 type iChartCollection interface {
 	// GetChartHistogram - Get charting data for a histogram
 	GetChartHistogram(ctx context.Context, namespace string, intervals []core.ChartHistogramInterval, collection CollectionName) ([]*core.ChartHistogram, error)
+
+	// GetChartAggregate - Get charting data for a histogram of fixed width time buckets, aggregated by the database
+	GetChartAggregate(ctx context.Context, namespace string, collection CollectionName, startTime *fftypes.FFTime, bucketWidth time.Duration, buckets int) ([]*core.ChartHistogram, error)
 }
 
 // PeristenceInterface are the operations that must be implemented by a database interface plugin.
@@ -606,6 +772,7 @@ type PersistenceInterface interface {
 	RunAsGroup(ctx context.Context, fn func(ctx context.Context) error) error
 
 	iNamespaceCollection
+	iLeaderLeaseCollection
 	iMessageCollection
 	iDataCollection
 	iBatchCollection
@@ -613,13 +780,24 @@ type PersistenceInterface interface {
 	iDatatypeCollection
 	iOffsetCollection
 	iPinCollection
+	iSequencedBatchCollection
+	iInboundEventCollection
+	iOperationQueueCollection
+	iAnchorDigestCollection
 	iOperationCollection
+	iCompensationCollection
 	iSubscriptionCollection
+	iSubscriptionSLACollection
 	iEventCollection
 	iIdentitiesCollection
 	iVerifiersCollection
+	iAddressBookCollection
+	iAPIKeyCollection
+	iQuarantineCollection
+	iDelegationCollection
 	iGroupCollection
 	iNonceCollection
+	iBlockchainCheckpointCollection
 	iNextPinCollection
 	iBlobCollection
 	iTokenPoolCollection
@@ -627,6 +805,7 @@ type PersistenceInterface interface {
 	iTokenTransferCollection
 	iTokenApprovalCollection
 	iFFICollection
+	iSharedFFICollection
 	iFFIMethodCollection
 	iFFIEventCollection
 	iFFIErrorCollection
@@ -781,6 +960,7 @@ var BatchQueryFactory = &ffapi.QueryFields{
 	"tx.type":    &ffapi.StringField{},
 	"tx.id":      &ffapi.UUIDField{},
 	"node":       &ffapi.UUIDField{},
+	"anchor":     &ffapi.JSONField{},
 }
 
 // TransactionQueryFactory filter fields for transactions
@@ -794,29 +974,35 @@ var TransactionQueryFactory = &ffapi.QueryFields{
 
 // DataQueryFactory filter fields for data
 var DataQueryFactory = &ffapi.QueryFields{
-	"id":               &ffapi.UUIDField{},
-	"validator":        &ffapi.StringField{},
-	"datatype.name":    &ffapi.StringField{},
-	"datatype.version": &ffapi.StringField{},
-	"hash":             &ffapi.Bytes32Field{},
-	"blob.hash":        &ffapi.Bytes32Field{},
-	"blob.public":      &ffapi.StringField{},
-	"blob.name":        &ffapi.StringField{},
-	"blob.path":        &ffapi.StringField{},
-	"blob.size":        &ffapi.Int64Field{},
-	"created":          &ffapi.TimeField{},
-	"value":            &ffapi.JSONField{},
-	"public":           &ffapi.StringField{},
+	"id":                    &ffapi.UUIDField{},
+	"validator":             &ffapi.StringField{},
+	"datatype.name":         &ffapi.StringField{},
+	"datatype.version":      &ffapi.StringField{},
+	"hash":                  &ffapi.Bytes32Field{},
+	"blob.hash":             &ffapi.Bytes32Field{},
+	"blob.public":           &ffapi.StringField{},
+	"blob.name":             &ffapi.StringField{},
+	"blob.path":             &ffapi.StringField{},
+	"blob.size":             &ffapi.Int64Field{},
+	"created":               &ffapi.TimeField{},
+	"value":                 &ffapi.JSONField{},
+	"public":                &ffapi.StringField{},
+	"pin.status":            &ffapi.StringField{},
+	"pin.request":           &ffapi.StringField{},
+	"migration.fromversion": &ffapi.StringField{},
+	"migration.datatype":    &ffapi.UUIDField{},
+	"migration.migrated":    &ffapi.TimeField{},
 }
 
 // DatatypeQueryFactory filter fields for data definitions
 var DatatypeQueryFactory = &ffapi.QueryFields{
-	"id":        &ffapi.UUIDField{},
-	"message":   &ffapi.UUIDField{},
-	"validator": &ffapi.StringField{},
-	"name":      &ffapi.StringField{},
-	"version":   &ffapi.StringField{},
-	"created":   &ffapi.TimeField{},
+	"id":                    &ffapi.UUIDField{},
+	"message":               &ffapi.UUIDField{},
+	"validator":             &ffapi.StringField{},
+	"name":                  &ffapi.StringField{},
+	"version":               &ffapi.StringField{},
+	"created":               &ffapi.TimeField{},
+	"migration.fromversion": &ffapi.StringField{},
 }
 
 // OffsetQueryFactory filter fields for data offsets
@@ -841,6 +1027,91 @@ var OperationQueryFactory = &ffapi.QueryFields{
 	"retry":   &ffapi.UUIDField{},
 }
 
+// CompensationQueryFactory filter fields for compensation records
+var CompensationQueryFactory = &ffapi.QueryFields{
+	"id":        &ffapi.UUIDField{},
+	"tx":        &ffapi.UUIDField{},
+	"operation": &ffapi.UUIDField{},
+	"type":      &ffapi.StringField{},
+	"status":    &ffapi.StringField{},
+	"error":     &ffapi.StringField{},
+	"created":   &ffapi.TimeField{},
+}
+
+// APIKeyQueryFactory filter fields for API keys
+var APIKeyQueryFactory = &ffapi.QueryFields{
+	"id":      &ffapi.UUIDField{},
+	"name":    &ffapi.StringField{},
+	"scope":   &ffapi.StringField{},
+	"created": &ffapi.TimeField{},
+}
+
+// QuarantineQueryFactory filter fields for quarantine entries
+var QuarantineQueryFactory = &ffapi.QueryFields{
+	"id":      &ffapi.UUIDField{},
+	"reason":  &ffapi.StringField{},
+	"detail":  &ffapi.StringField{},
+	"batch":   &ffapi.UUIDField{},
+	"message": &ffapi.UUIDField{},
+	"data":    &ffapi.UUIDField{},
+	"created": &ffapi.TimeField{},
+}
+
+// AddressBookQueryFactory filter fields for address book entries
+var AddressBookQueryFactory = &ffapi.QueryFields{
+	"id":      &ffapi.UUIDField{},
+	"name":    &ffapi.StringField{},
+	"address": &ffapi.StringField{},
+	"created": &ffapi.TimeField{},
+	"updated": &ffapi.TimeField{},
+}
+
+// DelegationQueryFactory filter fields for delegations
+var DelegationQueryFactory = &ffapi.QueryFields{
+	"id":        &ffapi.UUIDField{},
+	"delegator": &ffapi.StringField{},
+	"delegate":  &ffapi.StringField{},
+	"scope":     &ffapi.FFStringArrayField{},
+	"expires":   &ffapi.TimeField{},
+	"message":   &ffapi.UUIDField{},
+	"created":   &ffapi.TimeField{},
+}
+
+// SequencedBatchQueryFactory filter fields for batch pins ordered by the database sequencer
+var SequencedBatchQueryFactory = &ffapi.QueryFields{
+	"sequence": &ffapi.Int64Field{},
+	"tx":       &ffapi.UUIDField{},
+	"batch":    &ffapi.UUIDField{},
+	"signer":   &ffapi.StringField{},
+	"created":  &ffapi.TimeField{},
+}
+
+// InboundEventQueryFactory filter fields for events in the inbound event queue
+var InboundEventQueryFactory = &ffapi.QueryFields{
+	"sequence": &ffapi.Int64Field{},
+	"created":  &ffapi.TimeField{},
+}
+
+// OperationQueueQueryFactory filter fields for operations queued for submission to their plugin
+var OperationQueueQueryFactory = &ffapi.QueryFields{
+	"sequence": &ffapi.Int64Field{},
+	"id":       &ffapi.UUIDField{},
+	"type":     &ffapi.StringField{},
+	"created":  &ffapi.TimeField{},
+}
+
+// AnchorDigestQueryFactory filter fields for digests of batches submitted to an anchor chain
+var AnchorDigestQueryFactory = &ffapi.QueryFields{
+	"id":           &ffapi.UUIDField{},
+	"root":         &ffapi.Bytes32Field{},
+	"plugin":       &ffapi.StringField{},
+	"firstpin":     &ffapi.Int64Field{},
+	"lastpin":      &ffapi.Int64Field{},
+	"tx":           &ffapi.UUIDField{},
+	"blockchainid": &ffapi.StringField{},
+	"created":      &ffapi.TimeField{},
+}
+
 // SubscriptionQueryFactory filter fields for data subscriptions
 var SubscriptionQueryFactory = &ffapi.QueryFields{
 	"id":        &ffapi.UUIDField{},
@@ -849,9 +1120,18 @@ var SubscriptionQueryFactory = &ffapi.QueryFields{
 	"events":    &ffapi.StringField{},
 	"filters":   &ffapi.JSONField{},
 	"options":   &ffapi.StringField{},
+	"owner":     &ffapi.StringField{},
 	"created":   &ffapi.TimeField{},
 }
 
+// SubscriptionSLAQueryFactory filter fields for subscription SLA daily aggregates
+var SubscriptionSLAQueryFactory = &ffapi.QueryFields{
+	"day":          &ffapi.StringField{},
+	"delivered":    &ffapi.Int64Field{},
+	"withintarget": &ffapi.Int64Field{},
+	"updated":      &ffapi.TimeField{},
+}
+
 // EventQueryFactory filter fields for data events
 var EventQueryFactory = &ffapi.QueryFields{
 	"id":         &ffapi.UUIDField{},
@@ -887,17 +1167,20 @@ var IdentityQueryFactory = &ffapi.QueryFields{
 	"name":                  &ffapi.StringField{},
 	"description":           &ffapi.StringField{},
 	"profile":               &ffapi.JSONField{},
+	"verifiedsubject":       &ffapi.StringField{},
 	"created":               &ffapi.TimeField{},
 	"updated":               &ffapi.TimeField{},
 }
 
 // VerifierQueryFactory filter fields for identities
 var VerifierQueryFactory = &ffapi.QueryFields{
-	"hash":     &ffapi.Bytes32Field{},
-	"identity": &ffapi.UUIDField{},
-	"type":     &ffapi.StringField{},
-	"value":    &ffapi.StringField{},
-	"created":  &ffapi.TimeField{},
+	"hash":       &ffapi.Bytes32Field{},
+	"identity":   &ffapi.UUIDField{},
+	"type":       &ffapi.StringField{},
+	"value":      &ffapi.StringField{},
+	"created":    &ffapi.TimeField{},
+	"revoked":    &ffapi.TimeField{},
+	"revokedpin": &ffapi.Int64Field{},
 }
 
 // GroupQueryFactory filter fields for groups
@@ -978,23 +1261,27 @@ var TokenAccountPoolQueryFactory = &ffapi.QueryFields{
 
 // TokenTransferQueryFactory filter fields for token transfers
 var TokenTransferQueryFactory = &ffapi.QueryFields{
-	"localid":         &ffapi.StringField{},
-	"pool":            &ffapi.UUIDField{},
-	"tokenindex":      &ffapi.StringField{},
-	"uri":             &ffapi.StringField{},
-	"connector":       &ffapi.StringField{},
-	"key":             &ffapi.StringField{},
-	"from":            &ffapi.StringField{},
-	"to":              &ffapi.StringField{},
-	"amount":          &ffapi.Int64Field{},
-	"protocolid":      &ffapi.StringField{},
-	"message":         &ffapi.UUIDField{},
-	"messagehash":     &ffapi.Bytes32Field{},
-	"created":         &ffapi.TimeField{},
-	"tx.type":         &ffapi.StringField{},
-	"tx.id":           &ffapi.UUIDField{},
-	"blockchainevent": &ffapi.UUIDField{},
-	"type":            &ffapi.StringField{},
+	"sequence":         &ffapi.Int64Field{},
+	"localid":          &ffapi.StringField{},
+	"pool":             &ffapi.UUIDField{},
+	"tokenindex":       &ffapi.StringField{},
+	"uri":              &ffapi.StringField{},
+	"connector":        &ffapi.StringField{},
+	"key":              &ffapi.StringField{},
+	"from":             &ffapi.StringField{},
+	"to":               &ffapi.StringField{},
+	"amount":           &ffapi.Int64Field{},
+	"protocolid":       &ffapi.StringField{},
+	"message":          &ffapi.UUIDField{},
+	"messagehash":      &ffapi.Bytes32Field{},
+	"created":          &ffapi.TimeField{},
+	"tx.type":          &ffapi.StringField{},
+	"tx.id":            &ffapi.UUIDField{},
+	"blockchainevent":  &ffapi.UUIDField{},
+	"type":             &ffapi.StringField{},
+	"blocknumber":      &ffapi.Int64Field{},
+	"transactionindex": &ffapi.Int64Field{},
+	"logindex":         &ffapi.Int64Field{},
 }
 
 var TokenApprovalQueryFactory = &ffapi.QueryFields{
@@ -1081,9 +1368,22 @@ var BlockchainEventQueryFactory = &ffapi.QueryFields{
 
 // ContractAPIQueryFactory filter fields for Contract APIs
 var ContractAPIQueryFactory = &ffapi.QueryFields{
-	"id":          &ffapi.UUIDField{},
-	"name":        &ffapi.StringField{},
-	"networkname": &ffapi.StringField{},
-	"interface":   &ffapi.UUIDField{},
-	"published":   &ffapi.BoolField{},
+	"id":            &ffapi.UUIDField{},
+	"name":          &ffapi.StringField{},
+	"networkname":   &ffapi.StringField{},
+	"interface":     &ffapi.UUIDField{},
+	"published":     &ffapi.BoolField{},
+	"version":       &ffapi.StringField{},
+	"interfacehash": &ffapi.Bytes32Field{},
+}
+
+// SharedFFIQueryFactory filter fields for the shared interface registry
+var SharedFFIQueryFactory = &ffapi.QueryFields{
+	"id":        &ffapi.UUIDField{},
+	"namespace": &ffapi.StringField{},
+	"interface": &ffapi.UUIDField{},
+	"name":      &ffapi.StringField{},
+	"version":   &ffapi.StringField{},
+	"hash":      &ffapi.Bytes32Field{},
+	"created":   &ffapi.TimeField{},
 }
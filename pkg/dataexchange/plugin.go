@@ -70,12 +70,22 @@ type Plugin interface {
 	// If namespace is set, plugin will attempt to deliver only events for that namespace
 	SetOperationHandler(namespace string, handler core.OperationCallbacks)
 
+	// SetConnectionListener registers a listener to be notified when the connection to the runtime is lost or restored
+	SetConnectionListener(listener core.PluginConnectionListener)
+
+	// SetEventQueue registers a persistent queue to store events received from the runtime, so they can be acknowledged
+	// before they are processed. Without a queue, events are processed before they are acknowledged.
+	SetEventQueue(queue core.InboundEventQueue)
+
 	// Data exchange interface must not deliver any events until start is called
 	Start() error
 
 	// Capabilities returns capabilities - not called until after Init
 	Capabilities() *Capabilities
 
+	// CheckReady returns an error if the data exchange is not currently able to send and receive messages
+	CheckReady(ctx context.Context) error
+
 	// GetEndpointInfo returns the information about the local endpoint
 	GetEndpointInfo(ctx context.Context, nodeName string) (peer fftypes.JSONObject, err error)
 
@@ -96,11 +106,15 @@ type Plugin interface {
 	// Should return as quickly as possible for parallelism, then report completion asynchronously via the operation ID
 	SendMessage(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, data []byte) (err error)
 
-	// TransferBlob initiates a transfer of a previously stored blob to another node
-	TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string) (err error)
+	// TransferBlob initiates a transfer of a previously stored blob to another node.
+	// A non-zero offset resumes a previous transfer of the blob from that byte offset, as reported in the TransferProgress of that transfer
+	TransferBlob(ctx context.Context, nsOpID string, peer, sender fftypes.JSONObject, payloadRef string, offset int64) (err error)
 
 	// GetPeerID extracts the peer ID from the peer JSON
 	GetPeerID(peer fftypes.JSONObject) string
+
+	// GetPeerStatus reports whether the data exchange can currently reach the peer
+	GetPeerStatus(ctx context.Context, peer fftypes.JSONObject) (core.PeerStatus, error)
 }
 
 // Callbacks is the interface provided to the data exchange plugin, to allow it to pass events back to firefly.
@@ -127,17 +141,38 @@ const (
 )
 
 type MessageReceived struct {
-	PeerID    string
-	Transport *core.TransportWrapper
+	PeerID          string
+	CertFingerprint string // optional - only set if the connector reports the TLS certificate of the sending peer
+	Sequence        int64  // optional - only set if the connector numbers the messages from each peer, starting at 1, so duplicates and reordering can be detected
+	Transport       *core.TransportWrapper
 }
 
 type PrivateBlobReceived struct {
-	Namespace  string
-	PeerID     string
-	Hash       fftypes.Bytes32
-	Size       int64
-	PayloadRef string
-	DataID     string
+	Namespace       string
+	PeerID          string
+	CertFingerprint string // optional - only set if the connector reports the TLS certificate of the sending peer
+	Hash            fftypes.Bytes32
+	Size            int64
+	PayloadRef      string
+	DataID          string
+}
+
+// PeerCertFingerprintKey is the field in the peer JSON of a node, under which the SHA-256 fingerprint
+// of the TLS certificate of that peer is pinned when the node is registered
+const PeerCertFingerprintKey = "certFingerprint"
+
+// TransferProgressKey is the field in the output of a blob transfer operation, under which plugins report the TransferProgress
+const TransferProgressKey = "progress"
+
+// MaxExpandedTransportSize is the largest a compressed transport wrapper received from a peer is allowed to expand to
+const MaxExpandedTransportSize = 100 * 1024 * 1024
+
+// TransferProgress is the progress of a blob transfer, for plugins that report it
+type TransferProgress struct {
+	BytesSent    int64 `json:"bytesSent"`    // the number of bytes sent to the peer
+	ChunksAcked  int64 `json:"chunksAcked"`  // the number of chunks the peer has acknowledged
+	TotalBytes   int64 `json:"totalBytes"`   // the size of the blob
+	ResumeOffset int64 `json:"resumeOffset"` // the offset a retry of the transfer can resume from, up to which the peer has durably stored the blob
 }
 
 // Capabilities the supported featureset of the data exchange
@@ -145,4 +180,6 @@ type PrivateBlobReceived struct {
 type Capabilities struct {
 	// Manifest - whether TransferResult events contain the manifest generated by the receiving FireFly
 	Manifest bool
+	// PeerConnectivity - whether GetPeerStatus reports if registered peers are connected, rather than only if they are registered
+	PeerConnectivity bool
 }
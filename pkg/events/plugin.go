@@ -60,7 +60,7 @@ type Plugin interface {
 	NamespaceRestarted(ns string, startTime time.Time)
 }
 
-type SubscriptionMatcher func(core.SubscriptionRef) bool
+type SubscriptionMatcher func(sub *core.Subscription) bool
 
 type Callbacks interface {
 
@@ -0,0 +1,52 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keymanager
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/config"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Plugin is the interface implemented by each key manager plugin.
+//
+// A key manager sits in front of the blockchain plugin when keys supplied on API calls are resolved, so that
+// the keys used to sign can be governed by a policy (such as the set of keys held by a remote signer), and
+// can be referred to by alias rather than by their raw blockchain address.
+type Plugin interface {
+	core.Named
+
+	// InitConfig initializes the set of configuration options that are valid, with defaults. Called on all plugins.
+	InitConfig(config config.Section)
+
+	// Init initializes the plugin, with configuration
+	Init(ctx context.Context, config config.Section) error
+
+	// Capabilities returns capabilities - not called until after Init
+	Capabilities() *Capabilities
+
+	// ResolveKey maps a key supplied on an API call, which might be an alias, to the blockchain key that should be used.
+	// When the intent is to sign, an error is returned if the key manager does not permit signing with that key.
+	ResolveKey(ctx context.Context, keyRef string, intent blockchain.ResolveKeyIntent) (string, error)
+}
+
+// Capabilities the supported featureset of the key manager
+// interface implemented by the plugin, with the specified config
+type Capabilities struct {
+}
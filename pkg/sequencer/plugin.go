@@ -0,0 +1,56 @@
+// Copyright © 2024 Kaleido, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sequencer
+
+import (
+	"context"
+
+	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/pkg/blockchain"
+	"github.com/hyperledger/firefly/pkg/core"
+)
+
+// Plugin is the interface implemented by each sequencer. A sequencer establishes the single order in which
+// every member of a multi-party network processes batches of messages.
+//
+// Pinning batches to the blockchain is the default implementation. Other implementations allow a namespace
+// to use FireFly messaging semantics, with the ordering provided by another system.
+type Plugin interface {
+	core.Named
+
+	// SetHandler registers a handler to receive callbacks
+	SetHandler(handler Callbacks)
+
+	// Start begins delivering sequenced batches to the handler
+	Start() error
+
+	// WaitStop stops delivering sequenced batches, and waits for any delivery in progress to complete
+	WaitStop()
+
+	// SubmitBatchPin sequences a batch of messages. Every member of the network namespace is notified of
+	// the batch with a BatchPinComplete event, once its position in the sequence is final.
+	SubmitBatchPin(ctx context.Context, nsOpID, networkNamespace, signingKey string, batch *blockchain.BatchPin, location *fftypes.JSONAny) error
+}
+
+// Callbacks is the interface provided to the sequencer, to allow it to pass events back to firefly.
+//
+// Sequenced batches are delivered through the same path as batch pins from the blockchain, so that ordering,
+// checkpointing and replay protection are the same regardless of the sequencer.
+type Callbacks interface {
+	blockchain.Callbacks
+	core.OperationCallbacks
+}
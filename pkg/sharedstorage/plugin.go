@@ -46,10 +46,19 @@ type Plugin interface {
 
 	// DownloadData reads data back from IPFS using the payload reference format returned from UploadData
 	DownloadData(ctx context.Context, payloadRef string) (data io.ReadCloser, err error)
+
+	// PinData requests a remote pinning service to pin the data with the given payload reference.
+	// Only called when the Pinning capability is set.
+	PinData(ctx context.Context, payloadRef string) (pin *core.DataPin, err error)
+
+	// UnpinData removes a pin previously returned from PinData, when the data is deleted
+	UnpinData(ctx context.Context, pin *core.DataPin) error
 }
 
 type Callbacks interface {
 }
 
 type Capabilities struct {
+	// Pinning is true if uploaded data should be pinned with a remote pinning service
+	Pinning bool
 }
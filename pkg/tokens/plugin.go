@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/firefly-common/pkg/config"
 	"github.com/hyperledger/firefly-common/pkg/fftypes"
+	"github.com/hyperledger/firefly/internal/metrics"
 	"github.com/hyperledger/firefly/pkg/blockchain"
 	"github.com/hyperledger/firefly/pkg/core"
 )
@@ -33,7 +34,7 @@ type Plugin interface {
 	InitConfig(config config.Section)
 
 	// Init initializes the plugin, with configuration
-	Init(ctx context.Context, cancelCtx context.CancelFunc, name string, config config.Section) error
+	Init(ctx context.Context, cancelCtx context.CancelFunc, name string, config config.Section, metrics metrics.Manager) error
 
 	// SetHandler registers a handler to receive callbacks
 	// Plugin will attempt (but is not guaranteed) to deliver events only for the given namespace
@@ -43,6 +44,13 @@ type Plugin interface {
 	// If namespace is set, plugin will attempt to deliver only events for that namespace
 	SetOperationHandler(namespace string, handler core.OperationCallbacks)
 
+	// SetConnectionListener registers a listener to be notified when the connection to the runtime is lost or restored
+	SetConnectionListener(listener core.PluginConnectionListener)
+
+	// SetEventQueue registers a persistent queue to store events received from the runtime, so they can be acknowledged
+	// before they are processed. Without a queue, events are processed before they are acknowledged.
+	SetEventQueue(queue core.InboundEventQueue)
+
 	// StartNamespace starts a specific namespace within the plugin
 	StartNamespace(ctx context.Context, namespace string, tokenPools []*core.TokenPool) error
 
@@ -52,6 +60,9 @@ type Plugin interface {
 	// Capabilities returns capabilities - not called until after Init
 	Capabilities() *Capabilities
 
+	// CheckReady returns an error if the websocket to the tokens connector is not currently connected for any started namespace
+	CheckReady(ctx context.Context) error
+
 	// ConnectorName returns the configured connector name (plugin instance)
 	ConnectorName() string
 